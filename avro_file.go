@@ -0,0 +1,664 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"iter"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/google/uuid"
+	"github.com/hamba/avro/v2"
+	"github.com/hamba/avro/v2/ocf"
+)
+
+// AvroSchemaFingerprintKey is the file metadata key AvroFileWriter stores
+// the fingerprint of the file schema under, as returned by
+// AvroSchemaFingerprint.
+const AvroSchemaFingerprintKey = "avro-schema-fingerprint"
+
+// AvroSchemaFingerprint returns the CRC-64-AVRO fingerprint of the
+// Parsing Canonical Form of sc as a hex string. The canonical form
+// ignores properties such as Iceberg field IDs.
+func AvroSchemaFingerprint(sc avro.Schema) (string, error) {
+	fp, err := sc.FingerprintUsing(avro.CRC64Avro)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(fp), nil
+}
+
+// AvroReaderOption configures an AvroFileReader.
+type AvroReaderOption func(*avroReaderOptions)
+
+type avroReaderOptions struct {
+	projection *Schema
+}
+
+// WithAvroProjection sets the Iceberg schema records are read as by
+// AvroFileReader.Read. Fields are matched with the fields of the file by
+// ID, so renamed fields are read under their requested names and fields
+// missing from the file are null or their initial default. Values are
+// promoted to the requested types as PromoteType allows.
+func WithAvroProjection(sc *Schema) AvroReaderOption {
+	return func(o *avroReaderOptions) { o.projection = sc }
+}
+
+// AvroFileReader reads the values of an Avro object container file, such
+// as a manifest or manifest list, either as Go values decoded with the
+// schema of the file or as records of an Iceberg schema matched with the
+// file schema by field ID.
+type AvroFileReader struct {
+	rdr        *ocfReader
+	fileSchema *Schema
+	readSchema *Schema
+	proj       *avroProjection
+	projErr    error
+}
+
+// NewAvroFileReader reads the header of the Avro file in r. If the file
+// header holds a schema fingerprint, it must match the file schema.
+func NewAvroFileReader(r io.Reader, opts ...AvroReaderOption) (*AvroFileReader, error) {
+	var o avroReaderOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	rdr, err := newOCFReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &AvroFileReader{rdr: rdr}
+	if err := out.init(o); err != nil {
+		rdr.release()
+
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (r *AvroFileReader) init(o avroReaderOptions) error {
+	if fp, ok := r.rdr.Metadata()[AvroSchemaFingerprintKey]; ok {
+		expected, err := AvroSchemaFingerprint(r.rdr.Schema())
+		if err != nil {
+			return err
+		}
+		if string(fp) != expected {
+			return fmt.Errorf("%w: schema fingerprint %s does not match the file schema, %s",
+				errInvalidOCF, fp, expected)
+		}
+	}
+
+	var fileErr error
+	r.fileSchema, fileErr = avroSchemaToIceberg(r.rdr.Schema())
+
+	r.readSchema = o.projection
+	if r.readSchema == nil {
+		if fileErr != nil {
+			// values can still be decoded with Decode
+			r.projErr = fileErr
+
+			return nil
+		}
+		r.readSchema = r.fileSchema
+	} else {
+		if fileErr != nil {
+			return fmt.Errorf("cannot project file without Iceberg field IDs: %w", fileErr)
+		}
+
+		result, err := CheckCompatibility(r.readSchema, r.fileSchema)
+		if err != nil {
+			return err
+		}
+		if err := result.Err(); err != nil {
+			return err
+		}
+	}
+
+	st := r.readSchema.AsStruct()
+
+	var err error
+	r.proj, err = newAvroProjection(r.rdr.Schema(), &st)
+
+	return err
+}
+
+// Metadata returns the metadata of the file header.
+func (r *AvroFileReader) Metadata() map[string][]byte { return r.rdr.Metadata() }
+
+// Codec returns the name of the codec the blocks of the file are
+// compressed with, such as null, deflate, snappy or zstandard.
+func (r *AvroFileReader) Codec() string {
+	if r.rdr.codec == "" {
+		return string(ocf.Null)
+	}
+
+	return r.rdr.codec
+}
+
+// AvroSchema returns the Avro schema the file was written with.
+func (r *AvroFileReader) AvroSchema() avro.Schema { return r.rdr.Schema() }
+
+// FileSchema returns the Iceberg schema of the file, or nil if the file
+// schema does not carry Iceberg field IDs.
+func (r *AvroFileReader) FileSchema() *Schema { return r.fileSchema }
+
+// Schema returns the schema of the records returned by Read, the
+// projected schema if one was set and otherwise the file schema.
+func (r *AvroFileReader) Schema() *Schema { return r.readSchema }
+
+// HasNext reports whether there is another value to read.
+func (r *AvroFileReader) HasNext() bool { return r.rdr.HasNext() }
+
+// Decode decodes the next value with the schema of the file into v,
+// following the rules of the hamba/avro package. HasNext must be called
+// before every value.
+func (r *AvroFileReader) Decode(v any) error { return r.rdr.Decode(v) }
+
+// Read returns the next value as a record of Schema. Values use the Go
+// types described by Record; maps whose keys are binary or fixed use
+// string keys. HasNext must be called before every value.
+func (r *AvroFileReader) Read() (*Record, error) {
+	if r.projErr != nil {
+		return nil, r.projErr
+	}
+
+	var v any
+	if err := r.rdr.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	rec, err := r.proj.convert(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return rec.(*Record), nil
+}
+
+// All returns an iterator over the remaining records of the file, as
+// returned by Read, stopping at the first error.
+func (r *AvroFileReader) All() iter.Seq2[*Record, error] {
+	return func(yield func(*Record, error) bool) {
+		for r.HasNext() {
+			rec, err := r.Read()
+			if !yield(rec, err) || err != nil {
+				return
+			}
+		}
+
+		if err := r.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// Err returns the error that stopped reading the file, if any.
+func (r *AvroFileReader) Err() error { return r.rdr.Error() }
+
+// Close releases the buffers of the reader before the end of the file is
+// reached. It does not close the underlying reader.
+func (r *AvroFileReader) Close() { r.rdr.release() }
+
+// avroProjection converts values decoded with a file schema into the Go
+// values of a requested Iceberg type.
+type avroProjection struct {
+	read Type
+	// wrapped is set for values of unions of null and a named or complex
+	// type, which are decoded as a map from the type name to the value
+	wrapped bool
+
+	fields     []avroFieldProjection
+	elem       *avroProjection
+	key, value *avroProjection
+	// keyValue holds the names of the key and value fields of maps
+	// written as arrays of key/value records
+	keyValue [2]string
+}
+
+type avroFieldProjection struct {
+	name string
+	// the file does not hold the field if proj is nil
+	proj     *avroProjection
+	required bool
+	def      any
+}
+
+var avroStringSchema = avro.NewPrimitiveSchema(avro.String, nil)
+
+func newAvroProjection(sc avro.Schema, read Type) (*avroProjection, error) {
+	if ref, ok := sc.(*avro.RefSchema); ok {
+		sc = ref.Schema()
+	}
+
+	p := &avroProjection{read: read}
+	if u, ok := sc.(*avro.UnionSchema); ok {
+		for _, t := range u.Types() {
+			if t.Type() != avro.Null {
+				sc = t
+			}
+		}
+		if ref, ok := sc.(*avro.RefSchema); ok {
+			sc = ref.Schema()
+		}
+		_, primitive := sc.(*avro.PrimitiveSchema)
+		p.wrapped = !primitive
+	}
+
+	switch read := read.(type) {
+	case *StructType:
+		rec, ok := sc.(*avro.RecordSchema)
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot read %s as %s", ErrInvalidSchema, sc.Type(), read)
+		}
+
+		byID := make(map[int]*avro.Field, len(rec.Fields()))
+		for _, f := range rec.Fields() {
+			id, err := avroIDProp(f, "field "+f.Name(), "field-id")
+			if err != nil {
+				return nil, err
+			}
+			byID[id] = f
+		}
+
+		p.fields = make([]avroFieldProjection, len(read.FieldList))
+		for i, f := range read.FieldList {
+			fp := avroFieldProjection{name: f.Name, required: f.Required, def: f.InitialDefault}
+			if af, ok := byID[f.ID]; ok {
+				fp.name = af.Name()
+				proj, err := newAvroProjection(af.Type(), f.Type)
+				if err != nil {
+					return nil, fmt.Errorf("field %s: %w", f.Name, err)
+				}
+				fp.proj = proj
+			}
+			p.fields[i] = fp
+		}
+	case *ListType:
+		arr, ok := sc.(*avro.ArraySchema)
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot read %s as %s", ErrInvalidSchema, sc.Type(), read)
+		}
+
+		var err error
+		if p.elem, err = newAvroProjection(arr.Items(), read.Element); err != nil {
+			return nil, err
+		}
+	case *MapType:
+		var keySchema, valueSchema avro.Schema
+		switch s := sc.(type) {
+		case *avro.MapSchema:
+			keySchema, valueSchema = avroStringSchema, s.Values()
+		case *avro.ArraySchema:
+			rec, ok := s.Items().(*avro.RecordSchema)
+			if !ok || len(rec.Fields()) != 2 {
+				return nil, fmt.Errorf("%w: map array items must be a key/value record", ErrInvalidSchema)
+			}
+			k, v := rec.Fields()[0], rec.Fields()[1]
+			p.keyValue = [2]string{k.Name(), v.Name()}
+			keySchema, valueSchema = k.Type(), v.Type()
+		default:
+			return nil, fmt.Errorf("%w: cannot read %s as %s", ErrInvalidSchema, sc.Type(), read)
+		}
+
+		var err error
+		if p.key, err = newAvroProjection(keySchema, read.KeyType); err != nil {
+			return nil, err
+		}
+		if p.value, err = newAvroProjection(valueSchema, read.ValueType); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+func (p *avroProjection) convert(v any) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	if p.wrapped {
+		m, ok := v.(map[string]any)
+		if !ok || len(m) != 1 {
+			return nil, fmt.Errorf("%w: unexpected union value %T", errInvalidOCF, v)
+		}
+		for _, inner := range m {
+			v = inner
+		}
+	}
+
+	switch read := p.read.(type) {
+	case *StructType:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot read %T as %s", ErrInvalidSchema, v, read)
+		}
+
+		values := make([]any, len(p.fields))
+		for i, f := range p.fields {
+			if f.proj == nil {
+				values[i] = f.def
+
+				continue
+			}
+
+			val, err := f.proj.convert(m[f.name])
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", read.FieldList[i].Name, err)
+			}
+			if val == nil && f.required {
+				return nil, fmt.Errorf("%w: required field %s is null",
+					ErrInvalidSchema, read.FieldList[i].Name)
+			}
+			values[i] = val
+		}
+
+		return NewRecord(read, values...), nil
+	case *ListType:
+		items, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot read %T as %s", ErrInvalidSchema, v, read)
+		}
+
+		out := make([]any, len(items))
+		for i, item := range items {
+			val, err := p.elem.convert(item)
+			if err != nil {
+				return nil, err
+			}
+			if val == nil && read.ElementRequired {
+				return nil, fmt.Errorf("%w: required element is null", ErrInvalidSchema)
+			}
+			out[i] = val
+		}
+
+		return out, nil
+	case *MapType:
+		out := make(map[any]any)
+		put := func(k, val any) error {
+			key, err := p.key.convert(k)
+			if err != nil {
+				return err
+			}
+			value, err := p.value.convert(val)
+			if err != nil {
+				return err
+			}
+			if value == nil && read.ValueRequired {
+				return fmt.Errorf("%w: required map value is null", ErrInvalidSchema)
+			}
+
+			switch key := key.(type) {
+			case nil:
+				return fmt.Errorf("%w: map key is null", ErrInvalidSchema)
+			case []byte:
+				out[string(key)] = value
+			case *Record:
+				return fmt.Errorf("%w: maps with struct keys", ErrNotImplemented)
+			default:
+				out[key] = value
+			}
+
+			return nil
+		}
+
+		switch m := v.(type) {
+		case map[string]any:
+			for k, val := range m {
+				if err := put(k, val); err != nil {
+					return nil, err
+				}
+			}
+		case []any:
+			for _, item := range m {
+				kv, ok := item.(map[string]any)
+				if !ok {
+					return nil, fmt.Errorf("%w: cannot read %T as a map entry", ErrInvalidSchema, item)
+				}
+				if err := put(kv[p.keyValue[0]], kv[p.keyValue[1]]); err != nil {
+					return nil, err
+				}
+			}
+		default:
+			return nil, fmt.Errorf("%w: cannot read %T as %s", ErrInvalidSchema, v, read)
+		}
+
+		return out, nil
+	default:
+		return avroPrimitiveValue(v, read)
+	}
+}
+
+// avroPrimitiveValue converts a primitive value decoded by hamba/avro to
+// the Go type of t, applying type promotions.
+func avroPrimitiveValue(v any, t Type) (any, error) {
+	switch t := t.(type) {
+	case BooleanType:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case Int32Type:
+		if i, ok := v.(int); ok {
+			return int32(i), nil
+		}
+	case Int64Type:
+		switch i := v.(type) {
+		case int:
+			return int64(i), nil
+		case int64:
+			return i, nil
+		}
+	case Float32Type:
+		if f, ok := v.(float32); ok {
+			return f, nil
+		}
+	case Float64Type:
+		switch f := v.(type) {
+		case float32:
+			return float64(f), nil
+		case float64:
+			return f, nil
+		}
+	case StringType:
+		switch s := v.(type) {
+		case string:
+			return s, nil
+		case []byte:
+			return string(s), nil
+		}
+	case BinaryType:
+		switch b := v.(type) {
+		case []byte:
+			return b, nil
+		case string:
+			return []byte(b), nil
+		}
+	case FixedType:
+		if b, ok := avroFixedBytes(v); ok {
+			return b, nil
+		}
+	case UUIDType:
+		switch u := v.(type) {
+		case string:
+			return uuid.Parse(u)
+		default:
+			if b, ok := avroFixedBytes(v); ok && len(b) == 16 {
+				return uuid.UUID(b), nil
+			}
+		}
+	case DateType:
+		switch d := v.(type) {
+		case time.Time:
+			return Date(d.Unix() / int64((24 * time.Hour).Seconds())), nil
+		case int:
+			return Date(d), nil
+		}
+	case TimeType:
+		switch d := v.(type) {
+		case time.Duration:
+			return Time(d.Microseconds()), nil
+		case int64:
+			return Time(d), nil
+		}
+	case TimestampType, TimestampTzType:
+		switch ts := v.(type) {
+		case time.Time:
+			return Timestamp(ts.UnixMicro()), nil
+		case int64:
+			return Timestamp(ts), nil
+		}
+	case TimestampNsType, TimestampTzNsType:
+		switch ts := v.(type) {
+		case time.Time:
+			return TimestampNano(ts.UnixNano()), nil
+		case int64:
+			return TimestampNano(ts), nil
+		}
+	case DecimalType:
+		if r, ok := v.(*big.Rat); ok {
+			unscaled := new(big.Int).Mul(r.Num(),
+				new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(t.scale)), nil))
+			unscaled.Quo(unscaled, r.Denom())
+
+			return Decimal{Val: decimal128.FromBigInt(unscaled), Scale: t.scale}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: cannot read %T as %s", ErrInvalidSchema, v, t)
+}
+
+// avroFixedBytes returns the bytes of a fixed value, which hamba/avro
+// decodes as a byte array.
+func avroFixedBytes(v any) ([]byte, bool) {
+	if b, ok := v.([]byte); ok {
+		return b, true
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Array || rv.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, false
+	}
+
+	b := make([]byte, rv.Len())
+	reflect.Copy(reflect.ValueOf(b), rv)
+
+	return b, true
+}
+
+// AvroWriterOption configures an AvroFileWriter.
+type AvroWriterOption func(*avroWriterOptions)
+
+type avroWriterOptions struct {
+	compression manifestWriterOptions
+	meta        map[string][]byte
+	blockLength int
+}
+
+// WithAvroCompression sets the codec the blocks of the file are
+// compressed with, one of the AvroCodec constants, gzip by default. A
+// negative level uses the default level of the codec.
+func WithAvroCompression(codec string, level int) AvroWriterOption {
+	return func(o *avroWriterOptions) {
+		o.compression.codec, o.compression.level = codec, level
+	}
+}
+
+// WithAvroMetadata adds a key to the metadata of the file header.
+func WithAvroMetadata(key string, value []byte) AvroWriterOption {
+	return func(o *avroWriterOptions) { o.meta[key] = value }
+}
+
+// WithAvroBlockLength sets the number of values written per block.
+func WithAvroBlockLength(n int) AvroWriterOption {
+	return func(o *avroWriterOptions) { o.blockLength = n }
+}
+
+// AvroFileWriter writes values to an Avro object container file. The
+// full file schema, including Iceberg field IDs and other properties, is
+// stored in the file header along with its fingerprint under
+// AvroSchemaFingerprintKey.
+type AvroFileWriter struct {
+	enc         *ocf.Encoder
+	schema      avro.Schema
+	fingerprint string
+}
+
+// NewAvroFileWriter writes the header of an Avro file with schema sc to
+// w. Schemas of Iceberg tables can be converted with SchemaToAvroSchema.
+func NewAvroFileWriter(w io.Writer, sc avro.Schema, opts ...AvroWriterOption) (*AvroFileWriter, error) {
+	o := avroWriterOptions{
+		compression: manifestWriterOptions{codec: AvroCodecGzip, level: -1},
+		meta:        make(map[string][]byte),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	encOpts, err := o.compression.encoderOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	fp, err := AvroSchemaFingerprint(sc)
+	if err != nil {
+		return nil, err
+	}
+	o.meta[AvroSchemaFingerprintKey] = []byte(fp)
+
+	encOpts = append(encOpts,
+		ocf.WithSchemaMarshaler(ocf.FullSchemaMarshaler),
+		ocf.WithEncoderSchemaCache(&avro.SchemaCache{}),
+		ocf.WithMetadata(o.meta))
+	if o.blockLength > 0 {
+		encOpts = append(encOpts, ocf.WithBlockLength(o.blockLength))
+	}
+
+	enc, err := ocf.NewEncoderWithSchema(sc, w, encOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AvroFileWriter{enc: enc, schema: sc, fingerprint: fp}, nil
+}
+
+// Schema returns the schema of the file.
+func (w *AvroFileWriter) Schema() avro.Schema { return w.schema }
+
+// Fingerprint returns the fingerprint of the file schema, as returned by
+// AvroSchemaFingerprint.
+func (w *AvroFileWriter) Fingerprint() string { return w.fingerprint }
+
+// Encode appends v to the file, following the rules of the hamba/avro
+// package.
+func (w *AvroFileWriter) Encode(v any) error { return w.enc.Encode(v) }
+
+// Flush writes the values encoded so far as a block.
+func (w *AvroFileWriter) Flush() error { return w.enc.Flush() }
+
+// Close flushes the remaining values. It does not close the underlying
+// writer.
+func (w *AvroFileWriter) Close() error { return w.enc.Close() }
@@ -0,0 +1,231 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/hamba/avro/v2/ocf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var avroFileTestSchema = NewSchema(0,
+	NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.Int32, Required: true},
+	NestedField{ID: 2, Name: "name", Type: PrimitiveTypes.String},
+	NestedField{ID: 3, Name: "ts", Type: PrimitiveTypes.TimestampTz},
+	NestedField{ID: 4, Name: "tags", Type: &ListType{
+		ElementID: 5, Element: PrimitiveTypes.String, ElementRequired: true,
+	}},
+	NestedField{ID: 6, Name: "props", Type: &MapType{
+		KeyID: 7, KeyType: PrimitiveTypes.String,
+		ValueID: 8, ValueType: PrimitiveTypes.Float32, ValueRequired: true,
+	}},
+)
+
+type avroFileTestRecord struct {
+	ID    int32               `avro:"id"`
+	Name  *string             `avro:"name"`
+	TS    *time.Time          `avro:"ts"`
+	Tags  *[]string           `avro:"tags"`
+	Props *map[string]float32 `avro:"props"`
+}
+
+func writeAvroFileTest(t *testing.T, opts ...AvroWriterOption) []byte {
+	t.Helper()
+
+	sc, err := SchemaToAvroSchema(avroFileTestSchema)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w, err := NewAvroFileWriter(&buf, sc, opts...)
+	require.NoError(t, err)
+
+	name, ts := "a", time.UnixMicro(1_700_000_000_000_000).UTC()
+	tags, props := []string{"x", "y"}, map[string]float32{"p": 0.5}
+	require.NoError(t, w.Encode(avroFileTestRecord{ID: 1, Name: &name, TS: &ts, Tags: &tags, Props: &props}))
+	require.NoError(t, w.Encode(avroFileTestRecord{ID: 2}))
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func TestAvroFileRoundTrip(t *testing.T) {
+	codecs := map[string]string{
+		AvroCodecGzip: "deflate", AvroCodecZstd: "zstandard",
+		AvroCodecSnappy: "snappy", AvroCodecUncompressed: "null",
+	}
+
+	for codec, fileCodec := range codecs {
+		t.Run(codec, func(t *testing.T) {
+			data := writeAvroFileTest(t, WithAvroCompression(codec, -1),
+				WithAvroMetadata("key", []byte("value")), WithAvroBlockLength(1))
+
+			rdr, err := NewAvroFileReader(bytes.NewReader(data))
+			require.NoError(t, err)
+			defer rdr.Close()
+
+			assert.Equal(t, fileCodec, rdr.Codec())
+			assert.Equal(t, []byte("value"), rdr.Metadata()["key"])
+			fp, err := AvroSchemaFingerprint(rdr.AvroSchema())
+			require.NoError(t, err)
+			assert.Equal(t, fp, string(rdr.Metadata()[AvroSchemaFingerprintKey]))
+			assert.True(t, rdr.FileSchema().Equals(avroFileTestSchema))
+			assert.Same(t, rdr.FileSchema(), rdr.Schema())
+
+			var got [][]any
+			for rec, err := range rdr.All() {
+				require.NoError(t, err)
+				got = append(got, rec.Values())
+			}
+			assert.Equal(t, [][]any{
+				{int32(1), "a", Timestamp(1_700_000_000_000_000), []any{"x", "y"}, map[any]any{"p": float32(0.5)}},
+				{int32(2), nil, nil, nil, nil},
+			}, got)
+		})
+	}
+}
+
+func TestAvroFileProjection(t *testing.T) {
+	data := writeAvroFileTest(t)
+
+	projection := NewSchema(1,
+		NestedField{ID: 6, Name: "props", Type: &MapType{
+			KeyID: 7, KeyType: PrimitiveTypes.String,
+			ValueID: 8, ValueType: PrimitiveTypes.Float64, ValueRequired: true,
+		}},
+		NestedField{ID: 2, Name: "full_name", Type: PrimitiveTypes.String},
+		NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.Int64, Required: true},
+		NestedField{ID: 9, Name: "added", Type: PrimitiveTypes.Int32},
+		NestedField{ID: 10, Name: "with_default", Type: PrimitiveTypes.Int32, Required: true, InitialDefault: int32(7)},
+	)
+
+	rdr, err := NewAvroFileReader(bytes.NewReader(data), WithAvroProjection(projection))
+	require.NoError(t, err)
+	assert.Same(t, projection, rdr.Schema())
+
+	require.True(t, rdr.HasNext())
+	rec, err := rdr.Read()
+	require.NoError(t, err)
+	assert.Equal(t, []any{map[any]any{"p": float64(0.5)}, "a", int64(1), nil, int32(7)}, rec.Values())
+	name, _ := rec.Field("full_name")
+	assert.Equal(t, "a", name)
+
+	require.True(t, rdr.HasNext())
+	rec, err = rdr.Read()
+	require.NoError(t, err)
+	assert.Equal(t, []any{nil, nil, int64(2), nil, int32(7)}, rec.Values())
+
+	assert.False(t, rdr.HasNext())
+	require.NoError(t, rdr.Err())
+
+	t.Run("missing required field", func(t *testing.T) {
+		_, err := NewAvroFileReader(bytes.NewReader(data), WithAvroProjection(NewSchema(1,
+			NestedField{ID: 11, Name: "missing", Type: PrimitiveTypes.Int32, Required: true})))
+		assert.ErrorIs(t, err, ErrInvalidSchema)
+	})
+
+	t.Run("incompatible type", func(t *testing.T) {
+		_, err := NewAvroFileReader(bytes.NewReader(data), WithAvroProjection(NewSchema(1,
+			NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.Date, Required: true})))
+		assert.ErrorIs(t, err, ErrInvalidSchema)
+	})
+}
+
+func TestAvroFilePositionDeletes(t *testing.T) {
+	sc, err := SchemaToAvroSchema(PositionalDeleteSchema)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w, err := NewAvroFileWriter(&buf, sc)
+	require.NoError(t, err)
+	require.NoError(t, w.Encode(map[string]any{"file_path": "s3://bucket/data/a.parquet", "pos": int64(3)}))
+	require.NoError(t, w.Close())
+
+	rdr, err := NewAvroFileReader(bytes.NewReader(buf.Bytes()), WithAvroProjection(PositionalDeleteSchema))
+	require.NoError(t, err)
+	defer rdr.Close()
+
+	require.True(t, rdr.HasNext())
+	rec, err := rdr.Read()
+	require.NoError(t, err)
+	assert.Equal(t, []any{"s3://bucket/data/a.parquet", int64(3)}, rec.Values())
+}
+
+func TestAvroFileWithoutFieldIDs(t *testing.T) {
+	data := writeOCFTestFile(t, ocf.Null, 3)
+
+	_, err := NewAvroFileReader(bytes.NewReader(data), WithAvroProjection(avroFileTestSchema))
+	assert.ErrorIs(t, err, ErrInvalidSchema)
+
+	rdr, err := NewAvroFileReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Nil(t, rdr.FileSchema())
+
+	require.True(t, rdr.HasNext())
+	_, err = rdr.Read()
+	assert.ErrorIs(t, err, ErrInvalidSchema)
+
+	var rec ocfTestRecord
+	require.NoError(t, rdr.Decode(&rec))
+	assert.Equal(t, "name-0", rec.Name)
+}
+
+func TestAvroFileFingerprintMismatch(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := ocf.NewEncoder(ocfTestSchema, &buf,
+		ocf.WithMetadataKeyVal(AvroSchemaFingerprintKey, []byte("0000000000000000")))
+	require.NoError(t, err)
+	require.NoError(t, enc.Close())
+
+	_, err = NewAvroFileReader(&buf)
+	assert.ErrorIs(t, err, errInvalidOCF)
+	assert.ErrorContains(t, err, "fingerprint")
+}
+
+func TestAvroFileWriterInvalidCodec(t *testing.T) {
+	sc, err := SchemaToAvroSchema(avroFileTestSchema)
+	require.NoError(t, err)
+
+	_, err = NewAvroFileWriter(&bytes.Buffer{}, sc, WithAvroCompression("lz4", -1))
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestAvroFileReadManifestList(t *testing.T) {
+	var buf bytes.Buffer
+	seqNum := int64(1)
+	require.NoError(t, WriteManifestList(2, &buf, 1, nil, &seqNum, 0, []ManifestFile{
+		NewManifestFile(2, "s3://bucket/m1.avro", 100, 0, 1).Build(),
+	}))
+
+	// read the path and length of the manifests, by their field IDs
+	rdr, err := NewAvroFileReader(&buf, WithAvroProjection(NewSchema(0,
+		NestedField{ID: 500, Name: "path", Type: PrimitiveTypes.String, Required: true},
+		NestedField{ID: 501, Name: "length", Type: PrimitiveTypes.Int64, Required: true},
+	)))
+	require.NoError(t, err)
+
+	var got [][]any
+	for rec, err := range rdr.All() {
+		require.NoError(t, err)
+		got = append(got, rec.Values())
+	}
+	assert.Equal(t, [][]any{{"s3://bucket/m1.avro", int64(100)}}, got)
+}
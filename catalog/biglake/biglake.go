@@ -0,0 +1,209 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package biglake provides a catalog backed by Google BigLake Metastore
+// through its Iceberg REST endpoint, and registration of its tables in
+// BigQuery as external Iceberg tables.
+package biglake
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/catalog/rest"
+	"github.com/apache/iceberg-go/table"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	DefaultEndpoint         = "https://biglake.googleapis.com/iceberg/v1beta/restcatalog"
+	DefaultBigQueryEndpoint = "https://bigquery.googleapis.com/bigquery/v2"
+
+	// ProjectID is the catalog property holding the Google Cloud project
+	// that is billed for requests. The warehouse, a gs:// location or a
+	// bq://projects/<project> catalog, is read from "warehouse".
+	ProjectID = "biglake.project-id"
+	Endpoint  = "biglake.endpoint"
+	KeyPath   = "biglake.keypath"
+	JSONKey   = "biglake.jsonkey"
+
+	cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+)
+
+// ErrBigQuery is returned when BigQuery rejects a table registration.
+var ErrBigQuery = errors.New("bigquery error")
+
+func init() {
+	catalog.Register(string(catalog.BigLake), catalog.RegistrarFunc(func(ctx context.Context, name string, props iceberg.Properties) (catalog.Catalog, error) {
+		opts := []Option{WithRESTOptions(rest.WithAdditionalProps(props))}
+		if ep := props.Get(Endpoint, ""); ep != "" {
+			opts = append(opts, WithEndpoint(ep))
+		}
+
+		key := []byte(props.Get(JSONKey, ""))
+		if path := props.Get(KeyPath, ""); path != "" {
+			var err error
+			if key, err = os.ReadFile(path); err != nil {
+				return nil, err
+			}
+		}
+		if len(key) > 0 {
+			creds, err := google.CredentialsFromJSON(ctx, key, cloudPlatformScope)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, WithTokenSource(creds.TokenSource))
+		}
+
+		return NewCatalog(ctx, name, props.Get(ProjectID, ""), props.Get("warehouse", ""), opts...)
+	}))
+}
+
+// Catalog is a BigLake Metastore catalog. Catalog operations go through
+// the Iceberg REST endpoint of BigLake.
+type Catalog struct {
+	*rest.Catalog
+
+	project  string
+	bigQuery *url.URL
+	client   *http.Client
+}
+
+var _ catalog.Catalog = (*Catalog)(nil)
+
+// NewCatalog returns a catalog for the given warehouse, billing
+// requests to project.
+func NewCatalog(ctx context.Context, name, project, warehouse string, opts ...Option) (*Catalog, error) {
+	if project == "" {
+		return nil, fmt.Errorf("%w: biglake catalog requires a project id", iceberg.ErrInvalidArgument)
+	}
+	if warehouse == "" {
+		return nil, fmt.Errorf("%w: biglake catalog requires a warehouse", iceberg.ErrInvalidArgument)
+	}
+
+	o := options{endpoint: DefaultEndpoint, bigQueryEndpoint: DefaultBigQueryEndpoint}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.tokenSource == nil {
+		var err error
+		if o.tokenSource, err = google.DefaultTokenSource(ctx, cloudPlatformScope); err != nil {
+			return nil, err
+		}
+	}
+
+	bq, err := url.Parse(strings.TrimSuffix(o.bigQueryEndpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid bigquery endpoint: %w", iceberg.ErrInvalidArgument, err)
+	}
+
+	transport := &oauth2.Transport{Source: o.tokenSource, Base: http.DefaultTransport}
+	restOpts := append([]rest.Option{
+		rest.WithWarehouseLocation(warehouse),
+		rest.WithCustomTransport(transport),
+		rest.WithHeaders(map[string]string{"x-goog-user-project": project}),
+	}, o.restOptions...)
+
+	restCat, err := rest.NewCatalog(ctx, name, o.endpoint, restOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Catalog{
+		Catalog:  restCat,
+		project:  project,
+		bigQuery: bq,
+		client:   &http.Client{Transport: transport},
+	}, nil
+}
+
+func (c *Catalog) CatalogType() catalog.Type { return catalog.BigLake }
+
+// RegisterBigQueryTable makes tbl queryable from BigQuery as the external
+// Iceberg table dataset.name in the catalog's project, pointing at the
+// table's current metadata file. If the BigQuery table already exists it
+// is updated to the current metadata file, so this can be called again
+// after each commit.
+func (c *Catalog) RegisterBigQueryTable(ctx context.Context, tbl *table.Table, dataset, name string) error {
+	external := map[string]any{
+		"sourceFormat": "ICEBERG",
+		"sourceUris":   []string{tbl.MetadataLocation()},
+	}
+
+	tables := c.bigQuery.JoinPath("projects", c.project, "datasets", dataset, "tables")
+	status, err := c.do(ctx, http.MethodPost, tables, map[string]any{
+		"tableReference": map[string]string{
+			"projectId": c.project, "datasetId": dataset, "tableId": name,
+		},
+		"externalDataConfiguration": external,
+	})
+	if status != http.StatusConflict {
+		return err
+	}
+
+	_, err = c.do(ctx, http.MethodPatch, tables.JoinPath(name),
+		map[string]any{"externalDataConfiguration": external})
+
+	return err
+}
+
+func (c *Catalog) do(ctx context.Context, method string, u *url.URL, body any) (int, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-goog-user-project", c.project)
+
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode >= 300 {
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		data, _ := io.ReadAll(rsp.Body)
+		_ = json.Unmarshal(data, &apiErr)
+
+		return rsp.StatusCode, fmt.Errorf("%w: %s %s: %s: %s",
+			ErrBigQuery, method, u.Path, rsp.Status, apiErr.Error.Message)
+	}
+
+	return rsp.StatusCode, nil
+}
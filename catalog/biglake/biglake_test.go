@@ -0,0 +1,121 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package biglake_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/catalog/biglake"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestCatalog(t *testing.T) {
+	var (
+		warehouse string
+		requests  []string
+		bodies    []map[string]any
+		exists    bool
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "Bearer test-token", req.Header.Get("Authorization"))
+		assert.Equal(t, "my-project", req.Header.Get("x-goog-user-project"))
+		requests = append(requests, req.Method+" "+req.URL.Path)
+
+		switch req.URL.Path {
+		case "/iceberg/v1/config":
+			warehouse = req.URL.Query().Get("warehouse")
+			json.NewEncoder(w).Encode(map[string]any{"defaults": map[string]any{}, "overrides": map[string]any{}})
+		case "/bq/projects/my-project/datasets/analytics/tables":
+			if exists {
+				w.WriteHeader(http.StatusConflict)
+				json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"message": "Already Exists"}})
+
+				return
+			}
+			fallthrough
+		case "/bq/projects/my-project/datasets/analytics/tables/orders":
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			bodies = append(bodies, body)
+			json.NewEncoder(w).Encode(body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"message": "Not found: " + req.URL.Path}})
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	ctx := context.Background()
+	_, err := biglake.NewCatalog(ctx, "biglake", "", "gs://bucket/warehouse")
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+	cat, err := biglake.NewCatalog(ctx, "biglake", "my-project", "gs://bucket/warehouse",
+		biglake.WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "test-token"})),
+		biglake.WithEndpoint(srv.URL+"/iceberg"),
+		biglake.WithBigQueryEndpoint(srv.URL+"/bq"))
+	require.NoError(t, err)
+	assert.Equal(t, catalog.BigLake, cat.CatalogType())
+	assert.Equal(t, "gs://bucket/warehouse", warehouse)
+
+	meta, err := table.NewMetadata(iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true}),
+		iceberg.UnpartitionedSpec, table.UnsortedSortOrder, "gs://bucket/warehouse/sales/orders", nil)
+	require.NoError(t, err)
+	tbl := table.New(table.Identifier{"sales", "orders"}, meta,
+		"gs://bucket/warehouse/sales/orders/metadata/00001.metadata.json", nil, cat)
+
+	require.NoError(t, cat.RegisterBigQueryTable(ctx, tbl, "analytics", "orders"))
+	require.Len(t, bodies, 1)
+	assert.Equal(t, map[string]any{
+		"tableReference": map[string]any{
+			"projectId": "my-project", "datasetId": "analytics", "tableId": "orders",
+		},
+		"externalDataConfiguration": map[string]any{
+			"sourceFormat": "ICEBERG",
+			"sourceUris":   []any{"gs://bucket/warehouse/sales/orders/metadata/00001.metadata.json"},
+		},
+	}, bodies[0])
+
+	// registering again updates the existing table
+	exists = true
+	require.NoError(t, cat.RegisterBigQueryTable(ctx, tbl, "analytics", "orders"))
+	require.Len(t, bodies, 2)
+	assert.Equal(t, map[string]any{
+		"externalDataConfiguration": map[string]any{
+			"sourceFormat": "ICEBERG",
+			"sourceUris":   []any{"gs://bucket/warehouse/sales/orders/metadata/00001.metadata.json"},
+		},
+	}, bodies[1])
+	assert.Equal(t, "PATCH /bq/projects/my-project/datasets/analytics/tables/orders", requests[len(requests)-1])
+
+	err = cat.RegisterBigQueryTable(ctx, tbl, "missing", "orders")
+	assert.ErrorIs(t, err, biglake.ErrBigQuery)
+	assert.ErrorContains(t, err, "Not found")
+}
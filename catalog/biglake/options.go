@@ -0,0 +1,65 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package biglake
+
+import (
+	"github.com/apache/iceberg-go/catalog/rest"
+	"golang.org/x/oauth2"
+)
+
+type Option func(*options)
+
+// WithTokenSource sets the source of the OAuth2 tokens used to
+// authenticate to BigLake and BigQuery. By default the application
+// default credentials are used.
+func WithTokenSource(ts oauth2.TokenSource) Option {
+	return func(o *options) {
+		o.tokenSource = ts
+	}
+}
+
+// WithEndpoint overrides DefaultEndpoint, the BigLake Iceberg REST
+// catalog endpoint.
+func WithEndpoint(endpoint string) Option {
+	return func(o *options) {
+		o.endpoint = endpoint
+	}
+}
+
+// WithBigQueryEndpoint overrides DefaultBigQueryEndpoint, the BigQuery
+// API used to register external tables.
+func WithBigQueryEndpoint(endpoint string) Option {
+	return func(o *options) {
+		o.bigQueryEndpoint = endpoint
+	}
+}
+
+// WithRESTOptions passes additional options to the underlying REST
+// catalog, for example rest.WithAdditionalProps for FileIO properties.
+func WithRESTOptions(opts ...rest.Option) Option {
+	return func(o *options) {
+		o.restOptions = append(o.restOptions, opts...)
+	}
+}
+
+type options struct {
+	tokenSource      oauth2.TokenSource
+	endpoint         string
+	bigQueryEndpoint string
+	restOptions      []rest.Option
+}
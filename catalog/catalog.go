@@ -37,22 +37,27 @@ import (
 
 	"github.com/apache/iceberg-go"
 	iceinternal "github.com/apache/iceberg-go/internal"
+	iceio "github.com/apache/iceberg-go/io"
 	"github.com/apache/iceberg-go/table"
 )
 
 type Type string
 
 const (
-	REST     Type = "rest"
-	Hive     Type = "hive"
-	Glue     Type = "glue"
-	DynamoDB Type = "dynamodb"
-	SQL      Type = "sql"
+	REST      Type = "rest"
+	Hive      Type = "hive"
+	Glue      Type = "glue"
+	DynamoDB  Type = "dynamodb"
+	SQL       Type = "sql"
+	S3Tables  Type = "s3tables"
+	BigLake   Type = "biglake"
+	Snowflake Type = "snowflake"
+	Unity     Type = "unity"
 )
 
 var (
 	// ErrNoSuchTable is returned when a table does not exist in the catalog.
-	ErrNoSuchTable            = errors.New("table does not exist")
+	ErrNoSuchTable            = iceberg.ErrNoSuchTable
 	ErrNoSuchNamespace        = errors.New("namespace does not exist")
 	ErrNamespaceAlreadyExists = errors.New("namespace already exists")
 	ErrTableAlreadyExists     = errors.New("table already exists")
@@ -60,6 +65,11 @@ var (
 	ErrNamespaceNotEmpty      = errors.New("namespace is not empty")
 	ErrNoSuchView             = errors.New("view does not exist")
 	ErrViewAlreadyExists      = errors.New("view already exists")
+
+	// ErrHierarchicalNamespaceUnsupported is returned by catalogs whose
+	// namespaces map onto a flat list of databases when given a nested
+	// namespace.
+	ErrHierarchicalNamespaceUnsupported = errors.New("hierarchical namespace is not supported")
 )
 
 type PropertiesUpdateSummary struct {
@@ -142,12 +152,95 @@ type Catalog interface {
 		removals []string, updates iceberg.Properties) (PropertiesUpdateSummary, error)
 }
 
+// TransactionalCatalog is implemented by catalogs which can atomically
+// commit changes to multiple tables at once.
+type TransactionalCatalog interface {
+	Catalog
+
+	// CommitTransaction commits the changes to all of the given tables
+	// atomically: either every commit is applied or none of them are.
+	CommitTransaction(ctx context.Context, commits ...table.TableCommit) error
+}
+
+// RegisteringCatalog is implemented by catalogs which can register an
+// existing metadata file as a new table.
+type RegisteringCatalog interface {
+	Catalog
+
+	// RegisterTable creates a table in the catalog whose current metadata
+	// is the metadata file at metadataLocation.
+	RegisterTable(ctx context.Context, identifier table.Identifier, metadataLocation string) (*table.Table, error)
+}
+
+// TableInfo describes a table returned by a paginated table listing.
+type TableInfo struct {
+	Identifier table.Identifier
+	// MetadataLocation is the location of the current metadata file of the
+	// table. It is only set when requested with WithMetadataLocation, and
+	// left empty by catalogs which do not return it when listing tables.
+	MetadataLocation string
+}
+
+// PaginatedCatalog is implemented by catalogs which can list tables and
+// namespaces a page at a time, such as to back a catalog browser.
+type PaginatedCatalog interface {
+	Catalog
+
+	// ListTablesPage returns a page of at most pageSize tables in namespace
+	// along with the token of the next page. The token is empty for the
+	// first page and is returned empty after the last page. A pageSize of
+	// zero or less uses the default page size of the catalog.
+	ListTablesPage(ctx context.Context, namespace table.Identifier, pageToken string, pageSize int, opts ...ListTablesOpt) ([]TableInfo, string, error)
+	// ListNamespacesPage returns a page of the namespaces under parent in
+	// the same way as ListTablesPage.
+	ListNamespacesPage(ctx context.Context, parent table.Identifier, pageToken string, pageSize int) ([]table.Identifier, string, error)
+}
+
+// StagingCatalog is implemented by catalogs which can stage the creation of
+// a table, deferring it until a transaction is committed.
+type StagingCatalog interface {
+	Catalog
+
+	// StageCreateTable returns a transaction which creates the table when
+	// committed, together with any changes staged in the transaction such
+	// as appending data. The table does not exist in the catalog until then.
+	StageCreateTable(ctx context.Context, identifier table.Identifier, schema *iceberg.Schema, opts ...CreateTableOpt) (*table.Transaction, error)
+}
+
+// CloneTable exports a snapshot of src to location using table.Export and
+// registers the copy in cat as identifier. Restoring a backup made with
+// table.Export is done the same way, using a static table loaded from the
+// backup's metadata file as src.
+func CloneTable(ctx context.Context, cat Catalog, identifier table.Identifier, src *table.Table,
+	location string, fs iceio.WriteFileIO, opts ...table.ExportOption,
+) (*table.Table, error) {
+	reg, ok := cat.(RegisteringCatalog)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s catalog does not support registering tables",
+			iceberg.ErrNotImplemented, cat.CatalogType())
+	}
+
+	res, err := src.Export(ctx, location, fs, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return reg.RegisterTable(ctx, identifier, res.MetadataLocation)
+}
+
+// ToIdentifier builds an identifier from its parts. A single argument is
+// parsed as a dotted name using ParseIdentifier, falling back to a plain
+// split on dots if it is not a valid quoted identifier.
 func ToIdentifier(ident ...string) table.Identifier {
 	if len(ident) == 1 {
 		if ident[0] == "" {
 			return nil
 		}
 
+		if parsed, err := ParseIdentifier(ident[0]); err == nil {
+			return parsed
+		}
+
 		return table.Identifier(strings.Split(ident[0], "."))
 	}
 
@@ -192,6 +285,23 @@ func WithProperties(props iceberg.Properties) CreateTableOpt {
 	}
 }
 
+// ListTablesCfg represents the configuration used for ListTablesPage operations
+type ListTablesCfg struct {
+	IncludeMetadataLocation bool
+}
+
+type ListTablesOpt func(*ListTablesCfg)
+
+// WithMetadataLocation includes the metadata location of each table in a
+// paginated listing, which avoids loading every listed table to find it.
+// Catalogs which do not store the metadata location alongside the table
+// name leave it empty.
+func WithMetadataLocation() ListTablesOpt {
+	return func(cfg *ListTablesCfg) {
+		cfg.IncludeMetadataLocation = true
+	}
+}
+
 type CreateViewOpt func(*CreateViewCfg)
 
 func WithViewLocation(location string) CreateViewOpt {
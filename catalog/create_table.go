@@ -0,0 +1,269 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+)
+
+// CreateTableBuilder creates or replaces a table in a catalog, configured
+// fluently:
+//
+//	tbl, err := catalog.NewCreateTableBuilder(cat, ident).
+//		WithSchema(schema).
+//		WithPartitionSpec(spec).
+//		WithSortOrder(order).
+//		WithProperties(iceberg.Properties{"owner": "etl"}).
+//		Create(ctx)
+//
+// The Stage variants return a transaction instead, so that data can be
+// written to the new table and committed together with its creation.
+type CreateTableBuilder struct {
+	cat    Catalog
+	ident  table.Identifier
+	schema *iceberg.Schema
+	cfg    CreateTableCfg
+}
+
+// NewCreateTableBuilder returns a builder creating the table identified by
+// ident in cat.
+func NewCreateTableBuilder(cat Catalog, ident table.Identifier) *CreateTableBuilder {
+	return &CreateTableBuilder{cat: cat, ident: ident, cfg: NewCreateTableCfg()}
+}
+
+// WithSchema sets the schema of the table, which is required.
+func (b *CreateTableBuilder) WithSchema(schema *iceberg.Schema) *CreateTableBuilder {
+	b.schema = schema
+
+	return b
+}
+
+// WithLocation sets the location of the table. The catalog picks a default
+// location if it is not set.
+func (b *CreateTableBuilder) WithLocation(location string) *CreateTableBuilder {
+	WithLocation(location)(&b.cfg)
+
+	return b
+}
+
+// WithPartitionSpec sets the partition spec of the table, defined against
+// the schema passed to WithSchema. The table is unpartitioned if it is not
+// set.
+func (b *CreateTableBuilder) WithPartitionSpec(spec *iceberg.PartitionSpec) *CreateTableBuilder {
+	WithPartitionSpec(spec)(&b.cfg)
+
+	return b
+}
+
+// WithSortOrder sets the sort order of the table, defined against the schema
+// passed to WithSchema. The table is unsorted if it is not set.
+func (b *CreateTableBuilder) WithSortOrder(order table.SortOrder) *CreateTableBuilder {
+	WithSortOrder(order)(&b.cfg)
+
+	return b
+}
+
+// WithProperties adds properties to the table. It can be called several
+// times, with later values overriding earlier ones.
+func (b *CreateTableBuilder) WithProperties(props iceberg.Properties) *CreateTableBuilder {
+	if b.cfg.Properties == nil {
+		b.cfg.Properties = make(iceberg.Properties, len(props))
+	}
+	maps.Copy(b.cfg.Properties, props)
+
+	return b
+}
+
+// WithProperty sets a single property of the table.
+func (b *CreateTableBuilder) WithProperty(key, value string) *CreateTableBuilder {
+	return b.WithProperties(iceberg.Properties{key: value})
+}
+
+func (b *CreateTableBuilder) opts() []CreateTableOpt {
+	opts := []CreateTableOpt{WithPartitionSpec(b.cfg.PartitionSpec), WithSortOrder(b.cfg.SortOrder)}
+	if b.cfg.Location != "" {
+		opts = append(opts, WithLocation(b.cfg.Location))
+	}
+	if b.cfg.Properties != nil {
+		opts = append(opts, WithProperties(maps.Clone(b.cfg.Properties)))
+	}
+
+	return opts
+}
+
+func (b *CreateTableBuilder) validate() error {
+	if b.schema == nil {
+		return fmt.Errorf("%w: a schema is required to create table %s",
+			iceberg.ErrInvalidArgument, strings.Join(b.ident, "."))
+	}
+
+	return nil
+}
+
+// Create creates the table, failing with ErrTableAlreadyExists if it
+// already exists.
+func (b *CreateTableBuilder) Create(ctx context.Context) (*table.Table, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+
+	return b.cat.CreateTable(ctx, b.ident, b.schema, b.opts()...)
+}
+
+// CreateOrReplace creates the table, or replaces the definition of the
+// table if it already exists. A replaced table keeps its history and
+// existing properties, but takes the new schema, partition spec and sort
+// order and no longer has a current snapshot.
+func (b *CreateTableBuilder) CreateOrReplace(ctx context.Context) (*table.Table, error) {
+	tbl, err := b.loadTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if tbl == nil {
+		return b.Create(ctx)
+	}
+
+	txn, err := b.replaceTransaction(tbl)
+	if err != nil {
+		return nil, err
+	}
+
+	return txn.Commit(ctx)
+}
+
+// Replace replaces the definition of an existing table as done by
+// CreateOrReplace, failing with ErrNoSuchTable if the table does not exist.
+func (b *CreateTableBuilder) Replace(ctx context.Context) (*table.Table, error) {
+	txn, err := b.StageReplace(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return txn.Commit(ctx)
+}
+
+// StageCreate returns a transaction which creates the table when committed,
+// together with any changes staged in the transaction. The catalog must
+// implement StagingCatalog.
+func (b *CreateTableBuilder) StageCreate(ctx context.Context) (*table.Transaction, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+
+	staging, ok := b.cat.(StagingCatalog)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s catalog does not support staging table creation",
+			iceberg.ErrNotImplemented, b.cat.CatalogType())
+	}
+
+	return staging.StageCreateTable(ctx, b.ident, b.schema, b.opts()...)
+}
+
+// StageCreateOrReplace returns a transaction which creates the table, or
+// replaces its definition as done by CreateOrReplace, when committed.
+func (b *CreateTableBuilder) StageCreateOrReplace(ctx context.Context) (*table.Transaction, error) {
+	tbl, err := b.loadTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if tbl == nil {
+		return b.StageCreate(ctx)
+	}
+
+	return b.replaceTransaction(tbl)
+}
+
+// StageReplace returns a transaction which replaces the definition of an
+// existing table when committed, failing with ErrNoSuchTable if the table
+// does not exist. Data written in the transaction uses the new definition,
+// and readers keep seeing the previous version of the table until the
+// transaction is committed.
+func (b *CreateTableBuilder) StageReplace(ctx context.Context) (*table.Transaction, error) {
+	tbl, err := b.loadTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if tbl == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoSuchTable, strings.Join(b.ident, "."))
+	}
+
+	return b.replaceTransaction(tbl)
+}
+
+// loadTable returns the existing table, or nil if it does not exist.
+func (b *CreateTableBuilder) loadTable(ctx context.Context) (*table.Table, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+
+	tbl, err := b.cat.LoadTable(ctx, b.ident)
+	if errors.Is(err, ErrNoSuchTable) {
+		return nil, nil
+	}
+
+	return tbl, err
+}
+
+func (b *CreateTableBuilder) replaceTransaction(tbl *table.Table) (*table.Transaction, error) {
+	txn := tbl.NewTransaction()
+	if err := txn.ReplaceTable(b.schema, b.cfg.PartitionSpec, b.cfg.SortOrder, b.cfg.Location, b.cfg.Properties); err != nil {
+		return nil, err
+	}
+
+	return txn, nil
+}
+
+// CreateTableTransaction returns a transaction which creates the table in
+// cat when committed, so that the table only becomes visible once its
+// initial data has been written. It is a shortcut for StageCreate on a
+// CreateTableBuilder configured with opts.
+func CreateTableTransaction(ctx context.Context, cat Catalog, identifier table.Identifier, schema *iceberg.Schema, opts ...CreateTableOpt) (*table.Transaction, error) {
+	return newCreateTableBuilder(cat, identifier, schema, opts...).StageCreate(ctx)
+}
+
+// ReplaceTableTransaction returns a transaction which replaces the
+// definition of the table in cat when committed, so that a new version of
+// the table can be written and then swapped in atomically. If orCreate is
+// true and the table does not exist, the transaction creates it instead.
+func ReplaceTableTransaction(ctx context.Context, cat Catalog, identifier table.Identifier, schema *iceberg.Schema, orCreate bool, opts ...CreateTableOpt) (*table.Transaction, error) {
+	b := newCreateTableBuilder(cat, identifier, schema, opts...)
+	if orCreate {
+		return b.StageCreateOrReplace(ctx)
+	}
+
+	return b.StageReplace(ctx)
+}
+
+func newCreateTableBuilder(cat Catalog, identifier table.Identifier, schema *iceberg.Schema, opts ...CreateTableOpt) *CreateTableBuilder {
+	b := NewCreateTableBuilder(cat, identifier).WithSchema(schema)
+	for _, opt := range opts {
+		opt(&b.cfg)
+	}
+
+	return b
+}
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"iter"
 	"maps"
+	"math"
 	"strconv"
 	"strings"
 	_ "unsafe"
@@ -65,6 +66,13 @@ const (
 	SkipArchive        = "glue.skip-archive"
 	SkipArchiveDefault = true
 
+	// Sync the columns and comments of the current Iceberg schema into the
+	// storage descriptor of the Glue table on every commit, so that engines
+	// reading the Glue table definition (e.g. Athena) show the right columns.
+	// When disabled, the columns of the existing Glue table are left untouched.
+	SchemaSync        = "glue.schema-sync"
+	SchemaSyncDefault = true
+
 	AccessKeyID     = "glue.access-key-id"
 	SecretAccessKey = "glue.secret-access-key"
 	SessionToken    = "glue.session-token"
@@ -78,7 +86,7 @@ const (
 	icebergFieldCurrentKey  = "iceberg.field.current"
 )
 
-var _ catalog.Catalog = (*Catalog)(nil)
+var _ catalog.RegisteringCatalog = (*Catalog)(nil)
 
 func init() {
 	catalog.Register("glue", catalog.RegistrarFunc(func(ctx context.Context, _ string, props iceberg.Properties) (catalog.Catalog, error) {
@@ -206,6 +214,52 @@ func (c *Catalog) ListTables(ctx context.Context, namespace table.Identifier) it
 	}
 }
 
+// ListTablesPage returns a single page of the Iceberg tables in the given
+// Glue database, using the Glue next token as the page token. Pages can hold
+// fewer tables than pageSize as other table types are skipped.
+func (c *Catalog) ListTablesPage(ctx context.Context, namespace table.Identifier, pageToken string, pageSize int, opts ...catalog.ListTablesOpt) ([]catalog.TableInfo, string, error) {
+	var cfg catalog.ListTablesCfg
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	database, err := identifierToGlueDatabase(namespace)
+	if err != nil {
+		return nil, "", err
+	}
+
+	input := &glue.GetTablesInput{
+		CatalogId:    c.catalogId,
+		DatabaseName: aws.String(database),
+	}
+	if pageToken != "" {
+		input.NextToken = aws.String(pageToken)
+	}
+	if pageSize > 0 {
+		input.MaxResults = aws.Int32(int32(min(pageSize, math.MaxInt32)))
+	}
+
+	page, err := c.glueSvc.GetTables(ctx, input)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list tables in namespace %s: %w", database, err)
+	}
+
+	var tables []catalog.TableInfo
+	for _, tbl := range page.TableList {
+		if !strings.EqualFold(tbl.Parameters[tableParamTableType], glueTypeIceberg) {
+			continue
+		}
+
+		info := catalog.TableInfo{Identifier: TableIdentifier(database, aws.ToString(tbl.Name))}
+		if cfg.IncludeMetadataLocation {
+			info.MetadataLocation = tbl.Parameters[tableParamMetadataLocation]
+		}
+		tables = append(tables, info)
+	}
+
+	return tables, aws.ToString(page.NextToken), nil
+}
+
 // LoadTable loads a table from the catalog table details.
 //
 // The identifier should contain the Glue database name, then Glue table name.
@@ -258,7 +312,7 @@ func (c *Catalog) CreateTable(ctx context.Context, identifier table.Identifier,
 	_, err = c.glueSvc.CreateTable(ctx, &glue.CreateTableInput{
 		CatalogId:    c.catalogId,
 		DatabaseName: aws.String(database),
-		TableInput:   constructTableInput(tableName, staged.Table, nil),
+		TableInput:   constructTableInput(tableName, staged.Table, nil, c.schemaSync()),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create table %s.%s: %w", database, tableName, err)
@@ -267,6 +321,22 @@ func (c *Catalog) CreateTable(ctx context.Context, identifier table.Identifier,
 	return c.LoadTable(ctx, identifier)
 }
 
+// StageCreateTable returns a transaction which creates the table in the Glue
+// catalog when committed, together with any changes staged in the
+// transaction.
+func (c *Catalog) StageCreateTable(ctx context.Context, identifier table.Identifier, schema *iceberg.Schema, opts ...catalog.CreateTableOpt) (*table.Transaction, error) {
+	exists, err := c.CheckTableExists(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		return nil, fmt.Errorf("%w: %s", catalog.ErrTableAlreadyExists, strings.Join(identifier, "."))
+	}
+
+	return internal.StageCreateTable(ctx, c.props, c.LoadNamespaceProperties, identifier, schema, c, opts...)
+}
+
 // RegisterTable registers a new table using existing metadata.
 func (c *Catalog) RegisterTable(ctx context.Context, identifier table.Identifier, metadataLocation string) (*table.Table, error) {
 	database, tableName, err := identifierToGlueTable(identifier)
@@ -290,7 +360,7 @@ func (c *Catalog) RegisterTable(ctx context.Context, identifier table.Identifier
 	_, err = c.glueSvc.CreateTable(ctx, &glue.CreateTableInput{
 		CatalogId:    c.catalogId,
 		DatabaseName: aws.String(database),
-		TableInput:   constructTableInput(tableName, tbl, nil),
+		TableInput:   constructTableInput(tableName, tbl, nil, c.schemaSync()),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to register table %s.%s: %w", database, tableName, err)
@@ -320,14 +390,15 @@ func (c *Catalog) CommitTable(ctx context.Context, identifier table.Identifier,
 	}
 
 	// Create a staging table with the updates applied
-	staged, err := internal.UpdateAndStageTable(ctx, current, identifier, requirements, updates, c)
+	staged, err := internal.UpdateAndStageTable(ctx, current, identifier, requirements, updates, c.props, c)
 	if err != nil {
 		return nil, "", err
 	}
 	if current != nil && staged.Metadata().Equals(current.Metadata()) {
 		return current.Metadata(), current.MetadataLocation(), nil
 	}
-	if err := internal.WriteMetadata(ctx, staged.Metadata(), staged.MetadataLocation(), staged.Properties()); err != nil {
+	if err := internal.WriteMetadata(ctx, staged.Metadata(), staged.MetadataLocation(),
+		internal.IOProps(c.props, staged.Properties())); err != nil {
 		return nil, "", err
 	}
 
@@ -338,19 +409,25 @@ func (c *Catalog) CommitTable(ctx context.Context, identifier table.Identifier,
 		_, err = c.glueSvc.UpdateTable(ctx, &glue.UpdateTableInput{
 			CatalogId:    c.catalogId,
 			DatabaseName: aws.String(database),
-			TableInput:   constructTableInput(tableName, staged.Table, currentGlueTable),
+			TableInput:   constructTableInput(tableName, staged.Table, currentGlueTable, c.schemaSync()),
 			// use `VersionId` to implement optimistic locking
 			VersionId:   currentGlueTable.VersionId,
 			SkipArchive: aws.Bool(c.props.GetBool(SkipArchive, SkipArchiveDefault)),
 		})
 		if err != nil {
+			var cme *types.ConcurrentModificationException
+			if errors.As(err, &cme) {
+				return nil, "", fmt.Errorf("%w: table %s.%s was modified concurrently: %w",
+					iceberg.ErrCommitConflict, database, tableName, err)
+			}
+
 			return nil, "", err
 		}
 	} else {
 		_, err = c.glueSvc.CreateTable(ctx, &glue.CreateTableInput{
 			CatalogId:    c.catalogId,
 			DatabaseName: aws.String(database),
-			TableInput:   constructTableInput(tableName, staged.Table, nil),
+			TableInput:   constructTableInput(tableName, staged.Table, nil, c.schemaSync()),
 		})
 		if err != nil {
 			return nil, "", err
@@ -598,8 +675,8 @@ func (c *Catalog) ListNamespaces(ctx context.Context, parent table.Identifier) (
 		CatalogId: c.catalogId,
 	}
 
-	if parent != nil {
-		return nil, errors.New("hierarchical namespace is not supported")
+	if len(parent) > 0 {
+		return nil, catalog.ErrHierarchicalNamespaceUnsupported
 	}
 
 	var icebergNamespaces []table.Identifier
@@ -619,6 +696,34 @@ func (c *Catalog) ListNamespaces(ctx context.Context, parent table.Identifier) (
 	return icebergNamespaces, nil
 }
 
+// ListNamespacesPage returns a single page of the Glue databases, using the
+// Glue next token as the page token.
+func (c *Catalog) ListNamespacesPage(ctx context.Context, parent table.Identifier, pageToken string, pageSize int) ([]table.Identifier, string, error) {
+	if len(parent) > 0 {
+		return nil, "", catalog.ErrHierarchicalNamespaceUnsupported
+	}
+
+	input := &glue.GetDatabasesInput{CatalogId: c.catalogId}
+	if pageToken != "" {
+		input.NextToken = aws.String(pageToken)
+	}
+	if pageSize > 0 {
+		input.MaxResults = aws.Int32(int32(min(pageSize, math.MaxInt32)))
+	}
+
+	rsp, err := c.glueSvc.GetDatabases(ctx, input)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list databases: %w", err)
+	}
+
+	namespaces := make([]table.Identifier, len(rsp.DatabaseList))
+	for i, database := range rsp.DatabaseList {
+		namespaces[i] = DatabaseIdentifier(aws.ToString(database.Name))
+	}
+
+	return namespaces, aws.ToString(rsp.NextToken), nil
+}
+
 // GetTable loads a table from the Glue Catalog using the given database and table name.
 func (c *Catalog) getTable(ctx context.Context, database, tableName string) (*types.Table, error) {
 	tblRes, err := c.glueSvc.GetTable(ctx,
@@ -677,11 +782,11 @@ func (c *Catalog) convertGlueToIceberg(ctx context.Context, glueTable *types.Tab
 		return nil, fmt.Errorf("missing metadata location for table %s", tableName)
 	}
 
-	icebergTable, err := table.NewFromLocation(
+	icebergTable, err := internal.LoadTable(
 		utils.WithAwsConfig(ctx, c.awsCfg),
 		TableIdentifier(database, tableName),
 		metadataLocation,
-		io.LoadFSFunc(nil, metadataLocation),
+		c.props,
 		c,
 	)
 	if err != nil {
@@ -715,6 +820,10 @@ func identifierToGlueTable(identifier table.Identifier) (string, string, error)
 }
 
 func identifierToGlueDatabase(identifier table.Identifier) (string, error) {
+	if len(identifier) > 1 {
+		return "", fmt.Errorf("%w: %v", catalog.ErrHierarchicalNamespaceUnsupported, identifier)
+	}
+
 	if len(identifier) != 1 {
 		return "", fmt.Errorf("invalid identifier, missing database name: %v", identifier)
 	}
@@ -761,14 +870,28 @@ func constructParameters(staged *table.Table, previousGlueTable *types.Table) ma
 	return parameters
 }
 
-func constructTableInput(tableName string, staged *table.Table, previousGlueTable *types.Table) *types.TableInput {
+// schemaSync reports whether commits should sync the Iceberg schema into
+// the columns of the Glue table.
+func (c *Catalog) schemaSync() bool {
+	return c.props.GetBool(SchemaSync, SchemaSyncDefault)
+}
+
+func constructTableInput(tableName string, staged *table.Table, previousGlueTable *types.Table, syncSchema bool) *types.TableInput {
+	var columns []types.Column
+	switch {
+	case syncSchema:
+		columns = schemasToGlueColumns(staged.Metadata())
+	case previousGlueTable != nil && previousGlueTable.StorageDescriptor != nil:
+		columns = previousGlueTable.StorageDescriptor.Columns
+	}
+
 	tableInput := &types.TableInput{
 		Name:       aws.String(tableName),
 		TableType:  aws.String(glueTableType),
 		Parameters: constructParameters(staged, previousGlueTable),
 		StorageDescriptor: &types.StorageDescriptor{
 			Location: aws.String(staged.Location()),
-			Columns:  schemasToGlueColumns(staged.Metadata()),
+			Columns:  columns,
 		},
 	}
 
@@ -395,6 +395,47 @@ func TestGlueListTablesError(t *testing.T) {
 	mockGlueSvc.AssertExpectations(t)
 }
 
+func TestGlueListTablesPage(t *testing.T) {
+	assert := require.New(t)
+
+	mockGlueSvc := &mockGlueClient{}
+	mockGlueSvc.On("GetTables", mock.Anything, &glue.GetTablesInput{
+		DatabaseName: aws.String("test_database"),
+		NextToken:    aws.String("token1"),
+		MaxResults:   aws.Int32(2),
+	}, mock.Anything).Return(&glue.GetTablesOutput{
+		TableList: []types.Table{testIcebergGlueTable1, testNonIcebergGlueTable},
+		NextToken: aws.String("token2"),
+	}, nil).Once()
+	mockGlueSvc.On("GetDatabases", mock.Anything, &glue.GetDatabasesInput{
+		MaxResults: aws.Int32(1),
+	}, mock.Anything).Return(&glue.GetDatabasesOutput{
+		DatabaseList: []types.Database{{Name: aws.String("test_database")}},
+	}, nil).Once()
+
+	glueCatalog := &Catalog{
+		glueSvc: mockGlueSvc,
+	}
+
+	tables, next, err := glueCatalog.ListTablesPage(context.TODO(), DatabaseIdentifier("test_database"), "token1", 2,
+		catalog.WithMetadataLocation())
+	assert.NoError(err)
+	assert.Equal("token2", next)
+	assert.Equal([]catalog.TableInfo{{
+		Identifier:       TableIdentifier("test_database", "test_table"),
+		MetadataLocation: testIcebergGlueTable1.Parameters[tableParamMetadataLocation],
+	}}, tables)
+
+	namespaces, next, err := glueCatalog.ListNamespacesPage(context.TODO(), nil, "", 1)
+	assert.NoError(err)
+	assert.Empty(next)
+	assert.Equal([]table.Identifier{DatabaseIdentifier("test_database")}, namespaces)
+
+	_, _, err = glueCatalog.ListNamespacesPage(context.TODO(), table.Identifier{"a", "b"}, "", 1)
+	assert.ErrorIs(err, catalog.ErrHierarchicalNamespaceUnsupported)
+	mockGlueSvc.AssertExpectations(t)
+}
+
 func TestGlueListNamespaces(t *testing.T) {
 	assert := require.New(t)
 
@@ -1261,6 +1302,42 @@ func TestSnapshotManagementIntegration(t *testing.T) {
 	assert.Equal(newSnap.Summary.Operation, currSnap.Summary.Operation)
 }
 
+func TestConstructTableInputSchemaSync(t *testing.T) {
+	assert := require.New(t)
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.Int64Type{}, Required: true, Doc: "row id"},
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.StringType{}},
+	)
+	meta, err := table.NewMetadata(sc, iceberg.UnpartitionedSpec, table.UnsortedSortOrder, "s3://bucket/tbl", nil)
+	assert.NoError(err)
+	tbl := table.New(TableIdentifier("db", "tbl"), meta, "s3://bucket/tbl/metadata/00001.metadata.json", nil, nil)
+
+	previous := &types.Table{
+		Parameters: map[string]string{tableParamMetadataLocation: "s3://bucket/tbl/metadata/00000.metadata.json"},
+		StorageDescriptor: &types.StorageDescriptor{
+			Columns: []types.Column{{Name: aws.String("old"), Type: aws.String("int")}},
+		},
+	}
+
+	synced := constructTableInput("tbl", tbl, previous, true)
+	assert.Len(synced.StorageDescriptor.Columns, 2)
+	assert.Equal("id", *synced.StorageDescriptor.Columns[0].Name)
+	assert.Equal("bigint", *synced.StorageDescriptor.Columns[0].Type)
+	assert.Equal("row id", *synced.StorageDescriptor.Columns[0].Comment)
+	assert.Equal("data", *synced.StorageDescriptor.Columns[1].Name)
+
+	unsynced := constructTableInput("tbl", tbl, previous, false)
+	assert.Equal(previous.StorageDescriptor.Columns, unsynced.StorageDescriptor.Columns)
+	assert.Equal("s3://bucket/tbl", *unsynced.StorageDescriptor.Location)
+
+	created := constructTableInput("tbl", tbl, nil, false)
+	assert.Empty(created.StorageDescriptor.Columns)
+
+	assert.True((&Catalog{props: iceberg.Properties{}}).schemaSync())
+	assert.False((&Catalog{props: iceberg.Properties{SchemaSync: "false"}}).schemaSync())
+}
+
 func TestGlueCheckTableExists(t *testing.T) {
 	assert := require.New(t)
 	mockGlueSvc := &mockGlueClient{}
@@ -43,9 +43,12 @@ const (
 var _ catalog.Catalog = (*Catalog)(nil)
 
 func init() {
-	catalog.Register("hive", catalog.RegistrarFunc(func(ctx context.Context, _ string, props iceberg.Properties) (catalog.Catalog, error) {
+	reg := catalog.RegistrarFunc(func(ctx context.Context, _ string, props iceberg.Properties) (catalog.Catalog, error) {
 		return NewCatalog(props)
-	}))
+	})
+
+	catalog.Register("hive", reg)
+	catalog.Register("thrift", reg)
 }
 
 type Catalog struct {
@@ -76,10 +79,14 @@ func NewCatalog(props iceberg.Properties, opts ...Option) (*Catalog, error) {
 	}, nil
 }
 
-func NewCatalogWithClient(client HiveClient, props iceberg.Properties) *Catalog {
+func NewCatalogWithClient(client HiveClient, props iceberg.Properties, opts ...Option) *Catalog {
 	o := NewHiveOptions()
 	o.ApplyProperties(props)
 
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	return &Catalog{
 		client: client,
 		opts:   o,
@@ -145,13 +152,7 @@ func (c *Catalog) LoadTable(ctx context.Context, identifier table.Identifier) (*
 		return nil, fmt.Errorf("failed to get metadata location: %w", err)
 	}
 
-	return table.NewFromLocation(
-		ctx,
-		identifier,
-		metadataLocation,
-		io.LoadFSFunc(c.opts.props, metadataLocation),
-		c,
-	)
+	return internal.LoadTable(ctx, identifier, metadataLocation, c.opts.props, c)
 }
 
 func (c *Catalog) CreateTable(ctx context.Context, identifier table.Identifier, schema *iceberg.Schema, opts ...catalog.CreateTableOpt) (*table.Table, error) {
@@ -192,6 +193,22 @@ func (c *Catalog) CreateTable(ctx context.Context, identifier table.Identifier,
 	return c.LoadTable(ctx, identifier)
 }
 
+// StageCreateTable returns a transaction which creates the table in the
+// metastore when committed, together with any changes staged in the
+// transaction.
+func (c *Catalog) StageCreateTable(ctx context.Context, identifier table.Identifier, schema *iceberg.Schema, opts ...catalog.CreateTableOpt) (*table.Transaction, error) {
+	exists, err := c.CheckTableExists(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		return nil, fmt.Errorf("%w: %s", catalog.ErrTableAlreadyExists, strings.Join(identifier, "."))
+	}
+
+	return internal.StageCreateTable(ctx, c.opts.props, c.LoadNamespaceProperties, identifier, schema, c, opts...)
+}
+
 func (c *Catalog) DropTable(ctx context.Context, identifier table.Identifier) error {
 	database, tableName, err := identifierToTableName(identifier)
 	if err != nil {
@@ -249,7 +266,7 @@ func (c *Catalog) CommitTable(ctx context.Context, identifier table.Identifier,
 		return nil, "", err
 	}
 
-	lock, err := acquireLock(ctx, c.client, database, tableName, c.opts)
+	lock, err := c.acquireCommitLock(ctx, identifier, database, tableName)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to acquire lock for %s.%s: %w", database, tableName, err)
 	}
@@ -268,13 +285,13 @@ func (c *Catalog) CommitTable(ctx context.Context, identifier table.Identifier,
 		if err != nil {
 			return nil, "", err
 		}
-		current, err = table.NewFromLocation(ctx, identifier, metadataLoc, io.LoadFSFunc(c.opts.props, metadataLoc), c)
+		current, err = internal.LoadTable(ctx, identifier, metadataLoc, c.opts.props, c)
 		if err != nil {
 			return nil, "", err
 		}
 	}
 
-	staged, err := internal.UpdateAndStageTable(ctx, current, identifier, requirements, updates, c)
+	staged, err := internal.UpdateAndStageTable(ctx, current, identifier, requirements, updates, c.opts.props, c)
 	if err != nil {
 		return nil, "", err
 	}
@@ -283,12 +300,17 @@ func (c *Catalog) CommitTable(ctx context.Context, identifier table.Identifier,
 		return current.Metadata(), current.MetadataLocation(), nil
 	}
 
-	if err := internal.WriteMetadata(ctx, staged.Metadata(), staged.MetadataLocation(), staged.Properties()); err != nil {
+	if err := internal.WriteMetadata(ctx, staged.Metadata(), staged.MetadataLocation(),
+		internal.IOProps(c.opts.props, staged.Properties())); err != nil {
 		return nil, "", err
 	}
 
 	if current != nil {
-		updatedHiveTbl := updateHiveTableForCommit(currentHiveTbl, staged.MetadataLocation())
+		var schema *iceberg.Schema
+		if c.opts.SchemaSync {
+			schema = staged.Metadata().CurrentSchema()
+		}
+		updatedHiveTbl := updateHiveTableForCommit(currentHiveTbl, staged.MetadataLocation(), schema)
 
 		if err := c.client.AlterTable(ctx, database, tableName, updatedHiveTbl); err != nil {
 			return nil, "", fmt.Errorf("failed to commit table %s.%s: %w", database, tableName, err)
@@ -303,6 +325,16 @@ func (c *Catalog) CommitTable(ctx context.Context, identifier table.Identifier,
 	return staged.Metadata(), staged.MetadataLocation(), nil
 }
 
+// acquireCommitLock locks the table for a commit, using the configured
+// LockManager if there is one and a metastore lock otherwise.
+func (c *Catalog) acquireCommitLock(ctx context.Context, identifier table.Identifier, database, tableName string) (catalog.Lock, error) {
+	if c.opts.LockManager != nil {
+		return c.opts.LockManager.Acquire(ctx, identifier)
+	}
+
+	return acquireLock(ctx, c.client, database, tableName, c.opts)
+}
+
 // CheckTableExists checks if a table exists in the catalog.
 func (c *Catalog) CheckTableExists(ctx context.Context, identifier table.Identifier) (bool, error) {
 	database, tableName, err := identifierToTableName(identifier)
@@ -324,7 +356,7 @@ func (c *Catalog) CheckTableExists(ctx context.Context, identifier table.Identif
 
 func (c *Catalog) ListNamespaces(ctx context.Context, parent table.Identifier) ([]table.Identifier, error) {
 	if len(parent) > 0 {
-		return nil, errors.New("hierarchical namespace is not supported")
+		return nil, catalog.ErrHierarchicalNamespaceUnsupported
 	}
 
 	databases, err := c.client.GetAllDatabases(ctx)
@@ -519,6 +551,10 @@ func identifierToTableName(identifier table.Identifier) (string, string, error)
 }
 
 func identifierToDatabase(identifier table.Identifier) (string, error) {
+	if len(identifier) > 1 {
+		return "", fmt.Errorf("%w: %v", catalog.ErrHierarchicalNamespaceUnsupported, identifier)
+	}
+
 	if len(identifier) != 1 {
 		return "", fmt.Errorf("invalid identifier, expected [database]: %v", identifier)
 	}
@@ -541,6 +577,12 @@ func isNoSuchObjectError(err error) bool {
 		return false
 	}
 
+	var typed *hive_metastore.NoSuchObjectException
+	if errors.As(err, &typed) {
+		return true
+	}
+
+	// errors from the thrift transport only carry the exception name
 	errStr := err.Error()
 
 	return strings.Contains(errStr, "NoSuchObjectException") ||
@@ -553,6 +595,12 @@ func isAlreadyExistsError(err error) bool {
 		return false
 	}
 
+	var typed *hive_metastore.AlreadyExistsException
+	if errors.As(err, &typed) {
+		return true
+	}
+
+	// errors from the thrift transport only carry the exception name
 	errStr := err.Error()
 
 	return strings.Contains(errStr, "AlreadyExistsException") ||
@@ -564,6 +612,12 @@ func isInvalidOperationError(err error) bool {
 		return false
 	}
 
+	var typed *hive_metastore.InvalidOperationException
+	if errors.As(err, &typed) {
+		return true
+	}
+
+	// errors from the thrift transport only carry the exception name
 	errStr := err.Error()
 
 	return strings.Contains(errStr, "InvalidOperationException") ||
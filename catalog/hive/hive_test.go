@@ -278,6 +278,9 @@ func TestHiveListNamespacesHierarchicalError(t *testing.T) {
 	_, err := hiveCatalog.ListNamespaces(context.TODO(), []string{"parent"})
 	assert.Error(err)
 	assert.Contains(err.Error(), "hierarchical namespace is not supported")
+
+	err = hiveCatalog.CreateNamespace(context.TODO(), []string{"parent", "child"}, nil)
+	assert.ErrorIs(err, catalog.ErrHierarchicalNamespaceUnsupported)
 }
 
 func TestHiveCreateNamespace(t *testing.T) {
@@ -649,6 +652,44 @@ func TestSchemaToHiveColumns(t *testing.T) {
 	assert.Equal("boolean", columns[2].Type)
 }
 
+func TestUpdateHiveTableForCommit(t *testing.T) {
+	assert := require.New(t)
+
+	existing := constructHiveTable("db", "tbl", "s3://bucket/tbl", "s3://bucket/tbl/metadata/00000.metadata.json",
+		iceberg.NewSchema(0, iceberg.NestedField{ID: 1, Name: "foo", Type: iceberg.PrimitiveTypes.String}), nil)
+
+	newSchema := iceberg.NewSchema(1,
+		iceberg.NestedField{ID: 1, Name: "foo", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 2, Name: "bar", Type: iceberg.PrimitiveTypes.Int64, Doc: "a new column"},
+	)
+
+	updated := updateHiveTableForCommit(existing, "s3://bucket/tbl/metadata/00001.metadata.json", newSchema)
+	assert.Equal("s3://bucket/tbl/metadata/00001.metadata.json", updated.Parameters[MetadataLocationKey])
+	assert.Equal("s3://bucket/tbl/metadata/00000.metadata.json", updated.Parameters[PreviousMetadataLocationKey])
+	assert.Len(updated.Sd.Cols, 2)
+	assert.Equal("bar", updated.Sd.Cols[1].Name)
+	assert.Equal("bigint", updated.Sd.Cols[1].Type)
+	assert.Equal("a new column", updated.Sd.Cols[1].Comment)
+	assert.Equal("s3://bucket/tbl", updated.Sd.Location)
+	assert.Len(existing.Sd.Cols, 1)
+
+	unsynced := updateHiveTableForCommit(existing, "s3://bucket/tbl/metadata/00002.metadata.json", nil)
+	assert.Same(existing.Sd, unsynced.Sd)
+}
+
+func TestHiveOptionsSchemaSync(t *testing.T) {
+	assert := require.New(t)
+
+	opts := NewHiveOptions()
+	assert.True(opts.SchemaSync)
+
+	opts.ApplyProperties(iceberg.Properties{SchemaSync: "false"})
+	assert.False(opts.SchemaSync)
+
+	WithSchemaSync(true)(opts)
+	assert.True(opts.SchemaSync)
+}
+
 func TestUpdateNamespaceProperties(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -23,6 +23,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/table"
 	"github.com/beltran/gohive/hive_metastore"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -246,6 +249,25 @@ func TestReleaseLock(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestCommitLockUsesLockManager(t *testing.T) {
+	mockClient := new(mockHiveClient)
+	ctx := context.Background()
+	mgr := catalog.NewInMemoryLockManager()
+	cat := NewCatalogWithClient(mockClient, iceberg.Properties{}, WithLockManager(mgr))
+
+	lock, err := cat.acquireCommitLock(ctx, table.Identifier{"testdb", "testtable"}, "testdb", "testtable")
+	require.NoError(t, err)
+
+	// the table is locked in the lock manager, not in the metastore
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = mgr.Acquire(timeoutCtx, table.Identifier{"testdb", "testtable"})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	require.NoError(t, lock.Release(ctx))
+	mockClient.AssertNotCalled(t, "Lock", mock.Anything, mock.Anything)
+}
+
 func TestCalculateBackoff(t *testing.T) {
 	minWait := 100 * time.Millisecond
 	maxWait := 1 * time.Second
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
 )
 
 const (
@@ -47,6 +48,12 @@ const (
 	DefaultLockCheckMinWaitTime = 100 * time.Millisecond // 100ms
 	DefaultLockCheckMaxWaitTime = 60 * time.Second       // 1 minute
 	DefaultLockCheckRetries     = 4
+
+	// SchemaSync controls whether commits sync the columns and comments of
+	// the current Iceberg schema into the storage descriptor of the Hive
+	// table, so that Hive-compatible engines show the right columns.
+	SchemaSync        = "schema-sync"
+	DefaultSchemaSync = true
 )
 
 type HiveOptions struct {
@@ -58,6 +65,14 @@ type HiveOptions struct {
 	LockMinWaitTime time.Duration
 	LockMaxWaitTime time.Duration
 	LockRetries     int
+
+	// SchemaSync syncs the current schema into the Hive table columns on
+	// every commit.
+	SchemaSync bool
+
+	// LockManager, when set, serializes commits instead of the locks of
+	// the metastore.
+	LockManager catalog.LockManager
 }
 
 func NewHiveOptions() *HiveOptions {
@@ -66,6 +81,7 @@ func NewHiveOptions() *HiveOptions {
 		LockMinWaitTime: DefaultLockCheckMinWaitTime,
 		LockMaxWaitTime: DefaultLockCheckMaxWaitTime,
 		LockRetries:     DefaultLockCheckRetries,
+		SchemaSync:      DefaultSchemaSync,
 	}
 }
 
@@ -95,6 +111,12 @@ func (o *HiveOptions) ApplyProperties(props iceberg.Properties) {
 			o.LockRetries = i
 		}
 	}
+
+	if val, ok := props[SchemaSync]; ok {
+		if b, err := strconv.ParseBool(val); err == nil {
+			o.SchemaSync = b
+		}
+	}
 }
 
 type Option func(*HiveOptions)
@@ -117,3 +139,20 @@ func WithProperties(props iceberg.Properties) Option {
 		o.props = props
 	}
 }
+
+// WithLockManager commits tables while holding a lock of the given
+// LockManager instead of a Hive Metastore lock, for metastores that do
+// not support locking.
+func WithLockManager(m catalog.LockManager) Option {
+	return func(o *HiveOptions) {
+		o.LockManager = m
+	}
+}
+
+// WithSchemaSync sets whether commits sync the current Iceberg schema into
+// the columns of the Hive table.
+func WithSchemaSync(enabled bool) Option {
+	return func(o *HiveOptions) {
+		o.SchemaSync = enabled
+	}
+}
@@ -116,10 +116,22 @@ func constructHiveTable(dbName, tableName, location, metadataLocation string, sc
 	}
 }
 
-func updateHiveTableForCommit(existing *hive_metastore.Table, newMetadataLocation string) *hive_metastore.Table {
+// updateHiveTableForCommit returns a copy of the existing Hive table
+// pointing at the new metadata location. If schema is not nil, the columns
+// of the storage descriptor are replaced with the columns of schema.
+func updateHiveTableForCommit(existing *hive_metastore.Table, newMetadataLocation string, schema *iceberg.Schema) *hive_metastore.Table {
 	// Copy the existing table
 	updated := *existing
 
+	if schema != nil {
+		sd := hive_metastore.StorageDescriptor{}
+		if existing.Sd != nil {
+			sd = *existing.Sd
+		}
+		sd.Cols = schemaToHiveColumns(schema)
+		updated.Sd = &sd
+	}
+
 	// Update parameters
 	if updated.Parameters == nil {
 		updated.Parameters = make(map[string]string)
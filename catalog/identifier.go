@@ -0,0 +1,185 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package catalog
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+)
+
+type identifierCfg struct {
+	caseSensitive bool
+}
+
+// IdentifierOpt configures how identifiers are parsed.
+type IdentifierOpt func(*identifierCfg)
+
+// WithCaseSensitive controls whether unquoted identifier parts keep their
+// case. When false, unquoted parts are lowercased while backquoted parts
+// are preserved as written, and catalog names are matched ignoring case.
+// Identifiers are case sensitive by default.
+func WithCaseSensitive(caseSensitive bool) IdentifierOpt {
+	return func(cfg *identifierCfg) {
+		cfg.caseSensitive = caseSensitive
+	}
+}
+
+// ParseIdentifier parses a dotted name such as "db.events" into a table
+// or namespace identifier.
+//
+// Parts containing dots can be written in backquotes, e.g. "db.`my.table`",
+// with a doubled backquote standing for a literal backquote. Outside of
+// backquotes a backslash escapes the following character, so "my\.table"
+// is a single part as well. Empty parts are rejected.
+func ParseIdentifier(s string, opts ...IdentifierOpt) (table.Identifier, error) {
+	cfg := identifierCfg{caseSensitive: true}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty identifier", iceberg.ErrInvalidArgument)
+	}
+
+	var (
+		ident  table.Identifier
+		part   strings.Builder
+		quoted bool
+	)
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quoted:
+			if c != '`' {
+				part.WriteByte(c)
+			} else if i+1 < len(s) && s[i+1] == '`' {
+				part.WriteByte(c)
+				i++
+			} else {
+				quoted = false
+			}
+		case c == '`':
+			quoted = true
+		case c == '\\':
+			if i+1 == len(s) {
+				return nil, fmt.Errorf("%w: trailing escape character in identifier %q",
+					iceberg.ErrInvalidArgument, s)
+			}
+			i++
+			part.WriteByte(s[i])
+		case c == '.':
+			if part.Len() == 0 {
+				return nil, fmt.Errorf("%w: empty part in identifier %q", iceberg.ErrInvalidArgument, s)
+			}
+			ident = append(ident, part.String())
+			part.Reset()
+		default:
+			if !cfg.caseSensitive {
+				c = toLower(c)
+			}
+			part.WriteByte(c)
+		}
+	}
+
+	if quoted {
+		return nil, fmt.Errorf("%w: unterminated backquote in identifier %q", iceberg.ErrInvalidArgument, s)
+	}
+
+	if part.Len() == 0 {
+		return nil, fmt.Errorf("%w: empty part in identifier %q", iceberg.ErrInvalidArgument, s)
+	}
+
+	return append(ident, part.String()), nil
+}
+
+func toLower(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+
+	return c
+}
+
+// QuoteIdentifier formats an identifier as a dotted name, backquoting any
+// part that would otherwise not survive a round trip through
+// ParseIdentifier.
+func QuoteIdentifier(ident table.Identifier) string {
+	parts := make([]string, len(ident))
+	for i, p := range ident {
+		if p == "" || strings.ContainsAny(p, ".`\\") {
+			p = "`" + strings.ReplaceAll(p, "`", "``") + "`"
+		}
+		parts[i] = p
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// QualifiedIdentifier is a table or namespace identifier optionally
+// prefixed with the name of the catalog it belongs to.
+type QualifiedIdentifier struct {
+	// Catalog is the name of the catalog, or empty if the name did not
+	// start with a known catalog.
+	Catalog    string
+	Identifier table.Identifier
+}
+
+func (q QualifiedIdentifier) String() string {
+	if q.Catalog == "" {
+		return QuoteIdentifier(q.Identifier)
+	}
+
+	return QuoteIdentifier(append(table.Identifier{q.Catalog}, q.Identifier...))
+}
+
+// ParseQualifiedIdentifier parses a dotted name which may be prefixed with
+// a catalog name, e.g. "prod.db.events". The first part is treated as the
+// catalog only if it matches one of the given catalog names and more parts
+// follow it, so "db.events" stays a plain identifier even when a catalog
+// named "db" exists.
+func ParseQualifiedIdentifier(s string, catalogs []string, opts ...IdentifierOpt) (QualifiedIdentifier, error) {
+	cfg := identifierCfg{caseSensitive: true}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	ident, err := ParseIdentifier(s, opts...)
+	if err != nil {
+		return QualifiedIdentifier{}, err
+	}
+
+	if len(ident) > 1 {
+		idx := slices.IndexFunc(catalogs, func(name string) bool {
+			if cfg.caseSensitive {
+				return name == ident[0]
+			}
+
+			return strings.EqualFold(name, ident[0])
+		})
+		if idx >= 0 {
+			return QualifiedIdentifier{Catalog: catalogs[idx], Identifier: ident[1:]}, nil
+		}
+	}
+
+	return QualifiedIdentifier{Identifier: ident}, nil
+}
@@ -0,0 +1,111 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package catalog_test
+
+import (
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIdentifier(t *testing.T) {
+	tests := []struct {
+		input string
+		opts  []catalog.IdentifierOpt
+		want  table.Identifier
+	}{
+		{"tbl", nil, table.Identifier{"tbl"}},
+		{"db.Events", nil, table.Identifier{"db", "Events"}},
+		{"a.b.c", nil, table.Identifier{"a", "b", "c"}},
+		{"db.`my.table`", nil, table.Identifier{"db", "my.table"}},
+		{"`db`.`tbl`", nil, table.Identifier{"db", "tbl"}},
+		{"db.`a``b`", nil, table.Identifier{"db", "a`b"}},
+		{"db.pre`.`post", nil, table.Identifier{"db", "pre.post"}},
+		{`db.my\.table`, nil, table.Identifier{"db", "my.table"}},
+		{`db.a\\b`, nil, table.Identifier{"db", `a\b`}},
+		{"DB.`Events`", []catalog.IdentifierOpt{catalog.WithCaseSensitive(false)}, table.Identifier{"db", "Events"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := catalog.ParseIdentifier(tt.input, tt.opts...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	for _, invalid := range []string{"", ".", "db.", ".tbl", "db..tbl", "db.`tbl", `db.tbl\`, "db.``"} {
+		t.Run("invalid "+invalid, func(t *testing.T) {
+			_, err := catalog.ParseIdentifier(invalid)
+			assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+		})
+	}
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	idents := []table.Identifier{
+		{"db", "tbl"},
+		{"db", "my.table"},
+		{"db", "a`b"},
+		{"ns", `back\slash`, "Mixed"},
+	}
+
+	for _, ident := range idents {
+		quoted := catalog.QuoteIdentifier(ident)
+		parsed, err := catalog.ParseIdentifier(quoted)
+		require.NoError(t, err, quoted)
+		assert.Equal(t, ident, parsed)
+	}
+
+	assert.Equal(t, "db.tbl", catalog.QuoteIdentifier(table.Identifier{"db", "tbl"}))
+	assert.Equal(t, "db.`my.table`", catalog.QuoteIdentifier(table.Identifier{"db", "my.table"}))
+	assert.Equal(t, table.Identifier{"db", "my.table"}, catalog.ToIdentifier("db.`my.table`"))
+}
+
+func TestParseQualifiedIdentifier(t *testing.T) {
+	catalogs := []string{"prod", "Dev"}
+
+	q, err := catalog.ParseQualifiedIdentifier("prod.db.tbl", catalogs)
+	require.NoError(t, err)
+	assert.Equal(t, catalog.QualifiedIdentifier{Catalog: "prod", Identifier: table.Identifier{"db", "tbl"}}, q)
+	assert.Equal(t, "prod.db.tbl", q.String())
+
+	q, err = catalog.ParseQualifiedIdentifier("db.tbl", catalogs)
+	require.NoError(t, err)
+	assert.Equal(t, catalog.QualifiedIdentifier{Identifier: table.Identifier{"db", "tbl"}}, q)
+
+	// a single part is never treated as a catalog name
+	q, err = catalog.ParseQualifiedIdentifier("prod", catalogs)
+	require.NoError(t, err)
+	assert.Equal(t, catalog.QualifiedIdentifier{Identifier: table.Identifier{"prod"}}, q)
+
+	q, err = catalog.ParseQualifiedIdentifier("dev.db.tbl", catalogs)
+	require.NoError(t, err)
+	assert.Empty(t, q.Catalog)
+
+	q, err = catalog.ParseQualifiedIdentifier("DEV.db.TBL", catalogs, catalog.WithCaseSensitive(false))
+	require.NoError(t, err)
+	assert.Equal(t, catalog.QualifiedIdentifier{Catalog: "Dev", Identifier: table.Identifier{"db", "tbl"}}, q)
+
+	_, err = catalog.ParseQualifiedIdentifier("prod..tbl", catalogs)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
@@ -18,6 +18,7 @@
 package internal
 
 import (
+	"bytes"
 	"compress/gzip"
 	"context"
 	"encoding/json"
@@ -39,6 +40,10 @@ import (
 	"github.com/google/uuid"
 )
 
+// WriteTableMetadata writes metadata to loc. If fs supports exclusive
+// writes, the file is created with a single conditional request that
+// fails if loc already exists, so that a concurrent writer of the same
+// location cannot be silently overwritten.
 func WriteTableMetadata(metadata table.Metadata, fs icebergio.WriteFileIO, loc string, compression string) (err error) {
 	switch compression {
 	case table.MetadataCompressionCodecNone, table.MetadataCompressionCodecGzip:
@@ -47,23 +52,32 @@ func WriteTableMetadata(metadata table.Metadata, fs icebergio.WriteFileIO, loc s
 		return fmt.Errorf("unsupported write metadata compression codec: %s", compression)
 	}
 
+	if efs, ok := fs.(icebergio.ExclusiveWriteIO); ok {
+		var buf bytes.Buffer
+		if err := encodeTableMetadata(metadata, &buf, compression); err != nil {
+			return err
+		}
+
+		return efs.WriteFileExclusive(loc, buf.Bytes())
+	}
+
 	out, err := fs.Create(loc)
 	if err != nil {
 		return err
 	}
 	defer internal.CheckedClose(out, &err)
 
-	var writer io.Writer = out
-	var compressWriter io.WriteCloser
+	return encodeTableMetadata(metadata, out, compression)
+}
+
+func encodeTableMetadata(metadata table.Metadata, out io.Writer, compression string) (err error) {
 	if compression == table.MetadataCompressionCodecGzip {
-		compressWriter = gzip.NewWriter(out)
-		writer = compressWriter
+		compressWriter := gzip.NewWriter(out)
 		defer internal.CheckedClose(compressWriter, &err)
+		out = compressWriter
 	}
 
-	err = json.NewEncoder(writer).Encode(metadata)
-
-	return
+	return json.NewEncoder(out).Encode(metadata)
 }
 
 func WriteMetadata(ctx context.Context, metadata table.Metadata, loc string, props iceberg.Properties) error {
@@ -82,6 +96,32 @@ func WriteMetadata(ctx context.Context, metadata table.Metadata, loc string, pro
 	return WriteTableMetadata(metadata, wfs, loc, compression)
 }
 
+// IOProps returns the properties used to construct the FileIO for a table:
+// the catalog properties overlaid with the table's own properties, so that
+// settings such as per-table S3 credentials take precedence.
+func IOProps(catprops iceberg.Properties, tblprops iceberg.Properties) iceberg.Properties {
+	out := maps.Clone(catprops)
+	if out == nil {
+		out = make(iceberg.Properties, len(tblprops))
+	}
+	maps.Copy(out, tblprops)
+
+	return out
+}
+
+// LoadTable reads the table metadata at metadataLoc using the catalog
+// properties and returns a table whose FileIO is configured from IOProps.
+func LoadTable(ctx context.Context, ident table.Identifier, metadataLoc string, catprops iceberg.Properties, cat table.CatalogIO) (*table.Table, error) {
+	tbl, err := table.NewFromLocation(ctx, ident, metadataLoc,
+		icebergio.LoadFSFunc(catprops, metadataLoc), cat)
+	if err != nil {
+		return nil, err
+	}
+
+	return table.New(ident, tbl.Metadata(), metadataLoc,
+		icebergio.LoadFSFunc(IOProps(catprops, tbl.Properties()), metadataLoc), cat), nil
+}
+
 func UpdateTableMetadata(base table.Metadata, updates []table.Update, metadataLoc string) (table.Metadata, error) {
 	return table.UpdateTableMetadata(base, updates, metadataLoc)
 }
@@ -116,8 +156,7 @@ func CreateStagedTable(ctx context.Context, catprops iceberg.Properties, nsprops
 		return table.StagedTable{}, err
 	}
 
-	ioProps := maps.Clone(catprops)
-	maps.Copy(ioProps, cfg.Properties)
+	ioProps := IOProps(catprops, cfg.Properties)
 
 	return table.StagedTable{
 		Table: table.New(
@@ -130,6 +169,20 @@ func CreateStagedTable(ctx context.Context, catprops iceberg.Properties, nsprops
 	}, nil
 }
 
+// StageCreateTable stages the creation of a table like CreateStagedTable
+// and returns a transaction which creates it through cat when committed.
+func StageCreateTable(ctx context.Context, catprops iceberg.Properties, nspropsFn GetNamespacePropsFn, ident table.Identifier, sc *iceberg.Schema, cat table.CatalogIO, opts ...catalog.CreateTableOpt) (*table.Transaction, error) {
+	staged, err := CreateStagedTable(ctx, catprops, nspropsFn, ident, sc, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	tbl := table.New(ident, staged.Metadata(), staged.MetadataLocation(),
+		icebergio.LoadFSFunc(IOProps(catprops, staged.Properties()), staged.MetadataLocation()), cat)
+
+	return tbl.NewCreateTransaction()
+}
+
 type GetNamespacePropsFn func(context.Context, table.Identifier) (iceberg.Properties, error)
 
 func ResolveTableLocation(ctx context.Context, loc, dbname, tablename string, catprops iceberg.Properties, nsprops GetNamespacePropsFn) (string, error) {
@@ -184,7 +237,7 @@ func ParseMetadataVersion(location string) int {
 	return v
 }
 
-func UpdateAndStageTable(ctx context.Context, current *table.Table, ident table.Identifier, reqs []table.Requirement, updates []table.Update, cat table.CatalogIO) (*table.StagedTable, error) {
+func UpdateAndStageTable(ctx context.Context, current *table.Table, ident table.Identifier, reqs []table.Requirement, updates []table.Update, catprops iceberg.Properties, cat table.CatalogIO) (*table.StagedTable, error) {
 	var (
 		baseMeta    table.Metadata
 		metadataLoc string
@@ -193,18 +246,12 @@ func UpdateAndStageTable(ctx context.Context, current *table.Table, ident table.
 	if current != nil {
 		for _, r := range reqs {
 			if err := r.Validate(current.Metadata()); err != nil {
-				return nil, err
+				return nil, fmt.Errorf("%w: %w", iceberg.ErrCommitConflict, err)
 			}
 		}
 
 		baseMeta = current.Metadata()
 		metadataLoc = current.MetadataLocation()
-	} else {
-		var err error
-		baseMeta, err = table.NewMetadata(iceberg.NewSchema(0), nil, table.UnsortedSortOrder, "", nil)
-		if err != nil {
-			return nil, err
-		}
 	}
 
 	updated, err := UpdateTableMetadata(baseMeta, updates, metadataLoc)
@@ -228,7 +275,7 @@ func UpdateAndStageTable(ctx context.Context, current *table.Table, ident table.
 			ident,
 			updated,
 			newLocation,
-			icebergio.LoadFSFunc(updated.Properties(), newLocation),
+			icebergio.LoadFSFunc(IOProps(catprops, updated.Properties()), newLocation),
 			cat,
 		),
 	}, nil
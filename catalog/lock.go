@@ -0,0 +1,91 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package catalog
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/apache/iceberg-go/table"
+)
+
+// Lock is a commit lock held on a table.
+type Lock interface {
+	// Release releases the lock so that other writers can commit.
+	Release(ctx context.Context) error
+}
+
+// LockManager serializes the commits to a table for catalogs whose backend
+// cannot atomically compare and swap the metadata location of a table,
+// such as Hive Metastores without lock support. Catalogs acquire the lock
+// of a table before reading its current metadata and release it once the
+// new metadata location has been stored.
+//
+// Implementations can be backed by anything providing mutual exclusion
+// between writers, e.g. a DynamoDB table with conditional writes, etcd
+// leases or Postgres advisory locks.
+type LockManager interface {
+	// Acquire blocks until the commit lock of the table is held, returning
+	// an error if it cannot be acquired or ctx is done first.
+	Acquire(ctx context.Context, identifier table.Identifier) (Lock, error)
+}
+
+// InMemoryLockManager is a LockManager that only serializes the commits
+// made from within the current process. It is suitable when a single
+// process writes to the tables, and for tests.
+type InMemoryLockManager struct {
+	mx    sync.Mutex
+	locks map[string]chan struct{}
+}
+
+// NewInMemoryLockManager returns a LockManager serializing the commits of
+// the current process.
+func NewInMemoryLockManager() *InMemoryLockManager {
+	return &InMemoryLockManager{locks: make(map[string]chan struct{})}
+}
+
+func (m *InMemoryLockManager) Acquire(ctx context.Context, identifier table.Identifier) (Lock, error) {
+	key := strings.Join(identifier, ".")
+
+	m.mx.Lock()
+	ch, ok := m.locks[key]
+	if !ok {
+		ch = make(chan struct{}, 1)
+		m.locks[key] = ch
+	}
+	m.mx.Unlock()
+
+	select {
+	case ch <- struct{}{}:
+		return &inMemoryLock{ch: ch}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+type inMemoryLock struct {
+	ch   chan struct{}
+	once sync.Once
+}
+
+func (l *inMemoryLock) Release(context.Context) error {
+	l.once.Do(func() { <-l.ch })
+
+	return nil
+}
@@ -0,0 +1,73 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package catalog_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryLockManager(t *testing.T) {
+	ctx := context.Background()
+	mgr := catalog.NewInMemoryLockManager()
+	ident := table.Identifier{"db", "tbl"}
+
+	lock, err := mgr.Acquire(ctx, ident)
+	require.NoError(t, err)
+
+	// other tables can be locked concurrently
+	other, err := mgr.Acquire(ctx, table.Identifier{"db", "other"})
+	require.NoError(t, err)
+	require.NoError(t, other.Release(ctx))
+
+	// the same table cannot
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = mgr.Acquire(timeoutCtx, ident)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	acquired := make(chan catalog.Lock)
+	go func() {
+		l, err := mgr.Acquire(ctx, ident)
+		assert.NoError(t, err)
+		acquired <- l
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("lock acquired while held")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	require.NoError(t, lock.Release(ctx))
+	// releasing twice is a no-op
+	require.NoError(t, lock.Release(ctx))
+
+	select {
+	case l := <-acquired:
+		require.NoError(t, l.Release(ctx))
+	case <-time.After(time.Second):
+		t.Fatal("lock not acquired after release")
+	}
+}
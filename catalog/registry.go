@@ -36,7 +36,7 @@ func (r registry) getKeys() []string {
 	regMutex.Lock()
 	defer regMutex.Unlock()
 
-	return slices.Collect(maps.Keys(r))
+	return slices.Sorted(maps.Keys(r))
 }
 
 func (r registry) set(catalogType string, reg Registrar) {
@@ -77,7 +77,15 @@ func (f RegistrarFunc) GetCatalog(ctx context.Context, catalogName string, props
 }
 
 // Register adds the new catalog type to the registry. If the catalog type is already registered, it will be replaced.
+//
+// This is the extension point for custom catalog implementations, such as a
+// proprietary metastore: registering a factory from an init function makes
+// the catalog available to Load, and to the iceberg CLI when linked into it,
+// whenever the "type" property is set to catalogType.
 func Register(catalogType string, reg Registrar) {
+	if catalogType == "" {
+		panic("catalog: Register catalog type is empty")
+	}
 	if reg == nil {
 		panic("catalog: RegisterCatalog catalog factory is nil")
 	}
@@ -89,8 +97,8 @@ func Unregister(catalogType string) {
 	defaultRegistry.remove(catalogType)
 }
 
-// GetRegisteredCatalogs returns the list of registered catalog names that can
-// be looked up via LoadCatalog.
+// GetRegisteredCatalogs returns the sorted list of registered catalog types
+// that can be looked up via Load.
 func GetRegisteredCatalogs() []string {
 	return defaultRegistry.getKeys()
 }
@@ -108,6 +116,12 @@ func GetRegisteredCatalogs() []string {
 // directory containing the ".iceberg-go.yaml" file. The name will also be passed to
 // the registered GetCatalog function.
 //
+// Every key of the catalog's configuration, whether from the file or from
+// GOICEBERG_CATALOG__<NAME>__<KEY> environment variables (see
+// config.Config.ApplyEnv), is passed to the catalog as a property unless
+// props already has a value for it. This mirrors PyIceberg, so that, for
+// example, "s3.endpoint" or "token" can be configured per catalog.
+//
 // The catalog registry will be checked for the catalog's type. The "type" property
 // is used first to search the catalog registry, with the passed properties taking
 // priority over any loaded config.
@@ -128,6 +142,30 @@ func GetRegisteredCatalogs() []string {
 //     as the REST endpoint, otherwise the URI is used as the endpoint. The REST catalog also
 //     registers "http" and "https" so that Load with a http/s URI will automatically
 //     load the REST Catalog.
+//
+//   - "hive" for a Hive Metastore, which also registers "thrift" so that a thrift://
+//     metastore URI loads the Hive catalog.
+//
+//   - "sql" for a catalog stored in a SQL database.
+//
+//   - "s3tables" for an Amazon S3 table bucket, identified by its ARN in the
+//     "s3tables.table-bucket-arn" or "warehouse" property. Default AWS credentials
+//     are used unless "s3tables.access-key-id" and "s3tables.secret-access-key" are set.
+//
+//   - "biglake" for Google BigLake Metastore, with the billed project in "biglake.project-id"
+//     and the warehouse in "warehouse". Application default credentials are used unless
+//     "biglake.jsonkey" or "biglake.keypath" is set.
+//
+//   - "snowflake" for read-only access to Snowflake-managed Iceberg tables. The "uri"
+//     is passed, without its snowflake:// prefix, as the DSN of the database/sql driver
+//     named by "snowflake.driver", which must be imported separately.
+//
+//   - "unity" for Databricks Unity Catalog, also registered as "uc", with the workspace URL
+//     in "uri" and the Unity Catalog catalog name in "warehouse". It authenticates with the
+//     personal access token in "token" or the service principal "credential", falling back
+//     to the DATABRICKS_HOST and DATABRICKS_TOKEN environment variables.
+//
+// Each catalog type is registered when its package is imported.
 func Load(ctx context.Context, name string, props iceberg.Properties) (Catalog, error) {
 	if name == "" {
 		name = config.EnvConfig.DefaultCatalog
@@ -135,18 +173,15 @@ func Load(ctx context.Context, name string, props iceberg.Properties) (Catalog,
 
 	conf := config.EnvConfig.Catalogs[name]
 	if props == nil {
-		props = iceberg.Properties{
-			"uri":        conf.URI,
-			"credential": conf.Credential,
-			"warehouse":  conf.Warehouse,
+		props = iceberg.Properties{}
+	}
+	for k, v := range conf.Props() {
+		if _, ok := props[k]; !ok {
+			props[k] = v
 		}
-	} else {
-		props["uri"] = props.Get("uri", conf.URI)
-		props["credential"] = props.Get("credential", conf.Credential)
-		props["warehouse"] = props.Get("warehouse", conf.Warehouse)
 	}
 
-	catalogType := props.Get("type", conf.CatalogType)
+	catalogType := props.Get("type", "")
 	if catalogType == "" {
 		if strings.Contains(props["uri"], "://") {
 			uri, err := url.Parse(props["uri"])
@@ -157,9 +192,15 @@ func Load(ctx context.Context, name string, props iceberg.Properties) (Catalog,
 		}
 	}
 
+	if catalogType == "" {
+		return nil, fmt.Errorf("%w: no \"type\" or \"uri\" configured for catalog %q",
+			ErrCatalogNotFound, name)
+	}
+
 	cat, ok := defaultRegistry.get(catalogType)
 	if !ok {
-		return nil, fmt.Errorf("%w: %s", ErrCatalogNotFound, catalogType)
+		return nil, fmt.Errorf("%w: %s (registered types: %s)", ErrCatalogNotFound,
+			catalogType, strings.Join(GetRegisteredCatalogs(), ", "))
 	}
 
 	return cat.GetCatalog(ctx, name, props)
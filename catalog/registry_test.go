@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"slices"
 	"testing"
 
 	"github.com/apache/iceberg-go"
@@ -98,6 +99,37 @@ func TestCatalogRegistry(t *testing.T) {
 
 func TestRegistryPanic(t *testing.T) {
 	assert.PanicsWithValue(t, "catalog: RegisterCatalog catalog factory is nil", func() { catalog.Register("foobar", nil) })
+	assert.PanicsWithValue(t, "catalog: Register catalog type is empty", func() {
+		catalog.Register("", catalog.RegistrarFunc(func(context.Context, string, iceberg.Properties) (catalog.Catalog, error) {
+			return nil, nil
+		}))
+	})
+}
+
+func TestLoadCustomCatalogType(t *testing.T) {
+	ctx := context.Background()
+	catalog.Register("mycat", catalog.RegistrarFunc(func(ctx context.Context, name string, props iceberg.Properties) (catalog.Catalog, error) {
+		assert.Equal(t, "metastore", name)
+		assert.Equal(t, "thrift://meta:9083", props.Get("uri", ""))
+
+		return nil, nil
+	}))
+	t.Cleanup(func() { catalog.Unregister("mycat") })
+
+	assert.Contains(t, catalog.GetRegisteredCatalogs(), "mycat")
+	assert.True(t, slices.IsSorted(catalog.GetRegisteredCatalogs()))
+
+	_, err := catalog.Load(ctx, "metastore", iceberg.Properties{"type": "mycat", "uri": "thrift://meta:9083"})
+	assert.NoError(t, err)
+
+	_, err = catalog.Load(ctx, "metastore", iceberg.Properties{"type": "unknown"})
+	assert.ErrorIs(t, err, catalog.ErrCatalogNotFound)
+	assert.ErrorContains(t, err, "registered types: ")
+	assert.ErrorContains(t, err, "mycat")
+
+	_, err = catalog.Load(ctx, "metastore", iceberg.Properties{})
+	assert.ErrorIs(t, err, catalog.ErrCatalogNotFound)
+	assert.ErrorContains(t, err, `no "type" or "uri" configured for catalog "metastore"`)
 }
 
 func TestCatalogWithEmptyName(t *testing.T) {
@@ -210,3 +242,32 @@ func TestRegistryFromConfig(t *testing.T) {
 	assert.Equal(t, "foobar", c.(*rest.Catalog).Name())
 	assert.Equal(t, "catalog_name", params.Get("warehouse"))
 }
+
+func TestLoadPassesConfiguredProperties(t *testing.T) {
+	ctx := context.Background()
+	defaultConf := config.EnvConfig
+	t.Cleanup(func() { config.EnvConfig = defaultConf })
+
+	config.EnvConfig.Catalogs = map[string]config.CatalogConfig{
+		"configured": {
+			CatalogType: "props-mock",
+			URI:         "http://localhost:8181/",
+			Properties:  map[string]string{"s3.endpoint": "http://localhost:9000", "token": "abc"},
+		},
+	}
+	catalog.Register("props-mock", catalog.RegistrarFunc(func(ctx context.Context, name string, props iceberg.Properties) (catalog.Catalog, error) {
+		assert.Equal(t, "configured", name)
+		assert.Equal(t, iceberg.Properties{
+			"type":        "props-mock",
+			"uri":         "http://localhost:8181/",
+			"s3.endpoint": "http://localhost:9000",
+			"token":       "override",
+		}, props)
+
+		return nil, nil
+	}))
+	t.Cleanup(func() { catalog.Unregister("props-mock") })
+
+	_, err := catalog.Load(ctx, "configured", iceberg.Properties{"token": "override"})
+	assert.NoError(t, err)
+}
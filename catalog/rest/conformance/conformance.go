@@ -0,0 +1,373 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package conformance is a suite of tests of the behavior of an Iceberg
+// REST catalog server, runnable against any implementation such as
+// Apache Polaris, Lakekeeper or Apache Gravitino. Tests of operations
+// whose endpoints the server does not declare in its config response
+// are skipped instead of failed.
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/catalog/rest"
+	"github.com/apache/iceberg-go/table"
+	"github.com/apache/iceberg-go/view"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSchema = iceberg.NewSchema(0,
+	iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+	iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String},
+)
+
+type suite struct {
+	cat *rest.Catalog
+	ns  table.Identifier
+
+	options
+}
+
+// Run runs the conformance suite against cat as subtests of t. The suite
+// creates a namespace holding everything it creates, and drops it again
+// when t finishes.
+func Run(t *testing.T, cat *rest.Catalog, opts ...Option) {
+	s := &suite{cat: cat, options: options{namespace: fmt.Sprintf("conformance_%08x", rand.Uint32())}}
+	for _, opt := range opts {
+		opt(&s.options)
+	}
+	s.ns = table.Identifier{s.namespace}
+
+	s.requireEndpoints(t, rest.EndpointCreateNamespace, rest.EndpointLoadNamespace)
+	require.NoError(t, cat.CreateNamespace(t.Context(), s.ns, iceberg.Properties{"owner": "conformance"}))
+	t.Cleanup(func() {
+		if s.cat.SupportsEndpoint(rest.EndpointDeleteNamespace) {
+			assert.NoError(t, s.cat.DropNamespace(context.Background(), s.ns))
+		}
+	})
+
+	t.Run("Namespaces", s.testNamespaces)
+	t.Run("Tables", s.testTables)
+	t.Run("Commits", s.testCommits)
+	t.Run("RenameTable", s.testRenameTable)
+	t.Run("Transactions", s.testTransactions)
+	t.Run("Views", s.testViews)
+	t.Run("Data", s.testData)
+}
+
+// requireEndpoints skips the test unless the server supports all of the
+// endpoints.
+func (s *suite) requireEndpoints(t *testing.T, endpoints ...rest.Endpoint) {
+	t.Helper()
+	for _, e := range endpoints {
+		if !s.cat.SupportsEndpoint(e) {
+			t.Skipf("server does not support %s", e)
+		}
+	}
+}
+
+func (s *suite) ident(name string) table.Identifier {
+	return append(slices.Clone(s.ns), name)
+}
+
+// createTable creates a table that is dropped when t finishes.
+func (s *suite) createTable(t *testing.T, name string, opts ...catalog.CreateTableOpt) *table.Table {
+	t.Helper()
+	s.requireEndpoints(t, rest.EndpointCreateTable, rest.EndpointDeleteTable)
+
+	ident := s.ident(name)
+	tbl, err := s.cat.CreateTable(t.Context(), ident, testSchema, append(slices.Clone(s.createTableOpts), opts...)...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.cat.DropTable(context.Background(), ident) })
+
+	return tbl
+}
+
+func (s *suite) testNamespaces(t *testing.T) {
+	ctx := t.Context()
+	missing := table.Identifier{s.namespace + "_missing"}
+
+	err := s.cat.CreateNamespace(ctx, s.ns, nil)
+	assert.ErrorIs(t, err, catalog.ErrNamespaceAlreadyExists)
+
+	exists, err := s.cat.CheckNamespaceExists(ctx, s.ns)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = s.cat.CheckNamespaceExists(ctx, missing)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	props, err := s.cat.LoadNamespaceProperties(ctx, s.ns)
+	require.NoError(t, err)
+	assert.Equal(t, "conformance", props["owner"])
+	_, err = s.cat.LoadNamespaceProperties(ctx, missing)
+	assert.ErrorIs(t, err, catalog.ErrNoSuchNamespace)
+
+	t.Run("List", func(t *testing.T) {
+		s.requireEndpoints(t, rest.EndpointListNamespaces)
+
+		namespaces, err := s.cat.ListNamespaces(t.Context(), nil)
+		require.NoError(t, err)
+		assert.True(t, slices.ContainsFunc(namespaces, func(ns table.Identifier) bool {
+			return slices.Equal(ns, s.ns)
+		}), "namespace %v not listed in %v", s.ns, namespaces)
+	})
+
+	t.Run("UpdateProperties", func(t *testing.T) {
+		s.requireEndpoints(t, rest.EndpointUpdateNamespace)
+
+		summary, err := s.cat.UpdateNamespaceProperties(t.Context(), s.ns,
+			[]string{"owner"}, iceberg.Properties{"comment": "conformance suite"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"owner"}, summary.Removed)
+		assert.Equal(t, []string{"comment"}, summary.Updated)
+
+		props, err := s.cat.LoadNamespaceProperties(t.Context(), s.ns)
+		require.NoError(t, err)
+		assert.Equal(t, "conformance suite", props["comment"])
+		assert.NotContains(t, props, "owner")
+	})
+}
+
+func (s *suite) testTables(t *testing.T) {
+	s.requireEndpoints(t, rest.EndpointLoadTable)
+	ctx := t.Context()
+	ident := s.ident("tbl")
+
+	created := s.createTable(t, "tbl", catalog.WithProperties(iceberg.Properties{"conformance": "true"}))
+	assert.True(t, testSchema.Equals(created.Schema()), "created schema %s", created.Schema())
+
+	_, err := s.cat.CreateTable(ctx, ident, testSchema, s.createTableOpts...)
+	assert.ErrorIs(t, err, catalog.ErrTableAlreadyExists)
+
+	tbl, err := s.cat.LoadTable(ctx, ident)
+	require.NoError(t, err)
+	assert.Equal(t, created.Metadata().TableUUID(), tbl.Metadata().TableUUID())
+	assert.Equal(t, "true", tbl.Properties()["conformance"])
+	assert.True(t, testSchema.Equals(tbl.Schema()), "loaded schema %s", tbl.Schema())
+	assert.Nil(t, tbl.CurrentSnapshot())
+
+	exists, err := s.cat.CheckTableExists(ctx, ident)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = s.cat.CheckTableExists(ctx, s.ident("missing"))
+	require.NoError(t, err)
+	assert.False(t, exists)
+	_, err = s.cat.LoadTable(ctx, s.ident("missing"))
+	assert.ErrorIs(t, err, catalog.ErrNoSuchTable)
+
+	t.Run("List", func(t *testing.T) {
+		s.requireEndpoints(t, rest.EndpointListTables)
+
+		var tables []table.Identifier
+		for tbl, err := range s.cat.ListTables(t.Context(), s.ns) {
+			require.NoError(t, err)
+			tables = append(tables, tbl)
+		}
+		assert.Equal(t, []table.Identifier{ident}, tables)
+	})
+
+	require.NoError(t, s.cat.DropTable(ctx, ident))
+	exists, err = s.cat.CheckTableExists(ctx, ident)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	assert.ErrorIs(t, s.cat.DropTable(ctx, ident), catalog.ErrNoSuchTable)
+}
+
+func (s *suite) testCommits(t *testing.T) {
+	s.requireEndpoints(t, rest.EndpointLoadTable, rest.EndpointUpdateTable)
+	ctx := t.Context()
+	tbl := s.createTable(t, "commits")
+
+	tx := tbl.NewTransaction()
+	require.NoError(t, tx.SetProperties(iceberg.Properties{"k": "v"}))
+	updated, err := tx.Commit(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "v", updated.Properties()["k"])
+	assert.NotEqual(t, tbl.MetadataLocation(), updated.MetadataLocation())
+
+	// schema evolution guarded by the current schema id
+	evolved := iceberg.NewSchema(1, append(testSchema.Fields(), iceberg.NestedField{
+		ID: 3, Name: "ts", Type: iceberg.PrimitiveTypes.TimestampTz,
+	})...)
+	_, _, err = s.cat.CommitTable(ctx, tbl.Identifier(),
+		[]table.Requirement{table.AssertCurrentSchemaID(tbl.Schema().ID), table.AssertLastAssignedFieldID(2)},
+		[]table.Update{table.NewAddSchemaUpdate(evolved), table.NewSetCurrentSchemaUpdate(-1)})
+	require.NoError(t, err)
+
+	loaded, err := s.cat.LoadTable(ctx, tbl.Identifier())
+	require.NoError(t, err)
+	assert.Equal(t, "v", loaded.Properties()["k"])
+	assert.Equal(t, []string{"id", "data", "ts"}, fieldNames(loaded.Schema()))
+
+	// commits whose requirements no longer hold are rejected as conflicts
+	stale := int64(1)
+	_, _, err = s.cat.CommitTable(ctx, tbl.Identifier(),
+		[]table.Requirement{table.AssertRefSnapshotID(table.MainBranch, &stale)},
+		[]table.Update{table.NewSetPropertiesUpdate(iceberg.Properties{"k": "stale"})})
+	assert.ErrorIs(t, err, iceberg.ErrCommitConflict)
+	assert.True(t, iceberg.IsRetryable(err))
+
+	_, _, err = s.cat.CommitTable(ctx, tbl.Identifier(),
+		[]table.Requirement{table.AssertCurrentSchemaID(tbl.Schema().ID)},
+		[]table.Update{table.NewSetPropertiesUpdate(iceberg.Properties{"k": "stale"})})
+	assert.ErrorIs(t, err, iceberg.ErrCommitConflict)
+
+	loaded, err = s.cat.LoadTable(ctx, tbl.Identifier())
+	require.NoError(t, err)
+	assert.Equal(t, "v", loaded.Properties()["k"])
+}
+
+func (s *suite) testRenameTable(t *testing.T) {
+	s.requireEndpoints(t, rest.EndpointRenameTable, rest.EndpointLoadTable)
+	ctx := t.Context()
+	tbl := s.createTable(t, "rename_from")
+	to := s.ident("rename_to")
+
+	renamed, err := s.cat.RenameTable(ctx, tbl.Identifier(), to)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.cat.DropTable(context.Background(), to) })
+	assert.Equal(t, to, renamed.Identifier())
+	assert.Equal(t, tbl.Metadata().TableUUID(), renamed.Metadata().TableUUID())
+
+	exists, err := s.cat.CheckTableExists(ctx, tbl.Identifier())
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = s.cat.RenameTable(ctx, tbl.Identifier(), s.ident("rename_other"))
+	assert.ErrorIs(t, err, catalog.ErrNoSuchTable)
+}
+
+func (s *suite) testTransactions(t *testing.T) {
+	s.requireEndpoints(t, rest.EndpointCommitTransaction, rest.EndpointLoadTable)
+	ctx := t.Context()
+	first, second := s.createTable(t, "tx_first"), s.createTable(t, "tx_second")
+
+	commit := func(tbl *table.Table, props iceberg.Properties) table.TableCommit {
+		tx := tbl.NewTransaction()
+		require.NoError(t, tx.SetProperties(props))
+		c, err := tx.TableCommit()
+		require.NoError(t, err)
+
+		return c
+	}
+
+	require.NoError(t, s.cat.CommitTransaction(ctx,
+		commit(first, iceberg.Properties{"tx": "1"}), commit(second, iceberg.Properties{"tx": "1"})))
+
+	stale := int64(1)
+	failing := commit(second, iceberg.Properties{"tx": "2"})
+	failing.Requirements = append(failing.Requirements, table.AssertRefSnapshotID(table.MainBranch, &stale))
+	err := s.cat.CommitTransaction(ctx, commit(first, iceberg.Properties{"tx": "2"}), failing)
+	assert.ErrorIs(t, err, iceberg.ErrCommitConflict)
+
+	// neither table sees the changes of the failed transaction
+	for _, tbl := range []*table.Table{first, second} {
+		loaded, err := s.cat.LoadTable(ctx, tbl.Identifier())
+		require.NoError(t, err)
+		assert.Equal(t, "1", loaded.Properties()["tx"], "table %v", tbl.Identifier())
+	}
+}
+
+func (s *suite) testViews(t *testing.T) {
+	s.requireEndpoints(t, rest.EndpointCreateView, rest.EndpointLoadView, rest.EndpointDeleteView)
+	ctx := t.Context()
+	ident := s.ident("view")
+
+	version, err := view.NewVersion(1, 1, []view.Representation{
+		view.NewRepresentation("SELECT 1 AS id, 'a' AS data", "spark"),
+	}, s.ns)
+	require.NoError(t, err)
+
+	_, err = s.cat.CreateView(ctx, ident, version, testSchema,
+		catalog.WithViewProperties(iceberg.Properties{"conformance": "true"}))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.cat.DropView(context.Background(), ident) })
+
+	v, err := s.cat.LoadView(ctx, ident)
+	require.NoError(t, err)
+	assert.Equal(t, "true", v.Properties()["conformance"])
+
+	exists, err := s.cat.CheckViewExists(ctx, ident)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	if s.cat.SupportsEndpoint(rest.EndpointListViews) {
+		var views []table.Identifier
+		for v, err := range s.cat.ListViews(ctx, s.ns) {
+			require.NoError(t, err)
+			views = append(views, v)
+		}
+		assert.Equal(t, []table.Identifier{ident}, views)
+	}
+
+	require.NoError(t, s.cat.DropView(ctx, ident))
+	exists, err = s.cat.CheckViewExists(ctx, ident)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	_, err = s.cat.LoadView(ctx, ident)
+	assert.ErrorIs(t, err, catalog.ErrNoSuchView)
+}
+
+func (s *suite) testData(t *testing.T) {
+	if !s.dataTests {
+		t.Skip("data tests are not enabled")
+	}
+	s.requireEndpoints(t, rest.EndpointLoadTable, rest.EndpointUpdateTable)
+	ctx := t.Context()
+	tbl := s.createTable(t, "data")
+
+	arrSchema, err := table.SchemaToArrowSchema(testSchema, nil, false, false)
+	require.NoError(t, err)
+	data, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{
+		`[{"id": 1, "data": "a"}, {"id": 2, "data": "b"}, {"id": 3, "data": null}]`,
+	})
+	require.NoError(t, err)
+	defer data.Release()
+
+	tbl, err = tbl.AppendTable(ctx, data, data.NumRows(), nil)
+	require.NoError(t, err)
+	require.NotNil(t, tbl.CurrentSnapshot())
+
+	loaded, err := s.cat.LoadTable(ctx, tbl.Identifier())
+	require.NoError(t, err)
+	assert.Equal(t, tbl.CurrentSnapshot().SnapshotID, loaded.CurrentSnapshot().SnapshotID)
+
+	result, err := loaded.Scan().ToArrowTable(ctx)
+	require.NoError(t, err)
+	defer result.Release()
+	assert.EqualValues(t, 3, result.NumRows())
+}
+
+func fieldNames(sc *iceberg.Schema) []string {
+	names := make([]string, 0, sc.NumFields())
+	for _, f := range sc.Fields() {
+		names = append(names, f.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,69 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build integration
+
+package conformance_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog/rest"
+	"github.com/apache/iceberg-go/catalog/rest/conformance"
+	"github.com/apache/iceberg-go/io"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConformance runs the suite against the REST catalog at
+// ICEBERG_REST_URI, or against the local REST catalog of the integration
+// environment if it is not set. ICEBERG_REST_WAREHOUSE, ICEBERG_REST_TOKEN
+// and ICEBERG_REST_CREDENTIAL configure the catalog, and
+// ICEBERG_REST_DATA_TESTS=true enables the tests writing data.
+func TestConformance(t *testing.T) {
+	uri := os.Getenv("ICEBERG_REST_URI")
+
+	var opts []rest.Option
+	if uri == "" {
+		uri = "http://localhost:8181"
+		opts = append(opts, rest.WithAdditionalProps(iceberg.Properties{
+			io.S3Region:          "us-east-1",
+			io.S3AccessKeyID:     "admin",
+			io.S3SecretAccessKey: "password",
+		}))
+	}
+	if warehouse := os.Getenv("ICEBERG_REST_WAREHOUSE"); warehouse != "" {
+		opts = append(opts, rest.WithWarehouseLocation(warehouse))
+	}
+	if token := os.Getenv("ICEBERG_REST_TOKEN"); token != "" {
+		opts = append(opts, rest.WithOAuthToken(token))
+	}
+	if cred := os.Getenv("ICEBERG_REST_CREDENTIAL"); cred != "" {
+		opts = append(opts, rest.WithCredential(cred))
+	}
+
+	cat, err := rest.NewCatalog(t.Context(), "conformance", uri, opts...)
+	require.NoError(t, err)
+
+	var suiteOpts []conformance.Option
+	if os.Getenv("ICEBERG_REST_DATA_TESTS") == "true" {
+		suiteOpts = append(suiteOpts, conformance.WithDataTests())
+	}
+
+	conformance.Run(t, cat, suiteOpts...)
+}
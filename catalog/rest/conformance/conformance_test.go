@@ -0,0 +1,134 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package conformance_test
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog/rest"
+	"github.com/apache/iceberg-go/catalog/rest/conformance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// namespaceServer is an in-memory REST catalog serving only the
+// namespace endpoints, and declaring just those in its config.
+type namespaceServer struct {
+	mu         sync.Mutex
+	namespaces map[string]iceberg.Properties
+}
+
+func (s *namespaceServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	notFound := func() {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{
+			"message": "namespace not found", "type": "NoSuchNamespaceException", "code": 404,
+		}})
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, "/v1/")
+	name, sub, _ := strings.Cut(strings.TrimPrefix(path, "namespaces/"), "/")
+	props, exists := s.namespaces[name]
+
+	switch {
+	case path == "config":
+		json.NewEncoder(w).Encode(map[string]any{
+			"defaults": map[string]any{}, "overrides": map[string]any{},
+			"endpoints": []string{
+				rest.EndpointListNamespaces.String(), rest.EndpointLoadNamespace.String(),
+				rest.EndpointCreateNamespace.String(), rest.EndpointUpdateNamespace.String(),
+				rest.EndpointDeleteNamespace.String(),
+			},
+		})
+	case path == "namespaces" && req.Method == http.MethodGet:
+		names := slices.Sorted(maps.Keys(s.namespaces))
+		out := make([][]string, len(names))
+		for i, n := range names {
+			out[i] = []string{n}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"namespaces": out})
+	case path == "namespaces" && req.Method == http.MethodPost:
+		var body struct {
+			Namespace  []string           `json:"namespace"`
+			Properties iceberg.Properties `json:"properties"`
+		}
+		json.NewDecoder(req.Body).Decode(&body)
+		if _, ok := s.namespaces[body.Namespace[0]]; ok {
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{
+				"message": "namespace exists", "type": "AlreadyExistsException", "code": 409,
+			}})
+
+			return
+		}
+		if body.Properties == nil {
+			body.Properties = iceberg.Properties{}
+		}
+		s.namespaces[body.Namespace[0]] = body.Properties
+		json.NewEncoder(w).Encode(body)
+	case !exists:
+		notFound()
+	case sub == "properties" && req.Method == http.MethodPost:
+		var body struct {
+			Removals []string           `json:"removals"`
+			Updates  iceberg.Properties `json:"updates"`
+		}
+		json.NewDecoder(req.Body).Decode(&body)
+		for _, k := range body.Removals {
+			delete(props, k)
+		}
+		maps.Copy(props, body.Updates)
+		json.NewEncoder(w).Encode(map[string]any{
+			"removed": body.Removals, "updated": slices.Sorted(maps.Keys(body.Updates)), "missing": []string{},
+		})
+	case sub == "" && req.Method == http.MethodGet:
+		json.NewEncoder(w).Encode(map[string]any{"namespace": []string{name}, "properties": props})
+	case sub == "" && req.Method == http.MethodDelete:
+		delete(s.namespaces, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotImplemented)
+	}
+}
+
+func TestRun(t *testing.T) {
+	srv := &namespaceServer{namespaces: map[string]iceberg.Properties{}}
+	httpSrv := httptest.NewServer(srv)
+	defer httpSrv.Close()
+
+	cat, err := rest.NewCatalog(t.Context(), "conformance", httpSrv.URL)
+	require.NoError(t, err)
+
+	t.Run("suite", func(t *testing.T) {
+		conformance.Run(t, cat, conformance.WithNamespace("ns"))
+	})
+
+	// the suite drops the namespace it created
+	assert.Empty(t, srv.namespaces)
+}
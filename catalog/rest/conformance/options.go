@@ -0,0 +1,53 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package conformance
+
+import "github.com/apache/iceberg-go/catalog"
+
+type Option func(*options)
+
+// WithNamespace sets the namespace the suite creates its tables and
+// views in. It must not exist yet and is dropped when the suite
+// finishes. By default a randomly named namespace is used.
+func WithNamespace(namespace string) Option {
+	return func(o *options) {
+		o.namespace = namespace
+	}
+}
+
+// WithCreateTableOpts adds options to every table the suite creates, for
+// example catalog.WithLocation for servers that require table locations.
+func WithCreateTableOpts(opts ...catalog.CreateTableOpt) Option {
+	return func(o *options) {
+		o.createTableOpts = append(o.createTableOpts, opts...)
+	}
+}
+
+// WithDataTests enables the tests that write and read back data files,
+// which need working storage credentials for the server's warehouse.
+func WithDataTests() Option {
+	return func(o *options) {
+		o.dataTests = true
+	}
+}
+
+type options struct {
+	namespace       string
+	createTableOpts []catalog.CreateTableOpt
+	dataTests       bool
+}
@@ -0,0 +1,140 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/apache/iceberg-go"
+)
+
+// ErrEndpointNotSupported is returned, without a request being sent, for
+// operations whose endpoint the server did not declare in its config.
+var ErrEndpointNotSupported = fmt.Errorf("%w: endpoint not supported by the REST server", iceberg.ErrNotImplemented)
+
+// Endpoint is a route of the REST catalog API, as listed in the
+// "endpoints" of the server's config response, for example
+// "GET /v1/{prefix}/namespaces".
+type Endpoint struct {
+	Method string
+	Path   string
+}
+
+// ParseEndpoint parses an endpoint of the form "<method> <path>".
+func ParseEndpoint(s string) (Endpoint, error) {
+	method, path, ok := strings.Cut(strings.TrimSpace(s), " ")
+	path = strings.TrimSpace(path)
+	if !ok || method == "" || !strings.HasPrefix(path, "/") {
+		return Endpoint{}, fmt.Errorf("%w: invalid endpoint %q, expected '<method> <path>'",
+			iceberg.ErrInvalidArgument, s)
+	}
+
+	return Endpoint{Method: strings.ToUpper(method), Path: path}, nil
+}
+
+func (e Endpoint) String() string { return e.Method + " " + e.Path }
+
+const (
+	pathNamespaces = "/v1/{prefix}/namespaces"
+	pathNamespace  = pathNamespaces + "/{namespace}"
+	pathTables     = pathNamespace + "/tables"
+	pathTable      = pathTables + "/{table}"
+	pathViews      = pathNamespace + "/views"
+	pathView       = pathViews + "/{view}"
+)
+
+var (
+	EndpointListNamespaces    = Endpoint{http.MethodGet, pathNamespaces}
+	EndpointLoadNamespace     = Endpoint{http.MethodGet, pathNamespace}
+	EndpointNamespaceExists   = Endpoint{http.MethodHead, pathNamespace}
+	EndpointCreateNamespace   = Endpoint{http.MethodPost, pathNamespaces}
+	EndpointUpdateNamespace   = Endpoint{http.MethodPost, pathNamespace + "/properties"}
+	EndpointDeleteNamespace   = Endpoint{http.MethodDelete, pathNamespace}
+	EndpointListTables        = Endpoint{http.MethodGet, pathTables}
+	EndpointLoadTable         = Endpoint{http.MethodGet, pathTable}
+	EndpointTableExists       = Endpoint{http.MethodHead, pathTable}
+	EndpointCreateTable       = Endpoint{http.MethodPost, pathTables}
+	EndpointUpdateTable       = Endpoint{http.MethodPost, pathTable}
+	EndpointDeleteTable       = Endpoint{http.MethodDelete, pathTable}
+	EndpointRenameTable       = Endpoint{http.MethodPost, "/v1/{prefix}/tables/rename"}
+	EndpointRegisterTable     = Endpoint{http.MethodPost, pathNamespace + "/register"}
+	EndpointReportMetrics     = Endpoint{http.MethodPost, pathTable + "/metrics"}
+	EndpointCommitTransaction = Endpoint{http.MethodPost, "/v1/{prefix}/transactions/commit"}
+	EndpointListViews         = Endpoint{http.MethodGet, pathViews}
+	EndpointLoadView          = Endpoint{http.MethodGet, pathView}
+	EndpointViewExists        = Endpoint{http.MethodHead, pathView}
+	EndpointCreateView        = Endpoint{http.MethodPost, pathViews}
+	EndpointUpdateView        = Endpoint{http.MethodPost, pathView}
+	EndpointDeleteView        = Endpoint{http.MethodDelete, pathView}
+	EndpointRenameView        = Endpoint{http.MethodPost, "/v1/{prefix}/views/rename"}
+)
+
+// Endpoints returns the endpoints the server declared in its config
+// response. It returns nil for servers that predate endpoint
+// negotiation, for which every endpoint is assumed to be supported.
+func (r *Catalog) Endpoints() []Endpoint {
+	if r.endpoints == nil {
+		return nil
+	}
+
+	out := make([]Endpoint, 0, len(r.endpoints))
+	for e := range r.endpoints {
+		out = append(out, e)
+	}
+	slices.SortFunc(out, func(a, b Endpoint) int { return strings.Compare(a.String(), b.String()) })
+
+	return out
+}
+
+// SupportsEndpoint reports whether the server supports the endpoint.
+func (r *Catalog) SupportsEndpoint(e Endpoint) bool {
+	if r.endpoints == nil {
+		return true
+	}
+	_, ok := r.endpoints[e]
+
+	return ok
+}
+
+func (r *Catalog) checkEndpoint(e Endpoint) error {
+	if !r.SupportsEndpoint(e) {
+		return fmt.Errorf("%w: %s", ErrEndpointNotSupported, e)
+	}
+
+	return nil
+}
+
+func parseEndpoints(endpoints []string) (map[Endpoint]struct{}, error) {
+	if endpoints == nil {
+		return nil, nil
+	}
+
+	out := make(map[Endpoint]struct{}, len(endpoints))
+	for _, s := range endpoints {
+		e, err := ParseEndpoint(s)
+		if err != nil {
+			return nil, err
+		}
+		out[e] = struct{}{}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,132 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package rest_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog/rest"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEndpoint(t *testing.T) {
+	e, err := rest.ParseEndpoint("get /v1/{prefix}/namespaces")
+	require.NoError(t, err)
+	assert.Equal(t, rest.EndpointListNamespaces, e)
+	assert.Equal(t, "GET /v1/{prefix}/namespaces", e.String())
+
+	for _, s := range []string{"", "GET", "GET namespaces", "/v1/{prefix}/namespaces"} {
+		_, err := rest.ParseEndpoint(s)
+		assert.ErrorIs(t, err, iceberg.ErrInvalidArgument, s)
+	}
+}
+
+func TestEndpointNegotiation(t *testing.T) {
+	var (
+		endpoints []string
+		requests  []string
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/config", func(w http.ResponseWriter, req *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"defaults": map[string]any{}, "overrides": map[string]any{}, "endpoints": endpoints,
+		})
+	})
+	mux.HandleFunc("/v1/namespaces/ns", func(w http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		json.NewEncoder(w).Encode(map[string]any{"namespace": []string{"ns"}, "properties": map[string]string{}})
+	})
+	mux.HandleFunc("/v1/namespaces/missing", func(w http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{
+			"message": "not found", "type": "NoSuchNamespaceException", "code": 404,
+		}})
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	ctx := context.Background()
+
+	t.Run("legacy server", func(t *testing.T) {
+		endpoints, requests = nil, nil
+		cat, err := rest.NewCatalog(ctx, "rest", srv.URL)
+		require.NoError(t, err)
+
+		assert.Nil(t, cat.Endpoints())
+		assert.True(t, cat.SupportsEndpoint(rest.EndpointRenameView))
+
+		exists, err := cat.CheckNamespaceExists(ctx, table.Identifier{"ns"})
+		require.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, []string{"HEAD /v1/namespaces/ns"}, requests)
+	})
+
+	t.Run("declared endpoints", func(t *testing.T) {
+		endpoints = []string{rest.EndpointLoadNamespace.String(), rest.EndpointListNamespaces.String()}
+		requests = nil
+		cat, err := rest.NewCatalog(ctx, "rest", srv.URL)
+		require.NoError(t, err)
+
+		assert.Equal(t, []rest.Endpoint{rest.EndpointListNamespaces, rest.EndpointLoadNamespace}, cat.Endpoints())
+		assert.False(t, cat.SupportsEndpoint(rest.EndpointNamespaceExists))
+
+		// existence checks fall back to loading when HEAD is not declared
+		exists, err := cat.CheckNamespaceExists(ctx, table.Identifier{"ns"})
+		require.NoError(t, err)
+		assert.True(t, exists)
+		exists, err = cat.CheckNamespaceExists(ctx, table.Identifier{"missing"})
+		require.NoError(t, err)
+		assert.False(t, exists)
+		assert.Equal(t, []string{"GET /v1/namespaces/ns", "GET /v1/namespaces/missing"}, requests)
+
+		// undeclared operations fail without a request
+		requests = nil
+		_, err = cat.LoadTable(ctx, table.Identifier{"ns", "tbl"})
+		assert.ErrorIs(t, err, rest.ErrEndpointNotSupported)
+		assert.ErrorIs(t, err, iceberg.ErrNotImplemented)
+		assert.ErrorContains(t, err, "GET /v1/{prefix}/namespaces/{namespace}/tables/{table}")
+
+		_, err = cat.CheckTableExists(ctx, table.Identifier{"ns", "tbl"})
+		assert.ErrorIs(t, err, rest.ErrEndpointNotSupported)
+		assert.ErrorIs(t, cat.CreateNamespace(ctx, table.Identifier{"other"}, nil), rest.ErrEndpointNotSupported)
+		for _, err := range cat.ListViews(ctx, table.Identifier{"ns"}) {
+			assert.ErrorIs(t, err, rest.ErrEndpointNotSupported)
+		}
+
+		assert.Empty(t, requests)
+	})
+
+	t.Run("invalid endpoint", func(t *testing.T) {
+		endpoints = []string{"bogus"}
+		_, err := rest.NewCatalog(ctx, "rest", srv.URL)
+		assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	})
+}
@@ -46,7 +46,10 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 )
 
-var _ catalog.Catalog = (*Catalog)(nil)
+var (
+	_ catalog.TransactionalCatalog = (*Catalog)(nil)
+	_ catalog.RegisteringCatalog   = (*Catalog)(nil)
+)
 
 const (
 	pageSizeKey contextKey = "page_size"
@@ -81,8 +84,8 @@ var (
 	ErrAuthorizationExpired = fmt.Errorf("%w: authorization expired", ErrRESTError)
 	ErrServiceUnavailable   = fmt.Errorf("%w: service unavailable", ErrRESTError)
 	ErrServerError          = fmt.Errorf("%w: server error", ErrRESTError)
-	ErrCommitFailed         = fmt.Errorf("%w: commit failed, refresh and try again", ErrRESTError)
-	ErrCommitStateUnknown   = fmt.Errorf("%w: commit failed due to unknown reason", ErrRESTError)
+	ErrCommitFailed         = fmt.Errorf("%w: commit failed, refresh and try again: %w", ErrRESTError, iceberg.ErrCommitConflict)
+	ErrCommitStateUnknown   = fmt.Errorf("%w: commit failed due to unknown reason: %w", ErrRESTError, iceberg.ErrCommitStateUnknown)
 	ErrOAuthError           = fmt.Errorf("%w: oauth error", ErrRESTError)
 )
 
@@ -133,11 +136,17 @@ func (t *commitTableResponse) UnmarshalJSON(b []byte) (err error) {
 	return err
 }
 
+type storageCredential struct {
+	Prefix string             `json:"prefix"`
+	Config iceberg.Properties `json:"config"`
+}
+
 type loadTableResponse struct {
-	MetadataLoc string             `json:"metadata-location"`
-	RawMetadata json.RawMessage    `json:"metadata"`
-	Config      iceberg.Properties `json:"config"`
-	Metadata    table.Metadata     `json:"-"`
+	MetadataLoc        string              `json:"metadata-location"`
+	RawMetadata        json.RawMessage     `json:"metadata"`
+	Config             iceberg.Properties  `json:"config"`
+	StorageCredentials []storageCredential `json:"storage-credentials"`
+	Metadata           table.Metadata      `json:"-"`
 }
 
 func (t *loadTableResponse) UnmarshalJSON(b []byte) (err error) {
@@ -146,9 +155,27 @@ func (t *loadTableResponse) UnmarshalJSON(b []byte) (err error) {
 		return err
 	}
 
-	t.Metadata, err = table.ParseMetadataBytes(t.RawMetadata)
+	if t.Metadata, err = table.ParseMetadataBytes(t.RawMetadata); err != nil {
+		return err
+	}
 
-	return err
+	// vended credentials for the most specific prefix of the table
+	// location take precedence over the table config
+	var best *storageCredential
+	for i, cred := range t.StorageCredentials {
+		if strings.HasPrefix(t.Metadata.Location(), cred.Prefix) &&
+			(best == nil || len(cred.Prefix) > len(best.Prefix)) {
+			best = &t.StorageCredentials[i]
+		}
+	}
+	if best != nil {
+		if t.Config == nil {
+			t.Config = iceberg.Properties{}
+		}
+		maps.Copy(t.Config, best.Config)
+	}
+
+	return nil
 }
 
 type createTableRequest struct {
@@ -164,6 +191,7 @@ type createTableRequest struct {
 type configResponse struct {
 	Defaults  iceberg.Properties `json:"defaults"`
 	Overrides iceberg.Properties `json:"overrides"`
+	Endpoints []string           `json:"endpoints"`
 }
 
 type sessionTransport struct {
@@ -455,8 +483,9 @@ type Catalog struct {
 	baseURI *url.URL
 	cl      *http.Client
 
-	name  string
-	props iceberg.Properties
+	name      string
+	props     iceberg.Properties
+	endpoints map[Endpoint]struct{}
 }
 
 func newCatalogFromProps(ctx context.Context, name string, uri string, p iceberg.Properties) (*Catalog, error) {
@@ -598,6 +627,10 @@ func (r *Catalog) fetchConfig(ctx context.Context, opts *options) (*http.Client,
 		return nil, nil, err
 	}
 
+	if r.endpoints, err = parseEndpoints(rsp.Endpoints); err != nil {
+		return nil, nil, err
+	}
+
 	cfg := rsp.Defaults
 	if cfg == nil {
 		cfg = iceberg.Properties{}
@@ -665,7 +698,32 @@ func (r *Catalog) ListTables(ctx context.Context, namespace table.Identifier) it
 	}
 }
 
+// ListTablesPage returns a single page of the tables in namespace, passing
+// the page token through to the catalog. The REST protocol does not return
+// metadata locations when listing tables, so they are always left empty.
+func (r *Catalog) ListTablesPage(ctx context.Context, namespace table.Identifier, pageToken string, pageSize int, _ ...catalog.ListTablesOpt) ([]catalog.TableInfo, string, error) {
+	if pageSize <= 0 {
+		pageSize = r.getPageSize(ctx)
+	}
+
+	idents, next, err := r.listTablesPage(ctx, namespace, pageToken, pageSize)
+	if err != nil {
+		return nil, "", err
+	}
+
+	tables := make([]catalog.TableInfo, len(idents))
+	for i, ident := range idents {
+		tables[i] = catalog.TableInfo{Identifier: ident}
+	}
+
+	return tables, next, nil
+}
+
 func (r *Catalog) listTablesPage(ctx context.Context, namespace table.Identifier, pageToken string, pageSize int) ([]table.Identifier, string, error) {
+	if err := r.checkEndpoint(EndpointListTables); err != nil {
+		return nil, "", err
+	}
+
 	if err := checkValidNamespace(namespace); err != nil {
 		return nil, "", err
 	}
@@ -707,11 +765,41 @@ func splitIdentForPath(ident table.Identifier) (string, string, error) {
 }
 
 func (r *Catalog) CreateTable(ctx context.Context, identifier table.Identifier, schema *iceberg.Schema, opts ...catalog.CreateTableOpt) (*table.Table, error) {
-	ns, tbl, err := splitIdentForPath(identifier)
+	ret, config, err := r.createTable(ctx, identifier, schema, false, opts...)
 	if err != nil {
 		return nil, err
 	}
 
+	return r.tableFromResponse(ctx, identifier, ret.Metadata, ret.MetadataLoc, config)
+}
+
+// StageCreateTable asks the catalog to stage the creation of a table and
+// returns a transaction which creates it when committed, together with any
+// changes staged in the transaction.
+func (r *Catalog) StageCreateTable(ctx context.Context, identifier table.Identifier, schema *iceberg.Schema, opts ...catalog.CreateTableOpt) (*table.Transaction, error) {
+	ret, config, err := r.createTable(ctx, identifier, schema, true, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// staged tables have no metadata file yet, so the file IO is
+	// resolved from the table location instead
+	tbl := table.New(identifier, ret.Metadata, ret.MetadataLoc,
+		iceio.LoadFSFunc(config, ret.Metadata.Location()), r)
+
+	return tbl.NewCreateTransaction()
+}
+
+func (r *Catalog) createTable(ctx context.Context, identifier table.Identifier, schema *iceberg.Schema, stage bool, opts ...catalog.CreateTableOpt) (*loadTableResponse, iceberg.Properties, error) {
+	if err := r.checkEndpoint(EndpointCreateTable); err != nil {
+		return nil, nil, err
+	}
+
+	ns, tbl, err := splitIdentForPath(identifier)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	cfg := catalog.NewCreateTableCfg()
 	for _, o := range opts {
 		o(&cfg)
@@ -727,24 +815,28 @@ func (r *Catalog) CreateTable(ctx context.Context, identifier table.Identifier,
 		Location:      cfg.Location,
 		PartitionSpec: cfg.PartitionSpec,
 		WriteOrder:    &cfg.SortOrder,
-		StageCreate:   false,
+		StageCreate:   stage,
 		Props:         cfg.Properties,
 	}
 
 	ret, err := doPost[createTableRequest, loadTableResponse](ctx, r.baseURI, []string{"namespaces", ns, "tables"}, payload,
 		r.cl, map[int]error{http.StatusNotFound: catalog.ErrNoSuchNamespace, http.StatusConflict: catalog.ErrTableAlreadyExists})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	config := maps.Clone(r.props)
 	maps.Copy(config, ret.Metadata.Properties())
 	maps.Copy(config, ret.Config)
 
-	return r.tableFromResponse(ctx, identifier, ret.Metadata, ret.MetadataLoc, config)
+	return &ret, config, nil
 }
 
 func (r *Catalog) CommitTable(ctx context.Context, ident table.Identifier, requirements []table.Requirement, updates []table.Update) (table.Metadata, string, error) {
+	if err := r.checkEndpoint(EndpointUpdateTable); err != nil {
+		return nil, "", err
+	}
+
 	ns, tblName, err := splitIdentForPath(ident)
 	if err != nil {
 		return nil, "", err
@@ -774,7 +866,68 @@ func (r *Catalog) CommitTable(ctx context.Context, ident table.Identifier, requi
 	return ret.Metadata, ret.MetadataLoc, nil
 }
 
+// CommitTransaction atomically commits the requirements and updates for
+// multiple tables using the catalog's transactions endpoint. If any of the
+// requirements fail, none of the changes are applied.
+func (r *Catalog) CommitTransaction(ctx context.Context, commits ...table.TableCommit) error {
+	if err := r.checkEndpoint(EndpointCommitTransaction); err != nil {
+		return err
+	}
+
+	if len(commits) == 0 {
+		return fmt.Errorf("%w: no table changes to commit", iceberg.ErrInvalidArgument)
+	}
+
+	type tableChange struct {
+		Identifier   identifier          `json:"identifier"`
+		Requirements []table.Requirement `json:"requirements"`
+		Updates      []table.Update      `json:"updates"`
+	}
+
+	type payload struct {
+		TableChanges []tableChange `json:"table-changes"`
+	}
+
+	changes := make([]tableChange, len(commits))
+	for i, c := range commits {
+		if _, _, err := splitIdentForPath(c.Identifier); err != nil {
+			return err
+		}
+
+		changes[i] = tableChange{
+			Identifier: identifier{
+				Namespace: catalog.NamespaceFromIdent(c.Identifier),
+				Name:      catalog.TableNameFromIdent(c.Identifier),
+			},
+			Requirements: c.Requirements,
+			Updates:      c.Updates,
+		}
+		if changes[i].Requirements == nil {
+			changes[i].Requirements = []table.Requirement{}
+		}
+		if changes[i].Updates == nil {
+			changes[i].Updates = []table.Update{}
+		}
+	}
+
+	_, err := doPostAllowNoContent[payload, struct{}](ctx, r.baseURI, []string{"transactions", "commit"},
+		payload{TableChanges: changes}, r.cl,
+		map[int]error{
+			http.StatusNotFound:            catalog.ErrNoSuchTable,
+			http.StatusConflict:            ErrCommitFailed,
+			http.StatusInternalServerError: ErrCommitStateUnknown,
+			http.StatusBadGateway:          ErrCommitStateUnknown,
+			http.StatusGatewayTimeout:      ErrCommitStateUnknown,
+		}, true)
+
+	return err
+}
+
 func (r *Catalog) RegisterTable(ctx context.Context, identifier table.Identifier, metadataLoc string) (*table.Table, error) {
+	if err := r.checkEndpoint(EndpointRegisterTable); err != nil {
+		return nil, err
+	}
+
 	ns, tbl, err := splitIdentForPath(identifier)
 	if err != nil {
 		return nil, err
@@ -801,6 +954,10 @@ func (r *Catalog) RegisterTable(ctx context.Context, identifier table.Identifier
 }
 
 func (r *Catalog) LoadTable(ctx context.Context, identifier table.Identifier) (*table.Table, error) {
+	if err := r.checkEndpoint(EndpointLoadTable); err != nil {
+		return nil, err
+	}
+
 	ns, tbl, err := splitIdentForPath(identifier)
 	if err != nil {
 		return nil, err
@@ -822,6 +979,10 @@ func (r *Catalog) LoadTable(ctx context.Context, identifier table.Identifier) (*
 }
 
 func (r *Catalog) UpdateTable(ctx context.Context, ident table.Identifier, requirements []table.Requirement, updates []table.Update) (*table.Table, error) {
+	if err := r.checkEndpoint(EndpointUpdateTable); err != nil {
+		return nil, err
+	}
+
 	ns, tbl, err := splitIdentForPath(ident)
 	if err != nil {
 		return nil, err
@@ -850,6 +1011,10 @@ func (r *Catalog) UpdateTable(ctx context.Context, ident table.Identifier, requi
 }
 
 func (r *Catalog) DropTable(ctx context.Context, identifier table.Identifier) error {
+	if err := r.checkEndpoint(EndpointDeleteTable); err != nil {
+		return err
+	}
+
 	ns, tbl, err := splitIdentForPath(identifier)
 	if err != nil {
 		return err
@@ -867,6 +1032,10 @@ func (r *Catalog) DropTable(ctx context.Context, identifier table.Identifier) er
 }
 
 func (r *Catalog) PurgeTable(ctx context.Context, identifier table.Identifier) error {
+	if err := r.checkEndpoint(EndpointDeleteTable); err != nil {
+		return err
+	}
+
 	ns, tbl, err := splitIdentForPath(identifier)
 	if err != nil {
 		return err
@@ -884,6 +1053,10 @@ func (r *Catalog) PurgeTable(ctx context.Context, identifier table.Identifier) e
 }
 
 func (r *Catalog) RenameTable(ctx context.Context, from, to table.Identifier) (*table.Table, error) {
+	if err := r.checkEndpoint(EndpointRenameTable); err != nil {
+		return nil, err
+	}
+
 	type payload struct {
 		Source      identifier `json:"source"`
 		Destination identifier `json:"destination"`
@@ -907,6 +1080,10 @@ func (r *Catalog) RenameTable(ctx context.Context, from, to table.Identifier) (*
 }
 
 func (r *Catalog) CreateNamespace(ctx context.Context, namespace table.Identifier, props iceberg.Properties) error {
+	if err := r.checkEndpoint(EndpointCreateNamespace); err != nil {
+		return err
+	}
+
 	if err := checkValidNamespace(namespace); err != nil {
 		return err
 	}
@@ -920,6 +1097,10 @@ func (r *Catalog) CreateNamespace(ctx context.Context, namespace table.Identifie
 }
 
 func (r *Catalog) DropNamespace(ctx context.Context, namespace table.Identifier) error {
+	if err := r.checkEndpoint(EndpointDeleteNamespace); err != nil {
+		return err
+	}
+
 	if err := checkValidNamespace(namespace); err != nil {
 		return err
 	}
@@ -950,7 +1131,21 @@ func (r *Catalog) ListNamespaces(ctx context.Context, parent table.Identifier) (
 	return allNamespaces, nil
 }
 
+// ListNamespacesPage returns a single page of the namespaces under parent,
+// passing the page token through to the catalog.
+func (r *Catalog) ListNamespacesPage(ctx context.Context, parent table.Identifier, pageToken string, pageSize int) ([]table.Identifier, string, error) {
+	if pageSize <= 0 {
+		pageSize = r.getPageSize(ctx)
+	}
+
+	return r.listNamespacesPage(ctx, parent, pageToken, pageSize)
+}
+
 func (r *Catalog) listNamespacesPage(ctx context.Context, parent table.Identifier, pageToken string, pageSize int) ([]table.Identifier, string, error) {
+	if err := r.checkEndpoint(EndpointListNamespaces); err != nil {
+		return nil, "", err
+	}
+
 	uri := r.baseURI.JoinPath("namespaces")
 
 	v := url.Values{}
@@ -981,6 +1176,10 @@ func (r *Catalog) listNamespacesPage(ctx context.Context, parent table.Identifie
 }
 
 func (r *Catalog) LoadNamespaceProperties(ctx context.Context, namespace table.Identifier) (iceberg.Properties, error) {
+	if err := r.checkEndpoint(EndpointLoadNamespace); err != nil {
+		return nil, err
+	}
+
 	if err := checkValidNamespace(namespace); err != nil {
 		return nil, err
 	}
@@ -1002,6 +1201,10 @@ func (r *Catalog) LoadNamespaceProperties(ctx context.Context, namespace table.I
 func (r *Catalog) UpdateNamespaceProperties(ctx context.Context, namespace table.Identifier,
 	removals []string, updates iceberg.Properties,
 ) (catalog.PropertiesUpdateSummary, error) {
+	if err := r.checkEndpoint(EndpointUpdateNamespace); err != nil {
+		return catalog.PropertiesUpdateSummary{}, err
+	}
+
 	if err := checkValidNamespace(namespace); err != nil {
 		return catalog.PropertiesUpdateSummary{}, err
 	}
@@ -1022,8 +1225,13 @@ func (r *Catalog) CheckNamespaceExists(ctx context.Context, namespace table.Iden
 		return false, err
 	}
 
-	err := doHead(ctx, r.baseURI, []string{"namespaces", strings.Join(namespace, namespaceSeparator)},
-		r.cl, map[int]error{http.StatusNotFound: catalog.ErrNoSuchNamespace})
+	var err error
+	if r.SupportsEndpoint(EndpointNamespaceExists) {
+		err = doHead(ctx, r.baseURI, []string{"namespaces", strings.Join(namespace, namespaceSeparator)},
+			r.cl, map[int]error{http.StatusNotFound: catalog.ErrNoSuchNamespace})
+	} else {
+		_, err = r.LoadNamespaceProperties(ctx, namespace)
+	}
 	if err != nil {
 		if errors.Is(err, catalog.ErrNoSuchNamespace) {
 			return false, nil
@@ -1040,8 +1248,12 @@ func (r *Catalog) CheckTableExists(ctx context.Context, identifier table.Identif
 	if err != nil {
 		return false, err
 	}
-	err = doHead(ctx, r.baseURI, []string{"namespaces", ns, "tables", tbl},
-		r.cl, map[int]error{http.StatusNotFound: catalog.ErrNoSuchTable})
+	if r.SupportsEndpoint(EndpointTableExists) {
+		err = doHead(ctx, r.baseURI, []string{"namespaces", ns, "tables", tbl},
+			r.cl, map[int]error{http.StatusNotFound: catalog.ErrNoSuchTable})
+	} else {
+		_, err = r.LoadTable(ctx, identifier)
+	}
 	if err != nil {
 		if errors.Is(err, catalog.ErrNoSuchTable) {
 			return false, nil
@@ -1079,6 +1291,10 @@ func (r *Catalog) ListViews(ctx context.Context, namespace table.Identifier) ite
 }
 
 func (r *Catalog) listViewsPage(ctx context.Context, namespace table.Identifier, pageToken string, pageSize int) ([]table.Identifier, string, error) {
+	if err := r.checkEndpoint(EndpointListViews); err != nil {
+		return nil, "", err
+	}
+
 	if err := checkValidNamespace(namespace); err != nil {
 		return nil, "", err
 	}
@@ -1125,6 +1341,10 @@ func (r *Catalog) SetPageSize(ctx context.Context, sz int) context.Context {
 }
 
 func (r *Catalog) DropView(ctx context.Context, identifier table.Identifier) error {
+	if err := r.checkEndpoint(EndpointDeleteView); err != nil {
+		return err
+	}
+
 	ns, view, err := splitIdentForPath(identifier)
 	if err != nil {
 		return err
@@ -1142,8 +1362,12 @@ func (r *Catalog) CheckViewExists(ctx context.Context, identifier table.Identifi
 		return false, err
 	}
 
-	err = doHead(ctx, r.baseURI, []string{"namespaces", ns, "views", view},
-		r.cl, map[int]error{http.StatusNotFound: catalog.ErrNoSuchView})
+	if r.SupportsEndpoint(EndpointViewExists) {
+		err = doHead(ctx, r.baseURI, []string{"namespaces", ns, "views", view},
+			r.cl, map[int]error{http.StatusNotFound: catalog.ErrNoSuchView})
+	} else {
+		_, err = r.LoadView(ctx, identifier)
+	}
 	if err != nil {
 		if errors.Is(err, catalog.ErrNoSuchView) {
 			return false, nil
@@ -1183,6 +1407,10 @@ func (v *viewResponse) UnmarshalJSON(b []byte) (err error) {
 
 // CreateView creates a new view in the catalog.
 func (r *Catalog) CreateView(ctx context.Context, identifier table.Identifier, version *view.Version, schema *iceberg.Schema, opts ...catalog.CreateViewOpt) (*view.View, error) {
+	if err := r.checkEndpoint(EndpointCreateView); err != nil {
+		return nil, err
+	}
+
 	ns, viewName, err := splitIdentForPath(identifier)
 	if err != nil {
 		return nil, err
@@ -1235,6 +1463,10 @@ func (r *Catalog) CreateView(ctx context.Context, identifier table.Identifier, v
 
 // UpdateView updates a view in the catalog.
 func (r *Catalog) UpdateView(ctx context.Context, ident table.Identifier, requirements []view.Requirement, updates []view.Update) (*view.View, error) {
+	if err := r.checkEndpoint(EndpointUpdateView); err != nil {
+		return nil, err
+	}
+
 	ns, viewName, err := splitIdentForPath(ident)
 	if err != nil {
 		return nil, err
@@ -1268,6 +1500,10 @@ type loadViewResponse struct {
 
 // LoadView loads a view from the catalog.
 func (r *Catalog) LoadView(ctx context.Context, identifier table.Identifier) (*view.View, error) {
+	if err := r.checkEndpoint(EndpointLoadView); err != nil {
+		return nil, err
+	}
+
 	ns, v, err := splitIdentForPath(identifier)
 	if err != nil {
 		return nil, err
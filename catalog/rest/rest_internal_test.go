@@ -34,6 +34,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
@@ -544,3 +546,44 @@ func TestResponseBodyLeak(t *testing.T) {
 			"response body should be closed on non-200 status")
 	})
 }
+
+func TestLoadTableResponseStorageCredentials(t *testing.T) {
+	meta, err := table.NewMetadata(iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true}),
+		iceberg.UnpartitionedSpec, table.UnsortedSortOrder, "s3://bucket/warehouse/db/tbl", nil)
+	require.NoError(t, err)
+	rawMeta, err := json.Marshal(meta)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(map[string]any{
+		"metadata-location": "s3://bucket/warehouse/db/tbl/metadata/v1.metadata.json",
+		"metadata":          json.RawMessage(rawMeta),
+		"config":            map[string]string{"client.region": "us-west-2", "s3.access-key-id": "stale"},
+		"storage-credentials": []map[string]any{
+			{"prefix": "s3://bucket/", "config": map[string]string{"s3.access-key-id": "bucket"}},
+			{"prefix": "s3://bucket/warehouse/db/", "config": map[string]string{
+				"s3.access-key-id": "db", "s3.secret-access-key": "secret",
+			}},
+			{"prefix": "s3://other/", "config": map[string]string{"s3.access-key-id": "other"}},
+		},
+	})
+	require.NoError(t, err)
+
+	var rsp loadTableResponse
+	require.NoError(t, json.Unmarshal(data, &rsp))
+	assert.Equal(t, iceberg.Properties{
+		"client.region":        "us-west-2",
+		"s3.access-key-id":     "db",
+		"s3.secret-access-key": "secret",
+	}, rsp.Config)
+
+	data, err = json.Marshal(map[string]any{
+		"metadata-location": "s3://bucket/warehouse/db/tbl/metadata/v1.metadata.json",
+		"metadata":          json.RawMessage(rawMeta),
+	})
+	require.NoError(t, err)
+
+	rsp = loadTableResponse{}
+	require.NoError(t, json.Unmarshal(data, &rsp))
+	assert.Empty(t, rsp.Config)
+}
@@ -405,6 +405,45 @@ func (r *RestCatalogSuite) TestListTables200() {
 	r.Equal([]table.Identifier{{"examples", "fooshare"}}, tbls)
 }
 
+func (r *RestCatalogSuite) TestListTablesPage() {
+	r.mux.HandleFunc("/v1/namespaces/examples/tables", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodGet, req.Method)
+		r.Equal("token-1", req.URL.Query().Get("pageToken"))
+		r.Equal(strconv.Itoa(defaultPageSize), req.URL.Query().Get("pageSize"))
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"identifiers": []any{
+				map[string]any{"namespace": []string{"examples"}, "name": "fooshare"},
+			},
+			"next-page-token": "token-2",
+		})
+	})
+
+	r.mux.HandleFunc("/v1/namespaces", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodGet, req.Method)
+		r.Equal("", req.URL.Query().Get("pageToken"))
+		r.Equal("5", req.URL.Query().Get("pageSize"))
+
+		json.NewEncoder(w).Encode(map[string]any{
+			"namespaces": []any{[]string{"examples"}},
+		})
+	})
+
+	cat, err := rest.NewCatalog(context.Background(), "rest", r.srv.URL, rest.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	tables, next, err := cat.ListTablesPage(context.Background(), catalog.ToIdentifier("examples"), "token-1", 0,
+		catalog.WithMetadataLocation())
+	r.Require().NoError(err)
+	r.Equal([]catalog.TableInfo{{Identifier: table.Identifier{"examples", "fooshare"}}}, tables)
+	r.Equal("token-2", next)
+
+	namespaces, next, err := cat.ListNamespacesPage(context.Background(), nil, "", 5)
+	r.Require().NoError(err)
+	r.Equal([]table.Identifier{{"examples"}}, namespaces)
+	r.Empty(next)
+}
+
 func (r *RestCatalogSuite) TestListTablesPrefixed200() {
 	r.mux.HandleFunc("/v1/oauth/tokens", func(w http.ResponseWriter, req *http.Request) {
 		r.Equal(http.MethodPost, req.Method)
@@ -1193,6 +1232,47 @@ func (r *RestCatalogSuite) TestCreateTable200() {
 	r.Equal(table.UnsortedSortOrder, tbl.SortOrder())
 }
 
+func (r *RestCatalogSuite) TestStageCreateTable() {
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodPost, req.Method)
+
+		var payload map[string]any
+		r.Require().NoError(json.NewDecoder(req.Body).Decode(&payload))
+		r.Equal(true, payload["stage-create"])
+
+		w.Write([]byte(createTableRestExample))
+	})
+
+	r.mux.HandleFunc("/v1/namespaces/fokko/tables/fokko2", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodPost, req.Method)
+
+		var payload struct {
+			Requirements []map[string]any `json:"requirements"`
+			Updates      []map[string]any `json:"updates"`
+		}
+		r.Require().NoError(json.NewDecoder(req.Body).Decode(&payload))
+		r.Equal([]map[string]any{{"type": "assert-create"}}, payload.Requirements)
+		r.Require().NotEmpty(payload.Updates)
+		r.Equal("assign-uuid", payload.Updates[0]["action"])
+		r.Equal("set-properties", payload.Updates[len(payload.Updates)-1]["action"])
+
+		w.Write([]byte(createTableRestExample))
+	})
+
+	cat, err := rest.NewCatalog(context.Background(), "rest", r.srv.URL, rest.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	txn, err := catalog.NewCreateTableBuilder(cat, catalog.ToIdentifier("fokko", "fokko2")).
+		WithSchema(tableSchemaSimple).
+		StageCreate(context.Background())
+	r.Require().NoError(err)
+	r.Require().NoError(txn.SetProperties(iceberg.Properties{"owner": "fokko"}))
+
+	tbl, err := txn.Commit(context.Background())
+	r.Require().NoError(err)
+	r.Equal("bf289591-dcc0-4234-ad4f-5c3eed811a29", tbl.Metadata().TableUUID().String())
+}
+
 func (r *RestCatalogSuite) TestCreateTable409() {
 	// Mock the create table endpoint with 409 response
 	r.mux.HandleFunc("/v1/namespaces/fokko/tables", func(w http.ResponseWriter, req *http.Request) {
@@ -1527,6 +1607,106 @@ func (r *RestCatalogSuite) TestDropTable404() {
 	r.ErrorContains(err, "Table does not exist: fokko.table")
 }
 
+func (r *RestCatalogSuite) TestCommitTransaction204() {
+	tblUUID := uuid.New()
+	r.mux.HandleFunc("/v1/transactions/commit", func(w http.ResponseWriter, req *http.Request) {
+		r.Require().Equal(http.MethodPost, req.Method)
+
+		for k, v := range TestHeaders {
+			r.Equal(v, req.Header.Values(k))
+		}
+
+		var payload struct {
+			TableChanges []struct {
+				Identifier struct {
+					Namespace []string `json:"namespace"`
+					Name      string   `json:"name"`
+				} `json:"identifier"`
+				Requirements []map[string]any `json:"requirements"`
+				Updates      []map[string]any `json:"updates"`
+			} `json:"table-changes"`
+		}
+		r.Require().NoError(json.NewDecoder(req.Body).Decode(&payload))
+		r.Require().Len(payload.TableChanges, 2)
+
+		facts := payload.TableChanges[0]
+		r.Equal([]string{"fokko"}, facts.Identifier.Namespace)
+		r.Equal("facts", facts.Identifier.Name)
+		r.Require().Len(facts.Requirements, 1)
+		r.Equal("assert-table-uuid", facts.Requirements[0]["type"])
+		r.Equal(tblUUID.String(), facts.Requirements[0]["uuid"])
+		r.Require().Len(facts.Updates, 1)
+		r.Equal("set-properties", facts.Updates[0]["action"])
+
+		audit := payload.TableChanges[1]
+		r.Equal([]string{"fokko", "logs"}, audit.Identifier.Namespace)
+		r.Equal("audit", audit.Identifier.Name)
+		r.Empty(audit.Requirements)
+		r.Require().Len(audit.Updates, 1)
+		r.Equal("remove-properties", audit.Updates[0]["action"])
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	cat, err := rest.NewCatalog(context.Background(), "rest", r.srv.URL, rest.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	err = cat.CommitTransaction(context.Background(),
+		table.TableCommit{
+			Identifier:   catalog.ToIdentifier("fokko", "facts"),
+			Requirements: []table.Requirement{table.AssertTableUUID(tblUUID)},
+			Updates:      []table.Update{table.NewSetPropertiesUpdate(iceberg.Properties{"owner": "fokko"})},
+		},
+		table.TableCommit{
+			Identifier: catalog.ToIdentifier("fokko", "logs", "audit"),
+			Updates:    []table.Update{table.NewRemovePropertiesUpdate([]string{"owner"})},
+		})
+	r.NoError(err)
+}
+
+func (r *RestCatalogSuite) TestCommitTransactionErrors() {
+	tests := []struct {
+		status    int
+		errTyp    string
+		expErr    error
+		retryable bool
+	}{
+		{http.StatusNotFound, "NoSuchTableException", catalog.ErrNoSuchTable, false},
+		{http.StatusConflict, "CommitFailedException", iceberg.ErrCommitConflict, true},
+		{http.StatusInternalServerError, "CommitStateUnknownException", iceberg.ErrCommitStateUnknown, false},
+		{http.StatusGatewayTimeout, "CommitStateUnknownException", rest.ErrCommitStateUnknown, false},
+	}
+
+	var status int
+	var errTyp string
+	r.mux.HandleFunc("/v1/transactions/commit", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(map[string]any{
+			"error": map[string]any{
+				"message": "transaction failed",
+				"type":    errTyp,
+				"code":    status,
+			},
+		})
+	})
+
+	cat, err := rest.NewCatalog(context.Background(), "rest", r.srv.URL, rest.WithOAuthToken(TestToken))
+	r.Require().NoError(err)
+
+	for _, tt := range tests {
+		status, errTyp = tt.status, tt.errTyp
+		err = cat.CommitTransaction(context.Background(), table.TableCommit{
+			Identifier: catalog.ToIdentifier("fokko", "facts"),
+			Updates:    []table.Update{table.NewSetPropertiesUpdate(iceberg.Properties{"a": "b"})},
+		})
+		r.ErrorIs(err, tt.expErr, tt.status)
+		r.ErrorContains(err, "transaction failed")
+		r.Equal(tt.retryable, iceberg.IsRetryable(err), tt.status)
+	}
+
+	r.ErrorIs(cat.CommitTransaction(context.Background()), iceberg.ErrInvalidArgument)
+}
+
 func (r *RestCatalogSuite) TestRegisterTable200() {
 	r.mux.HandleFunc("/v1/namespaces/fokko/register", func(w http.ResponseWriter, req *http.Request) {
 		r.Require().Equal(http.MethodPost, req.Method)
@@ -0,0 +1,198 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package s3tables
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/table"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// Maintenance types of the S3 Tables service. Compaction and snapshot
+// management are configured per table, unreferenced file removal per
+// table bucket.
+const (
+	MaintenanceCompaction              = "icebergCompaction"
+	MaintenanceSnapshotManagement      = "icebergSnapshotManagement"
+	MaintenanceUnreferencedFileRemoval = "icebergUnreferencedFileRemoval"
+)
+
+type MaintenanceStatus string
+
+const (
+	MaintenanceEnabled  MaintenanceStatus = "enabled"
+	MaintenanceDisabled MaintenanceStatus = "disabled"
+)
+
+// MaintenanceConfig is the configuration of one maintenance type. The
+// settings are passed through unchanged, for example
+// {"icebergCompaction":{"targetFileSizeMB":512}} for compaction. See the
+// S3 Tables API reference for the settings of each type.
+type MaintenanceConfig struct {
+	Status   MaintenanceStatus `json:"status,omitempty"`
+	Settings json.RawMessage   `json:"settings,omitempty"`
+}
+
+// ErrS3Tables is returned when the S3 Tables service rejects a
+// maintenance configuration request.
+var ErrS3Tables = errors.New("s3tables error")
+
+// TableMaintenance returns the maintenance configuration of a table,
+// keyed by maintenance type.
+func (c *Catalog) TableMaintenance(ctx context.Context, ident table.Identifier) (map[string]MaintenanceConfig, error) {
+	path, err := c.tablePath(ident)
+	if err != nil {
+		return nil, err
+	}
+
+	var rsp struct {
+		Configuration map[string]MaintenanceConfig `json:"configuration"`
+	}
+	if err := c.do(ctx, http.MethodGet, append(path, "maintenance"), nil, &rsp, catalog.ErrNoSuchTable); err != nil {
+		return nil, err
+	}
+
+	return rsp.Configuration, nil
+}
+
+// SetTableMaintenance sets the configuration of one maintenance type of
+// a table.
+func (c *Catalog) SetTableMaintenance(ctx context.Context, ident table.Identifier, typ string, cfg MaintenanceConfig) error {
+	path, err := c.tablePath(ident)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, http.MethodPut, append(path, "maintenance", typ),
+		map[string]any{"value": cfg}, nil, catalog.ErrNoSuchTable)
+}
+
+// BucketMaintenance returns the maintenance configuration of the table
+// bucket, keyed by maintenance type.
+func (c *Catalog) BucketMaintenance(ctx context.Context) (map[string]MaintenanceConfig, error) {
+	var rsp struct {
+		Configuration map[string]MaintenanceConfig `json:"configuration"`
+	}
+	if err := c.do(ctx, http.MethodGet, []string{"buckets", c.bucket.String(), "maintenance"}, nil, &rsp, nil); err != nil {
+		return nil, err
+	}
+
+	return rsp.Configuration, nil
+}
+
+// SetBucketMaintenance sets the configuration of one maintenance type of
+// the table bucket.
+func (c *Catalog) SetBucketMaintenance(ctx context.Context, typ string, cfg MaintenanceConfig) error {
+	return c.do(ctx, http.MethodPut, []string{"buckets", c.bucket.String(), "maintenance", typ},
+		map[string]any{"value": cfg}, nil, nil)
+}
+
+func (c *Catalog) tablePath(ident table.Identifier) ([]string, error) {
+	ns := catalog.NamespaceFromIdent(ident)
+	if len(ns) != 1 {
+		return nil, fmt.Errorf("%w: %s", catalog.ErrHierarchicalNamespaceUnsupported, strings.Join(ident, "."))
+	}
+
+	return []string{"tables", c.bucket.String(), ns[0], catalog.TableNameFromIdent(ident)}, nil
+}
+
+// do sends a SigV4 signed JSON request to the S3 Tables API. The path
+// segments are escaped individually, as table bucket ARNs contain
+// slashes. A 404 response is reported as notFound if it is not nil.
+func (c *Catalog) do(ctx context.Context, method string, path []string, body, out any, notFound error) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	u := *c.endpoint
+	u.Path = c.endpoint.Path + "/" + strings.Join(path, "/")
+	u.RawPath = c.endpoint.EscapedPath() + "/" + escapeSegments(path)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	creds, err := c.cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(payload)
+	if err := v4.NewSigner().SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]),
+		signingName, c.bucket.Region, time.Now()); err != nil {
+		return err
+	}
+
+	rsp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	data, err := io.ReadAll(rsp.Body)
+	if err != nil {
+		return err
+	}
+
+	if rsp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(data, &apiErr)
+		if rsp.StatusCode == http.StatusNotFound && notFound != nil {
+			return fmt.Errorf("%w: %s", notFound, apiErr.Message)
+		}
+
+		return fmt.Errorf("%w: %s %s: %s: %s", ErrS3Tables, method, u.Path, rsp.Status, apiErr.Message)
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(data, out)
+}
+
+func escapeSegments(path []string) string {
+	escaped := make([]string, len(path))
+	for i, p := range path {
+		escaped[i] = url.PathEscape(p)
+	}
+
+	return strings.Join(escaped, "/")
+}
@@ -0,0 +1,59 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package s3tables
+
+import (
+	"github.com/apache/iceberg-go/catalog/rest"
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+type Option func(*options)
+
+// WithAwsConfig sets the AWS configuration used to sign requests. By
+// default it is loaded from the environment with the region of the
+// table bucket.
+func WithAwsConfig(cfg aws.Config) Option {
+	return func(o *options) {
+		o.awsConfig = cfg
+		o.awsConfigSet = true
+	}
+}
+
+// WithEndpoint overrides the S3 Tables endpoint derived from the table
+// bucket ARN, such as "https://s3tables.us-east-1.amazonaws.com". The
+// Iceberg REST API is served under its /iceberg path.
+func WithEndpoint(endpoint string) Option {
+	return func(o *options) {
+		o.endpoint = endpoint
+	}
+}
+
+// WithRESTOptions passes additional options to the underlying REST
+// catalog, for example rest.WithAdditionalProps for FileIO properties.
+func WithRESTOptions(opts ...rest.Option) Option {
+	return func(o *options) {
+		o.restOptions = append(o.restOptions, opts...)
+	}
+}
+
+type options struct {
+	awsConfig    aws.Config
+	awsConfigSet bool
+	endpoint     string
+	restOptions  []rest.Option
+}
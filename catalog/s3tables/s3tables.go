@@ -0,0 +1,186 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package s3tables provides a catalog for Amazon S3 table buckets. It
+// talks to the Iceberg REST endpoint of the S3 Tables service, signing
+// requests with SigV4, and exposes the service's maintenance
+// configuration of tables and table buckets.
+package s3tables
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/catalog/rest"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+const (
+	// TableBucketARNKey is the catalog property holding the ARN of the
+	// table bucket. The "warehouse" property is used if it is not set.
+	TableBucketARNKey = "s3tables.table-bucket-arn"
+	Endpoint          = "s3tables.endpoint"
+	AccessKeyID       = "s3tables.access-key-id"
+	SecretAccessKey   = "s3tables.secret-access-key"
+	SessionToken      = "s3tables.session-token"
+
+	signingName = "s3tables"
+)
+
+func init() {
+	catalog.Register(string(catalog.S3Tables), catalog.RegistrarFunc(func(ctx context.Context, name string, props iceberg.Properties) (catalog.Catalog, error) {
+		arn := props.Get(TableBucketARNKey, props.Get("warehouse", ""))
+		opts := []Option{WithRESTOptions(rest.WithAdditionalProps(props))}
+		if ep := props.Get(Endpoint, ""); ep != "" {
+			opts = append(opts, WithEndpoint(ep))
+		}
+
+		if key := props.Get(AccessKeyID, ""); key != "" {
+			bucket, err := ParseTableBucketARN(arn)
+			if err != nil {
+				return nil, err
+			}
+
+			cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(bucket.Region),
+				config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
+					key, props.Get(SecretAccessKey, ""), props.Get(SessionToken, ""))))
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, WithAwsConfig(cfg))
+		}
+
+		return NewCatalog(ctx, name, arn, opts...)
+	}))
+}
+
+// TableBucketARN identifies an S3 table bucket, for example
+// arn:aws:s3tables:us-east-1:111122223333:bucket/my-bucket.
+type TableBucketARN struct {
+	Partition string
+	Region    string
+	AccountID string
+	Bucket    string
+}
+
+// ParseTableBucketARN parses the ARN of an S3 table bucket.
+func ParseTableBucketARN(s string) (TableBucketARN, error) {
+	parts := strings.SplitN(s, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" || parts[2] != "s3tables" ||
+		parts[1] == "" || parts[3] == "" || parts[4] == "" {
+		return TableBucketARN{}, fmt.Errorf("%w: not an S3 table bucket ARN: %q", iceberg.ErrInvalidArgument, s)
+	}
+
+	bucket, ok := strings.CutPrefix(parts[5], "bucket/")
+	if !ok || bucket == "" || strings.Contains(bucket, "/") {
+		return TableBucketARN{}, fmt.Errorf("%w: not an S3 table bucket ARN: %q", iceberg.ErrInvalidArgument, s)
+	}
+
+	return TableBucketARN{
+		Partition: parts[1],
+		Region:    parts[3],
+		AccountID: parts[4],
+		Bucket:    bucket,
+	}, nil
+}
+
+func (a TableBucketARN) String() string {
+	return fmt.Sprintf("arn:%s:s3tables:%s:%s:bucket/%s", a.Partition, a.Region, a.AccountID, a.Bucket)
+}
+
+// Endpoint returns the regional S3 Tables endpoint for the bucket.
+func (a TableBucketARN) Endpoint() string {
+	domain := "amazonaws.com"
+	if a.Partition == "aws-cn" {
+		domain = "amazonaws.com.cn"
+	}
+
+	return fmt.Sprintf("https://s3tables.%s.%s", a.Region, domain)
+}
+
+// Catalog is a catalog of the tables in an S3 table bucket. All catalog
+// operations go through the service's Iceberg REST endpoint, and the
+// service's namespaces are single level.
+type Catalog struct {
+	*rest.Catalog
+
+	bucket   TableBucketARN
+	endpoint *url.URL
+	cfg      aws.Config
+	client   *http.Client
+}
+
+var _ catalog.Catalog = (*Catalog)(nil)
+
+// NewCatalog returns a catalog for the table bucket with the given ARN.
+func NewCatalog(ctx context.Context, name, tableBucketARN string, opts ...Option) (*Catalog, error) {
+	bucket, err := ParseTableBucketARN(tableBucketARN)
+	if err != nil {
+		return nil, err
+	}
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if !o.awsConfigSet {
+		if o.awsConfig, err = config.LoadDefaultConfig(ctx, config.WithRegion(bucket.Region)); err != nil {
+			return nil, err
+		}
+	}
+	o.awsConfig.Region = bucket.Region
+
+	if o.endpoint == "" {
+		o.endpoint = bucket.Endpoint()
+	}
+	endpoint, err := url.Parse(strings.TrimSuffix(o.endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid s3tables endpoint: %w", iceberg.ErrInvalidArgument, err)
+	}
+
+	restOpts := append([]rest.Option{
+		rest.WithWarehouseLocation(tableBucketARN),
+		rest.WithAwsConfig(o.awsConfig),
+		rest.WithSigV4RegionSvc(bucket.Region, signingName),
+	}, o.restOptions...)
+
+	restCat, err := rest.NewCatalog(ctx, name, endpoint.JoinPath("iceberg").String(), restOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Catalog{
+		Catalog:  restCat,
+		bucket:   bucket,
+		endpoint: endpoint,
+		cfg:      o.awsConfig,
+		client:   &http.Client{},
+	}, nil
+}
+
+func (c *Catalog) CatalogType() catalog.Type { return catalog.S3Tables }
+
+// TableBucket returns the ARN of the catalog's table bucket.
+func (c *Catalog) TableBucket() TableBucketARN { return c.bucket }
@@ -0,0 +1,157 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package s3tables_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/catalog/s3tables"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testARN = "arn:aws:s3tables:us-west-2:111122223333:bucket/analytics"
+
+func TestParseTableBucketARN(t *testing.T) {
+	arn, err := s3tables.ParseTableBucketARN(testARN)
+	require.NoError(t, err)
+	assert.Equal(t, s3tables.TableBucketARN{
+		Partition: "aws", Region: "us-west-2", AccountID: "111122223333", Bucket: "analytics",
+	}, arn)
+	assert.Equal(t, testARN, arn.String())
+	assert.Equal(t, "https://s3tables.us-west-2.amazonaws.com", arn.Endpoint())
+
+	cn, err := s3tables.ParseTableBucketARN("arn:aws-cn:s3tables:cn-north-1:111122223333:bucket/b")
+	require.NoError(t, err)
+	assert.Equal(t, "https://s3tables.cn-north-1.amazonaws.com.cn", cn.Endpoint())
+
+	for _, invalid := range []string{
+		"",
+		"s3://bucket",
+		"arn:aws:s3:us-west-2:111122223333:bucket/analytics",
+		"arn:aws:s3tables:us-west-2:111122223333:analytics",
+		"arn:aws:s3tables::111122223333:bucket/analytics",
+		"arn:aws:s3tables:us-west-2:111122223333:bucket/analytics/table",
+	} {
+		_, err := s3tables.ParseTableBucketARN(invalid)
+		assert.ErrorIs(t, err, iceberg.ErrInvalidArgument, invalid)
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	var (
+		warehouse string
+		requests  []string
+		putBody   map[string]any
+	)
+
+	escapedARN := url.PathEscape(testARN)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		auth := req.Header.Get("Authorization")
+		if !assert.Contains(t, auth, "/us-west-2/s3tables/aws4_request") {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		path := req.URL.EscapedPath()
+		requests = append(requests, req.Method+" "+path)
+
+		switch path {
+		case "/iceberg/v1/config":
+			warehouse = req.URL.Query().Get("warehouse")
+			json.NewEncoder(w).Encode(map[string]any{
+				"defaults":  map[string]any{},
+				"overrides": map[string]any{"prefix": escapedARN},
+			})
+		case "/tables/" + escapedARN + "/sales/orders/maintenance":
+			json.NewEncoder(w).Encode(map[string]any{
+				"tableARN": testARN + "/table/1",
+				"configuration": map[string]any{
+					s3tables.MaintenanceCompaction: map[string]any{
+						"status":   "enabled",
+						"settings": map[string]any{"icebergCompaction": map[string]any{"targetFileSizeMB": 512}},
+					},
+				},
+			})
+		case "/tables/" + escapedARN + "/sales/orders/maintenance/" + s3tables.MaintenanceSnapshotManagement,
+			"/buckets/" + escapedARN + "/maintenance/" + s3tables.MaintenanceUnreferencedFileRemoval:
+			body, _ := io.ReadAll(req.Body)
+			putBody = nil
+			require.NoError(t, json.Unmarshal(body, &putBody))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]any{"message": "not found: " + path})
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	cfg := aws.Config{Credentials: credentials.NewStaticCredentialsProvider("key", "secret", "")}
+	cat, err := s3tables.NewCatalog(ctx, "s3tables", testARN,
+		s3tables.WithAwsConfig(cfg), s3tables.WithEndpoint(srv.URL))
+	require.NoError(t, err)
+
+	assert.Equal(t, catalog.S3Tables, cat.CatalogType())
+	assert.Equal(t, "analytics", cat.TableBucket().Bucket)
+	assert.Equal(t, testARN, warehouse)
+
+	maint, err := cat.TableMaintenance(ctx, catalog.ToIdentifier("sales", "orders"))
+	require.NoError(t, err)
+	require.Contains(t, maint, s3tables.MaintenanceCompaction)
+	assert.Equal(t, s3tables.MaintenanceEnabled, maint[s3tables.MaintenanceCompaction].Status)
+	assert.JSONEq(t, `{"icebergCompaction":{"targetFileSizeMB":512}}`,
+		string(maint[s3tables.MaintenanceCompaction].Settings))
+
+	require.NoError(t, cat.SetTableMaintenance(ctx, catalog.ToIdentifier("sales", "orders"),
+		s3tables.MaintenanceSnapshotManagement, s3tables.MaintenanceConfig{
+			Status:   s3tables.MaintenanceEnabled,
+			Settings: json.RawMessage(`{"icebergSnapshotManagement":{"minSnapshotsToKeep":5}}`),
+		}))
+	assert.Equal(t, map[string]any{"value": map[string]any{
+		"status":   "enabled",
+		"settings": map[string]any{"icebergSnapshotManagement": map[string]any{"minSnapshotsToKeep": 5.0}},
+	}}, putBody)
+
+	require.NoError(t, cat.SetBucketMaintenance(ctx, s3tables.MaintenanceUnreferencedFileRemoval,
+		s3tables.MaintenanceConfig{Status: s3tables.MaintenanceDisabled}))
+	assert.Equal(t, map[string]any{"value": map[string]any{"status": "disabled"}}, putBody)
+
+	_, err = cat.TableMaintenance(ctx, catalog.ToIdentifier("sales", "missing"))
+	assert.ErrorIs(t, err, catalog.ErrNoSuchTable)
+
+	_, err = cat.BucketMaintenance(ctx)
+	assert.ErrorIs(t, err, s3tables.ErrS3Tables)
+
+	_, err = cat.TableMaintenance(ctx, catalog.ToIdentifier("a", "b", "c"))
+	assert.ErrorIs(t, err, catalog.ErrHierarchicalNamespaceUnsupported)
+
+	assert.True(t, strings.HasPrefix(requests[0], "GET /iceberg/v1/config"))
+}
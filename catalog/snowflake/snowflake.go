@@ -0,0 +1,342 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package snowflake provides a read-only catalog of Snowflake-managed
+// Iceberg tables. It resolves the current metadata location of each
+// table through Snowflake, so the table can be scanned directly from
+// object storage. The catalog uses a database/sql connection; import a
+// Snowflake driver such as github.com/snowflakedb/gosnowflake to open it.
+package snowflake
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/url"
+	"strings"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/catalog/internal"
+	"github.com/apache/iceberg-go/table"
+)
+
+const (
+	// DriverKey is the catalog property naming the database/sql driver
+	// used to open the "uri" property. It defaults to "snowflake".
+	DriverKey     = "snowflake.driver"
+	DefaultDriver = "snowflake"
+)
+
+// ErrReadOnly is returned by the operations that would modify the
+// catalog. Snowflake-managed tables can only be changed by Snowflake.
+var ErrReadOnly = fmt.Errorf("%w: the snowflake catalog is read-only", iceberg.ErrNotImplemented)
+
+func init() {
+	catalog.Register(string(catalog.Snowflake), catalog.RegistrarFunc(func(ctx context.Context, name string, p iceberg.Properties) (catalog.Catalog, error) {
+		db, err := sql.Open(p.Get(DriverKey, DefaultDriver), strings.TrimPrefix(p.Get("uri", ""), "snowflake://"))
+		if err != nil {
+			return nil, err
+		}
+
+		return NewCatalog(name, db, p), nil
+	}))
+}
+
+// Catalog is a read-only catalog of the Iceberg tables in a Snowflake
+// account. Namespaces are a database or a database and schema, and
+// table identifiers are database, schema and table name.
+type Catalog struct {
+	name  string
+	db    *sql.DB
+	props iceberg.Properties
+}
+
+var _ catalog.Catalog = (*Catalog)(nil)
+
+// NewCatalog returns a catalog using the given Snowflake connection. The
+// properties are used to configure the FileIO of loaded tables.
+func NewCatalog(name string, db *sql.DB, props iceberg.Properties) *Catalog {
+	return &Catalog{name: name, db: db, props: props}
+}
+
+func (c *Catalog) CatalogType() catalog.Type { return catalog.Snowflake }
+
+func (c *Catalog) Name() string { return c.name }
+
+// LoadTable loads the table from the metadata location that Snowflake
+// reports as current.
+func (c *Catalog) LoadTable(ctx context.Context, identifier table.Identifier) (*table.Table, error) {
+	exists, err := c.CheckTableExists(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", catalog.ErrNoSuchTable, strings.Join(identifier, "."))
+	}
+
+	var info string
+	if err := c.db.QueryRowContext(ctx, "SELECT SYSTEM$GET_ICEBERG_TABLE_INFORMATION(?)",
+		strings.Join(identifier, ".")).Scan(&info); err != nil {
+		return nil, fmt.Errorf("failed to get iceberg table information for %s: %w",
+			strings.Join(identifier, "."), err)
+	}
+
+	var parsed struct {
+		MetadataLocation string `json:"metadataLocation"`
+		Status           string `json:"status"`
+	}
+	if err := json.Unmarshal([]byte(info), &parsed); err != nil {
+		return nil, fmt.Errorf("invalid iceberg table information for %s: %w",
+			strings.Join(identifier, "."), err)
+	}
+	if parsed.MetadataLocation == "" {
+		return nil, fmt.Errorf("%w: %s, metadata location is missing (status %q)",
+			catalog.ErrNoSuchTable, strings.Join(identifier, "."), parsed.Status)
+	}
+
+	return internal.LoadTable(ctx, identifier, FileIOLocation(parsed.MetadataLocation), c.props, c)
+}
+
+// FileIOLocation converts a location as reported by Snowflake to one
+// that FileIO can open: azure://account.blob.core.windows.net/container/path
+// becomes wasbs://container@account.blob.core.windows.net/path and
+// gcs://bucket/path becomes gs://bucket/path.
+func FileIOLocation(loc string) string {
+	u, err := url.Parse(loc)
+	if err != nil {
+		return loc
+	}
+
+	switch u.Scheme {
+	case "azure":
+		container, path, _ := strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+
+		return fmt.Sprintf("wasbs://%s@%s/%s", container, u.Host, path)
+	case "gcs":
+		u.Scheme = "gs"
+
+		return u.String()
+	}
+
+	return loc
+}
+
+func (c *Catalog) CheckTableExists(ctx context.Context, identifier table.Identifier) (bool, error) {
+	if len(identifier) != 3 {
+		return false, fmt.Errorf("%w: snowflake table identifiers must be database.schema.table, got %s",
+			iceberg.ErrInvalidArgument, strings.Join(identifier, "."))
+	}
+
+	names, err := c.showNames(ctx, "SHOW ICEBERG TABLES LIKE ? IN SCHEMA IDENTIFIER(?)",
+		identifier[2], identifier[0]+"."+identifier[1])
+	if err != nil {
+		return false, err
+	}
+
+	return containsName(names, identifier[2]), nil
+}
+
+func (c *Catalog) ListTables(ctx context.Context, namespace table.Identifier) iter.Seq2[table.Identifier, error] {
+	return func(yield func(table.Identifier, error) bool) {
+		var query string
+		switch len(namespace) {
+		case 1:
+			query = "SHOW ICEBERG TABLES IN DATABASE IDENTIFIER(?)"
+		case 2:
+			query = "SHOW ICEBERG TABLES IN SCHEMA IDENTIFIER(?)"
+		default:
+			yield(nil, fmt.Errorf("%w: snowflake namespaces are a database or database.schema, got %s",
+				iceberg.ErrInvalidArgument, strings.Join(namespace, ".")))
+
+			return
+		}
+
+		rows, err := c.show(ctx, query, strings.Join(namespace, "."))
+		if err != nil {
+			yield(nil, err)
+
+			return
+		}
+
+		for _, r := range rows {
+			if !yield(table.Identifier{r["database_name"], r["schema_name"], r["name"]}, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (c *Catalog) ListNamespaces(ctx context.Context, parent table.Identifier) ([]table.Identifier, error) {
+	switch len(parent) {
+	case 0:
+		names, err := c.showNames(ctx, "SHOW DATABASES IN ACCOUNT")
+		if err != nil {
+			return nil, err
+		}
+
+		out := make([]table.Identifier, len(names))
+		for i, n := range names {
+			out[i] = table.Identifier{n}
+		}
+
+		return out, nil
+	case 1:
+		names, err := c.showNames(ctx, "SHOW SCHEMAS IN DATABASE IDENTIFIER(?)", parent[0])
+		if err != nil {
+			return nil, err
+		}
+
+		out := make([]table.Identifier, len(names))
+		for i, n := range names {
+			out[i] = table.Identifier{parent[0], n}
+		}
+
+		return out, nil
+	default:
+		return []table.Identifier{}, nil
+	}
+}
+
+func (c *Catalog) CheckNamespaceExists(ctx context.Context, namespace table.Identifier) (bool, error) {
+	var (
+		names []string
+		err   error
+	)
+
+	switch len(namespace) {
+	case 1:
+		names, err = c.showNames(ctx, "SHOW DATABASES LIKE ? IN ACCOUNT", namespace[0])
+	case 2:
+		names, err = c.showNames(ctx, "SHOW SCHEMAS LIKE ? IN DATABASE IDENTIFIER(?)",
+			namespace[1], namespace[0])
+	default:
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return containsName(names, namespace[len(namespace)-1]), nil
+}
+
+// LoadNamespaceProperties returns no properties, as Snowflake databases
+// and schemas have none that are relevant to Iceberg.
+func (c *Catalog) LoadNamespaceProperties(ctx context.Context, namespace table.Identifier) (iceberg.Properties, error) {
+	exists, err := c.CheckNamespaceExists(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", catalog.ErrNoSuchNamespace, strings.Join(namespace, "."))
+	}
+
+	return iceberg.Properties{}, nil
+}
+
+func (c *Catalog) CreateTable(context.Context, table.Identifier, *iceberg.Schema, ...catalog.CreateTableOpt) (*table.Table, error) {
+	return nil, ErrReadOnly
+}
+
+func (c *Catalog) CommitTable(context.Context, table.Identifier, []table.Requirement, []table.Update) (table.Metadata, string, error) {
+	return nil, "", ErrReadOnly
+}
+
+func (c *Catalog) DropTable(context.Context, table.Identifier) error { return ErrReadOnly }
+
+func (c *Catalog) RenameTable(context.Context, table.Identifier, table.Identifier) (*table.Table, error) {
+	return nil, ErrReadOnly
+}
+
+func (c *Catalog) CreateNamespace(context.Context, table.Identifier, iceberg.Properties) error {
+	return ErrReadOnly
+}
+
+func (c *Catalog) DropNamespace(context.Context, table.Identifier) error { return ErrReadOnly }
+
+func (c *Catalog) UpdateNamespaceProperties(context.Context, table.Identifier, []string, iceberg.Properties) (catalog.PropertiesUpdateSummary, error) {
+	return catalog.PropertiesUpdateSummary{}, ErrReadOnly
+}
+
+// show runs a SHOW command and returns its rows as maps from column
+// name to value, as the columns of SHOW output vary between versions.
+func (c *Catalog) show(ctx context.Context, query string, args ...any) ([]map[string]string, error) {
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var out []map[string]string
+	for rows.Next() {
+		vals := make([]sql.NullString, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range vals {
+			ptrs[i] = &vals[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]string, len(cols))
+		for i, col := range cols {
+			row[strings.ToLower(col)] = vals[i].String
+		}
+		out = append(out, row)
+	}
+
+	return out, rows.Err()
+}
+
+func (c *Catalog) showNames(ctx context.Context, query string, args ...any) ([]string, error) {
+	rows, err := c.show(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(rows))
+	for _, r := range rows {
+		name, ok := r["name"]
+		if !ok {
+			return nil, errors.New("snowflake SHOW output has no name column")
+		}
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// containsName reports whether names contains name. Unquoted Snowflake
+// identifiers are case-insensitive, and SHOW ... LIKE patterns may match
+// other names through wildcards.
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+
+	return false
+}
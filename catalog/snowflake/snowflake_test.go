@@ -0,0 +1,183 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package snowflake_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/catalog/snowflake"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDriver answers the queries issued by the catalog from canned
+// results, keyed by query text and arguments.
+type fakeDriver map[string]*fakeRows
+
+func (d fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{d}, nil }
+
+type fakeConn struct{ results fakeDriver }
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (c fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	key := query
+	for _, a := range args {
+		key += fmt.Sprintf(" [%v]", a.Value)
+	}
+
+	rows, ok := c.results[key]
+	if !ok {
+		return nil, fmt.Errorf("unexpected query: %s", key)
+	}
+
+	return &fakeRows{cols: rows.cols, rows: rows.rows}, nil
+}
+
+type fakeRows struct {
+	cols []string
+	rows [][]driver.Value
+	pos  int
+}
+
+func (r *fakeRows) Columns() []string { return r.cols }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.pos])
+	r.pos++
+
+	return nil
+}
+
+func showRows(rows ...[]driver.Value) *fakeRows {
+	return &fakeRows{cols: []string{"created_on", "name", "database_name", "schema_name"}, rows: rows}
+}
+
+func TestFileIOLocation(t *testing.T) {
+	tests := []struct{ in, out string }{
+		{"s3://bucket/db/tbl/metadata/v1.metadata.json", "s3://bucket/db/tbl/metadata/v1.metadata.json"},
+		{"gcs://bucket/db/tbl/metadata/v1.metadata.json", "gs://bucket/db/tbl/metadata/v1.metadata.json"},
+		{
+			"azure://acct.blob.core.windows.net/container/db/tbl/metadata/v1.metadata.json",
+			"wasbs://container@acct.blob.core.windows.net/db/tbl/metadata/v1.metadata.json",
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.out, snowflake.FileIOLocation(tt.in))
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	ctx := context.Background()
+
+	meta, err := table.NewMetadata(iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true}),
+		iceberg.UnpartitionedSpec, table.UnsortedSortOrder, t.TempDir(), nil)
+	require.NoError(t, err)
+	metaLoc := filepath.Join(t.TempDir(), "v1.metadata.json")
+	data, err := json.Marshal(meta)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(metaLoc, data, 0o644))
+
+	info, err := json.Marshal(map[string]string{"metadataLocation": metaLoc, "status": "success"})
+	require.NoError(t, err)
+
+	sql.Register("snowflake-fake", fakeDriver{
+		"SHOW DATABASES IN ACCOUNT": showRows(
+			[]driver.Value{"", "SALES", nil, nil}, []driver.Value{"", "HR", nil, nil}),
+		"SHOW SCHEMAS IN DATABASE IDENTIFIER(?) [SALES]": showRows(
+			[]driver.Value{"", "PUBLIC", "SALES", nil}),
+		"SHOW DATABASES LIKE ? IN ACCOUNT [sales]": showRows(
+			[]driver.Value{"", "SALES", nil, nil}),
+		"SHOW DATABASES LIKE ? IN ACCOUNT [missing]": showRows(),
+		"SHOW ICEBERG TABLES IN SCHEMA IDENTIFIER(?) [SALES.PUBLIC]": showRows(
+			[]driver.Value{"", "ORDERS", "SALES", "PUBLIC"}, []driver.Value{"", "ITEMS", "SALES", "PUBLIC"}),
+		"SHOW ICEBERG TABLES LIKE ? IN SCHEMA IDENTIFIER(?) [ORDERS] [SALES.PUBLIC]": showRows(
+			[]driver.Value{"", "ORDERS", "SALES", "PUBLIC"}),
+		"SHOW ICEBERG TABLES LIKE ? IN SCHEMA IDENTIFIER(?) [MISSING] [SALES.PUBLIC]": showRows(),
+		"SELECT SYSTEM$GET_ICEBERG_TABLE_INFORMATION(?) [SALES.PUBLIC.ORDERS]": {
+			cols: []string{"METADATA"}, rows: [][]driver.Value{{string(info)}},
+		},
+	})
+
+	cat, err := catalog.Load(ctx, "sf", iceberg.Properties{
+		"type": "snowflake", snowflake.DriverKey: "snowflake-fake", "uri": "snowflake://account",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, catalog.Snowflake, cat.CatalogType())
+
+	namespaces, err := cat.ListNamespaces(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []table.Identifier{{"SALES"}, {"HR"}}, namespaces)
+
+	namespaces, err = cat.ListNamespaces(ctx, table.Identifier{"SALES"})
+	require.NoError(t, err)
+	assert.Equal(t, []table.Identifier{{"SALES", "PUBLIC"}}, namespaces)
+
+	exists, err := cat.CheckNamespaceExists(ctx, table.Identifier{"sales"})
+	require.NoError(t, err)
+	assert.True(t, exists)
+	_, err = cat.LoadNamespaceProperties(ctx, table.Identifier{"missing"})
+	assert.ErrorIs(t, err, catalog.ErrNoSuchNamespace)
+
+	var tables []table.Identifier
+	for ident, err := range cat.ListTables(ctx, table.Identifier{"SALES", "PUBLIC"}) {
+		require.NoError(t, err)
+		tables = append(tables, ident)
+	}
+	assert.Equal(t, []table.Identifier{{"SALES", "PUBLIC", "ORDERS"}, {"SALES", "PUBLIC", "ITEMS"}}, tables)
+
+	tbl, err := cat.LoadTable(ctx, table.Identifier{"SALES", "PUBLIC", "ORDERS"})
+	require.NoError(t, err)
+	assert.Equal(t, metaLoc, tbl.MetadataLocation())
+	assert.True(t, meta.CurrentSchema().Equals(tbl.Schema()))
+
+	_, err = cat.LoadTable(ctx, table.Identifier{"SALES", "PUBLIC", "MISSING"})
+	assert.ErrorIs(t, err, catalog.ErrNoSuchTable)
+	_, err = cat.LoadTable(ctx, table.Identifier{"ORDERS"})
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+	_, err = tbl.NewTransaction().Commit(ctx)
+	require.NoError(t, err)
+	tx := tbl.NewTransaction()
+	require.NoError(t, tx.SetProperties(iceberg.Properties{"a": "b"}))
+	_, err = tx.Commit(ctx)
+	assert.ErrorIs(t, err, snowflake.ErrReadOnly)
+	assert.ErrorIs(t, cat.DropTable(ctx, table.Identifier{"SALES", "PUBLIC", "ORDERS"}), iceberg.ErrNotImplemented)
+	assert.True(t, slices.Equal(table.Identifier{"SALES", "PUBLIC", "ORDERS"}, tbl.Identifier()))
+}
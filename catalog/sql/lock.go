@@ -0,0 +1,105 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/table"
+)
+
+var _ catalog.LockManager = (*AdvisoryLockManager)(nil)
+
+// AdvisoryLockManager is a catalog.LockManager backed by Postgres session
+// level advisory locks, so that writers in different processes sharing a
+// Postgres database serialize their commits. Each held lock keeps one
+// connection of the pool reserved until it is released.
+type AdvisoryLockManager struct {
+	db        *sql.DB
+	namespace string
+}
+
+// NewAdvisoryLockManager returns a LockManager taking advisory locks in the
+// Postgres database of db. The namespace is included in the lock keys to
+// keep them apart from advisory locks taken by other applications of the
+// same database.
+func NewAdvisoryLockManager(db *sql.DB, namespace string) *AdvisoryLockManager {
+	return &AdvisoryLockManager{db: db, namespace: namespace}
+}
+
+// lockKey returns the advisory lock key of a table.
+func (m *AdvisoryLockManager) lockKey(identifier table.Identifier) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(m.namespace))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.Join(identifier, ".")))
+
+	return int64(h.Sum64())
+}
+
+func (m *AdvisoryLockManager) Acquire(ctx context.Context, identifier table.Identifier) (catalog.Lock, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key := m.lockKey(identifier)
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", key); err != nil {
+		discardConn(conn)
+
+		return nil, fmt.Errorf("failed to acquire advisory lock for %s: %w",
+			strings.Join(identifier, "."), err)
+	}
+
+	return &advisoryLock{conn: conn, key: key}, nil
+}
+
+type advisoryLock struct {
+	conn *sql.Conn
+	key  int64
+}
+
+func (l *advisoryLock) Release(ctx context.Context) error {
+	var released bool
+	err := l.conn.QueryRowContext(ctx, "SELECT pg_advisory_unlock($1)", l.key).Scan(&released)
+	if err != nil {
+		discardConn(l.conn)
+
+		return err
+	}
+
+	if !released {
+		err = fmt.Errorf("advisory lock %d was not held", l.key)
+	}
+
+	return errors.Join(err, l.conn.Close())
+}
+
+// discardConn closes conn without returning it to the pool, ending its
+// session and with it any advisory lock it may still hold.
+func discardConn(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+	_ = conn.Close()
+}
@@ -25,6 +25,7 @@ import (
 	"iter"
 	"maps"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	_ "unsafe"
@@ -60,6 +61,10 @@ const (
 	DialectKey           = "sql.dialect"
 	DriverKey            = "sql.driver"
 	initCatalogTablesKey = "init_catalog_tables"
+
+	// defaultPageSize is the page size used by paginated listings when
+	// none is given.
+	defaultPageSize = 100
 )
 
 const (
@@ -247,23 +252,33 @@ func (c *Catalog) ensureTablesExist() error {
 
 func (c *Catalog) namespaceExists(ctx context.Context, ns string) (bool, error) {
 	return withReadTx(ctx, c.db, func(ctx context.Context, tx bun.Tx) (bool, error) {
-		exists, err := tx.NewSelect().Model((*sqlIcebergTable)(nil)).
-			Where("catalog_name = ?", c.name).
-			Where("table_namespace = ?", ns).
-			Limit(1).Exists(ctx)
-		if err != nil {
-			return false, err
-		}
-		if exists {
-			return true, nil
-		}
-
-		return tx.NewSelect().Model((*sqlIcebergNamespaceProps)(nil)).
-			Where("catalog_name = ?", c.name).Where("namespace = ?", ns).
-			Limit(1).Exists(ctx)
+		return c.namespaceExistsTx(ctx, tx, ns)
 	})
 }
 
+func (c *Catalog) namespaceExistsTx(ctx context.Context, tx bun.Tx, ns string) (bool, error) {
+	exists, err := tx.NewSelect().Model((*sqlIcebergTable)(nil)).
+		Where("catalog_name = ?", c.name).
+		Where("table_namespace = ?", ns).
+		Limit(1).Exists(ctx)
+	if err != nil {
+		return false, err
+	}
+	if exists {
+		return true, nil
+	}
+
+	return tx.NewSelect().Model((*sqlIcebergNamespaceProps)(nil)).
+		Where("catalog_name = ?", c.name).Where("namespace = ?", ns).
+		Limit(1).Exists(ctx)
+}
+
+// isNamespaceOrChild reports whether ns is equal to parent or nested
+// somewhere below it.
+func isNamespaceOrChild(ns, parent table.Identifier) bool {
+	return len(ns) >= len(parent) && slices.Equal(ns[:len(parent)], parent)
+}
+
 func checkValidNamespace(ident table.Identifier) error {
 	if len(ident) < 1 {
 		return fmt.Errorf("%w: empty namespace identifier", catalog.ErrNoSuchNamespace)
@@ -325,6 +340,29 @@ func (c *Catalog) CreateTable(ctx context.Context, ident table.Identifier, sc *i
 	return c.LoadTable(ctx, ident)
 }
 
+// StageCreateTable returns a transaction which creates the table when
+// committed, together with any changes staged in the transaction. Nothing
+// is written to the catalog until then.
+func (c *Catalog) StageCreateTable(ctx context.Context, ident table.Identifier, sc *iceberg.Schema, opts ...catalog.CreateTableOpt) (*table.Transaction, error) {
+	ns := strings.Join(catalog.NamespaceFromIdent(ident), ".")
+	exists, err := c.namespaceExists(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", catalog.ErrNoSuchNamespace, ns)
+	}
+
+	if exists, err = c.CheckTableExists(ctx, ident); err != nil {
+		return nil, err
+	} else if exists {
+		return nil, fmt.Errorf("%w: %s", catalog.ErrTableAlreadyExists, strings.Join(ident, "."))
+	}
+
+	return internal.StageCreateTable(ctx, c.props, c.LoadNamespaceProperties, ident, sc, c, opts...)
+}
+
 func (c *Catalog) CommitTable(ctx context.Context, ident table.Identifier, reqs []table.Requirement, updates []table.Update) (table.Metadata, string, error) {
 	ns := catalog.NamespaceFromIdent(ident)
 	tblName := catalog.TableNameFromIdent(ident)
@@ -334,7 +372,7 @@ func (c *Catalog) CommitTable(ctx context.Context, ident table.Identifier, reqs
 		return nil, "", err
 	}
 
-	staged, err := internal.UpdateAndStageTable(ctx, current, ident, reqs, updates, c)
+	staged, err := internal.UpdateAndStageTable(ctx, current, ident, reqs, updates, c.props, c)
 	if err != nil {
 		return nil, "", err
 	}
@@ -344,7 +382,8 @@ func (c *Catalog) CommitTable(ctx context.Context, ident table.Identifier, reqs
 		return current.Metadata(), current.MetadataLocation(), nil
 	}
 
-	if err := internal.WriteMetadata(ctx, staged.Metadata(), staged.MetadataLocation(), staged.Properties()); err != nil {
+	if err := internal.WriteMetadata(ctx, staged.Metadata(), staged.MetadataLocation(),
+		internal.IOProps(c.props, staged.Properties())); err != nil {
 		return nil, "", err
 	}
 
@@ -370,7 +409,7 @@ func (c *Catalog) CommitTable(ctx context.Context, ident table.Identifier, reqs
 			}
 
 			if n == 0 {
-				return fmt.Errorf("table has been updated by another process: %s.%s", strings.Join(ns, "."), tblName)
+				return fmt.Errorf("%w: table has been updated by another process: %s.%s", iceberg.ErrCommitConflict, strings.Join(ns, "."), tblName)
 			}
 
 			return nil
@@ -425,13 +464,7 @@ func (c *Catalog) LoadTable(ctx context.Context, identifier table.Identifier) (*
 		return nil, fmt.Errorf("%w: %s, metadata location is missing", catalog.ErrNoSuchTable, identifier)
 	}
 
-	return table.NewFromLocation(
-		ctx,
-		identifier,
-		result.MetadataLocation.String,
-		io.LoadFSFunc(c.props, result.MetadataLocation.String),
-		c,
-	)
+	return internal.LoadTable(ctx, identifier, result.MetadataLocation.String, c.props, c)
 }
 
 func (c *Catalog) DropTable(ctx context.Context, identifier table.Identifier) error {
@@ -520,17 +553,21 @@ func (c *Catalog) RenameTable(ctx context.Context, from, to table.Identifier) (*
 	return c.LoadTable(ctx, to)
 }
 
+// CheckTableExists checks the table is registered in the catalog without
+// loading its metadata.
 func (c *Catalog) CheckTableExists(ctx context.Context, identifier table.Identifier) (bool, error) {
-	_, err := c.LoadTable(ctx, identifier)
-	if err != nil {
-		if errors.Is(err, catalog.ErrNoSuchTable) {
-			return false, nil
-		}
-
-		return false, err
-	}
+	ns := strings.Join(catalog.NamespaceFromIdent(identifier), ".")
+	tbl := catalog.TableNameFromIdent(identifier)
 
-	return true, nil
+	return withReadTx(ctx, c.db, func(ctx context.Context, tx bun.Tx) (bool, error) {
+		return tx.NewSelect().Model((*sqlIcebergTable)(nil)).
+			Where("catalog_name = ?", c.name).
+			Where("table_namespace = ?", ns).
+			Where("table_name = ?", tbl).
+			Where("iceberg_type = ?", TableType).
+			Where("metadata_location IS NOT NULL").
+			Limit(1).Exists(ctx)
+	})
 }
 
 func (c *Catalog) CreateNamespace(ctx context.Context, namespace table.Identifier, props iceberg.Properties) error {
@@ -605,6 +642,18 @@ func (c *Catalog) DropNamespace(ctx context.Context, namespace table.Identifier)
 		return fmt.Errorf("%w: %d tables exist in namespace %s", catalog.ErrNamespaceNotEmpty, len(tbls), nsToDelete)
 	}
 
+	children, err := c.ListNamespaces(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		if len(child) > len(namespace) {
+			return fmt.Errorf("%w: namespace %s has child namespace %s",
+				catalog.ErrNamespaceNotEmpty, nsToDelete, strings.Join(child, "."))
+		}
+	}
+
 	return withWriteTx(ctx, c.db, func(ctx context.Context, tx bun.Tx) error {
 		_, err := tx.NewDelete().Model((*sqlIcebergNamespaceProps)(nil)).
 			Where("catalog_name = ?", c.name).
@@ -667,6 +716,61 @@ func (c *Catalog) ListTables(ctx context.Context, namespace table.Identifier) it
 	}
 }
 
+// ListTablesPage returns a single page of the tables in namespace ordered
+// by name. Page tokens are the offset of the first table of the page, and
+// the metadata location of the tables is always included.
+func (c *Catalog) ListTablesPage(ctx context.Context, namespace table.Identifier, pageToken string, pageSize int, _ ...catalog.ListTablesOpt) ([]catalog.TableInfo, string, error) {
+	offset, err := parsePageToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	ns := strings.Join(namespace, ".")
+	exists, err := c.namespaceExists(ctx, ns)
+	if err != nil {
+		return nil, "", err
+	}
+	if !exists {
+		return nil, "", fmt.Errorf("%w: %s", catalog.ErrNoSuchNamespace, ns)
+	}
+
+	// fetch one more table than requested to find out whether there is
+	// another page after this one
+	tables, err := withReadTx(ctx, c.db, func(ctx context.Context, tx bun.Tx) ([]sqlIcebergTable, error) {
+		var tables []sqlIcebergTable
+		err := tx.NewSelect().Model(&tables).
+			Where("catalog_name = ?", c.name).
+			Where("table_namespace = ?", ns).
+			Where("iceberg_type = ?", TableType).
+			Order("table_name").
+			Limit(pageSize + 1).Offset(offset).
+			Scan(ctx)
+
+		return tables, err
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("error listing tables for namespace '%s': %w", namespace, err)
+	}
+
+	var next string
+	if len(tables) > pageSize {
+		tables, next = tables[:pageSize], strconv.Itoa(offset+pageSize)
+	}
+
+	ret := make([]catalog.TableInfo, len(tables))
+	for i, t := range tables {
+		ret[i] = catalog.TableInfo{
+			Identifier:       append(strings.Split(t.TableNamespace, "."), t.TableName),
+			MetadataLocation: t.MetadataLocation.String,
+		}
+	}
+
+	return ret, next, nil
+}
+
 func (c *Catalog) listTablesAll(ctx context.Context, namespace table.Identifier) ([]table.Identifier, error) {
 	if len(namespace) > 0 {
 		exists, err := c.namespaceExists(ctx, strings.Join(namespace, "."))
@@ -738,35 +842,108 @@ func (c *Catalog) ListNamespaces(ctx context.Context, parent table.Identifier) (
 		return nil, err
 	}
 
-	ret := make([]table.Identifier, len(namespaces))
-	for i, n := range namespaces {
-		ret[i] = strings.Split(n, ".")
+	// the like pattern also matches siblings sharing the parent as a
+	// string prefix (e.g. "db" matching "db2"), so filter on whole levels.
+	ret := make([]table.Identifier, 0, len(namespaces))
+	for _, n := range namespaces {
+		if ns := strings.Split(n, "."); isNamespaceOrChild(ns, parent) {
+			ret = append(ret, ns)
+		}
 	}
 
 	return ret, nil
 }
 
+// ListNamespacesPage returns a single page of the namespaces under parent
+// ordered by name. Page tokens are the offset of the first namespace of the
+// page.
+func (c *Catalog) ListNamespacesPage(ctx context.Context, parent table.Identifier, pageToken string, pageSize int) ([]table.Identifier, string, error) {
+	offset, err := parsePageToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	namespaces, err := c.ListNamespaces(ctx, parent)
+	if err != nil {
+		return nil, "", err
+	}
+
+	slices.SortFunc(namespaces, func(a, b table.Identifier) int {
+		return slices.Compare(a, b)
+	})
+
+	if offset >= len(namespaces) {
+		return []table.Identifier{}, "", nil
+	}
+
+	namespaces = namespaces[offset:]
+	if len(namespaces) > pageSize {
+		return namespaces[:pageSize], strconv.Itoa(offset + pageSize), nil
+	}
+
+	return namespaces, "", nil
+}
+
+func parsePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	offset, err := strconv.Atoi(token)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("%w: invalid page token %q", iceberg.ErrInvalidArgument, token)
+	}
+
+	return offset, nil
+}
+
 // avoid circular dependency while still avoiding having to export the getUpdatedPropsAndUpdateSummary function
 // so that we can re-use it in the catalog implementations without duplicating the code.
 
 //go:linkname getUpdatedPropsAndUpdateSummary github.com/apache/iceberg-go/catalog.getUpdatedPropsAndUpdateSummary
 func getUpdatedPropsAndUpdateSummary(currentProps iceberg.Properties, removals []string, updates iceberg.Properties) (iceberg.Properties, catalog.PropertiesUpdateSummary, error)
 
+// UpdateNamespaceProperties removes and updates the namespace properties
+// within a single database transaction, so concurrent updates can never
+// observe or produce a partially applied change.
 func (c *Catalog) UpdateNamespaceProperties(ctx context.Context, namespace table.Identifier, removals []string, updates iceberg.Properties) (catalog.PropertiesUpdateSummary, error) {
 	var summary catalog.PropertiesUpdateSummary
-	currentProps, err := c.LoadNamespaceProperties(ctx, namespace)
-	if err != nil {
-		return summary, err
-	}
-
-	_, summary, err = getUpdatedPropsAndUpdateSummary(currentProps, removals, updates)
-	if err != nil {
+	if err := checkValidNamespace(namespace); err != nil {
 		return summary, err
 	}
 
 	nsToUpdate := strings.Join(namespace, ".")
 
-	return summary, withWriteTx(ctx, c.db, func(ctx context.Context, tx bun.Tx) error {
+	err := withWriteTx(ctx, c.db, func(ctx context.Context, tx bun.Tx) error {
+		exists, err := c.namespaceExistsTx(ctx, tx, nsToUpdate)
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			return fmt.Errorf("%w: %s", catalog.ErrNoSuchNamespace, nsToUpdate)
+		}
+
+		var current []sqlIcebergNamespaceProps
+		err = tx.NewSelect().Model(&current).
+			Where("catalog_name = ?", c.name).
+			Where("namespace = ?", nsToUpdate).Scan(ctx)
+		if err != nil {
+			return fmt.Errorf("error loading namespace properties for '%s': %w", namespace, err)
+		}
+
+		currentProps := make(iceberg.Properties, len(current))
+		for _, p := range current {
+			currentProps[p.PropertyKey] = p.PropertyValue.String
+		}
+
+		if _, summary, err = getUpdatedPropsAndUpdateSummary(currentProps, removals, updates); err != nil {
+			return err
+		}
+
 		var m *sqlIcebergNamespaceProps
 		if len(removals) > 0 {
 			_, err := tx.NewDelete().Model(m).
@@ -815,6 +992,8 @@ func (c *Catalog) UpdateNamespaceProperties(ctx context.Context, namespace table
 
 		return nil
 	})
+
+	return summary, err
 }
 
 func (c *Catalog) CheckNamespaceExists(ctx context.Context, namespace table.Identifier) (bool, error) {
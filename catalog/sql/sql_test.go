@@ -26,9 +26,11 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/arrow-go/v18/arrow/array"
 	"github.com/apache/arrow-go/v18/arrow/memory"
 	"github.com/apache/arrow-go/v18/parquet/pqarrow"
@@ -547,6 +549,76 @@ func (s *SqliteCatalogTestSuite) TestListTables() {
 	}
 }
 
+func (s *SqliteCatalogTestSuite) TestListTablesPage() {
+	ctx := context.Background()
+	cat := s.getCatalogSqlite()
+	ns := table.Identifier{databaseName()}
+	s.Require().NoError(cat.CreateNamespace(ctx, ns, nil))
+
+	names := []string{"tbl_c", "tbl_a", "tbl_b"}
+	locations := map[string]string{}
+	for _, name := range names {
+		ident := append(slices.Clone(ns), name)
+		s.Require().NoError(os.MkdirAll(filepath.Join(s.warehouse, ns[0]+".db", name, "metadata"), 0o755))
+		tbl, err := cat.CreateTable(ctx, ident, tableSchemaNested)
+		s.Require().NoError(err)
+		locations[name] = tbl.MetadataLocation()
+	}
+
+	var (
+		listed []string
+		token  string
+		pages  int
+	)
+	for {
+		page, next, err := cat.ListTablesPage(ctx, ns, token, 2, catalog.WithMetadataLocation())
+		s.Require().NoError(err)
+		pages++
+		for _, info := range page {
+			name := catalog.TableNameFromIdent(info.Identifier)
+			listed = append(listed, name)
+			s.Equal(locations[name], info.MetadataLocation)
+		}
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	s.Equal(2, pages)
+	s.Equal([]string{"tbl_a", "tbl_b", "tbl_c"}, listed)
+
+	_, _, err := cat.ListTablesPage(ctx, ns, "not-a-token", 2)
+	s.ErrorIs(err, iceberg.ErrInvalidArgument)
+
+	_, _, err = cat.ListTablesPage(ctx, table.Identifier{"does_not_exist"}, "", 2)
+	s.ErrorIs(err, catalog.ErrNoSuchNamespace)
+
+	exists, err := cat.CheckTableExists(ctx, append(slices.Clone(ns), "tbl_a"))
+	s.Require().NoError(err)
+	s.True(exists)
+	exists, err = cat.CheckTableExists(ctx, append(slices.Clone(ns), "tbl_d"))
+	s.Require().NoError(err)
+	s.False(exists)
+}
+
+func (s *SqliteCatalogTestSuite) TestListNamespacesPage() {
+	ctx := context.Background()
+	cat := s.getCatalogSqlite()
+	for _, ns := range []string{"ns_b", "ns_a", "ns_c"} {
+		s.Require().NoError(cat.CreateNamespace(ctx, table.Identifier{ns}, nil))
+	}
+
+	page, next, err := cat.ListNamespacesPage(ctx, nil, "", 2)
+	s.Require().NoError(err)
+	s.Equal([]table.Identifier{{"ns_a"}, {"ns_b"}}, page)
+	s.NotEmpty(next)
+
+	page, next, err = cat.ListNamespacesPage(ctx, nil, next, 2)
+	s.Require().NoError(err)
+	s.Equal([]table.Identifier{{"ns_c"}}, page)
+	s.Empty(next)
+}
+
 func (s *SqliteCatalogTestSuite) TestListTablesMissingNamespace() {
 	tests := []struct {
 		cat       *sqlcat.Catalog
@@ -600,6 +672,53 @@ func (s *SqliteCatalogTestSuite) TestListNamespaces() {
 	}
 }
 
+func (s *SqliteCatalogTestSuite) TestNestedNamespaces() {
+	ctx := context.Background()
+	cat := s.getCatalogMemory()
+
+	for _, ns := range []table.Identifier{{"db"}, {"db", "child"}, {"db", "child", "leaf"}, {"db2"}} {
+		s.Require().NoError(cat.CreateNamespace(ctx, ns, nil))
+	}
+
+	nslist, err := cat.ListNamespaces(ctx, table.Identifier{"db"})
+	s.Require().NoError(err)
+	s.ElementsMatch([]table.Identifier{{"db"}, {"db", "child"}, {"db", "child", "leaf"}}, nslist)
+
+	nslist, err = cat.ListNamespaces(ctx, table.Identifier{"db", "child"})
+	s.Require().NoError(err)
+	s.ElementsMatch([]table.Identifier{{"db", "child"}, {"db", "child", "leaf"}}, nslist)
+
+	s.ErrorIs(cat.DropNamespace(ctx, table.Identifier{"db", "child"}), catalog.ErrNamespaceNotEmpty)
+	s.Require().NoError(cat.DropNamespace(ctx, table.Identifier{"db", "child", "leaf"}))
+	s.Require().NoError(cat.DropNamespace(ctx, table.Identifier{"db", "child"}))
+
+	exists, err := cat.CheckNamespaceExists(ctx, table.Identifier{"db", "child"})
+	s.Require().NoError(err)
+	s.False(exists)
+
+	summary, err := cat.UpdateNamespaceProperties(ctx, table.Identifier{"db"},
+		[]string{"exists", "missing"}, iceberg.Properties{"owner": "etl"})
+	s.Require().NoError(err)
+	s.Equal([]string{"exists"}, summary.Removed)
+	s.Equal([]string{"owner"}, summary.Updated)
+	s.Equal([]string{"missing"}, summary.Missing)
+
+	props, err := cat.LoadNamespaceProperties(ctx, table.Identifier{"db"})
+	s.Require().NoError(err)
+	s.Equal(iceberg.Properties{"owner": "etl"}, props)
+
+	_, err = cat.UpdateNamespaceProperties(ctx, table.Identifier{"db"},
+		[]string{"owner"}, iceberg.Properties{"owner": "other"})
+	s.Error(err)
+
+	props, err = cat.LoadNamespaceProperties(ctx, table.Identifier{"db"})
+	s.Require().NoError(err)
+	s.Equal(iceberg.Properties{"owner": "etl"}, props)
+
+	_, err = cat.UpdateNamespaceProperties(ctx, table.Identifier{"db", "child"}, nil, iceberg.Properties{"a": "b"})
+	s.ErrorIs(err, catalog.ErrNoSuchNamespace)
+}
+
 func (s *SqliteCatalogTestSuite) TestLoadTableFromSelfIdentifier() {
 	tests := []struct {
 		cat   *sqlcat.Catalog
@@ -1049,6 +1168,256 @@ func (s *SqliteCatalogTestSuite) TestCommitTable() {
 	}
 }
 
+func (s *SqliteCatalogTestSuite) TestCommitTableRequirementConflict() {
+	ctx := context.Background()
+	cat := s.getCatalogSqlite()
+	tblID := s.randomTableIdentifier()
+	s.Require().NoError(cat.CreateNamespace(ctx, catalog.NamespaceFromIdent(tblID), nil))
+
+	_, err := cat.CreateTable(ctx, tblID, tableSchemaNested)
+	s.Require().NoError(err)
+
+	_, _, err = cat.CommitTable(ctx, tblID,
+		[]table.Requirement{table.AssertCurrentSchemaID(99)},
+		[]table.Update{table.NewSetPropertiesUpdate(iceberg.Properties{"a": "b"})})
+	s.ErrorIs(err, iceberg.ErrCommitConflict)
+	s.True(iceberg.IsRetryable(err))
+}
+
+var builderSchema = iceberg.NewSchema(0,
+	iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+	iceberg.NestedField{ID: 2, Name: "name", Type: iceberg.PrimitiveTypes.String},
+)
+
+func (s *SqliteCatalogTestSuite) builderData(sc *iceberg.Schema, rows string) arrow.Table {
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, false, false)
+	s.Require().NoError(err)
+
+	tbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{rows})
+	s.Require().NoError(err)
+
+	return tbl
+}
+
+func (s *SqliteCatalogTestSuite) TestCreateTableBuilder() {
+	ctx := context.Background()
+	cat := s.getCatalogSqlite()
+	tblID := s.randomTableIdentifier()
+	s.Require().NoError(cat.CreateNamespace(ctx, catalog.NamespaceFromIdent(tblID), nil))
+
+	_, err := catalog.NewCreateTableBuilder(cat, tblID).Create(ctx)
+	s.ErrorIs(err, iceberg.ErrInvalidArgument)
+
+	spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+		SourceID: 1, FieldID: 1000, Name: "id_bucket", Transform: iceberg.BucketTransform{NumBuckets: 4},
+	})
+	order, err := table.NewSortOrder(1, []table.SortField{{
+		SourceID: 2, Transform: iceberg.IdentityTransform{}, Direction: table.SortASC, NullOrder: table.NullsFirst,
+	}})
+	s.Require().NoError(err)
+
+	builder := catalog.NewCreateTableBuilder(cat, tblID).
+		WithSchema(builderSchema).
+		WithPartitionSpec(&spec).
+		WithSortOrder(order).
+		WithProperties(iceberg.Properties{"owner": "etl", "retention": "7d"}).
+		WithProperty("retention", "30d")
+
+	tbl, err := builder.Create(ctx)
+	s.Require().NoError(err)
+
+	s.Equal("etl", tbl.Properties()["owner"])
+	s.Equal("30d", tbl.Properties()["retention"])
+	tblSpec := tbl.Spec()
+	s.Equal(1, tblSpec.NumFields())
+	s.Equal("id_bucket", tblSpec.Field(0).Name)
+	s.Len(slices.Collect(tbl.SortOrder().Fields()), 1)
+
+	_, err = builder.Create(ctx)
+	s.ErrorContains(err, "failed to create table")
+}
+
+func (s *SqliteCatalogTestSuite) TestCreateOrReplaceTable() {
+	ctx := context.Background()
+	cat := s.getCatalogSqlite()
+	tblID := s.randomTableIdentifier()
+	s.Require().NoError(cat.CreateNamespace(ctx, catalog.NamespaceFromIdent(tblID), nil))
+
+	tbl, err := catalog.NewCreateTableBuilder(cat, tblID).
+		WithSchema(builderSchema).
+		WithProperty("owner", "etl").
+		CreateOrReplace(ctx)
+	s.Require().NoError(err)
+
+	data := s.builderData(builderSchema, `[{"id": 1, "name": "a"}, {"id": 2, "name": "b"}]`)
+	defer data.Release()
+	tbl, err = tbl.AppendTable(ctx, data, data.NumRows(), nil)
+	s.Require().NoError(err)
+	s.Require().NotNil(tbl.CurrentSnapshot())
+
+	replacement := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "email", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 2, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+	)
+	spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+		SourceID: 2, FieldID: 1000, Name: "id_bucket", Transform: iceberg.BucketTransform{NumBuckets: 8},
+	})
+
+	replaced, err := catalog.NewCreateTableBuilder(cat, tblID).
+		WithSchema(replacement).
+		WithPartitionSpec(&spec).
+		WithProperty("team", "data").
+		CreateOrReplace(ctx)
+	s.Require().NoError(err)
+
+	s.Equal(tbl.Metadata().TableUUID(), replaced.Metadata().TableUUID())
+	s.Nil(replaced.CurrentSnapshot())
+	s.Len(replaced.Metadata().Snapshots(), 1)
+	s.Len(replaced.Metadata().Schemas(), 2)
+
+	sc := replaced.Schema()
+	id, ok := sc.FindFieldByName("id")
+	s.Require().True(ok)
+	s.Equal(1, id.ID)
+	email, ok := sc.FindFieldByName("email")
+	s.Require().True(ok)
+	s.Equal(3, email.ID)
+	s.Equal(3, replaced.Metadata().LastColumnID())
+
+	replacedSpec := replaced.Spec()
+	s.Equal(1, replacedSpec.NumFields())
+	s.Equal(id.ID, replacedSpec.Field(0).SourceID)
+	s.Equal("etl", replaced.Properties()["owner"])
+	s.Equal("data", replaced.Properties()["team"])
+
+	loaded, err := cat.LoadTable(ctx, tblID)
+	s.Require().NoError(err)
+	s.Equal(replaced.MetadataLocation(), loaded.MetadataLocation())
+}
+
+func (s *SqliteCatalogTestSuite) TestStageCreateTable() {
+	ctx := context.Background()
+	cat := s.getCatalogSqlite()
+
+	for _, version := range []string{"1", "2"} {
+		tblID := s.randomTableIdentifier()
+		s.Require().NoError(cat.CreateNamespace(ctx, catalog.NamespaceFromIdent(tblID), nil))
+
+		txn, err := catalog.NewCreateTableBuilder(cat, tblID).
+			WithSchema(builderSchema).
+			WithProperties(iceberg.Properties{table.PropertyFormatVersion: version, "owner": "etl"}).
+			StageCreate(ctx)
+		s.Require().NoError(err)
+
+		data := s.builderData(builderSchema, `[{"id": 1, "name": "a"}]`)
+		s.Require().NoError(txn.AppendTable(ctx, data, data.NumRows(), nil))
+		data.Release()
+
+		exists, err := cat.CheckTableExists(ctx, tblID)
+		s.Require().NoError(err)
+		s.False(exists)
+
+		tbl, err := txn.Commit(ctx)
+		s.Require().NoError(err)
+		s.Equal(version, strconv.Itoa(tbl.Metadata().Version()))
+		s.Equal("etl", tbl.Properties()["owner"])
+		s.Len(tbl.Metadata().Schemas(), 1)
+		s.Require().NotNil(tbl.CurrentSnapshot())
+
+		loaded, err := cat.LoadTable(ctx, tblID)
+		s.Require().NoError(err)
+		s.Equal(tbl.Metadata().TableUUID(), loaded.Metadata().TableUUID())
+
+		rows, err := loaded.Scan().ToArrowTable(ctx)
+		s.Require().NoError(err)
+		s.EqualValues(1, rows.NumRows())
+		rows.Release()
+
+		_, err = catalog.NewCreateTableBuilder(cat, tblID).WithSchema(builderSchema).StageCreate(ctx)
+		s.ErrorIs(err, catalog.ErrTableAlreadyExists)
+	}
+}
+
+func (s *SqliteCatalogTestSuite) TestStageCreateTableConflict() {
+	ctx := context.Background()
+	cat := s.getCatalogSqlite()
+	tblID := s.randomTableIdentifier()
+	s.Require().NoError(cat.CreateNamespace(ctx, catalog.NamespaceFromIdent(tblID), nil))
+
+	builder := catalog.NewCreateTableBuilder(cat, tblID).WithSchema(builderSchema)
+	txn, err := builder.StageCreate(ctx)
+	s.Require().NoError(err)
+
+	_, err = builder.Create(ctx)
+	s.Require().NoError(err)
+
+	_, err = txn.Commit(ctx)
+	s.ErrorIs(err, iceberg.ErrCommitConflict)
+
+	txn, err = builder.WithProperty("owner", "etl").StageCreateOrReplace(ctx)
+	s.Require().NoError(err)
+	tbl, err := txn.Commit(ctx)
+	s.Require().NoError(err)
+	s.Equal("etl", tbl.Properties()["owner"])
+}
+
+func (s *SqliteCatalogTestSuite) TestReplaceTableTransaction() {
+	ctx := context.Background()
+	cat := s.getCatalogSqlite()
+	tblID := s.randomTableIdentifier()
+	s.Require().NoError(cat.CreateNamespace(ctx, catalog.NamespaceFromIdent(tblID), nil))
+
+	_, err := catalog.ReplaceTableTransaction(ctx, cat, tblID, builderSchema, false)
+	s.ErrorIs(err, catalog.ErrNoSuchTable)
+
+	txn, err := catalog.CreateTableTransaction(ctx, cat, tblID, builderSchema,
+		catalog.WithProperties(iceberg.Properties{"owner": "etl"}))
+	s.Require().NoError(err)
+
+	data := s.builderData(builderSchema, `[{"id": 1, "name": "a"}, {"id": 2, "name": "b"}]`)
+	defer data.Release()
+	s.Require().NoError(txn.AppendTable(ctx, data, data.NumRows(), nil))
+	original, err := txn.Commit(ctx)
+	s.Require().NoError(err)
+
+	replacement := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "score", Type: iceberg.PrimitiveTypes.Float64},
+	)
+	txn, err = catalog.ReplaceTableTransaction(ctx, cat, tblID, replacement, false)
+	s.Require().NoError(err)
+
+	newData := s.builderData(replacement, `[{"id": 3, "score": 0.5}]`)
+	defer newData.Release()
+	s.Require().NoError(txn.AppendTable(ctx, newData, newData.NumRows(), nil))
+
+	// readers keep seeing the previous version until the commit
+	loaded, err := cat.LoadTable(ctx, tblID)
+	s.Require().NoError(err)
+	s.Equal(original.MetadataLocation(), loaded.MetadataLocation())
+	rows, err := loaded.Scan().ToArrowTable(ctx)
+	s.Require().NoError(err)
+	s.EqualValues(2, rows.NumRows())
+	rows.Release()
+
+	replaced, err := txn.Commit(ctx)
+	s.Require().NoError(err)
+	s.Len(replaced.Metadata().Snapshots(), 2)
+	s.Nil(replaced.CurrentSnapshot().ParentSnapshotID)
+	s.Equal("etl", replaced.Properties()["owner"])
+
+	rows, err = replaced.Scan().ToArrowTable(ctx)
+	s.Require().NoError(err)
+	defer rows.Release()
+	s.EqualValues(1, rows.NumRows())
+	s.Equal("score", rows.Schema().Field(1).Name)
+
+	txn, err = catalog.ReplaceTableTransaction(ctx, cat, table.Identifier{tblID[0], tableName()}, builderSchema, true)
+	s.Require().NoError(err)
+	_, err = txn.Commit(ctx)
+	s.Require().NoError(err)
+}
+
 func (s *SqliteCatalogTestSuite) TestCreateView() {
 	db := s.getCatalogSqlite()
 	s.Require().NoError(db.CreateSQLTables(context.Background()))
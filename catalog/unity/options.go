@@ -0,0 +1,62 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package unity
+
+import "github.com/apache/iceberg-go/catalog/rest"
+
+type Option func(*options)
+
+// WithToken authenticates with a Databricks personal access token.
+func WithToken(token string) Option {
+	return func(o *options) {
+		o.token = token
+	}
+}
+
+// WithCredential authenticates as a service principal with OAuth
+// machine-to-machine credentials in the form "<client-id>:<client-secret>",
+// exchanged for a token at the workspace's OIDC token endpoint.
+func WithCredential(credential string) Option {
+	return func(o *options) {
+		o.credential = credential
+	}
+}
+
+// WithEndpoint overrides the Iceberg REST endpoint derived from the
+// workspace URL, for example to use the legacy
+// /api/2.1/unity-catalog/iceberg path.
+func WithEndpoint(endpoint string) Option {
+	return func(o *options) {
+		o.endpoint = endpoint
+	}
+}
+
+// WithRESTOptions passes additional options to the underlying REST
+// catalog, for example rest.WithAdditionalProps for FileIO properties.
+func WithRESTOptions(opts ...rest.Option) Option {
+	return func(o *options) {
+		o.restOptions = append(o.restOptions, opts...)
+	}
+}
+
+type options struct {
+	token       string
+	credential  string
+	endpoint    string
+	restOptions []rest.Option
+}
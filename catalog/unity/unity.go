@@ -0,0 +1,156 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package unity provides a catalog for Databricks Unity Catalog through
+// the Iceberg REST endpoint of a Databricks workspace. Tables loaded from
+// it use the storage credentials vended by Unity Catalog.
+package unity
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/catalog/rest"
+)
+
+const (
+	// EndpointPath is the path of the Iceberg REST API in a workspace.
+	EndpointPath = "/api/2.1/unity-catalog/iceberg-rest"
+
+	// Endpoint is the catalog property overriding the Iceberg REST
+	// endpoint derived from the workspace URL in "uri". The Unity
+	// Catalog catalog name is read from "warehouse".
+	Endpoint = "unity.endpoint"
+	// Token is the catalog property holding a personal access token.
+	Token = "token"
+	// Credential is the catalog property holding the
+	// "<client-id>:<client-secret>" of a service principal, used for
+	// OAuth machine-to-machine authentication if no token is set.
+	Credential = "credential"
+
+	hostEnv  = "DATABRICKS_HOST"
+	tokenEnv = "DATABRICKS_TOKEN"
+
+	oauthScope = "all-apis"
+)
+
+func init() {
+	reg := catalog.RegistrarFunc(func(ctx context.Context, name string, props iceberg.Properties) (catalog.Catalog, error) {
+		// the workspace uri and credentials must not reach the REST
+		// catalog's properties, which are passed on to tables
+		restProps := maps.Clone(props)
+		for _, k := range []string{"uri", "type", Endpoint, Token, Credential} {
+			delete(restProps, k)
+		}
+
+		opts := []Option{WithRESTOptions(rest.WithAdditionalProps(restProps))}
+		if ep := props.Get(Endpoint, ""); ep != "" {
+			opts = append(opts, WithEndpoint(ep))
+		}
+
+		if tok := props.Get(Token, os.Getenv(tokenEnv)); tok != "" {
+			opts = append(opts, WithToken(tok))
+		} else if cred := props.Get(Credential, ""); cred != "" {
+			opts = append(opts, WithCredential(cred))
+		}
+
+		return NewCatalog(ctx, name, props.Get("uri", os.Getenv(hostEnv)), props.Get("warehouse", ""), opts...)
+	})
+
+	catalog.Register(string(catalog.Unity), reg)
+	catalog.Register("uc", reg)
+}
+
+// Catalog is a Unity Catalog catalog. All catalog operations go through
+// the workspace's Iceberg REST endpoint, where the schemas of the Unity
+// Catalog catalog are single level namespaces.
+type Catalog struct {
+	*rest.Catalog
+
+	workspace   *url.URL
+	catalogName string
+}
+
+var _ catalog.Catalog = (*Catalog)(nil)
+
+// NewCatalog returns a catalog for the Unity Catalog catalog named
+// catalogName in the Databricks workspace at workspace, such as
+// "https://dbc-1234.cloud.databricks.com".
+func NewCatalog(ctx context.Context, name, workspace, catalogName string, opts ...Option) (*Catalog, error) {
+	if workspace == "" {
+		return nil, fmt.Errorf("%w: unity catalog requires a workspace url", iceberg.ErrInvalidArgument)
+	}
+	if catalogName == "" {
+		return nil, fmt.Errorf("%w: unity catalog requires a catalog name as the warehouse", iceberg.ErrInvalidArgument)
+	}
+
+	if !strings.Contains(workspace, "://") {
+		workspace = "https://" + workspace
+	}
+	ws, err := url.Parse(strings.TrimSuffix(workspace, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid workspace url: %w", iceberg.ErrInvalidArgument, err)
+	}
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.endpoint == "" {
+		o.endpoint = ws.JoinPath(EndpointPath).String()
+	}
+
+	// the config endpoint returns the catalogs/<name> prefix as well, it
+	// is set up front for workspaces that leave it out
+	restOpts := []rest.Option{
+		rest.WithWarehouseLocation(catalogName),
+		rest.WithPrefix("catalogs/" + url.PathEscape(catalogName)),
+	}
+	switch {
+	case o.token != "":
+		restOpts = append(restOpts, rest.WithOAuthToken(o.token))
+	case o.credential != "":
+		restOpts = append(restOpts, rest.WithCredential(o.credential),
+			rest.WithAuthURI(ws.JoinPath("oidc", "v1", "token")), rest.WithScope(oauthScope))
+	}
+
+	restCat, err := rest.NewCatalog(ctx, name, o.endpoint, append(restOpts, o.restOptions...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Catalog{
+		Catalog:     restCat,
+		workspace:   ws,
+		catalogName: catalogName,
+	}, nil
+}
+
+func (c *Catalog) CatalogType() catalog.Type { return catalog.Unity }
+
+// CatalogName returns the name of the Unity Catalog catalog.
+func (c *Catalog) CatalogName() string { return c.catalogName }
+
+// Workspace returns the URL of the Databricks workspace.
+func (c *Catalog) Workspace() *url.URL { return c.workspace }
@@ -0,0 +1,144 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package unity_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/catalog/unity"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalog(t *testing.T) {
+	meta, err := table.NewMetadata(iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true}),
+		iceberg.UnpartitionedSpec, table.UnsortedSortOrder, t.TempDir(), nil)
+	require.NoError(t, err)
+	rawMeta, err := json.Marshal(meta)
+	require.NoError(t, err)
+
+	var (
+		warehouse string
+		auth      string
+		commits   []map[string]any
+	)
+
+	const api = "/api/2.1/unity-catalog/iceberg-rest/v1"
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.URL.Path == "/oidc/v1/token":
+			require.NoError(t, req.ParseForm())
+			assert.Equal(t, "sp-id", req.PostForm.Get("client_id"))
+			assert.Equal(t, "sp-secret", req.PostForm.Get("client_secret"))
+			assert.Equal(t, "all-apis", req.PostForm.Get("scope"))
+			json.NewEncoder(w).Encode(map[string]any{"access_token": "m2m-token", "token_type": "Bearer"})
+
+			return
+		case !strings.HasPrefix(req.Header.Get("Authorization"), "Bearer "):
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		auth = req.Header.Get("Authorization")
+		assert.Equal(t, "vended-credentials", req.Header.Get("X-Iceberg-Access-Delegation"))
+
+		switch req.Method + " " + req.URL.Path {
+		case "GET " + api + "/config":
+			warehouse = req.URL.Query().Get("warehouse")
+			json.NewEncoder(w).Encode(map[string]any{
+				"defaults": map[string]any{}, "overrides": map[string]any{"prefix": "catalogs/" + warehouse},
+			})
+		case "GET " + api + "/catalogs/main/namespaces/sales/tables/orders":
+			json.NewEncoder(w).Encode(map[string]any{
+				"metadata-location": meta.Location() + "/metadata/00001.metadata.json",
+				"metadata":          json.RawMessage(rawMeta),
+				"config":            map[string]string{},
+				"storage-credentials": []map[string]any{
+					{"prefix": meta.Location(), "config": map[string]string{"s3.access-key-id": "vended"}},
+				},
+			})
+		case "POST " + api + "/catalogs/main/namespaces/sales/tables/orders":
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			commits = append(commits, body)
+			json.NewEncoder(w).Encode(map[string]any{
+				"metadata-location": meta.Location() + "/metadata/00002.metadata.json",
+				"metadata":          json.RawMessage(rawMeta),
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{
+				"message": "not found: " + req.URL.Path, "type": "NoSuchTableException", "code": 404,
+			}})
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	ctx := context.Background()
+	_, err = unity.NewCatalog(ctx, "uc", srv.URL, "")
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+	cat, err := unity.NewCatalog(ctx, "uc", srv.URL, "main", unity.WithToken("dapi-pat"))
+	require.NoError(t, err)
+	assert.Equal(t, catalog.Unity, cat.CatalogType())
+	assert.Equal(t, "main", cat.CatalogName())
+	assert.Equal(t, "main", warehouse)
+	assert.Equal(t, "Bearer dapi-pat", auth)
+
+	tbl, err := cat.LoadTable(ctx, table.Identifier{"sales", "orders"})
+	require.NoError(t, err)
+	assert.Equal(t, meta.Location()+"/metadata/00001.metadata.json", tbl.MetadataLocation())
+
+	tx := tbl.NewTransaction()
+	require.NoError(t, tx.SetProperties(iceberg.Properties{"owner": "etl"}))
+	tbl, err = tx.Commit(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, meta.Location()+"/metadata/00002.metadata.json", tbl.MetadataLocation())
+	require.Len(t, commits, 1)
+	assert.Equal(t, map[string]any{"namespace": []any{"sales"}, "name": "orders"}, commits[0]["identifier"])
+
+	_, err = cat.LoadTable(ctx, table.Identifier{"sales", "missing"})
+	assert.ErrorIs(t, err, catalog.ErrNoSuchTable)
+
+	// service principals exchange their credential at the workspace's
+	// OIDC endpoint
+	_, err = unity.NewCatalog(ctx, "uc", srv.URL, "main", unity.WithCredential("sp-id:sp-secret"))
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer m2m-token", auth)
+
+	t.Setenv("DATABRICKS_TOKEN", "env-pat")
+	loaded, err := catalog.Load(ctx, "uc", iceberg.Properties{"type": "uc", "uri": srv.URL, "warehouse": "main"})
+	require.NoError(t, err)
+	assert.Equal(t, catalog.Unity, loaded.CatalogType())
+	assert.Equal(t, "Bearer env-pat", auth)
+
+	_, err = loaded.LoadTable(ctx, table.Identifier{"sales", "orders"})
+	require.NoError(t, err)
+}
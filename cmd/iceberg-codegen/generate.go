@@ -0,0 +1,458 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"slices"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/apache/iceberg-go"
+)
+
+// genField describes how a field of the schema is held by the generated
+// struct, appended by the generated encoder and read by the generated
+// decoder.
+type genField struct {
+	Name     string
+	ID       int
+	Required bool
+	Index    int
+	GoName   string
+	// GoType is the type of the struct field, a pointer to it if Pointer
+	// is set.
+	GoType  string
+	Pointer bool
+	// Builder is the arrow builder of the column, and Append the
+	// expression converting a value, %s, for it.
+	Builder string
+	Append  string
+	// Array is the type the column is asserted to when decoding, Check an
+	// optional further condition on the asserted array, arr, and Value the
+	// expression converting arr.Value(i), %s, to GoType.
+	Array string
+	Check string
+	Value string
+}
+
+// AppendExpr returns the expression appending the field of v to its
+// builder.
+func (f genField) AppendExpr(v string) string {
+	x := v + "." + f.GoName
+	if f.Pointer && !strings.HasPrefix(f.Append, "%s.") {
+		// field selectors dereference the pointer themselves
+		x = "*" + x
+	}
+
+	return fmt.Sprintf(f.Append, x)
+}
+
+func (f genField) ValueExpr(v string) string { return fmt.Sprintf(f.Value, v) }
+
+type genType struct {
+	Package    string
+	Type       string
+	SchemaJSON string
+	Fields     []genField
+	StdImports []string
+	Imports    []string
+}
+
+// lowerFirst returns name with its leading initialism or letter in
+// lower case, for unexported identifiers derived from it.
+func lowerFirst(name string) string {
+	runes := []rune(name)
+	i := 0
+	for i < len(runes) && unicode.IsUpper(runes[i]) {
+		i++
+	}
+	if i > 1 && i < len(runes) {
+		i-- // keep the first letter of the next word
+	}
+	if i == 0 {
+		i = 1
+	}
+
+	return strings.ToLower(string(runes[:i])) + string(runes[i:])
+}
+
+var funcs = template.FuncMap{"lowerFirst": lowerFirst}
+
+// initialisms are the words written in upper case in Go names.
+var initialisms = map[string]bool{
+	"id": true, "ip": true, "uuid": true, "url": true, "uri": true,
+	"http": true, "json": true, "sql": true, "api": true,
+}
+
+// goName returns the exported Go name of a field name such as
+// "event_id" or "eventId".
+func goName(name string) string {
+	var (
+		b    strings.Builder
+		word []rune
+	)
+	flush := func() {
+		if len(word) == 0 {
+			return
+		}
+		w := string(word)
+		if initialisms[strings.ToLower(w)] {
+			b.WriteString(strings.ToUpper(w))
+		} else {
+			b.WriteRune(unicode.ToUpper(word[0]))
+			b.WriteString(string(word[1:]))
+		}
+		word = word[:0]
+	}
+
+	for _, r := range name {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && len(word) > 0 && !unicode.IsUpper(word[len(word)-1]):
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+	}
+	flush()
+
+	out := b.String()
+	if out == "" || !unicode.IsLetter([]rune(out)[0]) {
+		out = "F" + out
+	}
+
+	return out
+}
+
+// newGenField returns the description of the top-level field f at index
+// of the schema.
+func newGenField(f iceberg.NestedField, index int) (genField, error) {
+	g := genField{
+		Name: f.Name, ID: f.ID, Required: f.Required, Index: index,
+		GoName: goName(f.Name), Append: "%s", Value: "%s",
+	}
+
+	switch t := f.Type.(type) {
+	case iceberg.BooleanType:
+		g.GoType, g.Builder, g.Array = "bool", "*array.BooleanBuilder", "interface{ Value(int) bool }"
+	case iceberg.Int32Type:
+		g.GoType, g.Builder, g.Array = "int32", "*array.Int32Builder", "*array.Int32"
+	case iceberg.Int64Type:
+		g.GoType, g.Builder, g.Array = "int64", "*array.Int64Builder", "*array.Int64"
+	case iceberg.Float32Type:
+		g.GoType, g.Builder, g.Array = "float32", "*array.Float32Builder", "*array.Float32"
+	case iceberg.Float64Type:
+		g.GoType, g.Builder, g.Array = "float64", "*array.Float64Builder", "*array.Float64"
+	case iceberg.StringType:
+		g.GoType, g.Builder, g.Array = "string", "*array.StringBuilder", "interface{ Value(int) string }"
+	case iceberg.BinaryType:
+		g.GoType, g.Builder, g.Array = "[]byte", "*array.BinaryBuilder", "interface{ Value(int) []byte }"
+		g.Value = "bytes.Clone(%s)"
+	case iceberg.FixedType:
+		g.GoType, g.Builder, g.Array = "[]byte", "*array.FixedSizeBinaryBuilder", "*array.FixedSizeBinary"
+		g.Value = "bytes.Clone(%s)"
+	case iceberg.DateType:
+		g.GoType, g.Builder, g.Array = "iceberg.Date", "*array.Date32Builder", "*array.Date32"
+		g.Append, g.Value = "arrow.Date32(%s)", "iceberg.Date(%s)"
+	case iceberg.TimeType:
+		g.GoType, g.Builder, g.Array = "iceberg.Time", "*array.Time64Builder", "*array.Time64"
+		g.Append, g.Value = "arrow.Time64(%s)", "iceberg.Time(%s)"
+		g.Check = "arr.DataType().(*arrow.Time64Type).Unit == arrow.Microsecond"
+	case iceberg.TimestampType, iceberg.TimestampTzType:
+		g.GoType, g.Builder, g.Array = "iceberg.Timestamp", "*array.TimestampBuilder", "*array.Timestamp"
+		g.Append, g.Value = "arrow.Timestamp(%s)", "iceberg.Timestamp(%s)"
+		g.Check = "arr.DataType().(*arrow.TimestampType).Unit == arrow.Microsecond"
+	case iceberg.TimestampNsType, iceberg.TimestampTzNsType:
+		g.GoType, g.Builder, g.Array = "iceberg.TimestampNano", "*array.TimestampBuilder", "*array.Timestamp"
+		g.Append, g.Value = "arrow.Timestamp(%s)", "iceberg.TimestampNano(%s)"
+		g.Check = "arr.DataType().(*arrow.TimestampType).Unit == arrow.Nanosecond"
+	case iceberg.UUIDType:
+		g.GoType, g.Builder, g.Array = "uuid.UUID", "*extensions.UUIDBuilder", "*extensions.UUIDArray"
+	case iceberg.DecimalType:
+		g.GoType, g.Builder, g.Array = "iceberg.Decimal", "*array.Decimal128Builder", "*array.Decimal128"
+		g.Append = "%s.Val"
+		g.Value = fmt.Sprintf("iceberg.Decimal{Val: %%s, Scale: %d}", t.Scale())
+		g.Check = fmt.Sprintf("arr.DataType().(*arrow.Decimal128Type).Scale == %d", t.Scale())
+	default:
+		return g, fmt.Errorf("%w: field %s of type %s, only primitive fields are supported",
+			iceberg.ErrNotImplemented, f.Name, f.Type)
+	}
+
+	g.Pointer = !f.Required && g.GoType != "[]byte"
+
+	return g, nil
+}
+
+// generate returns the Go source declaring the struct typeName, holding
+// the rows of the Iceberg schema in schemaJSON, with its encoder and
+// decoder, in package pkg.
+func generate(pkg, typeName string, schemaJSON []byte) ([]byte, error) {
+	if !token.IsIdentifier(typeName) || !token.IsExported(typeName) {
+		return nil, fmt.Errorf("%w: type name %q is not an exported Go identifier",
+			iceberg.ErrInvalidArgument, typeName)
+	}
+
+	var sc iceberg.Schema
+	if err := json.Unmarshal(schemaJSON, &sc); err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+
+	// the schema is embedded in its canonical form
+	canonical, err := json.MarshalIndent(&sc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Contains(canonical, []byte("`")) {
+		return nil, fmt.Errorf("%w: schema contains a backquote", iceberg.ErrInvalidArgument)
+	}
+
+	gt := genType{Package: pkg, Type: typeName, SchemaJSON: string(canonical)}
+	imports := map[string]bool{
+		"encoding/json": true, "fmt": true, "strconv": true, "sync": true,
+		"github.com/apache/arrow-go/v18/arrow":        true,
+		"github.com/apache/arrow-go/v18/arrow/array":  true,
+		"github.com/apache/arrow-go/v18/arrow/memory": true,
+		"github.com/apache/iceberg-go":                true,
+		"github.com/apache/iceberg-go/table":          true,
+	}
+
+	names := make(map[string]bool)
+	for i, f := range sc.Fields() {
+		g, err := newGenField(f, i)
+		if err != nil {
+			return nil, err
+		}
+		if names[g.GoName] {
+			g.GoName = fmt.Sprintf("%s%d", g.GoName, f.ID)
+		}
+		names[g.GoName] = true
+
+		switch g.GoType {
+		case "[]byte":
+			imports["bytes"] = true
+		case "uuid.UUID":
+			imports["github.com/apache/arrow-go/v18/arrow/extensions"] = true
+			imports["github.com/google/uuid"] = true
+		}
+		gt.Fields = append(gt.Fields, g)
+	}
+	if len(gt.Fields) == 0 {
+		return nil, fmt.Errorf("%w: schema has no fields", iceberg.ErrInvalidArgument)
+	}
+
+	for imp := range imports {
+		if strings.Contains(imp, ".") {
+			gt.Imports = append(gt.Imports, imp)
+		} else {
+			gt.StdImports = append(gt.StdImports, imp)
+		}
+	}
+	slices.Sort(gt.StdImports)
+	slices.Sort(gt.Imports)
+
+	var buf bytes.Buffer
+	if err := codeTemplate.Execute(&buf, gt); err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+
+	return src, nil
+}
+
+var codeTemplate = template.Must(template.New("code").Funcs(funcs).Parse(`// Code generated by iceberg-codegen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .StdImports}}
+	"{{.}}"
+{{- end}}
+{{range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+
+{{$lower := lowerFirst .Type -}}
+// {{$lower}}SchemaJSON is the Iceberg schema {{.Type}} was generated from.
+const {{$lower}}SchemaJSON = ` + "`{{.SchemaJSON}}`" + `
+
+// {{.Type}} is a row of the Iceberg schema {{.Type}}Schema. Optional
+// fields are nil when null.
+type {{.Type}} struct {
+{{- range .Fields}}
+	// {{.GoName}} holds field {{.ID}}, {{.Name}}.
+	{{.GoName}} {{if .Pointer}}*{{end}}{{.GoType}}
+{{- end}}
+}
+
+var {{$lower}}Schema = sync.OnceValue(func() *iceberg.Schema {
+	var sc iceberg.Schema
+	if err := json.Unmarshal([]byte({{$lower}}SchemaJSON), &sc); err != nil {
+		panic(err)
+	}
+
+	return &sc
+})
+
+// {{.Type}}Schema returns the Iceberg schema of {{.Type}}.
+func {{.Type}}Schema() *iceberg.Schema { return {{$lower}}Schema() }
+
+var {{$lower}}ArrowSchema = sync.OnceValue(func() *arrow.Schema {
+	sc, err := table.SchemaToArrowSchema({{.Type}}Schema(), nil, true, false)
+	if err != nil {
+		panic(err)
+	}
+
+	return sc
+})
+
+// {{.Type}}ArrowSchema returns the Arrow schema of the records written by
+// {{.Type}}Encoder, with the field ID of each column in its metadata.
+func {{.Type}}ArrowSchema() *arrow.Schema { return {{$lower}}ArrowSchema() }
+
+// {{.Type}}Encoder appends {{.Type}} values to Arrow records, for example to
+// write them with Table.Append, without reflection.
+type {{.Type}}Encoder struct {
+	bldr *array.RecordBuilder
+{{- range .Fields}}
+	{{lowerFirst .GoName}} {{.Builder}}
+{{- end}}
+}
+
+// New{{.Type}}Encoder returns an encoder allocating from mem.
+func New{{.Type}}Encoder(mem memory.Allocator) *{{.Type}}Encoder {
+	bldr := array.NewRecordBuilder(mem, {{.Type}}ArrowSchema())
+
+	return &{{.Type}}Encoder{
+		bldr: bldr,
+{{- range .Fields}}
+		{{lowerFirst .GoName}}: bldr.Field({{.Index}}).({{.Builder}}),
+{{- end}}
+	}
+}
+
+// Append appends v as a row of the next record.
+func (e *{{.Type}}Encoder) Append(v *{{.Type}}) {
+{{- range .Fields}}
+{{- if .Pointer}}
+	if v.{{.GoName}} == nil {
+		e.{{lowerFirst .GoName}}.AppendNull()
+	} else {
+		e.{{lowerFirst .GoName}}.Append({{.AppendExpr "v"}})
+	}
+{{- else if and (not .Required) (eq .GoType "[]byte")}}
+	if v.{{.GoName}} == nil {
+		e.{{lowerFirst .GoName}}.AppendNull()
+	} else {
+		e.{{lowerFirst .GoName}}.Append({{.AppendExpr "v"}})
+	}
+{{- else}}
+	e.{{lowerFirst .GoName}}.Append({{.AppendExpr "v"}})
+{{- end}}
+{{- end}}
+}
+
+// Len returns the number of rows appended since the last record.
+func (e *{{.Type}}Encoder) Len() int { return e.{{lowerFirst (index .Fields 0).GoName}}.Len() }
+
+// NewRecordBatch returns the rows appended since the last record as a
+// record, which must be released by the caller.
+func (e *{{.Type}}Encoder) NewRecordBatch() arrow.RecordBatch { return e.bldr.NewRecordBatch() }
+
+// Release releases the memory held by the encoder.
+func (e *{{.Type}}Encoder) Release() { e.bldr.Release() }
+
+// {{$lower}}Columns returns the index of the column of sc holding each field
+// of {{.Type}}, or -1 for fields sc does not hold. Columns are matched by
+// field ID, or by name for columns without one.
+func {{$lower}}Columns(sc *arrow.Schema) [{{len .Fields}}]int {
+	cols := [{{len .Fields}}]int{ {{- range $i, $f := .Fields}}{{if $i}}, {{end}}-1{{end -}} }
+	for i, f := range sc.Fields() {
+		id := -1
+		if v, ok := f.Metadata.GetValue(table.ArrowParquetFieldIDKey); ok {
+			if parsed, err := strconv.Atoi(v); err == nil {
+				id = parsed
+			}
+		}
+
+		switch {
+{{- range .Fields}}
+		case id == {{.ID}} || (id < 0 && f.Name == {{printf "%q" .Name}}):
+			cols[{{.Index}}] = i
+{{- end}}
+		}
+	}
+
+	return cols
+}
+
+// Decode{{.Type}} decodes the rows of rec into {{.Type}} values. Columns are
+// matched by field ID, so that records whose columns were renamed or
+// reordered are decoded as well; optional fields without a column are
+// null.
+func Decode{{.Type}}(rec arrow.RecordBatch) ([]{{.Type}}, error) {
+	cols := {{$lower}}Columns(rec.Schema())
+	rows := make([]{{.Type}}, rec.NumRows())
+{{range .Fields}}
+	if col := cols[{{.Index}}]; col >= 0 {
+		arr, ok := rec.Column(col).({{.Array}})
+		{{- if .Check}}
+		ok = ok && {{.Check}}
+		{{- end}}
+		if !ok {
+			return nil, fmt.Errorf("%w: column %d of type %s cannot hold field {{.Name}} of type {{.GoType}}",
+				iceberg.ErrInvalidSchema, col, rec.Column(col).DataType())
+		}
+
+		nulls := rec.Column(col)
+		for i := range rows {
+			if nulls.IsNull(i) {
+{{- if .Required}}
+				return nil, fmt.Errorf("%w: required field {{.Name}} is null in row %d", iceberg.ErrInvalidArgument, i)
+{{- else}}
+				continue
+{{- end}}
+			}
+{{- if .Pointer}}
+			v := {{.ValueExpr "arr.Value(i)"}}
+			rows[i].{{.GoName}} = &v
+{{- else}}
+			rows[i].{{.GoName}} = {{.ValueExpr "arr.Value(i)"}}
+{{- end}}
+		}
+	}
+{{- if .Required}} else {
+		return nil, fmt.Errorf("%w: required field {{.Name}} is missing", iceberg.ErrInvalidSchema)
+	}
+{{- end}}
+{{end}}
+	return rows, nil
+}
+`))
@@ -0,0 +1,86 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoName(t *testing.T) {
+	tests := []struct {
+		name, expected string
+	}{
+		{"event_id", "EventID"},
+		{"eventId", "EventID"},
+		{"user_name", "UserName"},
+		{"source-url", "SourceURL"},
+		{"ts", "Ts"},
+		{"HTTPStatus", "HTTPStatus"},
+		{"1st", "F1st"},
+		{"_", "F"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, goName(tt.name), tt.name)
+	}
+}
+
+func TestLowerFirst(t *testing.T) {
+	assert.Equal(t, "event", lowerFirst("Event"))
+	assert.Equal(t, "eventID", lowerFirst("EventID"))
+	assert.Equal(t, "id", lowerFirst("ID"))
+	assert.Equal(t, "httpStatus", lowerFirst("HTTPStatus"))
+}
+
+// TestGenerateExample checks that the generated code of the example
+// package is up to date.
+func TestGenerateExample(t *testing.T) {
+	schemaJSON, err := os.ReadFile(filepath.Join("internal", "example", "event.json"))
+	require.NoError(t, err)
+
+	src, err := generate("example", "Event", schemaJSON)
+	require.NoError(t, err)
+
+	expected, err := os.ReadFile(filepath.Join("internal", "example", "event_gen.go"))
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(src), "run go generate in internal/example")
+}
+
+func TestGenerateErrors(t *testing.T) {
+	const nested = `{"type": "struct", "schema-id": 0, "fields": [
+		{"id": 1, "name": "tags", "type": {"type": "list", "element-id": 2, "element": "string", "element-required": true}, "required": false}
+	]}`
+
+	_, err := generate("example", "Event", []byte(nested))
+	assert.ErrorIs(t, err, iceberg.ErrNotImplemented)
+
+	_, err = generate("example", "event", []byte(`{"type": "struct", "schema-id": 0, "fields": []}`))
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+	_, err = generate("example", "Event", []byte(`{"type": "struct", "schema-id": 0, "fields": []}`))
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+	_, err = generate("example", "Event", []byte(`{`))
+	assert.Error(t, err)
+}
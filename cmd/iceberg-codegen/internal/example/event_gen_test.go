@@ -0,0 +1,173 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package example
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func testEvents() []Event {
+	return []Event{
+		{
+			EventID:   uuid.MustParse("f79c3e09-677c-4bbd-a479-3f349cb785e7"),
+			UserName:  "alice",
+			Ts:        iceberg.Timestamp(1_700_000_000_000_000),
+			EventDate: ptr(iceberg.Date(19675)),
+			Count:     ptr(int32(3)),
+			Amount:    &iceberg.Decimal{Val: decimal128.FromI64(12345), Scale: 2},
+			Score:     ptr(0.5),
+			Active:    true,
+			Payload:   []byte("payload"),
+			Checksum:  []byte{1, 2, 3, 4},
+			Seq:       1,
+			AtTime:    ptr(iceberg.Time(3_600_000_000)),
+			Ratio:     ptr(float32(0.25)),
+		},
+		{
+			EventID:  uuid.MustParse("0f5fa3e2-3a2b-4f4e-8a55-4e6f3b1f9a01"),
+			UserName: "bob",
+			Ts:       iceberg.Timestamp(1_700_000_001_000_000),
+			Seq:      2,
+		},
+	}
+}
+
+func encode(t *testing.T, mem memory.Allocator, events []Event) arrow.RecordBatch {
+	enc := NewEventEncoder(mem)
+	defer enc.Release()
+
+	for i := range events {
+		enc.Append(&events[i])
+	}
+	require.Equal(t, len(events), enc.Len())
+
+	return enc.NewRecordBatch()
+}
+
+func TestEventRoundTrip(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	events := testEvents()
+	rec := encode(t, mem, events)
+	defer rec.Release()
+
+	assert.True(t, rec.Schema().Equal(EventArrowSchema()))
+	assert.EqualValues(t, 2, rec.NumRows())
+	assert.True(t, rec.Column(3).IsNull(1))
+
+	decoded, err := DecodeEvent(rec)
+	require.NoError(t, err)
+	assert.Equal(t, events, decoded)
+	assert.Equal(t, 13, EventSchema().NumFields())
+}
+
+func TestDecodeEventByFieldID(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	rec := encode(t, mem, testEvents())
+	defer rec.Release()
+
+	// rename and reverse the columns, and drop the optional ones past the
+	// first four, as the records of a table whose schema evolved would be
+	fields := make([]arrow.Field, 0)
+	cols := make([]arrow.Array, 0)
+	for i := int(rec.NumCols()) - 1; i >= 0; i-- {
+		f := rec.Schema().Field(i)
+		if i > 3 && !EventSchema().Field(i).Required {
+			continue
+		}
+		f.Name = "renamed_" + f.Name
+		fields = append(fields, f)
+		cols = append(cols, rec.Column(i))
+	}
+	evolved := array.NewRecordBatch(arrow.NewSchema(fields, nil), cols, rec.NumRows())
+	defer evolved.Release()
+
+	decoded, err := DecodeEvent(evolved)
+	require.NoError(t, err)
+
+	expected := testEvents()
+	for i := range expected {
+		expected[i].Count, expected[i].Amount, expected[i].Score = nil, nil, nil
+		expected[i].Payload, expected[i].Checksum = nil, nil
+		expected[i].AtTime, expected[i].Ratio = nil, nil
+	}
+	assert.Equal(t, expected, decoded)
+}
+
+func TestDecodeEventErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	rec := encode(t, mem, testEvents())
+	defer rec.Release()
+
+	t.Run("missing required field", func(t *testing.T) {
+		fields := append([]arrow.Field{rec.Schema().Field(0)}, rec.Schema().Fields()[2:]...)
+		cols := append([]arrow.Array{rec.Column(0)}, rec.Columns()[2:]...)
+		missing := array.NewRecordBatch(arrow.NewSchema(fields, nil), cols, rec.NumRows())
+		defer missing.Release()
+
+		_, err := DecodeEvent(missing)
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+		assert.ErrorContains(t, err, "user_name")
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		// the seq column, a long, with the field ID of count, an int
+		fields := slices.Clone(rec.Schema().Fields())
+		cols := slices.Clone(rec.Columns())
+		fields[4].Type, cols[4] = cols[10].DataType(), cols[10]
+		mismatched := array.NewRecordBatch(arrow.NewSchema(fields, nil), cols, rec.NumRows())
+		defer mismatched.Release()
+
+		_, err := DecodeEvent(mismatched)
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+		assert.ErrorContains(t, err, "count")
+	})
+
+	t.Run("null required value", func(t *testing.T) {
+		bldr := array.NewStringBuilder(mem)
+		defer bldr.Release()
+		bldr.AppendValues([]string{"alice", ""}, []bool{true, false})
+		names := bldr.NewArray()
+		defer names.Release()
+
+		withNull, err := rec.SetColumn(1, names)
+		require.NoError(t, err)
+		defer withNull.Release()
+
+		_, err = DecodeEvent(withNull)
+		assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+		assert.ErrorContains(t, err, "row 1")
+	})
+}
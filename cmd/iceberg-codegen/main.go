@@ -0,0 +1,75 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Command iceberg-codegen generates a Go struct holding the rows of an
+// Iceberg schema, together with an encoder appending values of the struct
+// to Arrow records for the write path and a decoder reading them back by
+// field ID, so that hot ingestion loops need no reflection.
+//
+// It is meant to be run by go generate:
+//
+//	//go:generate go run github.com/apache/iceberg-go/cmd/iceberg-codegen -schema event.json -type Event -out event_gen.go
+//
+// The schema is given in the JSON format of the schemas of table
+// metadata. Only schemas of primitive fields are supported.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	var (
+		schemaPath = flag.String("schema", "", "path of the Iceberg schema JSON file")
+		typeName   = flag.String("type", "", "name of the generated struct type")
+		pkg        = flag.String("package", os.Getenv("GOPACKAGE"), "package of the generated file, $GOPACKAGE by default")
+		out        = flag.String("out", "", "path of the generated file, standard output if empty")
+	)
+	flag.Parse()
+
+	if *schemaPath == "" || *typeName == "" || *pkg == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(*schemaPath, *typeName, *pkg, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "iceberg-codegen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(schemaPath, typeName, pkg, out string) error {
+	schemaJSON, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return err
+	}
+
+	src, err := generate(pkg, typeName, schemaJSON)
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+
+		return err
+	}
+
+	return os.WriteFile(out, src, 0o644)
+}
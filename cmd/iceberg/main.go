@@ -18,11 +18,14 @@
 package main
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"log"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/apache/iceberg-go"
@@ -46,7 +49,9 @@ Usage:
   iceberg create [options] (namespace | table) IDENTIFIER
   iceberg drop [options] (namespace | table) IDENTIFIER
   iceberg files [options] TABLE_ID [--history]
+  iceberg diff [options] TABLE_ID [--from LOCATION] [--to LOCATION]
   iceberg rename [options] <from> <to>
+  iceberg write [options] TABLE_ID FILE...
   iceberg properties [options] get (namespace | table) IDENTIFIER [PROPNAME]
   iceberg properties [options] set (namespace | table) IDENTIFIER PROPNAME VALUE
   iceberg properties [options] remove (namespace | table) IDENTIFIER PROPNAME
@@ -62,7 +67,9 @@ Commands:
   location    Return the location of the table.
   drop        Operations to drop a namespace or table.
   files       List all the files of the table.
+  diff        Show what changed between two versions of the table metadata.
   rename      Rename a table.
+  write       Append the rows of Parquet, CSV or JSON lines files to a table.
   properties  Properties on tables/namespaces.
 
 Arguments:
@@ -71,10 +78,11 @@ Arguments:
   TABLE_ID       full path to a table
   PROPNAME       name of a property
   VALUE          value to set
+  FILE           local path or URI of a file to write
 
 Options:
   -h --help          	show this help messages and exit
-  --catalog TEXT     	specify the catalog type [default: rest]
+  --catalog TEXT     	specify the catalog type (rest, glue, hive or any registered type) [default: rest]
   --uri TEXT         	specify the catalog URI
   --output TYPE      	output type (json/text) [default: text]
   --credential TEXT  	specify credentials for the catalog
@@ -86,12 +94,19 @@ Options:
   --location-uri TEXT  	specify a location URI for the namespace
   --schema JSON        	specify table schema in json (for create table use only)
                        	Ex: [{"name":"id","type":"int","required":false,"doc":"unique id"}]
+  --schema-file PATH   	read the table schema from a file (for create table use only): an Iceberg
+                       	schema (.json), an Avro schema (.avsc) or a YAML table definition (.yaml/.yml)
+                       	with columns and an optional partition-spec, sort-order and properties
   --properties TEXT 	specify table properties in key=value format (for create table use only)
 						Ex:"format-version=2,write.format.default=parquet"
   --partition-spec TEXT specify partition spec as comma-separated field names(for create table use only)
 						Ex:"field1,field2"
   --sort-order TEXT 	specify sort order as field:direction[:null-order] format(for create table use only)
-						Ex:"field1:asc,field2:desc:nulls-first,field3:asc:nulls-last"`
+						Ex:"field1:asc,field2:desc:nulls-first,field3:asc:nulls-last"
+  --from LOCATION    	metadata file to diff from, defaults to the previous metadata file
+  --to LOCATION      	metadata file to diff to, defaults to the current metadata file
+  --format FORMAT    	format of the files to write (parquet, csv or jsonl), defaults to the
+                       	format given by the file extension`
 
 type Config struct {
 	List     bool `docopt:"list"`
@@ -104,7 +119,9 @@ type Config struct {
 	Create   bool `docopt:"create"`
 	Drop     bool `docopt:"drop"`
 	Files    bool `docopt:"files"`
+	Diff     bool `docopt:"diff"`
 	Rename   bool `docopt:"rename"`
+	Write    bool `docopt:"write"`
 
 	Get    bool `docopt:"get"`
 	Set    bool `docopt:"set"`
@@ -122,6 +139,8 @@ type Config struct {
 	PropName string `docopt:"PROPNAME"`
 	Value    string `docopt:"VALUE"`
 
+	InputFiles []string `docopt:"FILE"`
+
 	Catalog       string `docopt:"--catalog"`
 	URI           string `docopt:"--uri"`
 	Output        string `docopt:"--output"`
@@ -134,9 +153,13 @@ type Config struct {
 	Description   string `docopt:"--description"`
 	LocationURI   string `docopt:"--location-uri"`
 	SchemaStr     string `docopt:"--schema"`
+	SchemaFile    string `docopt:"--schema-file"`
 	TableProps    string `docopt:"--properties"`
 	PartitionSpec string `docopt:"--partition-spec"`
 	SortOrder     string `docopt:"--sort-order"`
+	DiffFrom      string `docopt:"--from"`
+	DiffTo        string `docopt:"--to"`
+	Format        string `docopt:"--format"`
 }
 
 func main() {
@@ -208,7 +231,25 @@ func main() {
 			log.Fatal(err)
 		}
 	default:
-		log.Fatal("unrecognized catalog type")
+		// fall back to the catalog registry so that catalog types registered
+		// by packages linked into the binary can be selected with --catalog.
+		props := iceberg.Properties{"type": cfg.Catalog}
+		if len(cfg.URI) > 0 {
+			props["uri"] = cfg.URI
+		}
+		if len(cfg.Warehouse) > 0 {
+			props["warehouse"] = cfg.Warehouse
+		}
+		if len(cfg.Cred) > 0 {
+			props["credential"] = cfg.Cred
+		}
+		if len(cfg.Token) > 0 {
+			props["token"] = cfg.Token
+		}
+
+		if cat, err = catalog.Load(ctx, cfg.Catalog, props); err != nil {
+			log.Fatal(err)
+		}
 	}
 
 	switch {
@@ -245,7 +286,7 @@ func main() {
 		})
 	case cfg.Rename:
 		_, err := cat.RenameTable(ctx,
-			catalog.ToIdentifier(cfg.RenameFrom), catalog.ToIdentifier(cfg.RenameTo))
+			toIdentifier(output, cfg.RenameFrom), toIdentifier(output, cfg.RenameTo))
 		if err != nil {
 			output.Error(err)
 			os.Exit(1)
@@ -255,13 +296,13 @@ func main() {
 	case cfg.Drop:
 		switch {
 		case cfg.Namespace:
-			err := cat.DropNamespace(ctx, catalog.ToIdentifier(cfg.Ident))
+			err := cat.DropNamespace(ctx, toIdentifier(output, cfg.Ident))
 			if err != nil {
 				output.Error(err)
 				os.Exit(1)
 			}
 		case cfg.Table:
-			err := cat.DropTable(ctx, catalog.ToIdentifier(cfg.Ident))
+			err := cat.DropTable(ctx, toIdentifier(output, cfg.Ident))
 			if err != nil {
 				output.Error(err)
 				os.Exit(1)
@@ -280,38 +321,55 @@ func main() {
 				props["Location"] = cfg.LocationURI
 			}
 
-			err := cat.CreateNamespace(ctx, catalog.ToIdentifier(cfg.Ident), props)
+			err := cat.CreateNamespace(ctx, toIdentifier(output, cfg.Ident), props)
 			if err != nil {
 				output.Error(err)
 				os.Exit(1)
 			}
 			output.Text("Namespace " + cfg.Ident + " created successfully")
 		case cfg.Table:
-			if cfg.SchemaStr == "" {
-				output.Error(errors.New("missing --schema for table creation"))
+			var def tableDefinition
+			switch {
+			case cfg.SchemaFile != "" && cfg.SchemaStr != "":
+				output.Error(errors.New("--schema and --schema-file are mutually exclusive"))
+				os.Exit(1)
+			case cfg.SchemaFile != "":
+				def, err = readSchemaFile(cfg.SchemaFile)
+			case cfg.SchemaStr != "":
+				def.schema, err = iceberg.NewSchemaFromJsonFields(0, cfg.SchemaStr)
+			default:
+				output.Error(errors.New("missing --schema or --schema-file for table creation"))
 				os.Exit(1)
 			}
-
-			schema, err := iceberg.NewSchemaFromJsonFields(0, cfg.SchemaStr)
 			if err != nil {
 				output.Error(err)
 				os.Exit(1)
 			}
+			schema := def.schema
 
+			// the flags take precedence over the table definition file
 			var opts []catalog.CreateTableOpt
 			if cfg.LocationURI != "" {
 				opts = append(opts, catalog.WithLocation(cfg.LocationURI))
 			}
-			if cfg.TableProps != "" {
-				props, err := parseProperties(cfg.TableProps)
-				if err != nil {
-					output.Error(fmt.Errorf("failed to parse properties: %w", err))
-					os.Exit(1)
+			props, err := parseProperties(cfg.TableProps)
+			if err != nil {
+				output.Error(fmt.Errorf("failed to parse properties: %w", err))
+				os.Exit(1)
+			}
+			if len(def.props) > 0 || len(props) > 0 {
+				merged := maps.Clone(def.props)
+				if merged == nil {
+					merged = iceberg.Properties{}
 				}
-				opts = append(opts, catalog.WithProperties(props))
+				maps.Copy(merged, props)
+				opts = append(opts, catalog.WithProperties(merged))
 			}
-			if cfg.PartitionSpec != "" {
-				spec, err := parsePartitionSpec(cfg.PartitionSpec)
+			if specStr := cmp.Or(cfg.PartitionSpec, def.partitionSpec); specStr != "" {
+				spec, err := parsePartitionSpec(specStr)
+				if err == nil {
+					spec, err = bindPartitionSpec(spec, schema)
+				}
 				if err != nil {
 					output.Error(fmt.Errorf("failed to parse partition spec: %w", err))
 					os.Exit(1)
@@ -319,8 +377,8 @@ func main() {
 				opts = append(opts, catalog.WithPartitionSpec(spec))
 			}
 
-			if cfg.SortOrder != "" {
-				sortOrder, err := parseSortOrder(cfg.SortOrder)
+			if sortStr := cmp.Or(cfg.SortOrder, def.sortOrder); sortStr != "" {
+				sortOrder, err := parseSortOrder(sortStr, schema)
 				if err != nil {
 					output.Error(fmt.Errorf("failed to parse sort order: %w", err))
 					os.Exit(1)
@@ -328,7 +386,7 @@ func main() {
 				opts = append(opts, catalog.WithSortOrder(sortOrder))
 			}
 
-			ident := catalog.ToIdentifier(cfg.Ident)
+			ident := toIdentifier(output, cfg.Ident)
 			_, err = cat.CreateTable(ctx, ident, schema, opts...)
 			if err != nil {
 				output.Error(fmt.Errorf("failed to create table: %w", err))
@@ -342,11 +400,63 @@ func main() {
 	case cfg.Files:
 		tbl := loadTable(ctx, output, cat, cfg.TableID)
 		output.Files(tbl, cfg.History)
+	case cfg.Diff:
+		tbl := loadTable(ctx, output, cat, cfg.TableID)
+		diff(ctx, output, tbl, cfg.DiffFrom, cfg.DiffTo)
+	case cfg.Write:
+		tbl := loadTable(ctx, output, cat, cfg.TableID)
+		tbl, err := writeFiles(ctx, tbl, cfg.InputFiles, cfg.Format)
+		if err != nil {
+			output.Error(err)
+			os.Exit(1)
+		}
+
+		output.Text(fmt.Sprintf("Wrote %d files to table %s, snapshot %d",
+			len(cfg.InputFiles), cfg.TableID, tbl.CurrentSnapshot().SnapshotID))
+	}
+}
+
+// diff prints the changes between two metadata files of the table. Without
+// explicit locations, it shows what the latest commit changed by comparing
+// the current metadata with the previous metadata file.
+func diff(ctx context.Context, output Output, tbl *table.Table, from, to string) {
+	fs, err := tbl.FS(ctx)
+	if err != nil {
+		output.Error(err)
+		os.Exit(1)
 	}
+
+	load := func(loc string) table.Metadata {
+		st, err := table.StaticTableFromMetadataFile(ctx, loc, fs)
+		if err != nil {
+			output.Error(err)
+			os.Exit(1)
+		}
+
+		return st.Metadata()
+	}
+
+	newMeta := tbl.Metadata()
+	if to != "" {
+		newMeta = load(to)
+	}
+
+	if from == "" {
+		if prev := slices.Collect(newMeta.PreviousFiles()); len(prev) > 0 {
+			from = prev[len(prev)-1].MetadataFile
+		}
+	}
+
+	var oldMeta table.Metadata
+	if from != "" {
+		oldMeta = load(from)
+	}
+
+	output.MetadataDiff(table.DiffMetadata(oldMeta, newMeta))
 }
 
 func list(ctx context.Context, output Output, cat catalog.Catalog, parent string) {
-	prnt := catalog.ToIdentifier(parent)
+	prnt := toIdentifier(output, parent)
 
 	var ids []table.Identifier
 
@@ -374,7 +484,7 @@ func list(ctx context.Context, output Output, cat catalog.Catalog, parent string
 }
 
 func describe(ctx context.Context, output Output, cat catalog.Catalog, id string, entityType string) {
-	ident := catalog.ToIdentifier(id)
+	ident := toIdentifier(output, id)
 
 	isNS, isTbl := false, false
 	if (entityType == "any" || entityType == "ns") && len(ident) > 0 {
@@ -416,7 +526,7 @@ func describe(ctx context.Context, output Output, cat catalog.Catalog, id string
 }
 
 func loadTable(ctx context.Context, output Output, cat catalog.Catalog, id string) *table.Table {
-	tbl, err := cat.LoadTable(ctx, catalog.ToIdentifier(id))
+	tbl, err := cat.LoadTable(ctx, toIdentifier(output, id))
 	if err != nil {
 		output.Error(err)
 		os.Exit(1)
@@ -433,7 +543,7 @@ type propCmd struct {
 }
 
 func properties(ctx context.Context, output Output, cat catalog.Catalog, args propCmd) {
-	ident := catalog.ToIdentifier(args.identifier)
+	ident := toIdentifier(output, args.identifier)
 
 	switch {
 	case args.get:
@@ -24,9 +24,9 @@ import (
 	"log"
 	"os"
 	"strconv"
-	"strings"
 
 	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
 	"github.com/apache/iceberg-go/table"
 
 	"github.com/google/uuid"
@@ -43,6 +43,7 @@ type Output interface {
 	Schema(*iceberg.Schema)
 	Spec(iceberg.PartitionSpec)
 	Uuid(uuid.UUID)
+	MetadataDiff([]table.MetadataChange)
 	Error(error)
 }
 
@@ -51,7 +52,7 @@ type textOutput struct{}
 func (textOutput) Identifiers(idlist []table.Identifier) {
 	data := pterm.TableData{[]string{"IDs"}}
 	for _, ids := range idlist {
-		data = append(data, []string{strings.Join(ids, ".")})
+		data = append(data, []string{catalog.QuoteIdentifier(ids)})
 	}
 
 	pterm.DefaultTable.
@@ -164,7 +165,7 @@ func (t textOutput) Files(tbl *table.Table, history bool) {
 	}
 
 	node := putils.TreeFromLeveledList(snapshotTree)
-	node.Text = "Snapshots: " + strings.Join(tbl.Identifier(), ".")
+	node.Text = "Snapshots: " + catalog.QuoteIdentifier(tbl.Identifier())
 	pterm.DefaultTree.WithRoot(node).Render()
 }
 
@@ -222,6 +223,25 @@ func (textOutput) Uuid(u uuid.UUID) {
 	}
 }
 
+func (textOutput) MetadataDiff(changes []table.MetadataChange) {
+	if len(changes) == 0 {
+		fmt.Println("no changes")
+
+		return
+	}
+
+	data := pterm.TableData{[]string{"Change", "Key", "Old", "New"}}
+	for _, c := range changes {
+		data = append(data, []string{string(c.Kind), c.Key, c.Old, c.New})
+	}
+
+	pterm.DefaultTable.
+		WithBoxed(true).
+		WithHasHeader(true).
+		WithHeaderRowSeparator("-").
+		WithData(data).Render()
+}
+
 func (textOutput) Error(err error) {
 	log.Fatal(err)
 }
@@ -319,6 +339,20 @@ func (j jsonOutput) Spec(spec iceberg.PartitionSpec) {
 	}
 }
 
+func (j jsonOutput) MetadataDiff(changes []table.MetadataChange) {
+	type dataType struct {
+		Changes []table.MetadataChange `json:"changes"`
+	}
+
+	data := dataType{Changes: changes}
+	if data.Changes == nil {
+		data.Changes = []table.MetadataChange{}
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(data); err != nil {
+		j.Error(err)
+	}
+}
+
 func (j jsonOutput) Uuid(u uuid.UUID) {
 	type dataType struct {
 		UUID uuid.UUID `json:"uuid"`
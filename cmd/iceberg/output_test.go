@@ -407,3 +407,36 @@ func Test_jsonOutput_DescribeTable(t *testing.T) {
 		})
 	}
 }
+
+func Test_output_MetadataDiff(t *testing.T) {
+	changes := []table.MetadataChange{
+		{Kind: table.DiffPropertyChanged, Key: "owner", Old: "a", New: "b"},
+		{Kind: table.DiffSnapshotAdded, Key: "1", New: "append"},
+	}
+
+	var buf bytes.Buffer
+	pterm.SetDefaultOutput(&buf)
+	pterm.DisableColor()
+
+	textOutput{}.MetadataDiff(changes)
+	assert.Contains(t, buf.String(), "property-changed | owner | a   | b")
+	assert.Contains(t, buf.String(), "snapshot-added   | 1     |     | append")
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	defer func() {
+		os.Stdout = oldStdout
+	}()
+
+	jsonOutput{}.MetadataDiff(changes)
+	jsonOutput{}.MetadataDiff(nil)
+
+	w.Close()
+	buf.Reset()
+	_, _ = buf.ReadFrom(r)
+
+	assert.Equal(t, `{"changes":[{"kind":"property-changed","key":"owner","old":"a","new":"b"},{"kind":"snapshot-added","key":"1","new":"append"}]}
+{"changes":[]}
+`, buf.String())
+}
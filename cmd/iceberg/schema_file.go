@@ -0,0 +1,351 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/apache/iceberg-go"
+	"github.com/hamba/avro/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// tableDefinition is a table read from the file given with --schema-file.
+// Only YAML files define more than the schema.
+type tableDefinition struct {
+	schema        *iceberg.Schema
+	partitionSpec string
+	sortOrder     string
+	props         iceberg.Properties
+}
+
+// readSchemaFile reads the table definition at path, whose format is
+// picked by its extension:
+//
+//   - .json: an Iceberg schema, or a list of fields as given to --schema
+//   - .avsc: an Avro record schema, field IDs are assigned if missing
+//   - .yaml or .yml: a list of columns with DDL style types, along with
+//     an optional partition spec, sort order and properties
+func readSchemaFile(path string) (tableDefinition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return tableDefinition{}, err
+	}
+
+	var def tableDefinition
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		def.schema, err = schemaFromJSON(data)
+	case ".avsc":
+		def.schema, err = schemaFromAvro(data)
+	case ".yaml", ".yml":
+		def, err = tableFromYAML(data)
+	default:
+		return tableDefinition{}, fmt.Errorf("unsupported schema file extension %q, expected .json, .avsc, .yaml or .yml", ext)
+	}
+	if err != nil {
+		return tableDefinition{}, fmt.Errorf("invalid schema file %s: %w", path, err)
+	}
+
+	return def, nil
+}
+
+func schemaFromJSON(data []byte) (*iceberg.Schema, error) {
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "[") {
+		return iceberg.NewSchemaFromJsonFields(0, trimmed)
+	}
+
+	var sc iceberg.Schema
+	if err := json.Unmarshal(data, &sc); err != nil {
+		return nil, err
+	}
+
+	return &sc, iceberg.ValidateReservedFields(&sc)
+}
+
+// walkAvroIDs calls fn with every node of an Avro schema in its JSON form
+// that holds an Iceberg field ID, along with the property of the ID.
+func walkAvroIDs(node any, fn func(m map[string]any, key string)) {
+	switch n := node.(type) {
+	case []any:
+		for _, v := range n {
+			walkAvroIDs(v, fn)
+		}
+	case map[string]any:
+		switch typ, _ := n["type"].(string); typ {
+		case "record":
+			fields, _ := n["fields"].([]any)
+			for _, f := range fields {
+				if field, ok := f.(map[string]any); ok {
+					fn(field, "field-id")
+					walkAvroIDs(field["type"], fn)
+				}
+			}
+		case "array":
+			fn(n, "element-id")
+			walkAvroIDs(n["items"], fn)
+		case "map":
+			fn(n, "key-id")
+			fn(n, "value-id")
+			walkAvroIDs(n["values"], fn)
+		default:
+			walkAvroIDs(n["type"], fn)
+		}
+	}
+}
+
+// schemaFromAvro converts an Avro record schema. Plain Avro schemas do not
+// carry Iceberg field IDs, the missing ones are assigned after the highest
+// ID present.
+func schemaFromAvro(data []byte) (*iceberg.Schema, error) {
+	var root any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+
+	lastID := 0
+	walkAvroIDs(root, func(m map[string]any, key string) {
+		if id, ok := m[key].(float64); ok {
+			lastID = max(lastID, int(id))
+		}
+	})
+	walkAvroIDs(root, func(m map[string]any, key string) {
+		if _, ok := m[key]; !ok {
+			lastID++
+			m[key] = lastID
+		}
+	})
+
+	withIDs, err := json.Marshal(root)
+	if err != nil {
+		return nil, err
+	}
+
+	sc, err := avro.Parse(string(withIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	return iceberg.AvroSchemaToIceberg(sc)
+}
+
+type yamlColumn struct {
+	Name     string `yaml:"name"`
+	Type     string `yaml:"type"`
+	Required bool   `yaml:"required"`
+	Doc      string `yaml:"doc"`
+}
+
+type yamlTable struct {
+	Columns       []yamlColumn      `yaml:"columns"`
+	PartitionSpec string            `yaml:"partition-spec"`
+	SortOrder     string            `yaml:"sort-order"`
+	Properties    map[string]string `yaml:"properties"`
+}
+
+// tableFromYAML reads a table definition such as:
+//
+//	columns:
+//	  - name: id
+//	    type: long
+//	    required: true
+//	  - name: tags
+//	    type: map<string, list<string>>
+//	  - name: location
+//	    type: struct<lat:double, lon:double>
+//	partition-spec: id
+//	sort-order: id:asc
+//	properties:
+//	  format-version: "2"
+//
+// Elements, values and struct fields of nested types are optional. A type
+// with ": " in it must be quoted to be valid YAML.
+func tableFromYAML(data []byte) (tableDefinition, error) {
+	var tbl yamlTable
+	if err := yaml.Unmarshal(data, &tbl); err != nil {
+		return tableDefinition{}, err
+	}
+	if len(tbl.Columns) == 0 {
+		return tableDefinition{}, errors.New("no columns defined")
+	}
+
+	p := ddlTypeParser{lastID: len(tbl.Columns)}
+	fields := make([]iceberg.NestedField, len(tbl.Columns))
+	for i, col := range tbl.Columns {
+		if col.Name == "" {
+			return tableDefinition{}, fmt.Errorf("column %d has no name", i+1)
+		}
+
+		typ, err := p.parse(col.Type)
+		if err != nil {
+			return tableDefinition{}, fmt.Errorf("column %s: %w", col.Name, err)
+		}
+
+		fields[i] = iceberg.NestedField{
+			ID:       i + 1,
+			Name:     col.Name,
+			Type:     typ,
+			Required: col.Required,
+			Doc:      col.Doc,
+		}
+	}
+
+	sc := iceberg.NewSchema(0, fields...)
+	if err := iceberg.ValidateReservedFields(sc); err != nil {
+		return tableDefinition{}, err
+	}
+
+	return tableDefinition{
+		schema:        sc,
+		partitionSpec: tbl.PartitionSpec,
+		sortOrder:     tbl.SortOrder,
+		props:         tbl.Properties,
+	}, nil
+}
+
+// ddlTypeParser parses DDL style type strings such as
+// "map<string, decimal(10, 2)>", assigning IDs to nested fields after
+// lastID.
+type ddlTypeParser struct {
+	lastID int
+}
+
+func (p *ddlTypeParser) nextID() int {
+	p.lastID++
+
+	return p.lastID
+}
+
+func (p *ddlTypeParser) parse(s string) (iceberg.Type, error) {
+	s = strings.TrimSpace(s)
+	name, args, nested := strings.Cut(s, "<")
+	if !nested {
+		// primitive types use the names of the Iceberg schema JSON
+		var field iceberg.NestedField
+		err := json.Unmarshal([]byte(`{"id":0,"name":"","type":`+strconv.Quote(strings.ToLower(s))+`}`), &field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid type %q: %w", s, err)
+		}
+
+		return field.Type, nil
+	}
+
+	if !strings.HasSuffix(args, ">") {
+		return nil, fmt.Errorf("invalid type %q: missing closing '>'", s)
+	}
+	parts := splitTopLevel(strings.TrimSuffix(args, ">"))
+
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "list":
+		if len(parts) != 1 {
+			return nil, fmt.Errorf("invalid type %q: list takes one element type", s)
+		}
+		id := p.nextID()
+		elem, err := p.parse(parts[0])
+		if err != nil {
+			return nil, err
+		}
+
+		return &iceberg.ListType{ElementID: id, Element: elem}, nil
+	case "map":
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid type %q: map takes a key and a value type", s)
+		}
+		keyID, valueID := p.nextID(), p.nextID()
+		key, err := p.parse(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		value, err := p.parse(parts[1])
+		if err != nil {
+			return nil, err
+		}
+
+		return &iceberg.MapType{KeyID: keyID, KeyType: key, ValueID: valueID, ValueType: value}, nil
+	case "struct":
+		fields := make([]iceberg.NestedField, len(parts))
+		for i, part := range parts {
+			fieldName, fieldType, ok := strings.Cut(part, ":")
+			if !ok || strings.TrimSpace(fieldName) == "" {
+				return nil, fmt.Errorf("invalid type %q: struct fields must be name: type", s)
+			}
+			id := p.nextID()
+			typ, err := p.parse(fieldType)
+			if err != nil {
+				return nil, err
+			}
+			fields[i] = iceberg.NestedField{ID: id, Name: strings.TrimSpace(fieldName), Type: typ}
+		}
+
+		return &iceberg.StructType{FieldList: fields}, nil
+	default:
+		return nil, fmt.Errorf("invalid type %q: unknown nested type %s", s, name)
+	}
+}
+
+// splitTopLevel splits s on the commas that are not nested in angle
+// brackets or parentheses.
+func splitTopLevel(s string) []string {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+	for i, c := range s {
+		switch c {
+		case '<', '(':
+			depth++
+		case '>', ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
+// bindPartitionSpec points the source of each field of spec to the schema
+// column of the same name, as partition fields are given by column name.
+func bindPartitionSpec(spec *iceberg.PartitionSpec, sc *iceberg.Schema) (*iceberg.PartitionSpec, error) {
+	if spec.IsUnpartitioned() {
+		return spec, nil
+	}
+
+	fields := make([]iceberg.PartitionField, 0, spec.NumFields())
+	for field := range spec.Fields() {
+		col, ok := sc.FindFieldByName(field.Name)
+		if !ok {
+			return nil, fmt.Errorf("partition column %s is not in the schema", field.Name)
+		}
+		field.SourceID = col.ID
+		fields = append(fields, field)
+	}
+	bound := iceberg.NewPartitionSpec(fields...)
+
+	return &bound, nil
+}
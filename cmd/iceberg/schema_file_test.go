@@ -0,0 +1,163 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSchemaFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	return path
+}
+
+func TestReadSchemaFileJSON(t *testing.T) {
+	def, err := readSchemaFile(writeSchemaFile(t, "schema.json", `{
+		"type": "struct", "schema-id": 0,
+		"fields": [
+			{"id": 1, "name": "id", "type": "long", "required": true},
+			{"id": 2, "name": "data", "type": "string", "required": false}
+		]
+	}`))
+	require.NoError(t, err)
+	assert.True(t, def.schema.Equals(iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String},
+	)), def.schema.String())
+
+	def, err = readSchemaFile(writeSchemaFile(t, "fields.json",
+		`[{"id": 1, "name": "id", "type": "int", "required": false}]`))
+	require.NoError(t, err)
+	assert.Equal(t, 1, def.schema.NumFields())
+
+	_, err = readSchemaFile(writeSchemaFile(t, "schema.txt", ""))
+	assert.ErrorContains(t, err, "unsupported schema file extension")
+}
+
+func TestReadSchemaFileAvro(t *testing.T) {
+	def, err := readSchemaFile(writeSchemaFile(t, "event.avsc", `{
+		"type": "record", "name": "event",
+		"fields": [
+			{"name": "id", "type": "long", "field-id": 10},
+			{"name": "tags", "type": ["null", {"type": "array", "items": "string"}]},
+			{"name": "attrs", "type": {"type": "map", "values": "int"}}
+		]
+	}`))
+	require.NoError(t, err)
+
+	// missing IDs are assigned after the highest explicit one
+	id, ok := def.schema.FindFieldByName("id")
+	require.True(t, ok)
+	assert.Equal(t, 10, id.ID)
+	assert.True(t, id.Required)
+
+	tags, ok := def.schema.FindFieldByName("tags")
+	require.True(t, ok)
+	assert.Equal(t, 11, tags.ID)
+	assert.False(t, tags.Required)
+	assert.Equal(t, 12, tags.Type.(*iceberg.ListType).ElementID)
+
+	attrs, ok := def.schema.FindFieldByName("attrs")
+	require.True(t, ok)
+	assert.Equal(t, 13, attrs.ID)
+	assert.Equal(t, &iceberg.MapType{
+		KeyID: 14, KeyType: iceberg.PrimitiveTypes.String,
+		ValueID: 15, ValueType: iceberg.PrimitiveTypes.Int32, ValueRequired: true,
+	}, attrs.Type)
+}
+
+func TestReadSchemaFileAvroReservedID(t *testing.T) {
+	_, err := readSchemaFile(writeSchemaFile(t, "deletes.avsc", `{
+		"type": "record", "name": "position_delete",
+		"fields": [
+			{"name": "file_path", "type": "string", "field-id": 2147483546},
+			{"name": "pos", "type": "long", "field-id": 2147483545}
+		]
+	}`))
+	assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+}
+
+func TestReadSchemaFileYAML(t *testing.T) {
+	def, err := readSchemaFile(writeSchemaFile(t, "table.yaml", `
+columns:
+  - name: id
+    type: long
+    required: true
+    doc: unique id
+  - name: price
+    type: decimal(10, 2)
+  - name: tags
+    type: map<string, list<string>>
+  - name: location
+    type: struct<lat:double, lon:double>
+partition-spec: id
+sort-order: id:desc
+properties:
+  format-version: "2"
+`))
+	require.NoError(t, err)
+
+	assert.True(t, def.schema.Equals(iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true, Doc: "unique id"},
+		iceberg.NestedField{ID: 2, Name: "price", Type: iceberg.DecimalTypeOf(10, 2)},
+		iceberg.NestedField{ID: 3, Name: "tags", Type: &iceberg.MapType{
+			KeyID: 5, KeyType: iceberg.PrimitiveTypes.String,
+			ValueID: 6, ValueType: &iceberg.ListType{ElementID: 7, Element: iceberg.PrimitiveTypes.String},
+		}},
+		iceberg.NestedField{ID: 4, Name: "location", Type: &iceberg.StructType{FieldList: []iceberg.NestedField{
+			{ID: 8, Name: "lat", Type: iceberg.PrimitiveTypes.Float64},
+			{ID: 9, Name: "lon", Type: iceberg.PrimitiveTypes.Float64},
+		}}},
+	)), def.schema.String())
+	assert.Equal(t, "id", def.partitionSpec)
+	assert.Equal(t, "id:desc", def.sortOrder)
+	assert.Equal(t, iceberg.Properties{"format-version": "2"}, def.props)
+
+	spec, err := parsePartitionSpec(def.partitionSpec)
+	require.NoError(t, err)
+	spec, err = bindPartitionSpec(spec, def.schema)
+	require.NoError(t, err)
+	assert.Equal(t, 1, spec.Field(0).SourceID)
+
+	spec, err = parsePartitionSpec("missing")
+	require.NoError(t, err)
+	_, err = bindPartitionSpec(spec, def.schema)
+	assert.ErrorContains(t, err, "missing")
+
+	// sort fields are bound to the columns by name
+	order, err := parseSortOrder("location.lon:asc,price:desc", def.schema)
+	require.NoError(t, err)
+	var sourceIDs []int
+	for field := range order.Fields() {
+		sourceIDs = append(sourceIDs, field.SourceID)
+	}
+	assert.Equal(t, []int{9, 2}, sourceIDs)
+
+	for _, typ := range []string{"list<int, int>", "struct<int>", "array<int>", "map<string, int", "varchar"} {
+		_, err := readSchemaFile(writeSchemaFile(t, "bad.yml", "columns:\n  - name: c\n    type: "+typ+"\n"))
+		assert.Error(t, err, typ)
+	}
+}
@@ -19,12 +19,30 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
 	"github.com/apache/iceberg-go/table"
 )
 
+// toIdentifier parses a dotted, optionally backquoted, identifier given on
+// the command line, exiting with an error if it is malformed.
+func toIdentifier(output Output, s string) table.Identifier {
+	if s == "" {
+		return nil
+	}
+
+	ident, err := catalog.ParseIdentifier(s)
+	if err != nil {
+		output.Error(err)
+		os.Exit(1)
+	}
+
+	return ident
+}
+
 func parseProperties(propStr string) (iceberg.Properties, error) {
 	if propStr == "" {
 		return iceberg.Properties{}, nil
@@ -77,7 +95,9 @@ func parsePartitionSpec(specStr string) (*iceberg.PartitionSpec, error) {
 	return &spec, nil
 }
 
-func parseSortOrder(sortStr string) (table.SortOrder, error) {
+// parseSortOrder parses a sort order of field:direction[:null-order]
+// entries, whose fields are the columns of sc with those names.
+func parseSortOrder(sortStr string, sc *iceberg.Schema) (table.SortOrder, error) {
 	if sortStr == "" {
 		return table.UnsortedSortOrder, nil
 	}
@@ -85,12 +105,17 @@ func parseSortOrder(sortStr string) (table.SortOrder, error) {
 	fields := strings.Split(sortStr, ",")
 	var sortFields []table.SortField
 
-	for i, field := range fields {
+	for _, field := range fields {
 		field = strings.TrimSpace(field)
 		if field == "" {
 			continue
 		}
 		parts := strings.Split(field, ":")
+		name := strings.TrimSpace(parts[0])
+		col, ok := sc.FindFieldByName(name)
+		if !ok {
+			return table.UnsortedSortOrder, fmt.Errorf("sort column %s is not in the schema", name)
+		}
 		direction := "asc" // default value
 		var nullOrder table.NullOrder
 
@@ -128,7 +153,7 @@ func parseSortOrder(sortStr string) (table.SortOrder, error) {
 			}
 		}
 		sortFields = append(sortFields, table.SortField{
-			SourceID:  i + 1,
+			SourceID:  col.ID,
 			Transform: iceberg.IdentityTransform{},
 			Direction: sortDirection,
 			NullOrder: nullOrder,
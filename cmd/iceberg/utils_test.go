@@ -127,6 +127,7 @@ func TestParseSortOrder(t *testing.T) {
 		expectedFieldsCount int
 		expectedNullOrders  []table.NullOrder // for validation
 		expectedDirections  []table.SortDirection
+		expectedSourceIDs   []int
 	}{
 		{
 			name:                "empty string",
@@ -181,6 +182,20 @@ func TestParseSortOrder(t *testing.T) {
 			expectedFieldsCount: 3,
 			expectedDirections:  []table.SortDirection{table.SortASC, table.SortDESC, table.SortASC},
 			expectedNullOrders:  []table.NullOrder{table.NullsFirst, table.NullsFirst, table.NullsLast},
+			expectedSourceIDs:   []int{1, 2, 3},
+		},
+		{
+			name:                "fields bound by name",
+			input:               "ts:desc,field2",
+			expectedFieldsCount: 2,
+			expectedDirections:  []table.SortDirection{table.SortDESC, table.SortASC},
+			expectedNullOrders:  []table.NullOrder{table.NullsLast, table.NullsFirst},
+			expectedSourceIDs:   []int{4, 2},
+		},
+		{
+			name:  "unknown field",
+			input: "missing:asc",
+			isErr: true,
 		},
 		{
 			name:                "with spaces",
@@ -201,9 +216,16 @@ func TestParseSortOrder(t *testing.T) {
 		},
 	}
 
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "field1", Type: iceberg.PrimitiveTypes.Int64},
+		iceberg.NestedField{ID: 2, Name: "field2", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 3, Name: "field3", Type: iceberg.PrimitiveTypes.Date},
+		iceberg.NestedField{ID: 4, Name: "ts", Type: iceberg.PrimitiveTypes.TimestampTz},
+	)
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseSortOrder(tt.input)
+			got, err := parseSortOrder(tt.input, sc)
 			if (err != nil) != tt.isErr {
 				t.Errorf("parseSortOrder() error = %v, isErr %v", err, tt.isErr)
 
@@ -236,6 +258,9 @@ func TestParseSortOrder(t *testing.T) {
 					if i < len(tt.expectedNullOrders) && field.NullOrder != tt.expectedNullOrders[i] {
 						t.Errorf("parseSortOrder() field %d null order = %v, expected %v", i, field.NullOrder, tt.expectedNullOrders[i])
 					}
+					if i < len(tt.expectedSourceIDs) && field.SourceID != tt.expectedSourceIDs[i] {
+						t.Errorf("parseSortOrder() field %d source id = %d, expected %d", i, field.SourceID, tt.expectedSourceIDs[i])
+					}
 					i++
 				}
 			}
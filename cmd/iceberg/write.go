@@ -0,0 +1,188 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/csv"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+)
+
+// Formats of the files ingested by the write command
+const (
+	formatParquet = "parquet"
+	formatCSV     = "csv"
+	formatJSONL   = "jsonl"
+)
+
+// inputFormat returns the format of the file at location, which is given
+// by format if set and by the extension of location otherwise.
+func inputFormat(location, format string) (string, error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(path.Ext(location)), ".")
+	}
+
+	switch strings.ToLower(format) {
+	case "parquet":
+		return formatParquet, nil
+	case "csv":
+		return formatCSV, nil
+	case "jsonl", "ndjson", "json":
+		return formatJSONL, nil
+	default:
+		return "", fmt.Errorf("unsupported input format %q for %s, expected parquet, csv or jsonl", format, location)
+	}
+}
+
+// openInput returns a reader of the records of f. The records of Parquet
+// files are read with the schema of the file, while CSV and JSON lines
+// files are read with the types of the columns of the table schema sc.
+func openInput(ctx context.Context, f iceio.File, format string, sc *arrow.Schema) (array.RecordReader, error) {
+	mem := memory.DefaultAllocator
+
+	switch format {
+	case formatParquet:
+		pf, err := file.NewParquetReader(f)
+		if err != nil {
+			return nil, err
+		}
+		rdr, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{BatchSize: 64 * 1024}, mem)
+		if err != nil {
+			return nil, err
+		}
+
+		return rdr.GetRecordReader(ctx, nil, nil)
+	case formatCSV:
+		types := make(map[string]arrow.DataType, sc.NumFields())
+		for _, field := range sc.Fields() {
+			types[field.Name] = field.Type
+		}
+
+		rdr := csv.NewInferringReader(f,
+			csv.WithHeader(true),
+			csv.WithColumnTypes(types),
+			csv.WithNullReader(true, ""),
+			csv.WithAllocator(mem),
+			csv.WithChunk(64*1024))
+
+		// the schema of an inferring reader is only known once it has read
+		// the first chunk of the file
+		ok := rdr.Next()
+		if rdr.Schema() == nil {
+			defer rdr.Release()
+			if err := rdr.Err(); err != nil {
+				return nil, err
+			}
+
+			return nil, errors.New("missing CSV header")
+		}
+
+		return &primedReader{Reader: rdr, pending: true, ok: ok}, nil
+	case formatJSONL:
+		// required columns are read as nullable, their values are checked
+		// when the records are written
+		fields := make([]arrow.Field, sc.NumFields())
+		for i, field := range sc.Fields() {
+			field.Nullable = true
+			fields[i] = field
+		}
+
+		return array.NewJSONReader(f, arrow.NewSchema(fields, nil),
+			array.WithAllocator(mem), array.WithChunk(64*1024)), nil
+	default:
+		return nil, fmt.Errorf("unsupported input format %q", format)
+	}
+}
+
+// primedReader is a CSV reader whose first call to Next has already been
+// made, its result is returned by the next call to Next.
+type primedReader struct {
+	*csv.Reader
+	pending, ok bool
+}
+
+func (r *primedReader) Next() bool {
+	if r.pending {
+		r.pending = false
+
+		return r.ok
+	}
+
+	return r.Reader.Next()
+}
+
+// writeFiles appends the records of the files at locations to tbl with a
+// single commit. The records are written to new data files laid out by the
+// partition spec and write properties of the table, the input files are
+// left untouched. Locations without a scheme are local files, others are
+// read with the FileIO configured by the table properties.
+func writeFiles(ctx context.Context, tbl *table.Table, locations []string, format string) (*table.Table, error) {
+	sc, err := table.SchemaToArrowSchema(tbl.Schema(), nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	txn := tbl.NewTransaction()
+	for _, location := range locations {
+		if err := appendFile(ctx, txn, tbl, location, format, sc); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", location, err)
+		}
+	}
+
+	return txn.Commit(ctx)
+}
+
+func appendFile(ctx context.Context, txn *table.Transaction, tbl *table.Table, location, format string, sc *arrow.Schema) (err error) {
+	if format, err = inputFormat(location, format); err != nil {
+		return err
+	}
+
+	fsys, err := iceio.LoadFS(ctx, tbl.Properties(), location)
+	if err != nil {
+		return err
+	}
+	f, err := fsys.Open(location)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, f.Close()) }()
+
+	rdr, err := openInput(ctx, f, format, sc)
+	if err != nil {
+		return err
+	}
+	defer rdr.Release()
+
+	if err := txn.Append(ctx, rdr, nil); err != nil {
+		return err
+	}
+
+	// readers report decoding errors once they stop returning records
+	return rdr.Err()
+}
@@ -0,0 +1,120 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/catalog/sql"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun/driver/sqliteshim"
+)
+
+func TestInputFormat(t *testing.T) {
+	for location, want := range map[string]string{
+		"data.parquet":            formatParquet,
+		"s3://bucket/data.CSV":    formatCSV,
+		"/tmp/events.jsonl":       formatJSONL,
+		"/tmp/events.ndjson":      formatJSONL,
+		"gs://bucket/events.json": formatJSONL,
+	} {
+		got, err := inputFormat(location, "")
+		require.NoError(t, err, location)
+		assert.Equal(t, want, got, location)
+	}
+
+	got, err := inputFormat("data.txt", "csv")
+	require.NoError(t, err)
+	assert.Equal(t, formatCSV, got)
+
+	_, err = inputFormat("data.txt", "")
+	assert.ErrorContains(t, err, "unsupported input format")
+}
+
+func TestWriteFiles(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	cat, err := catalog.Load(ctx, "cli", iceberg.Properties{
+		"uri":          ":memory:",
+		"type":         "sql",
+		sql.DriverKey:  sqliteshim.ShimName,
+		sql.DialectKey: string(sql.SQLite),
+		"warehouse":    "file://" + filepath.ToSlash(dir),
+	})
+	require.NoError(t, err)
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "region", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 3, Name: "amount", Type: iceberg.PrimitiveTypes.Float64})
+	spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+		SourceID: 2, FieldID: iceberg.PartitionDataIDStart, Name: "region", Transform: iceberg.IdentityTransform{},
+	})
+
+	require.NoError(t, cat.CreateNamespace(ctx, table.Identifier{"db"}, nil))
+	tbl, err := cat.CreateTable(ctx, table.Identifier{"db", "sales"}, sc, catalog.WithPartitionSpec(&spec))
+	require.NoError(t, err)
+
+	csvPath := filepath.Join(dir, "sales.csv")
+	require.NoError(t, os.WriteFile(csvPath, []byte("region,id,amount\neu,1,1.5\nus,2,\n"), 0o644))
+
+	jsonPath := filepath.Join(dir, "sales.jsonl")
+	require.NoError(t, os.WriteFile(jsonPath, []byte(`{"id": 3, "region": "eu", "amount": 3}
+{"id": 4, "region": "apac"}
+`), 0o644))
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, false, false)
+	require.NoError(t, err)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{
+		`[{"id": 5, "region": "us", "amount": 5.5}]`,
+	})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+	pqPath := filepath.Join(dir, "sales.parquet")
+	out, err := os.Create(pqPath)
+	require.NoError(t, err)
+	// the writer closes the file
+	require.NoError(t, pqarrow.WriteTable(arrTbl, out, 1024, nil, pqarrow.DefaultWriterProps()))
+
+	tbl, err = writeFiles(ctx, tbl, []string{csvPath, jsonPath, pqPath}, "")
+	require.NoError(t, err)
+
+	result, err := tbl.Scan().ToArrowTable(ctx)
+	require.NoError(t, err)
+	defer result.Release()
+	assert.EqualValues(t, 5, result.NumRows())
+
+	// one data file per input file and partition
+	tasks, err := tbl.Scan().PlanFiles(ctx)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 5)
+
+	_, err = writeFiles(ctx, tbl, []string{filepath.Join(dir, "missing.csv")}, "")
+	assert.ErrorContains(t, err, "missing.csv")
+}
@@ -18,14 +18,21 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 const (
 	cfgFile           = ".iceberg-go.yaml"
+	envPrefix         = "GOICEBERG_"
 	defaultMaxWorkers = 5
 )
 
@@ -41,6 +48,50 @@ type CatalogConfig struct {
 	Output      string `yaml:"output"`
 	Credential  string `yaml:"credential"`
 	Warehouse   string `yaml:"warehouse"`
+	// Properties holds any other keys of the catalog's configuration,
+	// such as "s3.endpoint" or "token", which are passed on to the
+	// catalog as properties.
+	Properties map[string]string `yaml:",inline"`
+}
+
+// Props returns the catalog's configuration as catalog properties,
+// omitting unset values. The output format is a CLI setting and not
+// included.
+func (c CatalogConfig) Props() map[string]string {
+	props := maps.Clone(c.Properties)
+	if props == nil {
+		props = make(map[string]string)
+	}
+
+	for k, v := range map[string]string{
+		"type": c.CatalogType, "uri": c.URI, "credential": c.Credential, "warehouse": c.Warehouse,
+	} {
+		if v != "" {
+			props[k] = v
+		}
+	}
+
+	return props
+}
+
+func (c *CatalogConfig) set(key, value string) {
+	switch key {
+	case "type":
+		c.CatalogType = value
+	case "uri":
+		c.URI = value
+	case "output":
+		c.Output = value
+	case "credential":
+		c.Credential = value
+	case "warehouse":
+		c.Warehouse = value
+	default:
+		if c.Properties == nil {
+			c.Properties = make(map[string]string)
+		}
+		c.Properties[key] = value
+	}
 }
 
 func LoadConfig(configPath string) []byte {
@@ -72,27 +123,102 @@ func ParseConfig(file []byte, catalogName string) *CatalogConfig {
 	if !ok {
 		return nil
 	}
+	if len(res.Properties) == 0 {
+		res.Properties = nil
+	}
 
 	return &res
 }
 
-func fromConfigFiles() Config {
-	dir := os.Getenv("GOICEBERG_HOME")
-	if dir != "" {
-		dir = filepath.Join(dir, cfgFile)
+// LoadFile reads the configuration from the YAML file at path, then
+// applies any overrides from the environment (see ApplyEnv) and the
+// defaults for unset values. A missing file is not an error, so that
+// the configuration can come from the environment alone.
+func LoadFile(path string) (Config, error) {
+	var cfg Config
+
+	file, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return cfg, err
+	default:
+		if err := yaml.Unmarshal(file, &cfg); err != nil {
+			return cfg, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
 	}
 
-	var cfg Config
-	if err := yaml.Unmarshal(LoadConfig(dir), &cfg); err != nil {
-		return cfg
+	cfg.ApplyEnv(os.Environ())
+	cfg.setDefaults()
+
+	return cfg, nil
+}
+
+// ApplyEnv overrides the configuration with the GOICEBERG_ variables of
+// environ, given as "key=value" pairs. GOICEBERG_DEFAULT_CATALOG and
+// GOICEBERG_MAX_WORKERS set the top-level keys, and catalog properties
+// are set with GOICEBERG_CATALOG__<NAME>__<KEY>, where the catalog name
+// is lowercased and, in the key, "__" stands for "." and "_" for "-".
+// For example GOICEBERG_CATALOG__PROD__S3__ACCESS_KEY_ID sets the
+// "s3.access-key-id" property of the catalog "prod".
+func (c *Config) ApplyEnv(environ []string) {
+	for _, kv := range environ {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(key, envPrefix) {
+			continue
+		}
+
+		switch key = strings.TrimPrefix(key, envPrefix); key {
+		case "DEFAULT_CATALOG":
+			c.DefaultCatalog = value
+		case "MAX_WORKERS":
+			if n, err := strconv.Atoi(value); err == nil {
+				c.MaxWorkers = n
+			}
+		default:
+			parts := strings.SplitN(key, "__", 3)
+			if len(parts) != 3 || parts[0] != "CATALOG" || parts[1] == "" || parts[2] == "" {
+				continue
+			}
+
+			name := strings.ToLower(parts[1])
+			prop := strings.ReplaceAll(strings.ReplaceAll(strings.ToLower(parts[2]), "__", "."), "_", "-")
+			if c.Catalogs == nil {
+				c.Catalogs = make(map[string]CatalogConfig)
+			}
+			cat := c.Catalogs[name]
+			cat.set(prop, value)
+			c.Catalogs[name] = cat
+		}
 	}
+}
 
-	if cfg.DefaultCatalog == "" {
-		cfg.DefaultCatalog = "default"
+func (c *Config) setDefaults() {
+	if c.DefaultCatalog == "" {
+		c.DefaultCatalog = "default"
 	}
-	if cfg.MaxWorkers <= 0 {
-		cfg.MaxWorkers = defaultMaxWorkers
+	if c.MaxWorkers <= 0 {
+		c.MaxWorkers = defaultMaxWorkers
 	}
+}
+
+func fromConfigFiles() Config {
+	dir := os.Getenv("GOICEBERG_HOME")
+	if dir == "" {
+		dir, _ = os.UserHomeDir()
+	}
+
+	if dir != "" {
+		if cfg, err := LoadFile(filepath.Join(dir, cfgFile)); err == nil {
+			return cfg
+		}
+	}
+
+	// without a readable config file, the configuration comes from the
+	// environment alone
+	var cfg Config
+	cfg.ApplyEnv(os.Environ())
+	cfg.setDefaults()
 
 	return cfg
 }
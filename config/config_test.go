@@ -18,9 +18,12 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var testArgs = []struct {
@@ -87,3 +90,70 @@ func TestParseConfig(t *testing.T) {
 		assert.Equal(t, tt.expected, actual)
 	}
 }
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), cfgFile)
+	require.NoError(t, os.WriteFile(path, []byte(`
+default-catalog: prod
+catalog:
+  prod:
+    type: rest
+    uri: http://localhost:8181/
+    s3.endpoint: http://localhost:9000
+    rest.sigv4-enabled: true
+    max-retries: 3
+`), 0o600))
+
+	t.Setenv("GOICEBERG_CATALOG__PROD__URI", "https://catalog.example.com")
+	t.Setenv("GOICEBERG_CATALOG__PROD__S3__ACCESS_KEY_ID", "admin")
+	t.Setenv("GOICEBERG_CATALOG__LOCAL__TYPE", "sql")
+
+	cfg, err := LoadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "prod", cfg.DefaultCatalog)
+	assert.Equal(t, defaultMaxWorkers, cfg.MaxWorkers)
+	assert.Equal(t, map[string]string{
+		"type":               "rest",
+		"uri":                "https://catalog.example.com",
+		"s3.endpoint":        "http://localhost:9000",
+		"s3.access-key-id":   "admin",
+		"rest.sigv4-enabled": "true",
+		"max-retries":        "3",
+	}, cfg.Catalogs["prod"].Props())
+	assert.Equal(t, map[string]string{"type": "sql"}, cfg.Catalogs["local"].Props())
+
+	t.Run("missing file", func(t *testing.T) {
+		cfg, err := LoadFile(filepath.Join(t.TempDir(), cfgFile))
+		require.NoError(t, err)
+		assert.Equal(t, "default", cfg.DefaultCatalog)
+		assert.Equal(t, "sql", cfg.Catalogs["local"].CatalogType)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), cfgFile)
+		require.NoError(t, os.WriteFile(path, []byte("catalog: [1, 2"), 0o600))
+
+		_, err := LoadFile(path)
+		assert.Error(t, err)
+	})
+}
+
+func TestApplyEnv(t *testing.T) {
+	var cfg Config
+	cfg.ApplyEnv([]string{
+		"GOICEBERG_DEFAULT_CATALOG=warehouse",
+		"GOICEBERG_MAX_WORKERS=12",
+		"GOICEBERG_CATALOG__Warehouse__WAREHOUSE=s3://bucket/path",
+		"GOICEBERG_CATALOG__WAREHOUSE__OUTPUT=json",
+		"GOICEBERG_CATALOG__INVALID=ignored",
+		"GOICEBERG_HOME=/tmp",
+		"HOME=/root",
+	})
+
+	assert.Equal(t, "warehouse", cfg.DefaultCatalog)
+	assert.Equal(t, 12, cfg.MaxWorkers)
+	assert.Equal(t, map[string]CatalogConfig{
+		"warehouse": {Warehouse: "s3://bucket/path", Output: "json"},
+	}, cfg.Catalogs)
+}
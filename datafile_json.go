@@ -0,0 +1,414 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/iceberg-go/internal"
+	"github.com/google/uuid"
+)
+
+var contentJSONNames = map[ManifestEntryContent]string{
+	EntryContentData:       "data",
+	EntryContentPosDeletes: "position-deletes",
+	EntryContentEqDeletes:  "equality-deletes",
+}
+
+type countMapJSON struct {
+	Keys   []int   `json:"keys"`
+	Values []int64 `json:"values"`
+}
+
+type binaryMapJSON struct {
+	Keys   []int    `json:"keys"`
+	Values []string `json:"values"`
+}
+
+type dataFileJSON struct {
+	Content            string            `json:"content"`
+	FilePath           string            `json:"file-path"`
+	FileFormat         FileFormat        `json:"file-format"`
+	SpecID             int32             `json:"spec-id"`
+	Partition          []json.RawMessage `json:"partition"`
+	FileSizeInBytes    int64             `json:"file-size-in-bytes"`
+	RecordCount        int64             `json:"record-count"`
+	BlockSizeInBytes   int64             `json:"block-size-in-bytes,omitempty"`
+	KeyMetadata        string            `json:"key-metadata,omitempty"`
+	SplitOffsets       []int64           `json:"split-offsets,omitempty"`
+	SortOrderID        *int              `json:"sort-order-id,omitempty"`
+	EqualityIDs        []int             `json:"equality-ids,omitempty"`
+	ColumnSizes        *countMapJSON     `json:"column-sizes,omitempty"`
+	ValueCounts        *countMapJSON     `json:"value-counts,omitempty"`
+	NullValueCounts    *countMapJSON     `json:"null-value-counts,omitempty"`
+	NaNValueCounts     *countMapJSON     `json:"nan-value-counts,omitempty"`
+	DistinctCounts     *countMapJSON     `json:"distinct-counts,omitempty"`
+	LowerBounds        *binaryMapJSON    `json:"lower-bounds,omitempty"`
+	UpperBounds        *binaryMapJSON    `json:"upper-bounds,omitempty"`
+	FirstRowID         *int64            `json:"first-row-id,omitempty"`
+	ReferencedDataFile *string           `json:"referenced-data-file,omitempty"`
+	ContentOffset      *int64            `json:"content-offset,omitempty"`
+	ContentSizeInBytes *int64            `json:"content-size-in-bytes,omitempty"`
+}
+
+// MarshalDataFile serializes a data or delete file to the JSON content
+// file representation used by the REST scan planning API. The partition
+// spec and schema are used to write the partition tuple, in spec order,
+// as JSON single values. Column bounds and key metadata are written as
+// hex strings.
+func MarshalDataFile(df DataFile, spec PartitionSpec, schema *Schema) ([]byte, error) {
+	content, ok := contentJSONNames[df.ContentType()]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown content type %d", ErrInvalidArgument, df.ContentType())
+	}
+
+	if df.SpecID() != int32(spec.ID()) {
+		return nil, fmt.Errorf("%w: data file has spec id %d, got spec %d",
+			ErrInvalidArgument, df.SpecID(), spec.ID())
+	}
+
+	out := dataFileJSON{
+		Content:            content,
+		FilePath:           df.FilePath(),
+		FileFormat:         df.FileFormat(),
+		SpecID:             df.SpecID(),
+		Partition:          make([]json.RawMessage, 0, spec.NumFields()),
+		FileSizeInBytes:    df.FileSizeBytes(),
+		RecordCount:        df.Count(),
+		SplitOffsets:       df.SplitOffsets(),
+		SortOrderID:        df.SortOrderID(),
+		EqualityIDs:        df.EqualityFieldIDs(),
+		ColumnSizes:        countMapToJSON(df.ColumnSizes()),
+		ValueCounts:        countMapToJSON(df.ValueCounts()),
+		NullValueCounts:    countMapToJSON(df.NullValueCounts()),
+		NaNValueCounts:     countMapToJSON(df.NaNValueCounts()),
+		DistinctCounts:     countMapToJSON(df.DistinctValueCounts()),
+		LowerBounds:        binaryMapToJSON(df.LowerBoundValues()),
+		UpperBounds:        binaryMapToJSON(df.UpperBoundValues()),
+		FirstRowID:         df.FirstRowID(),
+		ReferencedDataFile: df.ReferencedDataFile(),
+		ContentOffset:      df.ContentOffset(),
+		ContentSizeInBytes: df.ContentSizeInBytes(),
+	}
+
+	if d, ok := df.(*dataFile); ok {
+		out.BlockSizeInBytes = d.BlockSizeInBytes
+	}
+
+	if key := df.KeyMetadata(); len(key) > 0 {
+		out.KeyMetadata = hex.EncodeToString(key)
+	}
+
+	partition := df.Partition()
+	for field := range spec.Fields() {
+		v, err := partitionValueToJSON(partition[field.FieldID])
+		if err != nil {
+			return nil, fmt.Errorf("partition field %s: %w", field.Name, err)
+		}
+		out.Partition = append(out.Partition, v)
+	}
+
+	return json.Marshal(out)
+}
+
+// UnmarshalDataFile parses a data or delete file written by
+// MarshalDataFile. The partition spec must be the spec the file was
+// written with and is used, along with the schema, to restore the
+// partition values to the same types produced when reading a manifest.
+func UnmarshalDataFile(data []byte, spec PartitionSpec, schema *Schema) (DataFile, error) {
+	var in dataFileJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, fmt.Errorf("%w: invalid data file json: %s", ErrInvalidArgument, err)
+	}
+
+	var (
+		content ManifestEntryContent
+		found   bool
+	)
+	for k, v := range contentJSONNames {
+		if v == in.Content {
+			content, found = k, true
+
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: unknown content type %q", ErrInvalidArgument, in.Content)
+	}
+
+	switch in.FileFormat {
+	case AvroFile, OrcFile, ParquetFile:
+	case PuffinFile:
+		if content != EntryContentPosDeletes {
+			return nil, fmt.Errorf("%w: format %s is only valid for position deletes",
+				ErrInvalidArgument, PuffinFile)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown file format %q", ErrInvalidArgument, in.FileFormat)
+	}
+
+	if in.FilePath == "" {
+		return nil, fmt.Errorf("%w: data file path cannot be empty", ErrInvalidArgument)
+	}
+
+	if in.SpecID != int32(spec.ID()) {
+		return nil, fmt.Errorf("%w: data file has spec id %d, got spec %d",
+			ErrInvalidArgument, in.SpecID, spec.ID())
+	}
+
+	if len(in.Partition) != spec.NumFields() {
+		return nil, fmt.Errorf("%w: expected %d partition values, got %d",
+			ErrInvalidArgument, spec.NumFields(), len(in.Partition))
+	}
+
+	partType := spec.PartitionType(schema)
+	avroSchema, err := partitionTypeToAvroSchema(partType)
+	if err != nil {
+		return nil, err
+	}
+
+	entrySchema, err := internal.NewManifestEntrySchema(avroSchema, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	nameToID, idToLogicalType, idToFixedSize := getFieldIDMap(entrySchema)
+
+	partitionData := make(map[string]any, spec.NumFields())
+	idToPartitionData := make(map[int]any, spec.NumFields())
+	for i, field := range partType.FieldList {
+		v, err := partitionValueFromJSON(in.Partition[i], field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("partition field %s: %w", field.Name, err)
+		}
+		partitionData[field.Name] = v
+		idToPartitionData[field.ID] = v
+	}
+
+	partition, err := newPartitionData(spec, partType.FieldList, idToPartitionData)
+	if err != nil {
+		return nil, err
+	}
+
+	df := &dataFile{
+		Content:                 content,
+		Path:                    in.FilePath,
+		Format:                  in.FileFormat,
+		PartitionValues:         partitionData,
+		RecordCount:             in.RecordCount,
+		FileSize:                in.FileSizeInBytes,
+		BlockSizeInBytes:        in.BlockSizeInBytes,
+		SortOrder:               in.SortOrderID,
+		FirstRowIDField:         in.FirstRowID,
+		ReferencedDataFileField: in.ReferencedDataFile,
+		ContentOffsetField:      in.ContentOffset,
+		ContentSizeInBytesField: in.ContentSizeInBytes,
+		fieldNameToID:           nameToID,
+		fieldIDToLogicalType:    idToLogicalType,
+		fieldIDToFixedSize:      idToFixedSize,
+		fieldIDToPartitionData:  idToPartitionData,
+		partition:               &partition,
+		specID:                  in.SpecID,
+	}
+
+	if len(in.SplitOffsets) > 0 {
+		df.Splits = &in.SplitOffsets
+	}
+
+	if len(in.EqualityIDs) > 0 {
+		df.EqualityIDs = &in.EqualityIDs
+	}
+
+	if in.KeyMetadata != "" {
+		key, err := hex.DecodeString(in.KeyMetadata)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid key metadata: %s", ErrInvalidArgument, err)
+		}
+		df.Key = &key
+	}
+
+	df.ColSizes = countMapFromJSON(in.ColumnSizes)
+	df.ValCounts = countMapFromJSON(in.ValueCounts)
+	df.NullCounts = countMapFromJSON(in.NullValueCounts)
+	df.NaNCounts = countMapFromJSON(in.NaNValueCounts)
+	df.DistinctCounts = countMapFromJSON(in.DistinctCounts)
+
+	if df.LowerBounds, err = binaryMapFromJSON(in.LowerBounds); err != nil {
+		return nil, err
+	}
+
+	if df.UpperBounds, err = binaryMapFromJSON(in.UpperBounds); err != nil {
+		return nil, err
+	}
+
+	return df, nil
+}
+
+func countMapToJSON(m map[int]int64) *countMapJSON {
+	if len(m) == 0 {
+		return nil
+	}
+
+	out := &countMapJSON{Keys: slices.Sorted(maps.Keys(m))}
+	out.Values = make([]int64, len(out.Keys))
+	for i, k := range out.Keys {
+		out.Values[i] = m[k]
+	}
+
+	return out
+}
+
+func countMapFromJSON(in *countMapJSON) *[]colMap[int, int64] {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]colMap[int, int64], 0, len(in.Keys))
+	for i, k := range in.Keys {
+		if i < len(in.Values) {
+			out = append(out, colMap[int, int64]{Key: k, Value: in.Values[i]})
+		}
+	}
+
+	return &out
+}
+
+func binaryMapToJSON(m map[int][]byte) *binaryMapJSON {
+	if len(m) == 0 {
+		return nil
+	}
+
+	out := &binaryMapJSON{Keys: slices.Sorted(maps.Keys(m))}
+	out.Values = make([]string, len(out.Keys))
+	for i, k := range out.Keys {
+		out.Values[i] = hex.EncodeToString(m[k])
+	}
+
+	return out
+}
+
+func binaryMapFromJSON(in *binaryMapJSON) (*[]colMap[int, []byte], error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	if len(in.Keys) != len(in.Values) {
+		return nil, fmt.Errorf("%w: bounds have %d keys and %d values",
+			ErrInvalidArgument, len(in.Keys), len(in.Values))
+	}
+
+	out := make([]colMap[int, []byte], len(in.Keys))
+	for i, k := range in.Keys {
+		v, err := hex.DecodeString(in.Values[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid bound for field %d: %s", ErrInvalidArgument, k, err)
+		}
+		out[i] = colMap[int, []byte]{Key: k, Value: v}
+	}
+
+	return &out, nil
+}
+
+func partitionValueToJSON(v any) (json.RawMessage, error) {
+	switch v := v.(type) {
+	case nil:
+		return json.RawMessage("null"), nil
+	case []byte:
+		return json.Marshal(hex.EncodeToString(v))
+	case [16]byte:
+		return json.Marshal(uuid.UUID(v).String())
+	case Decimal:
+		return json.Marshal(DecimalLiteral(v).String())
+	case DecimalLiteral:
+		return json.Marshal(v.String())
+	case bool, int32, int64, float32, float64, string, uuid.UUID,
+		Date, Time, Timestamp, TimestampNano:
+		return json.Marshal(v)
+	}
+
+	return nil, fmt.Errorf("%w: cannot serialize partition value of type %T", ErrNotImplemented, v)
+}
+
+func partitionValueFromJSON(data json.RawMessage, typ Type) (any, error) {
+	if string(data) == "null" {
+		return nil, nil
+	}
+
+	var (
+		out any
+		err error
+	)
+
+	switch t := typ.(type) {
+	case BooleanType:
+		out, err = unmarshalAs[bool](data)
+	case Int32Type:
+		out, err = unmarshalAs[int32](data)
+	case Int64Type:
+		out, err = unmarshalAs[int64](data)
+	case Float32Type:
+		out, err = unmarshalAs[float32](data)
+	case Float64Type:
+		out, err = unmarshalAs[float64](data)
+	case DateType:
+		out, err = unmarshalAs[Date](data)
+	case TimeType:
+		out, err = unmarshalAs[Time](data)
+	case TimestampType, TimestampTzType:
+		out, err = unmarshalAs[Timestamp](data)
+	case TimestampNsType, TimestampTzNsType:
+		out, err = unmarshalAs[TimestampNano](data)
+	case StringType:
+		out, err = unmarshalAs[string](data)
+	case UUIDType:
+		out, err = unmarshalAs[uuid.UUID](data)
+	case BinaryType, FixedType:
+		var s string
+		if s, err = unmarshalAs[string](data); err == nil {
+			out, err = hex.DecodeString(s)
+		}
+	case DecimalType:
+		var s string
+		if s, err = unmarshalAs[string](data); err == nil {
+			var n decimal128.Num
+			if n, err = decimal128.FromString(s, int32(t.Precision()), int32(t.Scale())); err == nil {
+				out = DecimalLiteral{Val: n, Scale: t.Scale()}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: cannot deserialize partition value of type %s", ErrNotImplemented, typ)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid %s partition value %s: %s", ErrInvalidArgument, typ, data, err)
+	}
+
+	return out, nil
+}
+
+func unmarshalAs[T any](data json.RawMessage) (T, error) {
+	var out T
+	err := json.Unmarshal(data, &out)
+
+	return out, err
+}
@@ -0,0 +1,157 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/iceberg-go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataFileJSONRoundTrip(t *testing.T) {
+	schema := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "ts", Type: iceberg.PrimitiveTypes.TimestampTz},
+		iceberg.NestedField{ID: 3, Name: "category", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 4, Name: "price", Type: iceberg.DecimalTypeOf(9, 2)},
+		iceberg.NestedField{ID: 5, Name: "key", Type: iceberg.PrimitiveTypes.UUID},
+		iceberg.NestedField{ID: 6, Name: "small", Type: iceberg.PrimitiveTypes.Int32},
+	)
+
+	spec := iceberg.NewPartitionSpecID(3,
+		iceberg.PartitionField{SourceID: 2, FieldID: 1000, Name: "ts_day", Transform: iceberg.DayTransform{}},
+		iceberg.PartitionField{SourceID: 3, FieldID: 1001, Name: "category", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 4, FieldID: 1002, Name: "price", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 5, FieldID: 1003, Name: "key", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 1, FieldID: 1004, Name: "id_bucket", Transform: iceberg.BucketTransform{NumBuckets: 8}},
+		iceberg.PartitionField{SourceID: 6, FieldID: 1005, Name: "small", Transform: iceberg.IdentityTransform{}},
+	)
+
+	price, err := decimal128.FromString("12.34", 9, 2)
+	require.NoError(t, err)
+	key := uuid.MustParse("f79c3e09-677c-4bbd-a479-3f349cb785e7")
+
+	partition := map[int]any{
+		1000: int32(19000),
+		1001: "books",
+		1002: iceberg.DecimalLiteral{Val: price, Scale: 2},
+		1003: key,
+		1004: int32(3),
+		1005: nil,
+	}
+
+	bldr, err := iceberg.NewDataFileBuilder(spec, iceberg.EntryContentData,
+		"s3://bucket/data/file.parquet", iceberg.ParquetFile, partition, nil, nil, 100, 2048)
+	require.NoError(t, err)
+
+	sortOrder := 1
+	df := bldr.ColumnSizes(map[int]int64{1: 100, 3: 50}).
+		ValueCounts(map[int]int64{1: 100, 3: 100}).
+		NullValueCounts(map[int]int64{1: 0, 3: 4}).
+		LowerBoundValues(map[int][]byte{1: {1, 0, 0, 0, 0, 0, 0, 0}, 3: []byte("a")}).
+		UpperBoundValues(map[int][]byte{1: {100, 0, 0, 0, 0, 0, 0, 0}, 3: []byte("z")}).
+		KeyMetadata([]byte{0xde, 0xad}).
+		SplitOffsets([]int64{4}).
+		SortOrderID(sortOrder).
+		Build()
+
+	data, err := iceberg.MarshalDataFile(df, spec, schema)
+	require.NoError(t, err)
+
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(data, &raw))
+	assert.Equal(t, "data", raw["content"])
+	assert.Equal(t, []any{19000.0, "books", "12.34", key.String(), 3.0, nil}, raw["partition"])
+	assert.Equal(t, "dead", raw["key-metadata"])
+	assert.Equal(t, map[string]any{"keys": []any{1.0, 3.0}, "values": []any{"0100000000000000", "61"}},
+		raw["lower-bounds"])
+
+	out, err := iceberg.UnmarshalDataFile(data, spec, schema)
+	require.NoError(t, err)
+
+	assert.Equal(t, df.ContentType(), out.ContentType())
+	assert.Equal(t, df.FilePath(), out.FilePath())
+	assert.Equal(t, df.FileFormat(), out.FileFormat())
+	assert.Equal(t, df.SpecID(), out.SpecID())
+	assert.Equal(t, df.Count(), out.Count())
+	assert.Equal(t, df.FileSizeBytes(), out.FileSizeBytes())
+	assert.Equal(t, df.Partition(), out.Partition())
+	assert.Equal(t, df.ColumnSizes(), out.ColumnSizes())
+	assert.Equal(t, df.ValueCounts(), out.ValueCounts())
+	assert.Equal(t, df.NullValueCounts(), out.NullValueCounts())
+	assert.Equal(t, df.LowerBoundValues(), out.LowerBoundValues())
+	assert.Equal(t, df.UpperBoundValues(), out.UpperBoundValues())
+	assert.Equal(t, df.KeyMetadata(), out.KeyMetadata())
+	assert.Equal(t, df.SplitOffsets(), out.SplitOffsets())
+	assert.Equal(t, df.SortOrderID(), out.SortOrderID())
+	assert.Empty(t, out.NaNValueCounts())
+}
+
+func TestDataFileJSONDeletes(t *testing.T) {
+	schema := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true})
+
+	bldr, err := iceberg.NewDataFileBuilder(*iceberg.UnpartitionedSpec, iceberg.EntryContentEqDeletes,
+		"file:///tmp/deletes.parquet", iceberg.ParquetFile, nil, nil, nil, 10, 512)
+	require.NoError(t, err)
+	df := bldr.EqualityFieldIDs([]int{1}).Build()
+
+	data, err := iceberg.MarshalDataFile(df, *iceberg.UnpartitionedSpec, schema)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"content": "equality-deletes", "file-path": "file:///tmp/deletes.parquet",
+		"file-format": "PARQUET", "spec-id": 0, "partition": [], "file-size-in-bytes": 512,
+		"record-count": 10, "equality-ids": [1]}`, string(data))
+
+	out, err := iceberg.UnmarshalDataFile(data, *iceberg.UnpartitionedSpec, schema)
+	require.NoError(t, err)
+	assert.Equal(t, iceberg.EntryContentEqDeletes, out.ContentType())
+	assert.Equal(t, []int{1}, out.EqualityFieldIDs())
+}
+
+func TestUnmarshalDataFileErrors(t *testing.T) {
+	schema := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true})
+	spec := iceberg.NewPartitionSpecID(1,
+		iceberg.PartitionField{SourceID: 1, FieldID: 1000, Name: "id", Transform: iceberg.IdentityTransform{}})
+
+	tests := []struct {
+		name, json string
+	}{
+		{"invalid json", `{`},
+		{"content", `{"content": "foo", "file-path": "a", "file-format": "PARQUET", "spec-id": 1, "partition": [1]}`},
+		{"format", `{"content": "data", "file-path": "a", "file-format": "CSV", "spec-id": 1, "partition": [1]}`},
+		{"path", `{"content": "data", "file-path": "", "file-format": "PARQUET", "spec-id": 1, "partition": [1]}`},
+		{"spec id", `{"content": "data", "file-path": "a", "file-format": "PARQUET", "spec-id": 0, "partition": [1]}`},
+		{"partition arity", `{"content": "data", "file-path": "a", "file-format": "PARQUET", "spec-id": 1, "partition": []}`},
+		{"partition type", `{"content": "data", "file-path": "a", "file-format": "PARQUET", "spec-id": 1, "partition": ["x"]}`},
+		{"bounds", `{"content": "data", "file-path": "a", "file-format": "PARQUET", "spec-id": 1, "partition": [1],
+			"lower-bounds": {"keys": [1], "values": ["zz"]}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := iceberg.UnmarshalDataFile([]byte(tt.json), spec, schema)
+			assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+		})
+	}
+}
@@ -0,0 +1,270 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package encryption_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/apache/iceberg-go/encryption"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randBytes(t *testing.T, n int) []byte {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	require.NoError(t, err)
+
+	return b
+}
+
+func encryptAll(t *testing.T, plain, key, aad []byte, blockSize int) []byte {
+	var buf bytes.Buffer
+	w, err := encryption.NewStreamWriterSize(&buf, key, aad, blockSize)
+	require.NoError(t, err)
+
+	// write in uneven pieces to exercise block boundaries
+	for rest := plain; len(rest) > 0; {
+		n := min(len(rest), 7)
+		_, err := w.Write(rest[:n])
+		require.NoError(t, err)
+		rest = rest[n:]
+	}
+	require.NoError(t, w.Close())
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func TestStreamRoundTrip(t *testing.T) {
+	key, aad := randBytes(t, 16), randBytes(t, 16)
+
+	tests := []struct {
+		name      string
+		size      int
+		blockSize int
+	}{
+		{"empty", 0, 64},
+		{"single partial block", 10, 64},
+		{"exact blocks", 128, 64},
+		{"partial last block", 200, 64},
+		{"default block size", 3 * 1024, encryption.DefaultPlainBlockSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plain := randBytes(t, tt.size)
+			enc := encryptAll(t, plain, key, aad, tt.blockSize)
+			assert.EqualValues(t, encryption.EncryptedLength(int64(tt.size), tt.blockSize), len(enc))
+			assert.Equal(t, "AGS1", string(enc[:4]))
+
+			rdr, err := encryption.NewStreamReader(bytes.NewReader(enc), int64(len(enc)), key, aad)
+			require.NoError(t, err)
+			assert.EqualValues(t, tt.size, rdr.Size())
+
+			out, err := io.ReadAll(rdr)
+			require.NoError(t, err)
+			assert.Equal(t, len(plain), len(out))
+			assert.True(t, bytes.Equal(plain, out))
+
+			if tt.size > 20 {
+				p := make([]byte, 20)
+				n, err := rdr.ReadAt(p, int64(tt.size-20))
+				require.NoError(t, err)
+				assert.Equal(t, 20, n)
+				assert.Equal(t, plain[tt.size-20:], p)
+
+				_, err = rdr.Seek(-10, io.SeekEnd)
+				require.NoError(t, err)
+				tail, err := io.ReadAll(rdr)
+				require.NoError(t, err)
+				assert.Equal(t, plain[tt.size-10:], tail)
+			}
+		})
+	}
+}
+
+func TestStreamTampering(t *testing.T) {
+	key, aad := randBytes(t, 16), randBytes(t, 16)
+	plain := randBytes(t, 300)
+	enc := encryptAll(t, plain, key, aad, 64)
+
+	t.Run("wrong aad", func(t *testing.T) {
+		rdr, err := encryption.NewStreamReader(bytes.NewReader(enc), int64(len(enc)), key, randBytes(t, 16))
+		require.NoError(t, err)
+		_, err = io.ReadAll(rdr)
+		assert.ErrorIs(t, err, encryption.ErrInvalidCipher)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		rdr, err := encryption.NewStreamReader(bytes.NewReader(enc), int64(len(enc)), randBytes(t, 16), aad)
+		require.NoError(t, err)
+		_, err = rdr.ReadAt(make([]byte, 1), 0)
+		assert.ErrorIs(t, err, encryption.ErrInvalidCipher)
+	})
+
+	t.Run("flipped bit", func(t *testing.T) {
+		modified := bytes.Clone(enc)
+		modified[len(modified)/2] ^= 1
+		rdr, err := encryption.NewStreamReader(bytes.NewReader(modified), int64(len(modified)), key, aad)
+		require.NoError(t, err)
+		_, err = io.ReadAll(rdr)
+		assert.ErrorIs(t, err, encryption.ErrInvalidCipher)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		truncated := enc[:len(enc)-(300%64)-20]
+		_, err := encryption.NewStreamReader(bytes.NewReader(truncated), int64(len(truncated)), key, aad)
+		assert.ErrorIs(t, err, encryption.ErrInvalidCipher)
+	})
+
+	t.Run("bad magic", func(t *testing.T) {
+		modified := bytes.Clone(enc)
+		copy(modified, "PAR1")
+		_, err := encryption.NewStreamReader(bytes.NewReader(modified), int64(len(modified)), key, aad)
+		assert.ErrorIs(t, err, encryption.ErrInvalidCipher)
+	})
+}
+
+func TestKeyMetadataRoundTrip(t *testing.T) {
+	length := int64(1234)
+	km := encryption.KeyMetadata{
+		EncryptionKey: randBytes(t, 16),
+		AADPrefix:     randBytes(t, 16),
+		FileLength:    &length,
+	}
+
+	data, err := km.Encode()
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, data[0])
+
+	decoded, err := encryption.DecodeKeyMetadata(data)
+	require.NoError(t, err)
+	assert.Equal(t, km, decoded)
+
+	noAAD, err := encryption.KeyMetadata{EncryptionKey: km.EncryptionKey}.Encode()
+	require.NoError(t, err)
+	decoded, err = encryption.DecodeKeyMetadata(noAAD)
+	require.NoError(t, err)
+	assert.Nil(t, decoded.AADPrefix)
+	assert.Nil(t, decoded.FileLength)
+
+	_, err = encryption.DecodeKeyMetadata([]byte{2, 0})
+	assert.ErrorIs(t, err, encryption.ErrInvalidMetadata)
+	_, err = encryption.DecodeKeyMetadata(nil)
+	assert.ErrorIs(t, err, encryption.ErrInvalidMetadata)
+}
+
+func TestStandardEncryptionManager(t *testing.T) {
+	ctx := context.Background()
+	kms, err := encryption.NewInMemoryKMS(nil)
+	require.NoError(t, err)
+	require.NoError(t, kms.AddKey("table-key", 32))
+
+	mgr, err := encryption.NewStandardEncryptionManager(kms, "table-key", 16)
+	require.NoError(t, err)
+
+	key, err := mgr.NewFileKey()
+	require.NoError(t, err)
+	assert.Len(t, key.Key, 16)
+	assert.Len(t, key.AADPrefix, encryption.DefaultAADPrefixLength)
+
+	// the key metadata holds the plain key in the standard format
+	km, err := encryption.DecodeKeyMetadata(key.Metadata)
+	require.NoError(t, err)
+	assert.Equal(t, key.Key, km.EncryptionKey)
+	assert.Equal(t, key.AADPrefix, km.AADPrefix)
+
+	fromMetadata, err := encryption.FileKeyFromMetadata(key.Metadata)
+	require.NoError(t, err)
+	assert.Equal(t, key, fromMetadata)
+
+	plain := randBytes(t, 5000)
+	var buf bytes.Buffer
+	w, err := encryption.NewStreamWriter(&buf, key.Key, key.AADPrefix)
+	require.NoError(t, err)
+	_, err = w.Write(plain)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	rdr, err := encryption.DecryptStream(bytes.NewReader(buf.Bytes()), int64(buf.Len()), key.Metadata)
+	require.NoError(t, err)
+	out, err := io.ReadAll(rdr)
+	require.NoError(t, err)
+	assert.Equal(t, plain, out)
+
+	length := int64(buf.Len() - 1)
+	withLength, err := encryption.KeyMetadata{EncryptionKey: key.Key, AADPrefix: key.AADPrefix, FileLength: &length}.Encode()
+	require.NoError(t, err)
+	_, err = encryption.DecryptStream(bytes.NewReader(buf.Bytes()), int64(buf.Len()), withLength)
+	assert.ErrorIs(t, err, encryption.ErrInvalidCipher)
+
+	wrapped, err := mgr.WrapKeyMetadata(ctx, key.Metadata)
+	require.NoError(t, err)
+	assert.NotContains(t, string(wrapped), string(key.Key))
+
+	unwrapped, err := encryption.UnwrapKeyMetadata(ctx, kms, wrapped, "table-key")
+	require.NoError(t, err)
+	assert.Equal(t, key.Metadata, unwrapped)
+
+	require.NoError(t, kms.AddKey("other-key", 16))
+	_, err = encryption.UnwrapKeyMetadata(ctx, kms, wrapped, "other-key")
+	assert.ErrorIs(t, err, encryption.ErrInvalidCipher)
+
+	_, err = encryption.NewStandardEncryptionManager(kms, "table-key", 20)
+	assert.ErrorIs(t, err, encryption.ErrInvalidKey)
+}
+
+func TestLoadManager(t *testing.T) {
+	ctx := context.Background()
+
+	mgr, err := encryption.LoadManager(ctx, map[string]string{})
+	require.NoError(t, err)
+	assert.Nil(t, mgr)
+
+	props := map[string]string{encryption.TableKeyIDKey: "key"}
+	_, err = encryption.LoadManager(ctx, props)
+	assert.ErrorIs(t, err, encryption.ErrMissingKMS)
+
+	kms, err := encryption.NewInMemoryKMS(map[string][]byte{"key": randBytes(t, 16)})
+	require.NoError(t, err)
+
+	mgr, err = encryption.LoadManager(encryption.WithKMSClient(ctx, kms), props)
+	require.NoError(t, err)
+	assert.Equal(t, "key", mgr.TableKeyID())
+
+	encryption.RegisterKMS("test-kms", encryption.KMSRegistrarFunc(
+		func(context.Context, map[string]string) (encryption.KeyManagementClient, error) {
+			return kms, nil
+		}))
+	defer encryption.UnregisterKMS("test-kms")
+	assert.Contains(t, encryption.GetRegisteredKMS(), "test-kms")
+
+	props[encryption.KMSTypeKey] = "test-kms"
+	mgr, err = encryption.LoadManager(ctx, props)
+	require.NoError(t, err)
+	assert.NotNil(t, mgr)
+
+	props[encryption.KMSTypeKey] = "unknown"
+	_, err = encryption.LoadManager(ctx, props)
+	assert.ErrorIs(t, err, encryption.ErrKMSNotFound)
+}
@@ -0,0 +1,94 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package encryption
+
+import (
+	"fmt"
+
+	"github.com/hamba/avro/v2"
+)
+
+const keyMetadataV1 byte = 1
+
+var keyMetadataSchemaV1 = avro.MustParse(`{
+	"type": "record",
+	"name": "key_metadata",
+	"fields": [
+		{"name": "encryption_key", "type": "bytes", "field-id": 0},
+		{"name": "aad_prefix", "type": ["null", "bytes"], "default": null, "field-id": 1},
+		{"name": "file_length", "type": ["null", "long"], "default": null, "field-id": 2}
+	]
+}`)
+
+// KeyMetadata is the key material stored with an encrypted file, in the
+// key_metadata field of its manifest or manifest list entry. It is
+// serialized as a version byte followed by an Avro record, with the fields
+// of the standard key metadata of the Iceberg spec.
+//
+// EncryptionKey is the plain key of the file, so the key metadata must
+// only be stored in encrypted files, or be wrapped by the KMS.
+type KeyMetadata struct {
+	EncryptionKey []byte
+	AADPrefix     []byte
+	// FileLength is the length of the encrypted file, if known.
+	FileLength *int64
+}
+
+type keyMetadataRecord struct {
+	EncryptionKey []byte  `avro:"encryption_key"`
+	AADPrefix     *[]byte `avro:"aad_prefix"`
+	FileLength    *int64  `avro:"file_length"`
+}
+
+// Encode serializes the key metadata.
+func (k KeyMetadata) Encode() ([]byte, error) {
+	rec := keyMetadataRecord{EncryptionKey: k.EncryptionKey, FileLength: k.FileLength}
+	if k.AADPrefix != nil {
+		rec.AADPrefix = &k.AADPrefix
+	}
+
+	data, err := avro.Marshal(keyMetadataSchemaV1, rec)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{keyMetadataV1}, data...), nil
+}
+
+// DecodeKeyMetadata parses key metadata previously produced by Encode.
+func DecodeKeyMetadata(b []byte) (KeyMetadata, error) {
+	if len(b) == 0 {
+		return KeyMetadata{}, fmt.Errorf("%w: empty buffer", ErrInvalidMetadata)
+	}
+
+	if b[0] != keyMetadataV1 {
+		return KeyMetadata{}, fmt.Errorf("%w: unsupported version %d", ErrInvalidMetadata, b[0])
+	}
+
+	var rec keyMetadataRecord
+	if err := avro.Unmarshal(keyMetadataSchemaV1, b[1:], &rec); err != nil {
+		return KeyMetadata{}, fmt.Errorf("%w: %w", ErrInvalidMetadata, err)
+	}
+
+	out := KeyMetadata{EncryptionKey: rec.EncryptionKey, FileLength: rec.FileLength}
+	if rec.AADPrefix != nil {
+		out.AADPrefix = *rec.AADPrefix
+	}
+
+	return out, nil
+}
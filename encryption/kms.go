@@ -0,0 +1,158 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package encryption implements the Iceberg table encryption scheme: the
+// AES-GCM stream file format, the standard key metadata stored alongside
+// encrypted files, and envelope encryption of manifest list keys through
+// a pluggable key management service.
+//
+// Data and delete files are encrypted with Parquet modular encryption,
+// manifests and manifest lists with AES-GCM streams. The key metadata of
+// data files and manifests holds their plain keys, and is protected by
+// the encryption of the manifest or manifest list it is stored in. The
+// key metadata of a manifest list is wrapped with the table's key by the
+// KMS and stored in the encryption keys of the table metadata.
+package encryption
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// Table and catalog properties that control encryption.
+const (
+	// TableKeyIDKey is the ID of the key-encryption key in the KMS that is
+	// used to wrap the manifest list keys of a table. Encryption is
+	// enabled for a table when this property is set.
+	TableKeyIDKey = "encryption.key-id"
+	// DataKeyLengthKey is the length in bytes of the generated data
+	// encryption keys. Valid values are 16, 24 and 32.
+	DataKeyLengthKey     = "encryption.data-key-length"
+	DataKeyLengthDefault = 16
+	// KMSTypeKey names a KMS client registered with RegisterKMS.
+	KMSTypeKey = "encryption.kms-type"
+)
+
+var (
+	ErrKMSNotFound     = errors.New("encryption: kms not found")
+	ErrMissingKMS      = errors.New("encryption: no kms client configured")
+	ErrInvalidKey      = errors.New("encryption: invalid key")
+	ErrInvalidCipher   = errors.New("encryption: invalid ciphertext")
+	ErrMissingManager  = errors.New("encryption: no encryption manager configured")
+	ErrInvalidMetadata = errors.New("encryption: invalid key metadata")
+)
+
+// KeyManagementClient is the interface to a key management service (KMS)
+// which holds the key-encryption keys of a table. Manifest list keys are
+// generated locally and only ever stored in wrapped form.
+type KeyManagementClient interface {
+	// WrapKey encrypts the given key with the key-encryption key identified
+	// by wrappingKeyID.
+	WrapKey(ctx context.Context, key []byte, wrappingKeyID string) ([]byte, error)
+	// UnwrapKey decrypts a key previously returned by WrapKey using the
+	// key-encryption key identified by wrappingKeyID.
+	UnwrapKey(ctx context.Context, wrappedKey []byte, wrappingKeyID string) ([]byte, error)
+}
+
+// KMSRegistrar is a factory for KeyManagementClient instances, used to
+// register a KMS implementation for loading via the "encryption.kms-type"
+// property.
+type KMSRegistrar interface {
+	GetKMSClient(ctx context.Context, props map[string]string) (KeyManagementClient, error)
+}
+
+type KMSRegistrarFunc func(context.Context, map[string]string) (KeyManagementClient, error)
+
+func (f KMSRegistrarFunc) GetKMSClient(ctx context.Context, props map[string]string) (KeyManagementClient, error) {
+	return f(ctx, props)
+}
+
+var (
+	kmsMutex    sync.Mutex
+	kmsRegistry = map[string]KMSRegistrar{}
+)
+
+// RegisterKMS adds a KMS client factory to the registry. If the type is
+// already registered, it will be replaced.
+func RegisterKMS(kmsType string, reg KMSRegistrar) {
+	if reg == nil {
+		panic("encryption: RegisterKMS factory is nil")
+	}
+
+	kmsMutex.Lock()
+	defer kmsMutex.Unlock()
+	kmsRegistry[kmsType] = reg
+}
+
+// UnregisterKMS removes the requested KMS client factory from the registry.
+func UnregisterKMS(kmsType string) {
+	kmsMutex.Lock()
+	defer kmsMutex.Unlock()
+	delete(kmsRegistry, kmsType)
+}
+
+// GetRegisteredKMS returns the list of registered KMS types.
+func GetRegisteredKMS() []string {
+	kmsMutex.Lock()
+	defer kmsMutex.Unlock()
+
+	return slices.Sorted(maps.Keys(kmsRegistry))
+}
+
+// LoadKMS returns the KMS client to use for the given properties. A client
+// attached to the context with WithKMSClient takes priority, otherwise the
+// registry is consulted using the "encryption.kms-type" property.
+func LoadKMS(ctx context.Context, props map[string]string) (KeyManagementClient, error) {
+	if client := KMSClientFromContext(ctx); client != nil {
+		return client, nil
+	}
+
+	kmsType, ok := props[KMSTypeKey]
+	if !ok {
+		return nil, ErrMissingKMS
+	}
+
+	kmsMutex.Lock()
+	reg, ok := kmsRegistry[kmsType]
+	kmsMutex.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrKMSNotFound, kmsType)
+	}
+
+	return reg.GetKMSClient(ctx, props)
+}
+
+type kmsctxkey struct{}
+
+// WithKMSClient returns a new context with the given KMS client.
+func WithKMSClient(ctx context.Context, client KeyManagementClient) context.Context {
+	return context.WithValue(ctx, kmsctxkey{}, client)
+}
+
+// KMSClientFromContext returns the KMS client from the given context.
+// Returns nil if no client is set.
+func KMSClientFromContext(ctx context.Context) KeyManagementClient {
+	if v := ctx.Value(kmsctxkey{}); v != nil {
+		return v.(KeyManagementClient)
+	}
+
+	return nil
+}
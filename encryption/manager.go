@@ -0,0 +1,180 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package encryption
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+func randomBytes(b []byte) error {
+	_, err := rand.Read(b)
+
+	return err
+}
+
+// StandardEncryptionManager implements envelope encryption of table files.
+// Each file is encrypted with a freshly generated data encryption key (DEK)
+// and AAD prefix, which are stored in plain text in the standard key
+// metadata of the file. That key metadata is itself protected by the file
+// it is stored in: the key metadata of data files and manifests is stored
+// in encrypted manifests and manifest lists, and the key metadata of a
+// manifest list is wrapped with the table's key-encryption key (KEK) by
+// the KMS and stored in the table metadata.
+type StandardEncryptionManager struct {
+	kms           KeyManagementClient
+	tableKeyID    string
+	dataKeyLength int
+}
+
+// NewStandardEncryptionManager returns a manager wrapping manifest list
+// keys with the KEK identified by tableKeyID.
+func NewStandardEncryptionManager(kms KeyManagementClient, tableKeyID string, dataKeyLength int) (*StandardEncryptionManager, error) {
+	if kms == nil {
+		return nil, ErrMissingKMS
+	}
+
+	if tableKeyID == "" {
+		return nil, fmt.Errorf("%w: table key id must not be empty", ErrInvalidKey)
+	}
+
+	if err := validateKeyLength(dataKeyLength); err != nil {
+		return nil, err
+	}
+
+	return &StandardEncryptionManager{
+		kms:           kms,
+		tableKeyID:    tableKeyID,
+		dataKeyLength: dataKeyLength,
+	}, nil
+}
+
+// LoadManager returns the encryption manager for a table with the given
+// properties, or nil if the table is not encrypted.
+func LoadManager(ctx context.Context, props map[string]string) (*StandardEncryptionManager, error) {
+	keyID, ok := props[TableKeyIDKey]
+	if !ok || keyID == "" {
+		return nil, nil
+	}
+
+	dataKeyLength := DataKeyLengthDefault
+	if v, ok := props[DataKeyLengthKey]; ok {
+		var err error
+		if dataKeyLength, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("%w: invalid %s: %w", ErrInvalidKey, DataKeyLengthKey, err)
+		}
+	}
+
+	kms, err := LoadKMS(ctx, props)
+	if err != nil {
+		return nil, fmt.Errorf("table is encrypted with key %q: %w", keyID, err)
+	}
+
+	return NewStandardEncryptionManager(kms, keyID, dataKeyLength)
+}
+
+// TableKeyID returns the ID of the key-encryption key used by the manager.
+func (m *StandardEncryptionManager) TableKeyID() string { return m.tableKeyID }
+
+// FileKey is the key material of a single encrypted file.
+type FileKey struct {
+	// Key is the plain data encryption key.
+	Key []byte
+	// AADPrefix is the prefix of the additional authenticated data of
+	// the file, which binds its encrypted modules to the file.
+	AADPrefix []byte
+	// Metadata is the serialized key metadata to store with the file,
+	// holding the key and the AAD prefix.
+	Metadata []byte
+}
+
+// NewFileKey generates the key material for a new file.
+func (m *StandardEncryptionManager) NewFileKey() (FileKey, error) {
+	dek := make([]byte, m.dataKeyLength)
+	if err := randomBytes(dek); err != nil {
+		return FileKey{}, err
+	}
+
+	aadPrefix := make([]byte, DefaultAADPrefixLength)
+	if err := randomBytes(aadPrefix); err != nil {
+		return FileKey{}, err
+	}
+
+	keyMetadata, err := KeyMetadata{EncryptionKey: dek, AADPrefix: aadPrefix}.Encode()
+	if err != nil {
+		return FileKey{}, err
+	}
+
+	return FileKey{Key: dek, AADPrefix: aadPrefix, Metadata: keyMetadata}, nil
+}
+
+// WrapKeyMetadata encrypts the key metadata of a manifest list with the
+// table's KEK, so that it can be stored in the table metadata.
+func (m *StandardEncryptionManager) WrapKeyMetadata(ctx context.Context, keyMetadata []byte) ([]byte, error) {
+	wrapped, err := m.kms.WrapKey(ctx, keyMetadata, m.tableKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap key metadata: %w", err)
+	}
+
+	return wrapped, nil
+}
+
+// UnwrapKeyMetadata decrypts key metadata wrapped by WrapKeyMetadata with
+// the KEK identified by wrappingKeyID.
+func UnwrapKeyMetadata(ctx context.Context, kms KeyManagementClient, wrapped []byte, wrappingKeyID string) ([]byte, error) {
+	keyMetadata, err := kms.UnwrapKey(ctx, wrapped, wrappingKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap key metadata: %w", err)
+	}
+
+	return keyMetadata, nil
+}
+
+// FileKeyFromMetadata returns the key material stored in the key metadata
+// of a file.
+func FileKeyFromMetadata(keyMetadata []byte) (FileKey, error) {
+	km, err := DecodeKeyMetadata(keyMetadata)
+	if err != nil {
+		return FileKey{}, err
+	}
+
+	if err := validateKeyLength(len(km.EncryptionKey)); err != nil {
+		return FileKey{}, err
+	}
+
+	return FileKey{Key: km.EncryptionKey, AADPrefix: km.AADPrefix, Metadata: keyMetadata}, nil
+}
+
+// DecryptStream returns a reader of the plaintext of the AES GCM stream of
+// the given size read from src, using the key stored in keyMetadata.
+func DecryptStream(src io.ReaderAt, size int64, keyMetadata []byte) (*StreamReader, error) {
+	km, err := DecodeKeyMetadata(keyMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	if km.FileLength != nil && *km.FileLength != size {
+		return nil, fmt.Errorf("%w: expected file length %d, got %d",
+			ErrInvalidCipher, *km.FileLength, size)
+	}
+
+	return NewStreamReader(src, size, km.EncryptionKey, km.AADPrefix)
+}
@@ -0,0 +1,127 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"maps"
+	"sync"
+)
+
+// InMemoryKMS is a KeyManagementClient which keeps its key-encryption keys
+// in memory. It is intended for tests and local development only, as the
+// master keys are not protected in any way.
+type InMemoryKMS struct {
+	mx   sync.RWMutex
+	keys map[string][]byte
+}
+
+// NewInMemoryKMS returns a KMS using the provided key-encryption keys,
+// indexed by key ID. Every key must be a valid AES key length.
+func NewInMemoryKMS(keys map[string][]byte) (*InMemoryKMS, error) {
+	for id, k := range keys {
+		if err := validateKeyLength(len(k)); err != nil {
+			return nil, fmt.Errorf("%w: key %q", err, id)
+		}
+	}
+
+	return &InMemoryKMS{keys: maps.Clone(keys)}, nil
+}
+
+// AddKey generates a new random key-encryption key with the given ID.
+func (m *InMemoryKMS) AddKey(keyID string, length int) error {
+	if err := validateKeyLength(length); err != nil {
+		return err
+	}
+
+	key := make([]byte, length)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	if m.keys == nil {
+		m.keys = make(map[string][]byte)
+	}
+	m.keys[keyID] = key
+
+	return nil
+}
+
+func (m *InMemoryKMS) gcm(keyID string) (cipher.AEAD, error) {
+	m.mx.RLock()
+	key, ok := m.keys[keyID]
+	m.mx.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key id %q", ErrInvalidKey, keyID)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func (m *InMemoryKMS) WrapKey(_ context.Context, key []byte, wrappingKeyID string) ([]byte, error) {
+	gcm, err := m.gcm(wrappingKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, key, []byte(wrappingKeyID)), nil
+}
+
+func (m *InMemoryKMS) UnwrapKey(_ context.Context, wrappedKey []byte, wrappingKeyID string) ([]byte, error) {
+	gcm, err := m.gcm(wrappingKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(wrappedKey) < gcm.NonceSize()+gcm.Overhead() {
+		return nil, fmt.Errorf("%w: wrapped key too short", ErrInvalidCipher)
+	}
+
+	nonce, ciphertext := wrappedKey[:gcm.NonceSize()], wrappedKey[gcm.NonceSize():]
+	key, err := gcm.Open(nil, nonce, ciphertext, []byte(wrappingKeyID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCipher, err)
+	}
+
+	return key, nil
+}
+
+func validateKeyLength(n int) error {
+	switch n {
+	case 16, 24, 32:
+		return nil
+	default:
+		return fmt.Errorf("%w: key length must be 16, 24 or 32 bytes, got %d", ErrInvalidKey, n)
+	}
+}
@@ -0,0 +1,371 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// The AES GCM stream (AGS1) format, used by the spec for files without
+// native encryption such as Avro manifests, splits the plaintext into
+// fixed size blocks which are encrypted independently. This allows random
+// access reads without decrypting the whole file.
+//
+// A stream consists of a header with the magic bytes "AGS1" and the plain
+// block size as a little-endian int32, followed by the encrypted blocks.
+// Each block is stored as nonce | ciphertext | tag, and is authenticated
+// with the file AAD prefix followed by the block index as a little-endian
+// int32, which prevents blocks from being reordered or swapped between
+// files.
+const (
+	streamMagic             = "AGS1"
+	streamHeaderLength      = len(streamMagic) + 4
+	gcmNonceLength          = 12
+	gcmTagLength            = 16
+	blockOverhead           = gcmNonceLength + gcmTagLength
+	DefaultPlainBlockSize   = 1024 * 1024
+	DefaultAADPrefixLength  = 16
+	maxPlainBlockSizeFactor = 16
+)
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if err := validateKeyLength(len(key)); err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCMWithNonceSize(block, gcmNonceLength)
+}
+
+func blockAAD(prefix []byte, index int32) []byte {
+	aad := make([]byte, len(prefix)+4)
+	copy(aad, prefix)
+	binary.LittleEndian.PutUint32(aad[len(prefix):], uint32(index))
+
+	return aad
+}
+
+// EncryptedLength returns the length of the AGS1 stream produced for a
+// plaintext of the given length.
+func EncryptedLength(plainLength int64, plainBlockSize int) int64 {
+	blocks := plainLength / int64(plainBlockSize)
+	if plainLength%int64(plainBlockSize) != 0 {
+		blocks++
+	}
+
+	return int64(streamHeaderLength) + plainLength + blocks*blockOverhead
+}
+
+// StreamWriter encrypts everything written to it into the AGS1 format.
+// Close must be called to flush the final block; it does not close the
+// underlying writer.
+type StreamWriter struct {
+	w         io.Writer
+	gcm       cipher.AEAD
+	aadPrefix []byte
+
+	plain      []byte
+	out        []byte
+	blockIndex int32
+	header     bool
+	closed     bool
+}
+
+// NewStreamWriter returns a StreamWriter which encrypts to w using the
+// given AES key and file AAD prefix and the default block size.
+func NewStreamWriter(w io.Writer, key, aadPrefix []byte) (*StreamWriter, error) {
+	return NewStreamWriterSize(w, key, aadPrefix, DefaultPlainBlockSize)
+}
+
+// NewStreamWriterSize is like NewStreamWriter but uses the given plain
+// block size.
+func NewStreamWriterSize(w io.Writer, key, aadPrefix []byte, plainBlockSize int) (*StreamWriter, error) {
+	if plainBlockSize <= 0 {
+		return nil, fmt.Errorf("%w: invalid plain block size %d", ErrInvalidCipher, plainBlockSize)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StreamWriter{
+		w:         w,
+		gcm:       gcm,
+		aadPrefix: aadPrefix,
+		plain:     make([]byte, 0, plainBlockSize),
+		out:       make([]byte, 0, plainBlockSize+blockOverhead),
+	}, nil
+}
+
+func (s *StreamWriter) writeHeader() error {
+	if s.header {
+		return nil
+	}
+
+	var hdr [streamHeaderLength]byte
+	copy(hdr[:], streamMagic)
+	binary.LittleEndian.PutUint32(hdr[len(streamMagic):], uint32(cap(s.plain)))
+	if _, err := s.w.Write(hdr[:]); err != nil {
+		return err
+	}
+	s.header = true
+
+	return nil
+}
+
+func (s *StreamWriter) flushBlock() error {
+	nonce := s.out[:gcmNonceLength]
+	if err := randomBytes(nonce); err != nil {
+		return err
+	}
+
+	sealed := s.gcm.Seal(nonce, nonce, s.plain, blockAAD(s.aadPrefix, s.blockIndex))
+	if _, err := s.w.Write(sealed); err != nil {
+		return err
+	}
+
+	s.blockIndex++
+	s.plain = s.plain[:0]
+
+	return nil
+}
+
+func (s *StreamWriter) Write(p []byte) (int, error) {
+	if s.closed {
+		return 0, errors.New("encryption: write to closed stream")
+	}
+
+	if err := s.writeHeader(); err != nil {
+		return 0, err
+	}
+
+	written := 0
+	for len(p) > 0 {
+		n := copy(s.plain[len(s.plain):cap(s.plain)], p)
+		s.plain = s.plain[:len(s.plain)+n]
+		p, written = p[n:], written+n
+
+		if len(s.plain) == cap(s.plain) {
+			if err := s.flushBlock(); err != nil {
+				return written, err
+			}
+		}
+	}
+
+	return written, nil
+}
+
+// Close encrypts and writes any buffered data. It is safe to call Close
+// more than once.
+func (s *StreamWriter) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	if err := s.writeHeader(); err != nil {
+		return err
+	}
+
+	if len(s.plain) > 0 {
+		return s.flushBlock()
+	}
+
+	return nil
+}
+
+// StreamReader provides random access to the plaintext of an AGS1 stream.
+// It is safe for concurrent use through ReadAt.
+type StreamReader struct {
+	src       io.ReaderAt
+	gcm       cipher.AEAD
+	aadPrefix []byte
+
+	plainBlockSize  int64
+	cipherBlockSize int64
+	numBlocks       int64
+	plainSize       int64
+
+	mx        sync.Mutex
+	pos       int64
+	lastIndex int64
+	lastBlock []byte
+}
+
+// NewStreamReader returns a reader of the plaintext of the AGS1 stream of
+// the given size read from src.
+func NewStreamReader(src io.ReaderAt, size int64, key, aadPrefix []byte) (*StreamReader, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if size < int64(streamHeaderLength) {
+		return nil, fmt.Errorf("%w: stream too short", ErrInvalidCipher)
+	}
+
+	var hdr [streamHeaderLength]byte
+	if _, err := src.ReadAt(hdr[:], 0); err != nil {
+		return nil, err
+	}
+
+	if string(hdr[:len(streamMagic)]) != streamMagic {
+		return nil, fmt.Errorf("%w: invalid magic %q", ErrInvalidCipher, hdr[:len(streamMagic)])
+	}
+
+	plainBlockSize := int64(binary.LittleEndian.Uint32(hdr[len(streamMagic):]))
+	if plainBlockSize <= 0 || plainBlockSize > maxPlainBlockSizeFactor*DefaultPlainBlockSize {
+		return nil, fmt.Errorf("%w: invalid plain block size %d", ErrInvalidCipher, plainBlockSize)
+	}
+
+	cipherBlockSize := plainBlockSize + blockOverhead
+	streamLen := size - int64(streamHeaderLength)
+	numBlocks, rem := streamLen/cipherBlockSize, streamLen%cipherBlockSize
+	plainSize := numBlocks * plainBlockSize
+	if rem > 0 {
+		if rem <= blockOverhead {
+			return nil, fmt.Errorf("%w: truncated final block", ErrInvalidCipher)
+		}
+		numBlocks++
+		plainSize += rem - blockOverhead
+	}
+
+	return &StreamReader{
+		src:             src,
+		gcm:             gcm,
+		aadPrefix:       aadPrefix,
+		plainBlockSize:  plainBlockSize,
+		cipherBlockSize: cipherBlockSize,
+		numBlocks:       numBlocks,
+		plainSize:       plainSize,
+		lastIndex:       -1,
+	}, nil
+}
+
+// Size returns the length of the plaintext.
+func (s *StreamReader) Size() int64 { return s.plainSize }
+
+func (s *StreamReader) block(index int64) ([]byte, error) {
+	s.mx.Lock()
+	if index == s.lastIndex {
+		b := s.lastBlock
+		s.mx.Unlock()
+
+		return b, nil
+	}
+	s.mx.Unlock()
+
+	offset := int64(streamHeaderLength) + index*s.cipherBlockSize
+	length := s.cipherBlockSize
+	if index == s.numBlocks-1 {
+		length = s.plainSize - index*s.plainBlockSize + blockOverhead
+	}
+
+	buf := make([]byte, length)
+	if _, err := s.src.ReadAt(buf, offset); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	nonce, sealed := buf[:gcmNonceLength], buf[gcmNonceLength:]
+	plain, err := s.gcm.Open(sealed[:0], nonce, sealed, blockAAD(s.aadPrefix, int32(index)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: block %d: %w", ErrInvalidCipher, index, err)
+	}
+
+	s.mx.Lock()
+	s.lastIndex, s.lastBlock = index, plain
+	s.mx.Unlock()
+
+	return plain, nil
+}
+
+func (s *StreamReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("encryption: negative offset %d", off)
+	}
+
+	n := 0
+	for n < len(p) {
+		if off >= s.plainSize {
+			return n, io.EOF
+		}
+
+		index := off / s.plainBlockSize
+		block, err := s.block(index)
+		if err != nil {
+			return n, err
+		}
+
+		copied := copy(p[n:], block[off-index*s.plainBlockSize:])
+		n += copied
+		off += int64(copied)
+	}
+
+	return n, nil
+}
+
+func (s *StreamReader) Read(p []byte) (int, error) {
+	s.mx.Lock()
+	pos := s.pos
+	s.mx.Unlock()
+
+	n, err := s.ReadAt(p, pos)
+
+	s.mx.Lock()
+	s.pos += int64(n)
+	s.mx.Unlock()
+
+	if n > 0 && errors.Is(err, io.EOF) {
+		err = nil
+	}
+
+	return n, err
+}
+
+func (s *StreamReader) Seek(offset int64, whence int) (int64, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += s.pos
+	case io.SeekEnd:
+		offset += s.plainSize
+	default:
+		return 0, fmt.Errorf("encryption: invalid whence %d", whence)
+	}
+
+	if offset < 0 {
+		return 0, fmt.Errorf("encryption: negative position %d", offset)
+	}
+	s.pos = offset
+
+	return offset, nil
+}
@@ -20,6 +20,9 @@ package iceberg
 import (
 	"errors"
 	"fmt"
+	"strings"
+
+	"github.com/apache/iceberg-go/io"
 )
 
 var (
@@ -36,3 +39,62 @@ var (
 	ErrInvalidBinSerialization = errors.New("invalid binary serialization")
 	ErrResolve                 = errors.New("cannot resolve type")
 )
+
+// Errors shared by catalogs, tables and IO so that callers can decide
+// whether to retry or alert with errors.Is instead of matching on the
+// error text. Catalog and table packages re-export the ones that belong
+// to them, e.g. catalog.ErrNoSuchTable and table.ErrCommitConflict.
+var (
+	// ErrCommitConflict means the commit was rejected because the table
+	// changed concurrently. Refreshing the table and retrying the
+	// operation may succeed.
+	ErrCommitConflict = errors.New("commit conflicts with concurrent changes")
+	// ErrCommitStateUnknown means the catalog failed in a way that leaves
+	// it unknown whether the commit was applied. It must not be retried
+	// blindly, and files written for the commit must not be deleted.
+	ErrCommitStateUnknown = errors.New("commit state unknown")
+	ErrNoSuchTable        = errors.New("table does not exist")
+	// ErrValidation means an operation was rejected because its input is
+	// invalid. Retrying it unchanged will fail again.
+	ErrValidation = errors.New("validation failed")
+	// ErrCleanupFailed means some of the files an operation should have
+	// deleted afterwards, or after failing, could not be removed. Errors
+	// wrapping it are *CleanupError, which lists the files.
+	ErrCleanupFailed = errors.New("cleanup failed")
+)
+
+// CleanupError is returned when files could not be deleted after an
+// operation, either to clean up after it succeeded or to discard the
+// files it wrote after it failed. It matches ErrCleanupFailed with
+// errors.Is and also unwraps to the underlying delete errors.
+type CleanupError struct {
+	// Files are the paths that could not be deleted.
+	Files []string
+	Err   error
+}
+
+func (e *CleanupError) Error() string {
+	msg := fmt.Sprintf("%s: could not delete %d file(s): %s",
+		ErrCleanupFailed, len(e.Files), strings.Join(e.Files, ", "))
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+
+	return msg
+}
+
+func (e *CleanupError) Unwrap() []error {
+	return []error{ErrCleanupFailed, e.Err}
+}
+
+// IsRetryable reports whether repeating the failed operation may
+// succeed: a commit conflict, after refreshing the table, or a transient
+// object store failure as reported by io.IsRetryable. Commits whose
+// state is unknown are never retryable.
+func IsRetryable(err error) bool {
+	if errors.Is(err, ErrCommitStateUnknown) {
+		return false
+	}
+
+	return errors.Is(err, ErrCommitConflict) || io.IsRetryable(err)
+}
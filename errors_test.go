@@ -0,0 +1,64 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCleanupError(t *testing.T) {
+	removeErr := errors.New("permission denied")
+	var err error = &iceberg.CleanupError{
+		Files: []string{"s3://bucket/a.avro", "s3://bucket/b.parquet"},
+		Err:   removeErr,
+	}
+	err = fmt.Errorf("expire snapshots: %w", err)
+
+	assert.ErrorIs(t, err, iceberg.ErrCleanupFailed)
+	assert.ErrorIs(t, err, removeErr)
+	assert.False(t, iceberg.IsRetryable(err))
+
+	var cleanupErr *iceberg.CleanupError
+	assert.ErrorAs(t, err, &cleanupErr)
+	assert.Equal(t, []string{"s3://bucket/a.avro", "s3://bucket/b.parquet"}, cleanupErr.Files)
+	assert.ErrorContains(t, err, "could not delete 2 file(s)")
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err       error
+		retryable bool
+	}{
+		{nil, false},
+		{errors.New("boom"), false},
+		{fmt.Errorf("%w: requirement failed", iceberg.ErrCommitConflict), true},
+		{fmt.Errorf("%w: %w", iceberg.ErrCommitConflict, iceberg.ErrCommitStateUnknown), false},
+		{iceberg.ErrCommitStateUnknown, false},
+		{fmt.Errorf("%w: duplicate files", iceberg.ErrValidation), false},
+		{iceberg.ErrNoSuchTable, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.retryable, iceberg.IsRetryable(tt.err), "%v", tt.err)
+	}
+}
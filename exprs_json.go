@@ -0,0 +1,347 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var opJSONNames = map[Operation]string{
+	OpTrue:          "true",
+	OpFalse:         "false",
+	OpIsNull:        "is-null",
+	OpNotNull:       "not-null",
+	OpIsNan:         "is-nan",
+	OpNotNan:        "not-nan",
+	OpLT:            "lt",
+	OpLTEQ:          "lt-eq",
+	OpGT:            "gt",
+	OpGTEQ:          "gt-eq",
+	OpEQ:            "eq",
+	OpNEQ:           "not-eq",
+	OpStartsWith:    "starts-with",
+	OpNotStartsWith: "not-starts-with",
+	OpIn:            "in",
+	OpNotIn:         "not-in",
+	OpNot:           "not",
+	OpAnd:           "and",
+	OpOr:            "or",
+}
+
+var jsonNameToOp = func() map[string]Operation {
+	out := make(map[string]Operation, len(opJSONNames))
+	for op, name := range opJSONNames {
+		out[name] = op
+	}
+
+	return out
+}()
+
+type expressionJSON struct {
+	Type   string            `json:"type"`
+	Term   string            `json:"term,omitempty"`
+	Value  json.RawMessage   `json:"value,omitempty"`
+	Values []json.RawMessage `json:"values,omitempty"`
+	Left   *expressionJSON   `json:"left,omitempty"`
+	Right  *expressionJSON   `json:"right,omitempty"`
+	Child  *expressionJSON   `json:"child,omitempty"`
+}
+
+// MarshalExpression serializes a boolean expression to the JSON
+// representation used by the REST catalog specification, for example:
+//
+//	{"type": "and",
+//	 "left": {"type": "gt-eq", "term": "id", "value": 10},
+//	 "right": {"type": "is-null", "term": "data"}}
+//
+// Bound predicates are written using the full column name of their
+// field, which is looked up in schema. Unbound expressions may be
+// marshalled with a nil schema.
+//
+// Date, time and timestamp literals are written as their integer
+// representation (days or microseconds since the epoch) and decimal and
+// UUID literals as strings, all of which convert back to the original
+// value when the expression is bound. Binary and fixed literals cannot
+// be serialized.
+func MarshalExpression(expr BooleanExpression, schema *Schema) ([]byte, error) {
+	out, err := expressionToJSON(expr, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(out)
+}
+
+// UnmarshalExpression parses an expression in the format written by
+// MarshalExpression. The result is unbound. Literal values are parsed as
+// strings, booleans, 64-bit integers or doubles and converted to the
+// type of the referenced column when the expression is bound.
+func UnmarshalExpression(data []byte) (BooleanExpression, error) {
+	data = bytes.TrimSpace(data)
+	// the specification also allows the constants as plain booleans
+	switch string(data) {
+	case "true":
+		return AlwaysTrue{}, nil
+	case "false":
+		return AlwaysFalse{}, nil
+	}
+
+	var in expressionJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return nil, fmt.Errorf("%w: invalid expression json: %s", ErrInvalidArgument, err)
+	}
+
+	return expressionFromJSON(&in)
+}
+
+func expressionToJSON(expr BooleanExpression, schema *Schema) (*expressionJSON, error) {
+	name, ok := opJSONNames[expr.Op()]
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot serialize expression %s", ErrInvalidArgument, expr)
+	}
+	out := &expressionJSON{Type: name}
+
+	var err error
+	switch e := expr.(type) {
+	case AlwaysTrue, AlwaysFalse:
+		return out, nil
+	case NotExpr:
+		out.Child, err = expressionToJSON(e.child, schema)
+	case AndExpr:
+		if out.Left, err = expressionToJSON(e.left, schema); err == nil {
+			out.Right, err = expressionToJSON(e.right, schema)
+		}
+	case OrExpr:
+		if out.Left, err = expressionToJSON(e.left, schema); err == nil {
+			out.Right, err = expressionToJSON(e.right, schema)
+		}
+	case *unboundUnaryPredicate:
+		out.Term, err = unboundTermName(e.term)
+	case *unboundLiteralPredicate:
+		if out.Term, err = unboundTermName(e.term); err == nil {
+			out.Value, err = literalToJSON(e.lit)
+		}
+	case *unboundSetPredicate:
+		if out.Term, err = unboundTermName(e.term); err == nil {
+			out.Values, err = literalsToJSON(e.lits.Members())
+		}
+	case BoundPredicate:
+		if out.Term, err = boundTermName(e.Ref(), schema); err != nil {
+			break
+		}
+
+		switch p := e.(type) {
+		case BoundLiteralPredicate:
+			out.Value, err = literalToJSON(p.Literal())
+		case BoundSetPredicate:
+			out.Values, err = literalsToJSON(p.Literals().Members())
+		}
+	default:
+		err = fmt.Errorf("%w: cannot serialize expression %s", ErrInvalidArgument, expr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func unboundTermName(t UnboundTerm) (string, error) {
+	ref, ok := t.(Reference)
+	if !ok {
+		return "", fmt.Errorf("%w: cannot serialize term %s", ErrNotImplemented, t)
+	}
+
+	return string(ref), nil
+}
+
+func boundTermName(ref BoundReference, schema *Schema) (string, error) {
+	if schema == nil {
+		return "", fmt.Errorf("%w: a schema is required to serialize bound expressions",
+			ErrInvalidArgument)
+	}
+
+	name, ok := schema.FindColumnName(ref.Field().ID)
+	if !ok {
+		return "", fmt.Errorf("%w: field %d not found in schema", ErrInvalidSchema, ref.Field().ID)
+	}
+
+	return name, nil
+}
+
+func literalsToJSON(lits []Literal) ([]json.RawMessage, error) {
+	out := make([]json.RawMessage, len(lits))
+	for i, l := range lits {
+		v, err := literalToJSON(l)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = v
+	}
+
+	return out, nil
+}
+
+func literalToJSON(lit Literal) (json.RawMessage, error) {
+	var v any
+	switch l := lit.(type) {
+	case BoolLiteral:
+		v = bool(l)
+	case Int32Literal:
+		v = int64(l)
+	case Int64Literal:
+		v = int64(l)
+	case Float32Literal:
+		if math.IsNaN(float64(l)) || math.IsInf(float64(l), 0) {
+			return nil, fmt.Errorf("%w: cannot serialize %s as json", ErrInvalidArgument, l)
+		}
+
+		return json.RawMessage(strconv.FormatFloat(float64(l), 'g', -1, 32)), nil
+	case Float64Literal:
+		if math.IsNaN(float64(l)) || math.IsInf(float64(l), 0) {
+			return nil, fmt.Errorf("%w: cannot serialize %s as json", ErrInvalidArgument, l)
+		}
+		v = float64(l)
+	case DateLiteral:
+		v = int64(l)
+	case TimeLiteral:
+		v = int64(l)
+	case TimestampLiteral:
+		v = int64(l)
+	case TimestampNsLiteral:
+		v = int64(l)
+	case StringLiteral:
+		v = string(l)
+	case DecimalLiteral, UUIDLiteral:
+		v = l.String()
+	default:
+		return nil, fmt.Errorf("%w: cannot serialize %s literal %s as json",
+			ErrNotImplemented, lit.Type(), lit)
+	}
+
+	return json.Marshal(v)
+}
+
+func literalFromJSON(data json.RawMessage) (Literal, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("%w: invalid literal %s", ErrInvalidArgument, data)
+	}
+
+	switch v := v.(type) {
+	case bool:
+		return NewLiteral(v), nil
+	case string:
+		return NewLiteral(v), nil
+	case json.Number:
+		if !strings.ContainsAny(v.String(), ".eE") {
+			if n, err := v.Int64(); err == nil {
+				return NewLiteral(n), nil
+			}
+		}
+
+		f, err := v.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid numeric literal %s", ErrInvalidArgument, v)
+		}
+
+		return NewLiteral(f), nil
+	}
+
+	return nil, fmt.Errorf("%w: unsupported literal %s", ErrInvalidArgument, data)
+}
+
+func expressionFromJSON(in *expressionJSON) (BooleanExpression, error) {
+	if in == nil {
+		return nil, fmt.Errorf("%w: missing expression", ErrInvalidArgument)
+	}
+
+	op, ok := jsonNameToOp[in.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown expression type %q", ErrInvalidArgument, in.Type)
+	}
+
+	switch op {
+	case OpTrue:
+		return AlwaysTrue{}, nil
+	case OpFalse:
+		return AlwaysFalse{}, nil
+	case OpNot:
+		child, err := expressionFromJSON(in.Child)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewNot(child), nil
+	case OpAnd, OpOr:
+		left, err := expressionFromJSON(in.Left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := expressionFromJSON(in.Right)
+		if err != nil {
+			return nil, err
+		}
+
+		if op == OpAnd {
+			return NewAnd(left, right), nil
+		}
+
+		return NewOr(left, right), nil
+	}
+
+	if in.Term == "" {
+		return nil, fmt.Errorf("%w: %s expression requires a term", ErrInvalidArgument, in.Type)
+	}
+	ref := Reference(in.Term)
+
+	switch op {
+	case OpIsNull, OpNotNull, OpIsNan, OpNotNan:
+		return UnaryPredicate(op, ref), nil
+	case OpIn, OpNotIn:
+		lits := make([]Literal, len(in.Values))
+		for i, v := range in.Values {
+			lit, err := literalFromJSON(v)
+			if err != nil {
+				return nil, err
+			}
+			lits[i] = lit
+		}
+
+		return SetPredicate(op, ref, lits), nil
+	}
+
+	if in.Value == nil {
+		return nil, fmt.Errorf("%w: %s expression requires a value", ErrInvalidArgument, in.Type)
+	}
+
+	lit, err := literalFromJSON(in.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return LiteralPredicate(op, ref, lit), nil
+}
@@ -0,0 +1,138 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg_test
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/iceberg-go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpressionJSONRoundTrip(t *testing.T) {
+	ref := func(name string) iceberg.Reference { return iceberg.Reference(name) }
+
+	tests := []struct {
+		name string
+		expr iceberg.BooleanExpression
+		json string
+	}{
+		{"true", iceberg.AlwaysTrue{}, `{"type":"true"}`},
+		{"false", iceberg.AlwaysFalse{}, `{"type":"false"}`},
+		{"is-null", iceberg.IsNull(ref("a")), `{"type":"is-null","term":"a"}`},
+		{"not-nan", iceberg.NotNaN(ref("f")), `{"type":"not-nan","term":"f"}`},
+		{"eq", iceberg.EqualTo(ref("a"), int64(5)), `{"type":"eq","term":"a","value":5}`},
+		{"lt-eq", iceberg.LessThanEqual(ref("f"), 1.5), `{"type":"lt-eq","term":"f","value":1.5}`},
+		{"starts-with", iceberg.StartsWith(ref("s"), "abc"), `{"type":"starts-with","term":"s","value":"abc"}`},
+		{"bool", iceberg.NotEqualTo(ref("b"), true), `{"type":"not-eq","term":"b","value":true}`},
+		{
+			"in", iceberg.IsIn(ref("a"), int64(1), int64(2)),
+			`{"type":"in","term":"a","values":[1,2]}`,
+		},
+		{
+			"and/or/not",
+			iceberg.NewAnd(
+				iceberg.GreaterThanEqual(ref("a"), int64(10)),
+				iceberg.NewOr(iceberg.NotNull(ref("b")), iceberg.NewNot(iceberg.EqualTo(ref("c"), "x")))),
+			`{"type":"and","left":{"type":"gt-eq","term":"a","value":10},` +
+				`"right":{"type":"or","left":{"type":"not-null","term":"b"},` +
+				`"right":{"type":"not","child":{"type":"eq","term":"c","value":"x"}}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := iceberg.MarshalExpression(tt.expr, nil)
+			require.NoError(t, err)
+			if tt.name == "in" {
+				// set members are unordered
+				assert.Contains(t, []string{tt.json, `{"type":"in","term":"a","values":[2,1]}`}, string(data))
+			} else {
+				assert.JSONEq(t, tt.json, string(data))
+			}
+
+			parsed, err := iceberg.UnmarshalExpression(data)
+			require.NoError(t, err)
+			assert.True(t, tt.expr.Equals(parsed), "expected %s, got %s", tt.expr, parsed)
+		})
+	}
+}
+
+func TestExpressionJSONBound(t *testing.T) {
+	sc := iceberg.NewSchema(1,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int32, Required: true},
+		iceberg.NestedField{ID: 2, Name: "ts", Type: iceberg.PrimitiveTypes.TimestampTz},
+		iceberg.NestedField{ID: 3, Name: "day", Type: iceberg.PrimitiveTypes.Date},
+		iceberg.NestedField{ID: 4, Name: "price", Type: iceberg.DecimalTypeOf(9, 2)},
+		iceberg.NestedField{ID: 5, Name: "uid", Type: iceberg.PrimitiveTypes.UUID},
+		iceberg.NestedField{ID: 6, Name: "loc", Type: &iceberg.StructType{FieldList: []iceberg.NestedField{
+			{ID: 7, Name: "lat", Type: iceberg.PrimitiveTypes.Float32},
+		}}},
+		iceberg.NestedField{ID: 8, Name: "bin", Type: iceberg.PrimitiveTypes.Binary})
+
+	expr := iceberg.NewAnd(
+		iceberg.IsIn(iceberg.Reference("id"), int32(1), int32(2), int32(3)),
+		iceberg.GreaterThan(iceberg.Reference("ts"), iceberg.Timestamp(1700000000123456)),
+		iceberg.LessThan(iceberg.Reference("day"), iceberg.Date(19000)),
+		iceberg.EqualTo(iceberg.Reference("price"), iceberg.Decimal{Val: decimal128.FromI64(1234), Scale: 2}),
+		iceberg.EqualTo(iceberg.Reference("uid"), uuid.MustParse("f79c3e09-677c-4bbd-a479-3f349cb785e7")),
+		iceberg.GreaterThan(iceberg.Reference("loc.lat"), float32(1.25)),
+	)
+
+	bound, err := iceberg.BindExpr(sc, expr, true)
+	require.NoError(t, err)
+
+	_, err = iceberg.MarshalExpression(bound, nil)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+	data, err := iceberg.MarshalExpression(bound, sc)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"term":"loc.lat"`)
+	assert.Contains(t, string(data), `"value":"12.34"`)
+
+	parsed, err := iceberg.UnmarshalExpression(data)
+	require.NoError(t, err)
+
+	rebound, err := iceberg.BindExpr(sc, parsed, true)
+	require.NoError(t, err)
+	assert.True(t, bound.Equals(rebound), "expected %s, got %s", bound, rebound)
+
+	_, err = iceberg.MarshalExpression(iceberg.EqualTo(iceberg.Reference("bin"), []byte("x")), nil)
+	assert.ErrorIs(t, err, iceberg.ErrNotImplemented)
+}
+
+func TestUnmarshalExpressionErrors(t *testing.T) {
+	expr, err := iceberg.UnmarshalExpression([]byte(" true "))
+	require.NoError(t, err)
+	assert.Equal(t, iceberg.AlwaysTrue{}, expr)
+
+	for _, in := range []string{
+		`{"type":"bogus"}`,
+		`{"type":"eq","term":"a"}`,
+		`{"type":"eq","value":1}`,
+		`{"type":"and","left":{"type":"true"}}`,
+		`{"type":"eq","term":"a","value":{"x":1}}`,
+		`not json`,
+	} {
+		_, err := iceberg.UnmarshalExpression([]byte(in))
+		assert.ErrorIs(t, err, iceberg.ErrInvalidArgument, in)
+	}
+}
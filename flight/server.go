@@ -0,0 +1,209 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package flight
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/apache/arrow-go/v18/arrow/flight"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/table"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server is an Arrow Flight service serving scans of the tables in a
+// catalog. Register it with a flight.Server:
+//
+//	srv := flight.NewServerWithMiddleware(nil)
+//	srv.RegisterFlightService(icebergflight.NewServer(cat))
+//	srv.Init("0.0.0.0:8815")
+//	srv.Serve()
+type Server struct {
+	flight.BaseFlightServer
+
+	cat   catalog.Catalog
+	alloc memory.Allocator
+}
+
+// ServerOption configures a Server.
+type ServerOption func(*Server)
+
+// WithAllocator sets the allocator used for serializing schemas.
+func WithAllocator(mem memory.Allocator) ServerOption {
+	return func(s *Server) { s.alloc = mem }
+}
+
+// NewServer returns a Flight service serving the tables of cat.
+func NewServer(cat catalog.Catalog, opts ...ServerOption) *Server {
+	s := &Server{cat: cat, alloc: memory.DefaultAllocator}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// scan loads the table of the ticket and returns the scan it describes.
+// A ticket without a snapshot is pinned to the current snapshot of the
+// table, so that the ticket returned by GetFlightInfo reads the snapshot
+// whose schema was advertised even if the table changes before DoGet.
+func (s *Server) scan(ctx context.Context, ticket *ScanTicket) (*table.Scan, error) {
+	tbl, err := s.cat.LoadTable(ctx, ticket.Table)
+	if err != nil {
+		return nil, err
+	}
+
+	if ticket.SnapshotID == nil {
+		if snap := tbl.CurrentSnapshot(); snap != nil {
+			id := snap.SnapshotID
+			ticket.SnapshotID = &id
+		}
+	}
+
+	// the scanner expects a filter that binds to the table schema, so
+	// reject bad client input here rather than while planning
+	if ticket.Filter != nil {
+		if _, err := iceberg.BindExpr(tbl.Schema(), ticket.Filter, ticket.CaseSensitive); err != nil {
+			return nil, err
+		}
+	}
+
+	return tbl.Scan(ticket.ScanOptions()...), nil
+}
+
+func (s *Server) ticketFromDescriptor(desc *flight.FlightDescriptor) (ScanTicket, error) {
+	switch desc.GetType() {
+	case flight.DescriptorCMD:
+		return ParseScanTicket(desc.GetCmd())
+	case flight.DescriptorPATH:
+		if len(desc.GetPath()) == 0 {
+			return ScanTicket{}, status.Error(codes.InvalidArgument, "descriptor path must name a table")
+		}
+
+		return NewScanTicket(desc.GetPath()), nil
+	}
+
+	return ScanTicket{}, status.Errorf(codes.InvalidArgument, "unsupported descriptor type %s", desc.GetType())
+}
+
+// GetFlightInfo returns the schema of the scan described by desc and a
+// single endpoint whose ticket can be passed to DoGet. The ticket names
+// the snapshot the schema was built from, so DoGet reads that snapshot
+// even if the table is committed to in the meantime.
+func (s *Server) GetFlightInfo(ctx context.Context, desc *flight.FlightDescriptor) (*flight.FlightInfo, error) {
+	ticket, err := s.ticketFromDescriptor(desc)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	scan, err := s.scan(ctx, &ticket)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	// the same schema as the records streamed by DoGet
+	schema, err := scan.ArrowSchema()
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	ticketBytes, err := json.Marshal(ticket)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &flight.FlightInfo{
+		Schema:           flight.SerializeSchema(schema, s.alloc),
+		FlightDescriptor: desc,
+		Endpoint:         []*flight.FlightEndpoint{{Ticket: &flight.Ticket{Ticket: ticketBytes}}},
+		TotalRecords:     -1,
+		TotalBytes:       -1,
+	}, nil
+}
+
+// GetSchema returns the schema of the scan described by desc.
+func (s *Server) GetSchema(ctx context.Context, desc *flight.FlightDescriptor) (*flight.SchemaResult, error) {
+	info, err := s.GetFlightInfo(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &flight.SchemaResult{Schema: info.Schema}, nil
+}
+
+// DoGet executes the scan described by the ticket and streams the
+// resulting record batches.
+func (s *Server) DoGet(tkt *flight.Ticket, stream flight.FlightService_DoGetServer) error {
+	ctx := stream.Context()
+
+	ticket, err := ParseScanTicket(tkt.GetTicket())
+	if err != nil {
+		return toStatus(err)
+	}
+
+	scan, err := s.scan(ctx, &ticket)
+	if err != nil {
+		return toStatus(err)
+	}
+
+	schema, records, err := scan.ToArrowRecords(ctx)
+	if err != nil {
+		return toStatus(err)
+	}
+
+	wr := flight.NewRecordWriter(stream, ipc.WithSchema(schema), ipc.WithAllocator(s.alloc))
+	for rec, err := range records {
+		if err != nil {
+			return errors.Join(toStatus(err), wr.Close())
+		}
+
+		err = wr.Write(rec)
+		rec.Release()
+		if err != nil {
+			return errors.Join(err, wr.Close())
+		}
+	}
+
+	return wr.Close()
+}
+
+// toStatus maps errors to gRPC status codes so that clients can tell
+// bad requests apart from server failures.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, catalog.ErrNoSuchTable), errors.Is(err, catalog.ErrNoSuchNamespace):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, iceberg.ErrInvalidArgument), errors.Is(err, iceberg.ErrInvalidSchema),
+		errors.Is(err, table.ErrInvalidOperation):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
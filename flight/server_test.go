@@ -0,0 +1,276 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package flight_test
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	arrowflight "github.com/apache/arrow-go/v18/arrow/flight"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	"github.com/apache/iceberg-go/catalog/sql"
+	"github.com/apache/iceberg-go/flight"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uptrace/bun/driver/sqliteshim"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func TestScanTicketJSON(t *testing.T) {
+	snap := int64(42)
+	ticket := flight.ScanTicket{
+		Table:          table.Identifier{"db", "events"},
+		SnapshotID:     &snap,
+		Filter:         iceberg.GreaterThanEqual(iceberg.Reference("id"), int64(10)),
+		SelectedFields: []string{"id"},
+		CaseSensitive:  false,
+		Limit:          5,
+	}
+
+	data, err := json.Marshal(ticket)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"table": ["db", "events"], "snapshot-id": 42,
+		"filter": {"type": "gt-eq", "term": "id", "value": 10},
+		"selected-fields": ["id"], "case-sensitive": false, "limit": 5}`, string(data))
+
+	parsed, err := flight.ParseScanTicket(data)
+	require.NoError(t, err)
+	assert.True(t, ticket.Filter.Equals(parsed.Filter))
+	parsed.Filter = ticket.Filter
+	assert.Equal(t, ticket, parsed)
+
+	parsed, err = flight.ParseScanTicket([]byte(`{"table": ["db", "events"]}`))
+	require.NoError(t, err)
+	assert.Equal(t, flight.NewScanTicket(table.Identifier{"db", "events"}), parsed)
+
+	_, err = flight.ParseScanTicket([]byte(`{}`))
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
+
+type FlightServerSuite struct {
+	suite.Suite
+
+	ctx    context.Context
+	cat    catalog.Catalog
+	server arrowflight.Server
+	client arrowflight.Client
+	arrTbl arrow.Table
+}
+
+func (s *FlightServerSuite) SetupSuite() {
+	s.ctx = context.Background()
+	loc := filepath.ToSlash(s.T().TempDir())
+
+	cat, err := catalog.Load(s.ctx, "flight", iceberg.Properties{
+		"uri":          ":memory:",
+		"type":         "sql",
+		sql.DriverKey:  sqliteshim.ShimName,
+		sql.DialectKey: string(sql.SQLite),
+		"warehouse":    "file://" + loc,
+	})
+	s.Require().NoError(err)
+	s.cat = cat
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String})
+
+	s.Require().NoError(cat.CreateNamespace(s.ctx, table.Identifier{"db"}, nil))
+	tbl, err := cat.CreateTable(s.ctx, table.Identifier{"db", "events"}, sc)
+	s.Require().NoError(err)
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, false, false)
+	s.Require().NoError(err)
+	s.arrTbl, err = array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{
+		`[{"id": 1, "data": "a"}, {"id": 2, "data": "b"}, {"id": 3, "data": "c"}]`,
+	})
+	s.Require().NoError(err)
+
+	_, err = tbl.AppendTable(s.ctx, s.arrTbl, 3, nil)
+	s.Require().NoError(err)
+
+	s.server = arrowflight.NewServerWithMiddleware(nil)
+	s.server.RegisterFlightService(flight.NewServer(cat))
+	s.Require().NoError(s.server.Init("localhost:0"))
+	go s.server.Serve()
+
+	s.client, err = arrowflight.NewClientWithMiddleware(s.server.Addr().String(), nil, nil,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	s.Require().NoError(err)
+}
+
+func (s *FlightServerSuite) TearDownSuite() {
+	s.client.Close()
+	s.server.Shutdown()
+	s.arrTbl.Release()
+}
+
+func (s *FlightServerSuite) doGet(ticket []byte) (arrow.Table, error) {
+	stream, err := s.client.DoGet(s.ctx, &arrowflight.Ticket{Ticket: ticket})
+	if err != nil {
+		return nil, err
+	}
+
+	rdr, err := arrowflight.NewRecordReader(stream)
+	if err != nil {
+		return nil, err
+	}
+	defer rdr.Release()
+
+	recs := make([]arrow.RecordBatch, 0)
+	for rdr.Next() {
+		rec := rdr.RecordBatch()
+		rec.Retain()
+		defer rec.Release()
+		recs = append(recs, rec)
+	}
+	if rdr.Err() != nil {
+		return nil, rdr.Err()
+	}
+
+	return array.NewTableFromRecords(rdr.Schema(), recs), nil
+}
+
+func (s *FlightServerSuite) TestDoGetFullTable() {
+	result, err := s.doGet([]byte(`{"table": ["db", "events"]}`))
+	s.Require().NoError(err)
+	defer result.Release()
+
+	s.True(array.TableEqual(s.arrTbl, result), "expected: %s\ngot: %s", s.arrTbl, result)
+}
+
+func (s *FlightServerSuite) TestDoGetFilterAndProjection() {
+	ticket := flight.NewScanTicket(table.Identifier{"db", "events"})
+	ticket.Filter = iceberg.GreaterThan(iceberg.Reference("id"), int64(1))
+	ticket.SelectedFields = []string{"data"}
+
+	data, err := json.Marshal(ticket)
+	s.Require().NoError(err)
+
+	result, err := s.doGet(data)
+	s.Require().NoError(err)
+	defer result.Release()
+
+	s.EqualValues(2, result.NumRows())
+	s.Equal([]string{"data"}, []string{result.Schema().Field(0).Name})
+	s.EqualValues(1, result.NumCols())
+}
+
+func (s *FlightServerSuite) TestGetFlightInfo() {
+	info, err := s.client.GetFlightInfo(s.ctx, &arrowflight.FlightDescriptor{
+		Type: arrowflight.DescriptorPATH,
+		Path: []string{"db", "events"},
+	})
+	s.Require().NoError(err)
+
+	schema, err := arrowflight.DeserializeSchema(info.GetSchema(), memory.DefaultAllocator)
+	s.Require().NoError(err)
+	s.True(schema.Equal(s.arrTbl.Schema()), "got schema %s", schema)
+
+	s.Require().Len(info.GetEndpoint(), 1)
+	result, err := s.doGet(info.GetEndpoint()[0].GetTicket().GetTicket())
+	s.Require().NoError(err)
+	defer result.Release()
+	s.EqualValues(3, result.NumRows())
+	s.True(schema.Equal(result.Schema()), "DoGet streamed schema %s", result.Schema())
+
+	info, err = s.client.GetFlightInfo(s.ctx, &arrowflight.FlightDescriptor{
+		Type: arrowflight.DescriptorCMD,
+		Cmd:  []byte(`{"table": ["db", "events"], "selected-fields": ["id"]}`),
+	})
+	s.Require().NoError(err)
+	schema, err = arrowflight.DeserializeSchema(info.GetSchema(), memory.DefaultAllocator)
+	s.Require().NoError(err)
+	s.Equal(1, schema.NumFields())
+	s.Equal("id", schema.Field(0).Name)
+}
+
+func (s *FlightServerSuite) TestGetFlightInfoPinsSnapshot() {
+	ident := table.Identifier{"db", "pinned"}
+	tbl, err := s.cat.CreateTable(s.ctx, ident, iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String}))
+	s.Require().NoError(err)
+
+	tbl, err = tbl.AppendTable(s.ctx, s.arrTbl, 3, nil)
+	s.Require().NoError(err)
+
+	info, err := s.client.GetFlightInfo(s.ctx, &arrowflight.FlightDescriptor{
+		Type: arrowflight.DescriptorPATH,
+		Path: ident,
+	})
+	s.Require().NoError(err)
+	schema, err := arrowflight.DeserializeSchema(info.GetSchema(), memory.DefaultAllocator)
+	s.Require().NoError(err)
+
+	ticket, err := flight.ParseScanTicket(info.GetEndpoint()[0].GetTicket().GetTicket())
+	s.Require().NoError(err)
+	s.Require().NotNil(ticket.SnapshotID)
+	s.Equal(tbl.CurrentSnapshot().SnapshotID, *ticket.SnapshotID)
+
+	// add a column and more rows before the client calls DoGet
+	txn := tbl.NewTransaction()
+	s.Require().NoError(txn.UpdateSchema(true, false).
+		AddColumn([]string{"note"}, iceberg.PrimitiveTypes.String, "", false, nil).
+		Commit())
+	tbl, err = txn.Commit(s.ctx)
+	s.Require().NoError(err)
+
+	arrSchema, err := table.SchemaToArrowSchema(tbl.Schema(), nil, false, false)
+	s.Require().NoError(err)
+	more, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{
+		`[{"id": 4, "data": "d", "note": "new"}]`,
+	})
+	s.Require().NoError(err)
+	defer more.Release()
+	_, err = tbl.AppendTable(s.ctx, more, 1, nil)
+	s.Require().NoError(err)
+
+	result, err := s.doGet(info.GetEndpoint()[0].GetTicket().GetTicket())
+	s.Require().NoError(err)
+	defer result.Release()
+	s.EqualValues(3, result.NumRows())
+	s.True(schema.Equal(result.Schema()), "DoGet streamed schema %s, advertised %s",
+		result.Schema(), schema)
+}
+
+func (s *FlightServerSuite) TestErrors() {
+	_, err := s.doGet([]byte(`{"table": ["db", "missing"]}`))
+	s.Equal(codes.NotFound, status.Code(err), err)
+
+	_, err = s.doGet([]byte(`not json`))
+	s.Equal(codes.InvalidArgument, status.Code(err), err)
+
+	_, err = s.doGet([]byte(`{"table": ["db", "events"], "filter": {"type": "eq", "term": "nope", "value": 1}}`))
+	s.Equal(codes.InvalidArgument, status.Code(err), err)
+}
+
+func TestFlightServer(t *testing.T) {
+	suite.Run(t, new(FlightServerSuite))
+}
@@ -0,0 +1,144 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package flight provides an Arrow Flight server that serves Iceberg
+// table scans, allowing any Flight client to read table data.
+//
+// A client requests data with a ticket describing the scan: the table
+// identifier and optionally a snapshot, row filter, projection and
+// limit. Tickets are JSON documents, so non-Go clients can build them
+// directly, for example:
+//
+//	{"table": ["db", "events"],
+//	 "filter": {"type": "gt-eq", "term": "id", "value": 100},
+//	 "selected-fields": ["id", "data"]}
+//
+// GetFlightInfo accepts either a command descriptor containing a ticket
+// or a path descriptor naming the table, and returns the schema of the
+// scan along with the ticket to pass to DoGet.
+package flight
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+)
+
+// ScanTicket describes a table scan to be served by DoGet.
+type ScanTicket struct {
+	Table          table.Identifier
+	SnapshotID     *int64
+	Filter         iceberg.BooleanExpression
+	SelectedFields []string
+	CaseSensitive  bool
+	Limit          int64
+}
+
+type scanTicketJSON struct {
+	Table          []string        `json:"table"`
+	SnapshotID     *int64          `json:"snapshot-id,omitempty"`
+	Filter         json.RawMessage `json:"filter,omitempty"`
+	SelectedFields []string        `json:"selected-fields,omitempty"`
+	CaseSensitive  *bool           `json:"case-sensitive,omitempty"`
+	Limit          int64           `json:"limit,omitempty"`
+}
+
+// NewScanTicket returns a ticket for a case sensitive scan of all the
+// rows and columns of the current snapshot of ident.
+func NewScanTicket(ident table.Identifier) ScanTicket {
+	return ScanTicket{Table: ident, CaseSensitive: true}
+}
+
+func (t ScanTicket) MarshalJSON() ([]byte, error) {
+	out := scanTicketJSON{
+		Table:          t.Table,
+		SnapshotID:     t.SnapshotID,
+		SelectedFields: t.SelectedFields,
+		CaseSensitive:  &t.CaseSensitive,
+		Limit:          t.Limit,
+	}
+
+	if t.Filter != nil {
+		filter, err := iceberg.MarshalExpression(t.Filter, nil)
+		if err != nil {
+			return nil, err
+		}
+		out.Filter = filter
+	}
+
+	return json.Marshal(out)
+}
+
+func (t *ScanTicket) UnmarshalJSON(data []byte) error {
+	var in scanTicketJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	if len(in.Table) == 0 {
+		return fmt.Errorf("%w: scan ticket must name a table", iceberg.ErrInvalidArgument)
+	}
+
+	*t = ScanTicket{
+		Table:          in.Table,
+		SnapshotID:     in.SnapshotID,
+		SelectedFields: in.SelectedFields,
+		CaseSensitive:  in.CaseSensitive == nil || *in.CaseSensitive,
+		Limit:          in.Limit,
+	}
+
+	if len(in.Filter) > 0 {
+		filter, err := iceberg.UnmarshalExpression(in.Filter)
+		if err != nil {
+			return err
+		}
+		t.Filter = filter
+	}
+
+	return nil
+}
+
+// ParseScanTicket parses the ticket bytes sent by a client.
+func ParseScanTicket(data []byte) (ScanTicket, error) {
+	var t ScanTicket
+	if err := json.Unmarshal(data, &t); err != nil {
+		return ScanTicket{}, fmt.Errorf("%w: invalid scan ticket: %w", iceberg.ErrInvalidArgument, err)
+	}
+
+	return t, nil
+}
+
+// ScanOptions returns the scan options described by the ticket.
+func (t ScanTicket) ScanOptions() []table.ScanOption {
+	opts := []table.ScanOption{table.WithCaseSensitive(t.CaseSensitive)}
+	if t.SnapshotID != nil {
+		opts = append(opts, table.WithSnapshotID(*t.SnapshotID))
+	}
+	if t.Filter != nil {
+		opts = append(opts, table.WithRowFilter(t.Filter))
+	}
+	if len(t.SelectedFields) > 0 {
+		opts = append(opts, table.WithSelectedFields(t.SelectedFields...))
+	}
+	if t.Limit > 0 {
+		opts = append(opts, table.WithLimit(t.Limit))
+	}
+
+	return opts
+}
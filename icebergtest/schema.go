@@ -0,0 +1,182 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package icebergtest provides utilities for testing code that works
+// with Iceberg schemas, such as converters to and from other formats.
+package icebergtest
+
+import (
+	"math/rand/v2"
+	"strconv"
+
+	"github.com/apache/iceberg-go"
+)
+
+// DefaultPrimitives are the primitive types used by a SchemaGenerator
+// whose Primitives are not set: every primitive type that the Avro,
+// Arrow and JSON conversions support, plus a few fixed and decimal types.
+var DefaultPrimitives = []iceberg.PrimitiveType{
+	iceberg.PrimitiveTypes.Bool,
+	iceberg.PrimitiveTypes.Int32,
+	iceberg.PrimitiveTypes.Int64,
+	iceberg.PrimitiveTypes.Float32,
+	iceberg.PrimitiveTypes.Float64,
+	iceberg.PrimitiveTypes.Date,
+	iceberg.PrimitiveTypes.Time,
+	iceberg.PrimitiveTypes.Timestamp,
+	iceberg.PrimitiveTypes.TimestampTz,
+	iceberg.PrimitiveTypes.String,
+	iceberg.PrimitiveTypes.Binary,
+	iceberg.PrimitiveTypes.UUID,
+	iceberg.FixedTypeOf(1),
+	iceberg.FixedTypeOf(16),
+	iceberg.DecimalTypeOf(9, 2),
+	iceberg.DecimalTypeOf(18, 0),
+	iceberg.DecimalTypeOf(38, 10),
+}
+
+// SchemaGenerator produces random, valid Iceberg schemas for property
+// based tests. Field IDs are unique and assigned in depth-first order
+// starting at 1, and field names are unique within each struct.
+// Generating the same sequence from a source with the same seed yields
+// the same schemas.
+type SchemaGenerator struct {
+	// MaxDepth is the maximum nesting of structs, lists and maps. Zero
+	// means 3 and a negative value generates only primitive fields.
+	MaxDepth int
+	// MaxFields is the maximum number of fields in each struct. Zero
+	// means 6.
+	MaxFields int
+	// Primitives are the types that leaf fields are chosen from. Empty
+	// means DefaultPrimitives.
+	Primitives []iceberg.PrimitiveType
+	// Docs, if set, gives some fields a random doc string.
+	Docs bool
+
+	rnd    *rand.Rand
+	nextID int
+}
+
+// NewSchemaGenerator returns a SchemaGenerator with the default limits
+// that draws from rnd.
+func NewSchemaGenerator(rnd *rand.Rand) *SchemaGenerator {
+	return &SchemaGenerator{rnd: rnd}
+}
+
+// Schema returns a new random schema with ID 0 and at least one field.
+func (g *SchemaGenerator) Schema() *iceberg.Schema {
+	g.nextID = 0
+
+	return iceberg.NewSchema(0, g.fields(g.maxDepth())...)
+}
+
+// Type returns a random type nested at most depth levels deep, assigning
+// field IDs to its nested fields, lists and maps.
+func (g *SchemaGenerator) Type(depth int) iceberg.Type {
+	if depth <= 0 {
+		return g.primitive()
+	}
+
+	switch g.rnd.IntN(6) {
+	case 0:
+		return &iceberg.StructType{FieldList: g.fields(depth - 1)}
+	case 1:
+		id := g.id()
+
+		return &iceberg.ListType{
+			ElementID:       id,
+			Element:         g.Type(depth - 1),
+			ElementRequired: g.rnd.IntN(2) == 0,
+		}
+	case 2:
+		keyID, valueID := g.id(), g.id()
+
+		return &iceberg.MapType{
+			KeyID:         keyID,
+			KeyType:       g.primitive(),
+			ValueID:       valueID,
+			ValueType:     g.Type(depth - 1),
+			ValueRequired: g.rnd.IntN(2) == 0,
+		}
+	default:
+		return g.primitive()
+	}
+}
+
+func (g *SchemaGenerator) fields(depth int) []iceberg.NestedField {
+	n := 1 + g.rnd.IntN(g.maxFields())
+	fields := make([]iceberg.NestedField, n)
+	for i := range fields {
+		fields[i] = iceberg.NestedField{
+			ID: g.id(),
+			// the position makes the name unique within the struct
+			Name:     g.name() + "_" + strconv.Itoa(i),
+			Required: g.rnd.IntN(2) == 0,
+		}
+		if g.Docs && g.rnd.IntN(3) == 0 {
+			fields[i].Doc = "doc for " + fields[i].Name
+		}
+		fields[i].Type = g.Type(depth)
+	}
+
+	return fields
+}
+
+const nameChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// name returns a random identifier that is valid in Avro, Arrow and
+// Parquet, starting with a letter.
+func (g *SchemaGenerator) name() string {
+	b := make([]byte, 1+g.rnd.IntN(8))
+	b[0] = nameChars[g.rnd.IntN(52)]
+	for i := 1; i < len(b); i++ {
+		b[i] = nameChars[g.rnd.IntN(len(nameChars))]
+	}
+
+	return string(b)
+}
+
+func (g *SchemaGenerator) primitive() iceberg.PrimitiveType {
+	types := g.Primitives
+	if len(types) == 0 {
+		types = DefaultPrimitives
+	}
+
+	return types[g.rnd.IntN(len(types))]
+}
+
+func (g *SchemaGenerator) id() int {
+	g.nextID++
+
+	return g.nextID
+}
+
+func (g *SchemaGenerator) maxDepth() int {
+	if g.MaxDepth == 0 {
+		return 3
+	}
+
+	return g.MaxDepth
+}
+
+func (g *SchemaGenerator) maxFields() int {
+	if g.MaxFields <= 0 {
+		return 6
+	}
+
+	return g.MaxFields
+}
@@ -0,0 +1,56 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package icebergtest_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/icebergtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaGenerator(t *testing.T) {
+	for seed := range uint64(64) {
+		gen := icebergtest.NewSchemaGenerator(rand.New(rand.NewPCG(seed, 0)))
+		gen.MaxDepth, gen.MaxFields = 2, 4
+		sc := gen.Schema()
+
+		again := icebergtest.NewSchemaGenerator(rand.New(rand.NewPCG(seed, 0)))
+		again.MaxDepth, again.MaxFields = 2, 4
+		assert.True(t, sc.Equals(again.Schema()), "seed %d is not deterministic", seed)
+
+		// IDs are unique and dense, so the index has an entry for each
+		ids, err := iceberg.IndexByID(sc)
+		require.NoError(t, err)
+		assert.Len(t, ids, sc.HighestFieldID())
+		assert.LessOrEqual(t, len(sc.Fields()), 4)
+	}
+}
+
+func TestSchemaGeneratorPrimitives(t *testing.T) {
+	gen := icebergtest.NewSchemaGenerator(rand.New(rand.NewPCG(1, 2)))
+	gen.MaxDepth = -1
+	gen.Primitives = []iceberg.PrimitiveType{iceberg.PrimitiveTypes.String}
+
+	for _, f := range gen.Schema().Fields() {
+		assert.Equal(t, iceberg.PrimitiveTypes.String, f.Type)
+	}
+}
@@ -18,17 +18,38 @@
 package io
 
 import (
+	"bytes"
 	"context"
+	"crypto/md5"
 	"errors"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"io/fs"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"gocloud.dev/blob"
+	"gocloud.dev/gcerrors"
 )
 
+// WriteVerifyChecksum enables reading back the attributes of every file
+// written in a single request and comparing the MD5 reported by the
+// object store with the one of the content. Stores whose ETags are not
+// the MD5 of the content, such as S3 with SSE-KMS, must not enable it.
+const WriteVerifyChecksum = "io.write.verify-checksum"
+
+// ErrChecksumMismatch is returned when the checksum of a written file
+// reported by the object store does not match the checksum of the
+// content that was sent.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
 // blobOpenFile describes a single open blob as a File.
 // It implements the iceberg-go/io.File interface.
 // It is based on gocloud.dev/blob.iofsOpenFile which:
@@ -43,11 +64,25 @@ type blobOpenFile struct {
 }
 
 func (f *blobOpenFile) ReadAt(p []byte, off int64) (n int, err error) {
+	err = f.b.retry.Do(f.ctx, func(ctx context.Context) error {
+		n, err = f.readAt(ctx, p, off)
+
+		return err
+	})
+
+	return n, err
+}
+
+func (f *blobOpenFile) readAt(ctx context.Context, p []byte, off int64) (n int, err error) {
+	return f.b.readAt(ctx, f.key, p, off)
+}
+
+func (bfs *blobFileIO) readAt(ctx context.Context, key string, p []byte, off int64) (n int, err error) {
 	var rdr io.ReadCloser
-	if f.b.newRangeReader != nil {
-		rdr, err = f.b.newRangeReader(f.ctx, f.key, off, int64(len(p)))
+	if bfs.newRangeReader != nil {
+		rdr, err = bfs.newRangeReader(ctx, key, off, int64(len(p)))
 	} else {
-		rdr, err = f.b.NewRangeReader(f.ctx, f.key, off, int64(len(p)), nil)
+		rdr, err = bfs.NewRangeReader(ctx, key, off, int64(len(p)), nil)
 	}
 	if err != nil {
 		return 0, err
@@ -66,6 +101,77 @@ func (f *blobOpenFile) Sys() any                   { return f.b }
 func (f *blobOpenFile) IsDir() bool                { return false }
 func (f *blobOpenFile) Stat() (fs.FileInfo, error) { return f, nil }
 
+// blobRangeFile is a File returned by OpenRandomAccess. All reads,
+// including sequential ones, are issued as ranged requests.
+type blobRangeFile struct {
+	name, key string
+	size      int64
+	modTime   time.Time
+	b         *blobFileIO
+
+	pos int64
+}
+
+func (f *blobRangeFile) ReadAt(p []byte, off int64) (n int, err error) {
+	if off >= f.size {
+		return 0, io.EOF
+	}
+
+	// avoid requesting bytes past the end of the object
+	short := int64(len(p)) > f.size-off
+	if short {
+		p = p[:f.size-off]
+	}
+
+	err = f.b.retry.Do(f.b.ctx, func(ctx context.Context) error {
+		n, err = f.b.readAt(ctx, f.key, p, off)
+
+		return err
+	})
+	if err == nil && short {
+		err = io.EOF
+	}
+
+	return n, err
+}
+
+func (f *blobRangeFile) Read(p []byte) (int, error) {
+	n, err := f.ReadAt(p, f.pos)
+	f.pos += int64(n)
+	if errors.Is(err, io.EOF) && n > 0 {
+		err = nil
+	}
+
+	return n, err
+}
+
+func (f *blobRangeFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.pos
+	case io.SeekEnd:
+		offset += f.size
+	default:
+		return 0, fs.ErrInvalid
+	}
+	if offset < 0 {
+		return 0, fs.ErrInvalid
+	}
+	f.pos = offset
+
+	return offset, nil
+}
+
+func (f *blobRangeFile) Close() error               { return nil }
+func (f *blobRangeFile) Name() string               { return f.name }
+func (f *blobRangeFile) Size() int64                { return f.size }
+func (f *blobRangeFile) ModTime() time.Time         { return f.modTime }
+func (f *blobRangeFile) Mode() fs.FileMode          { return fs.ModeIrregular }
+func (f *blobRangeFile) Sys() any                   { return f.b }
+func (f *blobRangeFile) IsDir() bool                { return false }
+func (f *blobRangeFile) Stat() (fs.FileInfo, error) { return f, nil }
+
 // KeyExtractor extracts the object key from an input path
 type KeyExtractor func(path string) (string, error)
 
@@ -93,6 +199,12 @@ type blobFileIO struct {
 
 	keyExtractor KeyExtractor
 	ctx          context.Context
+	// retry is applied to individual requests, a nil policy
+	// performs each request once.
+	retry *RetryPolicy
+	// verifyWrites enables checking the MD5 of written files, see
+	// WriteVerifyChecksum.
+	verifyWrites bool
 
 	// newRangeReader is an optional hook for testing.
 	// It allows injecting a mock reader to verify Close calls.
@@ -115,7 +227,14 @@ func (bfs *blobFileIO) Open(path string) (File, error) {
 
 	key, name := path, filepath.Base(path)
 
-	r, err := bfs.NewReader(bfs.ctx, key, nil)
+	var r *blob.Reader
+	// the reader streams the object after Open returns so it must not
+	// be bound by the per-request timeout.
+	err = bfs.retry.do(bfs.ctx, false, func(ctx context.Context) (err error) {
+		r, err = bfs.NewReader(ctx, key, nil)
+
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -123,6 +242,34 @@ func (bfs *blobFileIO) Open(path string) (File, error) {
 	return &blobOpenFile{Reader: r, name: name, key: key, b: bfs, ctx: bfs.ctx}, nil
 }
 
+// OpenRandomAccess opens the blob at path without reading it. Only the
+// object attributes are requested, reads are served with ranged requests.
+func (bfs *blobFileIO) OpenRandomAccess(path string) (File, error) {
+	var err error
+	path, err = bfs.preprocess(path)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: path, Err: err}
+	}
+	if !fs.ValidPath(path) {
+		return nil, &fs.PathError{Op: "open", Path: path, Err: fs.ErrInvalid}
+	}
+
+	var attrs *blob.Attributes
+	err = bfs.retry.Do(bfs.ctx, func(ctx context.Context) (err error) {
+		attrs, err = bfs.Attributes(ctx, path)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &blobRangeFile{
+		name: filepath.Base(path), key: path, b: bfs,
+		size: attrs.Size, modTime: attrs.ModTime,
+	}, nil
+}
+
 func (bfs *blobFileIO) Remove(name string) error {
 	var err error
 	name, err = bfs.preprocess(name)
@@ -130,11 +277,15 @@ func (bfs *blobFileIO) Remove(name string) error {
 		return &fs.PathError{Op: "remove", Path: name, Err: err}
 	}
 
-	return bfs.Delete(bfs.ctx, name)
+	return bfs.retry.Do(bfs.ctx, func(ctx context.Context) error {
+		return bfs.Delete(ctx, name)
+	})
 }
 
 func (bfs *blobFileIO) Create(name string) (FileWriter, error) {
-	return bfs.NewWriter(bfs.ctx, name, true, nil)
+	return bfs.NewWriter(bfs.ctx, name, true, &blob.WriterOptions{
+		BeforeWrite: requestChecksums(nil),
+	})
 }
 
 func (bfs *blobFileIO) WriteFile(name string, content []byte) error {
@@ -144,7 +295,80 @@ func (bfs *blobFileIO) WriteFile(name string, content []byte) error {
 		return &fs.PathError{Op: "write file", Path: name, Err: err}
 	}
 
-	return bfs.WriteAll(bfs.ctx, name, content, nil)
+	return bfs.retry.Do(bfs.ctx, func(ctx context.Context) error {
+		return bfs.writeAll(ctx, name, content, false)
+	})
+}
+
+// WriteFileExclusive writes content to name with a conditional request
+// that fails if the object already exists.
+func (bfs *blobFileIO) WriteFileExclusive(name string, content []byte) error {
+	key, err := bfs.preprocess(name)
+	if err != nil {
+		return &fs.PathError{Op: "write file", Path: name, Err: err}
+	}
+
+	exists := func(err error) bool {
+		code := gcerrors.Code(err)
+
+		return code == gcerrors.FailedPrecondition || code == gcerrors.AlreadyExists
+	}
+	read := func(ctx context.Context) ([]byte, error) { return bfs.ReadAll(ctx, key) }
+
+	err = bfs.retry.doExclusive(bfs.ctx, content, exists, read, func(ctx context.Context) error {
+		return bfs.writeAll(ctx, key, content, true)
+	})
+	if exists(err) {
+		return &fs.PathError{Op: "write file", Path: name, Err: fs.ErrExist}
+	}
+
+	return err
+}
+
+// writeAll writes content to key with a single request carrying its MD5,
+// and its CRC32C on GCS, so that the object store rejects a corrupted
+// upload.
+func (bfs *blobFileIO) writeAll(ctx context.Context, key string, content []byte, ifNotExist bool) error {
+	crc := crc32.Checksum(content, castagnoli)
+	sum := md5.Sum(content)
+
+	err := bfs.WriteAll(ctx, key, content, &blob.WriterOptions{
+		ContentMD5:  sum[:],
+		IfNotExist:  ifNotExist,
+		BeforeWrite: requestChecksums(&crc),
+	})
+	if err != nil || !bfs.verifyWrites {
+		return err
+	}
+
+	attrs, err := bfs.Attributes(ctx, key)
+	if err != nil {
+		return err
+	}
+	if len(attrs.MD5) > 0 && !bytes.Equal(attrs.MD5, sum[:]) {
+		return fmt.Errorf("%w: %s was stored with MD5 %x, expected %x", ErrChecksumMismatch, key, attrs.MD5, sum)
+	}
+
+	return nil
+}
+
+// requestChecksums returns a BeforeWrite hook asking S3 to verify the
+// CRC32C of uploads, and GCS to verify crc32c if the checksum of the whole
+// content is known up front.
+func requestChecksums(crc32c *uint32) func(asFunc func(any) bool) error {
+	return func(asFunc func(any) bool) error {
+		var req *s3.PutObjectInput
+		if asFunc(&req) {
+			req.ChecksumAlgorithm = types.ChecksumAlgorithmCrc32c
+		}
+
+		var w *storage.Writer
+		if crc32c != nil && asFunc(&w) {
+			w.CRC32C, w.SendCRC32C = *crc32c, true
+		}
+
+		return nil
+	}
 }
 
 // NewWriter returns a Writer that writes to the blob stored at path.
@@ -165,7 +389,13 @@ func (bfs *blobFileIO) NewWriter(ctx context.Context, path string, overwrite boo
 	}
 
 	if !overwrite {
-		if exists, err := bfs.Exists(ctx, path); err != nil || exists {
+		var exists bool
+		err := bfs.retry.Do(ctx, func(ctx context.Context) (err error) {
+			exists, err = bfs.Exists(ctx, path)
+
+			return err
+		})
+		if err != nil || exists {
 			if err != nil {
 				return nil, &fs.PathError{Op: "new writer", Path: path, Err: err}
 			}
@@ -185,8 +415,8 @@ func (bfs *blobFileIO) NewWriter(ctx context.Context, path string, overwrite boo
 		nil
 }
 
-func createBlobFS(ctx context.Context, bucket *blob.Bucket, keyExtractor KeyExtractor) IO {
-	return &blobFileIO{Bucket: bucket, keyExtractor: keyExtractor, ctx: ctx}
+func createBlobFS(ctx context.Context, bucket *blob.Bucket, keyExtractor KeyExtractor, retry *RetryPolicy) *blobFileIO {
+	return &blobFileIO{Bucket: bucket, keyExtractor: keyExtractor, ctx: ctx, retry: retry}
 }
 
 type blobWriteFile struct {
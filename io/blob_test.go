@@ -19,6 +19,7 @@ package io
 
 import (
 	"context"
+	"errors"
 	"io"
 	"io/fs"
 	"testing"
@@ -190,3 +191,45 @@ func TestReadAtResourceCleanup(t *testing.T) {
 		})
 	}
 }
+
+func TestBlobWriteFileExclusive(t *testing.T) {
+	fsys, err := LoadFS(context.Background(), map[string]string{WriteVerifyChecksum: "true"}, "mem://bucket/metadata")
+	require.NoError(t, err)
+	require.True(t, fsys.(*blobFileIO).verifyWrites)
+
+	name := "mem://bucket/metadata/v1.metadata.json"
+	require.NoError(t, WriteFileExclusive(fsys, name, []byte("first")))
+
+	err = WriteFileExclusive(fsys, name, []byte("second"))
+	assert.ErrorIs(t, err, fs.ErrExist)
+
+	f, err := fsys.Open(name)
+	require.NoError(t, err)
+	data, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	assert.Equal(t, "first", string(data))
+
+	// plain writes still overwrite
+	require.NoError(t, fsys.(WriteFileIO).WriteFile(name, []byte("third")))
+	w, err := fsys.(WriteFileIO).Create(name)
+	require.NoError(t, err)
+	_, err = w.Write([]byte("fourth"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	_, err = LoadFS(context.Background(), map[string]string{WriteVerifyChecksum: "maybe"}, "mem://bucket/metadata")
+	assert.ErrorContains(t, err, WriteVerifyChecksum)
+}
+
+func TestLocalWriteFileExclusive(t *testing.T) {
+	name := "file://" + t.TempDir() + "/metadata/v1.metadata.json"
+	require.NoError(t, WriteFileExclusive(LocalFS{}, name, []byte("first")))
+	assert.ErrorIs(t, WriteFileExclusive(LocalFS{}, name, []byte("second")), fs.ErrExist)
+
+	// wrappers pass exclusive writes through
+	wrapped := NewReadAheadIO(LocalFS{}, 1024, 2)
+	assert.ErrorIs(t, WriteFileExclusive(wrapped, name, []byte("second")), fs.ErrExist)
+
+	assert.ErrorIs(t, WriteFileExclusive(&HTTPFS{}, "http://host/file", nil), errors.ErrUnsupported)
+}
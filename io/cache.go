@@ -0,0 +1,402 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Constants for configuring the cache of metadata and manifest files.
+// The names match those used by the Java implementation.
+const (
+	ManifestCacheEnabled          = "io.manifest.cache-enabled"
+	ManifestCacheExpirationMs     = "io.manifest.cache.expiration-interval-ms"
+	ManifestCacheMaxTotalBytes    = "io.manifest.cache.max-total-bytes"
+	ManifestCacheMaxContentLength = "io.manifest.cache.max-content-length"
+)
+
+const (
+	ManifestCacheExpirationDefault       = time.Minute
+	ManifestCacheMaxTotalBytesDefault    = 100 * 1024 * 1024
+	ManifestCacheMaxContentLengthDefault = 8 * 1024 * 1024
+)
+
+type cacheEntry struct {
+	key     string
+	data    []byte
+	expires time.Time
+}
+
+// Cache is a size bounded LRU cache of file contents. Entries expire
+// after a fixed TTL. It is safe for concurrent use.
+type Cache struct {
+	maxBytes         int64
+	maxContentLength int64
+	ttl              time.Duration
+
+	mx      sync.Mutex
+	size    int64
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+// NewCache creates a cache holding at most maxBytes of file contents.
+// Files larger than maxContentLength are never cached, and a ttl of
+// zero means entries only leave the cache when evicted.
+func NewCache(maxBytes, maxContentLength int64, ttl time.Duration) *Cache {
+	return &Cache{
+		maxBytes:         maxBytes,
+		maxContentLength: min(maxContentLength, maxBytes),
+		ttl:              ttl,
+		entries:          make(map[string]*list.Element),
+		lru:              list.New(),
+	}
+}
+
+// Get returns the cached contents for key. The returned slice must not
+// be modified.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if c.ttl > 0 && time.Now().After(entry.expires) {
+		c.remove(elem)
+
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+
+	return entry.data, true
+}
+
+// Put stores data for key, evicting the least recently used entries as
+// needed. It reports whether the data was cached.
+func (c *Cache) Put(key string, data []byte) bool {
+	if int64(len(data)) > c.maxContentLength {
+		return false
+	}
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+
+	for c.size+int64(len(data)) > c.maxBytes {
+		c.remove(c.lru.Back())
+	}
+
+	c.entries[key] = c.lru.PushFront(&cacheEntry{
+		key: key, data: data, expires: time.Now().Add(c.ttl),
+	})
+	c.size += int64(len(data))
+
+	return true
+}
+
+// Invalidate removes key from the cache.
+func (c *Cache) Invalidate(key string) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+}
+
+// Size returns the total number of bytes currently cached.
+func (c *Cache) Size() int64 {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	return c.size
+}
+
+func (c *Cache) remove(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*cacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= int64(len(entry.data))
+}
+
+// manifestName matches the names of manifest files, <uuid>-m<n>.avro,
+// and manifest lists, snap-<snapshot id>-<attempt>-<uuid>.avro.
+var manifestName = regexp.MustCompile(`(^snap-.*|-m\d+)\.avro$`)
+
+// IsMetadataFile reports whether name is a table metadata file, a
+// manifest list or a manifest. These files are never modified after
+// being written, which makes them safe to cache. Other Avro files, such
+// as data files, are not matched.
+func IsMetadataFile(name string) bool {
+	if strings.HasSuffix(name, ".metadata.json") || strings.HasSuffix(name, ".metadata.json.gz") {
+		return true
+	}
+
+	return manifestName.MatchString(path.Base(name))
+}
+
+// CachingIO wraps an IO and serves the files selected by its filter
+// from a Cache. Writes and removals invalidate the cached contents.
+type CachingIO struct {
+	IO
+
+	cache  *Cache
+	filter func(name string) bool
+	// scope prefixes the cache keys, so that IOs with different
+	// credentials sharing a cache do not see each other's entries
+	scope string
+}
+
+// NewCachingIO wraps fsys so that files matching filter are cached in
+// cache. A nil filter caches the files selected by IsMetadataFile.
+//
+// Entries are keyed by file name only: a cache must not be shared with
+// IOs that are not allowed to read the same files as fsys, as they would
+// be served the files cached by fsys.
+func NewCachingIO(fsys IO, cache *Cache, filter func(name string) bool) *CachingIO {
+	if filter == nil {
+		filter = IsMetadataFile
+	}
+
+	return &CachingIO{IO: fsys, cache: cache, filter: filter}
+}
+
+// Unwrap returns the wrapped IO.
+func (c *CachingIO) Unwrap() IO { return c.IO }
+
+func (c *CachingIO) key(name string) string {
+	if c.scope == "" {
+		return name
+	}
+
+	return c.scope + "\x00" + name
+}
+
+func (c *CachingIO) readAll(name string) ([]byte, error) {
+	if data, ok := c.cache.Get(c.key(name)); ok {
+		return data, nil
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	if rf, ok := c.IO.(ReadFileIO); ok {
+		data, err = rf.ReadFile(name)
+	} else {
+		var f File
+		if f, err = c.IO.Open(name); err != nil {
+			return nil, err
+		}
+		data, err = io.ReadAll(f)
+		err = errors.Join(err, f.Close())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.cache.Put(c.key(name), data)
+
+	return data, nil
+}
+
+func (c *CachingIO) Open(name string) (File, error) {
+	if !c.filter(name) {
+		return c.IO.Open(name)
+	}
+
+	data, err := c.readAll(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &memFile{Reader: bytes.NewReader(data), name: path.Base(name)}, nil
+}
+
+func (c *CachingIO) ReadFile(name string) ([]byte, error) {
+	if !c.filter(name) {
+		if rf, ok := c.IO.(ReadFileIO); ok {
+			return rf.ReadFile(name)
+		}
+
+		f, err := c.IO.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(f)
+
+		return data, errors.Join(err, f.Close())
+	}
+
+	data, err := c.readAll(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.Clone(data), nil
+}
+
+func (c *CachingIO) OpenRandomAccess(name string) (File, error) {
+	if c.filter(name) {
+		return c.Open(name)
+	}
+
+	return OpenRandomAccess(c.IO, name)
+}
+
+func (c *CachingIO) Create(name string) (FileWriter, error) {
+	wfs, ok := c.IO.(WriteFileIO)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not support writing", errors.ErrUnsupported, c.IO)
+	}
+	c.cache.Invalidate(c.key(name))
+
+	return wfs.Create(name)
+}
+
+func (c *CachingIO) WriteFile(name string, p []byte) error {
+	wfs, ok := c.IO.(WriteFileIO)
+	if !ok {
+		return fmt.Errorf("%w: %T does not support writing", errors.ErrUnsupported, c.IO)
+	}
+	c.cache.Invalidate(c.key(name))
+
+	return wfs.WriteFile(name, p)
+}
+
+func (c *CachingIO) WriteFileExclusive(name string, p []byte) error {
+	c.cache.Invalidate(c.key(name))
+
+	return WriteFileExclusive(c.IO, name, p)
+}
+
+func (c *CachingIO) Remove(name string) error {
+	c.cache.Invalidate(c.key(name))
+
+	return c.IO.Remove(name)
+}
+
+// memFile is a File backed by an in-memory copy of the contents.
+type memFile struct {
+	*bytes.Reader
+
+	name string
+}
+
+func (f *memFile) Close() error               { return nil }
+func (f *memFile) Name() string               { return f.name }
+func (f *memFile) Mode() fs.FileMode          { return fs.ModeIrregular }
+func (f *memFile) ModTime() time.Time         { return time.Time{} }
+func (f *memFile) IsDir() bool                { return false }
+func (f *memFile) Sys() any                   { return nil }
+func (f *memFile) Stat() (fs.FileInfo, error) { return f, nil }
+
+type cacheConfig struct {
+	maxBytes, maxContentLength int64
+	ttl                        time.Duration
+}
+
+var sharedCaches = struct {
+	sync.Mutex
+	m map[cacheConfig]*Cache
+}{m: make(map[cacheConfig]*Cache)}
+
+// cacheScope returns the scope of the cache entries of an IO loaded with
+// props. It is a digest of every property other than the cache settings,
+// so that only IOs loaded with the same credentials, endpoints and other
+// settings share entries.
+func cacheScope(props map[string]string) string {
+	h := sha256.New()
+	for _, key := range slices.Sorted(maps.Keys(props)) {
+		if strings.HasPrefix(key, "io.manifest.cache") {
+			continue
+		}
+		fmt.Fprintf(h, "%d:%s%d:%s", len(key), key, len(props[key]), props[key])
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// sharedCacheFromProps returns the process wide cache for the settings
+// in props, or nil if caching is not enabled. Caches are shared so that
+// repeatedly loading an IO for the same table reuses the cached files,
+// the IOs using them must scope their entries with cacheScope.
+func sharedCacheFromProps(props map[string]string) (*Cache, error) {
+	if enabled, err := strconv.ParseBool(props[ManifestCacheEnabled]); err != nil || !enabled {
+		return nil, nil
+	}
+
+	cfg := cacheConfig{
+		maxBytes:         ManifestCacheMaxTotalBytesDefault,
+		maxContentLength: ManifestCacheMaxContentLengthDefault,
+		ttl:              ManifestCacheExpirationDefault,
+	}
+
+	for key, dst := range map[string]*int64{
+		ManifestCacheMaxTotalBytes:    &cfg.maxBytes,
+		ManifestCacheMaxContentLength: &cfg.maxContentLength,
+	} {
+		if v, ok := props[key]; ok {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid value for %s: %q", key, v)
+			}
+			*dst = n
+		}
+	}
+
+	if v, ok := props[ManifestCacheExpirationMs]; ok {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || ms < 0 {
+			return nil, fmt.Errorf("invalid value for %s: %q", ManifestCacheExpirationMs, v)
+		}
+		cfg.ttl = time.Duration(ms) * time.Millisecond
+	}
+
+	sharedCaches.Lock()
+	defer sharedCaches.Unlock()
+
+	cache, ok := sharedCaches.m[cfg]
+	if !ok {
+		cache = NewCache(cfg.maxBytes, cfg.maxContentLength, cfg.ttl)
+		sharedCaches.m[cfg] = cache
+	}
+
+	return cache, nil
+}
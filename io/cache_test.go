@@ -0,0 +1,211 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingOpenFS struct {
+	LocalFS
+
+	opens int
+}
+
+func (c *countingOpenFS) Open(name string) (File, error) {
+	c.opens++
+
+	return c.LocalFS.Open(name)
+}
+
+func TestCacheEviction(t *testing.T) {
+	c := NewCache(10, 8, 0)
+
+	assert.True(t, c.Put("a", []byte("aaaa")))
+	assert.True(t, c.Put("b", []byte("bbbb")))
+	assert.False(t, c.Put("big", []byte("123456789")), "larger than the max content length")
+	assert.EqualValues(t, 8, c.Size())
+
+	// touch a so that b is the least recently used
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+
+	assert.True(t, c.Put("c", []byte("cccc")))
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+	data, ok := c.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "aaaa", string(data))
+	assert.EqualValues(t, 8, c.Size())
+
+	// replacing an entry updates the size
+	assert.True(t, c.Put("a", []byte("aa")))
+	assert.EqualValues(t, 6, c.Size())
+
+	c.Invalidate("a")
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+	assert.EqualValues(t, 4, c.Size())
+}
+
+func TestCacheExpiration(t *testing.T) {
+	c := NewCache(100, 100, 20*time.Millisecond)
+	c.Put("a", []byte("data"))
+
+	_, ok := c.Get("a")
+	assert.True(t, ok)
+
+	time.Sleep(30 * time.Millisecond)
+	_, ok = c.Get("a")
+	assert.False(t, ok)
+	assert.Zero(t, c.Size())
+}
+
+func TestCachingIO(t *testing.T) {
+	dir := t.TempDir()
+	meta := filepath.Join(dir, "00001-abc.metadata.json")
+	data := filepath.Join(dir, "data.parquet")
+
+	inner := &countingOpenFS{}
+	fsys := NewCachingIO(inner, NewCache(1024, 1024, 0), nil)
+
+	require.NoError(t, fsys.WriteFile(meta, []byte(`{"format-version": 2}`)))
+	require.NoError(t, fsys.WriteFile(data, []byte("PAR1")))
+
+	for range 3 {
+		f, err := fsys.Open(meta)
+		require.NoError(t, err)
+		contents, err := io.ReadAll(f)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		assert.Equal(t, `{"format-version": 2}`, string(contents))
+
+		info, err := f.Stat()
+		require.NoError(t, err)
+		assert.EqualValues(t, len(contents), info.Size())
+	}
+	assert.Equal(t, 1, inner.opens)
+
+	contents, err := fsys.ReadFile(meta)
+	require.NoError(t, err)
+	assert.Equal(t, `{"format-version": 2}`, string(contents))
+	assert.Equal(t, 1, inner.opens)
+
+	// files not matching the filter go straight through
+	for range 2 {
+		f, err := fsys.Open(data)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+	}
+	assert.Equal(t, 3, inner.opens)
+
+	// writing invalidates the cached copy
+	require.NoError(t, fsys.WriteFile(meta, []byte(`{}`)))
+	contents, err = fsys.ReadFile(meta)
+	require.NoError(t, err)
+	assert.Equal(t, `{}`, string(contents))
+	assert.Equal(t, 4, inner.opens)
+
+	require.NoError(t, fsys.Remove(meta))
+	_, err = fsys.Open(meta)
+	assert.Error(t, err)
+}
+
+func TestLoadFSManifestCache(t *testing.T) {
+	ctx := context.Background()
+
+	fsys, err := LoadFS(ctx, map[string]string{}, "file:///tmp")
+	require.NoError(t, err)
+	assert.Equal(t, LocalFS{}, fsys)
+
+	props := map[string]string{
+		ManifestCacheEnabled:       "true",
+		ManifestCacheMaxTotalBytes: "2048",
+		ManifestCacheExpirationMs:  "0",
+	}
+	first, err := LoadFS(ctx, props, "file:///tmp")
+	require.NoError(t, err)
+	require.IsType(t, &CachingIO{}, first)
+
+	second, err := LoadFS(ctx, props, "file:///tmp")
+	require.NoError(t, err)
+	assert.Same(t, first.(*CachingIO).cache, second.(*CachingIO).cache)
+	assert.Equal(t, LocalFS{}, second.(*CachingIO).Unwrap())
+
+	props[ManifestCacheMaxTotalBytes] = "lots"
+	_, err = LoadFS(ctx, props, "file:///tmp")
+	assert.ErrorContains(t, err, ManifestCacheMaxTotalBytes)
+}
+
+func TestIsMetadataFile(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"s3://bucket/tbl/metadata/00001-3f1a.metadata.json":                          true,
+		"s3://bucket/tbl/metadata/00001-3f1a.metadata.json.gz":                       true,
+		"s3://bucket/tbl/metadata/snap-2931-1-6c1e8d4e.avro":                         true,
+		"s3://bucket/tbl/metadata/6c1e8d4e-m0.avro":                                  true,
+		"s3://bucket/tbl/metadata/6c1e8d4e-m12.avro":                                 true,
+		"s3://bucket/tbl/data/00000-0-6c1e8d4e.avro":                                 false,
+		"s3://bucket/tbl/data/region=eu/00000-0-6c1e8d4e-00001.parquet":              false,
+		"s3://bucket/tbl/data/snapshots.json":                                        false,
+		"s3://bucket/tbl/data/region=snap-eu/00000-0-6c1e8d4e-00001-deletes.parquet": false,
+	} {
+		assert.Equal(t, expected, IsMetadataFile(name), name)
+	}
+}
+
+func TestLoadFSManifestCacheScope(t *testing.T) {
+	ctx := context.Background()
+
+	meta := filepath.Join(t.TempDir(), "00001-abc.metadata.json")
+	require.NoError(t, os.WriteFile(meta, []byte(`{"format-version": 2}`), 0o644))
+
+	load := func(secret string) IO {
+		fsys, err := LoadFS(ctx, map[string]string{
+			ManifestCacheEnabled:      "true",
+			ManifestCacheExpirationMs: "0",
+			S3SecretAccessKey:         secret,
+		}, "file:///tmp")
+		require.NoError(t, err)
+
+		return fsys
+	}
+
+	tenantA, sameAsA, tenantB := load("a"), load("a"), load("b")
+	require.Same(t, tenantA.(*CachingIO).cache, tenantB.(*CachingIO).cache)
+
+	_, err := tenantA.(ReadFileIO).ReadFile(meta)
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(meta))
+
+	// IOs loaded with the same properties share the cached contents
+	contents, err := sameAsA.(ReadFileIO).ReadFile(meta)
+	require.NoError(t, err)
+	assert.Equal(t, `{"format-version": 2}`, string(contents))
+
+	// IOs loaded with other credentials must read the file themselves
+	_, err = tenantB.(ReadFileIO).ReadFile(meta)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
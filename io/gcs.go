@@ -20,12 +20,15 @@ package io
 import (
 	"context"
 	"net/url"
+	"strconv"
+	"time"
 
 	"cloud.google.com/go/storage"
 
 	"gocloud.dev/blob"
 	"gocloud.dev/blob/gcsblob"
 	"gocloud.dev/gcp"
+	"golang.org/x/oauth2"
 	"google.golang.org/api/option"
 )
 
@@ -36,6 +39,13 @@ const (
 	GCSJSONKey    = "gcs.jsonkey"
 	GCSCredType   = "gcs.credtype"
 	GCSUseJsonAPI = "gcs.usejsonapi" // set to anything to enable
+
+	// GCSOAuth2Token is a short-lived access token, such as one vended by
+	// a REST catalog, used instead of the default credentials.
+	GCSOAuth2Token = "gcs.oauth2.token"
+	// GCSOAuth2TokenExpiresAt is the expiry of GCSOAuth2Token in
+	// milliseconds since the epoch.
+	GCSOAuth2TokenExpiresAt = "gcs.oauth2.token-expires-at"
 )
 
 var allowedGCSCredTypes = map[string]option.CredentialsType{
@@ -75,18 +85,9 @@ func ParseGCSConfig(props map[string]string) *gcsblob.Options {
 // Construct a GCS bucket from a URL
 func createGCSBucket(ctx context.Context, parsed *url.URL, props map[string]string) (*blob.Bucket, error) {
 	gcscfg := ParseGCSConfig(props)
-	creds, _ := gcp.DefaultCredentials(ctx)
-	var client *gcp.HTTPClient
-	if creds == nil {
-		client = gcp.NewAnonymousHTTPClient(gcp.DefaultTransport())
-	} else {
-		var err error
-		client, err = gcp.NewHTTPClient(
-			gcp.DefaultTransport(),
-			gcp.CredentialsTokenSource(creds))
-		if err != nil {
-			return nil, err
-		}
+	client, err := gcsHTTPClient(ctx, props)
+	if err != nil {
+		return nil, err
 	}
 
 	bucket, err := gcsblob.OpenBucket(ctx, client, parsed.Host, gcscfg)
@@ -96,3 +97,28 @@ func createGCSBucket(ctx context.Context, parsed *url.URL, props map[string]stri
 
 	return bucket, nil
 }
+
+// gcsHTTPClient authenticates with a vended access token if one is set,
+// and otherwise with the default credentials, if any.
+func gcsHTTPClient(ctx context.Context, props map[string]string) (*gcp.HTTPClient, error) {
+	if tok := props[GCSOAuth2Token]; tok != "" {
+		return gcp.NewHTTPClient(gcp.DefaultTransport(),
+			oauth2.StaticTokenSource(gcsVendedToken(tok, props[GCSOAuth2TokenExpiresAt])))
+	}
+
+	creds, _ := gcp.DefaultCredentials(ctx)
+	if creds == nil {
+		return gcp.NewAnonymousHTTPClient(gcp.DefaultTransport()), nil
+	}
+
+	return gcp.NewHTTPClient(gcp.DefaultTransport(), gcp.CredentialsTokenSource(creds))
+}
+
+func gcsVendedToken(tok, expiresAt string) *oauth2.Token {
+	t := &oauth2.Token{AccessToken: tok, TokenType: "Bearer"}
+	if ms, err := strconv.ParseInt(expiresAt, 10, 64); err == nil {
+		t.Expiry = time.UnixMilli(ms)
+	}
+
+	return t
+}
@@ -0,0 +1,329 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HTTPHeaderPrefix is the prefix of the properties whose values are sent
+// as headers of every request made by an HTTPFS, such as
+// "http.header.Authorization".
+const HTTPHeaderPrefix = "http.header."
+
+// HTTPFS is a read-only IO for files served over http:// and https://,
+// such as public datasets exposed through a CDN. The length of a file is
+// read with a HEAD request and its contents with ranged GET requests, so
+// the server must support the Range header for files to be read
+// efficiently. Failed requests are retried according to the retry
+// properties, see ParseRetryPolicy.
+type HTTPFS struct {
+	ctx    context.Context
+	client *http.Client
+	header http.Header
+	retry  *RetryPolicy
+}
+
+// NewHTTPFS returns an HTTPFS making requests with client, or
+// http.DefaultClient if client is nil, configured by props.
+func NewHTTPFS(ctx context.Context, client *http.Client, props map[string]string) (*HTTPFS, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	retry, err := ParseRetryPolicy(props)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make(http.Header)
+	for k, v := range props {
+		if name, ok := strings.CutPrefix(k, HTTPHeaderPrefix); ok && name != "" {
+			header.Set(name, v)
+		}
+	}
+
+	return &HTTPFS{ctx: ctx, client: client, header: header, retry: retry}, nil
+}
+
+// httpResponseError is the error of a request that failed with an
+// unexpected status, which IsRetryable classifies by its status code.
+type httpResponseError struct {
+	code   int
+	status string
+}
+
+func (e *httpResponseError) Error() string       { return "unexpected HTTP status " + e.status }
+func (e *httpResponseError) HTTPStatusCode() int { return e.code }
+
+func (e *httpResponseError) Unwrap() error {
+	if e.code == http.StatusNotFound {
+		return fs.ErrNotExist
+	}
+
+	return nil
+}
+
+// do sends a request for name with send, retrying transient failures.
+func (h *HTTPFS) do(ctx context.Context, method, name, byteRange string, ok ...int) (resp *http.Response, err error) {
+	err = h.retry.do(ctx, false, func(ctx context.Context) error {
+		resp, err = h.send(ctx, method, name, byteRange, ok...)
+
+		return err
+	})
+
+	return resp, err
+}
+
+// send sends a request for name and returns the response if its status
+// is one of ok. The caller must close the body of the response.
+func (h *HTTPFS) send(ctx context.Context, method, name, byteRange string, ok ...int) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range h.header {
+		req.Header[k] = v
+	}
+	if byteRange != "" {
+		req.Header.Set("Range", byteRange)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, code := range ok {
+		if resp.StatusCode == code {
+			return resp, nil
+		}
+	}
+	resp.Body.Close()
+
+	return nil, &httpResponseError{code: resp.StatusCode, status: resp.Status}
+}
+
+func (h *HTTPFS) Open(name string) (File, error) {
+	resp, err := h.do(h.ctx, http.MethodHead, name, "", http.StatusOK)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	resp.Body.Close()
+
+	if resp.ContentLength < 0 {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: errors.New("server did not report the content length")}
+	}
+
+	modTime, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+
+	return &httpFile{
+		name: name, size: resp.ContentLength, modTime: modTime,
+		src: h, ctx: h.ctx, retry: h.retry,
+	}, nil
+}
+
+// OpenRandomAccess opens name like Open, whose files are already read
+// with ranged requests.
+func (h *HTTPFS) OpenRandomAccess(name string) (File, error) {
+	return h.Open(name)
+}
+
+func (h *HTTPFS) ReadFile(name string) ([]byte, error) {
+	resp, err := h.do(h.ctx, http.MethodGet, name, "", http.StatusOK)
+	if err != nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: err}
+	}
+	defer resp.Body.Close()
+
+	return io.ReadAll(resp.Body)
+}
+
+// Remove is not supported, HTTPFS is read-only.
+func (h *HTTPFS) Remove(name string) error {
+	return &fs.PathError{Op: "remove", Path: name, Err: errors.ErrUnsupported}
+}
+
+// openRange implements rangeOpener.
+func (h *HTTPFS) openRange(ctx context.Context, name string, off, length int64) (io.ReadCloser, error) {
+	var byteRange string
+	switch {
+	case length >= 0:
+		byteRange = "bytes=" + strconv.FormatInt(off, 10) + "-" + strconv.FormatInt(off+length-1, 10)
+	case off > 0:
+		byteRange = "bytes=" + strconv.FormatInt(off, 10) + "-"
+	}
+
+	resp, err := h.send(ctx, http.MethodGet, name, byteRange, http.StatusPartialContent, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	// a server ignoring the Range header returns the whole file
+	if off > 0 && resp.StatusCode == http.StatusOK {
+		if _, err := io.CopyN(io.Discard, resp.Body, off); err != nil {
+			resp.Body.Close()
+
+			return nil, fmt.Errorf("failed to skip to offset %d of %s: %w", off, name, err)
+		}
+	}
+
+	return resp.Body, nil
+}
+
+// rangeOpener is implemented by the HTTP based file systems to read a
+// range of a file.
+type rangeOpener interface {
+	// openRange returns a reader of the bytes [off, off+length) of
+	// name, or of the bytes from off to the end of the file if length
+	// is negative. It makes a single attempt.
+	openRange(ctx context.Context, name string, off, length int64) (io.ReadCloser, error)
+}
+
+// httpFile is a File served over HTTP. ReadAt issues a ranged request
+// per call, while Read streams the file from the current position.
+type httpFile struct {
+	name    string
+	size    int64
+	modTime time.Time
+	src     rangeOpener
+	ctx     context.Context
+	retry   *RetryPolicy
+
+	pos     int64
+	body    io.ReadCloser
+	bodyPos int64
+}
+
+func (f *httpFile) ReadAt(p []byte, off int64) (n int, err error) {
+	if off >= f.size {
+		return 0, io.EOF
+	}
+
+	// avoid requesting bytes past the end of the file
+	short := int64(len(p)) > f.size-off
+	if short {
+		p = p[:f.size-off]
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	err = f.retry.Do(f.ctx, func(ctx context.Context) error {
+		body, err := f.src.openRange(ctx, f.name, off, int64(len(p)))
+		if err != nil {
+			return err
+		}
+		defer body.Close()
+
+		n, err = io.ReadFull(body, p)
+
+		return err
+	})
+	if err == nil && short {
+		err = io.EOF
+	}
+
+	return n, err
+}
+
+func (f *httpFile) Read(p []byte) (int, error) {
+	if f.pos >= f.size {
+		return 0, io.EOF
+	}
+
+	if f.body == nil || f.bodyPos != f.pos {
+		if err := f.openBody(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.body.Read(p)
+	f.pos += int64(n)
+	f.bodyPos = f.pos
+	if errors.Is(err, io.EOF) && f.pos < f.size {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return n, err
+}
+
+// openBody starts streaming the file from the current position.
+func (f *httpFile) openBody() error {
+	if f.body != nil {
+		f.body.Close()
+		f.body = nil
+	}
+
+	err := f.retry.do(f.ctx, false, func(ctx context.Context) (err error) {
+		f.body, err = f.src.openRange(ctx, f.name, f.pos, -1)
+
+		return err
+	})
+	if err != nil {
+		return &fs.PathError{Op: "read", Path: f.name, Err: err}
+	}
+	f.bodyPos = f.pos
+
+	return nil
+}
+
+func (f *httpFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.pos
+	case io.SeekEnd:
+		offset += f.size
+	default:
+		return 0, fs.ErrInvalid
+	}
+	if offset < 0 {
+		return 0, fs.ErrInvalid
+	}
+	f.pos = offset
+
+	return offset, nil
+}
+
+func (f *httpFile) Close() error {
+	if f.body == nil {
+		return nil
+	}
+	err := f.body.Close()
+	f.body = nil
+
+	return err
+}
+
+func (f *httpFile) Name() string               { return path.Base(f.name) }
+func (f *httpFile) Size() int64                { return f.size }
+func (f *httpFile) ModTime() time.Time         { return f.modTime }
+func (f *httpFile) Mode() fs.FileMode          { return fs.ModeIrregular }
+func (f *httpFile) Sys() any                   { return f.src }
+func (f *httpFile) IsDir() bool                { return false }
+func (f *httpFile) Stat() (fs.FileInfo, error) { return f, nil }
@@ -0,0 +1,157 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPFS(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+
+	var (
+		ranged   atomic.Int32
+		failNext atomic.Bool
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/data.bin", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+		if failNext.CompareAndSwap(true, false) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+		if r.Header.Get("Range") != "" {
+			ranged.Add(1)
+		}
+		http.ServeContent(w, r, "data.bin", time.Unix(1700000000, 0), bytes.NewReader(content))
+	})
+	mux.HandleFunc("/no-range.bin", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "10000")
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(content)
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	props := map[string]string{
+		HTTPHeaderPrefix + "Authorization": "Bearer token",
+		RetryMaxAttempts:                   "3",
+		RetryInitialBackoffMs:              "1",
+		RetryMaxBackoffMs:                  "1",
+	}
+	fsys, err := LoadFS(context.Background(), props, srv.URL+"/data.bin")
+	require.NoError(t, err)
+	require.IsType(t, &HTTPFS{}, fsys)
+
+	t.Run("random access", func(t *testing.T) {
+		f, err := OpenRandomAccess(fsys, srv.URL+"/data.bin")
+		require.NoError(t, err)
+		defer f.Close()
+
+		info, err := f.Stat()
+		require.NoError(t, err)
+		assert.EqualValues(t, len(content), info.Size())
+		assert.Equal(t, "data.bin", info.Name())
+		assert.Equal(t, time.Unix(1700000000, 0).UTC(), info.ModTime().UTC())
+
+		before := ranged.Load()
+		buf := make([]byte, 5)
+		n, err := f.ReadAt(buf, 1003)
+		require.NoError(t, err)
+		assert.Equal(t, 5, n)
+		assert.Equal(t, "34567", string(buf))
+		assert.Equal(t, before+1, ranged.Load())
+
+		// reads past the end are shortened
+		n, err = f.ReadAt(buf, int64(len(content))-2)
+		assert.ErrorIs(t, err, io.EOF)
+		assert.Equal(t, "89", string(buf[:n]))
+	})
+
+	t.Run("sequential", func(t *testing.T) {
+		f, err := fsys.Open(srv.URL + "/data.bin")
+		require.NoError(t, err)
+		defer f.Close()
+
+		data, err := io.ReadAll(f)
+		require.NoError(t, err)
+		assert.Equal(t, content, data)
+
+		_, err = f.Seek(-4, io.SeekEnd)
+		require.NoError(t, err)
+		data, err = io.ReadAll(f)
+		require.NoError(t, err)
+		assert.Equal(t, "6789", string(data))
+	})
+
+	t.Run("read file", func(t *testing.T) {
+		data, err := fsys.(ReadFileIO).ReadFile(srv.URL + "/data.bin")
+		require.NoError(t, err)
+		assert.Equal(t, content, data)
+	})
+
+	t.Run("retries", func(t *testing.T) {
+		failNext.Store(true)
+		f, err := fsys.Open(srv.URL + "/data.bin")
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+	})
+
+	t.Run("server ignoring ranges", func(t *testing.T) {
+		f, err := fsys.Open(srv.URL + "/no-range.bin")
+		require.NoError(t, err)
+		defer f.Close()
+
+		buf := make([]byte, 3)
+		_, err = f.ReadAt(buf, 5001)
+		require.NoError(t, err)
+		assert.Equal(t, "123", string(buf))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := fsys.Open(srv.URL + "/missing.bin")
+		assert.ErrorIs(t, err, fs.ErrNotExist)
+
+		anonymous, err := NewHTTPFS(context.Background(), srv.Client(), nil)
+		require.NoError(t, err)
+		_, err = anonymous.Open(srv.URL + "/data.bin")
+		var pathErr *fs.PathError
+		require.True(t, errors.As(err, &pathErr))
+		assert.False(t, errors.Is(err, fs.ErrNotExist))
+
+		assert.ErrorIs(t, fsys.Remove(srv.URL+"/data.bin"), errors.ErrUnsupported)
+	})
+}
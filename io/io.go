@@ -24,6 +24,7 @@ import (
 	"io"
 	"io/fs"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"gocloud.dev/blob"
@@ -82,6 +83,30 @@ type WriteFileIO interface {
 	WriteFile(name string, p []byte) error
 }
 
+// ExclusiveWriteIO is the interface implemented by a file system that
+// can atomically create a file only if it does not already exist, such
+// as object stores supporting conditional writes.
+type ExclusiveWriteIO interface {
+	WriteFileIO
+
+	// WriteFileExclusive writes p to the named file if no file exists
+	// at name. Otherwise it fails with an error wrapping fs.ErrExist
+	// and leaves the existing file untouched.
+	WriteFileExclusive(name string, p []byte) error
+}
+
+// WriteFileExclusive writes p to name with fsys.WriteFileExclusive if
+// fsys implements ExclusiveWriteIO, and fails with an error wrapping
+// errors.ErrUnsupported otherwise.
+func WriteFileExclusive(fsys IO, name string, p []byte) error {
+	efs, ok := fsys.(ExclusiveWriteIO)
+	if !ok {
+		return fmt.Errorf("%w: %T does not support exclusive writes", errors.ErrUnsupported, fsys)
+	}
+
+	return efs.WriteFileExclusive(name, p)
+}
+
 // A File provides access to a single file. The File interface is the
 // minimum implementation required for Iceberg to interact with a file.
 // Directory files should also implement
@@ -235,6 +260,32 @@ func (f ioFile) ReadDir(count int) ([]fs.DirEntry, error) {
 	return d.ReadDir(count)
 }
 
+// IsCredentialProperty reports whether the property key holds a secret
+// used to access storage, such as an S3 secret key or session token, a
+// GCS or Azure key, a SAS or delegation token, or a password. Such
+// properties must not be copied to where the storage credentials of the
+// table are not meant to go, such as serialized scan plans.
+func IsCredentialProperty(key string) bool {
+	switch key {
+	case S3AccessKeyID, S3SecretAccessKey, S3SessionToken,
+		GCSJSONKey, GCSOAuth2Token, AdlsSharedKeyAccountKey, HDFSDelegationToken:
+		return true
+	}
+
+	if strings.HasPrefix(key, AdlsSasTokenPrefix) {
+		return true
+	}
+
+	key = strings.ToLower(key)
+	for _, secret := range []string{"secret", "password", "token", "credential"} {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func inferFileIOFromSchema(ctx context.Context, path string, props map[string]string) (IO, error) {
 	parsed, err := url.Parse(path)
 	if err != nil {
@@ -262,6 +313,26 @@ func inferFileIOFromSchema(ctx context.Context, path string, props map[string]st
 		keyExtractor = defaultKeyExtractor(parsed.Host)
 	case "file", "":
 		return LocalFS{}, nil
+	case "http", "https":
+		fsys, err := NewHTTPFS(ctx, nil, props)
+		if err != nil {
+			return nil, err
+		}
+		if fsys.retry.Limiter, err = hostRateLimiter(locationKey(path), props); err != nil {
+			return nil, err
+		}
+
+		return fsys, nil
+	case "hdfs", "webhdfs", "swebhdfs":
+		fsys, err := NewWebHDFS(ctx, nil, props)
+		if err != nil {
+			return nil, err
+		}
+		if fsys.retry.Limiter, err = hostRateLimiter(locationKey(path), props); err != nil {
+			return nil, err
+		}
+
+		return fsys, nil
 	case "abfs", "abfss", "wasb", "wasbs":
 		bucket, err = createAzureBucket(ctx, parsed, props)
 		if err != nil {
@@ -272,7 +343,22 @@ func inferFileIOFromSchema(ctx context.Context, path string, props map[string]st
 		return nil, fmt.Errorf("IO for file '%s' not implemented", path)
 	}
 
-	return createBlobFS(ctx, bucket, keyExtractor), nil
+	retry, err := ParseRetryPolicy(props)
+	if err != nil {
+		return nil, errors.Join(err, bucket.Close())
+	}
+	if retry.Limiter, err = hostRateLimiter(locationKey(path), props); err != nil {
+		return nil, errors.Join(err, bucket.Close())
+	}
+
+	bfs := createBlobFS(ctx, bucket, keyExtractor, retry)
+	if v, ok := props[WriteVerifyChecksum]; ok {
+		if bfs.verifyWrites, err = strconv.ParseBool(v); err != nil {
+			return nil, errors.Join(fmt.Errorf("invalid value for %s: %q", WriteVerifyChecksum, v), bucket.Close())
+		}
+	}
+
+	return bfs, nil
 }
 
 // LoadFS takes a map of properties and an optional URI location
@@ -282,7 +368,14 @@ func inferFileIOFromSchema(ctx context.Context, path string, props map[string]st
 // implementation. Otherwise this will return an error if the schema
 // does not yet have an implementation here.
 //
-// Currently local, S3, GCS, and In-Memory FSs are implemented.
+// Currently local, S3, GCS, Azure, HDFS (through WebHDFS), read-only
+// HTTP(S) and In-Memory FSs are implemented.
+//
+// If io.read-ahead.buffer-size-bytes is set, files opened with Open are
+// read ahead of the reader, see ReadAheadIO. If io.manifest.cache-enabled
+// is set, the returned IO caches metadata files, manifest lists and
+// manifests in memory. The cache is shared by the IOs loaded with the
+// same properties, including credentials.
 func LoadFS(ctx context.Context, props map[string]string, location string) (IO, error) {
 	if location == "" {
 		location = props["warehouse"]
@@ -297,13 +390,33 @@ func LoadFS(ctx context.Context, props map[string]string, location string) (IO,
 		iofs = LocalFS{}
 	}
 
+	bufSize, buffers, err := readAheadFromProps(props)
+	if err != nil {
+		return nil, err
+	}
+	if bufSize > 0 {
+		iofs = NewReadAheadIO(iofs, bufSize, buffers)
+	}
+
+	cache, err := sharedCacheFromProps(props)
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		cachingIO := NewCachingIO(iofs, cache, nil)
+		cachingIO.scope = cacheScope(props)
+		iofs = cachingIO
+	}
+
 	return iofs, nil
 }
 
-// LoadFSFunc is a helper function to create IO Func factory for later usage
+// LoadFSFunc is a helper function to create IO Func factory for later usage.
+// The returned IO is a ResolvingIO, which resolves the file system of each
+// file from its location, defaulting to the file system of location.
 func LoadFSFunc(props map[string]string, location string) func(ctx context.Context) (IO, error) {
 	return func(ctx context.Context) (IO, error) {
-		iofs, err := LoadFS(ctx, props, location)
+		iofs, err := NewResolvingIO(ctx, props, location)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load metadata file at %s: %w", location, err)
 		}
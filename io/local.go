@@ -18,6 +18,7 @@
 package io
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -44,6 +45,25 @@ func (LocalFS) WriteFile(name string, content []byte) error {
 	return os.WriteFile(strings.TrimPrefix(name, "file://"), content, 0o777)
 }
 
+// WriteFileExclusive creates name with the contents p, failing if the
+// file already exists.
+func (LocalFS) WriteFileExclusive(name string, content []byte) (err error) {
+	filename := strings.TrimPrefix(name, "file://")
+	if err := os.MkdirAll(filepath.Dir(filename), 0o777); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o777)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errors.Join(err, f.Close()) }()
+
+	_, err = f.Write(content)
+
+	return err
+}
+
 func (LocalFS) Remove(name string) error {
 	return os.Remove(strings.TrimPrefix(name, "file://"))
 }
@@ -0,0 +1,233 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"slices"
+	"sync"
+)
+
+const (
+	// DefaultFooterPrefetchSize is the number of trailing bytes fetched
+	// with a single request when a RangeReader is created, which is
+	// enough to hold the footer of most Parquet files.
+	DefaultFooterPrefetchSize = 64 * 1024
+	// DefaultRangeHoleSize is the largest gap between two needed ranges
+	// that will still be fetched with one request.
+	DefaultRangeHoleSize = 1024 * 1024
+	// DefaultMaxCoalescedSize bounds the size of a single coalesced request.
+	DefaultMaxCoalescedSize = 64 * 1024 * 1024
+)
+
+// RandomAccessIO is the interface implemented by a file system that can
+// open a file purely for random access. Unlike Open, the returned File
+// does not start a sequential read of the whole object, every ReadAt is
+// served by a ranged request.
+type RandomAccessIO interface {
+	IO
+
+	OpenRandomAccess(name string) (File, error)
+}
+
+// OpenRandomAccess opens name with fsys.OpenRandomAccess if fsys
+// implements RandomAccessIO, and with fsys.Open otherwise.
+func OpenRandomAccess(fsys IO, name string) (File, error) {
+	if ra, ok := fsys.(RandomAccessIO); ok {
+		return ra.OpenRandomAccess(name)
+	}
+
+	return fsys.Open(name)
+}
+
+// Range is a byte range [Offset, Offset+Length) of a file.
+type Range struct {
+	Offset, Length int64
+}
+
+func (r Range) end() int64 { return r.Offset + r.Length }
+
+// CoalesceRanges sorts ranges and merges those that overlap or are
+// separated by at most holeSize bytes, as long as the merged range does
+// not exceed maxSize.
+func CoalesceRanges(ranges []Range, holeSize, maxSize int64) []Range {
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	sorted := slices.Clone(ranges)
+	slices.SortFunc(sorted, func(a, b Range) int {
+		switch {
+		case a.Offset < b.Offset:
+			return -1
+		case a.Offset > b.Offset:
+			return 1
+		}
+
+		return 0
+	})
+
+	out := []Range{sorted[0]}
+	for _, r := range sorted[1:] {
+		last := &out[len(out)-1]
+		end := max(last.end(), r.end())
+		if r.Offset <= last.end()+holeSize && end-last.Offset <= maxSize {
+			last.Length = end - last.Offset
+
+			continue
+		}
+		out = append(out, r)
+	}
+
+	return out
+}
+
+type rangeBuffer struct {
+	Range
+
+	// needed is the number of bytes the registered ranges expect to
+	// read from this buffer, once served the buffer is released.
+	needed, served int64
+
+	once sync.Once
+	data []byte
+	err  error
+}
+
+// RangeReader wraps a File to reduce the number of requests made
+// against an object store. The trailing bytes of the file are fetched
+// once up front, and ranges registered with WillNeed are coalesced and
+// fetched on first access. Reads that are not covered by either are
+// passed through to the underlying file.
+//
+// RangeReader implements File and is safe for concurrent use.
+type RangeReader struct {
+	File
+
+	size     int64
+	footer   []byte
+	holeSize int64
+	maxSize  int64
+
+	mx      sync.Mutex
+	pending []*rangeBuffer
+}
+
+// NewRangeReader wraps f, which must be size bytes long, and prefetches
+// the last footerSize bytes. A footerSize of zero disables prefetching.
+func NewRangeReader(f File, size int64, footerSize int64) (*RangeReader, error) {
+	r := &RangeReader{
+		File:     f,
+		size:     size,
+		holeSize: DefaultRangeHoleSize,
+		maxSize:  DefaultMaxCoalescedSize,
+	}
+
+	footerSize = min(footerSize, size)
+	if footerSize > 0 {
+		r.footer = make([]byte, footerSize)
+		if _, err := f.ReadAt(r.footer, size-footerSize); err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// Size returns the size of the underlying file.
+func (r *RangeReader) Size() int64 { return r.size }
+
+// WillNeed registers ranges that are about to be read. They are
+// coalesced and each merged range is fetched with a single request the
+// first time any part of it is read.
+func (r *RangeReader) WillNeed(ranges []Range) {
+	footerStart := r.size - int64(len(r.footer))
+	needed := make([]Range, 0, len(ranges))
+	for _, rng := range ranges {
+		// anything in the footer is already available
+		if rng.Length <= 0 || rng.Offset >= footerStart {
+			continue
+		}
+		needed = append(needed, rng)
+	}
+
+	coalesced := CoalesceRanges(needed, r.holeSize, r.maxSize)
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	for _, c := range coalesced {
+		buf := &rangeBuffer{Range: c}
+		for _, rng := range needed {
+			if rng.Offset >= c.Offset && rng.end() <= c.end() {
+				buf.needed += rng.Length
+			}
+		}
+		r.pending = append(r.pending, buf)
+	}
+}
+
+func (r *RangeReader) ReadAt(p []byte, off int64) (int, error) {
+	end := off + int64(len(p))
+	if footerStart := r.size - int64(len(r.footer)); len(r.footer) > 0 && off >= footerStart {
+		n := copy(p, r.footer[off-footerStart:])
+		if n < len(p) {
+			return n, io.EOF
+		}
+
+		return n, nil
+	}
+
+	r.mx.Lock()
+	idx := slices.IndexFunc(r.pending, func(b *rangeBuffer) bool {
+		return off >= b.Offset && end <= b.end()
+	})
+	if idx < 0 {
+		r.mx.Unlock()
+
+		return r.File.ReadAt(p, off)
+	}
+	buf := r.pending[idx]
+	r.mx.Unlock()
+
+	// fetch outside of the reader lock so that other buffers can be
+	// filled concurrently.
+	buf.once.Do(func() {
+		buf.data = make([]byte, buf.Length)
+		if _, err := r.File.ReadAt(buf.data, buf.Offset); err != nil && !errors.Is(err, io.EOF) {
+			buf.err = err
+		}
+	})
+	if buf.err != nil {
+		return 0, buf.err
+	}
+
+	n := copy(p, buf.data[off-buf.Offset:])
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	buf.served += int64(n)
+	if buf.served >= buf.needed {
+		r.pending = slices.DeleteFunc(r.pending, func(b *rangeBuffer) bool { return b == buf })
+	}
+
+	return n, nil
+}
+
+func (r *RangeReader) Stat() (fs.FileInfo, error) { return r.File.Stat() }
@@ -0,0 +1,181 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/fs"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/blob/memblob"
+)
+
+type countingFile struct {
+	*bytes.Reader
+
+	reads atomic.Int32
+}
+
+func (c *countingFile) ReadAt(p []byte, off int64) (int, error) {
+	c.reads.Add(1)
+
+	return c.Reader.ReadAt(p, off)
+}
+
+func (c *countingFile) Close() error               { return nil }
+func (c *countingFile) Stat() (fs.FileInfo, error) { return nil, nil }
+
+func TestCoalesceRanges(t *testing.T) {
+	assert.Nil(t, CoalesceRanges(nil, 10, 100))
+
+	ranges := []Range{
+		{Offset: 100, Length: 10},
+		{Offset: 0, Length: 10},
+		{Offset: 15, Length: 10},
+		{Offset: 20, Length: 2},
+		{Offset: 200, Length: 50},
+	}
+
+	assert.Equal(t, []Range{
+		{Offset: 0, Length: 25},
+		{Offset: 100, Length: 10},
+		{Offset: 200, Length: 50},
+	}, CoalesceRanges(ranges, 10, 1000))
+
+	assert.Equal(t, []Range{
+		{Offset: 0, Length: 110},
+		{Offset: 200, Length: 50},
+	}, CoalesceRanges(ranges, 80, 1000))
+
+	// the max size prevents merging
+	assert.Equal(t, []Range{
+		{Offset: 0, Length: 25},
+		{Offset: 100, Length: 10},
+		{Offset: 200, Length: 50},
+	}, CoalesceRanges(ranges, 100, 50))
+}
+
+func TestRangeReader(t *testing.T) {
+	data := make([]byte, 1000)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	f := &countingFile{Reader: bytes.NewReader(data)}
+	rdr, err := NewRangeReader(f, int64(len(data)), 100)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, f.reads.Load())
+	assert.EqualValues(t, len(data), rdr.Size())
+
+	// footer reads are served from the prefetched bytes
+	buf := make([]byte, 8)
+	n, err := rdr.ReadAt(buf, 992)
+	require.NoError(t, err)
+	assert.Equal(t, 8, n)
+	assert.Equal(t, data[992:], buf)
+	n, err = rdr.ReadAt(make([]byte, 60), 950)
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, 50, n)
+	assert.EqualValues(t, 1, f.reads.Load())
+
+	rdr.WillNeed([]Range{{Offset: 0, Length: 100}, {Offset: 110, Length: 40}, {Offset: 500, Length: 10}})
+
+	buf = make([]byte, 100)
+	_, err = rdr.ReadAt(buf, 0)
+	require.NoError(t, err)
+	assert.Equal(t, data[:100], buf)
+	assert.EqualValues(t, 2, f.reads.Load())
+
+	buf = make([]byte, 40)
+	_, err = rdr.ReadAt(buf, 110)
+	require.NoError(t, err)
+	assert.Equal(t, data[110:150], buf)
+	assert.EqualValues(t, 2, f.reads.Load(), "coalesced range should be served from memory")
+
+	buf = make([]byte, 10)
+	_, err = rdr.ReadAt(buf, 500)
+	require.NoError(t, err)
+	assert.Equal(t, data[500:510], buf)
+	assert.EqualValues(t, 2, f.reads.Load())
+
+	// once fully consumed the buffer is released and reads go through
+	_, err = rdr.ReadAt(buf, 110)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, f.reads.Load())
+
+	// unregistered ranges are read directly
+	buf = make([]byte, 10)
+	_, err = rdr.ReadAt(buf, 300)
+	require.NoError(t, err)
+	assert.Equal(t, data[300:310], buf)
+	assert.EqualValues(t, 4, f.reads.Load())
+}
+
+func TestBlobOpenRandomAccess(t *testing.T) {
+	ctx := context.Background()
+
+	bucket := memblob.OpenBucket(nil)
+	defer bucket.Close()
+
+	content := []byte("hello random access")
+	require.NoError(t, bucket.WriteAll(ctx, "test-file", content, nil))
+
+	bfs := &blobFileIO{
+		Bucket:       bucket,
+		keyExtractor: func(path string) (string, error) { return path, nil },
+		ctx:          ctx,
+	}
+
+	f, err := OpenRandomAccess(bfs, "test-file")
+	require.NoError(t, err)
+	defer f.Close()
+	require.IsType(t, &blobRangeFile{}, f)
+
+	info, err := f.Stat()
+	require.NoError(t, err)
+	assert.EqualValues(t, len(content), info.Size())
+	assert.Equal(t, "test-file", info.Name())
+
+	buf := make([]byte, 6)
+	n, err := f.ReadAt(buf, 6)
+	require.NoError(t, err)
+	assert.Equal(t, "random", string(buf[:n]))
+
+	n, err = f.ReadAt(make([]byte, 10), int64(len(content))-3)
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, 3, n)
+
+	end, err := f.Seek(-6, io.SeekEnd)
+	require.NoError(t, err)
+	assert.EqualValues(t, len(content)-6, end)
+	rest, err := io.ReadAll(f)
+	require.NoError(t, err)
+	assert.Equal(t, "access", string(rest))
+
+	_, err = OpenRandomAccess(bfs, "missing")
+	assert.Error(t, err)
+
+	local, err := OpenRandomAccess(LocalFS{}, "missing-local-file")
+	assert.Nil(t, local)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
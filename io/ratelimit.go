@@ -0,0 +1,255 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gocloud.dev/gcerrors"
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/time/rate"
+)
+
+// Constants for limiting the requests made to a host, such as an object
+// store bucket, so that highly parallel scans do not trip server side
+// throttling or exhaust connections.
+const (
+	// MaxConcurrentRequests caps the number of requests to a host that
+	// are in flight at the same time.
+	MaxConcurrentRequests = "io.max-concurrent-requests"
+	// MaxRequestsPerSecond caps the rate of requests to a host. Bursts
+	// of up to one second worth of requests are allowed.
+	MaxRequestsPerSecond = "io.max-requests-per-second"
+)
+
+// RateLimiterStats are counters of the requests made through a
+// RateLimiter.
+type RateLimiterStats struct {
+	// Requests is the number of requests let through.
+	Requests int64
+	// Delayed is the number of requests that waited for a concurrency
+	// slot or for the request rate to allow them.
+	Delayed int64
+	// WaitTime is the total time requests waited.
+	WaitTime time.Duration
+	// Throttled is the number of requests rejected by the server because
+	// of throttling, such as S3 503 SlowDown or HTTP 429 responses.
+	Throttled int64
+}
+
+// RateLimiter caps the concurrency and the rate of the requests made to a
+// host. A nil *RateLimiter does not limit requests.
+type RateLimiter struct {
+	sem     *semaphore.Weighted
+	limiter *rate.Limiter
+
+	requests  atomic.Int64
+	delayed   atomic.Int64
+	waitNs    atomic.Int64
+	throttled atomic.Int64
+}
+
+// NewRateLimiter returns a RateLimiter allowing at most maxConcurrent
+// requests in flight and qps requests per second. A zero value disables
+// the corresponding limit.
+func NewRateLimiter(maxConcurrent int, qps float64) *RateLimiter {
+	l := &RateLimiter{}
+	if maxConcurrent > 0 {
+		l.sem = semaphore.NewWeighted(int64(maxConcurrent))
+	}
+	if qps > 0 {
+		l.limiter = rate.NewLimiter(rate.Limit(qps), max(1, int(math.Ceil(qps))))
+	}
+
+	return l
+}
+
+// acquire waits until a request may be made. Every successful acquire
+// must be followed by a release once the request completes.
+func (l *RateLimiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	start := time.Now()
+	delayed := false
+	if l.sem != nil && !l.sem.TryAcquire(1) {
+		delayed = true
+		if err := l.sem.Acquire(ctx, 1); err != nil {
+			return err
+		}
+	}
+
+	if l.limiter != nil {
+		r := l.limiter.Reserve()
+		if delay := r.Delay(); delay > 0 {
+			delayed = true
+			if err := sleepCtx(ctx, delay); err != nil {
+				r.Cancel()
+				if l.sem != nil {
+					l.sem.Release(1)
+				}
+
+				return err
+			}
+		}
+	}
+
+	l.requests.Add(1)
+	if delayed {
+		l.delayed.Add(1)
+		l.waitNs.Add(int64(time.Since(start)))
+	}
+
+	return nil
+}
+
+// release frees the concurrency slot of a request that completed with
+// err and records whether the server throttled it.
+func (l *RateLimiter) release(err error) {
+	if l == nil {
+		return
+	}
+	if l.sem != nil {
+		l.sem.Release(1)
+	}
+	if isThrottled(err) {
+		l.throttled.Add(1)
+	}
+}
+
+// Stats returns the counters of the requests made through l.
+func (l *RateLimiter) Stats() RateLimiterStats {
+	if l == nil {
+		return RateLimiterStats{}
+	}
+
+	return RateLimiterStats{
+		Requests:  l.requests.Load(),
+		Delayed:   l.delayed.Load(),
+		WaitTime:  time.Duration(l.waitNs.Load()),
+		Throttled: l.throttled.Load(),
+	}
+}
+
+// isThrottled reports whether err is a rejection of a request because
+// of server side throttling.
+func isThrottled(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var statusErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &statusErr) {
+		code := statusErr.HTTPStatusCode()
+
+		return code == 429 || code == 503
+	}
+
+	return gcerrors.Code(err) == gcerrors.ResourceExhausted
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type rateLimiterKey struct {
+	host          string
+	maxConcurrent int
+	qps           float64
+}
+
+// rateLimiters are the limiters shared by the IOs of each host, so that
+// loading a FileIO for every table does not multiply the limits.
+var rateLimiters = struct {
+	mx sync.Mutex
+	m  map[rateLimiterKey]*RateLimiter
+}{m: make(map[rateLimiterKey]*RateLimiter)}
+
+// hostRateLimiter returns the RateLimiter shared by the IOs of host that
+// are configured with the same limits by props, or nil if props set no
+// limit.
+func hostRateLimiter(host string, props map[string]string) (*RateLimiter, error) {
+	key := rateLimiterKey{host: host}
+	if v, ok := props[MaxConcurrentRequests]; ok {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid value for %s: %q", MaxConcurrentRequests, v)
+		}
+		key.maxConcurrent = n
+	}
+	if v, ok := props[MaxRequestsPerSecond]; ok {
+		qps, err := strconv.ParseFloat(v, 64)
+		if err != nil || qps < 0 || math.IsInf(qps, 0) || math.IsNaN(qps) {
+			return nil, fmt.Errorf("invalid value for %s: %q", MaxRequestsPerSecond, v)
+		}
+		key.qps = qps
+	}
+
+	if key.maxConcurrent == 0 && key.qps == 0 {
+		return nil, nil
+	}
+
+	rateLimiters.mx.Lock()
+	defer rateLimiters.mx.Unlock()
+
+	l, ok := rateLimiters.m[key]
+	if !ok {
+		l = NewRateLimiter(key.maxConcurrent, key.qps)
+		rateLimiters.m[key] = l
+	}
+
+	return l, nil
+}
+
+// RateLimitStats returns the counters of the requests made to each host
+// whose IO limits requests with the io.max-concurrent-requests or
+// io.max-requests-per-second properties, keyed by scheme and host such as
+// "s3://bucket".
+func RateLimitStats() map[string]RateLimiterStats {
+	rateLimiters.mx.Lock()
+	defer rateLimiters.mx.Unlock()
+
+	stats := make(map[string]RateLimiterStats, len(rateLimiters.m))
+	for key, l := range rateLimiters.m {
+		s, cur := l.Stats(), stats[key.host]
+		stats[key.host] = RateLimiterStats{
+			Requests:  cur.Requests + s.Requests,
+			Delayed:   cur.Delayed + s.Delayed,
+			WaitTime:  cur.WaitTime + s.WaitTime,
+			Throttled: cur.Throttled + s.Throttled,
+		}
+	}
+
+	return stats
+}
@@ -0,0 +1,116 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterConcurrency(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 1, Limiter: NewRateLimiter(2, 0)}
+
+	var (
+		wg            sync.WaitGroup
+		inFlight, top atomic.Int32
+	)
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = policy.Do(context.Background(), func(context.Context) error {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					cur := top.Load()
+					if n <= cur || top.CompareAndSwap(cur, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, top.Load(), int32(2))
+	stats := policy.Limiter.Stats()
+	assert.EqualValues(t, 8, stats.Requests)
+	assert.Positive(t, stats.Delayed)
+	assert.Positive(t, stats.WaitTime)
+}
+
+func TestRateLimiterThrottled(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 3, Limiter: NewRateLimiter(0, 1000)}
+
+	calls := 0
+	err := policy.Do(context.Background(), func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return httpStatusError(503)
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	stats := policy.Limiter.Stats()
+	assert.EqualValues(t, 3, stats.Requests)
+	assert.EqualValues(t, 2, stats.Throttled)
+}
+
+func TestRateLimiterCanceled(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 1, Limiter: NewRateLimiter(0, 0.001)}
+	require.NoError(t, policy.Do(context.Background(), func(context.Context) error { return nil }))
+
+	// the single token is used, the next request waits far past the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := policy.Do(ctx, func(context.Context) error { return nil })
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestHostRateLimiter(t *testing.T) {
+	_, err := hostRateLimiter("s3://bucket", map[string]string{MaxConcurrentRequests: "-1"})
+	assert.ErrorContains(t, err, MaxConcurrentRequests)
+	_, err = hostRateLimiter("s3://bucket", map[string]string{MaxRequestsPerSecond: "fast"})
+	assert.ErrorContains(t, err, MaxRequestsPerSecond)
+
+	l, err := hostRateLimiter("s3://bucket", nil)
+	require.NoError(t, err)
+	assert.Nil(t, l)
+
+	props := map[string]string{MaxConcurrentRequests: "4", MaxRequestsPerSecond: "100"}
+	fsys, err := LoadFS(context.Background(), props, "mem://rate-limited/data")
+	require.NoError(t, err)
+	require.NoError(t, fsys.(WriteFileIO).WriteFile("mem://rate-limited/data", []byte("data")))
+
+	// IOs of the same host share their limiter
+	other, err := hostRateLimiter("mem://rate-limited", props)
+	require.NoError(t, err)
+	assert.Positive(t, other.Stats().Requests)
+	assert.Equal(t, other.Stats(), RateLimitStats()["mem://rate-limited"])
+}
@@ -0,0 +1,341 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// Constants for configuring read-ahead of sequentially read files
+const (
+	// ReadAheadBufferSize is the size in bytes of each read-ahead buffer.
+	// Read-ahead is enabled when it is set to a positive value.
+	ReadAheadBufferSize = "io.read-ahead.buffer-size-bytes"
+	// ReadAheadBuffers is the number of buffers fetched ahead of the
+	// reader, including the one being read.
+	ReadAheadBuffers = "io.read-ahead.buffers"
+)
+
+// ReadAheadBuffersDefault double buffers reads: the next buffer is
+// fetched while the current one is decoded.
+const ReadAheadBuffersDefault = 2
+
+// ReadAheadIO wraps an IO so that the files it opens are read ahead of
+// the reader. While one buffer is consumed, the following ones are fetched
+// in the background with ranged reads, which overlaps network IO with the
+// decoding of Avro manifests and of Parquet files read in full. Files
+// opened with OpenRandomAccess are not read ahead.
+type ReadAheadIO struct {
+	IO
+
+	bufSize int64
+	buffers int
+}
+
+// NewReadAheadIO wraps fsys so that its files are read ahead by buffers
+// buffers of bufSize bytes.
+func NewReadAheadIO(fsys IO, bufSize int64, buffers int) *ReadAheadIO {
+	return &ReadAheadIO{IO: fsys, bufSize: max(bufSize, 1), buffers: max(buffers, 1)}
+}
+
+// Unwrap returns the wrapped IO.
+func (r *ReadAheadIO) Unwrap() IO { return r.IO }
+
+// Open opens name for random access in the wrapped IO and reads it ahead
+// of the reader.
+func (r *ReadAheadIO) Open(name string) (File, error) {
+	f, err := OpenRandomAccess(r.IO, name)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+
+		return nil, err
+	}
+
+	return newReadAheadFile(f, info.Size(), r.bufSize, r.buffers), nil
+}
+
+func (r *ReadAheadIO) OpenRandomAccess(name string) (File, error) {
+	return OpenRandomAccess(r.IO, name)
+}
+
+func (r *ReadAheadIO) ReadFile(name string) ([]byte, error) {
+	if rf, ok := r.IO.(ReadFileIO); ok {
+		return rf.ReadFile(name)
+	}
+
+	f, err := r.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(f)
+
+	return data, errors.Join(err, f.Close())
+}
+
+func (r *ReadAheadIO) Create(name string) (FileWriter, error) {
+	wfs, ok := r.IO.(WriteFileIO)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not support writing", errors.ErrUnsupported, r.IO)
+	}
+
+	return wfs.Create(name)
+}
+
+func (r *ReadAheadIO) WriteFile(name string, p []byte) error {
+	wfs, ok := r.IO.(WriteFileIO)
+	if !ok {
+		return fmt.Errorf("%w: %T does not support writing", errors.ErrUnsupported, r.IO)
+	}
+
+	return wfs.WriteFile(name, p)
+}
+
+func (r *ReadAheadIO) WriteFileExclusive(name string, p []byte) error {
+	return WriteFileExclusive(r.IO, name, p)
+}
+
+// readAheadBuffer is a range of a file fetched in the background.
+type readAheadBuffer struct {
+	off  int64
+	span int64
+	data []byte
+	err  error
+	done chan struct{}
+}
+
+func (b *readAheadBuffer) end() int64 { return b.off + int64(len(b.data)) }
+
+// readAheadFile serves the reads of a File from buffers fetched ahead of
+// the reader. Buffers cover consecutive ranges starting at the offset of
+// the last read that missed them, a read past the fetched buffers starts
+// over from its offset. Read and ReadAt are both served from the buffers.
+type readAheadFile struct {
+	File
+
+	size    int64
+	bufSize int64
+	buffers int
+
+	mx      sync.Mutex
+	pending []*readAheadBuffer
+	pos     int64
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+func newReadAheadFile(f File, size, bufSize int64, buffers int) *readAheadFile {
+	return &readAheadFile{File: f, size: size, bufSize: bufSize, buffers: buffers}
+}
+
+// fetch starts reading the buffer at off in the background. It must be
+// called with mx held.
+func (f *readAheadFile) fetch(off int64) *readAheadBuffer {
+	b := &readAheadBuffer{
+		off:  off,
+		span: min(f.bufSize, f.size-off),
+		done: make(chan struct{}),
+	}
+
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		defer close(b.done)
+
+		data := make([]byte, b.span)
+		n, err := f.File.ReadAt(data, off)
+		if err == io.EOF && n == len(data) {
+			err = nil
+		}
+		b.data, b.err = data[:n], err
+	}()
+
+	return b
+}
+
+// buffer returns the buffer holding off, dropping the buffers before it
+// and keeping the read-ahead window full.
+func (f *readAheadFile) buffer(off int64) (*readAheadBuffer, error) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	if f.closed {
+		return nil, fs.ErrClosed
+	}
+
+	idx := -1
+	for i, b := range f.pending {
+		// buffers still being fetched are assumed to be filled
+		if off >= b.off && off < b.off+b.span {
+			idx = i
+
+			break
+		}
+	}
+
+	if idx < 0 {
+		f.pending = append(f.pending[:0], f.fetch(off))
+	} else {
+		f.pending = f.pending[idx:]
+	}
+
+	for len(f.pending) < f.buffers {
+		last := f.pending[len(f.pending)-1]
+		next := last.off + last.span
+		if next >= f.size {
+			break
+		}
+		f.pending = append(f.pending, f.fetch(next))
+	}
+
+	return f.pending[0], nil
+}
+
+// discard drops the failed buffer b, along with the buffers after it, so
+// that the next read fetches it again.
+func (f *readAheadFile) discard(b *readAheadBuffer) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	if idx := slices.Index(f.pending, b); idx >= 0 {
+		f.pending = slices.Delete(f.pending, idx, len(f.pending))
+	}
+}
+
+func (f *readAheadFile) ReadAt(p []byte, off int64) (n int, err error) {
+	if off < 0 {
+		return 0, fs.ErrInvalid
+	}
+
+	for len(p) > 0 {
+		if off >= f.size {
+			return n, io.EOF
+		}
+
+		b, err := f.buffer(off)
+		if err != nil {
+			return n, err
+		}
+		<-b.done
+
+		if off < b.end() {
+			copied := copy(p, b.data[off-b.off:])
+			n, off, p = n+copied, off+int64(copied), p[copied:]
+
+			continue
+		}
+
+		if b.err != nil {
+			f.discard(b)
+
+			return n, b.err
+		}
+
+		// the file is shorter than its reported size
+		return n, io.ErrUnexpectedEOF
+	}
+
+	return n, nil
+}
+
+func (f *readAheadFile) Read(p []byte) (int, error) {
+	f.mx.Lock()
+	pos, closed := f.pos, f.closed
+	f.mx.Unlock()
+	if closed {
+		return 0, fs.ErrClosed
+	}
+
+	n, err := f.ReadAt(p, pos)
+
+	f.mx.Lock()
+	f.pos = pos + int64(n)
+	f.mx.Unlock()
+
+	if n > 0 && errors.Is(err, io.EOF) {
+		err = nil
+	}
+
+	return n, err
+}
+
+func (f *readAheadFile) Seek(offset int64, whence int) (int64, error) {
+	f.mx.Lock()
+	defer f.mx.Unlock()
+
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.pos
+	case io.SeekEnd:
+		offset += f.size
+	default:
+		return 0, fs.ErrInvalid
+	}
+	if offset < 0 {
+		return 0, fs.ErrInvalid
+	}
+	f.pos = offset
+
+	return offset, nil
+}
+
+// Close waits for the buffers being fetched and closes the file.
+func (f *readAheadFile) Close() error {
+	f.mx.Lock()
+	if f.closed {
+		f.mx.Unlock()
+
+		return fs.ErrClosed
+	}
+	f.closed, f.pending = true, nil
+	f.mx.Unlock()
+
+	f.wg.Wait()
+
+	return f.File.Close()
+}
+
+// readAheadFromProps returns the buffer size and count configured by the
+// io.read-ahead.* properties, with a zero size if read-ahead is disabled.
+func readAheadFromProps(props map[string]string) (bufSize int64, buffers int, err error) {
+	buffers = ReadAheadBuffersDefault
+
+	if v, ok := props[ReadAheadBufferSize]; ok {
+		if bufSize, err = strconv.ParseInt(v, 10, 64); err != nil || bufSize < 0 {
+			return 0, 0, fmt.Errorf("invalid value for %s: %q", ReadAheadBufferSize, v)
+		}
+	}
+	if v, ok := props[ReadAheadBuffers]; ok {
+		if buffers, err = strconv.Atoi(v); err != nil || buffers <= 0 {
+			return 0, 0, fmt.Errorf("invalid value for %s: %q", ReadAheadBuffers, v)
+		}
+	}
+
+	return bufSize, buffers, nil
+}
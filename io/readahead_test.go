@@ -0,0 +1,143 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakyFile fails the ReadAt calls at failAt once.
+type flakyFile struct {
+	countingFile
+
+	failAt atomic.Int64
+}
+
+func (f *flakyFile) ReadAt(p []byte, off int64) (int, error) {
+	if f.failAt.CompareAndSwap(off, -1) {
+		return 0, errors.New("connection reset")
+	}
+
+	return f.countingFile.ReadAt(p, off)
+}
+
+func TestReadAheadFile(t *testing.T) {
+	data := make([]byte, 1000)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	t.Run("sequential", func(t *testing.T) {
+		src := &countingFile{Reader: bytes.NewReader(data)}
+		f := newReadAheadFile(src, int64(len(data)), 64, 2)
+
+		var out bytes.Buffer
+		buf := make([]byte, 10)
+		for {
+			n, err := f.Read(buf)
+			out.Write(buf[:n])
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+		}
+		assert.Equal(t, data, out.Bytes())
+		// every byte is fetched exactly once
+		assert.EqualValues(t, 16, src.reads.Load())
+		require.NoError(t, f.Close())
+
+		_, err := f.Read(buf)
+		assert.ErrorIs(t, err, fs.ErrClosed)
+	})
+
+	t.Run("random access", func(t *testing.T) {
+		src := &countingFile{Reader: bytes.NewReader(data)}
+		f := newReadAheadFile(src, int64(len(data)), 64, 2)
+		defer f.Close()
+
+		// reads spanning several buffers
+		buf := make([]byte, 200)
+		n, err := f.ReadAt(buf, 500)
+		require.NoError(t, err)
+		assert.Equal(t, data[500:700], buf[:n])
+
+		n, err = f.ReadAt(buf, 900)
+		assert.ErrorIs(t, err, io.EOF)
+		assert.Equal(t, data[900:], buf[:n])
+
+		_, err = f.Seek(-5, io.SeekEnd)
+		require.NoError(t, err)
+		rest, err := io.ReadAll(f)
+		require.NoError(t, err)
+		assert.Equal(t, data[995:], rest)
+	})
+
+	t.Run("failed fetch", func(t *testing.T) {
+		src := &flakyFile{countingFile: countingFile{Reader: bytes.NewReader(data)}}
+		src.failAt.Store(64)
+		f := newReadAheadFile(src, int64(len(data)), 64, 2)
+		defer f.Close()
+
+		buf := make([]byte, 100)
+		n, err := f.ReadAt(buf, 0)
+		assert.ErrorContains(t, err, "connection reset")
+		assert.Equal(t, data[:n], buf[:n])
+
+		// the failed buffer is fetched again
+		n, err = f.ReadAt(buf, 0)
+		require.NoError(t, err)
+		assert.Equal(t, data[:100], buf[:n])
+	})
+}
+
+func TestLoadFSReadAhead(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "data.bin")
+	content := bytes.Repeat([]byte("iceberg"), 100)
+	require.NoError(t, LocalFS{}.WriteFile(name, content))
+
+	_, err := LoadFS(context.Background(), map[string]string{ReadAheadBuffers: "0", ReadAheadBufferSize: "1"}, name)
+	assert.ErrorContains(t, err, ReadAheadBuffers)
+
+	fsys, err := LoadFS(context.Background(), map[string]string{ReadAheadBufferSize: "100"}, name)
+	require.NoError(t, err)
+	require.IsType(t, &ReadAheadIO{}, fsys)
+	assert.Equal(t, LocalFS{}, fsys.(*ReadAheadIO).Unwrap())
+
+	f, err := fsys.Open(name)
+	require.NoError(t, err)
+	got, err := io.ReadAll(f)
+	require.NoError(t, err)
+	assert.Equal(t, content, got)
+	require.NoError(t, f.Close())
+
+	require.NoError(t, fsys.(WriteFileIO).WriteFile(name, []byte("updated")))
+	got, err = fsys.(ReadFileIO).ReadFile(name)
+	require.NoError(t, err)
+	assert.Equal(t, "updated", string(got))
+}
@@ -0,0 +1,187 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"sync"
+)
+
+// ResolvingIO is an IO that resolves the file system of each file from
+// the scheme and bucket of its location instead of using a single file
+// system for a whole table, so that tables whose files are spread across
+// file systems, such as s3:// and gs:// after a migration, can still be
+// read and written. The IO of each scheme and bucket is loaded with LoadFS
+// on first use and reused afterwards. Locations without a scheme use the
+// IO of the table location.
+type ResolvingIO struct {
+	ctx       context.Context
+	props     map[string]string
+	defaultIO IO
+
+	mx           sync.Mutex
+	fsByLocation map[string]IO
+}
+
+// NewResolvingIO returns a ResolvingIO loading file systems with props,
+// which eagerly loads the file system of location.
+func NewResolvingIO(ctx context.Context, props map[string]string, location string) (*ResolvingIO, error) {
+	defaultIO, err := LoadFS(ctx, props, location)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &ResolvingIO{
+		ctx:          ctx,
+		props:        props,
+		defaultIO:    defaultIO,
+		fsByLocation: make(map[string]IO),
+	}
+	if key := locationKey(location); key != "" {
+		r.fsByLocation[key] = defaultIO
+	}
+
+	return r, nil
+}
+
+// locationKey returns the scheme and bucket of location, or an empty
+// string if location has no scheme.
+func locationKey(location string) string {
+	scheme, rest, found := strings.Cut(location, "://")
+	if !found || scheme == "" {
+		return ""
+	}
+	bucket, _, _ := strings.Cut(rest, "/")
+
+	return strings.ToLower(scheme) + "://" + bucket
+}
+
+// Resolve returns the IO of the file system holding name.
+func (r *ResolvingIO) Resolve(name string) (IO, error) {
+	key := locationKey(name)
+	if key == "" {
+		return r.defaultIO, nil
+	}
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	if fsys, ok := r.fsByLocation[key]; ok {
+		return fsys, nil
+	}
+
+	fsys, err := LoadFS(r.ctx, r.props, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load IO for %s: %w", key, err)
+	}
+	r.fsByLocation[key] = fsys
+
+	return fsys, nil
+}
+
+func (r *ResolvingIO) Open(name string) (File, error) {
+	fsys, err := r.Resolve(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	return fsys.Open(name)
+}
+
+func (r *ResolvingIO) ReadFile(name string) ([]byte, error) {
+	fsys, err := r.Resolve(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	if rf, ok := fsys.(ReadFileIO); ok {
+		return rf.ReadFile(name)
+	}
+
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(f)
+
+	return data, errors.Join(err, f.Close())
+}
+
+func (r *ResolvingIO) OpenRandomAccess(name string) (File, error) {
+	fsys, err := r.Resolve(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	return OpenRandomAccess(fsys, name)
+}
+
+func (r *ResolvingIO) Create(name string) (FileWriter, error) {
+	wfs, err := r.resolveWrite(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return wfs.Create(name)
+}
+
+func (r *ResolvingIO) WriteFile(name string, p []byte) error {
+	wfs, err := r.resolveWrite(name)
+	if err != nil {
+		return err
+	}
+
+	return wfs.WriteFile(name, p)
+}
+
+func (r *ResolvingIO) WriteFileExclusive(name string, p []byte) error {
+	fsys, err := r.Resolve(name)
+	if err != nil {
+		return err
+	}
+
+	return WriteFileExclusive(fsys, name, p)
+}
+
+func (r *ResolvingIO) resolveWrite(name string) (WriteFileIO, error) {
+	fsys, err := r.Resolve(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "create", Path: name, Err: err}
+	}
+
+	wfs, ok := fsys.(WriteFileIO)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T does not support writing", errors.ErrUnsupported, fsys)
+	}
+
+	return wfs, nil
+}
+
+func (r *ResolvingIO) Remove(name string) error {
+	fsys, err := r.Resolve(name)
+	if err != nil {
+		return &fs.PathError{Op: "remove", Path: name, Err: err}
+	}
+
+	return fsys.Remove(name)
+}
@@ -0,0 +1,92 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocationKey(t *testing.T) {
+	assert.Equal(t, "s3://bucket", locationKey("s3://bucket/warehouse/data.parquet"))
+	assert.Equal(t, "gs://bucket", locationKey("GS://bucket/data.parquet"))
+	assert.Equal(t, "file://", locationKey("file:///tmp/data.parquet"))
+	assert.Equal(t, "", locationKey("/tmp/data.parquet"))
+	assert.Equal(t, "", locationKey("relative/data.parquet"))
+}
+
+func TestResolvingIO(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.ToSlash(t.TempDir())
+
+	r, err := NewResolvingIO(ctx, nil, dir)
+	require.NoError(t, err)
+
+	// files of the same table spread across file systems
+	files := map[string]string{
+		dir + "/local.txt":         "local",
+		"mem://bucket-a/a.txt":     "bucket a",
+		"mem://bucket-b/b.txt":     "bucket b",
+		"file://" + dir + "/f.txt": "file scheme",
+	}
+	for name, content := range files {
+		require.NoError(t, r.WriteFile(name, []byte(content)))
+	}
+
+	for name, content := range files {
+		data, err := r.ReadFile(name)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(data))
+
+		f, err := r.OpenRandomAccess(name)
+		require.NoError(t, err)
+		buf := make([]byte, len(content))
+		_, err = f.ReadAt(buf, 0)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(buf))
+		require.NoError(t, f.Close())
+	}
+
+	a1, err := r.Resolve("mem://bucket-a/other.txt")
+	require.NoError(t, err)
+	a2, err := r.Resolve("mem://bucket-a/a.txt")
+	require.NoError(t, err)
+	b, err := r.Resolve("mem://bucket-b/b.txt")
+	require.NoError(t, err)
+	assert.Same(t, a1, a2)
+	assert.NotSame(t, a1, b)
+
+	local, err := r.Resolve(dir + "/local.txt")
+	require.NoError(t, err)
+	assert.Equal(t, LocalFS{}, local)
+
+	require.NoError(t, r.Remove("mem://bucket-b/b.txt"))
+	_, err = r.Open("mem://bucket-b/b.txt")
+	assert.Error(t, err)
+
+	_, err = r.Open("unknown://bucket/file.txt")
+	var pathErr *fs.PathError
+	require.True(t, errors.As(err, &pathErr))
+	assert.Equal(t, "open", pathErr.Op)
+}
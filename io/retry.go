@@ -0,0 +1,315 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"gocloud.dev/gcerrors"
+)
+
+// Constants for configuring retries of object store requests
+const (
+	RetryMaxAttempts             = "io.retry.max-attempts"
+	RetryInitialBackoffMs        = "io.retry.initial-backoff-ms"
+	RetryMaxBackoffMs            = "io.retry.max-backoff-ms"
+	RequestTimeoutMs             = "io.request-timeout-ms"
+	CircuitBreakerThreshold      = "io.circuit-breaker.failure-threshold"
+	CircuitBreakerResetTimeoutMs = "io.circuit-breaker.reset-timeout-ms"
+)
+
+const (
+	RetryMaxAttemptsDefault    = 3
+	RetryInitialBackoffDefault = 100 * time.Millisecond
+	RetryMaxBackoffDefault     = 5 * time.Second
+	CircuitBreakerResetDefault = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned without contacting the object store when
+// too many consecutive requests have failed with transient errors.
+var ErrCircuitOpen = errors.New("circuit breaker open: too many consecutive object store failures")
+
+// RetryPolicy controls how FileIO operations against object stores are
+// retried when they fail with a transient error. A nil *RetryPolicy
+// performs each operation exactly once.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	MaxAttempts int
+	// InitialBackoff is the upper bound of the delay before the first
+	// retry. It doubles for each subsequent retry up to MaxBackoff.
+	// The actual delay is chosen uniformly at random below the bound.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// RequestTimeout, if non-zero, bounds each individual attempt.
+	RequestTimeout time.Duration
+	// FailureThreshold, if non-zero, is the number of consecutive
+	// failed attempts after which the circuit breaker opens and
+	// requests fail fast with ErrCircuitOpen for ResetTimeout.
+	FailureThreshold int
+	ResetTimeout     time.Duration
+	// Limiter, if non-nil, caps the concurrency and rate of the
+	// attempts. An attempt holds its concurrency slot until fn returns.
+	Limiter *RateLimiter
+
+	mx        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+// DefaultRetryPolicy returns the policy used when no retry properties
+// are set.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    RetryMaxAttemptsDefault,
+		InitialBackoff: RetryInitialBackoffDefault,
+		MaxBackoff:     RetryMaxBackoffDefault,
+		ResetTimeout:   CircuitBreakerResetDefault,
+	}
+}
+
+// ParseRetryPolicy builds a RetryPolicy from the io.retry.*,
+// io.request-timeout-ms and io.circuit-breaker.* properties, using the
+// defaults for any that are unset.
+func ParseRetryPolicy(props map[string]string) (*RetryPolicy, error) {
+	p := DefaultRetryPolicy()
+
+	parseInt := func(key string, dst *int) error {
+		v, ok := props[key]
+		if !ok {
+			return nil
+		}
+
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid value for %s: %q", key, v)
+		}
+		*dst = n
+
+		return nil
+	}
+
+	parseMs := func(key string, dst *time.Duration) error {
+		var ms int
+		if _, ok := props[key]; !ok {
+			return nil
+		}
+		if err := parseInt(key, &ms); err != nil {
+			return err
+		}
+		*dst = time.Duration(ms) * time.Millisecond
+
+		return nil
+	}
+
+	err := errors.Join(
+		parseInt(RetryMaxAttempts, &p.MaxAttempts),
+		parseMs(RetryInitialBackoffMs, &p.InitialBackoff),
+		parseMs(RetryMaxBackoffMs, &p.MaxBackoff),
+		parseMs(RequestTimeoutMs, &p.RequestTimeout),
+		parseInt(CircuitBreakerThreshold, &p.FailureThreshold),
+		parseMs(CircuitBreakerResetTimeoutMs, &p.ResetTimeout),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.MaxAttempts == 0 {
+		p.MaxAttempts = 1
+	}
+
+	return p, nil
+}
+
+// IsRetryable reports whether err is a transient object store failure
+// that may succeed if the request is repeated: throttling, 5xx
+// responses, timeouts and dropped connections.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	var statusErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &statusErr) {
+		code := statusErr.HTTPStatusCode()
+
+		return code == 429 || code >= 500
+	}
+
+	switch gcerrors.Code(err) {
+	case gcerrors.ResourceExhausted, gcerrors.DeadlineExceeded, gcerrors.Internal:
+		return true
+	}
+
+	return false
+}
+
+// Do calls fn until it succeeds, fails with an error that is not
+// retryable, or the attempts are exhausted. Each attempt receives a
+// context bounded by RequestTimeout. Waiting between attempts stops
+// early if ctx is done.
+func (p *RetryPolicy) Do(ctx context.Context, fn func(context.Context) error) error {
+	return p.do(ctx, true, fn)
+}
+
+// do is Do with the option of not applying RequestTimeout, for requests
+// such as opening a streaming reader whose result outlives the call.
+func (p *RetryPolicy) do(ctx context.Context, withTimeout bool, fn func(context.Context) error) error {
+	if p == nil {
+		return fn(ctx)
+	}
+
+	var err error
+	for attempt := range max(p.MaxAttempts, 1) {
+		if attempt > 0 {
+			if err := p.sleep(ctx, attempt); err != nil {
+				return errors.Join(err, ctx.Err())
+			}
+		}
+
+		if err := p.allow(); err != nil {
+			return err
+		}
+
+		if err := p.Limiter.acquire(ctx); err != nil {
+			return err
+		}
+		if withTimeout {
+			err = p.attempt(ctx, fn)
+		} else {
+			err = fn(ctx)
+		}
+		p.Limiter.release(err)
+		// only the parent context being done should stop retries, a
+		// deadline from RequestTimeout is treated as a transient error.
+		if err == nil || ctx.Err() != nil || !IsRetryable(err) {
+			p.record(err == nil || !IsRetryable(err))
+
+			return err
+		}
+
+		p.record(false)
+	}
+
+	return err
+}
+
+// doExclusive retries fn, a conditional create of content, like do. If
+// the response to an attempt that created the file is lost, the retry
+// fails because the file exists, as reported by exists. The file is then
+// read back with read, and the create succeeds if it holds content.
+func (p *RetryPolicy) doExclusive(ctx context.Context, content []byte, exists func(error) bool,
+	read func(context.Context) ([]byte, error), fn func(context.Context) error,
+) error {
+	attempts := 0
+
+	return p.do(ctx, true, func(ctx context.Context) error {
+		attempts++
+		err := fn(ctx)
+		if err == nil || attempts == 1 || !exists(err) {
+			return err
+		}
+
+		if stored, readErr := read(ctx); readErr == nil && bytes.Equal(stored, content) {
+			return nil
+		}
+
+		return err
+	})
+}
+
+func (p *RetryPolicy) attempt(ctx context.Context, fn func(context.Context) error) error {
+	if p.RequestTimeout <= 0 {
+		return fn(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.RequestTimeout)
+	defer cancel()
+
+	return fn(ctx)
+}
+
+func (p *RetryPolicy) sleep(ctx context.Context, attempt int) error {
+	backoff := p.InitialBackoff << (attempt - 1)
+	if backoff <= 0 || (p.MaxBackoff > 0 && backoff > p.MaxBackoff) {
+		backoff = p.MaxBackoff
+	}
+	if backoff <= 0 {
+		return nil
+	}
+
+	return sleepCtx(ctx, rand.N(backoff))
+}
+
+func (p *RetryPolicy) allow() error {
+	if p.FailureThreshold <= 0 {
+		return nil
+	}
+
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if !p.openUntil.IsZero() && time.Now().Before(p.openUntil) {
+		return ErrCircuitOpen
+	}
+
+	return nil
+}
+
+func (p *RetryPolicy) record(success bool) {
+	if p.FailureThreshold <= 0 {
+		return
+	}
+
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	if success {
+		p.failures, p.openUntil = 0, time.Time{}
+
+		return
+	}
+
+	p.failures++
+	if p.failures >= p.FailureThreshold {
+		// after ResetTimeout a single request is let through, one more
+		// failure re-opens the circuit.
+		p.openUntil = time.Now().Add(p.ResetTimeout)
+		p.failures = p.FailureThreshold - 1
+	}
+}
@@ -0,0 +1,289 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/blob/memblob"
+)
+
+type httpStatusError int
+
+func (e httpStatusError) Error() string       { return fmt.Sprintf("http status %d", int(e)) }
+func (e httpStatusError) HTTPStatusCode() int { return int(e) }
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err       error
+		retryable bool
+	}{
+		{nil, false},
+		{errors.New("boom"), false},
+		{io.ErrUnexpectedEOF, false},
+		{context.Canceled, false},
+		{context.DeadlineExceeded, true},
+		{fmt.Errorf("read: %w", syscall.ECONNRESET), true},
+		{httpStatusError(503), true},
+		{httpStatusError(500), true},
+		{httpStatusError(429), true},
+		{httpStatusError(404), false},
+		{httpStatusError(403), false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.retryable, IsRetryable(tt.err), "%v", tt.err)
+	}
+}
+
+func TestParseRetryPolicy(t *testing.T) {
+	p, err := ParseRetryPolicy(map[string]string{})
+	require.NoError(t, err)
+	assert.Equal(t, RetryMaxAttemptsDefault, p.MaxAttempts)
+	assert.Equal(t, RetryInitialBackoffDefault, p.InitialBackoff)
+	assert.Zero(t, p.RequestTimeout)
+	assert.Zero(t, p.FailureThreshold)
+
+	p, err = ParseRetryPolicy(map[string]string{
+		RetryMaxAttempts:             "5",
+		RetryInitialBackoffMs:        "10",
+		RetryMaxBackoffMs:            "200",
+		RequestTimeoutMs:             "1500",
+		CircuitBreakerThreshold:      "4",
+		CircuitBreakerResetTimeoutMs: "1000",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, p.MaxAttempts)
+	assert.Equal(t, 10*time.Millisecond, p.InitialBackoff)
+	assert.Equal(t, 200*time.Millisecond, p.MaxBackoff)
+	assert.Equal(t, 1500*time.Millisecond, p.RequestTimeout)
+	assert.Equal(t, 4, p.FailureThreshold)
+	assert.Equal(t, time.Second, p.ResetTimeout)
+
+	_, err = ParseRetryPolicy(map[string]string{RetryMaxAttempts: "-1"})
+	assert.ErrorContains(t, err, RetryMaxAttempts)
+	_, err = ParseRetryPolicy(map[string]string{RequestTimeoutMs: "soon"})
+	assert.ErrorContains(t, err, RequestTimeoutMs)
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	ctx := context.Background()
+	p := &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	t.Run("succeeds after transient failures", func(t *testing.T) {
+		calls := 0
+		err := p.Do(ctx, func(context.Context) error {
+			calls++
+			if calls < 3 {
+				return httpStatusError(503)
+			}
+
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		calls := 0
+		err := p.Do(ctx, func(context.Context) error {
+			calls++
+
+			return httpStatusError(503)
+		})
+		assert.Equal(t, httpStatusError(503), err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("does not retry permanent errors", func(t *testing.T) {
+		calls := 0
+		err := p.Do(ctx, func(context.Context) error {
+			calls++
+
+			return httpStatusError(404)
+		})
+		assert.Equal(t, httpStatusError(404), err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("nil policy runs once", func(t *testing.T) {
+		var nilPolicy *RetryPolicy
+		calls := 0
+		err := nilPolicy.Do(ctx, func(context.Context) error {
+			calls++
+
+			return httpStatusError(503)
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("request timeout", func(t *testing.T) {
+		p := &RetryPolicy{MaxAttempts: 2, RequestTimeout: 10 * time.Millisecond}
+		calls := 0
+		err := p.Do(ctx, func(ctx context.Context) error {
+			calls++
+			if calls == 1 {
+				<-ctx.Done()
+
+				return ctx.Err()
+			}
+
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("parent context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		calls := 0
+		err := p.Do(ctx, func(context.Context) error {
+			calls++
+			cancel()
+
+			return httpStatusError(503)
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestRetryPolicyDoExclusive(t *testing.T) {
+	ctx := context.Background()
+	p := &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	exists := func(err error) bool { return errors.Is(err, fs.ErrExist) }
+
+	// create stores content like an object store, and loses the response
+	// to the first lost attempts that succeeded
+	create := func(stored *[]byte, lost int) func(context.Context) error {
+		return func(context.Context) error {
+			if *stored != nil {
+				return fs.ErrExist
+			}
+			*stored = []byte("content")
+			if lost > 0 {
+				lost--
+
+				return httpStatusError(504)
+			}
+
+			return nil
+		}
+	}
+
+	t.Run("lost response of a successful create", func(t *testing.T) {
+		var stored []byte
+		reads := 0
+		err := p.doExclusive(ctx, []byte("content"), exists, func(context.Context) ([]byte, error) {
+			reads++
+
+			return stored, nil
+		}, create(&stored, 1))
+		require.NoError(t, err)
+		assert.Equal(t, 1, reads)
+	})
+
+	t.Run("file created by another writer", func(t *testing.T) {
+		stored := []byte("other")
+		reads := 0
+		err := p.doExclusive(ctx, []byte("content"), exists, func(context.Context) ([]byte, error) {
+			reads++
+
+			return stored, nil
+		}, create(&stored, 0))
+		assert.ErrorIs(t, err, fs.ErrExist)
+		assert.Zero(t, reads, "the first attempt cannot have created the file")
+	})
+
+	t.Run("file replaced before the retry", func(t *testing.T) {
+		var stored []byte
+		err := p.doExclusive(ctx, []byte("content"), exists, func(context.Context) ([]byte, error) {
+			return []byte("other"), nil
+		}, create(&stored, 1))
+		assert.ErrorIs(t, err, fs.ErrExist)
+	})
+}
+
+func TestRetryPolicyCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	p := &RetryPolicy{MaxAttempts: 1, FailureThreshold: 2, ResetTimeout: 50 * time.Millisecond}
+
+	fail := func(context.Context) error { return httpStatusError(503) }
+	succeed := func(context.Context) error { return nil }
+
+	assert.Equal(t, httpStatusError(503), p.Do(ctx, fail))
+	assert.Equal(t, httpStatusError(503), p.Do(ctx, fail))
+	assert.ErrorIs(t, p.Do(ctx, succeed), ErrCircuitOpen)
+
+	time.Sleep(60 * time.Millisecond)
+	// a single failure while half-open trips the breaker again
+	assert.Equal(t, httpStatusError(503), p.Do(ctx, fail))
+	assert.ErrorIs(t, p.Do(ctx, succeed), ErrCircuitOpen)
+
+	time.Sleep(60 * time.Millisecond)
+	require.NoError(t, p.Do(ctx, succeed))
+	require.NoError(t, p.Do(ctx, succeed))
+}
+
+func TestBlobReadAtRetries(t *testing.T) {
+	ctx := context.Background()
+
+	bucket := memblob.OpenBucket(nil)
+	defer bucket.Close()
+
+	content := []byte("hello world")
+	require.NoError(t, bucket.WriteAll(ctx, "test-file", content, nil))
+
+	failures := 2
+	bfs := &blobFileIO{
+		Bucket:       bucket,
+		keyExtractor: func(path string) (string, error) { return path, nil },
+		ctx:          ctx,
+		retry:        &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond},
+		newRangeReader: func(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+			if failures > 0 {
+				failures--
+
+				return nil, httpStatusError(503)
+			}
+
+			return bucket.NewRangeReader(ctx, key, offset, length, nil)
+		},
+	}
+
+	f, err := bfs.Open("test-file")
+	require.NoError(t, err)
+	defer f.Close()
+
+	buf := make([]byte, 5)
+	n, err := f.ReadAt(buf, 6)
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+	assert.Equal(t, "world", string(buf))
+	assert.Zero(t, failures)
+}
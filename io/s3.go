@@ -19,7 +19,6 @@ package io
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -48,15 +47,20 @@ const (
 	S3ProxyURI               = "s3.proxy-uri"
 	S3ConnectTimeout         = "s3.connect-timeout"
 	S3SignerUri              = "s3.signer.uri"
+	S3SignerEndpoint         = "s3.signer.endpoint"
 	S3RemoteSigningEnabled   = "s3.remote-signing-enabled"
 	S3ForceVirtualAddressing = "s3.force-virtual-addressing"
+	S3PathStyleAccess        = "s3.path-style-access"
 )
 
 var unsupportedS3Props = []string{
 	S3ConnectTimeout,
 }
 
-// ParseAWSConfig parses S3 properties and returns a configuration.
+// ParseAWSConfig parses S3 properties and returns a configuration. When
+// s3.remote-signing-enabled is set, the configuration has anonymous
+// credentials, since requests are signed by the catalog's signing
+// endpoint instead.
 func ParseAWSConfig(ctx context.Context, props map[string]string) (*aws.Config, error) {
 	// If any unsupported properties are set, return an error.
 	for k := range props {
@@ -65,11 +69,9 @@ func ParseAWSConfig(ctx context.Context, props map[string]string) (*aws.Config,
 		}
 	}
 
-	// Remote S3 request signing is not implemented yet.
-	if v, ok := props[S3RemoteSigningEnabled]; ok {
-		if enabled, err := strconv.ParseBool(v); err == nil && enabled {
-			return nil, errors.New("remote S3 request signing is not supported")
-		}
+	signer, err := newS3RemoteSigner(props)
+	if err != nil {
+		return nil, err
 	}
 
 	opts := []func(*config.LoadOptions) error{}
@@ -87,7 +89,9 @@ func ParseAWSConfig(ctx context.Context, props map[string]string) (*aws.Config,
 
 	accessKey, secretAccessKey := props[S3AccessKeyID], props[S3SecretAccessKey]
 	token := props[S3SessionToken]
-	if accessKey != "" || secretAccessKey != "" || token != "" {
+	if signer != nil {
+		opts = append(opts, config.WithCredentialsProvider(aws.AnonymousCredentials{}))
+	} else if accessKey != "" || secretAccessKey != "" || token != "" {
 		opts = append(opts, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
 			props[S3AccessKeyID], props[S3SecretAccessKey], props[S3SessionToken])))
 	}
@@ -106,7 +110,6 @@ func ParseAWSConfig(ctx context.Context, props map[string]string) (*aws.Config,
 	}
 
 	awscfg := new(aws.Config)
-	var err error
 	*awscfg, err = config.LoadDefaultConfig(ctx, opts...)
 	if err != nil {
 		return nil, err
@@ -115,13 +118,57 @@ func ParseAWSConfig(ctx context.Context, props map[string]string) (*aws.Config,
 	return awscfg, nil
 }
 
+// applyS3Overrides returns a copy of cfg with the region and static
+// credentials replaced by any that are explicitly set in props. This
+// allows per-table credentials to take precedence over a config that
+// was supplied through the context, e.g. by the Glue catalog.
+func applyS3Overrides(cfg *aws.Config, props map[string]string) *aws.Config {
+	out := cfg.Copy()
+	if region, ok := props[S3Region]; ok {
+		out.Region = region
+	} else if region, ok := props["client.region"]; ok {
+		out.Region = region
+	}
+
+	accessKey, secretAccessKey := props[S3AccessKeyID], props[S3SecretAccessKey]
+	if accessKey != "" && secretAccessKey != "" {
+		out.Credentials = aws.NewCredentialsCache(credentials.NewStaticCredentialsProvider(
+			accessKey, secretAccessKey, props[S3SessionToken]))
+	}
+
+	return &out
+}
+
+// s3UsePathStyle determines the addressing style from props. Path style
+// is used by default since it is required by most S3 compatible stores
+// such as MinIO. An explicit s3.path-style-access takes precedence over
+// s3.force-virtual-addressing.
+func s3UsePathStyle(props map[string]string) (bool, error) {
+	if v, ok := props[S3PathStyleAccess]; ok {
+		pathStyle, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, fmt.Errorf("invalid value for %s: %q", S3PathStyleAccess, v)
+		}
+
+		return pathStyle, nil
+	}
+
+	if forceVirtual, ok := props[S3ForceVirtualAddressing]; ok {
+		if cfgForceVirtual, err := strconv.ParseBool(forceVirtual); err == nil {
+			return !cfgForceVirtual, nil
+		}
+	}
+
+	return true, nil
+}
+
 func createS3Bucket(ctx context.Context, parsed *url.URL, props map[string]string) (*blob.Bucket, error) {
 	var (
 		awscfg *aws.Config
 		err    error
 	)
 	if v := utils.GetAwsConfig(ctx); v != nil {
-		awscfg = v
+		awscfg = applyS3Overrides(v, props)
 	} else {
 		awscfg, err = ParseAWSConfig(ctx, props)
 		if err != nil {
@@ -134,11 +181,14 @@ func createS3Bucket(ctx context.Context, parsed *url.URL, props map[string]strin
 		endpoint = os.Getenv("AWS_S3_ENDPOINT")
 	}
 
-	usePathStyle := true
-	if forceVirtual, ok := props[S3ForceVirtualAddressing]; ok {
-		if cfgForceVirtual, err := strconv.ParseBool(forceVirtual); err == nil {
-			usePathStyle = !cfgForceVirtual
-		}
+	usePathStyle, err := s3UsePathStyle(props)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := newS3RemoteSigner(props)
+	if err != nil {
+		return nil, err
 	}
 
 	client := s3.NewFromConfig(*awscfg, func(o *s3.Options) {
@@ -147,6 +197,11 @@ func createS3Bucket(ctx context.Context, parsed *url.URL, props map[string]strin
 		}
 		o.UsePathStyle = usePathStyle
 		o.DisableLogOutputChecksumValidationSkipped = true
+		if signer != nil {
+			signer.region = o.Region
+			o.Credentials = aws.AnonymousCredentials{}
+			o.APIOptions = append(o.APIOptions, signer.addMiddleware)
+		}
 	})
 
 	// Create a *blob.Bucket.
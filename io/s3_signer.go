@@ -0,0 +1,184 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// defaultS3SignerEndpoint is the path of the REST catalog's S3 signing
+// endpoint, relative to the signer URI.
+const defaultS3SignerEndpoint = "v1/aws/s3/sign"
+
+// s3SignRequest is the body of a request to the S3 signing endpoint.
+type s3SignRequest struct {
+	Region  string              `json:"region"`
+	URI     string              `json:"uri"`
+	Method  string              `json:"method"`
+	Headers map[string][]string `json:"headers"`
+}
+
+// s3SignResponse is the body of a response of the S3 signing endpoint,
+// holding the URI and headers of the signed request.
+type s3SignResponse struct {
+	URI     string              `json:"uri"`
+	Headers map[string][]string `json:"headers"`
+}
+
+// s3RemoteSigner signs S3 requests by sending them to the signing
+// endpoint of a REST catalog instead of signing them with local
+// credentials.
+type s3RemoteSigner struct {
+	client   *http.Client
+	endpoint string
+	token    string
+	region   string
+}
+
+// newS3RemoteSigner returns the remote signer configured by props, or nil
+// if s3.remote-signing-enabled is not set. The signing endpoint is
+// s3.signer.endpoint resolved against s3.signer.uri, which defaults to the
+// catalog uri, and requests are authorized with the catalog token.
+func newS3RemoteSigner(props map[string]string) (*s3RemoteSigner, error) {
+	v, ok := props[S3RemoteSigningEnabled]
+	if !ok {
+		return nil, nil
+	}
+
+	enabled, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %s: %q", S3RemoteSigningEnabled, v)
+	}
+	if !enabled {
+		return nil, nil
+	}
+
+	base, ok := props[S3SignerUri]
+	if !ok {
+		base = props["uri"]
+	}
+	if base == "" {
+		return nil, fmt.Errorf("remote S3 request signing requires %s", S3SignerUri)
+	}
+
+	path, ok := props[S3SignerEndpoint]
+	if !ok {
+		path = defaultS3SignerEndpoint
+	}
+
+	endpoint, err := url.JoinPath(base, path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 signer uri %q: %w", base, err)
+	}
+
+	return &s3RemoteSigner{
+		client:   http.DefaultClient,
+		endpoint: endpoint,
+		token:    props["token"],
+	}, nil
+}
+
+// ID identifies the signer in the middleware stack of the S3 client.
+func (s *s3RemoteSigner) ID() string { return "IcebergRemoteSigning" }
+
+// addMiddleware adds the signer to the end of the finalize step, after
+// the SDK signing that is skipped for anonymous credentials, so that
+// every attempt of a request is signed.
+func (s *s3RemoteSigner) addMiddleware(stack *middleware.Stack) error {
+	return stack.Finalize.Add(s, middleware.After)
+}
+
+func (s *s3RemoteSigner) HandleFinalize(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+	req, ok := in.Request.(*smithyhttp.Request)
+	if !ok {
+		return middleware.FinalizeOutput{}, middleware.Metadata{},
+			fmt.Errorf("unexpected S3 request type %T", in.Request)
+	}
+
+	if err := s.sign(ctx, req.Request); err != nil {
+		return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+	}
+
+	return next.HandleFinalize(ctx, in)
+}
+
+// sign replaces the URI of req and sets its headers to those returned by
+// the signing endpoint.
+func (s *s3RemoteSigner) sign(ctx context.Context, req *http.Request) error {
+	if req.Header.Get("X-Amz-Content-Sha256") == "" {
+		req.Header.Set("X-Amz-Content-Sha256", "UNSIGNED-PAYLOAD")
+	}
+
+	body, err := json.Marshal(s3SignRequest{
+		Region:  s.region,
+		URI:     req.URL.String(),
+		Method:  req.Method,
+		Headers: req.Header,
+	})
+	if err != nil {
+		return err
+	}
+
+	signReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	signReq.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		signReq.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	rsp, err := s.client.Do(signReq)
+	if err != nil {
+		return fmt.Errorf("failed to sign S3 request: %w", err)
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(rsp.Body, 1024))
+
+		return fmt.Errorf("failed to sign S3 request: %s: %s", rsp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var signed s3SignResponse
+	if err := json.NewDecoder(rsp.Body).Decode(&signed); err != nil {
+		return fmt.Errorf("invalid S3 signing response: %w", err)
+	}
+
+	if signed.URI != "" {
+		if req.URL, err = url.Parse(signed.URI); err != nil {
+			return fmt.Errorf("invalid signed S3 uri %q: %w", signed.URI, err)
+		}
+	}
+	for k, v := range signed.Headers {
+		req.Header[http.CanonicalHeaderKey(k)] = v
+	}
+
+	return nil
+}
@@ -0,0 +1,124 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewS3RemoteSigner(t *testing.T) {
+	t.Parallel()
+
+	signer, err := newS3RemoteSigner(map[string]string{S3RemoteSigningEnabled: "false"})
+	require.NoError(t, err)
+	assert.Nil(t, signer)
+
+	signer, err = newS3RemoteSigner(map[string]string{
+		S3RemoteSigningEnabled: "true",
+		"uri":                  "https://catalog.example.com/api",
+		"token":                "tok",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://catalog.example.com/api/v1/aws/s3/sign", signer.endpoint)
+	assert.Equal(t, "tok", signer.token)
+
+	signer, err = newS3RemoteSigner(map[string]string{
+		S3RemoteSigningEnabled: "true",
+		"uri":                  "https://catalog.example.com",
+		S3SignerUri:            "https://signer.example.com",
+		S3SignerEndpoint:       "sign",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://signer.example.com/sign", signer.endpoint)
+
+	_, err = newS3RemoteSigner(map[string]string{S3RemoteSigningEnabled: "maybe"})
+	assert.ErrorContains(t, err, S3RemoteSigningEnabled)
+}
+
+func TestS3RemoteSigning(t *testing.T) {
+	t.Parallel()
+
+	var signed []s3SignRequest
+	signerSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/aws/s3/sign" || r.Header.Get("Authorization") != "Bearer tok" {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		var req s3SignRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+		signed = append(signed, req)
+
+		headers := maps.Clone(req.Headers)
+		headers["Authorization"] = []string{"AWS4-HMAC-SHA256 remote"}
+		_ = json.NewEncoder(w).Encode(s3SignResponse{URI: req.URI, Headers: headers})
+	}))
+	defer signerSrv.Close()
+
+	s3Srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "AWS4-HMAC-SHA256 remote" {
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+		if r.Method != http.MethodGet || r.URL.Path != "/bucket/key" {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+		_, _ = w.Write([]byte("hello"))
+	}))
+	defer s3Srv.Close()
+
+	ctx := context.Background()
+	bucket, err := createS3Bucket(ctx, &url.URL{Scheme: "s3", Host: "bucket"}, map[string]string{
+		S3EndpointURL:          s3Srv.URL,
+		S3Region:               "us-east-1",
+		S3AccessKeyID:          "access",
+		S3SecretAccessKey:      "secret",
+		S3RemoteSigningEnabled: "true",
+		S3SignerUri:            signerSrv.URL,
+		"token":                "tok",
+	})
+	require.NoError(t, err)
+	defer bucket.Close()
+
+	data, err := bucket.ReadAll(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	require.NotEmpty(t, signed)
+	assert.Equal(t, "us-east-1", signed[0].Region)
+	assert.Equal(t, http.MethodGet, signed[0].Method)
+	assert.Contains(t, signed[0].URI, s3Srv.URL+"/bucket/key")
+	assert.NotContains(t, signed[0].Headers, "Authorization")
+}
@@ -21,6 +21,8 @@ import (
 	"context"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -31,11 +33,14 @@ func TestParseAWSConfigRemoteSigningEnabled(t *testing.T) {
 	t.Run("signer uri present with remote signing explicitly enabled", func(t *testing.T) {
 		t.Parallel()
 
-		_, err := ParseAWSConfig(context.Background(), map[string]string{
+		cfg, err := ParseAWSConfig(context.Background(), map[string]string{
 			S3SignerUri:            "https://signer.example.com",
 			S3RemoteSigningEnabled: "true",
+			S3AccessKeyID:          "access",
+			S3SecretAccessKey:      "secret",
 		})
-		require.ErrorContains(t, err, "remote S3 request signing is not supported")
+		require.NoError(t, err)
+		assert.True(t, aws.IsCredentialsProvider(cfg.Credentials, aws.AnonymousCredentials{}))
 	})
 
 	t.Run("signer uri present with remote signing explicitly disabled", func(t *testing.T) {
@@ -65,7 +70,7 @@ func TestParseAWSConfigRemoteSigningEnabled(t *testing.T) {
 		_, err := ParseAWSConfig(context.Background(), map[string]string{
 			S3RemoteSigningEnabled: "true",
 		})
-		require.ErrorContains(t, err, "remote S3 request signing is not supported")
+		require.ErrorContains(t, err, "remote S3 request signing requires s3.signer.uri")
 	})
 
 	t.Run("no signer properties at all", func(t *testing.T) {
@@ -87,3 +92,64 @@ func TestParseAWSConfigUnsupportedProperty(t *testing.T) {
 	})
 	require.ErrorContains(t, err, "unsupported S3 property")
 }
+
+func TestS3UsePathStyle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		props    map[string]string
+		expected bool
+	}{
+		{"default", map[string]string{}, true},
+		{"force virtual", map[string]string{S3ForceVirtualAddressing: "true"}, false},
+		{"path style disabled", map[string]string{S3PathStyleAccess: "false"}, false},
+		{"path style enabled", map[string]string{S3PathStyleAccess: "true"}, true},
+		{"path style wins", map[string]string{
+			S3PathStyleAccess:        "true",
+			S3ForceVirtualAddressing: "true",
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usePathStyle, err := s3UsePathStyle(tt.props)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, usePathStyle)
+		})
+	}
+
+	_, err := s3UsePathStyle(map[string]string{S3PathStyleAccess: "maybe"})
+	assert.ErrorContains(t, err, S3PathStyleAccess)
+}
+
+func TestApplyS3Overrides(t *testing.T) {
+	t.Parallel()
+
+	base := &aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("catalog-key", "catalog-secret", ""),
+	}
+
+	cfg := applyS3Overrides(base, map[string]string{})
+	assert.Equal(t, "us-east-1", cfg.Region)
+	creds, err := cfg.Credentials.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "catalog-key", creds.AccessKeyID)
+
+	cfg = applyS3Overrides(base, map[string]string{
+		S3Region:          "eu-central-1",
+		S3AccessKeyID:     "table-key",
+		S3SecretAccessKey: "table-secret",
+		S3SessionToken:    "table-token",
+	})
+	assert.Equal(t, "eu-central-1", cfg.Region)
+	creds, err = cfg.Credentials.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "table-key", creds.AccessKeyID)
+	assert.Equal(t, "table-secret", creds.SecretAccessKey)
+	assert.Equal(t, "table-token", creds.SessionToken)
+
+	// the original config must not be modified
+	assert.Equal(t, "us-east-1", base.Region)
+}
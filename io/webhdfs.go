@@ -0,0 +1,486 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Constants for WebHDFS configuration options
+const (
+	// HDFSWebHDFSEndpoint is the base URL of the WebHDFS REST API, such as
+	// "https://namenode:9871". When unset, it is derived from the location
+	// of each file: webhdfs:// and swebhdfs:// locations are served by
+	// their own host over http and https, and hdfs:// locations by the
+	// default NameNode HTTP port of their host.
+	HDFSWebHDFSEndpoint = "hdfs.webhdfs.endpoint"
+	// HDFSUser is the user requests are made as when the cluster uses
+	// simple authentication.
+	HDFSUser = "hdfs.user"
+	// HDFSDelegationToken is a delegation token authenticating requests
+	// to a secured (Kerberos) cluster, which takes precedence over
+	// HDFSUser.
+	HDFSDelegationToken = "hdfs.delegation-token"
+)
+
+// defaultWebHDFSPort is the default HTTP port of the NameNode, which serves
+// the WebHDFS API of hdfs:// locations.
+const defaultWebHDFSPort = "9870"
+
+// NegotiateTokenFunc returns the SPNEGO token sent in the
+// "Authorization: Negotiate" header of a request to host, which allows
+// authenticating to a Kerberos secured cluster with a ticket obtained by
+// the caller.
+type NegotiateTokenFunc func(ctx context.Context, host string) (string, error)
+
+// WebHDFSOption configures a WebHDFS.
+type WebHDFSOption func(*WebHDFS)
+
+// WithNegotiateToken authenticates the requests of a WebHDFS with the
+// SPNEGO tokens returned by fn.
+func WithNegotiateToken(fn NegotiateTokenFunc) WebHDFSOption {
+	return func(h *WebHDFS) {
+		h.negotiate = fn
+	}
+}
+
+// WebHDFS is an IO for files stored in HDFS, accessed through the WebHDFS
+// REST API of the cluster so that no native client is required. It serves
+// hdfs://, webhdfs:// and swebhdfs:// locations.
+//
+// Requests are authenticated with the user of HDFSUser on clusters with
+// simple authentication. Kerberos secured clusters are accessed with the
+// delegation token of HDFSDelegationToken, with SPNEGO tokens provided by
+// WithNegotiateToken, or with an Authorization header set through the
+// HTTPHeaderPrefix properties. Failed requests are retried according to
+// the retry properties, see ParseRetryPolicy.
+type WebHDFS struct {
+	ctx       context.Context
+	client    *http.Client
+	header    http.Header
+	retry     *RetryPolicy
+	endpoint  *url.URL
+	user      string
+	token     string
+	negotiate NegotiateTokenFunc
+}
+
+// NewWebHDFS returns a WebHDFS making requests with client, or
+// http.DefaultClient if client is nil, configured by props.
+func NewWebHDFS(ctx context.Context, client *http.Client, props map[string]string, opts ...WebHDFSOption) (*WebHDFS, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	retry, err := ParseRetryPolicy(props)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &WebHDFS{
+		ctx:    ctx,
+		client: client,
+		header: make(http.Header),
+		retry:  retry,
+		user:   props[HDFSUser],
+		token:  props[HDFSDelegationToken],
+	}
+
+	if endpoint := props[HDFSWebHDFSEndpoint]; endpoint != "" {
+		if h.endpoint, err = url.Parse(strings.TrimSuffix(endpoint, "/")); err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", HDFSWebHDFSEndpoint, endpoint, err)
+		}
+	}
+
+	for k, v := range props {
+		if name, ok := strings.CutPrefix(k, HTTPHeaderPrefix); ok && name != "" {
+			h.header.Set(name, v)
+		}
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h, nil
+}
+
+// webHDFSError is the error of a WebHDFS request that failed, which
+// carries the Java exception reported by the cluster.
+type webHDFSError struct {
+	code      int
+	status    string
+	exception string
+	message   string
+}
+
+func (e *webHDFSError) Error() string {
+	if e.exception == "" {
+		return "unexpected HTTP status " + e.status
+	}
+
+	return fmt.Sprintf("%s: %s (HTTP status %s)", e.exception, e.message, e.status)
+}
+
+func (e *webHDFSError) HTTPStatusCode() int { return e.code }
+
+func (e *webHDFSError) Unwrap() error {
+	switch {
+	case e.code == http.StatusNotFound || e.exception == "FileNotFoundException":
+		return fs.ErrNotExist
+	case e.exception == "FileAlreadyExistsException":
+		return fs.ErrExist
+	case e.code == http.StatusForbidden || e.exception == "AccessControlException":
+		return fs.ErrPermission
+	}
+
+	return nil
+}
+
+// newWebHDFSError reads the RemoteException of a failed response.
+func newWebHDFSError(resp *http.Response) error {
+	var body struct {
+		RemoteException struct {
+			Exception string `json:"exception"`
+			Message   string `json:"message"`
+		} `json:"RemoteException"`
+	}
+	// the body is informative only, a missing or malformed one is ignored
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&body)
+
+	return &webHDFSError{
+		code:      resp.StatusCode,
+		status:    resp.Status,
+		exception: body.RemoteException.Exception,
+		message:   body.RemoteException.Message,
+	}
+}
+
+// operationURL returns the URL of the WebHDFS operation op on the file at
+// location, with the query parameters params.
+func (h *WebHDFS) operationURL(location, op string, params url.Values) (*url.URL, error) {
+	parsed, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+
+	base := h.endpoint
+	if base == nil {
+		if parsed.Host == "" {
+			return nil, fmt.Errorf("%s must be set for location without a host: %s", HDFSWebHDFSEndpoint, location)
+		}
+
+		switch parsed.Scheme {
+		case "webhdfs":
+			base = &url.URL{Scheme: "http", Host: parsed.Host}
+		case "swebhdfs":
+			base = &url.URL{Scheme: "https", Host: parsed.Host}
+		default:
+			base = &url.URL{Scheme: "http", Host: net.JoinHostPort(parsed.Hostname(), defaultWebHDFSPort)}
+		}
+	}
+
+	if params == nil {
+		params = make(url.Values)
+	}
+	params.Set("op", op)
+	switch {
+	case h.token != "":
+		params.Set("delegation", h.token)
+	case h.user != "":
+		params.Set("user.name", h.user)
+	}
+
+	u := *base
+	u.Path = strings.TrimSuffix(base.Path, "/") + "/webhdfs/v1" + parsed.Path
+	u.RawPath = ""
+	u.RawQuery = params.Encode()
+
+	return &u, nil
+}
+
+// send makes a single request of the operation op on the file at location
+// and returns the response if its status is one of ok. Redirects, such as
+// those from the NameNode to a DataNode, are followed by the client. The
+// caller must close the body of the response.
+func (h *WebHDFS) send(ctx context.Context, client *http.Client, method, location, op string, params url.Values, body []byte, ok ...int) (*http.Response, error) {
+	u, err := h.operationURL(location, op, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.sendURL(ctx, client, method, u.String(), body, ok...)
+}
+
+func (h *WebHDFS) sendURL(ctx context.Context, client *http.Client, method, target string, body []byte, ok ...int) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range h.header {
+		req.Header[k] = v
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+	if h.negotiate != nil {
+		token, err := h.negotiate(ctx, req.URL.Hostname())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get SPNEGO token for %s: %w", req.URL.Host, err)
+		}
+		req.Header.Set("Authorization", "Negotiate "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, code := range ok {
+		if resp.StatusCode == code {
+			return resp, nil
+		}
+	}
+	defer resp.Body.Close()
+
+	return nil, newWebHDFSError(resp)
+}
+
+// do sends a request with send, retrying transient failures.
+func (h *WebHDFS) do(ctx context.Context, method, location, op string, params url.Values, body []byte, ok ...int) (resp *http.Response, err error) {
+	err = h.retry.do(ctx, false, func(ctx context.Context) error {
+		resp, err = h.send(ctx, h.client, method, location, op, params, body, ok...)
+
+		return err
+	})
+
+	return resp, err
+}
+
+// fileStatus is the FileStatus returned by the GETFILESTATUS operation.
+type fileStatus struct {
+	Length           int64  `json:"length"`
+	ModificationTime int64  `json:"modificationTime"`
+	Type             string `json:"type"`
+}
+
+func (h *WebHDFS) Open(name string) (File, error) {
+	resp, err := h.do(h.ctx, http.MethodGet, name, "GETFILESTATUS", nil, nil, http.StatusOK)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	defer resp.Body.Close()
+
+	var status struct {
+		FileStatus fileStatus `json:"FileStatus"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fmt.Errorf("invalid file status: %w", err)}
+	}
+	if status.FileStatus.Type == "DIRECTORY" {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: errors.New("is a directory")}
+	}
+
+	return &httpFile{
+		name: name, size: status.FileStatus.Length,
+		modTime: time.UnixMilli(status.FileStatus.ModificationTime),
+		src:     h, ctx: h.ctx, retry: h.retry,
+	}, nil
+}
+
+// OpenRandomAccess opens name like Open, whose files are already read
+// with ranged requests.
+func (h *WebHDFS) OpenRandomAccess(name string) (File, error) {
+	return h.Open(name)
+}
+
+func (h *WebHDFS) ReadFile(name string) ([]byte, error) {
+	resp, err := h.do(h.ctx, http.MethodGet, name, "OPEN", nil, nil, http.StatusOK)
+	if err != nil {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: err}
+	}
+	defer resp.Body.Close()
+
+	return io.ReadAll(resp.Body)
+}
+
+// openRange implements rangeOpener.
+func (h *WebHDFS) openRange(ctx context.Context, name string, off, length int64) (io.ReadCloser, error) {
+	params := url.Values{"offset": {strconv.FormatInt(off, 10)}}
+	if length >= 0 {
+		params.Set("length", strconv.FormatInt(length, 10))
+	}
+
+	resp, err := h.send(ctx, h.client, http.MethodGet, name, "OPEN", params, nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (h *WebHDFS) Remove(name string) error {
+	resp, err := h.do(h.ctx, http.MethodDelete, name, "DELETE", url.Values{"recursive": {"false"}}, nil, http.StatusOK)
+	if err != nil {
+		return &fs.PathError{Op: "remove", Path: name, Err: err}
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Boolean bool `json:"boolean"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return &fs.PathError{Op: "remove", Path: name, Err: fmt.Errorf("invalid delete result: %w", err)}
+	}
+	if !result.Boolean {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return nil
+}
+
+// Create returns a writer buffering the contents of name, which are
+// uploaded when the writer is closed.
+func (h *WebHDFS) Create(name string) (FileWriter, error) {
+	return &webHDFSWriter{fs: h, name: name}, nil
+}
+
+// WriteFile creates or overwrites name with p.
+func (h *WebHDFS) WriteFile(name string, p []byte) error {
+	if p == nil {
+		p = []byte{}
+	}
+
+	err := h.retry.do(h.ctx, false, func(ctx context.Context) error {
+		return h.create(ctx, name, p, true)
+	})
+	if err != nil {
+		return &fs.PathError{Op: "write", Path: name, Err: err}
+	}
+
+	return nil
+}
+
+// WriteFileExclusive creates name with p, failing if it already exists.
+func (h *WebHDFS) WriteFileExclusive(name string, p []byte) error {
+	if p == nil {
+		p = []byte{}
+	}
+
+	exists := func(err error) bool { return errors.Is(err, fs.ErrExist) }
+	read := func(ctx context.Context) ([]byte, error) {
+		resp, err := h.send(ctx, h.client, http.MethodGet, name, "OPEN", nil, nil, http.StatusOK)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		return io.ReadAll(resp.Body)
+	}
+
+	err := h.retry.doExclusive(h.ctx, p, exists, read, func(ctx context.Context) error {
+		return h.create(ctx, name, p, false)
+	})
+	if err != nil {
+		return &fs.PathError{Op: "write", Path: name, Err: err}
+	}
+
+	return nil
+}
+
+// create uploads p to name with the two steps of the CREATE operation:
+// the NameNode redirects the request to the DataNode that receives the
+// data.
+func (h *WebHDFS) create(ctx context.Context, name string, p []byte, overwrite bool) error {
+	noRedirect := *h.client
+	noRedirect.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	resp, err := h.send(ctx, &noRedirect, http.MethodPut, name, "CREATE",
+		url.Values{"overwrite": {strconv.FormatBool(overwrite)}}, nil, http.StatusTemporaryRedirect, http.StatusCreated)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusCreated {
+		return errors.New("the NameNode created the file without redirecting to a DataNode")
+	}
+
+	location, err := resp.Location()
+	if err != nil {
+		return fmt.Errorf("invalid DataNode redirect: %w", err)
+	}
+
+	resp, err = h.sendURL(ctx, h.client, http.MethodPut, location.String(), p, http.StatusCreated)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+// webHDFSWriter buffers the contents of a file written to a WebHDFS.
+type webHDFSWriter struct {
+	fs     *WebHDFS
+	name   string
+	buf    bytes.Buffer
+	closed bool
+}
+
+func (w *webHDFSWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, fs.ErrClosed
+	}
+
+	return w.buf.Write(p)
+}
+
+func (w *webHDFSWriter) ReadFrom(r io.Reader) (int64, error) {
+	if w.closed {
+		return 0, fs.ErrClosed
+	}
+
+	return w.buf.ReadFrom(r)
+}
+
+func (w *webHDFSWriter) Close() error {
+	if w.closed {
+		return fs.ErrClosed
+	}
+	w.closed = true
+
+	return w.fs.WriteFile(w.name, w.buf.Bytes())
+}
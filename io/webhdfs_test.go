@@ -0,0 +1,220 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package io
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeWebHDFS serves the subset of the WebHDFS REST API used by WebHDFS
+// from memory, redirecting writes to a fake DataNode endpoint.
+type fakeWebHDFS struct {
+	mu     sync.Mutex
+	files  map[string][]byte
+	tokens []string
+	// lostWrites is the number of DataNode writes whose response is
+	// replaced with a gateway timeout after storing the data
+	lostWrites int
+}
+
+func (s *fakeWebHDFS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if name, ok := strings.CutPrefix(r.URL.Path, "/datanode"); ok {
+		data, _ := io.ReadAll(r.Body)
+		s.files[name] = data
+		if s.lostWrites > 0 {
+			s.lostWrites--
+			w.WriteHeader(http.StatusGatewayTimeout)
+
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+
+		return
+	}
+
+	q := r.URL.Query()
+	s.tokens = append(s.tokens, q.Get("delegation"))
+
+	name := strings.TrimPrefix(r.URL.Path, "/webhdfs/v1")
+	data, exists := s.files[name]
+	notFound := func() {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"RemoteException":{"exception":"FileNotFoundException","message":"File does not exist: ` + name + `"}}`))
+	}
+
+	switch q.Get("op") {
+	case "GETFILESTATUS":
+		if !exists {
+			notFound()
+
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"FileStatus": map[string]any{
+			"length": len(data), "modificationTime": 1700000000000, "type": "FILE",
+		}})
+	case "OPEN":
+		if !exists {
+			notFound()
+
+			return
+		}
+		off, _ := strconv.Atoi(q.Get("offset"))
+		end := len(data)
+		if l := q.Get("length"); l != "" {
+			n, _ := strconv.Atoi(l)
+			end = min(end, off+n)
+		}
+		_, _ = w.Write(data[off:end])
+	case "CREATE":
+		if exists && q.Get("overwrite") == "false" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"RemoteException":{"exception":"FileAlreadyExistsException","message":"` + name + ` already exists"}}`))
+
+			return
+		}
+		http.Redirect(w, r, "/datanode"+name, http.StatusTemporaryRedirect)
+	case "DELETE":
+		delete(s.files, name)
+		_ = json.NewEncoder(w).Encode(map[string]bool{"boolean": exists})
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func TestWebHDFS(t *testing.T) {
+	server := &fakeWebHDFS{files: map[string][]byte{}}
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	base := "webhdfs://" + strings.TrimPrefix(srv.URL, "http://") + "/warehouse"
+	props := map[string]string{HDFSDelegationToken: "token", HDFSUser: "iceberg"}
+	fsys, err := LoadFS(context.Background(), props, base+"/data.bin")
+	require.NoError(t, err)
+	require.IsType(t, &WebHDFS{}, fsys)
+
+	content := []byte(strings.Repeat("0123456789", 100))
+	w, err := fsys.(WriteFileIO).Create(base + "/data.bin")
+	require.NoError(t, err)
+	_, err = w.Write(content[:500])
+	require.NoError(t, err)
+	_, err = w.Write(content[500:])
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.Equal(t, content, server.files["/warehouse/data.bin"])
+
+	err = WriteFileExclusive(fsys, base+"/data.bin", []byte("other"))
+	assert.ErrorIs(t, err, fs.ErrExist)
+	assert.Equal(t, content, server.files["/warehouse/data.bin"])
+
+	f, err := OpenRandomAccess(fsys, base+"/data.bin")
+	require.NoError(t, err)
+	info, err := f.Stat()
+	require.NoError(t, err)
+	assert.EqualValues(t, len(content), info.Size())
+	assert.EqualValues(t, 1700000000000, info.ModTime().UnixMilli())
+
+	buf := make([]byte, 4)
+	n, err := f.ReadAt(buf, 13)
+	require.NoError(t, err)
+	assert.Equal(t, "3456", string(buf[:n]))
+
+	_, err = f.Seek(-3, io.SeekEnd)
+	require.NoError(t, err)
+	rest, err := io.ReadAll(f)
+	require.NoError(t, err)
+	assert.Equal(t, "789", string(rest))
+	require.NoError(t, f.Close())
+
+	data, err := fsys.(ReadFileIO).ReadFile(base + "/data.bin")
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+
+	require.NoError(t, fsys.Remove(base+"/data.bin"))
+	assert.ErrorIs(t, fsys.Remove(base+"/data.bin"), fs.ErrNotExist)
+
+	_, err = fsys.Open(base + "/data.bin")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	assert.ErrorContains(t, err, "FileNotFoundException")
+
+	// the delegation token takes precedence over the user
+	require.NotEmpty(t, server.tokens)
+	for _, token := range server.tokens {
+		assert.Equal(t, "token", token)
+	}
+}
+
+func TestWebHDFSWriteFileExclusiveLostResponse(t *testing.T) {
+	server := &fakeWebHDFS{files: map[string][]byte{}, lostWrites: 1}
+	srv := httptest.NewServer(server)
+	defer srv.Close()
+
+	base := "webhdfs://" + strings.TrimPrefix(srv.URL, "http://") + "/warehouse"
+	fsys, err := LoadFS(context.Background(), map[string]string{RetryInitialBackoffMs: "1"}, base)
+	require.NoError(t, err)
+
+	// the retry finds the file created by the first attempt
+	name := base + "/metadata/v1.metadata.json"
+	require.NoError(t, WriteFileExclusive(fsys, name, []byte("v1")))
+	assert.Equal(t, []byte("v1"), server.files["/warehouse/metadata/v1.metadata.json"])
+	assert.Zero(t, server.lostWrites)
+}
+
+func TestWebHDFSEndpoint(t *testing.T) {
+	h, err := NewWebHDFS(context.Background(), nil, map[string]string{HDFSUser: "iceberg"})
+	require.NoError(t, err)
+
+	u, err := h.operationURL("hdfs://namenode:8020/warehouse/t/data.bin", "OPEN", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "http://namenode:9870/webhdfs/v1/warehouse/t/data.bin?op=OPEN&user.name=iceberg", u.String())
+
+	u, err = h.operationURL("swebhdfs://namenode:9871/warehouse/t/data.bin", "OPEN", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "https://namenode:9871/webhdfs/v1/warehouse/t/data.bin?op=OPEN&user.name=iceberg", u.String())
+
+	_, err = h.operationURL("hdfs:///warehouse/t/data.bin", "OPEN", nil)
+	assert.ErrorContains(t, err, HDFSWebHDFSEndpoint)
+
+	h, err = NewWebHDFS(context.Background(), nil, map[string]string{HDFSWebHDFSEndpoint: "https://gateway/hdfs/"},
+		WithNegotiateToken(func(context.Context, string) (string, error) {
+			return "", errors.New("no ticket")
+		}))
+	require.NoError(t, err)
+
+	u, err = h.operationURL("hdfs:///warehouse/t/data.bin", "DELETE", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "https://gateway/hdfs/webhdfs/v1/warehouse/t/data.bin?op=DELETE", u.String())
+
+	err = h.Remove("hdfs:///warehouse/t/data.bin")
+	assert.ErrorContains(t, err, "no ticket")
+}
@@ -22,12 +22,14 @@ import (
 	"cmp"
 	"encoding"
 	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
 	"math/big"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 	"unsafe"
 
@@ -213,6 +215,139 @@ func LiteralFromBytes(typ Type, data []byte) (Literal, error) {
 	return nil, ErrType
 }
 
+// BoundToBytes serializes a lower or upper bound for a column of the given
+// type using the spec's single-value binary serialization. The literal is
+// first cast to typ, so bounds computed from file statistics (e.g. a
+// fixed[16] value for a uuid column) are always written in the
+// representation other implementations expect.
+func BoundToBytes(typ Type, lit Literal) ([]byte, error) {
+	if lit == nil {
+		return nil, fmt.Errorf("%w: cannot serialize nil bound", ErrInvalidArgument)
+	}
+
+	v, err := lit.To(typ)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.MarshalBinary()
+}
+
+// BoundFromBytes deserializes a lower or upper bound, as stored in a data
+// file's metrics or a manifest's partition summaries, into a Literal of the
+// given type.
+//
+// Unlike LiteralFromBytes it also accepts the encodings left behind by
+// type promotion: bounds written while an int column was not yet promoted
+// to long (or float to double) remain in their original 4-byte form.
+func BoundFromBytes(typ Type, data []byte) (Literal, error) {
+	switch typ.(type) {
+	case Int64Type:
+		if len(data) == 4 {
+			var v Int32Literal
+			if err := v.UnmarshalBinary(data); err != nil {
+				return nil, err
+			}
+
+			return Int64Literal(v), nil
+		}
+	case Float64Type:
+		if len(data) == 4 {
+			var v Float32Literal
+			if err := v.UnmarshalBinary(data); err != nil {
+				return nil, err
+			}
+
+			return Float64Literal(v), nil
+		}
+	case UUIDType:
+		if len(data) != 16 {
+			return nil, fmt.Errorf("%w: expected 16 bytes for uuid value, got %d",
+				ErrInvalidBinSerialization, len(data))
+		}
+	}
+
+	return LiteralFromBytes(typ, data)
+}
+
+// LiteralFromDefault returns a Literal of the provided type for a field's
+// initial-default or write-default value. The value may either be one
+// produced by a Literal's Any method, or the single-value JSON form
+// decoded from table metadata (e.g. float64 for numbers, strings for
+// dates, times, timestamps, decimals and uuids, hex strings for binary).
+// A nil value returns a nil Literal.
+func LiteralFromDefault(typ Type, val any) (Literal, error) {
+	var lit Literal
+	switch v := val.(type) {
+	case nil:
+		return nil, nil
+	case Literal:
+		lit = v
+	case bool:
+		lit = NewLiteral(v)
+	case int32:
+		lit = NewLiteral(v)
+	case int64:
+		lit = NewLiteral(v)
+	case int:
+		lit = NewLiteral(int64(v))
+	case float32:
+		lit = NewLiteral(v)
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) <= math.MaxInt64 {
+			switch typ.(type) {
+			case Float32Type, Float64Type, DecimalType:
+			default:
+				lit = NewLiteral(int64(v))
+			}
+		}
+		if lit == nil {
+			lit = NewLiteral(v)
+		}
+	case string:
+		switch typ.(type) {
+		case BinaryType, FixedType:
+			b, err := hex.DecodeString(v)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid %s default value %q: %s",
+					ErrInvalidArgument, typ, v, err)
+			}
+			lit = NewLiteral(b)
+		default:
+			lit = NewLiteral(v)
+		}
+	case []byte:
+		lit = NewLiteral(v)
+	case Date:
+		lit = NewLiteral(v)
+	case Time:
+		lit = NewLiteral(v)
+	case Timestamp:
+		lit = NewLiteral(v)
+	case TimestampNano:
+		lit = NewLiteral(v)
+	case uuid.UUID:
+		lit = NewLiteral(v)
+	case Decimal:
+		lit = NewLiteral(v)
+	default:
+		return nil, fmt.Errorf("%w: unsupported default value %v (%T) for type %s",
+			ErrInvalidArgument, val, val, typ)
+	}
+
+	if lit.Type().Equals(typ) {
+		return lit, nil
+	}
+
+	out, err := lit.To(typ)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid %s default value %v: %s",
+			ErrInvalidArgument, typ, val, err)
+	}
+
+	return out, nil
+}
+
 // convenience to avoid repreating this pattern for primitive types
 func literalEq[L interface {
 	comparable
@@ -601,12 +736,7 @@ func (f Float32Literal) To(t Type) (Literal, error) {
 	case Float64Type:
 		return Float64Literal(f), nil
 	case DecimalType:
-		v, err := decimal128.FromFloat32(float32(f), int32(t.precision), int32(t.scale))
-		if err != nil {
-			return nil, err
-		}
-
-		return DecimalLiteral{Val: v, Scale: t.scale}, nil
+		return floatToDecimal(float64(f), 32, t)
 	}
 
 	return nil, fmt.Errorf("%w: Float32Literal to %s", ErrBadCast, t)
@@ -655,17 +785,46 @@ func (f Float64Literal) To(t Type) (Literal, error) {
 	case Float64Type:
 		return f, nil
 	case DecimalType:
-		v, err := decimal128.FromFloat64(float64(f), int32(t.precision), int32(t.scale))
-		if err != nil {
-			return nil, err
-		}
-
-		return DecimalLiteral{Val: v, Scale: t.scale}, nil
+		return floatToDecimal(float64(f), 64, t)
 	}
 
 	return nil, fmt.Errorf("%w: Float64Literal to %s", ErrBadCast, t)
 }
 
+// floatToDecimal converts f, a float of the given bit size, to a decimal
+// of type t. It goes through the shortest decimal representation of f
+// rather than its binary value, so 1.005 becomes 1.005 and not
+// 1.00499999999999989..., and rounds half up to the scale of t.
+func floatToDecimal(f float64, bitSize int, t DecimalType) (Literal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, fmt.Errorf("%w: cannot cast %v to %s", ErrBadCast, f, t)
+	}
+
+	str := strconv.FormatFloat(math.Abs(f), 'f', -1, bitSize)
+	intPart, frac, _ := strings.Cut(str, ".")
+	roundUp := false
+	if len(frac) > t.scale {
+		roundUp = frac[t.scale] >= '5'
+		frac = frac[:t.scale]
+	}
+	frac += strings.Repeat("0", t.scale-len(frac))
+
+	unscaled, _ := new(big.Int).SetString(intPart+frac, 10)
+	if roundUp {
+		unscaled.Add(unscaled, big.NewInt(1))
+	}
+	if f < 0 {
+		unscaled.Neg(unscaled)
+	}
+
+	if unscaled.BitLen() > 127 || !decimal128.FromBigInt(unscaled).FitsInPrecision(int32(t.precision)) {
+		return nil, fmt.Errorf("%w: %s exceeds the precision of %s",
+			ErrBadCast, strconv.FormatFloat(f, 'f', -1, bitSize), t)
+	}
+
+	return DecimalLiteral{Val: decimal128.FromBigInt(unscaled), Scale: t.scale}, nil
+}
+
 func (f Float64Literal) Equals(other Literal) bool {
 	return literalEq(f, other)
 }
@@ -1113,7 +1272,7 @@ func (f FixedLiteral) To(typ Type) (Literal, error) {
 		return nil, fmt.Errorf("%w: cannot convert FixedLiteral to %s, different length - %d <> %d",
 			ErrBadCast, typ, len(f), t.len)
 	case BinaryType:
-		return f, nil
+		return BinaryLiteral(f), nil
 	}
 
 	return nil, fmt.Errorf("%w: FixedLiteral[%d] to %s",
@@ -1227,12 +1386,17 @@ func (d DecimalLiteral) String() string {
 func (d DecimalLiteral) To(t Type) (Literal, error) {
 	switch t := t.(type) {
 	case DecimalType:
-		if d.Scale == t.scale {
-			return d, nil
+		if d.Scale != t.scale {
+			return nil, fmt.Errorf("%w: could not convert %v to %s",
+				ErrBadCast, d, t)
+		}
+
+		if !d.Val.FitsInPrecision(int32(t.precision)) {
+			return nil, fmt.Errorf("%w: %v exceeds the precision of %s",
+				ErrBadCast, d, t)
 		}
 
-		return nil, fmt.Errorf("%w: could not convert %v to %s",
-			ErrBadCast, d, t)
+		return d, nil
 	case Int32Type:
 		v := d.Val.BigInt().Int64()
 		if v > math.MaxInt32 {
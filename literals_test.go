@@ -386,6 +386,30 @@ func TestDecimalToDecimalConversion(t *testing.T) {
 	_, err = lit.To(iceberg.DecimalTypeOf(9, 3))
 	assert.ErrorIs(t, err, iceberg.ErrBadCast)
 	assert.ErrorContains(t, err, "could not convert 34.11 to decimal(9, 3)")
+
+	_, err = lit.To(iceberg.DecimalTypeOf(3, 2))
+	assert.ErrorIs(t, err, iceberg.ErrBadCast)
+	assert.ErrorContains(t, err, "34.11 exceeds the precision of decimal(3, 2)")
+}
+
+func TestFloatToDecimalConversionIsExact(t *testing.T) {
+	got, err := iceberg.Float64Literal(1.005).To(iceberg.DecimalTypeOf(38, 18))
+	require.NoError(t, err)
+	assert.Equal(t, "1.005000000000000000", got.String())
+
+	got, err = iceberg.Float32Literal(0.1).To(iceberg.DecimalTypeOf(10, 9))
+	require.NoError(t, err)
+	assert.Equal(t, "0.100000000", got.String())
+
+	got, err = iceberg.Float64Literal(2.345).To(iceberg.DecimalTypeOf(9, 2))
+	require.NoError(t, err)
+	assert.Equal(t, "2.35", got.String())
+
+	_, err = iceberg.Float64Literal(12345.6).To(iceberg.DecimalTypeOf(4, 1))
+	assert.ErrorIs(t, err, iceberg.ErrBadCast)
+
+	_, err = iceberg.Float64Literal(math.NaN()).To(iceberg.DecimalTypeOf(9, 2))
+	assert.ErrorIs(t, err, iceberg.ErrBadCast)
 }
 
 func TestDecimalLiteralConversions(t *testing.T) {
@@ -1309,6 +1333,72 @@ func TestDecimalMaxMinRoundTrip(t *testing.T) {
 	}
 }
 
+func TestBoundBytes(t *testing.T) {
+	id := uuid.UUID{0xf7, 0x9c, 0x3e, 0x09, 0x67, 0x7c, 0x4b, 0xbd, 0xa4, 0x79, 0x3f, 0x34, 0x9c, 0xb7, 0x85, 0xe7}
+
+	t.Run("round trip", func(t *testing.T) {
+		tests := []struct {
+			typ iceberg.Type
+			lit iceberg.Literal
+		}{
+			{iceberg.PrimitiveTypes.Bool, iceberg.BoolLiteral(true)},
+			{iceberg.PrimitiveTypes.Int32, iceberg.Int32Literal(-12)},
+			{iceberg.PrimitiveTypes.Int64, iceberg.Int64Literal(1 << 40)},
+			{iceberg.PrimitiveTypes.Float32, iceberg.Float32Literal(1.5)},
+			{iceberg.PrimitiveTypes.Float64, iceberg.Float64Literal(-2.25)},
+			{iceberg.PrimitiveTypes.Date, iceberg.DateLiteral(19000)},
+			{iceberg.PrimitiveTypes.Time, iceberg.TimeLiteral(3600000000)},
+			{iceberg.PrimitiveTypes.Timestamp, iceberg.TimestampLiteral(1700000000000000)},
+			{iceberg.PrimitiveTypes.TimestampTz, iceberg.TimestampLiteral(1700000000000000)},
+			{iceberg.PrimitiveTypes.TimestampNs, iceberg.TimestampNsLiteral(1700000000000000000)},
+			{iceberg.PrimitiveTypes.String, iceberg.StringLiteral("abc")},
+			{iceberg.PrimitiveTypes.Binary, iceberg.BinaryLiteral("abc")},
+			{iceberg.FixedTypeOf(3), iceberg.FixedLiteral("abc")},
+			{iceberg.PrimitiveTypes.UUID, iceberg.UUIDLiteral(id)},
+			{iceberg.DecimalTypeOf(9, 2), iceberg.DecimalLiteral{Scale: 2, Val: decimal128.FromI64(-12345)}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.typ.String(), func(t *testing.T) {
+				data, err := iceberg.BoundToBytes(tt.typ, tt.lit)
+				require.NoError(t, err)
+
+				lit, err := iceberg.BoundFromBytes(tt.typ, data)
+				require.NoError(t, err)
+				assert.True(t, lit.Equals(tt.lit), "got %s, expected %s", lit, tt.lit)
+			})
+		}
+	})
+
+	t.Run("uuid from fixed statistics", func(t *testing.T) {
+		data, err := iceberg.BoundToBytes(iceberg.PrimitiveTypes.UUID, iceberg.FixedLiteral(id[:]))
+		require.NoError(t, err)
+		assert.Equal(t, id[:], data)
+
+		lit, err := iceberg.BoundFromBytes(iceberg.PrimitiveTypes.UUID, data)
+		require.NoError(t, err)
+		assert.Equal(t, iceberg.UUIDLiteral(id), lit)
+
+		_, err = iceberg.BoundFromBytes(iceberg.PrimitiveTypes.UUID, data[:8])
+		assert.ErrorIs(t, err, iceberg.ErrInvalidBinSerialization)
+	})
+
+	t.Run("fixed length mismatch", func(t *testing.T) {
+		_, err := iceberg.BoundToBytes(iceberg.FixedTypeOf(4), iceberg.FixedLiteral("abc"))
+		assert.ErrorIs(t, err, iceberg.ErrBadCast)
+	})
+
+	t.Run("promoted types", func(t *testing.T) {
+		lit, err := iceberg.BoundFromBytes(iceberg.PrimitiveTypes.Int64, []byte{0xd2, 0x04, 0x00, 0x00})
+		require.NoError(t, err)
+		assert.Equal(t, iceberg.Int64Literal(1234), lit)
+
+		lit, err = iceberg.BoundFromBytes(iceberg.PrimitiveTypes.Float64, []byte{0x00, 0x00, 0x90, 0xc0})
+		require.NoError(t, err)
+		assert.Equal(t, iceberg.Float64Literal(-4.5), lit)
+	})
+}
+
 func TestDecimalMarshalBinaryIssue731(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1344,3 +1434,47 @@ func TestDecimalMarshalBinaryIssue731(t *testing.T) {
 		})
 	}
 }
+
+func TestLiteralFromDefault(t *testing.T) {
+	tests := []struct {
+		name     string
+		typ      iceberg.Type
+		val      any
+		expected iceberg.Literal
+	}{
+		{"nil", iceberg.PrimitiveTypes.Int32, nil, nil},
+		{"literal value", iceberg.PrimitiveTypes.Int64, int64(7), iceberg.Int64Literal(7)},
+		{"literal", iceberg.PrimitiveTypes.Int64, iceberg.Int32Literal(7), iceberg.Int64Literal(7)},
+		{"json int", iceberg.PrimitiveTypes.Int32, float64(34), iceberg.Int32Literal(34)},
+		{"json long", iceberg.PrimitiveTypes.Int64, float64(34), iceberg.Int64Literal(34)},
+		{"json float", iceberg.PrimitiveTypes.Float32, float64(1.5), iceberg.Float32Literal(1.5)},
+		{"json bool", iceberg.PrimitiveTypes.Bool, true, iceberg.BoolLiteral(true)},
+		{"json string", iceberg.PrimitiveTypes.String, "iceberg", iceberg.StringLiteral("iceberg")},
+		{"json date", iceberg.PrimitiveTypes.Date, "2017-11-16", iceberg.DateLiteral(17486)},
+		{"json binary", iceberg.PrimitiveTypes.Binary, "0000ff", iceberg.BinaryLiteral{0x00, 0x00, 0xff}},
+		{
+			"json uuid", iceberg.PrimitiveTypes.UUID, "f79c3e09-677c-4bbd-a479-3f349cb785e7",
+			iceberg.UUIDLiteral(uuid.MustParse("f79c3e09-677c-4bbd-a479-3f349cb785e7")),
+		},
+		{
+			"json decimal", iceberg.DecimalTypeOf(9, 2), "14.20",
+			iceberg.DecimalLiteral{Val: decimal128.FromI64(1420), Scale: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lit, err := iceberg.LiteralFromDefault(tt.typ, tt.val)
+			require.NoError(t, err)
+			if tt.expected == nil {
+				assert.Nil(t, lit)
+
+				return
+			}
+			assert.True(t, tt.expected.Equals(lit), "expected %s, got %s", tt.expected, lit)
+		})
+	}
+
+	_, err := iceberg.LiteralFromDefault(iceberg.PrimitiveTypes.Int32, "abc")
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
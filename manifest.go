@@ -18,10 +18,12 @@
 package iceberg
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"iter"
 	"math"
 	"math/big"
 	"reflect"
@@ -31,6 +33,7 @@ import (
 	"time"
 
 	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/iceberg-go/encryption"
 	"github.com/apache/iceberg-go/internal"
 	iceio "github.com/apache/iceberg-go/io"
 	"github.com/google/uuid"
@@ -332,6 +335,23 @@ func (m *manifestFile) setVersion(v int) {
 	m.version = v
 }
 
+func (m *manifestFile) FirstRowID() *int64 { return m.FirstRowId }
+
+// RelocateManifestFile returns a copy of mf pointing at the manifest file
+// at path with the given length. The rest of its summary is unchanged, so
+// the file at path must track the same entries as the original manifest.
+func RelocateManifestFile(mf ManifestFile, path string, length int64) ManifestFile {
+	m, ok := mf.(*manifestFile)
+	if !ok {
+		panic(fmt.Errorf("%w: cannot relocate manifest file of type %T", ErrInvalidArgument, mf))
+	}
+
+	out := *m
+	out.Path, out.Len = path, length
+
+	return &out
+}
+
 func (m *manifestFile) toV1(v1file *manifestFileV1) {
 	v1file.Path = m.Path
 	v1file.Len = m.Len
@@ -520,6 +540,10 @@ type ManifestFile interface {
 	// field in the spec. Each field in the list corresponds to a field in
 	// the manifest file's partition spec.
 	Partitions() []FieldSummary
+	// FirstRowID returns the first row ID assigned to the data files added
+	// or existing in the manifest that don't have one, or nil if row IDs
+	// were not assigned to it (v3+ only).
+	FirstRowID() *int64
 
 	// HasAddedFiles returns true if AddedDataFiles > 0 or if it was null.
 	HasAddedFiles() bool
@@ -544,7 +568,7 @@ type fallbackManifest[T any] interface {
 	*T
 }
 
-func decodeManifestsWithFallback[P fallbackManifest[T], T any](dec *ocf.Decoder) ([]ManifestFile, error) {
+func decodeManifestsWithFallback[P fallbackManifest[T], T any](dec *ocfReader) ([]ManifestFile, error) {
 	results := make([]ManifestFile, 0)
 	for dec.HasNext() {
 		tmp := P(new(T))
@@ -561,7 +585,7 @@ func decodeManifestsWithFallback[P fallbackManifest[T], T any](dec *ocf.Decoder)
 func decodeManifests[I interface {
 	ManifestFile
 	*T
-}, T any](dec *ocf.Decoder, version int) ([]ManifestFile, error) {
+}, T any](dec *ocfReader, version int) ([]ManifestFile, error) {
 	results := make([]ManifestFile, 0)
 	for dec.HasNext() {
 		tmp := I(new(T))
@@ -580,7 +604,7 @@ func decodeManifests[I interface {
 // This type is not thread-safe; its methods should not be called from
 // multiple goroutines.
 type ManifestReader struct {
-	dec           *ocf.Decoder
+	dec           *ocfReader
 	file          ManifestFile
 	formatVersion int
 	isFallback    bool
@@ -588,6 +612,15 @@ type ManifestReader struct {
 	fieldNameToID map[string]int
 	fieldIDToType map[int]avro.LogicalType
 	fieldIDToSize map[int]int
+	partType      *manifestPartitionType
+	// nextRowID is the first row ID inherited by the next live data file
+	// without one, or nil if the manifest has no first row ID.
+	nextRowID *int64
+
+	// entries and files are allocated in batches of manifestEntryBatch
+	// to amortize allocations over the entries of the manifest.
+	entries []manifestEntry
+	files   []dataFile
 
 	// The rest are lazily populated, on demand. Most readers
 	// will likely only try to load the entries.
@@ -601,7 +634,15 @@ type ManifestReader struct {
 // file. If the caller is interested in the manifest entries in the file, it must call
 // [ManifestReader.Entries] before closing the provided reader.
 func NewManifestReader(file ManifestFile, in io.Reader) (*ManifestReader, error) {
-	dec, err := ocf.NewDecoder(in, ocf.WithDecoderSchemaCache(&avro.SchemaCache{}))
+	if len(file.KeyMetadata()) > 0 {
+		decrypted, err := decryptManifest(file, in)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt manifest %s: %w", file.FilePath(), err)
+		}
+		in = decrypted
+	}
+
+	dec, err := newOCFReader(in)
 	if err != nil {
 		return nil, err
 	}
@@ -613,7 +654,9 @@ func NewManifestReader(file ManifestFile, in io.Reader) (*ManifestReader, error)
 	if err != nil {
 		return nil, fmt.Errorf("manifest file's 'format-version' metadata is invalid: %w", err)
 	}
-	if formatVersion != file.Version() {
+	// a version 3 manifest list keeps the version 2 manifests of a table
+	// that was upgraded
+	if formatVersion != file.Version() && (file.Version() != 3 || formatVersion != 2) {
 		return nil, fmt.Errorf("manifest file's 'format-version' metadata indicates version %d, but entry from manifest list indicates version %d",
 			formatVersion, file.Version())
 	}
@@ -652,7 +695,13 @@ func NewManifestReader(file ManifestFile, in io.Reader) (*ManifestReader, error)
 			}
 		}
 	}
-	fieldNameToID, fieldIDToType, fieldIDToSize := getFieldIDMap(sc)
+	fieldNameToID, fieldIDToType, fieldIDToSize := dec.schema.fieldIDs()
+
+	var nextRowID *int64
+	if first := file.FirstRowID(); first != nil && content == ManifestContentData {
+		id := *first
+		nextRowID = &id
+	}
 
 	return &ManifestReader{
 		dec:           dec,
@@ -663,9 +712,73 @@ func NewManifestReader(file ManifestFile, in io.Reader) (*ManifestReader, error)
 		fieldNameToID: fieldNameToID,
 		fieldIDToType: fieldIDToType,
 		fieldIDToSize: fieldIDToSize,
+		partType:      newManifestPartitionType(sc, metadata),
+		nextRowID:     nextRowID,
 	}, nil
 }
 
+// manifestPartitionType is the partition spec and partition type of the
+// files of a manifest, shared between the files read from it so they can
+// build their typed partition tuples.
+type manifestPartitionType struct {
+	spec   PartitionSpec
+	fields []NestedField
+}
+
+// newManifestPartitionType loads the partition spec and partition type
+// of a manifest from its metadata and schema. It returns nil if they
+// cannot be loaded, in which case the files of the manifest have no
+// typed partition tuples.
+func newManifestPartitionType(sc avro.Schema, metadata map[string][]byte) *manifestPartitionType {
+	id, err := strconv.Atoi(string(metadata["partition-spec-id"]))
+	if err != nil {
+		return nil
+	}
+
+	var fields []PartitionField
+	if err := json.Unmarshal(metadata["partition-spec"], &fields); err != nil {
+		return nil
+	}
+
+	partType, err := manifestPartitionStruct(sc)
+	if err != nil {
+		return nil
+	}
+
+	return &manifestPartitionType{
+		spec:   NewPartitionSpecID(id, fields...),
+		fields: partType.FieldList,
+	}
+}
+
+// manifestPartitionStruct returns the partition type that a manifest
+// with the given entry schema records its partition tuples with.
+func manifestPartitionStruct(sc avro.Schema) (*StructType, error) {
+	field := func(rec avro.Schema, name string) (avro.Schema, error) {
+		if rec, ok := rec.(*avro.RecordSchema); ok {
+			for _, f := range rec.Fields() {
+				if f.Name() == name {
+					return f.Type(), nil
+				}
+			}
+		}
+
+		return nil, fmt.Errorf("%w: manifest schema has no %s record", ErrInvalidSchema, name)
+	}
+
+	dataFile, err := field(sc, "data_file")
+	if err != nil {
+		return nil, err
+	}
+
+	partition, err := field(dataFile, "partition")
+	if err != nil {
+		return nil, err
+	}
+
+	return avroRecordToStruct(partition.(*avro.RecordSchema))
+}
+
 // Version returns the file's format version.
 func (c *ManifestReader) Version() int {
 	return c.formatVersion
@@ -749,7 +862,7 @@ func (c *ManifestReader) ReadEntry() (ManifestEntry, error) {
 			manifestEntry: manifestEntry{Data: &dataFile{}},
 		}
 	} else {
-		tmp = &manifestEntry{Data: &dataFile{}}
+		tmp = c.newEntry()
 	}
 
 	if err := c.dec.Decode(tmp); err != nil {
@@ -759,15 +872,127 @@ func (c *ManifestReader) ReadEntry() (ManifestEntry, error) {
 		tmp = tmp.(*fallbackManifestEntry).toEntry()
 	}
 	tmp.inherit(c.file)
+	c.inheritFirstRowID(tmp)
 	if fieldToIDMap, ok := tmp.DataFile().(hasFieldToIDMap); ok {
 		fieldToIDMap.setFieldNameToIDMap(c.fieldNameToID)
 		fieldToIDMap.setFieldIDToLogicalTypeMap(c.fieldIDToType)
 		fieldToIDMap.setFieldIDToFixedSizeMap(c.fieldIDToSize)
 	}
+	if df, ok := tmp.DataFile().(*dataFile); ok {
+		df.partitionType = c.partType
+	}
 
 	return tmp, nil
 }
 
+// manifestEntryBatch is the number of entries and data files a
+// ManifestReader allocates at once.
+const manifestEntryBatch = 64
+
+func (c *ManifestReader) newEntry() *manifestEntry {
+	if len(c.entries) == 0 {
+		c.entries = make([]manifestEntry, manifestEntryBatch)
+		c.files = make([]dataFile, manifestEntryBatch)
+	}
+
+	e, df := &c.entries[0], &c.files[0]
+	c.entries, c.files = c.entries[1:], c.files[1:]
+	e.Data = df
+
+	return e
+}
+
+// inheritFirstRowID assigns the next row IDs of the manifest to a live
+// data file which wasn't written with a first row ID, as row IDs are
+// assigned in order to the files of a manifest when it is committed.
+func (c *ManifestReader) inheritFirstRowID(entry ManifestEntry) {
+	if c.nextRowID == nil || entry.Status() == EntryStatusDELETED {
+		return
+	}
+
+	df, ok := entry.DataFile().(*dataFile)
+	if !ok || df.FirstRowIDField != nil {
+		return
+	}
+
+	id := *c.nextRowID
+	df.FirstRowIDField = &id
+	*c.nextRowID += df.RecordCount
+}
+
+// Entries returns an iterator over the remaining manifest entries in the
+// avro file. Iteration stops after the first error, which is yielded along
+// with a nil entry.
+func (c *ManifestReader) Entries() iter.Seq2[ManifestEntry, error] {
+	return func(yield func(ManifestEntry, error) bool) {
+		for {
+			entry, err := c.ReadEntry()
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					yield(nil, err)
+				}
+
+				return
+			}
+			if !yield(entry, nil) {
+				return
+			}
+		}
+	}
+}
+
+// decryptManifest returns a reader of the plaintext of a manifest which is
+// encrypted as an AES GCM stream with the key in its key metadata.
+func decryptManifest(file ManifestFile, in io.Reader) (io.Reader, error) {
+	if src, ok := in.(io.ReaderAt); ok && file.Length() > 0 {
+		return encryption.DecryptStream(src, file.Length(), file.KeyMetadata())
+	}
+
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return nil, err
+	}
+
+	return encryption.DecryptStream(bytes.NewReader(data), int64(len(data)), file.KeyMetadata())
+}
+
+// ManifestEntries returns an iterator that lazily opens the given manifest
+// and streams its entries, closing the file once iteration completes or is
+// stopped early. If discardDeleted is true, entries whose status is "deleted"
+// are skipped.
+func ManifestEntries(fs iceio.IO, m ManifestFile, discardDeleted bool) iter.Seq2[ManifestEntry, error] {
+	return func(yield func(ManifestEntry, error) bool) {
+		f, err := fs.Open(m.FilePath())
+		if err != nil {
+			yield(nil, err)
+
+			return
+		}
+		defer f.Close()
+
+		rdr, err := NewManifestReader(m, f)
+		if err != nil {
+			yield(nil, err)
+
+			return
+		}
+
+		for entry, err := range rdr.Entries() {
+			if err != nil {
+				yield(nil, err)
+
+				return
+			}
+			if discardDeleted && entry.Status() == EntryStatusDELETED {
+				continue
+			}
+			if !yield(entry, nil) {
+				return
+			}
+		}
+	}
+}
+
 // ReadManifest reads in an avro list file and returns a slice
 // of manifest entries or an error if one is encountered. If discardDeleted
 // is true, the returned slice omits entries whose status is "deleted".
@@ -777,13 +1002,8 @@ func ReadManifest(m ManifestFile, f io.Reader, discardDeleted bool) ([]ManifestE
 		return nil, err
 	}
 	var results []ManifestEntry
-	for {
-		entry, err := manifestReader.ReadEntry()
+	for entry, err := range manifestReader.Entries() {
 		if err != nil {
-			if errors.Is(err, io.EOF) {
-				return results, nil
-			}
-
 			return results, err
 		}
 		if discardDeleted && entry.Status() == EntryStatusDELETED {
@@ -791,20 +1011,19 @@ func ReadManifest(m ManifestFile, f io.Reader, discardDeleted bool) ([]ManifestE
 		}
 		results = append(results, entry)
 	}
+
+	return results, nil
 }
 
 // ReadManifestList reads in an avro manifest list file and returns a slice
 // of manifest files or an error if one is encountered.
 func ReadManifestList(in io.Reader) ([]ManifestFile, error) {
-	dec, err := ocf.NewDecoder(in, ocf.WithDecoderSchemaCache(&avro.SchemaCache{}))
+	dec, err := newOCFReader(in)
 	if err != nil {
 		return nil, err
 	}
 
-	sc, err := avro.ParseBytes(dec.Metadata()["avro.schema"])
-	if err != nil {
-		return nil, err
-	}
+	sc := dec.Schema()
 
 	version, err := strconv.Atoi(string(dec.Metadata()["format-version"]))
 	if err != nil {
@@ -832,13 +1051,11 @@ func ReadManifestList(in io.Reader) ([]ManifestFile, error) {
 }
 
 type writerImpl interface {
-	content() ManifestContent
 	prepareEntry(*manifestEntry, int64) (ManifestEntry, error)
 }
 
 type v1writerImpl struct{}
 
-func (v1writerImpl) content() ManifestContent { return ManifestContentData }
 func (v1writerImpl) prepareEntry(entry *manifestEntry, sn int64) (ManifestEntry, error) {
 	if entry.Snapshot != nil && *entry.Snapshot != sn {
 		if entry.EntryStatus != EntryStatusEXISTING {
@@ -855,7 +1072,6 @@ func (v1writerImpl) prepareEntry(entry *manifestEntry, sn int64) (ManifestEntry,
 
 type v2writerImpl struct{}
 
-func (v2writerImpl) content() ManifestContent { return ManifestContentData }
 func (v2writerImpl) prepareEntry(entry *manifestEntry, snapshotID int64) (ManifestEntry, error) {
 	if entry.SeqNum == nil {
 		if entry.Snapshot != nil && *entry.Snapshot != snapshotID {
@@ -872,7 +1088,6 @@ func (v2writerImpl) prepareEntry(entry *manifestEntry, snapshotID int64) (Manife
 
 type v3writerImpl struct{}
 
-func (v3writerImpl) content() ManifestContent { return ManifestContentData }
 func (v3writerImpl) prepareEntry(entry *manifestEntry, snapshotID int64) (ManifestEntry, error) {
 	if entry.SeqNum == nil {
 		if entry.Snapshot != nil && *entry.Snapshot != snapshotID {
@@ -897,61 +1112,58 @@ type partitionFieldStats[T LiteralType] struct {
 	min          *T
 	max          *T
 
+	typ PrimitiveType
 	cmp Comparator[T]
 }
 
 func newPartitionFieldStat(typ PrimitiveType) (fieldStats, error) {
 	switch typ.(type) {
 	case BooleanType:
-		return &partitionFieldStats[bool]{cmp: getComparator[bool]()}, nil
+		return &partitionFieldStats[bool]{typ: typ, cmp: getComparator[bool]()}, nil
 	case Int32Type:
-		return &partitionFieldStats[int32]{cmp: getComparator[int32]()}, nil
+		return &partitionFieldStats[int32]{typ: typ, cmp: getComparator[int32]()}, nil
 	case Int64Type:
-		return &partitionFieldStats[int64]{cmp: getComparator[int64]()}, nil
+		return &partitionFieldStats[int64]{typ: typ, cmp: getComparator[int64]()}, nil
 	case Float32Type:
-		return &partitionFieldStats[float32]{cmp: getComparator[float32]()}, nil
+		return &partitionFieldStats[float32]{typ: typ, cmp: getComparator[float32]()}, nil
 	case Float64Type:
-		return &partitionFieldStats[float64]{cmp: getComparator[float64]()}, nil
+		return &partitionFieldStats[float64]{typ: typ, cmp: getComparator[float64]()}, nil
 	case StringType:
-		return &partitionFieldStats[string]{cmp: getComparator[string]()}, nil
+		return &partitionFieldStats[string]{typ: typ, cmp: getComparator[string]()}, nil
 	case DateType:
-		return &partitionFieldStats[Date]{cmp: getComparator[Date]()}, nil
+		return &partitionFieldStats[Date]{typ: typ, cmp: getComparator[Date]()}, nil
 	case TimeType:
-		return &partitionFieldStats[Time]{cmp: getComparator[Time]()}, nil
+		return &partitionFieldStats[Time]{typ: typ, cmp: getComparator[Time]()}, nil
 	case TimestampType:
-		return &partitionFieldStats[Timestamp]{cmp: getComparator[Timestamp]()}, nil
+		return &partitionFieldStats[Timestamp]{typ: typ, cmp: getComparator[Timestamp]()}, nil
 	case TimestampTzType:
-		return &partitionFieldStats[Timestamp]{cmp: getComparator[Timestamp]()}, nil
+		return &partitionFieldStats[Timestamp]{typ: typ, cmp: getComparator[Timestamp]()}, nil
 	case UUIDType:
-		return &partitionFieldStats[uuid.UUID]{cmp: getComparator[uuid.UUID]()}, nil
+		return &partitionFieldStats[uuid.UUID]{typ: typ, cmp: getComparator[uuid.UUID]()}, nil
 	case BinaryType:
-		return &partitionFieldStats[[]byte]{cmp: getComparator[[]byte]()}, nil
+		return &partitionFieldStats[[]byte]{typ: typ, cmp: getComparator[[]byte]()}, nil
 	case FixedType:
-		return &partitionFieldStats[[]byte]{cmp: getComparator[[]byte]()}, nil
+		return &partitionFieldStats[[]byte]{typ: typ, cmp: getComparator[[]byte]()}, nil
 	case DecimalType:
-		return &partitionFieldStats[Decimal]{cmp: getComparator[Decimal]()}, nil
+		return &partitionFieldStats[Decimal]{typ: typ, cmp: getComparator[Decimal]()}, nil
 	default:
 		return nil, fmt.Errorf("expected primitive type for partition type: %s", typ)
 	}
 }
 
 func (p *partitionFieldStats[T]) toSummary() FieldSummary {
-	var (
-		lowerBound *[]byte
-		upperBound *[]byte
-		lit        Literal
-	)
+	var lowerBound, upperBound *[]byte
 
 	if p.min != nil {
-		lit = NewLiteral(*p.min)
-		lb, _ := lit.MarshalBinary()
-		lowerBound = &lb
+		if lb, err := BoundToBytes(p.typ, NewLiteral(*p.min)); err == nil {
+			lowerBound = &lb
+		}
 	}
 
 	if p.max != nil {
-		lit = NewLiteral(*p.max)
-		ub, _ := lit.MarshalBinary()
-		upperBound = &ub
+		if ub, err := BoundToBytes(p.typ, NewLiteral(*p.max)); err == nil {
+			upperBound = &ub
+		}
 	}
 
 	return FieldSummary{
@@ -1044,15 +1256,20 @@ type ManifestWriter struct {
 	closed  bool
 	version int
 	impl    writerImpl
+	content ManifestContent
 
-	output io.Writer
-	writer *ocf.Encoder
+	output      io.Writer
+	writer      *ocf.Encoder
+	encrypted   *encryption.StreamWriter
+	keyMetadata []byte
 
-	spec   PartitionSpec
-	schema *Schema
+	spec     PartitionSpec
+	schema   *Schema
+	partType *StructType
 
 	partFieldNameToID map[string]int
 	partFieldIDToType map[int]avro.LogicalType
+	partFieldIDToSize map[int]int
 
 	snapshotID    int64
 	addedFiles    int32
@@ -1067,7 +1284,33 @@ type ManifestWriter struct {
 	reusedEntry manifestEntry
 }
 
-func NewManifestWriter(version int, out io.Writer, spec PartitionSpec, schema *Schema, snapshotID int64) (*ManifestWriter, error) {
+func NewManifestWriter(version int, out io.Writer, spec PartitionSpec, schema *Schema, snapshotID int64, opts ...ManifestWriterOption) (*ManifestWriter, error) {
+	return newManifestWriter(version, out, spec, schema, snapshotID, ManifestContentData, opts...)
+}
+
+// NewDeleteManifestWriter creates a writer for a manifest that tracks
+// position and equality delete files. Delete manifests require format
+// version 2 or later.
+func NewDeleteManifestWriter(version int, out io.Writer, spec PartitionSpec, schema *Schema, snapshotID int64, opts ...ManifestWriterOption) (*ManifestWriter, error) {
+	if version < 2 {
+		return nil, fmt.Errorf("delete manifests are not supported in format version %d", version)
+	}
+
+	return newManifestWriter(version, out, spec, schema, snapshotID, ManifestContentDeletes, opts...)
+}
+
+func newManifestWriter(version int, out io.Writer, spec PartitionSpec, schema *Schema, snapshotID int64, content ManifestContent, opts ...ManifestWriterOption) (*ManifestWriter, error) {
+	options := newManifestWriterOptions(opts)
+	codecOpts, err := options.encoderOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := options.encrypt(out)
+	if err != nil {
+		return nil, err
+	}
+
 	var impl writerImpl
 
 	switch version {
@@ -1081,11 +1324,20 @@ func NewManifestWriter(version int, out io.Writer, spec PartitionSpec, schema *S
 		return nil, fmt.Errorf("unsupported manifest version: %d", version)
 	}
 
-	sc, err := partitionTypeToAvroSchema(spec.PartitionType(schema))
+	partType := spec.PartitionType(schema)
+	sc, err := partitionTypeToAvroSchema(partType)
 	if err != nil {
 		return nil, err
 	}
 
+	// decimals are written as fixed values sized for their precision
+	idToSize := make(map[int]int)
+	for _, f := range partType.FieldList {
+		if dec, ok := f.Type.(DecimalType); ok {
+			idToSize[f.ID] = internal.DecimalRequiredBytes(dec.Precision())
+		}
+	}
+
 	fileSchema, err := internal.NewManifestEntrySchema(sc, version)
 	if err != nil {
 		return nil, err
@@ -1096,26 +1348,33 @@ func NewManifestWriter(version int, out io.Writer, spec PartitionSpec, schema *S
 	w := &ManifestWriter{
 		impl:              impl,
 		version:           version,
+		content:           content,
 		output:            out,
 		spec:              spec,
 		schema:            schema,
+		partType:          partType,
 		partFieldNameToID: nameToID,
 		partFieldIDToType: idToType,
+		partFieldIDToSize: idToSize,
 		snapshotID:        snapshotID,
 		minSeqNum:         -1,
 		partitions:        make([]map[int]any, 0),
 	}
 
+	if encrypted != nil {
+		w.output, w.encrypted, w.keyMetadata = encrypted, encrypted, options.key.Metadata
+	}
+
 	md, err := w.meta()
 	if err != nil {
 		return nil, err
 	}
 
-	enc, err := ocf.NewEncoderWithSchema(fileSchema, out,
+	enc, err := ocf.NewEncoderWithSchema(fileSchema, w.output, append([]ocf.EncoderFunc{
 		ocf.WithSchemaMarshaler(ocf.FullSchemaMarshaler),
 		ocf.WithEncoderSchemaCache(&avro.SchemaCache{}),
 		ocf.WithMetadata(md),
-		ocf.WithCodec(ocf.Deflate))
+	}, codecOpts...)...)
 
 	w.writer = enc
 
@@ -1132,8 +1391,11 @@ func (w *ManifestWriter) Close() error {
 	}
 
 	w.closed = true
+	if err := w.writer.Close(); err != nil || w.encrypted == nil {
+		return err
+	}
 
-	return w.writer.Close()
+	return w.encrypted.Close()
 }
 
 func (w *ManifestWriter) ToManifestFile(location string, length int64) (ManifestFile, error) {
@@ -1155,7 +1417,7 @@ func (w *ManifestWriter) ToManifestFile(location string, length int64) (Manifest
 		Path:               location,
 		Len:                length,
 		SpecID:             int32(w.spec.id),
-		Content:            ManifestContentData,
+		Content:            w.content,
 		SeqNumber:          -1,
 		MinSeqNumber:       w.minSeqNum,
 		AddedSnapshotID:    w.snapshotID,
@@ -1166,7 +1428,7 @@ func (w *ManifestWriter) ToManifestFile(location string, length int64) (Manifest
 		ExistingRowsCount:  w.existingRows,
 		DeletedRowsCount:   w.deletedRows,
 		PartitionList:      &partitions,
-		Key:                nil,
+		Key:                w.keyMetadata,
 	}, nil
 }
 
@@ -1193,7 +1455,7 @@ func (w *ManifestWriter) meta() (map[string][]byte, error) {
 		"partition-spec":    specFieldsJson,
 		"partition-spec-id": []byte(strconv.Itoa(w.spec.ID())),
 		"format-version":    []byte(strconv.Itoa(w.version)),
-		"content":           []byte(w.impl.content().String()),
+		"content":           []byte(w.content.String()),
 	}, nil
 }
 
@@ -1221,8 +1483,14 @@ func (w *ManifestWriter) addEntry(entry *manifestEntry) error {
 		setter.setFieldIDToLogicalTypeMap(w.partFieldIDToType)
 	}
 
-	w.partitions = append(w.partitions, entry.Data.Partition())
-	partitionData := avroPartitionData(entry.Data.Partition(), w.partFieldIDToType)
+	partition, err := w.partitionData(entry.DataFile())
+	if err != nil {
+		return err
+	}
+
+	values := partition.Map()
+	w.partitions = append(w.partitions, values)
+	partitionData := avroPartitionData(values, w.partFieldIDToType, w.partFieldIDToSize)
 
 	if dataFile, ok := entry.DataFile().(*dataFile); ok {
 		convertedPartitionData := make(map[string]any)
@@ -1235,7 +1503,7 @@ func (w *ManifestWriter) addEntry(entry *manifestEntry) error {
 				}
 			}
 		}
-		dataFile.PartitionData = convertedPartitionData
+		dataFile.PartitionValues = convertedPartitionData
 	}
 
 	if (entry.Status() == EntryStatusADDED || entry.Status() == EntryStatusEXISTING) &&
@@ -1251,6 +1519,23 @@ func (w *ManifestWriter) addEntry(entry *manifestEntry) error {
 	return w.writer.Encode(toEncode)
 }
 
+// partitionData returns the partition tuple of df typed by the partition
+// type of the manifest, so that values of the wrong type are rejected
+// rather than written.
+func (w *ManifestWriter) partitionData(df DataFile) (PartitionData, error) {
+	values := df.Partition()
+	if p, ok := df.PartitionData(); ok {
+		values = p.Map()
+	}
+
+	partition, err := newPartitionData(w.spec, w.partType.FieldList, values)
+	if err != nil {
+		return PartitionData{}, fmt.Errorf("data file %s: %w", df.FilePath(), err)
+	}
+
+	return partition, nil
+}
+
 func (w *ManifestWriter) Add(entry ManifestEntry) error {
 	w.reusedEntry.wrap(EntryStatusADDED, &w.snapshotID, entry.(*manifestEntry).SeqNum, nil, entry.DataFile())
 
@@ -1276,10 +1561,11 @@ type ManifestListWriter struct {
 	commitSnapshotID int64
 	sequenceNumber   int64
 	writer           *ocf.Encoder
+	encrypted        *encryption.StreamWriter
 	nextRowID        *int64
 }
 
-func NewManifestListWriterV1(out io.Writer, snapshotID int64, parentSnapshot *int64) (*ManifestListWriter, error) {
+func NewManifestListWriterV1(out io.Writer, snapshotID int64, parentSnapshot *int64, opts ...ManifestWriterOption) (*ManifestListWriter, error) {
 	m := &ManifestListWriter{
 		version:          1,
 		out:              out,
@@ -1296,10 +1582,10 @@ func NewManifestListWriterV1(out io.Writer, snapshotID int64, parentSnapshot *in
 		"format-version":     []byte(strconv.Itoa(m.version)),
 		"snapshot-id":        []byte(strconv.Itoa(int(snapshotID))),
 		"parent-snapshot-id": []byte(parentSnapshotStr),
-	})
+	}, opts)
 }
 
-func NewManifestListWriterV2(out io.Writer, snapshotID, sequenceNumber int64, parentSnapshot *int64) (*ManifestListWriter, error) {
+func NewManifestListWriterV2(out io.Writer, snapshotID, sequenceNumber int64, parentSnapshot *int64, opts ...ManifestWriterOption) (*ManifestListWriter, error) {
 	m := &ManifestListWriter{
 		version:          2,
 		out:              out,
@@ -1317,10 +1603,10 @@ func NewManifestListWriterV2(out io.Writer, snapshotID, sequenceNumber int64, pa
 		"snapshot-id":        []byte(strconv.Itoa(int(snapshotID))),
 		"sequence-number":    []byte(strconv.Itoa(int(sequenceNumber))),
 		"parent-snapshot-id": []byte(parentSnapshotStr),
-	})
+	}, opts)
 }
 
-func NewManifestListWriterV3(out io.Writer, snapshotId, sequenceNumber, firstRowID int64, parentSnapshot *int64) (*ManifestListWriter, error) {
+func NewManifestListWriterV3(out io.Writer, snapshotId, sequenceNumber, firstRowID int64, parentSnapshot *int64, opts ...ManifestWriterOption) (*ManifestListWriter, error) {
 	m := &ManifestListWriter{
 		version:          3,
 		out:              out,
@@ -1339,20 +1625,33 @@ func NewManifestListWriterV3(out io.Writer, snapshotId, sequenceNumber, firstRow
 		"sequence-number":    []byte(strconv.Itoa(int(sequenceNumber))),
 		"first-row-id":       []byte(strconv.Itoa(int(firstRowID))),
 		"parent-snapshot-id": []byte(parentSnapshotStr),
-	})
+	}, opts)
 }
 
-func (m *ManifestListWriter) init(meta map[string][]byte) error {
+func (m *ManifestListWriter) init(meta map[string][]byte, opts []ManifestWriterOption) error {
+	options := newManifestWriterOptions(opts)
+	codecOpts, err := options.encoderOptions()
+	if err != nil {
+		return err
+	}
+
+	if m.encrypted, err = options.encrypt(m.out); err != nil {
+		return err
+	}
+	if m.encrypted != nil {
+		m.out = m.encrypted
+	}
+
 	fileSchema, err := internal.NewManifestFileSchema(m.version)
 	if err != nil {
 		return err
 	}
 
-	enc, err := ocf.NewEncoderWithSchema(fileSchema, m.out,
+	enc, err := ocf.NewEncoderWithSchema(fileSchema, m.out, append([]ocf.EncoderFunc{
 		ocf.WithSchemaMarshaler(ocf.FullSchemaMarshaler),
 		ocf.WithEncoderSchemaCache(&avro.SchemaCache{}),
 		ocf.WithMetadata(meta),
-		ocf.WithCodec(ocf.Deflate))
+	}, codecOpts...)...)
 	if err != nil {
 		return err
 	}
@@ -1367,7 +1666,11 @@ func (m *ManifestListWriter) Close() error {
 		return nil
 	}
 
-	return m.writer.Close()
+	if err := m.writer.Close(); err != nil || m.encrypted == nil {
+		return err
+	}
+
+	return m.encrypted.Close()
 }
 
 func (m *ManifestListWriter) NextRowID() *int64 {
@@ -1397,7 +1700,9 @@ func (m *ManifestListWriter) AddManifests(files []ManifestFile) error {
 
 	case 2, 3:
 		for _, file := range files {
-			if file.Version() != m.version {
+			// version 2 manifests of tables upgraded to version 3 are
+			// carried over, and get their first row id below
+			if file.Version() != m.version && (m.version != 3 || file.Version() != 2) {
 				return fmt.Errorf("%w: ManifestListWriter only supports version %d manifest files", ErrInvalidArgument, m.version)
 			}
 
@@ -1445,22 +1750,22 @@ func (m *ManifestListWriter) AddManifests(files []ManifestFile) error {
 }
 
 // WriteManifestList writes a list of manifest files to an avro file.
-func WriteManifestList(version int, out io.Writer, snapshotID int64, parentSnapshotID, sequenceNumber *int64, firstRowId int64, files []ManifestFile) (err error) {
+func WriteManifestList(version int, out io.Writer, snapshotID int64, parentSnapshotID, sequenceNumber *int64, firstRowId int64, files []ManifestFile, opts ...ManifestWriterOption) (err error) {
 	var writer *ManifestListWriter
 
 	switch version {
 	case 1:
-		writer, err = NewManifestListWriterV1(out, snapshotID, parentSnapshotID)
+		writer, err = NewManifestListWriterV1(out, snapshotID, parentSnapshotID, opts...)
 	case 2:
 		if sequenceNumber == nil {
 			return errors.New("sequence number is required for V2 tables")
 		}
-		writer, err = NewManifestListWriterV2(out, snapshotID, *sequenceNumber, parentSnapshotID)
+		writer, err = NewManifestListWriterV2(out, snapshotID, *sequenceNumber, parentSnapshotID, opts...)
 	case 3:
 		if sequenceNumber == nil {
 			return errors.New("sequence number is required for V3 tables")
 		}
-		writer, err = NewManifestListWriterV3(out, snapshotID, *sequenceNumber, firstRowId, parentSnapshotID)
+		writer, err = NewManifestListWriterV3(out, snapshotID, *sequenceNumber, firstRowId, parentSnapshotID, opts...)
 	default:
 		return fmt.Errorf("unsupported manifest version: %d", version)
 	}
@@ -1481,10 +1786,44 @@ func WriteManifest(
 	schema *Schema,
 	snapshotID int64,
 	entries []ManifestEntry,
+	opts ...ManifestWriterOption,
+) (mf ManifestFile, err error) {
+	return writeManifest(filename, out, version, spec, schema, snapshotID, entries, ManifestContentData, opts...)
+}
+
+// WriteDeleteManifest writes a manifest tracking the delete files of the
+// given entries, in the same way as WriteManifest does for data files.
+func WriteDeleteManifest(
+	filename string,
+	out io.Writer,
+	version int,
+	spec PartitionSpec,
+	schema *Schema,
+	snapshotID int64,
+	entries []ManifestEntry,
+	opts ...ManifestWriterOption,
+) (mf ManifestFile, err error) {
+	if version < 2 {
+		return nil, fmt.Errorf("delete manifests are not supported in format version %d", version)
+	}
+
+	return writeManifest(filename, out, version, spec, schema, snapshotID, entries, ManifestContentDeletes, opts...)
+}
+
+func writeManifest(
+	filename string,
+	out io.Writer,
+	version int,
+	spec PartitionSpec,
+	schema *Schema,
+	snapshotID int64,
+	entries []ManifestEntry,
+	content ManifestContent,
+	opts ...ManifestWriterOption,
 ) (mf ManifestFile, err error) {
 	cnt := &internal.CountingWriter{W: out}
 
-	w, err := NewManifestWriter(version, cnt, spec, schema, snapshotID)
+	w, err := newManifestWriter(version, cnt, spec, schema, snapshotID, content, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1545,6 +1884,9 @@ const (
 	AvroFile    FileFormat = "AVRO"
 	OrcFile     FileFormat = "ORC"
 	ParquetFile FileFormat = "PARQUET"
+	// PuffinFile is the format of deletion vectors, the position deletes
+	// of format version 3 tables, stored as blobs of puffin files.
+	PuffinFile FileFormat = "PUFFIN"
 )
 
 type colMap[K, V any] struct {
@@ -1557,7 +1899,7 @@ func avroColMapToMap[K comparable, V any](c *[]colMap[K, V]) map[K]V {
 		return nil
 	}
 
-	out := make(map[K]V)
+	out := make(map[K]V, len(*c))
 	for _, data := range *c {
 		out[data.Key] = data.Value
 	}
@@ -1578,11 +1920,11 @@ func mapToAvroColMap[K comparable, V any](m map[K]V) *[]colMap[K, V] {
 	return &out
 }
 
-func avroPartitionData(input map[int]any, logicalTypes map[int]avro.LogicalType) map[int]any {
+func avroPartitionData(input map[int]any, logicalTypes map[int]avro.LogicalType, fixedSizes map[int]int) map[int]any {
 	out := make(map[int]any)
 	for k, v := range input {
 		if logical, ok := logicalTypes[k]; ok {
-			out[k] = convertLogicalTypeValue(v, logical)
+			out[k] = convertLogicalTypeValue(v, logical, fixedSizes[k])
 		} else {
 			out[k] = v
 		}
@@ -1591,7 +1933,7 @@ func avroPartitionData(input map[int]any, logicalTypes map[int]avro.LogicalType)
 	return out
 }
 
-func convertLogicalTypeValue(v any, logicalType avro.LogicalType) any {
+func convertLogicalTypeValue(v any, logicalType avro.LogicalType, fixedSize int) any {
 	switch logicalType {
 	case avro.Date:
 		return convertDateValue(v)
@@ -1600,7 +1942,7 @@ func convertLogicalTypeValue(v any, logicalType avro.LogicalType) any {
 	case avro.TimestampMicros:
 		return convertTimestampMicrosValue(v)
 	case avro.Decimal:
-		return convertDecimalValue(v)
+		return convertDecimalValue(v, fixedSize)
 	case avro.UUID:
 		return convertUUIDValue(v)
 	default:
@@ -1644,18 +1986,18 @@ func convertTimestampMicrosValue(v any) any {
 	return v
 }
 
-func convertDecimalValue(v any) any {
+func convertDecimalValue(v any, fixedSize int) any {
 	if v == nil {
 		return map[string]any{"null": nil}
 	}
 
 	if dec, ok := v.(Decimal); ok {
-		fixedSize := internal.DecimalRequiredBytes(len(dec.String()))
 		bytes, err := DecimalLiteral(dec).MarshalBinary()
-		if err != nil {
+		if err != nil || len(bytes) > fixedSize {
+			// the value does not fit the precision of the field
 			return v
 		}
-		fixedArray := convertToFixedArray(padOrTruncateBytes(bytes, fixedSize), fixedSize)
+		fixedArray := convertToFixedArray(signExtendBytes(bytes, fixedSize), fixedSize)
 
 		return map[string]any{"fixed": fixedArray}
 	}
@@ -1675,13 +2017,22 @@ func convertUUIDValue(v any) any {
 	return v
 }
 
-func padOrTruncateBytes(bytes []byte, size int) []byte {
+// signExtendBytes widens the big-endian two's complement value in bytes
+// to size bytes.
+func signExtendBytes(bytes []byte, size int) []byte {
 	if len(bytes) >= size {
-		return bytes[len(bytes)-size:]
+		return bytes
 	}
-	padded := slices.Grow(bytes, size-len(bytes))
 
-	return append(make([]byte, size-len(bytes)), padded...)
+	out := make([]byte, size)
+	if len(bytes) > 0 && bytes[0]&0x80 != 0 {
+		for i := range size - len(bytes) {
+			out[i] = 0xff
+		}
+	}
+	copy(out[size-len(bytes):], bytes)
+
+	return out
 }
 
 func convertToFixedArray(bytes []byte, size int) any {
@@ -1695,7 +2046,7 @@ type dataFile struct {
 	Content                 ManifestEntryContent   `avro:"content"`
 	Path                    string                 `avro:"file_path"`
 	Format                  FileFormat             `avro:"file_format"`
-	PartitionData           map[string]any         `avro:"partition"`
+	PartitionValues         map[string]any         `avro:"partition"`
 	RecordCount             int64                  `avro:"record_count"`
 	FileSize                int64                  `avro:"file_size_in_bytes"`
 	BlockSizeInBytes        int64                  `avro:"block_size_in_bytes"`
@@ -1729,23 +2080,45 @@ type dataFile struct {
 	fieldIDToPartitionData map[int]any
 	fieldIDToFixedSize     map[int]int
 
-	specID   int32
-	initMaps sync.Once
+	// partition is the typed partition tuple, either set when the file
+	// is built or derived on first use from the partition values and the
+	// partition type of the manifest the file was read from
+	partition     *PartitionData
+	partitionType *manifestPartitionType
+
+	specID int32
+
+	// the maps are built on first use, in groups, so that scan planning
+	// only pays for the ones its filters need
+	initPartition sync.Once
+	initTuple     sync.Once
+	initMetrics   sync.Once
+	initSizes     sync.Once
 }
 
-func (d *dataFile) initializeMapData() {
-	d.initMaps.Do(func() {
-		d.colSizeMap = avroColMapToMap(d.ColSizes)
+func (d *dataFile) initMetricMaps() {
+	d.initMetrics.Do(func() {
 		d.valCntMap = avroColMapToMap(d.ValCounts)
 		d.nullCntMap = avroColMapToMap(d.NullCounts)
 		d.nanCntMap = avroColMapToMap(d.NaNCounts)
-		d.distinctCntMap = avroColMapToMap(d.DistinctCounts)
 		d.lowerBoundMap = avroColMapToMap(d.LowerBounds)
 		d.upperBoundMap = avroColMapToMap(d.UpperBounds)
+	})
+}
+
+func (d *dataFile) initSizeMaps() {
+	d.initSizes.Do(func() {
+		d.colSizeMap = avroColMapToMap(d.ColSizes)
+		d.distinctCntMap = avroColMapToMap(d.DistinctCounts)
+	})
+}
+
+func (d *dataFile) initPartitionData() {
+	d.initPartition.Do(func() {
 		// Populate fieldIDToPartition map if dataFile read from manifest file
-		if len(d.fieldIDToPartitionData) < len(d.PartitionData) {
-			d.fieldIDToPartitionData = make(map[int]any, len(d.PartitionData))
-			for k, v := range d.PartitionData {
+		if len(d.fieldIDToPartitionData) < len(d.PartitionValues) {
+			d.fieldIDToPartitionData = make(map[int]any, len(d.PartitionValues))
+			for k, v := range d.PartitionValues {
 				if id, ok := d.fieldNameToID[k]; ok {
 					convertedValue := d.convertAvroValueToIcebergType(v, id)
 					d.fieldIDToPartitionData[id] = convertedValue
@@ -1835,53 +2208,73 @@ func (d *dataFile) FileFormat() FileFormat            { return d.Format }
 
 // Partition returns the partition data as a map of partition field ID to value.
 func (d *dataFile) Partition() map[int]any {
-	d.initializeMapData()
+	d.initPartitionData()
 
 	return d.fieldIDToPartitionData
 }
 
+// PartitionData returns the typed partition tuple of the file.
+func (d *dataFile) PartitionData() (PartitionData, bool) {
+	d.initTuple.Do(func() {
+		if d.partition != nil || d.partitionType == nil {
+			return
+		}
+
+		p, err := newPartitionData(d.partitionType.spec, d.partitionType.fields, d.Partition())
+		if err == nil {
+			d.partition = &p
+		}
+	})
+
+	if d.partition == nil {
+		return PartitionData{}, false
+	}
+
+	return *d.partition, true
+}
+
 func (d *dataFile) Count() int64         { return d.RecordCount }
 func (d *dataFile) FileSizeBytes() int64 { return d.FileSize }
 func (d *dataFile) SpecID() int32        { return d.specID }
 
 func (d *dataFile) ColumnSizes() map[int]int64 {
-	d.initializeMapData()
+	d.initSizeMaps()
 
 	return d.colSizeMap
 }
 
 func (d *dataFile) ValueCounts() map[int]int64 {
-	d.initializeMapData()
+	d.initMetricMaps()
 
 	return d.valCntMap
 }
 
 func (d *dataFile) NullValueCounts() map[int]int64 {
-	d.initializeMapData()
+	d.initMetricMaps()
 
 	return d.nullCntMap
 }
 
 func (d *dataFile) NaNValueCounts() map[int]int64 {
-	d.initializeMapData()
+	d.initMetricMaps()
 
 	return d.nanCntMap
 }
 
 func (d *dataFile) DistinctValueCounts() map[int]int64 {
-	d.initializeMapData()
+	d.initSizeMaps()
 
 	return d.distinctCntMap
 }
 
 func (d *dataFile) LowerBoundValues() map[int][]byte {
-	d.initializeMapData()
+	d.initMetricMaps()
 
 	return d.lowerBoundMap
 }
 
 func (d *dataFile) UpperBoundValues() map[int][]byte {
-	d.initializeMapData()
+	d.initMetricMaps()
 
 	return d.upperBoundMap
 }
@@ -1917,6 +2310,84 @@ func (d *dataFile) ReferencedDataFile() *string { return d.ReferencedDataFileFie
 func (d *dataFile) ContentSizeInBytes() *int64  { return d.ContentSizeInBytesField }
 func (d *dataFile) ContentOffset() *int64       { return d.ContentOffsetField }
 
+// RelocateDataFile returns a copy of df with its file path, and the path of
+// the data file it references if any, passed through rewrite. For position
+// delete files the bounds of the file_path column are rewritten as well, and
+// a positive fileSize replaces the original size to account for delete files
+// whose contents had to be rewritten.
+func RelocateDataFile(df DataFile, rewrite func(string) string, fileSize int64) DataFile {
+	d, ok := df.(*dataFile)
+	if !ok {
+		panic(fmt.Errorf("%w: cannot relocate data file of type %T", ErrInvalidArgument, df))
+	}
+
+	out := &dataFile{
+		Content:                 d.Content,
+		Path:                    rewrite(d.Path),
+		Format:                  d.Format,
+		PartitionValues:         d.PartitionValues,
+		RecordCount:             d.RecordCount,
+		FileSize:                d.FileSize,
+		BlockSizeInBytes:        d.BlockSizeInBytes,
+		ColSizes:                d.ColSizes,
+		ValCounts:               d.ValCounts,
+		NullCounts:              d.NullCounts,
+		NaNCounts:               d.NaNCounts,
+		DistinctCounts:          d.DistinctCounts,
+		LowerBounds:             d.LowerBounds,
+		UpperBounds:             d.UpperBounds,
+		Key:                     d.Key,
+		Splits:                  d.Splits,
+		EqualityIDs:             d.EqualityIDs,
+		SortOrder:               d.SortOrder,
+		FirstRowIDField:         d.FirstRowIDField,
+		ContentOffsetField:      d.ContentOffsetField,
+		ContentSizeInBytesField: d.ContentSizeInBytesField,
+		fieldNameToID:           d.fieldNameToID,
+		fieldIDToLogicalType:    d.fieldIDToLogicalType,
+		fieldIDToPartitionData:  d.fieldIDToPartitionData,
+		fieldIDToFixedSize:      d.fieldIDToFixedSize,
+		partitionType:           d.partitionType,
+		specID:                  d.specID,
+	}
+	if p, ok := d.PartitionData(); ok {
+		out.partition = &p
+	}
+
+	if fileSize > 0 {
+		out.FileSize = fileSize
+	}
+
+	if d.ReferencedDataFileField != nil {
+		ref := rewrite(*d.ReferencedDataFileField)
+		out.ReferencedDataFileField = &ref
+	}
+
+	if d.Content == EntryContentPosDeletes {
+		pathFieldID := PositionalDeleteSchema.Field(0).ID
+		out.LowerBounds = relocateBound(d.LowerBounds, pathFieldID, rewrite)
+		out.UpperBounds = relocateBound(d.UpperBounds, pathFieldID, rewrite)
+	}
+
+	return out
+}
+
+func relocateBound(bounds *[]colMap[int, []byte], fieldID int, rewrite func(string) string) *[]colMap[int, []byte] {
+	if bounds == nil {
+		return nil
+	}
+
+	out := make([]colMap[int, []byte], len(*bounds))
+	for i, b := range *bounds {
+		if b.Key == fieldID {
+			b.Value = []byte(rewrite(string(b.Value)))
+		}
+		out[i] = b
+	}
+
+	return &out
+}
+
 type ManifestEntryBuilder struct {
 	m *manifestEntry
 }
@@ -2064,10 +2535,14 @@ func NewDataFileBuilder(
 		return nil, fmt.Errorf("%w: path cannot be empty", ErrInvalidArgument)
 	}
 
-	if format != AvroFile && format != OrcFile && format != ParquetFile {
+	switch {
+	case format == PuffinFile && content != EntryContentPosDeletes:
+		return nil, fmt.Errorf("%w: format %s is only valid for position deletes",
+			ErrInvalidArgument, PuffinFile)
+	case format != AvroFile && format != OrcFile && format != ParquetFile && format != PuffinFile:
 		return nil, fmt.Errorf(
-			"%w: format must be one of %s, %s, or %s",
-			ErrInvalidArgument, AvroFile, OrcFile, ParquetFile,
+			"%w: format must be one of %s, %s, %s, or %s",
+			ErrInvalidArgument, AvroFile, OrcFile, ParquetFile, PuffinFile,
 		)
 	}
 
@@ -2092,7 +2567,7 @@ func NewDataFileBuilder(
 			Content:                content,
 			Path:                   path,
 			Format:                 format,
-			PartitionData:          partitionData,
+			PartitionValues:        partitionData,
 			RecordCount:            recordCount,
 			FileSize:               fileSize,
 			specID:                 int32(spec.id),
@@ -2230,6 +2705,10 @@ type DataFile interface {
 	// Partition returns a mapping of field id to partition value for
 	// each of the partition spec's fields.
 	Partition() map[int]any
+	// PartitionData returns the partition values typed by the partition
+	// type of the file's spec. It is known for files read from a manifest
+	// or built from a PartitionData, and ok is false otherwise.
+	PartitionData() (partition PartitionData, ok bool)
 	// Count returns the number of records in this file.
 	Count() int64
 	// FileSizeBytes is the total file size in bytes.
@@ -2315,5 +2794,5 @@ type ManifestEntry interface {
 
 var PositionalDeleteSchema = NewSchema(0,
 	NestedField{ID: 2147483546, Type: PrimitiveTypes.String, Name: "file_path", Required: true},
-	NestedField{ID: 2147483545, Type: PrimitiveTypes.Int32, Name: "pos", Required: true},
+	NestedField{ID: 2147483545, Type: PrimitiveTypes.Int64, Name: "pos", Required: true},
 )
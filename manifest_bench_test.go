@@ -0,0 +1,117 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+)
+
+// writeBenchManifest writes a v2 data manifest of n entries, each with a
+// partition value and column metrics, to a file in a temporary directory.
+func writeBenchManifest(b *testing.B, n int) (iceberg.ManifestFile, string) {
+	b.Helper()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "category", Type: iceberg.PrimitiveTypes.String, Required: true})
+	spec := iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 2, FieldID: 1000, Name: "category", Transform: iceberg.IdentityTransform{}})
+
+	snapshotID, seqNum := int64(1), int64(1)
+	entries := make([]iceberg.ManifestEntry, 0, n)
+	for i := range n {
+		category := fmt.Sprintf("category-%d", i%50)
+		idLower, _ := iceberg.Int64Literal(int64(i) * 10).MarshalBinary()
+		idUpper, _ := iceberg.Int64Literal(int64(i)*10 + 9).MarshalBinary()
+
+		bldr, err := iceberg.NewDataFileBuilder(spec, iceberg.EntryContentData,
+			fmt.Sprintf("s3://bucket/data/%s/%08d.parquet", category, i), iceberg.ParquetFile,
+			map[int]any{1000: category}, nil, nil, 10, 4096)
+		if err != nil {
+			b.Fatal(err)
+		}
+		df := bldr.
+			ColumnSizes(map[int]int64{1: 80, 2: 120}).
+			ValueCounts(map[int]int64{1: 10, 2: 10}).
+			NullValueCounts(map[int]int64{1: 0, 2: 0}).
+			LowerBoundValues(map[int][]byte{1: idLower, 2: []byte(category)}).
+			UpperBoundValues(map[int][]byte{1: idUpper, 2: []byte(category)}).
+			Build()
+		entries = append(entries, iceberg.NewManifestEntry(iceberg.EntryStatusADDED, &snapshotID, &seqNum, &seqNum, df))
+	}
+
+	path := filepath.Join(b.TempDir(), "manifest.avro")
+	var buf bytes.Buffer
+	mf, err := iceberg.WriteManifest(path, &buf, 2, spec, sc, snapshotID, entries)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		b.Fatal(err)
+	}
+
+	return mf, path
+}
+
+// BenchmarkManifestReader measures decoding every entry of a manifest
+// file, to be profiled with -cpuprofile and -memprofile.
+func BenchmarkManifestReader(b *testing.B) {
+	for _, n := range []int{1_000, 10_000} {
+		b.Run(fmt.Sprintf("%d_entries", n), func(b *testing.B) {
+			mf, path := writeBenchManifest(b, n)
+
+			b.ReportAllocs()
+			for b.Loop() {
+				f, err := os.Open(path)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				rdr, err := iceberg.NewManifestReader(mf, f)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				count := 0
+				for {
+					_, err := rdr.ReadEntry()
+					if errors.Is(err, io.EOF) {
+						break
+					}
+					if err != nil {
+						b.Fatal(err)
+					}
+					count++
+				}
+				f.Close()
+
+				if count != n {
+					b.Fatalf("read %d entries, expected %d", count, n)
+				}
+			}
+		})
+	}
+}
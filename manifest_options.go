@@ -0,0 +1,114 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/apache/iceberg-go/encryption"
+	"github.com/hamba/avro/v2/ocf"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression codecs for manifest and manifest list files, named as in
+// the write.avro.compression-codec table property.
+const (
+	AvroCodecGzip         = "gzip"
+	AvroCodecZstd         = "zstd"
+	AvroCodecSnappy       = "snappy"
+	AvroCodecUncompressed = "uncompressed"
+)
+
+// ManifestWriterOption configures how manifest and manifest list files
+// are written.
+type ManifestWriterOption func(*manifestWriterOptions)
+
+type manifestWriterOptions struct {
+	codec string
+	level int
+	key   *encryption.FileKey
+}
+
+func newManifestWriterOptions(opts []ManifestWriterOption) *manifestWriterOptions {
+	o := &manifestWriterOptions{codec: AvroCodecGzip, level: -1}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithManifestCompression sets the codec manifest and manifest list
+// files are compressed with, gzip by default. A negative level uses the
+// default level of the codec; the level is ignored by codecs without
+// one.
+func WithManifestCompression(codec string, level int) ManifestWriterOption {
+	return func(o *manifestWriterOptions) {
+		o.codec, o.level = codec, level
+	}
+}
+
+// WithManifestEncryption encrypts manifest and manifest list files as AES
+// GCM streams with the given key. The key metadata of the key is set on
+// the manifest files returned by [ManifestWriter.ToManifestFile]; the key
+// metadata of a manifest list is stored by the caller, wrapped by the KMS.
+func WithManifestEncryption(key encryption.FileKey) ManifestWriterOption {
+	return func(o *manifestWriterOptions) {
+		o.key = &key
+	}
+}
+
+// encrypt returns a writer encrypting to out, or nil if the file is not
+// encrypted. It must be closed after the file is written.
+func (o *manifestWriterOptions) encrypt(out io.Writer) (*encryption.StreamWriter, error) {
+	if o.key == nil {
+		return nil, nil
+	}
+
+	return encryption.NewStreamWriter(out, o.key.Key, o.key.AADPrefix)
+}
+
+func (o *manifestWriterOptions) encoderOptions() ([]ocf.EncoderFunc, error) {
+	switch o.codec {
+	case AvroCodecGzip, "":
+		if o.level < 0 {
+			return []ocf.EncoderFunc{ocf.WithCodec(ocf.Deflate)}, nil
+		}
+		if o.level > 9 {
+			return nil, fmt.Errorf("%w: gzip compression level must be between 0 and 9, got %d",
+				ErrInvalidArgument, o.level)
+		}
+
+		return []ocf.EncoderFunc{ocf.WithCompressionLevel(o.level)}, nil
+	case AvroCodecZstd:
+		opts := []ocf.EncoderFunc{ocf.WithCodec(ocf.ZStandard)}
+		if o.level >= 0 {
+			opts = append(opts, ocf.WithZStandardEncoderOptions(
+				zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(o.level))))
+		}
+
+		return opts, nil
+	case AvroCodecSnappy:
+		return []ocf.EncoderFunc{ocf.WithCodec(ocf.Snappy)}, nil
+	case AvroCodecUncompressed:
+		return []ocf.EncoderFunc{ocf.WithCodec(ocf.Null)}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown avro compression codec %q", ErrInvalidArgument, o.codec)
+	}
+}
@@ -19,12 +19,19 @@ package iceberg
 
 import (
 	"bytes"
+	"crypto/rand"
 	"errors"
+	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
+	"github.com/apache/iceberg-go/encryption"
 	"github.com/apache/iceberg-go/internal"
+	iceio "github.com/apache/iceberg-go/io"
 	"github.com/hamba/avro/v2"
 	"github.com/hamba/avro/v2/ocf"
 	"github.com/stretchr/testify/suite"
@@ -102,7 +109,7 @@ var (
 				Content:          EntryContentEqDeletes,
 				Path:             "/home/iceberg/warehouse/nyc/taxis_partitioned/data/VendorID=null/00000-633-d8a4223e-dc97-45a1-86e1-adaba6e8abd7-00001.parquet",
 				Format:           ParquetFile,
-				PartitionData:    map[string]any{"VendorID": int(1), "tpep_pickup_datetime": time.Unix(1925, 0).UnixMicro()},
+				PartitionValues:  map[string]any{"VendorID": int(1), "tpep_pickup_datetime": time.Unix(1925, 0).UnixMicro()},
 				RecordCount:      19513,
 				FileSize:         388872,
 				BlockSizeInBytes: 67108864,
@@ -221,7 +228,7 @@ var (
 			Data: &dataFile{
 				Path:             "/home/iceberg/warehouse/nyc/taxis_partitioned/data/VendorID=1/00000-633-d8a4223e-dc97-45a1-86e1-adaba6e8abd7-00002.parquet",
 				Format:           ParquetFile,
-				PartitionData:    map[string]any{"VendorID": int(1), "tpep_pickup_datetime": time.Unix(1925, 0).UnixMicro()},
+				PartitionValues:  map[string]any{"VendorID": int(1), "tpep_pickup_datetime": time.Unix(1925, 0).UnixMicro()},
 				RecordCount:      95050,
 				FileSize:         1265950,
 				BlockSizeInBytes: 67108864,
@@ -356,7 +363,7 @@ var (
 			Data: &dataFile{
 				Path:             dataRecord0.Path,
 				Format:           dataRecord0.Format,
-				PartitionData:    dataRecord0.PartitionData,
+				PartitionValues:  dataRecord0.PartitionValues,
 				RecordCount:      dataRecord0.RecordCount,
 				FileSize:         dataRecord0.FileSize,
 				BlockSizeInBytes: dataRecord0.BlockSizeInBytes,
@@ -376,7 +383,7 @@ var (
 			Data: &dataFile{
 				Path:             dataRecord1.Path,
 				Format:           dataRecord1.Format,
-				PartitionData:    dataRecord1.PartitionData,
+				PartitionValues:  dataRecord1.PartitionValues,
 				RecordCount:      dataRecord1.RecordCount,
 				FileSize:         dataRecord1.FileSize,
 				BlockSizeInBytes: dataRecord1.BlockSizeInBytes,
@@ -400,7 +407,7 @@ var (
 			Data: &dataFile{
 				Path:                    dataRecord0.Path,
 				Format:                  dataRecord0.Format,
-				PartitionData:           dataRecord0.PartitionData,
+				PartitionValues:         dataRecord0.PartitionValues,
 				RecordCount:             dataRecord0.RecordCount,
 				FileSize:                dataRecord0.FileSize,
 				BlockSizeInBytes:        dataRecord0.BlockSizeInBytes,
@@ -424,7 +431,7 @@ var (
 			Data: &dataFile{
 				Path:                    dataRecord1.Path,
 				Format:                  dataRecord1.Format,
-				PartitionData:           dataRecord1.PartitionData,
+				PartitionValues:         dataRecord1.PartitionValues,
 				RecordCount:             dataRecord1.RecordCount,
 				FileSize:                dataRecord1.FileSize,
 				BlockSizeInBytes:        dataRecord1.BlockSizeInBytes,
@@ -1037,6 +1044,147 @@ func (m *ManifestTestSuite) TestReadManifestIncompleteSchema() {
 	m.ErrorContains(err, "unknown type: r2")
 }
 
+func (m *ManifestTestSuite) TestManifestEntriesIterator() {
+	partitionSpec := NewPartitionSpecID(1)
+	snapshotID, seqNum := int64(12345678), int64(9876)
+	sc := NewSchema(123, NestedField{ID: 1, Name: "id", Type: Int64Type{}})
+
+	var entries []ManifestEntry
+	for i, status := range []ManifestEntryStatus{EntryStatusADDED, EntryStatusDELETED, EntryStatusEXISTING} {
+		bldr, err := NewDataFileBuilder(partitionSpec, EntryContentData,
+			"s3://bucket/namespace/table/data/file-"+string(rune('a'+i))+".parquet",
+			ParquetFile, map[int]any{}, map[int]avro.LogicalType{}, map[int]int{},
+			int64(i+1), 1000)
+		m.Require().NoError(err)
+		entries = append(entries, NewManifestEntry(status, &snapshotID, &seqNum, &seqNum, bldr.Build()))
+	}
+
+	var buf bytes.Buffer
+	file, err := WriteManifest(filepath.Join(m.T().TempDir(), "manifest.avro"), &buf, 2,
+		partitionSpec, sc, snapshotID, entries)
+	m.Require().NoError(err)
+	m.Require().NoError(os.WriteFile(file.FilePath(), buf.Bytes(), 0o644))
+
+	rdr, err := NewManifestReader(file, bytes.NewReader(buf.Bytes()))
+	m.Require().NoError(err)
+	var counts []int64
+	for entry, err := range rdr.Entries() {
+		m.Require().NoError(err)
+		counts = append(counts, entry.DataFile().Count())
+	}
+	m.Equal([]int64{1, 2, 3}, counts)
+
+	counts = counts[:0]
+	for entry, err := range ManifestEntries(iceio.LocalFS{}, file, true) {
+		m.Require().NoError(err)
+		counts = append(counts, entry.DataFile().Count())
+	}
+	m.Equal([]int64{1, 3}, counts)
+
+	counts = counts[:0]
+	for entry, err := range ManifestEntries(iceio.LocalFS{}, file, false) {
+		m.Require().NoError(err)
+		counts = append(counts, entry.DataFile().Count())
+
+		break
+	}
+	m.Equal([]int64{1}, counts)
+
+	missing := &manifestFile{Path: filepath.Join(m.T().TempDir(), "missing.avro")}
+	for entry, err := range ManifestEntries(iceio.LocalFS{}, missing, false) {
+		m.Nil(entry)
+		m.Error(err)
+	}
+}
+
+func (m *ManifestTestSuite) TestManifestDecimalPartitions() {
+	sc := NewSchema(0, NestedField{ID: 1, Name: "amount", Type: DecimalTypeOf(12, 2), Required: true})
+	spec := NewPartitionSpecID(1,
+		PartitionField{FieldID: 1000, SourceID: 1, Name: "amount", Transform: IdentityTransform{}})
+	snapshotID := int64(1)
+
+	values := []Decimal{
+		{Val: decimal128.FromI64(-150), Scale: 2},
+		{Val: decimal128.FromI64(123456789012), Scale: 2},
+		{Val: decimal128.FromI64(0), Scale: 2},
+	}
+
+	var entries []ManifestEntry
+	for i, v := range values {
+		bldr, err := NewDataFileBuilder(spec, EntryContentData,
+			fmt.Sprintf("s3://bucket/table/data/file-%d.parquet", i), ParquetFile,
+			map[int]any{1000: v}, nil, nil, 1, 100)
+		m.Require().NoError(err)
+		entries = append(entries, NewManifestEntry(EntryStatusADDED, &snapshotID, nil, nil, bldr.Build()))
+	}
+
+	var buf bytes.Buffer
+	file, err := WriteManifest("decimal.avro", &buf, 2, spec, sc, snapshotID, entries)
+	m.Require().NoError(err)
+
+	summary := file.Partitions()[0]
+	lower, err := BoundFromBytes(DecimalTypeOf(12, 2), *summary.LowerBound)
+	m.Require().NoError(err)
+	upper, err := BoundFromBytes(DecimalTypeOf(12, 2), *summary.UpperBound)
+	m.Require().NoError(err)
+	m.Equal("-1.50", lower.String())
+	m.Equal("1234567890.12", upper.String())
+
+	rdr, err := NewManifestReader(file, bytes.NewReader(buf.Bytes()))
+	m.Require().NoError(err)
+	var got []string
+	for entry, err := range rdr.Entries() {
+		m.Require().NoError(err)
+		got = append(got, fmt.Sprint(entry.DataFile().Partition()[1000]))
+	}
+	m.Equal([]string{"-1.50", "1234567890.12", "0.00"}, got)
+}
+
+func (m *ManifestTestSuite) TestManifestPartitionData() {
+	sc := NewSchema(0,
+		NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.Int64, Required: true},
+		NestedField{ID: 2, Name: "ts", Type: PrimitiveTypes.Timestamp, Required: false})
+	spec := NewPartitionSpecID(1,
+		PartitionField{FieldID: 1000, SourceID: 1, Name: "id_bucket", Transform: BucketTransform{NumBuckets: 8}},
+		PartitionField{FieldID: 1001, SourceID: 2, Name: "ts_day", Transform: DayTransform{}})
+	snapshotID := int64(1)
+
+	partition, err := NewPartitionData(spec, sc, map[int]any{1000: int32(3), 1001: Date(19000)})
+	m.Require().NoError(err)
+	bldr, err := NewDataFileBuilderWithPartition(partition, EntryContentData,
+		"s3://bucket/table/data/file.parquet", ParquetFile, 1, 100)
+	m.Require().NoError(err)
+
+	df := bldr.Build()
+	got, ok := df.PartitionData()
+	m.Require().True(ok)
+	m.True(got.Equals(partition))
+
+	var buf bytes.Buffer
+	file, err := WriteManifest("partition.avro", &buf, 2, spec, sc, snapshotID,
+		[]ManifestEntry{NewManifestEntry(EntryStatusADDED, &snapshotID, nil, nil, df)})
+	m.Require().NoError(err)
+
+	rdr, err := NewManifestReader(file, bytes.NewReader(buf.Bytes()))
+	m.Require().NoError(err)
+	for entry, err := range rdr.Entries() {
+		m.Require().NoError(err)
+		got, ok := entry.DataFile().PartitionData()
+		m.Require().True(ok)
+		m.True(got.Equals(partition), "got %s, want %s", got, partition)
+		m.Equal(spec, got.Spec())
+	}
+
+	// values that do not match the partition type are rejected when
+	// the file is written rather than written as they are
+	bldr, err = NewDataFileBuilder(spec, EntryContentData, "s3://bucket/table/data/bad.parquet",
+		ParquetFile, map[int]any{1000: "three", 1001: Date(19000)}, nil, nil, 1, 100)
+	m.Require().NoError(err)
+	_, err = WriteManifest("bad.avro", io.Discard, 2, spec, sc, snapshotID,
+		[]ManifestEntry{NewManifestEntry(EntryStatusADDED, &snapshotID, nil, nil, bldr.Build())})
+	m.ErrorContains(err, "bad.parquet")
+}
+
 func (m *ManifestTestSuite) TestManifestEntriesV2() {
 	manifest := manifestFile{
 		version: 2,
@@ -1371,6 +1519,90 @@ func (m *ManifestTestSuite) TestManifestWriterMeta() {
 	m.Equal("[]", string(md["partition-spec"]))
 }
 
+func (m *ManifestTestSuite) TestDeleteManifestWriter() {
+	sch := NewSchema(0, NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.Int64, Required: true})
+
+	_, err := NewDeleteManifestWriter(1, io.Discard, *UnpartitionedSpec, sch, 1)
+	m.Error(err)
+
+	var buf bytes.Buffer
+	w, err := NewDeleteManifestWriter(2, &buf, *UnpartitionedSpec, sch, 1)
+	m.Require().NoError(err)
+
+	md, err := w.meta()
+	m.Require().NoError(err)
+	m.Equal("deletes", string(md["content"]))
+
+	bldr, err := NewDataFileBuilder(*UnpartitionedSpec, EntryContentPosDeletes,
+		"s3://bucket/deletes.parquet", ParquetFile, nil, nil, nil, 1, 100)
+	m.Require().NoError(err)
+	m.Require().NoError(w.Add(NewManifestEntry(EntryStatusADDED, nil, nil, nil, bldr.Build())))
+
+	mf, err := w.ToManifestFile("s3://bucket/manifest.avro", int64(buf.Len()))
+	m.Require().NoError(err)
+	m.Equal(ManifestContentDeletes, mf.ManifestContent())
+	m.EqualValues(1, mf.AddedDataFiles())
+}
+
+func (m *ManifestTestSuite) TestManifestWriterCompression() {
+	sch := NewSchema(0, NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.Int64, Required: true})
+	bldr, err := NewDataFileBuilder(*UnpartitionedSpec, EntryContentData,
+		"s3://bucket/data.parquet", ParquetFile, nil, nil, nil, 1, 100)
+	m.Require().NoError(err)
+	seqNum := int64(1)
+
+	tests := []struct {
+		codec, expected string
+		level           int
+	}{
+		{AvroCodecGzip, "deflate", -1},
+		{AvroCodecGzip, "deflate", 9},
+		{AvroCodecZstd, "zstandard", -1},
+		{AvroCodecZstd, "zstandard", 3},
+		{AvroCodecSnappy, "snappy", -1},
+		{AvroCodecUncompressed, "null", -1},
+	}
+	for _, tt := range tests {
+		m.Run(fmt.Sprintf("%s-%d", tt.codec, tt.level), func() {
+			opt := WithManifestCompression(tt.codec, tt.level)
+
+			var manifest bytes.Buffer
+			w, err := NewManifestWriter(2, &manifest, *UnpartitionedSpec, sch, 1, opt)
+			m.Require().NoError(err)
+			m.Require().NoError(w.Add(NewManifestEntry(EntryStatusADDED, nil, nil, nil, bldr.Build())))
+			mf, err := w.ToManifestFile("s3://bucket/manifest.avro", int64(manifest.Len()))
+			m.Require().NoError(err)
+
+			rdr, err := newOCFReader(bytes.NewReader(manifest.Bytes()))
+			m.Require().NoError(err)
+			m.Equal(tt.expected, string(rdr.Metadata()["avro.codec"]))
+
+			entries, err := ReadManifest(mf, bytes.NewReader(manifest.Bytes()), false)
+			m.Require().NoError(err)
+			m.Len(entries, 1)
+
+			var list bytes.Buffer
+			m.Require().NoError(WriteManifestList(2, &list, 1, nil, &seqNum, 0, []ManifestFile{mf}, opt))
+
+			rdr, err = newOCFReader(bytes.NewReader(list.Bytes()))
+			m.Require().NoError(err)
+			m.Equal(tt.expected, string(rdr.Metadata()["avro.codec"]))
+
+			files, err := ReadManifestList(bytes.NewReader(list.Bytes()))
+			m.Require().NoError(err)
+			m.Len(files, 1)
+		})
+	}
+
+	_, err = NewManifestWriter(2, io.Discard, *UnpartitionedSpec, sch, 1,
+		WithManifestCompression("lz4", -1))
+	m.ErrorIs(err, ErrInvalidArgument)
+
+	err = WriteManifestList(2, io.Discard, 1, nil, &seqNum, 0, nil,
+		WithManifestCompression(AvroCodecGzip, 10))
+	m.ErrorIs(err, ErrInvalidArgument)
+}
+
 func TestManifests(t *testing.T) {
 	suite.Run(t, new(ManifestTestSuite))
 }
@@ -1559,6 +1791,112 @@ func (m *ManifestTestSuite) TestWriteManifestListClosesWriterOnError() {
 	m.Require().ErrorIs(err, errLimitedWrite)
 }
 
+func (m *ManifestTestSuite) newFileKey() encryption.FileKey {
+	key, aadPrefix := make([]byte, 16), make([]byte, encryption.DefaultAADPrefixLength)
+	_, err := rand.Read(key)
+	m.Require().NoError(err)
+	_, err = rand.Read(aadPrefix)
+	m.Require().NoError(err)
+
+	keyMetadata, err := encryption.KeyMetadata{EncryptionKey: key, AADPrefix: aadPrefix}.Encode()
+	m.Require().NoError(err)
+
+	return encryption.FileKey{Key: key, AADPrefix: aadPrefix, Metadata: keyMetadata}
+}
+
+func (m *ManifestTestSuite) TestManifestEncryption() {
+	sch := NewSchema(0, NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.Int64, Required: true})
+	bldr, err := NewDataFileBuilder(*UnpartitionedSpec, EntryContentData,
+		"s3://bucket/data.parquet", ParquetFile, nil, nil, nil, 1, 100)
+	m.Require().NoError(err)
+	seqNum := int64(1)
+
+	manifestKey := m.newFileKey()
+	var manifest bytes.Buffer
+	w, err := NewManifestWriter(2, &manifest, *UnpartitionedSpec, sch, 1,
+		WithManifestEncryption(manifestKey))
+	m.Require().NoError(err)
+	m.Require().NoError(w.Add(NewManifestEntry(EntryStatusADDED, nil, nil, nil, bldr.Build())))
+	m.Require().NoError(w.Close())
+
+	// the manifest is fully flushed once the writer is closed
+	mf, err := w.ToManifestFile("s3://bucket/manifest.avro", int64(manifest.Len()))
+	m.Require().NoError(err)
+	m.Equal("AGS1", string(manifest.Bytes()[:4]))
+	m.Equal(manifestKey.Metadata, mf.KeyMetadata())
+
+	entries, err := ReadManifest(mf, bytes.NewReader(manifest.Bytes()), false)
+	m.Require().NoError(err)
+	m.Require().Len(entries, 1)
+	m.Equal("s3://bucket/data.parquet", entries[0].DataFile().FilePath())
+
+	// readers which are not io.ReaderAt are decrypted as well
+	entries, err = ReadManifest(mf, io.MultiReader(bytes.NewReader(manifest.Bytes())), false)
+	m.Require().NoError(err)
+	m.Len(entries, 1)
+
+	listKey := m.newFileKey()
+	var list bytes.Buffer
+	m.Require().NoError(WriteManifestList(2, &list, 1, nil, &seqNum, 0, []ManifestFile{mf},
+		WithManifestEncryption(listKey)))
+	m.Equal("AGS1", string(list.Bytes()[:4]))
+
+	rdr, err := encryption.DecryptStream(bytes.NewReader(list.Bytes()), int64(list.Len()), listKey.Metadata)
+	m.Require().NoError(err)
+	files, err := ReadManifestList(rdr)
+	m.Require().NoError(err)
+	m.Require().Len(files, 1)
+	m.Equal(manifestKey.Metadata, files[0].KeyMetadata())
+	m.EqualValues(manifest.Len(), files[0].Length())
+
+	entries, err = ReadManifest(files[0], bytes.NewReader(manifest.Bytes()), false)
+	m.Require().NoError(err)
+	m.Len(entries, 1)
+
+	// a manifest read with the wrong key fails to authenticate
+	wrongKey := NewManifestFile(2, mf.FilePath(), mf.Length(), 0, 1).
+		KeyMetadata(m.newFileKey().Metadata).Build()
+	_, err = NewManifestReader(wrongKey, bytes.NewReader(manifest.Bytes()))
+	m.ErrorIs(err, encryption.ErrInvalidCipher)
+}
+
+func (m *ManifestTestSuite) TestManifestListV3KeepsV2Manifests() {
+	partitionSpec := NewPartitionSpecID(1)
+	snapshotID, seqNum := int64(12345678), int64(9876)
+	sc := NewSchema(123, NestedField{ID: 1, Name: "id", Type: Int64Type{}})
+
+	bldr, err := NewDataFileBuilder(partitionSpec, EntryContentData, "s3://bucket/namespace/table/data/file.parquet",
+		ParquetFile, map[int]any{}, map[int]avro.LogicalType{}, map[int]int{}, 5, 1000)
+	m.Require().NoError(err)
+
+	var manifest bytes.Buffer
+	file, err := WriteManifest("s3://bucket/namespace/table/metadata/manifest.avro", &manifest, 2,
+		partitionSpec, sc, snapshotID, []ManifestEntry{
+			NewManifestEntry(EntryStatusADDED, &snapshotID, &seqNum, &seqNum, bldr.Build()),
+		})
+	m.Require().NoError(err)
+
+	// the manifests of a table upgraded to version 3 are carried over and
+	// get a first row id
+	var list bytes.Buffer
+	m.Require().NoError(WriteManifestList(3, &list, snapshotID, nil, &seqNum, 10, []ManifestFile{file}))
+
+	files, err := ReadManifestList(&list)
+	m.Require().NoError(err)
+	m.Require().Len(files, 1)
+	m.Equal(3, files[0].Version())
+	m.Require().NotNil(files[0].FirstRowID())
+	m.EqualValues(10, *files[0].FirstRowID())
+
+	rdr, err := NewManifestReader(files[0], bytes.NewReader(manifest.Bytes()))
+	m.Require().NoError(err)
+	m.Equal(2, rdr.Version())
+	for entry, err := range rdr.Entries() {
+		m.Require().NoError(err)
+		m.EqualValues(5, entry.DataFile().Count())
+	}
+}
+
 func (m *ManifestTestSuite) TestWriteManifestClosesWriterOnEntryError() {
 	partitionSpec := NewPartitionSpecID(1,
 		PartitionField{FieldID: 1000, SourceID: 1, Name: "VendorID", Transform: IdentityTransform{}},
@@ -53,6 +53,23 @@ func (m *MappedField) GetField(field string) *MappedField {
 	return nil
 }
 
+// GetFieldCaseInsensitive returns the nested field mapped from the given
+// name ignoring case. A field whose names include the exact name is
+// preferred over fields that only match ignoring case.
+func (m *MappedField) GetFieldCaseInsensitive(field string) *MappedField {
+	if f := m.GetField(field); f != nil {
+		return f
+	}
+
+	for _, f := range m.Fields {
+		if slices.ContainsFunc(f.Names, func(n string) bool { return strings.EqualFold(n, field) }) {
+			return &f
+		}
+	}
+
+	return nil
+}
+
 func (m *MappedField) Len() int { return len(m.Fields) }
 
 func (m *MappedField) String() string {
@@ -257,7 +274,12 @@ func (u *updateNameMappingVisitor) addNewFields(mappedFields []MappedField, pare
 	return append(fields, newFields...)
 }
 
-type NameMappingAccessor struct{}
+// NameMappingAccessor is a PartnerAccessor for visiting a schema together
+// with the mapped fields of a name mapping. Fields are found by name,
+// ignoring case if CaseInsensitive is set.
+type NameMappingAccessor struct {
+	CaseInsensitive bool
+}
 
 func (NameMappingAccessor) SchemaPartner(partner *MappedField) *MappedField {
 	return partner
@@ -268,6 +290,10 @@ func (n NameMappingAccessor) FieldPartner(partnerStruct *MappedField, _ int, fie
 		return nil
 	}
 
+	if n.CaseInsensitive {
+		return partnerStruct.GetFieldCaseInsensitive(fieldName)
+	}
+
 	return partnerStruct.GetField(fieldName)
 }
 
@@ -359,6 +385,7 @@ func (n *nameMapProjectVisitor) Field(field NestedField, fieldPartner *MappedFie
 		Doc:            field.Doc,
 		InitialDefault: field.InitialDefault,
 		WriteDefault:   field.WriteDefault,
+		Metadata:       field.Metadata,
 	}
 }
 
@@ -422,11 +449,24 @@ func (n *nameMapProjectVisitor) Primitive(p PrimitiveType, primitivePartner *Map
 	return NestedField{Type: p}
 }
 
+// ApplyNameMapping assigns the field IDs of a name mapping to a schema
+// whose field IDs are unknown, such as the schema of a data file written
+// without them, matching fields by name.
 func ApplyNameMapping(schemaWithoutIDs *Schema, nameMapping NameMapping) (*Schema, error) {
+	return applyNameMapping(schemaWithoutIDs, nameMapping, true)
+}
+
+// ApplyNameMappingCaseInsensitive is ApplyNameMapping matching the names
+// of fields with the names of the mapping ignoring case.
+func ApplyNameMappingCaseInsensitive(schemaWithoutIDs *Schema, nameMapping NameMapping) (*Schema, error) {
+	return applyNameMapping(schemaWithoutIDs, nameMapping, false)
+}
+
+func applyNameMapping(schemaWithoutIDs *Schema, nameMapping NameMapping, caseSensitive bool) (*Schema, error) {
 	top, err := VisitSchemaWithPartner(schemaWithoutIDs,
 		&MappedField{Fields: nameMapping},
 		&nameMapProjectVisitor{currentPath: make([]string, 0, 1)},
-		NameMappingAccessor{})
+		NameMappingAccessor{CaseInsensitive: !caseSensitive})
 	if err != nil {
 		return nil, err
 	}
@@ -316,3 +316,46 @@ func TestUpdateNameMapping(t *testing.T) {
 		assert.Equal(t, expected, result)
 	})
 }
+
+func TestMappedFieldGetFieldCaseInsensitive(t *testing.T) {
+	person := &iceberg.MappedField{Fields: []iceberg.MappedField{
+		{FieldID: makeID(1), Names: []string{"Name"}},
+		{FieldID: makeID(2), Names: []string{"name"}},
+		{FieldID: makeID(3), Names: []string{"age"}},
+	}}
+
+	assert.Nil(t, person.GetField("AGE"))
+	assert.Equal(t, 3, *person.GetFieldCaseInsensitive("AGE").FieldID)
+	assert.Equal(t, 2, *person.GetFieldCaseInsensitive("name").FieldID)
+	assert.Equal(t, 1, *person.GetFieldCaseInsensitive("Name").FieldID)
+	assert.Nil(t, person.GetFieldCaseInsensitive("missing"))
+}
+
+func TestApplyNameMappingCaseInsensitive(t *testing.T) {
+	schemaWithoutIDs := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: -1, Name: "FOO", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: -1, Name: "Person", Type: &iceberg.StructType{
+			FieldList: []iceberg.NestedField{
+				{ID: -1, Name: "Name", Type: iceberg.PrimitiveTypes.String},
+				{ID: -1, Name: "AGE", Type: iceberg.PrimitiveTypes.Int32, Required: true},
+			},
+		}},
+	)
+
+	_, err := iceberg.ApplyNameMapping(schemaWithoutIDs, tableNameMappingNested)
+	require.ErrorContains(t, err, "field missing from name mapping: FOO")
+
+	sc, err := iceberg.ApplyNameMappingCaseInsensitive(schemaWithoutIDs, tableNameMappingNested)
+	require.NoError(t, err)
+
+	expected := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "FOO", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 15, Name: "Person", Type: &iceberg.StructType{
+			FieldList: []iceberg.NestedField{
+				{ID: 16, Name: "Name", Type: iceberg.PrimitiveTypes.String},
+				{ID: 17, Name: "AGE", Type: iceberg.PrimitiveTypes.Int32, Required: true},
+			},
+		}},
+	)
+	assert.True(t, expected.Equals(sc), sc.String())
+}
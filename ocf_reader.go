@@ -0,0 +1,412 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/hamba/avro/v2"
+	"github.com/klauspost/compress/zstd"
+)
+
+// ocfReader reads the values of an avro object container file. It is a
+// leaner replacement for ocf.Decoder on the manifest read path: parsed
+// schemas are cached by their JSON text so the decoders hamba/avro caches
+// per schema fingerprint are found without re-parsing, and the input and
+// block buffers are pooled and returned once the file is exhausted.
+type ocfReader struct {
+	in     *bufio.Reader
+	bufs   *ocfBuffers
+	rdr    *avro.Reader
+	meta   map[string][]byte
+	schema *ocfSchema
+	codec  string
+	sync   [16]byte
+	count  int64
+	err    error
+}
+
+const (
+	ocfInputBufferSize = 64 << 10
+	// ocfMaxLength bounds the length of a block or metadata value, which
+	// is read from the file before the bytes themselves.
+	ocfMaxLength = 256 << 20
+	// ocfSchemaCacheSize bounds the number of distinct file schemas kept
+	// by the schema cache, as tables accumulate partition specs and
+	// schemas over time.
+	ocfSchemaCacheSize = 256
+)
+
+var (
+	ocfMagic = [4]byte{'O', 'b', 'j', 1}
+
+	errInvalidOCF = errors.New("invalid avro file")
+
+	ocfInputPool = sync.Pool{New: func() any {
+		return bufio.NewReaderSize(nil, ocfInputBufferSize)
+	}}
+	ocfBufferPool = sync.Pool{New: func() any { return &ocfBuffers{} }}
+	flateReaders  sync.Pool
+
+	zstdDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+		return zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+	})
+)
+
+// ocfBuffers holds the compressed and decompressed bytes of the block
+// being read.
+type ocfBuffers struct {
+	raw  []byte
+	data []byte
+}
+
+// ocfSchema is a parsed file schema shared between every reader of files
+// written with it, along with lazily derived state for manifest readers.
+type ocfSchema struct {
+	avro.Schema
+
+	fieldIDsOnce  sync.Once
+	fieldNameToID map[string]int
+	fieldIDToType map[int]avro.LogicalType
+	fieldIDToSize map[int]int
+}
+
+func (s *ocfSchema) fieldIDs() (map[string]int, map[int]avro.LogicalType, map[int]int) {
+	s.fieldIDsOnce.Do(func() {
+		s.fieldNameToID, s.fieldIDToType, s.fieldIDToSize = getFieldIDMap(s.Schema)
+	})
+
+	return s.fieldNameToID, s.fieldIDToType, s.fieldIDToSize
+}
+
+var ocfSchemas = struct {
+	sync.Mutex
+	m map[string]*ocfSchema
+}{m: make(map[string]*ocfSchema)}
+
+func lookupOCFSchema(text []byte) (*ocfSchema, error) {
+	ocfSchemas.Lock()
+	defer ocfSchemas.Unlock()
+
+	if sc, ok := ocfSchemas.m[string(text)]; ok {
+		return sc, nil
+	}
+
+	// every file gets its own named type cache, as the partition record
+	// types of different specs share names
+	parsed, err := avro.ParseBytesWithCache(text, "", &avro.SchemaCache{})
+	if err != nil {
+		return nil, err
+	}
+
+	sc := &ocfSchema{Schema: parsed}
+
+	if len(ocfSchemas.m) >= ocfSchemaCacheSize {
+		clear(ocfSchemas.m)
+	}
+	ocfSchemas.m[string(text)] = sc
+
+	return sc, nil
+}
+
+func newOCFReader(in io.Reader) (*ocfReader, error) {
+	br := ocfInputPool.Get().(*bufio.Reader)
+	br.Reset(in)
+
+	r := &ocfReader{in: br, rdr: avro.NewReader(nil, 0)}
+	if err := r.readHeader(); err != nil {
+		r.release()
+
+		return nil, err
+	}
+	r.bufs = ocfBufferPool.Get().(*ocfBuffers)
+
+	return r, nil
+}
+
+func (r *ocfReader) readHeader() error {
+	var magic [4]byte
+	if _, err := io.ReadFull(r.in, magic[:]); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidOCF, err)
+	}
+	if magic != ocfMagic {
+		return errInvalidOCF
+	}
+
+	r.meta = make(map[string][]byte)
+	for {
+		n, err := binary.ReadVarint(r.in)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errInvalidOCF, err)
+		}
+		if n == 0 {
+			break
+		}
+		if n < 0 {
+			// a negative count is followed by the block size in bytes
+			n = -n
+			if _, err := binary.ReadVarint(r.in); err != nil {
+				return fmt.Errorf("%w: %w", errInvalidOCF, err)
+			}
+		}
+
+		for range n {
+			key, err := r.readBytes()
+			if err != nil {
+				return err
+			}
+			val, err := r.readBytes()
+			if err != nil {
+				return err
+			}
+			r.meta[string(key)] = val
+		}
+	}
+
+	if _, err := io.ReadFull(r.in, r.sync[:]); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidOCF, err)
+	}
+
+	switch r.codec = string(r.meta["avro.codec"]); r.codec {
+	case "", "null", "deflate", "snappy", "zstandard":
+	default:
+		return fmt.Errorf("%w: unknown codec %s", errInvalidOCF, r.codec)
+	}
+
+	var err error
+	r.schema, err = lookupOCFSchema(r.meta["avro.schema"])
+
+	return err
+}
+
+func (r *ocfReader) readBytes() ([]byte, error) {
+	n, err := binary.ReadVarint(r.in)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidOCF, err)
+	}
+
+	return r.readN(nil, n)
+}
+
+// readN reads n bytes into buf, reusing its capacity. Past that capacity
+// the buffer grows with the bytes actually read rather than up front, so
+// a corrupt length cannot allocate more than the rest of the file holds.
+func (r *ocfReader) readN(buf []byte, n int64) ([]byte, error) {
+	if n < 0 || n > ocfMaxLength {
+		return nil, fmt.Errorf("%w: invalid length %d", errInvalidOCF, n)
+	}
+
+	if int64(cap(buf)) >= n {
+		buf = buf[:n]
+		if _, err := io.ReadFull(r.in, buf); err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidOCF, err)
+		}
+
+		return buf, nil
+	}
+
+	b := bytes.NewBuffer(buf[:0])
+	if _, err := io.CopyN(b, r.in, n); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return nil, fmt.Errorf("%w: %w", errInvalidOCF, err)
+	}
+
+	return b.Bytes(), nil
+}
+
+// Metadata returns the metadata of the file header.
+func (r *ocfReader) Metadata() map[string][]byte { return r.meta }
+
+// Schema returns the schema the file was written with.
+func (r *ocfReader) Schema() avro.Schema { return r.schema.Schema }
+
+// HasNext reports whether there is another value to decode, reading the
+// next block of the file if the current one is exhausted.
+func (r *ocfReader) HasNext() bool {
+	for r.count <= 0 {
+		if r.err != nil || r.in == nil {
+			return false
+		}
+
+		r.count, r.err = r.readBlock()
+		if r.err != nil || r.count == 0 && r.in == nil {
+			r.release()
+
+			return false
+		}
+	}
+
+	return true
+}
+
+// Decode decodes the next value of the file into v.
+func (r *ocfReader) Decode(v any) error {
+	if r.count <= 0 {
+		return errors.New("decoder: no data found, call HasNext first")
+	}
+
+	r.count--
+	r.rdr.ReadVal(r.schema.Schema, v)
+	if r.rdr.Error != nil {
+		r.err = r.rdr.Error
+		r.release()
+
+		return r.err
+	}
+
+	return nil
+}
+
+// Error returns the error that stopped reading the file, if any.
+func (r *ocfReader) Error() error { return r.err }
+
+// readBlock reads the next block of the file, returning its number of
+// values. At the end of the file it releases the reader's buffers and
+// returns 0.
+func (r *ocfReader) readBlock() (int64, error) {
+	count, err := binary.ReadVarint(r.in)
+	if errors.Is(err, io.EOF) {
+		r.release()
+
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	size, err := binary.ReadVarint(r.in)
+	if err != nil {
+		return 0, err
+	}
+
+	raw, err := r.readN(r.bufs.raw, size)
+	if err != nil {
+		return 0, err
+	}
+	r.bufs.raw = raw
+
+	var sync [16]byte
+	if _, err := io.ReadFull(r.in, sync[:]); err != nil {
+		return 0, err
+	}
+	if sync != r.sync {
+		return 0, errors.New("decoder: invalid block")
+	}
+
+	if count == 0 {
+		return 0, nil
+	}
+
+	data, err := r.decompress(raw)
+	if err != nil {
+		return 0, err
+	}
+	r.rdr.Reset(data)
+
+	return count, nil
+}
+
+func (r *ocfReader) decompress(raw []byte) ([]byte, error) {
+	switch r.codec {
+	case "deflate":
+		src := bytes.NewReader(raw)
+		fr, ok := flateReaders.Get().(io.ReadCloser)
+		if ok {
+			if err := fr.(flate.Resetter).Reset(src, nil); err != nil {
+				return nil, err
+			}
+		} else {
+			fr = flate.NewReader(src)
+		}
+		defer flateReaders.Put(fr)
+
+		buf := bytes.NewBuffer(r.bufs.data[:0])
+		if _, err := buf.ReadFrom(fr); err != nil {
+			return nil, err
+		}
+		r.bufs.data = buf.Bytes()
+
+		return r.bufs.data, nil
+	case "snappy":
+		if len(raw) < 5 {
+			return nil, errors.New("block does not contain snappy checksum")
+		}
+
+		n, err := snappy.DecodedLen(raw[:len(raw)-4])
+		if err != nil {
+			return nil, err
+		}
+		dst := r.bufs.data
+		if cap(dst) < n {
+			dst = make([]byte, n)
+		}
+		dst, err = snappy.Decode(dst[:n], raw[:len(raw)-4])
+		if err != nil {
+			return nil, err
+		}
+		if crc32.ChecksumIEEE(dst) != binary.BigEndian.Uint32(raw[len(raw)-4:]) {
+			return nil, errors.New("snappy checksum mismatch")
+		}
+		r.bufs.data = dst
+
+		return dst, nil
+	case "zstandard":
+		dec, err := zstdDecoder()
+		if err != nil {
+			return nil, err
+		}
+
+		dst, err := dec.DecodeAll(raw, r.bufs.data[:0])
+		if err != nil {
+			return nil, err
+		}
+		r.bufs.data = dst
+
+		return dst, nil
+	default:
+		return raw, nil
+	}
+}
+
+// release returns the pooled buffers of the reader. Values already
+// decoded do not alias them, as the avro reader copies strings and bytes
+// out of the block.
+func (r *ocfReader) release() {
+	if r.in != nil {
+		r.in.Reset(nil)
+		ocfInputPool.Put(r.in)
+		r.in = nil
+	}
+	if r.bufs != nil {
+		r.rdr.Reset(nil)
+		ocfBufferPool.Put(r.bufs)
+		r.bufs = nil
+	}
+}
@@ -0,0 +1,136 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/hamba/avro/v2"
+	"github.com/hamba/avro/v2/ocf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ocfTestRecord struct {
+	ID   int64  `avro:"id"`
+	Name string `avro:"name"`
+	Data []byte `avro:"data"`
+}
+
+const ocfTestSchema = `{"type": "record", "name": "rec", "fields": [
+	{"name": "id", "type": "long"},
+	{"name": "name", "type": "string"},
+	{"name": "data", "type": "bytes"}
+]}`
+
+func writeOCFTestFile(t *testing.T, codec ocf.CodecName, n int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	enc, err := ocf.NewEncoder(ocfTestSchema, &buf, ocf.WithCodec(codec),
+		ocf.WithBlockLength(7), ocf.WithMetadata(map[string][]byte{"key": []byte("value")}))
+	require.NoError(t, err)
+
+	for i := range n {
+		require.NoError(t, enc.Encode(ocfTestRecord{
+			ID: int64(i), Name: fmt.Sprintf("name-%d", i), Data: bytes.Repeat([]byte{byte(i)}, i%5),
+		}))
+	}
+	require.NoError(t, enc.Close())
+
+	return buf.Bytes()
+}
+
+func TestOCFReader(t *testing.T) {
+	for _, codec := range []ocf.CodecName{ocf.Null, ocf.Deflate, ocf.Snappy, ocf.ZStandard} {
+		t.Run(string(codec), func(t *testing.T) {
+			// read twice so the second pass runs on pooled buffers
+			for range 2 {
+				rdr, err := newOCFReader(bytes.NewReader(writeOCFTestFile(t, codec, 50)))
+				require.NoError(t, err)
+				assert.Equal(t, []byte("value"), rdr.Metadata()["key"])
+				assert.Equal(t, avro.Record, rdr.Schema().Type())
+
+				var got []ocfTestRecord
+				for rdr.HasNext() {
+					var rec ocfTestRecord
+					require.NoError(t, rdr.Decode(&rec))
+					got = append(got, rec)
+				}
+				require.NoError(t, rdr.Error())
+				assert.False(t, rdr.HasNext())
+
+				require.Len(t, got, 50)
+				for i, rec := range got {
+					assert.Equal(t, int64(i), rec.ID)
+					assert.Equal(t, fmt.Sprintf("name-%d", i), rec.Name)
+					assert.Equal(t, bytes.Repeat([]byte{byte(i)}, i%5), rec.Data)
+				}
+			}
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		rdr, err := newOCFReader(bytes.NewReader(writeOCFTestFile(t, ocf.Deflate, 0)))
+		require.NoError(t, err)
+		assert.False(t, rdr.HasNext())
+		assert.NoError(t, rdr.Error())
+	})
+
+	t.Run("shared schema", func(t *testing.T) {
+		file := writeOCFTestFile(t, ocf.Null, 1)
+		first, err := newOCFReader(bytes.NewReader(file))
+		require.NoError(t, err)
+		second, err := newOCFReader(bytes.NewReader(file))
+		require.NoError(t, err)
+		assert.Same(t, first.schema, second.schema)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := newOCFReader(bytes.NewReader([]byte("not an avro file")))
+		assert.ErrorIs(t, err, errInvalidOCF)
+
+		file := writeOCFTestFile(t, ocf.Null, 10)
+		file[len(file)-1] ^= 0xff
+		rdr, err := newOCFReader(bytes.NewReader(file))
+		require.NoError(t, err)
+		for rdr.HasNext() {
+			require.NoError(t, rdr.Decode(&ocfTestRecord{}))
+		}
+		assert.ErrorContains(t, rdr.Error(), "invalid block")
+	})
+
+	t.Run("corrupt lengths", func(t *testing.T) {
+		header := binary.AppendVarint([]byte("Obj\x01"), 1)
+		for _, n := range []int64{-1, 1 << 40, 1 << 20} {
+			_, err := newOCFReader(bytes.NewReader(binary.AppendVarint(header, n)))
+			assert.ErrorIs(t, err, errInvalidOCF)
+		}
+
+		file := writeOCFTestFile(t, ocf.Null, 0)
+		for _, size := range []int64{-1, 1 << 40, 1 << 20} {
+			rdr, err := newOCFReader(bytes.NewReader(binary.AppendVarint(binary.AppendVarint(file, 1), size)))
+			require.NoError(t, err)
+			assert.False(t, rdr.HasNext())
+			assert.ErrorIs(t, rdr.Error(), errInvalidOCF)
+		}
+	})
+}
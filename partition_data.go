@@ -0,0 +1,343 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hamba/avro/v2"
+)
+
+// PartitionData is the partition tuple of a data or delete file. It holds
+// a value for each field of a partition spec, typed by the result type of
+// the field's transform, with nil literals for null values.
+//
+// Unlike the maps returned by DataFile.Partition, the values of a
+// PartitionData are validated against the partition type when it is
+// created, so that values of the wrong type are caught before they are
+// written to a manifest.
+type PartitionData struct {
+	spec   PartitionSpec
+	fields []NestedField
+	values []Literal
+}
+
+// NewPartitionData creates the partition tuple of the spec from values
+// keyed by partition field ID. Values may be literals or Go values of
+// the corresponding type, and fields without a value are null. It is an
+// error for a value to have a type that cannot represent the field's
+// result type, or for the values to include fields that are not part
+// of the spec.
+func NewPartitionData(spec PartitionSpec, schema *Schema, values map[int]any) (PartitionData, error) {
+	partType := spec.PartitionType(schema)
+	if len(partType.FieldList) != spec.NumFields() {
+		return PartitionData{}, fmt.Errorf("%w: partition spec %d has source fields that are not in the schema",
+			ErrInvalidSchema, spec.ID())
+	}
+
+	return newPartitionData(spec, partType.FieldList, values)
+}
+
+// newPartitionData creates the partition tuple of the spec with the given
+// partition type fields, which manifests record without the schema that
+// NewPartitionData derives them from.
+func newPartitionData(spec PartitionSpec, fields []NestedField, values map[int]any) (PartitionData, error) {
+	out := PartitionData{
+		spec:   spec,
+		fields: fields,
+		values: make([]Literal, len(fields)),
+	}
+
+	for i, f := range fields {
+		lit, err := partitionLiteral(f.Type, values[f.ID])
+		if err != nil {
+			return PartitionData{}, fmt.Errorf("partition field %d (%s): %w", f.ID, f.Name, err)
+		}
+		out.values[i] = lit
+	}
+
+	for id := range values {
+		if !slices.ContainsFunc(out.fields, func(f NestedField) bool { return f.ID == id }) {
+			return PartitionData{}, fmt.Errorf("%w: unknown partition field id %d for spec id %d",
+				ErrInvalidArgument, id, spec.ID())
+		}
+	}
+
+	return out, nil
+}
+
+// partitionLiteral converts a partition value to a literal of the
+// partition field type. Values must already have the Go or literal type
+// of the field, other than Go ints for integer fields and conversions
+// between types with the same representation, such as timestamps with
+// and without a zone, so that values are written to manifests with the
+// type that their field requires.
+func partitionLiteral(typ Type, val any) (Literal, error) {
+	var lit Literal
+	switch v := val.(type) {
+	case nil:
+		return nil, nil
+	case Literal:
+		lit = v
+	case int:
+		switch typ.(type) {
+		case Int32Type:
+			if v < math.MinInt32 || v > math.MaxInt32 {
+				return nil, fmt.Errorf("%w: %s partition value %d is out of range",
+					ErrInvalidArgument, typ, v)
+			}
+			lit = NewLiteral(int32(v))
+		default:
+			lit = NewLiteral(int64(v))
+		}
+	case bool:
+		lit = NewLiteral(v)
+	case int32:
+		lit = NewLiteral(v)
+	case int64:
+		lit = NewLiteral(v)
+	case float32:
+		lit = NewLiteral(v)
+	case float64:
+		lit = NewLiteral(v)
+	case string:
+		lit = NewLiteral(v)
+	case []byte:
+		lit = NewLiteral(v)
+	case Date:
+		lit = NewLiteral(v)
+	case Time:
+		lit = NewLiteral(v)
+	case Timestamp:
+		lit = NewLiteral(v)
+	case TimestampNano:
+		lit = NewLiteral(v)
+	case uuid.UUID:
+		lit = NewLiteral(v)
+	case Decimal:
+		lit = NewLiteral(v)
+	default:
+		return nil, fmt.Errorf("%w: unsupported partition value %v (%T) for type %s",
+			ErrInvalidArgument, val, val, typ)
+	}
+
+	if lit.Type().Equals(typ) {
+		return lit, nil
+	}
+
+	compatible := false
+	switch lit.Type().(type) {
+	case TimestampType, TimestampTzType:
+		_, compatible = typ.(TimestampTzType)
+		if !compatible {
+			_, compatible = typ.(TimestampType)
+		}
+	case TimestampNsType, TimestampTzNsType:
+		_, compatible = typ.(TimestampTzNsType)
+		if !compatible {
+			_, compatible = typ.(TimestampNsType)
+		}
+	case DateType:
+		// day partitions are ints, but may be read as dates
+		_, compatible = typ.(Int32Type)
+	case Int32Type:
+		_, compatible = typ.(DateType)
+	case BinaryType:
+		_, compatible = typ.(FixedType)
+	case DecimalType:
+		var dec DecimalType
+		dec, compatible = typ.(DecimalType)
+		compatible = compatible && dec.Scale() == lit.Type().(DecimalType).Scale()
+	}
+
+	if !compatible {
+		return nil, fmt.Errorf("%w: %s partition value %s has type %s",
+			ErrInvalidArgument, typ, lit, lit.Type())
+	}
+
+	if d, ok := lit.(DateLiteral); ok {
+		return NewLiteral(int32(d)), nil
+	}
+
+	out, err := lit.To(typ)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid %s partition value %s: %s",
+			ErrInvalidArgument, typ, lit, err)
+	}
+
+	return out, nil
+}
+
+// PartitionDataFromFile returns the typed partition tuple of a data or
+// delete file that was written with the given spec.
+func PartitionDataFromFile(df DataFile, spec PartitionSpec, schema *Schema) (PartitionData, error) {
+	if df.SpecID() != int32(spec.ID()) {
+		return PartitionData{}, fmt.Errorf("%w: file %s has partition spec id %d, not %d",
+			ErrInvalidArgument, df.FilePath(), df.SpecID(), spec.ID())
+	}
+
+	return NewPartitionData(spec, schema, df.Partition())
+}
+
+// Spec returns the partition spec of the tuple.
+func (p PartitionData) Spec() PartitionSpec { return p.spec }
+
+// Type returns the partition type of the tuple.
+func (p PartitionData) Type() *StructType { return &StructType{FieldList: p.fields} }
+
+// Size returns the number of partition fields.
+func (p PartitionData) Size() int { return len(p.values) }
+
+// Literal returns the value of the partition field at pos, which is nil
+// if the value is null.
+func (p PartitionData) Literal(pos int) Literal { return p.values[pos] }
+
+// Get returns the value of the partition field at pos as a Go value,
+// the form used by the maps of DataFile.Partition.
+func (p PartitionData) Get(pos int) any {
+	if p.values[pos] == nil {
+		return nil
+	}
+
+	return p.values[pos].Any()
+}
+
+// Value returns the value of the partition field with the given ID, and
+// whether the field is part of the spec.
+func (p PartitionData) Value(fieldID int) (Literal, bool) {
+	for i, f := range p.fields {
+		if f.ID == fieldID {
+			return p.values[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// Map returns the values keyed by partition field ID, as accepted by
+// NewDataFileBuilder and returned by DataFile.Partition.
+func (p PartitionData) Map() map[int]any {
+	out := make(map[int]any, len(p.fields))
+	for i, f := range p.fields {
+		out[f.ID] = p.Get(i)
+	}
+
+	return out
+}
+
+// Equals reports whether both tuples belong to the same spec and have
+// equal values.
+func (p PartitionData) Equals(other PartitionData) bool {
+	if p.spec.ID() != other.spec.ID() || len(p.values) != len(other.values) {
+		return false
+	}
+
+	for i, v := range p.values {
+		o := other.values[i]
+		if v == nil || o == nil {
+			if v != o {
+				return false
+			}
+
+			continue
+		}
+
+		if !v.Equals(o) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Path returns the partition path of the tuple, in the form used by
+// PartitionSpec.PartitionToPath.
+func (p PartitionData) Path() string {
+	var sb strings.Builder
+	for i := range p.fields {
+		if i > 0 {
+			sb.WriteByte('/')
+		}
+
+		field := p.spec.Field(i)
+		sb.WriteString(field.EscapedName())
+		sb.WriteByte('=')
+		sb.WriteString(url.QueryEscape(field.Transform.ToHumanStr(p.Get(i))))
+	}
+
+	return sb.String()
+}
+
+func (p PartitionData) String() string {
+	return "{" + strings.ReplaceAll(p.Path(), "/", ", ") + "}"
+}
+
+// avroTypes returns the avro logical types and fixed sizes that the
+// partition values are written to manifests with.
+func (p PartitionData) avroTypes() (map[int]avro.LogicalType, map[int]int) {
+	logicalTypes, fixedSizes := make(map[int]avro.LogicalType), make(map[int]int)
+	for _, f := range p.fields {
+		switch t := f.Type.(type) {
+		case DateType:
+			logicalTypes[f.ID] = avro.Date
+		case TimeType:
+			logicalTypes[f.ID] = avro.TimeMicros
+		case TimestampType, TimestampTzType:
+			logicalTypes[f.ID] = avro.TimestampMicros
+		case DecimalType:
+			logicalTypes[f.ID] = avro.Decimal
+			fixedSizes[f.ID] = t.Scale()
+		case UUIDType:
+			logicalTypes[f.ID] = avro.UUID
+		}
+	}
+
+	return logicalTypes, fixedSizes
+}
+
+// NewDataFileBuilderWithPartition creates a builder like
+// NewDataFileBuilder, taking the partition values and the types they
+// are written with from the typed partition tuple.
+func NewDataFileBuilderWithPartition(
+	partition PartitionData,
+	content ManifestEntryContent,
+	path string,
+	format FileFormat,
+	recordCount int64,
+	fileSize int64,
+) (*DataFileBuilder, error) {
+	var values map[int]any
+	if len(partition.fields) > 0 {
+		values = partition.Map()
+	}
+	logicalTypes, fixedSizes := partition.avroTypes()
+
+	bldr, err := NewDataFileBuilder(partition.spec, content, path, format,
+		values, logicalTypes, fixedSizes, recordCount, fileSize)
+	if err != nil {
+		return nil, err
+	}
+	bldr.d.partition = &partition
+
+	return bldr, nil
+}
@@ -0,0 +1,106 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitionData(t *testing.T) {
+	schema := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int32, Required: true},
+		iceberg.NestedField{ID: 2, Name: "ts", Type: iceberg.PrimitiveTypes.TimestampTz, Required: true},
+		iceberg.NestedField{ID: 3, Name: "region", Type: iceberg.PrimitiveTypes.String})
+	spec := iceberg.NewPartitionSpecID(3,
+		iceberg.PartitionField{SourceID: 1, FieldID: 1000, Name: "id", Transform: iceberg.IdentityTransform{}},
+		iceberg.PartitionField{SourceID: 2, FieldID: 1001, Name: "ts_day", Transform: iceberg.DayTransform{}},
+		iceberg.PartitionField{SourceID: 3, FieldID: 1002, Name: "region", Transform: iceberg.IdentityTransform{}})
+
+	t.Run("typed values", func(t *testing.T) {
+		p, err := iceberg.NewPartitionData(spec, schema, map[int]any{
+			1000: 7, 1001: iceberg.Date(19000), 1002: "us west",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, p.Size())
+		assert.Equal(t, iceberg.NewLiteral(int32(7)), p.Literal(0))
+		// day partitions are ints
+		assert.Equal(t, map[int]any{1000: int32(7), 1001: int32(19000), 1002: "us west"}, p.Map())
+		assert.Equal(t, "id=7/ts_day=2022-01-08/region=us+west", p.Path())
+
+		lit, ok := p.Value(1002)
+		assert.True(t, ok)
+		assert.Equal(t, iceberg.NewLiteral("us west"), lit)
+
+		other, err := iceberg.NewPartitionData(spec, schema, map[int]any{
+			1000: int32(7), 1001: iceberg.NewLiteral(int32(19000)), 1002: "us west",
+		})
+		require.NoError(t, err)
+		assert.True(t, p.Equals(other))
+	})
+
+	t.Run("null values", func(t *testing.T) {
+		p, err := iceberg.NewPartitionData(spec, schema, map[int]any{1000: int32(1)})
+		require.NoError(t, err)
+
+		assert.Nil(t, p.Literal(1))
+		assert.Nil(t, p.Get(2))
+		assert.Equal(t, "id=1/ts_day=null/region=null", p.Path())
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		for _, values := range []map[int]any{
+			{1000: "7"},
+			{1000: int64(7)},
+			{1000: math.MaxInt32 + 1},
+			{1001: int64(19000)},
+			{1002: []byte("us")},
+			{1003: "unknown"},
+		} {
+			_, err := iceberg.NewPartitionData(spec, schema, values)
+			assert.ErrorIs(t, err, iceberg.ErrInvalidArgument, "values %v", values)
+		}
+	})
+
+	t.Run("data file builder", func(t *testing.T) {
+		p, err := iceberg.NewPartitionData(spec, schema, map[int]any{
+			1000: int32(7), 1001: iceberg.Date(19000), 1002: "us",
+		})
+		require.NoError(t, err)
+
+		bldr, err := iceberg.NewDataFileBuilderWithPartition(p, iceberg.EntryContentData,
+			"s3://bucket/data.parquet", iceberg.ParquetFile, 10, 100)
+		require.NoError(t, err)
+		df := bldr.Build()
+
+		assert.EqualValues(t, 3, df.SpecID())
+		assert.Equal(t, p.Map(), df.Partition())
+
+		fromFile, err := iceberg.PartitionDataFromFile(df, spec, schema)
+		require.NoError(t, err)
+		assert.True(t, p.Equals(fromFile))
+
+		_, err = iceberg.PartitionDataFromFile(df, *iceberg.UnpartitionedSpec, schema)
+		assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	})
+}
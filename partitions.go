@@ -18,13 +18,16 @@
 package iceberg
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"iter"
 	"net/url"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -483,7 +486,7 @@ func (ps *PartitionSpec) PartitionType(schema *Schema) *StructType {
 //
 // This does not apply the transforms to the data, it is assumed the provided data
 // has already been transformed appropriately.
-func (ps *PartitionSpec) PartitionToPath(data structLike, sc *Schema) string {
+func (ps *PartitionSpec) PartitionToPath(data StructLike, sc *Schema) string {
 	partType := ps.PartitionType(sc)
 
 	if len(partType.FieldList) == 0 {
@@ -516,6 +519,154 @@ func (ps *PartitionSpec) PartitionToPath(data structLike, sc *Schema) string {
 	return sb.String()
 }
 
+// ParsePartitionValue parses a partition path in the layout written by
+// PartitionToPath, such as "event_date=2024-05-01/bucket=3", into the
+// partition values of the spec keyed by partition field ID. Names and
+// values are URL decoded and values are parsed according to the
+// transform of their field, with "null" parsed as a null value. Every
+// field of the spec must appear exactly once, in any order. Errors wrap
+// ErrInvalidArgument.
+func (ps *PartitionSpec) ParsePartitionValue(sc *Schema, path string) (map[int]any, error) {
+	byName := make(map[string]PartitionField, len(ps.fields))
+	for _, f := range ps.fields {
+		byName[f.Name] = f
+	}
+
+	values := make(map[int]any, len(ps.fields))
+	if path = strings.Trim(path, "/"); path == "" {
+		if len(ps.fields) > 0 {
+			return nil, fmt.Errorf("%w: empty partition path for partitioned spec", ErrInvalidArgument)
+		}
+
+		return values, nil
+	}
+
+	for part := range strings.SplitSeq(path, "/") {
+		escName, escValue, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid partition path segment %q, expected name=value",
+				ErrInvalidArgument, part)
+		}
+
+		name, err := url.QueryUnescape(escName)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid partition field name %q: %w", ErrInvalidArgument, escName, err)
+		}
+		value, err := url.QueryUnescape(escValue)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid value of partition field %s: %w", ErrInvalidArgument, name, err)
+		}
+
+		field, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown partition field %q", ErrInvalidArgument, name)
+		}
+		if _, ok := values[field.FieldID]; ok {
+			return nil, fmt.Errorf("%w: duplicate partition field %q", ErrInvalidArgument, name)
+		}
+
+		source, ok := sc.FindFieldByID(field.SourceID)
+		if !ok {
+			return nil, fmt.Errorf("%w: source field %d of partition field %s not found in schema",
+				ErrInvalidArgument, field.SourceID, name)
+		}
+
+		if values[field.FieldID], err = parsePartitionFieldValue(field.Transform, source.Type, value); err != nil {
+			return nil, fmt.Errorf("%w: partition field %s: %w", ErrInvalidArgument, name, err)
+		}
+	}
+
+	for _, f := range ps.fields {
+		if _, ok := values[f.FieldID]; !ok {
+			return nil, fmt.Errorf("%w: missing partition field %q", ErrInvalidArgument, f.Name)
+		}
+	}
+
+	return values, nil
+}
+
+// parsePartitionFieldValue parses the human readable string of a value of
+// the transform, as returned by Transform.ToHumanStr, for a source of the
+// given type.
+func parsePartitionFieldValue(transform Transform, sourceType Type, value string) (any, error) {
+	if value == "null" {
+		return nil, nil
+	}
+
+	var (
+		tm  time.Time
+		err error
+	)
+	switch t := transform.(type) {
+	case VoidTransform:
+		return nil, fmt.Errorf("void transform values must be null, got %q", value)
+	case BucketTransform:
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 || n >= int64(t.NumBuckets) {
+			return nil, fmt.Errorf("bucket %d out of range for %s", n, t)
+		}
+
+		return int32(n), nil
+	case YearTransform:
+		year, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return int32(year - epochTM.Year()), nil
+	case MonthTransform:
+		if tm, err = time.Parse("2006-01", value); err != nil {
+			return nil, err
+		}
+
+		return int32((tm.Year()-epochTM.Year())*12 + int(tm.Month()) - 1), nil
+	case DayTransform:
+		if tm, err = time.Parse("2006-01-02", value); err != nil {
+			return nil, err
+		}
+
+		return int32(tm.Unix() / int64((24 * time.Hour).Seconds())), nil
+	case HourTransform:
+		if tm, err = time.Parse("2006-01-02-15", value); err != nil {
+			return nil, err
+		}
+
+		return int32(tm.Unix() / int64(time.Hour.Seconds())), nil
+	}
+
+	resultType := transform.ResultType(sourceType)
+	switch resultType.(type) {
+	case BinaryType, FixedType:
+		b, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, err
+		}
+		if fixed, ok := resultType.(FixedType); ok && len(b) != fixed.Len() {
+			return nil, fmt.Errorf("expected %d bytes for %s, got %d", fixed.Len(), fixed, len(b))
+		}
+
+		return b, nil
+	case TimestampTzType:
+		// partition paths hold timestamps without their zone, in UTC
+		resultType = PrimitiveTypes.Timestamp
+	}
+
+	lit, err := StringLiteral(value).To(resultType)
+	if err != nil {
+		return nil, err
+	}
+
+	switch lit.(type) {
+	case AboveMaxLiteral, BelowMinLiteral:
+		return nil, fmt.Errorf("value %s out of range for %s", value, resultType)
+	}
+
+	return lit.Any(), nil
+}
+
 // GeneratePartitionFieldName returns default partition field name based on field transform type
 //
 // The default names are aligned with other client implementations
@@ -190,6 +190,72 @@ func TestPartitionSpecToPath(t *testing.T) {
 		spec.PartitionToPath(record, schema))
 }
 
+func TestPartitionSpecParsePartitionValue(t *testing.T) {
+	schema := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "ts", Type: iceberg.PrimitiveTypes.TimestampTz},
+		iceberg.NestedField{ID: 2, Name: "id", Type: iceberg.PrimitiveTypes.Int64},
+		iceberg.NestedField{ID: 3, Name: "name", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 4, Name: "payload", Type: iceberg.PrimitiveTypes.Binary},
+		iceberg.NestedField{ID: 5, Name: "event_date", Type: iceberg.PrimitiveTypes.Date})
+
+	spec := iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 1, FieldID: 1000, Transform: iceberg.YearTransform{}, Name: "ts_year"},
+		iceberg.PartitionField{SourceID: 1, FieldID: 1001, Transform: iceberg.MonthTransform{}, Name: "ts_month"},
+		iceberg.PartitionField{SourceID: 1, FieldID: 1002, Transform: iceberg.DayTransform{}, Name: "ts_day"},
+		iceberg.PartitionField{SourceID: 1, FieldID: 1003, Transform: iceberg.HourTransform{}, Name: "ts_hour"},
+		iceberg.PartitionField{SourceID: 2, FieldID: 1004, Transform: iceberg.BucketTransform{NumBuckets: 8}, Name: "id bucket"},
+		iceberg.PartitionField{SourceID: 3, FieldID: 1005, Transform: iceberg.TruncateTransform{Width: 3}, Name: "name_trunc"},
+		iceberg.PartitionField{SourceID: 4, FieldID: 1006, Transform: iceberg.IdentityTransform{}, Name: "payload"},
+		iceberg.PartitionField{SourceID: 5, FieldID: 1007, Transform: iceberg.IdentityTransform{}, Name: "event_date"},
+		iceberg.PartitionField{SourceID: 1, FieldID: 1008, Transform: iceberg.IdentityTransform{}, Name: "ts"},
+		iceberg.PartitionField{SourceID: 2, FieldID: 1009, Transform: iceberg.VoidTransform{}, Name: "id_null"})
+
+	record := partitionRecord{
+		int32(54), int32(652), int32(19844), int32(476263), int32(3), "a/b",
+		[]byte{0xde, 0xad}, iceberg.Date(19844), iceberg.Timestamp(1714567380123456), nil,
+	}
+	path := spec.PartitionToPath(record, schema)
+	assert.Equal(t, "ts_year=2024/ts_month=2024-05/ts_day=2024-05-01/ts_hour=2024-05-01-07/"+
+		"id+bucket=3/name_trunc=a%2Fb/payload=3q0%3D/event_date=2024-05-01/"+
+		"ts=2024-05-01T12%3A43%3A00.123456/id_null=null", path)
+
+	values, err := spec.ParsePartitionValue(schema, path)
+	require.NoError(t, err)
+	for i := range spec.NumFields() {
+		f := spec.Field(i)
+		assert.Equal(t, record[i], values[f.FieldID], f.Name)
+	}
+
+	// fields may be given in any order
+	unordered := iceberg.NewPartitionSpec(spec.Field(7), spec.Field(4))
+	values, err = unordered.ParsePartitionValue(schema, "id+bucket=3/event_date=2024-05-01")
+	require.NoError(t, err)
+	assert.Equal(t, map[int]any{1004: int32(3), 1007: iceberg.Date(19844)}, values)
+
+	values, err = iceberg.UnpartitionedSpec.ParsePartitionValue(schema, "")
+	require.NoError(t, err)
+	assert.Empty(t, values)
+
+	tests := []struct {
+		path, msg string
+	}{
+		{"", "empty partition path"},
+		{"event_date", `expected name=value`},
+		{"event_date=2024-05-01/other=1", `unknown partition field "other"`},
+		{"event_date=2024-05-01/event_date=2024-05-02", `duplicate partition field "event_date"`},
+		{"event_date=2024-05-01", `missing partition field "id bucket"`},
+		{"event_date=2024-05-01/id+bucket=8", "bucket 8 out of range for bucket[8]"},
+		{"event_date=May/id+bucket=1", "partition field event_date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			_, err := unordered.ParsePartitionValue(schema, tt.path)
+			assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+			assert.ErrorContains(t, err, tt.msg)
+		})
+	}
+}
+
 func TestGetPartitionFieldName(t *testing.T) {
 	schema := iceberg.NewSchema(0,
 		iceberg.NestedField{ID: 1, Name: "str", Type: iceberg.PrimitiveTypes.String},
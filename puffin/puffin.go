@@ -0,0 +1,321 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package puffin reads and writes Puffin files, the format Iceberg uses
+// to store blobs such as table statistics and deletion vectors that do
+// not fit in manifests.
+//
+// A file starts with the magic bytes, followed by the blobs and a footer
+// describing them:
+//
+//	Magic Blob₁ Blob₂ ... Blobₙ Magic FooterPayload FooterPayloadSize Flags Magic
+//
+// where the footer payload is the JSON encoding of the FileMetadata.
+package puffin
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Magic is the byte sequence that starts and ends a Puffin file and
+// opens its footer.
+var Magic = [4]byte{0x50, 0x46, 0x41, 0x31}
+
+const (
+	// CompressionZstd is the codec of blobs compressed with zstd.
+	CompressionZstd = "zstd"
+	// CompressionLZ4 is the codec of blobs compressed with LZ4 frames,
+	// which this package cannot read.
+	CompressionLZ4 = "lz4"
+
+	// CreatedByProperty is the file property naming the application
+	// that wrote the file.
+	CreatedByProperty = "created-by"
+
+	// flag bit of the first flags byte set when the footer payload is
+	// compressed with LZ4
+	flagFooterCompressed = 1 << 0
+
+	// footer fields that follow the payload: its size, the flags and the
+	// closing magic
+	footerTrailerSize = 4 + 4 + len(Magic)
+)
+
+// ErrInvalidPuffin is returned when a file is not a valid Puffin file.
+var ErrInvalidPuffin = errors.New("invalid puffin file")
+
+// BlobMetadata describes a blob stored in a Puffin file.
+type BlobMetadata struct {
+	Type             string            `json:"type"`
+	Fields           []int32           `json:"fields"`
+	SnapshotID       int64             `json:"snapshot-id"`
+	SequenceNumber   int64             `json:"sequence-number"`
+	Offset           int64             `json:"offset"`
+	Length           int64             `json:"length"`
+	CompressionCodec *string           `json:"compression-codec,omitempty"`
+	Properties       map[string]string `json:"properties,omitempty"`
+}
+
+// FileMetadata is the content of the footer of a Puffin file.
+type FileMetadata struct {
+	Blobs      []BlobMetadata    `json:"blobs"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// Blob is a blob to be added to a Puffin file. The snapshot ID and
+// sequence number are those the blob was computed from, or -1 if the
+// blob does not depend on a snapshot, as for deletion vectors.
+type Blob struct {
+	Type           string
+	Fields         []int32
+	SnapshotID     int64
+	SequenceNumber int64
+	Properties     map[string]string
+	Data           []byte
+}
+
+// Writer writes a Puffin file. Blobs are written uncompressed as they
+// are added, and Finish writes the footer.
+type Writer struct {
+	w        io.Writer
+	offset   int64
+	meta     FileMetadata
+	started  bool
+	finished bool
+}
+
+// NewWriter returns a writer of a Puffin file to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w, meta: FileMetadata{Blobs: []BlobMetadata{}}}
+}
+
+// SetProperty sets a property of the file, recorded in its footer.
+func (w *Writer) SetProperty(key, value string) {
+	if w.meta.Properties == nil {
+		w.meta.Properties = make(map[string]string)
+	}
+	w.meta.Properties[key] = value
+}
+
+func (w *Writer) write(p []byte) error {
+	n, err := w.w.Write(p)
+	w.offset += int64(n)
+
+	return err
+}
+
+func (w *Writer) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+
+	return w.write(Magic[:])
+}
+
+// Add writes the blob and returns its metadata, which records where in
+// the file the blob is stored.
+func (w *Writer) Add(blob Blob) (BlobMetadata, error) {
+	if w.finished {
+		return BlobMetadata{}, errors.New("puffin writer is finished")
+	}
+
+	if blob.Type == "" {
+		return BlobMetadata{}, errors.New("puffin blob type must not be empty")
+	}
+
+	if err := w.start(); err != nil {
+		return BlobMetadata{}, err
+	}
+
+	meta := BlobMetadata{
+		Type:           blob.Type,
+		Fields:         slices.Clone(blob.Fields),
+		SnapshotID:     blob.SnapshotID,
+		SequenceNumber: blob.SequenceNumber,
+		Offset:         w.offset,
+		Length:         int64(len(blob.Data)),
+		Properties:     blob.Properties,
+	}
+	if meta.Fields == nil {
+		meta.Fields = []int32{}
+	}
+
+	if err := w.write(blob.Data); err != nil {
+		return BlobMetadata{}, err
+	}
+	w.meta.Blobs = append(w.meta.Blobs, meta)
+
+	return meta, nil
+}
+
+// Finish writes the footer and returns the total size of the file. The
+// writer cannot be used afterwards.
+func (w *Writer) Finish() (int64, error) {
+	if w.finished {
+		return w.offset, nil
+	}
+
+	if err := w.start(); err != nil {
+		return 0, err
+	}
+	w.finished = true
+
+	payload, err := json.Marshal(w.meta)
+	if err != nil {
+		return 0, err
+	}
+
+	footer := make([]byte, 0, 2*len(Magic)+len(payload)+footerTrailerSize)
+	footer = append(footer, Magic[:]...)
+	footer = append(footer, payload...)
+	footer = binary.LittleEndian.AppendUint32(footer, uint32(len(payload)))
+	footer = append(footer, 0, 0, 0, 0)
+	footer = append(footer, Magic[:]...)
+
+	if err := w.write(footer); err != nil {
+		return 0, err
+	}
+
+	return w.offset, nil
+}
+
+// Blobs returns the metadata of the blobs written so far.
+func (w *Writer) Blobs() []BlobMetadata { return w.meta.Blobs }
+
+// Reader reads the blobs of a Puffin file.
+type Reader struct {
+	r    io.ReaderAt
+	size int64
+	meta FileMetadata
+}
+
+// NewReader reads the footer of the Puffin file of the given size.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	minSize := int64(2*len(Magic) + footerTrailerSize)
+	if size < minSize {
+		return nil, fmt.Errorf("%w: file of %d bytes is too small", ErrInvalidPuffin, size)
+	}
+
+	var head [len(Magic)]byte
+	if err := readAt(r, head[:], 0); err != nil {
+		return nil, err
+	}
+	if head != Magic {
+		return nil, fmt.Errorf("%w: missing magic at file start", ErrInvalidPuffin)
+	}
+
+	trailer := make([]byte, footerTrailerSize)
+	if err := readAt(r, trailer, size-int64(footerTrailerSize)); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(trailer[8:], Magic[:]) {
+		return nil, fmt.Errorf("%w: missing magic at file end", ErrInvalidPuffin)
+	}
+	if trailer[4]&flagFooterCompressed != 0 {
+		return nil, fmt.Errorf("%w: compressed footers are not supported", ErrInvalidPuffin)
+	}
+
+	payloadSize := int64(binary.LittleEndian.Uint32(trailer[:4]))
+	footerStart := size - int64(footerTrailerSize) - payloadSize - int64(len(Magic))
+	if footerStart < int64(len(Magic)) {
+		return nil, fmt.Errorf("%w: footer payload of %d bytes exceeds the file", ErrInvalidPuffin, payloadSize)
+	}
+
+	footer := make([]byte, int64(len(Magic))+payloadSize)
+	if err := readAt(r, footer, footerStart); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(footer[:len(Magic)], Magic[:]) {
+		return nil, fmt.Errorf("%w: missing magic at footer start", ErrInvalidPuffin)
+	}
+
+	var meta FileMetadata
+	if err := json.Unmarshal(footer[len(Magic):], &meta); err != nil {
+		return nil, fmt.Errorf("%w: invalid footer: %s", ErrInvalidPuffin, err)
+	}
+
+	for _, b := range meta.Blobs {
+		if b.Offset < int64(len(Magic)) || b.Length < 0 || b.Offset+b.Length > footerStart {
+			return nil, fmt.Errorf("%w: blob of type %s at offset %d with length %d is out of bounds",
+				ErrInvalidPuffin, b.Type, b.Offset, b.Length)
+		}
+	}
+
+	return &Reader{r: r, size: size, meta: meta}, nil
+}
+
+// Metadata returns the metadata of the file read from its footer.
+func (r *Reader) Metadata() FileMetadata { return r.meta }
+
+// ReadBlob reads and decompresses the blob described by meta.
+func (r *Reader) ReadBlob(meta BlobMetadata) ([]byte, error) {
+	return ReadBlob(r.r, meta.Offset, meta.Length, meta.CompressionCodec)
+}
+
+// ReadBlob reads the blob at the given offset and length of a Puffin
+// file without reading its footer, as for deletion vectors whose
+// location is recorded in the manifests.
+func ReadBlob(r io.ReaderAt, offset, length int64, codec *string) ([]byte, error) {
+	if offset < 0 || length < 0 {
+		return nil, fmt.Errorf("%w: invalid blob offset %d and length %d", ErrInvalidPuffin, offset, length)
+	}
+
+	data := make([]byte, length)
+	if err := readAt(r, data, offset); err != nil {
+		return nil, err
+	}
+
+	if codec == nil {
+		return data, nil
+	}
+
+	switch *codec {
+	case CompressionZstd:
+		dec, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer dec.Close()
+
+		return dec.DecodeAll(data, nil)
+	default:
+		return nil, fmt.Errorf("%w: unsupported blob compression codec %q", ErrInvalidPuffin, *codec)
+	}
+}
+
+// readAt fills p from r at off, which io.ReaderAt allows to report
+// io.EOF even when p is filled.
+func readAt(r io.ReaderAt, p []byte, off int64) error {
+	n, err := r.ReadAt(p, off)
+	switch {
+	case n == len(p):
+		return nil
+	case err == io.EOF:
+		return io.ErrUnexpectedEOF
+	default:
+		return err
+	}
+}
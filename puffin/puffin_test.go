@@ -0,0 +1,133 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package puffin_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apache/iceberg-go/puffin"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w := puffin.NewWriter(&buf)
+	w.SetProperty(puffin.CreatedByProperty, "test")
+
+	first, err := w.Add(puffin.Blob{
+		Type: "some-blob", Fields: []int32{1, 2}, SnapshotID: 5, SequenceNumber: 3,
+		Data: []byte("hello"),
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, first.Offset)
+	assert.EqualValues(t, 5, first.Length)
+
+	second, err := w.Add(puffin.Blob{
+		Type: "other-blob", SnapshotID: -1, SequenceNumber: -1,
+		Properties: map[string]string{"key": "value"}, Data: []byte("world!"),
+	})
+	require.NoError(t, err)
+	assert.EqualValues(t, 9, second.Offset)
+
+	size, err := w.Finish()
+	require.NoError(t, err)
+	assert.EqualValues(t, buf.Len(), size)
+
+	_, err = w.Add(puffin.Blob{Type: "late"})
+	assert.Error(t, err)
+
+	rdr, err := puffin.NewReader(bytes.NewReader(buf.Bytes()), size)
+	require.NoError(t, err)
+
+	meta := rdr.Metadata()
+	assert.Equal(t, map[string]string{puffin.CreatedByProperty: "test"}, meta.Properties)
+	require.Len(t, meta.Blobs, 2)
+	assert.Equal(t, first, meta.Blobs[0])
+	assert.Equal(t, []int32{}, meta.Blobs[1].Fields)
+	assert.Equal(t, "value", meta.Blobs[1].Properties["key"])
+
+	data, err := rdr.ReadBlob(meta.Blobs[0])
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+	data, err = rdr.ReadBlob(meta.Blobs[1])
+	require.NoError(t, err)
+	assert.Equal(t, "world!", string(data))
+}
+
+func TestEmptyFile(t *testing.T) {
+	var buf bytes.Buffer
+	size, err := puffin.NewWriter(&buf).Finish()
+	require.NoError(t, err)
+
+	rdr, err := puffin.NewReader(bytes.NewReader(buf.Bytes()), size)
+	require.NoError(t, err)
+	assert.Empty(t, rdr.Metadata().Blobs)
+}
+
+func TestReadCompressedBlob(t *testing.T) {
+	enc, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	compressed := enc.EncodeAll([]byte("compressed blob"), nil)
+	require.NoError(t, enc.Close())
+
+	data, err := puffin.ReadBlob(bytes.NewReader(compressed), 0, int64(len(compressed)),
+		ptr(puffin.CompressionZstd))
+	require.NoError(t, err)
+	assert.Equal(t, "compressed blob", string(data))
+
+	_, err = puffin.ReadBlob(bytes.NewReader(compressed), 0, int64(len(compressed)),
+		ptr(puffin.CompressionLZ4))
+	assert.ErrorIs(t, err, puffin.ErrInvalidPuffin)
+}
+
+func TestInvalidFiles(t *testing.T) {
+	var buf bytes.Buffer
+	w := puffin.NewWriter(&buf)
+	_, err := w.Add(puffin.Blob{Type: "blob", Data: []byte("data")})
+	require.NoError(t, err)
+	_, err = w.Finish()
+	require.NoError(t, err)
+	valid := buf.Bytes()
+
+	corrupt := func(f func([]byte)) []byte {
+		out := bytes.Clone(valid)
+		f(out)
+
+		return out
+	}
+
+	tests := map[string][]byte{
+		"too small":      valid[:10],
+		"start magic":    corrupt(func(b []byte) { b[0] = 'X' }),
+		"end magic":      corrupt(func(b []byte) { b[len(b)-1] = 'X' }),
+		"compressed":     corrupt(func(b []byte) { b[len(b)-8] = 1 }),
+		"payload size":   corrupt(func(b []byte) { b[len(b)-12] = 0xFF; b[len(b)-11] = 0xFF }),
+		"footer payload": corrupt(func(b []byte) { b[len(valid)-13] = '!' }),
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := puffin.NewReader(bytes.NewReader(data), int64(len(data)))
+			assert.ErrorIs(t, err, puffin.ErrInvalidPuffin)
+		})
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -0,0 +1,447 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Record is a StructLike that stores the values of a row in field order.
+// Values use the Go types of the corresponding literals, for example
+// int32 for int columns, Date for date columns and Decimal for decimal
+// columns. Nested structs are StructLike values, lists are slices and maps
+// are Go maps. A nil value represents null.
+type Record struct {
+	typ    *StructType
+	values []any
+}
+
+// NewRecord returns a record of the given struct type. If no values are
+// provided all fields are null, otherwise the values are used as the row
+// without copying and must have one entry per field.
+func NewRecord(typ *StructType, values ...any) *Record {
+	if values == nil {
+		values = make([]any, len(typ.FieldList))
+	} else if len(values) != len(typ.FieldList) {
+		panic(fmt.Errorf("%w: record of %s requires %d values, got %d",
+			ErrInvalidArgument, typ, len(typ.FieldList), len(values)))
+	}
+
+	return &Record{typ: typ, values: values}
+}
+
+// Type returns the struct type of the record.
+func (r *Record) Type() *StructType { return r.typ }
+
+// Size returns the number of fields in the record.
+func (r *Record) Size() int { return len(r.values) }
+
+// Get returns the value of the field at the given position.
+func (r *Record) Get(pos int) any { return r.values[pos] }
+
+// Set changes the value of the field at the given position.
+func (r *Record) Set(pos int, val any) { r.values[pos] = val }
+
+// Values returns the values of the record in field order. The returned
+// slice is shared with the record.
+func (r *Record) Values() []any { return r.values }
+
+// Field returns the value of the top-level field with the given name.
+func (r *Record) Field(name string) (any, bool) {
+	for i, f := range r.typ.FieldList {
+		if f.Name == name {
+			return r.values[i], true
+		}
+	}
+
+	return nil, false
+}
+
+func (r *Record) String() string {
+	var b strings.Builder
+	b.WriteString("Record(")
+	for i, f := range r.typ.FieldList {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%s=%v", f.Name, r.values[i])
+	}
+	b.WriteByte(')')
+
+	return b.String()
+}
+
+// MapRecord is a StructLike view over a map from field names to values.
+// Nested structs stored as map[string]any are returned as MapRecord views
+// of the nested map, so no values are copied.
+type MapRecord struct {
+	typ    *StructType
+	values map[string]any
+}
+
+// NewMapRecord returns a view of the map as a row of the given struct type.
+func NewMapRecord(typ *StructType, values map[string]any) MapRecord {
+	return MapRecord{typ: typ, values: values}
+}
+
+// Type returns the struct type of the record.
+func (r MapRecord) Type() *StructType { return r.typ }
+
+// Map returns the underlying map.
+func (r MapRecord) Map() map[string]any { return r.values }
+
+func (r MapRecord) Size() int { return len(r.typ.FieldList) }
+
+func (r MapRecord) Get(pos int) any {
+	f := r.typ.FieldList[pos]
+	v := r.values[f.Name]
+	if st, ok := f.Type.(*StructType); ok {
+		if m, ok := v.(map[string]any); ok {
+			return MapRecord{typ: st, values: m}
+		}
+	}
+
+	return v
+}
+
+func (r MapRecord) Set(pos int, val any) {
+	r.values[r.typ.FieldList[pos].Name] = val
+}
+
+// StructToMap copies a row of the given struct type into a map from field
+// names to values, converting nested structs to nested maps.
+func StructToMap(typ *StructType, row StructLike) map[string]any {
+	out := make(map[string]any, len(typ.FieldList))
+	for i, f := range typ.FieldList {
+		v := row.Get(i)
+		if st, ok := f.Type.(*StructType); ok {
+			if nested, ok := v.(StructLike); ok {
+				v = StructToMap(st, nested)
+			}
+		}
+		out[f.Name] = v
+	}
+
+	return out
+}
+
+// StructBinding binds the fields of a struct type to the fields of a Go
+// struct type, so that values of the Go type can be used as rows without
+// copying them into a Record.
+//
+// Go fields are matched by an `iceberg:"name"` struct tag, or otherwise by
+// a case-insensitive match of the Go field name. Fields tagged
+// `iceberg:"-"` and unexported fields are ignored. Go fields may be
+// pointers, with a nil pointer representing null, and may use any type the
+// column's Go type converts to, such as int for an int column. Nested
+// structs bind to Go structs or struct pointers, and slice and map fields
+// are filled by converting each element.
+type StructBinding struct {
+	typ    *StructType
+	goType reflect.Type
+	fields []boundField
+}
+
+type boundField struct {
+	index  []int
+	goType reflect.Type
+	nested *StructBinding
+}
+
+// NewStructBinding creates a binding of the struct type to the Go struct
+// type of the value, which may be a struct, a pointer to a struct, or a
+// reflect.Type of either. Every required field must have a matching Go
+// field, and matched Go fields must be able to hold the column's values.
+func NewStructBinding(typ *StructType, goValue any) (*StructBinding, error) {
+	goType, ok := goValue.(reflect.Type)
+	if !ok {
+		goType = reflect.TypeOf(goValue)
+	}
+	if goType != nil && goType.Kind() == reflect.Pointer {
+		goType = goType.Elem()
+	}
+	if goType == nil || goType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: cannot bind %s to %v, expected a struct",
+			ErrInvalidArgument, typ, goType)
+	}
+
+	goFields := make(map[string][]int)
+	for _, f := range reflect.VisibleFields(goType) {
+		if !f.IsExported() || f.Anonymous {
+			continue
+		}
+
+		name := strings.ToLower(f.Name)
+		if tag, ok := f.Tag.Lookup("iceberg"); ok {
+			if tag == "-" {
+				continue
+			}
+			name = tag
+		} else if _, ok := goFields[name]; ok {
+			// tagged fields take priority over name matches
+			continue
+		}
+		goFields[name] = f.Index
+	}
+
+	b := &StructBinding{typ: typ, goType: goType, fields: make([]boundField, len(typ.FieldList))}
+	for i, f := range typ.FieldList {
+		index, ok := goFields[f.Name]
+		if !ok {
+			index, ok = goFields[strings.ToLower(f.Name)]
+		}
+		if !ok {
+			if f.Required {
+				return nil, fmt.Errorf("%w: required field %s has no matching field in %s",
+					ErrInvalidArgument, f.Name, goType)
+			}
+
+			continue
+		}
+
+		goField := goType.FieldByIndex(index)
+		bf := boundField{index: index, goType: goField.Type}
+		target := goField.Type
+		if target.Kind() == reflect.Pointer {
+			target = target.Elem()
+		}
+
+		switch ft := f.Type.(type) {
+		case *StructType:
+			nested, err := NewStructBinding(ft, target)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			bf.nested = nested
+		default:
+			if expected := goTypeForType(f.Type); expected != nil && !convertibleGoType(expected, target) {
+				return nil, fmt.Errorf("%w: field %s of type %s cannot be bound to %s of type %s",
+					ErrInvalidArgument, f.Name, f.Type, goField.Name, goField.Type)
+			}
+		}
+
+		b.fields[i] = bf
+	}
+
+	return b, nil
+}
+
+// Type returns the struct type of the binding.
+func (b *StructBinding) Type() *StructType { return b.typ }
+
+// Bind returns a StructLike view of the Go struct that ptr points to.
+// Getting values reads the struct's fields and setting values writes them.
+// Bind panics if ptr is not a non-nil pointer to the bound Go type.
+func (b *StructBinding) Bind(ptr any) StructLike {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Type() != b.goType {
+		panic(fmt.Errorf("%w: cannot bind %T, expected *%s", ErrInvalidArgument, ptr, b.goType))
+	}
+
+	return structView{binding: b, val: v.Elem()}
+}
+
+// Scan copies the values of a row of the bound struct type into the Go
+// struct that ptr points to.
+func (b *StructBinding) Scan(row StructLike, ptr any) (err error) {
+	pos := -1
+	defer func() {
+		if r := recover(); r != nil {
+			if pos < 0 {
+				err = fmt.Errorf("%w: cannot scan row into %T: %v", ErrInvalidArgument, ptr, r)
+			} else {
+				err = fmt.Errorf("%w: cannot scan field %s into %T: %v",
+					ErrInvalidArgument, b.typ.FieldList[pos].Name, ptr, r)
+			}
+		}
+	}()
+
+	view := b.Bind(ptr)
+	for pos = range b.fields {
+		view.Set(pos, row.Get(pos))
+	}
+
+	return nil
+}
+
+// structView is a StructLike backed by an addressable Go struct value.
+type structView struct {
+	binding *StructBinding
+	val     reflect.Value
+}
+
+func (s structView) Size() int { return len(s.binding.fields) }
+
+func (s structView) Get(pos int) any {
+	bf := s.binding.fields[pos]
+	if bf.index == nil {
+		return nil
+	}
+
+	v := s.val.FieldByIndex(bf.index)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	if bf.nested != nil {
+		return structView{binding: bf.nested, val: v}
+	}
+
+	if v.Kind() == reflect.Slice && v.IsNil() {
+		return nil
+	}
+
+	expected := goTypeForType(s.binding.typ.FieldList[pos].Type)
+	if expected != nil && v.Type() != expected {
+		v = v.Convert(expected)
+	}
+
+	return v.Interface()
+}
+
+func (s structView) Set(pos int, val any) {
+	bf := s.binding.fields[pos]
+	if bf.index == nil {
+		return
+	}
+
+	field := s.val.FieldByIndex(bf.index)
+	if val == nil {
+		field.SetZero()
+
+		return
+	}
+
+	target := field
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		target = field.Elem()
+	}
+
+	if bf.nested != nil {
+		row := val.(StructLike)
+		nested := structView{binding: bf.nested, val: target}
+		for i := range bf.nested.fields {
+			nested.Set(i, row.Get(i))
+		}
+
+		return
+	}
+
+	target.Set(convertGoValue(reflect.ValueOf(val), target.Type()))
+}
+
+// convertGoValue converts a value to the given Go type, converting the
+// elements of slices and maps and allocating pointers as needed. It panics
+// if the value cannot be converted.
+func convertGoValue(v reflect.Value, to reflect.Type) reflect.Value {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return reflect.Zero(to)
+	}
+
+	switch {
+	case v.Type() == to:
+		return v
+	case convertibleGoType(v.Type(), to):
+		return v.Convert(to)
+	case to.Kind() == reflect.Pointer:
+		out := reflect.New(to.Elem())
+		out.Elem().Set(convertGoValue(v, to.Elem()))
+
+		return out
+	case to.Kind() == reflect.Slice && v.Kind() == reflect.Slice:
+		out := reflect.MakeSlice(to, v.Len(), v.Len())
+		for i := range v.Len() {
+			out.Index(i).Set(convertGoValue(v.Index(i), to.Elem()))
+		}
+
+		return out
+	case to.Kind() == reflect.Map && v.Kind() == reflect.Map:
+		out := reflect.MakeMapWithSize(to, v.Len())
+		for it := v.MapRange(); it.Next(); {
+			out.SetMapIndex(convertGoValue(it.Key(), to.Key()),
+				convertGoValue(it.Value(), to.Elem()))
+		}
+
+		return out
+	}
+
+	panic(fmt.Errorf("%w: cannot convert %s to %s", ErrInvalidArgument, v.Type(), to))
+}
+
+// convertibleGoType reports whether values of one Go type can be converted
+// to another, excluding the integer to string conversion that yields a rune.
+func convertibleGoType(from, to reflect.Type) bool {
+	if !from.ConvertibleTo(to) {
+		return false
+	}
+
+	switch {
+	case to.Kind() == reflect.String:
+		return from.Kind() == reflect.String || from.Kind() == reflect.Slice
+	case from.Kind() == reflect.String:
+		return to.Kind() == reflect.Slice
+	}
+
+	return true
+}
+
+var (
+	bytesGoType      = reflect.TypeOf([]byte(nil))
+	primitiveGoTypes = map[Type]reflect.Type{
+		PrimitiveTypes.Bool:          reflect.TypeOf(false),
+		PrimitiveTypes.Int32:         reflect.TypeOf(int32(0)),
+		PrimitiveTypes.Int64:         reflect.TypeOf(int64(0)),
+		PrimitiveTypes.Float32:       reflect.TypeOf(float32(0)),
+		PrimitiveTypes.Float64:       reflect.TypeOf(float64(0)),
+		PrimitiveTypes.Date:          reflect.TypeOf(Date(0)),
+		PrimitiveTypes.Time:          reflect.TypeOf(Time(0)),
+		PrimitiveTypes.Timestamp:     reflect.TypeOf(Timestamp(0)),
+		PrimitiveTypes.TimestampTz:   reflect.TypeOf(Timestamp(0)),
+		PrimitiveTypes.TimestampNs:   reflect.TypeOf(TimestampNano(0)),
+		PrimitiveTypes.TimestampTzNs: reflect.TypeOf(TimestampNano(0)),
+		PrimitiveTypes.String:        reflect.TypeOf(""),
+		PrimitiveTypes.Binary:        bytesGoType,
+		PrimitiveTypes.UUID:          reflect.TypeOf(uuid.UUID{}),
+	}
+)
+
+// goTypeForType returns the Go type used for values of a primitive type,
+// or nil for nested and unknown types.
+func goTypeForType(t Type) reflect.Type {
+	switch t.(type) {
+	case FixedType:
+		return bytesGoType
+	case DecimalType:
+		return reflect.TypeOf(Decimal{})
+	}
+
+	return primitiveGoTypes[t]
+}
@@ -0,0 +1,128 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg_test
+
+import (
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordRepresentations(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 3, Name: "location", Type: &iceberg.StructType{FieldList: []iceberg.NestedField{
+			{ID: 4, Name: "lat", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+			{ID: 5, Name: "long", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+		}}})
+	st := sc.AsStruct()
+	locType := st.FieldList[2].Type.(*iceberg.StructType)
+
+	type location struct {
+		Lat  float64
+		Long float64
+	}
+	type row struct {
+		ID       int `iceberg:"id"`
+		Data     *string
+		Location *location
+		Ignored  string `iceberg:"-"`
+	}
+
+	binding, err := iceberg.NewStructBinding(&st, row{})
+	require.NoError(t, err)
+
+	data := "a"
+	goRow := row{ID: 1, Data: &data, Location: &location{Lat: 52.5, Long: 13.4}}
+	rows := map[string]iceberg.StructLike{
+		"record": iceberg.NewRecord(&st, int64(1), "a",
+			iceberg.NewRecord(locType, 52.5, 13.4)),
+		"map": iceberg.NewMapRecord(&st, map[string]any{
+			"id": int64(1), "data": "a",
+			"location": map[string]any{"lat": 52.5, "long": 13.4},
+		}),
+		"struct": binding.Bind(&goRow),
+	}
+
+	lat, ok := sc.FieldAccessor(4)
+	require.True(t, ok)
+	_, ok = sc.FieldAccessor(100)
+	assert.False(t, ok)
+
+	eval, err := iceberg.ExpressionEvaluator(sc, iceberg.NewAnd(
+		iceberg.EqualTo(iceberg.Reference("id"), int64(1)),
+		iceberg.GreaterThan(iceberg.Reference("location.lat"), 50.0)), true)
+	require.NoError(t, err)
+
+	for name, r := range rows {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, 3, r.Size())
+			assert.Equal(t, int64(1), r.Get(0))
+			assert.Equal(t, 52.5, lat(r))
+
+			match, err := eval(r)
+			require.NoError(t, err)
+			assert.True(t, match)
+
+			assert.Equal(t, map[string]any{
+				"id": int64(1), "data": "a",
+				"location": map[string]any{"lat": 52.5, "long": 13.4},
+			}, iceberg.StructToMap(&st, r))
+		})
+	}
+
+	t.Run("struct view writes through", func(t *testing.T) {
+		view := rows["struct"]
+		view.Set(0, int64(7))
+		view.Set(1, nil)
+		view.Get(2).(iceberg.StructLike).Set(0, 10.0)
+
+		assert.Equal(t, 7, goRow.ID)
+		assert.Nil(t, goRow.Data)
+		assert.Equal(t, 10.0, goRow.Location.Lat)
+		assert.Nil(t, view.Get(1))
+	})
+
+	t.Run("scan", func(t *testing.T) {
+		var out row
+		require.NoError(t, binding.Scan(rows["record"], &out))
+		require.NotNil(t, out.Data)
+		assert.Equal(t, row{ID: 1, Data: out.Data, Location: &location{Lat: 52.5, Long: 13.4}}, out)
+		assert.Equal(t, "a", *out.Data)
+
+		err := binding.Scan(iceberg.NewRecord(&st, "bad", nil, nil), &out)
+		assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	})
+
+	t.Run("invalid bindings", func(t *testing.T) {
+		_, err := iceberg.NewStructBinding(&st, struct{ Data string }{})
+		assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+		assert.ErrorContains(t, err, "required field id")
+
+		_, err = iceberg.NewStructBinding(&st, struct{ ID string }{})
+		assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+		_, err = iceberg.NewStructBinding(&st, 1)
+		assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+		assert.Panics(t, func() { iceberg.NewRecord(&st, 1) })
+	})
+}
@@ -0,0 +1,73 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// MaxUserFieldID is the largest field ID that table columns may use. The
+// IDs above it are reserved by the spec for metadata columns, such as
+// _file and _pos, and for the fields of delete files.
+const MaxUserFieldID = math.MaxInt32 - 200
+
+// reservedColumnNames are the names of the metadata columns that scans
+// can project alongside a table's columns.
+var reservedColumnNames = []string{
+	"_file", "_pos", "_deleted", "_spec_id", "_partition",
+	"_row_id", "_last_updated_sequence_number",
+	"_change_type", "_change_ordinal", "_commit_snapshot_id",
+}
+
+// IsReservedFieldID reports whether id is reserved for metadata columns.
+func IsReservedFieldID(id int) bool { return id > MaxUserFieldID }
+
+// IsReservedColumnName reports whether name is the name of a metadata
+// column, which top-level table columns may not use.
+func IsReservedColumnName(name string) bool {
+	return slices.Contains(reservedColumnNames, name)
+}
+
+// ValidateReservedFields checks that a table schema does not use any of
+// the field IDs reserved for metadata columns, nor their names for its
+// top-level columns.
+func ValidateReservedFields(sc *Schema) error {
+	fields, err := sc.FlatFields()
+	if err != nil {
+		return err
+	}
+
+	for _, f := range slices.SortedFunc(fields, func(a, b NestedField) int { return cmp.Compare(a.ID, b.ID) }) {
+		if IsReservedFieldID(f.ID) {
+			return fmt.Errorf("%w: field %s uses reserved field id %d (ids above %d are reserved)",
+				ErrInvalidSchema, f.Name, f.ID, MaxUserFieldID)
+		}
+	}
+
+	for _, f := range sc.fields {
+		if IsReservedColumnName(f.Name) {
+			return fmt.Errorf("%w: column name %s is reserved for a metadata column",
+				ErrInvalidSchema, f.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg_test
+
+import (
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/hamba/avro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateReservedFields(t *testing.T) {
+	assert.True(t, iceberg.IsReservedFieldID(2147483646))
+	assert.True(t, iceberg.IsReservedFieldID(iceberg.MaxUserFieldID+1))
+	assert.False(t, iceberg.IsReservedFieldID(iceberg.MaxUserFieldID))
+	assert.True(t, iceberg.IsReservedColumnName("_file"))
+	assert.False(t, iceberg.IsReservedColumnName("file"))
+
+	assert.NoError(t, iceberg.ValidateReservedFields(iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64},
+		iceberg.NestedField{ID: 2, Name: "nested", Type: &iceberg.StructType{FieldList: []iceberg.NestedField{
+			// metadata column names are only reserved at the top level
+			{ID: 3, Name: "_pos", Type: iceberg.PrimitiveTypes.Int64},
+		}}})))
+
+	err := iceberg.ValidateReservedFields(iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64},
+		iceberg.NestedField{ID: 2, Name: "nested", Type: &iceberg.StructType{FieldList: []iceberg.NestedField{
+			{ID: 2147483646, Name: "path", Type: iceberg.PrimitiveTypes.String},
+		}}}))
+	assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+	assert.ErrorContains(t, err, "reserved field id 2147483646")
+
+	err = iceberg.ValidateReservedFields(iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "_deleted", Type: iceberg.PrimitiveTypes.Bool}))
+	assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+	assert.ErrorContains(t, err, "_deleted is reserved")
+
+	t.Run("json fields", func(t *testing.T) {
+		_, err := iceberg.NewSchemaFromJsonFields(0,
+			`[{"id": 1, "name": "_spec_id", "type": "int", "required": false}]`)
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+	})
+
+	t.Run("avro", func(t *testing.T) {
+		avroSchema, err := avro.Parse(`{"type": "record", "name": "r", "fields": [
+			{"name": "id", "type": "long", "field-id": 2147483645}]}`)
+		require.NoError(t, err)
+
+		_, err = iceberg.AvroSchemaToIceberg(avroSchema)
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+
+		// files with reserved field IDs, such as position delete files,
+		// are still read, see TestAvroFilePositionDeletes
+		avroSchema, err = avro.Parse(`{"type": "record", "name": "position_delete", "fields": [
+			{"name": "file_path", "type": "string", "field-id": 2147483546},
+			{"name": "pos", "type": "long", "field-id": 2147483545}]}`)
+		require.NoError(t, err)
+
+		_, err = iceberg.AvroSchemaToIceberg(avroSchema)
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+	})
+}
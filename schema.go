@@ -26,7 +26,6 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
-	"unicode"
 )
 
 // Schema is an Iceberg table schema, represented as a struct with
@@ -52,7 +51,9 @@ type Schema struct {
 	lazyNameMapping func() NameMapping
 }
 
-// NewSchemaFromJsonFields constructs a new schema with the provided ID and fields in json form
+// NewSchemaFromJsonFields constructs a new schema with the provided ID and
+// fields in json form. As the fields are those of a table, they may not
+// use reserved field IDs or column names.
 func NewSchemaFromJsonFields(id int, jsonFieldsStr string) (*Schema, error) {
 	var fields []NestedField
 	err := json.Unmarshal([]byte(jsonFieldsStr), &fields)
@@ -60,11 +61,19 @@ func NewSchemaFromJsonFields(id int, jsonFieldsStr string) (*Schema, error) {
 		return nil, fmt.Errorf("failed to parse schema JSON: %w", err)
 	}
 
-	return NewSchema(id, fields...), nil
+	sc := NewSchema(id, fields...)
+	if err := ValidateReservedFields(sc); err != nil {
+		return nil, err
+	}
+
+	return sc, nil
 }
 
 // NewSchema constructs a new schema with the provided ID
-// and list of fields.
+// and list of fields. The fields are not validated, as NewSchema also
+// builds the schemas of metadata such as position delete files, which use
+// reserved field IDs; table schemas are checked with
+// ValidateReservedFields when they are added to table metadata.
 func NewSchema(id int, fields ...NestedField) *Schema {
 	return NewSchemaWithIdentifiers(id, []int{}, fields...)
 }
@@ -196,8 +205,47 @@ func (s *Schema) lazyIdToAccessor() (map[int]accessor, error) {
 	return idx, nil
 }
 
+// FieldAccessor returns a function that reads the value of the field with
+// the given ID from a row of this schema, descending into nested structs.
+// The function returns nil if any struct along the way is null. The second
+// return value reports whether the field exists and is reachable through
+// structs only, as fields inside lists and maps have no position in a row.
+func (s *Schema) FieldAccessor(fieldID int) (func(StructLike) any, bool) {
+	idx, err := s.lazyIdToAccessor()
+	if err != nil {
+		return nil, false
+	}
+
+	acc, ok := idx[fieldID]
+	if !ok {
+		return nil, false
+	}
+
+	return acc.Get, true
+}
+
 func (s *Schema) NameMapping() NameMapping { return s.lazyNameMapping() }
 
+// IndexByID returns the fields of the schema, nested fields included, by
+// field ID, as IndexByID does. The index is built on first use and
+// shared by later calls and must not be modified.
+func (s *Schema) IndexByID() (map[int]NestedField, error) { return s.lazyIDToField() }
+
+// IndexByName returns the IDs of the fields of the schema by full name,
+// as IndexByName does. The index is built on first use and shared by
+// later calls and must not be modified.
+func (s *Schema) IndexByName() (map[string]int, error) { return s.lazyNameToID() }
+
+// IndexNameByID returns the full names of the fields of the schema by
+// field ID, as IndexNameByID does. The index is built on first use and
+// shared by later calls and must not be modified.
+func (s *Schema) IndexNameByID() (map[int]string, error) { return s.lazyIDToName() }
+
+// IndexParents returns the ID of the parent of every nested field of the
+// schema by field ID, as IndexParents does. The index is built on first
+// use and shared by later calls and must not be modified.
+func (s *Schema) IndexParents() (map[int]int, error) { return s.lazyIDToParent() }
+
 func (s *Schema) Type() string { return "struct" }
 
 // AsStruct returns a Struct with the same fields as the schema which can
@@ -207,9 +255,11 @@ func (s *Schema) NumFields() int          { return len(s.fields) }
 func (s *Schema) Field(i int) NestedField { return s.fields[i] }
 func (s *Schema) Fields() []NestedField   { return slices.Clone(s.fields) }
 func (s *Schema) FieldIDs() []int {
-	idx, _ := s.lazyNameToID()
+	// the name index also holds short names, so use the id index to
+	// return each id once
+	idx, _ := s.lazyIDToField()
 
-	return slices.Collect(maps.Values(idx))
+	return slices.Collect(maps.Keys(idx))
 }
 
 func (s *Schema) UnmarshalJSON(b []byte) error {
@@ -886,6 +936,20 @@ func (i *indexByName) AfterListElement(elem NestedField) {
 	i.fieldNames = i.fieldNames[:len(i.fieldNames)-1]
 }
 
+func (i *indexByName) BeforeMapValue(value NestedField) {
+	if _, ok := value.Type.(*StructType); !ok {
+		i.shortFieldNames = append(i.shortFieldNames, value.Name)
+	}
+	i.fieldNames = append(i.fieldNames, value.Name)
+}
+
+func (i *indexByName) AfterMapValue(value NestedField) {
+	if _, ok := value.Type.(*StructType); !ok {
+		i.shortFieldNames = i.shortFieldNames[:len(i.shortFieldNames)-1]
+	}
+	i.fieldNames = i.fieldNames[:len(i.fieldNames)-1]
+}
+
 func (i *indexByName) BeforeField(field NestedField) {
 	i.fieldNames = append(i.fieldNames, field.Name)
 	i.shortFieldNames = append(i.shortFieldNames, field.Name)
@@ -898,6 +962,15 @@ func (i *indexByName) AfterField(field NestedField) {
 
 // PruneColumns visits a schema pruning any columns which do not exist in the
 // provided selected set. Parent fields of a selected child will be retained.
+//
+// With selectFullTypes, as Java's TypeUtil.select, selected fields keep
+// their full type, including all of their nested fields. Otherwise, as
+// TypeUtil.project, selected structs only keep their selected nested
+// fields, and are empty if there are none, and lists and maps are kept if
+// their element or value is selected or has selected nested fields;
+// selecting a list or map field itself is an error then, as its element
+// or value must be selected instead. Identifier field IDs are kept if
+// they are selected.
 func PruneColumns(schema *Schema, selected map[int]Void, selectFullTypes bool) (*Schema, error) {
 	result, err := Visit(schema, &pruneColVisitor{
 		selected:  selected,
@@ -919,13 +992,67 @@ func PruneColumns(schema *Schema, selected map[int]Void, selectFullTypes bool) (
 		}
 	}
 
-	return &Schema{
-		fields:             n.Fields(),
-		ID:                 schema.ID,
-		IdentifierFieldIDs: newIdentifierIDs,
-	}, nil
+	return NewSchemaWithIdentifiers(schema.ID, newIdentifierIDs, n.Fields()...), nil
+}
+
+// ProjectedIDs returns the IDs of the fields of schema that select it
+// whole with PruneColumns without selectFullTypes, as Java's
+// TypeUtil.getProjectedIds: the IDs of primitive fields, and of the
+// elements and values of lists and maps of primitives.
+func ProjectedIDs(schema *Schema) (map[int]Void, error) {
+	return Visit(schema, &projectedIDs{ids: make(map[int]Void)})
+}
+
+// SelectNot returns schema without the fields with the given IDs, as
+// Java's TypeUtil.selectNot. Structs left without fields are removed, and
+// removing the element of a list or the value of a map removes the list
+// or map.
+func SelectNot(schema *Schema, excluded map[int]Void) (*Schema, error) {
+	ids, err := ProjectedIDs(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	for id := range excluded {
+		delete(ids, id)
+	}
+
+	return PruneColumns(schema, ids, false)
+}
+
+type projectedIDs struct {
+	ids map[int]Void
+}
+
+func (p *projectedIDs) Schema(*Schema, map[int]Void) map[int]Void      { return p.ids }
+func (p *projectedIDs) Struct(StructType, []map[int]Void) map[int]Void { return p.ids }
+
+func (p *projectedIDs) Field(field NestedField, _ map[int]Void) map[int]Void {
+	if _, ok := field.Type.(PrimitiveType); ok {
+		p.ids[field.ID] = void
+	}
+
+	return p.ids
+}
+
+func (p *projectedIDs) List(list ListType, _ map[int]Void) map[int]Void {
+	if _, ok := list.Element.(PrimitiveType); ok {
+		p.ids[list.ElementID] = void
+	}
+
+	return p.ids
+}
+
+func (p *projectedIDs) Map(mapType MapType, _, _ map[int]Void) map[int]Void {
+	if _, ok := mapType.ValueType.(PrimitiveType); ok {
+		p.ids[mapType.ValueID] = void
+	}
+
+	return p.ids
 }
 
+func (p *projectedIDs) Primitive(PrimitiveType) map[int]Void { return p.ids }
+
 type pruneColVisitor struct {
 	selected  map[int]Void
 	fullTypes bool
@@ -945,14 +1072,9 @@ func (p *pruneColVisitor) Struct(st StructType, fieldResults []Type) Type {
 			selected = append(selected, field)
 		} else if t != nil {
 			sameType = false
-			// type has changed, create a new field with the projected type
-			selected = append(selected, NestedField{
-				ID:       field.ID,
-				Name:     field.Name,
-				Type:     t,
-				Doc:      field.Doc,
-				Required: field.Required,
-			})
+			// type has changed, keep the field with the projected type
+			field.Type = t
+			selected = append(selected, field)
 		}
 	}
 
@@ -1245,11 +1367,14 @@ func (s *setFreshIDs) Struct(st StructType, fieldResults []func() Type) Type {
 	newFields := make([]NestedField, len(st.FieldList))
 	for idx, f := range st.FieldList {
 		newFields[idx] = NestedField{
-			ID:       s.getAndInc(f.ID),
-			Name:     f.Name,
-			Type:     fieldResults[idx](),
-			Doc:      f.Doc,
-			Required: f.Required,
+			ID:             s.getAndInc(f.ID),
+			Name:           f.Name,
+			Type:           fieldResults[idx](),
+			Doc:            f.Doc,
+			Required:       f.Required,
+			InitialDefault: f.InitialDefault,
+			WriteDefault:   f.WriteDefault,
+			Metadata:       f.Metadata,
 		}
 	}
 
@@ -1317,6 +1442,16 @@ func AssignFreshSchemaIDs(sc *Schema, nextID func() int) (*Schema, error) {
 	return NewSchemaWithIdentifiers(0, newIdentifierIDs, fields...), nil
 }
 
+// SchemaWithPartnerVisitor is the SchemaVisitor counterpart for
+// traversing a schema together with a parallel structure, its partner,
+// such as an Arrow schema, an Avro schema or the arrays of a record
+// batch. Each callback receives the partner of the node being visited, as
+// found by a PartnerAccessor, which may be the zero value of P when the
+// partner structure has no counterpart for the node.
+//
+// A SchemaWithPartnerVisitor can also optionally implement the
+// Before/After partner visitor interfaces to be called at the
+// corresponding points of the traversal.
 type SchemaWithPartnerVisitor[T, P any] interface {
 	Schema(sc *Schema, schemaPartner P, structResult T) T
 	Struct(st StructType, structPartner P, fieldResults []T) T
@@ -1326,14 +1461,60 @@ type SchemaWithPartnerVisitor[T, P any] interface {
 	Primitive(p PrimitiveType, primitivePartner P) T
 }
 
+// PartnerAccessor finds the partners of the nested nodes of a schema in
+// the partner structure of VisitSchemaWithPartner. Accessors are called
+// with the zero value of P for nodes without a partner and should return
+// the zero value for their children.
 type PartnerAccessor[P any] interface {
+	// SchemaPartner returns the partner of the top-level struct of the
+	// schema given the partner passed to VisitSchemaWithPartner.
 	SchemaPartner(P) P
+	// FieldPartner returns the partner of the field with the given ID and
+	// name of the struct whose partner is partnerStruct.
 	FieldPartner(partnerStruct P, fieldID int, fieldName string) P
 	ListElementPartner(P) P
 	MapKeyPartner(P) P
 	MapValuePartner(P) P
 }
 
+type BeforeFieldPartnerVisitor[P any] interface {
+	BeforeField(field NestedField, partner P)
+}
+
+type AfterFieldPartnerVisitor[P any] interface {
+	AfterField(field NestedField, partner P)
+}
+
+type BeforeListElementPartnerVisitor[P any] interface {
+	BeforeListElement(elem NestedField, partner P)
+}
+
+type AfterListElementPartnerVisitor[P any] interface {
+	AfterListElement(elem NestedField, partner P)
+}
+
+type BeforeMapKeyPartnerVisitor[P any] interface {
+	BeforeMapKey(key NestedField, partner P)
+}
+
+type AfterMapKeyPartnerVisitor[P any] interface {
+	AfterMapKey(key NestedField, partner P)
+}
+
+type BeforeMapValuePartnerVisitor[P any] interface {
+	BeforeMapValue(value NestedField, partner P)
+}
+
+type AfterMapValuePartnerVisitor[P any] interface {
+	AfterMapValue(value NestedField, partner P)
+}
+
+// VisitSchemaWithPartner performs a post-order traversal of the given
+// schema together with partner, using accessor to find the partner of
+// every nested node. Downstream converters, for example from Iceberg to
+// Arrow or Avro schemas and values, can be built on it without
+// re-implementing the traversal; see AvroSchemaAccessor and
+// NameMappingAccessor.
 func VisitSchemaWithPartner[T, P any](sc *Schema, partner P, visitor SchemaWithPartnerVisitor[T, P], accessor PartnerAccessor[P]) (res T, err error) {
 	if sc == nil {
 		err = fmt.Errorf("%w: cannot visit nil schema", ErrInvalidArgument)
@@ -1364,17 +1545,8 @@ func VisitSchemaWithPartner[T, P any](sc *Schema, partner P, visitor SchemaWithP
 }
 
 func visitStructWithPartner[T, P any](st StructType, partner P, visitor SchemaWithPartnerVisitor[T, P], accessor PartnerAccessor[P]) T {
-	type (
-		beforeField interface {
-			BeforeField(NestedField, P)
-		}
-		afterField interface {
-			AfterField(NestedField, P)
-		}
-	)
-
-	bf, _ := visitor.(beforeField)
-	af, _ := visitor.(afterField)
+	bf, _ := visitor.(BeforeFieldPartnerVisitor[P])
+	af, _ := visitor.(AfterFieldPartnerVisitor[P])
 
 	fieldResults := make([]T, len(st.FieldList))
 
@@ -1394,21 +1566,12 @@ func visitStructWithPartner[T, P any](st StructType, partner P, visitor SchemaWi
 }
 
 func visitListWithPartner[T, P any](listType ListType, partner P, visitor SchemaWithPartnerVisitor[T, P], accessor PartnerAccessor[P]) T {
-	type (
-		beforeListElem interface {
-			BeforeListElement(NestedField, P)
-		}
-		afterListElem interface {
-			AfterListElement(NestedField, P)
-		}
-	)
-
 	elemPartner := accessor.ListElementPartner(partner)
-	if ble, ok := visitor.(beforeListElem); ok {
+	if ble, ok := visitor.(BeforeListElementPartnerVisitor[P]); ok {
 		ble.BeforeListElement(listType.ElementField(), elemPartner)
 	}
 	elemResult := visitTypeWithPartner(listType.Element, elemPartner, visitor, accessor)
-	if ale, ok := visitor.(afterListElem); ok {
+	if ale, ok := visitor.(AfterListElementPartnerVisitor[P]); ok {
 		ale.AfterListElement(listType.ElementField(), elemPartner)
 	}
 
@@ -1416,37 +1579,21 @@ func visitListWithPartner[T, P any](listType ListType, partner P, visitor Schema
 }
 
 func visitMapWithPartner[T, P any](m MapType, partner P, visitor SchemaWithPartnerVisitor[T, P], accessor PartnerAccessor[P]) T {
-	type (
-		beforeMapKey interface {
-			BeforeMapKey(NestedField, P)
-		}
-		afterMapKey interface {
-			AfterMapKey(NestedField, P)
-		}
-
-		beforeMapValue interface {
-			BeforeMapValue(NestedField, P)
-		}
-		afterMapValue interface {
-			AfterMapValue(NestedField, P)
-		}
-	)
-
 	keyPartner := accessor.MapKeyPartner(partner)
-	if bmk, ok := visitor.(beforeMapKey); ok {
+	if bmk, ok := visitor.(BeforeMapKeyPartnerVisitor[P]); ok {
 		bmk.BeforeMapKey(m.KeyField(), keyPartner)
 	}
 	keyResult := visitTypeWithPartner(m.KeyType, keyPartner, visitor, accessor)
-	if amk, ok := visitor.(afterMapKey); ok {
+	if amk, ok := visitor.(AfterMapKeyPartnerVisitor[P]); ok {
 		amk.AfterMapKey(m.KeyField(), keyPartner)
 	}
 
 	valPartner := accessor.MapValuePartner(partner)
-	if bmv, ok := visitor.(beforeMapValue); ok {
+	if bmv, ok := visitor.(BeforeMapValuePartnerVisitor[P]); ok {
 		bmv.BeforeMapValue(m.ValueField(), valPartner)
 	}
 	valResult := visitTypeWithPartner(m.ValueType, valPartner, visitor, accessor)
-	if amv, ok := visitor.(afterMapValue); ok {
+	if amv, ok := visitor.(AfterMapValuePartnerVisitor[P]); ok {
 		amv.AfterMapValue(m.ValueField(), valPartner)
 	}
 
@@ -1466,6 +1613,20 @@ func visitTypeWithPartner[T, P any](t Type, fieldPartner P, visitor SchemaWithPa
 	}
 }
 
+// FieldNameProp is the property of Avro fields, and the metadata key of
+// Arrow fields written to data files, that holds the original name of a
+// field whose name had to be sanitized.
+const FieldNameProp = "iceberg-field-name"
+
+// FieldMetadataProp is the property of Avro fields, and the metadata key
+// of Arrow fields, that holds the Metadata of the Iceberg field.
+const FieldMetadataProp = "iceberg-field-metadata"
+
+// makeCompatibleName returns n if it is a valid Avro name and otherwise
+// the name with every invalid character replaced, as "_" followed by the
+// digit for a leading digit and "_x" followed by the upper case hex code
+// point for anything else. The original name is recorded alongside the
+// sanitized one using FieldNameProp so it can be restored.
 func makeCompatibleName(n string) string {
 	if !validAvroName(n) {
 		return sanitizeName(n)
@@ -1474,17 +1635,23 @@ func makeCompatibleName(n string) string {
 	return n
 }
 
+func isAvroNameStart(r rune) bool {
+	return (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || r == '_'
+}
+
+func isAvroNameRune(r rune) bool {
+	return isAvroNameStart(r) || (r >= '0' && r <= '9')
+}
+
+// validAvroName reports whether n matches [A-Za-z_][A-Za-z0-9_]*, the
+// names allowed by the Avro specification.
 func validAvroName(n string) bool {
 	if len(n) == 0 {
 		panic("cannot validate empty name")
 	}
 
-	if !unicode.IsLetter(rune(n[0])) && n[0] != '_' {
-		return false
-	}
-
-	for _, r := range n[1:] {
-		if !unicode.In(r, unicode.Number, unicode.Letter) && r != '_' {
+	for i, r := range n {
+		if i == 0 && !isAvroNameStart(r) || !isAvroNameRune(r) {
 			return false
 		}
 	}
@@ -1493,7 +1660,7 @@ func validAvroName(n string) bool {
 }
 
 func sanitize(r rune) string {
-	if unicode.IsDigit(r) {
+	if r >= '0' && r <= '9' {
 		return "_" + string(r)
 	}
 
@@ -1504,15 +1671,8 @@ func sanitizeName(n string) string {
 	var b strings.Builder
 	b.Grow(len(n))
 
-	first := n[0]
-	if !unicode.IsLetter(rune(first)) && first != '_' {
-		b.WriteString(sanitize(rune(first)))
-	} else {
-		b.WriteByte(first)
-	}
-
-	for _, r := range n[1:] {
-		if !unicode.In(r, unicode.Number, unicode.Letter) && r != '_' {
+	for i, r := range n {
+		if i == 0 && !isAvroNameStart(r) || !isAvroNameRune(r) {
 			b.WriteString(sanitize(r))
 		} else {
 			b.WriteRune(r)
@@ -1522,6 +1682,9 @@ func sanitizeName(n string) string {
 	return b.String()
 }
 
+// SanitizeColumnNames returns the schema with every field name that is
+// not a valid Avro name sanitized. Use SanitizedFieldNames to find the
+// original names of the sanitized fields.
 func SanitizeColumnNames(sc *Schema) (*Schema, error) {
 	result, err := Visit(sc, sanitizeColumnNameVisitor{})
 	if err != nil {
@@ -1532,6 +1695,24 @@ func SanitizeColumnNames(sc *Schema) (*Schema, error) {
 		result.Type.(*StructType).FieldList...), nil
 }
 
+// SanitizedFieldNames returns the original names of the fields of the
+// schema that SanitizeColumnNames renames, by field ID.
+func SanitizedFieldNames(sc *Schema) (map[int]string, error) {
+	fields, err := sc.IndexByID()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int]string)
+	for id, f := range fields {
+		if f.Name != "" && !validAvroName(f.Name) {
+			names[id] = f.Name
+		}
+	}
+
+	return names, nil
+}
+
 type sanitizeColumnNameVisitor struct{}
 
 func (sanitizeColumnNameVisitor) Schema(_ *Schema, structResult NestedField) NestedField {
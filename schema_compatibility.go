@@ -0,0 +1,246 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CompatibilityIssueKind identifies the kind of problem found when checking
+// whether data written with one schema can be read with another.
+type CompatibilityIssueKind int
+
+const (
+	// CompatibilityMissingRequiredField is reported when a required read
+	// field without an initial default is not present in the write schema.
+	CompatibilityMissingRequiredField CompatibilityIssueKind = iota
+	// CompatibilityOptionalToRequired is reported when a required read
+	// field is optional in the write schema.
+	CompatibilityOptionalToRequired
+	// CompatibilityIncompatibleType is reported when the written type cannot
+	// be promoted to the read type.
+	CompatibilityIncompatibleType
+	// CompatibilityIDConflict is reported when a read column is written
+	// under a different field ID, a field ID is written under a different
+	// parent, or a field ID is written under the name of another column.
+	CompatibilityIDConflict
+)
+
+func (k CompatibilityIssueKind) String() string {
+	switch k {
+	case CompatibilityMissingRequiredField:
+		return "missing required field"
+	case CompatibilityOptionalToRequired:
+		return "optional to required"
+	case CompatibilityIncompatibleType:
+		return "incompatible type"
+	case CompatibilityIDConflict:
+		return "id conflict"
+	default:
+		return fmt.Sprintf("CompatibilityIssueKind(%d)", int(k))
+	}
+}
+
+// CompatibilityIssue describes a single incompatibility between a read
+// schema and a write schema.
+type CompatibilityIssue struct {
+	Kind CompatibilityIssueKind
+	// FieldID is the ID of the field in the read schema.
+	FieldID int
+	// Column is the full name of the field in the read schema.
+	Column string
+	// ReadField is the field in the read schema.
+	ReadField NestedField
+	// WriteField is the matching field in the write schema, if any.
+	WriteField *NestedField
+	// Message is a human readable description of the issue.
+	Message string
+}
+
+func (i CompatibilityIssue) String() string {
+	return fmt.Sprintf("%s: %s", i.Column, i.Message)
+}
+
+// CompatibilityResult holds the issues found by CheckCompatibility.
+type CompatibilityResult struct {
+	Issues []CompatibilityIssue
+}
+
+// Compatible reports whether no issues were found.
+func (r CompatibilityResult) Compatible() bool {
+	return len(r.Issues) == 0
+}
+
+// Err returns nil if the schemas are compatible, and otherwise an error
+// wrapping ErrInvalidSchema that lists every issue.
+func (r CompatibilityResult) Err() error {
+	if r.Compatible() {
+		return nil
+	}
+
+	var b strings.Builder
+	for _, issue := range r.Issues {
+		b.WriteString("\n- ")
+		b.WriteString(issue.String())
+	}
+
+	return fmt.Errorf("%w: write schema is not compatible with read schema:%s",
+		ErrInvalidSchema, b.String())
+}
+
+// CheckCompatibility checks whether data written with writeSchema can be
+// read with readSchema, matching fields by ID as readers do. Every read
+// field must either be present in the write schema, with a type that can
+// be promoted to the read type and without relaxing a required field to
+// optional, or be optional or have an initial default. Fields that only
+// exist in the write schema are ignored.
+//
+// All issues are collected in the returned result rather than stopping at
+// the first one. An error is only returned if either schema is invalid.
+func CheckCompatibility(readSchema, writeSchema *Schema) (CompatibilityResult, error) {
+	c := compatChecker{}
+
+	var err error
+	if c.readNames, err = IndexNameByID(readSchema); err != nil {
+		return CompatibilityResult{}, err
+	}
+	if c.readByName, err = IndexByName(readSchema); err != nil {
+		return CompatibilityResult{}, err
+	}
+	if c.readParents, err = IndexParents(readSchema); err != nil {
+		return CompatibilityResult{}, err
+	}
+	if c.writeByID, err = IndexByID(writeSchema); err != nil {
+		return CompatibilityResult{}, err
+	}
+	if c.writeByName, err = IndexByName(writeSchema); err != nil {
+		return CompatibilityResult{}, err
+	}
+	if c.writeNames, err = IndexNameByID(writeSchema); err != nil {
+		return CompatibilityResult{}, err
+	}
+	if c.writeParents, err = IndexParents(writeSchema); err != nil {
+		return CompatibilityResult{}, err
+	}
+
+	c.checkFields(readSchema.Fields())
+
+	return CompatibilityResult{Issues: c.issues}, nil
+}
+
+type compatChecker struct {
+	readNames    map[int]string
+	readByName   map[string]int
+	readParents  map[int]int
+	writeByID    map[int]NestedField
+	writeByName  map[string]int
+	writeNames   map[int]string
+	writeParents map[int]int
+
+	issues []CompatibilityIssue
+}
+
+func (c *compatChecker) report(kind CompatibilityIssueKind, read NestedField, write *NestedField, msg string, args ...any) {
+	c.issues = append(c.issues, CompatibilityIssue{
+		Kind:       kind,
+		FieldID:    read.ID,
+		Column:     c.readNames[read.ID],
+		ReadField:  read,
+		WriteField: write,
+		Message:    fmt.Sprintf(msg, args...),
+	})
+}
+
+func (c *compatChecker) checkFields(fields []NestedField) {
+	for _, f := range fields {
+		c.checkField(f)
+	}
+}
+
+func (c *compatChecker) checkField(read NestedField) {
+	write, ok := c.writeByID[read.ID]
+	if !ok {
+		if id, ok := c.writeByName[c.readNames[read.ID]]; ok {
+			c.report(CompatibilityIDConflict, read, nil,
+				"written with field id %d instead of %d", id, read.ID)
+		} else if read.Required && read.InitialDefault == nil {
+			c.report(CompatibilityMissingRequiredField, read, nil,
+				"required field %d is missing from the write schema", read.ID)
+		}
+
+		return
+	}
+
+	if c.readParents[read.ID] != c.writeParents[read.ID] {
+		c.report(CompatibilityIDConflict, read, &write,
+			"field id %d has parent %d in the write schema but %d in the read schema",
+			read.ID, c.writeParents[read.ID], c.readParents[read.ID])
+
+		return
+	}
+
+	// renaming a column is allowed, but not to the name of another read
+	// column as that silently swaps their data
+	if name := c.writeNames[read.ID]; name != c.readNames[read.ID] {
+		if id, ok := c.readByName[name]; ok {
+			c.report(CompatibilityIDConflict, read, &write,
+				"field id %d is written as %s, which is field id %d in the read schema",
+				read.ID, name, id)
+
+			return
+		}
+	}
+
+	if read.Required && !write.Required {
+		c.report(CompatibilityOptionalToRequired, read, &write,
+			"cannot read optional field as required")
+	}
+
+	switch rt := read.Type.(type) {
+	case *StructType:
+		if _, ok := write.Type.(*StructType); ok {
+			c.checkFields(rt.FieldList)
+
+			return
+		}
+	case *ListType:
+		if _, ok := write.Type.(*ListType); ok {
+			c.checkField(rt.ElementField())
+
+			return
+		}
+	case *MapType:
+		if _, ok := write.Type.(*MapType); ok {
+			c.checkField(rt.KeyField())
+			c.checkField(rt.ValueField())
+
+			return
+		}
+	default:
+		if read.Type.Equals(write.Type) {
+			return
+		}
+		if _, err := PromoteType(write.Type, read.Type); err == nil {
+			return
+		}
+	}
+
+	c.report(CompatibilityIncompatibleType, read, &write,
+		"cannot read %s as %s", write.Type, read.Type)
+}
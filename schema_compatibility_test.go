@@ -0,0 +1,112 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg_test
+
+import (
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCompatibility(t *testing.T) {
+	readSchema := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 3, Name: "location", Type: &iceberg.StructType{FieldList: []iceberg.NestedField{
+			{ID: 4, Name: "lat", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+			{ID: 5, Name: "long", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+		}}},
+		iceberg.NestedField{ID: 6, Name: "tags", Type: &iceberg.ListType{
+			ElementID: 7, Element: iceberg.PrimitiveTypes.String, ElementRequired: true,
+		}},
+		iceberg.NestedField{ID: 8, Name: "added", Type: iceberg.PrimitiveTypes.Int32, Required: true,
+			InitialDefault: int32(0)})
+
+	t.Run("compatible", func(t *testing.T) {
+		writeSchema := iceberg.NewSchema(0,
+			iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int32, Required: true},
+			iceberg.NestedField{ID: 2, Name: "renamed", Type: iceberg.PrimitiveTypes.String, Required: true},
+			iceberg.NestedField{ID: 3, Name: "location", Type: &iceberg.StructType{FieldList: []iceberg.NestedField{
+				{ID: 4, Name: "lat", Type: iceberg.PrimitiveTypes.Float32, Required: true},
+				{ID: 5, Name: "long", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+			}}},
+			iceberg.NestedField{ID: 9, Name: "extra", Type: iceberg.PrimitiveTypes.Binary, Required: true})
+
+		result, err := iceberg.CheckCompatibility(readSchema, writeSchema)
+		require.NoError(t, err)
+		assert.True(t, result.Compatible(), "%v", result.Issues)
+		assert.NoError(t, result.Err())
+	})
+
+	t.Run("incompatible", func(t *testing.T) {
+		writeSchema := iceberg.NewSchema(0,
+			iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.Int64},
+			iceberg.NestedField{ID: 3, Name: "location", Type: &iceberg.StructType{FieldList: []iceberg.NestedField{
+				{ID: 4, Name: "lat", Type: iceberg.PrimitiveTypes.Float64},
+				{ID: 10, Name: "long", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+			}}},
+			iceberg.NestedField{ID: 6, Name: "tags", Type: iceberg.PrimitiveTypes.String},
+			iceberg.NestedField{ID: 7, Name: "element", Type: iceberg.PrimitiveTypes.String})
+
+		result, err := iceberg.CheckCompatibility(readSchema, writeSchema)
+		require.NoError(t, err)
+		require.False(t, result.Compatible())
+
+		type issue struct {
+			kind   iceberg.CompatibilityIssueKind
+			column string
+		}
+		got := make([]issue, len(result.Issues))
+		for i, is := range result.Issues {
+			got[i] = issue{is.Kind, is.Column}
+		}
+		assert.Equal(t, []issue{
+			{iceberg.CompatibilityMissingRequiredField, "id"},
+			{iceberg.CompatibilityIncompatibleType, "data"},
+			{iceberg.CompatibilityOptionalToRequired, "location.lat"},
+			{iceberg.CompatibilityIDConflict, "location.long"},
+			{iceberg.CompatibilityIncompatibleType, "tags"},
+		}, got)
+
+		assert.Equal(t, "written with field id 10 instead of 5", result.Issues[3].Message)
+		assert.Nil(t, result.Issues[0].WriteField)
+		require.NotNil(t, result.Issues[1].WriteField)
+		assert.Equal(t, iceberg.PrimitiveTypes.Int64, result.Issues[1].WriteField.Type)
+
+		err = result.Err()
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+		assert.ErrorContains(t, err, "data: cannot read long as string")
+	})
+
+	t.Run("moved field", func(t *testing.T) {
+		writeSchema := iceberg.NewSchema(0,
+			iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+			iceberg.NestedField{ID: 4, Name: "lat", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+			iceberg.NestedField{ID: 3, Name: "location", Type: &iceberg.StructType{FieldList: []iceberg.NestedField{
+				{ID: 5, Name: "long", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+			}}})
+
+		result, err := iceberg.CheckCompatibility(readSchema, writeSchema)
+		require.NoError(t, err)
+		require.Len(t, result.Issues, 1)
+		assert.Equal(t, iceberg.CompatibilityIDConflict, result.Issues[0].Kind)
+		assert.Equal(t, "location.lat", result.Issues[0].Column)
+	})
+}
@@ -19,6 +19,7 @@ package iceberg
 
 import (
 	"fmt"
+	"maps"
 
 	"github.com/apache/iceberg-go/internal"
 	"github.com/hamba/avro/v2"
@@ -70,3 +71,520 @@ func partitionTypeToAvroSchema(t *StructType) (avro.Schema, error) {
 
 	return avro.NewRecordSchema("r102", "", fields)
 }
+
+// SchemaToAvroSchema converts an Iceberg schema to an Avro record schema
+// named "table" whose fields carry Iceberg field IDs, in the layout read
+// by AvroSchemaToIceberg. Optional fields become unions of null and the
+// field type, structs become records named after their field ID, and
+// maps with non-string keys become arrays of key/value records. Field
+// names that are not valid Avro names are sanitized, keeping the original
+// name in the FieldNameProp property.
+func SchemaToAvroSchema(sc *Schema) (avro.Schema, error) {
+	return structToAvroRecord("table", sc.AsStruct())
+}
+
+func structToAvroRecord(name string, st StructType) (*avro.RecordSchema, error) {
+	fields := make([]*avro.Field, len(st.FieldList))
+	for i, f := range st.FieldList {
+		typ, err := icebergToAvroType(f.Type, f.ID, f.Required)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+
+		name, props := f.Name, map[string]any{"field-id": f.ID}
+		if !validAvroName(name) {
+			name, props[FieldNameProp] = sanitizeName(name), name
+		}
+		if len(f.Metadata) > 0 {
+			props[FieldMetadataProp] = f.Metadata
+		}
+
+		opts := []avro.SchemaOption{avro.WithProps(props)}
+		if f.Doc != "" {
+			opts = append(opts, avro.WithDoc(f.Doc))
+		}
+		if !f.Required {
+			opts = append(opts, avro.WithDefault(nil))
+		}
+
+		if fields[i], err = avro.NewField(name, typ, opts...); err != nil {
+			return nil, fmt.Errorf("%w: field %s: %w", ErrInvalidSchema, f.Name, err)
+		}
+	}
+
+	rec, err := avro.NewRecordSchema(name, "", fields)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+
+	return rec, nil
+}
+
+// icebergToAvroType returns the Avro schema for values of an Iceberg type
+// with the given field ID, used to give named Avro types unique names.
+func icebergToAvroType(t Type, id int, required bool) (sc avro.Schema, err error) {
+	switch t := t.(type) {
+	case *StructType:
+		sc, err = structToAvroRecord(fmt.Sprintf("r%d", id), *t)
+	case *ListType:
+		var elem avro.Schema
+		if elem, err = icebergToAvroType(t.Element, t.ElementID, t.ElementRequired); err == nil {
+			sc = avro.NewArraySchema(elem, internal.WithElementID(t.ElementID))
+		}
+	case *MapType:
+		sc, err = mapToAvro(t)
+	case PrimitiveType:
+		sc, err = primitiveToAvro(t, id)
+	default:
+		err = fmt.Errorf("%w: unsupported type %s", ErrInvalidSchema, t)
+	}
+
+	if err != nil || required {
+		return sc, err
+	}
+
+	return internal.NullableSchema(sc), nil
+}
+
+func mapToAvro(t *MapType) (avro.Schema, error) {
+	value, err := icebergToAvroType(t.ValueType, t.ValueID, t.ValueRequired)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := t.KeyType.(StringType); ok {
+		return avro.NewMapSchema(value, avro.WithProps(map[string]any{
+			"key-id": t.KeyID, "value-id": t.ValueID,
+		})), nil
+	}
+
+	key, err := icebergToAvroType(t.KeyType, t.KeyID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	keyField, err := avro.NewField("key", key, internal.WithFieldID(t.KeyID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+	valueField, err := avro.NewField("value", value, internal.WithFieldID(t.ValueID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+
+	rec, err := avro.NewRecordSchema(fmt.Sprintf("k%d_v%d", t.KeyID, t.ValueID), "",
+		[]*avro.Field{keyField, valueField})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+
+	return avro.NewArraySchema(rec, avro.WithProps(map[string]any{"logicalType": "map"})), nil
+}
+
+func primitiveToAvro(t PrimitiveType, id int) (avro.Schema, error) {
+	switch t := t.(type) {
+	case BooleanType:
+		return internal.BoolSchema, nil
+	case Int32Type:
+		return internal.IntSchema, nil
+	case Int64Type:
+		return internal.LongSchema, nil
+	case Float32Type:
+		return internal.FloatSchema, nil
+	case Float64Type:
+		return internal.DoubleSchema, nil
+	case DateType:
+		return internal.DateSchema, nil
+	case TimeType:
+		return internal.TimeSchema, nil
+	case TimestampType:
+		return internal.TimestampSchema, nil
+	case TimestampTzType:
+		return internal.TimestampTzSchema, nil
+	case StringType:
+		return internal.StringSchema, nil
+	case BinaryType:
+		return internal.BinarySchema, nil
+	case UUIDType:
+		return avro.NewFixedSchema(fmt.Sprintf("uuid_%d", id), "", 16,
+			avro.NewPrimitiveLogicalSchema(avro.UUID))
+	case FixedType:
+		return avro.NewFixedSchema(fmt.Sprintf("fixed_%d", id), "", t.Len(), nil)
+	case DecimalType:
+		return avro.NewFixedSchema(fmt.Sprintf("decimal_%d", id), "",
+			internal.DecimalRequiredBytes(t.Precision()),
+			avro.NewDecimalLogicalSchema(t.Precision(), t.Scale()))
+	}
+
+	return nil, fmt.Errorf("%w: cannot convert %s to avro", ErrInvalidSchema, t)
+}
+
+// AvroSchemaToIceberg converts an Avro record schema whose fields are
+// annotated with Iceberg field IDs to an Iceberg schema. Record fields must
+// carry a "field-id" property, arrays an "element-id", and maps "key-id"
+// and "value-id" (or, for maps with non-string keys encoded as arrays of
+// key/value records, field IDs on the key and value fields). A union of
+// null and a single type becomes an optional field.
+//
+// As the result is a table schema, it may not use reserved field IDs or
+// column names, see ValidateReservedFields.
+func AvroSchemaToIceberg(sc avro.Schema) (*Schema, error) {
+	out, err := avroSchemaToIceberg(sc)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateReservedFields(out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// avroSchemaToIceberg converts an Avro record schema like
+// AvroSchemaToIceberg, but allows reserved field IDs, as the schemas of
+// files such as position delete files use them.
+func avroSchemaToIceberg(sc avro.Schema) (*Schema, error) {
+	rec, ok := sc.(*avro.RecordSchema)
+	if !ok {
+		return nil, fmt.Errorf("%w: avro schema must be a record, got %s",
+			ErrInvalidSchema, sc.Type())
+	}
+
+	st, err := avroRecordToStruct(rec)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSchema(0, st.FieldList...), nil
+}
+
+// ValidateAvroWriteSchema checks that rows encoded with the given Avro
+// schema can be written to a table with the given schema. Fields are
+// matched by ID, so the result reports every table column that the Avro
+// schema is missing, writes with an incompatible type, or writes under a
+// different ID, taking type promotions and the table's initial defaults
+// into account.
+func ValidateAvroWriteSchema(avroSchema avro.Schema, tableSchema *Schema) (CompatibilityResult, error) {
+	writeSchema, err := AvroSchemaToIceberg(avroSchema)
+	if err != nil {
+		return CompatibilityResult{}, err
+	}
+
+	return CheckCompatibility(tableSchema, writeSchema)
+}
+
+func avroIDProp(sc interface{ Prop(string) any }, name, prop string) (int, error) {
+	switch v := sc.Prop(prop).(type) {
+	case int:
+		return v, nil
+	case float64:
+		return int(v), nil
+	default:
+		return 0, fmt.Errorf("%w: %s is missing the %s property", ErrInvalidSchema, name, prop)
+	}
+}
+
+func avroRecordToStruct(rec *avro.RecordSchema) (*StructType, error) {
+	fields := make([]NestedField, len(rec.Fields()))
+	for i, f := range rec.Fields() {
+		id, err := avroIDProp(f, "field "+f.Name(), "field-id")
+		if err != nil {
+			return nil, err
+		}
+
+		typ, required, err := avroToIcebergType(f.Type())
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Name(), err)
+		}
+
+		fields[i] = NestedField{
+			ID:       id,
+			Name:     avroFieldName(f),
+			Type:     typ,
+			Required: required,
+			Doc:      f.Doc(),
+			Metadata: avroFieldMetadata(f),
+		}
+	}
+
+	return &StructType{FieldList: fields}, nil
+}
+
+// avroFieldName returns the name of an Avro field, restoring the original
+// name of a field that was sanitized when it was written.
+func avroFieldName(f *avro.Field) string {
+	if name, ok := f.Prop(FieldNameProp).(string); ok && name != "" {
+		return name
+	}
+
+	return f.Name()
+}
+
+// avroFieldMetadata returns the Iceberg field metadata recorded in the
+// properties of an Avro field, which is a map of strings once parsed.
+func avroFieldMetadata(f *avro.Field) map[string]string {
+	switch v := f.Prop(FieldMetadataProp).(type) {
+	case map[string]string:
+		return maps.Clone(v)
+	case map[string]any:
+		md := make(map[string]string, len(v))
+		for k, val := range v {
+			if s, ok := val.(string); ok {
+				md[k] = s
+			}
+		}
+
+		return md
+	default:
+		return nil
+	}
+}
+
+// avroToIcebergType returns the Iceberg type for an Avro schema and
+// whether values are required, that is the schema is not a nullable union.
+func avroToIcebergType(sc avro.Schema) (Type, bool, error) {
+	if ref, ok := sc.(*avro.RefSchema); ok {
+		sc = ref.Schema()
+	}
+
+	switch s := sc.(type) {
+	case *avro.UnionSchema:
+		types := s.Types()
+		if len(types) != 2 || !s.Nullable() {
+			return nil, false, fmt.Errorf("%w: only unions of null and one type are supported, got %s",
+				ErrInvalidSchema, s)
+		}
+
+		inner := types[0]
+		if inner.Type() == avro.Null {
+			inner = types[1]
+		}
+		typ, _, err := avroToIcebergType(inner)
+
+		return typ, false, err
+	case *avro.RecordSchema:
+		st, err := avroRecordToStruct(s)
+
+		return st, true, err
+	case *avro.ArraySchema:
+		if s.Prop("logicalType") == "map" {
+			return avroKeyValueArrayToMap(s)
+		}
+
+		elemID, err := avroIDProp(s, "array", "element-id")
+		if err != nil {
+			return nil, false, err
+		}
+		elem, elemRequired, err := avroToIcebergType(s.Items())
+		if err != nil {
+			return nil, false, err
+		}
+
+		return &ListType{ElementID: elemID, Element: elem, ElementRequired: elemRequired}, true, nil
+	case *avro.MapSchema:
+		keyID, err := avroIDProp(s, "map", "key-id")
+		if err != nil {
+			return nil, false, err
+		}
+		valueID, err := avroIDProp(s, "map", "value-id")
+		if err != nil {
+			return nil, false, err
+		}
+		value, valueRequired, err := avroToIcebergType(s.Values())
+		if err != nil {
+			return nil, false, err
+		}
+
+		return &MapType{
+			KeyID: keyID, KeyType: PrimitiveTypes.String,
+			ValueID: valueID, ValueType: value, ValueRequired: valueRequired,
+		}, true, nil
+	case *avro.EnumSchema:
+		return PrimitiveTypes.String, true, nil
+	case *avro.FixedSchema:
+		switch l := s.Logical().(type) {
+		case *avro.DecimalLogicalSchema:
+			return DecimalTypeOf(l.Precision(), l.Scale()), true, nil
+		case nil:
+			// the avro parser only recognizes uuid on strings and keeps
+			// it as a plain property on fixed schemas
+			if s.Size() == 16 && s.Prop("logicalType") == string(avro.UUID) {
+				return PrimitiveTypes.UUID, true, nil
+			}
+
+			return FixedTypeOf(s.Size()), true, nil
+		default:
+			if l.Type() == avro.UUID {
+				return PrimitiveTypes.UUID, true, nil
+			}
+		}
+	case *avro.PrimitiveSchema:
+		typ, err := avroPrimitiveToIceberg(s)
+
+		return typ, true, err
+	}
+
+	return nil, false, fmt.Errorf("%w: unsupported avro schema %s", ErrInvalidSchema, sc)
+}
+
+func avroKeyValueArrayToMap(s *avro.ArraySchema) (Type, bool, error) {
+	rec, ok := s.Items().(*avro.RecordSchema)
+	if !ok || len(rec.Fields()) != 2 {
+		return nil, false, fmt.Errorf("%w: map array items must be a key/value record", ErrInvalidSchema)
+	}
+
+	st, err := avroRecordToStruct(rec)
+	if err != nil {
+		return nil, false, err
+	}
+	key, value := st.FieldList[0], st.FieldList[1]
+
+	return &MapType{
+		KeyID: key.ID, KeyType: key.Type,
+		ValueID: value.ID, ValueType: value.Type, ValueRequired: value.Required,
+	}, true, nil
+}
+
+func avroPrimitiveToIceberg(s *avro.PrimitiveSchema) (Type, error) {
+	var logical avro.LogicalType
+	if s.Logical() != nil {
+		logical = s.Logical().Type()
+	}
+
+	switch s.Type() {
+	case avro.Boolean:
+		return PrimitiveTypes.Bool, nil
+	case avro.Int:
+		switch logical {
+		case avro.Date:
+			return PrimitiveTypes.Date, nil
+		case "":
+			return PrimitiveTypes.Int32, nil
+		}
+	case avro.Long:
+		switch logical {
+		case avro.TimeMicros:
+			return PrimitiveTypes.Time, nil
+		case avro.TimestampMicros:
+			// timestamps without the adjust-to-utc property are
+			// assumed to be in UTC
+			if adjust, ok := s.Prop("adjust-to-utc").(bool); ok && !adjust {
+				return PrimitiveTypes.Timestamp, nil
+			}
+
+			return PrimitiveTypes.TimestampTz, nil
+		case avro.LocalTimestampMicros:
+			return PrimitiveTypes.Timestamp, nil
+		case "":
+			return PrimitiveTypes.Int64, nil
+		}
+	case avro.Float:
+		return PrimitiveTypes.Float32, nil
+	case avro.Double:
+		return PrimitiveTypes.Float64, nil
+	case avro.String:
+		if logical == avro.UUID {
+			return PrimitiveTypes.UUID, nil
+		}
+
+		return PrimitiveTypes.String, nil
+	case avro.Bytes:
+		if d, ok := s.Logical().(*avro.DecimalLogicalSchema); ok {
+			return DecimalTypeOf(d.Precision(), d.Scale()), nil
+		}
+
+		return PrimitiveTypes.Binary, nil
+	}
+
+	return nil, fmt.Errorf("%w: unsupported avro type %s", ErrInvalidSchema, s)
+}
+
+// AvroSchemaAccessor is a PartnerAccessor for visiting an Iceberg schema
+// together with an Avro record schema, such as the schema of a manifest
+// or of a data file, with VisitSchemaWithPartner. Record fields are
+// matched by their field-id property, or by name in records without
+// field IDs. Partners are the schemas of the matching fields as written,
+// which may be nullable unions; the accessor looks through unions and
+// references when descending into them. Nodes without a counterpart in
+// the Avro schema have a nil partner.
+type AvroSchemaAccessor struct{}
+
+// unwrapAvroSchema returns the non-null type of a nullable union,
+// resolving references.
+func unwrapAvroSchema(sc avro.Schema) avro.Schema {
+	if ref, ok := sc.(*avro.RefSchema); ok {
+		sc = ref.Schema()
+	}
+
+	if u, ok := sc.(*avro.UnionSchema); ok && u.Nullable() && len(u.Types()) == 2 {
+		sc = u.Types()[0]
+		if sc.Type() == avro.Null {
+			sc = u.Types()[1]
+		}
+		if ref, ok := sc.(*avro.RefSchema); ok {
+			sc = ref.Schema()
+		}
+	}
+
+	return sc
+}
+
+func (AvroSchemaAccessor) SchemaPartner(partner avro.Schema) avro.Schema {
+	return partner
+}
+
+func (AvroSchemaAccessor) FieldPartner(partnerStruct avro.Schema, fieldID int, fieldName string) avro.Schema {
+	rec, ok := unwrapAvroSchema(partnerStruct).(*avro.RecordSchema)
+	if !ok {
+		return nil
+	}
+
+	var byName avro.Schema
+	for _, f := range rec.Fields() {
+		id, err := avroIDProp(f, f.Name(), "field-id")
+		switch {
+		case err == nil && id == fieldID:
+			return f.Type()
+		case err != nil && avroFieldName(f) == fieldName:
+			byName = f.Type()
+		}
+	}
+
+	return byName
+}
+
+func (AvroSchemaAccessor) ListElementPartner(partnerList avro.Schema) avro.Schema {
+	arr, ok := unwrapAvroSchema(partnerList).(*avro.ArraySchema)
+	if !ok || arr.Prop("logicalType") == "map" {
+		return nil
+	}
+
+	return arr.Items()
+}
+
+func (AvroSchemaAccessor) MapKeyPartner(partnerMap avro.Schema) avro.Schema {
+	switch m := unwrapAvroSchema(partnerMap).(type) {
+	case *avro.MapSchema:
+		return avro.NewPrimitiveSchema(avro.String, nil)
+	case *avro.ArraySchema:
+		if rec, ok := m.Items().(*avro.RecordSchema); ok && len(rec.Fields()) == 2 {
+			return rec.Fields()[0].Type()
+		}
+	}
+
+	return nil
+}
+
+func (AvroSchemaAccessor) MapValuePartner(partnerMap avro.Schema) avro.Schema {
+	switch m := unwrapAvroSchema(partnerMap).(type) {
+	case *avro.MapSchema:
+		return m.Values()
+	case *avro.ArraySchema:
+		if rec, ok := m.Items().(*avro.RecordSchema); ok && len(rec.Fields()) == 2 {
+			return rec.Fields()[1].Type()
+		}
+	}
+
+	return nil
+}
@@ -18,7 +18,9 @@
 package iceberg
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/apache/iceberg-go/internal"
@@ -147,3 +149,233 @@ func TestPartitionTypeToAvroSchemaNullableAndNonNullable(t *testing.T) {
 		assert.Empty(t, encoded)
 	})
 }
+
+func TestAvroSchemaToIceberg(t *testing.T) {
+	avroSchema, err := avro.Parse(`{
+		"type": "record", "name": "event", "fields": [
+			{"name": "id", "type": "long", "field-id": 1},
+			{"name": "data", "type": ["null", "string"], "default": null, "field-id": 2},
+			{"name": "ts", "type": {"type": "long", "logicalType": "timestamp-micros", "adjust-to-utc": false}, "field-id": 3},
+			{"name": "price", "type": {"type": "fixed", "name": "dec", "size": 8, "logicalType": "decimal", "precision": 9, "scale": 2}, "field-id": 4},
+			{"name": "tags", "type": {"type": "array", "items": "string", "element-id": 6}, "field-id": 5},
+			{"name": "attrs", "type": {"type": "map", "values": ["null", "int"], "key-id": 8, "value-id": 9}, "field-id": 7},
+			{"name": "location", "type": {"type": "record", "name": "loc", "fields": [
+				{"name": "lat", "type": "double", "field-id": 11}
+			]}, "field-id": 10}
+		]}`)
+	require.NoError(t, err)
+
+	sc, err := AvroSchemaToIceberg(avroSchema)
+	require.NoError(t, err)
+
+	expected := NewSchema(0,
+		NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.Int64, Required: true},
+		NestedField{ID: 2, Name: "data", Type: PrimitiveTypes.String},
+		NestedField{ID: 3, Name: "ts", Type: PrimitiveTypes.Timestamp, Required: true},
+		NestedField{ID: 4, Name: "price", Type: DecimalTypeOf(9, 2), Required: true},
+		NestedField{ID: 5, Name: "tags", Type: &ListType{
+			ElementID: 6, Element: PrimitiveTypes.String, ElementRequired: true,
+		}, Required: true},
+		NestedField{ID: 7, Name: "attrs", Type: &MapType{
+			KeyID: 8, KeyType: PrimitiveTypes.String, ValueID: 9, ValueType: PrimitiveTypes.Int32,
+		}, Required: true},
+		NestedField{ID: 10, Name: "location", Type: &StructType{FieldList: []NestedField{
+			{ID: 11, Name: "lat", Type: PrimitiveTypes.Float64, Required: true},
+		}}, Required: true})
+	assert.True(t, expected.Equals(sc), "expected %s, got %s", expected, sc)
+
+	missingID, err := avro.Parse(`{"type": "record", "name": "r", "fields": [{"name": "id", "type": "long"}]}`)
+	require.NoError(t, err)
+	_, err = AvroSchemaToIceberg(missingID)
+	assert.ErrorIs(t, err, ErrInvalidSchema)
+	assert.ErrorContains(t, err, "field id is missing the field-id property")
+}
+
+func TestValidateAvroWriteSchema(t *testing.T) {
+	tableSchema := NewSchema(0,
+		NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.Int64, Required: true},
+		NestedField{ID: 2, Name: "data", Type: PrimitiveTypes.String},
+		NestedField{ID: 3, Name: "count", Type: PrimitiveTypes.Int64, Required: true, InitialDefault: int64(0)})
+
+	// the producer writes ints that are promoted to longs and omits the
+	// optional and defaulted columns
+	avroSchema, err := avro.Parse(`{"type": "record", "name": "r", "fields": [
+		{"name": "id", "type": "int", "field-id": 1}
+	]}`)
+	require.NoError(t, err)
+
+	result, err := ValidateAvroWriteSchema(avroSchema, tableSchema)
+	require.NoError(t, err)
+	assert.True(t, result.Compatible(), "%v", result.Issues)
+
+	// the producer swapped the IDs of two columns
+	avroSchema, err = avro.Parse(`{"type": "record", "name": "r", "fields": [
+		{"name": "id", "type": ["null", "string"], "field-id": 2},
+		{"name": "data", "type": "long", "field-id": 1}
+	]}`)
+	require.NoError(t, err)
+
+	result, err = ValidateAvroWriteSchema(avroSchema, tableSchema)
+	require.NoError(t, err)
+	require.Len(t, result.Issues, 2)
+	assert.Equal(t, CompatibilityIDConflict, result.Issues[0].Kind)
+	assert.Equal(t, "id", result.Issues[0].Column)
+	assert.Equal(t, CompatibilityIDConflict, result.Issues[1].Kind)
+	assert.Equal(t, "data", result.Issues[1].Column)
+	assert.ErrorIs(t, result.Err(), ErrInvalidSchema)
+}
+
+// partnerTypes records the type of the partner of every primitive field
+// of a schema, by field path.
+type partnerTypes[P any] struct {
+	path     []string
+	typeName func(P) string
+	out      []string
+}
+
+func (v *partnerTypes[P]) BeforeField(f NestedField, _ P)            { v.path = append(v.path, f.Name) }
+func (v *partnerTypes[P]) AfterField(NestedField, P)                 { v.path = v.path[:len(v.path)-1] }
+func (v *partnerTypes[P]) BeforeListElement(f NestedField, _ P)      { v.path = append(v.path, f.Name) }
+func (v *partnerTypes[P]) AfterListElement(NestedField, P)           { v.path = v.path[:len(v.path)-1] }
+func (v *partnerTypes[P]) BeforeMapKey(f NestedField, _ P)           { v.path = append(v.path, f.Name) }
+func (v *partnerTypes[P]) AfterMapKey(NestedField, P)                { v.path = v.path[:len(v.path)-1] }
+func (v *partnerTypes[P]) BeforeMapValue(f NestedField, _ P)         { v.path = append(v.path, f.Name) }
+func (v *partnerTypes[P]) AfterMapValue(NestedField, P)              { v.path = v.path[:len(v.path)-1] }
+func (v *partnerTypes[P]) Schema(*Schema, P, []string) []string      { return v.out }
+func (v *partnerTypes[P]) Struct(StructType, P, [][]string) []string { return nil }
+func (v *partnerTypes[P]) Field(NestedField, P, []string) []string   { return nil }
+func (v *partnerTypes[P]) List(ListType, P, []string) []string       { return nil }
+func (v *partnerTypes[P]) Map(MapType, P, []string, []string) []string {
+	return nil
+}
+
+func (v *partnerTypes[P]) Primitive(_ PrimitiveType, partner P) []string {
+	v.out = append(v.out, strings.Join(v.path, ".")+"="+v.typeName(partner))
+
+	return nil
+}
+
+func TestAvroSchemaAccessor(t *testing.T) {
+	fields := []NestedField{
+		{ID: 1, Name: "id", Type: PrimitiveTypes.Int64, Required: true},
+		{ID: 2, Name: "name", Type: PrimitiveTypes.String},
+		{ID: 3, Name: "loc", Type: &StructType{FieldList: []NestedField{
+			{ID: 4, Name: "lat", Type: PrimitiveTypes.Float64, Required: true},
+		}}},
+		{ID: 5, Name: "ints", Type: &ListType{ElementID: 6, Element: PrimitiveTypes.Int32}},
+		{ID: 7, Name: "props", Type: &MapType{
+			KeyID: 8, KeyType: PrimitiveTypes.String, ValueID: 9, ValueType: PrimitiveTypes.Date,
+		}},
+		{ID: 10, Name: "by_id", Type: &MapType{
+			KeyID: 11, KeyType: PrimitiveTypes.Int32, ValueID: 12, ValueType: PrimitiveTypes.Binary, ValueRequired: true,
+		}},
+	}
+	avroSchema, err := SchemaToAvroSchema(NewSchema(0, fields...))
+	require.NoError(t, err)
+
+	// the read schema renames name and adds a field missing from the file
+	readFields := append([]NestedField{}, fields...)
+	readFields[1].Name = "full_name"
+	readFields = append(readFields, NestedField{ID: 13, Name: "added", Type: PrimitiveTypes.Bool})
+
+	visitor := &partnerTypes[avro.Schema]{typeName: func(sc avro.Schema) string {
+		if sc == nil {
+			return "missing"
+		}
+
+		return string(unwrapAvroSchema(sc).Type())
+	}}
+	out, err := VisitSchemaWithPartner[[]string, avro.Schema](NewSchema(0, readFields...),
+		avroSchema, visitor, AvroSchemaAccessor{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"id=long",
+		"full_name=string",
+		"loc.lat=double",
+		"ints.element=int",
+		"props.key=string",
+		"props.value=int",
+		"by_id.key=int",
+		"by_id.value=bytes",
+		"added=missing",
+	}, out)
+}
+
+func TestSanitizeName(t *testing.T) {
+	tests := []struct {
+		name, expected string
+	}{
+		{"valid_name", "valid_name"},
+		{"9x", "_9x"},
+		{"a.b", "a_x2Eb"},
+		{"a b", "a_x20b"},
+		{"名前", "_x540D_x524D"},
+		{"café", "caf_xE9"},
+		{"emoji_😀", "emoji__x1F600"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, makeCompatibleName(tt.name))
+			assert.True(t, validAvroName(makeCompatibleName(tt.name)))
+		})
+	}
+}
+
+func TestSchemaToAvroSchemaUnicodeNames(t *testing.T) {
+	sc := NewSchema(0,
+		NestedField{ID: 1, Name: "id", Type: PrimitiveTypes.Int64, Required: true},
+		NestedField{ID: 2, Name: "名前", Type: PrimitiveTypes.String},
+		NestedField{ID: 3, Name: "😀", Type: &StructType{FieldList: []NestedField{
+			{ID: 4, Name: "sub field", Type: PrimitiveTypes.Int32, Required: true},
+		}}},
+	)
+
+	avroSchema, err := SchemaToAvroSchema(sc)
+	require.NoError(t, err)
+
+	fields := avroSchema.(*avro.RecordSchema).Fields()
+	assert.Equal(t, "id", fields[0].Name())
+	assert.Nil(t, fields[0].Prop(FieldNameProp))
+	assert.Equal(t, "_x540D_x524D", fields[1].Name())
+	assert.Equal(t, "名前", fields[1].Prop(FieldNameProp))
+	assert.Equal(t, "_x1F600", fields[2].Name())
+
+	// the original names survive serializing the Avro schema
+	data, err := json.Marshal(avroSchema)
+	require.NoError(t, err)
+	parsed, err := avro.Parse(string(data))
+	require.NoError(t, err)
+
+	roundTripped, err := AvroSchemaToIceberg(parsed)
+	require.NoError(t, err)
+	assert.True(t, sc.Equals(roundTripped), "expected %s, got %s", sc, roundTripped)
+
+	names, err := SanitizedFieldNames(sc)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]string{2: "名前", 3: "😀", 4: "sub field"}, names)
+}
+
+func TestSchemaToAvroSchemaFieldMetadata(t *testing.T) {
+	md := map[string]string{"classification": "pii"}
+	sc := NewSchema(0,
+		NestedField{ID: 1, Name: "email", Type: PrimitiveTypes.String, Metadata: md},
+		NestedField{ID: 2, Name: "id", Type: PrimitiveTypes.Int64, Required: true},
+	)
+
+	avroSchema, err := SchemaToAvroSchema(sc)
+	require.NoError(t, err)
+
+	fields := avroSchema.(*avro.RecordSchema).Fields()
+	assert.Equal(t, md, fields[0].Prop(FieldMetadataProp))
+	assert.Nil(t, fields[1].Prop(FieldMetadataProp))
+
+	data, err := json.Marshal(avroSchema)
+	require.NoError(t, err)
+	parsed, err := avro.Parse(string(data))
+	require.NoError(t, err)
+
+	roundTripped, err := AvroSchemaToIceberg(parsed)
+	require.NoError(t, err)
+	assert.True(t, sc.Equals(roundTripped), "expected %s, got %s", sc, roundTripped)
+}
@@ -0,0 +1,76 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package iceberg_test
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/icebergtest"
+	"github.com/hamba/avro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// FuzzSchemaRoundTrip generates random schemas and checks that they
+// survive a round trip through JSON and through Avro, both as an Avro
+// schema value and as its JSON text. Run it with
+//
+//	go test -run '^$' -fuzz FuzzSchemaRoundTrip .
+func FuzzSchemaRoundTrip(f *testing.F) {
+	for seed := range uint64(32) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, seed uint64) {
+		gen := icebergtest.NewSchemaGenerator(rand.New(rand.NewPCG(seed, 0)))
+		gen.Docs = true
+		sc := gen.Schema()
+
+		data, err := json.Marshal(sc)
+		require.NoError(t, err)
+		var fromJSON iceberg.Schema
+		require.NoError(t, json.Unmarshal(data, &fromJSON))
+		assert.True(t, sc.Equals(&fromJSON), "json round trip:\n%s\n%s", sc, &fromJSON)
+
+		avroSc, err := iceberg.SchemaToAvroSchema(sc)
+		require.NoError(t, err)
+		fromAvro, err := iceberg.AvroSchemaToIceberg(avroSc)
+		require.NoError(t, err)
+		assert.True(t, sc.Equals(fromAvro), "avro round trip:\n%s\n%s", sc, fromAvro)
+
+		avroJSON, err := json.Marshal(avroSc)
+		require.NoError(t, err)
+		parsed, err := avro.ParseBytes(avroJSON)
+		require.NoError(t, err, string(avroJSON))
+		fromParsed, err := iceberg.AvroSchemaToIceberg(parsed)
+		require.NoError(t, err)
+		assert.True(t, sc.Equals(fromParsed), "parsed avro round trip:\n%s\n%s", sc, fromParsed)
+	})
+}
+
+func TestSchemaToAvroSchemaUnsupportedType(t *testing.T) {
+	sc := iceberg.NewSchema(0, iceberg.NestedField{
+		ID: 1, Name: "ts", Type: iceberg.PrimitiveTypes.TimestampNs, Required: true,
+	})
+
+	_, err := iceberg.SchemaToAvroSchema(sc)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+}
@@ -19,6 +19,8 @@ package iceberg_test
 
 import (
 	"encoding/json"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
@@ -269,6 +271,40 @@ func TestSchemaIndexByName(t *testing.T) {
 	}, index)
 }
 
+func TestSchemaIndexByNameMapStructValue(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "props", Type: &iceberg.MapType{
+			KeyID:   2,
+			KeyType: iceberg.PrimitiveTypes.String,
+			ValueID: 3,
+			ValueType: &iceberg.StructType{FieldList: []iceberg.NestedField{
+				{ID: 4, Name: "a", Type: iceberg.PrimitiveTypes.String},
+				{ID: 5, Name: "b", Type: iceberg.PrimitiveTypes.String},
+			}},
+		}})
+
+	index, err := iceberg.IndexByName(sc)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int{
+		"props":         1,
+		"props.key":     2,
+		"props.value":   3,
+		"props.value.a": 4,
+		"props.value.b": 5,
+		"props.a":       4,
+		"props.b":       5,
+	}, index)
+
+	name, ok := sc.FindColumnName(4)
+	assert.True(t, ok)
+	assert.Equal(t, "props.value.a", name)
+
+	projected, err := sc.Select(true, "props.a")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4}, slices.Sorted(slices.Values(projected.FieldIDs())))
+}
+
 func TestSchemaFindColumnName(t *testing.T) {
 	tests := []struct {
 		id   int
@@ -729,11 +765,142 @@ func TestPruneColumnsSelectOriginalSchema(t *testing.T) {
 	assert.True(t, sc.Equals(tableSchemaNested))
 }
 
+func TestPruneColumnsKeepsFieldProperties(t *testing.T) {
+	sc := iceberg.NewSchema(1,
+		iceberg.NestedField{
+			ID: 1, Name: "point", Doc: "a point", Required: true,
+			InitialDefault: map[string]any{}, WriteDefault: map[string]any{},
+			Type: &iceberg.StructType{FieldList: []iceberg.NestedField{
+				{ID: 2, Name: "x", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+				{ID: 3, Name: "y", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+			}},
+		})
+
+	pruned, err := iceberg.PruneColumns(sc, map[int]iceberg.Void{2: {}}, false)
+	require.NoError(t, err)
+
+	field := pruned.Field(0)
+	assert.Equal(t, "a point", field.Doc)
+	assert.NotNil(t, field.InitialDefault)
+	assert.NotNil(t, field.WriteDefault)
+	assert.Len(t, field.Type.(*iceberg.StructType).FieldList, 1)
+
+	// the pruned schema is fully initialized
+	assert.False(t, pruned.FieldHasOptionalParent(2))
+	parents, err := pruned.IndexParents()
+	require.NoError(t, err)
+	assert.Equal(t, map[int]int{2: 1}, parents)
+}
+
+func TestProjectedIDs(t *testing.T) {
+	ids, err := iceberg.ProjectedIDs(tableSchemaNested)
+	require.NoError(t, err)
+
+	expected := map[int]iceberg.Void{}
+	for _, id := range []int{1, 2, 3, 5, 10, 13, 14, 16, 17, 20, 22} {
+		expected[id] = iceberg.Void{}
+	}
+	assert.Equal(t, expected, ids)
+
+	// projecting the projected IDs returns the schema
+	sc, err := iceberg.PruneColumns(tableSchemaNested, ids, false)
+	require.NoError(t, err)
+	assert.True(t, sc.Equals(tableSchemaNested))
+}
+
+func TestSelectNot(t *testing.T) {
+	sc, err := iceberg.SelectNot(tableSchemaNested, map[int]iceberg.Void{
+		2: {}, 5: {}, 13: {}, 16: {}, 17: {},
+	})
+	require.NoError(t, err)
+
+	var names []string
+	for _, f := range sc.Fields() {
+		names = append(names, f.Name)
+	}
+	// qux loses its element and person all its fields
+	assert.Equal(t, []string{"foo", "baz", "quux", "location", "thing", "tags"}, names)
+
+	loc, ok := sc.FindFieldByName("location")
+	require.True(t, ok)
+	elem := loc.Type.(*iceberg.ListType).Element.(*iceberg.StructType)
+	assert.Equal(t, []iceberg.NestedField{
+		{ID: 14, Name: "longitude", Type: iceberg.PrimitiveTypes.Float32, Required: false},
+	}, elem.FieldList)
+	assert.Equal(t, []int{1}, sc.IdentifierFieldIDs)
+}
+
+func TestSchemaIndexes(t *testing.T) {
+	byID, err := tableSchemaNested.IndexByID()
+	require.NoError(t, err)
+	expectedByID, err := iceberg.IndexByID(tableSchemaNested)
+	require.NoError(t, err)
+	assert.Equal(t, expectedByID, byID)
+
+	byName, err := tableSchemaNested.IndexByName()
+	require.NoError(t, err)
+	assert.Equal(t, 13, byName["location.element.latitude"])
+	assert.Equal(t, 13, byName["location.latitude"])
+
+	nameByID, err := tableSchemaNested.IndexNameByID()
+	require.NoError(t, err)
+	assert.Equal(t, "location.element.latitude", nameByID[13])
+
+	parents, err := tableSchemaNested.IndexParents()
+	require.NoError(t, err)
+	assert.Equal(t, 12, parents[13])
+	assert.Equal(t, 11, parents[12])
+	assert.NotContains(t, parents, 1)
+
+	// the indexes are built once
+	again, err := tableSchemaNested.IndexByID()
+	require.NoError(t, err)
+	assert.Equal(t, reflect.ValueOf(byID).Pointer(), reflect.ValueOf(again).Pointer())
+}
+
 func TestPruneNilSchema(t *testing.T) {
 	_, err := iceberg.PruneColumns(nil, nil, true)
 	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
 }
 
+func TestFieldMetadataJSON(t *testing.T) {
+	sc := iceberg.NewSchema(1,
+		iceberg.NestedField{
+			ID: 1, Name: "email", Type: iceberg.PrimitiveTypes.String,
+			Metadata: map[string]string{"classification": "pii", "owner": "identity-team"},
+		},
+		iceberg.NestedField{ID: 2, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+	)
+
+	data, err := json.Marshal(sc)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "struct",
+		"fields": [
+			{"id": 1, "name": "email", "type": "string", "required": false,
+			 "metadata": {"classification": "pii", "owner": "identity-team"}},
+			{"id": 2, "name": "id", "type": "long", "required": true}
+		],
+		"schema-id": 1,
+		"identifier-field-ids": []
+	}`, string(data))
+
+	var out iceberg.Schema
+	require.NoError(t, json.Unmarshal(data, &out))
+	assert.True(t, sc.Equals(&out))
+
+	// metadata takes part in equality and survives assigning fresh IDs
+	withoutMD := iceberg.NewSchema(1,
+		iceberg.NestedField{ID: 1, Name: "email", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 2, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+	)
+	assert.False(t, sc.Equals(withoutMD))
+
+	fresh, err := iceberg.AssignFreshSchemaIDs(sc, nil)
+	require.NoError(t, err)
+	assert.Equal(t, sc.Field(0).Metadata, fresh.Field(0).Metadata)
+}
+
 func TestAssignFreshSchemaIDs(t *testing.T) {
 	startID := 100
 	sc, err := iceberg.AssignFreshSchemaIDs(tableSchemaNested, func() int {
@@ -0,0 +1,422 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/compute"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+)
+
+// readTypes describes how string, binary and timestamptz columns are
+// represented in the records returned by a scan.
+type readTypes struct {
+	// dictionary keeps the dictionary encoding of top-level string and
+	// binary columns, returning them as dictionary arrays with int32
+	// indices instead of materializing every value.
+	dictionary bool
+	// views returns the remaining string and binary columns, including
+	// nested ones, as StringView and BinaryView arrays whose values
+	// reference the buffers they were decoded into.
+	views bool
+	// timeZone is the time zone of the arrow timestamps that timestamptz
+	// columns are returned as, UTC when empty. The values are unchanged,
+	// only the zone they are displayed in differs.
+	timeZone string
+	// epochTimestamps returns timestamptz columns as int64 microseconds
+	// (nanoseconds for timestamptz_ns) since the unix epoch instead.
+	epochTimestamps bool
+	// naiveTimestampsAsUTC reads timestamps without a time zone in data
+	// files as UTC when the table column is a timestamptz. Otherwise such
+	// files fail the scan rather than being silently assumed to be UTC.
+	naiveTimestampsAsUTC bool
+}
+
+// scanReadTypes returns the readTypes selected by the options of a scan.
+func scanReadTypes(opts iceberg.Properties) (readTypes, error) {
+	var rt readTypes
+	rt.dictionary, _ = strconv.ParseBool(opts.Get(ScanOptionArrowReadDictionary, "false"))
+	rt.views, _ = strconv.ParseBool(opts.Get(ScanOptionArrowUseViewTypes, "false"))
+
+	switch tz := opts.Get(ScanOptionArrowTimestampTz, "UTC"); {
+	case slices.Contains(utcAliases, tz):
+	case strings.EqualFold(tz, TimestampTzEpoch):
+		rt.epochTimestamps = true
+	default:
+		if _, err := time.LoadLocation(tz); err != nil || tz == "" {
+			return rt, fmt.Errorf("%w: invalid %s scan option %q, expected UTC, %s or a time zone name",
+				iceberg.ErrInvalidArgument, ScanOptionArrowTimestampTz, tz, TimestampTzEpoch)
+		}
+		rt.timeZone = tz
+	}
+
+	naive := opts.Get(ScanOptionNaiveTimestampsAsUTC, "false")
+	var err error
+	if rt.naiveTimestampsAsUTC, err = strconv.ParseBool(naive); err != nil {
+		return rt, fmt.Errorf("%w: invalid %s scan option %q",
+			iceberg.ErrInvalidArgument, ScanOptionNaiveTimestampsAsUTC, naive)
+	}
+
+	return rt, nil
+}
+
+// nested reports whether columns nested in structs, lists and maps are
+// converted as well.
+func (rt readTypes) nested() bool {
+	return rt.views || rt.epochTimestamps || rt.timeZone != ""
+}
+
+var dictionaryReadIndexType = arrow.PrimitiveTypes.Int32
+
+// arrowType returns dt, an arrow type produced by TypeToArrowType, as it
+// is read with these settings.
+func (rt readTypes) arrowType(dt arrow.DataType, topLevel bool) arrow.DataType {
+	switch dt := dt.(type) {
+	case *arrow.StringType, *arrow.LargeStringType:
+		if rt.dictionary && topLevel {
+			return &arrow.DictionaryType{IndexType: dictionaryReadIndexType, ValueType: arrow.BinaryTypes.String}
+		}
+		if rt.views {
+			return arrow.BinaryTypes.StringView
+		}
+	case *arrow.BinaryType, *arrow.LargeBinaryType:
+		if rt.dictionary && topLevel {
+			return &arrow.DictionaryType{IndexType: dictionaryReadIndexType, ValueType: arrow.BinaryTypes.Binary}
+		}
+		if rt.views {
+			return arrow.BinaryTypes.BinaryView
+		}
+	case *arrow.TimestampType:
+		if dt.TimeZone == "" {
+			// timestamp without time zone
+			return dt
+		}
+
+		if rt.epochTimestamps {
+			return arrow.PrimitiveTypes.Int64
+		}
+
+		if rt.timeZone != "" {
+			return &arrow.TimestampType{Unit: dt.Unit, TimeZone: rt.timeZone}
+		}
+	case *arrow.StructType:
+		if !rt.nested() {
+			return dt
+		}
+
+		fields := dt.Fields()
+		for i, f := range fields {
+			fields[i].Type = rt.arrowType(f.Type, false)
+		}
+
+		return arrow.StructOf(fields...)
+	case *arrow.MapType:
+		if !rt.nested() {
+			return dt
+		}
+
+		key, item := dt.KeyField(), dt.ItemField()
+		key.Type, item.Type = rt.arrowType(key.Type, false), rt.arrowType(item.Type, false)
+
+		return arrow.MapOfFields(key, item)
+	case *arrow.ListType:
+		if !rt.nested() {
+			return dt
+		}
+
+		elem := dt.ElemField()
+		elem.Type = rt.arrowType(elem.Type, false)
+
+		return arrow.ListOfField(elem)
+	case *arrow.LargeListType:
+		if !rt.nested() {
+			return dt
+		}
+
+		elem := dt.ElemField()
+		elem.Type = rt.arrowType(elem.Type, false)
+
+		return arrow.LargeListOfField(elem)
+	}
+
+	return dt
+}
+
+// arrowSchema returns sc, the arrow schema of a projection, with its
+// columns converted by arrowType.
+func (rt readTypes) arrowSchema(sc *arrow.Schema) *arrow.Schema {
+	if !rt.dictionary && !rt.nested() {
+		return sc
+	}
+
+	fields := sc.Fields()
+	for i, f := range fields {
+		fields[i].Type = rt.arrowType(f.Type, true)
+	}
+	md := sc.Metadata()
+
+	return arrow.NewSchema(fields, &md)
+}
+
+// convert returns arr, a primitive array, as the given target type if it
+// is a dictionary, view or timestamp representation that arr can be
+// converted to without casting. Otherwise arr is returned, retained, as is.
+func (rt readTypes) convert(ctx context.Context, arr arrow.Array, target arrow.DataType) (arrow.Array, error) {
+	mem := compute.GetAllocator(ctx)
+
+	switch target := target.(type) {
+	case *arrow.DictionaryType:
+		if dict, ok := arr.(*array.Dictionary); ok {
+			return convertDictionaryValues(ctx, dict, target)
+		}
+
+		if !arrow.TypeEqual(arr.DataType(), target.ValueType) {
+			values, err := compute.CastArray(ctx, arr, compute.SafeCastOptions(target.ValueType))
+			if err != nil {
+				return nil, err
+			}
+			defer values.Release()
+			arr = values
+		}
+
+		bldr := array.NewDictionaryBuilder(mem, target)
+		defer bldr.Release()
+		if err := bldr.AppendArray(arr); err != nil {
+			return nil, err
+		}
+
+		return bldr.NewArray(), nil
+	case *arrow.StringViewType, *arrow.BinaryViewType:
+		switch arr.(type) {
+		case *array.String, *array.Binary:
+			return binaryToView(mem, arr, target), nil
+		case *array.LargeString, *array.LargeBinary:
+			// views address their data with 32-bit offsets
+			smallType, err := ensureSmallArrowTypes(arr.DataType())
+			if err != nil {
+				return nil, err
+			}
+			small, err := compute.CastArray(ctx, arr, compute.SafeCastOptions(smallType))
+			if err != nil {
+				return nil, err
+			}
+			defer small.Release()
+
+			return binaryToView(mem, small, target), nil
+		}
+	case *arrow.TimestampType, *arrow.Int64Type:
+		// both share the memory layout of timestamps, so only the type
+		// of the data changes
+		if ts, ok := arr.(*array.Timestamp); ok && !arrow.TypeEqual(ts.DataType(), target) {
+			data := array.NewData(target, ts.Len(), ts.Data().Buffers(), nil, ts.NullN(), ts.Data().Offset())
+			defer data.Release()
+
+			return array.MakeFromData(data), nil
+		}
+	}
+
+	arr.Retain()
+
+	return arr, nil
+}
+
+// convertDictionaryValues returns dict as the target dictionary type,
+// casting only its dictionary values if they are of a different type.
+func convertDictionaryValues(ctx context.Context, dict *array.Dictionary, target *arrow.DictionaryType) (arrow.Array, error) {
+	if arrow.TypeEqual(dict.DataType(), target) {
+		dict.Retain()
+
+		return dict, nil
+	}
+
+	if !arrow.TypeEqual(dict.Indices().DataType(), target.IndexType) {
+		return nil, fmt.Errorf("%w: cannot read dictionary with %s indices as %s",
+			arrow.ErrNotImplemented, dict.Indices().DataType(), target)
+	}
+
+	values, err := compute.CastArray(ctx, dict.Dictionary(), compute.SafeCastOptions(target.ValueType))
+	if err != nil {
+		return nil, err
+	}
+	defer values.Release()
+
+	return array.NewDictionaryArray(target, dict.Indices(), values), nil
+}
+
+// binaryToView converts arr, a String or Binary array, into a view array
+// of the target type. Only the view headers are allocated: values that do
+// not fit inline reference the data buffer of arr, which is shared rather
+// than copied.
+func binaryToView(mem memory.Allocator, arr arrow.Array, target arrow.DataType) arrow.Array {
+	var (
+		offsets []int32
+		data    = arr.Data().Buffers()[2]
+	)
+
+	switch arr := arr.(type) {
+	case *array.String:
+		offsets = arr.ValueOffsets()
+	case *array.Binary:
+		offsets = arr.ValueOffsets()
+	}
+
+	offset, n := arr.Data().Offset(), arr.Len()
+	views := memory.NewResizableBuffer(mem)
+	defer views.Release()
+	views.Resize(arrow.ViewHeaderTraits.BytesRequired(offset + n))
+
+	headers := arrow.ViewHeaderTraits.CastFromBytes(views.Bytes())
+	clear(headers)
+	var dataBytes []byte
+	if data != nil {
+		dataBytes = data.Bytes()
+	}
+
+	for i := range n {
+		if arr.IsNull(i) {
+			continue
+		}
+
+		start, end := offsets[i], offsets[i+1]
+		h := &headers[offset+i]
+		h.SetBytes(dataBytes[start:end])
+		if !h.IsInline() {
+			h.SetIndexOffset(0, start)
+		}
+	}
+
+	buffers := []*memory.Buffer{arr.Data().Buffers()[0], views}
+	if data != nil {
+		buffers = append(buffers, data)
+	}
+
+	out := array.NewData(target, n, buffers, nil, arr.NullN(), offset)
+	defer out.Release()
+
+	return array.MakeFromData(out)
+}
+
+// selectRecordBatch applies sel, a selection such as take or filter, to
+// every column of rec. The compute selection kernels do not support
+// dictionary arrays, so their indices are selected instead and the
+// dictionary is reused as is.
+func selectRecordBatch(rec arrow.RecordBatch, sel func(compute.Datum) (compute.Datum, error)) (arrow.RecordBatch, error) {
+	hasDict := false
+	for _, col := range rec.Columns() {
+		if col.DataType().ID() == arrow.DICTIONARY {
+			hasDict = true
+
+			break
+		}
+	}
+
+	if !hasDict {
+		out, err := sel(compute.NewDatumWithoutOwning(rec))
+		if err != nil {
+			return nil, err
+		}
+
+		return out.(*compute.RecordDatum).Value, nil
+	}
+
+	cols := make([]arrow.Array, rec.NumCols())
+	defer func() {
+		for _, c := range cols {
+			if c != nil {
+				c.Release()
+			}
+		}
+	}()
+
+	for i, col := range rec.Columns() {
+		dict, isDict := col.(*array.Dictionary)
+		if isDict {
+			col = dict.Indices()
+		}
+
+		out, err := sel(compute.NewDatumWithoutOwning(col))
+		if err != nil {
+			return nil, err
+		}
+		cols[i] = out.(*compute.ArrayDatum).MakeArray()
+		out.Release()
+
+		if isDict {
+			indices := cols[i]
+			cols[i] = array.NewDictionaryArray(dict.DataType(), indices, dict.Dictionary())
+			indices.Release()
+		}
+	}
+
+	return array.NewRecordBatch(rec.Schema(), cols, int64(cols[0].Len())), nil
+}
+
+// decodeDictionaries returns rec with its dictionary columns cast to their
+// value types, or rec itself, retained, if it has none.
+func decodeDictionaries(ctx context.Context, rec arrow.RecordBatch) (arrow.RecordBatch, error) {
+	var (
+		fields []arrow.Field
+		cols   []arrow.Array
+	)
+
+	for i, col := range rec.Columns() {
+		dict, ok := col.(*array.Dictionary)
+		if !ok {
+			continue
+		}
+
+		if cols == nil {
+			fields, cols = rec.Schema().Fields(), slices.Clone(rec.Columns())
+			for _, c := range cols {
+				c.Retain()
+			}
+			defer func() {
+				for _, c := range cols {
+					c.Release()
+				}
+			}()
+		}
+
+		valueType := dict.Dictionary().DataType()
+		decoded, err := compute.CastArray(ctx, dict, compute.SafeCastOptions(valueType))
+		if err != nil {
+			return nil, err
+		}
+		cols[i].Release()
+		cols[i], fields[i].Type = decoded, valueType
+	}
+
+	if cols == nil {
+		rec.Retain()
+
+		return rec, nil
+	}
+
+	md := rec.Schema().Metadata()
+
+	return array.NewRecordBatch(arrow.NewSchema(fields, &md), cols, rec.NumRows()), nil
+}
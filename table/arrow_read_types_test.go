@@ -0,0 +1,256 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanDictionaryAndViewTypes(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "name", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 3, Name: "payload", Type: iceberg.PrimitiveTypes.Binary},
+		iceberg.NestedField{ID: 4, Name: "tags", Type: &iceberg.ListType{
+			ElementID: 5, Element: iceberg.PrimitiveTypes.String, ElementRequired: true,
+		}})
+
+	tbl := newTestTable(t, withTestSchema(sc))
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, false, false)
+	require.NoError(t, err)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{`[
+		{"id": 1, "name": "short", "payload": "AQI=", "tags": ["a"]},
+		{"id": 2, "name": "a value longer than twelve bytes", "payload": null, "tags": ["b", "a long tag value here"]},
+		{"id": 3, "name": "short", "payload": "AQI=", "tags": null},
+		{"id": 4, "name": null, "payload": "Aw==", "tags": []}
+	]`})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	tbl, err = tbl.AppendTable(ctx, arrTbl, 10, nil)
+	require.NoError(t, err)
+
+	tbl, err = tbl.Delete(ctx, iceberg.EqualTo(iceberg.Reference("id"), int64(3)), nil)
+	require.NoError(t, err)
+
+	txn := tbl.NewTransaction()
+	require.NoError(t, txn.UpdateSchema(true, false).
+		AddColumn([]string{"note"}, iceberg.PrimitiveTypes.String, "", false, nil).
+		Commit())
+	tbl, err = txn.Commit(ctx)
+	require.NoError(t, err)
+
+	expected := map[int64][]any{
+		1: {"short", []byte{1, 2}, []any{"a"}, nil},
+		2: {"a value longer than twelve bytes", nil, []any{"b", "a long tag value here"}, nil},
+		4: {nil, []byte{3}, []any{}, nil},
+	}
+
+	readRows := func(t *testing.T, scan *table.Scan) map[int64][]any {
+		rows := map[int64][]any{}
+		for rec, err := range scan.Rows(ctx) {
+			require.NoError(t, err)
+			id, _ := rec.Field("id")
+			rows[id.(int64)] = rec.Values()[1:]
+		}
+
+		return rows
+	}
+
+	t.Run("dictionary", func(t *testing.T) {
+		scan := tbl.Scan(table.WithOptions(iceberg.Properties{table.ScanOptionArrowReadDictionary: "true"}))
+
+		result, err := scan.ToArrowTable(ctx)
+		require.NoError(t, err)
+		defer result.Release()
+
+		stringDict := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.String}
+		assert.True(t, arrow.TypeEqual(stringDict, result.Schema().Field(1).Type))
+		assert.True(t, arrow.TypeEqual(&arrow.DictionaryType{
+			IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.Binary,
+		}, result.Schema().Field(2).Type))
+		assert.True(t, arrow.TypeEqual(stringDict, result.Schema().Field(4).Type))
+		// nested columns are not read as dictionaries
+		assert.Equal(t, arrow.STRING, result.Schema().Field(3).Type.(*arrow.ListType).Elem().ID())
+		assert.EqualValues(t, 3, result.NumRows())
+
+		assert.Equal(t, expected, readRows(t, scan))
+
+		filtered := readRows(t, tbl.Scan(
+			table.WithOptions(iceberg.Properties{table.ScanOptionArrowReadDictionary: "true"}),
+			table.WithRowFilter(iceberg.EqualTo(iceberg.Reference("name"), "short"))))
+		assert.Equal(t, map[int64][]any{1: expected[1]}, filtered)
+	})
+
+	t.Run("views", func(t *testing.T) {
+		scan := tbl.Scan(table.WithOptions(iceberg.Properties{table.ScanOptionArrowUseViewTypes: "true"}))
+
+		result, err := scan.ToArrowTable(ctx)
+		require.NoError(t, err)
+		defer result.Release()
+
+		assert.Equal(t, arrow.STRING_VIEW, result.Schema().Field(1).Type.ID())
+		assert.Equal(t, arrow.BINARY_VIEW, result.Schema().Field(2).Type.ID())
+		assert.Equal(t, arrow.STRING_VIEW, result.Schema().Field(3).Type.(*arrow.ListType).Elem().ID())
+		assert.Equal(t, arrow.STRING_VIEW, result.Schema().Field(4).Type.ID())
+
+		assert.Equal(t, expected, readRows(t, scan))
+	})
+}
+
+func TestScanTimestampTzRepresentation(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "ts", Type: iceberg.PrimitiveTypes.TimestampTz},
+		iceberg.NestedField{ID: 3, Name: "history", Type: &iceberg.ListType{
+			ElementID: 4, Element: iceberg.PrimitiveTypes.TimestampTz, ElementRequired: true,
+		}})
+
+	tbl := newTestTable(t, withTestSchema(sc))
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, false, false)
+	require.NoError(t, err)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{`[
+		{"id": 1, "ts": "2024-01-02T03:04:05Z", "history": ["2023-12-31T00:00:00Z"]},
+		{"id": 2, "ts": null, "history": []}
+	]`})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	tbl, err = tbl.AppendTable(ctx, arrTbl, 10, nil)
+	require.NoError(t, err)
+
+	const (
+		tsMicros      = int64(1704164645000000)
+		historyMicros = int64(1703980800000000)
+	)
+
+	readTable := func(t *testing.T, opts iceberg.Properties) arrow.Table {
+		result, err := tbl.Scan(table.WithOptions(opts)).ToArrowTable(ctx)
+		require.NoError(t, err)
+		t.Cleanup(result.Release)
+		require.EqualValues(t, 2, result.NumRows())
+
+		return result
+	}
+
+	t.Run("default utc", func(t *testing.T) {
+		result := readTable(t, nil)
+
+		assert.True(t, arrow.TypeEqual(arrow.FixedWidthTypes.Timestamp_us, result.Schema().Field(1).Type))
+		ts := result.Column(1).Data().Chunk(0).(*array.Timestamp)
+		assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			ts.Value(0).ToTime(arrow.Microsecond))
+	})
+
+	t.Run("time zone", func(t *testing.T) {
+		result := readTable(t, iceberg.Properties{table.ScanOptionArrowTimestampTz: "America/New_York"})
+
+		nyc := &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "America/New_York"}
+		assert.True(t, arrow.TypeEqual(nyc, result.Schema().Field(1).Type))
+		assert.True(t, arrow.TypeEqual(nyc, result.Schema().Field(2).Type.(*arrow.ListType).Elem()))
+
+		ts := result.Column(1).Data().Chunk(0).(*array.Timestamp)
+		assert.EqualValues(t, tsMicros, ts.Value(0))
+		assert.True(t, ts.IsNull(1))
+	})
+
+	t.Run("epoch", func(t *testing.T) {
+		result := readTable(t, iceberg.Properties{table.ScanOptionArrowTimestampTz: table.TimestampTzEpoch})
+
+		assert.True(t, arrow.TypeEqual(arrow.PrimitiveTypes.Int64, result.Schema().Field(1).Type))
+		assert.True(t, arrow.TypeEqual(arrow.PrimitiveTypes.Int64,
+			result.Schema().Field(2).Type.(*arrow.ListType).Elem()))
+
+		ts := result.Column(1).Data().Chunk(0).(*array.Int64)
+		assert.Equal(t, tsMicros, ts.Value(0))
+		history := result.Column(2).Data().Chunk(0).(*array.List).ListValues().(*array.Int64)
+		assert.Equal(t, []int64{historyMicros}, history.Int64Values())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := tbl.Scan(table.WithOptions(iceberg.Properties{
+			table.ScanOptionArrowTimestampTz: "Not/AZone",
+		})).ToArrowTable(ctx)
+		assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	})
+
+	t.Run("naive timestamps", func(t *testing.T) {
+		fileSchema := arrow.NewSchema([]arrow.Field{
+			{Name: "id", Type: arrow.PrimitiveTypes.Int64, Metadata: arrow.NewMetadata(
+				[]string{table.ArrowParquetFieldIDKey}, []string{"1"})},
+			{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Microsecond}, Nullable: true,
+				Metadata: arrow.NewMetadata([]string{table.ArrowParquetFieldIDKey}, []string{"2"})},
+		}, nil)
+		naive, err := array.TableFromJSON(memory.DefaultAllocator, fileSchema, []string{`[
+			{"id": 3, "ts": "2024-01-02T03:04:05"}
+		]`})
+		require.NoError(t, err)
+		defer naive.Release()
+
+		path := tbl.Location() + "/data/naive.parquet"
+		fw, err := iceio.LocalFS{}.Create(path)
+		require.NoError(t, err)
+		require.NoError(t, pqarrow.WriteTable(naive, fw, naive.NumRows(), nil, pqarrow.DefaultWriterProps()))
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+
+		bldr, err := iceberg.NewDataFileBuilder(*iceberg.UnpartitionedSpec, iceberg.EntryContentData,
+			path, iceberg.ParquetFile, nil, nil, nil, naive.NumRows(), info.Size())
+		require.NoError(t, err)
+
+		txn := tbl.NewTransaction()
+		require.NoError(t, txn.AddDataFiles(ctx, []iceberg.DataFile{bldr.Build()}, nil))
+		withNaive, err := txn.Commit(ctx)
+		require.NoError(t, err)
+
+		filter := table.WithRowFilter(iceberg.EqualTo(iceberg.Reference("id"), int64(3)))
+		_, err = withNaive.Scan(filter).ToArrowTable(ctx)
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+		assert.ErrorContains(t, err, table.ScanOptionNaiveTimestampsAsUTC)
+
+		result, err := withNaive.Scan(filter, table.WithOptions(iceberg.Properties{
+			table.ScanOptionNaiveTimestampsAsUTC: "true",
+		})).ToArrowTable(ctx)
+		require.NoError(t, err)
+		defer result.Release()
+
+		require.EqualValues(t, 1, result.NumRows())
+		assert.True(t, arrow.TypeEqual(arrow.FixedWidthTypes.Timestamp_us, result.Schema().Field(1).Type))
+		ts := result.Column(1).Data().Chunk(0).(*array.Timestamp)
+		assert.EqualValues(t, tsMicros, ts.Value(0))
+	})
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"io"
 	"iter"
+	"slices"
 	"strconv"
 	"sync"
 
@@ -40,6 +41,32 @@ import (
 
 const (
 	ScanOptionArrowUseLargeTypes = "arrow.use_large_types"
+	// ScanOptionArrowReadDictionary, when "true", returns top-level string
+	// and binary columns as dictionary arrays that keep the dictionary
+	// encoding of the parquet files rather than materializing every value,
+	// which saves memory and CPU for columns with repeated values.
+	ScanOptionArrowReadDictionary = "arrow.read_dictionary"
+	// ScanOptionArrowUseViewTypes, when "true", returns string and binary
+	// columns as StringView and BinaryView arrays that reference the
+	// decoded data instead of copying it. Top-level columns read as
+	// dictionaries are not affected.
+	ScanOptionArrowUseViewTypes = "arrow.use_view_types"
+	// ScanOptionArrowTimestampTz selects how timestamptz columns are
+	// returned. "UTC", the default, returns arrow timestamps in UTC, whose
+	// values convert to UTC time.Time values. The name of another time
+	// zone, such as "America/New_York", returns the same instants as
+	// timestamps in that zone. TimestampTzEpoch returns int64 microseconds
+	// (nanoseconds for timestamptz_ns) since the unix epoch.
+	ScanOptionArrowTimestampTz = "arrow.timestamptz"
+	// ScanOptionNaiveTimestampsAsUTC, when "true", reads data files that
+	// store a timestamptz column as a timestamp without time zone, as
+	// some engines write them, by assuming the values are in UTC. By
+	// default reading such a file fails the scan.
+	ScanOptionNaiveTimestampsAsUTC = "arrow.naive_timestamps_as_utc"
+
+	// TimestampTzEpoch is the ScanOptionArrowTimestampTz value that
+	// returns timestamptz columns as epoch offsets.
+	TimestampTzEpoch = "epoch"
 )
 
 type (
@@ -60,8 +87,8 @@ func readAllDeleteFiles(ctx context.Context, fs iceio.IO, tasks []FileScanTask,
 				continue
 			}
 
-			if _, ok := uniqueDeletes[d.FilePath()]; !ok {
-				uniqueDeletes[d.FilePath()] = d
+			if key := contentFileKey(d); uniqueDeletes[key] == nil {
+				uniqueDeletes[key] = d
 			}
 		}
 	}
@@ -100,6 +127,10 @@ func readAllDeleteFiles(ctx context.Context, fs iceio.IO, tasks []FileScanTask,
 }
 
 func readDeletes(ctx context.Context, fs iceio.IO, dataFile iceberg.DataFile) (_ map[string]*arrow.Chunked, err error) {
+	if dataFile.FileFormat() == iceberg.PuffinFile {
+		return readDeletionVector(ctx, fs, dataFile)
+	}
+
 	src, err := internal.GetFile(ctx, fs, dataFile, true)
 	if err != nil {
 		return nil, err
@@ -179,13 +210,10 @@ func processPositionalDeletes(ctx context.Context, deletes set[int64]) recProces
 		indices := combinePositionalDeletes(mem, deletes, currentIdx, nextIdx)
 		defer indices.Release()
 
-		out, err := compute.Take(ctx, *compute.DefaultTakeOptions(),
-			compute.NewDatumWithoutOwning(r), compute.NewDatumWithoutOwning(indices))
-		if err != nil {
-			return nil, err
-		}
-
-		return out.(*compute.RecordDatum).Value, nil
+		return selectRecordBatch(r, func(d compute.Datum) (compute.Datum, error) {
+			return compute.Take(ctx, *compute.DefaultTakeOptions(),
+				d, compute.NewDatumWithoutOwning(indices))
+		})
 	}
 }
 
@@ -193,41 +221,59 @@ func filterRecords(ctx context.Context, recordFilter expr.Expression) recProcess
 	return func(rec arrow.RecordBatch) (arrow.RecordBatch, error) {
 		defer rec.Release()
 
-		input := compute.NewDatumWithoutOwning(rec)
-		mask, err := exprs.ExecuteScalarExpression(ctx, rec.Schema(), recordFilter, input)
+		// the expression is evaluated on the decoded values of any
+		// dictionary columns, which it cannot reference directly
+		decoded, err := decodeDictionaries(ctx, rec)
 		if err != nil {
 			return nil, err
 		}
-		defer mask.Release()
+		defer decoded.Release()
 
-		result, err := compute.Filter(ctx, input, mask, *compute.DefaultFilterOptions())
+		input := compute.NewDatumWithoutOwning(decoded)
+		mask, err := exprs.ExecuteScalarExpression(ctx, decoded.Schema(), recordFilter, input)
 		if err != nil {
 			return nil, err
 		}
+		defer mask.Release()
 
-		return result.(*compute.RecordDatum).Value, nil
+		return selectRecordBatch(rec, func(d compute.Datum) (compute.Datum, error) {
+			return compute.Filter(ctx, d, mask, *compute.DefaultFilterOptions())
+		})
 	}
 }
 
 type arrowScan struct {
 	fs              iceio.IO
-	metadata        Metadata
+	tableProps      iceberg.Properties
 	projectedSchema *iceberg.Schema
 	boundRowFilter  iceberg.BooleanExpression
 	caseSensitive   bool
 	rowLimit        int64
+	rowOffset       int64
 	options         iceberg.Properties
 
 	useLargeTypes bool
+	readTypes     readTypes
 	concurrency   int
 
 	nameMapping iceberg.NameMapping
+	// schemas are the table schemas that the tasks' data files may have
+	// been written with, see FileScanTask.SchemaID.
+	schemas []*iceberg.Schema
 }
 
 func (as *arrowScan) projectedFieldIDs() (set[int], error) {
 	idset := set[int]{}
-	for _, id := range as.projectedSchema.FieldIDs() {
-		typ, _ := as.projectedSchema.FindTypeByID(id)
+	dataSchema, metaCols := splitMetadataColumns(as.projectedSchema)
+	// row lineage columns are read from files which store them
+	for _, col := range metaCols {
+		if isRowLineageColumn(col.ID) {
+			idset[col.ID] = struct{}{}
+		}
+	}
+
+	for _, id := range dataSchema.FieldIDs() {
+		typ, _ := dataSchema.FindTypeByID(id)
 		switch typ.(type) {
 		case *iceberg.MapType, *iceberg.ListType:
 		default:
@@ -255,13 +301,42 @@ type enumeratedRecord struct {
 	Err    error
 }
 
-func (as *arrowScan) prepareToRead(ctx context.Context, file iceberg.DataFile) (*iceberg.Schema, []int, internal.FileReader, error) {
+// fileNameMapping returns the name mapping used to resolve the columns of
+// the file. The table's name mapping always takes precedence, but a file
+// without field IDs in a table without one is resolved by name against
+// the schema its manifest was written with.
+func (as *arrowScan) fileNameMapping(rdr internal.FileReader, task FileScanTask) (iceberg.NameMapping, error) {
+	if as.nameMapping != nil || task.SchemaID == nil {
+		return as.nameMapping, nil
+	}
+
+	sc, err := rdr.Schema()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range sc.Fields() {
+		if _, ok := f.Metadata.GetValue(ArrowParquetFieldIDKey); ok {
+			return nil, nil
+		}
+	}
+
+	for _, schema := range as.schemas {
+		if schema.ID == *task.SchemaID {
+			return schema.NameMapping(), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (as *arrowScan) prepareToRead(ctx context.Context, task FileScanTask) (*iceberg.Schema, []int, internal.FileReader, error) {
 	ids, err := as.projectedFieldIDs()
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	src, err := internal.GetFile(ctx, as.fs, file, false)
+	src, err := internal.GetFile(ctx, as.fs, task.File, false)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -271,14 +346,21 @@ func (as *arrowScan) prepareToRead(ctx context.Context, file iceberg.DataFile) (
 		return nil, nil, nil, err
 	}
 
-	fileSchema, colIndices, err := rdr.PrunedSchema(ids, as.nameMapping)
+	mapping, err := as.fileNameMapping(rdr, task)
 	if err != nil {
 		rdr.Close()
 
 		return nil, nil, nil, err
 	}
 
-	iceSchema, err := ArrowSchemaToIceberg(fileSchema, false, as.nameMapping)
+	fileSchema, colIndices, err := rdr.PrunedSchema(ids, mapping, as.caseSensitive)
+	if err != nil {
+		rdr.Close()
+
+		return nil, nil, nil, err
+	}
+
+	iceSchema, err := arrowSchemaToIceberg(fileSchema, false, mapping, as.caseSensitive)
 	if err != nil {
 		rdr.Close()
 
@@ -329,6 +411,7 @@ func (as *arrowScan) processRecords(
 	columns []int,
 	pipeline []recProcessFn,
 	out chan<- enumeratedRecord,
+	skipRowGroups bool,
 ) (err error) {
 	var (
 		testRowGroups any
@@ -337,6 +420,10 @@ func (as *arrowScan) processRecords(
 
 	switch task.Value.File.FileFormat() {
 	case iceberg.ParquetFile:
+		if !skipRowGroups {
+			break
+		}
+
 		testRowGroups, err = newParquetRowGroupStatsEvaluator(fileSchema, as.boundRowFilter, false)
 		if err != nil {
 			return err
@@ -351,14 +438,41 @@ func (as *arrowScan) processRecords(
 
 	var (
 		idx  int
+		rows int64
 		prev arrow.RecordBatch
 	)
 
-	for recRdr.Next() {
+	// no file needs to produce more rows than the scan returns, so stop
+	// reading once the offset and limit are covered by this file alone
+	maxRows := int64(-1)
+	if as.rowLimit > 0 {
+		maxRows = as.rowOffset + as.rowLimit
+	}
+
+	// with a memory budget, wait for records that were already sent
+	// to be consumed and released before reading the next one
+	budget := budgetFromContext(ctx)
+	pending := func() bool { return len(out) > 0 }
+
+	for maxRows < 0 || rows < maxRows {
+		if err := budget.wait(ctx, pending); err != nil {
+			if prev != nil {
+				prev.Release()
+			}
+
+			return err
+		}
+
+		if !recRdr.Next() {
+			break
+		}
+
 		if prev != nil {
-			out <- enumeratedRecord{Record: internal.Enumerated[arrow.RecordBatch]{
+			if err := sendRecord(ctx, out, enumeratedRecord{Record: internal.Enumerated[arrow.RecordBatch]{
 				Value: prev, Index: idx, Last: false,
-			}, Task: task}
+			}, Task: task}); err != nil {
+				return err
+			}
 			idx++
 		}
 
@@ -371,12 +485,15 @@ func (as *arrowScan) processRecords(
 				return err
 			}
 		}
+		rows += prev.NumRows()
 	}
 
 	if prev != nil {
-		out <- enumeratedRecord{Record: internal.Enumerated[arrow.RecordBatch]{
+		if err := sendRecord(ctx, out, enumeratedRecord{Record: internal.Enumerated[arrow.RecordBatch]{
 			Value: prev, Index: idx, Last: true,
-		}, Task: task}
+		}, Task: task}); err != nil {
+			return err
+		}
 	}
 
 	if recRdr.Err() != nil && recRdr.Err() != io.EOF {
@@ -386,6 +503,19 @@ func (as *arrowScan) processRecords(
 	return err
 }
 
+// sendRecord sends rec on out unless ctx is cancelled first, in which
+// case the record is released and the cancellation cause returned.
+func sendRecord(ctx context.Context, out chan<- enumeratedRecord, rec enumeratedRecord) error {
+	select {
+	case out <- rec:
+		return nil
+	case <-ctx.Done():
+		rec.Record.Value.Release()
+
+		return context.Cause(ctx)
+	}
+}
+
 func (as *arrowScan) recordsFromTask(ctx context.Context, task internal.Enumerated[FileScanTask], out chan<- enumeratedRecord, positionalDeletes positionDeletes) (err error) {
 	defer func() {
 		if err != nil {
@@ -401,15 +531,28 @@ func (as *arrowScan) recordsFromTask(ctx context.Context, task internal.Enumerat
 		dropFile   bool
 	)
 
-	iceSchema, colIndices, rdr, err = as.prepareToRead(ctx, task.Value.File)
+	iceSchema, colIndices, rdr, err = as.prepareToRead(ctx, task.Value)
 	if err != nil {
 		return err
 	}
 	defer iceinternal.CheckedClose(rdr, &err)
 
-	pipeline := make([]recProcessFn, 0, 2)
+	dataSchema, metaCols := splitMetadataColumns(as.projectedSchema)
+	markDeleted := slices.ContainsFunc(metaCols, func(f iceberg.NestedField) bool {
+		return f.ID == IsDeletedColumn.ID
+	})
+	withPositions := markDeleted || slices.ContainsFunc(metaCols, func(f iceberg.NestedField) bool {
+		return f.ID == RowPositionColumn.ID || f.ID == RowIDColumn.ID
+	})
+
+	pipeline := make([]recProcessFn, 0, 4)
+	if withPositions {
+		pipeline = append(pipeline, appendRowPositions(ctx))
+	}
+
+	var deletes set[int64]
 	if len(positionalDeletes) > 0 {
-		deletes := set[int64]{}
+		deletes = set[int64]{}
 		for _, chunk := range positionalDeletes {
 			for _, a := range chunk.Chunks() {
 				for _, v := range a.(*array.Int64).Int64Values() {
@@ -418,7 +561,10 @@ func (as *arrowScan) recordsFromTask(ctx context.Context, task internal.Enumerat
 			}
 		}
 
-		pipeline = append(pipeline, processPositionalDeletes(ctx, deletes))
+		// rows are only marked as deleted when _deleted is projected
+		if !markDeleted {
+			pipeline = append(pipeline, processPositionalDeletes(ctx, deletes))
+		}
 	}
 
 	filterFunc, dropFile, err = as.getRecordFilter(ctx, iceSchema)
@@ -428,7 +574,7 @@ func (as *arrowScan) recordsFromTask(ctx context.Context, task internal.Enumerat
 
 	if dropFile {
 		var emptySchema *arrow.Schema
-		emptySchema, err = SchemaToArrowSchema(as.projectedSchema, nil, false, as.useLargeTypes)
+		emptySchema, err = as.arrowSchema()
 		if err != nil {
 			return err
 		}
@@ -446,15 +592,38 @@ func (as *arrowScan) recordsFromTask(ctx context.Context, task internal.Enumerat
 	pipeline = append(pipeline, func(r arrow.RecordBatch) (arrow.RecordBatch, error) {
 		defer r.Release()
 
-		return ToRequestedSchema(ctx, as.projectedSchema, iceSchema, r, false, false, as.useLargeTypes)
+		var positions *array.Int64
+		if withPositions {
+			last := int(r.NumCols()) - 1
+			positions = r.Column(last).(*array.Int64)
+			data := array.NewRecordBatch(arrow.NewSchema(r.Schema().Fields()[:last], nil),
+				r.Columns()[:last], r.NumRows())
+			defer data.Release()
+			r = data
+		}
+
+		projected, err := toRequestedSchema(ctx, dataSchema, iceSchema, r, &arrowProjectionVisitor{
+			useLargeTypes: as.useLargeTypes,
+			readTypes:     as.readTypes,
+		})
+		if err != nil || len(metaCols) == 0 {
+			return projected, err
+		}
+		defer projected.Release()
+
+		return as.appendMetadataColumns(ctx, projected, metaCols, task.Value, positions, deletes,
+			storedRowLineage(iceSchema, r))
 	})
 
-	err = as.processRecords(ctx, task, iceSchema, rdr, colIndices, pipeline, out)
+	// positions are counted from the first row of the file, so no row
+	// groups can be skipped when they are needed
+	err = as.processRecords(ctx, task, iceSchema, rdr, colIndices, pipeline, out,
+		!withPositions && len(positionalDeletes) == 0)
 
 	return err
 }
 
-func createIterator(ctx context.Context, numWorkers uint, records <-chan enumeratedRecord, deletesPerFile perFilePosDeletes, cancel context.CancelCauseFunc, rowLimit int64) iter.Seq2[arrow.RecordBatch, error] {
+func createIterator(ctx context.Context, numWorkers uint, records <-chan enumeratedRecord, deletesPerFile perFilePosDeletes, cancel context.CancelCauseFunc, rowLimit, rowOffset int64) iter.Seq2[arrow.RecordBatch, error] {
 	isBeforeAny := func(batch enumeratedRecord) bool {
 		return batch.Task.Index < 0
 	}
@@ -526,6 +695,19 @@ func createIterator(ctx context.Context, numWorkers uint, records <-chan enumera
 				}
 
 				rec := enum.Record.Value
+				if rowOffset > 0 {
+					skip := min(rowOffset, rec.NumRows())
+					rowOffset -= skip
+					if skip == rec.NumRows() {
+						rec.Release()
+
+						continue
+					}
+
+					defer rec.Release()
+					rec = rec.NewSlice(skip, rec.NumRows())
+				}
+
 				if rowLimit > 0 {
 					if totalRowCount >= rowLimit {
 						rec.Release()
@@ -556,7 +738,7 @@ func createIterator(ctx context.Context, numWorkers uint, records <-chan enumera
 
 func (as *arrowScan) recordBatchesFromTasksAndDeletes(ctx context.Context, tasks []FileScanTask, deletesPerFile perFilePosDeletes) iter.Seq2[arrow.RecordBatch, error] {
 	extSet := substrait.NewExtensionSet()
-	as.nameMapping = as.metadata.NameMapping()
+	as.nameMapping = nameMappingFromProperties(as.tableProps)
 
 	ctx, cancel := context.WithCancelCause(exprs.WithExtensionIDSet(ctx, extSet))
 	taskChan := make(chan internal.Enumerated[FileScanTask], len(tasks))
@@ -603,17 +785,96 @@ func (as *arrowScan) recordBatchesFromTasksAndDeletes(ctx context.Context, tasks
 	}()
 
 	return createIterator(ctx, uint(numWorkers), records, deletesPerFile,
-		cancel, as.rowLimit)
+		cancel, as.rowLimit, as.rowOffset)
 }
 
-func (as *arrowScan) GetRecords(ctx context.Context, tasks []FileScanTask) (*arrow.Schema, iter.Seq2[arrow.RecordBatch, error], error) {
+// appendMetadataColumns returns rec, projected from a record read from
+// the task's file, with the values of the metadata columns appended.
+// storedRowLineage returns the row lineage columns of a record read from a
+// file which stores them, keyed by field ID.
+func storedRowLineage(fileSchema *iceberg.Schema, rec arrow.RecordBatch) map[int]arrow.Array {
+	var stored map[int]arrow.Array
+	for _, id := range []int{RowIDColumn.ID, LastUpdatedSequenceNumberColumn.ID} {
+		f, ok := fileSchema.FindFieldByID(id)
+		if !ok {
+			continue
+		}
+
+		if idx := rec.Schema().FieldIndices(f.Name); len(idx) == 1 {
+			if stored == nil {
+				stored = make(map[int]arrow.Array)
+			}
+			stored[id] = rec.Column(idx[0])
+		}
+	}
+
+	return stored
+}
+
+func (as *arrowScan) appendMetadataColumns(ctx context.Context, rec arrow.RecordBatch, metaCols []iceberg.NestedField,
+	task FileScanTask, positions *array.Int64, deletes set[int64], stored map[int]arrow.Array,
+) (arrow.RecordBatch, error) {
+	resultSchema, err := as.arrowSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := rec.Schema().Fields()
+	cols := slices.Clone(rec.Columns())
+	defer func() {
+		for _, c := range cols[rec.NumCols():] {
+			c.Release()
+		}
+	}()
+
+	for i, col := range metaCols {
+		base, err := TypeToArrowType(col.Type, false, as.useLargeTypes)
+		if err != nil {
+			return nil, err
+		}
+
+		field := resultSchema.Field(int(rec.NumCols()) + i)
+		arr, err := metadataColumnArray(ctx, as.readTypes, col, base, field.Type,
+			int(rec.NumRows()), task, positions, deletes, stored[col.ID])
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, field)
+		cols = append(cols, arr)
+	}
+
+	return array.NewRecordBatch(arrow.NewSchema(fields, nil), cols, rec.NumRows()), nil
+}
+
+// arrowSchema returns the arrow schema of the records returned by the scan.
+func (as *arrowScan) arrowSchema() (*arrow.Schema, error) {
+	sc, err := SchemaToArrowSchema(as.projectedSchema, nil, false, as.useLargeTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	return as.readTypes.arrowSchema(sc), nil
+}
+
+// resultSchema applies the scan options that change the Arrow types of
+// the records and returns the schema of the records.
+func (as *arrowScan) resultSchema() (*arrow.Schema, error) {
 	var err error
 	as.useLargeTypes, err = strconv.ParseBool(as.options.Get(ScanOptionArrowUseLargeTypes, "false"))
 	if err != nil {
 		as.useLargeTypes = false
 	}
 
-	resultSchema, err := SchemaToArrowSchema(as.projectedSchema, nil, false, as.useLargeTypes)
+	if as.readTypes, err = scanReadTypes(as.options); err != nil {
+		return nil, err
+	}
+
+	return as.arrowSchema()
+}
+
+func (as *arrowScan) GetRecords(ctx context.Context, tasks []FileScanTask) (*arrow.Schema, iter.Seq2[arrow.RecordBatch, error], error) {
+	resultSchema, err := as.resultSchema()
 	if err != nil {
 		return nil, nil, err
 	}
@@ -627,5 +888,9 @@ func (as *arrowScan) GetRecords(ctx context.Context, tasks []FileScanTask) (*arr
 		return nil, nil, err
 	}
 
+	if as.readTypes.dictionary {
+		ctx = internal.WithDictionaryReads(ctx)
+	}
+
 	return resultSchema, as.recordBatchesFromTasksAndDeletes(ctx, tasks, deletesPerFile), nil
 }
@@ -19,6 +19,8 @@ package table
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"iter"
 	"slices"
@@ -47,6 +49,12 @@ const (
 	// this key to identify the field id of the source Parquet field.
 	// We use this when converting to Iceberg to provide field IDs
 	ArrowParquetFieldIDKey = "PARQUET:field_id"
+	// ArrowFieldNameKey holds the original name of a field whose name
+	// was sanitized when the data file was written.
+	ArrowFieldNameKey = iceberg.FieldNameProp
+	// ArrowFieldMetadataKey holds the key/value metadata of the Iceberg
+	// field encoded as a JSON object.
+	ArrowFieldMetadataKey = iceberg.FieldMetadataProp
 
 	defaultBinPackLookback = 20
 )
@@ -255,10 +263,19 @@ func (c convertToIceberg) Field(field arrow.Field, result iceberg.NestedField) i
 		if doc, ok := field.Metadata.GetValue(ArrowFieldDocKey); ok {
 			result.Doc = doc
 		}
+		if md, ok := field.Metadata.GetValue(ArrowFieldMetadataKey); ok {
+			if err := json.Unmarshal([]byte(md), &result.Metadata); err != nil {
+				panic(fmt.Errorf("%w: invalid %s metadata of field %s: %w",
+					iceberg.ErrInvalidSchema, ArrowFieldMetadataKey, field.Name, err))
+			}
+		}
 	}
 
 	result.Required = !field.Nullable
 	result.Name = field.Name
+	if name, ok := field.Metadata.GetValue(ArrowFieldNameKey); ok && name != "" {
+		result.Name = name
+	}
 
 	return result
 }
@@ -388,6 +405,10 @@ func ArrowTypeToIceberg(dt arrow.DataType, downcastNsTimestamp bool) (iceberg.Ty
 }
 
 func ArrowSchemaToIceberg(sc *arrow.Schema, downcastNsTimestamp bool, nameMapping iceberg.NameMapping) (*iceberg.Schema, error) {
+	return arrowSchemaToIceberg(sc, downcastNsTimestamp, nameMapping, true)
+}
+
+func arrowSchemaToIceberg(sc *arrow.Schema, downcastNsTimestamp bool, nameMapping iceberg.NameMapping, caseSensitive bool) (*iceberg.Schema, error) {
 	hasIDs, _ := VisitArrowSchema(sc, hasIDs{})
 
 	switch {
@@ -414,6 +435,10 @@ func ArrowSchemaToIceberg(sc *arrow.Schema, downcastNsTimestamp bool, nameMappin
 			return nil, err
 		}
 
+		if !caseSensitive {
+			return iceberg.ApplyNameMappingCaseInsensitive(schemaWithoutIDs, nameMapping)
+		}
+
 		return iceberg.ApplyNameMapping(schemaWithoutIDs, nameMapping)
 	default:
 		return nil, fmt.Errorf("%w: arrow schema does not have field-ids and no name mapping provided",
@@ -517,6 +542,10 @@ func (c convertToArrow) Field(field iceberg.NestedField, result arrow.Field) arr
 		meta[ArrowParquetFieldIDKey] = strconv.Itoa(field.ID)
 	}
 
+	if len(field.Metadata) > 0 {
+		meta[ArrowFieldMetadataKey] = encodeFieldMetadata(field.Metadata)
+	}
+
 	if len(meta) > 0 {
 		result.Metadata = arrow.MetadataFrom(meta)
 	}
@@ -674,9 +703,18 @@ func (a arrowAccessor) FieldPartner(partnerStruct arrow.Array, fieldID int, _ st
 	}
 
 	if st, ok := partnerStruct.(*array.Struct); ok {
-		if idx, ok := st.DataType().(*arrow.StructType).FieldIdx(field.Name); ok {
+		stType := st.DataType().(*arrow.StructType)
+		if idx, ok := stType.FieldIdx(field.Name); ok {
 			return st.Field(idx)
 		}
+
+		// columns of data files whose names were sanitized when written
+		// keep their original name in the field metadata
+		for idx, f := range stType.Fields() {
+			if name, ok := f.Metadata.GetValue(ArrowFieldNameKey); ok && name == field.Name {
+				return st.Field(idx)
+			}
+		}
 	}
 
 	panic(fmt.Errorf("cannot find %s in expected partner_struct type %s",
@@ -707,6 +745,75 @@ func (a arrowAccessor) MapValuePartner(partnerMap arrow.Array) arrow.Array {
 	return nil
 }
 
+// ArrowSchemaAccessor is an iceberg.PartnerAccessor for visiting an
+// Iceberg schema together with an Arrow schema with
+// iceberg.VisitSchemaWithPartner. The partner of the schema is the Arrow
+// schema as a struct type, arrow.StructOf(sc.Fields()...). Struct fields
+// are matched by the field ID in their ArrowParquetFieldIDKey metadata, or
+// by name if they have none. Nodes without a counterpart in the Arrow
+// schema have a nil partner.
+type ArrowSchemaAccessor struct{}
+
+func (ArrowSchemaAccessor) SchemaPartner(partner arrow.DataType) arrow.DataType {
+	return partner
+}
+
+func (ArrowSchemaAccessor) FieldPartner(partnerStruct arrow.DataType, fieldID int, fieldName string) arrow.DataType {
+	st, ok := partnerStruct.(*arrow.StructType)
+	if !ok {
+		return nil
+	}
+
+	var byName arrow.DataType
+	for _, f := range st.Fields() {
+		id := getFieldID(f)
+		switch {
+		case id != nil && *id == fieldID:
+			return f.Type
+		case id == nil && f.Name == fieldName:
+			byName = f.Type
+		}
+	}
+
+	return byName
+}
+
+func (ArrowSchemaAccessor) ListElementPartner(partnerList arrow.DataType) arrow.DataType {
+	if _, ok := partnerList.(*arrow.MapType); ok {
+		return nil
+	}
+	if l, ok := partnerList.(arrow.ListLikeType); ok {
+		return l.Elem()
+	}
+
+	return nil
+}
+
+func (ArrowSchemaAccessor) MapKeyPartner(partnerMap arrow.DataType) arrow.DataType {
+	if m, ok := partnerMap.(*arrow.MapType); ok {
+		return m.KeyType()
+	}
+
+	return nil
+}
+
+func (ArrowSchemaAccessor) MapValuePartner(partnerMap arrow.DataType) arrow.DataType {
+	if m, ok := partnerMap.(*arrow.MapType); ok {
+		return m.ItemType()
+	}
+
+	return nil
+}
+
+// encodeFieldMetadata returns the metadata of an Iceberg field as the
+// JSON object stored in the ArrowFieldMetadataKey metadata.
+func encodeFieldMetadata(md map[string]string) string {
+	// a map of strings always marshals
+	b, _ := json.Marshal(md)
+
+	return string(b)
+}
+
 func retOrPanic[T any](v T, err error) T {
 	if err != nil {
 		panic(err)
@@ -721,9 +828,130 @@ type arrowProjectionVisitor struct {
 	includeFieldIDs     bool
 	downcastNsTimestamp bool
 	useLargeTypes       bool
+	readTypes           readTypes
+	// fieldNames are the original names of fields whose names were
+	// sanitized, recorded in the ArrowFieldNameKey metadata.
+	fieldNames map[int]string
+
+	// root is the struct array of the record being projected, whose
+	// fields are the top-level columns.
+	root arrow.Array
+}
+
+// defaultValueArray returns an array of n copies of the field's
+// initial-default value converted to the arrow type dt.
+func defaultValueArray(mem memory.Allocator, field iceberg.NestedField, dt arrow.DataType, n int) (arrow.Array, error) {
+	lit, err := iceberg.LiteralFromDefault(field.Type, field.InitialDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	arr, err := literalArray(mem, lit, dt, n)
+	if errors.Is(err, iceberg.ErrNotImplemented) {
+		return nil, fmt.Errorf("%w: initial-default values for %s fields",
+			iceberg.ErrNotImplemented, field.Type)
+	}
+
+	return arr, err
+}
+
+// literalArray returns an array of n copies of lit converted to the
+// arrow type dt, or of nulls if lit is nil.
+func literalArray(mem memory.Allocator, lit iceberg.Literal, dt arrow.DataType, n int) (arrow.Array, error) {
+	if lit == nil {
+		return array.MakeArrayOfNull(mem, dt, n), nil
+	}
+
+	bldr := array.NewBuilder(mem, dt)
+	defer bldr.Release()
+
+	var appendValue func()
+	switch b := bldr.(type) {
+	case *array.BooleanBuilder:
+		v := lit.Any().(bool)
+		appendValue = func() { b.Append(v) }
+	case *array.Int32Builder:
+		v := lit.Any().(int32)
+		appendValue = func() { b.Append(v) }
+	case *array.Int64Builder:
+		v := lit.Any().(int64)
+		appendValue = func() { b.Append(v) }
+	case *array.Float32Builder:
+		v := lit.Any().(float32)
+		appendValue = func() { b.Append(v) }
+	case *array.Float64Builder:
+		v := lit.Any().(float64)
+		appendValue = func() { b.Append(v) }
+	case *array.Date32Builder:
+		v := arrow.Date32(lit.Any().(iceberg.Date))
+		appendValue = func() { b.Append(v) }
+	case *array.Time64Builder:
+		v := arrow.Time64(lit.Any().(iceberg.Time))
+		appendValue = func() { b.Append(v) }
+	case *array.TimestampBuilder:
+		var v arrow.Timestamp
+		switch ts := lit.Any().(type) {
+		case iceberg.Timestamp:
+			v = arrow.Timestamp(ts)
+		case iceberg.TimestampNano:
+			v = arrow.Timestamp(ts)
+		}
+		appendValue = func() { b.Append(v) }
+	case *array.StringBuilder:
+		v := lit.Any().(string)
+		appendValue = func() { b.Append(v) }
+	case *array.LargeStringBuilder:
+		v := lit.Any().(string)
+		appendValue = func() { b.Append(v) }
+	case *array.BinaryBuilder:
+		v := lit.Any().([]byte)
+		appendValue = func() { b.Append(v) }
+	case *array.FixedSizeBinaryBuilder:
+		v := lit.Any().([]byte)
+		appendValue = func() { b.Append(v) }
+	case *array.Decimal128Builder:
+		v := lit.Any().(iceberg.Decimal).Val
+		appendValue = func() { b.Append(v) }
+	case *extensions.UUIDBuilder:
+		v := lit.Any().(uuid.UUID)
+		appendValue = func() { b.Append(v) }
+	default:
+		return nil, fmt.Errorf("%w: constant %s arrays", iceberg.ErrNotImplemented, dt)
+	}
+
+	bldr.Reserve(n)
+	for range n {
+		appendValue()
+	}
+
+	return bldr.NewArray(), nil
 }
 
-func (a *arrowProjectionVisitor) castIfNeeded(field iceberg.NestedField, vals arrow.Array) arrow.Array {
+func (a *arrowProjectionVisitor) castIfNeeded(field iceberg.NestedField, vals arrow.Array, topLevel bool) arrow.Array {
+	_, isPrimitive := field.Type.(iceberg.PrimitiveType)
+	_, isDict := vals.(*array.Dictionary)
+	if !isPrimitive || (!isDict && !a.readTypes.dictionary && !a.readTypes.nested()) {
+		return a.castPrimitive(field, vals)
+	}
+
+	target := a.readTypes.arrowType(retOrPanic(TypeToArrowType(field.Type, a.includeFieldIDs, a.useLargeTypes)), topLevel)
+	if dict, ok := vals.(*array.Dictionary); ok {
+		if target, ok := target.(*arrow.DictionaryType); ok {
+			return retOrPanic(convertDictionaryValues(a.ctx, dict, target))
+		}
+
+		vals = retOrPanic(compute.CastArray(a.ctx, dict,
+			compute.SafeCastOptions(dict.Dictionary().DataType())))
+		defer vals.Release()
+	}
+
+	out := a.castPrimitive(field, vals)
+	defer out.Release()
+
+	return retOrPanic(a.readTypes.convert(a.ctx, out, target))
+}
+
+func (a *arrowProjectionVisitor) castPrimitive(field iceberg.NestedField, vals arrow.Array) arrow.Array {
 	fileField, ok := a.fileSchema.FindFieldByID(field.ID)
 	if !ok {
 		panic(fmt.Errorf("could not find field id %d in schema", field.ID))
@@ -736,6 +964,21 @@ func (a *arrowProjectionVisitor) castIfNeeded(field iceberg.NestedField, vals ar
 		return vals
 	}
 
+	if isNaiveTimestampOf(typ, field.Type) {
+		if !a.readTypes.naiveTimestampsAsUTC {
+			panic(fmt.Errorf("%w: column %s is a %s but was written as a %s without time zone, set the %s scan option to read it as UTC",
+				iceberg.ErrInvalidSchema, field.Name, field.Type, typ, ScanOptionNaiveTimestampsAsUTC))
+		}
+
+		vt := vals.DataType().(*arrow.TimestampType)
+		data := array.NewData(&arrow.TimestampType{Unit: vt.Unit, TimeZone: "UTC"}, vals.Len(),
+			vals.Data().Buffers(), nil, vals.NullN(), vals.Data().Offset())
+		defer data.Release()
+		vals = array.MakeFromData(data)
+		defer vals.Release()
+		typ = field.Type.(iceberg.PrimitiveType)
+	}
+
 	if !field.Type.Equals(typ) {
 		promoted := retOrPanic(iceberg.PromoteType(fileField.Type, field.Type))
 		targetType := retOrPanic(TypeToArrowType(promoted, a.includeFieldIDs, a.useLargeTypes))
@@ -789,6 +1032,23 @@ func (a *arrowProjectionVisitor) castIfNeeded(field iceberg.NestedField, vals ar
 	return vals
 }
 
+// isNaiveTimestampOf reports whether fileType is the timestamp without
+// time zone that a data file stores a readType timestamptz column as.
+func isNaiveTimestampOf(fileType, readType iceberg.Type) bool {
+	switch readType.(type) {
+	case iceberg.TimestampTzType:
+		_, ok := fileType.(iceberg.TimestampType)
+
+		return ok
+	case iceberg.TimestampTzNsType:
+		_, ok := fileType.(iceberg.TimestampNsType)
+
+		return ok
+	}
+
+	return false
+}
+
 func (a *arrowProjectionVisitor) constructField(field iceberg.NestedField, arrowType arrow.DataType) arrow.Field {
 	metadata := map[string]string{}
 	if field.Doc != "" {
@@ -799,6 +1059,14 @@ func (a *arrowProjectionVisitor) constructField(field iceberg.NestedField, arrow
 		metadata[ArrowParquetFieldIDKey] = strconv.Itoa(field.ID)
 	}
 
+	if name, ok := a.fieldNames[field.ID]; ok {
+		metadata[ArrowFieldNameKey] = name
+	}
+
+	if len(field.Metadata) > 0 {
+		metadata[ArrowFieldMetadataKey] = encodeFieldMetadata(field.Metadata)
+	}
+
 	return arrow.Field{
 		Name:     field.Name,
 		Type:     arrowType,
@@ -816,6 +1084,7 @@ func (a *arrowProjectionVisitor) Struct(st iceberg.StructType, structArr arrow.A
 		return nil
 	}
 
+	topLevel := structArr == a.root
 	fieldArrs := make([]arrow.Array, len(st.FieldList))
 	fields := make([]arrow.Field, len(st.FieldList))
 	for i, field := range st.FieldList {
@@ -825,7 +1094,18 @@ func (a *arrowProjectionVisitor) Struct(st iceberg.StructType, structArr arrow.A
 				defer arr.Release()
 			}
 
-			arr = a.castIfNeeded(field, arr)
+			arr = a.castIfNeeded(field, arr, topLevel)
+			defer arr.Release()
+			fieldArrs[i] = arr
+			fields[i] = a.constructField(field, arr.DataType())
+		} else if field.InitialDefault != nil {
+			// the file was written before the field was added, so every
+			// row reads the field's initial-default value
+			dt := retOrPanic(TypeToArrowType(field.Type, false, a.useLargeTypes))
+
+			arr = retOrPanic(defaultValueArray(compute.GetAllocator(a.ctx), field, dt, structArr.Len()))
+			defer arr.Release()
+			arr = retOrPanic(a.readTypes.convert(a.ctx, arr, a.readTypes.arrowType(dt, topLevel)))
 			defer arr.Release()
 			fieldArrs[i] = arr
 			fields[i] = a.constructField(field, arr.DataType())
@@ -834,6 +1114,8 @@ func (a *arrowProjectionVisitor) Struct(st iceberg.StructType, structArr arrow.A
 
 			arr = array.MakeArrayOfNull(compute.GetAllocator(a.ctx), dt, structArr.Len())
 			defer arr.Release()
+			arr = retOrPanic(a.readTypes.convert(a.ctx, arr, a.readTypes.arrowType(dt, topLevel)))
+			defer arr.Release()
 			fieldArrs[i] = arr
 			fields[i] = a.constructField(field, arr.DataType())
 		} else {
@@ -874,7 +1156,7 @@ func (a *arrowProjectionVisitor) List(listType iceberg.ListType, listArr arrow.A
 		return nil
 	}
 
-	valArr = a.castIfNeeded(listType.ElementField(), valArr)
+	valArr = a.castIfNeeded(listType.ElementField(), valArr, false)
 	defer valArr.Release()
 
 	var outType arrow.ListLikeType
@@ -905,9 +1187,9 @@ func (a *arrowProjectionVisitor) Map(m iceberg.MapType, mapArray, keyResult, val
 		return nil
 	}
 
-	keys := a.castIfNeeded(m.KeyField(), keyResult)
+	keys := a.castIfNeeded(m.KeyField(), keyResult, false)
 	defer keys.Release()
-	vals := a.castIfNeeded(m.ValueField(), valResult)
+	vals := a.castIfNeeded(m.ValueField(), valResult, false)
 	defer vals.Release()
 
 	keyField := a.constructField(m.KeyField(), keys.DataType())
@@ -931,17 +1213,22 @@ func (a *arrowProjectionVisitor) Primitive(_ iceberg.PrimitiveType, arr arrow.Ar
 // ToRequestedSchema will construct a new record batch matching the requested iceberg schema
 // casting columns if necessary as appropriate.
 func ToRequestedSchema(ctx context.Context, requested, fileSchema *iceberg.Schema, batch arrow.RecordBatch, downcastTimestamp, includeFieldIDs, useLargeTypes bool) (arrow.RecordBatch, error) {
+	return toRequestedSchema(ctx, requested, fileSchema, batch, &arrowProjectionVisitor{
+		includeFieldIDs:     includeFieldIDs,
+		downcastNsTimestamp: downcastTimestamp,
+		useLargeTypes:       useLargeTypes,
+	})
+}
+
+// toRequestedSchema is ToRequestedSchema using the options of visitor,
+// which allows scans to also choose how string and binary columns are read.
+func toRequestedSchema(ctx context.Context, requested, fileSchema *iceberg.Schema, batch arrow.RecordBatch, visitor *arrowProjectionVisitor) (arrow.RecordBatch, error) {
 	st := array.RecordToStructArray(batch)
 	defer st.Release()
 
+	visitor.ctx, visitor.fileSchema, visitor.root = ctx, fileSchema, st
 	result, err := iceberg.VisitSchemaWithPartner[arrow.Array, arrow.Array](requested, st,
-		&arrowProjectionVisitor{
-			ctx:                 ctx,
-			fileSchema:          fileSchema,
-			includeFieldIDs:     includeFieldIDs,
-			downcastNsTimestamp: downcastTimestamp,
-			useLargeTypes:       useLargeTypes,
-		}, arrowAccessor{fileSchema: fileSchema})
+		visitor, arrowAccessor{fileSchema: fileSchema})
 	if err != nil {
 		return nil, err
 	}
@@ -1258,8 +1545,8 @@ func filesToDataFiles(ctx context.Context, fileIO iceio.IO, meta *MetadataBuilde
 				}
 			}
 
-			df := statistics.ToDataFile(currentSchema, currentSpec, filePath, iceberg.ParquetFile, rdr.SourceFileSize(), partitionValues)
-			if !yield(df, nil) {
+			df, err := statistics.ToDataFile(currentSchema, currentSpec, filePath, iceberg.ParquetFile, rdr.SourceFileSize(), partitionValues)
+			if !yield(df, err) || err != nil {
 				return
 			}
 		}
@@ -1289,12 +1576,62 @@ func binPackRecords(itr iter.Seq2[arrow.RecordBatch, error], recordLookback int,
 	}, targetFileSize, recordLookback, recordNBytes, false)
 }
 
+// budgetedRecords checks the memory budget of ctx, if there is one,
+// before pulling each record from itr, so that writes stop buffering
+// input while the files being written hold too much memory.
+func budgetedRecords(ctx context.Context, itr iter.Seq2[arrow.RecordBatch, error]) iter.Seq2[arrow.RecordBatch, error] {
+	budget := budgetFromContext(ctx)
+	if budget == nil {
+		return itr
+	}
+
+	pending := func() bool { return budget.active.Load() > 0 }
+
+	return func(yield func(arrow.RecordBatch, error) bool) {
+		next, stop := iter.Pull2(itr)
+		defer stop()
+
+		for {
+			if err := budget.wait(ctx, pending); err != nil {
+				yield(nil, err)
+
+				return
+			}
+
+			rec, err, ok := next()
+			if !ok || !yield(rec, err) {
+				return
+			}
+		}
+	}
+}
+
+// failIfCanceled yields the files of itr, followed by the error of ctx if
+// it was canceled, since writes stop early without an error of their own
+// when they are canceled.
+func failIfCanceled(ctx context.Context, itr iter.Seq2[iceberg.DataFile, error]) iter.Seq2[iceberg.DataFile, error] {
+	return func(yield func(iceberg.DataFile, error) bool) {
+		for df, err := range itr {
+			if !yield(df, err) || err != nil {
+				return
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
 type recordWritingArgs struct {
 	sc        *arrow.Schema
 	itr       iter.Seq2[arrow.RecordBatch, error]
 	fs        iceio.WriteFileIO
 	writeUUID *uuid.UUID
 	counter   iter.Seq[int]
+	// props are the table properties the files are written with, the
+	// properties of the table metadata if nil.
+	props iceberg.Properties
 }
 
 func recordsToDataFiles(ctx context.Context, rootLocation string, meta *MetadataBuilder, args recordWritingArgs) (ret iter.Seq2[iceberg.DataFile, error]) {
@@ -1322,8 +1659,15 @@ func recordsToDataFiles(ctx context.Context, rootLocation string, meta *Metadata
 		args.writeUUID = &u
 	}
 
-	targetFileSize := int64(meta.props.GetInt(WriteTargetFileSizeBytesKey,
+	if args.props == nil {
+		args.props = meta.props
+	}
+
+	targetFileSize := int64(args.props.GetInt(WriteTargetFileSizeBytesKey,
 		WriteTargetFileSizeBytesDefault))
+	if targetFileSize <= 0 {
+		targetFileSize = WriteTargetFileSizeBytesDefault
+	}
 
 	nameMapping := meta.CurrentSchema().NameMapping()
 	taskSchema, err := ArrowSchemaToIceberg(args.sc, false, nameMapping)
@@ -1335,13 +1679,42 @@ func recordsToDataFiles(ctx context.Context, rootLocation string, meta *Metadata
 		panic(fmt.Errorf("%w: cannot write files without a current spec", err))
 	}
 
+	mode, err := writeDistributionMode(args.props, *currentSpec)
+	if err != nil {
+		panic(err)
+	}
+	if mode == DistributionRange {
+		sortOrder := UnsortedSortOrder
+		if order, err := meta.GetSortOrderByID(meta.defaultSortOrderID); err == nil {
+			sortOrder = *order
+		}
+		args.itr = rangeDistribute(ctx, args.itr, meta.CurrentSchema(),
+			rangeSortKeys(*currentSpec, sortOrder))
+	}
+
+	args.itr = budgetedRecords(ctx, args.itr)
+
 	nextCount, stopCount := iter.Pull(args.counter)
 	if currentSpec.IsUnpartitioned() {
+		partition, err := iceberg.NewPartitionData(*currentSpec, meta.CurrentSchema(), nil)
+		if err != nil {
+			panic(err)
+		}
+
 		tasks := func(yield func(WriteTask) bool) {
 			defer stopCount()
 
 			fileCount := 0
 			for batch := range binPackRecords(args.itr, defaultBinPackLookback, targetFileSize) {
+				// files are not started once the write was canceled
+				if ctx.Err() != nil {
+					for _, rec := range batch {
+						rec.Release()
+					}
+
+					return
+				}
+
 				cnt, _ := nextCount()
 				fileCount++
 				t := WriteTask{
@@ -1358,12 +1731,18 @@ func recordsToDataFiles(ctx context.Context, rootLocation string, meta *Metadata
 			}
 		}
 
-		return writeFiles(ctx, rootLocation, args.fs, meta, nil, tasks)
+		return failIfCanceled(ctx, writeFiles(ctx, rootLocation, args.fs, meta, args.props, "", partition, tasks))
 	} else {
 		partitionWriter := newPartitionedFanoutWriter(*currentSpec, meta.CurrentSchema(), args.itr)
 		rollingDataWriters := NewWriterFactory(rootLocation, args, meta, taskSchema, targetFileSize)
 		partitionWriter.writers = &rollingDataWriters
+		partitionWriter.writerPerWorker = mode == DistributionNone
 		workers := config.EnvConfig.MaxWorkers
+		if mode == DistributionRange {
+			// a single worker keeps the sorted rows of each partition in
+			// order
+			workers = 1
+		}
 
 		return partitionWriter.Write(ctx, workers)
 	}
@@ -20,11 +20,15 @@ package table
 import (
 	"bytes"
 	"cmp"
+	"context"
 	"math"
 	"slices"
+	"strings"
 	"testing"
 
+	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/compute"
 	"github.com/apache/arrow-go/v18/arrow/memory"
 	"github.com/apache/arrow-go/v18/parquet"
 	"github.com/apache/arrow-go/v18/parquet/file"
@@ -199,8 +203,11 @@ func (suite *FileStatsMetricsSuite) getDataFile(meta iceberg.Properties, writeSt
 
 	stats := format.DataFileStatsFromMeta(fileMeta, collector, mapping)
 
-	return stats.ToDataFile(tableMeta.CurrentSchema(), tableMeta.PartitionSpec(), "fake-path.parquet",
+	df, err := stats.ToDataFile(tableMeta.CurrentSchema(), tableMeta.PartitionSpec(), "fake-path.parquet",
 		iceberg.ParquetFile, fileMeta.GetSourceFileSize(), nil)
+	suite.Require().NoError(err)
+
+	return df
 }
 
 func (suite *FileStatsMetricsSuite) TestRecordCount() {
@@ -446,3 +453,55 @@ func TestStatsTypes(t *testing.T) {
 		iceberg.PrimitiveTypes.Int32,
 	}, actual)
 }
+
+func TestReadTypeConversions(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+	ctx := compute.WithAllocator(context.Background(), mem)
+
+	strs, _, err := array.FromJSON(mem, arrow.BinaryTypes.String,
+		strings.NewReader(`["skipped", "tiny", null, "more than twelve bytes", "x"]`))
+	require.NoError(t, err)
+	defer strs.Release()
+
+	sliced := array.NewSlice(strs, 1, 5)
+	defer sliced.Release()
+
+	views := binaryToView(mem, sliced, arrow.BinaryTypes.StringView)
+	defer views.Release()
+	assert.Equal(t, `["tiny" (null) "more than twelve bytes" "x"]`, views.String())
+
+	dictType := &arrow.DictionaryType{IndexType: arrow.PrimitiveTypes.Int32, ValueType: arrow.BinaryTypes.String}
+	dict, err := readTypes{dictionary: true}.convert(ctx, sliced, dictType)
+	require.NoError(t, err)
+	defer dict.Release()
+
+	ids, _, err := array.FromJSON(mem, arrow.PrimitiveTypes.Int64, strings.NewReader(`[1, 2, 3, 4]`))
+	require.NoError(t, err)
+	defer ids.Release()
+
+	rec := array.NewRecordBatch(arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: dictType, Nullable: true},
+	}, nil), []arrow.Array{ids, dict}, 4)
+	defer rec.Release()
+
+	indices, _, err := array.FromJSON(mem, arrow.PrimitiveTypes.Int64, strings.NewReader(`[3, 0]`))
+	require.NoError(t, err)
+	defer indices.Release()
+
+	taken, err := selectRecordBatch(rec, func(d compute.Datum) (compute.Datum, error) {
+		return compute.Take(ctx, *compute.DefaultTakeOptions(), d, compute.NewDatumWithoutOwning(indices))
+	})
+	require.NoError(t, err)
+	defer taken.Release()
+
+	assert.True(t, arrow.TypeEqual(dictType, taken.Column(1).DataType()))
+	assert.Equal(t, `[4 1]`, taken.Column(0).String())
+
+	decoded, err := decodeDictionaries(ctx, taken)
+	require.NoError(t, err)
+	defer decoded.Release()
+	assert.Equal(t, arrow.STRING, decoded.Schema().Field(1).Type.ID())
+	assert.Equal(t, `["x" "tiny"]`, decoded.Column(1).String())
+}
@@ -20,6 +20,7 @@ package table_test
 import (
 	"bufio"
 	"context"
+	"math/rand/v2"
 	"strings"
 	"testing"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/apache/arrow-go/v18/arrow/extensions"
 	"github.com/apache/arrow-go/v18/arrow/memory"
 	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/icebergtest"
 	"github.com/apache/iceberg-go/table"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -358,6 +360,36 @@ func TestArrowSchemaRoundTripConversion(t *testing.T) {
 	}
 }
 
+func TestArrowSchemaFieldMetadata(t *testing.T) {
+	md := map[string]string{"classification": "pii", "owner": "identity-team"}
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "email", Type: iceberg.PrimitiveTypes.String, Metadata: md},
+		iceberg.NestedField{ID: 2, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+	)
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, true, false)
+	require.NoError(t, err)
+
+	encoded, ok := arrSchema.Field(0).Metadata.GetValue(table.ArrowFieldMetadataKey)
+	require.True(t, ok)
+	assert.JSONEq(t, `{"classification": "pii", "owner": "identity-team"}`, encoded)
+	_, ok = arrSchema.Field(1).Metadata.GetValue(table.ArrowFieldMetadataKey)
+	assert.False(t, ok)
+
+	ice, err := table.ArrowSchemaToIceberg(arrSchema, false, nil)
+	require.NoError(t, err)
+	assert.True(t, sc.Equals(ice), sc.String(), ice.String())
+
+	invalid := arrow.NewSchema([]arrow.Field{{
+		Name: "email", Type: arrow.BinaryTypes.String, Nullable: true,
+		Metadata: arrow.NewMetadata(
+			[]string{table.ArrowParquetFieldIDKey, table.ArrowFieldMetadataKey},
+			[]string{"1", "not json"}),
+	}}, nil)
+	_, err = table.ArrowSchemaToIceberg(invalid, false, nil)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+}
+
 func TestArrowSchemaWithNameMapping(t *testing.T) {
 	schemaWithoutIDs := arrow.NewSchema([]arrow.Field{
 		{Name: "foo", Type: arrow.BinaryTypes.String, Nullable: true},
@@ -585,3 +617,110 @@ func TestToRequestedSchema(t *testing.T) {
 
 	assert.True(t, array.RecordEqual(rec, rec2))
 }
+
+// FuzzArrowSchemaRoundTrip generates random schemas and checks that
+// converting them to Arrow with field IDs and back preserves them. Run it
+// with
+//
+//	go test -run '^$' -fuzz FuzzArrowSchemaRoundTrip ./table
+func FuzzArrowSchemaRoundTrip(f *testing.F) {
+	for seed := range uint64(32) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, seed uint64) {
+		sc := icebergtest.NewSchemaGenerator(rand.New(rand.NewPCG(seed, 0))).Schema()
+
+		for _, largeTypes := range []bool{false, true} {
+			arrSchema, err := table.SchemaToArrowSchema(sc, nil, true, largeTypes)
+			require.NoError(t, err)
+
+			out, err := table.ArrowSchemaToIceberg(arrSchema, false, nil)
+			require.NoError(t, err)
+			assert.True(t, sc.Equals(out), "large types %t:\n%s\n%s", largeTypes, sc, out)
+		}
+	})
+}
+
+// arrowPartnerTypes records the Arrow type of the partner of every
+// primitive field of a schema, by field name.
+type arrowPartnerTypes struct {
+	names []string
+	out   []string
+}
+
+func (v *arrowPartnerTypes) BeforeField(f iceberg.NestedField, _ arrow.DataType) {
+	v.names = append(v.names, f.Name)
+}
+
+func (v *arrowPartnerTypes) AfterField(iceberg.NestedField, arrow.DataType) {
+	v.names = v.names[:len(v.names)-1]
+}
+
+func (v *arrowPartnerTypes) Schema(*iceberg.Schema, arrow.DataType, []string) []string {
+	return v.out
+}
+
+func (v *arrowPartnerTypes) Struct(iceberg.StructType, arrow.DataType, [][]string) []string {
+	return nil
+}
+
+func (v *arrowPartnerTypes) Field(iceberg.NestedField, arrow.DataType, []string) []string {
+	return nil
+}
+
+func (v *arrowPartnerTypes) List(iceberg.ListType, arrow.DataType, []string) []string { return nil }
+
+func (v *arrowPartnerTypes) Map(iceberg.MapType, arrow.DataType, []string, []string) []string {
+	return nil
+}
+
+func (v *arrowPartnerTypes) Primitive(_ iceberg.PrimitiveType, partner arrow.DataType) []string {
+	name := "missing"
+	if partner != nil {
+		name = partner.String()
+	}
+	v.out = append(v.out, strings.Join(v.names, ".")+"="+name)
+
+	return nil
+}
+
+func TestArrowSchemaAccessor(t *testing.T) {
+	arrSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64, Metadata: fieldIDMeta("1")},
+		{Name: "old_name", Type: arrow.BinaryTypes.String, Metadata: fieldIDMeta("2")},
+		{Name: "no_id", Type: arrow.PrimitiveTypes.Float32},
+		{Name: "tags", Type: arrow.ListOfField(arrow.Field{
+			Name: "element", Type: arrow.PrimitiveTypes.Int32, Metadata: fieldIDMeta("5"),
+		}), Metadata: fieldIDMeta("4")},
+		{Name: "props", Type: arrow.MapOf(arrow.BinaryTypes.String, arrow.PrimitiveTypes.Float64),
+			Metadata: fieldIDMeta("6")},
+	}, nil)
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "name", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 3, Name: "no_id", Type: iceberg.PrimitiveTypes.Float32},
+		iceberg.NestedField{ID: 4, Name: "tags", Type: &iceberg.ListType{
+			ElementID: 5, Element: iceberg.PrimitiveTypes.Int32,
+		}},
+		iceberg.NestedField{ID: 6, Name: "props", Type: &iceberg.MapType{
+			KeyID: 7, KeyType: iceberg.PrimitiveTypes.String,
+			ValueID: 8, ValueType: iceberg.PrimitiveTypes.Float64,
+		}},
+		iceberg.NestedField{ID: 9, Name: "added", Type: iceberg.PrimitiveTypes.Bool},
+	)
+
+	out, err := iceberg.VisitSchemaWithPartner[[]string, arrow.DataType](sc,
+		arrow.StructOf(arrSchema.Fields()...), &arrowPartnerTypes{}, table.ArrowSchemaAccessor{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"id=int64",
+		"name=utf8",
+		"no_id=float32",
+		"tags=int32",
+		"props=utf8",
+		"props=float64",
+		"added=missing",
+	}, out)
+}
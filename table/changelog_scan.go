@@ -0,0 +1,454 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/compute"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/internal"
+	iceio "github.com/apache/iceberg-go/io"
+)
+
+// ChangeType is the kind of change a row of a changelog scan records.
+type ChangeType string
+
+const (
+	ChangeInsert ChangeType = "INSERT"
+	ChangeDelete ChangeType = "DELETE"
+)
+
+// ChangelogScanTask is a file whose rows were inserted or deleted by a
+// snapshot in the range of a changelog scan.
+type ChangelogScanTask struct {
+	FileScanTask
+	ChangeType ChangeType
+	// ChangeOrdinal is the position of the committing snapshot among the
+	// snapshots of the changelog, starting at 0 for the oldest.
+	ChangeOrdinal    int
+	CommitSnapshotID int64
+}
+
+// ChangelogScan reads the rows inserted and deleted by the snapshots
+// committed in a range of a table's history, like Spark's
+// create_changelog_view. Each row is returned along with the
+// _change_type, _change_ordinal and _commit_snapshot_id metadata
+// columns.
+//
+// Replace snapshots, such as compactions, do not change the table's
+// data and are skipped. Snapshots that add delete files are not yet
+// supported.
+type ChangelogScan struct {
+	scan           *Scan
+	fromSnapshotID *int64
+}
+
+// ChangelogScan returns a scan of the changes committed up to and
+// including the snapshot selected by opts, which is the current snapshot
+// unless WithSnapshotID or WithSnapshotAsOf is given. The row filter,
+// selected fields and read options apply as they do to Scan; limits and
+// offsets are ignored.
+func (t Table) ChangelogScan(opts ...ScanOption) *ChangelogScan {
+	return &ChangelogScan{scan: t.Scan(opts...)}
+}
+
+// FromSnapshot returns a copy of the scan that only reads the changes
+// committed after the snapshot with the given ID, which must be an
+// ancestor of the last snapshot of the scan.
+func (cs *ChangelogScan) FromSnapshot(snapshotID int64) *ChangelogScan {
+	out := *cs
+	out.fromSnapshotID = &snapshotID
+
+	return &out
+}
+
+// Schema returns the Arrow schema of the records returned by the scan:
+// the projected columns followed by the changelog metadata columns.
+func (cs *ChangelogScan) Schema() (*arrow.Schema, error) {
+	as, err := cs.arrowScan(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return cs.arrowSchema(as)
+}
+
+func (cs *ChangelogScan) arrowSchema(as *arrowScan) (*arrow.Schema, error) {
+	sc, err := as.arrowSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := SchemaToArrowSchema(iceberg.NewSchema(0,
+		ChangeTypeColumn, ChangeOrdinalColumn, CommitSnapshotIDColumn), nil, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	md := sc.Metadata()
+
+	return arrow.NewSchema(slices.Concat(sc.Fields(), meta.Fields()), &md), nil
+}
+
+// snapshots returns the snapshots whose changes the scan reads, oldest
+// first.
+func (cs *ChangelogScan) snapshots(fs iceio.IO) ([]*Snapshot, error) {
+	meta := cs.scan.metadata
+	last := cs.scan.Snapshot()
+	if last == nil {
+		if cs.fromSnapshotID != nil {
+			return nil, fmt.Errorf("%w: table has no snapshot to read changes after snapshot %d",
+				iceberg.ErrInvalidArgument, *cs.fromSnapshotID)
+		}
+
+		return nil, nil
+	}
+
+	if cs.fromSnapshotID != nil && !IsAncestorOf(meta, last.SnapshotID, *cs.fromSnapshotID) {
+		return nil, fmt.Errorf("%w: snapshot %d is not an ancestor of snapshot %d",
+			iceberg.ErrInvalidArgument, *cs.fromSnapshotID, last.SnapshotID)
+	}
+
+	var snapshots []*Snapshot
+	for snap := range AncestorsBetween(meta, last.SnapshotID, cs.fromSnapshotID) {
+		if snap.Summary != nil && snap.Summary.Operation == OpReplace {
+			continue
+		}
+
+		manifests, err := snap.Manifests(fs)
+		if err != nil {
+			return nil, err
+		}
+
+		if slices.ContainsFunc(manifests, func(m iceberg.ManifestFile) bool {
+			return m.ManifestContent() == iceberg.ManifestContentDeletes
+		}) {
+			return nil, fmt.Errorf("%w: changelog scans do not yet support delete files (snapshot %d)",
+				iceberg.ErrNotImplemented, snap.SnapshotID)
+		}
+
+		snapshots = append(snapshots, snap)
+	}
+	slices.Reverse(snapshots)
+
+	return snapshots, nil
+}
+
+// PlanFiles returns the files added and removed by the snapshots of the
+// changelog that may contain rows matching the row filter, ordered by
+// change ordinal with each snapshot's deletes before its inserts.
+func (cs *ChangelogScan) PlanFiles(ctx context.Context) ([]ChangelogScanTask, error) {
+	fs, err := cs.scan.ioF(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots, err := cs.snapshots(fs)
+	if err != nil || len(snapshots) == 0 {
+		return nil, err
+	}
+
+	schema, err := cs.scan.schema()
+	if err != nil {
+		return nil, err
+	}
+
+	residual, err := cs.scan.boundRowFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	metricsEval, err := newInclusiveMetricsEvaluator(schema, cs.scan.rowFilter,
+		cs.scan.caseSensitive, cs.scan.options["include_empty_files"] == "true")
+	if err != nil {
+		return nil, err
+	}
+
+	manifestEvaluators := newKeyDefaultMapWrapErr(cs.scan.buildManifestEvaluator)
+	partitionEvaluators := newKeyDefaultMapWrapErr(cs.scan.buildPartitionEvaluator)
+
+	var tasks []ChangelogScanTask
+	for ordinal, snap := range snapshots {
+		manifests, err := snap.Manifests(fs)
+		if err != nil {
+			return nil, err
+		}
+
+		var changed []ChangelogScanTask
+		for _, m := range manifests {
+			if m.SnapshotID() != snap.SnapshotID {
+				continue
+			}
+
+			if use, err := manifestEvaluators.Get(int(m.PartitionSpecID()))(m); err != nil || !use {
+				if err != nil {
+					return nil, err
+				}
+
+				continue
+			}
+
+			entries, schemaID, err := readChangedEntries(fs, m, snap.SnapshotID)
+			if err != nil {
+				return nil, err
+			}
+
+			partEval := partitionEvaluators.Get(int(m.PartitionSpecID()))
+			for _, e := range entries {
+				df := e.DataFile()
+				if ok, err := partEval(df); err != nil || !ok {
+					if err != nil {
+						return nil, err
+					}
+
+					continue
+				}
+				if ok, err := metricsEval(df); err != nil || !ok {
+					if err != nil {
+						return nil, err
+					}
+
+					continue
+				}
+
+				changeType := ChangeInsert
+				if e.Status() == iceberg.EntryStatusDELETED {
+					changeType = ChangeDelete
+				}
+
+				seqNum := e.SequenceNum()
+				changed = append(changed, ChangelogScanTask{
+					FileScanTask: FileScanTask{
+						File:               df,
+						Residual:           residual,
+						SchemaID:           schemaID,
+						DataSequenceNumber: &seqNum,
+						Length:             df.FileSizeBytes(),
+					},
+					ChangeType:       changeType,
+					ChangeOrdinal:    ordinal,
+					CommitSnapshotID: snap.SnapshotID,
+				})
+			}
+		}
+
+		slices.SortFunc(changed, func(a, b ChangelogScanTask) int {
+			// DELETE sorts before INSERT
+			return cmp.Or(cmp.Compare(a.ChangeType, b.ChangeType),
+				cmp.Compare(a.File.FilePath(), b.File.FilePath()))
+		})
+		tasks = append(tasks, changed...)
+	}
+
+	return tasks, nil
+}
+
+// readChangedEntries returns the data file entries of the manifest that
+// were added or deleted by the snapshot with the given ID, along with the
+// ID of the schema the manifest was written with.
+func readChangedEntries(fs iceio.IO, m iceberg.ManifestFile, snapshotID int64) (_ []iceberg.ManifestEntry, _ *int, err error) {
+	f, err := fs.Open(m.FilePath())
+	if err != nil {
+		return nil, nil, err
+	}
+	defer internal.CheckedClose(f, &err)
+
+	rdr, err := iceberg.NewManifestReader(m, f)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var schemaID *int
+	if id, err := rdr.SchemaID(); err == nil {
+		schemaID = &id
+	}
+
+	var out []iceberg.ManifestEntry
+	for e, err := range rdr.Entries() {
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if e.Status() == iceberg.EntryStatusEXISTING || e.SnapshotID() != snapshotID ||
+			e.DataFile().ContentType() != iceberg.EntryContentData {
+			continue
+		}
+		out = append(out, e)
+	}
+
+	return out, schemaID, nil
+}
+
+func (cs *ChangelogScan) arrowScan(fs iceio.IO) (*arrowScan, error) {
+	boundFilter, err := cs.scan.boundRowFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := cs.scan.Projection()
+	if err != nil {
+		return nil, err
+	}
+
+	as := &arrowScan{
+		tableProps:      cs.scan.metadata.Properties(),
+		fs:              fs,
+		projectedSchema: schema,
+		boundRowFilter:  boundFilter,
+		caseSensitive:   cs.scan.caseSensitive,
+		rowLimit:        ScanNoLimit,
+		options:         cs.scan.options,
+		concurrency:     cs.scan.concurrency,
+		schemas:         cs.scan.metadata.Schemas(),
+	}
+	as.useLargeTypes, _ = strconv.ParseBool(as.options.Get(ScanOptionArrowUseLargeTypes, "false"))
+	if as.readTypes, err = scanReadTypes(as.options); err != nil {
+		return nil, err
+	}
+
+	return as, nil
+}
+
+// ToArrowRecords returns the Arrow schema of the changelog and an
+// iterator over its records, in the order of the planned tasks. Errors
+// during planning are returned directly, errors while reading are
+// returned by the iterator.
+func (cs *ChangelogScan) ToArrowRecords(ctx context.Context) (*arrow.Schema, iter.Seq2[arrow.RecordBatch, error], error) {
+	ctx = cs.scan.allocatorContext(ctx)
+
+	tasks, err := cs.PlanFiles(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fs, err := cs.scan.ioF(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	as, err := cs.arrowScan(fs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schema, err := cs.arrowSchema(as)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return schema, func(yield func(arrow.RecordBatch, error) bool) {
+		mem := compute.GetAllocator(ctx)
+		for len(tasks) > 0 {
+			// read the tasks of each change together
+			first := tasks[0]
+			n := 1
+			for n < len(tasks) && tasks[n].ChangeOrdinal == first.ChangeOrdinal &&
+				tasks[n].ChangeType == first.ChangeType {
+				n++
+			}
+
+			group := make([]FileScanTask, n)
+			for i, t := range tasks[:n] {
+				group[i] = t.FileScanTask
+			}
+			tasks = tasks[n:]
+
+			_, itr, err := as.GetRecords(ctx, group)
+			if err != nil {
+				yield(nil, err)
+
+				return
+			}
+
+			for rec, err := range itr {
+				if err != nil {
+					yield(nil, err)
+
+					return
+				}
+
+				out := appendChangeColumns(mem, schema, rec, first)
+				rec.Release()
+				if !yield(out, nil) {
+					return
+				}
+			}
+		}
+	}, nil
+}
+
+// appendChangeColumns returns rec with the changelog metadata columns of
+// the task appended.
+func appendChangeColumns(mem memory.Allocator, schema *arrow.Schema, rec arrow.RecordBatch, task ChangelogScanTask) arrow.RecordBatch {
+	n := int(rec.NumRows())
+
+	changeType := array.NewStringBuilder(mem)
+	defer changeType.Release()
+	changeType.ReserveData(n * len(task.ChangeType))
+	ordinal := array.NewInt32Builder(mem)
+	defer ordinal.Release()
+	snapshotID := array.NewInt64Builder(mem)
+	defer snapshotID.Release()
+
+	changeType.Reserve(n)
+	ordinal.Reserve(n)
+	snapshotID.Reserve(n)
+	for range n {
+		changeType.Append(string(task.ChangeType))
+		ordinal.UnsafeAppend(int32(task.ChangeOrdinal))
+		snapshotID.UnsafeAppend(task.CommitSnapshotID)
+	}
+
+	cols := []arrow.Array{changeType.NewArray(), ordinal.NewArray(), snapshotID.NewArray()}
+	defer func() {
+		for _, c := range cols {
+			c.Release()
+		}
+	}()
+
+	return array.NewRecordBatch(schema, slices.Concat(rec.Columns(), cols), rec.NumRows())
+}
+
+// ToArrowTable reads the whole changelog into an Arrow table.
+func (cs *ChangelogScan) ToArrowTable(ctx context.Context) (arrow.Table, error) {
+	schema, itr, err := cs.ToArrowRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]arrow.RecordBatch, 0)
+	for rec, err := range itr {
+		if err != nil {
+			return nil, err
+		}
+
+		defer rec.Release()
+		records = append(records, rec)
+	}
+
+	return array.NewTableFromRecords(schema, records), nil
+}
@@ -0,0 +1,148 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangelogScan(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "name", Type: iceberg.PrimitiveTypes.String})
+
+	tbl := newTestTable(t, withTestSchema(sc))
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, false, false)
+	require.NoError(t, err)
+	appendRows := func(rows string) {
+		arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{rows})
+		require.NoError(t, err)
+		defer arrTbl.Release()
+
+		tbl, err = tbl.AppendTable(ctx, arrTbl, 10, nil)
+		require.NoError(t, err)
+	}
+
+	appendRows(`[{"id": 1, "name": "a"}, {"id": 2, "name": "b"}, {"id": 3, "name": "c"}]`)
+	first := tbl.CurrentSnapshot().SnapshotID
+	appendRows(`[{"id": 4, "name": "d"}, {"id": 5, "name": "e"}]`)
+	second := tbl.CurrentSnapshot().SnapshotID
+	tbl, err = tbl.Delete(ctx, iceberg.EqualTo(iceberg.Reference("id"), int64(1)), nil)
+	require.NoError(t, err)
+	third := tbl.CurrentSnapshot().SnapshotID
+
+	// changes renders the rows of the changelog as sorted
+	// "ordinal/snapshot/type/id" strings.
+	changes := func(t *testing.T, cs *table.ChangelogScan) []string {
+		result, err := cs.ToArrowTable(ctx)
+		require.NoError(t, err)
+		defer result.Release()
+
+		sc := result.Schema()
+		n := len(sc.Fields())
+		require.GreaterOrEqual(t, n, 4)
+		assert.Equal(t, []string{"_change_type", "_change_ordinal", "_commit_snapshot_id"},
+			[]string{sc.Field(n - 3).Name, sc.Field(n - 2).Name, sc.Field(n - 1).Name})
+
+		var out []string
+		rdr := array.NewTableReader(result, -1)
+		defer rdr.Release()
+		for rdr.Next() {
+			rec := rdr.RecordBatch()
+			ids := rec.Column(slices.IndexFunc(rec.Schema().Fields(),
+				func(f arrow.Field) bool { return f.Name == "id" })).(*array.Int64)
+			for i := range int(rec.NumRows()) {
+				out = append(out, fmt.Sprintf("%d/%d/%s/%d",
+					rec.Column(n-2).(*array.Int32).Value(i),
+					rec.Column(n-1).(*array.Int64).Value(i),
+					rec.Column(n-3).(*array.String).Value(i),
+					ids.Value(i)))
+			}
+		}
+		require.NoError(t, rdr.Err())
+		slices.Sort(out)
+
+		return out
+	}
+
+	row := func(ordinal int, snap int64, typ table.ChangeType, id int) string {
+		return fmt.Sprintf("%d/%d/%s/%d", ordinal, snap, typ, id)
+	}
+
+	t.Run("full history", func(t *testing.T) {
+		assert.Equal(t, []string{
+			row(0, first, table.ChangeInsert, 1), row(0, first, table.ChangeInsert, 2), row(0, first, table.ChangeInsert, 3),
+			row(1, second, table.ChangeInsert, 4), row(1, second, table.ChangeInsert, 5),
+			row(2, third, table.ChangeDelete, 1), row(2, third, table.ChangeDelete, 2), row(2, third, table.ChangeDelete, 3),
+			row(2, third, table.ChangeInsert, 2), row(2, third, table.ChangeInsert, 3),
+		}, changes(t, tbl.ChangelogScan()))
+	})
+
+	t.Run("range", func(t *testing.T) {
+		cs := tbl.ChangelogScan(table.WithSnapshotID(second)).FromSnapshot(first)
+		assert.Equal(t, []string{
+			row(0, second, table.ChangeInsert, 4), row(0, second, table.ChangeInsert, 5),
+		}, changes(t, cs))
+
+		tasks, err := tbl.ChangelogScan().FromSnapshot(second).PlanFiles(ctx)
+		require.NoError(t, err)
+		require.Len(t, tasks, 2)
+		assert.Equal(t, table.ChangeDelete, tasks[0].ChangeType)
+		assert.Equal(t, table.ChangeInsert, tasks[1].ChangeType)
+		assert.EqualValues(t, 3, tasks[0].File.Count())
+
+		tasks, err = tbl.ChangelogScan().FromSnapshot(third).PlanFiles(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, tasks)
+	})
+
+	t.Run("filter and projection", func(t *testing.T) {
+		cs := tbl.ChangelogScan(
+			table.WithRowFilter(iceberg.GreaterThanEqual(iceberg.Reference("id"), int64(3))),
+			table.WithSelectedFields("id"))
+		sc, err := cs.Schema()
+		require.NoError(t, err)
+		assert.Len(t, sc.Fields(), 4)
+
+		assert.Equal(t, []string{
+			row(0, first, table.ChangeInsert, 3),
+			row(1, second, table.ChangeInsert, 4), row(1, second, table.ChangeInsert, 5),
+			row(2, third, table.ChangeDelete, 3), row(2, third, table.ChangeInsert, 3),
+		}, changes(t, cs))
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := tbl.ChangelogScan(table.WithSnapshotID(first)).FromSnapshot(third).PlanFiles(ctx)
+		require.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+		assert.ErrorContains(t, err, "not an ancestor")
+	})
+}
@@ -0,0 +1,205 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/apache/iceberg-go"
+)
+
+// ColumnEventKind identifies the kind of change reported in a
+// ColumnLineage.
+type ColumnEventKind string
+
+const (
+	ColumnAdded   ColumnEventKind = "added"
+	ColumnRenamed ColumnEventKind = "renamed"
+	ColumnDropped ColumnEventKind = "dropped"
+)
+
+// ColumnEvent is a change of a column between two schemas of a table.
+type ColumnEvent struct {
+	Kind ColumnEventKind `json:"kind"`
+	// SchemaID is the ID of the first schema with the change.
+	SchemaID int `json:"schema-id"`
+	// SnapshotID and TimestampMs identify the first snapshot written with
+	// the schema, and are nil and zero if no retained snapshot was.
+	SnapshotID  *int64 `json:"snapshot-id,omitempty"`
+	TimestampMs int64  `json:"timestamp-ms,omitempty"`
+	// OldName and NewName are the full names of the column before and
+	// after the change, and are empty for columns that were added or
+	// dropped respectively.
+	OldName string `json:"old-name,omitempty"`
+	NewName string `json:"new-name,omitempty"`
+}
+
+// ColumnLineage is the history of a column of a table, identified by its
+// field ID.
+type ColumnLineage struct {
+	FieldID int `json:"field-id"`
+	// Name is the latest full name of the column.
+	Name   string        `json:"name"`
+	Events []ColumnEvent `json:"events"`
+	// FirstWrittenSnapshotID is the ID of the oldest retained snapshot
+	// that added data files while the column was in the table's schema,
+	// nil if there is none.
+	FirstWrittenSnapshotID *int64 `json:"first-written-snapshot-id,omitempty"`
+}
+
+// Dropped reports whether the column is no longer in the table's schemas
+// after its last change.
+func (c ColumnLineage) Dropped() bool {
+	return len(c.Events) > 0 && c.Events[len(c.Events)-1].Kind == ColumnDropped
+}
+
+// ColumnLineages returns the lineage of every column, nested fields
+// included, that appears in the schemas of meta, ordered by field ID.
+// Schemas are compared in the order of their IDs: a column is added in
+// the first schema that has its field ID, renamed in a schema where its
+// name differs from the previous schema, and dropped in the first schema
+// after that which no longer has it.
+func ColumnLineages(meta Metadata) ([]ColumnLineage, error) {
+	schemas := slices.SortedFunc(slices.Values(meta.Schemas()), func(a, b *iceberg.Schema) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	snapshots := slices.SortedFunc(slices.Values(meta.Snapshots()), func(a, b Snapshot) int {
+		return cmp.Or(cmp.Compare(a.SequenceNumber, b.SequenceNumber),
+			cmp.Compare(a.TimestampMs, b.TimestampMs))
+	})
+
+	// the first snapshot written with each schema
+	firstWithSchema := make(map[int]Snapshot)
+	for _, snap := range snapshots {
+		if snap.SchemaID == nil {
+			continue
+		}
+		if _, ok := firstWithSchema[*snap.SchemaID]; !ok {
+			firstWithSchema[*snap.SchemaID] = snap
+		}
+	}
+
+	lineages := make(map[int]*ColumnLineage)
+	addEvent := func(id int, ev ColumnEvent) {
+		if snap, ok := firstWithSchema[ev.SchemaID]; ok {
+			ev.SnapshotID, ev.TimestampMs = &snap.SnapshotID, snap.TimestampMs
+		}
+		l := lineages[id]
+		l.Events = append(l.Events, ev)
+	}
+
+	var prevNames map[int]string
+	var prevFields map[int]iceberg.NestedField
+	for _, sc := range schemas {
+		names, err := sc.IndexNameByID()
+		if err != nil {
+			return nil, err
+		}
+		fields, err := sc.IndexByID()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range slices.Sorted(maps.Keys(names)) {
+			name := names[id]
+			prevName, existed := prevNames[id]
+			switch {
+			case !existed:
+				if _, ok := lineages[id]; !ok {
+					lineages[id] = &ColumnLineage{FieldID: id}
+				}
+				addEvent(id, ColumnEvent{Kind: ColumnAdded, SchemaID: sc.ID, NewName: name})
+			case prevFields[id].Name != fields[id].Name:
+				addEvent(id, ColumnEvent{Kind: ColumnRenamed, SchemaID: sc.ID, OldName: prevName, NewName: name})
+			}
+			lineages[id].Name = name
+		}
+
+		for _, id := range slices.Sorted(maps.Keys(prevNames)) {
+			if _, ok := names[id]; !ok {
+				addEvent(id, ColumnEvent{Kind: ColumnDropped, SchemaID: sc.ID, OldName: prevNames[id]})
+			}
+		}
+
+		prevNames, prevFields = names, fields
+	}
+
+	schemasByID := make(map[int]*iceberg.Schema, len(schemas))
+	for _, sc := range schemas {
+		schemasByID[sc.ID] = sc
+	}
+
+	for _, snap := range snapshots {
+		if snap.SchemaID == nil || !snapshotAddedData(snap) {
+			continue
+		}
+
+		sc, ok := schemasByID[*snap.SchemaID]
+		if !ok {
+			continue
+		}
+		names, err := sc.IndexNameByID()
+		if err != nil {
+			return nil, err
+		}
+
+		for id := range names {
+			if l := lineages[id]; l != nil && l.FirstWrittenSnapshotID == nil {
+				l.FirstWrittenSnapshotID = &snap.SnapshotID
+			}
+		}
+	}
+
+	out := make([]ColumnLineage, 0, len(lineages))
+	for _, id := range slices.Sorted(maps.Keys(lineages)) {
+		out = append(out, *lineages[id])
+	}
+
+	return out, nil
+}
+
+// ColumnLineage returns the lineage of the columns of the table, as
+// ColumnLineages does for its metadata.
+func (t Table) ColumnLineage() ([]ColumnLineage, error) {
+	return ColumnLineages(t.Metadata())
+}
+
+// snapshotAddedData reports whether the snapshot added data files,
+// according to its summary.
+func snapshotAddedData(snap Snapshot) bool {
+	if snap.Summary == nil {
+		return false
+	}
+
+	if added, ok := snap.Summary.Properties[addedDataFilesKey]; ok {
+		n, err := strconv.Atoi(added)
+
+		return err == nil && n > 0
+	}
+
+	switch snap.Summary.Operation {
+	case OpAppend, OpOverwrite:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,123 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"testing"
+
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const columnLineageMetadata = `{
+	"format-version": 2,
+	"table-uuid": "9c12d441-03fe-4693-9a96-a0705ddf69c1",
+	"location": "s3://bucket/test/location",
+	"last-sequence-number": 3,
+	"last-updated-ms": 1700000300000,
+	"last-column-id": 5,
+	"current-schema-id": 2,
+	"schemas": [
+		{"type": "struct", "schema-id": 0, "fields": [
+			{"id": 1, "name": "id", "required": true, "type": "long"},
+			{"id": 2, "name": "email", "required": false, "type": "string"},
+			{"id": 3, "name": "addr", "required": false, "type": {"type": "struct", "fields": [
+				{"id": 4, "name": "city", "required": false, "type": "string"}
+			]}}
+		]},
+		{"type": "struct", "schema-id": 1, "fields": [
+			{"id": 1, "name": "id", "required": true, "type": "long"},
+			{"id": 2, "name": "email_address", "required": false, "type": "string"},
+			{"id": 3, "name": "addr", "required": false, "type": {"type": "struct", "fields": [
+				{"id": 4, "name": "city", "required": false, "type": "string"}
+			]}},
+			{"id": 5, "name": "phone", "required": false, "type": "string"}
+		]},
+		{"type": "struct", "schema-id": 2, "fields": [
+			{"id": 1, "name": "id", "required": true, "type": "long"},
+			{"id": 2, "name": "email_address", "required": false, "type": "string"},
+			{"id": 5, "name": "phone", "required": false, "type": "string"}
+		]}
+	],
+	"default-spec-id": 0,
+	"partition-specs": [{"spec-id": 0, "fields": []}],
+	"last-partition-id": 999,
+	"default-sort-order-id": 0,
+	"sort-orders": [{"order-id": 0, "fields": []}],
+	"current-snapshot-id": 3,
+	"snapshots": [
+		{"snapshot-id": 1, "sequence-number": 1, "timestamp-ms": 1700000100000,
+		 "manifest-list": "s3://a/b/1.avro", "schema-id": 0,
+		 "summary": {"operation": "append", "added-data-files": "2"}},
+		{"snapshot-id": 2, "parent-snapshot-id": 1, "sequence-number": 2, "timestamp-ms": 1700000200000,
+		 "manifest-list": "s3://a/b/2.avro", "schema-id": 1,
+		 "summary": {"operation": "delete", "deleted-data-files": "1"}},
+		{"snapshot-id": 3, "parent-snapshot-id": 2, "sequence-number": 3, "timestamp-ms": 1700000300000,
+		 "manifest-list": "s3://a/b/3.avro", "schema-id": 2,
+		 "summary": {"operation": "append", "added-data-files": "1"}}
+	]
+}`
+
+func TestColumnLineages(t *testing.T) {
+	meta, err := table.ParseMetadataString(columnLineageMetadata)
+	require.NoError(t, err)
+
+	lineages, err := table.ColumnLineages(meta)
+	require.NoError(t, err)
+	require.Len(t, lineages, 5)
+
+	snap := func(id int64) *int64 { return &id }
+
+	id := lineages[0]
+	assert.Equal(t, 1, id.FieldID)
+	assert.Equal(t, "id", id.Name)
+	assert.Equal(t, []table.ColumnEvent{{
+		Kind: table.ColumnAdded, SchemaID: 0, SnapshotID: snap(1),
+		TimestampMs: 1700000100000, NewName: "id",
+	}}, id.Events)
+	assert.Equal(t, snap(1), id.FirstWrittenSnapshotID)
+	assert.False(t, id.Dropped())
+
+	email := lineages[1]
+	assert.Equal(t, "email_address", email.Name)
+	require.Len(t, email.Events, 2)
+	assert.Equal(t, table.ColumnEvent{
+		Kind: table.ColumnRenamed, SchemaID: 1, SnapshotID: snap(2),
+		TimestampMs: 1700000200000, OldName: "email", NewName: "email_address",
+	}, email.Events[1])
+
+	// the nested field is dropped together with its parent
+	for _, l := range lineages[2:4] {
+		assert.True(t, l.Dropped(), l.Name)
+		assert.Equal(t, table.ColumnEvent{
+			Kind: table.ColumnDropped, SchemaID: 2, SnapshotID: snap(3),
+			TimestampMs: 1700000300000, OldName: l.Name,
+		}, l.Events[len(l.Events)-1])
+		assert.Equal(t, snap(1), l.FirstWrittenSnapshotID)
+	}
+	assert.Equal(t, "addr.city", lineages[3].Name)
+
+	// the schema with phone was only used by a snapshot that deleted
+	// data, so the first write of phone is the last append
+	phone := lineages[4]
+	assert.Equal(t, 5, phone.FieldID)
+	assert.Equal(t, table.ColumnAdded, phone.Events[0].Kind)
+	assert.Equal(t, 1, phone.Events[0].SchemaID)
+	assert.Equal(t, snap(3), phone.FirstWrittenSnapshotID)
+}
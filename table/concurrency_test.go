@@ -0,0 +1,158 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// syncCatalog is a CatalogIO which is safe for concurrent use.
+type syncCatalog struct {
+	mx       sync.Mutex
+	metadata table.Metadata
+	fsF      table.FSysF
+}
+
+func (c *syncCatalog) LoadTable(_ context.Context, ident table.Identifier) (*table.Table, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	return table.New(ident, c.metadata, "", c.fsF, c), nil
+}
+
+func (c *syncCatalog) CommitTable(_ context.Context, _ table.Identifier, reqs []table.Requirement, updates []table.Update) (table.Metadata, string, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	for _, r := range reqs {
+		if err := r.Validate(c.metadata); err != nil {
+			return nil, "", err
+		}
+	}
+
+	meta, err := table.UpdateTableMetadata(c.metadata, updates, "")
+	if err != nil {
+		return nil, "", err
+	}
+	c.metadata = meta
+
+	return meta, "", nil
+}
+
+func newSyncCatalogTable(t *testing.T) (*table.Table, *syncCatalog) {
+	cat := &syncCatalog{}
+	tbl := newTestTable(t, withTestCatalog(cat))
+	cat.metadata = tbl.Metadata()
+	cat.fsF = func(context.Context) (iceio.IO, error) { return iceio.LocalFS{}, nil }
+
+	return tbl, cat
+}
+
+func arrowSchemaFor(t *testing.T, tbl *table.Table) *arrow.Schema {
+	sc, err := table.SchemaToArrowSchema(tbl.Schema(), nil, false, false)
+	require.NoError(t, err)
+
+	return sc
+}
+
+func TestTableRefreshPinsScansAndTransactions(t *testing.T) {
+	ctx := context.Background()
+	tbl, _ := newSyncCatalogTable(t)
+
+	scan := tbl.Scan()
+	txn := tbl.NewTransaction()
+	copied := *tbl
+
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrowSchemaFor(t, tbl),
+		[]string{`[{"id": 1}, {"id": 2}]`})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	other := tbl.NewTransaction()
+	require.NoError(t, other.SetProperties(iceberg.Properties{"key": "value"}))
+	require.NoError(t, other.AppendTable(ctx, arrTbl, 1, nil))
+	_, err = other.Commit(ctx)
+	require.NoError(t, err)
+
+	assert.Nil(t, tbl.CurrentSnapshot())
+	require.NoError(t, tbl.Refresh(ctx))
+	assert.Equal(t, "value", tbl.Properties()["key"])
+	assert.NotNil(t, tbl.CurrentSnapshot())
+	assert.Equal(t, "value", copied.Properties()["key"], "copies share the refreshed version")
+
+	// the scan and transaction keep the version they were created from
+	assert.Nil(t, scan.Snapshot())
+	staged, err := txn.StagedTable()
+	require.NoError(t, err)
+	assert.NotContains(t, staged.Properties(), "key")
+}
+
+func TestTableConcurrentRefresh(t *testing.T) {
+	ctx := context.Background()
+	tbl, _ := newSyncCatalogTable(t)
+
+	const n = 20
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+
+	go func() {
+		defer wg.Done()
+		for i := range n {
+			txn := tbl.NewTransaction()
+			if !assert.NoError(t, txn.SetProperties(iceberg.Properties{"iteration": strconv.Itoa(i)})) {
+				return
+			}
+			if _, err := txn.Commit(ctx); !assert.NoError(t, err) {
+				return
+			}
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for range n {
+			assert.NoError(t, tbl.Refresh(ctx))
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		for range n {
+			_ = tbl.Scan().Snapshot()
+			_ = tbl.Schema()
+			_ = tbl.Properties()
+			_, _ = tbl.FS(ctx)
+			_ = tbl.NewTransaction()
+		}
+	}()
+
+	wg.Wait()
+}
@@ -0,0 +1,162 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/apache/iceberg-go"
+)
+
+// positionDeleteIndex indexes the position delete files of a scan so
+// that the deletes which may apply to a data file are found without
+// evaluating every delete file of the scan. Each group of deletes is
+// sorted by sequence number, so deletes that are older than a data file,
+// and so cannot apply to it, are skipped with a binary search.
+type positionDeleteIndex struct {
+	// deletes that only reference a single data file, by its path
+	byPath map[string][]iceberg.ManifestEntry
+	// the remaining deletes of partitioned specs, by spec and partition
+	byPartition map[string][]iceberg.ManifestEntry
+	// the remaining deletes of unpartitioned specs, which can apply to
+	// the data files of any partition
+	global []iceberg.ManifestEntry
+}
+
+// newPositionDeleteIndex indexes the delete entries, dropping those with
+// a sequence number lower than minDataSeqNum as they cannot apply to
+// any of the data files.
+func newPositionDeleteIndex(entries []iceberg.ManifestEntry, minDataSeqNum int64) *positionDeleteIndex {
+	idx := &positionDeleteIndex{
+		byPath:      make(map[string][]iceberg.ManifestEntry),
+		byPartition: make(map[string][]iceberg.ManifestEntry),
+	}
+
+	for _, e := range entries {
+		if e.SequenceNum() < minDataSeqNum {
+			continue
+		}
+
+		df := e.DataFile()
+		switch path, ok := positionDeleteFileTarget(df); {
+		case ok:
+			idx.byPath[path] = append(idx.byPath[path], e)
+		case len(df.Partition()) == 0:
+			idx.global = append(idx.global, e)
+		default:
+			key := partitionKey(df.SpecID(), df.Partition())
+			idx.byPartition[key] = append(idx.byPartition[key], e)
+		}
+	}
+
+	bySeqNum := func(a, b iceberg.ManifestEntry) int {
+		return cmp.Compare(a.SequenceNum(), b.SequenceNum())
+	}
+	for _, group := range idx.byPath {
+		slices.SortStableFunc(group, bySeqNum)
+	}
+	for _, group := range idx.byPartition {
+		slices.SortStableFunc(group, bySeqNum)
+	}
+	slices.SortStableFunc(idx.global, bySeqNum)
+
+	return idx
+}
+
+// forDataFile returns the delete files that may apply to the data file
+// of the entry, ordered by sequence number.
+func (idx *positionDeleteIndex) forDataFile(entry iceberg.ManifestEntry) ([]iceberg.DataFile, error) {
+	dataFile := entry.DataFile()
+
+	matches := slices.Clone(applicableDeletes(idx.byPath[dataFile.FilePath()], entry.SequenceNum()))
+
+	// deletes that reference several data files only apply to this one if
+	// the file path falls within their bounds
+	candidates := slices.Concat(
+		applicableDeletes(idx.byPartition[partitionKey(dataFile.SpecID(), dataFile.Partition())], entry.SequenceNum()),
+		applicableDeletes(idx.global, entry.SequenceNum()))
+	if len(candidates) > 0 {
+		evaluator, err := newInclusiveMetricsEvaluator(iceberg.PositionalDeleteSchema,
+			iceberg.EqualTo(iceberg.Reference("file_path"), dataFile.FilePath()), true, false)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range candidates {
+			ok, err := evaluator(e.DataFile())
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				matches = append(matches, e)
+			}
+		}
+	}
+
+	slices.SortStableFunc(matches, func(a, b iceberg.ManifestEntry) int {
+		return cmp.Compare(a.SequenceNum(), b.SequenceNum())
+	})
+
+	out := make([]iceberg.DataFile, len(matches))
+	for i, e := range matches {
+		out[i] = e.DataFile()
+	}
+
+	return out, nil
+}
+
+// applicableDeletes returns the deletes, sorted by sequence number, that
+// are not older than a data file with the given sequence number.
+func applicableDeletes(deletes []iceberg.ManifestEntry, dataSeqNum int64) []iceberg.ManifestEntry {
+	start, _ := slices.BinarySearchFunc(deletes, dataSeqNum, func(e iceberg.ManifestEntry, seq int64) int {
+		return cmp.Compare(e.SequenceNum(), seq)
+	})
+
+	return deletes[start:]
+}
+
+// positionDeleteFileTarget returns the data file that all of the deletes
+// in the position delete file reference, if there is only one.
+func positionDeleteFileTarget(df iceberg.DataFile) (string, bool) {
+	if ref := df.ReferencedDataFile(); ref != nil {
+		return *ref, true
+	}
+
+	lower, hasLower := df.LowerBoundValues()[positionDeleteFilePathID]
+	upper, hasUpper := df.UpperBoundValues()[positionDeleteFilePathID]
+	if hasLower && hasUpper && string(lower) == string(upper) {
+		return string(lower), true
+	}
+
+	return "", false
+}
+
+// partitionKey identifies the partition of a file of the given spec.
+func partitionKey(specID int32, partition map[int]any) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d", specID)
+	for _, id := range slices.Sorted(maps.Keys(partition)) {
+		fmt.Fprintf(&b, "/%d=%v", id, partition[id])
+	}
+
+	return b.String()
+}
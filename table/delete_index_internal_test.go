@@ -0,0 +1,90 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPositionDeleteIndex(t *testing.T) {
+	spec := iceberg.NewPartitionSpecID(1, iceberg.PartitionField{
+		SourceID: 1, FieldID: 1000, Name: "region", Transform: iceberg.IdentityTransform{},
+	})
+
+	newEntry := func(t *testing.T, spec iceberg.PartitionSpec, content iceberg.ManifestEntryContent,
+		path string, partition map[int]any, seqNum int64, bounds ...string,
+	) iceberg.ManifestEntry {
+		bldr, err := iceberg.NewDataFileBuilder(spec, content, path, iceberg.ParquetFile,
+			partition, nil, nil, 1, 100)
+		require.NoError(t, err)
+		if len(bounds) == 2 {
+			bldr.LowerBoundValues(map[int][]byte{positionDeleteFilePathID: []byte(bounds[0])}).
+				UpperBoundValues(map[int][]byte{positionDeleteFilePathID: []byte(bounds[1])})
+		}
+
+		return iceberg.NewManifestEntry(iceberg.EntryStatusADDED, nil, &seqNum, &seqNum, bldr.Build())
+	}
+
+	us, eu := map[int]any{1000: "us"}, map[int]any{1000: "eu"}
+	dataA := newEntry(t, spec, iceberg.EntryContentData, "s3://data/a.parquet", us, 2)
+	dataB := newEntry(t, spec, iceberg.EntryContentData, "s3://data/b.parquet", us, 4)
+
+	deletes := []iceberg.ManifestEntry{
+		// older than every data file
+		newEntry(t, spec, iceberg.EntryContentPosDeletes, "s3://deletes/old.parquet", us, 1,
+			"s3://data/a.parquet", "s3://data/b.parquet"),
+		// only references a.parquet
+		newEntry(t, spec, iceberg.EntryContentPosDeletes, "s3://deletes/a.parquet", us, 3,
+			"s3://data/a.parquet", "s3://data/a.parquet"),
+		// references both files of the partition
+		newEntry(t, spec, iceberg.EntryContentPosDeletes, "s3://deletes/us.parquet", us, 5,
+			"s3://data/a.parquet", "s3://data/b.parquet"),
+		// another partition
+		newEntry(t, spec, iceberg.EntryContentPosDeletes, "s3://deletes/eu.parquet", eu, 5,
+			"s3://data/a.parquet", "s3://data/b.parquet"),
+		// unpartitioned deletes apply to every partition
+		newEntry(t, *iceberg.UnpartitionedSpec, iceberg.EntryContentPosDeletes, "s3://deletes/global.parquet", nil, 4,
+			"s3://data/b.parquet", "s3://data/c.parquet"),
+	}
+
+	idx := newPositionDeleteIndex(deletes, 2)
+	assert.Len(t, idx.byPath, 1)
+	assert.Len(t, idx.byPartition, 2)
+	assert.Len(t, idx.global, 1)
+
+	paths := func(files []iceberg.DataFile) []string {
+		out := make([]string, len(files))
+		for i, f := range files {
+			out[i] = f.FilePath()
+		}
+
+		return out
+	}
+
+	files, err := idx.forDataFile(dataA)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"s3://deletes/a.parquet", "s3://deletes/us.parquet"}, paths(files))
+
+	files, err = idx.forDataFile(dataB)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"s3://deletes/global.parquet", "s3://deletes/us.parquet"}, paths(files))
+}
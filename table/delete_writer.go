@@ -0,0 +1,280 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/encryption"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table/internal"
+	"github.com/google/uuid"
+)
+
+type deleteWriterConfig struct {
+	rowSchema      *iceberg.Schema
+	specID         *int
+	partition      map[int]any
+	targetFileSize int64
+}
+
+// DeleteWriterOption configures a PositionDeleteWriter or an
+// EqualityDeleteWriter.
+type DeleteWriterOption func(*deleteWriterConfig)
+
+// WithDeleteRowSchema includes the deleted rows in position delete
+// files, using the given schema for the optional row column. Rows are
+// only written with PositionDeleteWriter.WriteRecord. It has no effect
+// on equality delete writers.
+func WithDeleteRowSchema(sc *iceberg.Schema) DeleteWriterOption {
+	return func(cfg *deleteWriterConfig) {
+		cfg.rowSchema = sc
+	}
+}
+
+// WithDeletePartition writes the delete files into the partition with
+// the given values, keyed by partition field ID, of the given partition
+// spec. Position deletes must be written to the partition of the data
+// files that they reference, so this is required for position deletes
+// in partitioned tables.
+func WithDeletePartition(specID int, values map[int]any) DeleteWriterOption {
+	return func(cfg *deleteWriterConfig) {
+		cfg.specID, cfg.partition = &specID, values
+	}
+}
+
+// WithDeleteTargetFileSize sets the size in bytes at which the writer
+// rolls over to a new delete file. It defaults to the table's
+// write.delete.target-file-size-bytes property.
+func WithDeleteTargetFileSize(size int64) DeleteWriterOption {
+	return func(cfg *deleteWriterConfig) {
+		cfg.targetFileSize = size
+	}
+}
+
+// deleteFileWriter buffers records of delete rows and writes them to
+// delete files of the target size, for both position and equality deletes.
+type deleteFileWriter struct {
+	ctx            context.Context
+	fs             iceio.WriteFileIO
+	loc            LocationProvider
+	format         internal.FileFormat
+	writeProps     any
+	rowGroupSize   int64
+	encryption     *encryption.StandardEncryptionManager
+	partition      iceberg.PartitionData
+	partitionPath  string
+	fileSchema     *iceberg.Schema
+	arrSchema      *arrow.Schema
+	statsCols      map[int]internal.StatisticsCollector
+	targetFileSize int64
+	writeUUID      uuid.UUID
+	content        iceberg.ManifestEntryContent
+	equalityIDs    []int
+
+	pending      []arrow.RecordBatch
+	pendingBytes int64
+	fileCount    int
+	files        []iceberg.DataFile
+	closed       bool
+}
+
+// newDeleteFileWriter prepares a writer of delete files with the given
+// schema for the table. The spec is the partition spec the files are
+// written with when the options do not select a partition.
+func newDeleteFileWriter(ctx context.Context, tbl *Table, fileSchema *iceberg.Schema,
+	content iceberg.ManifestEntryContent, spec iceberg.PartitionSpec, cfg deleteWriterConfig,
+) (*deleteFileWriter, error) {
+	meta := tbl.Metadata()
+	props := meta.Properties()
+
+	if cfg.targetFileSize <= 0 {
+		return nil, fmt.Errorf("%w: target file size must be positive, got %d",
+			iceberg.ErrInvalidArgument, cfg.targetFileSize)
+	}
+
+	fs, err := tbl.FS(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wfs, ok := fs.(iceio.WriteFileIO)
+	if !ok {
+		return nil, errors.New("filesystem IO does not support writing")
+	}
+
+	if cfg.specID != nil {
+		s := meta.PartitionSpecByID(*cfg.specID)
+		if s == nil {
+			return nil, fmt.Errorf("%w: id %d", ErrPartitionSpecNotFound, *cfg.specID)
+		}
+		spec = *s
+	}
+
+	schema := meta.CurrentSchema()
+	partition, err := iceberg.NewPartitionData(spec, schema, cfg.partition)
+	if err != nil {
+		return nil, err
+	}
+
+	var partitionPath string
+	if !spec.IsUnpartitioned() {
+		partType := spec.PartitionType(schema)
+		rec := make(partitionRecord, len(partType.FieldList))
+		for i, f := range partType.FieldList {
+			rec[i] = cfg.partition[f.ID]
+		}
+		partitionPath = spec.PartitionToPath(rec, schema)
+	}
+
+	arrSchema, err := SchemaToArrowSchema(fileSchema, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+
+	statsCols, err := computeStatsPlan(fileSchema, props)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := LoadLocationProvider(meta.Location(), props)
+	if err != nil {
+		return nil, err
+	}
+
+	encMgr, err := encryption.LoadManager(ctx, props)
+	if err != nil {
+		return nil, err
+	}
+
+	format := internal.GetFileFormat(iceberg.ParquetFile)
+
+	return &deleteFileWriter{
+		ctx:        ctx,
+		fs:         wfs,
+		loc:        loc,
+		format:     format,
+		writeProps: format.GetWriteProperties(props),
+		rowGroupSize: int64(props.GetInt(ParquetRowGroupSizeBytesKey,
+			ParquetRowGroupSizeBytesDefault)),
+		encryption:     encMgr,
+		partition:      partition,
+		partitionPath:  partitionPath,
+		fileSchema:     fileSchema,
+		arrSchema:      arrSchema,
+		statsCols:      statsCols,
+		targetFileSize: cfg.targetFileSize,
+		writeUUID:      uuid.New(),
+		content:        content,
+	}, nil
+}
+
+func defaultDeleteWriterConfig(props iceberg.Properties, opts []DeleteWriterOption) deleteWriterConfig {
+	cfg := deleteWriterConfig{
+		targetFileSize: int64(props.GetInt(WriteDeleteTargetFileSizeBytesKey,
+			WriteDeleteTargetFileSizeBytesDefault)),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+func (w *deleteFileWriter) checkOpen() error {
+	if w.closed {
+		return fmt.Errorf("%w: delete writer is closed", ErrInvalidOperation)
+	}
+
+	return nil
+}
+
+// checkSchema verifies that the record matches the delete file schema,
+// ignoring field metadata.
+func (w *deleteFileWriter) checkSchema(sc *arrow.Schema) error {
+	if sc.Equal(w.arrSchema) {
+		return nil
+	}
+
+	if sc.NumFields() != w.arrSchema.NumFields() {
+		return fmt.Errorf("%w: delete record schema %s does not match %s",
+			iceberg.ErrInvalidArgument, sc, w.arrSchema)
+	}
+
+	for i, f := range sc.Fields() {
+		if f.Name != w.arrSchema.Field(i).Name || !arrow.TypeEqual(f.Type, w.arrSchema.Field(i).Type) {
+			return fmt.Errorf("%w: delete record schema %s does not match %s",
+				iceberg.ErrInvalidArgument, sc, w.arrSchema)
+		}
+	}
+
+	return nil
+}
+
+// add buffers the record, which must already match the file schema, and
+// returns whether the buffered deletes reached the target file size.
+func (w *deleteFileWriter) add(rec arrow.RecordBatch, nbytes int64) bool {
+	w.pending = append(w.pending, rec)
+	w.pendingBytes += nbytes
+
+	return w.pendingBytes >= w.targetFileSize
+}
+
+// flush writes the buffered deletes to a new delete file, recording the
+// data file that the deletes reference if there is only one.
+func (w *deleteFileWriter) flush(referencedDataFile string) error {
+	if len(w.pending) == 0 {
+		return nil
+	}
+
+	defer func() {
+		for _, rec := range w.pending {
+			rec.Release()
+		}
+		w.pending, w.pendingBytes = nil, 0
+	}()
+
+	w.fileCount++
+	fileName := WriteTask{Uuid: w.writeUUID, FileCount: w.fileCount}.
+		GenerateDataFileName("parquet")
+	if w.partitionPath != "" {
+		fileName = w.partitionPath + "/" + fileName
+	}
+
+	df, err := w.format.WriteDataFile(w.ctx, w.fs, w.partition, internal.WriteFileInfo{
+		FileSchema:         w.fileSchema,
+		FileName:           w.loc.NewDataLocation(fileName),
+		StatsCols:          w.statsCols,
+		WriteProps:         w.writeProps,
+		Encryption:         w.encryption,
+		Content:            w.content,
+		EqualityFieldIDs:   w.equalityIDs,
+		ReferencedDataFile: referencedDataFile,
+		RowGroupSizeBytes:  w.rowGroupSize,
+	}, w.pending)
+	if err != nil {
+		return err
+	}
+	w.files = append(w.files, df)
+
+	return nil
+}
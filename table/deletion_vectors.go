@@ -0,0 +1,344 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/compute"
+	"github.com/apache/iceberg-go"
+	iceinternal "github.com/apache/iceberg-go/internal"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/puffin"
+	"github.com/apache/iceberg-go/table/internal"
+	"github.com/google/uuid"
+)
+
+// the field ID of the _pos metadata column, which deletion vectors
+// record as the field their positions belong to
+const rowPositionFieldID = 2147483645
+
+// DeletionVectorWriter writes the deletion vectors of a format version 3
+// table, which replace position delete files in that version. The
+// deletes of each data file are collected into a bitmap, merged with
+// the deletion vector the data file already has in the current snapshot,
+// and written as a blob of a single puffin file on Close. Committing the
+// returned files with Transaction.AddDeleteFiles replaces the previous
+// deletion vectors of the data files.
+type DeletionVectorWriter struct {
+	ctx       context.Context
+	tbl       *Table
+	fs        iceio.WriteFileIO
+	loc       LocationProvider
+	spec      iceberg.PartitionSpec
+	partition map[int]any
+	bitmaps   map[string]*internal.PositionBitmap
+	files     []iceberg.DataFile
+	closed    bool
+}
+
+// NewDeletionVectorWriter creates a writer of deletion vectors for the
+// data files of the table. Deletion vectors of a partitioned table must
+// be written to the partition of the data files they reference, given
+// with WithDeletePartition; the other options have no effect.
+func NewDeletionVectorWriter(ctx context.Context, tbl *Table, opts ...DeleteWriterOption) (*DeletionVectorWriter, error) {
+	pinned := tbl.pin()
+	tbl = &pinned
+
+	meta := tbl.Metadata()
+	if meta.Version() < 3 {
+		return nil, fmt.Errorf("%w: deletion vectors require format version 3, table has version %d",
+			iceberg.ErrInvalidArgument, meta.Version())
+	}
+
+	cfg := defaultDeleteWriterConfig(meta.Properties(), opts)
+	spec := meta.PartitionSpec()
+	if cfg.specID != nil {
+		s := meta.PartitionSpecByID(*cfg.specID)
+		if s == nil {
+			return nil, fmt.Errorf("%w: id %d", ErrPartitionSpecNotFound, *cfg.specID)
+		}
+		spec = *s
+	} else if !spec.IsUnpartitioned() {
+		return nil, fmt.Errorf("%w: deletion vectors for a partitioned table require a partition",
+			iceberg.ErrInvalidArgument)
+	}
+
+	fs, err := tbl.FS(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wfs, ok := fs.(iceio.WriteFileIO)
+	if !ok {
+		return nil, errors.New("filesystem IO does not support writing")
+	}
+
+	loc, err := LoadLocationProvider(meta.Location(), meta.Properties())
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeletionVectorWriter{
+		ctx:       ctx,
+		tbl:       tbl,
+		fs:        wfs,
+		loc:       loc,
+		spec:      spec,
+		partition: cfg.partition,
+		bitmaps:   make(map[string]*internal.PositionBitmap),
+	}, nil
+}
+
+// Delete deletes the row at position pos of the data file at filePath.
+// Unlike position delete files, deletion vectors accept deletes in any
+// order.
+func (w *DeletionVectorWriter) Delete(filePath string, pos int64) error {
+	if w.closed {
+		return fmt.Errorf("%w: delete writer is closed", ErrInvalidOperation)
+	}
+
+	if pos < 0 {
+		return fmt.Errorf("%w: invalid position %d for %s", iceberg.ErrInvalidArgument, pos, filePath)
+	}
+
+	bitmap, ok := w.bitmaps[filePath]
+	if !ok {
+		bitmap = internal.NewPositionBitmap()
+		w.bitmaps[filePath] = bitmap
+	}
+	bitmap.Add(pos)
+
+	return nil
+}
+
+// Close merges the deletes with the existing deletion vectors of the
+// data files, writes them to a puffin file and returns a delete file for
+// each deletion vector. The writer cannot be used afterwards.
+func (w *DeletionVectorWriter) Close() (_ []iceberg.DataFile, err error) {
+	if w.closed {
+		return w.files, nil
+	}
+	w.closed = true
+
+	if len(w.bitmaps) == 0 {
+		return nil, nil
+	}
+
+	previous, err := w.tbl.deletionVectors(w.ctx, w.bitmaps)
+	if err != nil {
+		return nil, err
+	}
+	for path, df := range previous {
+		bitmap, err := readDeletionVectorBitmap(w.fs, df)
+		if err != nil {
+			return nil, err
+		}
+		w.bitmaps[path].Merge(bitmap)
+	}
+
+	fileName := WriteTask{Uuid: uuid.New(), FileCount: 1}.GenerateDataFileName("puffin")
+	if !w.spec.IsUnpartitioned() {
+		schema := w.tbl.Metadata().CurrentSchema()
+		partType := w.spec.PartitionType(schema)
+		rec := make(partitionRecord, len(partType.FieldList))
+		for i, f := range partType.FieldList {
+			rec[i] = w.partition[f.ID]
+		}
+		fileName = w.spec.PartitionToPath(rec, schema) + "/" + fileName
+	}
+	filePath := w.loc.NewDataLocation(fileName)
+
+	out, err := w.fs.Create(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer iceinternal.CheckedClose(out, &err)
+
+	pw := puffin.NewWriter(out)
+	pw.SetProperty(puffin.CreatedByProperty, "iceberg-go")
+
+	paths := slices.Sorted(maps.Keys(w.bitmaps))
+	blobs := make([]puffin.BlobMetadata, len(paths))
+	for i, path := range paths {
+		data, err := internal.SerializeDeletionVector(w.bitmaps[path])
+		if err != nil {
+			return nil, err
+		}
+
+		blobs[i], err = pw.Add(puffin.Blob{
+			Type:           internal.DeletionVectorBlobType,
+			Fields:         []int32{rowPositionFieldID},
+			SnapshotID:     -1,
+			SequenceNumber: -1,
+			Properties: map[string]string{
+				internal.DeletionVectorReferencedDataFileProp: path,
+				internal.DeletionVectorCardinalityProp:        strconv.FormatInt(w.bitmaps[path].Cardinality(), 10),
+			},
+			Data: data,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	size, err := pw.Finish()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]iceberg.DataFile, len(paths))
+	for i, path := range paths {
+		bldr, err := iceberg.NewDataFileBuilder(w.spec, iceberg.EntryContentPosDeletes, filePath,
+			iceberg.PuffinFile, w.partition, nil, nil, w.bitmaps[path].Cardinality(), size)
+		if err != nil {
+			return nil, err
+		}
+		files[i] = bldr.ReferencedDataFile(path).
+			ContentOffset(blobs[i].Offset).
+			ContentSizeInBytes(blobs[i].Length).
+			Build()
+	}
+	w.files = files
+
+	return files, nil
+}
+
+// deletionVectors returns the deletion vectors of the current snapshot
+// for the data files with the given paths.
+func (t Table) deletionVectors(ctx context.Context, paths map[string]*internal.PositionBitmap) (map[string]iceberg.DataFile, error) {
+	snap := t.CurrentSnapshot()
+	if snap == nil {
+		return nil, nil
+	}
+
+	fs, err := t.FS(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]iceberg.DataFile)
+	for entry, err := range snap.Entries(fs) {
+		if err != nil {
+			return nil, err
+		}
+		if entry.Status() == iceberg.EntryStatusDELETED {
+			continue
+		}
+
+		df := entry.DataFile()
+		ref := df.ReferencedDataFile()
+		if df.FileFormat() != iceberg.PuffinFile || ref == nil {
+			continue
+		}
+		if _, ok := paths[*ref]; ok {
+			found[*ref] = df
+		}
+	}
+
+	return found, nil
+}
+
+// readDeletionVectorBitmap reads the positions of the deletion vector
+// stored in a blob of a puffin file.
+func readDeletionVectorBitmap(fs iceio.IO, df iceberg.DataFile) (_ *internal.PositionBitmap, err error) {
+	offset, size := df.ContentOffset(), df.ContentSizeInBytes()
+	if df.ReferencedDataFile() == nil || offset == nil || size == nil {
+		return nil, fmt.Errorf("%w: deletion vector in %s must have a referenced data file, content offset and size",
+			ErrInvalidMetadata, df.FilePath())
+	}
+
+	f, err := fs.Open(df.FilePath())
+	if err != nil {
+		return nil, err
+	}
+	defer iceinternal.CheckedClose(f, &err)
+
+	blob, err := puffin.ReadBlob(f, *offset, *size, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return internal.DeserializeDeletionVector(blob)
+}
+
+// readDeletionVector reads the deleted positions of a deletion vector
+// in the form returned by readDeletes.
+func readDeletionVector(ctx context.Context, fs iceio.IO, df iceberg.DataFile) (map[string]*arrow.Chunked, error) {
+	bitmap, err := readDeletionVectorBitmap(fs, df)
+	if err != nil {
+		return nil, err
+	}
+
+	bldr := array.NewInt64Builder(compute.GetAllocator(ctx))
+	defer bldr.Release()
+
+	bldr.Reserve(int(bitmap.Cardinality()))
+	for pos := range bitmap.Positions() {
+		bldr.UnsafeAppend(pos)
+	}
+
+	arr := bldr.NewArray()
+	defer arr.Release()
+
+	return map[string]*arrow.Chunked{
+		*df.ReferencedDataFile(): arrow.NewChunked(arrow.PrimitiveTypes.Int64, []arrow.Array{arr}),
+	}, nil
+}
+
+// validateContentFile checks that a file added to a table of the given
+// format version is supported by that version: delete files require
+// version 2, and position deletes are deletion vectors in version 3 and
+// position delete files before.
+func validateContentFile(formatVersion int, df iceberg.DataFile) error {
+	if df.ContentType() == iceberg.EntryContentData {
+		if df.FileFormat() == iceberg.PuffinFile {
+			return fmt.Errorf("%w: data file %s cannot be a puffin file",
+				iceberg.ErrInvalidArgument, df.FilePath())
+		}
+
+		return nil
+	}
+
+	if formatVersion < 2 {
+		return fmt.Errorf("%w: delete file %s requires format version 2, table has version %d",
+			iceberg.ErrInvalidArgument, df.FilePath(), formatVersion)
+	}
+
+	isDV := df.FileFormat() == iceberg.PuffinFile
+	switch {
+	case isDV && formatVersion < 3:
+		return fmt.Errorf("%w: deletion vector %s requires format version 3, table has version %d",
+			iceberg.ErrInvalidArgument, df.FilePath(), formatVersion)
+	case isDV && (df.ReferencedDataFile() == nil || df.ContentOffset() == nil || df.ContentSizeInBytes() == nil):
+		return fmt.Errorf("%w: deletion vector %s must have a referenced data file, content offset and size",
+			iceberg.ErrInvalidArgument, df.FilePath())
+	case !isDV && df.ContentType() == iceberg.EntryContentPosDeletes && formatVersion >= 3:
+		return fmt.Errorf("%w: position delete file %s cannot be added to a format version %d table, which uses deletion vectors",
+			iceberg.ErrInvalidArgument, df.FilePath(), formatVersion)
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newVersionedDeleteTable(t *testing.T, version int) *table.Table {
+	tbl := newTestTable(t, withTestFormatVersion(version))
+
+	arrSchema, err := table.SchemaToArrowSchema(tbl.Schema(), nil, false, false)
+	require.NoError(t, err)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{
+		`[{"id": 0}, {"id": 1}, {"id": 2}, {"id": 3}, {"id": 4}]`,
+	})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	tbl, err = tbl.AppendTable(context.Background(), arrTbl, 10, nil)
+	require.NoError(t, err)
+
+	return tbl
+}
+
+func commitDeletes(t *testing.T, tbl *table.Table, files []iceberg.DataFile) (*table.Table, error) {
+	txn := tbl.NewTransaction()
+	if err := txn.AddDeleteFiles(context.Background(), files, nil); err != nil {
+		return nil, err
+	}
+
+	return txn.Commit(context.Background())
+}
+
+func TestDeletionVectors(t *testing.T) {
+	ctx := context.Background()
+	tbl := newVersionedDeleteTable(t, 3)
+
+	tasks, err := tbl.Scan().PlanFiles(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	dataPath := tasks[0].File.FilePath()
+
+	w, err := table.NewDeletionVectorWriter(ctx, tbl)
+	require.NoError(t, err)
+	require.NoError(t, w.Delete(dataPath, 3))
+	require.NoError(t, w.Delete(dataPath, 1))
+	assert.ErrorIs(t, w.Delete(dataPath, -1), iceberg.ErrInvalidArgument)
+
+	files, err := w.Close()
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	dv := files[0]
+	assert.Equal(t, iceberg.EntryContentPosDeletes, dv.ContentType())
+	assert.Equal(t, iceberg.PuffinFile, dv.FileFormat())
+	assert.EqualValues(t, 2, dv.Count())
+	require.NotNil(t, dv.ReferencedDataFile())
+	assert.Equal(t, dataPath, *dv.ReferencedDataFile())
+	require.NotNil(t, dv.ContentOffset())
+	assert.EqualValues(t, 4, *dv.ContentOffset())
+	require.NotNil(t, dv.ContentSizeInBytes())
+	assert.Less(t, *dv.ContentSizeInBytes(), dv.FileSizeBytes())
+	assert.ErrorIs(t, w.Delete(dataPath, 0), table.ErrInvalidOperation)
+
+	tbl, err = commitDeletes(t, tbl, files)
+	require.NoError(t, err)
+	assert.Equal(t, table.OpDelete, tbl.CurrentSnapshot().Summary.Operation)
+	assert.Equal(t, "1", tbl.CurrentSnapshot().Summary.Properties["added-dvs"])
+	assert.Equal(t, "2", tbl.CurrentSnapshot().Summary.Properties["total-position-deletes"])
+	assert.Equal(t, []int64{0, 2, 4}, scanIDs(t, tbl.Scan()))
+
+	// a new deletion vector replaces the previous one, including its deletes
+	w, err = table.NewDeletionVectorWriter(ctx, tbl)
+	require.NoError(t, err)
+	require.NoError(t, w.Delete(dataPath, 4))
+	files, err = w.Close()
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.EqualValues(t, 3, files[0].Count())
+
+	tbl, err = commitDeletes(t, tbl, files)
+	require.NoError(t, err)
+	summary := tbl.CurrentSnapshot().Summary.Properties
+	assert.Equal(t, "1", summary["added-dvs"])
+	assert.Equal(t, "1", summary["removed-dvs"])
+	assert.Equal(t, "1", summary["total-delete-files"])
+	assert.Equal(t, "3", summary["total-position-deletes"])
+	assert.Equal(t, []int64{0, 2}, scanIDs(t, tbl.Scan()))
+
+	_, err = commitDeletes(t, tbl, []iceberg.DataFile{files[0], files[0]})
+	assert.ErrorIs(t, err, iceberg.ErrValidation)
+}
+
+func TestDeleteFormatVersionRefusals(t *testing.T) {
+	ctx := context.Background()
+
+	v1, v2, v3 := newVersionedDeleteTable(t, 1), newVersionedDeleteTable(t, 2), newVersionedDeleteTable(t, 3)
+
+	_, err := table.NewPositionDeleteWriter(ctx, v3)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	_, err = table.NewDeletionVectorWriter(ctx, v2)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+	posDeletes, err := iceberg.NewDataFileBuilder(*iceberg.UnpartitionedSpec, iceberg.EntryContentPosDeletes,
+		"file:///deletes.parquet", iceberg.ParquetFile, nil, nil, nil, 1, 100)
+	require.NoError(t, err)
+	dv, err := iceberg.NewDataFileBuilder(*iceberg.UnpartitionedSpec, iceberg.EntryContentPosDeletes,
+		"file:///deletes.puffin", iceberg.PuffinFile, nil, nil, nil, 1, 100)
+	require.NoError(t, err)
+	dv.ReferencedDataFile("file:///data.parquet").ContentOffset(4).ContentSizeInBytes(40)
+
+	_, err = iceberg.NewDataFileBuilder(*iceberg.UnpartitionedSpec, iceberg.EntryContentData,
+		"file:///data.puffin", iceberg.PuffinFile, nil, nil, nil, 1, 100)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+	tests := []struct {
+		name string
+		tbl  *table.Table
+		file iceberg.DataFile
+	}{
+		{"v1 position deletes", v1, posDeletes.Build()},
+		{"v2 deletion vector", v2, dv.Build()},
+		{"v3 position deletes", v3, posDeletes.Build()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := commitDeletes(t, tt.tbl, []iceberg.DataFile{tt.file})
+			assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+		})
+	}
+
+	// position delete files remain valid for version 2 tables
+	w, err := table.NewPositionDeleteWriter(ctx, v2)
+	require.NoError(t, err)
+	tasks, err := v2.Scan().PlanFiles(ctx)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(tasks[0].File.FilePath(), 0))
+	files, err := w.Close()
+	require.NoError(t, err)
+
+	v2, err = commitDeletes(t, v2, files)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 3, 4}, scanIDs(t, v2.Scan()))
+}
@@ -0,0 +1,289 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/compute"
+	"github.com/apache/iceberg-go"
+)
+
+// DistributionMode controls how the rows of a write are distributed
+// between the writers of its data files, see WriteDistributionModeKey.
+type DistributionMode string
+
+const (
+	// DistributionNone does not redistribute rows: each write worker has
+	// its own writer for every partition of the rows it handles, which
+	// can produce a file per worker per partition.
+	DistributionNone DistributionMode = "none"
+	// DistributionHash routes all rows of a partition to a single writer,
+	// so that each partition is written to as few files as possible.
+	DistributionHash DistributionMode = "hash"
+	// DistributionRange sorts the rows by partition and then by the
+	// table's sort order before writing them, so that each file holds a
+	// narrow range of the sort key. The rows of the write are buffered in
+	// memory to sort them.
+	DistributionRange DistributionMode = "range"
+)
+
+// writeDistributionMode returns the distribution mode set in props, or
+// the default for tables with the given spec.
+func writeDistributionMode(props iceberg.Properties, spec iceberg.PartitionSpec) (DistributionMode, error) {
+	mode := DistributionMode(strings.ToLower(props.Get(WriteDistributionModeKey, "")))
+	switch mode {
+	case "":
+		if spec.IsUnpartitioned() {
+			return DistributionNone, nil
+		}
+
+		return DistributionHash, nil
+	case DistributionNone, DistributionHash, DistributionRange:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: invalid %s %q, must be one of %s, %s or %s",
+			iceberg.ErrInvalidArgument, WriteDistributionModeKey, mode,
+			DistributionNone, DistributionHash, DistributionRange)
+	}
+}
+
+// rangeSortKey is a key the rows of a range distributed write are sorted
+// by.
+type rangeSortKey struct {
+	sourceID   int
+	transform  iceberg.Transform
+	desc       bool
+	nullsFirst bool
+}
+
+// rangeSortKeys returns the partition fields of spec, then the fields of
+// sortOrder, as sort keys.
+func rangeSortKeys(spec iceberg.PartitionSpec, sortOrder SortOrder) []rangeSortKey {
+	keys := make([]rangeSortKey, 0, spec.NumFields()+sortOrder.Len())
+	for field := range spec.Fields() {
+		keys = append(keys, rangeSortKey{
+			sourceID: field.SourceID, transform: field.Transform, nullsFirst: true,
+		})
+	}
+	for field := range sortOrder.Fields() {
+		keys = append(keys, rangeSortKey{
+			sourceID:   field.SourceID,
+			transform:  field.Transform,
+			desc:       field.Direction == SortDESC,
+			nullsFirst: field.NullOrder == NullsFirst,
+		})
+	}
+
+	return keys
+}
+
+// rangeDistribute returns the records of itr sorted by keys, in batches
+// no larger than the largest record of itr. Records are returned unchanged
+// if there are no keys.
+func rangeDistribute(ctx context.Context, itr iter.Seq2[arrow.RecordBatch, error], schema *iceberg.Schema, keys []rangeSortKey) iter.Seq2[arrow.RecordBatch, error] {
+	if len(keys) == 0 {
+		return itr
+	}
+
+	return func(yield func(arrow.RecordBatch, error) bool) {
+		var (
+			records []arrow.RecordBatch
+			maxRows int64
+		)
+		defer func() {
+			for _, rec := range records {
+				rec.Release()
+			}
+		}()
+
+		for rec, err := range itr {
+			if err != nil {
+				yield(nil, err)
+
+				return
+			}
+
+			rec.Retain()
+			records = append(records, rec)
+			maxRows = max(maxRows, rec.NumRows())
+		}
+		if maxRows == 0 {
+			return
+		}
+
+		combined, err := concatenateRecords(ctx, records)
+		if err != nil {
+			yield(nil, err)
+
+			return
+		}
+		defer combined.Release()
+
+		indices, err := sortedRowIndices(combined, schema, keys)
+		if err != nil {
+			yield(nil, err)
+
+			return
+		}
+
+		take := partitionBatchByKey(ctx)
+		for chunk := range slices.Chunk(indices, int(maxRows)) {
+			rec, err := take(combined, chunk)
+			if err != nil {
+				yield(nil, err)
+
+				return
+			}
+
+			ok := yield(rec, nil)
+			rec.Release()
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// concatenateRecords returns a single record holding the rows of records,
+// which must share a schema.
+func concatenateRecords(ctx context.Context, records []arrow.RecordBatch) (arrow.RecordBatch, error) {
+	mem := compute.GetAllocator(ctx)
+	sc := records[0].Schema()
+
+	var rows int64
+	for _, rec := range records {
+		rows += rec.NumRows()
+	}
+
+	cols := make([]arrow.Array, sc.NumFields())
+	defer func() {
+		for _, col := range cols {
+			if col != nil {
+				col.Release()
+			}
+		}
+	}()
+
+	chunks := make([]arrow.Array, len(records))
+	for i := range cols {
+		for j, rec := range records {
+			chunks[j] = rec.Column(i)
+		}
+
+		col, err := array.Concatenate(chunks, mem)
+		if err != nil {
+			return nil, err
+		}
+		cols[i] = col
+	}
+
+	return array.NewRecordBatch(sc, cols, rows), nil
+}
+
+// sortedRowIndices returns the indices of the rows of rec ordered by keys.
+// The values of each key are ranked once, so that rows are compared by
+// rank.
+func sortedRowIndices(rec arrow.RecordBatch, schema *iceberg.Schema, keys []rangeSortKey) ([]int64, error) {
+	type rankedColumn struct {
+		codes []int32
+		ranks []int
+	}
+
+	cols := make([]rankedColumn, len(keys))
+	for i, key := range keys {
+		name, ok := schema.FindColumnName(key.sourceID)
+		if !ok {
+			return nil, fmt.Errorf("%w: sort column %d not found in schema",
+				iceberg.ErrInvalidSchema, key.sourceID)
+		}
+
+		idx := rec.Schema().FieldIndices(name)
+		if len(idx) == 0 {
+			return nil, fmt.Errorf("%w: range distribution requires %s to be a top-level column",
+				iceberg.ErrNotImplemented, name)
+		}
+
+		pc, err := newPartitionColumn(rec.Column(idx[0]), key.transform)
+		if err != nil {
+			return nil, err
+		}
+
+		order := make([]int, len(pc.values))
+		for code := range order {
+			order[code] = code
+		}
+		slices.SortFunc(order, func(a, b int) int {
+			return compareSortValues(pc.values[a], pc.values[b], key)
+		})
+
+		ranks := make([]int, len(order))
+		for rank, code := range order {
+			ranks[code] = rank
+		}
+		cols[i] = rankedColumn{codes: pc.codes, ranks: ranks}
+	}
+
+	indices := make([]int64, rec.NumRows())
+	for i := range indices {
+		indices[i] = int64(i)
+	}
+	slices.SortStableFunc(indices, func(a, b int64) int {
+		for _, col := range cols {
+			if c := col.ranks[col.codes[a]] - col.ranks[col.codes[b]]; c != 0 {
+				return c
+			}
+		}
+
+		return 0
+	})
+
+	return indices, nil
+}
+
+// compareSortValues compares the transformed values a and b of a sort key.
+func compareSortValues(a, b any, key rangeSortKey) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		if key.nullsFirst {
+			return -1
+		}
+
+		return 1
+	case b == nil:
+		if key.nullsFirst {
+			return 1
+		}
+
+		return -1
+	}
+
+	if key.desc {
+		return compareGroupValues(b, a)
+	}
+
+	return compareGroupValues(a, b)
+}
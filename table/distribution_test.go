@@ -0,0 +1,186 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// distributionTestTable creates a table partitioned by category if
+// partitioned is set, and sorted by id.
+func distributionTestTable(t *testing.T, partitioned bool) *table.Table {
+	t.Helper()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "category", Type: iceberg.PrimitiveTypes.String, Required: true})
+
+	spec := iceberg.UnpartitionedSpec
+	if partitioned {
+		s := iceberg.NewPartitionSpec(iceberg.PartitionField{
+			SourceID: 2, FieldID: 1000, Name: "category", Transform: iceberg.IdentityTransform{}})
+		spec = &s
+	}
+
+	sortOrder, err := table.NewSortOrder(1, []table.SortField{{
+		SourceID: 1, Transform: iceberg.IdentityTransform{},
+		Direction: table.SortASC, NullOrder: table.NullsFirst,
+	}})
+	require.NoError(t, err)
+
+	return newTestTable(t, withTestSchema(sc), withTestSpec(spec), withTestSortOrder(sortOrder))
+}
+
+// shuffledRecords returns a reader of the ids [0, n) in random order, in
+// records of 10 rows, with categories a and b alternating by id.
+func shuffledRecords(t *testing.T, n int) array.RecordReader {
+	t.Helper()
+
+	arrSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "category", Type: arrow.BinaryTypes.String},
+	}, nil)
+
+	ids := rand.New(rand.NewPCG(1, 2)).Perm(n)
+	var recs []arrow.RecordBatch
+	for chunk := range slices.Chunk(ids, 10) {
+		rows := make([]string, len(chunk))
+		for i, id := range chunk {
+			rows[i] = fmt.Sprintf(`{"id": %d, "category": %q}`, id, string(rune('a'+id%2)))
+		}
+
+		rec, _, err := array.RecordFromJSON(memory.DefaultAllocator, arrSchema,
+			strings.NewReader("["+strings.Join(rows, ",")+"]"))
+		require.NoError(t, err)
+		recs = append(recs, rec)
+	}
+
+	rdr, err := array.NewRecordReader(arrSchema, recs)
+	require.NoError(t, err)
+	for _, rec := range recs {
+		rec.Release()
+	}
+	t.Cleanup(rdr.Release)
+
+	return rdr
+}
+
+func dataFiles(t *testing.T, tbl *table.Table) []iceberg.DataFile {
+	t.Helper()
+
+	tasks, err := tbl.Scan().PlanFiles(context.Background())
+	require.NoError(t, err)
+
+	files := make([]iceberg.DataFile, len(tasks))
+	for i, task := range tasks {
+		files[i] = task.File
+	}
+
+	return files
+}
+
+func TestWriteDistributionHash(t *testing.T) {
+	tbl := distributionTestTable(t, true)
+
+	tbl, err := tbl.Append(context.Background(), shuffledRecords(t, 100), nil,
+		table.WithDistributionMode(table.DistributionHash))
+	require.NoError(t, err)
+
+	// every partition is written to a single file
+	files := dataFiles(t, tbl)
+	require.Len(t, files, 2)
+	for _, f := range files {
+		assert.EqualValues(t, 50, f.Count())
+	}
+}
+
+func TestWriteDistributionNone(t *testing.T) {
+	tbl := distributionTestTable(t, true)
+
+	tbl, err := tbl.Append(context.Background(), shuffledRecords(t, 100), nil,
+		table.WithDistributionMode(table.DistributionNone))
+	require.NoError(t, err)
+
+	var records int64
+	for _, f := range dataFiles(t, tbl) {
+		records += f.Count()
+	}
+	assert.EqualValues(t, 100, records)
+}
+
+func TestWriteDistributionRange(t *testing.T) {
+	for _, partitioned := range []bool{false, true} {
+		t.Run(fmt.Sprintf("partitioned=%t", partitioned), func(t *testing.T) {
+			tbl := distributionTestTable(t, partitioned)
+
+			tbl, err := tbl.Append(context.Background(), shuffledRecords(t, 100), nil,
+				table.WithDistributionMode(table.DistributionRange),
+				table.WithTargetFileSize(256))
+			require.NoError(t, err)
+
+			files := dataFiles(t, tbl)
+			require.Greater(t, len(files), 2)
+
+			// the files of each partition hold disjoint ranges of ids
+			type idRange struct{ lower, upper int64 }
+			byPartition := make(map[any][]idRange)
+			var records int64
+			for _, f := range files {
+				lower, err := iceberg.LiteralFromBytes(iceberg.PrimitiveTypes.Int64, f.LowerBoundValues()[1])
+				require.NoError(t, err)
+				upper, err := iceberg.LiteralFromBytes(iceberg.PrimitiveTypes.Int64, f.UpperBoundValues()[1])
+				require.NoError(t, err)
+
+				part := f.Partition()[1000]
+				byPartition[part] = append(byPartition[part], idRange{
+					lower.Any().(int64), upper.Any().(int64),
+				})
+				records += f.Count()
+			}
+			assert.EqualValues(t, 100, records)
+
+			for part, ranges := range byPartition {
+				slices.SortFunc(ranges, func(a, b idRange) int { return int(a.lower - b.lower) })
+				for i := 1; i < len(ranges); i++ {
+					assert.Greater(t, ranges[i].lower, ranges[i-1].upper, "partition %v", part)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteDistributionInvalid(t *testing.T) {
+	tbl := distributionTestTable(t, true)
+
+	_, err := tbl.Append(context.Background(), shuffledRecords(t, 10), nil,
+		table.WithDistributionMode("random"))
+	require.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
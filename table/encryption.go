@@ -0,0 +1,187 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/encryption"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/google/uuid"
+)
+
+// EncryptedKey is an entry of the encryption-keys list of v3 table metadata.
+// It holds key metadata, such as a manifest list key, which is itself
+// encrypted by another key identified by EncryptedByID (either another
+// entry of the list or a key in the KMS).
+type EncryptedKey struct {
+	KeyID                string            `json:"key-id"`
+	EncryptedKeyMetadata []byte            `json:"encrypted-key-metadata"`
+	EncryptedByID        string            `json:"encrypted-by-id,omitempty"`
+	Properties           map[string]string `json:"properties,omitempty"`
+}
+
+func (e EncryptedKey) Equals(other EncryptedKey) bool {
+	return e.KeyID == other.KeyID && e.EncryptedByID == other.EncryptedByID &&
+		slices.Equal(e.EncryptedKeyMetadata, other.EncryptedKeyMetadata) &&
+		maps.Equal(e.Properties, other.Properties)
+}
+
+// tableEncryption is the encryption state of an encrypted table: the
+// manager which encrypts the manifests and manifest lists written to the
+// table, and the keys of the manifest lists of its snapshots.
+type tableEncryption struct {
+	// ctx is the context of the operation the table's IO was created
+	// for, used to call the KMS
+	ctx     context.Context
+	keys    func() iter.Seq[EncryptedKey]
+	manager func() (*encryption.StandardEncryptionManager, error)
+	kms     func() (encryption.KeyManagementClient, error)
+}
+
+// encryptedFS is implemented by the IO of encrypted tables, see
+// withTableEncryption.
+type encryptedFS interface {
+	tableEncryption() *tableEncryption
+}
+
+type encryptedIO struct {
+	iceio.IO
+	enc *tableEncryption
+}
+
+func (e *encryptedIO) tableEncryption() *tableEncryption { return e.enc }
+
+type encryptedWriteIO struct {
+	iceio.WriteFileIO
+	enc *tableEncryption
+}
+
+func (e *encryptedWriteIO) tableEncryption() *tableEncryption { return e.enc }
+
+// withTableEncryption returns the IO of a table with the given properties
+// and encryption keys, which is fs itself unless the table is encrypted.
+// The IO of an encrypted table carries its encryption, so that manifest
+// lists can be decrypted by Snapshot.Manifests and encrypted by snapshot
+// producers. The KMS is only loaded once it is needed.
+func withTableEncryption(ctx context.Context, fs iceio.IO, props iceberg.Properties, keys func() iter.Seq[EncryptedKey]) iceio.IO {
+	if props[encryption.TableKeyIDKey] == "" && !hasEncryptionKeys(keys()) {
+		return fs
+	}
+
+	enc := &tableEncryption{
+		ctx:  ctx,
+		keys: keys,
+		manager: sync.OnceValues(func() (*encryption.StandardEncryptionManager, error) {
+			return encryption.LoadManager(ctx, props)
+		}),
+		kms: sync.OnceValues(func() (encryption.KeyManagementClient, error) {
+			return encryption.LoadKMS(ctx, props)
+		}),
+	}
+
+	if wfs, ok := fs.(iceio.WriteFileIO); ok {
+		return &encryptedWriteIO{WriteFileIO: wfs, enc: enc}
+	}
+
+	return &encryptedIO{IO: fs, enc: enc}
+}
+
+func hasEncryptionKeys(keys iter.Seq[EncryptedKey]) bool {
+	for range keys {
+		return true
+	}
+
+	return false
+}
+
+// encryptionOf returns the encryption of the table fs belongs to, or nil
+// if the table is not encrypted.
+func encryptionOf(fs iceio.IO) *tableEncryption {
+	if efs, ok := fs.(encryptedFS); ok {
+		return efs.tableEncryption()
+	}
+
+	return nil
+}
+
+// newManifestListKey generates the key of a new manifest list, along with
+// the entry of the table's encryption keys holding its key metadata
+// wrapped with the table's key.
+func (e *tableEncryption) newManifestListKey(mgr *encryption.StandardEncryptionManager) (encryption.FileKey, EncryptedKey, error) {
+	key, err := mgr.NewFileKey()
+	if err != nil {
+		return encryption.FileKey{}, EncryptedKey{}, err
+	}
+
+	wrapped, err := mgr.WrapKeyMetadata(e.ctx, key.Metadata)
+	if err != nil {
+		return encryption.FileKey{}, EncryptedKey{}, err
+	}
+
+	return key, EncryptedKey{
+		KeyID:                uuid.NewString(),
+		EncryptedKeyMetadata: wrapped,
+		EncryptedByID:        mgr.TableKeyID(),
+	}, nil
+}
+
+// decryptManifestList returns a reader of the plaintext of the manifest
+// list f, encrypted with the key keyID of the table's encryption keys.
+func (e *tableEncryption) decryptManifestList(keyID string, f iceio.File) (io.Reader, error) {
+	var (
+		key   EncryptedKey
+		found bool
+	)
+	for k := range e.keys() {
+		if k.KeyID == keyID {
+			key, found = k, true
+
+			break
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("%w: encryption key %s not found in table metadata",
+			encryption.ErrInvalidKey, keyID)
+	}
+
+	kms, err := e.kms()
+	if err != nil {
+		return nil, err
+	}
+
+	keyMetadata, err := encryption.UnwrapKeyMetadata(e.ctx, kms, key.EncryptedKeyMetadata, key.EncryptedByID)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	return encryption.DecryptStream(f, info.Size(), keyMetadata)
+}
@@ -0,0 +1,194 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/encryption"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEncryptedTestTable(t *testing.T, opts ...testTableOption) (*table.Table, arrow.Table) {
+	t.Helper()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String})
+	tbl := newTestTable(t, append([]testTableOption{
+		withTestSchema(sc),
+		withTestFormatVersion(3),
+		withTestProperties(iceberg.Properties{encryption.TableKeyIDKey: "table-key"}),
+	}, opts...)...)
+
+	arrSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "data", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{
+		`[{"id": 1, "data": "a"}, {"id": 2, "data": "b"}, {"id": 3, "data": null}]`,
+	})
+	require.NoError(t, err)
+	t.Cleanup(arrTbl.Release)
+
+	return tbl, arrTbl
+}
+
+func newTestKMSContext(t *testing.T) context.Context {
+	t.Helper()
+
+	kms, err := encryption.NewInMemoryKMS(nil)
+	require.NoError(t, err)
+	require.NoError(t, kms.AddKey("table-key", 16))
+
+	return encryption.WithKMSClient(context.Background(), kms)
+}
+
+func requireFileHeader(t *testing.T, path, expected string) {
+	t.Helper()
+
+	f, err := iceio.LocalFS{}.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	header := make([]byte, 4)
+	_, err = io.ReadFull(f, header)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(header), path)
+}
+
+func TestEncryptedTable(t *testing.T) {
+	ctx := context.Background()
+	tbl, arrTbl := newEncryptedTestTable(t)
+
+	// writing without a KMS available must fail
+	_, err := tbl.AppendTable(ctx, arrTbl, 1, nil)
+	require.ErrorIs(t, err, encryption.ErrMissingKMS)
+
+	kmsCtx := newTestKMSContext(t)
+	tbl, err = tbl.AppendTable(kmsCtx, arrTbl, 1, nil)
+	require.NoError(t, err)
+
+	// the key metadata of the manifest list is wrapped with the table key
+	// and stored in the table metadata
+	snap := tbl.CurrentSnapshot()
+	require.NotNil(t, snap.KeyID)
+	var keys []table.EncryptedKey
+	for key := range tbl.Metadata().EncryptionKeys() {
+		keys = append(keys, key)
+	}
+	require.Len(t, keys, 1)
+	assert.Equal(t, *snap.KeyID, keys[0].KeyID)
+	assert.Equal(t, "table-key", keys[0].EncryptedByID)
+	_, err = encryption.DecodeKeyMetadata(keys[0].EncryptedKeyMetadata)
+	assert.Error(t, err, "the manifest list key must not be stored in plain text")
+	requireFileHeader(t, snap.ManifestList, "AGS1")
+
+	// the manifest list cannot be read without the keys of the table
+	_, err = snap.Manifests(iceio.LocalFS{})
+	require.ErrorIs(t, err, encryption.ErrMissingManager)
+
+	fs, err := tbl.FS(kmsCtx)
+	require.NoError(t, err)
+	manifests, err := snap.Manifests(fs)
+	require.NoError(t, err)
+	require.Len(t, manifests, 1)
+
+	// manifests and data files hold their keys in the standard key
+	// metadata format, protected by the encryption of their parent
+	manifestKey, err := encryption.DecodeKeyMetadata(manifests[0].KeyMetadata())
+	require.NoError(t, err)
+	assert.Len(t, manifestKey.EncryptionKey, encryption.DataKeyLengthDefault)
+	requireFileHeader(t, manifests[0].FilePath(), "AGS1")
+
+	info, err := iceio.LocalFS{}.Open(manifests[0].FilePath())
+	require.NoError(t, err)
+	stat, err := info.Stat()
+	require.NoError(t, err)
+	require.NoError(t, info.Close())
+	assert.Equal(t, stat.Size(), manifests[0].Length())
+
+	tasks, err := tbl.Scan().PlanFiles(kmsCtx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+
+	df := tasks[0].File
+	dataKey, err := encryption.DecodeKeyMetadata(df.KeyMetadata())
+	require.NoError(t, err)
+	assert.Len(t, dataKey.EncryptionKey, encryption.DataKeyLengthDefault)
+	// parquet modular encryption with an encrypted footer
+	requireFileHeader(t, df.FilePath(), "PARE")
+
+	result, err := tbl.Scan().ToArrowTable(kmsCtx)
+	require.NoError(t, err)
+	defer result.Release()
+
+	assert.True(t, array.TableEqual(arrTbl, result), "expected: %s\ngot: %s", arrTbl, result)
+
+	_, err = tbl.Scan().ToArrowTable(ctx)
+	assert.ErrorIs(t, err, encryption.ErrMissingKMS)
+}
+
+func TestEncryptedTableTransaction(t *testing.T) {
+	kmsCtx := newTestKMSContext(t)
+	tbl, arrTbl := newEncryptedTestTable(t)
+
+	// the second append reads the manifest list staged by the first one,
+	// whose key is only known to the transaction
+	txn := tbl.NewTransaction()
+	require.NoError(t, txn.AppendTable(kmsCtx, arrTbl, 1, nil))
+	require.NoError(t, txn.AppendTable(kmsCtx, arrTbl, 1, nil))
+
+	scan, err := txn.Scan()
+	require.NoError(t, err)
+	staged, err := scan.ToArrowTable(kmsCtx)
+	require.NoError(t, err)
+	defer staged.Release()
+	assert.EqualValues(t, 6, staged.NumRows())
+
+	tbl, err = txn.Commit(kmsCtx)
+	require.NoError(t, err)
+
+	var keys int
+	for range tbl.Metadata().EncryptionKeys() {
+		keys++
+	}
+	assert.Equal(t, 2, keys)
+
+	result, err := tbl.Scan().ToArrowTable(kmsCtx)
+	require.NoError(t, err)
+	defer result.Release()
+	assert.EqualValues(t, 6, result.NumRows())
+}
+
+func TestEncryptedTableRequiresV3(t *testing.T) {
+	tbl, arrTbl := newEncryptedTestTable(t, withTestFormatVersion(2))
+
+	_, err := tbl.AppendTable(newTestKMSContext(t), arrTbl, 1, nil)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	assert.ErrorContains(t, err, "format version 3")
+}
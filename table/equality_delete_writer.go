@@ -0,0 +1,170 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/iceberg-go"
+)
+
+// EqualityDeleteWriter writes equality delete files for a table. Each
+// delete row holds the values of the key columns, and deletes every row
+// of the table with equal values in those columns that was written
+// before the delete file was committed. The written files are returned
+// by Close and still need to be committed to the table.
+//
+// Deletes are global, applying to data files in every partition, unless
+// they are scoped to a single partition with WithDeletePartition.
+type EqualityDeleteWriter struct {
+	*deleteFileWriter
+}
+
+// NewEqualityDeleteWriter creates a writer for equality delete files of
+// the table keyed by the columns with the given field IDs of the
+// table's current schema. Key columns must be required primitive
+// columns that are not floating point, as the spec requires for
+// equality fields.
+func NewEqualityDeleteWriter(ctx context.Context, tbl *Table, equalityIDs []int, opts ...DeleteWriterOption) (*EqualityDeleteWriter, error) {
+	pinned := tbl.pin()
+	tbl = &pinned
+
+	if len(equalityIDs) == 0 {
+		return nil, fmt.Errorf("%w: equality deletes require at least one key column",
+			iceberg.ErrInvalidArgument)
+	}
+
+	schema := tbl.Metadata().CurrentSchema()
+	selected := make(map[int]iceberg.Void, len(equalityIDs))
+	for _, id := range equalityIDs {
+		if err := validateEqualityField(schema, id); err != nil {
+			return nil, err
+		}
+
+		if _, ok := selected[id]; ok {
+			return nil, fmt.Errorf("%w: duplicate equality field id %d",
+				iceberg.ErrInvalidArgument, id)
+		}
+		selected[id] = iceberg.Void{}
+	}
+
+	fileSchema, err := iceberg.PruneColumns(schema, selected, false)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := defaultDeleteWriterConfig(tbl.Metadata().Properties(), opts)
+
+	// global deletes are written with an unpartitioned spec so that they
+	// apply to the data files of every partition
+	var spec iceberg.PartitionSpec
+	if cfg.specID == nil {
+		found := false
+		for _, s := range tbl.Metadata().PartitionSpecs() {
+			if s.IsUnpartitioned() {
+				spec, found = s, true
+
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("%w: global equality deletes require an unpartitioned spec in the table, use WithDeletePartition instead",
+				iceberg.ErrInvalidArgument)
+		}
+	}
+
+	w, err := newDeleteFileWriter(ctx, tbl, fileSchema, iceberg.EntryContentEqDeletes, spec, cfg)
+	if err != nil {
+		return nil, err
+	}
+	w.equalityIDs = equalityIDs
+
+	return &EqualityDeleteWriter{deleteFileWriter: w}, nil
+}
+
+func validateEqualityField(schema *iceberg.Schema, id int) error {
+	field, ok := schema.FindFieldByID(id)
+	if !ok {
+		return fmt.Errorf("%w: equality field id %d not found in schema",
+			iceberg.ErrInvalidArgument, id)
+	}
+
+	if _, ok := field.Type.(iceberg.PrimitiveType); !ok {
+		return fmt.Errorf("%w: equality field %s must be a primitive type, got %s",
+			iceberg.ErrInvalidArgument, field.Name, field.Type)
+	}
+
+	switch field.Type.(type) {
+	case iceberg.Float32Type, iceberg.Float64Type:
+		return fmt.Errorf("%w: equality field %s must not be a floating point type, got %s",
+			iceberg.ErrInvalidArgument, field.Name, field.Type)
+	}
+
+	if !field.Required || schema.FieldHasOptionalParent(id) {
+		return fmt.Errorf("%w: equality field %s must be required",
+			iceberg.ErrInvalidArgument, field.Name)
+	}
+
+	return nil
+}
+
+// Schema returns the arrow schema of the records accepted by Write,
+// holding the key columns in the order of the table schema.
+func (w *EqualityDeleteWriter) Schema() *arrow.Schema { return w.arrSchema }
+
+// Write deletes the rows of the table whose key columns are equal to
+// any of the rows of the record, whose schema must match Schema.
+func (w *EqualityDeleteWriter) Write(rec arrow.RecordBatch) error {
+	if err := w.checkOpen(); err != nil {
+		return err
+	}
+
+	if err := w.checkSchema(rec.Schema()); err != nil {
+		return err
+	}
+
+	for i, col := range rec.Columns() {
+		if col.NullN() > 0 {
+			return fmt.Errorf("%w: equality delete column %s must not be null",
+				iceberg.ErrInvalidArgument, rec.ColumnName(i))
+		}
+	}
+
+	if w.add(array.NewRecordBatch(w.arrSchema, rec.Columns(), rec.NumRows()), recordNBytes(rec)) {
+		return w.flush("")
+	}
+
+	return nil
+}
+
+// Close writes any remaining deletes and returns all of the delete
+// files written. The writer cannot be used afterwards.
+func (w *EqualityDeleteWriter) Close() ([]iceberg.DataFile, error) {
+	if w.closed {
+		return w.files, nil
+	}
+
+	err := w.flush("")
+	w.closed = true
+
+	return w.files, err
+}
@@ -0,0 +1,138 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEqualityDeleteRecord(t *testing.T, sc *arrow.Schema, ids ...int64) arrow.RecordBatch {
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, sc)
+	defer bldr.Release()
+
+	bldr.Field(0).(*array.Int64Builder).AppendValues(ids, nil)
+
+	return bldr.NewRecordBatch()
+}
+
+func TestEqualityDeleteWriter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("global deletes", func(t *testing.T) {
+		tbl := newDeleteWriterTable(t)
+
+		w, err := table.NewEqualityDeleteWriter(ctx, tbl, []int{1})
+		require.NoError(t, err)
+		require.Equal(t, 1, w.Schema().NumFields())
+		assert.Equal(t, "id", w.Schema().Field(0).Name)
+
+		rec := newEqualityDeleteRecord(t, w.Schema(), 3, 1, 2)
+		defer rec.Release()
+		require.NoError(t, w.Write(rec))
+
+		files, err := w.Close()
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		df := files[0]
+		assert.Equal(t, iceberg.EntryContentEqDeletes, df.ContentType())
+		assert.Equal(t, []int{1}, df.EqualityFieldIDs())
+		assert.EqualValues(t, 3, df.Count())
+		assert.Zero(t, df.SpecID())
+		assert.Empty(t, df.Partition())
+
+		assert.ErrorIs(t, w.Write(rec), table.ErrInvalidOperation)
+	})
+
+	t.Run("invalid key columns", func(t *testing.T) {
+		sc := iceberg.NewSchema(0,
+			iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+			iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String},
+			iceberg.NestedField{ID: 3, Name: "score", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+			iceberg.NestedField{ID: 4, Name: "tags", Type: &iceberg.ListType{
+				ElementID: 5, Element: iceberg.PrimitiveTypes.String, ElementRequired: true,
+			}, Required: true})
+
+		tbl := newTestTable(t, withTestSchema(sc))
+
+		for _, ids := range [][]int{nil, {2}, {3}, {4}, {10}, {1, 1}} {
+			_, err := table.NewEqualityDeleteWriter(ctx, tbl, ids)
+			assert.ErrorIs(t, err, iceberg.ErrInvalidArgument, "ids %v", ids)
+		}
+	})
+
+	t.Run("partition scoped deletes", func(t *testing.T) {
+		sc := iceberg.NewSchema(0,
+			iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+			iceberg.NestedField{ID: 2, Name: "region", Type: iceberg.PrimitiveTypes.String, Required: true})
+		spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+			SourceID: 2, FieldID: 1000, Name: "region", Transform: iceberg.IdentityTransform{},
+		})
+
+		tbl := newTestTable(t, withTestSchema(sc), withTestSpec(&spec))
+
+		// the table has never been unpartitioned, so there is no spec to
+		// write global deletes with
+		_, err := table.NewEqualityDeleteWriter(ctx, tbl, []int{1})
+		assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+		w, err := table.NewEqualityDeleteWriter(ctx, tbl, []int{1},
+			table.WithDeletePartition(0, map[int]any{1000: "us"}))
+		require.NoError(t, err)
+
+		rec := newEqualityDeleteRecord(t, w.Schema(), 7)
+		defer rec.Release()
+		require.NoError(t, w.Write(rec))
+
+		files, err := w.Close()
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		df := files[0]
+		assert.Equal(t, []int{1}, df.EqualityFieldIDs())
+		assert.Equal(t, map[int]any{1000: "us"}, df.Partition())
+		assert.True(t, strings.Contains(df.FilePath(), "/region=us/"), df.FilePath())
+	})
+
+	t.Run("rolling", func(t *testing.T) {
+		tbl := newDeleteWriterTable(t)
+
+		w, err := table.NewEqualityDeleteWriter(ctx, tbl, []int{1}, table.WithDeleteTargetFileSize(1))
+		require.NoError(t, err)
+
+		for i := range int64(3) {
+			rec := newEqualityDeleteRecord(t, w.Schema(), i)
+			require.NoError(t, w.Write(rec))
+			rec.Release()
+		}
+
+		files, err := w.Close()
+		require.NoError(t, err)
+		assert.Len(t, files, 3)
+	})
+}
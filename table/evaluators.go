@@ -18,6 +18,8 @@
 package table
 
 import (
+	"cmp"
+	"encoding/binary"
 	"fmt"
 	"math"
 	"slices"
@@ -157,7 +159,7 @@ func (m *manifestEvalVisitor) VisitIn(term iceberg.BoundTerm, literals iceberg.S
 		return rowsMightMatch
 	}
 
-	lower, err := iceberg.LiteralFromBytes(term.Type(), *field.LowerBound)
+	lower, err := iceberg.BoundFromBytes(term.Type(), *field.LowerBound)
 	if err != nil {
 		panic(err)
 	}
@@ -167,7 +169,7 @@ func (m *manifestEvalVisitor) VisitIn(term iceberg.BoundTerm, literals iceberg.S
 	}
 
 	if field.UpperBound != nil {
-		upper, err := iceberg.LiteralFromBytes(term.Type(), *field.UpperBound)
+		upper, err := iceberg.BoundFromBytes(term.Type(), *field.UpperBound)
 		if err != nil {
 			panic(err)
 		}
@@ -286,7 +288,7 @@ func (m *manifestEvalVisitor) VisitEqual(term iceberg.BoundTerm, lit iceberg.Lit
 		return rowsCannotMatch
 	}
 
-	lower, err := iceberg.LiteralFromBytes(term.Ref().Type(), *field.LowerBound)
+	lower, err := iceberg.BoundFromBytes(term.Ref().Type(), *field.LowerBound)
 	if err != nil {
 		panic(err)
 	}
@@ -296,7 +298,7 @@ func (m *manifestEvalVisitor) VisitEqual(term iceberg.BoundTerm, lit iceberg.Lit
 		return rowsCannotMatch
 	}
 
-	upper, err := iceberg.LiteralFromBytes(term.Ref().Type(), *field.UpperBound)
+	upper, err := iceberg.BoundFromBytes(term.Ref().Type(), *field.UpperBound)
 	if err != nil {
 		panic(err)
 	}
@@ -322,7 +324,7 @@ func (m *manifestEvalVisitor) VisitGreaterEqual(term iceberg.BoundTerm, lit iceb
 		return rowsCannotMatch
 	}
 
-	upper, err := iceberg.LiteralFromBytes(term.Ref().Type(), *field.UpperBound)
+	upper, err := iceberg.BoundFromBytes(term.Ref().Type(), *field.UpperBound)
 	if err != nil {
 		panic(err)
 	}
@@ -342,7 +344,7 @@ func (m *manifestEvalVisitor) VisitGreater(term iceberg.BoundTerm, lit iceberg.L
 		return rowsCannotMatch
 	}
 
-	upper, err := iceberg.LiteralFromBytes(term.Ref().Type(), *field.UpperBound)
+	upper, err := iceberg.BoundFromBytes(term.Ref().Type(), *field.UpperBound)
 	if err != nil {
 		panic(err)
 	}
@@ -362,7 +364,7 @@ func (m *manifestEvalVisitor) VisitLessEqual(term iceberg.BoundTerm, lit iceberg
 		return rowsCannotMatch
 	}
 
-	lower, err := iceberg.LiteralFromBytes(term.Ref().Type(), *field.LowerBound)
+	lower, err := iceberg.BoundFromBytes(term.Ref().Type(), *field.LowerBound)
 	if err != nil {
 		panic(err)
 	}
@@ -382,7 +384,7 @@ func (m *manifestEvalVisitor) VisitLess(term iceberg.BoundTerm, lit iceberg.Lite
 		return rowsCannotMatch
 	}
 
-	lower, err := iceberg.LiteralFromBytes(term.Ref().Type(), *field.LowerBound)
+	lower, err := iceberg.BoundFromBytes(term.Ref().Type(), *field.LowerBound)
 	if err != nil {
 		panic(err)
 	}
@@ -411,7 +413,7 @@ func (m *manifestEvalVisitor) VisitStartsWith(term iceberg.BoundTerm, lit iceber
 		return rowsCannotMatch
 	}
 
-	lower, err := iceberg.LiteralFromBytes(term.Ref().Type(), *field.LowerBound)
+	lower, err := iceberg.BoundFromBytes(term.Ref().Type(), *field.LowerBound)
 	if err != nil {
 		panic(err)
 	}
@@ -439,7 +441,7 @@ func (m *manifestEvalVisitor) VisitStartsWith(term iceberg.BoundTerm, lit iceber
 		return rowsCannotMatch
 	}
 
-	upper, err := iceberg.LiteralFromBytes(term.Ref().Type(), *field.UpperBound)
+	upper, err := iceberg.BoundFromBytes(term.Ref().Type(), *field.UpperBound)
 	if err != nil {
 		panic(err)
 	}
@@ -474,12 +476,12 @@ func (m *manifestEvalVisitor) VisitNotStartsWith(term iceberg.BoundTerm, lit ice
 
 	// NotStartsWith will match unless ALL values must start with the prefix.
 	// this happens when the lower and upper bounds BOTH start with the prefix
-	lower, err := iceberg.LiteralFromBytes(term.Ref().Type(), *field.LowerBound)
+	lower, err := iceberg.BoundFromBytes(term.Ref().Type(), *field.LowerBound)
 	if err != nil {
 		panic(err)
 	}
 
-	upper, err := iceberg.LiteralFromBytes(term.Ref().Type(), *field.UpperBound)
+	upper, err := iceberg.BoundFromBytes(term.Ref().Type(), *field.UpperBound)
 	if err != nil {
 		panic(err)
 	}
@@ -608,6 +610,224 @@ func newInclusiveProjection(s *iceberg.Schema, spec iceberg.PartitionSpec, caseS
 	}).Project
 }
 
+type strictProjection struct{ projectionEvaluator }
+
+func (p *strictProjection) Project(expr iceberg.BooleanExpression) (iceberg.BooleanExpression, error) {
+	expr, err := iceberg.RewriteNotExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	bound, err := iceberg.BindExpr(p.schema, expr, p.caseSensitive)
+	if err != nil {
+		return nil, err
+	}
+
+	return iceberg.VisitExpr(bound, p)
+}
+
+func (p *strictProjection) VisitBound(pred iceberg.BoundPredicate) iceberg.BooleanExpression {
+	parts := p.spec.FieldsBySourceID(pred.Term().Ref().Field().ID)
+
+	var result iceberg.BooleanExpression = iceberg.AlwaysFalse{}
+	for _, part := range parts {
+		// consider (ts > 2019-01-01T01:00:00) with day(ts) and hour(ts)
+		// projections: d > 2019-01-01 and h > 2019-01-01-01. any row in a
+		// partition matching either projection matches the predicate, e.g.
+		// ts = 2019-01-01T03:00:00 matches the hour projection but not the
+		// day projection, so the projections are combined with or.
+		strictProjection, err := part.Transform.ProjectStrict(part.Name, pred)
+		if err != nil {
+			panic(err)
+		}
+		if strictProjection != nil {
+			result = iceberg.NewOr(result, strictProjection)
+		}
+	}
+
+	return result
+}
+
+// newStrictProjection returns a function projecting a row filter onto the
+// partition spec such that every row in a partition matching the projected
+// expression matches the row filter.
+func newStrictProjection(s *iceberg.Schema, spec iceberg.PartitionSpec, caseSensitive bool) func(iceberg.BooleanExpression) (iceberg.BooleanExpression, error) {
+	return (&strictProjection{
+		projectionEvaluator: projectionEvaluator{
+			schema:        s,
+			spec:          spec,
+			caseSensitive: caseSensitive,
+		},
+	}).Project
+}
+
+// newStrictPartitionEvaluator returns a function reporting whether every
+// row of a data file written with spec matches filter, judging by the
+// partition of the file alone. The returned function holds mutable state
+// and must not be shared between goroutines.
+func newStrictPartitionEvaluator(spec iceberg.PartitionSpec, schema *iceberg.Schema, filter iceberg.BooleanExpression, caseSensitive bool) (func(iceberg.DataFile) (bool, error), error) {
+	partExpr, err := newStrictProjection(schema, spec, caseSensitive)(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if partExpr.Equals(iceberg.AlwaysFalse{}) {
+		return func(iceberg.DataFile) (bool, error) { return false, nil }, nil
+	}
+
+	partType := spec.PartitionType(schema)
+	plan := partitionPlan{
+		partType:   partType,
+		partSchema: iceberg.NewSchema(0, partType.FieldList...),
+		partExpr:   partExpr,
+	}
+
+	return plan.evaluator(caseSensitive)
+}
+
+// residualEvaluator derives the residual of a row filter for the data
+// files of a partition spec: the part of the filter that the rows of a
+// file must still be tested against given the partition of the file.
+// Predicates that every row of the partition satisfies are replaced with
+// AlwaysTrue and predicates that no row of it can satisfy with
+// AlwaysFalse. It holds mutable state and must not be shared between
+// goroutines.
+type residualEvaluator struct {
+	projectionEvaluator
+
+	expr     iceberg.BooleanExpression
+	partType *iceberg.StructType
+	rec      partitionRecord
+	// leaves holds the partition evaluators of the predicates of expr in
+	// the order they are visited, so they are built once per spec rather
+	// than once per file
+	leaves      []residualLeaf
+	next        int
+	partitioned bool
+}
+
+type residualLeaf struct {
+	strict, inclusive []func(iceberg.StructLike) (bool, error)
+}
+
+// newResidualEvaluator returns an evaluator of the residuals of filter,
+// which must be bound to schema, for the data files written with spec.
+func newResidualEvaluator(spec iceberg.PartitionSpec, schema *iceberg.Schema, filter iceberg.BooleanExpression, caseSensitive bool) (*residualEvaluator, error) {
+	expr, err := iceberg.RewriteNotExpr(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	partType := spec.PartitionType(schema)
+	r := &residualEvaluator{
+		projectionEvaluator: projectionEvaluator{
+			spec:          spec,
+			schema:        iceberg.NewSchema(0, partType.FieldList...),
+			caseSensitive: caseSensitive,
+		},
+		expr:     expr,
+		partType: partType,
+		rec:      make(partitionRecord, len(partType.FieldList)),
+	}
+
+	if _, err := iceberg.VisitExpr(expr, &residualLeafVisitor{r}); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Residual returns the residual of the filter for dataFile.
+func (r *residualEvaluator) Residual(dataFile iceberg.DataFile) (iceberg.BooleanExpression, error) {
+	if !r.partitioned {
+		return r.expr, nil
+	}
+
+	fillPartitionRecord(r.rec, dataFile, r.partType)
+	r.next = 0
+
+	return iceberg.VisitExpr(r.expr, r)
+}
+
+func (r *residualEvaluator) VisitBound(pred iceberg.BoundPredicate) iceberg.BooleanExpression {
+	leaf := r.leaves[r.next]
+	r.next++
+
+	// a row in a partition matching a strict projection of the predicate
+	// matches the predicate, and a row in a partition not matching an
+	// inclusive projection cannot
+	for _, fn := range leaf.strict {
+		ok, err := fn(r.rec)
+		if err != nil {
+			panic(err)
+		}
+		if ok {
+			return iceberg.AlwaysTrue{}
+		}
+	}
+
+	for _, fn := range leaf.inclusive {
+		ok, err := fn(r.rec)
+		if err != nil {
+			panic(err)
+		}
+		if !ok {
+			return iceberg.AlwaysFalse{}
+		}
+	}
+
+	return pred
+}
+
+// residualLeafVisitor builds the partition evaluators of the predicates
+// of a residualEvaluator's filter.
+type residualLeafVisitor struct{ r *residualEvaluator }
+
+func (*residualLeafVisitor) VisitTrue() bool          { return true }
+func (*residualLeafVisitor) VisitFalse() bool         { return true }
+func (*residualLeafVisitor) VisitNot(bool) bool       { return true }
+func (*residualLeafVisitor) VisitAnd(bool, bool) bool { return true }
+func (*residualLeafVisitor) VisitOr(bool, bool) bool  { return true }
+func (v *residualLeafVisitor) VisitUnbound(pred iceberg.UnboundPredicate) bool {
+	v.r.VisitUnbound(pred)
+
+	return true
+}
+
+func (v *residualLeafVisitor) VisitBound(pred iceberg.BoundPredicate) bool {
+	var leaf residualLeaf
+	for _, part := range v.r.spec.FieldsBySourceID(pred.Term().Ref().Field().ID) {
+		strict, err := part.Transform.ProjectStrict(part.Name, pred)
+		if err != nil {
+			panic(err)
+		}
+		if strict != nil {
+			leaf.strict = append(leaf.strict, v.evaluator(strict))
+		}
+
+		incl, err := part.Transform.Project(part.Name, pred)
+		if err != nil {
+			panic(err)
+		}
+		if incl != nil {
+			leaf.inclusive = append(leaf.inclusive, v.evaluator(incl))
+		}
+	}
+	v.r.leaves = append(v.r.leaves, leaf)
+	v.r.partitioned = v.r.partitioned || len(leaf.strict) > 0 || len(leaf.inclusive) > 0
+
+	return true
+}
+
+func (v *residualLeafVisitor) evaluator(expr iceberg.BooleanExpression) func(iceberg.StructLike) (bool, error) {
+	fn, err := iceberg.ExpressionEvaluator(v.r.schema, expr, v.r.caseSensitive)
+	if err != nil {
+		panic(err)
+	}
+
+	return fn
+}
+
 type metricsEvaluator struct {
 	valueCounts map[int]int64
 	nullCounts  map[int]int64
@@ -663,6 +883,74 @@ func (m *metricsEvaluator) isNan(v iceberg.Literal) bool {
 	}
 }
 
+// compareBound compares the serialized bound b of a column of type typ
+// with lit. Bounds of integer, float and string columns are compared in
+// place, without decoding them to a Literal, since planning compares
+// bounds for every file of a scan. nan reports a NaN float bound, which
+// makes the bounds of the file unreliable.
+func (m *metricsEvaluator) compareBound(typ iceberg.Type, b []byte, lit iceberg.Literal) (result int, nan bool) {
+	switch lit := lit.(type) {
+	case iceberg.Int32Literal:
+		if len(b) == 4 {
+			return cmp.Compare(int32(binary.LittleEndian.Uint32(b)), int32(lit)), false
+		}
+	case iceberg.DateLiteral:
+		if len(b) == 4 {
+			return cmp.Compare(int32(binary.LittleEndian.Uint32(b)), int32(lit)), false
+		}
+	case iceberg.Int64Literal:
+		if len(b) == 8 {
+			return cmp.Compare(int64(binary.LittleEndian.Uint64(b)), int64(lit)), false
+		}
+	case iceberg.TimeLiteral:
+		if len(b) == 8 {
+			return cmp.Compare(int64(binary.LittleEndian.Uint64(b)), int64(lit)), false
+		}
+	case iceberg.TimestampLiteral:
+		if len(b) == 8 {
+			return cmp.Compare(int64(binary.LittleEndian.Uint64(b)), int64(lit)), false
+		}
+	case iceberg.Float32Literal:
+		if len(b) == 4 {
+			v := math.Float32frombits(binary.LittleEndian.Uint32(b))
+			if math.IsNaN(float64(v)) {
+				return 0, true
+			}
+
+			return cmp.Compare(v, float32(lit)), false
+		}
+	case iceberg.Float64Literal:
+		if len(b) == 8 {
+			v := math.Float64frombits(binary.LittleEndian.Uint64(b))
+			if math.IsNaN(v) {
+				return 0, true
+			}
+
+			return cmp.Compare(v, float64(lit)), false
+		}
+	case iceberg.StringLiteral:
+		// comparing the converted bytes does not copy them
+		switch {
+		case string(b) < string(lit):
+			return -1, false
+		case string(b) > string(lit):
+			return 1, false
+		default:
+			return 0, false
+		}
+	}
+
+	bound, err := iceberg.BoundFromBytes(typ, b)
+	if err != nil {
+		panic(err)
+	}
+	if m.isNan(bound) {
+		return 0, true
+	}
+
+	return getCmpLiteral(bound)(bound, lit), false
+}
+
 func newInclusiveMetricsEvaluator(s *iceberg.Schema, expr iceberg.BooleanExpression,
 	caseSensitive bool, includeEmptyFiles bool,
 ) (func(iceberg.DataFile) (bool, error), error) {
@@ -676,6 +964,13 @@ func newInclusiveMetricsEvaluator(s *iceberg.Schema, expr iceberg.BooleanExpress
 		return nil, err
 	}
 
+	if bound.Equals(iceberg.AlwaysTrue{}) {
+		// no predicate to test against the column metrics, skip decoding them
+		return func(file iceberg.DataFile) (bool, error) {
+			return includeEmptyFiles || file.Count() != 0, nil
+		}, nil
+	}
+
 	return (&inclusiveMetricsEval{
 		st:                s.AsStruct(),
 		includeEmptyFiles: includeEmptyFiles,
@@ -845,17 +1140,13 @@ func (m *inclusiveMetricsEval) VisitLess(t iceberg.BoundTerm, lit iceberg.Litera
 	}
 
 	if lowerBoundBytes := m.lowerBounds[fieldID]; lowerBoundBytes != nil {
-		lowerBound, err := iceberg.LiteralFromBytes(field.Type, lowerBoundBytes)
-		if err != nil {
-			panic(err)
-		}
-
-		if m.isNan(lowerBound) {
+		result, nan := m.compareBound(field.Type, lowerBoundBytes, lit)
+		if nan {
 			// nan indicates unreliable bounds
 			return rowsMightMatch
 		}
 
-		if getCmpLiteral(lowerBound)(lowerBound, lit) >= 0 {
+		if result >= 0 {
 			return rowsCannotMatch
 		}
 	}
@@ -877,17 +1168,13 @@ func (m *inclusiveMetricsEval) VisitLessEqual(t iceberg.BoundTerm, lit iceberg.L
 	}
 
 	if lowerBoundBytes := m.lowerBounds[fieldID]; lowerBoundBytes != nil {
-		lowerBound, err := iceberg.LiteralFromBytes(field.Type, lowerBoundBytes)
-		if err != nil {
-			panic(err)
-		}
-
-		if m.isNan(lowerBound) {
+		result, nan := m.compareBound(field.Type, lowerBoundBytes, lit)
+		if nan {
 			// nan indicates unreliable bounds
 			return rowsMightMatch
 		}
 
-		if getCmpLiteral(lowerBound)(lowerBound, lit) > 0 {
+		if result > 0 {
 			return rowsCannotMatch
 		}
 	}
@@ -909,16 +1196,12 @@ func (m *inclusiveMetricsEval) VisitGreater(t iceberg.BoundTerm, lit iceberg.Lit
 	}
 
 	if upperBoundBytes := m.upperBounds[fieldID]; upperBoundBytes != nil {
-		upperBound, err := iceberg.LiteralFromBytes(field.Type, upperBoundBytes)
-		if err != nil {
-			panic(err)
+		result, nan := m.compareBound(field.Type, upperBoundBytes, lit)
+		if nan {
+			return rowsMightMatch
 		}
 
-		if getCmpLiteral(upperBound)(upperBound, lit) <= 0 {
-			if m.isNan(upperBound) {
-				return rowsMightMatch
-			}
-
+		if result <= 0 {
 			return rowsCannotMatch
 		}
 	}
@@ -940,16 +1223,12 @@ func (m *inclusiveMetricsEval) VisitGreaterEqual(t iceberg.BoundTerm, lit iceber
 	}
 
 	if upperBoundBytes := m.upperBounds[fieldID]; upperBoundBytes != nil {
-		upperBound, err := iceberg.LiteralFromBytes(field.Type, upperBoundBytes)
-		if err != nil {
-			panic(err)
+		result, nan := m.compareBound(field.Type, upperBoundBytes, lit)
+		if nan {
+			return rowsMightMatch
 		}
 
-		if getCmpLiteral(upperBound)(upperBound, lit) < 0 {
-			if m.isNan(upperBound) {
-				return rowsMightMatch
-			}
-
+		if result < 0 {
 			return rowsCannotMatch
 		}
 	}
@@ -970,35 +1249,24 @@ func (m *inclusiveMetricsEval) VisitEqual(t iceberg.BoundTerm, lit iceberg.Liter
 			iceberg.ErrInvalidTypeString, field.Type))
 	}
 
-	var cmp func(iceberg.Literal, iceberg.Literal) int
 	if lowerBoundBytes := m.lowerBounds[fieldID]; lowerBoundBytes != nil {
-		lowerBound, err := iceberg.LiteralFromBytes(field.Type, lowerBoundBytes)
-		if err != nil {
-			panic(err)
-		}
-
-		if m.isNan(lowerBound) {
+		result, nan := m.compareBound(field.Type, lowerBoundBytes, lit)
+		if nan {
 			return rowsMightMatch
 		}
 
-		cmp = getCmpLiteral(lowerBound)
-		if cmp(lowerBound, lit) == 1 {
+		if result > 0 {
 			return rowsCannotMatch
 		}
 	}
 
 	if upperBoundBytes := m.upperBounds[fieldID]; upperBoundBytes != nil {
-		upperBound, err := iceberg.LiteralFromBytes(field.Type, upperBoundBytes)
-		if err != nil {
-			panic(err)
-		}
-
-		if m.isNan(upperBound) {
+		result, nan := m.compareBound(field.Type, upperBoundBytes, lit)
+		if nan {
 			return rowsMightMatch
 		}
 
-		cmp = getCmpLiteral(upperBound)
-		if cmp(upperBound, lit) == -1 {
+		if result < 0 {
 			return rowsCannotMatch
 		}
 	}
@@ -1030,7 +1298,7 @@ func (m *inclusiveMetricsEval) VisitIn(t iceberg.BoundTerm, s iceberg.Set[iceber
 
 	values := s.Members()
 	if lowerBoundBytes := m.lowerBounds[fieldID]; lowerBoundBytes != nil {
-		lowerBound, err := iceberg.LiteralFromBytes(field.Type, lowerBoundBytes)
+		lowerBound, err := iceberg.BoundFromBytes(field.Type, lowerBoundBytes)
 		if err != nil {
 			panic(lowerBound)
 		}
@@ -1046,7 +1314,7 @@ func (m *inclusiveMetricsEval) VisitIn(t iceberg.BoundTerm, s iceberg.Set[iceber
 	}
 
 	if upperBoundBytes := m.upperBounds[fieldID]; upperBoundBytes != nil {
-		upperBound, err := iceberg.LiteralFromBytes(field.Type, upperBoundBytes)
+		upperBound, err := iceberg.BoundFromBytes(field.Type, upperBoundBytes)
 		if err != nil {
 			panic(err)
 		}
@@ -1094,7 +1362,7 @@ func (m *inclusiveMetricsEval) VisitStartsWith(t iceberg.BoundTerm, lit iceberg.
 	lenPrefix := len(prefix)
 
 	if lowerBoundBytes := m.lowerBounds[fieldID]; lowerBoundBytes != nil {
-		lowerBound, err := iceberg.LiteralFromBytes(field.Type, lowerBoundBytes)
+		lowerBound, err := iceberg.BoundFromBytes(field.Type, lowerBoundBytes)
 		if err != nil {
 			panic(err)
 		}
@@ -1117,7 +1385,7 @@ func (m *inclusiveMetricsEval) VisitStartsWith(t iceberg.BoundTerm, lit iceberg.
 	}
 
 	if upperBoundBytes := m.upperBounds[fieldID]; upperBoundBytes != nil {
-		upperBound, err := iceberg.LiteralFromBytes(field.Type, upperBoundBytes)
+		upperBound, err := iceberg.BoundFromBytes(field.Type, upperBoundBytes)
 		if err != nil {
 			panic(err)
 		}
@@ -1159,12 +1427,12 @@ func (m *inclusiveMetricsEval) VisitNotStartsWith(t iceberg.BoundTerm, lit icebe
 	// this happens when the lower and upper bounds both start with the prefix
 	lowerBoundBytes, upperBoundBytes := m.lowerBounds[fieldID], m.upperBounds[fieldID]
 	if lowerBoundBytes != nil && upperBoundBytes != nil {
-		lowerBound, err := iceberg.LiteralFromBytes(field.Type, lowerBoundBytes)
+		lowerBound, err := iceberg.BoundFromBytes(field.Type, lowerBoundBytes)
 		if err != nil {
 			panic(err)
 		}
 
-		upperBound, err := iceberg.LiteralFromBytes(field.Type, upperBoundBytes)
+		upperBound, err := iceberg.BoundFromBytes(field.Type, upperBoundBytes)
 		if err != nil {
 			panic(err)
 		}
@@ -1303,7 +1571,7 @@ func (m *strictMetricsEval) VisitLess(t iceberg.BoundTerm, lit iceberg.Literal)
 	}
 
 	if upperBoundBytes := m.upperBounds[fieldID]; upperBoundBytes != nil {
-		upperBound, err := iceberg.LiteralFromBytes(field.Type, upperBoundBytes)
+		upperBound, err := iceberg.BoundFromBytes(field.Type, upperBoundBytes)
 		if err != nil {
 			panic(err)
 		}
@@ -1325,7 +1593,7 @@ func (m *strictMetricsEval) VisitLessEqual(t iceberg.BoundTerm, lit iceberg.Lite
 	}
 
 	if upperBoundBytes := m.upperBounds[fieldID]; upperBoundBytes != nil {
-		upperBound, err := iceberg.LiteralFromBytes(field.Type, upperBoundBytes)
+		upperBound, err := iceberg.BoundFromBytes(field.Type, upperBoundBytes)
 		if err != nil {
 			panic(err)
 		}
@@ -1347,7 +1615,7 @@ func (m *strictMetricsEval) VisitGreater(t iceberg.BoundTerm, lit iceberg.Litera
 	}
 
 	if lowerBoundBytes := m.lowerBounds[fieldID]; lowerBoundBytes != nil {
-		lowerBound, err := iceberg.LiteralFromBytes(field.Type, lowerBoundBytes)
+		lowerBound, err := iceberg.BoundFromBytes(field.Type, lowerBoundBytes)
 		if err != nil {
 			panic(err)
 		}
@@ -1374,7 +1642,7 @@ func (m *strictMetricsEval) VisitGreaterEqual(t iceberg.BoundTerm, lit iceberg.L
 	}
 
 	if lowerBoundBytes := m.lowerBounds[fieldID]; lowerBoundBytes != nil {
-		lowerBound, err := iceberg.LiteralFromBytes(field.Type, lowerBoundBytes)
+		lowerBound, err := iceberg.BoundFromBytes(field.Type, lowerBoundBytes)
 		if err != nil {
 			panic(err)
 		}
@@ -1404,11 +1672,11 @@ func (m *strictMetricsEval) VisitEqual(t iceberg.BoundTerm, lit iceberg.Literal)
 	upperBytes := m.upperBounds[fieldID]
 
 	if lowerBytes != nil && upperBytes != nil {
-		lowerBound, err := iceberg.LiteralFromBytes(field.Type, lowerBytes)
+		lowerBound, err := iceberg.BoundFromBytes(field.Type, lowerBytes)
 		if err != nil {
 			panic(err)
 		}
-		upperBound, err := iceberg.LiteralFromBytes(field.Type, upperBytes)
+		upperBound, err := iceberg.BoundFromBytes(field.Type, upperBytes)
 		if err != nil {
 			panic(err)
 		}
@@ -1432,7 +1700,7 @@ func (m *strictMetricsEval) VisitNotEqual(t iceberg.BoundTerm, lit iceberg.Liter
 
 	var cmp func(iceberg.Literal, iceberg.Literal) int
 	if lowerBoundBytes := m.lowerBounds[fieldID]; lowerBoundBytes != nil {
-		lowerBound, err := iceberg.LiteralFromBytes(field.Type, lowerBoundBytes)
+		lowerBound, err := iceberg.BoundFromBytes(field.Type, lowerBoundBytes)
 		if err != nil {
 			panic(err)
 		}
@@ -1448,7 +1716,7 @@ func (m *strictMetricsEval) VisitNotEqual(t iceberg.BoundTerm, lit iceberg.Liter
 	}
 
 	if upperBoundBytes := m.upperBounds[fieldID]; upperBoundBytes != nil {
-		upperBound, err := iceberg.LiteralFromBytes(field.Type, upperBoundBytes)
+		upperBound, err := iceberg.BoundFromBytes(field.Type, upperBoundBytes)
 		if err != nil {
 			panic(err)
 		}
@@ -1478,7 +1746,7 @@ func (m *strictMetricsEval) VisitIn(t iceberg.BoundTerm, s iceberg.Set[iceberg.L
 	upperBytes := m.upperBounds[fieldID]
 
 	if lowerBytes != nil && upperBytes != nil {
-		lowerBound, err := iceberg.LiteralFromBytes(field.Type, lowerBytes)
+		lowerBound, err := iceberg.BoundFromBytes(field.Type, lowerBytes)
 		if err != nil {
 			panic(err)
 		}
@@ -1486,7 +1754,7 @@ func (m *strictMetricsEval) VisitIn(t iceberg.BoundTerm, s iceberg.Set[iceberg.L
 			return rowsMightNotMatch
 		}
 
-		upperBound, err := iceberg.LiteralFromBytes(field.Type, upperBytes)
+		upperBound, err := iceberg.BoundFromBytes(field.Type, upperBytes)
 		if err != nil {
 			panic(err)
 		}
@@ -1514,7 +1782,7 @@ func (m *strictMetricsEval) VisitNotIn(t iceberg.BoundTerm, s iceberg.Set[iceber
 
 	values := s.Members()
 	if lowerBoundBytes := m.lowerBounds[fieldID]; lowerBoundBytes != nil {
-		lowerBound, err := iceberg.LiteralFromBytes(field.Type, lowerBoundBytes)
+		lowerBound, err := iceberg.BoundFromBytes(field.Type, lowerBoundBytes)
 		if err != nil {
 			panic(err)
 		}
@@ -1530,7 +1798,7 @@ func (m *strictMetricsEval) VisitNotIn(t iceberg.BoundTerm, s iceberg.Set[iceber
 	}
 
 	if upperBoundBytes := m.upperBounds[fieldID]; upperBoundBytes != nil {
-		upperBound, err := iceberg.LiteralFromBytes(field.Type, upperBoundBytes)
+		upperBound, err := iceberg.BoundFromBytes(field.Type, upperBoundBytes)
 		if err != nil {
 			panic(err)
 		}
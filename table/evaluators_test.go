@@ -21,6 +21,7 @@ import (
 	"math"
 	"testing"
 
+	"github.com/apache/arrow-go/v18/arrow/decimal128"
 	"github.com/apache/iceberg-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -966,6 +967,18 @@ func (p *ProjectionTestSuite) TestStringTruncateProjection() {
 		{iceberg.NotEqualTo(ref, "aaa"), iceberg.AlwaysTrue{}},
 		{iceberg.IsIn(ref, "aaa", "aab"), iceberg.EqualTo(truncStr, "aa")},
 		{iceberg.NotIn(ref, "aaa", "aab"), iceberg.AlwaysTrue{}},
+		{iceberg.StartsWith(ref, "a"), iceberg.StartsWith(truncStr, "a")},
+		{iceberg.StartsWith(ref, "aa"), iceberg.EqualTo(truncStr, "aa")},
+		{iceberg.StartsWith(ref, "aaa"), iceberg.StartsWith(truncStr, "aa")},
+		{iceberg.NotStartsWith(ref, "a"), iceberg.NotStartsWith(truncStr, "a")},
+		{iceberg.NotStartsWith(ref, "aa"), iceberg.NotEqualTo(truncStr, "aa")},
+		// partition "aa" holds both "aab" and "aaa", so it cannot be pruned
+		{iceberg.NotStartsWith(ref, "aaa"), iceberg.AlwaysTrue{}},
+		// truncation counts code points rather than bytes
+		{iceberg.EqualTo(ref, "イロハ"), iceberg.EqualTo(truncStr, "イロ")},
+		{iceberg.IsIn(ref, "イロハ", "😀😁😂"), iceberg.IsIn(truncStr, "イロ", "😀😁")},
+		{iceberg.StartsWith(ref, "イロ"), iceberg.EqualTo(truncStr, "イロ")},
+		{iceberg.LessThan(ref, "ßßß"), iceberg.LessThanEqual(truncStr, "ßß")},
 	}
 
 	project := newInclusiveProjection(schema, spec, true)
@@ -1007,6 +1020,172 @@ func (p *ProjectionTestSuite) TestIntTruncateProjection() {
 	}
 }
 
+func (p *ProjectionTestSuite) TestStrictIdentityProjection() {
+	schema, spec := p.schema(), p.idSpec()
+
+	idRef, idPartRef := iceberg.Reference("id"), iceberg.Reference("id_part")
+	tests := []struct {
+		pred, expected iceberg.BooleanExpression
+	}{
+		{iceberg.NotNull(idRef), iceberg.NotNull(idPartRef)},
+		{iceberg.IsNull(idRef), iceberg.IsNull(idPartRef)},
+		{iceberg.LessThan(idRef, int64(100)), iceberg.LessThan(idPartRef, int64(100))},
+		{iceberg.EqualTo(idRef, int64(104)), iceberg.EqualTo(idPartRef, int64(104))},
+		{iceberg.NotEqualTo(idRef, int64(105)), iceberg.NotEqualTo(idPartRef, int64(105))},
+		{iceberg.IsIn(idRef, int64(3), 4, 5), iceberg.IsIn(idPartRef, int64(3), 4, 5)},
+		{iceberg.NotIn(idRef, int64(3), 4, 5), iceberg.NotIn(idPartRef, int64(3), 4, 5)},
+	}
+
+	project := newStrictProjection(schema, spec, true)
+	for _, tt := range tests {
+		p.Run(tt.pred.String(), func() {
+			expr, err := project(tt.pred)
+			p.Require().NoError(err)
+			p.Truef(tt.expected.Equals(expr), "expected: %s\ngot: %s", tt.expected, expr)
+		})
+	}
+}
+
+func (p *ProjectionTestSuite) TestStrictBucketProjection() {
+	schema, spec := p.schema(), p.bucketSpec()
+
+	dataRef, dataBkt := iceberg.Reference("data"), iceberg.Reference("data_bucket")
+	tests := []struct {
+		pred, expected iceberg.BooleanExpression
+	}{
+		{iceberg.NotNull(dataRef), iceberg.NotNull(dataBkt)},
+		{iceberg.IsNull(dataRef), iceberg.IsNull(dataBkt)},
+		{iceberg.LessThan(dataRef, "val"), iceberg.AlwaysFalse{}},
+		{iceberg.GreaterThanEqual(dataRef, "val"), iceberg.AlwaysFalse{}},
+		{iceberg.EqualTo(dataRef, "val"), iceberg.AlwaysFalse{}},
+		{iceberg.NotEqualTo(dataRef, "val"), iceberg.NotEqualTo(dataBkt, int32(14))},
+		{iceberg.IsIn(dataRef, "v1", "v2", "v3"), iceberg.AlwaysFalse{}},
+		{iceberg.NotIn(dataRef, "v1", "v2", "v3"), iceberg.NotIn(dataBkt, int32(1), 3, 13)},
+	}
+
+	project := newStrictProjection(schema, spec, true)
+	for _, tt := range tests {
+		p.Run(tt.pred.String(), func() {
+			expr, err := project(tt.pred)
+			p.Require().NoError(err)
+			p.Truef(tt.expected.Equals(expr), "expected: %s\ngot: %s", tt.expected, expr)
+		})
+	}
+}
+
+func (p *ProjectionTestSuite) TestStrictDayProjection() {
+	schema, spec := p.schema(), p.daySpec()
+
+	ref, date := iceberg.Reference("event_ts"), iceberg.Reference("date")
+	tests := []struct {
+		pred, expected iceberg.BooleanExpression
+	}{
+		{iceberg.NotNull(ref), iceberg.NotNull(date)},
+		{iceberg.IsNull(ref), iceberg.IsNull(date)},
+		{iceberg.LessThan(ref, "2022-11-27T00:00:00"), iceberg.LessThan(date, int32(19323))},
+		{iceberg.LessThanEqual(ref, "2022-11-26T23:59:59.999999"), iceberg.LessThan(date, int32(19323))},
+		{iceberg.GreaterThan(ref, "2022-11-26T23:59:59.999999"), iceberg.GreaterThan(date, int32(19322))},
+		{iceberg.GreaterThanEqual(ref, "2022-11-27T00:00:00"), iceberg.GreaterThan(date, int32(19322))},
+		{iceberg.EqualTo(ref, "2022-11-27T10:00:00"), iceberg.AlwaysFalse{}},
+		{iceberg.NotEqualTo(ref, "2022-11-27T10:00:00"), iceberg.NotEqualTo(date, int32(19323))},
+		{iceberg.IsIn(ref, "2022-11-27T00:00:00", "2022-11-26T23:59:59.999999"), iceberg.AlwaysFalse{}},
+		{iceberg.NotIn(ref, "2022-11-27T00:00:00", "2022-11-26T23:59:59.999999"), iceberg.NotIn(date, int32(19322), 19323)},
+	}
+
+	project := newStrictProjection(schema, spec, true)
+	for _, tt := range tests {
+		p.Run(tt.pred.String(), func() {
+			expr, err := project(tt.pred)
+			p.Require().NoError(err)
+			p.Truef(tt.expected.Equals(expr), "expected: %s\ngot: %s", tt.expected, expr)
+		})
+	}
+}
+
+func (p *ProjectionTestSuite) TestStrictStringTruncateProjection() {
+	schema, spec := p.schema(), p.truncateStrSpec()
+
+	ref, truncStr := iceberg.Reference("data"), iceberg.Reference("data_trunc")
+	tests := []struct {
+		pred, expected iceberg.BooleanExpression
+	}{
+		{iceberg.NotNull(ref), iceberg.NotNull(truncStr)},
+		{iceberg.IsNull(ref), iceberg.IsNull(truncStr)},
+		{iceberg.LessThan(ref, "aaa"), iceberg.LessThan(truncStr, "aa")},
+		{iceberg.LessThanEqual(ref, "aaa"), iceberg.LessThan(truncStr, "aa")},
+		{iceberg.GreaterThan(ref, "aaa"), iceberg.GreaterThan(truncStr, "aa")},
+		{iceberg.GreaterThanEqual(ref, "aaa"), iceberg.GreaterThan(truncStr, "aa")},
+		{iceberg.EqualTo(ref, "aaa"), iceberg.AlwaysFalse{}},
+		{iceberg.NotEqualTo(ref, "aaa"), iceberg.NotEqualTo(truncStr, "aa")},
+		{iceberg.IsIn(ref, "aaa", "aab"), iceberg.AlwaysFalse{}},
+		{iceberg.NotIn(ref, "aaa", "abc"), iceberg.NotIn(truncStr, "aa", "ab")},
+		{iceberg.StartsWith(ref, "a"), iceberg.StartsWith(truncStr, "a")},
+		{iceberg.StartsWith(ref, "aa"), iceberg.EqualTo(truncStr, "aa")},
+		{iceberg.StartsWith(ref, "aaa"), iceberg.AlwaysFalse{}},
+		{iceberg.NotStartsWith(ref, "a"), iceberg.NotStartsWith(truncStr, "a")},
+		{iceberg.NotStartsWith(ref, "aa"), iceberg.NotEqualTo(truncStr, "aa")},
+		{iceberg.NotStartsWith(ref, "aaa"), iceberg.NotStartsWith(truncStr, "aa")},
+		{iceberg.NotEqualTo(ref, "イロハ"), iceberg.NotEqualTo(truncStr, "イロ")},
+		{iceberg.NotStartsWith(ref, "😀😁😂"), iceberg.NotStartsWith(truncStr, "😀😁")},
+	}
+
+	project := newStrictProjection(schema, spec, true)
+	for _, tt := range tests {
+		p.Run(tt.pred.String(), func() {
+			expr, err := project(tt.pred)
+			p.Require().NoError(err)
+			p.Truef(tt.expected.Equals(expr), "expected: %s\ngot: %s", tt.expected, expr)
+		})
+	}
+}
+
+func (p *ProjectionTestSuite) TestStrictIntTruncateProjection() {
+	schema, spec := p.schema(), p.truncateIntSpec()
+
+	ref, idTrunc := iceberg.Reference("id"), iceberg.Reference("id_trunc")
+	tests := []struct {
+		pred, expected iceberg.BooleanExpression
+	}{
+		{iceberg.NotNull(ref), iceberg.NotNull(idTrunc)},
+		{iceberg.IsNull(ref), iceberg.IsNull(idTrunc)},
+		{iceberg.LessThan(ref, int32(10)), iceberg.LessThan(idTrunc, int64(10))},
+		{iceberg.LessThanEqual(ref, int32(9)), iceberg.LessThan(idTrunc, int64(10))},
+		{iceberg.GreaterThan(ref, int32(9)), iceberg.GreaterThan(idTrunc, int64(0))},
+		{iceberg.GreaterThanEqual(ref, int32(10)), iceberg.GreaterThan(idTrunc, int64(0))},
+		{iceberg.EqualTo(ref, int32(15)), iceberg.AlwaysFalse{}},
+		{iceberg.NotEqualTo(ref, int32(15)), iceberg.NotEqualTo(idTrunc, int64(10))},
+		{iceberg.IsIn(ref, int32(15), 16), iceberg.AlwaysFalse{}},
+		{iceberg.NotIn(ref, int32(15), 16), iceberg.NotEqualTo(idTrunc, int64(10))},
+	}
+
+	project := newStrictProjection(schema, spec, true)
+	for _, tt := range tests {
+		p.Run(tt.pred.String(), func() {
+			expr, err := project(tt.pred)
+			p.Require().NoError(err)
+			p.Truef(tt.expected.Equals(expr), "expected: %s\ngot: %s", tt.expected, expr)
+		})
+	}
+}
+
+func (p *ProjectionTestSuite) TestStrictNotProjection() {
+	project := newStrictProjection(p.schema(), p.idAndBucketSpec(), true)
+	// not rewrites In to NotIn, which the bucket transform can project
+	expr, err := project(iceberg.NewNot(iceberg.NewOr(iceberg.LessThan(iceberg.Reference("id"), int64(5)),
+		iceberg.IsIn(iceberg.Reference("data"), "a", "b", "c"))))
+	p.Require().NoError(err)
+
+	p.Truef(expr.Equals(iceberg.NewAnd(iceberg.GreaterThanEqual(iceberg.Reference("id_part"), int64(5)),
+		iceberg.NotIn(iceberg.Reference("data_bucket"), int32(2), 3, 15))), "got: %s", expr)
+}
+
+func (p *ProjectionTestSuite) TestStrictProjectEmptySpec() {
+	project := newStrictProjection(p.schema(), p.emptySpec(), true)
+	expr, err := project(iceberg.LessThan(iceberg.Reference("id"), int32(5)))
+	p.Require().NoError(err)
+	p.Equal(iceberg.AlwaysFalse{}, expr)
+}
+
 func (p *ProjectionTestSuite) TestProjectionCaseSensitive() {
 	schema, spec := p.schema(), p.idSpec()
 	project := newInclusiveProjection(schema, spec, true)
@@ -1108,6 +1287,10 @@ func (*mockDataFile) ReferencedDataFile() *string               { return nil }
 func (*mockDataFile) ContentOffset() *int64                     { return nil }
 func (*mockDataFile) ContentSizeInBytes() *int64                { return nil }
 
+func (*mockDataFile) PartitionData() (iceberg.PartitionData, bool) {
+	return iceberg.PartitionData{}, false
+}
+
 type InclusiveMetricsTestSuite struct {
 	suite.Suite
 
@@ -2727,3 +2910,50 @@ func TestEvaluators(t *testing.T) {
 	suite.Run(t, &InclusiveMetricsTestSuite{})
 	suite.Run(t, &StrictMetricsTestSuite{})
 }
+
+func TestMetricsCompareBound(t *testing.T) {
+	var m metricsEvaluator
+	bytesOf := func(lit iceberg.Literal) []byte {
+		b, err := lit.MarshalBinary()
+		require.NoError(t, err)
+
+		return b
+	}
+
+	tests := []struct {
+		name  string
+		typ   iceberg.Type
+		bound iceberg.Literal
+		lit   iceberg.Literal
+		want  int
+		nan   bool
+	}{
+		{"int", iceberg.PrimitiveTypes.Int32, iceberg.Int32Literal(-5), iceberg.Int32Literal(3), -1, false},
+		{"long", iceberg.PrimitiveTypes.Int64, iceberg.Int64Literal(1 << 40), iceberg.Int64Literal(-1), 1, false},
+		{"date", iceberg.PrimitiveTypes.Date, iceberg.DateLiteral(19000), iceberg.DateLiteral(19000), 0, false},
+		{"timestamp", iceberg.PrimitiveTypes.TimestampTz, iceberg.TimestampLiteral(10), iceberg.TimestampLiteral(20), -1, false},
+		{"float", iceberg.PrimitiveTypes.Float32, iceberg.Float32Literal(-1.5), iceberg.Float32Literal(-2), 1, false},
+		{"float nan", iceberg.PrimitiveTypes.Float32, iceberg.Float32Literal(float32(math.NaN())), iceberg.Float32Literal(0), 0, true},
+		{"double nan", iceberg.PrimitiveTypes.Float64, iceberg.Float64Literal(math.NaN()), iceberg.Float64Literal(0), 0, true},
+		{"string", iceberg.PrimitiveTypes.String, iceberg.StringLiteral("abc"), iceberg.StringLiteral("abd"), -1, false},
+		{"decimal", iceberg.DecimalTypeOf(9, 2), iceberg.NewLiteral(iceberg.Decimal{Val: decimal128.FromI64(150), Scale: 2}),
+			iceberg.NewLiteral(iceberg.Decimal{Val: decimal128.FromI64(100), Scale: 2}), 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, nan := m.compareBound(tt.typ, bytesOf(tt.bound), tt.lit)
+			assert.Equal(t, tt.nan, nan)
+			if !tt.nan {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+
+	// integer and string bounds are compared without decoding them
+	longBound, strBound := bytesOf(iceberg.Int64Literal(42)), []byte("category-7")
+	assert.Zero(t, testing.AllocsPerRun(100, func() {
+		m.compareBound(iceberg.PrimitiveTypes.Int64, longBound, iceberg.Int64Literal(7))
+		m.compareBound(iceberg.PrimitiveTypes.String, strBound, iceberg.StringLiteral("category-3"))
+	}))
+}
@@ -0,0 +1,560 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/compute"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/config"
+	iceinternal "github.com/apache/iceberg-go/internal"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table/internal"
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+)
+
+type exportConfig struct {
+	snapshotID  *int64
+	concurrency int
+}
+
+// ExportOption configures Table.Export.
+type ExportOption func(*exportConfig)
+
+// WithExportSnapshotID exports the given snapshot instead of the table's
+// current snapshot.
+func WithExportSnapshotID(id int64) ExportOption {
+	return func(cfg *exportConfig) {
+		cfg.snapshotID = &id
+	}
+}
+
+// WithExportConcurrency sets the number of files copied concurrently. It
+// defaults to the configured maximum number of workers.
+func WithExportConcurrency(n int) ExportOption {
+	return func(cfg *exportConfig) {
+		if n > 0 {
+			cfg.concurrency = n
+		}
+	}
+}
+
+// ExportResult describes the table written by Table.Export.
+type ExportResult struct {
+	// MetadataLocation is the metadata file of the exported table, which
+	// can be registered with a catalog to restore or clone the table.
+	MetadataLocation string
+	// SnapshotID is the exported snapshot, or 0 if the table had none.
+	SnapshotID        int64
+	DataFilesCopied   int
+	DeleteFilesCopied int
+	BytesCopied       int64
+}
+
+// Export writes a self-contained copy of a single snapshot of the table to
+// targetLocation on targetFS: the snapshot's live data and delete files, its
+// manifests and manifest list, and a new metadata file. All paths under the
+// table location are rewritten to point into targetLocation, including the
+// data file paths stored inside position delete files, so the copy does not
+// reference the source table in any way. Files outside the table location
+// can't be relocated and make the export fail.
+//
+// The exported metadata keeps the table UUID, schemas, partition specs, sort
+// orders and properties, but only the exported snapshot, as the main branch,
+// and no history, statistics or other refs. Registering the returned
+// metadata location with a catalog restores the table, or clones it if the
+// source table is kept.
+//
+// Exporting format version 3 tables, deletion vectors and encrypted position
+// delete files is not supported yet.
+func (t Table) Export(ctx context.Context, targetLocation string, targetFS iceio.WriteFileIO, opts ...ExportOption) (ExportResult, error) {
+	t = t.pin()
+
+	cfg := exportConfig{concurrency: config.EnvConfig.MaxWorkers}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	meta := t.Metadata()
+	if meta.Version() > 2 {
+		return ExportResult{}, fmt.Errorf("%w: exporting format version %d tables",
+			iceberg.ErrNotImplemented, meta.Version())
+	}
+
+	targetLocation = strings.TrimSuffix(targetLocation, "/")
+	if targetLocation == "" {
+		return ExportResult{}, fmt.Errorf("%w: export target location is empty", iceberg.ErrInvalidArgument)
+	}
+
+	snap := meta.CurrentSnapshot()
+	if cfg.snapshotID != nil {
+		if snap = meta.SnapshotByID(*cfg.snapshotID); snap == nil {
+			return ExportResult{}, fmt.Errorf("%w: snapshot %d not found",
+				iceberg.ErrInvalidArgument, *cfg.snapshotID)
+		}
+	}
+
+	fs, err := t.FS(ctx)
+	if err != nil {
+		return ExportResult{}, err
+	}
+
+	e := &exporter{
+		meta:      meta,
+		srcFS:     fs,
+		dstFS:     targetFS,
+		src:       strings.TrimSuffix(meta.Location(), "/"),
+		dst:       targetLocation,
+		commitID:  uuid.New(),
+		rewritten: make(map[string]int64),
+	}
+
+	e.props = e.relocateProperties()
+	if e.loc, err = LoadLocationProvider(e.dst, e.props); err != nil {
+		return ExportResult{}, err
+	}
+
+	var result ExportResult
+	if snap != nil {
+		result.SnapshotID = snap.SnapshotID
+		if snap, err = e.exportSnapshot(ctx, snap, cfg.concurrency, &result); err != nil {
+			return ExportResult{}, err
+		}
+	}
+
+	if result.MetadataLocation, err = e.writeMetadata(snap); err != nil {
+		return ExportResult{}, err
+	}
+
+	return result, nil
+}
+
+type exporter struct {
+	meta     Metadata
+	srcFS    iceio.IO
+	dstFS    iceio.WriteFileIO
+	src, dst string
+	props    iceberg.Properties
+	loc      LocationProvider
+	commitID uuid.UUID
+
+	mx sync.Mutex
+	// rewritten holds the new size of position delete files whose
+	// contents were rewritten.
+	rewritten map[string]int64
+}
+
+func (e *exporter) relocate(path string) string {
+	if !isWithinLocation(path, e.src) {
+		return path
+	}
+
+	return e.dst + path[len(e.src):]
+}
+
+func (e *exporter) checkRelocatable(path string) error {
+	if !isWithinLocation(path, e.src) {
+		return fmt.Errorf("%w: file %s is outside of the table location %s",
+			iceberg.ErrInvalidArgument, path, e.src)
+	}
+
+	return nil
+}
+
+// relocateProperties rewrites table properties, such as the write data and
+// metadata paths, which point inside the table location.
+func (e *exporter) relocateProperties() iceberg.Properties {
+	props := make(iceberg.Properties, len(e.meta.Properties()))
+	for k, v := range e.meta.Properties() {
+		props[k] = e.relocate(v)
+	}
+
+	return props
+}
+
+func (e *exporter) exportSnapshot(ctx context.Context, snap *Snapshot, concurrency int, result *ExportResult) (*Snapshot, error) {
+	manifests, err := snap.Manifests(e.srcFS)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([][]iceberg.ManifestEntry, len(manifests))
+	files := make(map[string]iceberg.DataFile)
+	for i, m := range manifests {
+		if entries[i], err = m.FetchEntries(e.srcFS, true); err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries[i] {
+			df := entry.DataFile()
+			if err := e.checkDataFile(df); err != nil {
+				return nil, err
+			}
+			files[df.FilePath()] = df
+		}
+	}
+
+	var (
+		g   errgroup.Group
+		cnt sync.Mutex
+	)
+	g.SetLimit(concurrency)
+	for _, df := range files {
+		g.Go(func() error {
+			n, err := e.copyFile(ctx, df)
+			if err != nil {
+				return err
+			}
+
+			cnt.Lock()
+			defer cnt.Unlock()
+			if df.ContentType() == iceberg.EntryContentData {
+				result.DataFilesCopied++
+			} else {
+				result.DeleteFilesCopied++
+			}
+			result.BytesCopied += n
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	newManifests := make([]iceberg.ManifestFile, 0, len(manifests))
+	for i, m := range manifests {
+		if len(entries[i]) == 0 {
+			continue
+		}
+
+		path := e.loc.NewMetadataLocation(newManifestFileName(i, e.commitID))
+		mf, err := e.writeManifest(path, snap, m, entries[i])
+		if err != nil {
+			return nil, err
+		}
+		newManifests = append(newManifests, mf)
+	}
+
+	listPath := e.loc.NewMetadataLocation(newManifestListFileName(snap.SnapshotID, 0, e.commitID))
+	if err := e.writeManifestList(listPath, snap, nil, newManifests); err != nil {
+		return nil, err
+	}
+
+	exported := *snap
+	exported.ParentSnapshotID = nil
+	exported.ManifestList = listPath
+
+	return &exported, nil
+}
+
+func (e *exporter) checkDataFile(df iceberg.DataFile) error {
+	if err := e.checkRelocatable(df.FilePath()); err != nil {
+		return err
+	}
+
+	if ref := df.ReferencedDataFile(); ref != nil {
+		if err := e.checkRelocatable(*ref); err != nil {
+			return err
+		}
+	}
+
+	if df.ContentType() == iceberg.EntryContentPosDeletes {
+		switch {
+		case df.ContentOffset() != nil:
+			return fmt.Errorf("%w: exporting deletion vector %s",
+				iceberg.ErrNotImplemented, df.FilePath())
+		case len(df.KeyMetadata()) > 0:
+			return fmt.Errorf("%w: exporting encrypted position delete file %s",
+				iceberg.ErrNotImplemented, df.FilePath())
+		}
+	}
+
+	return nil
+}
+
+// copyFile copies df to its relocated path and returns the number of bytes
+// written. Position delete files are rewritten to reference the relocated
+// data files.
+func (e *exporter) copyFile(ctx context.Context, df iceberg.DataFile) (n int64, err error) {
+	if df.ContentType() == iceberg.EntryContentPosDeletes {
+		return e.rewritePositionDeletes(ctx, df)
+	}
+
+	src, err := e.srcFS.Open(df.FilePath())
+	if err != nil {
+		return 0, err
+	}
+	defer iceinternal.CheckedClose(src, &err)
+
+	dst, err := e.dstFS.Create(e.relocate(df.FilePath()))
+	if err != nil {
+		return 0, err
+	}
+	defer iceinternal.CheckedClose(dst, &err)
+
+	return io.Copy(dst, src)
+}
+
+func (e *exporter) rewritePositionDeletes(ctx context.Context, df iceberg.DataFile) (n int64, err error) {
+	src, err := internal.GetFile(ctx, e.srcFS, df, true)
+	if err != nil {
+		return 0, err
+	}
+
+	rdr, err := src.GetReader(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer iceinternal.CheckedClose(rdr, &err)
+
+	tbl, err := rdr.ReadTable(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tbl.Release()
+
+	relocated, err := e.relocatePathColumn(ctx, tbl)
+	if err != nil {
+		return 0, err
+	}
+	defer relocated.Release()
+
+	out, err := e.dstFS.Create(e.relocate(df.FilePath()))
+	if err != nil {
+		return 0, err
+	}
+	defer iceinternal.CheckedClose(out, &err)
+
+	cnt := &iceinternal.CountingWriter{W: out}
+	format := internal.GetFileFormat(iceberg.ParquetFile)
+	mem := compute.GetAllocator(ctx)
+	writerProps := parquet.NewWriterProperties(append(
+		format.GetWriteProperties(e.meta.Properties()).([]parquet.WriterProperty),
+		parquet.WithAllocator(mem))...)
+	wr, err := pqarrow.NewFileWriter(relocated.Schema(), cnt, writerProps,
+		pqarrow.NewArrowWriterProperties(pqarrow.WithAllocator(mem), pqarrow.WithStoreSchema()))
+	if err != nil {
+		return 0, err
+	}
+
+	if err := wr.WriteTable(relocated, max(relocated.NumRows(), 1)); err != nil {
+		return 0, err
+	}
+
+	if err := wr.Close(); err != nil {
+		return 0, err
+	}
+
+	e.mx.Lock()
+	defer e.mx.Unlock()
+	e.rewritten[df.FilePath()] = cnt.Count
+
+	return cnt.Count, nil
+}
+
+// relocatePathColumn returns tbl with the values of its file_path column
+// relocated.
+func (e *exporter) relocatePathColumn(ctx context.Context, tbl arrow.Table) (arrow.Table, error) {
+	idx := tbl.Schema().FieldIndices("file_path")
+	if len(idx) != 1 {
+		return nil, fmt.Errorf("%w: position delete file has no file_path column", ErrInvalidMetadata)
+	}
+
+	col := tbl.Column(idx[0])
+	mem := compute.GetAllocator(ctx)
+	chunks := make([]arrow.Array, 0, len(col.Data().Chunks()))
+	defer func() {
+		for _, c := range chunks {
+			c.Release()
+		}
+	}()
+
+	for _, chunk := range col.Data().Chunks() {
+		values := chunk
+		if dict, ok := chunk.(*array.Dictionary); ok {
+			values = dict.Dictionary()
+		}
+
+		strs, ok := values.(interface{ Value(int) string })
+		if !ok {
+			return nil, fmt.Errorf("%w: unexpected file_path column type %s",
+				ErrInvalidMetadata, chunk.DataType())
+		}
+
+		bldr := array.NewStringBuilder(mem)
+		bldr.Reserve(chunk.Len())
+		for i := 0; i < chunk.Len(); i++ {
+			j := i
+			if dict, ok := chunk.(*array.Dictionary); ok {
+				j = dict.GetValueIndex(i)
+			}
+			bldr.Append(e.relocate(strs.Value(j)))
+		}
+		chunks = append(chunks, bldr.NewArray())
+		bldr.Release()
+	}
+
+	fields := tbl.Schema().Fields()
+	fields[idx[0]].Type = arrow.BinaryTypes.String
+	schema := arrow.NewSchema(fields, nil)
+
+	cols := make([]arrow.Column, tbl.NumCols())
+	for i := range cols {
+		if i == idx[0] {
+			chunked := arrow.NewChunked(arrow.BinaryTypes.String, chunks)
+			cols[i] = *arrow.NewColumn(fields[i], chunked)
+			chunked.Release()
+		} else {
+			cols[i] = *tbl.Column(i)
+			cols[i].Retain()
+		}
+	}
+	defer func() {
+		for i := range cols {
+			cols[i].Release()
+		}
+	}()
+
+	return array.NewTable(schema, cols, tbl.NumRows()), nil
+}
+
+// writeManifest writes the relocated entries of m, which belongs to snap,
+// to path.
+func (e *exporter) writeManifest(path string, snap *Snapshot, m iceberg.ManifestFile, entries []iceberg.ManifestEntry) (_ iceberg.ManifestFile, err error) {
+	spec := e.meta.PartitionSpecByID(int(m.PartitionSpecID()))
+	if spec == nil {
+		return nil, fmt.Errorf("%w: id %d", ErrPartitionSpecNotFound, m.PartitionSpecID())
+	}
+
+	schema := e.meta.CurrentSchema()
+	if snap.SchemaID != nil {
+		if i := slices.IndexFunc(e.meta.Schemas(), func(sc *iceberg.Schema) bool {
+			return sc.ID == *snap.SchemaID
+		}); i >= 0 {
+			schema = e.meta.Schemas()[i]
+		}
+	}
+
+	relocated := make([]iceberg.ManifestEntry, len(entries))
+	for i, entry := range entries {
+		df := entry.DataFile()
+		snapshotID, seqNum := entry.SnapshotID(), entry.SequenceNum()
+		relocated[i] = iceberg.NewManifestEntry(entry.Status(), &snapshotID, &seqNum,
+			entry.FileSequenceNum(), iceberg.RelocateDataFile(df, e.relocate, e.rewritten[df.FilePath()]))
+	}
+
+	out, err := e.dstFS.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	defer iceinternal.CheckedClose(out, &err)
+
+	if m.ManifestContent() == iceberg.ManifestContentDeletes {
+		return iceberg.WriteDeleteManifest(path, out, e.meta.Version(), *spec, schema, snap.SnapshotID, relocated,
+			manifestWriterOptions(e.meta.Properties()))
+	}
+
+	return iceberg.WriteManifest(path, out, e.meta.Version(), *spec, schema, snap.SnapshotID, relocated,
+		manifestWriterOptions(e.meta.Properties()))
+}
+
+func (e *exporter) writeManifestList(path string, snap *Snapshot, parent *int64, manifests []iceberg.ManifestFile) (err error) {
+	out, err := e.dstFS.Create(path)
+	if err != nil {
+		return err
+	}
+	defer iceinternal.CheckedClose(out, &err)
+
+	var firstRowID int64
+	if snap.FirstRowID != nil {
+		firstRowID = *snap.FirstRowID
+	}
+
+	return iceberg.WriteManifestList(e.meta.Version(), out, snap.SnapshotID, parent,
+		&snap.SequenceNumber, firstRowID, manifests, manifestWriterOptions(e.meta.Properties()))
+}
+
+func (e *exporter) writeMetadata(snap *Snapshot) (string, error) {
+	bldr, err := MetadataBuilderFromBase(e.meta, "")
+	if err != nil {
+		return "", err
+	}
+
+	bldr.loc = e.dst
+	bldr.props = e.props
+	bldr.metadataLog = nil
+	bldr.snapshotList, bldr.snapshotLog = nil, nil
+	bldr.currentSnapshotID = nil
+	bldr.refs = make(map[string]SnapshotRef)
+	if snap != nil {
+		bldr.snapshotList = []Snapshot{*snap}
+		bldr.snapshotLog = []SnapshotLogEntry{{SnapshotID: snap.SnapshotID, TimestampMs: snap.TimestampMs}}
+		bldr.currentSnapshotID = &snap.SnapshotID
+		bldr.refs[MainBranch] = SnapshotRef{SnapshotID: snap.SnapshotID, SnapshotRefType: BranchRef}
+	}
+
+	meta, err := bldr.Build()
+	if err != nil {
+		return "", err
+	}
+
+	path, err := e.loc.NewTableMetadataFileLocation(0)
+	if err != nil {
+		return "", err
+	}
+
+	return path, e.writeMetadataFile(path, meta)
+}
+
+func (e *exporter) writeMetadataFile(path string, meta Metadata) error {
+	var buf bytes.Buffer
+	var w io.Writer = &buf
+	var gz *gzip.Writer
+	if isGzippedMetadataJson(path) {
+		gz = gzip.NewWriter(&buf)
+		w = gz
+	}
+
+	if err := json.NewEncoder(w).Encode(meta); err != nil {
+		return err
+	}
+
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return err
+		}
+	}
+
+	return e.dstFS.WriteFile(path, buf.Bytes())
+}
@@ -0,0 +1,131 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newExportTestTable creates a table at loc with two appended data files
+// and a position delete file deleting the second row of the first one.
+func newExportTestTable(t *testing.T, loc string) *Table {
+	ctx := context.Background()
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true})
+	meta, err := NewMetadata(sc, iceberg.UnpartitionedSpec, UnsortedSortOrder, loc,
+		iceberg.Properties{PropertyFormatVersion: "2"})
+	require.NoError(t, err)
+
+	tbl := New(Identifier{"default", "export"}, meta, loc+"/metadata/v1.metadata.json",
+		func(context.Context) (iceio.IO, error) { return iceio.LocalFS{}, nil },
+		&inMemoryCatalog{meta})
+
+	arrSchema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	for _, rows := range []string{`[{"id": 0}, {"id": 1}, {"id": 2}]`, `[{"id": 3}, {"id": 4}]`} {
+		arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{rows})
+		require.NoError(t, err)
+		tbl, err = tbl.AppendTable(ctx, arrTbl, 3, nil)
+		require.NoError(t, err)
+		arrTbl.Release()
+	}
+
+	fs, err := tbl.FS(ctx)
+	require.NoError(t, err)
+	var firstFile string
+	for df, err := range tbl.CurrentSnapshot().dataFiles(fs, nil) {
+		require.NoError(t, err)
+		if df.Count() == 3 {
+			firstFile = df.FilePath()
+		}
+	}
+	require.NotEmpty(t, firstFile)
+
+	return commitDeleteFiles(t, tbl, writePositionDeletes(t, tbl, firstFile, 1))
+}
+
+// scanIDs returns the sorted ids read from the table.
+func scanIDs(t *testing.T, tbl *Table, opts ...ScanOption) []int64 {
+	result, err := tbl.Scan(opts...).ToArrowTable(context.Background())
+	require.NoError(t, err)
+	defer result.Release()
+
+	var ids []int64
+	for _, chunk := range result.Column(0).Data().Chunks() {
+		ids = append(ids, chunk.(*array.Int64).Int64Values()...)
+	}
+	slices.Sort(ids)
+
+	return ids
+}
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.ToSlash(t.TempDir())
+	src, dst := dir+"/src", dir+"/dst"
+
+	fsF := func(context.Context) (iceio.IO, error) { return iceio.LocalFS{}, nil }
+	tbl := newExportTestTable(t, src)
+	firstSnapshot := tbl.Metadata().Snapshots()[0].SnapshotID
+
+	res, err := tbl.Export(ctx, dst+"/", iceio.LocalFS{})
+	require.NoError(t, err)
+	assert.Equal(t, tbl.CurrentSnapshot().SnapshotID, res.SnapshotID)
+	assert.Equal(t, 2, res.DataFilesCopied)
+	assert.Equal(t, 1, res.DeleteFilesCopied)
+	assert.Positive(t, res.BytesCopied)
+	assert.True(t, strings.HasPrefix(res.MetadataLocation, dst+"/metadata/"))
+
+	// the copy must be readable without the source table
+	require.NoError(t, os.RemoveAll(src))
+
+	exported, err := NewFromLocation(ctx, Identifier{"default", "copy"}, res.MetadataLocation,
+		fsF, &inMemoryCatalog{})
+	require.NoError(t, err)
+	assert.Equal(t, dst, exported.Location())
+	assert.Equal(t, tbl.Metadata().TableUUID(), exported.Metadata().TableUUID())
+	require.Len(t, exported.Metadata().Snapshots(), 1)
+	assert.Nil(t, exported.CurrentSnapshot().ParentSnapshotID)
+
+	for f, err := range ReachableFiles(ctx, exported.Metadata(), res.MetadataLocation, iceio.LocalFS{}) {
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(f.Path, dst+"/"), f.Path)
+	}
+
+	assert.Equal(t, []int64{0, 2, 3, 4}, scanIDs(t, exported))
+
+	res, err = exported.Export(ctx, dir+"/older", iceio.LocalFS{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.DataFilesCopied)
+	assert.Equal(t, 1, res.DeleteFilesCopied)
+
+	_, err = exported.Export(ctx, dir+"/other", iceio.LocalFS{}, WithExportSnapshotID(firstSnapshot))
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
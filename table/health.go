@@ -0,0 +1,215 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/apache/iceberg-go"
+)
+
+// HealthMetrics summarizes the state of a table's current snapshot for
+// monitoring, for example to find tables that need compaction or
+// snapshot expiration. It is computed from metadata and manifests only.
+type HealthMetrics struct {
+	// Snapshots is the number of snapshots in the table metadata.
+	Snapshots int
+	// DataFiles and DeleteFiles are the numbers of live data and delete
+	// files in the current snapshot.
+	DataFiles   int64
+	DeleteFiles int64
+	// DataBytes and DeleteBytes are the total sizes of those files.
+	DataBytes   int64
+	DeleteBytes int64
+	// Records is the total record count of the data files.
+	Records int64
+	// SmallFileThreshold is the size below which a data file is counted
+	// as small, and SmallFiles the number of such files.
+	SmallFileThreshold int64
+	SmallFiles         int64
+	// Partitions holds the metrics of each partition, sorted by spec ID
+	// and path. It is empty for tables that have never been partitioned.
+	Partitions []PartitionHealth
+}
+
+// AvgDataFileSize returns the average data file size in bytes, or zero if
+// there are no data files.
+func (h HealthMetrics) AvgDataFileSize() float64 {
+	return ratio(h.DataBytes, h.DataFiles)
+}
+
+// DeleteFileRatio returns the number of delete files per data file.
+func (h HealthMetrics) DeleteFileRatio() float64 {
+	return ratio(h.DeleteFiles, h.DataFiles)
+}
+
+// SmallFileRatio returns the fraction of data files that are small.
+func (h HealthMetrics) SmallFileRatio() float64 {
+	return ratio(h.SmallFiles, h.DataFiles)
+}
+
+// PartitionHealth holds the file metrics of a single partition.
+type PartitionHealth struct {
+	SpecID int
+	// Path is the partition path, such as "date=2024-01-01/bucket=3".
+	Path        string
+	DataFiles   int64
+	DeleteFiles int64
+	DataBytes   int64
+	SmallFiles  int64
+}
+
+// SmallFileRatio returns the fraction of the partition's data files
+// that are small.
+func (p PartitionHealth) SmallFileRatio() float64 {
+	return ratio(p.SmallFiles, p.DataFiles)
+}
+
+func ratio(n, d int64) float64 {
+	if d == 0 {
+		return 0
+	}
+
+	return float64(n) / float64(d)
+}
+
+type healthConfig struct {
+	smallFileThreshold int64
+}
+
+// HealthOption configures HealthMetrics.
+type HealthOption func(*healthConfig)
+
+// WithSmallFileThreshold sets the size in bytes below which data files
+// are counted as small. It defaults to 75% of the table's
+// write.target-file-size-bytes.
+func WithSmallFileThreshold(bytes int64) HealthOption {
+	return func(cfg *healthConfig) {
+		cfg.smallFileThreshold = bytes
+	}
+}
+
+// HealthMetrics computes the health metrics of the table's current
+// snapshot by reading its manifests. No data files are read.
+func (t Table) HealthMetrics(ctx context.Context, opts ...HealthOption) (HealthMetrics, error) {
+	t = t.pin()
+
+	target := t.Properties().GetInt(WriteTargetFileSizeBytesKey, WriteTargetFileSizeBytesDefault)
+	cfg := healthConfig{smallFileThreshold: int64(target) * 3 / 4}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	h := HealthMetrics{
+		Snapshots:          len(t.Metadata().Snapshots()),
+		SmallFileThreshold: cfg.smallFileThreshold,
+	}
+
+	snap := t.CurrentSnapshot()
+	if snap == nil {
+		return h, nil
+	}
+
+	fs, err := t.FS(ctx)
+	if err != nil {
+		return h, err
+	}
+
+	manifests, err := snap.Manifests(fs)
+	if err != nil {
+		return h, err
+	}
+
+	type partitionKey struct {
+		specID int
+		path   string
+	}
+
+	var (
+		schema     = t.Schema()
+		specs      = make(map[int]iceberg.PartitionSpec)
+		partitions = make(map[partitionKey]*PartitionHealth)
+	)
+	for _, spec := range t.Metadata().PartitionSpecs() {
+		specs[spec.ID()] = spec
+	}
+
+	for _, m := range manifests {
+		if err := ctx.Err(); err != nil {
+			return h, err
+		}
+
+		entries, err := m.FetchEntries(fs, true)
+		if err != nil {
+			return h, err
+		}
+
+		spec, ok := specs[int(m.PartitionSpecID())]
+		for _, e := range entries {
+			df := e.DataFile()
+
+			var part *PartitionHealth
+			if ok && !spec.IsUnpartitioned() {
+				key := partitionKey{spec.ID(), spec.PartitionToPath(
+					getPartitionRecord(df, spec.PartitionType(schema)), schema)}
+				if part = partitions[key]; part == nil {
+					part = &PartitionHealth{SpecID: key.specID, Path: key.path}
+					partitions[key] = part
+				}
+			}
+
+			if df.ContentType() != iceberg.EntryContentData {
+				h.DeleteFiles++
+				h.DeleteBytes += df.FileSizeBytes()
+				if part != nil {
+					part.DeleteFiles++
+				}
+
+				continue
+			}
+
+			small := df.FileSizeBytes() < cfg.smallFileThreshold
+			h.DataFiles++
+			h.DataBytes += df.FileSizeBytes()
+			h.Records += df.Count()
+			if small {
+				h.SmallFiles++
+			}
+
+			if part != nil {
+				part.DataFiles++
+				part.DataBytes += df.FileSizeBytes()
+				if small {
+					part.SmallFiles++
+				}
+			}
+		}
+	}
+
+	h.Partitions = make([]PartitionHealth, 0, len(partitions))
+	for _, p := range partitions {
+		h.Partitions = append(h.Partitions, *p)
+	}
+	slices.SortFunc(h.Partitions, func(a, b PartitionHealth) int {
+		return cmp.Or(cmp.Compare(a.SpecID, b.SpecID), cmp.Compare(a.Path, b.Path))
+	})
+
+	return h, nil
+}
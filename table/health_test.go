@@ -0,0 +1,90 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthMetrics(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "category", Type: iceberg.PrimitiveTypes.String, Required: true})
+
+	spec := iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 2, FieldID: 1000, Name: "category", Transform: iceberg.IdentityTransform{}})
+
+	tbl := newTestTable(t, withTestSchema(sc), withTestSpec(&spec))
+
+	h, err := tbl.HealthMetrics(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, h.Snapshots)
+	assert.Zero(t, h.DataFiles)
+	assert.Zero(t, h.AvgDataFileSize())
+	assert.EqualValues(t, table.WriteTargetFileSizeBytesDefault*3/4, h.SmallFileThreshold)
+
+	arrSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "category", Type: arrow.BinaryTypes.String},
+	}, nil)
+	for _, rows := range []string{
+		`[{"id": 1, "category": "a"}, {"id": 2, "category": "b"}, {"id": 3, "category": "a"}]`,
+		`[{"id": 4, "category": "a"}]`,
+	} {
+		arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{rows})
+		require.NoError(t, err)
+		tbl, err = tbl.AppendTable(ctx, arrTbl, 3, nil)
+		arrTbl.Release()
+		require.NoError(t, err)
+	}
+
+	h, err = tbl.HealthMetrics(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, h.Snapshots)
+	assert.EqualValues(t, 3, h.DataFiles)
+	assert.EqualValues(t, 4, h.Records)
+	assert.Zero(t, h.DeleteFiles)
+	assert.Zero(t, h.DeleteFileRatio())
+	assert.Positive(t, h.DataBytes)
+	assert.InDelta(t, float64(h.DataBytes)/3, h.AvgDataFileSize(), 1e-9)
+	assert.EqualValues(t, 3, h.SmallFiles)
+	assert.Equal(t, 1.0, h.SmallFileRatio())
+
+	require.Len(t, h.Partitions, 2)
+	assert.Equal(t, "category=a", h.Partitions[0].Path)
+	assert.EqualValues(t, 2, h.Partitions[0].DataFiles)
+	assert.Equal(t, "category=b", h.Partitions[1].Path)
+	assert.EqualValues(t, 1, h.Partitions[1].DataFiles)
+	assert.Equal(t, h.DataBytes, h.Partitions[0].DataBytes+h.Partitions[1].DataBytes)
+
+	h, err = tbl.HealthMetrics(ctx, table.WithSmallFileThreshold(0))
+	require.NoError(t, err)
+	assert.Zero(t, h.SmallFiles)
+	assert.Zero(t, h.Partitions[0].SmallFileRatio())
+}
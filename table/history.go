@@ -0,0 +1,147 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/apache/iceberg-go"
+)
+
+// HistoryEntry is an entry of a table's history: a snapshot that became
+// the current snapshot of the table at a point in time.
+type HistoryEntry struct {
+	SnapshotID       int64
+	ParentSnapshotID *int64
+	TimestampMs      int64
+	// IsCurrentAncestor reports whether the snapshot is an ancestor of
+	// (or is) the table's current snapshot. It is false for snapshots
+	// that were rolled back.
+	IsCurrentAncestor bool
+}
+
+// History returns the table's snapshot log, oldest first, annotated with
+// whether each snapshot is still an ancestor of the current snapshot.
+func (t Table) History() []HistoryEntry {
+	meta := t.Metadata()
+	current := make(map[int64]struct{})
+	if snap := meta.CurrentSnapshot(); snap != nil {
+		for s := range snap.Ancestors(meta) {
+			current[s.SnapshotID] = struct{}{}
+		}
+	}
+
+	var history []HistoryEntry
+	for entry := range meta.SnapshotLogs() {
+		h := HistoryEntry{SnapshotID: entry.SnapshotID, TimestampMs: entry.TimestampMs}
+		if snap := meta.SnapshotByID(entry.SnapshotID); snap != nil {
+			h.ParentSnapshotID = snap.ParentSnapshotID
+		}
+		_, h.IsCurrentAncestor = current[entry.SnapshotID]
+		history = append(history, h)
+	}
+
+	return history
+}
+
+// Ancestors returns an iterator over the snapshot and its ancestors in
+// meta, newest first. Iteration stops at the first parent that is no
+// longer in the metadata, e.g. because it has been expired.
+func (s Snapshot) Ancestors(meta Metadata) iter.Seq[*Snapshot] {
+	return func(yield func(*Snapshot) bool) {
+		for snap := &s; snap != nil; {
+			if !yield(snap) || snap.ParentSnapshotID == nil {
+				return
+			}
+			snap = meta.SnapshotByID(*snap.ParentSnapshotID)
+		}
+	}
+}
+
+// AncestorsBetween returns an iterator over the ancestors of the snapshot
+// with ID latestID, newest first, up to but excluding the snapshot with
+// ID oldestID. If oldestID is nil, all ancestors are returned. This is
+// the set of snapshots to process when incrementally consuming the
+// changes committed after oldestID.
+func AncestorsBetween(meta Metadata, latestID int64, oldestID *int64) iter.Seq[*Snapshot] {
+	return func(yield func(*Snapshot) bool) {
+		latest := meta.SnapshotByID(latestID)
+		if latest == nil {
+			return
+		}
+
+		for snap := range latest.Ancestors(meta) {
+			if oldestID != nil && snap.SnapshotID == *oldestID {
+				return
+			}
+			if !yield(snap) {
+				return
+			}
+		}
+	}
+}
+
+// IsAncestorOf reports whether the snapshot with ID ancestorID is the
+// snapshot with ID snapshotID or one of its ancestors in meta.
+func IsAncestorOf(meta Metadata, snapshotID, ancestorID int64) bool {
+	for snap := range AncestorsBetween(meta, snapshotID, nil) {
+		if snap.SnapshotID == ancestorID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CommonAncestor returns the newest snapshot that is an ancestor of both
+// the snapshots with IDs a and b, or nil if their histories do not meet
+// within the snapshots retained in meta.
+func CommonAncestor(meta Metadata, a, b int64) *Snapshot {
+	seen := make(map[int64]struct{})
+	for snap := range AncestorsBetween(meta, a, nil) {
+		seen[snap.SnapshotID] = struct{}{}
+	}
+
+	for snap := range AncestorsBetween(meta, b, nil) {
+		if _, ok := seen[snap.SnapshotID]; ok {
+			return snap
+		}
+	}
+
+	return nil
+}
+
+// CommonAncestor returns the newest snapshot that is an ancestor of the
+// snapshots referenced by the branches or tags refA and refB, e.g. the
+// point at which a branch diverged from main. It returns nil if the refs
+// share no retained ancestor.
+func (t Table) CommonAncestor(refA, refB string) (*Snapshot, error) {
+	meta := t.Metadata()
+	a := meta.SnapshotByName(refA)
+	if a == nil {
+		return nil, fmt.Errorf("%w: no snapshot for ref %q", iceberg.ErrInvalidArgument, refA)
+	}
+
+	b := meta.SnapshotByName(refB)
+	if b == nil {
+		return nil, fmt.Errorf("%w: no snapshot for ref %q", iceberg.ErrInvalidArgument, refB)
+	}
+
+	return CommonAncestor(meta, a.SnapshotID, b.SnapshotID), nil
+}
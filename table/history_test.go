@@ -0,0 +1,142 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// historyMetadata describes a table whose main branch went 1 -> 2 -> 4,
+// was rolled back to 2 and then committed 3; branch dev points at 4 and
+// tag old at 1.
+const historyMetadata = `{
+    "format-version": 2,
+    "table-uuid": "9c12d441-03fe-4693-9a96-a0705ddf69c1",
+    "location": "s3://bucket/test/location",
+    "last-sequence-number": 4,
+    "last-updated-ms": 1602638573590,
+    "last-column-id": 1,
+    "current-schema-id": 0,
+    "schemas": [{"type": "struct", "schema-id": 0, "fields": [{"id": 1, "name": "x", "required": true, "type": "long"}]}],
+    "default-spec-id": 0,
+    "partition-specs": [{"spec-id": 0, "fields": []}],
+    "last-partition-id": 999,
+    "default-sort-order-id": 0,
+    "sort-orders": [{"order-id": 0, "fields": []}],
+    "current-snapshot-id": 3,
+    "snapshots": [
+        {"snapshot-id": 1, "timestamp-ms": 1000, "sequence-number": 1, "summary": {"operation": "append"}, "manifest-list": "s3://a/b/1.avro"},
+        {"snapshot-id": 2, "parent-snapshot-id": 1, "timestamp-ms": 2000, "sequence-number": 2, "summary": {"operation": "append"}, "manifest-list": "s3://a/b/2.avro"},
+        {"snapshot-id": 4, "parent-snapshot-id": 2, "timestamp-ms": 3000, "sequence-number": 3, "summary": {"operation": "append"}, "manifest-list": "s3://a/b/4.avro"},
+        {"snapshot-id": 3, "parent-snapshot-id": 2, "timestamp-ms": 4000, "sequence-number": 4, "summary": {"operation": "append"}, "manifest-list": "s3://a/b/3.avro"}
+    ],
+    "snapshot-log": [
+        {"snapshot-id": 1, "timestamp-ms": 1000},
+        {"snapshot-id": 2, "timestamp-ms": 2000},
+        {"snapshot-id": 4, "timestamp-ms": 3000},
+        {"snapshot-id": 2, "timestamp-ms": 3500},
+        {"snapshot-id": 3, "timestamp-ms": 4000}
+    ],
+    "metadata-log": [],
+    "refs": {
+        "main": {"snapshot-id": 3, "type": "branch"},
+        "dev": {"snapshot-id": 4, "type": "branch"},
+        "old": {"snapshot-id": 1, "type": "tag"}
+    }
+}`
+
+func snapshotIDs(it func(func(*table.Snapshot) bool)) []int64 {
+	var ids []int64
+	for s := range it {
+		ids = append(ids, s.SnapshotID)
+	}
+
+	return ids
+}
+
+func TestSnapshotHistory(t *testing.T) {
+	meta, err := table.ParseMetadataString(historyMetadata)
+	require.NoError(t, err)
+	tbl := table.New(table.Identifier{"db", "history"}, meta, "s3://bucket/test/location/metadata/v1.metadata.json", nil, nil)
+
+	t.Run("history", func(t *testing.T) {
+		history := tbl.History()
+		require.Len(t, history, 5)
+
+		ids := make([]int64, len(history))
+		current := make([]bool, len(history))
+		for i, h := range history {
+			ids[i], current[i] = h.SnapshotID, h.IsCurrentAncestor
+		}
+		assert.Equal(t, []int64{1, 2, 4, 2, 3}, ids)
+		assert.Equal(t, []bool{true, true, false, true, true}, current)
+		assert.Nil(t, history[0].ParentSnapshotID)
+		assert.EqualValues(t, 2, *history[2].ParentSnapshotID)
+		assert.EqualValues(t, 3000, history[2].TimestampMs)
+	})
+
+	t.Run("ancestors", func(t *testing.T) {
+		assert.Equal(t, []int64{3, 2, 1}, snapshotIDs(tbl.CurrentSnapshot().Ancestors(meta)))
+		assert.Equal(t, []int64{4, 2, 1}, snapshotIDs(tbl.SnapshotByName("dev").Ancestors(meta)))
+
+		oldest := int64(1)
+		assert.Equal(t, []int64{3, 2}, snapshotIDs(table.AncestorsBetween(meta, 3, &oldest)))
+		assert.Equal(t, []int64{3, 2, 1}, snapshotIDs(table.AncestorsBetween(meta, 3, nil)))
+		assert.Empty(t, snapshotIDs(table.AncestorsBetween(meta, 99, nil)))
+
+		// iteration stops as soon as the consumer does
+		n := 0
+		for range tbl.CurrentSnapshot().Ancestors(meta) {
+			n++
+
+			break
+		}
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("is ancestor", func(t *testing.T) {
+		assert.True(t, table.IsAncestorOf(meta, 3, 3))
+		assert.True(t, table.IsAncestorOf(meta, 3, 1))
+		assert.True(t, table.IsAncestorOf(meta, 4, 2))
+		assert.False(t, table.IsAncestorOf(meta, 3, 4))
+		assert.False(t, table.IsAncestorOf(meta, 1, 2))
+		assert.False(t, table.IsAncestorOf(meta, 99, 1))
+	})
+
+	t.Run("common ancestor", func(t *testing.T) {
+		snap, err := tbl.CommonAncestor("main", "dev")
+		require.NoError(t, err)
+		require.NotNil(t, snap)
+		assert.EqualValues(t, 2, snap.SnapshotID)
+
+		snap, err = tbl.CommonAncestor("dev", "old")
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, snap.SnapshotID)
+
+		assert.EqualValues(t, 3, table.CommonAncestor(meta, 3, 3).SnapshotID)
+		assert.Nil(t, table.CommonAncestor(meta, 3, 99))
+
+		_, err = tbl.CommonAncestor("main", "missing")
+		assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	})
+}
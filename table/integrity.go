@@ -0,0 +1,447 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"slices"
+	"sync"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/config"
+	iceio "github.com/apache/iceberg-go/io"
+	"golang.org/x/sync/errgroup"
+)
+
+// IntegrityIssueKind classifies an issue found by CheckIntegrity.
+type IntegrityIssueKind string
+
+const (
+	// IntegrityMissingFile is a referenced file that cannot be opened.
+	IntegrityMissingFile IntegrityIssueKind = "missing-file"
+	// IntegrityLengthMismatch is a file whose size differs from the size
+	// recorded for it.
+	IntegrityLengthMismatch IntegrityIssueKind = "length-mismatch"
+	// IntegrityChecksumMismatch is a file whose checksum differs from the
+	// expected checksum, see WithExpectedChecksums.
+	IntegrityChecksumMismatch IntegrityIssueKind = "checksum-mismatch"
+	// IntegrityUndecodableFile is a manifest list or manifest that exists
+	// but whose Avro blocks cannot be decoded.
+	IntegrityUndecodableFile IntegrityIssueKind = "undecodable-file"
+	// IntegrityUnknownSnapshot is a reference to a snapshot ID that is not
+	// in the metadata.
+	IntegrityUnknownSnapshot IntegrityIssueKind = "unknown-snapshot"
+	// IntegrityUnknownSchema is a reference to a schema ID that is not in
+	// the metadata.
+	IntegrityUnknownSchema IntegrityIssueKind = "unknown-schema"
+	// IntegrityUnknownSpec is a reference to a partition spec ID that is
+	// not in the metadata.
+	IntegrityUnknownSpec IntegrityIssueKind = "unknown-spec"
+)
+
+// IntegrityIssue is a problem found by CheckIntegrity.
+type IntegrityIssue struct {
+	Kind IntegrityIssueKind `json:"kind"`
+	// Path is the file with the issue, or empty for issues of the table
+	// metadata itself.
+	Path     string            `json:"path,omitempty"`
+	FileKind ReachableFileKind `json:"file-kind,omitempty"`
+	Message  string            `json:"message"`
+}
+
+// IntegrityReport is the result of CheckIntegrity. It marshals to JSON for
+// consumption by CI jobs.
+type IntegrityReport struct {
+	// FilesChecked is the number of referenced files that were opened.
+	FilesChecked int `json:"files-checked"`
+	// Issues holds the problems found, sorted by path.
+	Issues []IntegrityIssue `json:"issues"`
+	// Checksums maps the path of every file that could be read to its
+	// CRC-32C checksum, hex encoded. It is only set if checksums were
+	// computed, see WithIntegrityChecksums.
+	Checksums map[string]string `json:"checksums,omitempty"`
+}
+
+// Valid reports whether no issues were found.
+func (r IntegrityReport) Valid() bool { return len(r.Issues) == 0 }
+
+type integrityConfig struct {
+	checksums   bool
+	expected    map[string]string
+	concurrency int
+}
+
+// IntegrityOption configures CheckIntegrity.
+type IntegrityOption func(*integrityConfig)
+
+// WithIntegrityChecksums sets whether every referenced file is read in full
+// to compute its checksum for the report. By default files are only
+// opened to check their size.
+func WithIntegrityChecksums(enabled bool) IntegrityOption {
+	return func(cfg *integrityConfig) {
+		cfg.checksums = enabled
+	}
+}
+
+// WithExpectedChecksums compares the checksums of the referenced files
+// against those of a previous report, such as one taken when the table
+// was backed up, and reports the files whose content changed. Files
+// absent from expected are not compared. It implies WithIntegrityChecksums.
+func WithExpectedChecksums(expected map[string]string) IntegrityOption {
+	return func(cfg *integrityConfig) {
+		cfg.checksums = true
+		cfg.expected = expected
+	}
+}
+
+// WithIntegrityConcurrency sets the number of files read at once.
+func WithIntegrityConcurrency(n int) IntegrityOption {
+	return func(cfg *integrityConfig) {
+		if n > 0 {
+			cfg.concurrency = n
+		}
+	}
+}
+
+// CheckIntegrity verifies the files referenced by the table's metadata.
+// It checks that the current metadata file, if any, the manifest lists and
+// manifests of every snapshot, their data and delete files, and the
+// statistics files exist and have the size recorded for them, that
+// manifest lists and manifests decode, and that the snapshot, schema and
+// partition spec IDs they reference exist in the metadata.
+//
+// Files of previous metadata versions are not checked, since they may
+// have been deleted after commit. Problems with the table are reported as
+// issues; an error is only returned if the check itself fails, such as
+// when the context is canceled.
+func (t Table) CheckIntegrity(ctx context.Context, opts ...IntegrityOption) (IntegrityReport, error) {
+	t = t.pin()
+
+	cfg := integrityConfig{concurrency: config.EnvConfig.MaxWorkers}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	fs, err := t.FS(ctx)
+	if err != nil {
+		return IntegrityReport{}, err
+	}
+
+	c := &integrityChecker{meta: t.Metadata(), fs: fs, cfg: cfg}
+	if cfg.checksums {
+		c.report.Checksums = make(map[string]string)
+	}
+
+	c.checkMetadataIDs()
+	files, err := c.collectFiles(ctx, t.MetadataLocation())
+	if err != nil {
+		return IntegrityReport{}, err
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(cfg.concurrency)
+	for _, f := range files {
+		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			c.checkFile(f)
+
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return IntegrityReport{}, err
+	}
+
+	slices.SortStableFunc(c.report.Issues, func(a, b IntegrityIssue) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+
+	return c.report, nil
+}
+
+type integrityChecker struct {
+	meta Metadata
+	fs   iceio.IO
+	cfg  integrityConfig
+
+	mx     sync.Mutex
+	report IntegrityReport
+}
+
+func (c *integrityChecker) addIssue(kind IntegrityIssueKind, f ReachableFile, format string, args ...any) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	c.report.Issues = append(c.report.Issues, IntegrityIssue{
+		Kind: kind, Path: f.Path, FileKind: f.Kind, Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// checkMetadataIDs checks the IDs referenced from the metadata itself.
+func (c *integrityChecker) checkMetadataIDs() {
+	schemas := make(map[int]struct{})
+	for _, s := range c.meta.Schemas() {
+		schemas[s.ID] = struct{}{}
+	}
+	if _, ok := schemas[c.meta.CurrentSchema().ID]; !ok {
+		c.addIssue(IntegrityUnknownSchema, ReachableFile{},
+			"current schema %d does not exist", c.meta.CurrentSchema().ID)
+	}
+	if c.meta.PartitionSpecByID(c.meta.DefaultPartitionSpec()) == nil {
+		c.addIssue(IntegrityUnknownSpec, ReachableFile{},
+			"default partition spec %d does not exist", c.meta.DefaultPartitionSpec())
+	}
+
+	for name, ref := range c.meta.Refs() {
+		if c.meta.SnapshotByID(ref.SnapshotID) == nil {
+			c.addIssue(IntegrityUnknownSnapshot, ReachableFile{},
+				"ref %s points to snapshot %d which does not exist", name, ref.SnapshotID)
+		}
+	}
+
+	for _, snap := range c.meta.Snapshots() {
+		if snap.SchemaID == nil {
+			continue
+		}
+		if _, ok := schemas[*snap.SchemaID]; !ok {
+			c.addIssue(IntegrityUnknownSchema, ReachableFile{},
+				"snapshot %d references schema %d which does not exist", snap.SnapshotID, *snap.SchemaID)
+		}
+	}
+
+	for stats := range c.meta.Statistics() {
+		if c.meta.SnapshotByID(stats.SnapshotID) == nil {
+			c.addIssue(IntegrityUnknownSnapshot,
+				ReachableFile{Path: stats.StatisticsPath, Kind: ReachableStatistics},
+				"statistics of snapshot %d which does not exist", stats.SnapshotID)
+		}
+	}
+	for stats := range c.meta.PartitionStatistics() {
+		if c.meta.SnapshotByID(stats.SnapshotID) == nil {
+			c.addIssue(IntegrityUnknownSnapshot,
+				ReachableFile{Path: stats.StatisticsPath, Kind: ReachablePartitionStatistics},
+				"partition statistics of snapshot %d which does not exist", stats.SnapshotID)
+		}
+	}
+}
+
+// collectFiles returns the files to check, reading the manifest lists and
+// manifests of every snapshot to do so. Manifest lists and manifests that
+// cannot be decoded are reported, and their files are not collected.
+func (c *integrityChecker) collectFiles(ctx context.Context, metadataLocation string) ([]ReachableFile, error) {
+	var (
+		files []ReachableFile
+		seen  = make(map[string]struct{})
+		mx    sync.Mutex
+	)
+	add := func(f ReachableFile) bool {
+		mx.Lock()
+		defer mx.Unlock()
+		if f.Path == "" {
+			return false
+		}
+		if _, ok := seen[f.Path]; ok {
+			return false
+		}
+		seen[f.Path] = struct{}{}
+		files = append(files, f)
+
+		return true
+	}
+
+	add(ReachableFile{Path: metadataLocation, Kind: ReachableMetadataFile})
+	for stats := range c.meta.Statistics() {
+		add(ReachableFile{
+			Path: stats.StatisticsPath, Kind: ReachableStatistics,
+			SnapshotID: stats.SnapshotID, SizeBytes: stats.FileSizeInBytes,
+		})
+	}
+	for stats := range c.meta.PartitionStatistics() {
+		add(ReachableFile{
+			Path: stats.StatisticsPath, Kind: ReachablePartitionStatistics,
+			SnapshotID: stats.SnapshotID, SizeBytes: stats.FileSizeInBytes,
+		})
+	}
+
+	type manifest struct {
+		file     ReachableFile
+		manifest iceberg.ManifestFile
+	}
+
+	var manifests []manifest
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(c.cfg.concurrency)
+	for _, snap := range c.meta.Snapshots() {
+		list := ReachableFile{Path: snap.ManifestList, Kind: ReachableManifestList, SnapshotID: snap.SnapshotID}
+		if !add(list) {
+			continue
+		}
+
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+
+			files, err := snap.Manifests(c.fs)
+			if err != nil {
+				c.addDecodeIssue(list, err)
+
+				return nil
+			}
+
+			for _, m := range files {
+				f := ReachableFile{
+					Path: m.FilePath(), Kind: ReachableManifest,
+					SnapshotID: snap.SnapshotID, SizeBytes: m.Length(),
+				}
+				if add(f) {
+					mx.Lock()
+					manifests = append(manifests, manifest{f, m})
+					mx.Unlock()
+				}
+			}
+
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	g, gctx = errgroup.WithContext(ctx)
+	g.SetLimit(c.cfg.concurrency)
+	for _, m := range manifests {
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+			for _, df := range c.checkManifest(m.file, m.manifest) {
+				add(df)
+			}
+
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// checkManifest decodes the manifest m, checks the IDs its entries
+// reference and returns the files of its entries.
+func (c *integrityChecker) checkManifest(f ReachableFile, m iceberg.ManifestFile) []ReachableFile {
+	if c.meta.PartitionSpecByID(int(m.PartitionSpecID())) == nil {
+		c.addIssue(IntegrityUnknownSpec, f,
+			"manifest references partition spec %d which does not exist", m.PartitionSpecID())
+	}
+
+	entries, err := m.FetchEntries(c.fs, false)
+	if err != nil {
+		c.addDecodeIssue(f, err)
+
+		return nil
+	}
+
+	files := make([]ReachableFile, 0, len(entries))
+	for _, e := range entries {
+		df := e.DataFile()
+		// entries added or deleted by the snapshot that wrote the manifest
+		// reference it, which may since have expired; any other snapshot
+		// they reference must exist
+		if e.Status() != iceberg.EntryStatusEXISTING && e.SnapshotID() != m.SnapshotID() &&
+			c.meta.SnapshotByID(e.SnapshotID()) == nil {
+			c.addIssue(IntegrityUnknownSnapshot, f,
+				"entry for %s references snapshot %d which does not exist",
+				df.FilePath(), e.SnapshotID())
+		}
+
+		files = append(files, ReachableFile{
+			Path: df.FilePath(), Kind: reachableKind(df.ContentType()),
+			SnapshotID: f.SnapshotID, SizeBytes: df.FileSizeBytes(),
+		})
+	}
+
+	return files
+}
+
+// addDecodeIssue reports that the file f could not be decoded, unless it
+// cannot be opened at all, which is reported when the file is checked.
+func (c *integrityChecker) addDecodeIssue(f ReachableFile, err error) {
+	rdr, openErr := c.fs.Open(f.Path)
+	if openErr != nil {
+		return
+	}
+	rdr.Close()
+
+	c.addIssue(IntegrityUndecodableFile, f, "failed to decode: %s", err)
+}
+
+// checkFile checks that f exists and has its recorded size, and computes
+// its checksum if enabled.
+func (c *integrityChecker) checkFile(f ReachableFile) {
+	rdr, err := c.fs.Open(f.Path)
+	if err != nil {
+		c.addIssue(IntegrityMissingFile, f, "failed to open: %s", err)
+
+		return
+	}
+	defer rdr.Close()
+
+	c.mx.Lock()
+	c.report.FilesChecked++
+	c.mx.Unlock()
+
+	info, err := rdr.Stat()
+	if err != nil {
+		c.addIssue(IntegrityMissingFile, f, "failed to stat: %s", err)
+
+		return
+	}
+	if f.SizeBytes > 0 && info.Size() != f.SizeBytes {
+		c.addIssue(IntegrityLengthMismatch, f,
+			"recorded size is %d bytes but the file has %d", f.SizeBytes, info.Size())
+	}
+
+	if !c.cfg.checksums {
+		return
+	}
+
+	hash := crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	if _, err := io.Copy(hash, rdr); err != nil {
+		c.addIssue(IntegrityMissingFile, f, "failed to read: %s", err)
+
+		return
+	}
+	sum := fmt.Sprintf("%08x", hash.Sum32())
+
+	c.mx.Lock()
+	c.report.Checksums[f.Path] = sum
+	c.mx.Unlock()
+
+	if expected, ok := c.cfg.expected[f.Path]; ok && expected != sum {
+		c.addIssue(IntegrityChecksumMismatch, f,
+			"checksum is %s but %s was expected", sum, expected)
+	}
+}
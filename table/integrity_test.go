@@ -0,0 +1,107 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckIntegrity(t *testing.T) {
+	ctx := context.Background()
+	tbl := newTestTable(t)
+
+	arrSchema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	for _, data := range []string{`[{"id": 1}, {"id": 2}]`, `[{"id": 3}]`} {
+		arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{data})
+		require.NoError(t, err)
+
+		tbl, err = tbl.AppendTable(ctx, arrTbl, 10, nil)
+		arrTbl.Release()
+		require.NoError(t, err)
+	}
+
+	report, err := tbl.CheckIntegrity(ctx, table.WithIntegrityChecksums(true))
+	require.NoError(t, err)
+	assert.True(t, report.Valid(), report.Issues)
+	// two manifest lists, two manifests and two data files; the mocked
+	// catalog does not write metadata files
+	assert.Equal(t, 6, report.FilesChecked)
+	assert.Len(t, report.Checksums, 6)
+
+	tasks, err := tbl.Scan().PlanFiles(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+
+	manifests, err := tbl.CurrentSnapshot().Manifests(iceio.LocalFS{})
+	require.NoError(t, err)
+	require.Len(t, manifests, 2)
+
+	// rewrite a data file in place with its size unchanged, remove the
+	// other one and truncate a manifest
+	changed, missing := tasks[0].File.FilePath(), tasks[1].File.FilePath()
+	data, err := os.ReadFile(changed)
+	require.NoError(t, err)
+	data[len(data)/2] ^= 0xff
+	require.NoError(t, os.WriteFile(changed, data, 0o644))
+	require.NoError(t, os.Remove(missing))
+
+	truncated := manifests[0].FilePath()
+	require.NoError(t, os.Truncate(truncated, manifests[0].Length()/2))
+
+	report, err = tbl.CheckIntegrity(ctx, table.WithExpectedChecksums(report.Checksums),
+		table.WithIntegrityConcurrency(1))
+	require.NoError(t, err)
+	assert.False(t, report.Valid())
+
+	issues := make(map[string][]table.IntegrityIssueKind)
+	for _, issue := range report.Issues {
+		issues[issue.Path] = append(issues[issue.Path], issue.Kind)
+	}
+
+	// the entries of the truncated manifest cannot be read, so only the
+	// files of the other manifest are checked
+	assert.Len(t, issues, 2)
+	assert.ElementsMatch(t, []table.IntegrityIssueKind{
+		table.IntegrityUndecodableFile, table.IntegrityLengthMismatch, table.IntegrityChecksumMismatch,
+	}, issues[truncated])
+
+	if _, ok := issues[missing]; ok {
+		assert.Equal(t, []table.IntegrityIssueKind{table.IntegrityMissingFile}, issues[missing])
+	} else {
+		assert.Equal(t, []table.IntegrityIssueKind{table.IntegrityChecksumMismatch}, issues[changed])
+	}
+
+	out, err := json.Marshal(report)
+	require.NoError(t, err)
+
+	var decoded table.IntegrityReport
+	require.NoError(t, json.Unmarshal(out, &decoded))
+	assert.Equal(t, report.Issues, decoded.Issues)
+	assert.Contains(t, string(out), `"kind":"undecodable-file"`)
+}
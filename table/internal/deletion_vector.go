@@ -0,0 +1,454 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"iter"
+	"maps"
+	"math"
+	"math/bits"
+	"slices"
+)
+
+// DeletionVectorBlobType is the puffin blob type of deletion vectors.
+const DeletionVectorBlobType = "deletion-vector-v1"
+
+// properties of the blob metadata of deletion vectors
+const (
+	DeletionVectorReferencedDataFileProp = "referenced-data-file"
+	DeletionVectorCardinalityProp        = "cardinality"
+)
+
+var (
+	deletionVectorMagic = [4]byte{0xD1, 0xD3, 0x39, 0x64}
+
+	errInvalidDeletionVector = errors.New("invalid deletion vector")
+)
+
+const (
+	// cookies opening the portable serialization of a 32-bit roaring
+	// bitmap without and with run containers
+	roaringSerialCookieNoRuns = 12346
+	roaringSerialCookie       = 12347
+	// bitmaps with run containers and fewer containers than this omit
+	// the container offsets
+	roaringNoOffsetThreshold = 4
+	// containers with more values than this are stored as bitmaps
+	roaringMaxArrayContainer = 4096
+
+	containerWords = 1 << 16 / 64
+)
+
+type positionContainer [containerWords]uint64
+
+func (c *positionContainer) cardinality() int {
+	n := 0
+	for _, w := range c {
+		n += bits.OnesCount64(w)
+	}
+
+	return n
+}
+
+// PositionBitmap is a set of row positions, the content of a deletion
+// vector. Positions are grouped into containers of 2^16 positions which
+// are serialized as the portable 64-bit roaring bitmap that the spec
+// requires for deletion vectors.
+type PositionBitmap struct {
+	// containers by the upper 48 bits of their positions
+	containers map[uint64]*positionContainer
+}
+
+// NewPositionBitmap returns an empty bitmap.
+func NewPositionBitmap() *PositionBitmap {
+	return &PositionBitmap{containers: make(map[uint64]*positionContainer)}
+}
+
+// Add adds the position, which must not be negative, to the bitmap.
+func (b *PositionBitmap) Add(pos int64) {
+	key, low := uint64(pos)>>16, uint16(pos)
+	c, ok := b.containers[key]
+	if !ok {
+		c = new(positionContainer)
+		b.containers[key] = c
+	}
+	c[low/64] |= 1 << (low % 64)
+}
+
+// Contains returns whether the position is in the bitmap.
+func (b *PositionBitmap) Contains(pos int64) bool {
+	if pos < 0 {
+		return false
+	}
+
+	c, ok := b.containers[uint64(pos)>>16]
+	if !ok {
+		return false
+	}
+	low := uint16(pos)
+
+	return c[low/64]&(1<<(low%64)) != 0
+}
+
+// Merge adds all of the positions of other to the bitmap.
+func (b *PositionBitmap) Merge(other *PositionBitmap) {
+	for key, oc := range other.containers {
+		c, ok := b.containers[key]
+		if !ok {
+			c = new(positionContainer)
+			b.containers[key] = c
+		}
+		for i, w := range oc {
+			c[i] |= w
+		}
+	}
+}
+
+// Cardinality returns the number of positions in the bitmap.
+func (b *PositionBitmap) Cardinality() int64 {
+	var n int64
+	for _, c := range b.containers {
+		n += int64(c.cardinality())
+	}
+
+	return n
+}
+
+// IsEmpty returns whether the bitmap has no positions.
+func (b *PositionBitmap) IsEmpty() bool {
+	for _, c := range b.containers {
+		for _, w := range c {
+			if w != 0 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Positions iterates over the positions of the bitmap in ascending order.
+func (b *PositionBitmap) Positions() iter.Seq[int64] {
+	return func(yield func(int64) bool) {
+		for _, key := range slices.Sorted(maps.Keys(b.containers)) {
+			c := b.containers[key]
+			for i, w := range c {
+				for w != 0 {
+					bit := bits.TrailingZeros64(w)
+					w &= w - 1
+					if !yield(int64(key<<16 | uint64(i*64+bit))) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// MarshalBinary serializes the bitmap in the portable 64-bit roaring
+// format: the number of 32-bit bitmaps followed by each bitmap, in
+// ascending order of the upper 32 bits of its positions, prefixed with
+// those bits.
+func (b *PositionBitmap) MarshalBinary() ([]byte, error) {
+	groups := make(map[uint32][]uint64)
+	for key, c := range b.containers {
+		if c.cardinality() == 0 {
+			continue
+		}
+		high := uint32(key >> 16)
+		groups[high] = append(groups[high], key)
+	}
+
+	highs := slices.Sorted(maps.Keys(groups))
+	out := binary.LittleEndian.AppendUint64(nil, uint64(len(highs)))
+	for _, high := range highs {
+		keys := groups[high]
+		slices.Sort(keys)
+		out = binary.LittleEndian.AppendUint32(out, high)
+		out = b.appendRoaring32(out, keys)
+	}
+
+	return out, nil
+}
+
+// appendRoaring32 appends the portable serialization, without run
+// containers, of the 32-bit bitmap made of the containers with the
+// given keys.
+func (b *PositionBitmap) appendRoaring32(out []byte, keys []uint64) []byte {
+	start := len(out)
+	out = binary.LittleEndian.AppendUint32(out, roaringSerialCookieNoRuns)
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(keys)))
+
+	cards := make([]int, len(keys))
+	for i, key := range keys {
+		cards[i] = b.containers[key].cardinality()
+		out = binary.LittleEndian.AppendUint16(out, uint16(key))
+		out = binary.LittleEndian.AppendUint16(out, uint16(cards[i]-1))
+	}
+
+	offset := len(out) - start + 4*len(keys)
+	for _, card := range cards {
+		out = binary.LittleEndian.AppendUint32(out, uint32(offset))
+		if card > roaringMaxArrayContainer {
+			offset += 8 * containerWords
+		} else {
+			offset += 2 * card
+		}
+	}
+
+	for i, key := range keys {
+		c := b.containers[key]
+		if cards[i] > roaringMaxArrayContainer {
+			for _, w := range c {
+				out = binary.LittleEndian.AppendUint64(out, w)
+			}
+
+			continue
+		}
+
+		for j, w := range c {
+			for w != 0 {
+				bit := bits.TrailingZeros64(w)
+				w &= w - 1
+				out = binary.LittleEndian.AppendUint16(out, uint16(j*64+bit))
+			}
+		}
+	}
+
+	return out
+}
+
+// UnmarshalBinary replaces the content of the bitmap with the portable
+// 64-bit roaring bitmap in data.
+func (b *PositionBitmap) UnmarshalBinary(data []byte) error {
+	b.containers = make(map[uint64]*positionContainer)
+
+	rd := roaringReader{data: data}
+	n, err := rd.uint64()
+	if err != nil {
+		return err
+	}
+
+	for range n {
+		high, err := rd.uint32()
+		if err != nil {
+			return err
+		}
+		if err := b.readRoaring32(&rd, uint64(high)<<16); err != nil {
+			return err
+		}
+	}
+
+	if len(rd.data) != rd.pos {
+		return fmt.Errorf("%w: %d trailing bytes", errInvalidDeletionVector, len(rd.data)-rd.pos)
+	}
+
+	return nil
+}
+
+func (b *PositionBitmap) readRoaring32(rd *roaringReader, high uint64) error {
+	cookie, err := rd.uint32()
+	if err != nil {
+		return err
+	}
+
+	var (
+		n       int
+		runFlag []byte
+	)
+	switch {
+	case cookie == roaringSerialCookieNoRuns:
+		count, err := rd.uint32()
+		if err != nil {
+			return err
+		}
+		n = int(count)
+	case cookie&0xFFFF == roaringSerialCookie:
+		n = int(cookie>>16) + 1
+		if runFlag, err = rd.bytes((n + 7) / 8); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: unknown roaring cookie %d", errInvalidDeletionVector, cookie)
+	}
+
+	keys, cards := make([]uint16, n), make([]int, n)
+	for i := range n {
+		if keys[i], err = rd.uint16(); err != nil {
+			return err
+		}
+		card, err := rd.uint16()
+		if err != nil {
+			return err
+		}
+		cards[i] = int(card) + 1
+	}
+
+	// the containers directly follow the offsets, so they can be skipped
+	if runFlag == nil || n >= roaringNoOffsetThreshold {
+		if _, err := rd.bytes(4 * n); err != nil {
+			return err
+		}
+	}
+
+	for i := range n {
+		c := new(positionContainer)
+		switch {
+		case runFlag != nil && runFlag[i/8]&(1<<(i%8)) != 0:
+			runs, err := rd.uint16()
+			if err != nil {
+				return err
+			}
+			for range runs {
+				start, err := rd.uint16()
+				if err != nil {
+					return err
+				}
+				length, err := rd.uint16()
+				if err != nil {
+					return err
+				}
+				for v := int(start); v <= int(start)+int(length); v++ {
+					if v > math.MaxUint16 {
+						return fmt.Errorf("%w: run exceeds container", errInvalidDeletionVector)
+					}
+					c[v/64] |= 1 << (v % 64)
+				}
+			}
+		case cards[i] > roaringMaxArrayContainer:
+			for j := range c {
+				if c[j], err = rd.uint64(); err != nil {
+					return err
+				}
+			}
+		default:
+			for range cards[i] {
+				v, err := rd.uint16()
+				if err != nil {
+					return err
+				}
+				c[v/64] |= 1 << (v % 64)
+			}
+		}
+
+		key := high | uint64(keys[i])
+		if existing, ok := b.containers[key]; ok {
+			for j, w := range c {
+				existing[j] |= w
+			}
+
+			continue
+		}
+		b.containers[key] = c
+	}
+
+	return nil
+}
+
+type roaringReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *roaringReader) bytes(n int) ([]byte, error) {
+	if n < 0 || len(r.data)-r.pos < n {
+		return nil, fmt.Errorf("%w: truncated bitmap", errInvalidDeletionVector)
+	}
+	out := r.data[r.pos : r.pos+n]
+	r.pos += n
+
+	return out, nil
+}
+
+func (r *roaringReader) uint16() (uint16, error) {
+	b, err := r.bytes(2)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint16(b), nil
+}
+
+func (r *roaringReader) uint32() (uint32, error) {
+	b, err := r.bytes(4)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+func (r *roaringReader) uint64() (uint64, error) {
+	b, err := r.bytes(8)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint64(b), nil
+}
+
+// SerializeDeletionVector returns the puffin blob of a deletion vector
+// with the positions of the bitmap: the big-endian length of the magic
+// and bitmap, the magic, the serialized bitmap and the big-endian CRC-32
+// of the magic and bitmap.
+func SerializeDeletionVector(b *PositionBitmap) ([]byte, error) {
+	bitmap, err := b.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, 4+len(deletionVectorMagic)+len(bitmap)+4)
+	out = binary.BigEndian.AppendUint32(out, uint32(len(deletionVectorMagic)+len(bitmap)))
+	out = append(out, deletionVectorMagic[:]...)
+	out = append(out, bitmap...)
+
+	return binary.BigEndian.AppendUint32(out, crc32.ChecksumIEEE(out[4:])), nil
+}
+
+// DeserializeDeletionVector returns the positions of the deletion vector
+// stored in the puffin blob.
+func DeserializeDeletionVector(blob []byte) (*PositionBitmap, error) {
+	if len(blob) < 4+len(deletionVectorMagic)+4 {
+		return nil, fmt.Errorf("%w: blob of %d bytes is too small", errInvalidDeletionVector, len(blob))
+	}
+
+	length := binary.BigEndian.Uint32(blob)
+	if int64(length) != int64(len(blob)-8) {
+		return nil, fmt.Errorf("%w: length %d does not match blob of %d bytes",
+			errInvalidDeletionVector, length, len(blob))
+	}
+
+	body := blob[4 : len(blob)-4]
+	if [4]byte(body[:4]) != deletionVectorMagic {
+		return nil, fmt.Errorf("%w: invalid magic", errInvalidDeletionVector)
+	}
+	if crc := binary.BigEndian.Uint32(blob[len(blob)-4:]); crc != crc32.ChecksumIEEE(body) {
+		return nil, fmt.Errorf("%w: checksum mismatch", errInvalidDeletionVector)
+	}
+
+	b := NewPositionBitmap()
+	if err := b.UnmarshalBinary(body[4:]); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
@@ -0,0 +1,142 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package internal_test
+
+import (
+	"encoding/binary"
+	"slices"
+	"testing"
+
+	"github.com/apache/iceberg-go/table/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPositionBitmapSerialization(t *testing.T) {
+	b := internal.NewPositionBitmap()
+	b.Add(3)
+	b.Add(1)
+	b.Add(3)
+
+	data, err := b.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{
+		1, 0, 0, 0, 0, 0, 0, 0, // one 32-bit bitmap
+		0, 0, 0, 0, // with upper bits 0
+		0x3A, 0x30, 0, 0, // no run containers cookie
+		1, 0, 0, 0, // one container
+		0, 0, 1, 0, // key 0 with cardinality 2
+		16, 0, 0, 0, // offset of the container
+		1, 0, 3, 0, // array container
+	}, data)
+
+	blob, err := internal.SerializeDeletionVector(b)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4+len(data), binary.BigEndian.Uint32(blob))
+	assert.Equal(t, []byte{0xD1, 0xD3, 0x39, 0x64}, blob[4:8])
+
+	out, err := internal.DeserializeDeletionVector(blob)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 3}, slices.Collect(out.Positions()))
+}
+
+func TestPositionBitmapRoundTrip(t *testing.T) {
+	positions := []int64{0, 5, 65535, 65536, 1<<32 + 7, 1 << 33, 1<<40 + 65537}
+	// enough positions in one container to store it as a bitmap
+	for i := range int64(5000) {
+		positions = append(positions, 3<<16+2*i)
+	}
+
+	b := internal.NewPositionBitmap()
+	for _, pos := range positions {
+		b.Add(pos)
+	}
+	slices.Sort(positions)
+
+	assert.EqualValues(t, len(positions), b.Cardinality())
+	assert.True(t, b.Contains(1<<32+7))
+	assert.False(t, b.Contains(1<<32+8))
+	assert.False(t, b.Contains(-1))
+
+	blob, err := internal.SerializeDeletionVector(b)
+	require.NoError(t, err)
+	out, err := internal.DeserializeDeletionVector(blob)
+	require.NoError(t, err)
+	assert.Equal(t, positions, slices.Collect(out.Positions()))
+
+	other := internal.NewPositionBitmap()
+	other.Add(4)
+	other.Add(5)
+	out.Merge(other)
+	assert.EqualValues(t, len(positions)+1, out.Cardinality())
+	assert.True(t, out.Contains(4))
+	assert.True(t, internal.NewPositionBitmap().IsEmpty())
+}
+
+func TestPositionBitmapRunContainers(t *testing.T) {
+	data := []byte{
+		1, 0, 0, 0, 0, 0, 0, 0, // one 32-bit bitmap
+		1, 0, 0, 0, // with upper bits 1
+		0x3B, 0x30, 1, 0, // run containers cookie, two containers
+		0x01,       // the first container is a run container
+		0, 0, 9, 0, // key 0 with cardinality 10
+		2, 0, 1, 0, // key 2 with cardinality 2
+		1, 0, 100, 0, 9, 0, // a run of 10 positions from 100
+		7, 0, 9, 0, // array container
+	}
+
+	var b internal.PositionBitmap
+	require.NoError(t, b.UnmarshalBinary(data))
+
+	expected := make([]int64, 0, 12)
+	for i := range int64(10) {
+		expected = append(expected, 1<<32+100+i)
+	}
+	expected = append(expected, 1<<32|2<<16|7, 1<<32|2<<16|9)
+	assert.Equal(t, expected, slices.Collect(b.Positions()))
+
+	assert.Error(t, b.UnmarshalBinary(data[:len(data)-1]))
+	assert.Error(t, b.UnmarshalBinary(append(slices.Clone(data), 0)))
+}
+
+func TestDeserializeInvalidDeletionVector(t *testing.T) {
+	b := internal.NewPositionBitmap()
+	b.Add(10)
+	blob, err := internal.SerializeDeletionVector(b)
+	require.NoError(t, err)
+
+	corrupt := func(i int) []byte {
+		out := slices.Clone(blob)
+		out[i] ^= 0xFF
+
+		return out
+	}
+
+	for name, data := range map[string][]byte{
+		"too small": blob[:6],
+		"length":    corrupt(3),
+		"magic":     corrupt(4),
+		"bitmap":    corrupt(len(blob) - 6),
+		"checksum":  corrupt(len(blob) - 1),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := internal.DeserializeDeletionVector(data)
+			assert.Error(t, err)
+		})
+	}
+}
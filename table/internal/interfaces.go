@@ -27,6 +27,7 @@ import (
 	"github.com/apache/arrow-go/v18/arrow/array"
 	"github.com/apache/arrow-go/v18/arrow/compute"
 	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/encryption"
 	iceio "github.com/apache/iceberg-go/io"
 )
 
@@ -63,8 +64,9 @@ type FileReader interface {
 	// PrunedSchema takes in the list of projected field IDs and returns the arrow schema
 	// that represents the underlying file schema with only the projected fields. It also
 	// returns the indexes of the projected columns to allow reading *only* the needed
-	// columns.
-	PrunedSchema(projectedIDs map[int]struct{}, mapping iceberg.NameMapping) (*arrow.Schema, []int, error)
+	// columns. Columns without field IDs are resolved through the name mapping,
+	// ignoring case unless caseSensitive is set.
+	PrunedSchema(projectedIDs map[int]struct{}, mapping iceberg.NameMapping, caseSensitive bool) (*arrow.Schema, []int, error)
 	// GetRecords returns a record reader for only the provided columns (using nil will read
 	// all of the columns of the underlying file.) The `tester` is a function that can be used,
 	// if non-nil, to filter aspects of the file such as skipping row groups in a parquet file.
@@ -78,7 +80,7 @@ type FileFormat interface {
 	PathToIDMapping(*iceberg.Schema) (map[string]int, error)
 	DataFileStatsFromMeta(rdr Metadata, statsCols map[int]StatisticsCollector, colMapping map[string]int) *DataFileStatistics
 	GetWriteProperties(iceberg.Properties) any
-	WriteDataFile(ctx context.Context, fs iceio.WriteFileIO, partitionValues map[int]any, info WriteFileInfo, batches []arrow.RecordBatch) (iceberg.DataFile, error)
+	WriteDataFile(ctx context.Context, fs iceio.WriteFileIO, partition iceberg.PartitionData, info WriteFileInfo, batches []arrow.RecordBatch) (iceberg.DataFile, error)
 }
 
 func GetFileFormat(format iceberg.FileFormat) FileFormat {
@@ -101,8 +103,20 @@ func FormatFromFileName(fileName string) FileFormat {
 
 type WriteFileInfo struct {
 	FileSchema *iceberg.Schema
-	Spec       iceberg.PartitionSpec
 	FileName   string
 	StatsCols  map[int]StatisticsCollector
 	WriteProps any
+	// Encryption, if non-nil, is used to encrypt the written file.
+	Encryption *encryption.StandardEncryptionManager
+	// Content is the type of content being written, data by default.
+	Content iceberg.ManifestEntryContent
+	// EqualityFieldIDs are the IDs of the columns that the rows of an
+	// equality delete file are matched on.
+	EqualityFieldIDs []int
+	// ReferencedDataFile is the data file that every row of a position
+	// delete file references, if there is only one.
+	ReferencedDataFile string
+	// RowGroupSizeBytes is the size after which a new row group is
+	// started. Zero leaves row groups bounded by row count only.
+	RowGroupSizeBytes int64
 }
@@ -18,6 +18,7 @@
 package internal
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
@@ -25,6 +26,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"unsafe"
 
 	"github.com/apache/arrow-go/v18/arrow"
@@ -32,12 +34,14 @@ import (
 	"github.com/apache/arrow-go/v18/arrow/compute"
 	"github.com/apache/arrow-go/v18/arrow/decimal128"
 	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/arrow/util"
 	"github.com/apache/arrow-go/v18/parquet"
 	"github.com/apache/arrow-go/v18/parquet/compress"
 	"github.com/apache/arrow-go/v18/parquet/file"
 	"github.com/apache/arrow-go/v18/parquet/metadata"
 	"github.com/apache/arrow-go/v18/parquet/pqarrow"
 	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/encryption"
 	"github.com/apache/iceberg-go/internal"
 	iceio "github.com/apache/iceberg-go/io"
 	"github.com/google/uuid"
@@ -82,7 +86,7 @@ func (parquetFormat) Open(ctx context.Context, fs iceio.IO, path string) (FileRe
 		return nil, err
 	}
 
-	return wrapPqArrowReader{arrRdr}, nil
+	return wrapPqArrowReader{FileReader: arrRdr}, nil
 }
 
 func (parquetFormat) PathToIDMapping(sc *iceberg.Schema) (map[string]int, error) {
@@ -199,18 +203,36 @@ func (parquetFormat) PrimitiveTypeToPhysicalType(typ iceberg.PrimitiveType) stri
 	}
 }
 
+// sinkBufferSize is the size of the buffer between the parquet writer
+// and the file being written.
+const sinkBufferSize = 1 << 20
+
+var sinkBufferPool = sync.Pool{New: func() any {
+	return bufio.NewWriterSize(nil, sinkBufferSize)
+}}
+
+// positiveProp returns the integer property for key, or defaultVal if
+// the property is unset or not positive.
+func positiveProp(props iceberg.Properties, key string, defaultVal int) int64 {
+	if v := props.GetInt(key, defaultVal); v > 0 {
+		return int64(v)
+	}
+
+	return int64(defaultVal)
+}
+
 func (parquetFormat) GetWriteProperties(props iceberg.Properties) any {
 	writerProps := []parquet.WriterProperty{
-		parquet.WithDictionaryDefault(false),
-		parquet.WithMaxRowGroupLength(int64(props.GetInt(ParquetRowGroupLimitKey,
-			ParquetRowGroupLimitDefault))),
-		parquet.WithDataPageSize(int64(props.GetInt(ParquetPageSizeBytesKey,
-			ParquetPageSizeBytesDefault))),
+		parquet.WithDictionaryDefault(true),
+		parquet.WithMaxRowGroupLength(positiveProp(props, ParquetRowGroupLimitKey,
+			ParquetRowGroupLimitDefault)),
+		parquet.WithDataPageSize(positiveProp(props, ParquetPageSizeBytesKey,
+			ParquetPageSizeBytesDefault)),
 		parquet.WithDataPageVersion(parquet.DataPageV2),
-		parquet.WithBatchSize(int64(props.GetInt(ParquetPageRowLimitKey,
-			ParquetPageRowLimitDefault))),
-		parquet.WithDictionaryPageSizeLimit(int64(props.GetInt(ParquetDictSizeBytesKey,
-			ParquetDictSizeBytesDefault))),
+		parquet.WithBatchSize(positiveProp(props, ParquetPageRowLimitKey,
+			ParquetPageRowLimitDefault)),
+		parquet.WithDictionaryPageSizeLimit(positiveProp(props, ParquetDictSizeBytesKey,
+			ParquetDictSizeBytesDefault)),
 	}
 
 	compression := props.Get(ParquetCompressionKey, ParquetCompressionDefault)
@@ -243,7 +265,7 @@ func (parquetFormat) GetWriteProperties(props iceberg.Properties) any {
 		parquet.WithCompressionLevel(compressionLevel))
 }
 
-func (p parquetFormat) WriteDataFile(ctx context.Context, fs iceio.WriteFileIO, partitionValues map[int]any, info WriteFileInfo, batches []arrow.RecordBatch) (_ iceberg.DataFile, err error) {
+func (p parquetFormat) WriteDataFile(ctx context.Context, fs iceio.WriteFileIO, partition iceberg.PartitionData, info WriteFileInfo, batches []arrow.RecordBatch) (_ iceberg.DataFile, err error) {
 	fw, err := fs.Create(info.FileName)
 	if err != nil {
 		return nil, err
@@ -251,9 +273,37 @@ func (p parquetFormat) WriteDataFile(ctx context.Context, fs iceio.WriteFileIO,
 
 	defer internal.CheckedClose(fw, &err)
 
-	cntWriter := internal.CountingWriter{W: fw}
+	// buffer the many small writes of the parquet writer before they
+	// reach the file
+	bw := sinkBufferPool.Get().(*bufio.Writer)
+	bw.Reset(fw)
+	defer func() {
+		bw.Reset(nil)
+		sinkBufferPool.Put(bw)
+	}()
+
+	cntWriter := internal.CountingWriter{W: bw}
 	mem := compute.GetAllocator(ctx)
-	writerProps := parquet.NewWriterProperties(info.WriteProps.([]parquet.WriterProperty)...)
+	props := slices.Concat(info.WriteProps.([]parquet.WriterProperty),
+		[]parquet.WriterProperty{parquet.WithAllocator(mem)})
+
+	var keyMetadata []byte
+	if info.Encryption != nil {
+		key, err := info.Encryption.NewFileKey()
+		if err != nil {
+			return nil, err
+		}
+		keyMetadata = key.Metadata
+
+		// the AAD prefix is kept in the key metadata rather than in the
+		// file, as the Java implementation does. Encrypted v2 data pages
+		// cannot be read back by the parquet reader, so v1 pages are used.
+		props = append(props, parquet.WithDataPageVersion(parquet.DataPageV1),
+			parquet.WithEncryptionProperties(parquet.NewFileEncryptionProperties(
+				string(key.Key), parquet.WithAadPrefix(string(key.AADPrefix)), parquet.DisableAadPrefixStorage())))
+	}
+
+	writerProps := parquet.NewWriterProperties(props...)
 	arrProps := pqarrow.NewArrowWriterProperties(pqarrow.WithAllocator(mem), pqarrow.WithStoreSchema())
 
 	writer, err := pqarrow.NewFileWriter(batches[0].Schema(), &cntWriter, writerProps, arrProps)
@@ -261,16 +311,30 @@ func (p parquetFormat) WriteDataFile(ctx context.Context, fs iceio.WriteFileIO,
 		return nil, err
 	}
 
+	// buffered row groups only report their size once pages are flushed,
+	// so row groups are cut by the in-memory size of the batches instead
+	var rowGroupBytes int64
 	for _, batch := range batches {
+		if rowGroupBytes > 0 && info.RowGroupSizeBytes > 0 &&
+			rowGroupBytes >= info.RowGroupSizeBytes {
+			writer.NewBufferedRowGroup()
+			rowGroupBytes = 0
+		}
+
 		if err := writer.WriteBuffered(batch); err != nil {
 			return nil, err
 		}
+		rowGroupBytes += util.TotalRecordSize(batch)
 	}
 
 	if err := writer.Close(); err != nil {
 		return nil, err
 	}
 
+	if err := bw.Flush(); err != nil {
+		return nil, err
+	}
+
 	filemeta, err := writer.FileMetadata()
 	if err != nil {
 		return nil, err
@@ -281,8 +345,13 @@ func (p parquetFormat) WriteDataFile(ctx context.Context, fs iceio.WriteFileIO,
 		return nil, err
 	}
 
-	return p.DataFileStatsFromMeta(filemeta, info.StatsCols, colMapping).
-		ToDataFile(info.FileSchema, info.Spec, info.FileName, iceberg.ParquetFile, cntWriter.Count, partitionValues), nil
+	stats := p.DataFileStatsFromMeta(filemeta, info.StatsCols, colMapping)
+	stats.KeyMetadata = keyMetadata
+	stats.Content = info.Content
+	stats.ReferencedDataFile = info.ReferencedDataFile
+	stats.EqualityFieldIDs = info.EqualityFieldIDs
+
+	return stats.ToDataFileWithPartition(partition, info.FileName, iceberg.ParquetFile, cntWriter.Count)
 }
 
 type decAsIntAgg[T int32 | int64] struct {
@@ -565,6 +634,10 @@ type ParquetFileSource struct {
 
 type wrapPqArrowReader struct {
 	*pqarrow.FileReader
+
+	// ranges, if set, is notified of the column chunks that are about
+	// to be read so that they can be fetched with fewer requests.
+	ranges *iceio.RangeReader
 }
 
 func (w wrapPqArrowReader) Metadata() Metadata {
@@ -579,8 +652,8 @@ func (w wrapPqArrowReader) Close() error {
 	return w.ParquetReader().Close()
 }
 
-func (w wrapPqArrowReader) PrunedSchema(projectedIDs map[int]struct{}, mapping iceberg.NameMapping) (*arrow.Schema, []int, error) {
-	return pruneParquetColumns(w.Manifest, projectedIDs, false, mapping)
+func (w wrapPqArrowReader) PrunedSchema(projectedIDs map[int]struct{}, mapping iceberg.NameMapping, caseSensitive bool) (*arrow.Schema, []int, error) {
+	return pruneParquetColumns(w.Manifest, projectedIDs, false, mapping, caseSensitive)
 }
 
 func (w wrapPqArrowReader) GetRecords(ctx context.Context, cols []int, tester any) (array.RecordReader, error) {
@@ -613,18 +686,115 @@ func (w wrapPqArrowReader) GetRecords(ctx context.Context, cols []int, tester an
 		}
 	}
 
+	if w.ranges != nil {
+		ranges, err := columnChunkRanges(w.ParquetReader().MetaData(), rgList, cols)
+		if err != nil {
+			return nil, err
+		}
+		w.ranges.WillNeed(ranges)
+	}
+
 	return w.GetRecordReader(ctx, cols, rgList)
 }
 
+// columnChunkRanges returns the byte ranges of the column chunks for the
+// given row groups and leaf columns, nil meaning all of them.
+func columnChunkRanges(fileMeta *metadata.FileMetaData, rowGroups, cols []int) ([]iceio.Range, error) {
+	if rowGroups == nil {
+		rowGroups = make([]int, fileMeta.NumRowGroups())
+		for i := range rowGroups {
+			rowGroups[i] = i
+		}
+	}
+
+	if cols == nil {
+		cols = make([]int, fileMeta.NumColumns())
+		for i := range cols {
+			cols[i] = i
+		}
+	}
+
+	sourceSize := fileMeta.GetSourceFileSize()
+	// mirror the PARQUET-816 workaround applied by the parquet reader
+	// so that the padded read is served from the same range.
+	needsPadding := fileMeta.WriterVersion().LessThan(metadata.Parquet816FixedVersion)
+
+	ranges := make([]iceio.Range, 0, len(rowGroups)*len(cols))
+	for _, rg := range rowGroups {
+		rgMeta := fileMeta.RowGroup(rg)
+		for _, c := range cols {
+			col, err := rgMeta.ColumnChunk(c)
+			if err != nil {
+				return nil, err
+			}
+
+			start := col.DataPageOffset()
+			if col.HasDictionaryPage() && col.DictionaryPageOffset() > 0 && start > col.DictionaryPageOffset() {
+				start = col.DictionaryPageOffset()
+			}
+
+			length := col.TotalCompressedSize()
+			if needsPadding {
+				length += min(100, sourceSize-(start+length))
+			}
+			ranges = append(ranges, iceio.Range{Offset: start, Length: length})
+		}
+	}
+
+	return ranges, nil
+}
+
+type dictionaryReadsKey struct{}
+
+// WithDictionaryReads returns a context in which parquet data files read
+// through GetFile return their top-level string and binary columns as
+// dictionary arrays, keeping the dictionary encoding of the file.
+func WithDictionaryReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dictionaryReadsKey{}, true)
+}
+
+func dictionaryReads(ctx context.Context) bool {
+	v, _ := ctx.Value(dictionaryReadsKey{}).(bool)
+
+	return v
+}
+
 func (pfs *ParquetFileSource) GetReader(ctx context.Context) (FileReader, error) {
-	pf, err := pfs.fs.Open(pfs.file.FilePath())
+	pf, err := iceio.OpenRandomAccess(pfs.fs, pfs.file.FilePath())
+	if err != nil {
+		return nil, err
+	}
+
+	readProps := parquet.NewReaderProperties(pfs.mem)
+	if keyMetadata := pfs.file.KeyMetadata(); len(keyMetadata) > 0 {
+		key, err := encryption.FileKeyFromMetadata(keyMetadata)
+		if err != nil {
+			pf.Close()
+
+			return nil, fmt.Errorf("cannot read encrypted file %s: %w", pfs.file.FilePath(), err)
+		}
+		readProps.FileDecryptProps = parquet.NewFileDecryptionProperties(
+			parquet.WithFooterKey(string(key.Key)), parquet.WithDecryptAadPrefix(string(key.AADPrefix)))
+	}
+
+	info, err := pf.Stat()
 	if err != nil {
+		pf.Close()
+
 		return nil, err
 	}
 
-	rdr, err := file.NewParquetReader(pf,
-		file.WithReadProps(parquet.NewReaderProperties(pfs.mem)))
+	ranges, err := iceio.NewRangeReader(pf, info.Size(), iceio.DefaultFooterPrefetchSize)
 	if err != nil {
+		pf.Close()
+
+		return nil, err
+	}
+
+	rdr, err := file.NewParquetReader(ranges, file.WithReadProps(readProps))
+	if err != nil {
+		ranges.Close()
+
 		return nil, err
 	}
 
@@ -637,6 +807,15 @@ func (pfs *ParquetFileSource) GetReader(ctx context.Context) (FileReader, error)
 	if pfs.file.ContentType() == iceberg.EntryContentPosDeletes {
 		// for dictionary for filepath col
 		arrProps.SetReadDict(0, true)
+	} else if dictionaryReads(ctx) {
+		// only top-level columns, the schema projection does not
+		// handle dictionaries nested in lists, maps or structs
+		sc := rdr.MetaData().Schema
+		for i := range sc.NumColumns() {
+			if len(sc.Column(i).ColumnPath()) == 1 {
+				arrProps.SetReadDict(i, true)
+			}
+		}
 	}
 
 	fr, err := pqarrow.NewFileReader(rdr, arrProps, pfs.mem)
@@ -644,7 +823,7 @@ func (pfs *ParquetFileSource) GetReader(ctx context.Context) (FileReader, error)
 		return nil, err
 	}
 
-	return wrapPqArrowReader{fr}, nil
+	return wrapPqArrowReader{FileReader: fr, ranges: ranges}, nil
 }
 
 type manifestVisitor[T any] interface {
@@ -656,7 +835,7 @@ type manifestVisitor[T any] interface {
 	Primitive(pqarrow.SchemaField, *iceberg.MappedField) T
 }
 
-func visitParquetManifest[T any](manifest *pqarrow.SchemaManifest, visitor manifestVisitor[T], mapping *iceberg.MappedField) (res T, err error) {
+func visitParquetManifest[T any](manifest *pqarrow.SchemaManifest, visitor manifestVisitor[T], mapping *iceberg.MappedField, caseSensitive bool) (res T, err error) {
 	if manifest == nil {
 		err = fmt.Errorf("%w: cannot visit nil manifest", iceberg.ErrInvalidArgument)
 
@@ -674,41 +853,41 @@ func visitParquetManifest[T any](manifest *pqarrow.SchemaManifest, visitor manif
 	results := make([]T, len(manifest.Fields))
 	for i, f := range manifest.Fields {
 		if mapping != nil {
-			fieldMap = mapping.GetField(f.Field.Name)
+			fieldMap = mappedChild(mapping, f.Field.Name, caseSensitive)
 		}
-		res := visitManifestField(f, visitor, fieldMap)
+		res := visitManifestField(f, visitor, fieldMap, caseSensitive)
 		results[i] = visitor.Field(f, res, fieldMap)
 	}
 
 	return visitor.Manifest(manifest, results, mapping), nil
 }
 
-func visitParquetManifestStruct[T any](field pqarrow.SchemaField, visitor manifestVisitor[T], mapping *iceberg.MappedField) T {
+func visitParquetManifestStruct[T any](field pqarrow.SchemaField, visitor manifestVisitor[T], mapping *iceberg.MappedField, caseSensitive bool) T {
 	results := make([]T, len(field.Children))
 	var fieldMap *iceberg.MappedField
 	for i, f := range field.Children {
 		if mapping != nil {
-			fieldMap = mapping.GetField(f.Field.Name)
+			fieldMap = mappedChild(mapping, f.Field.Name, caseSensitive)
 		}
-		res := visitManifestField(f, visitor, fieldMap)
+		res := visitManifestField(f, visitor, fieldMap, caseSensitive)
 		results[i] = visitor.Field(f, res, fieldMap)
 	}
 
 	return visitor.Struct(field, results, mapping)
 }
 
-func visitManifestList[T any](field pqarrow.SchemaField, visitor manifestVisitor[T], mapping *iceberg.MappedField) T {
+func visitManifestList[T any](field pqarrow.SchemaField, visitor manifestVisitor[T], mapping *iceberg.MappedField, caseSensitive bool) T {
 	elemField := field.Children[0]
 	var elemMapping *iceberg.MappedField
 	if mapping != nil {
 		elemMapping = mapping.GetField("element")
 	}
-	res := visitManifestField(elemField, visitor, elemMapping)
+	res := visitManifestField(elemField, visitor, elemMapping, caseSensitive)
 
 	return visitor.List(field, res, mapping)
 }
 
-func visitManifestMap[T any](field pqarrow.SchemaField, visitor manifestVisitor[T], mapping *iceberg.MappedField) T {
+func visitManifestMap[T any](field pqarrow.SchemaField, visitor manifestVisitor[T], mapping *iceberg.MappedField, caseSensitive bool) T {
 	kvfield := field.Children[0]
 	keyField, valField := kvfield.Children[0], kvfield.Children[1]
 	var keyMapping, valMapping *iceberg.MappedField
@@ -717,23 +896,34 @@ func visitManifestMap[T any](field pqarrow.SchemaField, visitor manifestVisitor[
 		valMapping = mapping.GetField("value")
 	}
 
-	return visitor.Map(field, visitManifestField(keyField, visitor, keyMapping), visitManifestField(valField, visitor, valMapping), mapping)
+	return visitor.Map(field, visitManifestField(keyField, visitor, keyMapping, caseSensitive),
+		visitManifestField(valField, visitor, valMapping, caseSensitive), mapping)
 }
 
-func visitManifestField[T any](field pqarrow.SchemaField, visitor manifestVisitor[T], mapping *iceberg.MappedField) T {
+func visitManifestField[T any](field pqarrow.SchemaField, visitor manifestVisitor[T], mapping *iceberg.MappedField, caseSensitive bool) T {
 	switch field.Field.Type.(type) {
 	case *arrow.StructType:
-		return visitParquetManifestStruct(field, visitor, mapping)
+		return visitParquetManifestStruct(field, visitor, mapping, caseSensitive)
 	case *arrow.MapType:
-		return visitManifestMap(field, visitor, mapping)
+		return visitManifestMap(field, visitor, mapping, caseSensitive)
 	case arrow.ListLikeType:
-		return visitManifestList(field, visitor, mapping)
+		return visitManifestList(field, visitor, mapping, caseSensitive)
 	default:
 		return visitor.Primitive(field, mapping)
 	}
 }
 
-func pruneParquetColumns(manifest *pqarrow.SchemaManifest, selected map[int]struct{}, selectFullTypes bool, mapping iceberg.NameMapping) (*arrow.Schema, []int, error) {
+// mappedChild returns the field of mapping for the given column name,
+// ignoring case unless caseSensitive is set.
+func mappedChild(mapping *iceberg.MappedField, name string, caseSensitive bool) *iceberg.MappedField {
+	if caseSensitive {
+		return mapping.GetField(name)
+	}
+
+	return mapping.GetFieldCaseInsensitive(name)
+}
+
+func pruneParquetColumns(manifest *pqarrow.SchemaManifest, selected map[int]struct{}, selectFullTypes bool, mapping iceberg.NameMapping, caseSensitive bool) (*arrow.Schema, []int, error) {
 	visitor := &pruneParquetSchema{
 		selected:  selected,
 		manifest:  manifest,
@@ -741,7 +931,7 @@ func pruneParquetColumns(manifest *pqarrow.SchemaManifest, selected map[int]stru
 		indices:   []int{},
 	}
 
-	result, err := visitParquetManifest(manifest, visitor, &iceberg.MappedField{Fields: mapping})
+	result, err := visitParquetManifest(manifest, visitor, &iceberg.MappedField{Fields: mapping}, caseSensitive)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -809,14 +999,18 @@ func (p *pruneParquetSchema) Struct(field pqarrow.SchemaField, children []arrow.
 
 	for i, t := range children {
 		field := fields[i]
+		if t.Type == nil {
+			continue
+		}
+
 		if arrow.TypeEqual(field.Field.Type, t.Type) {
 			selected = append(selected, *field.Field)
-		} else if t.Type == nil {
+		} else {
 			sameType = false
 			// type has changed, create a new field with the projected type
 			selected = append(selected, arrow.Field{
 				Name:     field.Field.Name,
-				Type:     field.Field.Type,
+				Type:     t.Type,
 				Nullable: field.Field.Nullable,
 				Metadata: field.Field.Metadata,
 			})
@@ -917,6 +1111,8 @@ func (p *pruneParquetSchema) Map(field pqarrow.SchemaField, keyResult, valResult
 	_, ok := p.selected[p.fieldID(*field.Children[0].Children[1].Field, valMapping)]
 	if !ok {
 		if valResult.Type != nil {
+			// map values cannot be read without their keys
+			p.addLeaves(field.Children[0].Children[0])
 			result := *field.Field
 			result.Type = p.projectMap(field.Field.Type.(*arrow.MapType), valResult.Type)
 
@@ -932,6 +1128,7 @@ func (p *pruneParquetSchema) Map(field pqarrow.SchemaField, keyResult, valResult
 
 	_, ok = field.Children[0].Children[1].Field.Type.(*arrow.StructType)
 	if ok {
+		p.addLeaves(field.Children[0].Children[0])
 		result := *field.Field
 		projected := p.projectSelectedStruct(valResult.Type)
 		result.Type = p.projectMap(field.Field.Type.(*arrow.MapType), projected)
@@ -949,6 +1146,22 @@ func (p *pruneParquetSchema) Map(field pqarrow.SchemaField, keyResult, valResult
 	return *field.Field
 }
 
+// addLeaves adds the column indices of all leaves of field that have not
+// already been selected.
+func (p *pruneParquetSchema) addLeaves(field pqarrow.SchemaField) {
+	if field.IsLeaf() {
+		if !slices.Contains(p.indices, field.ColIndex) {
+			p.indices = append(p.indices, field.ColIndex)
+		}
+
+		return
+	}
+
+	for _, child := range field.Children {
+		p.addLeaves(child)
+	}
+}
+
 func (p *pruneParquetSchema) Primitive(_ pqarrow.SchemaField, _ *iceberg.MappedField) arrow.Field {
 	return arrow.Field{}
 }
@@ -992,7 +1205,13 @@ func (p *pruneParquetSchema) projectMap(m *arrow.MapType, valResult arrow.DataTy
 		return m
 	}
 
-	return arrow.MapOf(m.KeyType(), valResult)
+	// keep the key and item fields so their field ids are preserved
+	itemField := m.ItemField()
+	itemField.Type = valResult
+	result := arrow.MapOfFields(m.KeyField(), itemField)
+	result.KeysSorted = m.KeysSorted
+
+	return result
 }
 
 type id2ParquetPath struct {
@@ -21,13 +21,18 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io/fs"
 	"math/big"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/compute"
 	"github.com/apache/arrow-go/v18/arrow/decimal128"
 	"github.com/apache/arrow-go/v18/arrow/memory"
 	"github.com/apache/arrow-go/v18/parquet"
@@ -37,6 +42,7 @@ import (
 	"github.com/apache/arrow-go/v18/parquet/schema"
 	"github.com/apache/iceberg-go"
 	internal2 "github.com/apache/iceberg-go/internal"
+	iceio "github.com/apache/iceberg-go/io"
 	"github.com/apache/iceberg-go/table"
 	"github.com/apache/iceberg-go/table/internal"
 	"github.com/google/uuid"
@@ -260,8 +266,9 @@ func TestMetricsPrimitiveTypes(t *testing.T) {
 	require.NoError(t, err)
 
 	stats := format.DataFileStatsFromMeta(internal.Metadata(meta), getCollector(), mapping)
-	df := stats.ToDataFile(tblMeta.CurrentSchema(), tblMeta.PartitionSpec(), "fake-path.parquet",
+	df, err := stats.ToDataFile(tblMeta.CurrentSchema(), tblMeta.PartitionSpec(), "fake-path.parquet",
 		iceberg.ParquetFile, meta.GetSourceFileSize(), nil)
+	require.NoError(t, err)
 
 	assert.Len(t, df.ValueCounts(), 15)
 	assert.Len(t, df.NullValueCounts(), 15)
@@ -462,8 +469,9 @@ func TestDecimalPhysicalTypes(t *testing.T) {
 			stats := format.DataFileStatsFromMeta(internal.Metadata(meta), collector, mapping)
 			require.NotNil(t, stats)
 
-			df := stats.ToDataFile(tableMeta.CurrentSchema(), tableMeta.PartitionSpec(), "test.parquet",
+			df, err := stats.ToDataFile(tableMeta.CurrentSchema(), tableMeta.PartitionSpec(), "test.parquet",
 				iceberg.ParquetFile, meta.GetSourceFileSize(), nil)
+			require.NoError(t, err)
 
 			// Verify bounds are correctly extracted
 			require.Contains(t, df.LowerBoundValues(), 1)
@@ -519,12 +527,298 @@ func TestWriteDataFileErrOnClose(t *testing.T) {
 	icesc, err := table.ArrowSchemaToIceberg(schema, false, nil)
 	require.NoError(t, err)
 
-	_, err = fm.WriteDataFile(ctx, &mockfs, nil, internal.WriteFileInfo{
+	_, err = fm.WriteDataFile(ctx, &mockfs, iceberg.PartitionData{}, internal.WriteFileInfo{
 		FileSchema: icesc,
-		Spec:       iceberg.PartitionSpec{},
 		FileName:   "f",
 		StatsCols:  nil,
 		WriteProps: []parquet.WriterProperty{},
 	}, []arrow.RecordBatch{rec})
 	require.ErrorContains(t, err, "error on close")
 }
+
+func TestWriteDataFileRowGroupSize(t *testing.T) {
+	ctx := context.Background()
+	fm := internal.GetFileFormat(iceberg.ParquetFile)
+
+	schema := arrow.NewSchema([]arrow.Field{{
+		Name: "id", Type: arrow.PrimitiveTypes.Int64,
+		Metadata: arrow.NewMetadata([]string{table.ArrowParquetFieldIDKey}, []string{"1"}),
+	}}, nil)
+	icesc, err := table.ArrowSchemaToIceberg(schema, false, nil)
+	require.NoError(t, err)
+
+	bldr := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer bldr.Release()
+	batches := make([]arrow.RecordBatch, 4)
+	for i := range batches {
+		for j := range 1000 {
+			bldr.Field(0).(*array.Int64Builder).Append(int64(i*1000 + j))
+		}
+		batches[i] = bldr.NewRecordBatch()
+		defer batches[i].Release()
+	}
+
+	tests := []struct {
+		name      string
+		size      int64
+		rowGroups int
+	}{
+		{"unbounded", 0, 1},
+		{"per batch", 1, 4},
+		{"two batches", 8000 * 2, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "data.parquet")
+			df, err := fm.WriteDataFile(ctx, iceio.LocalFS{}, iceberg.PartitionData{}, internal.WriteFileInfo{
+				FileSchema: icesc,
+				FileName:   path,
+				StatsCols: map[int]internal.StatisticsCollector{1: {
+					FieldID:    1,
+					Mode:       internal.MetricsMode{Typ: internal.MetricModeFull},
+					ColName:    "id",
+					IcebergTyp: iceberg.PrimitiveTypes.Int64,
+				}},
+				WriteProps:        fm.GetWriteProperties(nil),
+				RowGroupSizeBytes: tt.size,
+			}, batches)
+			require.NoError(t, err)
+			assert.EqualValues(t, 4000, df.Count())
+
+			rdr, err := file.OpenParquetFile(path, false)
+			require.NoError(t, err)
+			defer rdr.Close()
+			assert.Equal(t, tt.rowGroups, rdr.NumRowGroups())
+		})
+	}
+}
+
+type countingReadFile struct {
+	*bytes.Reader
+
+	reads *int
+}
+
+func (c countingReadFile) ReadAt(p []byte, off int64) (int, error) {
+	*c.reads++
+
+	return c.Reader.ReadAt(p, off)
+}
+
+func (c countingReadFile) Close() error { return nil }
+
+func (c countingReadFile) Stat() (fs.FileInfo, error) {
+	return fstest.MapFS{"f": {Data: make([]byte, c.Size())}}.Stat("f")
+}
+
+type countingReadFS struct {
+	data  []byte
+	reads int
+}
+
+func (c *countingReadFS) Open(string) (iceio.File, error) {
+	return countingReadFile{Reader: bytes.NewReader(c.data), reads: &c.reads}, nil
+}
+
+func (c *countingReadFS) Remove(string) error { return nil }
+
+func TestParquetReaderCoalescesRanges(t *testing.T) {
+	ctx := context.Background()
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	arrSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "a", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "b", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "c", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+
+	bldr := array.NewRecordBuilder(mem, arrSchema)
+	defer bldr.Release()
+	for i := range 50000 {
+		bldr.Field(0).(*array.Int64Builder).Append(int64(i))
+		bldr.Field(1).(*array.StringBuilder).Append(fmt.Sprintf("value-%d", i))
+		bldr.Field(2).(*array.Float64Builder).Append(float64(i) / 2)
+	}
+	rec := bldr.NewRecordBatch()
+	defer rec.Release()
+
+	var buf bytes.Buffer
+	wr, err := pqarrow.NewFileWriter(arrSchema, &buf,
+		parquet.NewWriterProperties(parquet.WithMaxRowGroupLength(5000),
+			parquet.WithDictionaryDefault(false)),
+		pqarrow.DefaultWriterProps())
+	require.NoError(t, err)
+	require.NoError(t, wr.Write(rec))
+	require.NoError(t, wr.Close())
+
+	fsys := &countingReadFS{data: buf.Bytes()}
+	bldrDf, err := iceberg.NewDataFileBuilder(*iceberg.UnpartitionedSpec, iceberg.EntryContentData,
+		"f.parquet", iceberg.ParquetFile, nil, nil, nil, 50000, int64(buf.Len()))
+	require.NoError(t, err)
+
+	src, err := internal.GetFile(ctx, fsys, bldrDf.Build(), false)
+	require.NoError(t, err)
+
+	rdr, err := src.GetReader(compute.WithAllocator(ctx, mem))
+	require.NoError(t, err)
+	defer rdr.Close()
+
+	// the footer is read with a single request
+	require.Greater(t, buf.Len(), iceio.DefaultFooterPrefetchSize)
+	assert.Equal(t, 1, fsys.reads)
+
+	recRdr, err := rdr.GetRecords(ctx, []int{0, 2}, nil)
+	require.NoError(t, err)
+	defer recRdr.Release()
+
+	var rows int64
+	for recRdr.Next() {
+		rows += recRdr.RecordBatch().NumRows()
+	}
+	require.NoError(t, recRdr.Err())
+	assert.EqualValues(t, 50000, rows)
+
+	// all 20 column chunks were fetched with a single coalesced request
+	assert.Equal(t, 2, fsys.reads)
+}
+
+func TestParquetPrunedNestedColumns(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "address", Type: &iceberg.StructType{FieldList: []iceberg.NestedField{
+			{ID: 3, Name: "city", Type: iceberg.PrimitiveTypes.String},
+			{ID: 4, Name: "zip", Type: iceberg.PrimitiveTypes.String},
+		}}},
+		iceberg.NestedField{ID: 5, Name: "points", Type: &iceberg.ListType{
+			ElementID: 6, Element: &iceberg.StructType{FieldList: []iceberg.NestedField{
+				{ID: 7, Name: "x", Type: iceberg.PrimitiveTypes.Int32},
+				{ID: 8, Name: "y", Type: iceberg.PrimitiveTypes.Int32},
+			}},
+		}},
+		iceberg.NestedField{ID: 9, Name: "props", Type: &iceberg.MapType{
+			KeyID: 10, KeyType: iceberg.PrimitiveTypes.String,
+			ValueID: 11, ValueType: &iceberg.StructType{FieldList: []iceberg.NestedField{
+				{ID: 12, Name: "a", Type: iceberg.PrimitiveTypes.String},
+				{ID: 13, Name: "b", Type: iceberg.PrimitiveTypes.String},
+			}},
+		}},
+	)
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, true, false)
+	require.NoError(t, err)
+
+	rec, _, err := array.RecordFromJSON(memory.DefaultAllocator, arrSchema, strings.NewReader(`[
+		{"id": 1, "address": {"city": "Oslo", "zip": "0150"}, "points": [{"x": 1, "y": 2}],
+		 "props": [{"key": "k", "value": {"a": "A", "b": "B"}}]}
+	]`))
+	require.NoError(t, err)
+	defer rec.Release()
+
+	var buf bytes.Buffer
+	wr, err := pqarrow.NewFileWriter(arrSchema, &buf, parquet.NewWriterProperties(),
+		pqarrow.DefaultWriterProps())
+	require.NoError(t, err)
+	require.NoError(t, wr.Write(rec))
+	require.NoError(t, wr.Close())
+
+	df, err := iceberg.NewDataFileBuilder(*iceberg.UnpartitionedSpec, iceberg.EntryContentData,
+		"f.parquet", iceberg.ParquetFile, nil, nil, nil, 1, int64(buf.Len()))
+	require.NoError(t, err)
+
+	src, err := internal.GetFile(ctx, &countingReadFS{data: buf.Bytes()}, df.Build(), false)
+	require.NoError(t, err)
+
+	rdr, err := src.GetReader(ctx)
+	require.NoError(t, err)
+	defer rdr.Close()
+
+	// leaves: id, address.city, address.zip, points.x, points.y,
+	// props.key, props.value.a, props.value.b
+	tests := []struct {
+		name     string
+		ids      []int
+		expected []int
+		schema   string
+	}{
+		{"struct field", []int{3}, []int{1}, "struct<address: struct<city: utf8>>"},
+		{"list element field", []int{7}, []int{3}, "struct<points: list<element: struct<x: int32>, nullable>>"},
+		{"map value field", []int{12}, []int{5, 6}, "struct<props: map<utf8, struct<a: utf8>, items_nullable>>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := make(map[int]struct{})
+			for _, id := range tt.ids {
+				ids[id] = struct{}{}
+			}
+
+			pruned, indices, err := rdr.PrunedSchema(ids, nil, true)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, slices.Sorted(slices.Values(indices)))
+			assert.Equal(t, tt.schema, arrow.StructOf(pruned.Fields()...).String())
+		})
+	}
+
+	// the selected leaves of the map can be read back together
+	_, indices, err := rdr.PrunedSchema(map[int]struct{}{12: {}}, nil, true)
+	require.NoError(t, err)
+	recRdr, err := rdr.GetRecords(ctx, indices, nil)
+	require.NoError(t, err)
+	defer recRdr.Release()
+
+	require.True(t, recRdr.Next())
+	assert.Equal(t, `[{["k"] {["A"]}}]`, recRdr.RecordBatch().Column(0).String())
+}
+
+func TestParquetPrunedSchemaCaseInsensitiveMapping(t *testing.T) {
+	ctx := context.Background()
+
+	arrSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "ID", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "Name", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+
+	rec, _, err := array.RecordFromJSON(memory.DefaultAllocator, arrSchema,
+		strings.NewReader(`[{"ID": 1, "Name": "a"}]`))
+	require.NoError(t, err)
+	defer rec.Release()
+
+	var buf bytes.Buffer
+	wr, err := pqarrow.NewFileWriter(arrSchema, &buf, parquet.NewWriterProperties(),
+		pqarrow.DefaultWriterProps())
+	require.NoError(t, err)
+	require.NoError(t, wr.Write(rec))
+	require.NoError(t, wr.Close())
+
+	df, err := iceberg.NewDataFileBuilder(*iceberg.UnpartitionedSpec, iceberg.EntryContentData,
+		"f.parquet", iceberg.ParquetFile, nil, nil, nil, 1, int64(buf.Len()))
+	require.NoError(t, err)
+
+	src, err := internal.GetFile(ctx, &countingReadFS{data: buf.Bytes()}, df.Build(), false)
+	require.NoError(t, err)
+
+	rdr, err := src.GetReader(ctx)
+	require.NoError(t, err)
+	defer rdr.Close()
+
+	id1, id2 := 1, 2
+	mapping := iceberg.NameMapping{
+		{FieldID: &id1, Names: []string{"id"}},
+		{FieldID: &id2, Names: []string{"name"}},
+	}
+	selected := map[int]struct{}{2: {}}
+
+	_, indices, err := rdr.PrunedSchema(selected, mapping, true)
+	require.NoError(t, err)
+	assert.Empty(t, indices)
+
+	pruned, indices, err := rdr.PrunedSchema(selected, mapping, false)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, indices)
+	require.Len(t, pruned.Fields(), 1)
+	assert.Equal(t, "Name", pruned.Field(0).Name)
+}
@@ -34,7 +34,6 @@ import (
 	"github.com/apache/arrow-go/v18/arrow/decimal"
 	"github.com/apache/arrow-go/v18/arrow/decimal128"
 	"github.com/apache/iceberg-go"
-	"github.com/hamba/avro/v2"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -204,6 +203,17 @@ type DataFileStatistics struct {
 	NanValueCounts  map[int]int64
 	ColAggs         map[int]StatsAgg
 	SplitOffsets    []int64
+	// KeyMetadata is set when the file was written encrypted and holds
+	// the serialized key metadata needed to decrypt it.
+	KeyMetadata []byte
+	// Content is the type of content in the file, which is data unless
+	// a delete file was written.
+	Content iceberg.ManifestEntryContent
+	// EqualityFieldIDs is set for equality delete files.
+	EqualityFieldIDs []int
+	// ReferencedDataFile is set for position delete files that only
+	// hold deletes for a single data file.
+	ReferencedDataFile string
 }
 
 func (d *DataFileStatistics) PartitionValue(field iceberg.PartitionField, sc *iceberg.Schema) any {
@@ -234,11 +244,11 @@ func (d *DataFileStatistics) PartitionValue(field iceberg.PartitionField, sc *ic
 	return lowerT.Val.Any()
 }
 
-func (d *DataFileStatistics) ToDataFile(schema *iceberg.Schema, spec iceberg.PartitionSpec, path string, format iceberg.FileFormat, filesize int64, partitionValues map[int]any) iceberg.DataFile {
+// ToDataFile builds the data file for the statistics, inferring each
+// partition value present in partitionValues from the column bounds
+// where the file holds a single value for its source column.
+func (d *DataFileStatistics) ToDataFile(schema *iceberg.Schema, spec iceberg.PartitionSpec, path string, format iceberg.FileFormat, filesize int64, partitionValues map[int]any) (iceberg.DataFile, error) {
 	var fieldIDToPartitionData map[int]any
-	fieldIDToLogicalType := make(map[int]avro.LogicalType)
-	fieldIDToFixedSize := make(map[int]int)
-
 	if !spec.Equals(*iceberg.UnpartitionedSpec) {
 		fieldIDToPartitionData = make(map[int]any)
 		for field := range spec.Fields() {
@@ -253,33 +263,24 @@ func (d *DataFileStatistics) ToDataFile(schema *iceberg.Schema, spec iceberg.Par
 			} else {
 				fieldIDToPartitionData[field.FieldID] = nil
 			}
-
-			if sourceField, ok := schema.FindFieldByID(field.SourceID); ok {
-				resultType := field.Transform.ResultType(sourceField.Type)
-
-				switch rt := resultType.(type) {
-				case iceberg.DateType:
-					fieldIDToLogicalType[field.FieldID] = avro.Date
-				case iceberg.TimeType:
-					fieldIDToLogicalType[field.FieldID] = avro.TimeMicros
-				case iceberg.TimestampType:
-					fieldIDToLogicalType[field.FieldID] = avro.TimestampMicros
-				case iceberg.TimestampTzType:
-					fieldIDToLogicalType[field.FieldID] = avro.TimestampMicros
-				case iceberg.DecimalType:
-					fieldIDToLogicalType[field.FieldID] = avro.Decimal
-					fieldIDToFixedSize[field.FieldID] = rt.Scale()
-				case iceberg.UUIDType:
-					fieldIDToLogicalType[field.FieldID] = avro.UUID
-				}
-			}
 		}
 	}
 
-	bldr, err := iceberg.NewDataFileBuilder(spec, iceberg.EntryContentData,
-		path, format, fieldIDToPartitionData, fieldIDToLogicalType, fieldIDToFixedSize, d.RecordCount, filesize)
+	partition, err := iceberg.NewPartitionData(spec, schema, fieldIDToPartitionData)
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("data file %s: %w", path, err)
+	}
+
+	return d.ToDataFileWithPartition(partition, path, format, filesize)
+}
+
+// ToDataFileWithPartition builds the data file for the statistics
+// with the given partition tuple.
+func (d *DataFileStatistics) ToDataFileWithPartition(partition iceberg.PartitionData, path string, format iceberg.FileFormat, filesize int64) (iceberg.DataFile, error) {
+	bldr, err := iceberg.NewDataFileBuilderWithPartition(partition, d.Content,
+		path, format, d.RecordCount, filesize)
+	if err != nil {
+		return nil, err
 	}
 
 	lowerBounds := make(map[int][]byte)
@@ -308,8 +309,17 @@ func (d *DataFileStatistics) ToDataFile(schema *iceberg.Schema, spec iceberg.Par
 	bldr.NullValueCounts(d.NullValueCounts)
 	bldr.NaNValueCounts(d.NanValueCounts)
 	bldr.SplitOffsets(d.SplitOffsets)
+	if len(d.KeyMetadata) > 0 {
+		bldr.KeyMetadata(d.KeyMetadata)
+	}
+	if len(d.EqualityFieldIDs) > 0 {
+		bldr.EqualityFieldIDs(d.EqualityFieldIDs)
+	}
+	if d.ReferencedDataFile != "" {
+		bldr.ReferencedDataFile(d.ReferencedDataFile)
+	}
 
-	return bldr.Build()
+	return bldr.Build(), nil
 }
 
 type MetricModeType string
@@ -401,12 +411,7 @@ func (s *statsAggregator[T]) Update(stats interface{ HasMinMax() bool }) {
 }
 
 func (s *statsAggregator[T]) toBytes(val iceberg.Literal) ([]byte, error) {
-	v, err := val.To(s.primitiveType)
-	if err != nil {
-		return nil, err
-	}
-
-	return v.MarshalBinary()
+	return iceberg.BoundToBytes(s.primitiveType, val)
 }
 
 func (s *statsAggregator[T]) updateMin(val T) {
@@ -0,0 +1,325 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"fmt"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/io"
+)
+
+// ErrCommitConflict is returned when an operation conflicts with changes
+// committed after the snapshot it was validated from. It is the same
+// error as iceberg.ErrCommitConflict.
+var ErrCommitConflict = iceberg.ErrCommitConflict
+
+// IsolationLevel controls which concurrent changes cause an overwrite or
+// delete to fail, mirroring the isolation levels used by Spark.
+type IsolationLevel string
+
+const (
+	// IsolationSerializable rejects the operation if any snapshot committed
+	// since the validation snapshot added data that matches the conflict
+	// detection filter, in addition to the checks of IsolationSnapshot.
+	IsolationSerializable IsolationLevel = "serializable"
+	// IsolationSnapshot allows concurrent appends but rejects the operation
+	// if data matching the conflict detection filter was concurrently
+	// deleted, or if delete files matching it were concurrently added.
+	IsolationSnapshot IsolationLevel = "snapshot"
+)
+
+// ParseIsolationLevel parses the value of an isolation level property.
+func ParseIsolationLevel(level string) (IsolationLevel, error) {
+	switch l := IsolationLevel(level); l {
+	case IsolationSerializable, IsolationSnapshot:
+		return l, nil
+	default:
+		return "", fmt.Errorf("%w: unknown isolation level %q", iceberg.ErrInvalidArgument, level)
+	}
+}
+
+// isolationLevelFromProps returns the isolation level configured by the
+// first of the given keys that is set, falling back to the default.
+func isolationLevelFromProps(props iceberg.Properties, keys ...string) (IsolationLevel, error) {
+	for _, key := range keys {
+		if v, ok := props[key]; ok {
+			return ParseIsolationLevel(v)
+		}
+	}
+
+	return ParseIsolationLevel(WriteIsolationLevelDefault)
+}
+
+// conflictDetection describes how an overwrite or delete is validated
+// against the snapshots committed after the snapshot it was planned from.
+// Validation is skipped when validateFrom is nil.
+type conflictDetection struct {
+	level         IsolationLevel
+	validateFrom  *int64
+	filter        iceberg.BooleanExpression
+	caseSensitive bool
+}
+
+// newConflictDetection resolves the isolation level of an operation, using
+// the first of the given table properties that is set when no level was
+// explicitly requested.
+func (t *Transaction) newConflictDetection(level IsolationLevel, validateFrom *int64, filter iceberg.BooleanExpression, caseSensitive bool, levelKeys ...string) (conflictDetection, error) {
+	cd := conflictDetection{validateFrom: validateFrom, filter: filter, caseSensitive: caseSensitive}
+	if validateFrom == nil {
+		return cd, nil
+	}
+
+	var err error
+	if level != "" {
+		cd.level, err = ParseIsolationLevel(string(level))
+	} else {
+		cd.level, err = isolationLevelFromProps(t.meta.props, levelKeys...)
+	}
+
+	return cd, err
+}
+
+// snapshotsSince returns the ancestors of the current snapshot that were
+// committed after the snapshot with the given ID, newest first.
+func (t *Transaction) snapshotsSince(snapshotID int64) ([]*Snapshot, error) {
+	if _, err := t.meta.SnapshotByID(snapshotID); err != nil {
+		return nil, fmt.Errorf("%w: cannot validate from snapshot: %w", iceberg.ErrInvalidArgument, err)
+	}
+
+	var snapshots []*Snapshot
+	for snap := t.meta.currentSnapshot(); snap != nil; {
+		if snap.SnapshotID == snapshotID {
+			return snapshots, nil
+		}
+		snapshots = append(snapshots, snap)
+
+		if snap.ParentSnapshotID == nil {
+			break
+		}
+
+		parent, err := t.meta.SnapshotByID(*snap.ParentSnapshotID)
+		if err != nil {
+			break
+		}
+		snap = parent
+	}
+
+	return nil, fmt.Errorf("%w: snapshot %d is not an ancestor of the current snapshot",
+		ErrCommitConflict, snapshotID)
+}
+
+// validateNoConflicts checks the snapshots committed since
+// cd.validateFrom for changes that conflict with an operation whose
+// scope is cd.filter. Compactions (replace snapshots) never conflict as
+// they do not change the table's data.
+func (t *Transaction) validateNoConflicts(fs io.IO, cd conflictDetection) error {
+	if cd.validateFrom == nil {
+		return nil
+	}
+
+	snapshots, err := t.snapshotsSince(*cd.validateFrom)
+	if err != nil || len(snapshots) == 0 {
+		return err
+	}
+
+	filter := cd.filter
+	if filter == nil {
+		filter = iceberg.AlwaysTrue{}
+	}
+
+	matcher := newConflictMatcher(t.meta, filter, cd.caseSensitive)
+	for _, snap := range snapshots {
+		if snap.Summary == nil {
+			continue
+		}
+
+		op := snap.Summary.Operation
+		checkAddedData := cd.level == IsolationSerializable && (op == OpAppend || op == OpOverwrite)
+		checkDeletes := op == OpOverwrite || op == OpDelete
+		if !checkAddedData && !checkDeletes {
+			continue
+		}
+
+		manifests, err := snap.Manifests(fs)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range manifests {
+			if m.SnapshotID() != snap.SnapshotID {
+				continue
+			}
+
+			isData := m.ManifestContent() == iceberg.ManifestContentData
+			if !isData && !checkDeletes {
+				continue
+			}
+
+			ok, err := matcher.manifestMightMatch(m)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+
+			entries, err := m.FetchEntries(fs, false)
+			if err != nil {
+				return err
+			}
+
+			for _, e := range entries {
+				if e.SnapshotID() != snap.SnapshotID {
+					continue
+				}
+
+				var kind string
+				switch {
+				case isData && e.Status() == iceberg.EntryStatusADDED && checkAddedData:
+					kind = "added data file"
+				case isData && e.Status() == iceberg.EntryStatusDELETED && checkDeletes:
+					kind = "deleted data file"
+				case !isData && e.Status() == iceberg.EntryStatusADDED:
+					kind = "added delete file"
+				default:
+					continue
+				}
+
+				ok, err := matcher.fileMightMatch(e.DataFile())
+				if err != nil {
+					return err
+				}
+				if ok {
+					return fmt.Errorf("%w: %s %s in snapshot %d may contain records matching %s",
+						ErrCommitConflict, kind, e.DataFile().FilePath(), snap.SnapshotID, filter)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// conflictMatcher evaluates whether manifests and files may contain rows
+// matching a conflict detection filter, caching evaluators per spec.
+type conflictMatcher struct {
+	meta          *MetadataBuilder
+	filter        iceberg.BooleanExpression
+	caseSensitive bool
+
+	metricsEval   func(iceberg.DataFile) (bool, error)
+	partFilters   map[int]iceberg.BooleanExpression
+	manifestEvals map[int]func(iceberg.ManifestFile) (bool, error)
+	partEvals     map[int]func(iceberg.DataFile) (bool, error)
+}
+
+func newConflictMatcher(meta *MetadataBuilder, filter iceberg.BooleanExpression, caseSensitive bool) *conflictMatcher {
+	return &conflictMatcher{
+		meta:          meta,
+		filter:        filter,
+		caseSensitive: caseSensitive,
+		partFilters:   make(map[int]iceberg.BooleanExpression),
+		manifestEvals: make(map[int]func(iceberg.ManifestFile) (bool, error)),
+		partEvals:     make(map[int]func(iceberg.DataFile) (bool, error)),
+	}
+}
+
+// partitionFilter returns the inclusive projection of the filter onto the
+// partition spec with the given ID.
+func (c *conflictMatcher) partitionFilter(specID int) (*iceberg.PartitionSpec, iceberg.BooleanExpression, error) {
+	spec, err := c.meta.GetSpecByID(specID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if expr, ok := c.partFilters[specID]; ok {
+		return spec, expr, nil
+	}
+
+	expr, err := newInclusiveProjection(c.meta.CurrentSchema(), *spec, c.caseSensitive)(c.filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.partFilters[specID] = expr
+
+	return spec, expr, nil
+}
+
+func (c *conflictMatcher) manifestMightMatch(m iceberg.ManifestFile) (bool, error) {
+	specID := int(m.PartitionSpecID())
+	eval, ok := c.manifestEvals[specID]
+	if !ok {
+		spec, partExpr, err := c.partitionFilter(specID)
+		if err != nil {
+			return false, err
+		}
+
+		eval, err = newManifestEvaluator(*spec, c.meta.CurrentSchema(), partExpr, c.caseSensitive)
+		if err != nil {
+			return false, err
+		}
+		c.manifestEvals[specID] = eval
+	}
+
+	return eval(m)
+}
+
+func (c *conflictMatcher) fileMightMatch(df iceberg.DataFile) (bool, error) {
+	schema := c.meta.CurrentSchema()
+
+	specID := int(df.SpecID())
+	partEval, ok := c.partEvals[specID]
+	if !ok {
+		spec, partExpr, err := c.partitionFilter(specID)
+		if err != nil {
+			return false, err
+		}
+
+		partType := spec.PartitionType(schema)
+		fn, err := iceberg.ExpressionEvaluator(iceberg.NewSchema(0, partType.FieldList...), partExpr, c.caseSensitive)
+		if err != nil {
+			return false, err
+		}
+
+		partEval = func(d iceberg.DataFile) (bool, error) {
+			return fn(getPartitionRecord(d, partType))
+		}
+		c.partEvals[specID] = partEval
+	}
+
+	if ok, err := partEval(df); err != nil || !ok {
+		return false, err
+	}
+
+	// position delete files only carry metrics for the reserved delete
+	// columns, so they are matched on their partition alone
+	if df.ContentType() == iceberg.EntryContentPosDeletes {
+		return true, nil
+	}
+
+	if c.metricsEval == nil {
+		eval, err := newInclusiveMetricsEvaluator(schema, c.filter, c.caseSensitive, true)
+		if err != nil {
+			return false, err
+		}
+		c.metricsEval = eval
+	}
+
+	return c.metricsEval(df)
+}
@@ -0,0 +1,161 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverwriteIsolationLevels(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "region", Type: iceberg.PrimitiveTypes.String, Required: true})
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, false, false)
+	require.NoError(t, err)
+
+	rows := func(t *testing.T, json string) arrow.Table {
+		tbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{json})
+		require.NoError(t, err)
+		t.Cleanup(tbl.Release)
+
+		return tbl
+	}
+
+	newTable := func(t *testing.T, props iceberg.Properties) *table.Table {
+		spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+			SourceID: 2, FieldID: 1000, Name: "region", Transform: iceberg.IdentityTransform{},
+		})
+		tbl := newTestTable(t, withTestSchema(sc), withTestSpec(&spec), withTestProperties(props))
+
+		tbl, err := tbl.AppendTable(ctx, rows(t, `[{"id": 1, "region": "us"}, {"id": 2, "region": "eu"}]`), 10, nil)
+		require.NoError(t, err)
+
+		return tbl
+	}
+
+	usFilter := iceberg.EqualTo(iceberg.Reference("region"), "us")
+	euFilter := iceberg.EqualTo(iceberg.Reference("region"), "eu")
+
+	t.Run("concurrent append", func(t *testing.T) {
+		// each case starts from a table where "eu" data was appended after
+		// the snapshot the overwrite was planned from
+		setup := func(t *testing.T) (*table.Table, int64) {
+			tbl := newTable(t, iceberg.Properties{})
+			readSnapshot := tbl.CurrentSnapshot().SnapshotID
+
+			tbl, err := tbl.AppendTable(ctx, rows(t, `[{"id": 3, "region": "eu"}]`), 10, nil)
+			require.NoError(t, err)
+
+			return tbl, readSnapshot
+		}
+		newRows := rows(t, `[{"id": 4, "region": "eu"}]`)
+
+		tbl, readSnapshot := setup(t)
+		_, err := tbl.OverwriteTable(ctx, newRows, 10, nil,
+			table.WithOverwriteFilter(euFilter),
+			table.WithOverwriteValidateFromSnapshot(readSnapshot))
+		assert.ErrorIs(t, err, table.ErrCommitConflict)
+
+		// without a validation snapshot nothing is checked
+		_, err = tbl.OverwriteTable(ctx, newRows, 10, nil, table.WithOverwriteFilter(euFilter))
+		require.NoError(t, err)
+
+		// appends outside the overwritten partition never conflict
+		tbl, readSnapshot = setup(t)
+		_, err = tbl.OverwriteTable(ctx, newRows, 10, nil,
+			table.WithOverwriteFilter(usFilter),
+			table.WithOverwriteValidateFromSnapshot(readSnapshot))
+		require.NoError(t, err)
+
+		tbl, readSnapshot = setup(t)
+		_, err = tbl.OverwriteTable(ctx, newRows, 10, nil,
+			table.WithOverwriteFilter(euFilter),
+			table.WithOverwriteValidateFromSnapshot(readSnapshot),
+			table.WithOverwriteIsolationLevel(table.IsolationSnapshot))
+		require.NoError(t, err)
+
+		// a narrower conflict detection filter excludes the appended rows
+		tbl, readSnapshot = setup(t)
+		_, err = tbl.OverwriteTable(ctx, newRows, 10, nil,
+			table.WithOverwriteFilter(euFilter),
+			table.WithOverwriteValidateFromSnapshot(readSnapshot),
+			table.WithOverwriteConflictDetectionFilter(iceberg.EqualTo(iceberg.Reference("id"), int64(100))))
+		require.NoError(t, err)
+	})
+
+	t.Run("isolation level property", func(t *testing.T) {
+		tbl := newTable(t, iceberg.Properties{table.WriteIsolationLevelKey: "snapshot"})
+		readSnapshot := tbl.CurrentSnapshot().SnapshotID
+
+		tbl, err := tbl.AppendTable(ctx, rows(t, `[{"id": 3, "region": "eu"}]`), 10, nil)
+		require.NoError(t, err)
+
+		_, err = tbl.OverwriteTable(ctx, rows(t, `[{"id": 4, "region": "eu"}]`), 10, nil,
+			table.WithOverwriteFilter(euFilter),
+			table.WithOverwriteValidateFromSnapshot(readSnapshot))
+		require.NoError(t, err)
+
+		_, err = tbl.Delete(ctx, euFilter, nil,
+			table.WithDeleteValidateFromSnapshot(readSnapshot),
+			table.WithDeleteIsolationLevel(table.IsolationSerializable))
+		assert.ErrorIs(t, err, table.ErrCommitConflict)
+
+		tbl = newTable(t, iceberg.Properties{table.WriteIsolationLevelKey: "read-committed"})
+		_, err = tbl.Delete(ctx, euFilter, nil,
+			table.WithDeleteValidateFromSnapshot(tbl.CurrentSnapshot().SnapshotID))
+		assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	})
+
+	t.Run("concurrent delete", func(t *testing.T) {
+		tbl := newTable(t, iceberg.Properties{})
+		readSnapshot := tbl.CurrentSnapshot().SnapshotID
+
+		tbl, err := tbl.Delete(ctx, usFilter, nil)
+		require.NoError(t, err)
+
+		for _, level := range []table.IsolationLevel{table.IsolationSerializable, table.IsolationSnapshot} {
+			_, err = tbl.OverwriteTable(ctx, rows(t, `[{"id": 5, "region": "us"}]`), 10, nil,
+				table.WithOverwriteFilter(usFilter),
+				table.WithOverwriteValidateFromSnapshot(readSnapshot),
+				table.WithOverwriteIsolationLevel(level))
+			assert.ErrorIs(t, err, table.ErrCommitConflict, "level %s", level)
+		}
+
+		_, err = tbl.Delete(ctx, euFilter, nil,
+			table.WithDeleteValidateFromSnapshot(readSnapshot))
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid validation snapshot", func(t *testing.T) {
+		tbl := newTable(t, iceberg.Properties{})
+
+		_, err := tbl.Delete(ctx, usFilter, nil, table.WithDeleteValidateFromSnapshot(-1))
+		assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	})
+}
@@ -23,6 +23,7 @@ import (
 	"path"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/apache/iceberg-go"
 	"github.com/google/uuid"
@@ -35,6 +36,8 @@ const (
 	entropyDirDepth      = 3
 )
 
+// LocationProvider determines where the data and metadata files of a
+// table are written.
 type LocationProvider interface {
 	NewDataLocation(dataFileName string) string
 	NewTableMetadataFileLocation(newVersion int) (string, error)
@@ -83,6 +86,26 @@ func (slp *simpleLocationProvider) NewMetadataLocation(metadataFileName string)
 	return slp.metadataPath.JoinPath(metadataFileName).String()
 }
 
+// dataPathProperty returns the configured data path, falling back to the
+// deprecated object storage and folder storage properties.
+func dataPathProperty(tableProps iceberg.Properties) (string, bool) {
+	for _, key := range []string{WriteDataPathKey, WriteObjectStoragePathKey, WriteFolderStoragePathKey} {
+		if v, ok := tableProps[key]; ok {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// isWithinLocation reports whether path is location itself or a file or
+// directory below it.
+func isWithinLocation(path, location string) bool {
+	location = strings.TrimSuffix(location, "/")
+
+	return path == location || strings.HasPrefix(path, location+"/")
+}
+
 func newSimpleLocationProvider(tableLoc *url.URL, tableProps iceberg.Properties) (*simpleLocationProvider, error) {
 	out := &simpleLocationProvider{
 		tableLoc:   tableLoc,
@@ -90,7 +113,7 @@ func newSimpleLocationProvider(tableLoc *url.URL, tableProps iceberg.Properties)
 	}
 
 	var err error
-	if propPath, ok := tableProps[WriteDataPathKey]; ok {
+	if propPath, ok := dataPathProperty(tableProps); ok {
 		out.dataPath, err = url.Parse(propPath)
 		if err != nil {
 			return nil, err
@@ -144,17 +167,23 @@ func dirsFromHash(fileHash string) string {
 	return strings.Join(hashWithDirs, "/")
 }
 
+// NewDataLocation returns a location under the data path prefixed with
+// a hash of the file name so that files are spread across object store
+// prefixes. A file name may carry a partition path such as
+// `ts_day=2024-01-01/00000-0-uuid-00001.parquet`; the partition path is
+// kept after the hash when partitioned paths are enabled and dropped
+// otherwise.
 func (p *objectStoreLocationProvider) NewDataLocation(dataFileName string) string {
-	if path.Dir(dataFileName) != "." {
-		return p.simpleLocationProvider.NewDataLocation(dataFileName)
-	}
+	if !p.includePartitionPaths {
+		dataFileName = path.Base(dataFileName)
+		hashedPath := computeHash(dataFileName)
 
-	hashedPath := computeHash(dataFileName)
-	if p.includePartitionPaths {
-		return p.simpleLocationProvider.dataPath.JoinPath(hashedPath, dataFileName).String()
-	} else {
 		return p.simpleLocationProvider.dataPath.JoinPath(hashedPath + "-" + dataFileName).String()
 	}
+
+	hashedPath := computeHash(dataFileName)
+
+	return p.simpleLocationProvider.dataPath.JoinPath(hashedPath, dataFileName).String()
 }
 
 func newObjectStoreLocationProvider(tableLoc *url.URL, tableProps iceberg.Properties) (*objectStoreLocationProvider, error) {
@@ -170,7 +199,64 @@ func newObjectStoreLocationProvider(tableLoc *url.URL, tableProps iceberg.Proper
 	}, nil
 }
 
+// LocationProviderFactory creates a LocationProvider for a table from its
+// location and properties.
+type LocationProviderFactory func(tableLocation string, tableProps iceberg.Properties) (LocationProvider, error)
+
+var (
+	locProviderMutex    sync.Mutex
+	locProviderRegistry = map[string]LocationProviderFactory{}
+)
+
+// RegisterLocationProvider makes a custom LocationProvider available to tables
+// that set the write.location-provider.impl property to the given name. If
+// the name is already registered, it will be replaced.
+func RegisterLocationProvider(name string, factory LocationProviderFactory) {
+	if factory == nil {
+		panic("table: RegisterLocationProvider factory is nil")
+	}
+
+	locProviderMutex.Lock()
+	defer locProviderMutex.Unlock()
+	locProviderRegistry[name] = factory
+}
+
+// UnregisterLocationProvider removes the named location provider from the registry.
+func UnregisterLocationProvider(name string) {
+	locProviderMutex.Lock()
+	defer locProviderMutex.Unlock()
+	delete(locProviderRegistry, name)
+}
+
+func getLocationProvider(name string) (LocationProviderFactory, bool) {
+	locProviderMutex.Lock()
+	defer locProviderMutex.Unlock()
+	factory, ok := locProviderRegistry[name]
+
+	return factory, ok
+}
+
+// LoadLocationProvider returns the LocationProvider to use for a table.
+//
+// If write.location-provider.impl is set, the provider registered under that
+// name with RegisterLocationProvider is used. Otherwise files are placed by
+// the object storage provider when write.object-storage.enabled is true,
+// which prefixes data files with a deterministic hash to spread them across
+// object store prefixes, and by the simple provider otherwise. Both place
+// data files under write.data.path, defaulting to <table location>/data,
+// and metadata files under write.metadata.path, defaulting to
+// <table location>/metadata.
 func LoadLocationProvider(tableLocation string, tableProps iceberg.Properties) (LocationProvider, error) {
+	if impl, ok := tableProps[WriteLocationProviderImplKey]; ok {
+		factory, ok := getLocationProvider(impl)
+		if !ok {
+			return nil, fmt.Errorf("%w: location provider %q is not registered",
+				iceberg.ErrInvalidArgument, impl)
+		}
+
+		return factory(tableLocation, tableProps)
+	}
+
 	u, err := url.Parse(tableLocation)
 	if err != nil {
 		return nil, err
@@ -18,6 +18,7 @@
 package table_test
 
 import (
+	"strconv"
 	"strings"
 	"testing"
 
@@ -100,3 +101,84 @@ func TestObjectStoreLocationProviderPartitionedPathsDisabled(t *testing.T) {
 	assert.Equal(t, "table_location/data/1001/0001/0100/01110011-d", provider.NewDataLocation("d"))
 	assert.Equal(t, "table_location/data/0110/1010/0011/11101000-test.parquet", provider.NewDataLocation("test.parquet"))
 }
+
+func TestObjectStoreLocationProviderPartitionedPaths(t *testing.T) {
+	provider, err := table.LoadLocationProvider("table_location",
+		iceberg.Properties{table.ObjectStoreEnabledKey: "true"})
+	require.NoError(t, err)
+
+	// the hash covers the partition path, which is kept after the hash
+	assert.Equal(t, "table_location/data/0100/0101/0111/00001111/region=us%2Fwest/a.parquet",
+		provider.NewDataLocation("region=us%2Fwest/a.parquet"))
+
+	provider, err = table.LoadLocationProvider("table_location",
+		iceberg.Properties{table.ObjectStoreEnabledKey: "true", table.WriteObjectStorePartitionedPathsKey: "false"})
+	require.NoError(t, err)
+
+	// without partitioned paths only the file name is used
+	assert.Equal(t, provider.NewDataLocation("a.parquet"),
+		provider.NewDataLocation("region=us%2Fwest/a.parquet"))
+}
+
+func TestSimpleLocationProviderPartitionedPaths(t *testing.T) {
+	provider, err := table.LoadLocationProvider("s3://bucket/table",
+		iceberg.Properties{table.WriteDataPathKey: "s3://other/data"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "s3://other/data/region=us%2Fwest/a.parquet",
+		provider.NewDataLocation("region=us%2Fwest/a.parquet"))
+}
+
+func TestLocationProviderDeprecatedDataPaths(t *testing.T) {
+	provider, err := table.LoadLocationProvider("s3://bucket/table",
+		iceberg.Properties{table.WriteFolderStoragePathKey: "s3://folder/data"})
+	require.NoError(t, err)
+	assert.Equal(t, "s3://folder/data/a.parquet", provider.NewDataLocation("a.parquet"))
+
+	provider, err = table.LoadLocationProvider("s3://bucket/table", iceberg.Properties{
+		table.WriteFolderStoragePathKey: "s3://folder/data",
+		table.WriteObjectStoragePathKey: "s3://object/data",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "s3://object/data/a.parquet", provider.NewDataLocation("a.parquet"))
+
+	provider, err = table.LoadLocationProvider("s3://bucket/table", iceberg.Properties{
+		table.WriteObjectStoragePathKey: "s3://object/data",
+		table.WriteDataPathKey:          "s3://write/data",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "s3://write/data/a.parquet", provider.NewDataLocation("a.parquet"))
+}
+
+type prefixLocationProvider struct {
+	prefix string
+}
+
+func (p prefixLocationProvider) NewDataLocation(name string) string {
+	return p.prefix + "/custom/" + name
+}
+
+func (p prefixLocationProvider) NewTableMetadataFileLocation(newVersion int) (string, error) {
+	return p.NewMetadataLocation(strconv.Itoa(newVersion) + ".metadata.json"), nil
+}
+
+func (p prefixLocationProvider) NewMetadataLocation(name string) string {
+	return p.prefix + "/meta/" + name
+}
+
+func TestCustomLocationProvider(t *testing.T) {
+	props := iceberg.Properties{table.WriteLocationProviderImplKey: "prefix"}
+
+	_, err := table.LoadLocationProvider("s3://bucket/table", props)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+	table.RegisterLocationProvider("prefix", func(loc string, _ iceberg.Properties) (table.LocationProvider, error) {
+		return prefixLocationProvider{prefix: loc}, nil
+	})
+	defer table.UnregisterLocationProvider("prefix")
+
+	provider, err := table.LoadLocationProvider("s3://bucket/table", props)
+	require.NoError(t, err)
+	assert.Equal(t, "s3://bucket/table/custom/a.parquet", provider.NewDataLocation("a.parquet"))
+	assert.Equal(t, "s3://bucket/table/meta/a.avro", provider.NewMetadataLocation("a.avro"))
+}
@@ -0,0 +1,213 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow/compute"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// ErrMemoryBudgetExceeded is returned by scans and writes using a
+// MemoryBudget with the BudgetError policy once the tracked allocations
+// exceed the budget's limit.
+var ErrMemoryBudgetExceeded = errors.New("memory budget exceeded")
+
+// BudgetPolicy determines what happens when a MemoryBudget is exceeded.
+type BudgetPolicy int8
+
+const (
+	// BudgetBlock applies backpressure: readers and writers wait before
+	// producing more data until enough memory has been released to get
+	// back under the limit. To avoid deadlocks they only wait while other
+	// work that can release memory is still pending, e.g. records that
+	// have been produced but not yet consumed.
+	BudgetBlock BudgetPolicy = iota
+	// BudgetError fails the scan or write with ErrMemoryBudgetExceeded.
+	BudgetError
+)
+
+func (p BudgetPolicy) String() string {
+	switch p {
+	case BudgetBlock:
+		return "block"
+	case BudgetError:
+		return "error"
+	}
+
+	return fmt.Sprintf("BudgetPolicy(%d)", int8(p))
+}
+
+// budgetPollInterval bounds how long a blocked reader or writer waits
+// before re-checking whether there is still pending work, since the
+// consumption of pending work is not itself signalled.
+const budgetPollInterval = 10 * time.Millisecond
+
+// MemoryBudget is a memory.Allocator that tracks the bytes allocated
+// through it against a maximum. Allocations themselves always succeed;
+// the limit is enforced between record batches, where scans and writes
+// check the budget before reading or buffering more data.
+//
+// To apply a budget to a scan use WithMemoryLimit or pass a MemoryBudget
+// to WithAllocator. Writes use the allocator of their context, so a
+// budget is applied to them with compute.WithAllocator:
+//
+//	budget := table.NewMemoryBudget(memory.DefaultAllocator, 512<<20, table.BudgetBlock)
+//	tbl, err = tbl.Append(compute.WithAllocator(ctx, budget), rdr, nil)
+//
+// A blocking budget relies on records being released as they are consumed;
+// when all records of a scan are retained, as ToArrowTable does, use
+// BudgetError instead.
+type MemoryBudget struct {
+	mem    memory.Allocator
+	limit  int64
+	policy BudgetPolicy
+
+	cur, peak atomic.Int64
+	// active counts the files currently being written with this budget,
+	// each of which releases its buffers once the file is closed.
+	active atomic.Int64
+
+	mx      sync.Mutex
+	waiters int
+	freed   chan struct{}
+}
+
+// NewMemoryBudget wraps mem, or memory.DefaultAllocator if mem is nil, in
+// a MemoryBudget that allows up to maxBytes of outstanding allocations.
+func NewMemoryBudget(mem memory.Allocator, maxBytes int64, policy BudgetPolicy) *MemoryBudget {
+	if mem == nil {
+		mem = memory.DefaultAllocator
+	}
+
+	return &MemoryBudget{
+		mem: mem, limit: maxBytes, policy: policy,
+		freed: make(chan struct{}),
+	}
+}
+
+// Limit returns the maximum number of bytes of the budget.
+func (b *MemoryBudget) Limit() int64 { return b.limit }
+
+// Policy returns what happens when the budget is exceeded.
+func (b *MemoryBudget) Policy() BudgetPolicy { return b.policy }
+
+// CurrentAlloc returns the number of bytes currently allocated through
+// the budget.
+func (b *MemoryBudget) CurrentAlloc() int64 { return b.cur.Load() }
+
+// PeakAlloc returns the largest number of bytes that were allocated
+// through the budget at any one time.
+func (b *MemoryBudget) PeakAlloc() int64 { return b.peak.Load() }
+
+func (b *MemoryBudget) Allocate(size int) []byte {
+	out := b.mem.Allocate(size)
+	b.grow(int64(size))
+
+	return out
+}
+
+func (b *MemoryBudget) Reallocate(size int, buf []byte) []byte {
+	prev := len(buf)
+	out := b.mem.Reallocate(size, buf)
+	if delta := int64(size - prev); delta < 0 {
+		b.shrink(-delta)
+	} else {
+		b.grow(delta)
+	}
+
+	return out
+}
+
+func (b *MemoryBudget) Free(buf []byte) {
+	size := len(buf)
+	b.mem.Free(buf)
+	b.shrink(int64(size))
+}
+
+func (b *MemoryBudget) grow(n int64) {
+	cur := b.cur.Add(n)
+	for {
+		peak := b.peak.Load()
+		if cur <= peak || b.peak.CompareAndSwap(peak, cur) {
+			return
+		}
+	}
+}
+
+func (b *MemoryBudget) shrink(n int64) {
+	b.cur.Add(-n)
+
+	b.mx.Lock()
+	defer b.mx.Unlock()
+	if b.waiters > 0 {
+		close(b.freed)
+		b.freed = make(chan struct{})
+		b.waiters = 0
+	}
+}
+
+// wait is called before producing or buffering more data. If the budget
+// is exceeded it either returns ErrMemoryBudgetExceeded or, for blocking
+// budgets, waits until memory is released, pending reports there is no
+// more outstanding work that could release it, or ctx is done. A nil
+// budget never waits.
+func (b *MemoryBudget) wait(ctx context.Context, pending func() bool) error {
+	if b == nil || b.cur.Load() <= b.limit {
+		return nil
+	}
+
+	if b.policy == BudgetError {
+		return fmt.Errorf("%w: %d bytes allocated, limit is %d bytes",
+			ErrMemoryBudgetExceeded, b.cur.Load(), b.limit)
+	}
+
+	timer := time.NewTimer(budgetPollInterval)
+	defer timer.Stop()
+
+	for b.cur.Load() > b.limit && pending() {
+		b.mx.Lock()
+		b.waiters++
+		freed := b.freed
+		b.mx.Unlock()
+
+		timer.Reset(budgetPollInterval)
+		select {
+		case <-freed:
+		case <-timer.C:
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	}
+
+	return nil
+}
+
+// budgetFromContext returns the MemoryBudget that is the allocator of
+// ctx, if any.
+func budgetFromContext(ctx context.Context) *MemoryBudget {
+	b, _ := compute.GetAllocator(ctx).(*MemoryBudget)
+
+	return b
+}
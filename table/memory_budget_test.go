@@ -0,0 +1,110 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/compute"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryBudget(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	budget := table.NewMemoryBudget(mem, 64, table.BudgetError)
+	assert.EqualValues(t, 64, budget.Limit())
+	assert.Equal(t, table.BudgetError, budget.Policy())
+
+	buf := budget.Allocate(32)
+	assert.EqualValues(t, 32, budget.CurrentAlloc())
+	buf = budget.Reallocate(100, buf)
+	assert.EqualValues(t, 100, budget.CurrentAlloc())
+	buf = budget.Reallocate(10, buf)
+	assert.EqualValues(t, 10, budget.CurrentAlloc())
+	budget.Free(buf)
+	assert.Zero(t, budget.CurrentAlloc())
+	assert.EqualValues(t, 100, budget.PeakAlloc())
+}
+
+func TestScanAndWriteMemoryLimit(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "name", Type: iceberg.PrimitiveTypes.String})
+
+	tbl := newTestTable(t, withTestSchema(sc))
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, false, false)
+	require.NoError(t, err)
+
+	// a blocking budget far below what writing a file needs still lets
+	// writes complete, one file at a time
+	writeBudget := table.NewMemoryBudget(memory.DefaultAllocator, 1, table.BudgetBlock)
+	for _, data := range []string{
+		`[{"id": 1, "name": "a"}, {"id": 2, "name": "b"}]`,
+		`[{"id": 3, "name": "c"}]`,
+	} {
+		arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{data})
+		require.NoError(t, err)
+		tbl, err = tbl.AppendTable(compute.WithAllocator(ctx, writeBudget), arrTbl, 1, nil)
+		arrTbl.Release()
+		require.NoError(t, err)
+	}
+	assert.Positive(t, writeBudget.PeakAlloc())
+
+	t.Run("block", func(t *testing.T) {
+		mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+		defer mem.AssertSize(t, 0)
+
+		_, itr, err := tbl.Scan(table.WithAllocator(mem),
+			table.WithMemoryLimit(1, table.BudgetBlock)).ToArrowRecords(ctx)
+		require.NoError(t, err)
+
+		var rows int64
+		for rec, err := range itr {
+			require.NoError(t, err)
+			rows += rec.NumRows()
+			rec.Release()
+		}
+		assert.EqualValues(t, 3, rows)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := tbl.Scan(table.WithMemoryLimit(1, table.BudgetError)).ToArrowTable(ctx)
+		assert.ErrorIs(t, err, table.ErrMemoryBudgetExceeded)
+	})
+
+	t.Run("allocator budget", func(t *testing.T) {
+		budget := table.NewMemoryBudget(nil, 1<<30, table.BudgetError)
+		result, err := tbl.Scan(table.WithAllocator(budget)).ToArrowTable(ctx)
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, result.NumRows())
+		assert.Positive(t, budget.CurrentAlloc())
+		result.Release()
+		assert.Zero(t, budget.CurrentAlloc())
+	})
+}
@@ -28,7 +28,6 @@ import (
 	"maps"
 	"slices"
 	"strconv"
-	"time"
 
 	"github.com/apache/iceberg-go"
 	iceinternal "github.com/apache/iceberg-go/internal"
@@ -157,6 +156,9 @@ type Metadata interface {
 	// write the partition statistics file during each write operation,
 	// or it can also be computed on demand.
 	PartitionStatistics() iter.Seq[PartitionStatisticsFile]
+	// EncryptionKeys returns the list of encrypted keys stored in the table
+	// metadata, used to decrypt the manifest lists of v3 tables.
+	EncryptionKeys() iter.Seq[EncryptedKey]
 }
 
 // MetadataBuilder is a struct used for building and updating Iceberg table metadata.
@@ -186,6 +188,7 @@ type MetadataBuilder struct {
 	sortOrderList      []SortOrder
 	defaultSortOrderID int
 	refs               map[string]SnapshotRef
+	encryptionKeys     []EncryptedKey
 
 	previousFileEntry *MetadataLogEntry
 	// >v1 specific
@@ -196,6 +199,8 @@ type MetadataBuilder struct {
 	lastAddedSchemaID    *int
 	lastAddedPartitionID *int
 	lastAddedSortOrderID *int
+
+	clock Clock
 }
 
 func NewMetadataBuilder(formatVersion int) (*MetadataBuilder, error) {
@@ -261,6 +266,7 @@ func MetadataBuilderFromBase(metadata Metadata, currentFileLocation string) (*Me
 	b.refs = maps.Collect(metadata.Refs())
 	b.snapshotLog = slices.Collect(metadata.SnapshotLogs())
 	b.metadataLog = slices.Collect(metadata.PreviousFiles())
+	b.encryptionKeys = slices.Collect(metadata.EncryptionKeys())
 
 	if currentFileLocation != "" {
 		b.previousFileEntry = &MetadataLogEntry{
@@ -286,6 +292,22 @@ func (b *MetadataBuilder) CurrentSchema() *iceberg.Schema {
 
 func (b *MetadataBuilder) LastUpdatedMS() int64 { return b.lastUpdatedMS }
 
+// SetClock sets the Clock used for the timestamps of the metadata updates,
+// SystemClock by default.
+func (b *MetadataBuilder) SetClock(c Clock) *MetadataBuilder {
+	b.clock = c
+
+	return b
+}
+
+func (b *MetadataBuilder) nowMs() int64 {
+	if b.clock == nil {
+		return SystemClock.Now().UnixMilli()
+	}
+
+	return b.clock.Now().UnixMilli()
+}
+
 func (b *MetadataBuilder) nextSequenceNumber() int64 {
 	if b.formatVersion > 1 {
 		if b.lastSequenceNumber == nil {
@@ -318,6 +340,10 @@ func (b *MetadataBuilder) currentSnapshot() *Snapshot {
 }
 
 func (b *MetadataBuilder) AddSchema(schema *iceberg.Schema) error {
+	if err := iceberg.ValidateReservedFields(schema); err != nil {
+		return err
+	}
+
 	if err := checkSchemaCompatibility(schema, b.formatVersion); err != nil {
 		return err
 	}
@@ -369,6 +395,10 @@ func (b *MetadataBuilder) AddPartitionSpec(spec *iceberg.PartitionSpec, initial
 	maxFieldID := 0
 	fieldCount := 0
 	for f := range freshSpec.Fields() {
+		if f.FieldID < iceberg.PartitionDataIDStart {
+			return fmt.Errorf("%w: partition field %s has id %d, partition field ids start at %d",
+				iceberg.ErrInvalidArgument, f.Name, f.FieldID, iceberg.PartitionDataIDStart)
+		}
 		maxFieldID = max(maxFieldID, f.FieldID)
 		if b.formatVersion <= 1 {
 			expectedID := partitionFieldStartID + fieldCount
@@ -426,7 +456,10 @@ func (b *MetadataBuilder) AddSnapshot(snapshot *Snapshot) error {
 				snapshot.TimestampMs, last.TimestampMs)
 		}
 	}
-	maxTS := max(b.lastUpdatedMS, b.base.LastUpdatedMillis())
+	maxTS := b.lastUpdatedMS
+	if b.base != nil {
+		maxTS = max(maxTS, b.base.LastUpdatedMillis())
+	}
 	if snapshot.TimestampMs-maxTS < -oneMinuteInMs {
 		return fmt.Errorf("invalid snapshot timestamp %d: before last updated timestamp %d",
 			snapshot.TimestampMs, maxTS)
@@ -779,7 +812,7 @@ func (b *MetadataBuilder) SetSnapshotRef(
 	if name == MainBranch {
 		b.currentSnapshotID = &snapshotID
 		if !isAddedSnapshot {
-			b.lastUpdatedMS = time.Now().Local().UnixMilli()
+			b.lastUpdatedMS = b.nowMs()
 		}
 		b.snapshotLog = append(b.snapshotLog, SnapshotLogEntry{
 			SnapshotID:  snapshotID,
@@ -813,6 +846,43 @@ func (b *MetadataBuilder) RemoveSnapshotRef(name string) error {
 	return nil
 }
 
+func (b *MetadataBuilder) AddEncryptionKey(key EncryptedKey) error {
+	if b.formatVersion < 3 {
+		return fmt.Errorf("%w: encryption keys require format version 3, got %d",
+			iceberg.ErrInvalidArgument, b.formatVersion)
+	}
+
+	if key.KeyID == "" {
+		return fmt.Errorf("%w: encryption key id must not be empty", iceberg.ErrInvalidArgument)
+	}
+
+	idx := slices.IndexFunc(b.encryptionKeys, func(k EncryptedKey) bool { return k.KeyID == key.KeyID })
+	if idx >= 0 {
+		if b.encryptionKeys[idx].Equals(key) {
+			return nil
+		}
+
+		return fmt.Errorf("%w: encryption key already exists: %s", iceberg.ErrInvalidArgument, key.KeyID)
+	}
+
+	b.encryptionKeys = append(b.encryptionKeys, key)
+	b.updates = append(b.updates, NewAddEncryptionKeyUpdate(key))
+
+	return nil
+}
+
+func (b *MetadataBuilder) RemoveEncryptionKey(keyID string) error {
+	idx := slices.IndexFunc(b.encryptionKeys, func(k EncryptedKey) bool { return k.KeyID == keyID })
+	if idx < 0 {
+		return nil
+	}
+
+	b.encryptionKeys = slices.Delete(slices.Clone(b.encryptionKeys), idx, idx+1)
+	b.updates = append(b.updates, NewRemoveEncryptionKeyUpdate(keyID))
+
+	return nil
+}
+
 func (b *MetadataBuilder) SetUUID(uuid uuid.UUID) error {
 	if b.uuid == uuid {
 		return nil
@@ -825,7 +895,7 @@ func (b *MetadataBuilder) SetUUID(uuid uuid.UUID) error {
 }
 
 func (b *MetadataBuilder) SetLastUpdatedMS() *MetadataBuilder {
-	b.lastUpdatedMS = time.Now().UnixMilli()
+	b.lastUpdatedMS = b.nowMs()
 
 	return b
 }
@@ -841,7 +911,7 @@ func (b *MetadataBuilder) buildCommonMetadata() (*commonMetadata, error) {
 	}
 
 	if b.lastUpdatedMS == 0 {
-		b.lastUpdatedMS = time.Now().UnixMilli()
+		b.lastUpdatedMS = b.nowMs()
 	}
 
 	if b.previousFileEntry != nil && b.HasChanges() {
@@ -871,6 +941,7 @@ func (b *MetadataBuilder) buildCommonMetadata() (*commonMetadata, error) {
 		SortOrderList:      b.sortOrderList,
 		DefaultSortOrderID: b.defaultSortOrderID,
 		SnapshotRefs:       b.refs,
+		EncryptionKeyList:  b.encryptionKeys,
 	}, nil
 }
 
@@ -1229,6 +1300,7 @@ type commonMetadata struct {
 	SnapshotRefs       map[string]SnapshotRef    `json:"refs,omitempty"`
 	StatisticsList     []StatisticsFile          `json:"statistics,omitempty"`
 	PartitionStatsList []PartitionStatisticsFile `json:"partition-statistics,omitempty"`
+	EncryptionKeyList  []EncryptedKey            `json:"encryption-keys,omitempty"`
 	// V2+ fields
 	LastSequenceNumber *int64 `json:"last-sequence-number,omitempty"`
 	// V3+ fields
@@ -1287,6 +1359,8 @@ func (c *commonMetadata) Equals(other *commonMetadata) bool {
 	case !maps.Equal(c.Props, other.Props):
 		fallthrough
 	case !maps.EqualFunc(c.SnapshotRefs, other.SnapshotRefs, func(sr1, sr2 SnapshotRef) bool { return sr1.Equals(sr2) }):
+		fallthrough
+	case !slices.EqualFunc(c.EncryptionKeyList, other.EncryptionKeyList, EncryptedKey.Equals):
 		return false
 	}
 
@@ -1393,6 +1467,10 @@ func (c *commonMetadata) Statistics() iter.Seq[StatisticsFile] {
 	return slices.Values(c.StatisticsList)
 }
 
+func (c *commonMetadata) EncryptionKeys() iter.Seq[EncryptedKey] {
+	return slices.Values(c.EncryptionKeyList)
+}
+
 func (c *commonMetadata) PartitionStatistics() iter.Seq[PartitionStatisticsFile] {
 	return slices.Values(c.PartitionStatsList)
 }
@@ -1549,7 +1627,11 @@ func (c *commonMetadata) validate() error {
 }
 
 func (c *commonMetadata) NameMapping() iceberg.NameMapping {
-	if nameMappingJson, ok := c.Props[DefaultNameMappingKey]; ok {
+	return nameMappingFromProperties(c.Props)
+}
+
+func nameMappingFromProperties(props iceberg.Properties) iceberg.NameMapping {
+	if nameMappingJson, ok := props[DefaultNameMappingKey]; ok {
 		nm := iceberg.NameMapping{}
 		if err := json.Unmarshal([]byte(nameMappingJson), &nm); err == nil {
 			return nm
@@ -2012,7 +2094,18 @@ func reassignIDs(sc *iceberg.Schema, partitions *iceberg.PartitionSpec, sortOrde
 }
 
 func UpdateTableMetadata(base Metadata, updates []Update, metadataLoc string) (Metadata, error) {
-	bldr, err := MetadataBuilderFromBase(base, metadataLoc)
+	var (
+		bldr *MetadataBuilder
+		err  error
+	)
+
+	// a nil base is a table being created, whose updates are applied
+	// to empty metadata of the lowest format version
+	if base == nil {
+		bldr, err = NewMetadataBuilder(1)
+	} else {
+		bldr, err = MetadataBuilderFromBase(base, metadataLoc)
+	}
 	if err != nil {
 		return nil, err
 	}
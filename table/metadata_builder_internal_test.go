@@ -18,7 +18,10 @@
 package table
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
+	"slices"
 	"testing"
 	"time"
 
@@ -1697,3 +1700,75 @@ func TestUnknownTypeValidation(t *testing.T) {
 		require.ErrorContains(t, err, "must be optional")
 	})
 }
+
+func TestAddRemoveEncryptionKey(t *testing.T) {
+	key := EncryptedKey{
+		KeyID:                "key-1",
+		EncryptedKeyMetadata: []byte{1, 2, 3},
+		EncryptedByID:        "table-key",
+	}
+
+	v2 := builderWithoutChanges(2)
+	require.ErrorIs(t, v2.AddEncryptionKey(key), iceberg.ErrInvalidArgument)
+
+	builder := builderWithoutChanges(3)
+	require.ErrorIs(t, builder.AddEncryptionKey(EncryptedKey{}), iceberg.ErrInvalidArgument)
+	require.NoError(t, builder.AddEncryptionKey(key))
+	require.NoError(t, builder.AddEncryptionKey(key))
+	require.ErrorIs(t, builder.AddEncryptionKey(EncryptedKey{KeyID: "key-1"}), iceberg.ErrInvalidArgument)
+	require.Len(t, builder.updates, 1)
+	require.Equal(t, UpdateAddEncryptionKey, builder.updates[0].Action())
+
+	meta, err := builder.Build()
+	require.NoError(t, err)
+	require.Equal(t, []EncryptedKey{key}, slices.Collect(meta.EncryptionKeys()))
+
+	data, err := json.Marshal(meta)
+	require.NoError(t, err)
+	parsed, err := ParseMetadataBytes(data)
+	require.NoError(t, err)
+	require.True(t, meta.Equals(parsed))
+
+	fromBase, err := MetadataBuilderFromBase(parsed, "")
+	require.NoError(t, err)
+	require.NoError(t, fromBase.RemoveEncryptionKey("key-1"))
+	require.NoError(t, fromBase.RemoveEncryptionKey("missing"))
+	require.Len(t, fromBase.updates, 1)
+
+	meta, err = fromBase.Build()
+	require.NoError(t, err)
+	require.Empty(t, slices.Collect(meta.EncryptionKeys()))
+}
+
+func TestReservedFieldsAndPartitionIDs(t *testing.T) {
+	builder := builderWithoutChanges(2)
+
+	err := builder.AddSchema(iceberg.NewSchema(1,
+		iceberg.NestedField{ID: 1, Name: "x", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "_partition", Type: iceberg.PrimitiveTypes.String}))
+	require.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+
+	err = builder.AddSchema(iceberg.NewSchema(1,
+		iceberg.NestedField{ID: 1, Name: "x", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: math.MaxInt32 - 1, Name: "path", Type: iceberg.PrimitiveTypes.String}))
+	require.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+
+	id := 999
+	spec, err := iceberg.NewPartitionSpecOpts(iceberg.WithSpecID(10),
+		iceberg.AddPartitionFieldBySourceID(2, "y", iceberg.IdentityTransform{}, builder.CurrentSchema(), &id))
+	require.NoError(t, err)
+	require.ErrorContains(t, builder.AddPartitionSpec(&spec, false), "partition field ids start at 1000")
+
+	txn := New([]string{"id"}, testMetadata, "", nil, nil).NewTransaction()
+	_, err = NewUpdateSchema(txn, true, false).
+		AddColumn([]string{"_file"}, iceberg.PrimitiveTypes.String, "", false, nil).Apply()
+	require.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+
+	_, err = NewUpdateSchema(txn, true, false).RenameColumn([]string{"name"}, "_pos").Apply()
+	require.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+
+	// nested columns may use the names of metadata columns
+	_, err = NewUpdateSchema(txn, true, false).
+		AddColumn([]string{"address", "_file"}, iceberg.PrimitiveTypes.String, "", false, nil).Apply()
+	require.NoError(t, err)
+}
@@ -0,0 +1,319 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/compute"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+)
+
+// The metadata columns that scans can project by selecting their names.
+// They use reserved field IDs and are appended after the table columns.
+var (
+	FilePathColumn = iceberg.NestedField{
+		ID: math.MaxInt32 - 1, Name: "_file", Type: iceberg.PrimitiveTypes.String,
+		Required: true, Doc: "Path of the file in which a row is stored",
+	}
+	RowPositionColumn = iceberg.NestedField{
+		ID: math.MaxInt32 - 2, Name: "_pos", Type: iceberg.PrimitiveTypes.Int64,
+		Required: true, Doc: "Ordinal position of a row in the source data file",
+	}
+	// IsDeletedColumn reports whether a row was removed by a delete file.
+	// Projecting it makes the scan return deleted rows instead of
+	// dropping them.
+	IsDeletedColumn = iceberg.NestedField{
+		ID: math.MaxInt32 - 3, Name: "_deleted", Type: iceberg.PrimitiveTypes.Bool,
+		Required: true, Doc: "Whether the row has been deleted",
+	}
+	SpecIDColumn = iceberg.NestedField{
+		ID: math.MaxInt32 - 4, Name: "_spec_id", Type: iceberg.PrimitiveTypes.Int32,
+		Required: true, Doc: "Spec ID used to track the file containing a row",
+	}
+)
+
+const (
+	PartitionColumnID   = math.MaxInt32 - 5
+	PartitionColumnName = "_partition"
+)
+
+// The row lineage columns of format version 3 tables. Rows inherit their
+// row ID from the first row ID of their data file and their position in
+// it, and their last updated sequence number from the data sequence number
+// of the file, unless the file stores explicit values. Both are null for
+// files written before row lineage was enabled.
+var (
+	RowIDColumn = iceberg.NestedField{
+		ID: math.MaxInt32 - 107, Name: "_row_id", Type: iceberg.PrimitiveTypes.Int64,
+		Doc: "Implicit row ID that is automatically assigned",
+	}
+	LastUpdatedSequenceNumberColumn = iceberg.NestedField{
+		ID: math.MaxInt32 - 108, Name: "_last_updated_sequence_number", Type: iceberg.PrimitiveTypes.Int64,
+		Doc: "Sequence number when the row was last updated",
+	}
+)
+
+// The metadata columns appended to the rows returned by a changelog scan.
+var (
+	ChangeTypeColumn = iceberg.NestedField{
+		ID: math.MaxInt32 - 104, Name: "_change_type", Type: iceberg.PrimitiveTypes.String,
+		Required: true, Doc: "Record type in changelog",
+	}
+	ChangeOrdinalColumn = iceberg.NestedField{
+		ID: math.MaxInt32 - 105, Name: "_change_ordinal", Type: iceberg.PrimitiveTypes.Int32,
+		Doc: "Change ordinal in changelog",
+	}
+	CommitSnapshotIDColumn = iceberg.NestedField{
+		ID: math.MaxInt32 - 106, Name: "_commit_snapshot_id", Type: iceberg.PrimitiveTypes.Int64,
+		Doc: "Commit snapshot ID",
+	}
+)
+
+// PartitionColumn returns the _partition metadata column of a table. Its
+// type is a struct of every partition field of the table's specs whose
+// source column is in schema, so that rows of files written with
+// different specs share a type; fields that are not part of a file's
+// spec are null.
+func PartitionColumn(meta Metadata, schema *iceberg.Schema) iceberg.NestedField {
+	var fields []iceberg.NestedField
+	for _, spec := range meta.PartitionSpecs() {
+		for _, f := range spec.PartitionType(schema).FieldList {
+			if !slices.ContainsFunc(fields, func(e iceberg.NestedField) bool { return e.ID == f.ID }) {
+				f.Required = false
+				fields = append(fields, f)
+			}
+		}
+	}
+	slices.SortFunc(fields, func(a, b iceberg.NestedField) int { return cmp.Compare(a.ID, b.ID) })
+
+	return iceberg.NestedField{
+		ID: PartitionColumnID, Name: PartitionColumnName,
+		Type: &iceberg.StructType{FieldList: fields},
+		Doc:  "Partition to which a row belongs to",
+	}
+}
+
+// isMetadataColumn reports whether id is the field ID of a metadata
+// column that scans can project.
+func isMetadataColumn(id int) bool {
+	return id >= PartitionColumnID || isRowLineageColumn(id)
+}
+
+// isRowLineageColumn reports whether id is the field ID of a row lineage
+// column, which data files may also store.
+func isRowLineageColumn(id int) bool {
+	return id == RowIDColumn.ID || id == LastUpdatedSequenceNumberColumn.ID
+}
+
+// metadataColumn returns the metadata column with the given name.
+func metadataColumn(meta Metadata, schema *iceberg.Schema, name string, caseSensitive bool) (iceberg.NestedField, bool) {
+	matches := func(n string) bool {
+		if caseSensitive {
+			return name == n
+		}
+
+		return strings.EqualFold(name, n)
+	}
+
+	for _, col := range []iceberg.NestedField{
+		FilePathColumn, RowPositionColumn, IsDeletedColumn, SpecIDColumn,
+		RowIDColumn, LastUpdatedSequenceNumberColumn,
+	} {
+		if matches(col.Name) {
+			return col, true
+		}
+	}
+
+	if matches(PartitionColumnName) {
+		return PartitionColumn(meta, schema), true
+	}
+
+	return iceberg.NestedField{}, false
+}
+
+// splitMetadataColumns returns the table columns of a projected schema
+// and its metadata columns.
+func splitMetadataColumns(projected *iceberg.Schema) (*iceberg.Schema, []iceberg.NestedField) {
+	fields := projected.Fields()
+	i := slices.IndexFunc(fields, func(f iceberg.NestedField) bool { return isMetadataColumn(f.ID) })
+	if i < 0 {
+		return projected, nil
+	}
+
+	return iceberg.NewSchemaWithIdentifiers(projected.ID, projected.IdentifierFieldIDs, fields[:i]...), fields[i:]
+}
+
+// appendRowPositions returns a function that appends the position of
+// each row within the file to the records read from it. It must see
+// every record of the file, in order.
+func appendRowPositions(ctx context.Context) recProcessFn {
+	nextPos, mem := int64(0), compute.GetAllocator(ctx)
+
+	return func(r arrow.RecordBatch) (arrow.RecordBatch, error) {
+		defer r.Release()
+
+		bldr := array.NewInt64Builder(mem)
+		defer bldr.Release()
+		bldr.Reserve(int(r.NumRows()))
+		for i := range r.NumRows() {
+			bldr.UnsafeAppend(nextPos + i)
+		}
+		nextPos += r.NumRows()
+
+		pos := bldr.NewArray()
+		defer pos.Release()
+
+		fields := append(r.Schema().Fields(), arrow.Field{Name: RowPositionColumn.Name, Type: arrow.PrimitiveTypes.Int64})
+
+		return array.NewRecordBatch(arrow.NewSchema(fields, nil),
+			slices.Concat(r.Columns(), []arrow.Array{pos}), r.NumRows()), nil
+	}
+}
+
+// metadataColumnArray returns the values of a metadata column for the n
+// rows of a record read from the task's file. The values are built with
+// the arrow type base and then converted to the read type target.
+// positions holds the position of each row in the file and deletes the
+// positions deleted by the file's delete files. stored holds the values of
+// the column read from the file, if it has it.
+func metadataColumnArray(ctx context.Context, rt readTypes, col iceberg.NestedField, base, target arrow.DataType,
+	n int, task FileScanTask, positions *array.Int64, deletes set[int64], stored arrow.Array,
+) (arrow.Array, error) {
+	mem := compute.GetAllocator(ctx)
+
+	var (
+		arr arrow.Array
+		err error
+	)
+	switch col.ID {
+	case FilePathColumn.ID:
+		arr, err = literalArray(mem, iceberg.NewLiteral(task.File.FilePath()), base, n)
+	case SpecIDColumn.ID:
+		arr, err = literalArray(mem, iceberg.NewLiteral(task.File.SpecID()), base, n)
+	case RowPositionColumn.ID:
+		positions.Retain()
+		arr = positions
+	case IsDeletedColumn.ID:
+		bldr := array.NewBooleanBuilder(mem)
+		defer bldr.Release()
+		bldr.Reserve(n)
+		for _, pos := range positions.Int64Values() {
+			_, deleted := deletes[pos]
+			bldr.UnsafeAppend(deleted)
+		}
+		arr = bldr.NewArray()
+	case RowIDColumn.ID, LastUpdatedSequenceNumberColumn.ID:
+		arr, err = rowLineageArray(mem, col, n, task, positions, stored)
+	case PartitionColumnID:
+		return partitionColumnArray(ctx, rt, col, base.(*arrow.StructType), target.(*arrow.StructType), n, task)
+	default:
+		return nil, fmt.Errorf("%w: unknown metadata column %s", iceberg.ErrInvalidArgument, col)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer arr.Release()
+
+	return rt.convert(ctx, arr, target)
+}
+
+// rowLineageArray returns the values of a row lineage column for the n
+// rows of a record: the values stored in the file where they are not null,
+// and otherwise the values inherited from the task's file.
+func rowLineageArray(mem memory.Allocator, col iceberg.NestedField, n int, task FileScanTask,
+	positions *array.Int64, stored arrow.Array,
+) (arrow.Array, error) {
+	var storedValues *array.Int64
+	if stored != nil {
+		var ok bool
+		if storedValues, ok = stored.(*array.Int64); !ok {
+			return nil, fmt.Errorf("%w: column %s of %s has type %s",
+				ErrInvalidMetadata, col.Name, task.File.FilePath(), stored.DataType())
+		}
+	}
+
+	firstRowID := task.File.FirstRowID()
+	bldr := array.NewInt64Builder(mem)
+	defer bldr.Release()
+	bldr.Reserve(n)
+	for i := range n {
+		switch {
+		case storedValues != nil && storedValues.IsValid(i):
+			bldr.UnsafeAppend(storedValues.Value(i))
+		case firstRowID == nil:
+			bldr.UnsafeAppendBoolToBitmap(false)
+		case col.ID == RowIDColumn.ID:
+			bldr.UnsafeAppend(*firstRowID + positions.Value(i))
+		case task.DataSequenceNumber != nil:
+			bldr.UnsafeAppend(*task.DataSequenceNumber)
+		default:
+			bldr.UnsafeAppendBoolToBitmap(false)
+		}
+	}
+
+	return bldr.NewArray(), nil
+}
+
+// partitionColumnArray returns n copies of the partition tuple of the
+// task's file as a _partition column.
+func partitionColumnArray(ctx context.Context, rt readTypes, col iceberg.NestedField, base, target *arrow.StructType,
+	n int, task FileScanTask,
+) (arrow.Array, error) {
+	if target.NumFields() == 0 {
+		return array.MakeFromData(array.NewData(target, n, []*memory.Buffer{nil}, nil, 0, 0)), nil
+	}
+
+	mem := compute.GetAllocator(ctx)
+	partition := task.File.Partition()
+	children := make([]arrow.Array, target.NumFields())
+	defer func() {
+		for _, c := range children {
+			if c != nil {
+				c.Release()
+			}
+		}
+	}()
+
+	for i, f := range col.Type.(*iceberg.StructType).FieldList {
+		lit, err := iceberg.LiteralFromDefault(f.Type, partition[f.ID])
+		if err != nil {
+			return nil, fmt.Errorf("partition field %s of %s: %w", f.Name, task.File.FilePath(), err)
+		}
+
+		arr, err := literalArray(mem, lit, base.Field(i).Type, n)
+		if err != nil {
+			return nil, err
+		}
+		children[i], err = rt.convert(ctx, arr, target.Field(i).Type)
+		arr.Release()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return array.NewStructArrayWithFields(children, target.Fields())
+}
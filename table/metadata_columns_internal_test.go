@@ -0,0 +1,278 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"cmp"
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanMetadataColumns(t *testing.T) {
+	ctx := context.Background()
+	loc := filepath.ToSlash(t.TempDir())
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "cat", Type: iceberg.PrimitiveTypes.String, Required: true})
+	spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+		SourceID: 2, FieldID: 1000, Transform: iceberg.IdentityTransform{}, Name: "cat",
+	})
+	meta, err := NewMetadata(sc, &spec, UnsortedSortOrder, loc,
+		iceberg.Properties{PropertyFormatVersion: "2"})
+	require.NoError(t, err)
+
+	tbl := New(Identifier{"default", "metadata_columns"}, meta, loc+"/metadata/v1.metadata.json",
+		func(context.Context) (iceio.IO, error) { return iceio.LocalFS{}, nil },
+		&inMemoryCatalog{meta})
+
+	arrSchema, err := SchemaToArrowSchema(sc, nil, false, false)
+	require.NoError(t, err)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{`[
+		{"id": 1, "cat": "a"}, {"id": 2, "cat": "a"}, {"id": 3, "cat": "a"},
+		{"id": 4, "cat": "b"}, {"id": 5, "cat": "b"}
+	]`})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+	tbl, err = tbl.AppendTable(ctx, arrTbl, 10, nil)
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	fs, err := tbl.FS(ctx)
+	require.NoError(t, err)
+	for df, err := range tbl.CurrentSnapshot().dataFiles(fs, nil) {
+		require.NoError(t, err)
+		files[df.Partition()[1000].(string)] = df.FilePath()
+	}
+	require.Len(t, files, 2)
+
+	// delete the row with id 2
+	w, err := NewPositionDeleteWriter(ctx, tbl, WithDeletePartition(0, map[int]any{1000: "a"}))
+	require.NoError(t, err)
+	require.NoError(t, w.Write(files["a"], 1))
+	deletes, err := w.Close()
+	require.NoError(t, err)
+	tbl = commitDeleteFiles(t, tbl, deletes)
+
+	type row struct {
+		ID      int64
+		Meta    []any
+		Deleted bool
+	}
+
+	scanRows := func(t *testing.T, opts ...ScanOption) (*arrow.Schema, []row) {
+		result, err := tbl.Scan(opts...).ToArrowTable(ctx)
+		require.NoError(t, err)
+		defer result.Release()
+
+		var out []row
+		rdr := array.NewTableReader(result, -1)
+		defer rdr.Release()
+		for rdr.Next() {
+			rec := rdr.RecordBatch()
+			for i := range int(rec.NumRows()) {
+				r := row{ID: rec.Column(0).(*array.Int64).Value(i)}
+				for _, col := range rec.Columns()[1:] {
+					switch col := col.(type) {
+					case *array.Struct:
+						r.Meta = append(r.Meta, col.Field(0).(*array.String).Value(i))
+					case *array.Boolean:
+						r.Deleted = col.Value(i)
+					default:
+						r.Meta = append(r.Meta, col.GetOneForMarshal(i))
+					}
+				}
+				out = append(out, r)
+			}
+		}
+		require.NoError(t, rdr.Err())
+
+		return result.Schema(), out
+	}
+
+	t.Run("projection", func(t *testing.T) {
+		projected, err := tbl.Scan(WithSelectedFields("id", "_partition", "_file")).Projection()
+		require.NoError(t, err)
+		require.Equal(t, 3, projected.NumFields())
+		assert.Equal(t, FilePathColumn, projected.Field(2))
+
+		partition := projected.Field(1)
+		assert.Equal(t, PartitionColumnID, partition.ID)
+		assert.Equal(t, []iceberg.NestedField{{ID: 1000, Name: "cat", Type: iceberg.PrimitiveTypes.String}},
+			partition.Type.(*iceberg.StructType).FieldList)
+
+		_, err = tbl.Scan(WithSelectedFields("id", "_unknown")).Projection()
+		assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+	})
+
+	t.Run("file, position, spec and partition", func(t *testing.T) {
+		sc, rows := scanRows(t, WithSelectedFields("id", "_file", "_pos", "_spec_id", "_partition"))
+
+		names := make([]string, 0, sc.NumFields())
+		for _, f := range sc.Fields() {
+			names = append(names, f.Name)
+		}
+		assert.Equal(t, []string{"id", "_file", "_pos", "_spec_id", "_partition"}, names)
+		assert.Equal(t, arrow.PrimitiveTypes.Int64, sc.Field(2).Type)
+		assert.False(t, sc.Field(2).Nullable)
+
+		assert.ElementsMatch(t, []row{
+			{ID: 1, Meta: []any{files["a"], int64(0), int32(0), "a"}},
+			{ID: 3, Meta: []any{files["a"], int64(2), int32(0), "a"}},
+			{ID: 4, Meta: []any{files["b"], int64(0), int32(0), "b"}},
+			{ID: 5, Meta: []any{files["b"], int64(1), int32(0), "b"}},
+		}, rows)
+	})
+
+	t.Run("deleted rows", func(t *testing.T) {
+		_, rows := scanRows(t, WithSelectedFields("id", "_deleted"))
+		assert.ElementsMatch(t, []row{
+			{ID: 1}, {ID: 2, Deleted: true}, {ID: 3}, {ID: 4}, {ID: 5},
+		}, rows)
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		_, rows := scanRows(t, WithSelectedFields("id", "_pos"),
+			WithRowFilter(iceberg.GreaterThanEqual(iceberg.Reference("id"), int64(3))))
+		assert.ElementsMatch(t, []row{
+			{ID: 3, Meta: []any{int64(2)}},
+			{ID: 4, Meta: []any{int64(0)}},
+			{ID: 5, Meta: []any{int64(1)}},
+		}, rows)
+	})
+
+	t.Run("read types", func(t *testing.T) {
+		result, err := tbl.Scan(WithSelectedFields("id", "_file", "_partition"),
+			WithOptions(iceberg.Properties{ScanOptionArrowUseViewTypes: "true"})).ToArrowTable(ctx)
+		require.NoError(t, err)
+		defer result.Release()
+
+		assert.EqualValues(t, 4, result.NumRows())
+		assert.Equal(t, arrow.BinaryTypes.StringView, result.Schema().Field(1).Type)
+		assert.Equal(t, arrow.BinaryTypes.StringView,
+			result.Schema().Field(2).Type.(*arrow.StructType).Field(0).Type)
+	})
+
+	t.Run("case insensitive", func(t *testing.T) {
+		_, rows := scanRows(t, WithSelectedFields("ID", "_FILE"), WithCaseSensitive(false),
+			WithRowFilter(iceberg.EqualTo(iceberg.Reference("id"), int64(4))))
+		require.Len(t, rows, 1)
+		assert.Equal(t, files["b"], rows[0].Meta[0])
+		assert.True(t, strings.HasSuffix(rows[0].Meta[0].(string), ".parquet"))
+	})
+}
+
+func TestScanRowLineageColumns(t *testing.T) {
+	ctx := context.Background()
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true})
+	arrSchema, err := SchemaToArrowSchema(sc, nil, false, false)
+	require.NoError(t, err)
+
+	scanLineage := func(t *testing.T, version string) [][]any {
+		loc := filepath.ToSlash(t.TempDir())
+		meta, err := NewMetadata(sc, iceberg.UnpartitionedSpec, UnsortedSortOrder, loc,
+			iceberg.Properties{PropertyFormatVersion: version})
+		require.NoError(t, err)
+
+		tbl := New(Identifier{"default", "lineage"}, meta, loc+"/metadata/v1.metadata.json",
+			func(context.Context) (iceio.IO, error) { return iceio.LocalFS{}, nil },
+			&inMemoryCatalog{meta})
+
+		for _, rows := range []string{`[{"id": 1}, {"id": 2}, {"id": 3}]`, `[{"id": 4}, {"id": 5}]`} {
+			arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{rows})
+			require.NoError(t, err)
+			tbl, err = tbl.AppendTable(ctx, arrTbl, 10, nil)
+			require.NoError(t, err)
+			arrTbl.Release()
+		}
+
+		result, err := tbl.Scan(WithSelectedFields("id", "_row_id", "_last_updated_sequence_number")).
+			ToArrowTable(ctx)
+		require.NoError(t, err)
+		defer result.Release()
+
+		var out [][]any
+		rdr := array.NewTableReader(result, -1)
+		defer rdr.Release()
+		for rdr.Next() {
+			rec := rdr.RecordBatch()
+			for i := range int(rec.NumRows()) {
+				r := make([]any, 0, rec.NumCols())
+				for _, col := range rec.Columns() {
+					r = append(r, col.GetOneForMarshal(i))
+				}
+				out = append(out, r)
+			}
+		}
+		require.NoError(t, rdr.Err())
+		slices.SortFunc(out, func(a, b []any) int { return cmp.Compare(a[0].(int64), b[0].(int64)) })
+
+		return out
+	}
+
+	t.Run("v3", func(t *testing.T) {
+		assert.Equal(t, [][]any{
+			{int64(1), int64(0), int64(1)},
+			{int64(2), int64(1), int64(1)},
+			{int64(3), int64(2), int64(1)},
+			{int64(4), int64(3), int64(2)},
+			{int64(5), int64(4), int64(2)},
+		}, scanLineage(t, "3"))
+	})
+
+	t.Run("v2", func(t *testing.T) {
+		for _, r := range scanLineage(t, "2") {
+			assert.Nil(t, r[1])
+			assert.Nil(t, r[2])
+		}
+	})
+
+	t.Run("stored values", func(t *testing.T) {
+		bldr, err := iceberg.NewDataFileBuilder(*iceberg.UnpartitionedSpec, iceberg.EntryContentData,
+			"file.parquet", iceberg.ParquetFile, nil, nil, nil, 3, 10)
+		require.NoError(t, err)
+		task := FileScanTask{File: bldr.FirstRowID(100).Build()}
+
+		positions, _, err := array.FromJSON(memory.DefaultAllocator, arrow.PrimitiveTypes.Int64,
+			strings.NewReader(`[0, 1, 2]`))
+		require.NoError(t, err)
+		defer positions.Release()
+		stored, _, err := array.FromJSON(memory.DefaultAllocator, arrow.PrimitiveTypes.Int64,
+			strings.NewReader(`[7, null, 9]`))
+		require.NoError(t, err)
+		defer stored.Release()
+
+		arr, err := rowLineageArray(memory.DefaultAllocator, RowIDColumn, 3, task,
+			positions.(*array.Int64), stored)
+		require.NoError(t, err)
+		defer arr.Release()
+		assert.Equal(t, []int64{7, 101, 9}, arr.(*array.Int64).Int64Values())
+	})
+}
@@ -0,0 +1,218 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/apache/iceberg-go"
+)
+
+// DiffKind identifies the kind of change reported by DiffMetadata.
+type DiffKind string
+
+const (
+	DiffFormatVersionChanged    DiffKind = "format-version-changed"
+	DiffLocationChanged         DiffKind = "location-changed"
+	DiffSchemaAdded             DiffKind = "schema-added"
+	DiffSchemaRemoved           DiffKind = "schema-removed"
+	DiffCurrentSchemaChanged    DiffKind = "current-schema-changed"
+	DiffSpecAdded               DiffKind = "spec-added"
+	DiffSpecRemoved             DiffKind = "spec-removed"
+	DiffDefaultSpecChanged      DiffKind = "default-spec-changed"
+	DiffSortOrderAdded          DiffKind = "sort-order-added"
+	DiffSortOrderRemoved        DiffKind = "sort-order-removed"
+	DiffDefaultSortOrderChanged DiffKind = "default-sort-order-changed"
+	DiffPropertyAdded           DiffKind = "property-added"
+	DiffPropertyChanged         DiffKind = "property-changed"
+	DiffPropertyRemoved         DiffKind = "property-removed"
+	DiffSnapshotAdded           DiffKind = "snapshot-added"
+	DiffSnapshotExpired         DiffKind = "snapshot-expired"
+	DiffRefAdded                DiffKind = "ref-added"
+	DiffRefChanged              DiffKind = "ref-changed"
+	DiffRefRemoved              DiffKind = "ref-removed"
+)
+
+// MetadataChange is a single difference between two versions of a table's
+// metadata.
+type MetadataChange struct {
+	Kind DiffKind `json:"kind"`
+	// Key identifies what changed: the property or ref name, or the ID of
+	// the schema, partition spec, sort order or snapshot.
+	Key string `json:"key,omitempty"`
+	// Old and New describe the value before and after the change, and
+	// are empty for values that were added or removed respectively.
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+}
+
+func (c MetadataChange) String() string {
+	var s string
+	switch {
+	case c.Old != "" && c.New != "":
+		s = c.Old + " -> " + c.New
+	case c.New != "":
+		s = c.New
+	default:
+		s = c.Old
+	}
+
+	if c.Key == "" {
+		return fmt.Sprintf("%s: %s", c.Kind, s)
+	}
+
+	if s == "" {
+		return fmt.Sprintf("%s %s", c.Kind, c.Key)
+	}
+
+	return fmt.Sprintf("%s %s: %s", c.Kind, c.Key, s)
+}
+
+// DiffMetadata compares two versions of a table's metadata and returns the
+// changes needed to go from oldMeta to newMeta, such as added schemas,
+// changed properties, or added and expired snapshots. A nil oldMeta is
+// treated as an empty table, reporting everything in newMeta as added.
+//
+// Changes are returned grouped by kind in a deterministic order, with
+// properties and refs sorted by name.
+func DiffMetadata(oldMeta, newMeta Metadata) []MetadataChange {
+	var changes []MetadataChange
+	add := func(kind DiffKind, key, oldVal, newVal string) {
+		changes = append(changes, MetadataChange{Kind: kind, Key: key, Old: oldVal, New: newVal})
+	}
+
+	if oldMeta != nil {
+		if oldMeta.Version() != newMeta.Version() {
+			add(DiffFormatVersionChanged, "", strconv.Itoa(oldMeta.Version()), strconv.Itoa(newMeta.Version()))
+		}
+		if oldMeta.Location() != newMeta.Location() {
+			add(DiffLocationChanged, "", oldMeta.Location(), newMeta.Location())
+		}
+	}
+
+	var (
+		oldSchemas []*iceberg.Schema
+		oldSpecs   []iceberg.PartitionSpec
+		oldOrders  []SortOrder
+		oldSnaps   []Snapshot
+		oldProps   iceberg.Properties
+		oldRefs    = map[string]SnapshotRef{}
+	)
+	if oldMeta != nil {
+		oldSchemas, oldSpecs, oldOrders = oldMeta.Schemas(), oldMeta.PartitionSpecs(), oldMeta.SortOrders()
+		oldSnaps, oldProps, oldRefs = oldMeta.Snapshots(), oldMeta.Properties(), maps.Collect(oldMeta.Refs())
+	}
+
+	changes = append(changes, diffByID(oldSchemas, newMeta.Schemas(), DiffSchemaAdded, DiffSchemaRemoved,
+		func(sc *iceberg.Schema) int64 { return int64(sc.ID) }, (*iceberg.Schema).String)...)
+	if oldMeta != nil && oldMeta.CurrentSchema().ID != newMeta.CurrentSchema().ID {
+		add(DiffCurrentSchemaChanged, "", strconv.Itoa(oldMeta.CurrentSchema().ID), strconv.Itoa(newMeta.CurrentSchema().ID))
+	}
+
+	changes = append(changes, diffByID(oldSpecs, newMeta.PartitionSpecs(), DiffSpecAdded, DiffSpecRemoved,
+		func(spec iceberg.PartitionSpec) int64 { return int64(spec.ID()) }, iceberg.PartitionSpec.String)...)
+	if oldMeta != nil && oldMeta.DefaultPartitionSpec() != newMeta.DefaultPartitionSpec() {
+		add(DiffDefaultSpecChanged, "", strconv.Itoa(oldMeta.DefaultPartitionSpec()), strconv.Itoa(newMeta.DefaultPartitionSpec()))
+	}
+
+	changes = append(changes, diffByID(oldOrders, newMeta.SortOrders(), DiffSortOrderAdded, DiffSortOrderRemoved,
+		func(so SortOrder) int64 { return int64(so.OrderID()) }, SortOrder.String)...)
+	if oldMeta != nil && oldMeta.DefaultSortOrder() != newMeta.DefaultSortOrder() {
+		add(DiffDefaultSortOrderChanged, "", strconv.Itoa(oldMeta.DefaultSortOrder()), strconv.Itoa(newMeta.DefaultSortOrder()))
+	}
+
+	newProps := newMeta.Properties()
+	for _, k := range slices.Sorted(maps.Keys(newProps)) {
+		oldVal, ok := oldProps[k]
+		switch {
+		case !ok:
+			add(DiffPropertyAdded, k, "", newProps[k])
+		case oldVal != newProps[k]:
+			add(DiffPropertyChanged, k, oldVal, newProps[k])
+		}
+	}
+	for _, k := range slices.Sorted(maps.Keys(oldProps)) {
+		if _, ok := newProps[k]; !ok {
+			add(DiffPropertyRemoved, k, oldProps[k], "")
+		}
+	}
+
+	changes = append(changes, diffByID(oldSnaps, newMeta.Snapshots(), DiffSnapshotAdded, DiffSnapshotExpired,
+		func(s Snapshot) int64 { return s.SnapshotID }, snapshotOperation)...)
+
+	newRefs := maps.Collect(newMeta.Refs())
+	for _, name := range slices.Sorted(maps.Keys(newRefs)) {
+		ref := newRefs[name]
+		oldRef, ok := oldRefs[name]
+		switch {
+		case !ok:
+			add(DiffRefAdded, name, "", refDescription(ref))
+		case !oldRef.Equals(ref):
+			add(DiffRefChanged, name, refDescription(oldRef), refDescription(ref))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(oldRefs)) {
+		if _, ok := newRefs[name]; !ok {
+			add(DiffRefRemoved, name, refDescription(oldRefs[name]), "")
+		}
+	}
+
+	return changes
+}
+
+// diffByID reports the items of newItems whose ID is not in oldItems as
+// added, and the items of oldItems whose ID is not in newItems as removed.
+func diffByID[T any](oldItems, newItems []T, added, removed DiffKind, id func(T) int64, desc func(T) string) []MetadataChange {
+	var changes []MetadataChange
+
+	oldIDs := make(map[int64]bool, len(oldItems))
+	for _, item := range oldItems {
+		oldIDs[id(item)] = true
+	}
+
+	newIDs := make(map[int64]bool, len(newItems))
+	for _, item := range newItems {
+		newIDs[id(item)] = true
+		if !oldIDs[id(item)] {
+			changes = append(changes, MetadataChange{Kind: added, Key: strconv.FormatInt(id(item), 10), New: desc(item)})
+		}
+	}
+
+	for _, item := range oldItems {
+		if !newIDs[id(item)] {
+			changes = append(changes, MetadataChange{Kind: removed, Key: strconv.FormatInt(id(item), 10), Old: desc(item)})
+		}
+	}
+
+	return changes
+}
+
+func snapshotOperation(s Snapshot) string {
+	if s.Summary == nil {
+		return ""
+	}
+
+	return string(s.Summary.Operation)
+}
+
+func refDescription(ref SnapshotRef) string {
+	return fmt.Sprintf("%s %d", ref.SnapshotRefType, ref.SnapshotID)
+}
@@ -0,0 +1,96 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffMetadata(t *testing.T) {
+	base, err := ParseMetadataString(ExampleTableMetadataV2)
+	require.NoError(t, err)
+
+	assert.Empty(t, DiffMetadata(base, base))
+
+	b, err := MetadataBuilderFromBase(base, "")
+	require.NoError(t, err)
+	require.NoError(t, b.SetProperties(iceberg.Properties{"owner": "etl", "read.split.target.size": "1024"}))
+	require.NoError(t, b.RemoveSnapshotRef("test"))
+	require.NoError(t, b.RemoveSnapshots([]int64{3051729675574597004}))
+	require.NoError(t, b.AddSchema(iceberg.NewSchema(2,
+		iceberg.NestedField{ID: 1, Name: "x", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "y", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 3, Name: "z", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 4, Name: "w", Type: iceberg.PrimitiveTypes.String})))
+	require.NoError(t, b.SetCurrentSchemaID(2))
+	updated, err := b.Build()
+	require.NoError(t, err)
+
+	changes := DiffMetadata(base, updated)
+	kinds := make([]DiffKind, len(changes))
+	for i, c := range changes {
+		kinds[i] = c.Kind
+	}
+	assert.Equal(t, []DiffKind{
+		DiffSchemaAdded, DiffCurrentSchemaChanged, DiffPropertyAdded,
+		DiffPropertyChanged, DiffSnapshotExpired, DiffRefRemoved,
+	}, kinds)
+
+	assert.Equal(t, MetadataChange{Kind: DiffCurrentSchemaChanged, Old: "1", New: "2"}, changes[1])
+	assert.Equal(t, MetadataChange{Kind: DiffPropertyAdded, Key: "owner", New: "etl"}, changes[2])
+	assert.Equal(t, MetadataChange{Kind: DiffPropertyChanged, Key: "read.split.target.size",
+		Old: "134217728", New: "1024"}, changes[3])
+	assert.Equal(t, MetadataChange{Kind: DiffSnapshotExpired, Key: "3051729675574597004", Old: "append"}, changes[4])
+	assert.Equal(t, MetadataChange{Kind: DiffRefRemoved, Key: "test", Old: "tag 3051729675574597004"}, changes[5])
+
+	assert.Equal(t, "current-schema-changed: 1 -> 2", changes[1].String())
+	assert.Equal(t, "property-changed read.split.target.size: 134217728 -> 1024", changes[3].String())
+	assert.Equal(t, "ref-removed test: tag 3051729675574597004", changes[5].String())
+
+	// the reverse diff reports everything the other way around
+	reverse := DiffMetadata(updated, base)
+	assert.Contains(t, reverse, MetadataChange{Kind: DiffPropertyRemoved, Key: "owner", Old: "etl"})
+	assert.Contains(t, reverse, MetadataChange{Kind: DiffSnapshotAdded, Key: "3051729675574597004", New: "append"})
+	assert.Contains(t, reverse, MetadataChange{Kind: DiffRefAdded, Key: "test", New: "tag 3051729675574597004"})
+
+	t.Run("from nothing", func(t *testing.T) {
+		changes := DiffMetadata(nil, base)
+		counts := map[DiffKind]int{}
+		for _, c := range changes {
+			counts[c.Kind]++
+			assert.Empty(t, c.Old)
+		}
+
+		assert.Equal(t, map[DiffKind]int{
+			DiffSchemaAdded: 2, DiffSpecAdded: 1, DiffSortOrderAdded: 1,
+			DiffPropertyAdded: 1, DiffSnapshotAdded: 2, DiffRefAdded: 2,
+		}, counts)
+	})
+
+	t.Run("format version", func(t *testing.T) {
+		v3, err := ParseMetadataString(ExampleTableMetadataV3)
+		require.NoError(t, err)
+
+		assert.Contains(t, DiffMetadata(base, v3),
+			MetadataChange{Kind: DiffFormatVersionChanged, Old: "2", New: "3"})
+	})
+}
@@ -26,6 +26,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -162,6 +163,8 @@ type OrphanCleanupResult struct {
 }
 
 func (t Table) DeleteOrphanFiles(ctx context.Context, opts ...OrphanCleanupOption) (OrphanCleanupResult, error) {
+	t = t.pin()
+
 	cfg := &orphanCleanupConfig{
 		location:           "",             // empty means use table's data location
 		olderThan:          72 * time.Hour, // 3 days ago
@@ -182,24 +185,37 @@ func (t Table) DeleteOrphanFiles(ctx context.Context, opts ...OrphanCleanupOptio
 }
 
 func (t Table) executeOrphanCleanup(ctx context.Context, cfg *orphanCleanupConfig) (OrphanCleanupResult, error) {
-	fs, err := t.fsF(ctx)
+	fs, err := t.FS(ctx)
 	if err != nil {
 		return OrphanCleanupResult{}, fmt.Errorf("failed to get filesystem: %w", err)
 	}
 
-	scanLocation := cfg.location
-	if scanLocation == "" {
-		scanLocation = t.metadata.Location()
+	scanLocations := []string{cfg.location}
+	if cfg.location == "" {
+		scanLocations = t.cleanupLocations()
 	}
 
-	referencedFiles, err := t.getReferencedFiles(fs)
+	referencedFiles, err := t.getReferencedFiles(ctx, fs)
 	if err != nil {
 		return OrphanCleanupResult{}, fmt.Errorf("failed to get referenced files: %w", err)
 	}
 
-	allFiles, totalSize, err := t.scanFiles(fs, scanLocation, cfg)
-	if err != nil {
-		return OrphanCleanupResult{}, fmt.Errorf("failed to scan files: %w", err)
+	var (
+		allFiles  []string
+		totalSize int64
+	)
+	for i, loc := range scanLocations {
+		files, size, err := t.scanFiles(fs, loc, cfg)
+		if err != nil {
+			// data and metadata path overrides may not have been written to yet
+			if i > 0 && errors.Is(err, stdfs.ErrNotExist) {
+				continue
+			}
+
+			return OrphanCleanupResult{}, fmt.Errorf("failed to scan files: %w", err)
+		}
+		allFiles = append(allFiles, files...)
+		totalSize += size
 	}
 
 	orphanFiles, err := identifyOrphanFiles(allFiles, referencedFiles, cfg)
@@ -225,48 +241,45 @@ func (t Table) executeOrphanCleanup(ctx context.Context, cfg *orphanCleanupConfi
 	return result, nil
 }
 
-// getReferencedFiles collects all files referenced by current snapshots
-func (t Table) getReferencedFiles(fs iceio.IO) (map[string]bool, error) {
+// getReferencedFiles collects all files reachable from the table metadata
+func (t Table) getReferencedFiles(ctx context.Context, fs iceio.IO) (map[string]bool, error) {
 	referenced := make(map[string]bool)
-	metadata := t.metadata
-
-	for entry := range metadata.PreviousFiles() {
-		referenced[entry.MetadataFile] = true
-	}
-	referenced[t.metadataLocation] = true
 
 	// Add version hint file (for Hadoop-style tables)
 	// Following Java's ReachableFileUtil.versionHintLocation() logic:
-	versionHintPath := filepath.Join(metadata.Location(), "metadata", "version-hint.text")
+	metadataDir := filepath.Join(t.Metadata().Location(), "metadata")
+	if p, ok := t.Metadata().Properties()[WriteMetadataPathKey]; ok {
+		metadataDir = p
+	}
+	versionHintPath := filepath.Join(metadataDir, versionHintFile)
 	referenced[versionHintPath] = true
 
-	// TODO: Add statistics files support once iceberg-go exposes statisticsFiles()
-
-	for _, snapshot := range metadata.Snapshots() {
-		if snapshot.ManifestList != "" {
-			referenced[snapshot.ManifestList] = true
-		}
-
-		manifestFiles, err := snapshot.Manifests(fs)
+	for f, err := range ReachableFiles(ctx, t.Metadata(), t.MetadataLocation(), fs) {
 		if err != nil {
-			return nil, fmt.Errorf("failed to read manifests for snapshot %d: %w", snapshot.SnapshotID, err)
+			return nil, err
 		}
+		referenced[f.Path] = true
+	}
 
-		for _, manifest := range manifestFiles {
-			referenced[manifest.FilePath()] = true
+	return referenced, nil
+}
 
-			entries, err := manifest.FetchEntries(fs, false)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read manifest entries: %w", err)
-			}
+// cleanupLocations returns the locations to scan for orphan files: the table
+// location along with the write.data.path and write.metadata.path overrides
+// which are outside of it.
+func (t Table) cleanupLocations() []string {
+	props := t.Metadata().Properties()
+	dataPath, _ := dataPathProperty(props)
 
-			for _, entry := range entries {
-				referenced[entry.DataFile().FilePath()] = true
-			}
+	locs := []string{t.Metadata().Location()}
+	for _, p := range []string{dataPath, props[WriteMetadataPathKey]} {
+		if p == "" || slices.ContainsFunc(locs, func(l string) bool { return isWithinLocation(p, l) }) {
+			continue
 		}
+		locs = append(locs, p)
 	}
 
-	return referenced, nil
+	return locs
 }
 
 func (t Table) scanFiles(fs iceio.IO, location string, cfg *orphanCleanupConfig) ([]string, int64, error) {
@@ -321,6 +334,14 @@ func makeFileWalkFunc(fn func(path string, info stdfs.FileInfo) error, pathTrans
 }
 
 func walkDirectory(fsys iceio.IO, root string, fn func(path string, info stdfs.FileInfo) error) error {
+	if r, ok := fsys.(*iceio.ResolvingIO); ok {
+		resolved, err := r.Resolve(root)
+		if err != nil {
+			return err
+		}
+		fsys = resolved
+	}
+
 	switch v := fsys.(type) {
 	case iceio.LocalFS:
 		cleanRoot := strings.TrimPrefix(root, "file://")
@@ -21,6 +21,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/apache/iceberg-go"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -468,3 +470,39 @@ func TestOrphanCleanup_EdgeCases(t *testing.T) {
 		assert.Equal(t, "host", result)
 	})
 }
+
+func TestCleanupLocations(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true})
+
+	tests := []struct {
+		name     string
+		props    iceberg.Properties
+		expected []string
+	}{
+		{"defaults", nil, []string{"s3://bucket/tbl"}},
+		{"nested overrides", iceberg.Properties{
+			WriteDataPathKey:     "s3://bucket/tbl/files",
+			WriteMetadataPathKey: "s3://bucket/tbl/meta",
+		}, []string{"s3://bucket/tbl"}},
+		{"external overrides", iceberg.Properties{
+			WriteDataPathKey:     "s3://data-bucket/tbl",
+			WriteMetadataPathKey: "s3://meta-bucket/tbl",
+		}, []string{"s3://bucket/tbl", "s3://data-bucket/tbl", "s3://meta-bucket/tbl"}},
+		{"shared override", iceberg.Properties{
+			WriteDataPathKey:     "s3://other/tbl",
+			WriteMetadataPathKey: "s3://other/tbl/metadata",
+		}, []string{"s3://bucket/tbl", "s3://other/tbl"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, err := NewMetadata(sc, iceberg.UnpartitionedSpec, UnsortedSortOrder,
+				"s3://bucket/tbl", tt.props)
+			require.NoError(t, err)
+
+			tbl := New(Identifier{"db", "tbl"}, meta, "", nil, nil)
+			assert.Equal(t, tt.expected, tbl.cleanupLocations())
+		})
+	}
+}
@@ -19,9 +19,12 @@ package table
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"iter"
+	"math"
+	"strconv"
 
 	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/arrow-go/v18/arrow/array"
@@ -40,14 +43,18 @@ type partitionedFanoutWriter struct {
 	schema        *iceberg.Schema
 	itr           iter.Seq2[arrow.RecordBatch, error]
 	writers       *writerFactory
+	// writerPerWorker gives each worker its own writers rather than
+	// sharing one writer per partition between workers, see
+	// DistributionNone.
+	writerPerWorker bool
 }
 
 // PartitionInfo holds the row indices and partition values for a specific partition,
 // used during the fanout process to group rows by their partition key.
 type partitionInfo struct {
-	rows            []int64
-	partitionValues map[int]any
-	partitionRec    partitionRecord // The actual partition values for generating the path
+	rows         []int64
+	partition    iceberg.PartitionData
+	partitionRec partitionRecord // The actual partition values for generating the path
 }
 
 // NewPartitionedFanoutWriter creates a new PartitionedFanoutWriter with the specified
@@ -74,9 +81,9 @@ func (p *partitionedFanoutWriter) Write(ctx context.Context, workers int) iter.S
 	fanoutWorkers, ctx := errgroup.WithContext(ctx)
 	p.startRecordFeeder(ctx, fanoutWorkers, inputRecordsCh)
 
-	for range workers {
+	for worker := range workers {
 		fanoutWorkers.Go(func() error {
-			return p.fanout(ctx, inputRecordsCh, outputDataFilesCh)
+			return p.fanout(ctx, worker, inputRecordsCh, outputDataFilesCh)
 		})
 	}
 
@@ -106,7 +113,7 @@ func (p *partitionedFanoutWriter) startRecordFeeder(ctx context.Context, fanoutW
 	})
 }
 
-func (p *partitionedFanoutWriter) fanout(ctx context.Context, inputRecordsCh <-chan arrow.RecordBatch, dataFilesChannel chan<- iceberg.DataFile) error {
+func (p *partitionedFanoutWriter) fanout(ctx context.Context, worker int, inputRecordsCh <-chan arrow.RecordBatch, dataFilesChannel chan<- iceberg.DataFile) error {
 	for {
 		select {
 		case <-ctx.Done():
@@ -136,7 +143,12 @@ func (p *partitionedFanoutWriter) fanout(ctx context.Context, inputRecordsCh <-c
 				}
 
 				partitionPath := p.partitionPath(val.partitionRec)
-				rollingDataWriter, err := p.writers.getOrCreateRollingDataWriter(ctx, partitionPath, val.partitionValues, dataFilesChannel)
+				writerKey := partitionPath
+				if p.writerPerWorker {
+					writerKey = strconv.Itoa(worker) + "/" + partitionPath
+				}
+
+				rollingDataWriter, err := p.writers.getOrCreateRollingDataWriter(ctx, writerKey, partitionPath, val.partition, dataFilesChannel)
 				if err != nil {
 					return err
 				}
@@ -183,136 +195,196 @@ func (p *partitionedFanoutWriter) yieldDataFiles(fanoutWorkers *errgroup.Group,
 }
 
 func (p *partitionedFanoutWriter) getPartitions(record arrow.RecordBatch) ([]*partitionInfo, error) {
-	partitionMap := newPartitionMapNode()
 	partitionFields := p.partitionSpec.PartitionType(p.schema).FieldList
-	partitionRec := make(partitionRecord, len(partitionFields))
-
-	partitionColumns := make([]arrow.Array, len(partitionFields))
-	partitionFieldsInfo := make([]struct {
-		sourceField *iceberg.PartitionField
-		fieldID     int
-	}, len(partitionFields))
 
+	columns := make([]*partitionColumn, len(partitionFields))
 	for i := range partitionFields {
 		sourceField := p.partitionSpec.Field(i)
 		colName, _ := p.schema.FindColumnName(sourceField.SourceID)
 		colIdx := record.Schema().FieldIndices(colName)[0]
-		partitionColumns[i] = record.Column(colIdx)
-		partitionFieldsInfo[i] = struct {
-			sourceField *iceberg.PartitionField
-			fieldID     int
-		}{&sourceField, sourceField.FieldID}
+
+		col, err := newPartitionColumn(record.Column(colIdx), sourceField.Transform)
+		if err != nil {
+			return nil, err
+		}
+		columns[i] = col
 	}
 
+	var (
+		partitions []*partitionInfo
+		byKey      = make(map[string]*partitionInfo)
+		key        = make([]byte, 4*len(columns))
+	)
 	for row := range record.NumRows() {
-		for i := range partitionFields {
-			col := partitionColumns[i]
-			if !col.IsNull(int(row)) {
-				sourceField := partitionFieldsInfo[i].sourceField
-				val, err := getArrowValueAsIcebergLiteral(col, int(row))
-				if err != nil {
-					return nil, fmt.Errorf("failed to get arrow values as iceberg literal: %w", err)
-				}
+		for i, col := range columns {
+			binary.LittleEndian.PutUint32(key[4*i:], uint32(col.codes[row]))
+		}
 
-				transformedLiteral := sourceField.Transform.Apply(iceberg.Optional[iceberg.Literal]{Valid: true, Val: val})
-				if transformedLiteral.Valid {
-					partitionRec[i] = transformedLiteral.Val.Any()
-				} else {
-					partitionRec[i] = nil
-				}
-			} else {
-				partitionRec[i] = nil
+		partVal, ok := byKey[string(key)]
+		if !ok {
+			var err error
+			if partVal, err = p.newPartitionInfo(partitionFields, columns, int(row)); err != nil {
+				return nil, err
 			}
+			byKey[string(key)] = partVal
+			partitions = append(partitions, partVal)
 		}
-
-		// Get or create partition info for this partition key
-		partVal := partitionMap.getOrCreate(partitionRec, partitionFieldsInfo)
 		partVal.rows = append(partVal.rows, row)
 	}
 
-	return partitionMap.collectPartitions(), nil
+	return partitions, nil
 }
 
-// partitionMapNode represents a simple tree structure for storing partitionInfo.
-//
-// Each key is the partition value at that level of the tree, and the key hierarchy
-// is in the order of the partition spec.
-// The value is either a *partitionMapNode or a *partitionInfo.
-type partitionMapNode struct {
-	children  map[any]any
-	leafCount int
-}
+// newPartitionInfo creates the partitionInfo for the partition of the
+// given row.
+func (p *partitionedFanoutWriter) newPartitionInfo(partitionFields []iceberg.NestedField, columns []*partitionColumn, row int) (*partitionInfo, error) {
+	partitionValues := make(map[int]any, len(columns))
+	partitionRec := make(partitionRecord, len(columns))
+	for i, col := range columns {
+		val := col.values[col.codes[row]]
+		partitionValues[partitionFields[i].ID] = val
+		partitionRec[i] = val
+	}
 
-func newPartitionMapNode() *partitionMapNode {
-	return &partitionMapNode{
-		children:  make(map[any]any),
-		leafCount: 0,
+	partition, err := iceberg.NewPartitionData(p.partitionSpec, p.schema, partitionValues)
+	if err != nil {
+		return nil, err
 	}
+
+	return &partitionInfo{
+		rows:         make([]int64, 0, 128), // modest starting capacity
+		partition:    partition,
+		partitionRec: partitionRec,
+	}, nil
 }
 
-// getOrCreate navigates the tree and returns the partitionInfo for the given partition key,
-// creating nodes along the way if they don't exist
-func (n *partitionMapNode) getOrCreate(partitionRec partitionRecord, fieldInfo []struct {
-	sourceField *iceberg.PartitionField
-	fieldID     int
-},
-) *partitionInfo {
-	// Navigate through all but the last partition field
-	node := n
-	for _, part := range partitionRec[:len(partitionRec)-1] {
-		val, ok := node.children[part]
-		if !ok {
-			newNode := newPartitionMapNode()
-			node.children[part] = newNode
-			node = newNode
-		} else {
-			node = val.(*partitionMapNode)
+// partitionColumn holds the values of one partition field for the rows
+// of a record: each row is assigned the code of its transformed value,
+// and values holds the distinct transformed values by code. The
+// transform is applied once per distinct source value rather than once
+// per row.
+type partitionColumn struct {
+	codes  []int32
+	values []any
+}
+
+func newPartitionColumn(col arrow.Array, transform iceberg.Transform) (*partitionColumn, error) {
+	pc := &partitionColumn{codes: make([]int32, col.Len())}
+	valueCodes := make(map[any]int32)
+
+	// transformed returns the code of the transformed value of a row
+	transformed := func(row int) (int32, error) {
+		var val any
+		if !col.IsNull(row) {
+			lit, err := getArrowValueAsIcebergLiteral(col, row)
+			if err != nil {
+				return 0, fmt.Errorf("failed to get arrow values as iceberg literal: %w", err)
+			}
+
+			if out := transform.Apply(iceberg.Optional[iceberg.Literal]{Valid: true, Val: lit}); out.Valid {
+				val = out.Val.Any()
+			}
 		}
-	}
 
-	// Last level stores the actual partitionInfo
-	lastKey := partitionRec[len(partitionRec)-1]
-	partVal, ok := node.children[lastKey].(*partitionInfo)
-	if ok {
-		return partVal
-	}
+		key := val
+		if b, ok := val.([]byte); ok {
+			key = string(b)
+		}
 
-	// First time seeing this partition - create partitionValues map
-	partitionValues := make(map[int]any, len(partitionRec))
+		code, ok := valueCodes[key]
+		if !ok {
+			code = int32(len(pc.values))
+			valueCodes[key] = code
+			pc.values = append(pc.values, val)
+		}
 
-	// Copy partitionRec values so they don't get overwritten
-	partRecCopy := make(partitionRecord, len(partitionRec))
-	for i := range partitionRec {
-		partitionValues[fieldInfo[i].fieldID] = partitionRec[i]
-		partRecCopy[i] = partitionRec[i]
+		return code, nil
 	}
 
-	partVal = &partitionInfo{
-		rows:            make([]int64, 0, 128), // modest starting capacity
-		partitionValues: partitionValues,
-		partitionRec:    partRecCopy,
+	var err error
+	switch arr := col.(type) {
+	case *array.String:
+		err = memoizeCodes(pc.codes, col, arr.Value, transformed)
+	case *array.Int64:
+		err = memoizeCodes(pc.codes, col, arr.Value, transformed)
+	case *array.Int32:
+		err = memoizeCodes(pc.codes, col, arr.Value, transformed)
+	case *array.Int16:
+		err = memoizeCodes(pc.codes, col, arr.Value, transformed)
+	case *array.Int8:
+		err = memoizeCodes(pc.codes, col, arr.Value, transformed)
+	case *array.Uint64:
+		err = memoizeCodes(pc.codes, col, arr.Value, transformed)
+	case *array.Uint32:
+		err = memoizeCodes(pc.codes, col, arr.Value, transformed)
+	case *array.Uint16:
+		err = memoizeCodes(pc.codes, col, arr.Value, transformed)
+	case *array.Uint8:
+		err = memoizeCodes(pc.codes, col, arr.Value, transformed)
+	case *array.Boolean:
+		err = memoizeCodes(pc.codes, col, arr.Value, transformed)
+	case *array.Date32:
+		err = memoizeCodes(pc.codes, col, arr.Value, transformed)
+	case *array.Time64:
+		err = memoizeCodes(pc.codes, col, arr.Value, transformed)
+	case *array.Timestamp:
+		err = memoizeCodes(pc.codes, col, arr.Value, transformed)
+	case *array.Decimal128:
+		err = memoizeCodes(pc.codes, col, arr.Value, transformed)
+	case *array.Float32:
+		err = memoizeCodes(pc.codes, col, func(i int) uint32 {
+			return math.Float32bits(arr.Value(i))
+		}, transformed)
+	case *array.Float64:
+		err = memoizeCodes(pc.codes, col, func(i int) uint64 {
+			return math.Float64bits(arr.Value(i))
+		}, transformed)
+	default:
+		for row := range pc.codes {
+			if pc.codes[row], err = transformed(row); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
 	}
-	node.children[lastKey] = partVal
-	node.leafCount++
 
-	return partVal
+	return pc, nil
 }
 
-// collectPartitions recursively collects all partitionInfo into a slice
-func (n *partitionMapNode) collectPartitions() []*partitionInfo {
-	result := make([]*partitionInfo, 0, n.leafCount)
-
-	for _, v := range n.children {
-		switch node := v.(type) {
-		case *partitionInfo:
-			result = append(result, node)
-		case *partitionMapNode:
-			// Recursively collect from child nodes
-			result = append(result, node.collectPartitions()...)
+// memoizeCodes fills codes with the code of each row of col, calling
+// code only for the first row holding each distinct source value.
+func memoizeCodes[K comparable](codes []int32, col arrow.Array, value func(int) K, code func(int) (int32, error)) error {
+	var (
+		seen     = make(map[K]int32)
+		nullCode = int32(-1)
+		err      error
+	)
+	for row := range codes {
+		if col.IsNull(row) {
+			if nullCode < 0 {
+				if nullCode, err = code(row); err != nil {
+					return err
+				}
+			}
+			codes[row] = nullCode
+
+			continue
+		}
+
+		v := value(row)
+		c, ok := seen[v]
+		if !ok {
+			if c, err = code(row); err != nil {
+				return err
+			}
+			seen[v] = c
 		}
+		codes[row] = c
 	}
 
-	return result
+	return nil
 }
 
 type partitionBatchFn func(arrow.RecordBatch, []int64) (arrow.RecordBatch, error)
@@ -20,8 +20,10 @@ package table
 import (
 	"context"
 	"fmt"
+	"math"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"strconv"
 	"testing"
 	"time"
@@ -181,6 +183,123 @@ func (s *FanoutWriterTestSuite) TestIdentityTransform() {
 	s.testTransformPartition(iceberg.IdentityTransform{}, "name", "identity", testRecord, 3)
 }
 
+func (s *FanoutWriterTestSuite) TestGetPartitionsGroupsRows() {
+	arrSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "payload", Type: arrow.BinaryTypes.Binary, Nullable: true},
+	}, nil)
+
+	nan := math.NaN()
+	testRecord := s.createCustomTestRecord(arrSchema, [][]any{
+		{int32(1), "a", 1.5, []byte("x")},
+		{int32(2), "b", nan, []byte("y")},
+		{int32(3), "a", 1.5, []byte("x")},
+		{int32(4), nil, nan, []byte("y")},
+		{int32(5), "b", nan, []byte("y")},
+		{int32(6), nil, nil, nil},
+		{int32(7), nil, nan, []byte("y")},
+	})
+	defer testRecord.Release()
+
+	icebergSchema, err := ArrowSchemaToIcebergWithFreshIDs(arrSchema, false)
+	s.Require().NoError(err)
+
+	var fields []iceberg.PartitionField
+	for i, name := range []string{"name", "score", "payload"} {
+		f, ok := icebergSchema.FindFieldByName(name)
+		s.Require().True(ok)
+		fields = append(fields, iceberg.PartitionField{
+			SourceID: f.ID, FieldID: 1000 + i, Transform: iceberg.IdentityTransform{}, Name: name,
+		})
+	}
+	fields = append(fields, iceberg.PartitionField{
+		SourceID: 1, FieldID: 1003, Transform: iceberg.BucketTransform{NumBuckets: 1}, Name: "id_bucket",
+	})
+	spec := iceberg.NewPartitionSpec(fields...)
+
+	partitions, err := newPartitionedFanoutWriter(spec, icebergSchema, nil).getPartitions(testRecord)
+	s.Require().NoError(err)
+
+	// partitions are returned in the order they are first seen
+	s.Require().Len(partitions, 4)
+	s.Equal([]int64{0, 2}, partitions[0].rows)
+	s.Equal([]int64{1, 4}, partitions[1].rows)
+	s.Equal([]int64{3, 6}, partitions[2].rows)
+	s.Equal([]int64{5}, partitions[3].rows)
+
+	s.Equal(partitionRecord{"a", 1.5, []byte("x"), int32(0)}, partitions[0].partitionRec)
+	s.Equal(map[int]any{1000: "a", 1001: 1.5, 1002: []byte("x"), 1003: int32(0)},
+		partitions[0].partition.Map())
+	s.Nil(partitions[2].partitionRec[0])
+	s.True(math.IsNaN(partitions[2].partitionRec[1].(float64)))
+	s.Equal(partitionRecord{nil, nil, nil, int32(0)}, partitions[3].partitionRec)
+}
+
+func (s *FanoutWriterTestSuite) TestObjectStorePartitionedLayout() {
+	arrSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+
+	testRecord := s.createCustomTestRecord(arrSchema, [][]any{
+		{int32(1), "a/b"},
+		{int32(2), "c"},
+	})
+	defer testRecord.Release()
+
+	icebergSchema, err := ArrowSchemaToIcebergWithFreshIDs(arrSchema, false)
+	s.Require().NoError(err)
+
+	spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+		SourceID: 2, FieldID: 1000, Transform: iceberg.IdentityTransform{}, Name: "name",
+	})
+
+	loc := filepath.ToSlash(s.T().TempDir())
+	meta, err := NewMetadata(icebergSchema, &spec, UnsortedSortOrder, loc,
+		iceberg.Properties{ObjectStoreEnabledKey: "true"})
+	s.Require().NoError(err)
+
+	metaBuilder, err := MetadataBuilderFromBase(meta, "")
+	s.Require().NoError(err)
+
+	writeUUID := uuid.New()
+	args := recordWritingArgs{
+		sc: arrSchema,
+		itr: func(yield func(arrow.RecordBatch, error) bool) {
+			testRecord.Retain()
+			yield(testRecord, nil)
+		},
+		fs:        iceio.LocalFS{},
+		writeUUID: &writeUUID,
+		counter: func(yield func(int) bool) {
+			for i := 0; ; i++ {
+				if !yield(i) {
+					break
+				}
+			}
+		},
+	}
+
+	partitionWriter := newPartitionedFanoutWriter(spec, icebergSchema, args.itr)
+	rollingDataWriters := NewWriterFactory(loc, args, metaBuilder, icebergSchema, 1024*1024)
+	partitionWriter.writers = &rollingDataWriters
+
+	layout := regexp.MustCompile(`^` + regexp.QuoteMeta(loc) +
+		`/data/[01]{4}/[01]{4}/[01]{4}/[01]{8}/name=(a%2Fb|c)/[^/]+\.parquet$`)
+	fileCount := 0
+	for dataFile, err := range partitionWriter.Write(s.ctx, 1) {
+		s.Require().NoError(err)
+		s.Regexp(layout, dataFile.FilePath())
+		fileCount++
+	}
+	s.Equal(2, fileCount)
+
+	// writing must not leak per-partition locations into the table properties
+	s.NotContains(metaBuilder.props, WriteDataPathKey)
+}
+
 func (s *FanoutWriterTestSuite) TestBucketTransform() {
 	arrSchema := arrow.NewSchema([]arrow.Field{
 		{Name: "id", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
@@ -0,0 +1,242 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/compute"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table/internal"
+)
+
+// field IDs reserved by the spec for the columns of position delete files
+const (
+	positionDeleteFilePathID = 2147483546
+	positionDeletePosID      = 2147483545
+	positionDeleteRowID      = 2147483544
+)
+
+// rows buffered by PositionDeleteWriter.Write before they are converted
+// into a record batch.
+const positionDeleteBatchSize = 8192
+
+// PositionDeleteWriter writes position delete files for the data files
+// of a table. The spec requires the deletes in a file to be sorted by
+// data file path and then by position, so the writer rejects any delete
+// that is written out of order. The written files are returned by Close
+// and still need to be committed to the table.
+//
+// The file_path column of each file is written with full, untruncated
+// bounds, and files that only reference a single data file record it as
+// their referenced data file, so that planning can skip delete files
+// that cannot apply to a data file.
+type PositionDeleteWriter struct {
+	*deleteFileWriter
+
+	bldr      *array.RecordBuilder
+	bldrRows  int
+	firstPath string
+	lastPath  string
+	lastPos   int64
+	started   bool
+}
+
+// NewPositionDeleteWriter creates a writer for position delete files of
+// the table, stored alongside its data files. Format version 3 tables
+// use deletion vectors instead, see NewDeletionVectorWriter.
+func NewPositionDeleteWriter(ctx context.Context, tbl *Table, opts ...DeleteWriterOption) (*PositionDeleteWriter, error) {
+	pinned := tbl.pin()
+	tbl = &pinned
+
+	if v := tbl.Metadata().Version(); v >= 3 {
+		return nil, fmt.Errorf("%w: format version %d tables use deletion vectors instead of position delete files",
+			iceberg.ErrInvalidArgument, v)
+	}
+
+	cfg := defaultDeleteWriterConfig(tbl.Metadata().Properties(), opts)
+
+	spec := tbl.Metadata().PartitionSpec()
+	if !spec.IsUnpartitioned() && cfg.specID == nil {
+		return nil, fmt.Errorf("%w: position deletes for a partitioned table require a partition",
+			iceberg.ErrInvalidArgument)
+	}
+
+	w, err := newDeleteFileWriter(ctx, tbl, positionDeleteSchema(cfg.rowSchema),
+		iceberg.EntryContentPosDeletes, spec, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep complete bounds for the file paths so that planning can tell
+	// which data files the deletes apply to
+	for _, id := range []int{positionDeleteFilePathID, positionDeletePosID} {
+		col := w.statsCols[id]
+		col.Mode = internal.MetricsMode{Typ: internal.MetricModeFull}
+		w.statsCols[id] = col
+	}
+
+	return &PositionDeleteWriter{
+		deleteFileWriter: w,
+		bldr:             array.NewRecordBuilder(compute.GetAllocator(ctx), w.arrSchema),
+	}, nil
+}
+
+func positionDeleteSchema(rowSchema *iceberg.Schema) *iceberg.Schema {
+	fields := []iceberg.NestedField{
+		{ID: positionDeleteFilePathID, Name: "file_path", Type: iceberg.PrimitiveTypes.String, Required: true},
+		{ID: positionDeletePosID, Name: "pos", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+	}
+	if rowSchema != nil {
+		rowType := rowSchema.AsStruct()
+		fields = append(fields, iceberg.NestedField{
+			ID: positionDeleteRowID, Name: "row", Type: &rowType,
+		})
+	}
+
+	return iceberg.NewSchema(0, fields...)
+}
+
+// Schema returns the arrow schema of the records accepted by WriteRecord.
+func (w *PositionDeleteWriter) Schema() *arrow.Schema { return w.arrSchema }
+
+// Write deletes the row at position pos of the data file at filePath. If
+// the writer includes deleted rows, the row is written as null.
+func (w *PositionDeleteWriter) Write(filePath string, pos int64) error {
+	if err := w.checkOrder(filePath, pos); err != nil {
+		return err
+	}
+
+	w.bldr.Field(0).(*array.StringBuilder).Append(filePath)
+	w.bldr.Field(1).(*array.Int64Builder).Append(pos)
+	if w.arrSchema.NumFields() > 2 {
+		w.bldr.Field(2).AppendNull()
+	}
+	w.bldrRows++
+	w.pendingBytes += int64(len(filePath)) + 8
+
+	if w.bldrRows >= positionDeleteBatchSize {
+		w.finishBatch()
+	}
+
+	return w.maybeRoll()
+}
+
+// WriteRecord writes a batch of deletes whose schema matches Schema,
+// including the deleted rows if the writer was created with
+// WithDeleteRowSchema.
+func (w *PositionDeleteWriter) WriteRecord(rec arrow.RecordBatch) error {
+	if err := w.checkOpen(); err != nil {
+		return err
+	}
+
+	if err := w.checkSchema(rec.Schema()); err != nil {
+		return err
+	}
+
+	paths, ok := rec.Column(0).(*array.String)
+	if !ok || paths.NullN() > 0 || rec.Column(1).NullN() > 0 {
+		return fmt.Errorf("%w: position delete file_path and pos must not be null",
+			iceberg.ErrInvalidArgument)
+	}
+	positions := rec.Column(1).(*array.Int64)
+
+	for i := range int(rec.NumRows()) {
+		if err := w.checkOrder(paths.Value(i), positions.Value(i)); err != nil {
+			return err
+		}
+	}
+
+	w.finishBatch()
+	w.add(array.NewRecordBatch(w.arrSchema, rec.Columns(), rec.NumRows()), recordNBytes(rec))
+
+	return w.maybeRoll()
+}
+
+func (w *PositionDeleteWriter) checkOrder(filePath string, pos int64) error {
+	if err := w.checkOpen(); err != nil {
+		return err
+	}
+
+	if pos < 0 {
+		return fmt.Errorf("%w: invalid position %d for %s", iceberg.ErrInvalidArgument, pos, filePath)
+	}
+
+	if w.started && (filePath < w.lastPath || (filePath == w.lastPath && pos < w.lastPos)) {
+		return fmt.Errorf("%w: position deletes must be sorted by file path and position, got (%s, %d) after (%s, %d)",
+			iceberg.ErrInvalidArgument, filePath, pos, w.lastPath, w.lastPos)
+	}
+
+	if w.firstPath == "" {
+		w.firstPath = filePath
+	}
+	w.lastPath, w.lastPos, w.started = filePath, pos, true
+
+	return nil
+}
+
+// finishBatch moves the rows buffered by Write into a pending record.
+func (w *PositionDeleteWriter) finishBatch() {
+	if w.bldrRows == 0 {
+		return
+	}
+
+	// Write already accounted for the size of the rows
+	w.add(w.bldr.NewRecordBatch(), 0)
+	w.bldrRows = 0
+}
+
+func (w *PositionDeleteWriter) maybeRoll() error {
+	if w.pendingBytes < w.targetFileSize {
+		return nil
+	}
+
+	return w.flush()
+}
+
+// flush writes the pending deletes to a new delete file.
+func (w *PositionDeleteWriter) flush() error {
+	w.finishBatch()
+
+	// the deletes are sorted by path, so a file references a single data
+	// file if its first and last deletes do
+	var referenced string
+	if w.firstPath == w.lastPath {
+		referenced = w.firstPath
+	}
+	w.firstPath = ""
+
+	return w.deleteFileWriter.flush(referenced)
+}
+
+// Close writes any remaining deletes and returns all of the delete
+// files written. The writer cannot be used afterwards.
+func (w *PositionDeleteWriter) Close() ([]iceberg.DataFile, error) {
+	if w.closed {
+		return w.files, nil
+	}
+
+	err := w.flush()
+	w.closed = true
+	w.bldr.Release()
+
+	return w.files, err
+}
@@ -0,0 +1,197 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDeleteWriterTable(t *testing.T) *table.Table {
+	return newTestTable(t, withTestSchema(iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String})))
+}
+
+func readDeleteFile(t *testing.T, path string) ([]string, []int64) {
+	f, err := os.Open(strings.TrimPrefix(path, "file://"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	rdr, err := file.NewParquetReader(f)
+	require.NoError(t, err)
+	defer rdr.Close()
+
+	arrRdr, err := pqarrow.NewFileReader(rdr, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	require.NoError(t, err)
+	tbl, err := arrRdr.ReadTable(context.Background())
+	require.NoError(t, err)
+	defer tbl.Release()
+
+	var (
+		paths     []string
+		positions []int64
+	)
+	for _, chunk := range tbl.Column(0).Data().Chunks() {
+		for i := range chunk.Len() {
+			paths = append(paths, chunk.(*array.String).Value(i))
+		}
+	}
+	for _, chunk := range tbl.Column(1).Data().Chunks() {
+		positions = append(positions, chunk.(*array.Int64).Int64Values()...)
+	}
+
+	return paths, positions
+}
+
+func TestPositionDeleteWriter(t *testing.T) {
+	ctx := context.Background()
+	tbl := newDeleteWriterTable(t)
+
+	t.Run("multiple data files", func(t *testing.T) {
+		w, err := table.NewPositionDeleteWriter(ctx, tbl)
+		require.NoError(t, err)
+
+		require.NoError(t, w.Write("s3://bucket/data/a-long-data-file-name.parquet", 1))
+		require.NoError(t, w.Write("s3://bucket/data/a-long-data-file-name.parquet", 5))
+		require.NoError(t, w.Write("s3://bucket/data/b-long-data-file-name.parquet", 0))
+
+		files, err := w.Close()
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		df := files[0]
+		assert.Equal(t, iceberg.EntryContentPosDeletes, df.ContentType())
+		assert.EqualValues(t, 3, df.Count())
+		assert.Nil(t, df.ReferencedDataFile())
+		assert.Equal(t, "s3://bucket/data/a-long-data-file-name.parquet",
+			string(df.LowerBoundValues()[2147483546]))
+		assert.Equal(t, "s3://bucket/data/b-long-data-file-name.parquet",
+			string(df.UpperBoundValues()[2147483546]))
+
+		paths, positions := readDeleteFile(t, df.FilePath())
+		assert.Equal(t, []string{
+			"s3://bucket/data/a-long-data-file-name.parquet",
+			"s3://bucket/data/a-long-data-file-name.parquet",
+			"s3://bucket/data/b-long-data-file-name.parquet",
+		}, paths)
+		assert.Equal(t, []int64{1, 5, 0}, positions)
+	})
+
+	t.Run("out of order", func(t *testing.T) {
+		w, err := table.NewPositionDeleteWriter(ctx, tbl)
+		require.NoError(t, err)
+
+		require.NoError(t, w.Write("b.parquet", 3))
+		assert.ErrorIs(t, w.Write("b.parquet", 2), iceberg.ErrInvalidArgument)
+		assert.ErrorIs(t, w.Write("a.parquet", 10), iceberg.ErrInvalidArgument)
+		assert.ErrorIs(t, w.Write("b.parquet", -1), iceberg.ErrInvalidArgument)
+
+		files, err := w.Close()
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		assert.EqualValues(t, 1, files[0].Count())
+		require.NotNil(t, files[0].ReferencedDataFile())
+		assert.Equal(t, "b.parquet", *files[0].ReferencedDataFile())
+
+		assert.ErrorIs(t, w.Write("c.parquet", 0), table.ErrInvalidOperation)
+	})
+
+	t.Run("rolling", func(t *testing.T) {
+		w, err := table.NewPositionDeleteWriter(ctx, tbl, table.WithDeleteTargetFileSize(100))
+		require.NoError(t, err)
+
+		for _, path := range []string{"a.parquet", "b.parquet", "c.parquet"} {
+			for pos := range int64(10) {
+				require.NoError(t, w.Write(path, pos))
+			}
+		}
+
+		files, err := w.Close()
+		require.NoError(t, err)
+		require.Greater(t, len(files), 1)
+
+		var (
+			total int64
+			last  string
+		)
+		for _, df := range files {
+			total += df.Count()
+			paths, _ := readDeleteFile(t, df.FilePath())
+			require.NotEmpty(t, paths)
+			assert.GreaterOrEqual(t, paths[0], last)
+			last = paths[len(paths)-1]
+		}
+		assert.EqualValues(t, 30, total)
+	})
+
+	t.Run("row payload", func(t *testing.T) {
+		w, err := table.NewPositionDeleteWriter(ctx, tbl, table.WithDeleteRowSchema(tbl.Schema()))
+		require.NoError(t, err)
+		require.Equal(t, 3, w.Schema().NumFields())
+
+		rec, _, err := array.RecordFromJSON(memory.DefaultAllocator, w.Schema(), strings.NewReader(`[
+			{"file_path": "a.parquet", "pos": 0, "row": {"id": 1, "data": "x"}},
+			{"file_path": "a.parquet", "pos": 3, "row": {"id": 4, "data": null}}
+		]`))
+		require.NoError(t, err)
+		defer rec.Release()
+
+		require.NoError(t, w.WriteRecord(rec))
+		require.NoError(t, w.Write("a.parquet", 7))
+
+		files, err := w.Close()
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		assert.EqualValues(t, 3, files[0].Count())
+
+		_, positions := readDeleteFile(t, files[0].FilePath())
+		assert.Equal(t, []int64{0, 3, 7}, positions)
+	})
+
+	t.Run("partitioned table requires partition", func(t *testing.T) {
+		spec := iceberg.NewPartitionSpec(
+			iceberg.PartitionField{SourceID: 1, FieldID: 1000, Name: "id_bucket", Transform: iceberg.BucketTransform{NumBuckets: 4}})
+		partitioned := newTestTable(t, withTestSchema(tbl.Schema()), withTestSpec(&spec))
+
+		_, err := table.NewPositionDeleteWriter(ctx, partitioned)
+		assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+		w, err := table.NewPositionDeleteWriter(ctx, partitioned,
+			table.WithDeletePartition(partitioned.Metadata().DefaultPartitionSpec(), map[int]any{1000: int32(2)}))
+		require.NoError(t, err)
+		require.NoError(t, w.Write(partitioned.Location()+"/data/id_bucket=2/f.parquet", 0))
+
+		files, err := w.Close()
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		assert.Contains(t, files[0].FilePath(), "id_bucket=2/")
+		assert.EqualValues(t, 2, files[0].Partition()[1000])
+	})
+}
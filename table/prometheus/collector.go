@@ -0,0 +1,199 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package prometheus exposes table health metrics as a Prometheus
+// collector, so that tables that need compaction or snapshot expiration
+// can be alerted on.
+package prometheus
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/iceberg-go/table"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// TableLoader loads tables by identifier. catalog.Catalog implements it.
+type TableLoader interface {
+	LoadTable(ctx context.Context, identifier table.Identifier) (*table.Table, error)
+}
+
+var (
+	tableLabels     = []string{"table"}
+	partitionLabels = []string{"table", "spec_id", "partition"}
+
+	scrapeSuccessDesc = prom.NewDesc("iceberg_table_health_scrape_success",
+		"Whether the table's health metrics were collected (1) or not (0).", tableLabels, nil)
+	snapshotsDesc = prom.NewDesc("iceberg_table_snapshots",
+		"Number of snapshots in the table metadata.", tableLabels, nil)
+	dataFilesDesc = prom.NewDesc("iceberg_table_data_files",
+		"Number of live data files in the current snapshot.", tableLabels, nil)
+	deleteFilesDesc = prom.NewDesc("iceberg_table_delete_files",
+		"Number of live delete files in the current snapshot.", tableLabels, nil)
+	dataBytesDesc = prom.NewDesc("iceberg_table_data_bytes",
+		"Total size of the live data files in bytes.", tableLabels, nil)
+	deleteBytesDesc = prom.NewDesc("iceberg_table_delete_bytes",
+		"Total size of the live delete files in bytes.", tableLabels, nil)
+	recordsDesc = prom.NewDesc("iceberg_table_records",
+		"Total record count of the live data files.", tableLabels, nil)
+	avgFileSizeDesc = prom.NewDesc("iceberg_table_average_data_file_bytes",
+		"Average size of the live data files in bytes.", tableLabels, nil)
+	deleteRatioDesc = prom.NewDesc("iceberg_table_delete_file_ratio",
+		"Number of delete files per data file.", tableLabels, nil)
+	smallFilesDesc = prom.NewDesc("iceberg_table_small_data_files",
+		"Number of data files smaller than the small file threshold.", tableLabels, nil)
+	smallRatioDesc = prom.NewDesc("iceberg_table_small_file_ratio",
+		"Fraction of data files smaller than the small file threshold.", tableLabels, nil)
+	partDataFilesDesc = prom.NewDesc("iceberg_table_partition_data_files",
+		"Number of live data files in the partition.", partitionLabels, nil)
+	partSmallRatioDesc = prom.NewDesc("iceberg_table_partition_small_file_ratio",
+		"Fraction of the partition's data files smaller than the small file threshold.", partitionLabels, nil)
+)
+
+// Collector is a prometheus.Collector reporting table.HealthMetrics for
+// a fixed set of tables. Tables are loaded, and their manifests read, on
+// every scrape, so scrape intervals should be chosen accordingly.
+type Collector struct {
+	loader     TableLoader
+	tables     []table.Identifier
+	opts       []table.HealthOption
+	timeout    time.Duration
+	partitions bool
+}
+
+// Option configures a Collector.
+type Option func(*Collector)
+
+// WithHealthOptions sets the options passed to Table.HealthMetrics.
+func WithHealthOptions(opts ...table.HealthOption) Option {
+	return func(c *Collector) {
+		c.opts = opts
+	}
+}
+
+// WithTimeout bounds the time spent collecting the metrics of each
+// table. It defaults to 30 seconds.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Collector) {
+		c.timeout = d
+	}
+}
+
+// WithPartitionMetrics controls whether per-partition series are
+// reported. They are by default; disable them for tables with many
+// partitions to limit the number of series.
+func WithPartitionMetrics(enabled bool) Option {
+	return func(c *Collector) {
+		c.partitions = enabled
+	}
+}
+
+// NewCollector returns a Collector for the given tables.
+func NewCollector(loader TableLoader, tables []table.Identifier, opts ...Option) *Collector {
+	c := &Collector{
+		loader:     loader,
+		tables:     tables,
+		timeout:    30 * time.Second,
+		partitions: true,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prom.Desc) {
+	for _, d := range []*prom.Desc{
+		scrapeSuccessDesc, snapshotsDesc, dataFilesDesc, deleteFilesDesc,
+		dataBytesDesc, deleteBytesDesc, recordsDesc, avgFileSizeDesc,
+		deleteRatioDesc, smallFilesDesc, smallRatioDesc,
+		partDataFilesDesc, partSmallRatioDesc,
+	} {
+		ch <- d
+	}
+}
+
+// Collect implements prometheus.Collector. Tables are collected
+// concurrently.
+func (c *Collector) Collect(ch chan<- prom.Metric) {
+	var wg sync.WaitGroup
+	for _, ident := range c.tables {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.collectTable(ch, ident)
+		}()
+	}
+	wg.Wait()
+}
+
+func (c *Collector) collectTable(ch chan<- prom.Metric, ident table.Identifier) {
+	name := strings.Join(ident, ".")
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	h, err := c.health(ctx, ident)
+	if err != nil {
+		ch <- prom.MustNewConstMetric(scrapeSuccessDesc, prom.GaugeValue, 0, name)
+
+		return
+	}
+
+	gauge := func(d *prom.Desc, v float64, labels ...string) {
+		ch <- prom.MustNewConstMetric(d, prom.GaugeValue, v, labels...)
+	}
+
+	gauge(scrapeSuccessDesc, 1, name)
+	gauge(snapshotsDesc, float64(h.Snapshots), name)
+	gauge(dataFilesDesc, float64(h.DataFiles), name)
+	gauge(deleteFilesDesc, float64(h.DeleteFiles), name)
+	gauge(dataBytesDesc, float64(h.DataBytes), name)
+	gauge(deleteBytesDesc, float64(h.DeleteBytes), name)
+	gauge(recordsDesc, float64(h.Records), name)
+	gauge(avgFileSizeDesc, h.AvgDataFileSize(), name)
+	gauge(deleteRatioDesc, h.DeleteFileRatio(), name)
+	gauge(smallFilesDesc, float64(h.SmallFiles), name)
+	gauge(smallRatioDesc, h.SmallFileRatio(), name)
+
+	if !c.partitions {
+		return
+	}
+
+	for _, p := range h.Partitions {
+		specID := strconv.Itoa(p.SpecID)
+		gauge(partDataFilesDesc, float64(p.DataFiles), name, specID, p.Path)
+		gauge(partSmallRatioDesc, p.SmallFileRatio(), name, specID, p.Path)
+	}
+}
+
+func (c *Collector) health(ctx context.Context, ident table.Identifier) (table.HealthMetrics, error) {
+	tbl, err := c.loader.LoadTable(ctx, ident)
+	if err != nil {
+		return table.HealthMetrics{}, err
+	}
+
+	return tbl.HealthMetrics(ctx, c.opts...)
+}
+
+var _ prom.Collector = (*Collector)(nil)
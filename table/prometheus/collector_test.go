@@ -0,0 +1,120 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package prometheus_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/catalog"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	icebergprom "github.com/apache/iceberg-go/table/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tableLoader map[string]*table.Table
+
+func (l tableLoader) LoadTable(_ context.Context, ident table.Identifier) (*table.Table, error) {
+	tbl, ok := l[strings.Join(ident, ".")]
+	if !ok {
+		return nil, catalog.ErrNoSuchTable
+	}
+
+	return tbl, nil
+}
+
+func newTable(t *testing.T) *table.Table {
+	ctx := context.Background()
+	loc := filepath.ToSlash(t.TempDir())
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "category", Type: iceberg.PrimitiveTypes.String, Required: true})
+	spec := iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 2, FieldID: 1000, Name: "category", Transform: iceberg.IdentityTransform{}})
+
+	meta, err := table.NewMetadata(sc, &spec, table.UnsortedSortOrder, loc, nil)
+	require.NoError(t, err)
+
+	tbl := table.New(table.Identifier{"db", "events"}, meta, loc+"/metadata/v1.metadata.json",
+		func(context.Context) (iceio.IO, error) { return iceio.LocalFS{}, nil }, nil)
+
+	arrSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "category", Type: arrow.BinaryTypes.String},
+	}, nil)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{
+		`[{"id": 1, "category": "a"}, {"id": 2, "category": "b"}]`,
+	})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	// committing through a transaction avoids the need for a catalog
+	tx := tbl.NewTransaction()
+	require.NoError(t, tx.AppendTable(ctx, arrTbl, 2, nil))
+	staged, err := tx.StagedTable()
+	require.NoError(t, err)
+
+	return staged.Table
+}
+
+func TestCollector(t *testing.T) {
+	loader := tableLoader{"db.events": newTable(t)}
+	idents := []table.Identifier{{"db", "events"}, {"db", "missing"}}
+
+	c := icebergprom.NewCollector(loader, idents)
+	assert.Equal(t, 1+11+2*2, testutil.CollectAndCount(c))
+
+	expected := `
+# HELP iceberg_table_health_scrape_success Whether the table's health metrics were collected (1) or not (0).
+# TYPE iceberg_table_health_scrape_success gauge
+iceberg_table_health_scrape_success{table="db.events"} 1
+iceberg_table_health_scrape_success{table="db.missing"} 0
+# HELP iceberg_table_data_files Number of live data files in the current snapshot.
+# TYPE iceberg_table_data_files gauge
+iceberg_table_data_files{table="db.events"} 2
+# HELP iceberg_table_small_file_ratio Fraction of data files smaller than the small file threshold.
+# TYPE iceberg_table_small_file_ratio gauge
+iceberg_table_small_file_ratio{table="db.events"} 1
+# HELP iceberg_table_partition_data_files Number of live data files in the partition.
+# TYPE iceberg_table_partition_data_files gauge
+iceberg_table_partition_data_files{partition="category=a",spec_id="0",table="db.events"} 1
+iceberg_table_partition_data_files{partition="category=b",spec_id="0",table="db.events"} 1
+`
+	require.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected),
+		"iceberg_table_health_scrape_success", "iceberg_table_data_files",
+		"iceberg_table_small_file_ratio", "iceberg_table_partition_data_files"))
+
+	c = icebergprom.NewCollector(loader, idents[:1], icebergprom.WithPartitionMetrics(false),
+		icebergprom.WithHealthOptions(table.WithSmallFileThreshold(0)))
+	assert.Equal(t, 11, testutil.CollectAndCount(c))
+	require.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(`
+# HELP iceberg_table_small_file_ratio Fraction of data files smaller than the small file threshold.
+# TYPE iceberg_table_small_file_ratio gauge
+iceberg_table_small_file_ratio{table="db.events"} 0
+`), "iceberg_table_small_file_ratio"))
+}
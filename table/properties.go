@@ -24,12 +24,25 @@ import (
 )
 
 const (
+	// WriteFormatDefaultKey sets the file format of data files, one of
+	// parquet, avro or orc.
+	WriteFormatDefaultKey     = "write.format.default"
+	WriteFormatDefaultDefault = "parquet"
+
 	WriteDataPathKey                        = "write.data.path"
 	WriteMetadataPathKey                    = "write.metadata.path"
 	WriteObjectStorePartitionedPathsKey     = "write.object-storage.partitioned-paths"
 	WriteObjectStorePartitionedPathsDefault = true
 	ObjectStoreEnabledKey                   = "write.object-storage.enabled"
 	ObjectStoreEnabledDefault               = false
+	WriteLocationProviderImplKey            = "write.location-provider.impl"
+
+	// Deprecated: use WriteDataPathKey. Only consulted when
+	// write.data.path is not set.
+	WriteObjectStoragePathKey = "write.object-storage.path"
+	// Deprecated: use WriteDataPathKey. Only consulted when neither
+	// write.data.path nor write.object-storage.path is set.
+	WriteFolderStoragePathKey = "write.folder-storage.path"
 
 	DefaultNameMappingKey = "schema.name-mapping.default"
 
@@ -67,9 +80,17 @@ const (
 	WritePartitionSummaryLimitKey     = "write.summary.partition-limit"
 	WritePartitionSummaryLimitDefault = 0
 
+	// WriteDistributionModeKey sets the DistributionMode of writes. It
+	// defaults to hash for partitioned tables and none otherwise.
+	WriteDistributionModeKey = "write.distribution-mode"
+
 	WriteDeleteModeKey     = "write.delete.mode"
 	WriteDeleteModeDefault = WriteModeCopyOnWrite
 
+	WriteIsolationLevelKey       = "write.isolation-level"
+	WriteDeleteIsolationLevelKey = "write.delete.isolation-level"
+	WriteIsolationLevelDefault   = string(IsolationSerializable)
+
 	MetadataDeleteAfterCommitEnabledKey     = "write.metadata.delete-after-commit.enabled"
 	MetadataDeleteAfterCommitEnabledDefault = false
 
@@ -79,9 +100,17 @@ const (
 	MetadataCompressionKey     = "write.metadata.compression-codec"
 	MetadataCompressionDefault = "none"
 
+	AvroCompressionKey          = "write.avro.compression-codec"
+	AvroCompressionDefault      = "gzip"
+	AvroCompressionLevelKey     = "write.avro.compression-level"
+	AvroCompressionLevelDefault = -1
+
 	WriteTargetFileSizeBytesKey     = "write.target-file-size-bytes"
 	WriteTargetFileSizeBytesDefault = 512 * 1024 * 1024 // 512 MB
 
+	WriteDeleteTargetFileSizeBytesKey     = "write.delete.target-file-size-bytes"
+	WriteDeleteTargetFileSizeBytesDefault = 64 * 1024 * 1024 // 64 MB
+
 	MinSnapshotsToKeepKey     = "min-snapshots-to-keep"
 	MinSnapshotsToKeepDefault = math.MaxInt
 
@@ -90,6 +119,14 @@ const (
 
 	MaxRefAgeMsKey     = "max-ref-age-ms"
 	MaxRefAgeMsDefault = math.MaxInt
+
+	// Table-wide retention used by ExpireSnapshots for refs that do not
+	// set their own min-snapshots-to-keep, max-snapshot-age-ms or
+	// max-ref-age-ms. The defaults are MinSnapshotsToKeepDefault,
+	// MaxSnapshotAgeMsDefault and MaxRefAgeMsDefault.
+	HistoryExpireMinSnapshotsToKeepKey = "history.expire.min-snapshots-to-keep"
+	HistoryExpireMaxSnapshotAgeMsKey   = "history.expire.max-snapshot-age-ms"
+	HistoryExpireMaxRefAgeMsKey        = "history.expire.max-ref-age-ms"
 )
 
 // Reserved properties
@@ -0,0 +1,197 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+)
+
+// ReachableFileKind classifies a file referenced by table metadata.
+type ReachableFileKind string
+
+const (
+	ReachableMetadataFile        ReachableFileKind = "metadata"
+	ReachableManifestList        ReachableFileKind = "manifest-list"
+	ReachableManifest            ReachableFileKind = "manifest"
+	ReachableDataFile            ReachableFileKind = "data"
+	ReachablePositionDeletes     ReachableFileKind = "position-deletes"
+	ReachableEqualityDeletes     ReachableFileKind = "equality-deletes"
+	ReachableStatistics          ReachableFileKind = "statistics"
+	ReachablePartitionStatistics ReachableFileKind = "partition-statistics"
+)
+
+// ReachableFile is a file referenced, directly or through manifests, by a
+// table's metadata.
+type ReachableFile struct {
+	Path string
+	Kind ReachableFileKind
+	// SnapshotID is the first snapshot found to reference the file, or 0
+	// for metadata files.
+	SnapshotID int64
+	// SizeBytes is the size of the file when recorded in the metadata, or
+	// 0 if unknown.
+	SizeBytes int64
+}
+
+// ReachableFiles returns every file reachable from the table's current
+// metadata file, see ReachableFiles.
+func (t Table) ReachableFiles(ctx context.Context) iter.Seq2[ReachableFile, error] {
+	t = t.pin()
+
+	return func(yield func(ReachableFile, error) bool) {
+		fs, err := t.FS(ctx)
+		if err != nil {
+			yield(ReachableFile{}, err)
+
+			return
+		}
+
+		for f, err := range ReachableFiles(ctx, t.Metadata(), t.MetadataLocation(), fs) {
+			if !yield(f, err) {
+				return
+			}
+		}
+	}
+}
+
+// ReachableFiles streams the complete set of files reachable from the
+// metadata file at metadataLocation: the metadata file itself and those in
+// its metadata log, the statistics files, and for every snapshot its
+// manifest list, manifests, data files and delete files. Each file is
+// yielded once, even when shared between snapshots, and manifests shared
+// between snapshots are only read once.
+//
+// Files of manifest entries marked as deleted are included as well, since
+// they may still be read by time travel to older snapshots. The stream
+// stops at the first error, such as an unreadable manifest, so that a
+// partial set is never mistaken for a complete one.
+//
+// This is the set of files orphan cleanup must keep, and the set a backup
+// of the table must copy.
+func ReachableFiles(ctx context.Context, meta Metadata, metadataLocation string, fs iceio.IO) iter.Seq2[ReachableFile, error] {
+	return func(yield func(ReachableFile, error) bool) {
+		seen := make(map[string]struct{})
+		emit := func(f ReachableFile) bool {
+			if f.Path == "" {
+				return true
+			}
+			if _, ok := seen[f.Path]; ok {
+				return true
+			}
+			seen[f.Path] = struct{}{}
+
+			return yield(f, nil)
+		}
+
+		if !emit(ReachableFile{Path: metadataLocation, Kind: ReachableMetadataFile}) {
+			return
+		}
+		for entry := range meta.PreviousFiles() {
+			if !emit(ReachableFile{Path: entry.MetadataFile, Kind: ReachableMetadataFile}) {
+				return
+			}
+		}
+
+		for stats := range meta.Statistics() {
+			if !emit(ReachableFile{
+				Path: stats.StatisticsPath, Kind: ReachableStatistics,
+				SnapshotID: stats.SnapshotID, SizeBytes: stats.FileSizeInBytes,
+			}) {
+				return
+			}
+		}
+		for stats := range meta.PartitionStatistics() {
+			if !emit(ReachableFile{
+				Path: stats.StatisticsPath, Kind: ReachablePartitionStatistics,
+				SnapshotID: stats.SnapshotID, SizeBytes: stats.FileSizeInBytes,
+			}) {
+				return
+			}
+		}
+
+		for _, snapshot := range meta.Snapshots() {
+			if err := ctx.Err(); err != nil {
+				yield(ReachableFile{}, err)
+
+				return
+			}
+
+			if !emit(ReachableFile{
+				Path: snapshot.ManifestList, Kind: ReachableManifestList,
+				SnapshotID: snapshot.SnapshotID,
+			}) {
+				return
+			}
+
+			manifests, err := snapshot.Manifests(fs)
+			if err != nil {
+				yield(ReachableFile{}, fmt.Errorf("failed to read manifests for snapshot %d: %w",
+					snapshot.SnapshotID, err))
+
+				return
+			}
+
+			for _, manifest := range manifests {
+				if _, ok := seen[manifest.FilePath()]; ok {
+					continue
+				}
+
+				if !emit(ReachableFile{
+					Path: manifest.FilePath(), Kind: ReachableManifest,
+					SnapshotID: snapshot.SnapshotID, SizeBytes: manifest.Length(),
+				}) {
+					return
+				}
+
+				entries, err := manifest.FetchEntries(fs, false)
+				if err != nil {
+					yield(ReachableFile{}, fmt.Errorf("failed to read manifest entries of %s: %w",
+						manifest.FilePath(), err))
+
+					return
+				}
+
+				for _, entry := range entries {
+					df := entry.DataFile()
+					if !emit(ReachableFile{
+						Path: df.FilePath(), Kind: reachableKind(df.ContentType()),
+						SnapshotID: snapshot.SnapshotID, SizeBytes: df.FileSizeBytes(),
+					}) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+func reachableKind(content iceberg.ManifestEntryContent) ReachableFileKind {
+	switch content {
+	case iceberg.EntryContentPosDeletes:
+		return ReachablePositionDeletes
+	case iceberg.EntryContentEqDeletes:
+		return ReachableEqualityDeletes
+	default:
+		return ReachableDataFile
+	}
+}
@@ -0,0 +1,135 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReachableFiles(t *testing.T) {
+	ctx := context.Background()
+	loc := filepath.ToSlash(t.TempDir())
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true})
+	meta, err := NewMetadata(sc, iceberg.UnpartitionedSpec, UnsortedSortOrder, loc,
+		iceberg.Properties{PropertyFormatVersion: "2"})
+	require.NoError(t, err)
+
+	tbl := New(Identifier{"default", "reachable"}, meta, loc+"/metadata/v1.metadata.json",
+		func(context.Context) (iceio.IO, error) { return iceio.LocalFS{}, nil },
+		&inMemoryCatalog{meta})
+
+	arrSchema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	for _, rows := range []string{`[{"id": 0}, {"id": 1}]`, `[{"id": 2}, {"id": 3}]`} {
+		arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{rows})
+		require.NoError(t, err)
+		tbl, err = tbl.AppendTable(ctx, arrTbl, 2, nil)
+		require.NoError(t, err)
+		arrTbl.Release()
+	}
+
+	fs, err := tbl.FS(ctx)
+	require.NoError(t, err)
+	var dataPaths []string
+	for df, err := range tbl.CurrentSnapshot().dataFiles(fs, nil) {
+		require.NoError(t, err)
+		dataPaths = append(dataPaths, df.FilePath())
+	}
+	require.Len(t, dataPaths, 2)
+
+	deletes := writePositionDeletes(t, tbl, dataPaths[0], 1)
+	tbl = commitDeleteFiles(t, tbl, deletes)
+
+	// copy-on-write delete of a whole file, leaving it reachable only
+	// through the older snapshots
+	tbl, err = tbl.Delete(ctx, iceberg.EqualTo(iceberg.Reference("id"), int64(2)), nil)
+	require.NoError(t, err)
+	tbl, err = tbl.Delete(ctx, iceberg.EqualTo(iceberg.Reference("id"), int64(3)), nil)
+	require.NoError(t, err)
+
+	// the in-memory catalog doesn't write metadata files, so pass a location
+	metadataLoc := loc + "/metadata/v5.metadata.json"
+	byKind := map[ReachableFileKind][]ReachableFile{}
+	seen := map[string]bool{}
+	for f, err := range ReachableFiles(ctx, tbl.Metadata(), metadataLoc, fs) {
+		require.NoError(t, err)
+		assert.False(t, seen[f.Path], "file %s yielded twice", f.Path)
+		seen[f.Path] = true
+		byKind[f.Kind] = append(byKind[f.Kind], f)
+
+		if f.Kind != ReachableMetadataFile {
+			info, err := os.Stat(f.Path)
+			require.NoError(t, err)
+			if f.SizeBytes != 0 {
+				assert.Equal(t, info.Size(), f.SizeBytes, f.Path)
+			}
+		}
+	}
+
+	assert.Equal(t, []ReachableFile{{Path: metadataLoc, Kind: ReachableMetadataFile}},
+		byKind[ReachableMetadataFile])
+	assert.Len(t, byKind[ReachableManifestList], len(tbl.Metadata().Snapshots()))
+	assert.ElementsMatch(t, dataPaths, []string{byKind[ReachableDataFile][0].Path, byKind[ReachableDataFile][1].Path})
+	require.Len(t, byKind[ReachablePositionDeletes], 1)
+	assert.Equal(t, deletes[0].FilePath(), byKind[ReachablePositionDeletes][0].Path)
+	assert.NotEmpty(t, byKind[ReachableManifest])
+
+	// every manifest of every snapshot is included
+	for _, snap := range tbl.Metadata().Snapshots() {
+		manifests, err := snap.Manifests(fs)
+		require.NoError(t, err)
+		for _, m := range manifests {
+			assert.True(t, seen[m.FilePath()], m.FilePath())
+		}
+	}
+
+	t.Run("stops early", func(t *testing.T) {
+		n := 0
+		for range tbl.ReachableFiles(ctx) {
+			n++
+			if n == 2 {
+				break
+			}
+		}
+		assert.Equal(t, 2, n)
+	})
+
+	t.Run("missing manifest list", func(t *testing.T) {
+		require.NoError(t, os.Remove(tbl.CurrentSnapshot().ManifestList))
+
+		var err error
+		for _, err = range tbl.ReachableFiles(ctx) {
+			if err != nil {
+				break
+			}
+		}
+		assert.ErrorContains(t, err, "failed to read manifests for snapshot")
+	})
+}
@@ -0,0 +1,176 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+
+	"github.com/apache/iceberg-go"
+)
+
+// ReplaceTable stages replacing the definition of the table with the given
+// schema, partition spec, sort order and properties, as done by a CREATE OR
+// REPLACE TABLE statement. A nil spec leaves the table unpartitioned and an
+// empty location keeps the current one. The properties are merged into the
+// existing ones, and a format-version property upgrades the table.
+//
+// The history of the table is kept, but the main branch no longer points to
+// a snapshot so the replaced table is empty until data is written to it.
+// Columns of the new schema keep the field ID of the column of the same name
+// in the current schema, so that existing snapshots can still be read.
+func (t *Transaction) ReplaceTable(sc *iceberg.Schema, spec *iceberg.PartitionSpec, order SortOrder, location string, props iceberg.Properties) error {
+	current, err := t.meta.Build()
+	if err != nil {
+		return err
+	}
+
+	props = maps.Clone(props)
+	formatVersion := current.Version()
+	var updates []Update
+	if v, ok := props[PropertyFormatVersion]; ok {
+		if formatVersion, err = strconv.Atoi(v); err != nil {
+			return fmt.Errorf("%w: invalid %s %q", iceberg.ErrInvalidArgument, PropertyFormatVersion, v)
+		}
+		delete(props, PropertyFormatVersion)
+		updates = append(updates, NewUpgradeFormatVersionUpdate(formatVersion))
+	}
+
+	freshSc, err := replacementSchema(sc, current.CurrentSchema(), current.LastColumnID())
+	if err != nil {
+		return err
+	}
+
+	freshSpec, err := replacementSpec(spec, sc, freshSc, current, formatVersion)
+	if err != nil {
+		return err
+	}
+
+	freshOrder, err := AssignFreshSortOrderIDs(order, sc, freshSc)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidMetadata, err)
+	}
+
+	updates = append(updates,
+		NewAddSchemaUpdate(freshSc),
+		NewSetCurrentSchemaUpdate(-1),
+		NewAddPartitionSpecUpdate(freshSpec, false),
+		NewSetDefaultSpecUpdate(-1),
+		NewAddSortOrderUpdate(&freshOrder),
+		NewSetDefaultSortOrderUpdate(-1),
+	)
+
+	if location != "" && location != current.Location() {
+		updates = append(updates, NewSetLocationUpdate(location))
+	}
+
+	if len(props) > 0 {
+		updates = append(updates, NewSetPropertiesUpdate(props))
+	}
+
+	// the main branch must still point at the snapshot being replaced when
+	// committing, which also covers snapshots added later in the transaction
+	var reqs []Requirement
+	if ref, ok := maps.Collect(current.Refs())[MainBranch]; ok {
+		updates = append(updates, NewRemoveSnapshotRefUpdate(MainBranch))
+		reqs = append(reqs, AssertRefSnapshotID(MainBranch, &ref.SnapshotID))
+	}
+
+	return t.apply(updates, reqs)
+}
+
+// replacementSchema assigns fresh field IDs to sc, reusing the ID of the
+// field with the same name in base and assigning IDs above lastColumnID to
+// the new fields.
+func replacementSchema(sc, base *iceberg.Schema, lastColumnID int) (*iceberg.Schema, error) {
+	fresh, err := iceberg.AssignFreshSchemaIDs(sc, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// fresh IDs are assigned sequentially in visiting order, so the n-th
+	// assigned ID belongs to the field of fresh with ID n.
+	ids := make([]int, fresh.HighestFieldID())
+	for i := range ids {
+		name, _ := fresh.FindColumnName(i + 1)
+		if field, ok := base.FindFieldByName(name); ok {
+			ids[i] = field.ID
+		} else {
+			lastColumnID++
+			ids[i] = lastColumnID
+		}
+	}
+
+	next := 0
+
+	return iceberg.AssignFreshSchemaIDs(sc, func() int {
+		next++
+
+		return ids[next-1]
+	})
+}
+
+// replacementSpec binds spec, defined against sc, to freshSc. On v2 and
+// later tables partition fields keep the ID of an existing partition field
+// with the same source column and transform, while v1 tables require
+// sequential partition field IDs.
+func replacementSpec(spec *iceberg.PartitionSpec, sc, freshSc *iceberg.Schema, current Metadata, formatVersion int) (*iceberg.PartitionSpec, error) {
+	if spec == nil {
+		spec = iceberg.UnpartitionedSpec
+	}
+
+	var opts []iceberg.PartitionOption
+	i := 0
+	for f := range spec.Fields() {
+		name, ok := sc.FindColumnName(f.SourceID)
+		if !ok {
+			return nil, fmt.Errorf("%w: field %d not found in schema", ErrInvalidMetadata, f.SourceID)
+		}
+
+		source, _ := freshSc.FindFieldByName(name)
+		var fieldID *int
+		if formatVersion <= 1 {
+			id := iceberg.PartitionDataIDStart + i
+			fieldID = &id
+		} else {
+			fieldID = existingPartitionFieldID(current, source.ID, f.Transform)
+		}
+		opts = append(opts, iceberg.AddPartitionFieldByName(name, f.Name, f.Transform, freshSc, fieldID))
+		i++
+	}
+
+	fresh, err := iceberg.NewPartitionSpecOpts(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMetadata, err)
+	}
+
+	return &fresh, nil
+}
+
+func existingPartitionFieldID(meta Metadata, sourceID int, transform iceberg.Transform) *int {
+	for _, spec := range meta.PartitionSpecs() {
+		for f := range spec.Fields() {
+			if f.SourceID == sourceID && f.Transform.Equals(transform) {
+				return &f.FieldID
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceTable(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "data", Type: iceberg.PrimitiveTypes.String})
+	spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+		SourceID: 1, FieldID: 1000, Name: "id", Transform: iceberg.IdentityTransform{},
+	})
+
+	replacement := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "category", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 2, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true})
+	replacementSpec := iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 1, FieldID: 1000, Name: "category_bucket", Transform: iceberg.BucketTransform{NumBuckets: 4}},
+		iceberg.PartitionField{SourceID: 2, FieldID: 1001, Name: "id", Transform: iceberg.IdentityTransform{}},
+	)
+
+	tests := []struct {
+		version  int
+		fieldIDs []int
+	}{
+		{1, []int{1000, 1001}},
+		{2, []int{1001, 1000}},
+	}
+
+	for _, tt := range tests {
+		t.Run("v"+strconv.Itoa(tt.version), func(t *testing.T) {
+			tbl := newTestTable(t, withTestSchema(sc), withTestSpec(&spec), withTestFormatVersion(tt.version),
+				withTestProperties(iceberg.Properties{"owner": "etl"}))
+
+			txn := tbl.NewTransaction()
+			require.NoError(t, txn.ReplaceTable(replacement, &replacementSpec, table.UnsortedSortOrder, "",
+				iceberg.Properties{"team": "data"}))
+			replaced, err := txn.Commit(context.Background())
+			require.NoError(t, err)
+
+			assert.Equal(t, tbl.Metadata().TableUUID(), replaced.Metadata().TableUUID())
+			assert.Equal(t, tt.version, replaced.Metadata().Version())
+			assert.Equal(t, "etl", replaced.Properties()["owner"])
+			assert.Equal(t, "data", replaced.Properties()["team"])
+
+			id, ok := replaced.Schema().FindFieldByName("id")
+			require.True(t, ok)
+			assert.Equal(t, 1, id.ID)
+			category, ok := replaced.Schema().FindFieldByName("category")
+			require.True(t, ok)
+			assert.Equal(t, 3, category.ID)
+
+			newSpec := replaced.Spec()
+			fields := slices.Collect(newSpec.Fields())
+			require.Len(t, fields, 2)
+			assert.Equal(t, category.ID, fields[0].SourceID)
+			assert.Equal(t, id.ID, fields[1].SourceID)
+			assert.Equal(t, tt.fieldIDs, []int{fields[0].FieldID, fields[1].FieldID})
+		})
+	}
+
+	t.Run("upgrade", func(t *testing.T) {
+		tbl := newVersionedDeleteTable(t, 2)
+		require.NotNil(t, tbl.CurrentSnapshot())
+
+		txn := tbl.NewTransaction()
+		require.NoError(t, txn.ReplaceTable(tbl.Schema(), nil, table.UnsortedSortOrder, "",
+			iceberg.Properties{table.PropertyFormatVersion: "3"}))
+		replaced, err := txn.Commit(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, replaced.Metadata().Version())
+		assert.Nil(t, replaced.CurrentSnapshot())
+		assert.Len(t, replaced.Metadata().Snapshots(), 1)
+		assert.Len(t, replaced.Metadata().Schemas(), 1)
+	})
+}
@@ -0,0 +1,237 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/config"
+	iceio "github.com/apache/iceberg-go/io"
+	"golang.org/x/sync/errgroup"
+)
+
+// FileCopy is a file which has to be copied from Source to Target.
+type FileCopy struct {
+	Source string
+	Target string
+}
+
+// RewritePathsResult describes the metadata written by Table.RewritePaths.
+type RewritePathsResult struct {
+	// MetadataLocation is the rewritten metadata file, which can be
+	// registered with a catalog once the files in FilesToCopy are copied.
+	MetadataLocation string
+	// FilesToCopy are the data and equality delete files referenced by the
+	// rewritten metadata. They are not modified by the rewrite and have to
+	// be copied to their new location by the caller.
+	FilesToCopy []FileCopy
+	// RewrittenFiles is the number of manifest lists, manifests and
+	// position delete files written under the target prefix.
+	RewrittenFiles int
+}
+
+// RewritePaths prepares the relocation of the table from sourcePrefix to
+// targetPrefix, for instance when moving it to a new bucket. Every absolute
+// path starting with sourcePrefix in the table metadata, manifest lists,
+// manifests and position delete files is rewritten to start with
+// targetPrefix instead, and the rewritten files are written to targetFS at
+// their new paths. Unlike Export, the whole snapshot history is kept and data
+// files are not copied; they are returned in FilesToCopy instead. Any path
+// outside of sourcePrefix makes the rewrite fail.
+//
+// The metadata log and statistics files are not carried over, and
+// encrypted manifests, deletion vectors and encrypted position delete files
+// are not supported yet.
+func (t Table) RewritePaths(ctx context.Context, sourcePrefix, targetPrefix string, targetFS iceio.WriteFileIO) (RewritePathsResult, error) {
+	t = t.pin()
+
+	sourcePrefix = strings.TrimSuffix(sourcePrefix, "/")
+	targetPrefix = strings.TrimSuffix(targetPrefix, "/")
+	if sourcePrefix == "" || targetPrefix == "" {
+		return RewritePathsResult{}, fmt.Errorf("%w: source and target prefixes must not be empty",
+			iceberg.ErrInvalidArgument)
+	}
+
+	fs, err := t.FS(ctx)
+	if err != nil {
+		return RewritePathsResult{}, err
+	}
+
+	e := &exporter{
+		meta:      t.Metadata(),
+		srcFS:     fs,
+		dstFS:     targetFS,
+		src:       sourcePrefix,
+		dst:       targetPrefix,
+		rewritten: make(map[string]int64),
+	}
+
+	if err := e.checkRelocatable(t.Metadata().Location()); err != nil {
+		return RewritePathsResult{}, err
+	}
+
+	e.props = e.relocateProperties()
+	if e.loc, err = LoadLocationProvider(e.relocate(t.Metadata().Location()), e.props); err != nil {
+		return RewritePathsResult{}, err
+	}
+
+	// first collect the manifests of every snapshot, shared manifests only
+	// once, and the files they reference
+	type snapshotManifests struct {
+		snap      *Snapshot
+		manifests []iceberg.ManifestFile
+	}
+
+	var (
+		result      RewritePathsResult
+		snapshots   []snapshotManifests
+		manifests   = make(map[string][]iceberg.ManifestEntry)
+		seen        = make(map[string]struct{})
+		posDeletes  []iceberg.DataFile
+		sourceSnaps = t.Metadata().Snapshots()
+	)
+
+	for i := range sourceSnaps {
+		snap := &sourceSnaps[i]
+		if err := e.checkRelocatable(snap.ManifestList); err != nil {
+			return RewritePathsResult{}, err
+		}
+
+		mfs, err := snap.Manifests(fs)
+		if err != nil {
+			return RewritePathsResult{}, err
+		}
+		snapshots = append(snapshots, snapshotManifests{snap: snap, manifests: mfs})
+
+		for _, m := range mfs {
+			if _, ok := manifests[m.FilePath()]; ok {
+				continue
+			}
+
+			if len(m.KeyMetadata()) > 0 {
+				return RewritePathsResult{}, fmt.Errorf("%w: rewriting encrypted manifest %s",
+					iceberg.ErrNotImplemented, m.FilePath())
+			}
+
+			if err := e.checkRelocatable(m.FilePath()); err != nil {
+				return RewritePathsResult{}, err
+			}
+
+			entries, err := m.FetchEntries(fs, false)
+			if err != nil {
+				return RewritePathsResult{}, err
+			}
+			manifests[m.FilePath()] = entries
+
+			for _, entry := range entries {
+				df := entry.DataFile()
+				if err := e.checkDataFile(df); err != nil {
+					return RewritePathsResult{}, err
+				}
+
+				// removed files may already have been cleaned up
+				if _, ok := seen[df.FilePath()]; ok || entry.Status() == iceberg.EntryStatusDELETED {
+					continue
+				}
+				seen[df.FilePath()] = struct{}{}
+
+				if df.ContentType() == iceberg.EntryContentPosDeletes {
+					posDeletes = append(posDeletes, df)
+				} else {
+					result.FilesToCopy = append(result.FilesToCopy,
+						FileCopy{Source: df.FilePath(), Target: e.relocate(df.FilePath())})
+				}
+			}
+		}
+	}
+
+	var g errgroup.Group
+	g.SetLimit(config.EnvConfig.MaxWorkers)
+	for _, df := range posDeletes {
+		g.Go(func() error {
+			_, err := e.rewritePositionDeletes(ctx, df)
+
+			return err
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return RewritePathsResult{}, err
+	}
+	result.RewrittenFiles += len(posDeletes)
+
+	relocated := make(map[string]iceberg.ManifestFile, len(manifests))
+	newSnapshots := make([]Snapshot, 0, len(snapshots))
+	for _, s := range snapshots {
+		mfs := make([]iceberg.ManifestFile, len(s.manifests))
+		for i, m := range s.manifests {
+			if mf, ok := relocated[m.FilePath()]; ok {
+				mfs[i] = mf
+
+				continue
+			}
+
+			path := e.relocate(m.FilePath())
+			written, err := e.writeManifest(path, s.snap, m, manifests[m.FilePath()])
+			if err != nil {
+				return RewritePathsResult{}, err
+			}
+			result.RewrittenFiles++
+
+			mfs[i] = iceberg.RelocateManifestFile(m, path, written.Length())
+			relocated[m.FilePath()] = mfs[i]
+		}
+
+		snap := *s.snap
+		snap.ManifestList = e.relocate(snap.ManifestList)
+		if err := e.writeManifestList(snap.ManifestList, &snap, snap.ParentSnapshotID, mfs); err != nil {
+			return RewritePathsResult{}, err
+		}
+		result.RewrittenFiles++
+		newSnapshots = append(newSnapshots, snap)
+	}
+
+	bldr, err := MetadataBuilderFromBase(t.Metadata(), "")
+	if err != nil {
+		return RewritePathsResult{}, err
+	}
+	bldr.loc = e.relocate(t.Metadata().Location())
+	bldr.props = e.props
+	bldr.metadataLog = nil
+	bldr.snapshotList = newSnapshots
+
+	meta, err := bldr.Build()
+	if err != nil {
+		return RewritePathsResult{}, err
+	}
+
+	if isWithinLocation(t.MetadataLocation(), sourcePrefix) {
+		result.MetadataLocation = e.relocate(t.MetadataLocation())
+	} else if result.MetadataLocation, err = e.loc.NewTableMetadataFileLocation(0); err != nil {
+		return RewritePathsResult{}, err
+	}
+
+	if err := e.writeMetadataFile(result.MetadataLocation, meta); err != nil {
+		return RewritePathsResult{}, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,72 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRewritePaths(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.ToSlash(t.TempDir())
+	src, dst := dir+"/src", dir+"/dst"
+
+	tbl := newExportTestTable(t, src)
+	firstSnapshot := tbl.Metadata().Snapshots()[0].SnapshotID
+
+	_, err := tbl.RewritePaths(ctx, dir+"/elsewhere", dst, iceio.LocalFS{})
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+
+	res, err := tbl.RewritePaths(ctx, src+"/", dst, iceio.LocalFS{})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(res.MetadataLocation, dst+"/metadata/"))
+	// three manifest lists, three manifests and a position delete file
+	assert.Equal(t, 7, res.RewrittenFiles)
+	require.Len(t, res.FilesToCopy, 2)
+
+	for _, f := range res.FilesToCopy {
+		assert.Equal(t, dst+strings.TrimPrefix(f.Source, src), f.Target)
+		data, err := os.ReadFile(f.Source)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(f.Target, data, 0o644))
+	}
+	require.NoError(t, os.RemoveAll(src))
+
+	relocated, err := NewFromLocation(ctx, Identifier{"default", "moved"}, res.MetadataLocation,
+		func(context.Context) (iceio.IO, error) { return iceio.LocalFS{}, nil }, &inMemoryCatalog{})
+	require.NoError(t, err)
+	assert.Equal(t, dst, relocated.Location())
+	assert.Len(t, relocated.Metadata().Snapshots(), 3)
+
+	for f, err := range relocated.ReachableFiles(ctx) {
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(f.Path, dst+"/"), f.Path)
+	}
+
+	assert.Equal(t, []int64{0, 2, 3, 4}, scanIDs(t, relocated))
+	assert.Equal(t, []int64{0, 1, 2}, scanIDs(t, relocated, WithSnapshotID(firstSnapshot)))
+}
@@ -0,0 +1,367 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table/internal"
+)
+
+type rewritePositionDeletesConfig struct {
+	minInputFiles  int
+	targetFileSize int64
+}
+
+// RewritePositionDeletesOption configures Transaction.RewritePositionDeletes.
+type RewritePositionDeletesOption func(*rewritePositionDeletesConfig)
+
+// WithRewriteMinInputFiles sets the minimum number of small position
+// delete files that a partition must have for them to be rewritten.
+// It defaults to 2.
+func WithRewriteMinInputFiles(n int) RewritePositionDeletesOption {
+	return func(cfg *rewritePositionDeletesConfig) {
+		cfg.minInputFiles = n
+	}
+}
+
+// WithRewriteTargetFileSize sets the size in bytes of the rewritten
+// position delete files. Files smaller than the target size are
+// considered for rewriting. It defaults to the table's
+// write.delete.target-file-size-bytes property.
+func WithRewriteTargetFileSize(size int64) RewritePositionDeletesOption {
+	return func(cfg *rewritePositionDeletesConfig) {
+		cfg.targetFileSize = size
+	}
+}
+
+// RewritePositionDeletesResult describes the changes made by
+// Transaction.RewritePositionDeletes.
+type RewritePositionDeletesResult struct {
+	// RewrittenDeleteFiles are the position delete files that were
+	// replaced by AddedDeleteFiles. For format version 3 tables they
+	// include the deletion vectors that were merged into the added ones.
+	RewrittenDeleteFiles []iceberg.DataFile
+	// AddedDeleteFiles are the consolidated position delete files, or
+	// the deletion vectors for format version 3 tables.
+	AddedDeleteFiles []iceberg.DataFile
+	// DanglingDeleteFiles are the position delete files that were
+	// removed because none of the data files they reference are live.
+	DanglingDeleteFiles []iceberg.DataFile
+	// DanglingDeletes is the number of deletes dropped from rewritten
+	// files because the data files they reference are no longer live.
+	DanglingDeletes int64
+}
+
+// positionDeleteGroup holds the position delete files of a single
+// partition of a partition spec.
+type positionDeleteGroup struct {
+	specID    int
+	partition map[int]any
+	files     []iceberg.DataFile
+}
+
+// RewritePositionDeletes compacts the small position delete files of
+// each partition into files of the target size, and removes delete files
+// whose referenced data files are no longer part of the table. Deletes
+// that reference removed data files are dropped from the rewritten
+// files. The deleted rows stored in delete files are not carried over
+// to the rewritten files.
+//
+// Format version 3 tables track position deletes as deletion vectors,
+// so the live deletes of all their position delete files are converted
+// to deletion vectors, merged with the deletion vectors the data files
+// already have, regardless of the minimum input files and target file
+// size. Deletion vectors that are not merged are left as they are.
+//
+// If nothing needs to be rewritten, no snapshot is created.
+func (t *Transaction) RewritePositionDeletes(ctx context.Context, snapshotProps iceberg.Properties, opts ...RewritePositionDeletesOption) (RewritePositionDeletesResult, error) {
+	cfg := rewritePositionDeletesConfig{
+		minInputFiles: 2,
+		targetFileSize: int64(t.meta.props.GetInt(WriteDeleteTargetFileSizeBytesKey,
+			WriteDeleteTargetFileSizeBytesDefault)),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.targetFileSize <= 0 {
+		return RewritePositionDeletesResult{}, fmt.Errorf("%w: target file size must be positive, got %d",
+			iceberg.ErrInvalidArgument, cfg.targetFileSize)
+	}
+
+	var result RewritePositionDeletesResult
+
+	snap := t.meta.currentSnapshot()
+	if snap == nil {
+		return result, nil
+	}
+
+	fs, err := t.fs(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	manifests, err := snap.Manifests(fs)
+	if err != nil {
+		return result, err
+	}
+
+	liveDataFiles := make(set[string])
+	var deleteFiles []iceberg.DataFile
+	for _, m := range manifests {
+		entries, err := m.FetchEntries(fs, true)
+		if err != nil {
+			return result, err
+		}
+
+		for _, e := range entries {
+			switch df := e.DataFile(); df.ContentType() {
+			case iceberg.EntryContentData:
+				liveDataFiles[df.FilePath()] = struct{}{}
+			case iceberg.EntryContentPosDeletes:
+				// deletion vectors are stored in puffin files and are
+				// not rewritten
+				if df.ContentOffset() == nil {
+					deleteFiles = append(deleteFiles, df)
+				}
+			}
+		}
+	}
+
+	schema := t.meta.CurrentSchema()
+	groups := make(map[string]*positionDeleteGroup)
+	for _, df := range deleteFiles {
+		if path, ok := positionDeleteFileTarget(df); ok {
+			if _, live := liveDataFiles[path]; !live {
+				result.DanglingDeleteFiles = append(result.DanglingDeleteFiles, df)
+
+				continue
+			}
+		}
+
+		if t.meta.formatVersion < 3 && df.FileSizeBytes() >= cfg.targetFileSize {
+			continue
+		}
+
+		spec, err := t.meta.GetSpecByID(int(df.SpecID()))
+		if err != nil {
+			return result, err
+		}
+
+		key := fmt.Sprintf("%d/%s", df.SpecID(),
+			spec.PartitionToPath(getPartitionRecord(df, spec.PartitionType(schema)), schema))
+		grp, ok := groups[key]
+		if !ok {
+			grp = &positionDeleteGroup{specID: int(df.SpecID()), partition: df.Partition()}
+			groups[key] = grp
+		}
+		grp.files = append(grp.files, df)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(groups)) {
+		grp := groups[key]
+
+		var (
+			added    []iceberg.DataFile
+			dangling int64
+		)
+		if t.meta.formatVersion >= 3 {
+			added, dangling, err = t.convertPositionDeleteGroup(ctx, grp, liveDataFiles)
+		} else {
+			if len(grp.files) < cfg.minInputFiles {
+				continue
+			}
+			added, dangling, err = t.rewritePositionDeleteGroup(ctx, grp, liveDataFiles, cfg.targetFileSize)
+		}
+		if err != nil {
+			return result, err
+		}
+
+		result.RewrittenDeleteFiles = append(result.RewrittenDeleteFiles, grp.files...)
+		result.AddedDeleteFiles = append(result.AddedDeleteFiles, added...)
+		result.DanglingDeletes += dangling
+	}
+
+	if t.meta.formatVersion >= 3 && len(result.AddedDeleteFiles) > 0 {
+		merged, err := t.mergedDeletionVectors(ctx, result.AddedDeleteFiles)
+		if err != nil {
+			return result, err
+		}
+		result.RewrittenDeleteFiles = append(result.RewrittenDeleteFiles, merged...)
+	}
+
+	if len(result.RewrittenDeleteFiles) == 0 && len(result.DanglingDeleteFiles) == 0 {
+		return result, nil
+	}
+
+	commitUUID := t.newCommitUUID()
+	updater := t.updateSnapshot(fs, snapshotProps, OpReplace).mergeOverwrite(&commitUUID)
+	for _, df := range slices.Concat(result.RewrittenDeleteFiles, result.DanglingDeleteFiles) {
+		updater.deleteDataFile(df)
+	}
+	for _, df := range result.AddedDeleteFiles {
+		updater.appendDataFile(df)
+	}
+
+	updates, reqs, err := updater.commit()
+	if err != nil {
+		return result, err
+	}
+
+	return result, t.apply(updates, reqs)
+}
+
+// livePositions reads the deletes of the group's files, returning the
+// deleted positions of each live data file and the number of dangling
+// deletes, which reference data files that are no longer live.
+func (t *Transaction) livePositions(ctx context.Context, grp *positionDeleteGroup, liveDataFiles set[string]) (map[string][]int64, int64, error) {
+	fs, err := t.fs(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	positions := make(map[string][]int64)
+	var dangling int64
+	for _, df := range grp.files {
+		deletes, err := readDeletes(ctx, fs, df)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for path, chunked := range deletes {
+			_, live := liveDataFiles[path]
+			for _, chunk := range chunked.Chunks() {
+				if live {
+					positions[path] = append(positions[path], chunk.(*array.Int64).Int64Values()...)
+				} else {
+					dangling += int64(chunk.Len())
+				}
+			}
+			chunked.Release()
+		}
+	}
+
+	return positions, dangling, nil
+}
+
+// rewritePositionDeleteGroup writes the live deletes of the group's
+// files to new delete files, returning the files written and the number
+// of dangling deletes that were dropped.
+func (t *Transaction) rewritePositionDeleteGroup(ctx context.Context, grp *positionDeleteGroup, liveDataFiles set[string], targetFileSize int64) ([]iceberg.DataFile, int64, error) {
+	positions, dangling, err := t.livePositions(ctx, grp, liveDataFiles)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	staged, err := t.StagedTable()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	w, err := NewPositionDeleteWriter(ctx, staged.Table,
+		WithDeletePartition(grp.specID, grp.partition),
+		WithDeleteTargetFileSize(targetFileSize))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, path := range slices.Sorted(maps.Keys(positions)) {
+		pos := positions[path]
+		slices.Sort(pos)
+		for _, p := range slices.Compact(pos) {
+			if err := w.Write(path, p); err != nil {
+				_, closeErr := w.Close()
+
+				return nil, 0, errors.Join(err, closeErr)
+			}
+		}
+	}
+
+	files, err := w.Close()
+
+	return files, dangling, err
+}
+
+// convertPositionDeleteGroup writes the live deletes of the group's
+// position delete files as deletion vectors, merged with the existing
+// deletion vectors of the data files, returning the deletion vectors
+// written and the number of dangling deletes that were dropped.
+func (t *Transaction) convertPositionDeleteGroup(ctx context.Context, grp *positionDeleteGroup, liveDataFiles set[string]) ([]iceberg.DataFile, int64, error) {
+	positions, dangling, err := t.livePositions(ctx, grp, liveDataFiles)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	staged, err := t.StagedTable()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	w, err := NewDeletionVectorWriter(ctx, staged.Table, WithDeletePartition(grp.specID, grp.partition))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for path, pos := range positions {
+		for _, p := range pos {
+			if err := w.Delete(path, p); err != nil {
+				_, closeErr := w.Close()
+
+				return nil, 0, errors.Join(err, closeErr)
+			}
+		}
+	}
+
+	files, err := w.Close()
+
+	return files, dangling, err
+}
+
+// mergedDeletionVectors returns the deletion vectors of the table for
+// the data files referenced by the deletion vectors in added, which were
+// merged into them and must be removed with them.
+func (t *Transaction) mergedDeletionVectors(ctx context.Context, added []iceberg.DataFile) ([]iceberg.DataFile, error) {
+	staged, err := t.StagedTable()
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make(map[string]*internal.PositionBitmap, len(added))
+	for _, df := range added {
+		targets[*df.ReferencedDataFile()] = nil
+	}
+
+	previous, err := staged.deletionVectors(ctx, targets)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := make([]iceberg.DataFile, 0, len(previous))
+	for _, path := range slices.Sorted(maps.Keys(previous)) {
+		merged = append(merged, previous[path])
+	}
+
+	return merged, nil
+}
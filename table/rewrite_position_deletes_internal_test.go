@@ -0,0 +1,239 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type inMemoryCatalog struct {
+	metadata Metadata
+}
+
+func (c *inMemoryCatalog) LoadTable(context.Context, Identifier) (*Table, error) {
+	return nil, nil
+}
+
+func (c *inMemoryCatalog) CommitTable(_ context.Context, _ Identifier, _ []Requirement, updates []Update) (Metadata, string, error) {
+	meta, err := UpdateTableMetadata(c.metadata, updates, "")
+	if err != nil {
+		return nil, "", err
+	}
+	c.metadata = meta
+
+	return meta, "", nil
+}
+
+// commitDeleteFiles adds the delete files to the table in a new snapshot.
+func commitDeleteFiles(t *testing.T, tbl *Table, files []iceberg.DataFile) *Table {
+	ctx := context.Background()
+	fs, err := tbl.FS(ctx)
+	require.NoError(t, err)
+
+	txn := tbl.NewTransaction()
+	updater := txn.updateSnapshot(fs, nil, OpOverwrite).mergeOverwrite(nil)
+	for _, df := range files {
+		updater.appendDataFile(df)
+	}
+	updates, reqs, err := updater.commit()
+	require.NoError(t, err)
+	require.NoError(t, txn.apply(updates, reqs))
+
+	tbl, err = txn.Commit(ctx)
+	require.NoError(t, err)
+
+	return tbl
+}
+
+func writePositionDeletes(t *testing.T, tbl *Table, deletes ...any) []iceberg.DataFile {
+	w, err := NewPositionDeleteWriter(context.Background(), tbl)
+	require.NoError(t, err)
+	for i := 0; i < len(deletes); i += 2 {
+		require.NoError(t, w.Write(deletes[i].(string), int64(deletes[i+1].(int))))
+	}
+	files, err := w.Close()
+	require.NoError(t, err)
+
+	return files
+}
+
+// newPositionDeletesTable creates a format version 2 table with two data
+// files of three rows each, returning the table and the paths of its data
+// files, in the order they were appended.
+func newPositionDeletesTable(t *testing.T) (*Table, []string) {
+	ctx := context.Background()
+	loc := filepath.ToSlash(t.TempDir())
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true})
+	meta, err := NewMetadata(sc, iceberg.UnpartitionedSpec, UnsortedSortOrder, loc,
+		iceberg.Properties{PropertyFormatVersion: "2"})
+	require.NoError(t, err)
+
+	tbl := New(Identifier{"default", "deletes"}, meta, loc+"/metadata/v1.metadata.json",
+		func(context.Context) (iceio.IO, error) { return iceio.LocalFS{}, nil },
+		&inMemoryCatalog{meta})
+
+	fs, err := tbl.FS(ctx)
+	require.NoError(t, err)
+
+	arrSchema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	var dataPaths []string
+	for _, rows := range []string{`[{"id": 0}, {"id": 1}, {"id": 2}]`, `[{"id": 3}, {"id": 4}, {"id": 5}]`} {
+		arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{rows})
+		require.NoError(t, err)
+		tbl, err = tbl.AppendTable(ctx, arrTbl, 3, nil)
+		require.NoError(t, err)
+		arrTbl.Release()
+
+		for df, err := range tbl.CurrentSnapshot().dataFiles(fs, nil) {
+			require.NoError(t, err)
+			if !slices.Contains(dataPaths, df.FilePath()) {
+				dataPaths = append(dataPaths, df.FilePath())
+			}
+		}
+	}
+	require.Len(t, dataPaths, 2)
+
+	return tbl, dataPaths
+}
+
+func TestRewritePositionDeletes(t *testing.T) {
+	ctx := context.Background()
+	tbl, dataPaths := newPositionDeletesTable(t)
+	slices.Sort(dataPaths)
+	loc := tbl.Location()
+	fs, err := tbl.FS(ctx)
+	require.NoError(t, err)
+
+	// a delete file for a data file that was never part of the table, and
+	// two small delete files where one references a missing data file
+	missing := loc + "/data/0-missing.parquet"
+	dangling := writePositionDeletes(t, tbl, missing, 0)
+	tbl = commitDeleteFiles(t, tbl, slices.Concat(
+		dangling,
+		writePositionDeletes(t, tbl, dataPaths[0], 0),
+		writePositionDeletes(t, tbl, missing, 5, dataPaths[0], 2, dataPaths[1], 0, dataPaths[1], 2)))
+
+	t.Run("min input files", func(t *testing.T) {
+		txn := tbl.NewTransaction()
+		result, err := txn.RewritePositionDeletes(ctx, nil, WithRewriteMinInputFiles(3))
+		require.NoError(t, err)
+
+		assert.Empty(t, result.RewrittenDeleteFiles)
+		assert.Empty(t, result.AddedDeleteFiles)
+		assert.Len(t, result.DanglingDeleteFiles, 1)
+	})
+
+	txn := tbl.NewTransaction()
+	result, err := txn.RewritePositionDeletes(ctx, nil)
+	require.NoError(t, err)
+
+	assert.Len(t, result.RewrittenDeleteFiles, 2)
+	require.Len(t, result.DanglingDeleteFiles, 1)
+	assert.Equal(t, dangling[0].FilePath(), result.DanglingDeleteFiles[0].FilePath())
+	assert.EqualValues(t, 1, result.DanglingDeletes)
+	require.Len(t, result.AddedDeleteFiles, 1)
+	assert.EqualValues(t, 4, result.AddedDeleteFiles[0].Count())
+
+	tbl, err = txn.Commit(ctx)
+	require.NoError(t, err)
+
+	snap := tbl.CurrentSnapshot()
+	assert.Equal(t, OpReplace, snap.Summary.Operation)
+	assert.Equal(t, "1", snap.Summary.Properties["total-delete-files"])
+	assert.Equal(t, "4", snap.Summary.Properties["total-position-deletes"])
+
+	var deleteFiles []iceberg.DataFile
+	for df, err := range snap.dataFiles(fs, set[iceberg.ManifestEntryContent]{iceberg.EntryContentPosDeletes: {}}) {
+		require.NoError(t, err)
+		deleteFiles = append(deleteFiles, df)
+	}
+	// the manifest with the removed delete files records them as deleted
+	assert.Len(t, deleteFiles, 4)
+
+	assert.Equal(t, []int64{1, 4}, scanIDs(t, tbl))
+}
+
+func TestRewritePositionDeletesDeletionVectors(t *testing.T) {
+	ctx := context.Background()
+	tbl, dataPaths := newPositionDeletesTable(t)
+	sorted := slices.Sorted(slices.Values(dataPaths))
+	missing := tbl.Location() + "/data/0-missing.parquet"
+	dangling := writePositionDeletes(t, tbl, missing, 0)
+	tbl = commitDeleteFiles(t, tbl, slices.Concat(
+		dangling,
+		writePositionDeletes(t, tbl, sorted[0], 0),
+		writePositionDeletes(t, tbl, missing, 5, sorted[0], 2, sorted[1], 0, sorted[1], 2)))
+
+	txn := tbl.NewTransaction()
+	require.NoError(t, txn.apply([]Update{NewUpgradeFormatVersionUpdate(3)}, nil))
+	tbl, err := txn.Commit(ctx)
+	require.NoError(t, err)
+
+	// an existing deletion vector, merged with the converted deletes
+	w, err := NewDeletionVectorWriter(ctx, tbl)
+	require.NoError(t, err)
+	require.NoError(t, w.Delete(dataPaths[1], 1))
+	existing, err := w.Close()
+	require.NoError(t, err)
+	tbl = commitDeleteFiles(t, tbl, existing)
+
+	txn = tbl.NewTransaction()
+	result, err := txn.RewritePositionDeletes(ctx, nil, WithRewriteMinInputFiles(10))
+	require.NoError(t, err)
+
+	require.Len(t, result.DanglingDeleteFiles, 1)
+	assert.Equal(t, dangling[0].FilePath(), result.DanglingDeleteFiles[0].FilePath())
+	assert.EqualValues(t, 1, result.DanglingDeletes)
+
+	rewritten := make([]string, len(result.RewrittenDeleteFiles))
+	for i, df := range result.RewrittenDeleteFiles {
+		rewritten[i] = df.FilePath()
+	}
+	assert.Len(t, rewritten, 3)
+	assert.Contains(t, rewritten, existing[0].FilePath())
+
+	counts := make(map[string]int64)
+	for _, df := range result.AddedDeleteFiles {
+		assert.Equal(t, iceberg.PuffinFile, df.FileFormat())
+		require.NotNil(t, df.ReferencedDataFile())
+		counts[*df.ReferencedDataFile()] = df.Count()
+	}
+	assert.Equal(t, map[string]int64{dataPaths[0]: 2, dataPaths[1]: 3}, counts)
+
+	tbl, err = txn.Commit(ctx)
+	require.NoError(t, err)
+
+	snap := tbl.CurrentSnapshot()
+	assert.Equal(t, OpReplace, snap.Summary.Operation)
+	assert.Equal(t, "2", snap.Summary.Properties["total-delete-files"])
+	assert.Equal(t, "5", snap.Summary.Properties["total-position-deletes"])
+	assert.Equal(t, []int64{1}, scanIDs(t, tbl))
+}
@@ -21,7 +21,6 @@ import (
 	"context"
 	"fmt"
 	"iter"
-	"net/url"
 	"sync"
 	"sync/atomic"
 
@@ -65,32 +64,33 @@ func NewWriterFactory(rootLocation string, args recordWritingArgs, meta *Metadat
 // them to data files when the target file size is reached, implementing a rolling
 // file strategy to manage file sizes.
 type RollingDataWriter struct {
-	partitionKey    string
-	partitionID     int          // unique ID for this partition
-	fileCount       atomic.Int64 // counter for files in this partition
-	recordCh        chan arrow.RecordBatch
-	errorCh         chan error
-	factory         *writerFactory
-	partitionValues map[int]any
-	ctx             context.Context
-	cancel          context.CancelFunc
-	wg              sync.WaitGroup
+	key           string // key of the writer in its factory
+	partitionKey  string
+	partitionID   int          // unique ID for this partition
+	fileCount     atomic.Int64 // counter for files in this partition
+	recordCh      chan arrow.RecordBatch
+	errorCh       chan error
+	factory       *writerFactory
+	partitionData iceberg.PartitionData
+	ctx           context.Context
+	cancel        context.CancelFunc
+	wg            sync.WaitGroup
 }
 
 // NewRollingDataWriter creates a new RollingDataWriter for the specified partition
-// with the given partition values.
-func (w *writerFactory) NewRollingDataWriter(ctx context.Context, partition string, partitionValues map[int]any, outputDataFilesCh chan<- iceberg.DataFile) *RollingDataWriter {
+// with the given partition tuple.
+func (w *writerFactory) NewRollingDataWriter(ctx context.Context, partition string, partitionData iceberg.PartitionData, outputDataFilesCh chan<- iceberg.DataFile) *RollingDataWriter {
 	ctx, cancel := context.WithCancel(ctx)
 	partitionID := int(w.partitionIDCounter.Add(1) - 1)
 	writer := &RollingDataWriter{
-		partitionKey:    partition,
-		partitionID:     partitionID,
-		recordCh:        make(chan arrow.RecordBatch, 64),
-		errorCh:         make(chan error, 1),
-		factory:         w,
-		partitionValues: partitionValues,
-		ctx:             ctx,
-		cancel:          cancel,
+		partitionKey:  partition,
+		partitionID:   partitionID,
+		recordCh:      make(chan arrow.RecordBatch, 64),
+		errorCh:       make(chan error, 1),
+		factory:       w,
+		partitionData: partitionData,
+		ctx:           ctx,
+		cancel:        cancel,
 	}
 
 	writer.wg.Add(1)
@@ -99,11 +99,14 @@ func (w *writerFactory) NewRollingDataWriter(ctx context.Context, partition stri
 	return writer
 }
 
-func (w *writerFactory) getOrCreateRollingDataWriter(ctx context.Context, partition string, partitionValues map[int]any, outputDataFilesCh chan<- iceberg.DataFile) (*RollingDataWriter, error) {
+// getOrCreateRollingDataWriter returns the writer stored under key,
+// creating a writer for the partition if there is none. Writers of the
+// same partition may be stored under different keys.
+func (w *writerFactory) getOrCreateRollingDataWriter(ctx context.Context, key, partition string, partitionData iceberg.PartitionData, outputDataFilesCh chan<- iceberg.DataFile) (*RollingDataWriter, error) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	if existing, ok := w.writers.Load(partition); ok {
+	if existing, ok := w.writers.Load(key); ok {
 		if writer, ok := existing.(*RollingDataWriter); ok {
 			return writer, nil
 		}
@@ -111,8 +114,9 @@ func (w *writerFactory) getOrCreateRollingDataWriter(ctx context.Context, partit
 		return nil, fmt.Errorf("invalid writer type for partition: %s", partition)
 	}
 
-	writer := w.NewRollingDataWriter(ctx, partition, partitionValues, outputDataFilesCh)
-	w.writers.Store(partition, writer)
+	writer := w.NewRollingDataWriter(ctx, partition, partitionData, outputDataFilesCh)
+	writer.key = key
+	w.writers.Store(key, writer)
 
 	return writer, nil
 }
@@ -179,18 +183,8 @@ func (r *RollingDataWriter) flushToDataFile(batch []arrow.RecordBatch, outputDat
 		})
 	})
 
-	parseDataLoc, err := url.Parse(r.factory.rootLocation)
-	if err != nil {
-		return fmt.Errorf("failed to parse rootLocation: %v", err)
-	}
-
-	partitionMeta := *r.factory.meta
-	if partitionMeta.props == nil {
-		partitionMeta.props = make(map[string]string)
-	}
-	partitionMeta.props[WriteDataPathKey] = parseDataLoc.JoinPath("data").JoinPath(r.partitionKey).String()
-
-	outputDataFiles := writeFiles(r.ctx, r.factory.rootLocation, r.factory.args.fs, &partitionMeta, r.partitionValues, task)
+	outputDataFiles := writeFiles(r.ctx, r.factory.rootLocation, r.factory.args.fs, r.factory.meta,
+		r.factory.args.props, r.partitionKey, r.partitionData, task)
 	for dataFile, err := range outputDataFiles {
 		if err != nil {
 			return err
@@ -212,7 +206,7 @@ func (r *RollingDataWriter) close() {
 
 func (r *RollingDataWriter) closeAndWait() error {
 	r.close()
-	r.factory.writers.Delete(r.partitionKey)
+	r.factory.writers.Delete(r.key)
 	r.wg.Wait()
 
 	select {
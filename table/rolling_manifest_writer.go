@@ -0,0 +1,148 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"errors"
+	"io"
+	"iter"
+	"slices"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/config"
+	"github.com/apache/iceberg-go/internal"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// minManifestFileGroupSize is the smallest number of added files that
+	// is given its own concurrent manifest writer. Smaller appends are
+	// written by a single writer.
+	minManifestFileGroupSize = 10_000
+	// manifestSizeCheckInterval is the number of entries written between
+	// checks of whether a manifest has reached its target size.
+	manifestSizeCheckInterval = 250
+)
+
+// manifestWriterParallelism returns the number of groups that numFiles
+// added files are split into to write their manifests concurrently.
+func manifestWriterParallelism(numFiles int) int {
+	return max(1, min(numFiles/minManifestFileGroupSize, config.EnvConfig.MaxWorkers))
+}
+
+// writeAddedManifests writes manifests of the given content type for spec
+// that track files as added in this snapshot. Large numbers of files are
+// split into groups whose manifests are written concurrently, and every
+// group rolls over to a new manifest once the current one reaches the
+// table's target manifest size. The manifests are returned in the order
+// of the files they contain.
+func (sp *snapshotProducer) writeAddedManifests(spec iceberg.PartitionSpec, content iceberg.ManifestContent, files []iceberg.DataFile) ([]iceberg.ManifestFile, error) {
+	groups := manifestWriterParallelism(len(files))
+	if groups == 1 {
+		return sp.writeRollingManifests(spec, content, slices.Values(files))
+	}
+
+	groupSize := (len(files) + groups - 1) / groups
+	results := make([][]iceberg.ManifestFile, groups)
+
+	var g errgroup.Group
+	for i := range groups {
+		group := files[i*groupSize : min((i+1)*groupSize, len(files))]
+		g.Go(func() (err error) {
+			results[i], err = sp.writeRollingManifests(spec, content, slices.Values(group))
+
+			return err
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return slices.Concat(results...), nil
+}
+
+// writeRollingManifests writes files as added entries to as many
+// manifests as needed to keep each of them around the target manifest
+// size, so that only one manifest per writer is buffered at a time.
+func (sp *snapshotProducer) writeRollingManifests(spec iceberg.PartitionSpec, content iceberg.ManifestContent, files iter.Seq[iceberg.DataFile]) (_ []iceberg.ManifestFile, err error) {
+	targetSize := int64(sp.txn.meta.props.GetInt(ManifestTargetSizeBytesKey, ManifestTargetSizeBytesDefault))
+
+	var (
+		result  []iceberg.ManifestFile
+		wr      *iceberg.ManifestWriter
+		path    string
+		counter *internal.CountingWriter
+		out     io.Closer
+		entries int
+	)
+
+	defer func() {
+		// only reached with an open manifest if writing failed
+		if wr != nil {
+			err = errors.Join(err, wr.Close(), out.Close())
+		}
+	}()
+
+	finish := func() error {
+		// close the writer to force a flush and ensure counter.Count is accurate
+		if err := wr.Close(); err != nil {
+			return err
+		}
+
+		mf, err := wr.ToManifestFile(path, counter.Count)
+		if err != nil {
+			return err
+		}
+
+		wr, err = nil, out.Close()
+		result = append(result, mf)
+
+		return err
+	}
+
+	for df := range files {
+		if wr == nil {
+			wr, path, counter, out, err = sp.newManifestWriter(spec, content)
+			if err != nil {
+				return nil, err
+			}
+			entries = 0
+		}
+
+		if err := wr.Add(iceberg.NewManifestEntry(iceberg.EntryStatusADDED, &sp.snapshotID,
+			nil, nil, df)); err != nil {
+			return nil, err
+		}
+
+		entries++
+		if entries%manifestSizeCheckInterval == 0 && counter.Count >= targetSize {
+			if err := finish(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if wr != nil {
+		if err := finish(); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,149 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkedAppendManifests(t *testing.T) {
+	ctx := context.Background()
+
+	newTable := func(t *testing.T, props iceberg.Properties) *table.Table {
+		return newTestTable(t, withTestProperties(props))
+	}
+
+	dataFiles := func(t *testing.T, prefix string, n int) []iceberg.DataFile {
+		files := make([]iceberg.DataFile, n)
+		for i := range files {
+			files[i] = mustDataFile(t, *iceberg.UnpartitionedSpec,
+				fmt.Sprintf("s3://bucket/%s/data-%05d.parquet", prefix, i), nil, 10, 100)
+		}
+
+		return files
+	}
+
+	manifestCounts := func(t *testing.T, tbl *table.Table) []int32 {
+		fs, err := tbl.FS(ctx)
+		require.NoError(t, err)
+		manifests, err := tbl.CurrentSnapshot().Manifests(fs)
+		require.NoError(t, err)
+
+		counts := make([]int32, len(manifests))
+		for i, m := range manifests {
+			counts[i] = m.AddedDataFiles() + m.ExistingDataFiles()
+		}
+
+		return counts
+	}
+
+	t.Run("rolls over at the target size", func(t *testing.T) {
+		tbl := newTable(t, iceberg.Properties{table.ManifestTargetSizeBytesKey: "1"})
+
+		txn := tbl.NewTransaction()
+		require.NoError(t, txn.AddDataFiles(ctx, dataFiles(t, "roll", 600), nil))
+		tbl, err := txn.Commit(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, []int32{250, 250, 100}, manifestCounts(t, tbl))
+	})
+
+	t.Run("writes large appends concurrently", func(t *testing.T) {
+		tbl := newTable(t, iceberg.Properties{})
+
+		txn := tbl.NewTransaction()
+		require.NoError(t, txn.AddDataFiles(ctx, dataFiles(t, "bulk", 25_000), nil))
+		tbl, err := txn.Commit(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, []int32{12_500, 12_500}, manifestCounts(t, tbl))
+		assert.Equal(t, "25000", tbl.CurrentSnapshot().Summary.Properties["added-data-files"])
+	})
+
+	t.Run("streams files into manifests", func(t *testing.T) {
+		tbl := newTable(t, iceberg.Properties{table.ManifestTargetSizeBytesKey: "1"})
+
+		txn := tbl.NewTransaction()
+		files := dataFiles(t, "stream", 600)
+		var seq iter.Seq2[iceberg.DataFile, error] = func(yield func(iceberg.DataFile, error) bool) {
+			for _, df := range files {
+				if !yield(df, nil) {
+					return
+				}
+			}
+		}
+		require.NoError(t, txn.AddDataFilesSeq(ctx, seq, nil))
+		tbl, err := txn.Commit(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, []int32{250, 250, 100}, manifestCounts(t, tbl))
+		summary := tbl.CurrentSnapshot().Summary.Properties
+		assert.Equal(t, "600", summary["added-data-files"])
+		assert.Equal(t, "6000", summary["added-records"])
+		assert.Equal(t, "6000", summary["total-records"])
+
+		// files that are already part of the table are rejected
+		txn = tbl.NewTransaction()
+		err = txn.AddDataFilesSeq(ctx, func(yield func(iceberg.DataFile, error) bool) {
+			yield(files[10], nil)
+		}, nil)
+		assert.ErrorContains(t, err, "already referenced by table")
+
+		// as are duplicates within the stream and errors of the stream
+		txn = tbl.NewTransaction()
+		more := dataFiles(t, "more", 2)
+		err = txn.AddDataFilesSeq(ctx, func(yield func(iceberg.DataFile, error) bool) {
+			_ = yield(more[0], nil) && yield(more[1], nil) && yield(more[0], nil)
+		}, nil)
+		assert.ErrorContains(t, err, "must be unique")
+
+		err = txn.AddDataFilesSeq(ctx, func(yield func(iceberg.DataFile, error) bool) {
+			_ = yield(more[0], nil) && yield(nil, assert.AnError)
+		}, nil)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("merges manifests on commit", func(t *testing.T) {
+		tbl := newTable(t, iceberg.Properties{
+			table.ManifestMergeEnabledKey:  "true",
+			table.ManifestMinMergeCountKey: "3",
+		})
+
+		for i := range 3 {
+			txn := tbl.NewTransaction()
+			require.NoError(t, txn.AddDataFiles(ctx, dataFiles(t, fmt.Sprintf("merge-%d", i), 5), nil))
+			var err error
+			tbl, err = txn.Commit(ctx)
+			require.NoError(t, err)
+
+			if i < 2 {
+				assert.Len(t, manifestCounts(t, tbl), i+1)
+			}
+		}
+
+		assert.Equal(t, []int32{15}, manifestCounts(t, tbl))
+	})
+}
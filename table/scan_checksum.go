@@ -0,0 +1,110 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"strconv"
+
+	"github.com/apache/arrow-go/v18/arrow"
+)
+
+// ScanChecksum is a digest of the rows read by a scan, see Scan.Checksum.
+type ScanChecksum struct {
+	// Rows is the number of rows read by the scan.
+	Rows int64
+	// Sum is the SHA-256 digest of the projected schema and of the rows.
+	Sum [sha256.Size]byte
+}
+
+func (c ScanChecksum) String() string { return hex.EncodeToString(c.Sum[:]) }
+
+// Checksum reads the rows of the scan in reproducible order, see
+// WithReproducibleOrder, and returns a digest of their contents. Values
+// are digested by their string form, so the checksum does not depend on
+// how the rows are split into batches nor on the Arrow types used to read
+// them, such as large or dictionary encoded types. Two scans returning the
+// same rows in the same order with the same projected schema have the
+// same checksum.
+func (scan *Scan) Checksum(ctx context.Context) (ScanChecksum, error) {
+	ordered := *scan
+	ordered.reproducibleOrder = true
+
+	projection, err := ordered.Projection()
+	if err != nil {
+		return ScanChecksum{}, err
+	}
+
+	_, itr, err := ordered.ToArrowRecords(ctx)
+	if err != nil {
+		return ScanChecksum{}, err
+	}
+
+	var (
+		result ScanChecksum
+		h      = sha256.New()
+	)
+
+	for _, f := range projection.Fields() {
+		writeChecksumValue(h, strconv.Itoa(f.ID))
+		writeChecksumValue(h, f.Name)
+		writeChecksumValue(h, f.Type.String())
+	}
+
+	for rec, err := range itr {
+		if err != nil {
+			return ScanChecksum{}, err
+		}
+
+		writeChecksumRecord(h, rec)
+		result.Rows += rec.NumRows()
+		rec.Release()
+	}
+
+	h.Sum(result.Sum[:0])
+
+	return result, nil
+}
+
+// writeChecksumRecord writes the values of rec to h row by row.
+func writeChecksumRecord(h hash.Hash, rec arrow.RecordBatch) {
+	cols := rec.Columns()
+	for i := range int(rec.NumRows()) {
+		for _, col := range cols {
+			if col.IsNull(i) {
+				h.Write([]byte{0})
+
+				continue
+			}
+
+			h.Write([]byte{1})
+			writeChecksumValue(h, col.ValueStr(i))
+		}
+	}
+}
+
+// writeChecksumValue writes v to h prefixed by its length, so that
+// consecutive values cannot be confused with each other.
+func writeChecksumValue(h hash.Hash, v string) {
+	h.Write(binary.AppendUvarint(nil, uint64(len(v))))
+	h.Write([]byte(v))
+}
@@ -0,0 +1,92 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanChecksum(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "name", Type: iceberg.PrimitiveTypes.String})
+
+	tbl := newTestTable(t, withTestSchema(sc))
+
+	arrSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	for i := range 3 {
+		arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{
+			fmt.Sprintf(`[{"id": %d, "name": "a"}, {"id": %d, "name": null}, {"id": %d, "name": "c"}]`,
+				i*3+1, i*3+2, i*3+3),
+		})
+		require.NoError(t, err)
+
+		tbl, err = tbl.AppendTable(ctx, arrTbl, 3, nil)
+		arrTbl.Release()
+		require.NoError(t, err)
+	}
+
+	tasks, err := tbl.Scan(table.WithReproducibleOrder()).PlanFiles(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 3)
+	assert.True(t, slices.IsSortedFunc(tasks, func(a, b table.FileScanTask) int {
+		return strings.Compare(a.File.FilePath(), b.File.FilePath())
+	}))
+
+	sum, err := tbl.Scan().Checksum(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 9, sum.Rows)
+	assert.Len(t, sum.String(), 64)
+
+	// the checksum does not depend on concurrency or on the Arrow types read
+	serial, err := tbl.Scan(table.WitMaxConcurrency(1)).Checksum(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, sum, serial)
+
+	large, err := tbl.Scan(table.WithOptions(iceberg.Properties{table.ScanOptionArrowUseLargeTypes: "true"})).Checksum(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, sum, large)
+
+	// it does depend on the rows and on the projection
+	filtered, err := tbl.Scan(table.WithRowFilter(iceberg.NotEqualTo(iceberg.Reference("id"), int64(5)))).Checksum(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 8, filtered.Rows)
+	assert.NotEqual(t, sum.Sum, filtered.Sum)
+
+	projected, err := tbl.Scan(table.WithSelectedFields("id")).Checksum(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 9, projected.Rows)
+	assert.NotEqual(t, sum.Sum, projected.Sum)
+}
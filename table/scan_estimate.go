@@ -0,0 +1,114 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+)
+
+// ScanEstimate is an estimate of the amount of data a scan will read,
+// computed from the manifest metrics of the planned files without
+// reading the files themselves.
+type ScanEstimate struct {
+	// Files is the number of data files the scan will read.
+	Files int
+	// Rows is the estimated number of rows the scan will return. It is
+	// the record count of the data files that may contain matching rows,
+	// less the rows skipped by the scan's offset and capped at the scan's
+	// row limit. Rows in files that only partially match the row filter
+	// are counted in full and deletes are not applied, so this is an
+	// upper bound on the rows the scan returns before deletes.
+	Rows int64
+	// Bytes is the estimated number of bytes the scan will read. When a
+	// data file has column size metrics only the sizes of the projected
+	// columns are counted, otherwise the whole file is. The sizes of the
+	// delete files that apply to the data files are included.
+	Bytes int64
+}
+
+// Estimate plans the files for the scan and returns an estimate of the
+// rows and bytes it would read. Only the manifests are read.
+func (scan *Scan) Estimate(ctx context.Context) (ScanEstimate, error) {
+	tasks, err := scan.PlanFiles(ctx)
+	if err != nil {
+		return ScanEstimate{}, err
+	}
+
+	projection, err := scan.Projection()
+	if err != nil {
+		return ScanEstimate{}, err
+	}
+	fieldIDs := projection.FieldIDs()
+
+	// the record counts of delete files are not subtracted: a delete file
+	// can apply to files the scan does not read, or delete rows that are
+	// also deleted by another file, which would undercount the rows.
+	var (
+		est         = ScanEstimate{Files: len(tasks)}
+		deleteFiles = make(map[string]struct{})
+	)
+
+	for _, task := range tasks {
+		est.Rows += task.File.Count()
+		est.Bytes += projectedSize(task.File.ColumnSizes(), fieldIDs, task.File.FileSizeBytes())
+
+		for _, df := range task.DeleteFiles {
+			if _, ok := deleteFiles[df.FilePath()]; ok {
+				continue
+			}
+			deleteFiles[df.FilePath()] = struct{}{}
+			est.Bytes += df.FileSizeBytes()
+		}
+	}
+
+	est.Rows = max(est.Rows-scan.offset, 0)
+	if scan.limit >= 0 {
+		est.Rows = min(est.Rows, scan.limit)
+	}
+
+	return est, nil
+}
+
+// EstimateRows returns the estimated number of rows the scan will
+// return. See [ScanEstimate.Rows].
+func (scan *Scan) EstimateRows(ctx context.Context) (int64, error) {
+	est, err := scan.Estimate(ctx)
+
+	return est.Rows, err
+}
+
+// EstimateBytes returns the estimated number of bytes the scan will
+// read. See [ScanEstimate.Bytes].
+func (scan *Scan) EstimateBytes(ctx context.Context) (int64, error) {
+	est, err := scan.Estimate(ctx)
+
+	return est.Bytes, err
+}
+
+func projectedSize(columnSizes map[int]int64, fieldIDs []int, fileSize int64) int64 {
+	if len(columnSizes) == 0 {
+		return fileSize
+	}
+
+	var total int64
+	for _, id := range fieldIDs {
+		total += columnSizes[id]
+	}
+
+	return total
+}
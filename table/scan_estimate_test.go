@@ -0,0 +1,137 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanEstimate(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "category", Type: iceberg.PrimitiveTypes.String, Required: true},
+		iceberg.NestedField{ID: 3, Name: "data", Type: iceberg.PrimitiveTypes.String})
+
+	spec := iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 2, FieldID: 1000, Name: "category", Transform: iceberg.IdentityTransform{}})
+
+	tbl := newTestTable(t, withTestSchema(sc), withTestSpec(&spec))
+
+	est, err := tbl.Scan().Estimate(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, est)
+
+	arrSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "category", Type: arrow.BinaryTypes.String},
+		{Name: "data", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{
+		`[{"id": 1, "category": "a", "data": "a long string value"},
+		  {"id": 2, "category": "b", "data": "another long string value"},
+		  {"id": 3, "category": "a", "data": null}]`,
+	})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	tbl, err = tbl.AppendTable(ctx, arrTbl, 3, nil)
+	require.NoError(t, err)
+
+	tasks, err := tbl.Scan().PlanFiles(ctx)
+	require.NoError(t, err)
+	var totalSize int64
+	for _, task := range tasks {
+		totalSize += task.File.FileSizeBytes()
+	}
+
+	est, err = tbl.Scan().Estimate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, est.Files)
+	assert.EqualValues(t, 3, est.Rows)
+	assert.Positive(t, est.Bytes)
+	assert.LessOrEqual(t, est.Bytes, totalSize)
+
+	rows, err := tbl.Scan(table.WithRowFilter(
+		iceberg.EqualTo(iceberg.Reference("category"), "a"))).EstimateRows(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, rows)
+
+	rows, err = tbl.Scan(table.WithRowFilter(
+		iceberg.GreaterThan(iceberg.Reference("id"), int64(10)))).EstimateRows(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, rows)
+
+	rows, err = tbl.Scan(table.WithLimit(1)).EstimateRows(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, rows)
+
+	idBytes, err := tbl.Scan(table.WithSelectedFields("id")).EstimateBytes(ctx)
+	require.NoError(t, err)
+	assert.Positive(t, idBytes)
+	assert.Less(t, idBytes, est.Bytes)
+
+	// two delete files removing the same row must not lower the estimate
+	// below the number of rows the scan returns
+	var dataFile string
+	for _, task := range tasks {
+		if task.File.Partition()[1000] == "a" {
+			dataFile = task.File.FilePath()
+		}
+	}
+	require.NotEmpty(t, dataFile)
+
+	var deleteFiles []iceberg.DataFile
+	for range 2 {
+		w, err := table.NewPositionDeleteWriter(ctx, tbl,
+			table.WithDeletePartition(0, map[int]any{1000: "a"}))
+		require.NoError(t, err)
+		require.NoError(t, w.Write(dataFile, 0))
+		files, err := w.Close()
+		require.NoError(t, err)
+		deleteFiles = append(deleteFiles, files...)
+	}
+
+	txn := tbl.NewTransaction()
+	require.NoError(t, txn.AddDeleteFiles(ctx, deleteFiles, nil))
+	tbl, err = txn.Commit(ctx)
+	require.NoError(t, err)
+
+	scanned, err := tbl.Scan().ToArrowTable(ctx)
+	require.NoError(t, err)
+	defer scanned.Release()
+	require.EqualValues(t, 2, scanned.NumRows())
+
+	est, err = tbl.Scan().Estimate(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, est.Rows)
+
+	_, err = tbl.Scan(table.WithRowFilter(
+		iceberg.EqualTo(iceberg.Reference("missing"), int64(1)))).Estimate(ctx)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidSchema)
+}
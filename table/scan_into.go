@@ -0,0 +1,304 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/extensions"
+	"github.com/apache/iceberg-go"
+)
+
+// Into reads the results of the scan into the slice that dst points to,
+// appending one element per row. The slice elements must be structs or
+// pointers to structs, whose fields are matched to the projected columns
+// as described by iceberg.StructBinding: by `iceberg:"name"` tag or
+// case-insensitively by name, converting values to the field types.
+//
+// Values are converted from Arrow to the Go types of iceberg literals
+// before being assigned, so a date column can be read into an
+// iceberg.Date, an int32 or any other integer type, and a list column into
+// a slice of any convertible element type.
+func (scan *Scan) Into(ctx context.Context, dst any) error {
+	ptr := reflect.ValueOf(dst)
+	if ptr.Kind() != reflect.Pointer || ptr.IsNil() || ptr.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("%w: scan destination must be a pointer to a slice, got %T",
+			iceberg.ErrInvalidArgument, dst)
+	}
+
+	slice := ptr.Elem()
+	elemType := slice.Type().Elem()
+	structType, elemIsPtr := elemType, elemType.Kind() == reflect.Pointer
+	if elemIsPtr {
+		structType = elemType.Elem()
+	}
+
+	schema, err := scan.Projection()
+	if err != nil {
+		return err
+	}
+
+	st := schema.AsStruct()
+	binding, err := iceberg.NewStructBinding(&st, structType)
+	if err != nil {
+		return err
+	}
+
+	_, itr, err := scan.ToArrowRecords(ctx)
+	if err != nil {
+		return err
+	}
+
+	for rec, err := range itr {
+		if err != nil {
+			return err
+		}
+
+		err = appendRecordBatch(binding, rec, slice, structType, elemIsPtr)
+		rec.Release()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Rows returns an iterator over the rows of the scan as iceberg records
+// typed by the scan's projected schema. Each yielded record is freshly
+// allocated and may be retained by the caller. Any error, including one
+// from planning the scan, is yielded with a nil record and ends iteration.
+func (scan *Scan) Rows(ctx context.Context) iter.Seq2[*iceberg.Record, error] {
+	return func(yield func(*iceberg.Record, error) bool) {
+		schema, err := scan.Projection()
+		if err != nil {
+			yield(nil, err)
+
+			return
+		}
+
+		_, itr, err := scan.ToArrowRecords(ctx)
+		if err != nil {
+			yield(nil, err)
+
+			return
+		}
+
+		st := schema.AsStruct()
+		for rec, err := range itr {
+			if err != nil {
+				yield(nil, err)
+
+				return
+			}
+
+			cols := rec.Columns()
+			for i := range int(rec.NumRows()) {
+				row := iceberg.NewRecord(&st)
+				for j, f := range st.FieldList {
+					v, err := arrowValue(cols[j], i, f.Type)
+					if err != nil {
+						rec.Release()
+						yield(nil, err)
+
+						return
+					}
+					row.Set(j, v)
+				}
+
+				if !yield(row, nil) {
+					rec.Release()
+
+					return
+				}
+			}
+			rec.Release()
+		}
+	}
+}
+
+func appendRecordBatch(binding *iceberg.StructBinding, rec arrow.RecordBatch, slice reflect.Value, structType reflect.Type, elemIsPtr bool) error {
+	st := binding.Type()
+	cols := rec.Columns()
+	row := iceberg.NewRecord(st)
+
+	for i := range int(rec.NumRows()) {
+		for j, f := range st.FieldList {
+			v, err := arrowValue(cols[j], i, f.Type)
+			if err != nil {
+				return err
+			}
+			row.Set(j, v)
+		}
+
+		elem := reflect.New(structType)
+		if err := binding.Scan(row, elem.Interface()); err != nil {
+			return err
+		}
+
+		if elemIsPtr {
+			slice.Set(reflect.Append(slice, elem))
+		} else {
+			slice.Set(reflect.Append(slice, elem.Elem()))
+		}
+	}
+
+	return nil
+}
+
+// arrowValue returns the value at position i of an arrow array as the Go
+// type used by iceberg literals of the given type. Structs are returned as
+// iceberg.Record, lists as []any and maps as map[any]any. Byte slices are
+// copied so the value remains valid after the array is released, except
+// for map keys, which are returned as strings so that they can be hashed.
+// Maps with nested key types cannot be returned as Go maps and fail with
+// an error.
+func arrowValue(arr arrow.Array, i int, typ iceberg.Type) (any, error) {
+	if arr.IsNull(i) {
+		return nil, nil
+	}
+
+	switch a := arr.(type) {
+	case *array.Dictionary:
+		return arrowValue(a.Dictionary(), a.GetValueIndex(i), typ)
+	case *array.Boolean:
+		return a.Value(i), nil
+	case *array.Int8:
+		return int32(a.Value(i)), nil
+	case *array.Int16:
+		return int32(a.Value(i)), nil
+	case *array.Int32:
+		return a.Value(i), nil
+	case *array.Int64:
+		return a.Value(i), nil
+	case *array.Float32:
+		return a.Value(i), nil
+	case *array.Float64:
+		return a.Value(i), nil
+	case *array.String:
+		return a.Value(i), nil
+	case *array.LargeString:
+		return a.Value(i), nil
+	case *array.StringView:
+		return a.Value(i), nil
+	case *array.Binary:
+		return slices.Clone(a.Value(i)), nil
+	case *array.LargeBinary:
+		return slices.Clone(a.Value(i)), nil
+	case *array.BinaryView:
+		return slices.Clone(a.Value(i)), nil
+	case *extensions.UUIDArray:
+		return a.Value(i), nil
+	case *array.FixedSizeBinary:
+		return slices.Clone(a.Value(i)), nil
+	case *array.Date32:
+		return iceberg.Date(a.Value(i)), nil
+	case *array.Time64:
+		unit := a.DataType().(*arrow.Time64Type).Unit
+		d := time.Duration(a.Value(i)) * unit.Multiplier()
+
+		return iceberg.Time(d.Microseconds()), nil
+	case *array.Timestamp:
+		t := a.Value(i).ToTime(a.DataType().(*arrow.TimestampType).Unit)
+		switch typ.(type) {
+		case iceberg.TimestampNsType, iceberg.TimestampTzNsType:
+			return iceberg.TimestampNano(t.UnixNano()), nil
+		default:
+			return iceberg.Timestamp(t.UnixMicro()), nil
+		}
+	case *array.Decimal128:
+		return iceberg.Decimal{
+			Val:   a.Value(i),
+			Scale: int(a.DataType().(*arrow.Decimal128Type).Scale),
+		}, nil
+	case *array.Struct:
+		st, _ := typ.(*iceberg.StructType)
+		if st == nil {
+			break
+		}
+
+		rec := iceberg.NewRecord(st)
+		for j, f := range st.FieldList {
+			v, err := arrowValue(a.Field(j), i, f.Type)
+			if err != nil {
+				return nil, err
+			}
+			rec.Set(j, v)
+		}
+
+		return rec, nil
+	case *array.Map:
+		mt, _ := typ.(*iceberg.MapType)
+		if mt == nil {
+			break
+		}
+
+		if _, nested := mt.KeyType.(iceberg.NestedType); nested {
+			return nil, fmt.Errorf("%w: cannot read map with %s keys into a Go map",
+				iceberg.ErrInvalidArgument, mt.KeyType)
+		}
+
+		start, end := a.ValueOffsets(i)
+		keys, items := a.Keys(), a.Items()
+		out := make(map[any]any, end-start)
+		for j := int(start); j < int(end); j++ {
+			k, err := arrowValue(keys, j, mt.KeyType)
+			if err != nil {
+				return nil, err
+			}
+			if b, ok := k.([]byte); ok {
+				k = string(b)
+			}
+
+			v, err := arrowValue(items, j, mt.ValueType)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = v
+		}
+
+		return out, nil
+	case array.ListLike:
+		lt, _ := typ.(*iceberg.ListType)
+		if lt == nil {
+			break
+		}
+
+		start, end := a.ValueOffsets(i)
+		values := a.ListValues()
+		out := make([]any, 0, end-start)
+		for j := int(start); j < int(end); j++ {
+			v, err := arrowValue(values, j, lt.Element)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+
+		return out, nil
+	}
+
+	return arr.GetOneForMarshal(i), nil
+}
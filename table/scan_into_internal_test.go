@@ -0,0 +1,61 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArrowValueMapKeys(t *testing.T) {
+	binaryKeys := &iceberg.MapType{
+		KeyID: 1, KeyType: iceberg.PrimitiveTypes.Binary,
+		ValueID: 2, ValueType: iceberg.PrimitiveTypes.Int64, ValueRequired: true,
+	}
+	arr, _, err := array.FromJSON(memory.DefaultAllocator,
+		arrow.MapOf(arrow.BinaryTypes.Binary, arrow.PrimitiveTypes.Int64),
+		strings.NewReader(`[[{"key": "YQ==", "value": 1}, {"key": "Yg==", "value": 2}]]`))
+	require.NoError(t, err)
+	defer arr.Release()
+
+	v, err := arrowValue(arr, 0, binaryKeys)
+	require.NoError(t, err)
+	assert.Equal(t, map[any]any{"a": int64(1), "b": int64(2)}, v)
+
+	listKeys := &iceberg.MapType{
+		KeyID: 1, KeyType: &iceberg.ListType{
+			ElementID: 3, Element: iceberg.PrimitiveTypes.Int32, ElementRequired: true,
+		},
+		ValueID: 2, ValueType: iceberg.PrimitiveTypes.Int64, ValueRequired: true,
+	}
+	arr, _, err = array.FromJSON(memory.DefaultAllocator,
+		arrow.MapOf(arrow.ListOf(arrow.PrimitiveTypes.Int32), arrow.PrimitiveTypes.Int64),
+		strings.NewReader(`[[{"key": [1, 2], "value": 3}]]`))
+	require.NoError(t, err)
+	defer arr.Release()
+
+	_, err = arrowValue(arr, 0, listKeys)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
@@ -0,0 +1,234 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanInto(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "name", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 3, Name: "day", Type: iceberg.PrimitiveTypes.Date, Required: true},
+		iceberg.NestedField{ID: 4, Name: "ts", Type: iceberg.PrimitiveTypes.TimestampTz},
+		iceberg.NestedField{ID: 5, Name: "tags", Type: &iceberg.ListType{
+			ElementID: 6, Element: iceberg.PrimitiveTypes.String, ElementRequired: true,
+		}},
+		iceberg.NestedField{ID: 7, Name: "location", Type: &iceberg.StructType{FieldList: []iceberg.NestedField{
+			{ID: 8, Name: "lat", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+			{ID: 9, Name: "long", Type: iceberg.PrimitiveTypes.Float64, Required: true},
+		}}})
+
+	tbl := newTestTable(t, withTestSchema(sc))
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, false, false)
+	require.NoError(t, err)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{`[
+		{"id": 1, "name": "a", "day": "2024-01-02", "ts": "2024-01-02T03:04:05Z",
+		 "tags": ["x", "y"], "location": {"lat": 52.5, "long": 13.4}},
+		{"id": 2, "name": null, "day": "2024-01-03", "ts": null, "tags": null, "location": null}
+	]`})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	tbl, err = tbl.AppendTable(ctx, arrTbl, 10, nil)
+	require.NoError(t, err)
+
+	type location struct {
+		Lat, Long float64
+	}
+	type row struct {
+		ID       int
+		Name     *string
+		Day      iceberg.Date
+		Ts       *iceberg.Timestamp `iceberg:"ts"`
+		Labels   []string           `iceberg:"tags"`
+		Location *location
+	}
+
+	var rows []row
+	require.NoError(t, tbl.Scan(table.WithSelectedFields("*")).Into(ctx, &rows))
+	require.Len(t, rows, 2)
+	if rows[0].ID != 1 {
+		rows[0], rows[1] = rows[1], rows[0]
+	}
+
+	name := "a"
+	ts := iceberg.Timestamp(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC).UnixMicro())
+	assert.Equal(t, row{
+		ID: 1, Name: &name, Day: iceberg.Date(19724), Ts: &ts,
+		Labels: []string{"x", "y"}, Location: &location{Lat: 52.5, Long: 13.4},
+	}, rows[0])
+	assert.Equal(t, row{ID: 2, Day: iceberg.Date(19725)}, rows[1])
+
+	// pointer elements, a projection and int coercion of dates
+	var projected []*struct {
+		ID  int64
+		Day int32
+	}
+	require.NoError(t, tbl.Scan(
+		table.WithSelectedFields("id", "day"),
+		table.WithRowFilter(iceberg.EqualTo(iceberg.Reference("id"), int64(2))),
+	).Into(ctx, &projected))
+	require.Len(t, projected, 1)
+	assert.EqualValues(t, 2, projected[0].ID)
+	assert.EqualValues(t, 19725, projected[0].Day)
+
+	var notSlice row
+	assert.ErrorIs(t, tbl.Scan().Into(ctx, &notSlice), iceberg.ErrInvalidArgument)
+
+	var mismatched []struct{ ID string }
+	assert.ErrorIs(t, tbl.Scan().Into(ctx, &mismatched), iceberg.ErrInvalidArgument)
+}
+
+func TestScanIntoMapKeys(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "attrs", Type: &iceberg.MapType{
+			KeyID: 3, KeyType: iceberg.PrimitiveTypes.Binary,
+			ValueID: 4, ValueType: iceberg.PrimitiveTypes.Int64, ValueRequired: true,
+		}})
+	tbl := newTestTable(t, withTestSchema(sc))
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, false, false)
+	require.NoError(t, err)
+	// binary values are base64 encoded in JSON: "YQ==" is "a", "Yg==" is "b"
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{`[
+		{"id": 1, "attrs": [{"key": "YQ==", "value": 1}, {"key": "Yg==", "value": 2}]}
+	]`})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	tbl, err = tbl.AppendTable(ctx, arrTbl, 10, nil)
+	require.NoError(t, err)
+
+	var rows []struct {
+		ID    int64
+		Attrs map[string]int64
+	}
+	require.NoError(t, tbl.Scan().Into(ctx, &rows))
+	require.Len(t, rows, 1)
+	assert.Equal(t, map[string]int64{"a": 1, "b": 2}, rows[0].Attrs)
+
+}
+
+func TestScanRowsAndEntries(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "name", Type: iceberg.PrimitiveTypes.String})
+
+	tbl := newTestTable(t, withTestSchema(sc))
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, false, false)
+	require.NoError(t, err)
+	for _, data := range []string{
+		`[{"id": 1, "name": "a"}, {"id": 2, "name": null}]`,
+		`[{"id": 3, "name": "c"}]`,
+	} {
+		arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{data})
+		require.NoError(t, err)
+		tbl, err = tbl.AppendTable(ctx, arrTbl, 10, nil)
+		arrTbl.Release()
+		require.NoError(t, err)
+	}
+
+	names := map[int64]any{}
+	for rec, err := range tbl.Scan().Rows(ctx) {
+		require.NoError(t, err)
+		assert.Equal(t, 2, rec.Size())
+		id, _ := rec.Field("id")
+		names[id.(int64)] = rec.Get(1)
+	}
+	assert.Equal(t, map[int64]any{1: "a", 2: nil, 3: "c"}, names)
+
+	count := 0
+	for _, err := range tbl.Scan().Rows(ctx) {
+		require.NoError(t, err)
+		count++
+
+		break
+	}
+	assert.Equal(t, 1, count)
+
+	fs, err := tbl.FS(ctx)
+	require.NoError(t, err)
+
+	var records int64
+	for entry, err := range tbl.CurrentSnapshot().Entries(fs) {
+		require.NoError(t, err)
+		assert.Equal(t, iceberg.EntryContentData, entry.DataFile().ContentType())
+		records += entry.DataFile().Count()
+	}
+	assert.EqualValues(t, 3, records)
+
+	for rec, err := range tbl.Scan(table.WithSelectedFields("missing")).Rows(ctx) {
+		assert.Nil(t, rec)
+		assert.Error(t, err)
+	}
+}
+
+func TestScanRowsMapKeys(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "attrs", Type: &iceberg.MapType{
+			KeyID: 3, KeyType: iceberg.PrimitiveTypes.Binary,
+			ValueID: 4, ValueType: iceberg.PrimitiveTypes.String,
+		}})
+	tbl := newTestTable(t, withTestSchema(sc))
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, false, false)
+	require.NoError(t, err)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{`[
+		{"id": 1, "attrs": [{"key": "YQ==", "value": "x"}, {"key": "Yg==", "value": null}]},
+		{"id": 2, "attrs": null}
+	]`})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	tbl, err = tbl.AppendTable(ctx, arrTbl, 10, nil)
+	require.NoError(t, err)
+
+	attrs := map[int64]any{}
+	for rec, err := range tbl.Scan().Rows(ctx) {
+		require.NoError(t, err)
+		id, _ := rec.Field("id")
+		attrs[id.(int64)] = rec.Get(1)
+	}
+	assert.Equal(t, map[int64]any{
+		1: map[any]any{"a": "x", "b": nil},
+		2: nil,
+	}, attrs)
+}
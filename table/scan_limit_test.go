@@ -0,0 +1,122 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scanIDs(t *testing.T, scan *table.Scan) []int64 {
+	t.Helper()
+
+	result, err := scan.ToArrowTable(context.Background())
+	require.NoError(t, err)
+	defer result.Release()
+
+	ids := make([]int64, 0, result.NumRows())
+	for _, chunk := range result.Column(0).Data().Chunks() {
+		ids = append(ids, chunk.(*array.Int64).Int64Values()...)
+	}
+
+	return ids
+}
+
+func TestScanLimitAndOffset(t *testing.T) {
+	ctx := context.Background()
+	tbl := newTestTable(t)
+
+	arrSchema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	// three files of four rows each
+	for i := range 3 {
+		arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{
+			fmt.Sprintf(`[{"id": %d}, {"id": %d}, {"id": %d}, {"id": %d}]`,
+				i*4+1, i*4+2, i*4+3, i*4+4),
+		})
+		require.NoError(t, err)
+
+		tbl, err = tbl.AppendTable(ctx, arrTbl, 4, nil)
+		arrTbl.Release()
+		require.NoError(t, err)
+	}
+
+	tasks, err := tbl.Scan().PlanFiles(ctx)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 3)
+
+	t.Run("limit stops planning", func(t *testing.T) {
+		tasks, err := tbl.Scan(table.WithLimit(3)).PlanFiles(ctx)
+		require.NoError(t, err)
+		assert.Len(t, tasks, 1)
+
+		tasks, err = tbl.Scan(table.WithLimit(5)).PlanFiles(ctx)
+		require.NoError(t, err)
+		assert.Len(t, tasks, 2)
+
+		tasks, err = tbl.Scan(table.WithLimit(0)).PlanFiles(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, tasks)
+
+		assert.Len(t, scanIDs(t, tbl.Scan(table.WithLimit(3))), 3)
+		assert.Len(t, scanIDs(t, tbl.Scan(table.WithLimit(5))), 5)
+	})
+
+	t.Run("limit with filter", func(t *testing.T) {
+		// a filter that metrics cannot prove for whole files keeps all of them
+		scan := tbl.Scan(table.WithLimit(2),
+			table.WithRowFilter(iceberg.IsIn(iceberg.Reference("id"), int64(1), int64(5), int64(9))))
+		tasks, err := scan.PlanFiles(ctx)
+		require.NoError(t, err)
+		assert.Len(t, tasks, 3)
+		assert.Len(t, scanIDs(t, scan), 2)
+
+		// files whose bounds all match the filter count towards the limit
+		scan = tbl.Scan(table.WithLimit(2),
+			table.WithRowFilter(iceberg.GreaterThan(iceberg.Reference("id"), int64(4))))
+		tasks, err = scan.PlanFiles(ctx)
+		require.NoError(t, err)
+		assert.Len(t, tasks, 1)
+		assert.Len(t, scanIDs(t, scan), 2)
+	})
+
+	t.Run("offset pages", func(t *testing.T) {
+		all := make([]int64, 0, 12)
+		for offset := int64(0); offset < 14; offset += 5 {
+			page := scanIDs(t, tbl.Scan(table.WithOffset(offset), table.WithLimit(5)))
+			assert.LessOrEqual(t, len(page), 5)
+			all = append(all, page...)
+		}
+		assert.ElementsMatch(t, []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, all)
+
+		assert.Len(t, scanIDs(t, tbl.Scan(table.WithOffset(10))), 2)
+		assert.Empty(t, scanIDs(t, tbl.Scan(table.WithOffset(12))))
+
+		rows, err := tbl.Scan(table.WithOffset(10)).EstimateRows(ctx)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, rows)
+	})
+}
@@ -0,0 +1,145 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/apache/iceberg-go"
+)
+
+// ScanManifestEntries are the live entries of a manifest whose files may
+// contain rows matching the filter of a scan, see Scan.ReadManifest.
+type ScanManifestEntries struct {
+	Manifest iceberg.ManifestFile
+	// SchemaID is the ID of the table schema that the manifest was
+	// written with, or nil if the manifest did not record one.
+	SchemaID *int
+	Entries  []iceberg.ManifestEntry
+}
+
+var errUnionScanManifests = errors.New("manifests of a union scan cannot be planned separately, use PlanFiles")
+
+// PlanManifests returns the manifests of the scan's snapshot that may
+// contain files matching the row filter of the scan, pruned using the
+// partition summaries of each manifest. Delete manifests older than every
+// data manifest are left out, since their deletes apply to no data file.
+//
+// Together with ReadManifest, or EntryEvaluator for callers reading the
+// manifests themselves, and PlanFilesFromEntries it exposes the steps of
+// PlanFiles, so that query engines embedding this library can interleave
+// their own planning, such as distributing manifests across workers or
+// pruning files with their own statistics. Scans combining several
+// snapshots with UnionRefs can only be planned with PlanFiles.
+func (scan *Scan) PlanManifests(ctx context.Context) ([]iceberg.ManifestFile, error) {
+	if len(scan.unionSnapshotIDs) > 0 {
+		return nil, errUnionScanManifests
+	}
+
+	if _, err := scan.boundRowFilter(); err != nil {
+		return nil, err
+	}
+
+	manifests, err := scan.fetchPartitionSpecFilteredManifests(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	minSeqNum := minSequenceNum(manifests)
+
+	return slices.DeleteFunc(manifests, func(mf iceberg.ManifestFile) bool {
+		return !scan.checkSequenceNumber(minSeqNum, mf)
+	}), nil
+}
+
+// EntryEvaluator returns a function reporting whether a data or delete
+// file, tracked by a manifest written with the partition spec of the
+// given ID, may contain rows matching the row filter of the scan, based
+// on its partition values and column metrics.
+func (scan *Scan) EntryEvaluator(specID int) (func(iceberg.DataFile) (bool, error), error) {
+	partEval, err := scan.buildPartitionEvaluator(specID)
+	if err != nil {
+		return nil, err
+	}
+
+	metricsEval, err := scan.metricsEvaluator()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(df iceberg.DataFile) (bool, error) {
+		if ok, err := partEval(df); !ok || err != nil {
+			return false, err
+		}
+
+		return metricsEval(df)
+	}, nil
+}
+
+// ReadManifest reads the live entries of the manifest, one returned by
+// PlanManifests, that may contain rows matching the row filter of the
+// scan.
+func (scan *Scan) ReadManifest(ctx context.Context, mf iceberg.ManifestFile) (ScanManifestEntries, error) {
+	fs, err := scan.ioF(ctx)
+	if err != nil {
+		return ScanManifestEntries{}, err
+	}
+
+	partEval, err := scan.buildPartitionEvaluator(int(mf.PartitionSpecID()))
+	if err != nil {
+		return ScanManifestEntries{}, err
+	}
+
+	metricsEval, err := scan.metricsEvaluator()
+	if err != nil {
+		return ScanManifestEntries{}, err
+	}
+
+	entries, schemaID, err := openManifest(fs, mf, partEval, metricsEval)
+	if err != nil {
+		return ScanManifestEntries{}, err
+	}
+
+	return ScanManifestEntries{Manifest: mf, SchemaID: schemaID, Entries: entries}, nil
+}
+
+// PlanFilesFromEntries returns the tasks reading the data files of the
+// given entries, in the same manner as PlanFiles. The positional deletes
+// among the entries are matched to the data files they apply to, so the
+// entries of the delete manifests must be included.
+func (scan *Scan) PlanFilesFromEntries(entries []ScanManifestEntries) ([]FileScanTask, error) {
+	if len(scan.unionSnapshotIDs) > 0 {
+		return nil, errUnionScanManifests
+	}
+
+	residual, err := scan.boundRowFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	collected := newManifestEntries()
+	for _, e := range entries {
+		if err := collected.addEntries(e.Entries, e.SchemaID); err != nil {
+			return nil, err
+		}
+	}
+
+	return scan.planTasks(collected, residual)
+}
@@ -0,0 +1,140 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanPlanManifests(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "part", Type: iceberg.PrimitiveTypes.String, Required: true})
+	spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+		SourceID: 2, FieldID: 1000, Name: "part", Transform: iceberg.IdentityTransform{},
+	})
+
+	tbl := newTestTable(t, withTestSchema(sc), withTestSpec(&spec))
+
+	arrSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "part", Type: arrow.BinaryTypes.String},
+	}, nil)
+	// one manifest per partition, each with a file of ids 1-3, 4-6 and 7-9
+	for i, part := range []string{"a", "b", "c"} {
+		arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{
+			fmt.Sprintf(`[{"id": %d, "part": %q}, {"id": %d, "part": %q}, {"id": %d, "part": %q}]`,
+				i*3+1, part, i*3+2, part, i*3+3, part),
+		})
+		require.NoError(t, err)
+
+		tbl, err = tbl.AppendTable(ctx, arrTbl, 3, nil)
+		arrTbl.Release()
+		require.NoError(t, err)
+	}
+
+	t.Run("partition pruning", func(t *testing.T) {
+		scan := tbl.Scan(table.WithRowFilter(iceberg.EqualTo(iceberg.Reference("part"), "b")))
+		manifests, err := scan.PlanManifests(ctx)
+		require.NoError(t, err)
+		require.Len(t, manifests, 1)
+
+		entries, err := scan.ReadManifest(ctx, manifests[0])
+		require.NoError(t, err)
+		require.Len(t, entries.Entries, 1)
+		assert.Equal(t, "b", entries.Entries[0].DataFile().Partition()[1000])
+		assert.NotNil(t, entries.SchemaID)
+
+		tasks, err := scan.PlanFilesFromEntries([]table.ScanManifestEntries{entries})
+		require.NoError(t, err)
+		planned, err := scan.PlanFiles(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, planned, tasks)
+	})
+
+	t.Run("caller filtering", func(t *testing.T) {
+		scan := tbl.Scan(table.WithRowFilter(iceberg.GreaterThan(iceberg.Reference("id"), int64(2))))
+		manifests, err := scan.PlanManifests(ctx)
+		require.NoError(t, err)
+		require.Len(t, manifests, 3)
+
+		var all []table.ScanManifestEntries
+		for _, mf := range manifests {
+			entries, err := scan.ReadManifest(ctx, mf)
+			require.NoError(t, err)
+			all = append(all, entries)
+		}
+
+		tasks, err := scan.PlanFilesFromEntries(all)
+		require.NoError(t, err)
+		assert.Len(t, tasks, 3)
+
+		// drop the entries of partition "a" as an external planner might
+		for i := range all {
+			if all[i].Entries[0].DataFile().Partition()[1000] == "a" {
+				all[i].Entries = nil
+			}
+		}
+		tasks, err = scan.PlanFilesFromEntries(all)
+		require.NoError(t, err)
+		assert.Len(t, tasks, 2)
+	})
+
+	t.Run("entry evaluator", func(t *testing.T) {
+		scan := tbl.Scan(table.WithRowFilter(iceberg.LessThan(iceberg.Reference("id"), int64(4))))
+		manifests, err := scan.PlanManifests(ctx)
+		require.NoError(t, err)
+
+		eval, err := scan.EntryEvaluator(spec.ID())
+		require.NoError(t, err)
+
+		fs, err := tbl.FS(ctx)
+		require.NoError(t, err)
+
+		var matched int
+		for _, mf := range manifests {
+			entries, err := mf.FetchEntries(fs, true)
+			require.NoError(t, err)
+			for _, e := range entries {
+				ok, err := eval(e.DataFile())
+				require.NoError(t, err)
+				if ok {
+					matched++
+				}
+			}
+		}
+		assert.Equal(t, 1, matched)
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		_, err := tbl.Scan(table.WithRowFilter(iceberg.EqualTo(iceberg.Reference("missing"), int64(1)))).PlanManifests(ctx)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,340 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"maps"
+	"runtime"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+)
+
+// ScanPlan is the serializable result of planning a scan. It carries
+// everything needed to read the planned files without access to the
+// catalog or the table metadata, so a coordinator can plan a scan and
+// fan the tasks out to stateless workers.
+//
+// A plan is created with [Scan.Plan], split with [ScanPlan.WithTasks]
+// and sent to workers as JSON. Workers parse it with json.Unmarshal and
+// read the records with [ScanPlan.ToArrowRecords].
+type ScanPlan struct {
+	// Schema is the table schema that the row filter and the task
+	// residuals are bound to.
+	Schema *iceberg.Schema
+	// Schemas are the other table schemas that the data files of the
+	// tasks were written with, see FileScanTask.SchemaID.
+	Schemas []*iceberg.Schema
+	// Specs are the partition specs of the table, used to restore the
+	// partition values of the data and delete files.
+	Specs      []iceberg.PartitionSpec
+	Projection *iceberg.Schema
+	RowFilter  iceberg.BooleanExpression
+	// Properties are the table properties, which control how files are
+	// read, such as the name mapping and encryption settings. Storage
+	// credentials are left out, see io.IsCredentialProperty: workers
+	// read the files with the IO passed to ToArrowRecords.
+	Properties    iceberg.Properties
+	Options       iceberg.Properties
+	CaseSensitive bool
+	// Limit is the maximum number of rows to read from the plan and
+	// Offset the number of rows to skip first. Both apply separately to
+	// each plan created with WithTasks.
+	Limit  int64
+	Offset int64
+	Tasks  []FileScanTask
+}
+
+// Plan plans the files to read for the scan and returns them along with
+// the information needed to read them as a ScanPlan.
+func (scan *Scan) Plan(ctx context.Context) (*ScanPlan, error) {
+	tasks, err := scan.PlanFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rowFilter, err := scan.boundRowFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := scan.schema()
+	if err != nil {
+		return nil, err
+	}
+
+	projection, err := scan.Projection()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScanPlan{
+		Schema:        schema,
+		Schemas:       writeSchemas(scan.metadata, schema.ID, tasks),
+		Specs:         scan.metadata.PartitionSpecs(),
+		Projection:    projection,
+		RowFilter:     rowFilter,
+		Properties:    withoutCredentials(scan.metadata.Properties()),
+		Options:       scan.options,
+		CaseSensitive: scan.caseSensitive,
+		Limit:         scan.limit,
+		Offset:        scan.offset,
+		Tasks:         tasks,
+	}, nil
+}
+
+// WithTasks returns a copy of the plan that only reads the given tasks.
+func (p *ScanPlan) WithTasks(tasks []FileScanTask) *ScanPlan {
+	out := *p
+	out.Tasks = tasks
+
+	return &out
+}
+
+// ToArrowRecords reads the tasks of the plan using the provided file IO
+// and returns the arrow schema of the projection and an iterator over
+// the resulting records, in the same manner as [Scan.ToArrowRecords].
+func (p *ScanPlan) ToArrowRecords(ctx context.Context, fs iceio.IO) (*arrow.Schema, iter.Seq2[arrow.RecordBatch, error], error) {
+	return (&arrowScan{
+		tableProps:      p.Properties,
+		fs:              fs,
+		projectedSchema: p.Projection,
+		boundRowFilter:  p.RowFilter,
+		caseSensitive:   p.CaseSensitive,
+		rowLimit:        p.Limit,
+		rowOffset:       p.Offset,
+		options:         p.Options,
+		concurrency:     runtime.GOMAXPROCS(0),
+		schemas:         append([]*iceberg.Schema{p.Schema}, p.Schemas...),
+	}).GetRecords(ctx, p.Tasks)
+}
+
+// withoutCredentials returns a copy of props without the properties
+// holding storage credentials.
+func withoutCredentials(props iceberg.Properties) iceberg.Properties {
+	out := maps.Clone(props)
+	maps.DeleteFunc(out, func(key, _ string) bool { return iceio.IsCredentialProperty(key) })
+
+	return out
+}
+
+// writeSchemas returns the schemas of the table metadata, other than the
+// one with the given ID, that the data files of the tasks were written with.
+func writeSchemas(meta Metadata, schemaID int, tasks []FileScanTask) []*iceberg.Schema {
+	ids := set[int]{}
+	for _, t := range tasks {
+		if t.SchemaID != nil && *t.SchemaID != schemaID {
+			ids[*t.SchemaID] = struct{}{}
+		}
+	}
+
+	var out []*iceberg.Schema
+	for _, sc := range meta.Schemas() {
+		if _, ok := ids[sc.ID]; ok {
+			out = append(out, sc)
+		}
+	}
+
+	return out
+}
+
+func (p *ScanPlan) specByID(id int32) (iceberg.PartitionSpec, error) {
+	for _, s := range p.Specs {
+		if s.ID() == int(id) {
+			return s, nil
+		}
+	}
+
+	return iceberg.PartitionSpec{}, fmt.Errorf("%w: unknown partition spec id %d",
+		iceberg.ErrInvalidArgument, id)
+}
+
+type scanTaskJSON struct {
+	DataFile    json.RawMessage   `json:"data-file"`
+	DeleteFiles []json.RawMessage `json:"delete-files,omitempty"`
+	Residual    json.RawMessage   `json:"residual,omitempty"`
+	SchemaID    *int              `json:"schema-id,omitempty"`
+	Start       int64             `json:"start"`
+	Length      int64             `json:"length"`
+}
+
+type scanPlanJSON struct {
+	Schema        *iceberg.Schema         `json:"schema"`
+	Schemas       []*iceberg.Schema       `json:"schemas,omitempty"`
+	Specs         []iceberg.PartitionSpec `json:"partition-specs"`
+	Projection    *iceberg.Schema         `json:"projection"`
+	RowFilter     json.RawMessage         `json:"row-filter,omitempty"`
+	Properties    iceberg.Properties      `json:"properties,omitempty"`
+	Options       iceberg.Properties      `json:"options,omitempty"`
+	CaseSensitive bool                    `json:"case-sensitive"`
+	Limit         int64                   `json:"limit"`
+	Offset        int64                   `json:"offset,omitempty"`
+	Tasks         []scanTaskJSON          `json:"tasks"`
+}
+
+func (p *ScanPlan) marshalFile(df iceberg.DataFile) (json.RawMessage, error) {
+	spec, err := p.specByID(df.SpecID())
+	if err != nil {
+		return nil, err
+	}
+
+	return iceberg.MarshalDataFile(df, spec, p.Schema)
+}
+
+func (p *ScanPlan) unmarshalFile(data json.RawMessage) (iceberg.DataFile, error) {
+	var hdr struct {
+		SpecID int32 `json:"spec-id"`
+	}
+	if err := json.Unmarshal(data, &hdr); err != nil {
+		return nil, fmt.Errorf("%w: invalid data file json: %s", iceberg.ErrInvalidArgument, err)
+	}
+
+	spec, err := p.specByID(hdr.SpecID)
+	if err != nil {
+		return nil, err
+	}
+
+	return iceberg.UnmarshalDataFile(data, spec, p.Schema)
+}
+
+func (p *ScanPlan) marshalExpr(expr iceberg.BooleanExpression) (json.RawMessage, error) {
+	if expr == nil {
+		return nil, nil
+	}
+
+	return iceberg.MarshalExpression(expr, p.Schema)
+}
+
+func (p *ScanPlan) unmarshalExpr(data json.RawMessage) (iceberg.BooleanExpression, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	expr, err := iceberg.UnmarshalExpression(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return iceberg.BindExpr(p.Schema, expr, p.CaseSensitive)
+}
+
+func (p *ScanPlan) MarshalJSON() ([]byte, error) {
+	if p.Schema == nil || p.Projection == nil {
+		return nil, fmt.Errorf("%w: scan plan requires a schema and projection",
+			iceberg.ErrInvalidArgument)
+	}
+
+	out := scanPlanJSON{
+		Schema:        p.Schema,
+		Schemas:       p.Schemas,
+		Specs:         p.Specs,
+		Projection:    p.Projection,
+		Properties:    p.Properties,
+		Options:       p.Options,
+		CaseSensitive: p.CaseSensitive,
+		Limit:         p.Limit,
+		Offset:        p.Offset,
+		Tasks:         make([]scanTaskJSON, len(p.Tasks)),
+	}
+
+	var err error
+	if out.RowFilter, err = p.marshalExpr(p.RowFilter); err != nil {
+		return nil, err
+	}
+
+	for i, t := range p.Tasks {
+		task := &out.Tasks[i]
+		task.Start, task.Length, task.SchemaID = t.Start, t.Length, t.SchemaID
+		if task.DataFile, err = p.marshalFile(t.File); err != nil {
+			return nil, err
+		}
+
+		if task.Residual, err = p.marshalExpr(t.Residual); err != nil {
+			return nil, err
+		}
+
+		for _, df := range t.DeleteFiles {
+			data, err := p.marshalFile(df)
+			if err != nil {
+				return nil, err
+			}
+			task.DeleteFiles = append(task.DeleteFiles, data)
+		}
+	}
+
+	return json.Marshal(out)
+}
+
+func (p *ScanPlan) UnmarshalJSON(b []byte) error {
+	var in scanPlanJSON
+	if err := json.Unmarshal(b, &in); err != nil {
+		return err
+	}
+
+	if in.Schema == nil || in.Projection == nil {
+		return fmt.Errorf("%w: scan plan requires a schema and projection",
+			iceberg.ErrInvalidArgument)
+	}
+
+	out := ScanPlan{
+		Schema:        in.Schema,
+		Schemas:       in.Schemas,
+		Specs:         in.Specs,
+		Projection:    in.Projection,
+		Properties:    in.Properties,
+		Options:       in.Options,
+		CaseSensitive: in.CaseSensitive,
+		Limit:         in.Limit,
+		Offset:        in.Offset,
+		Tasks:         make([]FileScanTask, len(in.Tasks)),
+	}
+
+	var err error
+	if out.RowFilter, err = out.unmarshalExpr(in.RowFilter); err != nil {
+		return err
+	}
+
+	for i, t := range in.Tasks {
+		task := &out.Tasks[i]
+		task.Start, task.Length, task.SchemaID = t.Start, t.Length, t.SchemaID
+		if task.File, err = out.unmarshalFile(t.DataFile); err != nil {
+			return err
+		}
+
+		if task.Residual, err = out.unmarshalExpr(t.Residual); err != nil {
+			return err
+		}
+
+		for _, data := range t.DeleteFiles {
+			df, err := out.unmarshalFile(data)
+			if err != nil {
+				return err
+			}
+			task.DeleteFiles = append(task.DeleteFiles, df)
+		}
+	}
+
+	*p = out
+
+	return nil
+}
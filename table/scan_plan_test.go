@@ -0,0 +1,222 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanPlanJSONRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "category", Type: iceberg.PrimitiveTypes.String, Required: true},
+		iceberg.NestedField{ID: 3, Name: "data", Type: iceberg.PrimitiveTypes.String})
+
+	spec := iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 2, FieldID: 1000, Name: "category", Transform: iceberg.IdentityTransform{}})
+
+	tbl := newTestTable(t, withTestSchema(sc), withTestSpec(&spec),
+		withTestProperties(iceberg.Properties{
+			iceio.S3AccessKeyID:     "AKIA",
+			iceio.S3SecretAccessKey: "secret",
+			iceio.S3SessionToken:    "token",
+		}))
+
+	arrSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "category", Type: arrow.BinaryTypes.String},
+		{Name: "data", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{
+		`[{"id": 1, "category": "a", "data": "x"}, {"id": 2, "category": "b", "data": "y"},
+		  {"id": 3, "category": "a", "data": null}, {"id": 4, "category": "c", "data": "z"}]`,
+	})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	tbl, err = tbl.AppendTable(ctx, arrTbl, 4, nil)
+	require.NoError(t, err)
+
+	scan := tbl.Scan(
+		table.WithRowFilter(iceberg.GreaterThan(iceberg.Reference("id"), int64(1))),
+		table.WithSelectedFields("id", "data"))
+
+	plan, err := scan.Plan(ctx)
+	require.NoError(t, err)
+	require.Len(t, plan.Tasks, 3)
+	for _, task := range plan.Tasks {
+		assert.True(t, plan.RowFilter.Equals(task.Residual))
+	}
+
+	data, err := json.Marshal(plan)
+	require.NoError(t, err)
+	// storage credentials are not sent to the workers
+	require.Contains(t, tbl.Properties(), iceio.S3SecretAccessKey)
+	for _, key := range []string{iceio.S3AccessKeyID, iceio.S3SecretAccessKey, iceio.S3SessionToken} {
+		assert.NotContains(t, plan.Properties, key)
+		assert.NotContains(t, string(data), key)
+	}
+
+	var parsed table.ScanPlan
+	require.NoError(t, json.Unmarshal(data, &parsed))
+
+	assert.True(t, plan.Schema.Equals(parsed.Schema))
+	assert.True(t, plan.Projection.Equals(parsed.Projection))
+	assert.True(t, plan.RowFilter.Equals(parsed.RowFilter))
+	assert.Equal(t, plan.Limit, parsed.Limit)
+	assert.Equal(t, plan.CaseSensitive, parsed.CaseSensitive)
+	require.Len(t, parsed.Tasks, len(plan.Tasks))
+	for i, task := range parsed.Tasks {
+		expected := plan.Tasks[i]
+		assert.Equal(t, expected.File.FilePath(), task.File.FilePath())
+		assert.Equal(t, expected.File.Partition(), task.File.Partition())
+		assert.Equal(t, expected.File.LowerBoundValues(), task.File.LowerBoundValues())
+		assert.Equal(t, expected.Length, task.Length)
+		assert.True(t, expected.Residual.Equals(task.Residual))
+	}
+
+	// read each task as if on a separate worker
+	var rows int64
+	ids := make([]int64, 0)
+	for _, task := range parsed.Tasks {
+		part, err := json.Marshal(parsed.WithTasks([]table.FileScanTask{task}))
+		require.NoError(t, err)
+
+		var worker table.ScanPlan
+		require.NoError(t, json.Unmarshal(part, &worker))
+
+		schema, records, err := worker.ToArrowRecords(ctx, iceio.LocalFS{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"id", "data"}, []string{schema.Field(0).Name, schema.Field(1).Name})
+
+		for rec, err := range records {
+			require.NoError(t, err)
+			rows += rec.NumRows()
+			ids = append(ids, rec.Column(0).(*array.Int64).Int64Values()...)
+			rec.Release()
+		}
+	}
+
+	assert.EqualValues(t, 3, rows)
+	assert.ElementsMatch(t, []int64{2, 3, 4}, ids)
+}
+
+func TestScanPlanUnmarshalErrors(t *testing.T) {
+	var plan table.ScanPlan
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"tasks": []}`), &plan), iceberg.ErrInvalidArgument)
+
+	err := json.Unmarshal([]byte(`{
+		"schema": {"type": "struct", "schema-id": 0, "fields": [
+			{"id": 1, "name": "id", "type": "long", "required": true}]},
+		"projection": {"type": "struct", "schema-id": 0, "fields": [
+			{"id": 1, "name": "id", "type": "long", "required": true}]},
+		"partition-specs": [],
+		"tasks": [{"data-file": {"content": "data", "file-path": "a.parquet",
+			"file-format": "PARQUET", "spec-id": 4, "partition": []}, "start": 0, "length": 1}]
+	}`), &plan)
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	assert.ErrorContains(t, err, "unknown partition spec id 4")
+}
+
+func TestScanPlanResiduals(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "category", Type: iceberg.PrimitiveTypes.String, Required: true})
+	spec := iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 2, FieldID: 1000, Name: "category", Transform: iceberg.IdentityTransform{}})
+	tbl := newTestTable(t, withTestSchema(sc), withTestSpec(&spec))
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, false, false)
+	require.NoError(t, err)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{
+		`[{"id": 1, "category": "a"}, {"id": 5, "category": "a"},
+		  {"id": 2, "category": "b"}, {"id": 6, "category": "b"},
+		  {"id": 3, "category": "c"}, {"id": 7, "category": "c"}]`,
+	})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	tbl, err = tbl.AppendTable(ctx, arrTbl, 6, nil)
+	require.NoError(t, err)
+
+	idFilter, err := iceberg.BindExpr(tbl.Schema(),
+		iceberg.GreaterThan(iceberg.Reference("id"), int64(2)), true)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		filter   iceberg.BooleanExpression
+		expected map[string]iceberg.BooleanExpression
+	}{
+		{
+			name: "and",
+			filter: iceberg.NewAnd(
+				iceberg.NotEqualTo(iceberg.Reference("category"), "b"),
+				iceberg.GreaterThan(iceberg.Reference("id"), int64(2))),
+			expected: map[string]iceberg.BooleanExpression{"a": idFilter, "c": idFilter},
+		},
+		{
+			name: "or",
+			filter: iceberg.NewOr(
+				iceberg.EqualTo(iceberg.Reference("category"), "a"),
+				iceberg.GreaterThan(iceberg.Reference("id"), int64(2))),
+			expected: map[string]iceberg.BooleanExpression{
+				"a": iceberg.AlwaysTrue{}, "b": idFilter, "c": idFilter,
+			},
+		},
+		{
+			name: "not",
+			filter: iceberg.NewNot(iceberg.NewOr(
+				iceberg.EqualTo(iceberg.Reference("category"), "a"),
+				iceberg.LessThanEqual(iceberg.Reference("id"), int64(2)))),
+			expected: map[string]iceberg.BooleanExpression{"b": idFilter, "c": idFilter},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := tbl.Scan(table.WithRowFilter(tt.filter)).Plan(ctx)
+			require.NoError(t, err)
+
+			residuals := make(map[string]iceberg.BooleanExpression)
+			for _, task := range plan.Tasks {
+				residuals[task.File.Partition()[1000].(string)] = task.Residual
+			}
+			require.Len(t, residuals, len(tt.expected))
+			for category, expected := range tt.expected {
+				assert.True(t, expected.Equals(residuals[category]),
+					"category %s: expected %s, got %s", category, expected, residuals[category])
+			}
+		})
+	}
+}
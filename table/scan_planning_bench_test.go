@@ -0,0 +1,182 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+)
+
+const planningEntriesPerManifest = 10_000
+
+var planningSizes = []struct {
+	name    string
+	entries int
+}{
+	{"10K_entries", 10_000},
+	{"100K_entries", 100_000},
+	{"1M_entries", 1_000_000},
+}
+
+var planningFilters = []struct {
+	name   string
+	filter iceberg.BooleanExpression
+}{
+	{"no_filter", iceberg.AlwaysTrue{}},
+	{"partition_filter", iceberg.EqualTo(iceberg.Reference("category"), "category-7")},
+	{"metrics_filter", iceberg.GreaterThanEqual(iceberg.Reference("id"), int64(990_000))},
+	{"combined_filter", iceberg.NewAnd(
+		iceberg.EqualTo(iceberg.Reference("category"), "category-7"),
+		iceberg.LessThan(iceberg.Reference("id"), int64(5_000)))},
+}
+
+// newPlanningBenchTable writes a snapshot of entries data files spread
+// over manifests of planningEntriesPerManifest entries, each file
+// carrying a partition value and column metrics like a real table.
+func newPlanningBenchTable(b *testing.B, entries int) *table.Table {
+	b.Helper()
+
+	loc := filepath.ToSlash(b.TempDir())
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "category", Type: iceberg.PrimitiveTypes.String, Required: true},
+		iceberg.NestedField{ID: 3, Name: "ts", Type: iceberg.PrimitiveTypes.TimestampTz})
+	spec := iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 2, FieldID: 1000, Name: "category", Transform: iceberg.IdentityTransform{}})
+
+	meta, err := table.NewMetadata(sc, &spec, table.UnsortedSortOrder, loc,
+		iceberg.Properties{table.PropertyFormatVersion: "2"})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	fs := iceio.LocalFS{}
+	if err := os.MkdirAll(loc+"/metadata", 0o755); err != nil {
+		b.Fatal(err)
+	}
+	snapshotID, seqNum := int64(1), int64(1)
+	tsLower, _ := iceberg.TimestampLiteral(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMicro()).MarshalBinary()
+	tsUpper, _ := iceberg.TimestampLiteral(time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC).UnixMicro()).MarshalBinary()
+
+	var manifests []iceberg.ManifestFile
+	for start := 0; start < entries; start += planningEntriesPerManifest {
+		batch := make([]iceberg.ManifestEntry, 0, planningEntriesPerManifest)
+		for i := start; i < min(start+planningEntriesPerManifest, entries); i++ {
+			category := fmt.Sprintf("category-%d", i%50)
+			idLower, _ := iceberg.Int64Literal(int64(i) * 10).MarshalBinary()
+			idUpper, _ := iceberg.Int64Literal(int64(i)*10 + 9).MarshalBinary()
+
+			bldr, err := iceberg.NewDataFileBuilder(spec, iceberg.EntryContentData,
+				fmt.Sprintf("%s/data/%s/%08d.parquet", loc, category, i), iceberg.ParquetFile,
+				map[int]any{1000: category}, nil, nil, 10, 4096)
+			if err != nil {
+				b.Fatal(err)
+			}
+			df := bldr.
+				ColumnSizes(map[int]int64{1: 80, 2: 120, 3: 80}).
+				ValueCounts(map[int]int64{1: 10, 2: 10, 3: 10}).
+				NullValueCounts(map[int]int64{1: 0, 2: 0, 3: 1}).
+				LowerBoundValues(map[int][]byte{1: idLower, 2: []byte(category), 3: tsLower}).
+				UpperBoundValues(map[int][]byte{1: idUpper, 2: []byte(category), 3: tsUpper}).
+				Build()
+			batch = append(batch, iceberg.NewManifestEntry(iceberg.EntryStatusADDED, &snapshotID, &seqNum, &seqNum, df))
+		}
+
+		path := fmt.Sprintf("%s/metadata/manifest-%d.avro", loc, len(manifests))
+		var buf bytes.Buffer
+		mf, err := iceberg.WriteManifest(path, &buf, 2, spec, sc, snapshotID, batch)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := fs.WriteFile(path, buf.Bytes()); err != nil {
+			b.Fatal(err)
+		}
+		manifests = append(manifests, mf)
+	}
+
+	listPath := loc + "/metadata/snap-1.avro"
+	var listBuf bytes.Buffer
+	if err := iceberg.WriteManifestList(2, &listBuf, snapshotID, nil, &seqNum, 0, manifests); err != nil {
+		b.Fatal(err)
+	}
+	if err := fs.WriteFile(listPath, listBuf.Bytes()); err != nil {
+		b.Fatal(err)
+	}
+
+	bldr, err := table.MetadataBuilderFromBase(meta, "")
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err := bldr.AddSnapshot(&table.Snapshot{
+		SnapshotID:     snapshotID,
+		SequenceNumber: seqNum,
+		TimestampMs:    time.Now().UnixMilli(),
+		ManifestList:   listPath,
+		Summary:        &table.Summary{Operation: table.OpAppend},
+	}); err != nil {
+		b.Fatal(err)
+	}
+	if err := bldr.SetSnapshotRef(table.MainBranch, snapshotID, table.BranchRef); err != nil {
+		b.Fatal(err)
+	}
+	meta, err = bldr.Build()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return table.New(table.Identifier{"bench", "planning"}, meta, loc+"/metadata/v1.metadata.json",
+		func(context.Context) (iceio.IO, error) { return fs, nil }, nil)
+}
+
+// BenchmarkPlanFiles measures scan planning over large manifests, to be
+// profiled with -cpuprofile and -memprofile. Decoding the manifest
+// entries from Avro is most of the planning time, so speeding up the
+// evaluators alone shows mostly as fewer allocations.
+func BenchmarkPlanFiles(b *testing.B) {
+	ctx := context.Background()
+
+	for _, size := range planningSizes {
+		b.Run(size.name, func(b *testing.B) {
+			if testing.Short() && size.entries > 100_000 {
+				b.Skip("skipping large manifests in short mode")
+			}
+			tbl := newPlanningBenchTable(b, size.entries)
+
+			for _, f := range planningFilters {
+				b.Run(f.name, func(b *testing.B) {
+					b.ReportAllocs()
+					for b.Loop() {
+						if _, err := tbl.Scan(table.WithRowFilter(f.filter)).PlanFiles(ctx); err != nil {
+							b.Fatal(err)
+						}
+					}
+					b.ReportMetric(float64(size.entries), "entries/op")
+				})
+			}
+		})
+	}
+}
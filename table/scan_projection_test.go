@@ -0,0 +1,94 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanNestedFieldProjection(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "address", Type: &iceberg.StructType{FieldList: []iceberg.NestedField{
+			{ID: 3, Name: "city", Type: iceberg.PrimitiveTypes.String},
+			{ID: 4, Name: "zip", Type: iceberg.PrimitiveTypes.String},
+		}}},
+		iceberg.NestedField{ID: 5, Name: "points", Type: &iceberg.ListType{
+			ElementID: 6, Element: &iceberg.StructType{FieldList: []iceberg.NestedField{
+				{ID: 7, Name: "x", Type: iceberg.PrimitiveTypes.Int32},
+				{ID: 8, Name: "y", Type: iceberg.PrimitiveTypes.Int32},
+			}},
+		}},
+		iceberg.NestedField{ID: 9, Name: "props", Type: &iceberg.MapType{
+			KeyID: 10, KeyType: iceberg.PrimitiveTypes.String,
+			ValueID: 11, ValueType: &iceberg.StructType{FieldList: []iceberg.NestedField{
+				{ID: 12, Name: "a", Type: iceberg.PrimitiveTypes.String},
+				{ID: 13, Name: "b", Type: iceberg.PrimitiveTypes.String},
+			}},
+		}},
+	)
+
+	tbl := newTestTable(t, withTestSchema(sc))
+
+	arrSchema, err := table.SchemaToArrowSchema(tbl.Schema(), nil, false, false)
+	require.NoError(t, err)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{`[
+		{"id": 1, "address": {"city": "Oslo", "zip": "0150"}, "points": [{"x": 1, "y": 2}, {"x": 3, "y": 4}],
+		 "props": [{"key": "k", "value": {"a": "A", "b": "B"}}]},
+		{"id": 2, "address": null, "points": null, "props": null}
+	]`})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	tbl, err = tbl.AppendTable(ctx, arrTbl, 2, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		selected []string
+		schema   string
+		values   string
+	}{
+		{[]string{"address.city"}, "address: type=struct<city: utf8>", `{["Oslo" (null)]}`},
+		{[]string{"points.element.x"}, "points: type=list<element: struct<x: int32>", `[{[1 3]} (null)]`},
+		{[]string{"points.x"}, "points: type=list<element: struct<x: int32>", `[{[1 3]} (null)]`},
+		{[]string{"props.value.a"}, "props: type=map<utf8, struct<a: utf8>", `[{["k"] {["A"]}} (null)]`},
+		{[]string{"props.a"}, "props: type=map<utf8, struct<a: utf8>", `[{["k"] {["A"]}} (null)]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selected[0], func(t *testing.T) {
+			result, err := tbl.Scan(table.WithSelectedFields(tt.selected...)).ToArrowTable(ctx)
+			require.NoError(t, err)
+			defer result.Release()
+
+			require.EqualValues(t, 1, result.NumCols())
+			assert.Contains(t, result.Schema().String(), tt.schema)
+			assert.Equal(t, tt.values, result.Column(0).Data().Chunk(0).String())
+		})
+	}
+}
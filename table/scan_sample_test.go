@@ -0,0 +1,82 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanSample(t *testing.T) {
+	ctx := context.Background()
+
+	tbl := newTestTable(t)
+
+	const numFiles = 20
+
+	arrSchema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	for i := range numFiles {
+		arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema,
+			[]string{fmt.Sprintf(`[{"id": %d}]`, i)})
+		require.NoError(t, err)
+
+		tbl, err = tbl.AppendTable(ctx, arrTbl, 1, nil)
+		arrTbl.Release()
+		require.NoError(t, err)
+	}
+
+	sampledPaths := func(fraction float64, seed int64) []string {
+		scan, err := tbl.Scan().Sample(fraction, seed)
+		require.NoError(t, err)
+
+		tasks, err := scan.PlanFiles(ctx)
+		require.NoError(t, err)
+
+		paths := make([]string, len(tasks))
+		for i, task := range tasks {
+			paths[i] = task.File.FilePath()
+		}
+
+		return paths
+	}
+
+	half := sampledPaths(0.5, 1)
+	assert.NotEmpty(t, half)
+	assert.Less(t, len(half), numFiles)
+	assert.Equal(t, half, sampledPaths(0.5, 1), "a seed selects the same files")
+	assert.NotEqual(t, half, sampledPaths(0.5, 2), "seeds select different files")
+	assert.Subset(t, sampledPaths(0.75, 1), half, "a larger fraction extends the sample")
+	assert.Len(t, sampledPaths(1, 1), numFiles)
+
+	scan, err := tbl.Scan().Sample(0.5, 1)
+	require.NoError(t, err)
+	assert.Len(t, scanIDs(t, scan), len(half))
+
+	for _, fraction := range []float64{0, -0.5, 1.5} {
+		_, err := tbl.Scan().Sample(fraction, 1)
+		assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+	}
+}
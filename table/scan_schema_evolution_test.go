@@ -0,0 +1,110 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanAcrossSchemaEvolution(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int32, Required: true},
+		iceberg.NestedField{ID: 2, Name: "name", Type: iceberg.PrimitiveTypes.String},
+	)
+
+	tbl := newTestTable(t, withTestSchema(sc), withTestFormatVersion(3))
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, false, false)
+	require.NoError(t, err)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{`[
+		{"id": 1, "name": "a"},
+		{"id": 2, "name": "b"}
+	]`})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	tbl, err = tbl.AppendTable(ctx, arrTbl, 2, nil)
+	require.NoError(t, err)
+	oldSnapshot := tbl.CurrentSnapshot().SnapshotID
+
+	txn := tbl.NewTransaction()
+	require.NoError(t, txn.UpdateSchema(true, false).
+		UpdateColumn([]string{"id"}, table.ColumnUpdate{
+			FieldType: iceberg.Optional[iceberg.Type]{Valid: true, Val: iceberg.PrimitiveTypes.Int64},
+		}).
+		RenameColumn([]string{"name"}, "label").
+		AddColumn([]string{"score"}, iceberg.PrimitiveTypes.Int64, "", false, iceberg.Int64Literal(7)).
+		Commit())
+	tbl, err = txn.Commit(ctx)
+	require.NoError(t, err)
+
+	t.Run("current schema", func(t *testing.T) {
+		result, err := tbl.Scan(table.WithRowFilter(iceberg.EqualTo(iceberg.Reference("label"), "b"))).
+			ToArrowTable(ctx)
+		require.NoError(t, err)
+		defer result.Release()
+
+		require.EqualValues(t, 1, result.NumRows())
+		assert.Equal(t, []string{"id", "label", "score"}, fieldNames(result.Schema()))
+		assert.Equal(t, arrow.PrimitiveTypes.Int64, result.Schema().Field(0).Type)
+
+		assert.EqualValues(t, 2, result.Column(0).Data().Chunk(0).(*array.Int64).Value(0))
+		assert.Equal(t, "b", result.Column(1).Data().Chunk(0).(*array.String).Value(0))
+		assert.EqualValues(t, 7, result.Column(2).Data().Chunk(0).(*array.Int64).Value(0))
+	})
+
+	t.Run("time travel", func(t *testing.T) {
+		scan := tbl.Scan(table.WithSnapshotID(oldSnapshot),
+			table.WithRowFilter(iceberg.EqualTo(iceberg.Reference("name"), "a")))
+
+		tasks, err := scan.PlanFiles(ctx)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		require.NotNil(t, tasks[0].SchemaID)
+		assert.Equal(t, 0, *tasks[0].SchemaID)
+
+		result, err := scan.ToArrowTable(ctx)
+		require.NoError(t, err)
+		defer result.Release()
+
+		require.EqualValues(t, 1, result.NumRows())
+		assert.Equal(t, []string{"id", "name"}, fieldNames(result.Schema()))
+		assert.Equal(t, arrow.PrimitiveTypes.Int32, result.Schema().Field(0).Type)
+		assert.EqualValues(t, 1, result.Column(0).Data().Chunk(0).(*array.Int32).Value(0))
+	})
+}
+
+func fieldNames(sc *arrow.Schema) []string {
+	names := make([]string, sc.NumFields())
+	for i, f := range sc.Fields() {
+		names[i] = f.Name
+	}
+
+	return names
+}
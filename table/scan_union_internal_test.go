@@ -0,0 +1,101 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanUnionRefsDeletes(t *testing.T) {
+	ctx := context.Background()
+	loc := filepath.ToSlash(t.TempDir())
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true})
+	meta, err := NewMetadata(sc, iceberg.UnpartitionedSpec, UnsortedSortOrder, loc,
+		iceberg.Properties{PropertyFormatVersion: "2"})
+	require.NoError(t, err)
+
+	ident := Identifier{"default", "union"}
+	fsF := func(context.Context) (iceio.IO, error) { return iceio.LocalFS{}, nil }
+	cat := &inMemoryCatalog{meta}
+	tbl := New(ident, meta, loc+"/metadata/v1.metadata.json", fsF, cat)
+
+	arrSchema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema,
+		[]string{`[{"id": 0}, {"id": 1}, {"id": 2}]`})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	tbl, err = tbl.AppendTable(ctx, arrTbl, 3, nil)
+	require.NoError(t, err)
+	before := tbl.CurrentSnapshot().SnapshotID
+
+	tasks, err := tbl.Scan().PlanFiles(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	dataPath := tasks[0].File.FilePath()
+
+	tbl = commitDeleteFiles(t, tbl, writePositionDeletes(t, tbl, dataPath, 1))
+	tbl = commitDeleteFiles(t, tbl, writePositionDeletes(t, tbl, dataPath, 2))
+
+	meta, _, err = cat.CommitTable(ctx, ident, nil, []Update{
+		NewSetSnapshotRefUpdate("before", before, TagRef, -1, -1, -1),
+		NewSetSnapshotRefUpdate("one-delete", *tbl.CurrentSnapshot().ParentSnapshotID, TagRef, -1, -1, -1),
+	})
+	require.NoError(t, err)
+	tbl = New(ident, meta, "", fsF, cat)
+
+	ids := func(scan *Scan) []int64 {
+		result, err := scan.ToArrowTable(ctx)
+		require.NoError(t, err)
+		defer result.Release()
+
+		var out []int64
+		for _, chunk := range result.Column(0).Data().Chunks() {
+			out = append(out, chunk.(*array.Int64).Int64Values()...)
+		}
+
+		return out
+	}
+
+	assert.ElementsMatch(t, []int64{0}, ids(tbl.Scan()))
+
+	// the union keeps only the deletes applying in every snapshot
+	scan, err := tbl.Scan().UnionRefs("one-delete")
+	require.NoError(t, err)
+	tasks, err = scan.PlanFiles(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Len(t, tasks[0].DeleteFiles, 1)
+	assert.ElementsMatch(t, []int64{0, 2}, ids(scan))
+
+	scan, err = scan.UnionRefs("before")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{0, 1, 2}, ids(scan))
+}
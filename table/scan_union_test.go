@@ -0,0 +1,97 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanUnionRefs(t *testing.T) {
+	ctx := context.Background()
+	cat := &mockedCatalog{}
+	tbl := newTestTable(t, withTestCatalog(cat))
+	cat.metadata = tbl.Metadata()
+	ident := tbl.Identifier()
+	fsF := func(context.Context) (iceio.IO, error) { return iceio.LocalFS{}, nil }
+
+	arrSchema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	appendIDs := func(tbl *table.Table, a, b int64) *table.Table {
+		arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{
+			fmt.Sprintf(`[{"id": %d}, {"id": %d}]`, a, b),
+		})
+		require.NoError(t, err)
+		defer arrTbl.Release()
+
+		tbl, err = tbl.AppendTable(ctx, arrTbl, 2, nil)
+		require.NoError(t, err)
+
+		return tbl
+	}
+
+	tbl = appendIDs(tbl, 1, 2)
+	published := tbl.CurrentSnapshot().SnapshotID
+	tbl = appendIDs(tbl, 3, 4)
+	staged := tbl.CurrentSnapshot().SnapshotID
+
+	// stage the second append on an audit branch and move main back, as a
+	// write-audit-publish workflow would, then keep writing to main
+	var err error
+	cat.metadata, _, err = cat.CommitTable(ctx, ident, nil, []table.Update{
+		table.NewSetSnapshotRefUpdate("audit", staged, table.BranchRef, -1, -1, -1),
+		table.NewSetSnapshotRefUpdate("published", published, table.TagRef, -1, -1, -1),
+		table.NewSetSnapshotRefUpdate(table.MainBranch, published, table.BranchRef, -1, -1, -1),
+	})
+	require.NoError(t, err)
+	tbl = appendIDs(table.New(ident, cat.metadata, "", fsF, cat), 5, 6)
+
+	assert.ElementsMatch(t, []int64{1, 2, 5, 6}, scanIDs(t, tbl.Scan()))
+
+	scan, err := tbl.Scan().UnionRefs("audit")
+	require.NoError(t, err)
+	tasks, err := scan.PlanFiles(ctx)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 3, "the file shared by main and the branch is planned once")
+	assert.ElementsMatch(t, []int64{1, 2, 3, 4, 5, 6}, scanIDs(t, scan))
+
+	scan, err = tbl.Scan().UnionRefs("published", "published")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{1, 2, 5, 6}, scanIDs(t, scan))
+
+	scan, err = tbl.Scan(table.WithRowFilter(iceberg.GreaterThan(iceberg.Reference("id"), int64(2)))).
+		UnionRefs("audit")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []int64{3, 4, 5, 6}, scanIDs(t, scan))
+
+	scan, err = tbl.Scan(table.WithLimit(2)).UnionRefs("audit")
+	require.NoError(t, err)
+	assert.Len(t, scanIDs(t, scan), 2)
+
+	_, err = tbl.Scan().UnionRefs("missing")
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
@@ -20,8 +20,11 @@ package table
 import (
 	"cmp"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"hash/fnv"
+	"io"
 	"iter"
 	"math"
 	"slices"
@@ -29,8 +32,11 @@ import (
 
 	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/compute"
+	"github.com/apache/arrow-go/v18/arrow/memory"
 	"github.com/apache/iceberg-go"
-	"github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/internal"
+	iceio "github.com/apache/iceberg-go/io"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -93,61 +99,143 @@ func (p partitionRecord) Size() int            { return len(p) }
 func (p partitionRecord) Get(pos int) any      { return p[pos] }
 func (p partitionRecord) Set(pos int, val any) { p[pos] = val }
 
+// dataEntry is a data file manifest entry along with the ID of the schema
+// that its manifest was written with.
+type dataEntry struct {
+	iceberg.ManifestEntry
+	schemaID *int
+}
+
 // manifestEntries holds the data and positional delete entries read from manifests.
 type manifestEntries struct {
-	dataEntries             []iceberg.ManifestEntry
+	dataEntries             []dataEntry
 	positionalDeleteEntries []iceberg.ManifestEntry
 	mu                      sync.Mutex
 }
 
 func newManifestEntries() *manifestEntries {
 	return &manifestEntries{
-		dataEntries:             make([]iceberg.ManifestEntry, 0),
+		dataEntries:             make([]dataEntry, 0),
 		positionalDeleteEntries: make([]iceberg.ManifestEntry, 0),
 	}
 }
 
-func (m *manifestEntries) addDataEntry(e iceberg.ManifestEntry) {
+// addEntries sorts the entries read from a single manifest into data and
+// positional delete entries, taking the lock once for the whole manifest.
+func (m *manifestEntries) addEntries(es []iceberg.ManifestEntry, schemaID *int) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.dataEntries = append(m.dataEntries, e)
-}
 
-func (m *manifestEntries) addPositionalDeleteEntry(e iceberg.ManifestEntry) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	m.positionalDeleteEntries = append(m.positionalDeleteEntries, e)
+	for _, e := range es {
+		df := e.DataFile()
+		switch df.ContentType() {
+		case iceberg.EntryContentData:
+			m.dataEntries = append(m.dataEntries, dataEntry{ManifestEntry: e, schemaID: schemaID})
+		case iceberg.EntryContentPosDeletes:
+			m.positionalDeleteEntries = append(m.positionalDeleteEntries, e)
+		case iceberg.EntryContentEqDeletes:
+			return errors.New("iceberg-go does not yet support equality deletes")
+		default:
+			return fmt.Errorf("%w: unknown DataFileContent type (%s): %s",
+				ErrInvalidMetadata, df.ContentType(), e)
+		}
+	}
+
+	return nil
 }
 
 func getPartitionRecord(dataFile iceberg.DataFile, partitionType *iceberg.StructType) partitionRecord {
-	partitionData := dataFile.Partition()
-
 	out := make(partitionRecord, len(partitionType.FieldList))
+	fillPartitionRecord(out, dataFile, partitionType)
+
+	return out
+}
+
+// fillPartitionRecord copies the partition values of dataFile into rec in
+// the field order of partitionType, so a single record can be reused for
+// every entry of a manifest.
+func fillPartitionRecord(rec partitionRecord, dataFile iceberg.DataFile, partitionType *iceberg.StructType) {
+	partitionData := dataFile.Partition()
 	for i, f := range partitionType.FieldList {
-		out[i] = partitionData[f.ID]
+		rec[i] = partitionData[f.ID]
 	}
+}
 
-	return out
+// partitionPlan is the per-spec state needed to evaluate the partition
+// filter against data files. It is computed once per spec and shared
+// between planning goroutines; evaluators built from it are not.
+type partitionPlan struct {
+	partType   *iceberg.StructType
+	partSchema *iceberg.Schema
+	partExpr   iceberg.BooleanExpression
 }
 
-func openManifest(io io.IO, manifest iceberg.ManifestFile,
-	partitionFilter, metricsEval func(iceberg.DataFile) (bool, error),
-) ([]iceberg.ManifestEntry, error) {
-	entries, err := manifest.FetchEntries(io, true)
+// evaluator returns a partition filter for the data files of a single
+// manifest. The returned function holds mutable state and must not be
+// shared between goroutines.
+func (p *partitionPlan) evaluator(caseSensitive bool) (func(iceberg.DataFile) (bool, error), error) {
+	if p.partExpr.Equals(iceberg.AlwaysTrue{}) {
+		return func(iceberg.DataFile) (bool, error) { return true, nil }, nil
+	}
+
+	fn, err := iceberg.ExpressionEvaluator(p.partSchema, p.partExpr, caseSensitive)
 	if err != nil {
 		return nil, err
 	}
 
-	out := make([]iceberg.ManifestEntry, 0, len(entries))
-	for _, entry := range entries {
+	rec := make(partitionRecord, len(p.partType.FieldList))
+
+	return func(d iceberg.DataFile) (bool, error) {
+		fillPartitionRecord(rec, d, p.partType)
+
+		return fn(rec)
+	}, nil
+}
+
+// openManifest reads the live entries of the manifest that pass both
+// filters, along with the ID of the schema the manifest was written with,
+// or nil if the manifest does not record one.
+func openManifest(fs iceio.IO, manifest iceberg.ManifestFile,
+	partitionFilter, metricsEval func(iceberg.DataFile) (bool, error),
+) (_ []iceberg.ManifestEntry, _ *int, err error) {
+	f, err := fs.Open(manifest.FilePath())
+	if err != nil {
+		return nil, nil, err
+	}
+	defer internal.CheckedClose(f, &err)
+
+	rdr, err := iceberg.NewManifestReader(manifest, f)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var schemaID *int
+	if id, err := rdr.SchemaID(); err == nil {
+		schemaID = &id
+	}
+
+	out := make([]iceberg.ManifestEntry, 0)
+	for {
+		entry, err := rdr.ReadEntry()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if entry.Status() == iceberg.EntryStatusDELETED {
+			continue
+		}
+
 		p, err := partitionFilter(entry.DataFile())
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		m, err := metricsEval(entry.DataFile())
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		if p && m {
@@ -155,7 +243,7 @@ func openManifest(io io.IO, manifest iceberg.ManifestFile,
 		}
 	}
 
-	return out, nil
+	return out, schemaID, nil
 }
 
 type Scan struct {
@@ -168,9 +256,23 @@ type Scan struct {
 	asOfTimestamp  *int64
 	options        iceberg.Properties
 	limit          int64
+	offset         int64
+
+	// unionSnapshotIDs are the snapshots, besides the snapshot of the scan,
+	// whose files are planned along with it, see UnionRefs.
+	unionSnapshotIDs []int64
+	// sample selects the files read by the scan, see Sample.
+	sample *fileSample
+	// reproducibleOrder orders the tasks and their delete files by path,
+	// see WithReproducibleOrder.
+	reproducibleOrder bool
 
 	partitionFilters *keyDefaultMap[int, iceberg.BooleanExpression]
 	concurrency      int
+
+	mem       memory.Allocator
+	memLimit  int64
+	memPolicy BudgetPolicy
 }
 
 func (scan *Scan) UseRowLimit(n int64) *Scan {
@@ -197,6 +299,74 @@ func (scan *Scan) UseRef(name string) (*Scan, error) {
 	return nil, fmt.Errorf("%w: cannot scan unknown ref=%s", iceberg.ErrInvalidArgument, name)
 }
 
+// UnionRefs returns a copy of the scan which reads the union of the files
+// of the scan's snapshot and the snapshots referenced by the given branches
+// and tags. A file live in more than one of the snapshots is read once,
+// with only the delete files that apply to it in every snapshot it is live
+// in, so the scan returns each row visible in any of the snapshots.
+//
+// This can be used to validate the changes staged on a write-audit-publish
+// branch together with the data of main before publishing them.
+func (scan *Scan) UnionRefs(names ...string) (*Scan, error) {
+	ids := slices.Clone(scan.unionSnapshotIDs)
+	for _, name := range names {
+		snap := scan.metadata.SnapshotByName(name)
+		if snap == nil {
+			return nil, fmt.Errorf("%w: cannot scan unknown ref=%s", iceberg.ErrInvalidArgument, name)
+		}
+
+		if !slices.Contains(ids, snap.SnapshotID) {
+			ids = append(ids, snap.SnapshotID)
+		}
+	}
+
+	out := *scan
+	out.unionSnapshotIDs = ids
+
+	return &out, nil
+}
+
+// fileSample deterministically selects a fraction of files by hashing
+// their paths with a seed.
+type fileSample struct {
+	fraction float64
+	seed     int64
+}
+
+// includes reports whether the file at path is part of the sample. The
+// selection of a file depends only on its path and the seed, so the same
+// files are selected by every scan with the same seed regardless of the
+// order in which files are planned.
+func (f fileSample) includes(path string) bool {
+	h := fnv.New64a()
+	var seed [8]byte
+	binary.LittleEndian.PutUint64(seed[:], uint64(f.seed))
+	h.Write(seed[:])
+	h.Write([]byte(path))
+
+	// the top 53 bits of the hash as a uniform value in [0, 1)
+	return float64(h.Sum64()>>11)/(1<<53) < f.fraction
+}
+
+// Sample returns a copy of the scan which reads an approximate fraction of
+// the table, for profiling or data quality checks on large tables. Rather
+// than sampling rows, whole data files are selected deterministically from
+// their paths and the seed, so repeating a sample with the same seed reads
+// the same files while a different seed selects a different subset. The
+// number of rows read is only proportional to fraction when the files are
+// of similar sizes. The fraction must be in (0, 1].
+func (scan *Scan) Sample(fraction float64, seed int64) (*Scan, error) {
+	if !(fraction > 0 && fraction <= 1) {
+		return nil, fmt.Errorf("%w: sample fraction must be in (0, 1], got %v",
+			iceberg.ErrInvalidArgument, fraction)
+	}
+
+	out := *scan
+	out.sample = &fileSample{fraction: fraction, seed: seed}
+
+	return &out, nil
+}
+
 func (scan *Scan) Snapshot() *Snapshot {
 	if scan.snapshotID != nil {
 		return scan.metadata.SnapshotByID(*scan.snapshotID)
@@ -215,30 +385,92 @@ func (scan *Scan) Snapshot() *Snapshot {
 	return scan.metadata.CurrentSnapshot()
 }
 
-func (scan *Scan) Projection() (*iceberg.Schema, error) {
-	curSchema := scan.metadata.CurrentSchema()
-	if scan.snapshotID != nil {
-		snap := scan.metadata.SnapshotByID(*scan.snapshotID)
-		if snap == nil {
+// schema returns the table schema that the scan reads with. When time
+// traveling to a snapshot, that is the schema the snapshot was written
+// with so that columns which were since dropped or renamed can still be
+// selected and filtered on. Otherwise it is the current table schema.
+func (scan *Scan) schema() (*iceberg.Schema, error) {
+	if scan.snapshotID == nil && scan.asOfTimestamp == nil {
+		return scan.metadata.CurrentSchema(), nil
+	}
+
+	snap := scan.Snapshot()
+	if snap == nil {
+		if scan.snapshotID != nil {
 			return nil, fmt.Errorf("%w: snapshot not found: %d", ErrInvalidOperation, *scan.snapshotID)
 		}
 
-		if snap.SchemaID != nil {
-			for _, schema := range scan.metadata.Schemas() {
-				if schema.ID == *snap.SchemaID {
-					curSchema = schema
+		return nil, fmt.Errorf("%w: no snapshot found for timestamp %d", ErrInvalidOperation, *scan.asOfTimestamp)
+	}
 
-					break
-				}
+	if snap.SchemaID != nil {
+		for _, schema := range scan.metadata.Schemas() {
+			if schema.ID == *snap.SchemaID {
+				return schema, nil
 			}
 		}
 	}
 
-	if slices.Contains(scan.selectedFields, "*") {
-		return curSchema, nil
+	return scan.metadata.CurrentSchema(), nil
+}
+
+func (scan *Scan) Projection() (*iceberg.Schema, error) {
+	curSchema, err := scan.schema()
+	if err != nil {
+		return nil, err
 	}
 
-	return curSchema.Select(scan.caseSensitive, scan.selectedFields...)
+	// selected names that are not table columns may be metadata columns
+	names := make([]string, 0, len(scan.selectedFields))
+	var metaCols []iceberg.NestedField
+	for _, name := range scan.selectedFields {
+		if name != "*" && !scan.hasColumn(curSchema, name) {
+			if col, ok := metadataColumn(scan.metadata, curSchema, name, scan.caseSensitive); ok {
+				metaCols = append(metaCols, col)
+
+				continue
+			}
+		}
+		names = append(names, name)
+	}
+
+	projected := curSchema
+	if !slices.Contains(names, "*") {
+		if projected, err = curSchema.Select(scan.caseSensitive, names...); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(metaCols) == 0 {
+		return projected, nil
+	}
+
+	return iceberg.NewSchemaWithIdentifiers(projected.ID, projected.IdentifierFieldIDs,
+		slices.Concat(projected.Fields(), metaCols)...), nil
+}
+
+// ArrowSchema returns the Arrow schema of the records returned by
+// ToArrowRecords, including the effect of the scan options on the Arrow
+// types, without planning the scan.
+func (scan *Scan) ArrowSchema() (*arrow.Schema, error) {
+	projection, err := scan.Projection()
+	if err != nil {
+		return nil, err
+	}
+
+	return (&arrowScan{projectedSchema: projection, options: scan.options}).resultSchema()
+}
+
+func (scan *Scan) hasColumn(schema *iceberg.Schema, name string) bool {
+	if scan.caseSensitive {
+		_, ok := schema.FindFieldByName(name)
+
+		return ok
+	}
+
+	_, ok := schema.FindFieldByNameCaseInsensitive(name)
+
+	return ok
 }
 
 func (scan *Scan) buildPartitionProjection(specID int) (iceberg.BooleanExpression, error) {
@@ -246,7 +478,11 @@ func (scan *Scan) buildPartitionProjection(specID int) (iceberg.BooleanExpressio
 	if spec == nil {
 		return nil, fmt.Errorf("%w: id %d", ErrPartitionSpecNotFound, specID)
 	}
-	project := newInclusiveProjection(scan.metadata.CurrentSchema(), *spec, true)
+	schema, err := scan.schema()
+	if err != nil {
+		return nil, err
+	}
+	project := newInclusiveProjection(schema, *spec, true)
 
 	return project(scan.rowFilter)
 }
@@ -257,29 +493,42 @@ func (scan *Scan) buildManifestEvaluator(specID int) (func(iceberg.ManifestFile)
 		return nil, fmt.Errorf("%w: id %d", ErrPartitionSpecNotFound, specID)
 	}
 
-	return newManifestEvaluator(*spec, scan.metadata.CurrentSchema(),
+	schema, err := scan.schema()
+	if err != nil {
+		return nil, err
+	}
+
+	return newManifestEvaluator(*spec, schema,
 		scan.partitionFilters.Get(specID), scan.caseSensitive)
 }
 
-func (scan *Scan) buildPartitionEvaluator(specID int) (func(iceberg.DataFile) (bool, error), error) {
+func (scan *Scan) buildPartitionPlan(specID int) (*partitionPlan, error) {
 	spec := scan.metadata.PartitionSpecByID(specID)
 	if spec == nil {
 		return nil, fmt.Errorf("%w: id %d", ErrPartitionSpecNotFound, specID)
 	}
-	partType := spec.PartitionType(scan.metadata.CurrentSchema())
-	partSchema := iceberg.NewSchema(0, partType.FieldList...)
-	partExpr := scan.partitionFilters.Get(specID)
-
-	fn, err := iceberg.ExpressionEvaluator(partSchema, partExpr, scan.caseSensitive)
+	schema, err := scan.schema()
 	if err != nil {
 		return nil, err
 	}
+	partType := spec.PartitionType(schema)
 
-	return func(d iceberg.DataFile) (bool, error) {
-		return fn(getPartitionRecord(d, partType))
+	return &partitionPlan{
+		partType:   partType,
+		partSchema: iceberg.NewSchema(0, partType.FieldList...),
+		partExpr:   scan.partitionFilters.Get(specID),
 	}, nil
 }
 
+func (scan *Scan) buildPartitionEvaluator(specID int) (func(iceberg.DataFile) (bool, error), error) {
+	plan, err := scan.buildPartitionPlan(specID)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan.evaluator(scan.caseSensitive)
+}
+
 func (scan *Scan) checkSequenceNumber(minSeqNum int64, manifest iceberg.ManifestFile) bool {
 	return manifest.ManifestContent() == iceberg.ManifestContentData ||
 		(manifest.ManifestContent() == iceberg.ManifestContentDeletes &&
@@ -300,32 +549,6 @@ func minSequenceNum(manifests []iceberg.ManifestFile) int64 {
 	return n
 }
 
-func matchDeletesToData(entry iceberg.ManifestEntry, positionalDeletes []iceberg.ManifestEntry) ([]iceberg.DataFile, error) {
-	idx, _ := slices.BinarySearchFunc(positionalDeletes, entry, func(me1, me2 iceberg.ManifestEntry) int {
-		return cmp.Compare(me1.SequenceNum(), me2.SequenceNum())
-	})
-
-	evaluator, err := newInclusiveMetricsEvaluator(iceberg.PositionalDeleteSchema,
-		iceberg.EqualTo(iceberg.Reference("file_path"), entry.DataFile().FilePath()), true, false)
-	if err != nil {
-		return nil, err
-	}
-
-	out := make([]iceberg.DataFile, 0)
-	for _, relevant := range positionalDeletes[idx:] {
-		df := relevant.DataFile()
-		ok, err := evaluator(df)
-		if err != nil {
-			return nil, err
-		}
-		if ok {
-			out = append(out, df)
-		}
-	}
-
-	return out, nil
-}
-
 // fetchPartitionSpecFilteredManifests retrieves the table's current snapshot,
 // fetches its manifest files, and applies partition-spec filters to remove irrelevant manifests.
 func (scan *Scan) fetchPartitionSpecFilteredManifests(ctx context.Context) ([]iceberg.ManifestFile, error) {
@@ -356,18 +579,29 @@ func (scan *Scan) fetchPartitionSpecFilteredManifests(ctx context.Context) ([]ic
 	return manifestList, nil
 }
 
+// metricsEvaluator returns the evaluator of the row filter of the scan
+// against the column metrics of a file.
+func (scan *Scan) metricsEvaluator() (func(iceberg.DataFile) (bool, error), error) {
+	schema, err := scan.schema()
+	if err != nil {
+		return nil, err
+	}
+
+	return newInclusiveMetricsEvaluator(
+		schema,
+		scan.rowFilter,
+		scan.caseSensitive,
+		scan.options["include_empty_files"] == "true",
+	)
+}
+
 // collectManifestEntries concurrently opens manifests, applies partition and metrics
 // filters, and accumulates both data entries and positional-delete entries.
 func (scan *Scan) collectManifestEntries(
 	ctx context.Context,
 	manifestList []iceberg.ManifestFile,
 ) (*manifestEntries, error) {
-	metricsEval, err := newInclusiveMetricsEvaluator(
-		scan.metadata.CurrentSchema(),
-		scan.rowFilter,
-		scan.caseSensitive,
-		scan.options["include_empty_files"] == "true",
-	)
+	metricsEval, err := scan.metricsEvaluator()
 	if err != nil {
 		return nil, err
 	}
@@ -379,7 +613,7 @@ func (scan *Scan) collectManifestEntries(
 	g, _ := errgroup.WithContext(ctx)
 	g.SetLimit(concurrencyLimit)
 
-	partitionEvaluators := newKeyDefaultMapWrapErr(scan.buildPartitionEvaluator)
+	partitionPlans := newKeyDefaultMapWrapErr(scan.buildPartitionPlan)
 
 	for _, mf := range manifestList {
 		if !scan.checkSequenceNumber(minSeqNum, mf) {
@@ -391,28 +625,16 @@ func (scan *Scan) collectManifestEntries(
 			if err != nil {
 				return err
 			}
-			partEval := partitionEvaluators.Get(int(mf.PartitionSpecID()))
-			manifestEntries, err := openManifest(fs, mf, partEval, metricsEval)
+			partEval, err := partitionPlans.Get(int(mf.PartitionSpecID())).evaluator(scan.caseSensitive)
 			if err != nil {
 				return err
 			}
-
-			for _, e := range manifestEntries {
-				df := e.DataFile()
-				switch df.ContentType() {
-				case iceberg.EntryContentData:
-					entries.addDataEntry(e)
-				case iceberg.EntryContentPosDeletes:
-					entries.addPositionalDeleteEntry(e)
-				case iceberg.EntryContentEqDeletes:
-					return errors.New("iceberg-go does not yet support equality deletes")
-				default:
-					return fmt.Errorf("%w: unknown DataFileContent type (%s): %s",
-						ErrInvalidMetadata, df.ContentType(), e)
-				}
+			manifestEntries, schemaID, err := openManifest(fs, mf, partEval, metricsEval)
+			if err != nil {
+				return err
 			}
 
-			return nil
+			return entries.addEntries(manifestEntries, schemaID)
 		})
 	}
 
@@ -443,6 +665,16 @@ func (scan *Scan) PlanFiles(ctx context.Context) ([]FileScanTask, error) {
 		scan.snapshotID = &snapshot.SnapshotID
 		scan.asOfTimestamp = nil
 	}
+
+	if len(scan.unionSnapshotIDs) > 0 {
+		return scan.planUnionFiles(ctx)
+	}
+
+	residual, err := scan.boundRowFilter()
+	if err != nil {
+		return nil, err
+	}
+
 	// Step 1: Retrieve filtered manifests based on snapshot and partition specs.
 	manifestList, err := scan.fetchPartitionSpecFilteredManifests(ctx)
 	if err != nil || len(manifestList) == 0 {
@@ -455,32 +687,284 @@ func (scan *Scan) PlanFiles(ctx context.Context) ([]FileScanTask, error) {
 		return nil, err
 	}
 
-	// Step 3: Sort positional deletes and match them to data files.
-	slices.SortFunc(entries.positionalDeleteEntries, func(a, b iceberg.ManifestEntry) int {
-		return cmp.Compare(a.SequenceNum(), b.SequenceNum())
-	})
+	// Step 3: Index positional deletes and match them to data files.
+	return scan.planTasks(entries, residual)
+}
+
+// planTasks matches the positional delete entries to the data entries
+// and returns the tasks reading the data files, in path order.
+func (scan *Scan) planTasks(entries *manifestEntries, residual iceberg.BooleanExpression) ([]FileScanTask, error) {
+	minDataSeqNum := int64(math.MaxInt64)
+	for _, e := range entries.dataEntries {
+		minDataSeqNum = min(minDataSeqNum, e.SequenceNum())
+	}
+	deleteIndex := newPositionDeleteIndex(entries.positionalDeleteEntries, minDataSeqNum)
+	residuals := make(map[int32]*residualEvaluator)
 
 	results := make([]FileScanTask, 0, len(entries.dataEntries))
 	for _, e := range entries.dataEntries {
-		deleteFiles, err := matchDeletesToData(e, entries.positionalDeleteEntries)
+		if scan.sample != nil && !scan.sample.includes(e.DataFile().FilePath()) {
+			continue
+		}
+
+		deleteFiles, err := deleteIndex.forDataFile(e.ManifestEntry)
+		if err != nil {
+			return nil, err
+		}
+
+		fileResidual, err := scan.residualFor(residuals, residual, e.DataFile())
 		if err != nil {
 			return nil, err
 		}
+
+		seqNum := e.SequenceNum()
 		results = append(results, FileScanTask{
-			File:        e.DataFile(),
-			DeleteFiles: deleteFiles,
-			Start:       0,
-			Length:      e.DataFile().FileSizeBytes(),
+			File:               e.DataFile(),
+			DeleteFiles:        deleteFiles,
+			Residual:           fileResidual,
+			SchemaID:           e.schemaID,
+			DataSequenceNumber: &seqNum,
+			Start:              0,
+			Length:             e.DataFile().FileSizeBytes(),
 		})
 	}
 
+	// manifests are read concurrently, so sort the tasks to return rows
+	// in a stable order that limits and offsets can page through
+	scan.sortTasks(results)
+
+	if scan.limit >= 0 {
+		return scan.limitTasks(results, scan.offset+scan.limit)
+	}
+
+	return results, nil
+}
+
+// residualFor returns the residual of the bound row filter for dataFile,
+// reusing the evaluators of the partition specs in evaluators.
+func (scan *Scan) residualFor(evaluators map[int32]*residualEvaluator, filter iceberg.BooleanExpression, dataFile iceberg.DataFile) (iceberg.BooleanExpression, error) {
+	if filter == nil {
+		return nil, nil
+	}
+
+	eval, ok := evaluators[dataFile.SpecID()]
+	if !ok {
+		spec := scan.metadata.PartitionSpecByID(int(dataFile.SpecID()))
+		if spec == nil {
+			return nil, fmt.Errorf("%w: id %d", ErrPartitionSpecNotFound, dataFile.SpecID())
+		}
+
+		schema, err := scan.schema()
+		if err != nil {
+			return nil, err
+		}
+
+		if eval, err = newResidualEvaluator(*spec, schema, filter, scan.caseSensitive); err != nil {
+			return nil, err
+		}
+		evaluators[dataFile.SpecID()] = eval
+	}
+
+	return eval.Residual(dataFile)
+}
+
+// sortTasks orders tasks by file path. In reproducible order, tasks of
+// the same file are ordered by offset and the delete files of each task
+// by path, so that the plan does not depend on the order in which
+// manifests were read.
+func (scan *Scan) sortTasks(tasks []FileScanTask) {
+	if !scan.reproducibleOrder {
+		slices.SortFunc(tasks, func(a, b FileScanTask) int {
+			return cmp.Compare(a.File.FilePath(), b.File.FilePath())
+		})
+
+		return
+	}
+
+	for _, task := range tasks {
+		slices.SortFunc(task.DeleteFiles, func(a, b iceberg.DataFile) int {
+			return cmp.Compare(a.FilePath(), b.FilePath())
+		})
+	}
+
+	slices.SortFunc(tasks, func(a, b FileScanTask) int {
+		return cmp.Or(
+			cmp.Compare(a.File.FilePath(), b.File.FilePath()),
+			cmp.Compare(a.Start, b.Start))
+	})
+}
+
+// planUnionFiles plans the files of the scan's snapshot and of each of
+// its union snapshots separately and merges the tasks, reading each data
+// file once.
+func (scan *Scan) planUnionFiles(ctx context.Context) ([]FileScanTask, error) {
+	var ids []int64
+	if snap := scan.Snapshot(); snap != nil {
+		ids = append(ids, snap.SnapshotID)
+	}
+	for _, id := range scan.unionSnapshotIDs {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	var (
+		results []FileScanTask
+		byPath  = make(map[string]int)
+	)
+	for _, id := range ids {
+		snap := *scan
+		snap.snapshotID = &id
+		snap.unionSnapshotIDs = nil
+		snap.limit = ScanNoLimit
+		snap.partitionFilters = newKeyDefaultMapWrapErr(snap.buildPartitionProjection)
+
+		tasks, err := snap.PlanFiles(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, task := range tasks {
+			i, ok := byPath[task.File.FilePath()]
+			if !ok {
+				byPath[task.File.FilePath()] = len(results)
+				results = append(results, task)
+
+				continue
+			}
+
+			// a row deleted in one snapshot may still be visible in another
+			results[i].DeleteFiles = slices.DeleteFunc(results[i].DeleteFiles, func(df iceberg.DataFile) bool {
+				return !slices.ContainsFunc(task.DeleteFiles, func(other iceberg.DataFile) bool {
+					return other.FilePath() == df.FilePath()
+				})
+			})
+		}
+	}
+
+	scan.sortTasks(results)
+
+	if scan.limit >= 0 {
+		return scan.limitTasks(results, scan.offset+scan.limit)
+	}
+
 	return results, nil
 }
 
+// taskRowCounter returns a function that reports the number of rows a
+// task is guaranteed to return and whether that number is exact. Only
+// files whose metrics show that every row matches the row filter are
+// counted, less the rows that their position deletes may remove.
+func (scan *Scan) taskRowCounter() (func(FileScanTask) (int64, bool, error), error) {
+	schema, err := scan.schema()
+	if err != nil {
+		return nil, err
+	}
+
+	strictEval, err := newStrictMetricsEvaluator(schema,
+		scan.rowFilter, scan.caseSensitive, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(task FileScanTask) (int64, bool, error) {
+		allMatch, err := strictEval(task.File)
+		if err != nil || !allMatch {
+			return 0, false, err
+		}
+
+		rows := task.File.Count()
+		for _, df := range task.DeleteFiles {
+			rows -= df.Count()
+		}
+
+		return max(rows, 0), len(task.DeleteFiles) == 0, nil
+	}, nil
+}
+
+// limitTasks drops the tasks that are not needed once the preceding
+// tasks are guaranteed to return at least n rows.
+func (scan *Scan) limitTasks(tasks []FileScanTask, n int64) ([]FileScanTask, error) {
+	countRows, err := scan.taskRowCounter()
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for i, task := range tasks {
+		if total >= n {
+			return tasks[:i], nil
+		}
+
+		rows, _, err := countRows(task)
+		if err != nil {
+			return nil, err
+		}
+		total += rows
+	}
+
+	return tasks, nil
+}
+
+// skipOffsetTasks drops the leading tasks whose rows all fall within the
+// scan's offset and returns the remaining tasks along with the number of
+// rows still to be skipped from them.
+func (scan *Scan) skipOffsetTasks(tasks []FileScanTask) ([]FileScanTask, int64, error) {
+	offset := scan.offset
+	if offset <= 0 {
+		return tasks, 0, nil
+	}
+
+	countRows, err := scan.taskRowCounter()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i, task := range tasks {
+		rows, exact, err := countRows(task)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if !exact || rows > offset {
+			return tasks[i:], offset, nil
+		}
+		offset -= rows
+	}
+
+	return nil, offset, nil
+}
+
 type FileScanTask struct {
-	File          iceberg.DataFile
-	DeleteFiles   []iceberg.DataFile
-	Start, Length int64
+	File        iceberg.DataFile
+	DeleteFiles []iceberg.DataFile
+	// Residual is the filter, bound to the table schema, that rows read
+	// from the file must still satisfy: the row filter of the scan with
+	// the predicates decided by the partition of the file replaced by
+	// AlwaysTrue or AlwaysFalse.
+	Residual iceberg.BooleanExpression
+	// SchemaID is the ID of the table schema that the manifest tracking
+	// the file was written with, or nil if the manifest did not record
+	// one. It is used to resolve the columns of files written without
+	// field IDs when the table has no name mapping.
+	SchemaID *int
+	// DataSequenceNumber is the data sequence number of the file, which
+	// its rows inherit as their _last_updated_sequence_number.
+	DataSequenceNumber *int64
+	Start, Length      int64
+}
+
+func (scan *Scan) boundRowFilter() (iceberg.BooleanExpression, error) {
+	if scan.rowFilter == nil {
+		return nil, nil
+	}
+
+	schema, err := scan.schema()
+	if err != nil {
+		return nil, err
+	}
+
+	return iceberg.BindExpr(schema, scan.rowFilter, scan.caseSensitive)
 }
 
 // ToArrowRecords returns the arrow schema of the expected records and an interator
@@ -492,17 +976,21 @@ type FileScanTask struct {
 // The purpose for returning the schema up front is to handle the case where there are no
 // rows returned. The resulting Arrow Schema of the projection will still be known.
 func (scan *Scan) ToArrowRecords(ctx context.Context) (*arrow.Schema, iter.Seq2[arrow.RecordBatch, error], error) {
+	ctx = scan.allocatorContext(ctx)
+
 	tasks, err := scan.PlanFiles(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var boundFilter iceberg.BooleanExpression
-	if scan.rowFilter != nil {
-		boundFilter, err = iceberg.BindExpr(scan.metadata.CurrentSchema(), scan.rowFilter, scan.caseSensitive)
-		if err != nil {
-			return nil, nil, err
-		}
+	tasks, offset, err := scan.skipOffsetTasks(tasks)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	boundFilter, err := scan.boundRowFilter()
+	if err != nil {
+		return nil, nil, err
 	}
 
 	schema, err := scan.Projection()
@@ -516,17 +1004,37 @@ func (scan *Scan) ToArrowRecords(ctx context.Context) (*arrow.Schema, iter.Seq2[
 	}
 
 	return (&arrowScan{
-		metadata:        scan.metadata,
+		tableProps:      scan.metadata.Properties(),
 		fs:              fs,
 		projectedSchema: schema,
 		boundRowFilter:  boundFilter,
 		caseSensitive:   scan.caseSensitive,
 		rowLimit:        scan.limit,
+		rowOffset:       offset,
 		options:         scan.options,
 		concurrency:     scan.concurrency,
+		schemas:         scan.metadata.Schemas(),
 	}).GetRecords(ctx, tasks)
 }
 
+// allocatorContext returns ctx with the allocator configured for the scan,
+// wrapping it in a MemoryBudget if a memory limit was set.
+func (scan *Scan) allocatorContext(ctx context.Context) context.Context {
+	mem := scan.mem
+	if mem == nil {
+		if scan.memLimit <= 0 {
+			return ctx
+		}
+		mem = compute.GetAllocator(ctx)
+	}
+
+	if scan.memLimit > 0 {
+		mem = NewMemoryBudget(mem, scan.memLimit, scan.memPolicy)
+	}
+
+	return compute.WithAllocator(ctx, mem)
+}
+
 // ToArrowTable calls ToArrowRecords and then gathers all of the records together
 // and returns an arrow.Table make from those records.
 func (scan *Scan) ToArrowTable(ctx context.Context) (arrow.Table, error) {
@@ -23,11 +23,12 @@ import (
 	"io"
 	"maps"
 	"slices"
+	"strconv"
 	"sync/atomic"
-	"time"
 
 	"github.com/apache/iceberg-go"
 	"github.com/apache/iceberg-go/config"
+	"github.com/apache/iceberg-go/encryption"
 	"github.com/apache/iceberg-go/internal"
 	iceio "github.com/apache/iceberg-go/io"
 	tblutils "github.com/apache/iceberg-go/table/internal"
@@ -140,7 +141,7 @@ func (of *overwriteFiles) existingManifests() ([]iceberg.ManifestFile, error) {
 		foundDeleted := make([]iceberg.ManifestEntry, 0)
 		notDeleted := make([]iceberg.ManifestEntry, 0, len(entries))
 		for _, entry := range entries {
-			if _, ok := of.base.deletedFiles[entry.DataFile().FilePath()]; ok {
+			if _, ok := of.base.deletedFiles[contentFileKey(entry.DataFile())]; ok {
 				foundDeleted = append(foundDeleted, entry)
 			} else {
 				notDeleted = append(notDeleted, entry)
@@ -164,7 +165,7 @@ func (of *overwriteFiles) existingManifests() ([]iceberg.ManifestFile, error) {
 				return nil, err
 			}
 
-			wr, path, counter, fileCloser, err := of.base.newManifestWriter(*spec)
+			wr, path, counter, fileCloser, err := of.base.newManifestWriter(*spec, m.ManifestContent())
 			if err != nil {
 				return nil, err
 			}
@@ -224,8 +225,7 @@ func (of *overwriteFiles) deletedEntries() ([]iceberg.ManifestEntry, error) {
 
 		result := make([]iceberg.ManifestEntry, 0, len(entries))
 		for _, entry := range entries {
-			_, ok := of.base.deletedFiles[entry.DataFile().FilePath()]
-			if ok && entry.DataFile().ContentType() == iceberg.EntryContentData {
+			if _, ok := of.base.deletedFiles[contentFileKey(entry.DataFile())]; ok {
 				seqNum := entry.SequenceNum()
 				result = append(result,
 					iceberg.NewManifestEntry(iceberg.EntryStatusDELETED,
@@ -268,7 +268,7 @@ func (m *manifestMergeManager) groupBySpec(manifests []iceberg.ManifestFile) map
 }
 
 func (m *manifestMergeManager) createManifest(specID int, bin []iceberg.ManifestFile) (mf iceberg.ManifestFile, err error) {
-	wr, path, counter, fileCloser, err := m.snap.newManifestWriter(m.snap.spec(specID))
+	wr, path, counter, fileCloser, err := m.snap.newManifestWriter(m.snap.spec(specID), iceberg.ManifestContentData)
 	if err != nil {
 		return nil, err
 	}
@@ -437,6 +437,12 @@ type snapshotProducer struct {
 	manifestCount    atomic.Int32
 	deletedFiles     map[string]iceberg.DataFile
 	snapshotProps    iceberg.Properties
+
+	// streamedManifests and streamedSummary track added data files that
+	// were written to manifests as they were received, instead of being
+	// held in addedFiles until the commit.
+	streamedManifests []iceberg.ManifestFile
+	streamedSummary   *SnapshotSummaryCollector
 }
 
 func createSnapshotProducer(op Operation, txn *Transaction, fs iceio.WriteFileIO, commitUUID *uuid.UUID, snapshotProps iceberg.Properties) *snapshotProducer {
@@ -446,7 +452,7 @@ func createSnapshotProducer(op Operation, txn *Transaction, fs iceio.WriteFileIO
 	)
 
 	if commitUUID == nil {
-		commit = uuid.New()
+		commit = txn.newCommitUUID()
 	} else {
 		commit = *commitUUID
 	}
@@ -460,7 +466,7 @@ func createSnapshotProducer(op Operation, txn *Transaction, fs iceio.WriteFileIO
 		io:               fs,
 		txn:              txn,
 		op:               op,
-		snapshotID:       txn.meta.newSnapshotID(),
+		snapshotID:       txn.newSnapshotID(),
 		parentSnapshotID: parentSnapshot,
 		addedFiles:       []iceberg.DataFile{},
 		deletedFiles:     make(map[string]iceberg.DataFile),
@@ -483,20 +489,50 @@ func (sp *snapshotProducer) appendDataFile(df iceberg.DataFile) *snapshotProduce
 }
 
 func (sp *snapshotProducer) deleteDataFile(df iceberg.DataFile) *snapshotProducer {
-	sp.deletedFiles[df.FilePath()] = df
+	sp.deletedFiles[contentFileKey(df)] = df
 
 	return sp
 }
 
-func (sp *snapshotProducer) newManifestWriter(spec iceberg.PartitionSpec) (_ *iceberg.ManifestWriter, _ string, _ *internal.CountingWriter, _ io.Closer, err error) {
+// contentFileKey identifies a file of the table. Deletion vectors are
+// blobs of puffin files that may hold several of them, so they are also
+// identified by their offset.
+func contentFileKey(df iceberg.DataFile) string {
+	if df.FileFormat() == iceberg.PuffinFile && df.ContentOffset() != nil {
+		return df.FilePath() + "#" + strconv.FormatInt(*df.ContentOffset(), 10)
+	}
+
+	return df.FilePath()
+}
+
+func (sp *snapshotProducer) newManifestWriter(spec iceberg.PartitionSpec, content iceberg.ManifestContent) (_ *iceberg.ManifestWriter, _ string, _ *internal.CountingWriter, _ io.Closer, err error) {
+	opts := []iceberg.ManifestWriterOption{manifestWriterOptions(sp.txn.meta.props)}
+	mgr, err := sp.encryptionManager()
+	if err != nil {
+		return nil, "", nil, nil, err
+	}
+
+	if mgr != nil {
+		key, err := mgr.NewFileKey()
+		if err != nil {
+			return nil, "", nil, nil, err
+		}
+		opts = append(opts, iceberg.WithManifestEncryption(key))
+	}
+
 	out, path, err := sp.newManifestOutput()
 	if err != nil {
 		return nil, "", nil, nil, err
 	}
 
+	newWriter := iceberg.NewManifestWriter
+	if content == iceberg.ManifestContentDeletes {
+		newWriter = iceberg.NewDeleteManifestWriter
+	}
+
 	counter := &internal.CountingWriter{W: out}
-	wr, err := iceberg.NewManifestWriter(sp.txn.meta.formatVersion, counter, spec,
-		sp.txn.meta.CurrentSchema(), sp.snapshotID)
+	wr, err := newWriter(sp.txn.meta.formatVersion, counter, spec,
+		sp.txn.meta.CurrentSchema(), sp.snapshotID, opts...)
 	if err != nil {
 		return nil, "", nil, nil, errors.Join(err, out.Close())
 	}
@@ -504,6 +540,36 @@ func (sp *snapshotProducer) newManifestWriter(spec iceberg.PartitionSpec) (_ *ic
 	return wr, path, counter, out, nil
 }
 
+// encryptionManager returns the manager encrypting the manifests and the
+// manifest list written by the producer, or nil if the table is not
+// encrypted.
+func (sp *snapshotProducer) encryptionManager() (*encryption.StandardEncryptionManager, error) {
+	enc := encryptionOf(sp.io)
+	if enc == nil {
+		return nil, nil
+	}
+
+	mgr, err := enc.manager()
+	if err != nil || mgr == nil {
+		return nil, err
+	}
+
+	if sp.txn.meta.formatVersion < 3 {
+		return nil, fmt.Errorf("%w: table encryption requires format version 3, got %d",
+			iceberg.ErrInvalidArgument, sp.txn.meta.formatVersion)
+	}
+
+	return mgr, nil
+}
+
+// manifestWriterOptions returns the options manifests and manifest lists
+// are written with for a table with the given properties.
+func manifestWriterOptions(props iceberg.Properties) iceberg.ManifestWriterOption {
+	return iceberg.WithManifestCompression(
+		props.Get(AvroCompressionKey, AvroCompressionDefault),
+		props.GetInt(AvroCompressionLevelKey, AvroCompressionLevelDefault))
+}
+
 func (sp *snapshotProducer) newManifestOutput() (io.WriteCloser, string, error) {
 	provider, err := sp.txn.tbl.LocationProvider()
 	if err != nil {
@@ -511,6 +577,7 @@ func (sp *snapshotProducer) newManifestOutput() (io.WriteCloser, string, error)
 	}
 	fname := newManifestFileName(int(sp.manifestCount.Add(1)), sp.commitUuid)
 	filepath := provider.NewMetadataLocation(fname)
+	sp.txn.written.add(filepath)
 	f, err := sp.io.Create(filepath)
 	if err != nil {
 		return nil, "", fmt.Errorf("could not create manifest file: %w", err)
@@ -531,70 +598,86 @@ func (sp *snapshotProducer) manifests() (_ []iceberg.ManifestFile, err error) {
 
 	var g errgroup.Group
 
-	results := [...][]iceberg.ManifestFile{nil, nil, nil}
+	results := [...][]iceberg.ManifestFile{nil, nil, nil, nil}
 
-	if len(sp.addedFiles) > 0 {
-		g.Go(func() (err error) {
-			out, path, err := sp.newManifestOutput()
-			if err != nil {
-				return err
-			}
-			defer internal.CheckedClose(out, &err)
+	// delete files are tracked in delete manifests, written for the spec
+	// that each delete file was written with
+	addedDataFiles := make([]iceberg.DataFile, 0, len(sp.addedFiles))
+	addedDeleteFiles := map[int][]iceberg.DataFile{}
+	for _, df := range sp.addedFiles {
+		if df.ContentType() == iceberg.EntryContentData {
+			addedDataFiles = append(addedDataFiles, df)
+		} else {
+			addedDeleteFiles[int(df.SpecID())] = append(addedDeleteFiles[int(df.SpecID())], df)
+		}
+	}
 
-			counter := &internal.CountingWriter{W: out}
+	// manifests of files that were streamed in were already written
+	results[0] = slices.Clone(sp.streamedManifests)
+	if len(addedDataFiles) > 0 {
+		g.Go(func() error {
 			currentSpec, err := sp.txn.meta.CurrentSpec()
 			if err != nil || currentSpec == nil {
 				return fmt.Errorf("could not get current partition spec: %w", err)
 			}
-			wr, err := iceberg.NewManifestWriter(sp.txn.meta.formatVersion, counter,
-				*currentSpec, sp.txn.meta.CurrentSchema(),
-				sp.snapshotID)
-			if err != nil {
-				return err
+
+			mfs, err := sp.writeAddedManifests(*currentSpec, iceberg.ManifestContentData, addedDataFiles)
+			if err == nil {
+				results[0] = append(results[0], mfs...)
 			}
-			defer internal.CheckedClose(wr, &err)
 
-			for _, df := range sp.addedFiles {
-				err := wr.Add(iceberg.NewManifestEntry(iceberg.EntryStatusADDED, &sp.snapshotID,
-					nil, nil, df))
+			return err
+		})
+	}
+
+	if len(addedDeleteFiles) > 0 {
+		g.Go(func() error {
+			for _, specid := range slices.Sorted(maps.Keys(addedDeleteFiles)) {
+				mfs, err := sp.writeAddedManifests(sp.spec(specid), iceberg.ManifestContentDeletes,
+					addedDeleteFiles[specid])
 				if err != nil {
 					return err
 				}
+				results[3] = append(results[3], mfs...)
 			}
 
-			// close the writer to force a flush and ensure counter.Count is accurate
-			if err := wr.Close(); err != nil {
-				return err
-			}
-
-			mf, err := wr.ToManifestFile(path, counter.Count)
-			if err == nil {
-				results[0] = append(results[0], mf)
-			}
-
-			return err
+			return nil
 		})
 	}
 
 	if len(deleted) > 0 {
 		g.Go(func() error {
-			partitionGroups := map[int][]iceberg.ManifestEntry{}
+			type groupKey struct {
+				specid   int
+				isDelete bool
+			}
+
+			partitionGroups := map[groupKey][]iceberg.ManifestEntry{}
 			for _, entry := range deleted {
-				specid := int(entry.DataFile().SpecID())
+				key := groupKey{
+					specid:   int(entry.DataFile().SpecID()),
+					isDelete: entry.DataFile().ContentType() != iceberg.EntryContentData,
+				}
 
-				group := partitionGroups[specid]
-				partitionGroups[specid] = append(group, entry)
+				group := partitionGroups[key]
+				partitionGroups[key] = append(group, entry)
 			}
 
-			writeGroup := func(specid int, entries []iceberg.ManifestEntry) (_ iceberg.ManifestFile, retErr error) {
+			writeGroup := func(key groupKey, entries []iceberg.ManifestEntry) (_ iceberg.ManifestFile, retErr error) {
 				out, path, err := sp.newManifestOutput()
 				if err != nil {
 					return nil, err
 				}
 				defer internal.CheckedClose(out, &retErr)
 
-				mf, err := iceberg.WriteManifest(path, out, sp.txn.meta.formatVersion,
-					sp.spec(specid), sp.txn.meta.CurrentSchema(), sp.snapshotID, entries)
+				write := iceberg.WriteManifest
+				if key.isDelete {
+					write = iceberg.WriteDeleteManifest
+				}
+
+				mf, err := write(path, out, sp.txn.meta.formatVersion,
+					sp.spec(key.specid), sp.txn.meta.CurrentSchema(), sp.snapshotID, entries,
+					manifestWriterOptions(sp.txn.meta.props))
 				if err != nil {
 					return nil, err
 				}
@@ -602,8 +685,8 @@ func (sp *snapshotProducer) manifests() (_ []iceberg.ManifestFile, err error) {
 				return mf, nil
 			}
 
-			for specid, entries := range partitionGroups {
-				mf, err := writeGroup(specid, entries)
+			for key, entries := range partitionGroups {
+				mf, err := writeGroup(key, entries)
 				if err != nil {
 					return err
 				}
@@ -628,13 +711,16 @@ func (sp *snapshotProducer) manifests() (_ []iceberg.ManifestFile, err error) {
 		return nil, err
 	}
 
-	manifests := slices.Concat(results[0], results[1], results[2])
+	manifests := slices.Concat(results[0], results[3], results[1], results[2])
 
 	return sp.processManifests(manifests)
 }
 
 func (sp *snapshotProducer) summary(props iceberg.Properties) (Summary, error) {
 	var ssc SnapshotSummaryCollector
+	if sp.streamedSummary != nil {
+		ssc = *sp.streamedSummary
+	}
 	partitionSummaryLimit := sp.txn.meta.props.
 		GetInt(WritePartitionSummaryLimitKey, WritePartitionSummaryLimitDefault)
 	ssc.setPartitionSummaryLimit(partitionSummaryLimit)
@@ -645,7 +731,12 @@ func (sp *snapshotProducer) summary(props iceberg.Properties) (Summary, error) {
 		return Summary{}, fmt.Errorf("could not get current partition spec: %w", err)
 	}
 	for _, df := range sp.addedFiles {
-		if err = ssc.addFile(df, currentSchema, *partitionSpec); err != nil {
+		spec := *partitionSpec
+		if df.ContentType() != iceberg.EntryContentData {
+			spec = sp.spec(int(df.SpecID()))
+		}
+
+		if err = ssc.addFile(df, currentSchema, spec); err != nil {
 			return Summary{}, err
 		}
 	}
@@ -679,6 +770,12 @@ func (sp *snapshotProducer) summary(props iceberg.Properties) (Summary, error) {
 }
 
 func (sp *snapshotProducer) commit() (_ []Update, _ []Requirement, err error) {
+	for _, df := range sp.addedFiles {
+		if err := validateContentFile(sp.txn.meta.formatVersion, df); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	newManifests, err := sp.manifests()
 	if err != nil {
 		return nil, nil, err
@@ -706,6 +803,23 @@ func (sp *snapshotProducer) commit() (_ []Update, _ []Requirement, err error) {
 	firstRowID := int64(0)
 	var addedRows int64
 
+	// the key metadata of an encrypted manifest list is wrapped by the
+	// KMS and added to the encryption keys of the table
+	opts := []iceberg.ManifestWriterOption{manifestWriterOptions(sp.txn.meta.props)}
+	var listKey *EncryptedKey
+	mgr, err := sp.encryptionManager()
+	if err != nil {
+		return nil, nil, err
+	}
+	if mgr != nil {
+		key, encryptedKey, err := encryptionOf(sp.io).newManifestListKey(mgr)
+		if err != nil {
+			return nil, nil, err
+		}
+		opts, listKey = append(opts, iceberg.WithManifestEncryption(key)), &encryptedKey
+	}
+
+	sp.txn.written.add(manifestListFilePath)
 	out, err := sp.io.Create(manifestListFilePath)
 	if err != nil {
 		return nil, nil, err
@@ -714,7 +828,8 @@ func (sp *snapshotProducer) commit() (_ []Update, _ []Requirement, err error) {
 
 	if sp.txn.meta.formatVersion == 3 {
 		firstRowID = sp.txn.meta.NextRowID()
-		writer, err := iceberg.NewManifestListWriterV3(out, sp.snapshotID, nextSequence, firstRowID, parentSnapshot)
+		writer, err := iceberg.NewManifestListWriterV3(out, sp.snapshotID, nextSequence, firstRowID, parentSnapshot,
+			opts...)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -727,7 +842,8 @@ func (sp *snapshotProducer) commit() (_ []Update, _ []Requirement, err error) {
 		}
 	} else {
 		err = iceberg.WriteManifestList(sp.txn.meta.formatVersion, out,
-			sp.snapshotID, parentSnapshot, &nextSequence, firstRowID, newManifests)
+			sp.snapshotID, parentSnapshot, &nextSequence, firstRowID, newManifests,
+			opts...)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -740,17 +856,23 @@ func (sp *snapshotProducer) commit() (_ []Update, _ []Requirement, err error) {
 		ManifestList:     manifestListFilePath,
 		Summary:          &summary,
 		SchemaID:         &sp.txn.meta.currentSchemaID,
-		TimestampMs:      time.Now().UnixMilli(),
+		TimestampMs:      sp.txn.meta.nowMs(),
 	}
 	if sp.txn.meta.formatVersion == 3 {
 		snapshot.FirstRowID = &firstRowID
 		snapshot.AddedRows = &addedRows
 	}
 
-	return []Update{
-			NewAddSnapshotUpdate(&snapshot),
-			NewSetSnapshotRefUpdate("main", sp.snapshotID, BranchRef, -1, -1, -1),
-		}, []Requirement{
-			AssertRefSnapshotID("main", sp.txn.meta.currentSnapshotID),
-		}, nil
+	var updates []Update
+	if listKey != nil {
+		snapshot.KeyID = &listKey.KeyID
+		updates = append(updates, NewAddEncryptionKeyUpdate(*listKey))
+	}
+	updates = append(updates,
+		NewAddSnapshotUpdate(&snapshot),
+		NewSetSnapshotRefUpdate("main", sp.snapshotID, BranchRef, -1, -1, -1))
+
+	return updates, []Requirement{
+		AssertRefSnapshotID("main", sp.txn.meta.currentSnapshotID),
+	}, nil
 }
@@ -0,0 +1,121 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/apache/iceberg-go"
+)
+
+// retentionPolicy is the table-wide snapshot retention applied to refs
+// that do not set their own retention.
+type retentionPolicy struct {
+	minSnapshotsToKeep int
+	maxSnapshotAgeMs   int64
+	maxRefAgeMs        int64
+}
+
+// newRetentionPolicy resolves the retention from the table properties,
+// overridden by the options given to ExpireSnapshots.
+func newRetentionPolicy(props iceberg.Properties, cfg expireSnapshotsCfg) retentionPolicy {
+	policy := retentionPolicy{
+		minSnapshotsToKeep: props.GetInt(HistoryExpireMinSnapshotsToKeepKey, MinSnapshotsToKeepDefault),
+		maxSnapshotAgeMs:   int64(props.GetInt(HistoryExpireMaxSnapshotAgeMsKey, MaxSnapshotAgeMsDefault)),
+		maxRefAgeMs:        int64(props.GetInt(HistoryExpireMaxRefAgeMsKey, MaxRefAgeMsDefault)),
+	}
+
+	if cfg.minSnapshotsToKeep != nil {
+		policy.minSnapshotsToKeep = *cfg.minSnapshotsToKeep
+	}
+	if cfg.maxSnapshotAgeMs != nil {
+		policy.maxSnapshotAgeMs = *cfg.maxSnapshotAgeMs
+	}
+
+	return policy
+}
+
+// refRetention is the outcome of applying a retentionPolicy to the refs
+// of a table.
+type refRetention struct {
+	// retained holds the IDs of the snapshots that must be kept.
+	retained map[int64]struct{}
+	// expiredRefs holds the names of the refs older than their
+	// max-ref-age-ms, sorted by name.
+	expiredRefs []string
+}
+
+// apply walks every ref of the table at nowMs. A ref other than main
+// whose snapshot is older than its max-ref-age-ms expires and retains
+// nothing. A tag retains its snapshot. A branch retains its head and then
+// every ancestor that is either among its min-snapshots-to-keep most
+// recent snapshots or younger than its max-snapshot-age-ms. Settings on
+// the ref take precedence over the policy.
+func (p retentionPolicy) apply(meta *MetadataBuilder, nowMs int64) (refRetention, error) {
+	result := refRetention{retained: make(map[int64]struct{})}
+
+	for _, name := range slices.Sorted(maps.Keys(meta.refs)) {
+		ref := meta.refs[name]
+
+		snap, err := meta.SnapshotByID(ref.SnapshotID)
+		if err != nil {
+			return refRetention{}, err
+		}
+
+		maxRefAgeMs := p.maxRefAgeMs
+		if ref.MaxRefAgeMs != nil {
+			maxRefAgeMs = *ref.MaxRefAgeMs
+		}
+
+		if name != MainBranch && nowMs-snap.TimestampMs > maxRefAgeMs {
+			result.expiredRefs = append(result.expiredRefs, name)
+
+			continue
+		}
+
+		result.retained[ref.SnapshotID] = struct{}{}
+		if ref.SnapshotRefType != BranchRef {
+			continue
+		}
+
+		minSnapshotsToKeep, maxSnapshotAgeMs := p.minSnapshotsToKeep, p.maxSnapshotAgeMs
+		if ref.MinSnapshotsToKeep != nil {
+			minSnapshotsToKeep = *ref.MinSnapshotsToKeep
+		}
+		if ref.MaxSnapshotAgeMs != nil {
+			maxSnapshotAgeMs = *ref.MaxSnapshotAgeMs
+		}
+
+		for numSnapshots := 1; snap.ParentSnapshotID != nil; numSnapshots++ {
+			// Parent snapshot may have been removed by a previous expiration.
+			// Treat missing parent as end of chain - this is expected behavior.
+			if snap, err = meta.SnapshotByID(*snap.ParentSnapshotID); err != nil {
+				break
+			}
+
+			if nowMs-snap.TimestampMs > maxSnapshotAgeMs && numSnapshots >= minSnapshotsToKeep {
+				break
+			}
+
+			result.retained[snap.SnapshotID] = struct{}{}
+		}
+	}
+
+	return result, nil
+}
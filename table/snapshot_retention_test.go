@@ -0,0 +1,108 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/iceberg-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dayMs = int64(24 * time.Hour / time.Millisecond)
+
+// retentionTestBuilder returns a builder with a main branch of five
+// snapshots committed one day apart, starting at the returned timestamp.
+func retentionTestBuilder(t *testing.T, props iceberg.Properties) (*MetadataBuilder, int64) {
+	t.Helper()
+
+	sc := schema()
+	meta, err := NewMetadata(&sc, iceberg.UnpartitionedSpec, UnsortedSortOrder, "s3://bucket/tbl", props)
+	require.NoError(t, err)
+
+	b, err := MetadataBuilderFromBase(meta, "")
+	require.NoError(t, err)
+
+	start := meta.LastUpdatedMillis() + 1
+	for i := int64(1); i <= 5; i++ {
+		snap := &Snapshot{
+			SnapshotID:     i,
+			SequenceNumber: i,
+			TimestampMs:    start + (i-1)*dayMs,
+			ManifestList:   "/snap.avro",
+			Summary:        &Summary{Operation: OpAppend},
+		}
+		if i > 1 {
+			parent := i - 1
+			snap.ParentSnapshotID = &parent
+		}
+		require.NoError(t, b.AddSnapshot(snap))
+		require.NoError(t, b.SetSnapshotRef(MainBranch, i, BranchRef))
+	}
+
+	return b, start
+}
+
+func TestRetentionPolicyRefs(t *testing.T) {
+	b, start := retentionTestBuilder(t, nil)
+
+	require.NoError(t, b.SetSnapshotRef("release", 2, TagRef))
+	require.NoError(t, b.SetSnapshotRef("stale", 1, TagRef, WithMaxRefAgeMs(5*dayMs)))
+	require.NoError(t, b.SetSnapshotRef("dev", 3, BranchRef, WithMinSnapshotsToKeep(2)))
+
+	retainLast, olderThan := 1, dayMs
+	policy := newRetentionPolicy(b.props, expireSnapshotsCfg{
+		minSnapshotsToKeep: &retainLast,
+		maxSnapshotAgeMs:   &olderThan,
+	})
+
+	result, err := policy.apply(b, start+10*dayMs)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"stale"}, result.expiredRefs)
+	assert.Equal(t, map[int64]struct{}{2: {}, 3: {}, 5: {}}, result.retained)
+}
+
+func TestRetentionPolicyTableProperties(t *testing.T) {
+	b, start := retentionTestBuilder(t, iceberg.Properties{
+		HistoryExpireMaxRefAgeMsKey:        "7776000000",
+		HistoryExpireMinSnapshotsToKeepKey: "2",
+		HistoryExpireMaxSnapshotAgeMsKey:   "86400000",
+	})
+
+	require.NoError(t, b.SetSnapshotRef("release", 1, TagRef))
+	require.NoError(t, b.SetSnapshotRef("nightly", 3, TagRef, WithMaxRefAgeMs(dayMs)))
+
+	policy := newRetentionPolicy(b.props, expireSnapshotsCfg{})
+	assert.Equal(t, retentionPolicy{minSnapshotsToKeep: 2, maxSnapshotAgeMs: dayMs, maxRefAgeMs: 90 * dayMs}, policy)
+
+	result, err := policy.apply(b, start+10*dayMs)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"nightly"}, result.expiredRefs)
+	assert.Equal(t, map[int64]struct{}{1: {}, 4: {}, 5: {}}, result.retained)
+
+	// The main branch never expires, even past the max ref age.
+	result, err = policy.apply(b, start+100*dayMs)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"nightly", "release"}, result.expiredRefs)
+	assert.Equal(t, map[int64]struct{}{4: {}, 5: {}}, result.retained)
+}
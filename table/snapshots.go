@@ -28,6 +28,7 @@ import (
 	"strings"
 
 	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/encryption"
 	"github.com/apache/iceberg-go/internal"
 	iceio "github.com/apache/iceberg-go/io"
 )
@@ -63,6 +64,7 @@ const (
 
 	addedDataFilesKey         = "added-data-files"
 	addedDeleteFilesKey       = "added-delete-files"
+	addedDVsKey               = "added-dvs"
 	addedEqDeletesKey         = "added-equality-deletes"
 	addedFileSizeKey          = "added-files-size"
 	addedPosDeletesKey        = "added-position-deletes"
@@ -72,6 +74,7 @@ const (
 	deletedDataFilesKey       = "deleted-data-files"
 	deletedRecordsKey         = "deleted-records"
 	removedDeleteFilesKey     = "removed-delete-files"
+	removedDVsKey             = "removed-dvs"
 	removedEqDeletesKey       = "removed-equality-deletes"
 	removedEqDeleteFilesKey   = "removed-equality-delete-files"
 	removedFileSizeKey        = "removed-files-size"
@@ -96,6 +99,8 @@ type updateMetrics struct {
 	removedEqDeleteFiles  int64
 	addedPosDeleteFiles   int64
 	removedPosDeleteFiles int64
+	addedDVs              int64
+	removedDVs            int64
 	addedDeleteFiles      int64
 	removedDeleteFiles    int64
 	addedRecords          int64
@@ -106,15 +111,30 @@ type updateMetrics struct {
 	removedEqDeletes      int64
 }
 
+// contentSize returns the size of the content of a file, which for a
+// deletion vector is the size of its blob rather than of the puffin
+// file holding it.
+func contentSize(df iceberg.DataFile) int64 {
+	if df.FileFormat() == iceberg.PuffinFile && df.ContentSizeInBytes() != nil {
+		return *df.ContentSizeInBytes()
+	}
+
+	return df.FileSizeBytes()
+}
+
 func (m *updateMetrics) addDataFile(df iceberg.DataFile) error {
-	m.addedFileSize += df.FileSizeBytes()
+	m.addedFileSize += contentSize(df)
 	switch df.ContentType() {
 	case iceberg.EntryContentData:
 		m.addedDataFiles++
 		m.addedRecords += df.Count()
 	case iceberg.EntryContentPosDeletes:
 		m.addedDeleteFiles++
-		m.addedPosDeleteFiles++
+		if df.FileFormat() == iceberg.PuffinFile {
+			m.addedDVs++
+		} else {
+			m.addedPosDeleteFiles++
+		}
 		m.addedPosDeletes += df.Count()
 	case iceberg.EntryContentEqDeletes:
 		m.addedDeleteFiles++
@@ -128,14 +148,18 @@ func (m *updateMetrics) addDataFile(df iceberg.DataFile) error {
 }
 
 func (m *updateMetrics) removeFile(df iceberg.DataFile) error {
-	m.removedFileSize += df.FileSizeBytes()
+	m.removedFileSize += contentSize(df)
 	switch df.ContentType() {
 	case iceberg.EntryContentData:
 		m.removedDataFiles++
 		m.deletedRecords += df.Count()
 	case iceberg.EntryContentPosDeletes:
 		m.removedDeleteFiles++
-		m.removedPosDeleteFiles++
+		if df.FileFormat() == iceberg.PuffinFile {
+			m.removedDVs++
+		} else {
+			m.removedPosDeleteFiles++
+		}
 		m.removedPosDeletes += df.Count()
 	case iceberg.EntryContentEqDeletes:
 		m.removedDeleteFiles++
@@ -164,6 +188,8 @@ func (m *updateMetrics) toProps() iceberg.Properties {
 	setWhenPositive(props, removedEqDeleteFilesKey, m.removedEqDeleteFiles)
 	setWhenPositive(props, addedPosDeleteFilesKey, m.addedPosDeleteFiles)
 	setWhenPositive(props, removedPosDeleteFilesKey, m.removedPosDeleteFiles)
+	setWhenPositive(props, addedDVsKey, m.addedDVs)
+	setWhenPositive(props, removedDVsKey, m.removedDVs)
 	setWhenPositive(props, addedDeleteFilesKey, m.addedDeleteFiles)
 	setWhenPositive(props, removedDeleteFilesKey, m.removedDeleteFiles)
 	setWhenPositive(props, addedRecordsKey, m.addedRecords)
@@ -257,6 +283,7 @@ type Snapshot struct {
 	SchemaID         *int     `json:"schema-id,omitempty"`
 	FirstRowID       *int64   `json:"first-row-id,omitempty"` // V3: Starting row ID for this snapshot
 	AddedRows        *int64   `json:"added-rows,omitempty"`   // V3: Number of rows added by this snapshot
+	KeyID            *string  `json:"key-id,omitempty"`       // V3: ID of the encryption key of the manifest list
 }
 
 func (s Snapshot) String() string {
@@ -293,6 +320,10 @@ func (s Snapshot) Equals(other Snapshot) bool {
 	case s.AddedRows == nil && other.AddedRows != nil:
 		fallthrough
 	case s.AddedRows != nil && other.AddedRows == nil:
+		fallthrough
+	case s.KeyID == nil && other.KeyID != nil:
+		fallthrough
+	case s.KeyID != nil && other.KeyID == nil:
 		return false
 	}
 
@@ -301,6 +332,7 @@ func (s Snapshot) Equals(other Snapshot) bool {
 		((s.SchemaID == other.SchemaID) || (*s.SchemaID == *other.SchemaID)) &&
 		((s.FirstRowID == other.FirstRowID) || (*s.FirstRowID == *other.FirstRowID)) &&
 		((s.AddedRows == other.AddedRows) || (*s.AddedRows == *other.AddedRows)) &&
+		((s.KeyID == other.KeyID) || (*s.KeyID == *other.KeyID)) &&
 		s.SequenceNumber == other.SequenceNumber &&
 		s.TimestampMs == other.TimestampMs &&
 		s.ManifestList == other.ManifestList &&
@@ -321,22 +353,45 @@ func (s Snapshot) ValidateRowLineage() error {
 	return nil
 }
 
+// Manifests returns the manifest files listed in the manifest list of the
+// snapshot. Encrypted manifest lists can only be read with the IO of their
+// table, as returned by [Table.FS], which holds the table's encryption keys.
 func (s Snapshot) Manifests(fio iceio.IO) (_ []iceberg.ManifestFile, err error) {
-	if s.ManifestList != "" {
-		f, err := fio.Open(s.ManifestList)
-		if err != nil {
-			return nil, fmt.Errorf("could not open manifest file: %w", err)
+	if s.ManifestList == "" {
+		return nil, nil
+	}
+
+	var enc *tableEncryption
+	if s.KeyID != nil {
+		if enc = encryptionOf(fio); enc == nil {
+			return nil, fmt.Errorf("%w: cannot read manifest list %s encrypted with key %s",
+				encryption.ErrMissingManager, s.ManifestList, *s.KeyID)
 		}
-		defer internal.CheckedClose(f, &err)
+	}
 
+	f, err := fio.Open(s.ManifestList)
+	if err != nil {
+		return nil, fmt.Errorf("could not open manifest file: %w", err)
+	}
+	defer internal.CheckedClose(f, &err)
+
+	if enc == nil {
 		return iceberg.ReadManifestList(f)
 	}
 
-	return nil, nil
+	in, err := enc.decryptManifestList(*s.KeyID, f)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt manifest list %s: %w", s.ManifestList, err)
+	}
+
+	return iceberg.ReadManifestList(in)
 }
 
-func (s Snapshot) dataFiles(fio iceio.IO, fileFilter set[iceberg.ManifestEntryContent]) iter.Seq2[iceberg.DataFile, error] {
-	return func(yield func(iceberg.DataFile, error) bool) {
+// Entries returns an iterator over the manifest entries of every manifest
+// in this snapshot, including deleted entries. Manifests are opened lazily,
+// one at a time, as iteration proceeds.
+func (s Snapshot) Entries(fio iceio.IO) iter.Seq2[iceberg.ManifestEntry, error] {
+	return func(yield func(iceberg.ManifestEntry, error) bool) {
 		manifests, err := s.Manifests(fio)
 		if err != nil {
 			yield(nil, err)
@@ -345,23 +400,32 @@ func (s Snapshot) dataFiles(fio iceio.IO, fileFilter set[iceberg.ManifestEntryCo
 		}
 
 		for _, m := range manifests {
-			dataFiles, err := m.FetchEntries(fio, false)
+			for entry, err := range iceberg.ManifestEntries(fio, m, false) {
+				if !yield(entry, err) || err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s Snapshot) dataFiles(fio iceio.IO, fileFilter set[iceberg.ManifestEntryContent]) iter.Seq2[iceberg.DataFile, error] {
+	return func(yield func(iceberg.DataFile, error) bool) {
+		for entry, err := range s.Entries(fio) {
 			if err != nil {
 				yield(nil, err)
 
 				return
 			}
 
-			for _, f := range dataFiles {
-				if fileFilter != nil {
-					if _, ok := fileFilter[f.DataFile().ContentType()]; !ok {
-						continue
-					}
-				}
-				if !yield(f.DataFile(), nil) {
-					return
+			if fileFilter != nil {
+				if _, ok := fileFilter[entry.DataFile().ContentType()]; !ok {
+					continue
 				}
 			}
+			if !yield(entry.DataFile(), nil) {
+				return
+			}
 		}
 	}
 }
@@ -465,7 +529,7 @@ func (s *SnapshotSummaryCollector) build() iceberg.Properties {
 
 func updateSnapshotSummaries(sum Summary, previous iceberg.Properties) (Summary, error) {
 	switch sum.Operation {
-	case OpAppend, OpOverwrite, OpDelete:
+	case OpAppend, OpOverwrite, OpDelete, OpReplace:
 	default:
 		return sum, fmt.Errorf("%w: operation: %s", iceberg.ErrNotImplemented, sum.Operation)
 	}
@@ -152,6 +152,6 @@ func TestMergeSnapshotSummaries(t *testing.T) {
 }
 
 func TestInvalidOperation(t *testing.T) {
-	_, err := updateSnapshotSummaries(Summary{Operation: OpReplace}, nil)
+	_, err := updateSnapshotSummaries(Summary{Operation: Operation("unknown")}, nil)
 	assert.ErrorIs(t, err, iceberg.ErrNotImplemented)
 }
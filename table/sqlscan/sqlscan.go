@@ -0,0 +1,319 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package sqlscan loads the results of an Iceberg table scan into a
+// database/sql database so that they can be queried with SQL in-process,
+// for example with an in-memory DuckDB or SQLite database:
+//
+//	db, _ := sql.Open("duckdb", "")
+//	n, err := sqlscan.Load(ctx, db, "events", tbl.Scan(table.WithRowFilter(filter)))
+//	rows, err := db.QueryContext(ctx, `SELECT kind, count(*) FROM events GROUP BY kind`)
+//
+// Data is streamed batch by batch from the scan, so only a single Arrow
+// record batch is held in memory at a time.
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/extensions"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+)
+
+// DefaultRowsPerInsert is the number of rows bound to a single
+// multi-row INSERT statement.
+const DefaultRowsPerInsert = 256
+
+type config struct {
+	rowsPerInsert int
+	placeholder   func(n int) string
+	replace       bool
+}
+
+// Option configures how a scan is loaded.
+type Option func(*config)
+
+// WithRowsPerInsert sets the number of rows inserted per statement.
+func WithRowsPerInsert(n int) Option {
+	return func(c *config) {
+		if n > 0 {
+			c.rowsPerInsert = n
+		}
+	}
+}
+
+// WithNumberedPlaceholders uses $1, $2, ... placeholders as required by
+// PostgreSQL style drivers instead of the default "?".
+func WithNumberedPlaceholders() Option {
+	return func(c *config) {
+		c.placeholder = func(n int) string { return fmt.Sprintf("$%d", n) }
+	}
+}
+
+// WithReplace drops the target table if it already exists.
+func WithReplace() Option {
+	return func(c *config) { c.replace = true }
+}
+
+// Load creates the table name in db with columns matching the projected
+// schema of scan and inserts every row produced by the scan into it.
+// It returns the number of rows inserted. The inserts are performed in a
+// single transaction so that a failed load leaves no partial table
+// contents behind.
+func Load(ctx context.Context, db *sql.DB, name string, scan *table.Scan, opts ...Option) (n int64, err error) {
+	cfg := config{
+		rowsPerInsert: DefaultRowsPerInsert,
+		placeholder:   func(int) string { return "?" },
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	schema, itr, err := scan.ToArrowRecords(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	ddl, err := CreateTableStatement(name, schema)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback())
+		}
+	}()
+
+	if cfg.replace {
+		if _, err = tx.ExecContext(ctx, "DROP TABLE IF EXISTS "+quoteIdent(name)); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, ddl); err != nil {
+		return 0, err
+	}
+
+	for rec, recErr := range itr {
+		if recErr != nil {
+			return n, recErr
+		}
+
+		inserted, insErr := insertBatch(ctx, tx, name, rec, &cfg)
+		rec.Release()
+		n += inserted
+		if insErr != nil {
+			return n, insErr
+		}
+	}
+
+	return n, tx.Commit()
+}
+
+// CreateTableStatement returns the CREATE TABLE statement used by Load
+// for an Arrow schema.
+func CreateTableStatement(name string, schema *arrow.Schema) (string, error) {
+	var b strings.Builder
+	b.WriteString("CREATE TABLE ")
+	b.WriteString(quoteIdent(name))
+	b.WriteString(" (")
+	for i, f := range schema.Fields() {
+		typ, err := sqlType(f.Type)
+		if err != nil {
+			return "", fmt.Errorf("column %s: %w", f.Name, err)
+		}
+
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(quoteIdent(f.Name))
+		b.WriteByte(' ')
+		b.WriteString(typ)
+		if !f.Nullable {
+			b.WriteString(" NOT NULL")
+		}
+	}
+	b.WriteByte(')')
+
+	return b.String(), nil
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func sqlType(dt arrow.DataType) (string, error) {
+	switch dt := dt.(type) {
+	case *arrow.BooleanType:
+		return "BOOLEAN", nil
+	case *arrow.Int8Type, *arrow.Int16Type, *arrow.Int32Type,
+		*arrow.Uint8Type, *arrow.Uint16Type:
+		return "INTEGER", nil
+	case *arrow.Int64Type, *arrow.Uint32Type:
+		return "BIGINT", nil
+	case *arrow.Float32Type:
+		return "REAL", nil
+	case *arrow.Float64Type:
+		return "DOUBLE PRECISION", nil
+	case *arrow.StringType, *arrow.LargeStringType, *arrow.StringViewType,
+		*extensions.UUIDType:
+		return "VARCHAR", nil
+	case *arrow.BinaryType, *arrow.LargeBinaryType, *arrow.BinaryViewType,
+		*arrow.FixedSizeBinaryType:
+		return "BLOB", nil
+	case *arrow.Date32Type:
+		return "DATE", nil
+	case *arrow.Time64Type:
+		return "TIME", nil
+	case *arrow.TimestampType:
+		if dt.TimeZone != "" {
+			return "TIMESTAMP WITH TIME ZONE", nil
+		}
+
+		return "TIMESTAMP", nil
+	case *arrow.Decimal128Type:
+		return fmt.Sprintf("DECIMAL(%d, %d)", dt.Precision, dt.Scale), nil
+	}
+
+	return "", fmt.Errorf("%w: cannot load arrow type %s into a SQL column",
+		iceberg.ErrNotImplemented, dt)
+}
+
+func insertBatch(ctx context.Context, tx *sql.Tx, name string, rec arrow.RecordBatch, cfg *config) (int64, error) {
+	ncols, nrows := int(rec.NumCols()), int(rec.NumRows())
+	if nrows == 0 {
+		return 0, nil
+	}
+
+	getters := make([]func(int) any, ncols)
+	for i, col := range rec.Columns() {
+		get, err := valueGetter(col)
+		if err != nil {
+			return 0, fmt.Errorf("column %s: %w", rec.ColumnName(i), err)
+		}
+		getters[i] = get
+	}
+
+	prefix := "INSERT INTO " + quoteIdent(name) + " VALUES "
+	var inserted int64
+	for start := 0; start < nrows; start += cfg.rowsPerInsert {
+		end := min(start+cfg.rowsPerInsert, nrows)
+
+		var b strings.Builder
+		b.WriteString(prefix)
+		args := make([]any, 0, (end-start)*ncols)
+		for row := start; row < end; row++ {
+			if row > start {
+				b.WriteString(", ")
+			}
+			b.WriteByte('(')
+			for col := range ncols {
+				if col > 0 {
+					b.WriteString(", ")
+				}
+				args = append(args, getters[col](row))
+				b.WriteString(cfg.placeholder(len(args)))
+			}
+			b.WriteByte(')')
+		}
+
+		if _, err := tx.ExecContext(ctx, b.String(), args...); err != nil {
+			return inserted, err
+		}
+		inserted += int64(end - start)
+	}
+
+	return inserted, nil
+}
+
+// valueGetter returns a function converting the value at a given row
+// of arr to a type accepted by database/sql drivers.
+func valueGetter(arr arrow.Array) (func(int) any, error) {
+	var get func(int) any
+	switch arr := arr.(type) {
+	case *array.Boolean:
+		get = func(i int) any { return arr.Value(i) }
+	case *array.Int8:
+		get = func(i int) any { return int64(arr.Value(i)) }
+	case *array.Int16:
+		get = func(i int) any { return int64(arr.Value(i)) }
+	case *array.Int32:
+		get = func(i int) any { return int64(arr.Value(i)) }
+	case *array.Int64:
+		get = func(i int) any { return arr.Value(i) }
+	case *array.Uint8:
+		get = func(i int) any { return int64(arr.Value(i)) }
+	case *array.Uint16:
+		get = func(i int) any { return int64(arr.Value(i)) }
+	case *array.Uint32:
+		get = func(i int) any { return int64(arr.Value(i)) }
+	case *array.Float32:
+		get = func(i int) any { return float64(arr.Value(i)) }
+	case *array.Float64:
+		get = func(i int) any { return arr.Value(i) }
+	case *array.String:
+		get = func(i int) any { return arr.Value(i) }
+	case *array.LargeString:
+		get = func(i int) any { return arr.Value(i) }
+	case *array.StringView:
+		get = func(i int) any { return arr.Value(i) }
+	case *extensions.UUIDArray:
+		get = func(i int) any { return arr.Value(i).String() }
+	case *array.Binary:
+		get = func(i int) any { return arr.Value(i) }
+	case *array.LargeBinary:
+		get = func(i int) any { return arr.Value(i) }
+	case *array.BinaryView:
+		get = func(i int) any { return arr.Value(i) }
+	case *array.FixedSizeBinary:
+		get = func(i int) any { return arr.Value(i) }
+	case *array.Date32:
+		get = func(i int) any { return arr.Value(i).ToTime() }
+	case *array.Time64:
+		unit := arr.DataType().(*arrow.Time64Type).Unit
+		get = func(i int) any { return arr.Value(i).ToTime(unit).Format("15:04:05.999999") }
+	case *array.Timestamp:
+		unit := arr.DataType().(*arrow.TimestampType).Unit
+		get = func(i int) any { return arr.Value(i).ToTime(unit).UTC() }
+	case *array.Decimal128:
+		scale := arr.DataType().(*arrow.Decimal128Type).Scale
+		get = func(i int) any { return arr.Value(i).ToString(scale) }
+	default:
+		return nil, fmt.Errorf("%w: cannot load arrow type %s into a SQL column",
+			iceberg.ErrNotImplemented, arr.DataType())
+	}
+
+	return func(i int) any {
+		if arr.IsNull(i) {
+			return nil
+		}
+
+		return get(i)
+	}, nil
+}
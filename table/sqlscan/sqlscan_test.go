@@ -0,0 +1,174 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sqlscan_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	"github.com/apache/iceberg-go/table/sqlscan"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun/driver/sqliteshim"
+)
+
+type noopCatalog struct {
+	meta table.Metadata
+}
+
+func (n *noopCatalog) LoadTable(context.Context, table.Identifier) (*table.Table, error) {
+	return nil, nil
+}
+
+func (n *noopCatalog) CommitTable(_ context.Context, _ table.Identifier, _ []table.Requirement, updates []table.Update) (table.Metadata, string, error) {
+	meta, err := table.UpdateTableMetadata(n.meta, updates, "")
+	if err != nil {
+		return nil, "", err
+	}
+	n.meta = meta
+
+	return meta, "", nil
+}
+
+func createTable(t *testing.T) *table.Table {
+	loc := filepath.ToSlash(t.TempDir())
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "kind", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 3, Name: "amount", Type: iceberg.DecimalTypeOf(9, 2)},
+		iceberg.NestedField{ID: 4, Name: "ts", Type: iceberg.PrimitiveTypes.TimestampTz})
+
+	meta, err := table.NewMetadata(sc, iceberg.UnpartitionedSpec, table.UnsortedSortOrder, loc,
+		iceberg.Properties{table.PropertyFormatVersion: "2"})
+	require.NoError(t, err)
+
+	tbl := table.New(table.Identifier{"default", "events"}, meta, loc+"/metadata/v1.metadata.json",
+		func(context.Context) (iceio.IO, error) { return iceio.LocalFS{}, nil },
+		&noopCatalog{meta})
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, false, false)
+	require.NoError(t, err)
+
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{
+		`[
+			{"id": 1, "kind": "click", "amount": "1.50", "ts": "2024-01-01T10:00:00Z"},
+			{"id": 2, "kind": "view", "amount": "0.25", "ts": "2024-01-01T11:00:00Z"},
+			{"id": 3, "kind": "click", "amount": "2.00", "ts": "2024-01-02T10:00:00Z"},
+			{"id": 4, "kind": null, "amount": null, "ts": null}
+		]`,
+	})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	tbl, err = tbl.AppendTable(context.Background(), arrTbl, 2, nil)
+	require.NoError(t, err)
+
+	return tbl
+}
+
+func openDB(t *testing.T) *sql.DB {
+	db, err := sql.Open(sqliteshim.ShimName, ":memory:")
+	require.NoError(t, err)
+	// every connection to :memory: is a separate database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func TestLoad(t *testing.T) {
+	ctx := context.Background()
+	tbl := createTable(t)
+	db := openDB(t)
+
+	n, err := sqlscan.Load(ctx, db, "events", tbl.Scan(), sqlscan.WithRowsPerInsert(3))
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, n)
+
+	rows, err := db.QueryContext(ctx, `SELECT kind, count(*) FROM events WHERE kind IS NOT NULL GROUP BY kind ORDER BY kind`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var (
+			kind  string
+			count int
+		)
+		require.NoError(t, rows.Scan(&kind, &count))
+		counts[kind] = count
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, map[string]int{"click": 2, "view": 1}, counts)
+
+	// sqlite only converts columns declared exactly as TIMESTAMP back
+	// into a time.Time, so parse the stored text here.
+	var amount, ts string
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT amount, ts FROM events WHERE id = 2`).Scan(&amount, &ts))
+	assert.Equal(t, "0.25", amount)
+	parsed, err := time.Parse("2006-01-02 15:04:05.999999999 -0700 MST", ts)
+	require.NoError(t, err)
+	assert.True(t, parsed.Equal(time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)), ts)
+
+	var nulls int
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT count(*) FROM events WHERE amount IS NULL AND ts IS NULL`).Scan(&nulls))
+	assert.Equal(t, 1, nulls)
+
+	// loading again fails unless the table is replaced
+	_, err = sqlscan.Load(ctx, db, "events", tbl.Scan())
+	assert.Error(t, err)
+
+	n, err = sqlscan.Load(ctx, db, "events", tbl.Scan(
+		table.WithRowFilter(iceberg.EqualTo(iceberg.Reference("kind"), "click")),
+		table.WithSelectedFields("id")), sqlscan.WithReplace())
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, n)
+
+	var total int
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT sum(id) FROM events`).Scan(&total))
+	assert.Equal(t, 4, total)
+}
+
+func TestCreateTableStatement(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: `we"ird`, Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "price", Type: &arrow.Decimal128Type{Precision: 10, Scale: 3}, Nullable: true},
+		{Name: "at", Type: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, Nullable: true},
+	}, nil)
+
+	ddl, err := sqlscan.CreateTableStatement("t", schema)
+	require.NoError(t, err)
+	assert.Equal(t, `CREATE TABLE "t" ("id" BIGINT NOT NULL, "we""ird" VARCHAR, `+
+		`"price" DECIMAL(10, 3), "at" TIMESTAMP WITH TIME ZONE)`, ddl)
+
+	_, err = sqlscan.CreateTableStatement("t", arrow.NewSchema([]arrow.Field{
+		{Name: "l", Type: arrow.ListOf(arrow.PrimitiveTypes.Int32)},
+	}, nil))
+	assert.ErrorIs(t, err, iceberg.ErrNotImplemented)
+}
@@ -0,0 +1,136 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/iceberg-go"
+	icebergio "github.com/apache/iceberg-go/io"
+)
+
+const versionHintFile = "version-hint.text"
+
+// StaticTable is a read-only table loaded directly from a metadata file,
+// without any catalog. It can be inspected and scanned like any other
+// table, but every operation that would commit a change is rejected with
+// ErrInvalidOperation before any file is written.
+type StaticTable struct {
+	*Table
+}
+
+// StaticTableFromMetadataFile loads a read-only table from the metadata
+// file at location using the given file IO.
+//
+// The location may also be the root location of a table, in which case
+// the current metadata file is found through the "metadata/version-hint.text"
+// file maintained by file system (Hadoop) catalogs.
+func StaticTableFromMetadataFile(ctx context.Context, location string, fs icebergio.IO) (*StaticTable, error) {
+	if !strings.HasSuffix(location, ".metadata.json") && !isGzippedMetadataJson(location) {
+		var err error
+		if location, err = metadataLocationFromVersionHint(fs, location); err != nil {
+			return nil, err
+		}
+	}
+
+	tbl, err := NewFromLocation(ctx, Identifier{"static-table", location}, location,
+		func(context.Context) (icebergio.IO, error) { return fs, nil }, staticCatalog{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &StaticTable{Table: tbl}, nil
+}
+
+func metadataLocationFromVersionHint(fs icebergio.IO, tableLocation string) (string, error) {
+	metadataDir := strings.TrimRight(tableLocation, "/") + "/metadata/"
+
+	f, err := fs.Open(metadataDir + versionHintFile)
+	if err != nil {
+		return "", fmt.Errorf("could not read version hint of table at %s: %w", tableLocation, err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return "", fmt.Errorf("could not read version hint of table at %s: %w", tableLocation, err)
+	}
+
+	hint := strings.TrimSpace(string(data))
+	switch {
+	case hint == "":
+		return "", fmt.Errorf("%w: empty version hint for table at %s", iceberg.ErrInvalidArgument, tableLocation)
+	case strings.HasSuffix(hint, ".metadata.json"):
+		return metadataDir + hint, nil
+	case strings.Trim(hint, "0123456789") == "":
+		return metadataDir + "v" + hint + ".metadata.json", nil
+	default:
+		return metadataDir + hint + ".metadata.json", nil
+	}
+}
+
+// staticCatalog backs static tables so that nothing can be committed to
+// or reloaded from a catalog.
+type staticCatalog struct{}
+
+func (staticCatalog) LoadTable(context.Context, Identifier) (*Table, error) {
+	return nil, fmt.Errorf("%w: cannot load a static table from a catalog", ErrInvalidOperation)
+}
+
+func (staticCatalog) CommitTable(context.Context, Identifier, []Requirement, []Update) (Metadata, string, error) {
+	return nil, "", fmt.Errorf("%w: cannot commit to a static table", ErrInvalidOperation)
+}
+
+var errStaticTableReadOnly = fmt.Errorf("%w: static tables are read-only", ErrInvalidOperation)
+
+func (s *StaticTable) Refresh(ctx context.Context) error {
+	return fmt.Errorf("%w: cannot refresh a static table", ErrInvalidOperation)
+}
+
+func (s *StaticTable) NewTransaction() *Transaction {
+	panic(errStaticTableReadOnly)
+}
+
+func (s *StaticTable) AppendTable(context.Context, arrow.Table, int64, iceberg.Properties, ...WriteOption) (*Table, error) {
+	return nil, errStaticTableReadOnly
+}
+
+func (s *StaticTable) Append(context.Context, array.RecordReader, iceberg.Properties, ...WriteOption) (*Table, error) {
+	return nil, errStaticTableReadOnly
+}
+
+func (s *StaticTable) OverwriteTable(context.Context, arrow.Table, int64, iceberg.Properties, ...OverwriteOption) (*Table, error) {
+	return nil, errStaticTableReadOnly
+}
+
+func (s *StaticTable) Overwrite(context.Context, array.RecordReader, iceberg.Properties, ...OverwriteOption) (*Table, error) {
+	return nil, errStaticTableReadOnly
+}
+
+func (s *StaticTable) Delete(context.Context, iceberg.BooleanExpression, iceberg.Properties, ...DeleteOption) (*Table, error) {
+	return nil, errStaticTableReadOnly
+}
+
+func (s *StaticTable) DeleteOrphanFiles(context.Context, ...OrphanCleanupOption) (OrphanCleanupResult, error) {
+	return OrphanCleanupResult{}, errStaticTableReadOnly
+}
@@ -0,0 +1,71 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticTable(t *testing.T) {
+	ctx := context.Background()
+	loc := filepath.ToSlash(t.TempDir())
+	metadataFile := loc + "/metadata/v2.metadata.json"
+	require.NoError(t, os.MkdirAll(loc+"/metadata", 0o755))
+	require.NoError(t, os.WriteFile(metadataFile, []byte(table.ExampleTableMetadataV2), 0o644))
+
+	tbl, err := table.StaticTableFromMetadataFile(ctx, metadataFile, iceio.LocalFS{})
+	require.NoError(t, err)
+	assert.Equal(t, metadataFile, tbl.MetadataLocation())
+	assert.Equal(t, table.Identifier{"static-table", metadataFile}, tbl.Identifier())
+	assert.EqualValues(t, 3055729675574597004, tbl.CurrentSnapshot().SnapshotID)
+	assert.Len(t, tbl.Schema().Fields(), 3)
+
+	_, err = tbl.Append(ctx, nil, nil)
+	assert.ErrorIs(t, err, table.ErrInvalidOperation)
+	_, err = tbl.Delete(ctx, iceberg.AlwaysTrue{}, nil)
+	assert.ErrorIs(t, err, table.ErrInvalidOperation)
+	assert.ErrorIs(t, tbl.Refresh(ctx), table.ErrInvalidOperation)
+	assert.Panics(t, func() { tbl.NewTransaction() })
+
+	txn := tbl.Table.NewTransaction()
+	require.NoError(t, txn.SetProperties(iceberg.Properties{"owner": "backup"}))
+	_, err = txn.Commit(ctx)
+	assert.ErrorIs(t, err, table.ErrInvalidOperation)
+
+	t.Run("version hint", func(t *testing.T) {
+		_, err := table.StaticTableFromMetadataFile(ctx, loc, iceio.LocalFS{})
+		assert.ErrorContains(t, err, "could not read version hint")
+
+		for _, hint := range []string{"2\n", "v2.metadata.json", "v2"} {
+			require.NoError(t, os.WriteFile(loc+"/metadata/version-hint.text", []byte(hint), 0o644))
+
+			tbl, err := table.StaticTableFromMetadataFile(ctx, loc+"/", iceio.LocalFS{})
+			require.NoError(t, err, hint)
+			assert.Equal(t, metadataFile, tbl.MetadataLocation())
+		}
+	})
+}
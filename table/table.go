@@ -27,9 +27,11 @@ import (
 	"runtime"
 	"slices"
 	"strings"
+	"sync/atomic"
 
 	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
 	"github.com/apache/iceberg-go"
 	"github.com/apache/iceberg-go/internal"
 	icebergio "github.com/apache/iceberg-go/io"
@@ -46,36 +48,88 @@ type CatalogIO interface {
 	CommitTable(context.Context, Identifier, []Requirement, []Update) (Metadata, string, error)
 }
 
+// Table is a handle to an Iceberg table which is safe for concurrent use.
+//
+// The metadata, metadata location and file system of a table are held in an
+// immutable version which Refresh replaces atomically. Every method reads a
+// single version, and scans and transactions created from a table pin the
+// version current at their creation, so a concurrent Refresh never changes
+// the metadata they operate on. Copies of a Table share their version:
+// refreshing one copy refreshes all of them.
 type Table struct {
-	identifier       Identifier
+	identifier Identifier
+	cat        CatalogIO
+	current    *atomic.Pointer[tableVersion]
+}
+
+// tableVersion is an immutable snapshot of the state of a Table.
+type tableVersion struct {
 	metadata         Metadata
 	metadataLocation string
-	cat              CatalogIO
 	fsF              FSysF
 }
 
+// version returns the current version of t.
+func (t Table) version() *tableVersion {
+	if t.current == nil {
+		return &tableVersion{}
+	}
+
+	return t.current.Load()
+}
+
+// fs returns the IO of the table, which decrypts the manifest lists of
+// encrypted tables.
+func (v *tableVersion) fs(ctx context.Context) (icebergio.IO, error) {
+	fs, err := v.fsF(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return withTableEncryption(ctx, fs, v.metadata.Properties(), v.metadata.EncryptionKeys), nil
+}
+
+// pin returns a handle to the current version of t which is not affected
+// by later refreshes of t.
+func (t Table) pin() Table {
+	return Table{
+		identifier: t.identifier,
+		cat:        t.cat,
+		current:    newTableVersion(t.version()),
+	}
+}
+
+func newTableVersion(v *tableVersion) *atomic.Pointer[tableVersion] {
+	p := new(atomic.Pointer[tableVersion])
+	p.Store(v)
+
+	return p
+}
+
 func (t Table) Equals(other Table) bool {
+	v, o := t.version(), other.version()
+
 	return slices.Equal(t.identifier, other.identifier) &&
-		t.metadataLocation == other.metadataLocation &&
-		t.metadata.Equals(other.metadata)
+		v.metadataLocation == o.metadataLocation &&
+		v.metadata.Equals(o.metadata)
 }
 
 func (t Table) Identifier() Identifier                       { return t.identifier }
-func (t Table) Metadata() Metadata                           { return t.metadata }
-func (t Table) MetadataLocation() string                     { return t.metadataLocation }
-func (t Table) FS(ctx context.Context) (icebergio.IO, error) { return t.fsF(ctx) }
-func (t Table) Schema() *iceberg.Schema                      { return t.metadata.CurrentSchema() }
-func (t Table) Spec() iceberg.PartitionSpec                  { return t.metadata.PartitionSpec() }
-func (t Table) SortOrder() SortOrder                         { return t.metadata.SortOrder() }
-func (t Table) Properties() iceberg.Properties               { return t.metadata.Properties() }
-func (t Table) NameMapping() iceberg.NameMapping             { return t.metadata.NameMapping() }
-func (t Table) Location() string                             { return t.metadata.Location() }
-func (t Table) CurrentSnapshot() *Snapshot                   { return t.metadata.CurrentSnapshot() }
-func (t Table) SnapshotByID(id int64) *Snapshot              { return t.metadata.SnapshotByID(id) }
-func (t Table) SnapshotByName(name string) *Snapshot         { return t.metadata.SnapshotByName(name) }
+func (t Table) Metadata() Metadata                           { return t.version().metadata }
+func (t Table) MetadataLocation() string                     { return t.version().metadataLocation }
+func (t Table) FS(ctx context.Context) (icebergio.IO, error) { return t.version().fs(ctx) }
+func (t Table) Schema() *iceberg.Schema                      { return t.Metadata().CurrentSchema() }
+func (t Table) Spec() iceberg.PartitionSpec                  { return t.Metadata().PartitionSpec() }
+func (t Table) SortOrder() SortOrder                         { return t.Metadata().SortOrder() }
+func (t Table) Properties() iceberg.Properties               { return t.Metadata().Properties() }
+func (t Table) NameMapping() iceberg.NameMapping             { return t.Metadata().NameMapping() }
+func (t Table) Location() string                             { return t.Metadata().Location() }
+func (t Table) CurrentSnapshot() *Snapshot                   { return t.Metadata().CurrentSnapshot() }
+func (t Table) SnapshotByID(id int64) *Snapshot              { return t.Metadata().SnapshotByID(id) }
+func (t Table) SnapshotByName(name string) *Snapshot         { return t.Metadata().SnapshotByName(name) }
 func (t Table) Schemas() map[int]*iceberg.Schema {
 	m := make(map[int]*iceberg.Schema)
-	for _, s := range t.metadata.Schemas() {
+	for _, s := range t.Metadata().Schemas() {
 		m[s.ID] = s
 	}
 
@@ -83,36 +137,100 @@ func (t Table) Schemas() map[int]*iceberg.Schema {
 }
 
 func (t Table) LocationProvider() (LocationProvider, error) {
-	return LoadLocationProvider(t.metadata.Location(), t.metadata.Properties())
+	meta := t.Metadata()
+
+	return LoadLocationProvider(meta.Location(), meta.Properties())
 }
 
-func (t Table) NewTransaction() *Transaction {
-	meta, _ := MetadataBuilderFromBase(t.metadata, t.metadataLocation)
+func (t Table) NewTransaction(opts ...TransactionOption) *Transaction {
+	tbl := t.pin()
+	v := tbl.version()
+	meta, _ := MetadataBuilderFromBase(v.metadata, v.metadataLocation)
 
-	return &Transaction{
-		tbl:  &t,
+	txn := &Transaction{
+		tbl:  &tbl,
 		meta: meta,
 		reqs: []Requirement{},
 	}
+	for _, opt := range opts {
+		opt(txn)
+	}
+
+	return txn
+}
+
+// NewCreateTransaction returns a transaction which creates t in its catalog
+// when committed. t is expected to be a staged table whose metadata has not
+// yet been committed, such as one returned by a catalog staging the creation
+// of a table. Changes made in the transaction, such as appending data, are
+// committed together with the creation of the table.
+func (t Table) NewCreateTransaction(opts ...TransactionOption) (*Transaction, error) {
+	tbl := t.pin()
+	base := tbl.Metadata()
+	meta, err := MetadataBuilderFromBase(base, "")
+	if err != nil {
+		return nil, err
+	}
+
+	txn := &Transaction{
+		tbl:    &tbl,
+		meta:   meta,
+		reqs:   []Requirement{},
+		create: createTableUpdates(base),
+	}
+	for _, opt := range opts {
+		opt(txn)
+	}
+
+	return txn, nil
+}
+
+// createTableUpdates returns the updates which build meta when applied to
+// empty table metadata.
+func createTableUpdates(meta Metadata) []Update {
+	spec := meta.PartitionSpec()
+	order := meta.SortOrder()
+	updates := []Update{
+		NewAssignUUIDUpdate(meta.TableUUID()),
+		NewUpgradeFormatVersionUpdate(meta.Version()),
+		NewAddSchemaUpdate(meta.CurrentSchema()),
+		NewSetCurrentSchemaUpdate(-1),
+		NewAddPartitionSpecUpdate(&spec, true),
+		NewSetDefaultSpecUpdate(-1),
+		NewAddSortOrderUpdate(&order),
+		NewSetDefaultSortOrderUpdate(-1),
+		NewSetLocationUpdate(meta.Location()),
+	}
+
+	if props := meta.Properties(); len(props) > 0 {
+		updates = append(updates, NewSetPropertiesUpdate(props))
+	}
+
+	return updates
 }
 
+// Refresh loads the latest metadata of t from its catalog and atomically
+// replaces the current version of t with it. Scans and transactions created
+// before the refresh keep using the version they were created from.
 func (t *Table) Refresh(ctx context.Context) error {
 	fresh, err := t.cat.LoadTable(ctx, t.identifier)
 	if err != nil {
 		return err
 	}
 
-	t.metadata = fresh.metadata
-	t.fsF = fresh.fsF
-	t.metadataLocation = fresh.metadataLocation
+	if t.current == nil {
+		t.current = newTableVersion(fresh.version())
+	} else {
+		t.current.Store(fresh.version())
+	}
 
 	return nil
 }
 
 // AppendTable is a shortcut for NewTransaction().AppendTable() and then committing the transaction
-func (t Table) AppendTable(ctx context.Context, tbl arrow.Table, batchSize int64, snapshotProps iceberg.Properties) (*Table, error) {
+func (t Table) AppendTable(ctx context.Context, tbl arrow.Table, batchSize int64, snapshotProps iceberg.Properties, opts ...WriteOption) (*Table, error) {
 	txn := t.NewTransaction()
-	if err := txn.AppendTable(ctx, tbl, batchSize, snapshotProps); err != nil {
+	if err := txn.AppendTable(ctx, tbl, batchSize, snapshotProps, opts...); err != nil {
 		return nil, err
 	}
 
@@ -120,9 +238,9 @@ func (t Table) AppendTable(ctx context.Context, tbl arrow.Table, batchSize int64
 }
 
 // Append is a shortcut for NewTransaction().Append() and then committing the transaction
-func (t Table) Append(ctx context.Context, rdr array.RecordReader, snapshotProps iceberg.Properties) (*Table, error) {
+func (t Table) Append(ctx context.Context, rdr array.RecordReader, snapshotProps iceberg.Properties, opts ...WriteOption) (*Table, error) {
 	txn := t.NewTransaction()
-	if err := txn.Append(ctx, rdr, snapshotProps); err != nil {
+	if err := txn.Append(ctx, rdr, snapshotProps, opts...); err != nil {
 		return nil, err
 	}
 
@@ -208,7 +326,8 @@ func (t Table) Delete(ctx context.Context, filter iceberg.BooleanExpression, sna
 }
 
 func (t Table) AllManifests(ctx context.Context) iter.Seq2[iceberg.ManifestFile, error] {
-	fs, err := t.fsF(ctx)
+	v := t.version()
+	fs, err := v.fs(ctx)
 	if err != nil {
 		return func(yield func(iceberg.ManifestFile, error) bool) {
 			yield(nil, err)
@@ -218,10 +337,10 @@ func (t Table) AllManifests(ctx context.Context) iter.Seq2[iceberg.ManifestFile,
 	type list = tblutils.Enumerated[[]iceberg.ManifestFile]
 	g := errgroup.Group{}
 
-	n := len(t.metadata.Snapshots())
+	n := len(v.metadata.Snapshots())
 	ch := make(chan list, n)
 
-	for i, sn := range t.metadata.Snapshots() {
+	for i, sn := range v.metadata.Snapshots() {
 		g.Go(func() error {
 			manifests, err := sn.Manifests(fs)
 			if err != nil {
@@ -300,22 +419,24 @@ func (t Table) doCommit(ctx context.Context, updates []Update, reqs []Requiremen
 	if err != nil {
 		return nil, err
 	}
-	fs, err := t.fsF(ctx)
-	if err != nil {
-		return nil, err
+	// the commit succeeded, so failing to clean up old metadata files is
+	// not an error
+	v := t.version()
+	if fs, err := v.fsF(ctx); err == nil {
+		deleteOldMetadata(fs, v.metadata, newMeta)
 	}
-	deleteOldMetadata(fs, t.metadata, newMeta)
 
-	return New(t.identifier, newMeta, newLoc, t.fsF, t.cat), nil
+	return New(t.identifier, newMeta, newLoc, v.fsF, t.cat), nil
 }
 
 // SnapshotAsOf finds the snapshot that was current as of or right before the given timestamp.
 func (t Table) SnapshotAsOf(timestampMs int64, inclusive bool) *Snapshot {
-	entries := slices.Collect(t.metadata.SnapshotLogs())
+	meta := t.Metadata()
+	entries := slices.Collect(meta.SnapshotLogs())
 	for i := len(entries) - 1; i >= 0; i-- {
 		entry := entries[i]
 		if (inclusive && entry.TimestampMs <= timestampMs) || (!inclusive && entry.TimestampMs < timestampMs) {
-			return t.metadata.SnapshotByID(entry.SnapshotID)
+			return meta.SnapshotByID(entry.SnapshotID)
 		}
 	}
 
@@ -414,6 +535,31 @@ func WithLimit(n int64) ScanOption {
 	}
 }
 
+// WithOffset skips the first n rows returned by the scan. Files that
+// are known to only contain skipped rows are not read. Together with
+// WithLimit this allows paging through the rows of a table.
+func WithOffset(n int64) ScanOption {
+	if n <= 0 {
+		return noopOption
+	}
+
+	return func(scan *Scan) {
+		scan.offset = n
+	}
+}
+
+// WithReproducibleOrder returns the tasks planned by the scan, and the
+// rows it reads, in an order that depends only on the table contents:
+// tasks are ordered by file path and offset, the delete files of each
+// task by path, and rows by file path and then position within the file.
+// This allows comparing the output of a scan across runs or versions, see
+// Scan.Checksum.
+func WithReproducibleOrder() ScanOption {
+	return func(scan *Scan) {
+		scan.reproducibleOrder = true
+	}
+}
+
 // WitMaxConcurrency sets the maximum concurrency for table scan and plan
 // operations. When unset it defaults to runtime.GOMAXPROCS.
 func WitMaxConcurrency(n int) ScanOption {
@@ -426,6 +572,35 @@ func WitMaxConcurrency(n int) ScanOption {
 	}
 }
 
+// WithAllocator sets the allocator used for the buffers of the records
+// read by the scan. When unset, the allocator of the context passed to
+// ToArrowRecords is used. If mem is a MemoryBudget, its limit is applied
+// to the scan.
+func WithAllocator(mem memory.Allocator) ScanOption {
+	if mem == nil {
+		return noopOption
+	}
+
+	return func(scan *Scan) {
+		scan.mem = mem
+	}
+}
+
+// WithMemoryLimit limits the memory the scan keeps allocated for record
+// buffers, including records that have been returned but not yet released,
+// to maxBytes. The policy determines whether exceeding the limit pauses
+// reading until records are released or fails the scan, see MemoryBudget.
+func WithMemoryLimit(maxBytes int64, policy BudgetPolicy) ScanOption {
+	if maxBytes <= 0 {
+		return noopOption
+	}
+
+	return func(scan *Scan) {
+		scan.memLimit = maxBytes
+		scan.memPolicy = policy
+	}
+}
+
 func WithOptions(opts iceberg.Properties) ScanOption {
 	if opts == nil {
 		return noopOption
@@ -436,10 +611,13 @@ func WithOptions(opts iceberg.Properties) ScanOption {
 	}
 }
 
+// Scan returns a scan of the current version of t. The scan is pinned to
+// that version and is not affected by a concurrent Refresh of t.
 func (t Table) Scan(opts ...ScanOption) *Scan {
+	v := t.version()
 	s := &Scan{
-		metadata:       t.metadata,
-		ioF:            t.fsF,
+		metadata:       v.metadata,
+		ioF:            v.fs,
 		rowFilter:      iceberg.AlwaysTrue{},
 		selectedFields: []string{"*"},
 		caseSensitive:  true,
@@ -458,11 +636,13 @@ func (t Table) Scan(opts ...ScanOption) *Scan {
 
 func New(ident Identifier, meta Metadata, metadataLocation string, fsF FSysF, cat CatalogIO) *Table {
 	return &Table{
-		identifier:       ident,
-		metadata:         meta,
-		metadataLocation: metadataLocation,
-		fsF:              fsF,
-		cat:              cat,
+		identifier: ident,
+		cat:        cat,
+		current: newTableVersion(&tableVersion{
+			metadata:         meta,
+			metadataLocation: metadataLocation,
+			fsF:              fsF,
+		}),
 	}
 }
 
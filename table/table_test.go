@@ -27,6 +27,7 @@ import (
 	"io"
 	"io/fs"
 	"log"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -40,6 +41,7 @@ import (
 	"github.com/apache/arrow-go/v18/arrow/array"
 	"github.com/apache/arrow-go/v18/arrow/compute"
 	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
 	"github.com/apache/arrow-go/v18/parquet/pqarrow"
 	"github.com/apache/iceberg-go"
 	"github.com/apache/iceberg-go/catalog"
@@ -49,6 +51,7 @@ import (
 	"github.com/apache/iceberg-go/table"
 	"github.com/google/uuid"
 	"github.com/pterm/pterm"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uptrace/bun/driver/sqliteshim"
@@ -681,6 +684,9 @@ func (t *TableWritingTestSuite) TestAddFilesWithLargeAndRegular() {
 
 	t.EqualValues(2, result.NumRows())
 	t.Truef(arrowSchemaLarge.Equal(result.Schema()), "expected schema: %s, got: %s", arrowSchemaLarge, result.Schema())
+	schema, err := scan.ArrowSchema()
+	t.Require().NoError(err)
+	t.Truef(schema.Equal(result.Schema()), "expected schema: %s, got: %s", result.Schema(), schema)
 }
 
 func (t *TableWritingTestSuite) TestAddFilesValidUpcast() {
@@ -1047,6 +1053,33 @@ func (t *TableWritingTestSuite) TestReplaceDataFilesWithDataFilesValidatesPartit
 	t.ErrorContains(err, "missing partition value")
 }
 
+func (t *TableWritingTestSuite) TestAddDataFilesValidatesPartitionValueTypes() {
+	ident := table.Identifier{"default", "add_data_files_partition_types_v" + strconv.Itoa(t.formatVersion)}
+	spec := iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 4, FieldID: 1000, Transform: iceberg.IdentityTransform{}, Name: "baz"},
+	)
+	tbl := t.createTable(ident, t.formatVersion, spec, t.tableSchema)
+
+	filePath := fmt.Sprintf("%s/add_data_files_partition_types_v%d/test.parquet", t.location, t.formatVersion)
+	t.writeParquet(mustFS(t.T(), tbl).(iceio.WriteFileIO), filePath, t.arrTbl)
+
+	df := mustDataFile(t.T(), spec, filePath, map[int]any{1000: "123"}, 1, mustFileSize(t.T(), filePath))
+
+	tx := tbl.NewTransaction()
+	err := tx.AddDataFiles(t.ctx, []iceberg.DataFile{df}, nil)
+	t.ErrorIs(err, iceberg.ErrInvalidArgument)
+	t.ErrorContains(err, "invalid partition data")
+
+	df = mustDataFile(t.T(), spec, filePath, map[int]any{1000: int64(math.MaxInt64)}, 1, mustFileSize(t.T(), filePath))
+	t.ErrorIs(tx.AddDataFiles(t.ctx, []iceberg.DataFile{df}, nil), iceberg.ErrInvalidArgument)
+
+	df = mustDataFile(t.T(), spec, filePath, map[int]any{1000: int64(123)}, 1, mustFileSize(t.T(), filePath))
+	t.ErrorIs(tx.AddDataFiles(t.ctx, []iceberg.DataFile{df}, nil), iceberg.ErrInvalidArgument)
+
+	df = mustDataFile(t.T(), spec, filePath, map[int]any{1000: 123}, 1, mustFileSize(t.T(), filePath))
+	t.NoError(tx.AddDataFiles(t.ctx, []iceberg.DataFile{df}, nil))
+}
+
 // ============================================================================
 // AddDataFiles Error Path Tests
 // ============================================================================
@@ -1482,6 +1515,107 @@ func (t *TableWritingTestSuite) TestExpireSnapshots() {
 	t.Require().Equal(2, len(slices.Collect(tbl.Metadata().SnapshotLogs())))
 }
 
+func (t *TableWritingTestSuite) TestTransactionIDGenerators() {
+	fs := iceio.LocalFS{}
+
+	files := make([]string, 0)
+	for i := range 2 {
+		filePath := fmt.Sprintf("%s/id_generators_v%d/data-%d.parquet", t.location, t.formatVersion, i)
+		t.writeParquet(fs, filePath, t.arrTablePromotedTypes)
+		files = append(files, filePath)
+	}
+
+	ident := table.Identifier{"default", "id_generators_v" + strconv.Itoa(t.formatVersion)}
+	meta, err := table.NewMetadata(t.tableSchemaPromotedTypes, iceberg.UnpartitionedSpec,
+		table.UnsortedSortOrder, t.location, iceberg.Properties{table.PropertyFormatVersion: strconv.Itoa(t.formatVersion)})
+	t.Require().NoError(err)
+
+	ctx := context.Background()
+
+	tbl := table.New(
+		ident,
+		meta,
+		t.getMetadataLoc(),
+		func(ctx context.Context) (iceio.IO, error) {
+			return fs, nil
+		},
+		&mockedCatalog{meta},
+	)
+
+	nextID := int64(1000)
+	snapshotIDs := func() int64 {
+		nextID++
+
+		return nextID
+	}
+	commitUUIDs := func() uuid.UUID {
+		return uuid.MustParse("00000000-0000-7000-8000-000000000001")
+	}
+
+	for i := range 2 {
+		tx := tbl.NewTransaction(table.WithSnapshotIDGenerator(snapshotIDs), table.WithCommitUUIDGenerator(commitUUIDs))
+		t.Require().NoError(tx.AddFiles(ctx, files[i:i+1], nil, false))
+		tbl, err = tx.Commit(ctx)
+		t.Require().NoError(err)
+	}
+
+	snaps := tbl.Metadata().Snapshots()
+	t.Require().Len(snaps, 2)
+	t.EqualValues(1001, snaps[0].SnapshotID)
+	t.EqualValues(1002, snaps[1].SnapshotID)
+	t.Equal(int64(1001), *snaps[1].ParentSnapshotID)
+	t.Contains(snaps[1].ManifestList, "snap-1002-")
+	t.Contains(snaps[1].ManifestList, "00000000-0000-7000-8000-000000000001")
+}
+
+func (t *TableWritingTestSuite) TestTransactionClock() {
+	fs := iceio.LocalFS{}
+
+	files := make([]string, 0)
+	for i := range 3 {
+		filePath := fmt.Sprintf("%s/clock_v%d/data-%d.parquet", t.location, t.formatVersion, i)
+		t.writeParquet(fs, filePath, t.arrTablePromotedTypes)
+		files = append(files, filePath)
+	}
+
+	ident := table.Identifier{"default", "clock_v" + strconv.Itoa(t.formatVersion)}
+	meta, err := table.NewMetadata(t.tableSchemaPromotedTypes, iceberg.UnpartitionedSpec,
+		table.UnsortedSortOrder, t.location, iceberg.Properties{table.PropertyFormatVersion: strconv.Itoa(t.formatVersion)})
+	t.Require().NoError(err)
+
+	ctx := context.Background()
+
+	tbl := table.New(
+		ident,
+		meta,
+		t.getMetadataLoc(),
+		func(ctx context.Context) (iceio.IO, error) {
+			return fs, nil
+		},
+		&mockedCatalog{meta},
+	)
+
+	frozen := time.UnixMilli(meta.LastUpdatedMillis()).Add(time.Hour)
+	clock := table.ClockFunc(func() time.Time { return frozen })
+
+	for i := range 2 {
+		tx := tbl.NewTransaction(table.WithClock(clock))
+		t.Require().NoError(tx.AddFiles(ctx, files[i:i+1], nil, false))
+		tbl, err = tx.Commit(ctx)
+		t.Require().NoError(err)
+	}
+
+	for _, snap := range tbl.Metadata().Snapshots() {
+		t.Equal(frozen.UnixMilli(), snap.TimestampMs)
+	}
+	t.Equal(frozen.UnixMilli(), tbl.Metadata().LastUpdatedMillis())
+
+	// Backdating before the last update of the table is rejected.
+	backdated := table.ClockFunc(func() time.Time { return frozen.Add(-time.Hour) })
+	tx := tbl.NewTransaction(table.WithClock(backdated))
+	t.ErrorContains(tx.AddFiles(ctx, files[2:3], nil, false), "before last")
+}
+
 // TestExpireSnapshotsNoOpWhenNothingToExpire verifies that when there are no
 // snapshots to expire, no new metadata file is created. This prevents unnecessary
 // metadata file proliferation when the maintenance job runs but finds nothing to do.
@@ -1889,6 +2023,130 @@ func (t *TableWritingTestSuite) TestWriteSpecialCharacterColumn() {
 	t.True(array.TableEqual(arrowTable, result), "expected:\n %s\ngot:\n %s", arrowTable, result)
 }
 
+func (t *TableWritingTestSuite) TestAppendWriteOptions() {
+	sc := iceberg.NewSchema(0, iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64})
+	arrSchema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: true}}, nil)
+
+	recs := make([]arrow.RecordBatch, 10)
+	for i := range recs {
+		bldr := array.NewInt64Builder(memory.DefaultAllocator)
+		for j := range 100 {
+			bldr.Append(int64(i*100 + j))
+		}
+		arr := bldr.NewArray()
+		recs[i] = array.NewRecordBatch(arrSchema, []arrow.Array{arr}, 100)
+		arr.Release()
+		bldr.Release()
+		defer recs[i].Release()
+	}
+	arrTbl := array.NewTableFromRecords(arrSchema, recs)
+	defer arrTbl.Release()
+
+	dataFiles := func(tbl *table.Table) []iceberg.DataFile {
+		tasks, err := tbl.Scan().PlanFiles(t.ctx)
+		t.Require().NoError(err)
+		files := make([]iceberg.DataFile, len(tasks))
+		for i, task := range tasks {
+			files[i] = task.File
+		}
+
+		return files
+	}
+	rowGroups := func(df iceberg.DataFile) int {
+		rdr, err := file.OpenParquetFile(strings.TrimPrefix(df.FilePath(), "file://"), false)
+		t.Require().NoError(err)
+		defer rdr.Close()
+
+		return rdr.NumRowGroups()
+	}
+
+	ident := table.Identifier{"default", "append_write_options_v" + strconv.Itoa(t.formatVersion)}
+	tbl := t.createTable(ident, t.formatVersion, *iceberg.UnpartitionedSpec, sc)
+
+	// every batch exceeds the target size so each is written to its own file
+	tbl, err := tbl.AppendTable(t.ctx, arrTbl, 100, nil, table.WithTargetFileSize(1))
+	t.Require().NoError(err)
+	files := dataFiles(tbl)
+	t.Len(files, 10)
+	for _, df := range files {
+		t.EqualValues(100, df.Count())
+		t.Equal(1, rowGroups(df))
+	}
+
+	tbl = t.createTable(ident, t.formatVersion, *iceberg.UnpartitionedSpec, sc)
+	tbl, err = tbl.AppendTable(t.ctx, arrTbl, 100, nil,
+		table.WithRowGroupSize(1), table.WithPageSize(64), table.WithDictionarySize(64))
+	t.Require().NoError(err)
+	files = dataFiles(tbl)
+	t.Require().Len(files, 1)
+	t.EqualValues(1000, files[0].Count())
+	t.Equal(10, rowGroups(files[0]))
+
+	// the options apply to a single write, not to the table
+	_, ok := tbl.Properties()[table.ParquetRowGroupSizeBytesKey]
+	t.False(ok)
+}
+
+func (t *TableWritingTestSuite) TestAppendUnicodeColumnNames() {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64},
+		iceberg.NestedField{ID: 2, Name: "名前", Type: iceberg.PrimitiveTypes.String},
+		iceberg.NestedField{ID: 3, Name: "😀 emoji", Type: iceberg.PrimitiveTypes.Int32},
+	)
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, false, false)
+	t.Require().NoError(err)
+
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{
+		`[{"id": 1, "名前": "一", "😀 emoji": 10}, {"id": 2, "名前": "二", "😀 emoji": 20}]`,
+	})
+	t.Require().NoError(err)
+	defer arrTbl.Release()
+
+	ident := table.Identifier{"default", "unicode_names_v" + strconv.Itoa(t.formatVersion)}
+	tbl := t.createTable(ident, t.formatVersion, *iceberg.UnpartitionedSpec, sc)
+	tbl, err = tbl.AppendTable(t.ctx, arrTbl, 2, nil)
+	t.Require().NoError(err)
+
+	tasks, err := tbl.Scan().PlanFiles(t.ctx)
+	t.Require().NoError(err)
+	t.Require().Len(tasks, 1)
+
+	// the data file uses Avro compatible names and keeps the originals
+	// in the field metadata
+	pf, err := file.OpenParquetFile(strings.TrimPrefix(tasks[0].File.FilePath(), "file://"), false)
+	t.Require().NoError(err)
+	defer pf.Close()
+	fr, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	t.Require().NoError(err)
+	fileSchema, err := fr.Schema()
+	t.Require().NoError(err)
+
+	t.Equal("id", fileSchema.Field(0).Name)
+	t.Equal("_x540D_x524D", fileSchema.Field(1).Name)
+	t.Equal("_x1F600_x20emoji", fileSchema.Field(2).Name)
+	name, _ := fileSchema.Field(1).Metadata.GetValue(table.ArrowFieldNameKey)
+	t.Equal("名前", name)
+
+	fileIceSchema, err := table.ArrowSchemaToIceberg(fileSchema, false, nil)
+	t.Require().NoError(err)
+	t.True(sc.Equals(fileIceSchema), "expected %s, got %s", sc, fileIceSchema)
+
+	result, err := tbl.Scan().ToArrowTable(t.ctx)
+	t.Require().NoError(err)
+	defer result.Release()
+
+	t.True(array.TableEqual(arrTbl, result), "expected:\n %s\ngot:\n %s", arrTbl, result)
+
+	filtered, err := tbl.Scan(table.WithRowFilter(iceberg.EqualTo(iceberg.Reference("名前"), "二")),
+		table.WithSelectedFields("id", "😀 emoji")).ToArrowTable(t.ctx)
+	t.Require().NoError(err)
+	defer filtered.Release()
+
+	t.EqualValues(1, filtered.NumRows())
+	t.Equal("😀 emoji", filtered.Schema().Field(1).Name)
+	t.Equal(`[20]`, filtered.Column(1).Data().Chunk(0).String())
+}
+
 func (t *TableWritingTestSuite) getInMemCatalog() catalog.Catalog {
 	cat, err := catalog.Load(context.Background(), "default", iceberg.Properties{
 		"uri":          ":memory:",
@@ -2663,3 +2921,95 @@ func (t *TableTestSuite) TestMetadataCompressionRoundTrip() {
 
 	t.True(tbl.Equals(*tbl2))
 }
+
+func TestTransactionTableCommit(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true})
+	meta, err := table.NewMetadata(sc, iceberg.UnpartitionedSpec, table.UnsortedSortOrder, "file:///tmp/facts",
+		iceberg.Properties{table.PropertyFormatVersion: "2"})
+	require.NoError(t, err)
+
+	ident := table.Identifier{"default", "facts"}
+	tbl := table.New(ident, meta, "file:///tmp/facts/metadata/v1.metadata.json",
+		func(context.Context) (iceio.IO, error) { return iceio.LocalFS{}, nil },
+		&mockedCatalog{meta})
+
+	txn := tbl.NewTransaction()
+	require.NoError(t, txn.SetProperties(iceberg.Properties{"owner": "etl"}))
+
+	commit, err := txn.TableCommit()
+	require.NoError(t, err)
+	assert.Equal(t, ident, commit.Identifier)
+	assert.Equal(t, []table.Requirement{table.AssertTableUUID(meta.TableUUID())}, commit.Requirements)
+	require.Len(t, commit.Updates, 1)
+	assert.Equal(t, "set-properties", commit.Updates[0].Action())
+
+	_, err = txn.TableCommit()
+	assert.ErrorContains(t, err, "already been committed")
+	_, err = txn.Commit(context.Background())
+	assert.ErrorContains(t, err, "already been committed")
+}
+
+type loadingCatalog struct {
+	mockedCatalog
+}
+
+func (c *loadingCatalog) LoadTable(_ context.Context, ident table.Identifier) (*table.Table, error) {
+	return table.New(ident, c.metadata, "",
+		func(context.Context) (iceio.IO, error) { return iceio.LocalFS{}, nil }, c), nil
+}
+
+func TestTransactionValidate(t *testing.T) {
+	ctx := context.Background()
+	loc := filepath.ToSlash(t.TempDir())
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true})
+	meta, err := table.NewMetadata(sc, iceberg.UnpartitionedSpec, table.UnsortedSortOrder, loc,
+		iceberg.Properties{table.PropertyFormatVersion: "2"})
+	require.NoError(t, err)
+
+	cat := &loadingCatalog{mockedCatalog{meta}}
+	tbl := table.New(table.Identifier{"default", "validate"}, meta, loc+"/metadata/v1.metadata.json",
+		func(context.Context) (iceio.IO, error) { return iceio.LocalFS{}, nil }, cat)
+
+	arrSchema, err := table.SchemaToArrowSchema(sc, nil, false, false)
+	require.NoError(t, err)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{`[{"id": 1}, {"id": 2}]`})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	txn := tbl.NewTransaction()
+	require.NoError(t, txn.SetProperties(iceberg.Properties{"owner": "etl"}))
+	require.NoError(t, txn.AppendTable(ctx, arrTbl, 10, nil))
+
+	result, err := txn.Validate(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, result.Requirements, table.AssertTableUUID(meta.TableUUID()))
+	actions := make([]string, len(result.Updates))
+	for i, u := range result.Updates {
+		actions[i] = u.Action()
+	}
+	assert.Contains(t, actions, "set-properties")
+	assert.Contains(t, actions, "add-snapshot")
+	require.NotNil(t, result.Metadata.CurrentSnapshot())
+	assert.Equal(t, "2", result.Metadata.CurrentSnapshot().Summary.Properties["added-records"])
+	assert.Equal(t, "etl", result.Metadata.Properties()["owner"])
+
+	// nothing was committed
+	assert.Nil(t, cat.metadata.CurrentSnapshot())
+
+	// a concurrent append makes the transaction conflict
+	_, err = tbl.AppendTable(ctx, arrTbl, 10, nil)
+	require.NoError(t, err)
+	require.NotNil(t, cat.metadata.CurrentSnapshot())
+
+	_, err = txn.Validate(ctx)
+	assert.ErrorIs(t, err, table.ErrCommitConflict)
+	assert.ErrorContains(t, err, "was created concurrently")
+
+	_, err = txn.TableCommit()
+	require.NoError(t, err)
+	_, err = txn.Validate(ctx)
+	assert.ErrorContains(t, err, "already been committed")
+}
@@ -0,0 +1,330 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/apache/iceberg-go"
+	"github.com/google/uuid"
+)
+
+// TaskGroup is a set of scan tasks whose data files share the same
+// values for the grouping partition fields.
+type TaskGroup struct {
+	// Fields are the partition fields that the tasks are grouped by.
+	// They are the same for every group returned by a call to PlanTasks.
+	Fields []iceberg.PartitionField
+	// Key holds the partition values of the group, in the order of Fields.
+	Key   []any
+	Tasks []FileScanTask
+}
+
+type taskGrouping struct {
+	bucketsOnly bool
+	keyType     *iceberg.StructType
+}
+
+// TaskGroupingOption configures how PlanTasks groups scan tasks.
+type TaskGroupingOption func(*taskGrouping)
+
+// WithBucketGrouping groups the tasks only by the bucket partition
+// fields, ignoring any other partition fields. Two tables bucketed the
+// same way can then be joined group by group, matching groups by key.
+func WithBucketGrouping() TaskGroupingOption {
+	return func(g *taskGrouping) {
+		g.bucketsOnly = true
+	}
+}
+
+// WithGroupingKeyType groups the tasks by the partition fields of keyType,
+// as returned by GroupingKeyType, instead of by the fields shared by the
+// specs of the planned files. Grouping scans of an evolved table at
+// different snapshots, or of tables partitioned alike, by the same key type
+// makes their groups line up. The spec of every planned file must have all
+// of the fields of the key type.
+func WithGroupingKeyType(keyType *iceberg.StructType) TaskGroupingOption {
+	return func(g *taskGrouping) {
+		g.keyType = keyType
+	}
+}
+
+// GroupingKeyType returns the type of the partition values that the data
+// of a table partitioned by the given specs can be grouped by: a struct of
+// the partition fields, other than void fields, which all of the specs have
+// in common, ordered by field ID. Fields whose source column is not in
+// schema are left out. The struct has no fields if the specs share none.
+func GroupingKeyType(schema *iceberg.Schema, specs ...iceberg.PartitionSpec) *iceberg.StructType {
+	keyType := &iceberg.StructType{}
+	for _, f := range commonPartitionFields(specs, false) {
+		sourceType, ok := schema.FindTypeByID(f.SourceID)
+		if !ok {
+			continue
+		}
+
+		keyType.FieldList = append(keyType.FieldList, iceberg.NestedField{
+			ID: f.FieldID, Name: f.Name, Type: f.Transform.ResultType(sourceType),
+		})
+	}
+
+	return keyType
+}
+
+// GroupingKeyType returns the grouping key type of every partition spec
+// the table has used, see GroupingKeyType.
+func (t Table) GroupingKeyType() *iceberg.StructType {
+	meta := t.Metadata()
+
+	return GroupingKeyType(meta.CurrentSchema(), meta.PartitionSpecs()...)
+}
+
+// PlanTasks plans the files to read like PlanFiles, and groups the
+// resulting tasks by partition so that each group can be processed
+// independently of the others.
+//
+// Unless WithGroupingKeyType is used, tasks are grouped by the partition
+// fields that every partition spec of the planned files has in common,
+// ordered by partition field ID, so that files written under an evolved
+// spec still group with older files. Void fields, which are always null,
+// are ignored. Groups are ordered by key, with nulls first, and the tasks
+// within a group by file path, so the ordering is stable across calls.
+func (scan *Scan) PlanTasks(ctx context.Context, opts ...TaskGroupingOption) ([]TaskGroup, error) {
+	var grouping taskGrouping
+	for _, opt := range opts {
+		opt(&grouping)
+	}
+
+	tasks, err := scan.PlanFiles(ctx)
+	if err != nil || len(tasks) == 0 {
+		return nil, err
+	}
+
+	fields, err := scan.groupingFields(tasks, grouping)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []TaskGroup
+	for _, task := range tasks {
+		key := make([]any, len(fields))
+		partition := task.File.Partition()
+		for i, f := range fields {
+			key[i] = partition[f.FieldID]
+		}
+
+		idx, found := slices.BinarySearchFunc(groups, key, func(g TaskGroup, key []any) int {
+			return compareGroupKeys(g.Key, key)
+		})
+		if !found {
+			groups = slices.Insert(groups, idx, TaskGroup{Fields: fields, Key: key})
+		}
+		groups[idx].Tasks = append(groups[idx].Tasks, task)
+	}
+
+	return groups, nil
+}
+
+// groupingFields returns the partition fields, ordered by field ID, that
+// the tasks are grouped by: those of the grouping key type if one is set,
+// and otherwise those shared by the partition specs of all of the tasks'
+// files.
+func (scan *Scan) groupingFields(tasks []FileScanTask, grouping taskGrouping) ([]iceberg.PartitionField, error) {
+	var specs []iceberg.PartitionSpec
+	for _, task := range tasks {
+		specID := task.File.SpecID()
+		if slices.ContainsFunc(specs, func(s iceberg.PartitionSpec) bool { return int32(s.ID()) == specID }) {
+			continue
+		}
+
+		spec := scan.metadata.PartitionSpecByID(int(specID))
+		if spec == nil {
+			return nil, fmt.Errorf("%w: id %d", ErrPartitionSpecNotFound, specID)
+		}
+		specs = append(specs, *spec)
+	}
+
+	if grouping.keyType == nil {
+		return commonPartitionFields(specs, grouping.bucketsOnly), nil
+	}
+
+	fields := make([]iceberg.PartitionField, 0, len(grouping.keyType.FieldList))
+	for _, key := range grouping.keyType.FieldList {
+		var field *iceberg.PartitionField
+		for _, spec := range specs {
+			var found bool
+			for f := range spec.Fields() {
+				if f.FieldID == key.ID {
+					found = true
+					if field == nil {
+						field = &f
+					}
+
+					break
+				}
+			}
+
+			if !found {
+				return nil, fmt.Errorf("%w: partition spec %d has no partition field %d of the grouping key",
+					iceberg.ErrInvalidArgument, spec.ID(), key.ID)
+			}
+		}
+
+		if _, ok := field.Transform.(iceberg.BucketTransform); ok || !grouping.bucketsOnly {
+			fields = append(fields, *field)
+		}
+	}
+
+	slices.SortFunc(fields, func(a, b iceberg.PartitionField) int {
+		return cmp.Compare(a.FieldID, b.FieldID)
+	})
+
+	return fields, nil
+}
+
+// commonPartitionFields returns the partition fields, ordered by field ID,
+// that all of the specs have in common with the same transform. Void
+// fields, and unless bucketsOnly is false fields which aren't bucketed,
+// are left out.
+func commonPartitionFields(specs []iceberg.PartitionSpec, bucketsOnly bool) []iceberg.PartitionField {
+	var fields []iceberg.PartitionField
+	for i, spec := range specs {
+		var specFields []iceberg.PartitionField
+		for f := range spec.Fields() {
+			switch f.Transform.(type) {
+			case iceberg.VoidTransform:
+				continue
+			case iceberg.BucketTransform:
+			default:
+				if bucketsOnly {
+					continue
+				}
+			}
+			specFields = append(specFields, f)
+		}
+
+		if i == 0 {
+			fields = specFields
+		} else {
+			fields = slices.DeleteFunc(fields, func(f iceberg.PartitionField) bool {
+				return !slices.ContainsFunc(specFields, func(other iceberg.PartitionField) bool {
+					return f.FieldID == other.FieldID && f.Transform.Equals(other.Transform)
+				})
+			})
+		}
+	}
+
+	slices.SortFunc(fields, func(a, b iceberg.PartitionField) int {
+		return cmp.Compare(a.FieldID, b.FieldID)
+	})
+
+	return fields
+}
+
+func compareGroupKeys(a, b []any) int {
+	for i := range a {
+		if c := compareGroupValues(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+// compareGroupValues orders partition values, sorting nulls first.
+func compareGroupValues(a, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	switch a := a.(type) {
+	case bool:
+		if b, ok := b.(bool); ok {
+			switch {
+			case a == b:
+				return 0
+			case !a:
+				return -1
+			default:
+				return 1
+			}
+		}
+	case int:
+		if b, ok := b.(int); ok {
+			return cmp.Compare(a, b)
+		}
+	case int32:
+		if b, ok := b.(int32); ok {
+			return cmp.Compare(a, b)
+		}
+	case int64:
+		if b, ok := b.(int64); ok {
+			return cmp.Compare(a, b)
+		}
+	case float32:
+		if b, ok := b.(float32); ok {
+			return cmp.Compare(a, b)
+		}
+	case float64:
+		if b, ok := b.(float64); ok {
+			return cmp.Compare(a, b)
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return cmp.Compare(a, b)
+		}
+	case iceberg.Date:
+		if b, ok := b.(iceberg.Date); ok {
+			return cmp.Compare(a, b)
+		}
+	case iceberg.Time:
+		if b, ok := b.(iceberg.Time); ok {
+			return cmp.Compare(a, b)
+		}
+	case iceberg.Timestamp:
+		if b, ok := b.(iceberg.Timestamp); ok {
+			return cmp.Compare(a, b)
+		}
+	case iceberg.TimestampNano:
+		if b, ok := b.(iceberg.TimestampNano); ok {
+			return cmp.Compare(a, b)
+		}
+	case []byte:
+		if b, ok := b.([]byte); ok {
+			return bytes.Compare(a, b)
+		}
+	case uuid.UUID:
+		if b, ok := b.(uuid.UUID); ok {
+			return bytes.Compare(a[:], b[:])
+		}
+	case iceberg.Decimal:
+		if b, ok := b.(iceberg.Decimal); ok {
+			return iceberg.DecimalLiteral(a).Comparator()(a, b)
+		}
+	}
+
+	return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
@@ -0,0 +1,198 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanPlanTasks(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "category", Type: iceberg.PrimitiveTypes.String, Required: true})
+
+	spec := iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 1, FieldID: 1000, Name: "id_bucket", Transform: iceberg.BucketTransform{NumBuckets: 2}},
+		iceberg.PartitionField{SourceID: 2, FieldID: 1001, Name: "category", Transform: iceberg.IdentityTransform{}})
+
+	tbl := newTestTable(t, withTestSchema(sc), withTestSpec(&spec))
+
+	groups, err := tbl.Scan().PlanTasks(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+
+	arrSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "category", Type: arrow.BinaryTypes.String},
+	}, nil)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{
+		`[{"id": 1, "category": "b"}, {"id": 2, "category": "a"}, {"id": 3, "category": "b"},
+		  {"id": 4, "category": "a"}, {"id": 5, "category": "b"}, {"id": 6, "category": "a"}]`,
+	})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	tbl, err = tbl.AppendTable(ctx, arrTbl, 6, nil)
+	require.NoError(t, err)
+
+	tasks, err := tbl.Scan().PlanFiles(ctx)
+	require.NoError(t, err)
+
+	groups, err = tbl.Scan().PlanTasks(ctx)
+	require.NoError(t, err)
+	require.Len(t, groups, len(tasks))
+
+	var prev []any
+	for _, g := range groups {
+		require.Len(t, g.Fields, 2)
+		assert.Equal(t, 1000, g.Fields[0].FieldID)
+		assert.Equal(t, 1001, g.Fields[1].FieldID)
+
+		require.Len(t, g.Key, 2)
+		require.Len(t, g.Tasks, 1)
+		assert.Equal(t, g.Key[0], g.Tasks[0].File.Partition()[1000])
+		assert.Equal(t, g.Key[1], g.Tasks[0].File.Partition()[1001])
+
+		if prev != nil {
+			assert.True(t, fmt.Sprint(prev[0]) < fmt.Sprint(g.Key[0]) ||
+				(prev[0] == g.Key[0] && prev[1].(string) < g.Key[1].(string)),
+				"groups out of order: %v before %v", prev, g.Key)
+		}
+		prev = g.Key
+	}
+
+	bucketed, err := tbl.Scan().PlanTasks(ctx, table.WithBucketGrouping())
+	require.NoError(t, err)
+	require.Len(t, bucketed, 2)
+
+	var total int
+	for i, g := range bucketed {
+		require.Len(t, g.Fields, 1)
+		assert.Equal(t, "id_bucket", g.Fields[0].Name)
+		assert.EqualValues(t, i, g.Key[0], "bucket keys should be ordered")
+		for _, task := range g.Tasks {
+			assert.Equal(t, g.Key[0], task.File.Partition()[1000])
+		}
+		assert.IsNonDecreasing(t, taskPaths(g.Tasks))
+		total += len(g.Tasks)
+	}
+	assert.Equal(t, len(tasks), total)
+
+	again, err := tbl.Scan().PlanTasks(ctx, table.WithBucketGrouping())
+	require.NoError(t, err)
+	for i := range bucketed {
+		assert.Equal(t, taskPaths(bucketed[i].Tasks), taskPaths(again[i].Tasks))
+	}
+}
+
+func taskPaths(tasks []table.FileScanTask) []string {
+	paths := make([]string, len(tasks))
+	for i, t := range tasks {
+		paths[i] = t.File.FilePath()
+	}
+
+	return paths
+}
+
+func TestGroupingKeyType(t *testing.T) {
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "category", Type: iceberg.PrimitiveTypes.String, Required: true},
+		iceberg.NestedField{ID: 3, Name: "ts", Type: iceberg.PrimitiveTypes.Timestamp, Required: true})
+
+	original := iceberg.NewPartitionSpecID(0,
+		iceberg.PartitionField{SourceID: 1, FieldID: 1000, Name: "id_bucket", Transform: iceberg.BucketTransform{NumBuckets: 4}},
+		iceberg.PartitionField{SourceID: 3, FieldID: 1001, Name: "ts_day", Transform: iceberg.DayTransform{}})
+	// the bucket field was dropped, and replaced by a void field in v1
+	// tables, and a category field added
+	evolved := iceberg.NewPartitionSpecID(1,
+		iceberg.PartitionField{SourceID: 1, FieldID: 1000, Name: "id_bucket", Transform: iceberg.VoidTransform{}},
+		iceberg.PartitionField{SourceID: 3, FieldID: 1001, Name: "ts_day", Transform: iceberg.DayTransform{}},
+		iceberg.PartitionField{SourceID: 2, FieldID: 1002, Name: "category", Transform: iceberg.IdentityTransform{}})
+
+	assert.Equal(t, []iceberg.NestedField{
+		{ID: 1000, Name: "id_bucket", Type: iceberg.PrimitiveTypes.Int32},
+		{ID: 1001, Name: "ts_day", Type: iceberg.PrimitiveTypes.Int32},
+	}, table.GroupingKeyType(sc, original).FieldList)
+
+	assert.Equal(t, []iceberg.NestedField{
+		{ID: 1001, Name: "ts_day", Type: iceberg.PrimitiveTypes.Int32},
+	}, table.GroupingKeyType(sc, original, evolved).FieldList)
+
+	assert.Empty(t, table.GroupingKeyType(sc, original, *iceberg.UnpartitionedSpec).FieldList)
+}
+
+func TestScanPlanTasksWithGroupingKeyType(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "category", Type: iceberg.PrimitiveTypes.String, Required: true})
+	spec := iceberg.NewPartitionSpec(
+		iceberg.PartitionField{SourceID: 1, FieldID: 1000, Name: "id_bucket", Transform: iceberg.BucketTransform{NumBuckets: 2}},
+		iceberg.PartitionField{SourceID: 2, FieldID: 1001, Name: "category", Transform: iceberg.IdentityTransform{}})
+	tbl := newTestTable(t, withTestSchema(sc), withTestSpec(&spec))
+
+	arrSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "category", Type: arrow.BinaryTypes.String},
+	}, nil)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{
+		`[{"id": 1, "category": "b"}, {"id": 2, "category": "a"}, {"id": 3, "category": "b"},
+		  {"id": 4, "category": "a"}, {"id": 5, "category": "b"}, {"id": 6, "category": "a"}]`,
+	})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	tbl, err = tbl.AppendTable(ctx, arrTbl, 6, nil)
+	require.NoError(t, err)
+
+	keyType := &iceberg.StructType{FieldList: []iceberg.NestedField{
+		{ID: 1001, Name: "category", Type: iceberg.PrimitiveTypes.String},
+	}}
+	groups, err := tbl.Scan().PlanTasks(ctx, table.WithGroupingKeyType(keyType))
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	for i, category := range []string{"a", "b"} {
+		require.Len(t, groups[i].Fields, 1)
+		assert.Equal(t, "category", groups[i].Fields[0].Name)
+		assert.Equal(t, []any{category}, groups[i].Key)
+	}
+
+	assert.Len(t, tbl.GroupingKeyType().FieldList, 2)
+	groups, err = tbl.Scan().PlanTasks(ctx, table.WithGroupingKeyType(tbl.GroupingKeyType()))
+	require.NoError(t, err)
+	assert.Len(t, groups[0].Fields, 2)
+
+	keyType.FieldList = append(keyType.FieldList,
+		iceberg.NestedField{ID: 1005, Name: "missing", Type: iceberg.PrimitiveTypes.Int32})
+	_, err = tbl.Scan().PlanTasks(ctx, table.WithGroupingKeyType(keyType))
+	assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+}
@@ -0,0 +1,113 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"maps"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/require"
+)
+
+type testTableConfig struct {
+	schema        *iceberg.Schema
+	spec          *iceberg.PartitionSpec
+	sortOrder     table.SortOrder
+	formatVersion int
+	props         iceberg.Properties
+	cat           table.CatalogIO
+	fsys          iceio.IO
+}
+
+// testTableOption configures the table created by newTestTable.
+type testTableOption func(*testTableConfig)
+
+func withTestSchema(sc *iceberg.Schema) testTableOption {
+	return func(c *testTableConfig) { c.schema = sc }
+}
+
+func withTestSpec(spec *iceberg.PartitionSpec) testTableOption {
+	return func(c *testTableConfig) { c.spec = spec }
+}
+
+func withTestSortOrder(order table.SortOrder) testTableOption {
+	return func(c *testTableConfig) { c.sortOrder = order }
+}
+
+func withTestFormatVersion(version int) testTableOption {
+	return func(c *testTableConfig) { c.formatVersion = version }
+}
+
+// withTestProperties sets the table properties in addition to the format
+// version.
+func withTestProperties(props iceberg.Properties) testTableOption {
+	return func(c *testTableConfig) { c.props = props }
+}
+
+// withTestCatalog commits the changes to the table to cat instead of a
+// mockedCatalog.
+func withTestCatalog(cat table.CatalogIO) testTableOption {
+	return func(c *testTableConfig) { c.cat = cat }
+}
+
+func withTestIO(fsys iceio.IO) testTableOption {
+	return func(c *testTableConfig) { c.fsys = fsys }
+}
+
+// newTestTable creates an empty table in a temporary directory. By default
+// it is a format version 2 table with a single required long column, id,
+// unpartitioned and unsorted, which commits to a mockedCatalog and reads
+// and writes files with the local file system.
+func newTestTable(t *testing.T, opts ...testTableOption) *table.Table {
+	t.Helper()
+
+	cfg := testTableConfig{
+		schema: iceberg.NewSchema(0,
+			iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true}),
+		spec:          iceberg.UnpartitionedSpec,
+		sortOrder:     table.UnsortedSortOrder,
+		formatVersion: 2,
+		fsys:          iceio.LocalFS{},
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	props := maps.Clone(cfg.props)
+	if props == nil {
+		props = iceberg.Properties{}
+	}
+	props[table.PropertyFormatVersion] = strconv.Itoa(cfg.formatVersion)
+
+	loc := filepath.ToSlash(t.TempDir())
+	meta, err := table.NewMetadata(cfg.schema, cfg.spec, cfg.sortOrder, loc, props)
+	require.NoError(t, err)
+
+	if cfg.cat == nil {
+		cfg.cat = &mockedCatalog{meta}
+	}
+
+	return table.New(table.Identifier{"default", "test"}, meta, loc+"/metadata/v1.metadata.json",
+		func(context.Context) (iceio.IO, error) { return cfg.fsys, nil }, cfg.cat)
+}
@@ -68,10 +68,7 @@ func TestSnapshotAsOf(t *testing.T) {
 	meta, err := createTestMetadata(snapshots, snapshotLog)
 	require.NoError(t, err)
 
-	table := Table{
-		identifier: []string{"db", "table"},
-		metadata:   meta,
-	}
+	table := *New([]string{"db", "table"}, meta, "", nil, nil)
 
 	t.Run("SnapshotAsOf finds exact timestamp match (inclusive)", func(t *testing.T) {
 		timestamp := baseTime.Add(2 * time.Hour).UnixMilli()
@@ -153,10 +150,7 @@ func TestTable_WithSnapshotAsOf(t *testing.T) {
 	meta, err := createTestMetadata(snapshots, snapshotLog)
 	require.NoError(t, err)
 
-	table := Table{
-		identifier: []string{"db", "table"},
-		metadata:   meta,
-	}
+	table := *New([]string{"db", "table"}, meta, "", nil, nil)
 
 	t.Run("WithSnapshotAsOf creates scan with correct snapshot ID", func(t *testing.T) {
 		timestamp := baseTime.Add(90 * time.Minute).UnixMilli() // Between snapshots
@@ -210,10 +204,7 @@ func TestSnapshotAsOfEdgeCases(t *testing.T) {
 		meta, err := createTestMetadata(nil, nil)
 		require.NoError(t, err)
 
-		table := Table{
-			identifier: []string{"db", "table"},
-			metadata:   meta,
-		}
+		table := *New([]string{"db", "table"}, meta, "", nil, nil)
 
 		snapshot := table.SnapshotAsOf(time.Now().UnixMilli(), true)
 		assert.Nil(t, snapshot)
@@ -244,10 +235,7 @@ func TestSnapshotAsOfEdgeCases(t *testing.T) {
 		meta, err := createTestMetadata(snapshots, snapshotLog)
 		require.NoError(t, err)
 
-		table := Table{
-			identifier: []string{"db", "table"},
-			metadata:   meta,
-		}
+		table := *New([]string{"db", "table"}, meta, "", nil, nil)
 
 		// Before snapshot
 		snapshot := table.SnapshotAsOf(now.Add(-1*time.Hour).UnixMilli(), true)
@@ -18,11 +18,12 @@
 package table
 
 import (
-	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
+	"maps"
 	"runtime"
 	"slices"
 	"sync"
@@ -31,6 +32,7 @@ import (
 	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/arrow-go/v18/arrow/array"
 	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/config"
 	"github.com/apache/iceberg-go/io"
 	"github.com/google/uuid"
 	"golang.org/x/sync/errgroup"
@@ -65,9 +67,70 @@ type Transaction struct {
 	meta *MetadataBuilder
 
 	reqs []Requirement
+	// create holds the updates creating the table for transactions
+	// returned by Table.NewCreateTransaction, and is nil otherwise.
+	create []Update
 
 	mx        sync.Mutex
 	committed bool
+	// written holds the files written by the transaction, deleted if it
+	// fails or is aborted.
+	written writtenFiles
+
+	snapshotIDs SnapshotIDGenerator
+	commitUUIDs CommitUUIDGenerator
+}
+
+// SnapshotIDGenerator returns the ID of each new snapshot produced by a
+// transaction. It must not return the ID of a snapshot already in the table.
+type SnapshotIDGenerator func() int64
+
+// CommitUUIDGenerator returns the UUID of each commit of a transaction,
+// which is used in the names of the manifests and data files it writes.
+type CommitUUIDGenerator func() uuid.UUID
+
+type TransactionOption func(*Transaction)
+
+// WithSnapshotIDGenerator generates the IDs of new snapshots with gen
+// instead of randomly, for reproducible tests or for environments that
+// require k-sortable IDs.
+func WithSnapshotIDGenerator(gen SnapshotIDGenerator) TransactionOption {
+	return func(t *Transaction) {
+		t.snapshotIDs = gen
+	}
+}
+
+// WithClock uses c instead of the system time for the timestamps of the
+// snapshots and metadata updates of the transaction, and as the current
+// time when expiring snapshots.
+func WithClock(c Clock) TransactionOption {
+	return func(t *Transaction) {
+		t.meta.SetClock(c)
+	}
+}
+
+// WithCommitUUIDGenerator generates the UUIDs of commits with gen instead
+// of randomly.
+func WithCommitUUIDGenerator(gen CommitUUIDGenerator) TransactionOption {
+	return func(t *Transaction) {
+		t.commitUUIDs = gen
+	}
+}
+
+func (t *Transaction) newSnapshotID() int64 {
+	if t.snapshotIDs != nil {
+		return t.snapshotIDs()
+	}
+
+	return t.meta.newSnapshotID()
+}
+
+func (t *Transaction) newCommitUUID() uuid.UUID {
+	if t.commitUUIDs != nil {
+		return t.commitUUIDs()
+	}
+
+	return uuid.New()
 }
 
 func (t *Transaction) apply(updates []Update, reqs []Requirement) error {
@@ -112,7 +175,7 @@ func (t *Transaction) apply(updates []Update, reqs []Requirement) error {
 	// changes added and thus need to update the lastupdated value.
 	if prevUpdates < len(t.meta.updates) {
 		if prevLastUpdated == t.meta.lastUpdatedMS {
-			t.meta.lastUpdatedMS = time.Now().UnixMilli()
+			t.meta.lastUpdatedMS = t.meta.nowMs()
 		}
 	}
 
@@ -198,95 +261,46 @@ func WithPostCommit(postCommit bool) ExpireSnapshotsOpt {
 	}
 }
 
+// ExpireSnapshots removes the snapshots and refs that are no longer
+// retained by the table's retention policy. Branches and tags may set
+// their own max-ref-age-ms, and branches their own min-snapshots-to-keep
+// and max-snapshot-age-ms. Unset values fall back to WithRetainLast and
+// WithOlderThan, then to the history.expire.* table properties. Refs
+// other than main expire once their snapshot is older than their
+// max-ref-age-ms, so a tag without one survives regardless of the options.
 func (t *Transaction) ExpireSnapshots(opts ...ExpireSnapshotsOpt) error {
 	var (
-		cfg         = expireSnapshotsCfg{postCommit: true}
-		updates     []Update
-		reqs        []Requirement
-		snapsToKeep = make(map[int64]struct{})
-		nowMs       = time.Now().UnixMilli()
+		cfg     = expireSnapshotsCfg{postCommit: true}
+		updates []Update
+		reqs    []Requirement
+		nowMs   = t.meta.nowMs()
 	)
 
 	for _, opt := range opts {
 		opt(&cfg)
 	}
 
-	for refName, ref := range t.meta.refs {
+	retention, err := newRetentionPolicy(t.meta.props, cfg).apply(t.meta, nowMs)
+	if err != nil {
+		return err
+	}
+
+	for _, refName := range slices.Sorted(maps.Keys(t.meta.refs)) {
 		// Assert that this ref's snapshot ID hasn't changed concurrently.
 		// This ensures we don't accidentally expire snapshots that are now
 		// referenced by updated refs.
-		snapshotID := ref.SnapshotID
+		snapshotID := t.meta.refs[refName].SnapshotID
 		reqs = append(reqs, AssertRefSnapshotID(refName, &snapshotID))
+	}
 
-		if refName == MainBranch {
-			snapsToKeep[ref.SnapshotID] = struct{}{}
-		}
-
-		snap, err := t.meta.SnapshotByID(ref.SnapshotID)
-		if err != nil {
-			return err
-		}
-
-		maxRefAgeMs := cmp.Or(ref.MaxRefAgeMs, cfg.maxSnapshotAgeMs)
-		if maxRefAgeMs == nil {
-			return errors.New("cannot find a valid value for maxRefAgeMs")
-		}
-
-		refAge := nowMs - snap.TimestampMs
-		if refAge > *maxRefAgeMs && refName != MainBranch {
-			updates = append(updates, NewRemoveSnapshotRefUpdate(refName))
-
-			continue
-		}
-
-		var (
-			minSnapshotsToKeep = cmp.Or(ref.MinSnapshotsToKeep, cfg.minSnapshotsToKeep)
-			maxSnapshotAgeMs   = cmp.Or(ref.MaxSnapshotAgeMs, cfg.maxSnapshotAgeMs)
-		)
-
-		if minSnapshotsToKeep == nil || maxSnapshotAgeMs == nil {
-			return errors.New("cannot find a valid value for minSnapshotsToKeep and maxSnapshotAgeMs")
-		}
-
-		if ref.SnapshotRefType != BranchRef {
-			snapsToKeep[ref.SnapshotID] = struct{}{}
-
-			continue
-		}
-
-		var (
-			numSnapshots int
-			snapId       = ref.SnapshotID
-		)
-
-		for {
-			snap, err := t.meta.SnapshotByID(snapId)
-			if err != nil {
-				// Parent snapshot may have been removed by a previous expiration.
-				// Treat missing parent as end of chain - this is expected behavior.
-				break
-			}
-
-			snapAge := time.Now().UnixMilli() - snap.TimestampMs
-			if (snapAge > *maxSnapshotAgeMs) && (numSnapshots >= *minSnapshotsToKeep) {
-				break
-			}
-
-			snapsToKeep[snap.SnapshotID] = struct{}{}
-
-			if snap.ParentSnapshotID == nil {
-				break
-			}
-
-			snapId = *snap.ParentSnapshotID
-			numSnapshots++
-		}
+	for _, refName := range retention.expiredRefs {
+		updates = append(updates, NewRemoveSnapshotRefUpdate(refName))
 	}
 
 	var snapsToDelete []int64
 
 	for _, snap := range t.meta.snapshotList {
-		if _, found := snapsToKeep[snap.SnapshotID]; !found {
+		if _, found := retention.retained[snap.SnapshotID]; !found {
 			snapsToDelete = append(snapsToDelete, snap.SnapshotID)
 		}
 	}
@@ -301,15 +315,21 @@ func (t *Transaction) ExpireSnapshots(opts ...ExpireSnapshotsOpt) error {
 	return t.apply(updates, reqs)
 }
 
-func (t *Transaction) AppendTable(ctx context.Context, tbl arrow.Table, batchSize int64, snapshotProps iceberg.Properties) error {
+func (t *Transaction) AppendTable(ctx context.Context, tbl arrow.Table, batchSize int64, snapshotProps iceberg.Properties, opts ...WriteOption) error {
 	rdr := array.NewTableReader(tbl, batchSize)
 	defer rdr.Release()
 
-	return t.Append(ctx, rdr, snapshotProps)
+	return t.Append(ctx, rdr, snapshotProps, opts...)
 }
 
-func (t *Transaction) Append(ctx context.Context, rdr array.RecordReader, snapshotProps iceberg.Properties) error {
-	fs, err := t.tbl.fsF(ctx)
+// Append writes the records of the reader to new data files and adds
+// them to the table. The layout of the files follows the table
+// properties unless overridden by the given options.
+func (t *Transaction) Append(ctx context.Context, rdr array.RecordReader, snapshotProps iceberg.Properties, opts ...WriteOption) (err error) {
+	defer t.cleanupOnError(ctx, t.written.mark(), &err)
+	ctx = withWrittenFiles(ctx, &t.written)
+
+	fs, err := t.fs(ctx)
 	if err != nil {
 		return err
 	}
@@ -319,6 +339,7 @@ func (t *Transaction) Append(ctx context.Context, rdr array.RecordReader, snapsh
 		itr:       array.IterFromReader(rdr),
 		fs:        fs.(io.WriteFileIO),
 		writeUUID: &appendFiles.commitUuid,
+		props:     writeProperties(t.meta.props, opts),
 	})
 
 	for df, err := range itr {
@@ -344,7 +365,9 @@ func (t *Transaction) Append(ctx context.Context, rdr array.RecordReader, snapsh
 // operation is only valid if the data is exactly the same as the previous snapshot.
 //
 // For now, we'll keep using an overwrite operation.
-func (t *Transaction) ReplaceDataFiles(ctx context.Context, filesToDelete, filesToAdd []string, snapshotProps iceberg.Properties) error {
+func (t *Transaction) ReplaceDataFiles(ctx context.Context, filesToDelete, filesToAdd []string, snapshotProps iceberg.Properties) (err error) {
+	defer t.cleanupOnError(ctx, t.written.mark(), &err)
+
 	if len(filesToDelete) == 0 {
 		if len(filesToAdd) > 0 {
 			return t.AddFiles(ctx, filesToAdd, snapshotProps, false)
@@ -365,11 +388,11 @@ func (t *Transaction) ReplaceDataFiles(ctx context.Context, filesToDelete, files
 	}
 
 	if len(setToDelete) != len(filesToDelete) {
-		return errors.New("delete file paths must be unique for ReplaceDataFiles")
+		return fmt.Errorf("%w: delete file paths must be unique for ReplaceDataFiles", iceberg.ErrValidation)
 	}
 
 	if len(setToAdd) != len(filesToAdd) {
-		return errors.New("add file paths must be unique for ReplaceDataFiles")
+		return fmt.Errorf("%w: add file paths must be unique for ReplaceDataFiles", iceberg.ErrValidation)
 	}
 
 	s := t.meta.currentSnapshot()
@@ -377,7 +400,7 @@ func (t *Transaction) ReplaceDataFiles(ctx context.Context, filesToDelete, files
 		return fmt.Errorf("%w: cannot replace files in a table without an existing snapshot", ErrInvalidOperation)
 	}
 
-	fs, err := t.tbl.fsF(ctx)
+	fs, err := t.fs(ctx)
 	if err != nil {
 		return err
 	}
@@ -397,7 +420,7 @@ func (t *Transaction) ReplaceDataFiles(ctx context.Context, filesToDelete, files
 	}
 
 	if len(markedForDeletion) != len(setToDelete) {
-		return errors.New("cannot delete files that do not belong to the table")
+		return fmt.Errorf("%w: cannot delete files that do not belong to the table", iceberg.ErrValidation)
 	}
 
 	if t.meta.NameMapping() == nil {
@@ -412,7 +435,7 @@ func (t *Transaction) ReplaceDataFiles(ctx context.Context, filesToDelete, files
 		}
 	}
 
-	commitUUID := uuid.New()
+	commitUUID := t.newCommitUUID()
 	updater := t.updateSnapshot(fs, snapshotProps, OpOverwrite).mergeOverwrite(&commitUUID)
 
 	for _, df := range markedForDeletion {
@@ -436,8 +459,9 @@ func (t *Transaction) ReplaceDataFiles(ctx context.Context, filesToDelete, files
 }
 
 // validateDataFilePartitionData verifies that DataFile partition values match
-// the current partition spec fields by ID without reading file contents.
-func validateDataFilePartitionData(df iceberg.DataFile, spec *iceberg.PartitionSpec) error {
+// the current partition spec fields by ID and have the types of the partition
+// fields, without reading file contents.
+func validateDataFilePartitionData(df iceberg.DataFile, spec *iceberg.PartitionSpec, schema *iceberg.Schema) error {
 	partitionData := df.Partition()
 	if partitionData == nil {
 		partitionData = map[int]any{}
@@ -457,7 +481,9 @@ func validateDataFilePartitionData(df iceberg.DataFile, spec *iceberg.PartitionS
 		}
 	}
 
-	return nil
+	_, err := iceberg.NewPartitionData(*spec, schema, partitionData)
+
+	return err
 }
 
 // validateDataFilesToAdd performs metadata-only validation for caller-provided
@@ -482,7 +508,7 @@ func (t *Transaction) validateDataFilesToAdd(dataFiles []iceberg.DataFile, opera
 		}
 
 		if _, ok := setToAdd[path]; ok {
-			return nil, fmt.Errorf("add data file paths must be unique for %s", operation)
+			return nil, fmt.Errorf("%w: add data file paths must be unique for %s", iceberg.ErrValidation, operation)
 		}
 		setToAdd[path] = struct{}{}
 
@@ -501,7 +527,7 @@ func (t *Transaction) validateDataFilesToAdd(dataFiles []iceberg.DataFile, opera
 				path, df.SpecID(), operation, expectedSpecID)
 		}
 
-		if err := validateDataFilePartitionData(df, currentSpec); err != nil {
+		if err := validateDataFilePartitionData(df, currentSpec, t.meta.CurrentSchema()); err != nil {
 			return nil, fmt.Errorf("data file %s has invalid partition data for %s: %w", path, operation, err)
 		}
 	}
@@ -518,7 +544,9 @@ func (t *Transaction) validateDataFilesToAdd(dataFiles []iceberg.DataFile, opera
 //
 // Callers are responsible for ensuring each DataFile is valid and consistent with the table.
 // Supplying incorrect DataFile metadata can produce an invalid snapshot and break reads.
-func (t *Transaction) AddDataFiles(ctx context.Context, dataFiles []iceberg.DataFile, snapshotProps iceberg.Properties) error {
+func (t *Transaction) AddDataFiles(ctx context.Context, dataFiles []iceberg.DataFile, snapshotProps iceberg.Properties) (err error) {
+	defer t.cleanupOnError(ctx, t.written.mark(), &err)
+
 	if len(dataFiles) == 0 {
 		return nil
 	}
@@ -528,7 +556,7 @@ func (t *Transaction) AddDataFiles(ctx context.Context, dataFiles []iceberg.Data
 		return err
 	}
 
-	fs, err := t.tbl.fsF(ctx)
+	fs, err := t.fs(ctx)
 	if err != nil {
 		return err
 	}
@@ -550,23 +578,231 @@ func (t *Transaction) AddDataFiles(ctx context.Context, dataFiles []iceberg.Data
 		}
 	}
 
-	if t.meta.NameMapping() == nil {
-		nameMapping := t.meta.CurrentSchema().NameMapping()
-		mappingJson, err := json.Marshal(nameMapping)
-		if err != nil {
+	if err := t.ensureNameMapping(); err != nil {
+		return err
+	}
+
+	appendFiles := t.appendSnapshotProducer(fs, snapshotProps)
+	for _, df := range dataFiles {
+		appendFiles.appendDataFile(df)
+	}
+
+	updates, reqs, err := appendFiles.commit()
+	if err != nil {
+		return err
+	}
+
+	return t.apply(updates, reqs)
+}
+
+// AddDeleteFiles commits delete files, such as those written by
+// PositionDeleteWriter, EqualityDeleteWriter or DeletionVectorWriter,
+// to the table in a new delete snapshot.
+//
+// The files must be supported by the format version of the table:
+// version 1 tables have no deletes, and position deletes are written as
+// position delete files in version 2 and as deletion vectors in version
+// 3. A data file has at most one deletion vector, so a deletion vector
+// replaces the one its data file has in the current snapshot, whose
+// deletes DeletionVectorWriter already merged into it.
+func (t *Transaction) AddDeleteFiles(ctx context.Context, deleteFiles []iceberg.DataFile, snapshotProps iceberg.Properties) (err error) {
+	defer t.cleanupOnError(ctx, t.written.mark(), &err)
+
+	if len(deleteFiles) == 0 {
+		return nil
+	}
+
+	dvTargets := make(set[string])
+	seen := make(map[string]struct{}, len(deleteFiles))
+	for i, df := range deleteFiles {
+		if df == nil {
+			return fmt.Errorf("nil delete file at index %d for AddDeleteFiles", i)
+		}
+
+		if df.ContentType() == iceberg.EntryContentData {
+			return fmt.Errorf("%w: file %s is a data file, not a delete file", iceberg.ErrInvalidArgument, df.FilePath())
+		}
+
+		key := contentFileKey(df)
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("%w: add delete files must be unique for AddDeleteFiles", iceberg.ErrValidation)
+		}
+		seen[key] = struct{}{}
+
+		if err := validateContentFile(t.meta.formatVersion, df); err != nil {
 			return err
 		}
-		err = t.SetProperties(iceberg.Properties{DefaultNameMappingKey: string(mappingJson)})
-		if err != nil {
+
+		if _, err := t.meta.GetSpecByID(int(df.SpecID())); err != nil {
 			return err
 		}
+
+		if df.FileFormat() == iceberg.PuffinFile {
+			ref := *df.ReferencedDataFile()
+			if _, ok := dvTargets[ref]; ok {
+				return fmt.Errorf("%w: data file %s has more than one deletion vector",
+					iceberg.ErrValidation, ref)
+			}
+			dvTargets[ref] = struct{}{}
+		}
+	}
+
+	fs, err := t.fs(ctx)
+	if err != nil {
+		return err
+	}
+
+	updater := t.updateSnapshot(fs, snapshotProps, OpDelete).mergeOverwrite(nil)
+	if s := t.meta.currentSnapshot(); s != nil && len(dvTargets) > 0 {
+		for df, err := range s.dataFiles(fs, set[iceberg.ManifestEntryContent]{iceberg.EntryContentPosDeletes: {}}) {
+			if err != nil {
+				return err
+			}
+
+			if ref := df.ReferencedDataFile(); ref != nil && df.FileFormat() == iceberg.PuffinFile {
+				if _, ok := dvTargets[*ref]; ok {
+					updater.deleteDataFile(df)
+				}
+			}
+		}
+	}
+
+	for _, df := range deleteFiles {
+		updater.appendDataFile(df)
+	}
+
+	updates, reqs, err := updater.commit()
+	if err != nil {
+		return err
+	}
+
+	return t.apply(updates, reqs)
+}
+
+// AddDataFilesSeq is AddDataFiles for a stream of data files, meant for
+// bulk imports of more files than should be held in memory at once.
+//
+// Rather than collecting the files until the commit, they are validated
+// and written to manifests as they are received, in groups that are
+// written concurrently by up to the configured number of workers. Only
+// the paths of the files are retained, to check that none of them is
+// added twice or already referenced by the table. If the table has
+// manifest merging enabled, the new manifests are merged on commit as
+// they are for other appends.
+func (t *Transaction) AddDataFilesSeq(ctx context.Context, dataFiles iter.Seq2[iceberg.DataFile, error], snapshotProps iceberg.Properties) (err error) {
+	defer t.cleanupOnError(ctx, t.written.mark(), &err)
+
+	fs, err := t.fs(ctx)
+	if err != nil {
+		return err
+	}
+
+	currentSpec, err := t.meta.CurrentSpec()
+	if err != nil || currentSpec == nil {
+		return fmt.Errorf("could not get current partition spec: %w", err)
 	}
 
 	appendFiles := t.appendSnapshotProducer(fs, snapshotProps)
-	for _, df := range dataFiles {
-		appendFiles.appendDataFile(df)
+
+	var ssc SnapshotSummaryCollector
+	ssc.setPartitionSummaryLimit(t.meta.props.GetInt(WritePartitionSummaryLimitKey,
+		WritePartitionSummaryLimitDefault))
+
+	var (
+		mx      sync.Mutex
+		written = map[int][]iceberg.ManifestFile{}
+		groups  int
+		added   = map[string]struct{}{}
+		group   = make([]iceberg.DataFile, 0, minManifestFileGroupSize)
+	)
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(config.EnvConfig.MaxWorkers)
+	writeGroup := func() {
+		files, idx := group, groups
+		group, groups = make([]iceberg.DataFile, 0, minManifestFileGroupSize), groups+1
+
+		g.Go(func() error {
+			mfs, err := appendFiles.writeRollingManifests(*currentSpec, iceberg.ManifestContentData,
+				slices.Values(files))
+			mx.Lock()
+			defer mx.Unlock()
+			written[idx] = mfs
+
+			return err
+		})
+	}
+
+	streamErr := func() error {
+		for df, err := range dataFiles {
+			if err != nil {
+				return err
+			}
+
+			if err := gctx.Err(); err != nil {
+				// a manifest writer failed, g.Wait reports why
+				return nil
+			}
+
+			if _, err := t.validateDataFilesToAdd([]iceberg.DataFile{df}, "AddDataFilesSeq"); err != nil {
+				return err
+			}
+
+			if _, ok := added[df.FilePath()]; ok {
+				return fmt.Errorf("%w: add data file paths must be unique for AddDataFilesSeq", iceberg.ErrValidation)
+			}
+			added[df.FilePath()] = struct{}{}
+
+			if err := ssc.addFile(df, t.meta.CurrentSchema(), *currentSpec); err != nil {
+				return err
+			}
+
+			if group = append(group, df); len(group) == minManifestFileGroupSize {
+				writeGroup()
+			}
+		}
+
+		if len(group) > 0 {
+			writeGroup()
+		}
+
+		return nil
+	}()
+
+	if err := errors.Join(streamErr, g.Wait()); err != nil {
+		return err
+	}
+
+	if len(added) == 0 {
+		return nil
+	}
+
+	if s := t.meta.currentSnapshot(); s != nil {
+		referenced := make([]string, 0)
+		for df, err := range s.dataFiles(fs, nil) {
+			if err != nil {
+				return err
+			}
+
+			if _, ok := added[df.FilePath()]; ok {
+				referenced = append(referenced, df.FilePath())
+			}
+		}
+
+		if len(referenced) > 0 {
+			return fmt.Errorf("cannot add files that are already referenced by table, files: %s", referenced)
+		}
+	}
+
+	if err := t.ensureNameMapping(); err != nil {
+		return err
 	}
 
+	for i := range groups {
+		appendFiles.streamedManifests = append(appendFiles.streamedManifests, written[i]...)
+	}
+	appendFiles.streamedSummary = &ssc
+
 	updates, reqs, err := appendFiles.commit()
 	if err != nil {
 		return err
@@ -575,6 +811,22 @@ func (t *Transaction) AddDataFiles(ctx context.Context, dataFiles []iceberg.Data
 	return t.apply(updates, reqs)
 }
 
+// ensureNameMapping sets the default name mapping of the table from its
+// current schema if it does not have one, so that files added without
+// field IDs can be read.
+func (t *Transaction) ensureNameMapping() error {
+	if t.meta.NameMapping() != nil {
+		return nil
+	}
+
+	mappingJson, err := json.Marshal(t.meta.CurrentSchema().NameMapping())
+	if err != nil {
+		return err
+	}
+
+	return t.SetProperties(iceberg.Properties{DefaultNameMappingKey: string(mappingJson)})
+}
+
 // ReplaceDataFilesWithDataFiles replaces files using pre-built DataFile objects.
 // This avoids scanning files to extract schema and statistics - the caller provides
 // DataFile objects directly with all required metadata.
@@ -592,7 +844,9 @@ func (t *Transaction) AddDataFiles(ctx context.Context, dataFiles []iceberg.Data
 //   - Files are written via a separate I/O path and metadata is already known
 //   - Avoiding file scanning improves performance or reliability
 //   - Working with storage systems where immediate file reads may be unreliable
-func (t *Transaction) ReplaceDataFilesWithDataFiles(ctx context.Context, filesToDelete, filesToAdd []iceberg.DataFile, snapshotProps iceberg.Properties) error {
+func (t *Transaction) ReplaceDataFilesWithDataFiles(ctx context.Context, filesToDelete, filesToAdd []iceberg.DataFile, snapshotProps iceberg.Properties) (err error) {
+	defer t.cleanupOnError(ctx, t.written.mark(), &err)
+
 	if len(filesToDelete) == 0 {
 		if len(filesToAdd) > 0 {
 			return t.AddDataFiles(ctx, filesToAdd, snapshotProps)
@@ -614,11 +868,11 @@ func (t *Transaction) ReplaceDataFilesWithDataFiles(ctx context.Context, filesTo
 
 		path := df.FilePath()
 		if path == "" {
-			return errors.New("delete data file paths must be non-empty for ReplaceDataFilesWithDataFiles")
+			return fmt.Errorf("%w: delete data file paths must be non-empty for ReplaceDataFilesWithDataFiles", iceberg.ErrValidation)
 		}
 
 		if _, ok := setToDelete[path]; ok {
-			return errors.New("delete data file paths must be unique for ReplaceDataFilesWithDataFiles")
+			return fmt.Errorf("%w: delete data file paths must be unique for ReplaceDataFilesWithDataFiles", iceberg.ErrValidation)
 		}
 		setToDelete[path] = struct{}{}
 	}
@@ -628,7 +882,7 @@ func (t *Transaction) ReplaceDataFilesWithDataFiles(ctx context.Context, filesTo
 		return fmt.Errorf("%w: cannot replace files in a table without an existing snapshot", ErrInvalidOperation)
 	}
 
-	fs, err := t.tbl.fsF(ctx)
+	fs, err := t.fs(ctx)
 	if err != nil {
 		return err
 	}
@@ -649,7 +903,7 @@ func (t *Transaction) ReplaceDataFilesWithDataFiles(ctx context.Context, filesTo
 	}
 
 	if len(markedForDeletion) != len(setToDelete) {
-		return errors.New("cannot delete files that do not belong to the table")
+		return fmt.Errorf("%w: cannot delete files that do not belong to the table", iceberg.ErrValidation)
 	}
 
 	if t.meta.NameMapping() == nil {
@@ -664,7 +918,7 @@ func (t *Transaction) ReplaceDataFilesWithDataFiles(ctx context.Context, filesTo
 		}
 	}
 
-	commitUUID := uuid.New()
+	commitUUID := t.newCommitUUID()
 	updater := t.updateSnapshot(fs, snapshotProps, OpOverwrite).mergeOverwrite(&commitUUID)
 
 	for _, df := range markedForDeletion {
@@ -683,20 +937,22 @@ func (t *Transaction) ReplaceDataFilesWithDataFiles(ctx context.Context, filesTo
 	return t.apply(updates, reqs)
 }
 
-func (t *Transaction) AddFiles(ctx context.Context, files []string, snapshotProps iceberg.Properties, ignoreDuplicates bool) error {
+func (t *Transaction) AddFiles(ctx context.Context, files []string, snapshotProps iceberg.Properties, ignoreDuplicates bool) (err error) {
+	defer t.cleanupOnError(ctx, t.written.mark(), &err)
+
 	set := make(map[string]string)
 	for _, f := range files {
 		set[f] = f
 	}
 
 	if len(set) != len(files) {
-		return errors.New("file paths must be unique for AddFiles")
+		return fmt.Errorf("%w: file paths must be unique for AddFiles", iceberg.ErrValidation)
 	}
 
 	if !ignoreDuplicates {
 		if s := t.meta.currentSnapshot(); s != nil {
 			referenced := make([]string, 0)
-			fs, err := t.tbl.fsF(ctx)
+			fs, err := t.fs(ctx)
 			if err != nil {
 				return err
 			}
@@ -727,7 +983,7 @@ func (t *Transaction) AddFiles(ctx context.Context, files []string, snapshotProp
 		}
 	}
 
-	fs, err := t.tbl.fsF(ctx)
+	fs, err := t.fs(ctx)
 	if err != nil {
 		return err
 	}
@@ -778,9 +1034,13 @@ func (t *Transaction) OverwriteTable(ctx context.Context, tbl arrow.Table, batch
 }
 
 type overwriteOperation struct {
-	concurrency   int
-	filter        iceberg.BooleanExpression
-	caseSensitive bool
+	concurrency    int
+	filter         iceberg.BooleanExpression
+	caseSensitive  bool
+	isolationLevel IsolationLevel
+	validateFrom   *int64
+	conflictFilter iceberg.BooleanExpression
+	writeOpts      []WriteOption
 }
 
 // OverwriteOption applies options to overwrite operations
@@ -816,6 +1076,43 @@ func WithOverwriteCaseInsensitive() OverwriteOption {
 	}
 }
 
+// WithOverwriteValidateFromSnapshot enables conflict detection for the overwrite.
+// The snapshots committed after the given snapshot, typically the one the
+// overwritten data was read from, are checked for changes that conflict with
+// the overwrite under its isolation level (see WithOverwriteIsolationLevel).
+// Default: no conflict detection
+func WithOverwriteValidateFromSnapshot(snapshotID int64) OverwriteOption {
+	return func(op *overwriteOperation) {
+		op.validateFrom = &snapshotID
+	}
+}
+
+// WithOverwriteIsolationLevel overwrites the isolation level used for conflict detection.
+// Default: the write.isolation-level table property, or serializable if it is not set
+func WithOverwriteIsolationLevel(level IsolationLevel) OverwriteOption {
+	return func(op *overwriteOperation) {
+		op.isolationLevel = level
+	}
+}
+
+// WithOverwriteConflictDetectionFilter overwrites the filter limiting which
+// concurrent changes are considered conflicting.
+// Default: the overwrite filter
+func WithOverwriteConflictDetectionFilter(filter iceberg.BooleanExpression) OverwriteOption {
+	return func(op *overwriteOperation) {
+		op.conflictFilter = filter
+	}
+}
+
+// WithOverwriteWriteOptions overrides the table properties the new data
+// files are written with (see WriteOption).
+// Default: the table properties
+func WithOverwriteWriteOptions(opts ...WriteOption) OverwriteOption {
+	return func(op *overwriteOperation) {
+		op.writeOpts = append(op.writeOpts, opts...)
+	}
+}
+
 // Overwrite overwrites the table data using a RecordReader.
 //
 // An optional filter (see WithOverwriteFilter) determines which existing data to delete or rewrite:
@@ -832,10 +1129,18 @@ func WithOverwriteCaseInsensitive() OverwriteOption {
 //
 // New data from the provided RecordReader is written to the table regardless of the filter.
 //
+// When a validation snapshot is set with WithOverwriteValidateFromSnapshot, the overwrite
+// fails with ErrCommitConflict if a snapshot committed since then deleted data or added
+// delete files matching the conflict detection filter. Under serializable isolation,
+// concurrently appended data matching the filter is a conflict as well.
+//
 // The concurrency parameter controls the level of parallelism for manifest processing and file rewriting and
 // can be overridden using the WithOverwriteConcurrency option.
 // If concurrency <= 0, defaults to runtime.GOMAXPROCS(0).
-func (t *Transaction) Overwrite(ctx context.Context, rdr array.RecordReader, snapshotProps iceberg.Properties, opts ...OverwriteOption) error {
+func (t *Transaction) Overwrite(ctx context.Context, rdr array.RecordReader, snapshotProps iceberg.Properties, opts ...OverwriteOption) (err error) {
+	defer t.cleanupOnError(ctx, t.written.mark(), &err)
+	ctx = withWrittenFiles(ctx, &t.written)
+
 	overwrite := overwriteOperation{
 		concurrency:   runtime.GOMAXPROCS(0),
 		filter:        iceberg.AlwaysTrue{},
@@ -845,12 +1150,22 @@ func (t *Transaction) Overwrite(ctx context.Context, rdr array.RecordReader, sna
 		apply(&overwrite)
 	}
 
-	updater, err := t.performCopyOnWriteDeletion(ctx, OpOverwrite, snapshotProps, overwrite.filter, overwrite.caseSensitive, overwrite.concurrency)
+	conflictFilter := overwrite.conflictFilter
+	if conflictFilter == nil {
+		conflictFilter = overwrite.filter
+	}
+	cd, err := t.newConflictDetection(overwrite.isolationLevel, overwrite.validateFrom,
+		conflictFilter, overwrite.caseSensitive, WriteIsolationLevelKey)
 	if err != nil {
 		return err
 	}
 
-	fs, err := t.tbl.fsF(ctx)
+	updater, err := t.performCopyOnWriteDeletion(ctx, OpOverwrite, snapshotProps, overwrite.filter, overwrite.caseSensitive, overwrite.concurrency, cd)
+	if err != nil {
+		return err
+	}
+
+	fs, err := t.fs(ctx)
 	if err != nil {
 		return err
 	}
@@ -859,6 +1174,7 @@ func (t *Transaction) Overwrite(ctx context.Context, rdr array.RecordReader, sna
 		itr:       array.IterFromReader(rdr),
 		fs:        fs.(io.WriteFileIO),
 		writeUUID: &updater.commitUuid,
+		props:     writeProperties(t.meta.props, overwrite.writeOpts),
 	})
 
 	for df, err := range itr {
@@ -876,12 +1192,16 @@ func (t *Transaction) Overwrite(ctx context.Context, rdr array.RecordReader, sna
 	return t.apply(updates, reqs)
 }
 
-func (t *Transaction) performCopyOnWriteDeletion(ctx context.Context, operation Operation, snapshotProps iceberg.Properties, filter iceberg.BooleanExpression, caseSensitive bool, concurrency int) (*snapshotProducer, error) {
-	fs, err := t.tbl.fsF(ctx)
+func (t *Transaction) performCopyOnWriteDeletion(ctx context.Context, operation Operation, snapshotProps iceberg.Properties, filter iceberg.BooleanExpression, caseSensitive bool, concurrency int, cd conflictDetection) (*snapshotProducer, error) {
+	fs, err := t.fs(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := t.validateNoConflicts(fs, cd); err != nil {
+		return nil, err
+	}
+
 	if t.meta.NameMapping() == nil {
 		nameMapping := t.meta.CurrentSchema().NameMapping()
 		mappingJson, err := json.Marshal(nameMapping)
@@ -894,7 +1214,7 @@ func (t *Transaction) performCopyOnWriteDeletion(ctx context.Context, operation
 		}
 	}
 
-	commitUUID := uuid.New()
+	commitUUID := t.newCommitUUID()
 	updater := t.updateSnapshot(fs, snapshotProps, operation).mergeOverwrite(&commitUUID)
 
 	filesToDelete, filesToRewrite, err := t.classifyFilesForOverwrite(ctx, fs, filter, caseSensitive, concurrency)
@@ -918,8 +1238,11 @@ func (t *Transaction) performCopyOnWriteDeletion(ctx context.Context, operation
 type DeleteOption func(deleteOp *deleteOperation)
 
 type deleteOperation struct {
-	caseSensitive bool
-	concurrency   int
+	caseSensitive  bool
+	concurrency    int
+	isolationLevel IsolationLevel
+	validateFrom   *int64
+	conflictFilter iceberg.BooleanExpression
 }
 
 // WithDeleteConcurrency overwrites the default concurrency for delete operations.
@@ -944,6 +1267,34 @@ func WithDeleteCaseInsensitive() DeleteOption {
 	}
 }
 
+// WithDeleteValidateFromSnapshot enables conflict detection for the delete.
+// The snapshots committed after the given snapshot are checked for changes
+// that conflict with the delete under its isolation level (see WithDeleteIsolationLevel).
+// Default: no conflict detection
+func WithDeleteValidateFromSnapshot(snapshotID int64) DeleteOption {
+	return func(deleteOp *deleteOperation) {
+		deleteOp.validateFrom = &snapshotID
+	}
+}
+
+// WithDeleteIsolationLevel overwrites the isolation level used for conflict detection.
+// Default: the write.delete.isolation-level table property, then write.isolation-level,
+// or serializable if neither is set
+func WithDeleteIsolationLevel(level IsolationLevel) DeleteOption {
+	return func(deleteOp *deleteOperation) {
+		deleteOp.isolationLevel = level
+	}
+}
+
+// WithDeleteConflictDetectionFilter overwrites the filter limiting which
+// concurrent changes are considered conflicting.
+// Default: the delete filter
+func WithDeleteConflictDetectionFilter(filter iceberg.BooleanExpression) DeleteOption {
+	return func(deleteOp *deleteOperation) {
+		deleteOp.conflictFilter = filter
+	}
+}
+
 // Delete deletes records matching the provided filter.
 //
 // The provided filter acts as a row-level predicate on existing data:
@@ -958,7 +1309,10 @@ func WithDeleteCaseInsensitive() DeleteOption {
 //
 // The concurrency parameter controls the level of parallelism for manifest processing and file rewriting and
 // can be overridden using the WithOverwriteConcurrency option. Defaults to runtime.GOMAXPROCS(0).
-func (t *Transaction) Delete(ctx context.Context, filter iceberg.BooleanExpression, snapshotProps iceberg.Properties, opts ...DeleteOption) error {
+func (t *Transaction) Delete(ctx context.Context, filter iceberg.BooleanExpression, snapshotProps iceberg.Properties, opts ...DeleteOption) (err error) {
+	defer t.cleanupOnError(ctx, t.written.mark(), &err)
+	ctx = withWrittenFiles(ctx, &t.written)
+
 	deleteOp := deleteOperation{
 		concurrency:   runtime.GOMAXPROCS(0),
 		caseSensitive: true,
@@ -971,7 +1325,18 @@ func (t *Transaction) Delete(ctx context.Context, filter iceberg.BooleanExpressi
 	if writeDeleteMode != WriteModeCopyOnWrite {
 		return fmt.Errorf("'%s' is set to '%s' but only '%s' is currently supported", WriteDeleteModeKey, writeDeleteMode, WriteModeCopyOnWrite)
 	}
-	updater, err := t.performCopyOnWriteDeletion(ctx, OpDelete, snapshotProps, filter, deleteOp.caseSensitive, deleteOp.concurrency)
+	conflictFilter := deleteOp.conflictFilter
+	if conflictFilter == nil {
+		conflictFilter = filter
+	}
+	cd, err := t.newConflictDetection(deleteOp.isolationLevel, deleteOp.validateFrom,
+		conflictFilter, deleteOp.caseSensitive,
+		WriteDeleteIsolationLevelKey, WriteIsolationLevelKey)
+	if err != nil {
+		return err
+	}
+
+	updater, err := t.performCopyOnWriteDeletion(ctx, OpDelete, snapshotProps, filter, deleteOp.caseSensitive, deleteOp.concurrency, cd)
 	if err != nil {
 		return err
 	}
@@ -1071,6 +1436,18 @@ func (t *Transaction) classifyFilesForFilteredOverwrite(ctx context.Context, fs
 				return fmt.Errorf("failed to fetch manifest entries: %w", err)
 			}
 
+			manifestSpec := meta.PartitionSpecByID(int(manifest.PartitionSpecID()))
+			if manifestSpec == nil {
+				return fmt.Errorf("%w: id %d", ErrPartitionSpecNotFound, manifest.PartitionSpecID())
+			}
+
+			// files in partitions whose every row matches the filter are
+			// deleted without consulting their column metrics
+			strictPartitionEvaluator, err := newStrictPartitionEvaluator(*manifestSpec, schema, filter, caseSensitive)
+			if err != nil {
+				return fmt.Errorf("failed to create strict partition evaluator: %w", err)
+			}
+
 			localDelete := make([]iceberg.DataFile, 0)
 			localRewrite := make([]iceberg.DataFile, 0)
 
@@ -1084,6 +1461,17 @@ func (t *Transaction) classifyFilesForFilteredOverwrite(ctx context.Context, fs
 					continue
 				}
 
+				allRowsMatch, err := strictPartitionEvaluator(df)
+				if err != nil {
+					return fmt.Errorf("failed to evaluate partition of data file %s: %w", df.FilePath(), err)
+				}
+
+				if allRowsMatch {
+					localDelete = append(localDelete, df)
+
+					continue
+				}
+
 				inclusive, err := inclusiveEvaluator(df)
 				if err != nil {
 					return fmt.Errorf("failed to evaluate data file %s with inclusive evaluator: %w", df.FilePath(), err)
@@ -1129,7 +1517,7 @@ func (t *Transaction) rewriteFilesWithFilter(ctx context.Context, fs io.IO, upda
 
 	for _, originalFile := range files {
 		// Use a separate UUID for rewrite operations to avoid filename collisions with new data files
-		rewriteUUID := uuid.New()
+		rewriteUUID := t.newCommitUUID()
 		rewrittenFiles, err := t.rewriteSingleFile(ctx, fs, originalFile, complementFilter, caseSensitive, rewriteUUID, concurrency)
 		if err != nil {
 			return fmt.Errorf("failed to rewrite file %s: %w", originalFile.FilePath(), err)
@@ -1163,7 +1551,7 @@ func (t *Transaction) rewriteSingleFile(ctx context.Context, fs io.IO, originalF
 	}
 
 	scanner := &arrowScan{
-		metadata:        meta,
+		tableProps:      meta.Properties(),
 		fs:              fs,
 		projectedSchema: t.meta.CurrentSchema(),
 		boundRowFilter:  boundFilter,
@@ -1212,6 +1600,19 @@ func (t *Transaction) rewriteSingleFile(ctx context.Context, fs io.IO, originalF
 	return result, nil
 }
 
+// fs returns the IO of the table, which also decrypts the manifest lists
+// of the snapshots staged by the transaction.
+func (t *Transaction) fs(ctx context.Context) (io.IO, error) {
+	fs, err := t.tbl.version().fsF(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return withTableEncryption(ctx, fs, t.meta.props, func() iter.Seq[EncryptedKey] {
+		return slices.Values(t.meta.encryptionKeys)
+	}), nil
+}
+
 func (t *Transaction) Scan(opts ...ScanOption) (*Scan, error) {
 	updatedMeta, err := t.meta.Build()
 	if err != nil {
@@ -1220,7 +1621,7 @@ func (t *Transaction) Scan(opts ...ScanOption) (*Scan, error) {
 
 	s := &Scan{
 		metadata:       updatedMeta,
-		ioF:            t.tbl.fsF,
+		ioF:            t.fs,
 		rowFilter:      iceberg.AlwaysTrue{},
 		selectedFields: []string{"*"},
 		caseSensitive:  true,
@@ -1248,7 +1649,7 @@ func (t *Transaction) StagedTable() (*StagedTable, error) {
 			t.tbl.identifier,
 			updatedMeta,
 			updatedMeta.Location(),
-			t.tbl.fsF,
+			t.tbl.version().fsF,
 			t.tbl.cat,
 		),
 	}, nil
@@ -1264,10 +1665,16 @@ func (t *Transaction) Commit(ctx context.Context) (*Table, error) {
 
 	t.committed = true
 
-	if len(t.meta.updates) > 0 {
-		t.reqs = append(t.reqs, AssertTableUUID(t.meta.uuid))
-		tbl, err := t.tbl.doCommit(ctx, t.meta.updates, t.reqs)
+	if len(t.meta.updates) > 0 || t.create != nil {
+		reqs, updates := t.commitChanges()
+		tbl, err := t.tbl.doCommit(ctx, updates, reqs)
 		if err != nil {
+			// the files written by the transaction are unreferenced only
+			// if the commit was rejected, otherwise it may have been applied
+			if commitRejected(err) {
+				t.cleanupOnError(ctx, 0, &err)
+			}
+
 			return tbl, err
 		}
 
@@ -1283,6 +1690,110 @@ func (t *Transaction) Commit(ctx context.Context) (*Table, error) {
 	return t.tbl, nil
 }
 
+// TableCommit holds the requirements and updates to apply to a single
+// table as part of a multi-table transaction.
+type TableCommit struct {
+	Identifier   Identifier
+	Requirements []Requirement
+	Updates      []Update
+}
+
+// TableCommit marks the transaction as committed and returns its staged
+// requirements and updates so that they can be committed atomically
+// together with changes to other tables by a catalog supporting multi-table
+// transactions.
+func (t *Transaction) TableCommit() (TableCommit, error) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	if t.committed {
+		return TableCommit{}, errors.New("transaction has already been committed")
+	}
+
+	t.committed = true
+
+	reqs, updates := t.commitChanges()
+
+	return TableCommit{
+		Identifier:   t.tbl.identifier,
+		Requirements: reqs,
+		Updates:      updates,
+	}, nil
+}
+
+// commitChanges returns the requirements and updates sent to the catalog
+// to commit the transaction. Transactions creating a table only assert that
+// it does not exist yet, and send the updates building its initial metadata
+// ahead of the staged ones.
+func (t *Transaction) commitChanges() ([]Requirement, []Update) {
+	if t.create != nil {
+		return []Requirement{AssertCreate()}, slices.Concat(t.create, t.meta.updates)
+	}
+
+	return append(slices.Clone(t.reqs), AssertTableUUID(t.meta.uuid)), slices.Clone(t.meta.updates)
+}
+
+// ValidationResult is the outcome of validating a transaction without
+// committing it.
+type ValidationResult struct {
+	// Requirements are the requirements the commit would assert.
+	Requirements []Requirement
+	// Updates are the metadata updates the commit would apply.
+	Updates []Update
+	// Metadata is the table metadata that committing the transaction
+	// would produce from the table's current metadata.
+	Metadata Metadata
+}
+
+// Validate performs a dry run of committing the transaction: the current
+// metadata of the table is loaded from its catalog, the transaction's
+// requirements are checked against it and its updates are applied to it,
+// but nothing is committed and the transaction can still be committed or
+// further modified afterwards.
+//
+// Conflicts with concurrent changes are reported as an error wrapping
+// ErrCommitConflict that lists every failed requirement. Data and manifest
+// files already written by operations staged in the transaction are left
+// in place.
+func (t *Transaction) Validate(ctx context.Context) (ValidationResult, error) {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	if t.committed {
+		return ValidationResult{}, errors.New("transaction has already been committed")
+	}
+
+	var result ValidationResult
+	result.Requirements, result.Updates = t.commitChanges()
+
+	var (
+		currentMeta Metadata
+		currentLoc  string
+	)
+
+	current, err := t.tbl.cat.LoadTable(ctx, t.tbl.identifier)
+	switch {
+	case err == nil:
+		currentMeta, currentLoc = current.Metadata(), current.MetadataLocation()
+	case t.create == nil || !errors.Is(err, iceberg.ErrNoSuchTable):
+		return result, err
+	}
+
+	var failed []error
+	for _, r := range result.Requirements {
+		if err := r.Validate(currentMeta); err != nil {
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) > 0 {
+		return result, fmt.Errorf("%w: %w", ErrCommitConflict, errors.Join(failed...))
+	}
+
+	result.Metadata, err = UpdateTableMetadata(currentMeta, result.Updates, currentLoc)
+
+	return result, err
+}
+
 type StagedTable struct {
 	*Table
 }
@@ -374,6 +374,8 @@ type ColumnUpdate struct {
 	Required     iceberg.Optional[bool]
 	WriteDefault iceberg.Optional[iceberg.Literal]
 	Doc          iceberg.Optional[string]
+	// Metadata replaces the key/value metadata of the column.
+	Metadata iceberg.Optional[map[string]string]
 }
 
 func (u *UpdateSchema) UpdateColumn(path []string, update ColumnUpdate) *UpdateSchema {
@@ -389,7 +391,8 @@ func (u *UpdateSchema) updateColumn(path []string, update ColumnUpdate) error {
 		!update.FieldType.Valid &&
 		!update.Required.Valid &&
 		!update.WriteDefault.Valid &&
-		!update.Doc.Valid {
+		!update.Doc.Valid &&
+		!update.Metadata.Valid {
 		return nil
 	}
 
@@ -491,6 +494,9 @@ func (u *UpdateSchema) updateColumn(path []string, update ColumnUpdate) error {
 	if update.Doc.Valid {
 		updatedField.Doc = update.Doc.Val
 	}
+	if update.Metadata.Valid {
+		updatedField.Metadata = maps.Clone(update.Metadata.Val)
+	}
 	u.updates[parentID][field.ID] = updatedField
 
 	return nil
@@ -708,7 +714,12 @@ func (u *UpdateSchema) Apply() (*iceberg.Schema, error) {
 		}).ID
 	}
 
-	return iceberg.NewSchemaWithIdentifiers(nextSchemaID, identifierFieldIDs, st.(*iceberg.StructType).FieldList...), nil
+	updated := iceberg.NewSchemaWithIdentifiers(nextSchemaID, identifierFieldIDs, st.(*iceberg.StructType).FieldList...)
+	if err := iceberg.ValidateReservedFields(updated); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
 }
 
 func (u *UpdateSchema) Commit() error {
@@ -760,18 +771,21 @@ func (a *applyChanges) Struct(structType iceberg.StructType, fieldResults []iceb
 		doc := field.Doc
 		required := field.Required
 		writeDefault := field.WriteDefault
+		metadata := field.Metadata
 
 		if update, ok := a.updates[field.ID]; ok {
 			name = update.Name
 			doc = update.Doc
 			required = update.Required
 			writeDefault = update.WriteDefault
+			metadata = update.Metadata
 		}
 		if field.Name == name &&
 			field.Type.Equals(resultType) &&
 			field.Required == required &&
 			field.Doc == doc &&
-			field.WriteDefault == writeDefault {
+			field.WriteDefault == writeDefault &&
+			maps.Equal(field.Metadata, metadata) {
 			newFields = append(newFields, field)
 		} else {
 			hasChanges = true
@@ -783,6 +797,7 @@ func (a *applyChanges) Struct(structType iceberg.StructType, fieldResults []iceb
 				Doc:            doc,
 				InitialDefault: field.InitialDefault,
 				WriteDefault:   writeDefault,
+				Metadata:       metadata,
 			})
 		}
 	}
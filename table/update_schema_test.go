@@ -81,7 +81,7 @@ func TestAddColumn(t *testing.T) {
 				ValueType:     iceberg.PrimitiveTypes.String,
 				ValueRequired: false,
 			}, Required: false, Doc: ""},
-			{ID: 12, Name: "gender", Type: iceberg.PrimitiveTypes.String, Required: false, Doc: ""},
+			{ID: 12, Name: "gender", Type: iceberg.PrimitiveTypes.String, Required: false, Doc: "", InitialDefault: "male", WriteDefault: "male"},
 		}, newSchema.Fields())
 	})
 
@@ -524,6 +524,32 @@ func TestUpdateColumn(t *testing.T) {
 		assert.Equal(t, "User's age in years", ageField.Doc)
 	})
 
+	t.Run("test update column metadata", func(t *testing.T) {
+		table := New([]string{"id"}, testMetadata, "", nil, nil)
+		txn := table.NewTransaction()
+
+		md := map[string]string{"classification": "pii", "owner": "identity-team"}
+		newSchema, err := NewUpdateSchema(txn, true, true).UpdateColumn([]string{"name"}, ColumnUpdate{
+			Metadata: iceberg.Optional[map[string]string]{Valid: true, Val: md},
+		}).Apply()
+		assert.NoError(t, err)
+
+		nameField, ok := newSchema.FindFieldByName("name")
+		assert.True(t, ok)
+		assert.Equal(t, md, nameField.Metadata)
+		assert.False(t, newSchema.Equals(originalSchema))
+
+		// renaming keeps the metadata of the column
+		renamed, err := NewUpdateSchema(txn, true, true).UpdateColumn([]string{"name"}, ColumnUpdate{
+			Metadata: iceberg.Optional[map[string]string]{Valid: true, Val: md},
+		}).RenameColumn([]string{"name"}, "full_name").Apply()
+		assert.NoError(t, err)
+
+		nameField, ok = renamed.FindFieldByName("full_name")
+		assert.True(t, ok)
+		assert.Equal(t, md, nameField.Metadata)
+	})
+
 	t.Run("test update non-existent column", func(t *testing.T) {
 		table := New([]string{"id"}, testMetadata, "", nil, nil)
 		txn := table.NewTransaction()
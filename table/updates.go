@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/apache/iceberg-go"
 	"github.com/google/uuid"
@@ -34,6 +35,9 @@ const (
 	UpdateAddSnapshot  = "add-snapshot"
 	UpdateAddSortOrder = "add-sort-order"
 
+	UpdateAddEncryptionKey    = "add-encryption-key"
+	UpdateRemoveEncryptionKey = "remove-encryption-key"
+
 	UpdateAssignUUID = "assign-uuid"
 
 	UpdateRemoveProperties  = "remove-properties"
@@ -112,6 +116,10 @@ func (u *Updates) UnmarshalJSON(data []byte) error {
 			upd = &removeSpecUpdate{}
 		case UpdateRemoveSchemas:
 			upd = &removeSchemasUpdate{}
+		case UpdateAddEncryptionKey:
+			upd = &addEncryptionKeyUpdate{}
+		case UpdateRemoveEncryptionKey:
+			upd = &removeEncryptionKeyUpdate{}
 		default:
 			return fmt.Errorf("%w: unknown update action: %s", iceberg.ErrInvalidArgument, base.ActionName)
 		}
@@ -496,15 +504,25 @@ func (u *removeSnapshotsUpdate) PostCommit(ctx context.Context, preTable *Table,
 		}
 	}
 
-	var res error
+	var (
+		failed []string
+		res    error
+	)
 
 	for f := range filesToDelete {
 		if err := prefs.Remove(f); err != nil {
+			failed = append(failed, f)
 			res = errors.Join(res, err)
 		}
 	}
 
-	return res
+	if len(failed) > 0 {
+		slices.Sort(failed)
+
+		return &iceberg.CleanupError{Files: failed, Err: res}
+	}
+
+	return nil
 }
 
 type removeSnapshotRefUpdate struct {
@@ -560,3 +578,39 @@ func NewRemoveSchemasUpdate(schemaIds []int) *removeSchemasUpdate {
 func (u *removeSchemasUpdate) Apply(builder *MetadataBuilder) error {
 	return builder.RemoveSchemas(u.SchemaIDs)
 }
+
+type addEncryptionKeyUpdate struct {
+	baseUpdate
+	EncryptionKey EncryptedKey `json:"encryption-key"`
+}
+
+// NewAddEncryptionKeyUpdate creates a new Update that adds an encrypted key
+// to the table metadata.
+func NewAddEncryptionKeyUpdate(key EncryptedKey) *addEncryptionKeyUpdate {
+	return &addEncryptionKeyUpdate{
+		baseUpdate:    baseUpdate{ActionName: UpdateAddEncryptionKey},
+		EncryptionKey: key,
+	}
+}
+
+func (u *addEncryptionKeyUpdate) Apply(builder *MetadataBuilder) error {
+	return builder.AddEncryptionKey(u.EncryptionKey)
+}
+
+type removeEncryptionKeyUpdate struct {
+	baseUpdate
+	KeyID string `json:"key-id"`
+}
+
+// NewRemoveEncryptionKeyUpdate creates a new Update that removes the
+// encrypted key with the given ID from the table metadata.
+func NewRemoveEncryptionKeyUpdate(keyID string) *removeEncryptionKeyUpdate {
+	return &removeEncryptionKeyUpdate{
+		baseUpdate: baseUpdate{ActionName: UpdateRemoveEncryptionKey},
+		KeyID:      keyID,
+	}
+}
+
+func (u *removeEncryptionKeyUpdate) Apply(builder *MetadataBuilder) error {
+	return builder.RemoveEncryptionKey(u.KeyID)
+}
@@ -0,0 +1,482 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/config"
+	iceio "github.com/apache/iceberg-go/io"
+	"golang.org/x/sync/errgroup"
+)
+
+// ValidationCheck identifies an invariant checked by ValidateTable.
+type ValidationCheck string
+
+const (
+	// ValidationRequiredNotNull checks that required columns hold no nulls.
+	ValidationRequiredNotNull ValidationCheck = "required-not-null"
+	// ValidationPartitionValues checks that every row of a data file
+	// belongs to the partition the file is tracked in.
+	ValidationPartitionValues ValidationCheck = "partition-values"
+	// ValidationMetrics checks that the value counts, null counts and
+	// bounds recorded for a data file agree with its rows.
+	ValidationMetrics ValidationCheck = "metrics"
+	// ValidationRecordCount checks that the record count of a data file
+	// is the number of rows it holds.
+	ValidationRecordCount ValidationCheck = "record-count"
+	// ValidationSequenceNumbers checks that sequence numbers increase with
+	// each snapshot and that files and manifests do not have sequence
+	// numbers beyond those of the snapshot they are live in.
+	ValidationSequenceNumbers ValidationCheck = "sequence-numbers"
+)
+
+// ValidationIssue is a violated invariant found by ValidateTable.
+type ValidationIssue struct {
+	Check ValidationCheck
+	// FilePath is the data or manifest file with the issue, or empty for
+	// issues with the table metadata.
+	FilePath string
+	Message  string
+}
+
+func (i ValidationIssue) String() string {
+	if i.FilePath == "" {
+		return fmt.Sprintf("%s: %s", i.Check, i.Message)
+	}
+
+	return fmt.Sprintf("%s: %s: %s", i.Check, i.FilePath, i.Message)
+}
+
+// ValidationReport is the result of ValidateTable.
+type ValidationReport struct {
+	// DataFiles is the number of live data files checked and Records the
+	// number of rows read from them.
+	DataFiles int64
+	Records   int64
+	// Issues holds the violated invariants, sorted by file path.
+	Issues []ValidationIssue
+}
+
+// Valid reports whether no issues were found.
+func (r ValidationReport) Valid() bool { return len(r.Issues) == 0 }
+
+type validateConfig struct {
+	readData    bool
+	concurrency int
+}
+
+// ValidateOption configures ValidateTable.
+type ValidateOption func(*validateConfig)
+
+// WithValidateDataFiles sets whether the rows of data files are read to
+// check them against their partition and metrics. It defaults to true.
+// Without reading data files, only the sequence numbers and the null
+// counts of required columns recorded in the metrics are checked.
+func WithValidateDataFiles(enabled bool) ValidateOption {
+	return func(cfg *validateConfig) {
+		cfg.readData = enabled
+	}
+}
+
+// WithValidateConcurrency sets the number of data files read at once.
+func WithValidateConcurrency(n int) ValidateOption {
+	return func(cfg *validateConfig) {
+		if n > 0 {
+			cfg.concurrency = n
+		}
+	}
+}
+
+// ValidateTable checks the invariants of the table's current snapshot, a
+// lightweight fsck, and reports the violations it finds. The data files of
+// the snapshot are read to check that required columns hold no nulls,
+// that rows match the partition of their file, and that record counts and
+// column metrics agree with the data. Delete files are not applied. An
+// error is only returned if the table cannot be read.
+func (t Table) ValidateTable(ctx context.Context, opts ...ValidateOption) (ValidationReport, error) {
+	t = t.pin()
+
+	cfg := validateConfig{readData: true, concurrency: config.EnvConfig.MaxWorkers}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var (
+		report ValidationReport
+		mx     sync.Mutex
+	)
+	addIssue := func(check ValidationCheck, path, format string, args ...any) {
+		mx.Lock()
+		defer mx.Unlock()
+		report.Issues = append(report.Issues, ValidationIssue{
+			Check: check, FilePath: path, Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	meta := t.Metadata()
+	snap := meta.CurrentSnapshot()
+	if snap == nil {
+		return report, nil
+	}
+
+	v := &tableValidator{meta: meta, schema: meta.CurrentSchema(), addIssue: addIssue}
+	v.checkSnapshotSequence(snap)
+
+	fs, err := t.FS(ctx)
+	if err != nil {
+		return report, err
+	}
+
+	manifests, err := snap.Manifests(fs)
+	if err != nil {
+		return report, err
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(cfg.concurrency)
+	for _, m := range manifests {
+		entries, err := m.FetchEntries(fs, true)
+		if err != nil {
+			return report, err
+		}
+
+		spec := meta.PartitionSpecByID(int(m.PartitionSpecID()))
+		for _, e := range entries {
+			v.checkEntrySequence(snap, m, e)
+
+			df := e.DataFile()
+			if df.ContentType() != iceberg.EntryContentData {
+				continue
+			}
+
+			report.DataFiles++
+			if !cfg.readData {
+				v.checkRequiredMetrics(df)
+
+				continue
+			}
+
+			g.Go(func() error {
+				n, err := v.checkDataFile(ctx, fs, spec, df)
+				if err != nil {
+					return fmt.Errorf("failed to validate data file %s: %w", df.FilePath(), err)
+				}
+
+				mx.Lock()
+				defer mx.Unlock()
+				report.Records += n
+
+				return nil
+			})
+		}
+	}
+
+	if err := g.Wait(); err != nil {
+		return report, err
+	}
+
+	slices.SortStableFunc(report.Issues, func(a, b ValidationIssue) int {
+		return cmp.Compare(a.FilePath, b.FilePath)
+	})
+
+	return report, nil
+}
+
+type tableValidator struct {
+	meta     Metadata
+	schema   *iceberg.Schema
+	addIssue func(check ValidationCheck, path, format string, args ...any)
+}
+
+// checkSnapshotSequence checks that the sequence numbers of the ancestors
+// of snap increase with each snapshot.
+func (v *tableValidator) checkSnapshotSequence(snap *Snapshot) {
+	if v.meta.Version() < 2 {
+		return
+	}
+
+	for s := range snap.Ancestors(v.meta) {
+		if s.SequenceNumber > v.meta.LastSequenceNumber() {
+			v.addIssue(ValidationSequenceNumbers, "",
+				"snapshot %d has sequence number %d beyond the last sequence number %d",
+				s.SnapshotID, s.SequenceNumber, v.meta.LastSequenceNumber())
+		}
+
+		if s.ParentSnapshotID == nil {
+			continue
+		}
+
+		if parent := v.meta.SnapshotByID(*s.ParentSnapshotID); parent != nil && s.SequenceNumber <= parent.SequenceNumber {
+			v.addIssue(ValidationSequenceNumbers, "",
+				"snapshot %d has sequence number %d, not greater than %d of its parent %d",
+				s.SnapshotID, s.SequenceNumber, parent.SequenceNumber, parent.SnapshotID)
+		}
+	}
+}
+
+// checkEntrySequence checks that neither the manifest nor the entry have
+// a sequence number beyond the snapshot they are live in.
+func (v *tableValidator) checkEntrySequence(snap *Snapshot, m iceberg.ManifestFile, e iceberg.ManifestEntry) {
+	if v.meta.Version() < 2 {
+		return
+	}
+
+	if m.SequenceNum() > snap.SequenceNumber {
+		v.addIssue(ValidationSequenceNumbers, m.FilePath(),
+			"manifest has sequence number %d beyond %d of snapshot %d",
+			m.SequenceNum(), snap.SequenceNumber, snap.SnapshotID)
+	}
+
+	if e.SequenceNum() > m.SequenceNum() {
+		v.addIssue(ValidationSequenceNumbers, e.DataFile().FilePath(),
+			"data sequence number %d is beyond %d of its manifest %s",
+			e.SequenceNum(), m.SequenceNum(), m.FilePath())
+	}
+}
+
+// checkRequiredMetrics checks the null counts recorded for the required
+// columns of df.
+func (v *tableValidator) checkRequiredMetrics(df iceberg.DataFile) {
+	nullCounts := df.NullValueCounts()
+	for _, f := range v.schema.Fields() {
+		if n := nullCounts[f.ID]; f.Required && n > 0 {
+			v.addIssue(ValidationRequiredNotNull, df.FilePath(),
+				"required column %s has %d nulls", f.Name, n)
+		}
+	}
+}
+
+// columnStats are the statistics of a top-level column computed from the
+// rows of a data file.
+type columnStats struct {
+	values, nulls int64
+	min, max      any
+	// unsupported is set if the values of the column could not be
+	// converted, in which case its bounds are not checked.
+	unsupported bool
+}
+
+func (s *columnStats) update(col arrow.Array) {
+	s.values += int64(col.Len())
+	s.nulls += int64(col.NullN())
+	if s.unsupported {
+		return
+	}
+
+	for row := range col.Len() {
+		if col.IsNull(row) {
+			continue
+		}
+
+		lit, err := getArrowValueAsIcebergLiteral(col, row)
+		if err != nil {
+			s.unsupported = true
+
+			return
+		}
+
+		val := lit.Any()
+		switch f := val.(type) {
+		case float32:
+			if math.IsNaN(float64(f)) {
+				continue
+			}
+		case float64:
+			if math.IsNaN(f) {
+				continue
+			}
+		}
+
+		if s.min == nil || compareGroupValues(val, s.min) < 0 {
+			s.min = val
+		}
+		if s.max == nil || compareGroupValues(val, s.max) > 0 {
+			s.max = val
+		}
+	}
+}
+
+// checkDataFile reads the rows of df and checks them against its record
+// count, partition and metrics. It returns the number of rows read.
+func (v *tableValidator) checkDataFile(ctx context.Context, fs iceio.IO, spec *iceberg.PartitionSpec, df iceberg.DataFile) (int64, error) {
+	_, itr, err := (&arrowScan{
+		tableProps:      v.meta.Properties(),
+		fs:              fs,
+		projectedSchema: v.schema,
+		boundRowFilter:  iceberg.AlwaysTrue{},
+		rowLimit:        ScanNoLimit,
+		concurrency:     1,
+		schemas:         v.meta.Schemas(),
+	}).GetRecords(ctx, []FileScanTask{{File: df, Length: df.FileSizeBytes()}})
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		fields    = v.schema.Fields()
+		stats     = make([]columnStats, len(fields))
+		partCheck = v.newPartitionCheck(spec, df)
+		rows      int64
+	)
+	for rec, err := range itr {
+		if err != nil {
+			return rows, err
+		}
+
+		for i := range fields {
+			stats[i].update(rec.Column(i))
+		}
+		if partCheck != nil {
+			partCheck(rec)
+		}
+		rows += rec.NumRows()
+		rec.Release()
+	}
+
+	if rows != df.Count() {
+		v.addIssue(ValidationRecordCount, df.FilePath(),
+			"record count is %d but the file holds %d rows", df.Count(), rows)
+	}
+
+	for i, f := range fields {
+		if f.Required && stats[i].nulls > 0 {
+			v.addIssue(ValidationRequiredNotNull, df.FilePath(),
+				"required column %s has %d nulls", f.Name, stats[i].nulls)
+		}
+
+		if _, ok := f.Type.(iceberg.PrimitiveType); ok {
+			v.checkMetrics(df, f, &stats[i])
+		}
+	}
+
+	return rows, nil
+}
+
+// checkMetrics checks the metrics of df recorded for the primitive field
+// f against the statistics of its rows.
+func (v *tableValidator) checkMetrics(df iceberg.DataFile, f iceberg.NestedField, s *columnStats) {
+	if n, ok := df.ValueCounts()[f.ID]; ok && n != s.values {
+		v.addIssue(ValidationMetrics, df.FilePath(),
+			"column %s has value count %d but holds %d values", f.Name, n, s.values)
+	}
+
+	if n, ok := df.NullValueCounts()[f.ID]; ok && n != s.nulls {
+		v.addIssue(ValidationMetrics, df.FilePath(),
+			"column %s has null count %d but holds %d nulls", f.Name, n, s.nulls)
+	}
+
+	if s.unsupported {
+		return
+	}
+
+	// bounds may be truncated, so only values outside of them are issues
+	if lower, ok := v.bound(f, df.LowerBoundValues()); ok && s.min != nil &&
+		comparableValues(lower, s.min) && compareGroupValues(lower, s.min) > 0 {
+		v.addIssue(ValidationMetrics, df.FilePath(),
+			"column %s has lower bound %v above its minimum %v", f.Name, lower, s.min)
+	}
+
+	if upper, ok := v.bound(f, df.UpperBoundValues()); ok && s.max != nil &&
+		comparableValues(upper, s.max) && compareGroupValues(upper, s.max) < 0 {
+		v.addIssue(ValidationMetrics, df.FilePath(),
+			"column %s has upper bound %v below its maximum %v", f.Name, upper, s.max)
+	}
+}
+
+func (v *tableValidator) bound(f iceberg.NestedField, bounds map[int][]byte) (any, bool) {
+	data, ok := bounds[f.ID]
+	if !ok {
+		return nil, false
+	}
+
+	lit, err := iceberg.LiteralFromBytes(f.Type, data)
+	if err != nil {
+		return nil, false
+	}
+
+	return lit.Any(), true
+}
+
+func comparableValues(a, b any) bool {
+	return reflect.TypeOf(a) == reflect.TypeOf(b)
+}
+
+// newPartitionCheck returns a function checking that the rows of a record
+// belong to the partition of df, or nil if the partition of df cannot be
+// checked because the spec is unknown or a source column is nested.
+func (v *tableValidator) newPartitionCheck(spec *iceberg.PartitionSpec, df iceberg.DataFile) func(arrow.RecordBatch) {
+	if spec == nil || spec.IsUnpartitioned() {
+		return nil
+	}
+
+	cols := make([]int, 0, spec.NumFields())
+	for field := range spec.Fields() {
+		idx := slices.IndexFunc(v.schema.Fields(), func(f iceberg.NestedField) bool {
+			return f.ID == field.SourceID
+		})
+		if idx < 0 {
+			return nil
+		}
+		cols = append(cols, idx)
+	}
+
+	expected := spec.PartitionToPath(getPartitionRecord(df, spec.PartitionType(v.schema)), v.schema)
+	rec := make(partitionRecord, len(cols))
+	reported := false
+
+	return func(batch arrow.RecordBatch) {
+		if reported {
+			return
+		}
+
+		for row := range int(batch.NumRows()) {
+			i := 0
+			for field := range spec.Fields() {
+				col := batch.Column(cols[i])
+				rec[i] = nil
+				if !col.IsNull(row) {
+					lit, err := getArrowValueAsIcebergLiteral(col, row)
+					if err != nil {
+						return
+					}
+					if out := field.Transform.Apply(iceberg.Optional[iceberg.Literal]{Valid: true, Val: lit}); out.Valid {
+						rec[i] = out.Val.Any()
+					}
+				}
+				i++
+			}
+
+			if path := spec.PartitionToPath(rec, v.schema); path != expected {
+				v.addIssue(ValidationPartitionValues, df.FilePath(),
+					"row %d belongs to partition %s but the file is in %s", row, path, expected)
+				reported = true
+
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func copyFile(t *testing.T, src, dst string) {
+	t.Helper()
+
+	in, err := os.Open(src)
+	require.NoError(t, err)
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	require.NoError(t, err)
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	require.NoError(t, err)
+}
+
+func TestValidateTable(t *testing.T) {
+	ctx := context.Background()
+
+	sc := iceberg.NewSchema(0,
+		iceberg.NestedField{ID: 1, Name: "id", Type: iceberg.PrimitiveTypes.Int64, Required: true},
+		iceberg.NestedField{ID: 2, Name: "category", Type: iceberg.PrimitiveTypes.String, Required: true})
+	spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+		SourceID: 2, FieldID: 1000, Name: "category", Transform: iceberg.IdentityTransform{}})
+
+	tbl := newTestTable(t, withTestSchema(sc), withTestSpec(&spec))
+
+	arrSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "category", Type: arrow.BinaryTypes.String},
+	}, nil)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{`[
+		{"id": 1, "category": "a"},
+		{"id": 2, "category": "a"},
+		{"id": 3, "category": "b"}
+	]`})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	tbl, err = tbl.AppendTable(ctx, arrTbl, 10, nil)
+	require.NoError(t, err)
+
+	report, err := tbl.ValidateTable(ctx)
+	require.NoError(t, err)
+	assert.True(t, report.Valid(), report.Issues)
+	assert.EqualValues(t, 2, report.DataFiles)
+	assert.EqualValues(t, 3, report.Records)
+
+	tasks, err := tbl.Scan(table.WithRowFilter(iceberg.EqualTo(iceberg.Reference("category"), "a"))).PlanFiles(ctx)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+
+	// register a copy of the file of partition "a" as belonging to
+	// partition "b", with a wrong record count and metrics
+	corruptPath := tbl.Location() + "/data/corrupt.parquet"
+	copyFile(t, tasks[0].File.FilePath(), corruptPath)
+
+	lower, err := iceberg.Int64Literal(100).MarshalBinary()
+	require.NoError(t, err)
+
+	bldr, err := iceberg.NewDataFileBuilder(spec, iceberg.EntryContentData, corruptPath,
+		iceberg.ParquetFile, map[int]any{1000: "b"}, nil, nil, 5, tasks[0].File.FileSizeBytes())
+	require.NoError(t, err)
+	corrupt := bldr.
+		ValueCounts(map[int]int64{1: 2, 2: 2}).
+		NullValueCounts(map[int]int64{1: 1, 2: 0}).
+		LowerBoundValues(map[int][]byte{1: lower}).
+		Build()
+
+	tx := tbl.NewTransaction()
+	require.NoError(t, tx.AddDataFiles(ctx, []iceberg.DataFile{corrupt}, nil))
+	tbl, err = tx.Commit(ctx)
+	require.NoError(t, err)
+
+	report, err = tbl.ValidateTable(ctx)
+	require.NoError(t, err)
+	assert.False(t, report.Valid())
+	assert.EqualValues(t, 3, report.DataFiles)
+	assert.EqualValues(t, 5, report.Records)
+
+	checks := make(map[table.ValidationCheck]int)
+	for _, issue := range report.Issues {
+		assert.Equal(t, corruptPath, issue.FilePath, issue.String())
+		checks[issue.Check]++
+	}
+	assert.Equal(t, map[table.ValidationCheck]int{
+		table.ValidationRecordCount:     1,
+		table.ValidationPartitionValues: 1,
+		table.ValidationMetrics:         2,
+	}, checks)
+
+	// without reading data files, only the recorded null count of the
+	// required column is found
+	report, err = tbl.ValidateTable(ctx, table.WithValidateDataFiles(false))
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, report.DataFiles)
+	assert.Zero(t, report.Records)
+	require.Len(t, report.Issues, 1)
+	assert.Equal(t, table.ValidationRequiredNotNull, report.Issues[0].Check)
+	assert.Equal(t, corruptPath, report.Issues[0].FilePath)
+}
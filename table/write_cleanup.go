@@ -0,0 +1,147 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"errors"
+	iofs "io/fs"
+	"slices"
+	"sync"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/io"
+)
+
+// writtenFiles records the paths of the files written by a transaction,
+// in the order they were created, so that they can be deleted if the
+// transaction fails. Paths are recorded before their files are created,
+// so that files left partially written are deleted as well.
+type writtenFiles struct {
+	mu    sync.Mutex
+	paths []string
+}
+
+func (w *writtenFiles) add(path string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.paths = append(w.paths, path)
+}
+
+// mark returns the number of paths recorded so far, to pass to remove.
+func (w *writtenFiles) mark() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return len(w.paths)
+}
+
+// remove deletes the files recorded since mark and stops tracking them.
+// Files that were never created are ignored; files that cannot be
+// deleted are reported as a *iceberg.CleanupError.
+func (w *writtenFiles) remove(fs io.IO, mark int) error {
+	w.mu.Lock()
+	paths := slices.Clone(w.paths[mark:])
+	w.paths = w.paths[:mark]
+	w.mu.Unlock()
+
+	var (
+		failed []string
+		res    error
+	)
+	for _, path := range paths {
+		if err := fs.Remove(path); err != nil && !errors.Is(err, iofs.ErrNotExist) {
+			failed = append(failed, path)
+			res = errors.Join(res, err)
+		}
+	}
+
+	if len(failed) > 0 {
+		return &iceberg.CleanupError{Files: failed, Err: res}
+	}
+
+	return nil
+}
+
+type writtenFilesKey struct{}
+
+// withWrittenFiles returns a context recording the data files written
+// with it in w.
+func withWrittenFiles(ctx context.Context, w *writtenFiles) context.Context {
+	return context.WithValue(ctx, writtenFilesKey{}, w)
+}
+
+// trackWrittenFile records path in the writtenFiles of ctx, if any.
+func trackWrittenFile(ctx context.Context, path string) {
+	if w, ok := ctx.Value(writtenFilesKey{}).(*writtenFiles); ok {
+		w.add(path)
+	}
+}
+
+// commitRejected reports whether a commit that failed with err is known
+// not to have been applied, so that the files written for it can be
+// deleted. That is only the case for commit conflicts, which include
+// failed requirements; any other error, such as a timeout, a canceled
+// context or a transport failure, may hide a commit that succeeded and
+// the files must be kept.
+func commitRejected(err error) bool {
+	return errors.Is(err, iceberg.ErrCommitConflict)
+}
+
+// cleanupOnError deletes the files the transaction wrote since mark if
+// the operation failed with *err, including failures due to the
+// cancellation of ctx, so that failed writes do not leave orphaned files
+// behind. Cleanup is best effort: files that cannot be deleted are
+// reported by joining a *iceberg.CleanupError to *err.
+func (t *Transaction) cleanupOnError(ctx context.Context, mark int, err *error) {
+	if *err == nil {
+		return
+	}
+
+	fs, fsErr := t.tbl.FS(context.WithoutCancel(ctx))
+	if fsErr != nil {
+		*err = errors.Join(*err, fsErr)
+
+		return
+	}
+
+	if cleanupErr := t.written.remove(fs, mark); cleanupErr != nil {
+		*err = errors.Join(*err, cleanupErr)
+	}
+}
+
+// Abort discards the transaction, deleting the data, manifest and
+// manifest list files written by its operations. The transaction cannot
+// be committed afterwards. Cleanup is best effort: files that cannot be
+// deleted are reported in a *iceberg.CleanupError.
+func (t *Transaction) Abort(ctx context.Context) error {
+	t.mx.Lock()
+	defer t.mx.Unlock()
+
+	if t.committed {
+		return errors.New("transaction has already been committed")
+	}
+	t.committed = true
+
+	fs, err := t.fs(ctx)
+	if err != nil {
+		return err
+	}
+
+	return t.written.remove(fs, 0)
+}
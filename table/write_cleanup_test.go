@@ -0,0 +1,153 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// undeletableFS fails to remove any file.
+type undeletableFS struct {
+	iceio.LocalFS
+}
+
+func (undeletableFS) Remove(string) error { return fs.ErrPermission }
+
+// cancelingReader cancels its context once its first record was read.
+type cancelingReader struct {
+	array.RecordReader
+	cancel context.CancelFunc
+	read   bool
+}
+
+func (r *cancelingReader) Next() bool {
+	if r.read {
+		r.cancel()
+	}
+	r.read = true
+
+	return r.RecordReader.Next()
+}
+
+func cleanupTestRecords(t *testing.T) array.RecordReader {
+	t.Helper()
+
+	arrSchema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema,
+		[]string{`[{"id": 1}, {"id": 2}, {"id": 3}, {"id": 4}]`})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	rdr := array.NewTableReader(arrTbl, 1)
+	t.Cleanup(rdr.Release)
+
+	return rdr
+}
+
+// filesUnder returns the paths of the files under dir.
+func filesUnder(t *testing.T, dir string) []string {
+	t.Helper()
+
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			files = append(files, path)
+		}
+
+		return err
+	})
+	require.NoError(t, err)
+
+	return files
+}
+
+func TestFailedCommitDeletesWrittenFiles(t *testing.T) {
+	ctx := context.Background()
+
+	tbl := newTestTable(t, withTestCatalog(&failingCatalog{err: iceberg.ErrCommitConflict}))
+	_, err := tbl.Append(ctx, cleanupTestRecords(t), nil)
+	require.ErrorIs(t, err, iceberg.ErrCommitConflict)
+	assert.Empty(t, filesUnder(t, tbl.Location()))
+
+	// the commit may have been applied, so its files must be kept
+	for _, commitErr := range []error{
+		iceberg.ErrCommitStateUnknown,
+		context.DeadlineExceeded,
+		errors.New("connection reset by peer"),
+	} {
+		tbl = newTestTable(t, withTestCatalog(&failingCatalog{err: commitErr}))
+		_, err = tbl.Append(ctx, cleanupTestRecords(t), nil)
+		require.ErrorIs(t, err, commitErr)
+		assert.NotEmpty(t, filesUnder(t, tbl.Location()), commitErr.Error())
+	}
+}
+
+func TestCanceledWriteDeletesWrittenFiles(t *testing.T) {
+	tbl := newTestTable(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tx := tbl.NewTransaction()
+	err := tx.Append(ctx, &cancelingReader{RecordReader: cleanupTestRecords(t), cancel: cancel}, nil,
+		table.WithTargetFileSize(1))
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, filesUnder(t, tbl.Location()))
+}
+
+func TestTransactionAbort(t *testing.T) {
+	ctx := context.Background()
+	tbl := newTestTable(t)
+
+	tx := tbl.NewTransaction()
+	require.NoError(t, tx.Append(ctx, cleanupTestRecords(t), nil))
+	require.NotEmpty(t, filesUnder(t, tbl.Location()))
+
+	require.NoError(t, tx.Abort(ctx))
+	assert.Empty(t, filesUnder(t, tbl.Location()))
+
+	_, err := tx.Commit(ctx)
+	require.Error(t, err)
+	require.Error(t, tx.Abort(ctx))
+}
+
+func TestFailedCleanupIsReported(t *testing.T) {
+	ctx := context.Background()
+
+	tbl := newTestTable(t, withTestCatalog(&failingCatalog{err: iceberg.ErrCommitConflict}), withTestIO(undeletableFS{}))
+	_, err := tbl.Append(ctx, cleanupTestRecords(t), nil)
+	require.ErrorIs(t, err, iceberg.ErrCommitConflict)
+	require.ErrorIs(t, err, iceberg.ErrCleanupFailed)
+
+	var cleanupErr *iceberg.CleanupError
+	require.True(t, errors.As(err, &cleanupErr))
+	assert.ElementsMatch(t, filesUnder(t, tbl.Location()), cleanupErr.Files)
+}
@@ -21,10 +21,13 @@ import (
 	"context"
 	"fmt"
 	"iter"
+	"maps"
+	"strconv"
 
 	"github.com/apache/arrow-go/v18/arrow"
 	"github.com/apache/iceberg-go"
 	"github.com/apache/iceberg-go/config"
+	"github.com/apache/iceberg-go/encryption"
 	"github.com/apache/iceberg-go/io"
 	"github.com/apache/iceberg-go/table/internal"
 	"github.com/google/uuid"
@@ -47,16 +50,99 @@ func (w WriteTask) GenerateDataFileName(extension string) string {
 	return fmt.Sprintf("%05d-%d-%s-%05d.%s", w.PartitionID, w.ID, w.Uuid, w.FileCount, extension)
 }
 
+// WriteOption overrides, for a single write, the table properties that
+// control how data files are laid out.
+type WriteOption func(*writeConfig)
+
+type writeConfig struct {
+	props iceberg.Properties
+}
+
+func (c *writeConfig) set(key string, value int64) {
+	c.setString(key, strconv.FormatInt(value, 10))
+}
+
+func (c *writeConfig) setString(key, value string) {
+	if c.props == nil {
+		c.props = make(iceberg.Properties)
+	}
+	c.props[key] = value
+}
+
+// WithTargetFileSize sets the size in bytes at which data files are
+// rolled over.
+// Default: the write.target-file-size-bytes table property
+func WithTargetFileSize(size int64) WriteOption {
+	return func(c *writeConfig) { c.set(WriteTargetFileSizeBytesKey, size) }
+}
+
+// WithRowGroupSize sets the size in bytes of the parquet row groups.
+// Default: the write.parquet.row-group-size-bytes table property
+func WithRowGroupSize(size int64) WriteOption {
+	return func(c *writeConfig) { c.set(ParquetRowGroupSizeBytesKey, size) }
+}
+
+// WithPageSize sets the size in bytes of the parquet data pages.
+// Default: the write.parquet.page-size-bytes table property
+func WithPageSize(size int64) WriteOption {
+	return func(c *writeConfig) { c.set(ParquetPageSizeBytesKey, size) }
+}
+
+// WithDictionarySize sets the size in bytes of a parquet dictionary page,
+// beyond which a column falls back to plain encoding.
+// Default: the write.parquet.dict-size-bytes table property
+func WithDictionarySize(size int64) WriteOption {
+	return func(c *writeConfig) { c.set(ParquetDictSizeBytesKey, size) }
+}
+
+// WithDistributionMode sets how rows are distributed between the
+// writers of a write.
+// Default: the write.distribution-mode table property
+func WithDistributionMode(mode DistributionMode) WriteOption {
+	return func(c *writeConfig) { c.setString(WriteDistributionModeKey, string(mode)) }
+}
+
+// writeProperties returns the table properties with the overrides of
+// the given options applied.
+func writeProperties(props iceberg.Properties, opts []WriteOption) iceberg.Properties {
+	var cfg writeConfig
+	for _, apply := range opts {
+		apply(&cfg)
+	}
+	if len(cfg.props) == 0 {
+		return props
+	}
+
+	merged := maps.Clone(props)
+	if merged == nil {
+		merged = make(iceberg.Properties, len(cfg.props))
+	}
+	maps.Copy(merged, cfg.props)
+
+	return merged
+}
+
 type writer struct {
 	loc        LocationProvider
 	fs         io.WriteFileIO
 	fileSchema *iceberg.Schema
+	// fieldNames are the original names of the sanitized fields of
+	// fileSchema, written to the data files as field metadata.
+	fieldNames map[int]string
 	format     internal.FileFormat
 	props      any
-	meta       *MetadataBuilder
+	tblProps   iceberg.Properties
+	// rowGroupSize is the size in bytes at which a new row group is started
+	rowGroupSize int64
+	encryption   *encryption.StandardEncryptionManager
 }
 
-func (w *writer) writeFile(ctx context.Context, partitionValues map[int]any, task WriteTask) (iceberg.DataFile, error) {
+func (w *writer) writeFile(ctx context.Context, partitionPath string, partition iceberg.PartitionData, task WriteTask) (iceberg.DataFile, error) {
+	if budget := budgetFromContext(ctx); budget != nil {
+		budget.active.Add(1)
+		defer budget.active.Add(-1)
+	}
+
 	defer func() {
 		for _, b := range task.Batches {
 			b.Release()
@@ -65,8 +151,8 @@ func (w *writer) writeFile(ctx context.Context, partitionValues map[int]any, tas
 
 	batches := make([]arrow.RecordBatch, len(task.Batches))
 	for i, b := range task.Batches {
-		rec, err := ToRequestedSchema(ctx, w.fileSchema,
-			task.Schema, b, false, true, false)
+		rec, err := toRequestedSchema(ctx, w.fileSchema, task.Schema, b,
+			&arrowProjectionVisitor{includeFieldIDs: true, fieldNames: w.fieldNames})
 		if err != nil {
 			return nil, err
 		}
@@ -74,30 +160,34 @@ func (w *writer) writeFile(ctx context.Context, partitionValues map[int]any, tas
 		defer rec.Release()
 	}
 
-	statsCols, err := computeStatsPlan(w.fileSchema, w.meta.props)
+	statsCols, err := computeStatsPlan(w.fileSchema, w.tblProps)
 	if err != nil {
 		return nil, err
 	}
 
-	filePath := w.loc.NewDataLocation(
-		task.GenerateDataFileName("parquet"))
-
-	currentSpec, err := w.meta.CurrentSpec()
-	if err != nil {
-		return nil, err
+	fileName := task.GenerateDataFileName("parquet")
+	if partitionPath != "" {
+		fileName = partitionPath + "/" + fileName
 	}
-
-	return w.format.WriteDataFile(ctx, w.fs, partitionValues, internal.WriteFileInfo{
-		FileSchema: w.fileSchema,
-		FileName:   filePath,
-		StatsCols:  statsCols,
-		WriteProps: w.props,
-		Spec:       *currentSpec,
+	filePath := w.loc.NewDataLocation(fileName)
+	trackWrittenFile(ctx, filePath)
+
+	return w.format.WriteDataFile(ctx, w.fs, partition, internal.WriteFileInfo{
+		FileSchema:        w.fileSchema,
+		FileName:          filePath,
+		StatsCols:         statsCols,
+		WriteProps:        w.props,
+		Encryption:        w.encryption,
+		RowGroupSizeBytes: w.rowGroupSize,
 	}, batches)
 }
 
-func writeFiles(ctx context.Context, rootLocation string, fs io.WriteFileIO, meta *MetadataBuilder, partitionValues map[int]any, tasks iter.Seq[WriteTask]) iter.Seq2[iceberg.DataFile, error] {
-	locProvider, err := LoadLocationProvider(rootLocation, meta.props)
+func writeFiles(ctx context.Context, rootLocation string, fs io.WriteFileIO, meta *MetadataBuilder, props iceberg.Properties, partitionPath string, partition iceberg.PartitionData, tasks iter.Seq[WriteTask]) iter.Seq2[iceberg.DataFile, error] {
+	if props == nil {
+		props = meta.props
+	}
+
+	locProvider, err := LoadLocationProvider(rootLocation, props)
 	if err != nil {
 		return func(yield func(iceberg.DataFile, error) bool) {
 			yield(nil, err)
@@ -116,22 +206,43 @@ func writeFiles(ctx context.Context, rootLocation string, fs io.WriteFileIO, met
 	// if the schema needs to be transformed, use the transformed schema
 	// and adjust the arrow schema appropriately. otherwise we just
 	// use the original schema.
+	var fieldNames map[int]string
 	if !sanitized.Equals(fileSchema) {
+		if fieldNames, err = iceberg.SanitizedFieldNames(fileSchema); err != nil {
+			return func(yield func(iceberg.DataFile, error) bool) {
+				yield(nil, err)
+			}
+		}
 		fileSchema = sanitized
 	}
 
+	encMgr, err := encryption.LoadManager(ctx, props)
+	if err != nil {
+		return func(yield func(iceberg.DataFile, error) bool) {
+			yield(nil, err)
+		}
+	}
+
 	w := &writer{
+		encryption: encMgr,
 		loc:        locProvider,
 		fs:         fs,
 		fileSchema: fileSchema,
+		fieldNames: fieldNames,
 		format:     format,
-		props:      format.GetWriteProperties(meta.props),
-		meta:       meta,
+		props:      format.GetWriteProperties(props),
+		tblProps:   props,
+		rowGroupSize: int64(props.GetInt(ParquetRowGroupSizeBytesKey,
+			ParquetRowGroupSizeBytesDefault)),
+	}
+
+	if w.rowGroupSize <= 0 {
+		w.rowGroupSize = ParquetRowGroupSizeBytesDefault
 	}
 
 	nworkers := config.EnvConfig.MaxWorkers
 
 	return internal.MapExec(nworkers, tasks, func(t WriteTask) (iceberg.DataFile, error) {
-		return w.writeFile(ctx, partitionValues, t)
+		return w.writeFile(ctx, partitionPath, partition, t)
 	})
 }
@@ -0,0 +1,186 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table/internal"
+)
+
+// MetricsMode is a mode of the column metrics kept for data files, set
+// by the DefaultWriteMetricsModeKey and MetricsModeColumnConfPrefix
+// properties.
+type MetricsMode = internal.MetricsMode
+
+// MetricModeType is the kind of a MetricsMode.
+type MetricModeType = internal.MetricModeType
+
+const (
+	MetricModeTruncate = internal.MetricModeTruncate
+	MetricModeNone     = internal.MetricModeNone
+	MetricModeCounts   = internal.MetricModeCounts
+	MetricModeFull     = internal.MetricModeFull
+)
+
+// ParseMetricsMode parses a metrics mode property value: none, counts,
+// full or truncate(N).
+func ParseMetricsMode(mode string) (MetricsMode, error) {
+	m, err := internal.MatchMetricsMode(mode)
+	if err != nil {
+		return MetricsMode{}, fmt.Errorf("%w: %w", iceberg.ErrInvalidArgument, err)
+	}
+
+	return m, nil
+}
+
+// WriterConfig holds the settings for writing data files to a table,
+// resolved from its properties. It lets writers outside of this package
+// honor the settings of the table's owner.
+type WriterConfig struct {
+	// FileFormat is the format of data files, see WriteFormatDefaultKey.
+	FileFormat iceberg.FileFormat
+	// TargetFileSizeBytes is the size at which data files are rolled
+	// over, and DeleteTargetFileSizeBytes that of delete files.
+	TargetFileSizeBytes       int64
+	DeleteTargetFileSizeBytes int64
+	// Compression and CompressionLevel are the codec and level of the
+	// FileFormat, e.g. from ParquetCompressionKey for parquet files. A
+	// level of -1 is the codec's default.
+	Compression      string
+	CompressionLevel int
+	// RowGroupSizeBytes, PageSizeBytes and DictSizeBytes are the sizes of
+	// the row groups, data pages and dictionary pages of parquet files.
+	RowGroupSizeBytes int64
+	PageSizeBytes     int64
+	DictSizeBytes     int64
+	// DistributionMode is how rows are distributed between writers.
+	DistributionMode DistributionMode
+	// MetricsMode is the default mode of column metrics, and
+	// ColumnMetricsModes the modes set for columns by full name.
+	MetricsMode        MetricsMode
+	ColumnMetricsModes map[string]MetricsMode
+}
+
+// ColumnMetricsMode returns the metrics mode of the column with the given
+// full name.
+func (c WriterConfig) ColumnMetricsMode(name string) MetricsMode {
+	if m, ok := c.ColumnMetricsModes[name]; ok {
+		return m
+	}
+
+	return c.MetricsMode
+}
+
+var (
+	parquetCodecs = []string{"snappy", "zstd", "uncompressed", "gzip", "brotli", "lz4", "lz4raw", "lzo"}
+	avroCodecs    = []string{iceberg.AvroCodecGzip, iceberg.AvroCodecZstd, iceberg.AvroCodecSnappy,
+		iceberg.AvroCodecUncompressed}
+)
+
+// NewWriterConfig resolves the WriterConfig of a table with the given
+// properties and partition spec, with the overrides of the options
+// applied. It returns an error wrapping iceberg.ErrInvalidArgument if a
+// property has an invalid value.
+func NewWriterConfig(props iceberg.Properties, spec iceberg.PartitionSpec, opts ...WriteOption) (WriterConfig, error) {
+	props = writeProperties(props, opts)
+
+	cfg := WriterConfig{
+		TargetFileSizeBytes: int64(props.GetInt(WriteTargetFileSizeBytesKey,
+			WriteTargetFileSizeBytesDefault)),
+		DeleteTargetFileSizeBytes: int64(props.GetInt(WriteDeleteTargetFileSizeBytesKey,
+			WriteDeleteTargetFileSizeBytesDefault)),
+		RowGroupSizeBytes: int64(props.GetInt(ParquetRowGroupSizeBytesKey,
+			ParquetRowGroupSizeBytesDefault)),
+		PageSizeBytes: int64(props.GetInt(ParquetPageSizeBytesKey, ParquetPageSizeBytesDefault)),
+		DictSizeBytes: int64(props.GetInt(ParquetDictSizeBytesKey, ParquetDictSizeBytesDefault)),
+	}
+
+	var codecs []string
+	switch format := strings.ToLower(props.Get(WriteFormatDefaultKey, WriteFormatDefaultDefault)); format {
+	case "parquet":
+		cfg.FileFormat, codecs = iceberg.ParquetFile, parquetCodecs
+		cfg.Compression = strings.ToLower(props.Get(ParquetCompressionKey, ParquetCompressionDefault))
+		cfg.CompressionLevel = props.GetInt(ParquetCompressionLevelKey, ParquetCompressionLevelDefault)
+	case "avro":
+		cfg.FileFormat, codecs = iceberg.AvroFile, avroCodecs
+		cfg.Compression = strings.ToLower(props.Get(AvroCompressionKey, AvroCompressionDefault))
+		cfg.CompressionLevel = props.GetInt(AvroCompressionLevelKey, AvroCompressionLevelDefault)
+	case "orc":
+		cfg.FileFormat = iceberg.OrcFile
+	default:
+		return WriterConfig{}, fmt.Errorf("%w: invalid %s %q, must be parquet, avro or orc",
+			iceberg.ErrInvalidArgument, WriteFormatDefaultKey, format)
+	}
+
+	if codecs != nil && !slices.Contains(codecs, cfg.Compression) {
+		return WriterConfig{}, fmt.Errorf("%w: unsupported %s compression codec %q",
+			iceberg.ErrInvalidArgument, cfg.FileFormat, cfg.Compression)
+	}
+
+	for key, size := range map[string]int64{
+		WriteTargetFileSizeBytesKey:       cfg.TargetFileSizeBytes,
+		WriteDeleteTargetFileSizeBytesKey: cfg.DeleteTargetFileSizeBytes,
+		ParquetRowGroupSizeBytesKey:       cfg.RowGroupSizeBytes,
+		ParquetPageSizeBytesKey:           cfg.PageSizeBytes,
+		ParquetDictSizeBytesKey:           cfg.DictSizeBytes,
+	} {
+		if size <= 0 {
+			return WriterConfig{}, fmt.Errorf("%w: %s must be positive, got %d",
+				iceberg.ErrInvalidArgument, key, size)
+		}
+	}
+
+	var err error
+	if cfg.DistributionMode, err = writeDistributionMode(props, spec); err != nil {
+		return WriterConfig{}, err
+	}
+
+	if cfg.MetricsMode, err = ParseMetricsMode(props.Get(DefaultWriteMetricsModeKey,
+		DefaultWriteMetricsModeDefault)); err != nil {
+		return WriterConfig{}, err
+	}
+
+	prefix := MetricsModeColumnConfPrefix + "."
+	for key, val := range props {
+		name, ok := strings.CutPrefix(key, prefix)
+		if !ok {
+			continue
+		}
+
+		mode, err := ParseMetricsMode(val)
+		if err != nil {
+			return WriterConfig{}, fmt.Errorf("column %s: %w", name, err)
+		}
+		if cfg.ColumnMetricsModes == nil {
+			cfg.ColumnMetricsModes = make(map[string]MetricsMode)
+		}
+		cfg.ColumnMetricsModes[name] = mode
+	}
+
+	return cfg, nil
+}
+
+// WriterConfig returns the settings for writing data files to the table,
+// resolved from its properties with the overrides of the options applied.
+func (t Table) WriterConfig(opts ...WriteOption) (WriterConfig, error) {
+	return NewWriterConfig(t.Properties(), t.Spec(), opts...)
+}
@@ -0,0 +1,102 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"testing"
+
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWriterConfigDefaults(t *testing.T) {
+	cfg, err := table.NewWriterConfig(nil, *iceberg.UnpartitionedSpec)
+	require.NoError(t, err)
+
+	assert.Equal(t, table.WriterConfig{
+		FileFormat:                iceberg.ParquetFile,
+		TargetFileSizeBytes:       table.WriteTargetFileSizeBytesDefault,
+		DeleteTargetFileSizeBytes: table.WriteDeleteTargetFileSizeBytesDefault,
+		Compression:               table.ParquetCompressionDefault,
+		CompressionLevel:          table.ParquetCompressionLevelDefault,
+		RowGroupSizeBytes:         table.ParquetRowGroupSizeBytesDefault,
+		PageSizeBytes:             table.ParquetPageSizeBytesDefault,
+		DictSizeBytes:             table.ParquetDictSizeBytesDefault,
+		DistributionMode:          table.DistributionNone,
+		MetricsMode:               table.MetricsMode{Typ: table.MetricModeTruncate, Len: 16},
+	}, cfg)
+
+	spec := iceberg.NewPartitionSpec(iceberg.PartitionField{
+		SourceID: 1, FieldID: 1000, Name: "id_bucket", Transform: iceberg.BucketTransform{NumBuckets: 4},
+	})
+	cfg, err = table.NewWriterConfig(nil, spec)
+	require.NoError(t, err)
+	assert.Equal(t, table.DistributionHash, cfg.DistributionMode)
+}
+
+func TestNewWriterConfigProperties(t *testing.T) {
+	props := iceberg.Properties{
+		table.WriteFormatDefaultKey:                     "AVRO",
+		table.AvroCompressionKey:                        "zstd",
+		table.AvroCompressionLevelKey:                   "3",
+		table.WriteTargetFileSizeBytesKey:               "1048576",
+		table.WriteDistributionModeKey:                  "range",
+		table.DefaultWriteMetricsModeKey:                "counts",
+		table.MetricsModeColumnConfPrefix + ".email":    "none",
+		table.MetricsModeColumnConfPrefix + ".addr.zip": "full",
+	}
+
+	cfg, err := table.NewWriterConfig(props, *iceberg.UnpartitionedSpec,
+		table.WithTargetFileSize(2048))
+	require.NoError(t, err)
+
+	assert.Equal(t, iceberg.AvroFile, cfg.FileFormat)
+	assert.Equal(t, "zstd", cfg.Compression)
+	assert.Equal(t, 3, cfg.CompressionLevel)
+	assert.EqualValues(t, 2048, cfg.TargetFileSizeBytes)
+	assert.Equal(t, table.DistributionRange, cfg.DistributionMode)
+	assert.Equal(t, table.MetricsMode{Typ: table.MetricModeCounts}, cfg.MetricsMode)
+	assert.Equal(t, table.MetricsMode{Typ: table.MetricModeNone}, cfg.ColumnMetricsMode("email"))
+	assert.Equal(t, table.MetricsMode{Typ: table.MetricModeFull}, cfg.ColumnMetricsMode("addr.zip"))
+	assert.Equal(t, cfg.MetricsMode, cfg.ColumnMetricsMode("id"))
+}
+
+func TestNewWriterConfigInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		props iceberg.Properties
+		msg   string
+	}{
+		{"format", iceberg.Properties{table.WriteFormatDefaultKey: "csv"}, `invalid write.format.default "csv"`},
+		{"codec", iceberg.Properties{table.ParquetCompressionKey: "rar"}, `unsupported PARQUET compression codec "rar"`},
+		{"size", iceberg.Properties{table.WriteTargetFileSizeBytesKey: "0"}, "write.target-file-size-bytes must be positive"},
+		{"distribution", iceberg.Properties{table.WriteDistributionModeKey: "random"}, "random"},
+		{"metrics", iceberg.Properties{table.DefaultWriteMetricsModeKey: "truncate(x)"}, "malformed truncate metrics mode"},
+		{"column metrics", iceberg.Properties{table.MetricsModeColumnConfPrefix + ".id": "some"}, "column id: invalid argument: unsupported metrics mode: some"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := table.NewWriterConfig(tt.props, *iceberg.UnpartitionedSpec)
+			assert.ErrorIs(t, err, iceberg.ErrInvalidArgument)
+			assert.ErrorContains(t, err, tt.msg)
+		})
+	}
+}
@@ -0,0 +1,301 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/iceberg-go"
+	"github.com/apache/iceberg-go/io"
+	"github.com/google/uuid"
+)
+
+// ErrWriterPoolClosed is returned when using a WriterPool, or one of its
+// writers, after it was committed or aborted.
+var ErrWriterPoolClosed = errors.New("writer pool is closed")
+
+// WriterPool lets several goroutines write data files for a table
+// independently, each with its own PoolWriter, and then commits all of
+// the files in a single append snapshot. The files are committed in the
+// order their writers were created, and in the order each writer wrote
+// them, regardless of when the writers finished.
+//
+// Until the pool is committed the files are not part of the table. Abort
+// deletes them, as does a failed Commit, so that a failed write does not
+// leave orphaned files behind.
+//
+// A WriterPool is safe for concurrent use, its writers are not.
+type WriterPool struct {
+	tbl       *Table
+	fs        io.WriteFileIO
+	meta      *MetadataBuilder
+	props     iceberg.Properties
+	writeUUID uuid.UUID
+	// nextTaskID numbers the files written by all writers of the pool,
+	// which share writeUUID, so that their names are unique
+	nextTaskID atomic.Int64
+
+	mu      sync.Mutex
+	writers []*PoolWriter
+	closed  bool
+}
+
+// NewWriterPool creates a pool writing data files for the table as of
+// the current metadata of tbl. The layout of the files follows the table
+// properties unless overridden by the given options.
+func NewWriterPool(ctx context.Context, tbl *Table, opts ...WriteOption) (*WriterPool, error) {
+	pinned := tbl.pin()
+	tbl = &pinned
+
+	fs, err := tbl.FS(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wfs, ok := fs.(io.WriteFileIO)
+	if !ok {
+		return nil, fmt.Errorf("%w: filesystem does not support writing", iceberg.ErrNotImplemented)
+	}
+
+	meta, err := MetadataBuilderFromBase(tbl.Metadata(), tbl.MetadataLocation())
+	if err != nil {
+		return nil, err
+	}
+
+	return &WriterPool{
+		tbl:       tbl,
+		fs:        wfs,
+		meta:      meta,
+		props:     writeProperties(tbl.Metadata().Properties(), opts),
+		writeUUID: uuid.New(),
+	}, nil
+}
+
+// NewWriter returns a writer of the pool for use by a single goroutine.
+func (p *WriterPool) NewWriter() (*PoolWriter, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, ErrWriterPoolClosed
+	}
+
+	w := &PoolWriter{pool: p}
+	p.writers = append(p.writers, w)
+
+	return w, nil
+}
+
+// DataFiles returns the files written so far by the writers of the pool,
+// in commit order.
+func (p *WriterPool) DataFiles() []iceberg.DataFile {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.dataFiles()
+}
+
+func (p *WriterPool) dataFiles() []iceberg.DataFile {
+	var files []iceberg.DataFile
+	for _, w := range p.writers {
+		files = append(files, w.files...)
+	}
+
+	return files
+}
+
+// close marks the pool closed and returns its files, or an error if it
+// was already closed or, when requireClosed is set, if any of its writers
+// is still open.
+func (p *WriterPool) close(requireClosed bool) ([]iceberg.DataFile, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, ErrWriterPoolClosed
+	}
+	if requireClosed {
+		if i := slices.IndexFunc(p.writers, func(w *PoolWriter) bool { return !w.closed }); i >= 0 {
+			return nil, fmt.Errorf("%w: writer %d of the pool is still open", iceberg.ErrInvalidArgument, i)
+		}
+	}
+	p.closed = true
+
+	return p.dataFiles(), nil
+}
+
+// Commit appends the files of every writer to the table in a single
+// snapshot and returns the updated table. All writers must be closed.
+//
+// If the commit is rejected with iceberg.ErrCommitConflict the written
+// files are deleted. On any other commit error they are kept, as the
+// commit may have been applied and the files may be part of the table.
+func (p *WriterPool) Commit(ctx context.Context, snapshotProps iceberg.Properties) (*Table, error) {
+	files, err := p.close(true)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := p.tbl.NewTransaction()
+	appendFiles := tx.appendSnapshotProducer(p.fs, snapshotProps)
+	for _, df := range files {
+		appendFiles.appendDataFile(df)
+	}
+
+	updates, reqs, err := appendFiles.commit()
+	if err == nil {
+		err = tx.apply(updates, reqs)
+	}
+
+	var tbl *Table
+	if err != nil {
+		// nothing was sent to the catalog
+		tx.cleanupOnError(ctx, 0, &err)
+		err = errors.Join(err, p.deleteFiles(files))
+
+		return nil, err
+	}
+
+	if tbl, err = tx.Commit(ctx); err != nil && commitRejected(err) {
+		err = errors.Join(err, p.deleteFiles(files))
+	}
+
+	return tbl, err
+}
+
+// Abort deletes the files written by the writers of the pool without
+// committing them. The pool cannot be used afterwards.
+func (p *WriterPool) Abort() error {
+	files, err := p.close(false)
+	if err != nil {
+		return err
+	}
+
+	return p.deleteFiles(files)
+}
+
+func (p *WriterPool) deleteFiles(files []iceberg.DataFile) error {
+	var err error
+	for _, df := range files {
+		if removeErr := p.fs.Remove(df.FilePath()); removeErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to delete %s: %w", df.FilePath(), removeErr))
+		}
+	}
+
+	return err
+}
+
+// taskIDs returns the sequence of task IDs of a write, drawn from the
+// counter shared by all writers of the pool.
+func (p *WriterPool) taskIDs(yield func(int) bool) {
+	for {
+		if !yield(int(p.nextTaskID.Add(1) - 1)) {
+			return
+		}
+	}
+}
+
+// PoolWriter writes data files for a WriterPool. Each writer is meant to
+// be used by a single goroutine, and must be closed before the pool is
+// committed.
+type PoolWriter struct {
+	pool   *WriterPool
+	files  []iceberg.DataFile
+	closed bool
+}
+
+// Write writes the records of rdr to new data files. Files written before
+// an error are kept by the pool, so that they are deleted on Abort.
+func (w *PoolWriter) Write(ctx context.Context, rdr array.RecordReader) error {
+	if err := w.checkOpen(); err != nil {
+		return err
+	}
+
+	itr := recordsToDataFiles(ctx, w.pool.tbl.Location(), w.pool.meta, recordWritingArgs{
+		sc:        rdr.Schema(),
+		itr:       array.IterFromReader(rdr),
+		fs:        w.pool.fs,
+		writeUUID: &w.pool.writeUUID,
+		counter:   w.pool.taskIDs,
+		props:     w.pool.props,
+	})
+
+	for df, err := range itr {
+		if err != nil {
+			return err
+		}
+
+		w.pool.mu.Lock()
+		closed := w.pool.closed
+		if !closed {
+			w.files = append(w.files, df)
+		}
+		w.pool.mu.Unlock()
+
+		// the pool was aborted while writing, so no one else will
+		// delete the file
+		if closed {
+			return errors.Join(ErrWriterPoolClosed, w.pool.deleteFiles([]iceberg.DataFile{df}))
+		}
+	}
+
+	return nil
+}
+
+// WriteTable writes the rows of tbl to new data files, reading it in
+// batches of batchSize rows.
+func (w *PoolWriter) WriteTable(ctx context.Context, tbl arrow.Table, batchSize int64) error {
+	rdr := array.NewTableReader(tbl, batchSize)
+	defer rdr.Release()
+
+	return w.Write(ctx, rdr)
+}
+
+// Close marks the writer as done. Its files are committed with the pool.
+func (w *PoolWriter) Close() error {
+	w.pool.mu.Lock()
+	defer w.pool.mu.Unlock()
+
+	if w.pool.closed {
+		return ErrWriterPoolClosed
+	}
+	w.closed = true
+
+	return nil
+}
+
+func (w *PoolWriter) checkOpen() error {
+	w.pool.mu.Lock()
+	defer w.pool.mu.Unlock()
+
+	switch {
+	case w.pool.closed:
+		return ErrWriterPoolClosed
+	case w.closed:
+		return fmt.Errorf("%w: writer is closed", iceberg.ErrInvalidArgument)
+	}
+
+	return nil
+}
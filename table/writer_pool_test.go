@@ -0,0 +1,194 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package table_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/iceberg-go"
+	iceio "github.com/apache/iceberg-go/io"
+	"github.com/apache/iceberg-go/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingCatalog fails every commit with err.
+type failingCatalog struct {
+	err error
+}
+
+func (c *failingCatalog) LoadTable(context.Context, table.Identifier) (*table.Table, error) {
+	return nil, nil
+}
+
+func (c *failingCatalog) CommitTable(context.Context, table.Identifier, []table.Requirement, []table.Update) (table.Metadata, string, error) {
+	return nil, "", c.err
+}
+
+func writeIDs(t *testing.T, w *table.PoolWriter, ids ...int) error {
+	t.Helper()
+
+	arrSchema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	rows := "["
+	for i, id := range ids {
+		if i > 0 {
+			rows += ","
+		}
+		rows += fmt.Sprintf(`{"id": %d}`, id)
+	}
+
+	arrTbl, err := array.TableFromJSON(memory.DefaultAllocator, arrSchema, []string{rows + "]"})
+	require.NoError(t, err)
+	defer arrTbl.Release()
+
+	return w.WriteTable(context.Background(), arrTbl, 10)
+}
+
+func TestWriterPoolCommit(t *testing.T) {
+	ctx := context.Background()
+	tbl := newTestTable(t)
+
+	pool, err := table.NewWriterPool(ctx, tbl)
+	require.NoError(t, err)
+
+	const numWriters = 4
+	writers := make([]*table.PoolWriter, numWriters)
+	for i := range writers {
+		writers[i], err = pool.NewWriter()
+		require.NoError(t, err)
+	}
+
+	// writers finish in reverse order of creation
+	var wg sync.WaitGroup
+	done := make([]chan struct{}, numWriters+1)
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	close(done[numWriters])
+	for i, w := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done[i])
+
+			<-done[i+1]
+			assert.NoError(t, writeIDs(t, w, 2*i, 2*i+1))
+			assert.NoError(t, w.Close())
+		}()
+	}
+	wg.Wait()
+
+	open, err := pool.NewWriter()
+	require.NoError(t, err)
+	_, err = pool.Commit(ctx, nil)
+	require.ErrorIs(t, err, iceberg.ErrInvalidArgument, "a writer is still open")
+	require.NoError(t, open.Close())
+
+	files := pool.DataFiles()
+	require.Len(t, files, numWriters)
+
+	tbl, err = pool.Commit(ctx, iceberg.Properties{"writer": "pool"})
+	require.NoError(t, err)
+	require.Len(t, tbl.Metadata().Snapshots(), 1)
+
+	snap := tbl.CurrentSnapshot()
+	assert.Equal(t, table.OpAppend, snap.Summary.Operation)
+	assert.Equal(t, "pool", snap.Summary.Properties["writer"])
+	assert.Equal(t, "8", snap.Summary.Properties["added-records"])
+
+	// files are committed in the order of their writers
+	manifests, err := snap.Manifests(iceio.LocalFS{})
+	require.NoError(t, err)
+	require.Len(t, manifests, 1)
+	entries, err := manifests[0].FetchEntries(iceio.LocalFS{}, true)
+	require.NoError(t, err)
+	require.Len(t, entries, numWriters)
+	for i, e := range entries {
+		assert.Equal(t, files[i].FilePath(), e.DataFile().FilePath())
+	}
+
+	_, err = pool.Commit(ctx, nil)
+	require.ErrorIs(t, err, table.ErrWriterPoolClosed)
+	require.ErrorIs(t, writeIDs(t, writers[0], 100), table.ErrWriterPoolClosed)
+}
+
+func TestWriterPoolAbort(t *testing.T) {
+	ctx := context.Background()
+	tbl := newTestTable(t)
+
+	pool, err := table.NewWriterPool(ctx, tbl)
+	require.NoError(t, err)
+
+	w, err := pool.NewWriter()
+	require.NoError(t, err)
+	require.NoError(t, writeIDs(t, w, 1, 2, 3))
+
+	files := pool.DataFiles()
+	require.Len(t, files, 1)
+	require.FileExists(t, files[0].FilePath())
+
+	require.NoError(t, pool.Abort())
+	assert.NoFileExists(t, files[0].FilePath())
+	assert.Nil(t, tbl.CurrentSnapshot())
+
+	require.ErrorIs(t, writeIDs(t, w, 4), table.ErrWriterPoolClosed)
+	require.ErrorIs(t, pool.Abort(), table.ErrWriterPoolClosed)
+	_, err = pool.NewWriter()
+	require.ErrorIs(t, err, table.ErrWriterPoolClosed)
+}
+
+func TestWriterPoolFailedCommit(t *testing.T) {
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		err  error
+		kept bool
+	}{
+		{err: iceberg.ErrCommitConflict, kept: false},
+		{err: iceberg.ErrCommitStateUnknown, kept: true},
+		{err: context.DeadlineExceeded, kept: true},
+		{err: errors.New("connection reset by peer"), kept: true},
+	} {
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			tbl := newTestTable(t, withTestCatalog(&failingCatalog{err: tc.err}))
+
+			pool, err := table.NewWriterPool(ctx, tbl)
+			require.NoError(t, err)
+
+			w, err := pool.NewWriter()
+			require.NoError(t, err)
+			require.NoError(t, writeIDs(t, w, 1))
+			require.NoError(t, w.Close())
+
+			path := pool.DataFiles()[0].FilePath()
+			_, err = pool.Commit(ctx, nil)
+			require.ErrorIs(t, err, tc.err)
+
+			_, statErr := os.Stat(path)
+			assert.Equal(t, tc.kept, !errors.Is(statErr, os.ErrNotExist))
+		})
+	}
+}
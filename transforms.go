@@ -27,6 +27,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 	"unsafe"
 
 	"github.com/apache/arrow-go/v18/arrow/decimal128"
@@ -90,7 +91,14 @@ type Transform interface {
 	PreservesOrder() bool
 	Equals(Transform) bool
 	Apply(Optional[Literal]) Optional[Literal]
+	// Project returns an inclusive projection of pred onto the partition
+	// field name: every row matching pred is in a partition matching the
+	// projection. It returns nil if pred cannot be projected.
 	Project(name string, pred BoundPredicate) (UnboundPredicate, error)
+	// ProjectStrict returns a strict projection of pred onto the partition
+	// field name: every row in a partition matching the projection matches
+	// pred. It returns nil if pred cannot be projected.
+	ProjectStrict(name string, pred BoundPredicate) (UnboundPredicate, error)
 
 	ToHumanStr(any) string
 }
@@ -161,6 +169,12 @@ func (t IdentityTransform) Project(name string, pred BoundPredicate) (UnboundPre
 	return nil, nil
 }
 
+// ProjectStrict is the same as Project as the identity transform does not
+// lose information.
+func (t IdentityTransform) ProjectStrict(name string, pred BoundPredicate) (UnboundPredicate, error) {
+	return t.Project(name, pred)
+}
+
 // VoidTransform is a transformation that always returns nil.
 type VoidTransform struct{}
 
@@ -190,6 +204,10 @@ func (VoidTransform) Project(string, BoundPredicate) (UnboundPredicate, error) {
 	return nil, nil
 }
 
+func (VoidTransform) ProjectStrict(string, BoundPredicate) (UnboundPredicate, error) {
+	return nil, nil
+}
+
 // BucketTransform transforms values into a bucket partition value. It is
 // parameterized by a number of buckets. Bucket partition transforms use
 // a 32-bit hash of the source value to produce a positive value by mod
@@ -374,6 +392,35 @@ func (t BucketTransform) Project(name string, pred BoundPredicate) (UnboundPredi
 	return nil, nil
 }
 
+// ProjectStrict projects not equal and not in predicates, as rows with a
+// different bucket cannot be equal to the value. Comparisons and equality
+// cannot be projected as values of many buckets may match.
+func (t BucketTransform) ProjectStrict(name string, pred BoundPredicate) (UnboundPredicate, error) {
+	if _, ok := pred.Term().(*BoundTransform); ok {
+		return projectTransformPredicate(t, name, pred)
+	}
+
+	transformer := t.Transformer(pred.Term().Type())
+	switch p := pred.(type) {
+	case BoundUnaryPredicate:
+		return p.AsUnbound(Reference(name)), nil
+	case BoundLiteralPredicate:
+		if p.Op() != OpNEQ {
+			break
+		}
+
+		return p.AsUnbound(Reference(name), transformLiteral(transformer, p.Literal())), nil
+	case BoundSetPredicate:
+		if p.Op() != OpNotIn {
+			break
+		}
+
+		return setApplyTransform(name, p, transformer), nil
+	}
+
+	return nil, nil
+}
+
 // TruncateTransform is a transformation for truncating a value to a specified width.
 type TruncateTransform struct {
 	Width int
@@ -436,7 +483,7 @@ func (t TruncateTransform) Transformer(src Type) (func(any) any, error) {
 		return func(v any) any {
 			switch v := v.(type) {
 			case string:
-				return v[:min(len(v), t.Width)]
+				return truncateString(v, t.Width)
 			case []byte:
 				return v[:min(len(v), t.Width)]
 			default:
@@ -466,6 +513,20 @@ func (t TruncateTransform) Transformer(src Type) (func(any) any, error) {
 		ErrInvalidArgument, src)
 }
 
+// truncateString truncates s to at most width unicode code points, as
+// required by the spec, rather than width bytes.
+func truncateString(s string, width int) string {
+	n := 0
+	for i := range s {
+		if n == width {
+			return s[:i]
+		}
+		n++
+	}
+
+	return s
+}
+
 func (t TruncateTransform) Apply(value Optional[Literal]) (out Optional[Literal]) {
 	if !value.Valid {
 		return out
@@ -505,6 +566,18 @@ func (TruncateTransform) ToHumanStr(val any) string {
 }
 
 func (t TruncateTransform) Project(name string, pred BoundPredicate) (UnboundPredicate, error) {
+	return t.project(name, pred, false)
+}
+
+// ProjectStrict projects comparisons to the truncated boundary with the
+// comparison made exclusive, and not equal and not in predicates to the
+// truncated values. Equality cannot be projected as many values share a
+// truncated value.
+func (t TruncateTransform) ProjectStrict(name string, pred BoundPredicate) (UnboundPredicate, error) {
+	return t.project(name, pred, true)
+}
+
+func (t TruncateTransform) project(name string, pred BoundPredicate, strict bool) (UnboundPredicate, error) {
 	if _, ok := pred.Term().(*BoundTransform); ok {
 		return projectTransformPredicate(t, name, pred)
 	}
@@ -516,11 +589,16 @@ func (t TruncateTransform) Project(name string, pred BoundPredicate) (UnboundPre
 		return nil, err
 	}
 
+	setOp := OpIn
+	if strict {
+		setOp = OpNotIn
+	}
+
 	switch p := pred.(type) {
 	case BoundUnaryPredicate:
 		return p.AsUnbound(Reference(name)), nil
 	case BoundSetPredicate:
-		if p.Op() != OpIn {
+		if p.Op() != setOp {
 			break
 		}
 
@@ -537,18 +615,57 @@ func (t TruncateTransform) Project(name string, pred BoundPredicate) (UnboundPre
 			return setApplyTransform(name, p, wrapTransformFn[[]byte](transformer)), nil
 		}
 	case BoundLiteralPredicate:
+		if p.Op() == OpStartsWith || p.Op() == OpNotStartsWith {
+			return t.projectStartsWith(name, p, strict)
+		}
+
 		switch fieldType.(type) {
 		case Int32Type:
-			return truncateNumber(name, p, wrapTransformFn[int32](transformer))
+			return projectNumber(name, p, wrapTransformFn[int32](transformer), strict)
 		case Int64Type:
-			return truncateNumber(name, p, wrapTransformFn[int64](transformer))
+			return projectNumber(name, p, wrapTransformFn[int64](transformer), strict)
 		case DecimalType:
-			return truncateNumber(name, p, wrapTransformFn[Decimal](transformer))
+			return projectNumber(name, p, wrapTransformFn[Decimal](transformer), strict)
 		case StringType:
-			return truncateArray(name, p, wrapTransformFn[string](transformer))
+			return projectArray(name, p, wrapTransformFn[string](transformer), strict)
 		case BinaryType:
-			return truncateArray(name, p, wrapTransformFn[[]byte](transformer))
+			return projectArray(name, p, wrapTransformFn[[]byte](transformer), strict)
+		}
+	}
+
+	return nil, nil
+}
+
+// projectStartsWith projects starts with and not starts with predicates.
+// A prefix shorter than the width is unchanged by truncation and a prefix
+// of exactly the width is the whole partition value. A longer prefix is
+// truncated for an inclusive starts with or a strict not starts with and
+// cannot be projected otherwise, as partitions matching the truncated
+// prefix may hold values both matching and not matching it.
+func (t TruncateTransform) projectStartsWith(name string, pred BoundLiteralPredicate, strict bool) (UnboundPredicate, error) {
+	var length int
+	switch lit := pred.Literal().(type) {
+	case StringLiteral:
+		length = utf8.RuneCountInString(string(lit))
+	case BinaryLiteral:
+		length = len(lit)
+	default:
+		return nil, nil
+	}
+
+	switch {
+	case length < t.Width:
+		return pred.AsUnbound(Reference(name), pred.Literal()), nil
+	case length == t.Width:
+		if pred.Op() == OpStartsWith {
+			return LiteralPredicate(OpEQ, Reference(name), pred.Literal()), nil
 		}
+
+		return LiteralPredicate(OpNEQ, Reference(name), pred.Literal()), nil
+	case strict == (pred.Op() == OpNotStartsWith):
+		truncated := t.Apply(Optional[Literal]{Valid: true, Val: pred.Literal()})
+
+		return pred.AsUnbound(Reference(name), truncated.Val), nil
 	}
 
 	return nil, nil
@@ -596,6 +713,32 @@ func projectTimeTransform(t TimeTransform, name string, pred BoundPredicate) (Un
 	return nil, nil
 }
 
+func projectTimeTransformStrict(t TimeTransform, name string, pred BoundPredicate) (UnboundPredicate, error) {
+	if _, ok := pred.Term().(*BoundTransform); ok {
+		return projectTransformPredicate(t, name, pred)
+	}
+
+	transformer, err := t.Transformer(pred.Term().Ref().Type())
+	if err != nil {
+		return nil, err
+	}
+
+	switch p := pred.(type) {
+	case BoundUnaryPredicate:
+		return p.AsUnbound(Reference(name)), nil
+	case BoundLiteralPredicate:
+		return truncateNumberStrict(name, p, transformer)
+	case BoundSetPredicate:
+		if p.Op() != OpNotIn {
+			break
+		}
+
+		return setApplyTransform(name, p, transformer), nil
+	}
+
+	return nil, nil
+}
+
 // YearTransform transforms a datetime value into a year value.
 type YearTransform struct{}
 
@@ -675,6 +818,10 @@ func (t YearTransform) Project(name string, pred BoundPredicate) (UnboundPredica
 	return projectTimeTransform(t, name, pred)
 }
 
+func (t YearTransform) ProjectStrict(name string, pred BoundPredicate) (UnboundPredicate, error) {
+	return projectTimeTransformStrict(t, name, pred)
+}
+
 // MonthTransform transforms a datetime value into a month value.
 type MonthTransform struct{}
 
@@ -765,6 +912,10 @@ func (t MonthTransform) Project(name string, pred BoundPredicate) (UnboundPredic
 	return projectTimeTransform(t, name, pred)
 }
 
+func (t MonthTransform) ProjectStrict(name string, pred BoundPredicate) (UnboundPredicate, error) {
+	return projectTimeTransformStrict(t, name, pred)
+}
+
 // DayTransform transforms a datetime value into a date value.
 type DayTransform struct{}
 
@@ -844,6 +995,10 @@ func (t DayTransform) Project(name string, pred BoundPredicate) (UnboundPredicat
 	return projectTimeTransform(t, name, pred)
 }
 
+func (t DayTransform) ProjectStrict(name string, pred BoundPredicate) (UnboundPredicate, error) {
+	return projectTimeTransformStrict(t, name, pred)
+}
+
 // HourTransform transforms a datetime value into an hour value.
 type HourTransform struct{}
 
@@ -920,6 +1075,10 @@ func (t HourTransform) Project(name string, pred BoundPredicate) (UnboundPredica
 	return projectTimeTransform(t, name, pred)
 }
 
+func (t HourTransform) ProjectStrict(name string, pred BoundPredicate) (UnboundPredicate, error) {
+	return projectTimeTransformStrict(t, name, pred)
+}
+
 func removeTransform(partName string, pred BoundPredicate) (UnboundPredicate, error) {
 	switch p := pred.(type) {
 	case BoundUnaryPredicate:
@@ -1029,17 +1188,77 @@ func truncateArray[T LiteralType](name string, pred BoundLiteralPredicate, fn fu
 	case OpEQ:
 		return LiteralPredicate(OpEQ, Reference(name),
 			transformLiteral(fn, boundary)), nil
-	case OpStartsWith:
-		return LiteralPredicate(OpStartsWith, Reference(name),
+	}
+
+	return nil, nil
+}
+
+// truncateNumberStrict projects a comparison onto a partition value
+// computed by an order preserving fn so that every value in a matching
+// partition satisfies the comparison. Equality cannot be projected as
+// adjacent values share a partition.
+func truncateNumberStrict[T LiteralType](name string, pred BoundLiteralPredicate, fn func(any) Optional[T]) (UnboundPredicate, error) {
+	boundary, ok := pred.Literal().(NumericLiteral)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected numeric literal, got %s",
+			ErrInvalidArgument, pred.Literal().Type())
+	}
+
+	switch pred.Op() {
+	case OpLT:
+		return LiteralPredicate(OpLT, Reference(name),
+			transformLiteral(fn, boundary)), nil
+	case OpLTEQ:
+		return LiteralPredicate(OpLT, Reference(name),
+			transformLiteral(fn, boundary.Increment())), nil
+	case OpGT:
+		return LiteralPredicate(OpGT, Reference(name),
+			transformLiteral(fn, boundary)), nil
+	case OpGTEQ:
+		return LiteralPredicate(OpGT, Reference(name),
+			transformLiteral(fn, boundary.Decrement())), nil
+	case OpNEQ:
+		return LiteralPredicate(OpNEQ, Reference(name),
+			transformLiteral(fn, boundary)), nil
+	}
+
+	return nil, nil
+}
+
+func truncateArrayStrict[T LiteralType](name string, pred BoundLiteralPredicate, fn func(any) Optional[T]) (UnboundPredicate, error) {
+	boundary := pred.Literal()
+
+	switch pred.Op() {
+	case OpLT, OpLTEQ:
+		return LiteralPredicate(OpLT, Reference(name),
+			transformLiteral(fn, boundary)), nil
+	case OpGT, OpGTEQ:
+		return LiteralPredicate(OpGT, Reference(name),
 			transformLiteral(fn, boundary)), nil
-	case OpNotStartsWith:
-		return LiteralPredicate(OpNotStartsWith, Reference(name),
+	case OpNEQ:
+		return LiteralPredicate(OpNEQ, Reference(name),
 			transformLiteral(fn, boundary)), nil
 	}
 
 	return nil, nil
 }
 
+func projectNumber[T LiteralType](name string, pred BoundLiteralPredicate, fn func(any) Optional[T], strict bool) (UnboundPredicate, error) {
+	if strict {
+		return truncateNumberStrict(name, pred, fn)
+	}
+
+	return truncateNumber(name, pred, fn)
+}
+
+func projectArray[T LiteralType](name string, pred BoundLiteralPredicate, fn func(any) Optional[T], strict bool) (UnboundPredicate, error) {
+	if strict {
+		return truncateArrayStrict(name, pred, fn)
+	}
+
+	return truncateArray(name, pred, fn)
+}
+
 func setApplyTransform[T LiteralType](name string, pred BoundSetPredicate, fn func(any) Optional[T]) UnboundPredicate {
 	lits := pred.Literals().Members()
 	for i, l := range lits {
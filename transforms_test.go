@@ -20,6 +20,7 @@ package iceberg_test
 import (
 	"bytes"
 	"fmt"
+	"math"
 	"reflect"
 	"strings"
 	"testing"
@@ -28,6 +29,7 @@ import (
 	"github.com/apache/arrow-go/v18/arrow/decimal"
 	"github.com/apache/arrow-go/v18/arrow/decimal128"
 	"github.com/apache/iceberg-go"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -383,6 +385,11 @@ func TestTruncateTransform(t *testing.T) {
 			Scale: 2,
 		}},
 		{3, iceberg.StringLiteral("abcdef"), iceberg.StringLiteral("abc")},
+		{10, iceberg.StringLiteral("iceberg"), iceberg.StringLiteral("iceberg")},
+		// strings are truncated to code points, not bytes
+		{3, iceberg.StringLiteral("イロハニホヘト"), iceberg.StringLiteral("イロハ")},
+		{2, iceberg.StringLiteral("😀😁😂"), iceberg.StringLiteral("😀😁")},
+		{1, iceberg.StringLiteral("ßa"), iceberg.StringLiteral("ß")},
 		{
 			3, iceberg.BinaryLiteral([]byte{0x01, 0x02, 0x03, 0x04, 0x05}),
 			iceberg.BinaryLiteral([]byte{0x01, 0x02, 0x03}),
@@ -398,3 +405,35 @@ func TestTruncateTransform(t *testing.T) {
 		})
 	}
 }
+
+func TestBucketTransformSpecVectors(t *testing.T) {
+	// hash values from the reference implementation, see appendix B of the
+	// Iceberg table spec
+	tests := []struct {
+		name  string
+		value iceberg.Literal
+		hash  int32
+	}{
+		{"int", iceberg.Int32Literal(34), 2017239379},
+		{"long", iceberg.Int64Literal(34), 2017239379},
+		{"decimal", iceberg.DecimalLiteral{Val: decimal128.FromI64(1420), Scale: 2}, -500754589},
+		{"date", iceberg.DateLiteral(17486), -653330422},
+		{"time", iceberg.TimeLiteral(81068000000), -662762989},
+		{"timestamp", iceberg.TimestampLiteral(1510871468000000), -2047944441},
+		{"string", iceberg.StringLiteral("iceberg"), 1210000089},
+		{"uuid", iceberg.UUIDLiteral(uuid.MustParse("f79c3e09-677c-4bbd-a479-3f349cb785e7")), 1488055340},
+		{"fixed", iceberg.FixedLiteral([]byte{0, 1, 2, 3}), -188683207},
+		{"binary", iceberg.BinaryLiteral([]byte{0, 1, 2, 3}), -188683207},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, n := range []int{1, 16, 100, math.MaxInt32} {
+				result := iceberg.BucketTransform{NumBuckets: n}.Apply(
+					iceberg.Optional[iceberg.Literal]{Val: tt.value, Valid: true})
+				require.True(t, result.Valid)
+				assert.Equal(t, iceberg.Int32Literal((tt.hash&math.MaxInt32)%int32(n)), result.Val)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ package iceberg
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"regexp"
 	"slices"
 	"strconv"
@@ -190,6 +191,11 @@ type NestedField struct {
 	Doc            string `json:"doc,omitempty"`
 	InitialDefault any    `json:"initial-default,omitempty"`
 	WriteDefault   any    `json:"write-default,omitempty"`
+	// Metadata holds arbitrary key/value metadata of the field, such as
+	// ownership or classification tags. It is persisted in the schema JSON
+	// as an extension that other implementations ignore and is carried
+	// into the Avro and Arrow schemas converted from the schema.
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 func optOrReq(required bool) string {
@@ -217,6 +223,7 @@ func (n *NestedField) Equals(other NestedField) bool {
 		n.Doc == other.Doc &&
 		n.InitialDefault == other.InitialDefault &&
 		n.WriteDefault == other.WriteDefault &&
+		maps.Equal(n.Metadata, other.Metadata) &&
 		n.Type.Equals(other.Type)
 }
 